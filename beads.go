@@ -9,6 +9,7 @@ package beads
 
 import (
 	"context"
+	"time"
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/configfile"
@@ -77,6 +78,58 @@ type UpdateIssueOptions = storage.UpdateIssueOptions
 // name it without importing internal/storage.
 type CommentPageCursor = storage.CommentPageCursor
 
+// Clock supplies the current time to storage operations (timestamps, defer,
+// overdue, ready-work recency). Give one to a store with WithStoreClock.
+type Clock = storage.Clock
+
+// IDGenerator mints hash-mode issue IDs in place of the content hash. Give
+// one to a store with WithStoreIDGenerator.
+type IDGenerator = storage.IDGenerator
+
+// FakeClock is a Clock that only moves when Set or Advance is called.
+type FakeClock = storage.FakeClock
+
+// SequentialIDGenerator mints prefix-1, prefix-2, ... regardless of content.
+type SequentialIDGenerator = storage.SequentialIDGenerator
+
+// OpenOption configures a store opened with Open or OpenFromConfig.
+type OpenOption func(*dolt.Config)
+
+// WithStoreClock makes the store read time from c in every operation, so
+// tests and simulations can control defer, SLA, and overdue behavior.
+func WithStoreClock(c Clock) OpenOption {
+	return func(cfg *dolt.Config) { cfg.Clock = c }
+}
+
+// WithStoreIDGenerator makes the store mint hash-mode issue IDs with g,
+// producing reproducible IDs across runs.
+func WithStoreIDGenerator(g IDGenerator) OpenOption {
+	return func(cfg *dolt.Config) { cfg.IDGenerator = g }
+}
+
+// WithClock returns a context whose storage operations read time from c,
+// overriding the store's clock for calls made with it.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return storage.WithClock(ctx, c)
+}
+
+// WithIDGenerator returns a context whose issue creations mint IDs with g,
+// overriding the store's generator for calls made with it.
+func WithIDGenerator(ctx context.Context, g IDGenerator) context.Context {
+	return storage.WithIDGenerator(ctx, g)
+}
+
+// NewFakeClock returns a FakeClock frozen at t.
+func NewFakeClock(t time.Time) *FakeClock {
+	return storage.NewFakeClock(t)
+}
+
+// NewSequentialIDGenerator returns an IDGenerator whose first ID for every
+// prefix is prefix-1.
+func NewSequentialIDGenerator() *SequentialIDGenerator {
+	return storage.NewSequentialIDGenerator()
+}
+
 // RemoteStore provides dolt remote management and replication operations.
 // Use type assertion on a Storage value to access these methods:
 //
@@ -251,16 +304,24 @@ type (
 // Open opens a Dolt-backed beads database at the given path.
 // This always opens in embedded mode. Use OpenFromConfig to respect
 // server mode settings from metadata.json.
-func Open(ctx context.Context, dbPath string) (Storage, error) {
-	return dolt.New(ctx, &dolt.Config{Path: dbPath, CreateIfMissing: true})
+func Open(ctx context.Context, dbPath string, opts ...OpenOption) (Storage, error) {
+	cfg := &dolt.Config{Path: dbPath, CreateIfMissing: true}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return dolt.New(ctx, cfg)
 }
 
 // OpenFromConfig opens the Dolt implementation using configuration from
 // metadata.json. Unlike Open, this respects Dolt server mode settings and database
 // name configuration.
 // beadsDir is the path to the .beads directory.
-func OpenFromConfig(ctx context.Context, beadsDir string) (Storage, error) {
-	return dolt.NewFromConfigWithOptions(ctx, beadsDir, &dolt.Config{CreateIfMissing: true})
+func OpenFromConfig(ctx context.Context, beadsDir string, opts ...OpenOption) (Storage, error) {
+	cfg := &dolt.Config{CreateIfMissing: true}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return dolt.NewFromConfigWithOptions(ctx, beadsDir, cfg)
 }
 
 // FindDatabasePath finds the beads database in the current directory tree
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var acCmd = &cobra.Command{
+	Use:     "ac",
+	GroupID: "issues",
+	Short:   "Check off acceptance criteria items",
+	Long: `Work with an issue's acceptance criteria as a checklist.
+
+Lines written as markdown task items ("- [ ] ...") are checkable; they are
+numbered from 1 in order, and any other lines are prose. Ticking an item
+rewrites only its box, and --evidence records a link (a PR, CI run, or
+screenshot) in metadata.acceptance_evidence along with who ticked it.
+
+'bd close' checks the list according to validation.acceptance in
+config.yaml: "warn" (default) reports unchecked items, "error" refuses the
+close, "none" disables the check. Closes whose reason is duplicate,
+wontfix, obsolete, or cannot-reproduce are not checked, and --force skips
+the check.
+
+Examples:
+  bd ac list bd-42
+  bd ac tick bd-42 2 --evidence https://github.com/org/repo/pull/17
+  bd ac tick bd-42 1 3
+  bd ac untick bd-42 2`,
+}
+
+var acListCmd = &cobra.Command{
+	Use:           "list <id>",
+	Short:         "List an issue's acceptance criteria items",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("ac list is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("ac list")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			return HandleErrorRespectJSON("issue %s not found: %v", id, err)
+		}
+		items := types.ParseAcceptanceItems(issue.AcceptanceCriteria)
+		evidence, err := types.AcceptanceEvidenceFromMetadata(issue.Metadata)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		SetLastTouchedID(id)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"id":       id,
+				"items":    items,
+				"checked":  len(items) - len(types.UncheckedAcceptanceItems(items)),
+				"total":    len(items),
+				"evidence": evidence,
+			})
+		}
+		if len(items) == 0 {
+			fmt.Printf("%s has no checkable acceptance criteria (write them as \"- [ ] ...\" lines)\n", id)
+			return nil
+		}
+		fmt.Printf("%s %s\n\n", ui.RenderBold("ACCEPTANCE CRITERIA"), formatAcceptanceProgress(items))
+		for _, item := range items {
+			box := "[ ]"
+			if item.Checked {
+				box = ui.RenderPass("[x]")
+			}
+			fmt.Printf("  %2d. %s %s\n", item.Number, box, item.Text)
+			for _, e := range latestAcceptanceEvidence(evidence, item) {
+				fmt.Printf("        %s\n", ui.RenderMuted(fmt.Sprintf("%s · %s · %s", e.URL, e.Actor, e.RecordedAt.Local().Format("2006-01-02 15:04"))))
+			}
+		}
+		return nil
+	},
+}
+
+var acTickCmd = &cobra.Command{
+	Use:           "tick <id> <item>...",
+	Short:         "Check acceptance criteria items, optionally recording evidence",
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAcSet(cmd, args, true)
+	},
+}
+
+var acUntickCmd = &cobra.Command{
+	Use:           "untick <id> <item>...",
+	Short:         "Uncheck acceptance criteria items",
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAcSet(cmd, args, false)
+	},
+}
+
+// runAcSet checks or unchecks the numbered items in args[1:] on issue
+// args[0], appending an evidence record per ticked item when --evidence is
+// given.
+func runAcSet(cmd *cobra.Command, args []string, checked bool) error {
+	name := "ac untick"
+	if checked {
+		name = "ac tick"
+	}
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("%s is not supported in proxied-server mode", name)
+	}
+	CheckReadonly(name)
+	evt := metrics.NewCommandEvent(name)
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("database not available: %v", err)
+	}
+	ctx := rootCtx
+
+	var url string
+	if checked {
+		url, _ = cmd.Flags().GetString("evidence")
+	}
+	numbers := make([]int, 0, len(args)-1)
+	for _, a := range args[1:] {
+		n, err := strconv.Atoi(a)
+		if err != nil {
+			return HandleErrorRespectJSON("invalid item number %q", a)
+		}
+		numbers = append(numbers, n)
+	}
+
+	id, err := utils.ResolvePartialID(ctx, store, args[0])
+	if err != nil {
+		return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+	}
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return HandleErrorRespectJSON("issue %s not found: %v", id, err)
+	}
+
+	criteria := issue.AcceptanceCriteria
+	var changed []types.AcceptanceItem
+	for _, n := range numbers {
+		var item types.AcceptanceItem
+		criteria, item, err = types.SetAcceptanceItem(criteria, n, checked)
+		if err != nil {
+			return HandleErrorRespectJSON("%s: %v", id, err)
+		}
+		changed = append(changed, item)
+	}
+	if criteria != issue.AcceptanceCriteria {
+		if err := store.UpdateIssue(ctx, id, map[string]interface{}{"acceptance_criteria": criteria}, actor); err != nil {
+			return HandleErrorRespectJSON("updating acceptance criteria: %v", err)
+		}
+	}
+
+	if url != "" {
+		evidence, err := types.AcceptanceEvidenceFromMetadata(issue.Metadata)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		now := time.Now().UTC()
+		for _, item := range changed {
+			evidence = append(evidence, types.AcceptanceEvidence{
+				Item:       item.Number,
+				Text:       item.Text,
+				URL:        url,
+				Actor:      actor,
+				RecordedAt: now,
+			})
+		}
+		raw, err := json.Marshal(evidence)
+		if err != nil {
+			return HandleErrorRespectJSON("encoding evidence: %v", err)
+		}
+		if err := store.MergeMetadata(ctx, id, types.AcceptanceEvidenceMetadataKey, raw, actor); err != nil {
+			return HandleErrorRespectJSON("saving evidence: %v", err)
+		}
+	}
+	commandDidWrite.Store(true)
+	SetLastTouchedID(id)
+
+	items := types.ParseAcceptanceItems(criteria)
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"id":      id,
+			"changed": changed,
+			"checked": len(items) - len(types.UncheckedAcceptanceItems(items)),
+			"total":   len(items),
+		})
+	}
+	verb := "Unchecked"
+	if checked {
+		verb = "Checked"
+	}
+	for _, item := range changed {
+		fmt.Printf("%s %s %s item %d: %s\n", ui.RenderPass("✓"), verb, id, item.Number, item.Text)
+	}
+	fmt.Printf("  %s\n", formatAcceptanceProgress(items))
+	return nil
+}
+
+// latestAcceptanceEvidence returns the evidence recorded for item, matched
+// by its text so records survive renumbering.
+func latestAcceptanceEvidence(evidence []types.AcceptanceEvidence, item types.AcceptanceItem) []types.AcceptanceEvidence {
+	var out []types.AcceptanceEvidence
+	for _, e := range evidence {
+		if e.Text == item.Text && e.URL != "" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// formatAcceptanceHeading is bd show's ACCEPTANCE CRITERIA heading, with
+// the checklist progress when the criteria have checkable items.
+func formatAcceptanceHeading(issue *types.Issue) string {
+	heading := ui.RenderBold("ACCEPTANCE CRITERIA")
+	if items := types.ParseAcceptanceItems(issue.AcceptanceCriteria); len(items) > 0 {
+		heading += " " + ui.RenderMuted(formatAcceptanceProgress(items))
+	}
+	return heading
+}
+
+// formatAcceptanceProgress renders "(2/3 checked)".
+func formatAcceptanceProgress(items []types.AcceptanceItem) string {
+	done := len(items) - len(types.UncheckedAcceptanceItems(items))
+	return fmt.Sprintf("(%d/%d checked)", done, len(items))
+}
+
+// acceptanceMode returns validation.acceptance: "error" refuses closes with
+// unchecked acceptance criteria items, "none" disables the check, and
+// anything else (the default) warns.
+func acceptanceMode() string {
+	switch mode := config.GetString("validation.acceptance"); mode {
+	case "error", "none":
+		return mode
+	default:
+		return "warn"
+	}
+}
+
+// checkAcceptanceCriteria applies validation.acceptance to a close. Closes
+// that don't claim the work was done (duplicate, wontfix, obsolete,
+// cannot-reproduce) are not checked.
+func checkAcceptanceCriteria(issue *types.Issue, reason string) error {
+	if issue == nil {
+		return nil
+	}
+	mode := acceptanceMode()
+	if mode == "none" {
+		return nil
+	}
+	switch types.CloseReasonCategory(reason, closeReasonCategories()) {
+	case types.CloseReasonDuplicate, types.CloseReasonWontFix, types.CloseReasonObsolete, types.CloseReasonCannotReproduce:
+		return nil
+	}
+	unchecked := types.UncheckedAcceptanceItems(types.ParseAcceptanceItems(issue.AcceptanceCriteria))
+	if len(unchecked) == 0 {
+		return nil
+	}
+	nums := make([]string, len(unchecked))
+	for i, item := range unchecked {
+		nums[i] = strconv.Itoa(item.Number)
+	}
+	err := fmt.Errorf("%d acceptance criteria item(s) unchecked (#%s); tick them with bd ac tick %s <n>",
+		len(unchecked), strings.Join(nums, ", #"), issue.ID)
+	if mode == "error" {
+		return fmt.Errorf("%w, or use --force", err)
+	}
+	fmt.Fprintf(os.Stderr, "%s %s: %v\n", ui.RenderWarn("⚠"), issue.ID, err)
+	return nil
+}
+
+func init() {
+	acTickCmd.Flags().String("evidence", "", "Link backing the ticked items (PR, CI run, screenshot)")
+	acCmd.AddCommand(acListCmd, acTickCmd, acUntickCmd)
+	rootCmd.AddCommand(acCmd)
+}
@@ -5,22 +5,47 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/steveyegge/beads/internal/assign"
 	"github.com/steveyegge/beads/internal/metrics"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
 var assignCmd = &cobra.Command{
-	Use:     "assign <id> <name>",
+	Use:     "assign <id> <name> | --auto [<id>...]",
 	GroupID: "issues",
 	Short:   "Assign an issue to someone",
 	Long: `Assign an issue to someone.
 
 Shorthand for 'bd update <id> --assignee <name>'.
 
+With --auto, bd picks the assignee using the team and strategy in
+config.yaml, and explains each decision. Without IDs it assigns unassigned
+ready work; run it from a scheduler or hook to hand out new work as it
+becomes ready. Each decision is recorded in metadata.auto_assignment.
+
+  assign:
+    team: "alice:2,bob,carol"   # name[:weight]; weight is relative capacity
+    strategy: weighted          # round-robin (default), weighted, sticky-label
+    sticky:                     # sticky-label: pin labels to members
+      frontend: carol
+    fallback: weighted          # sticky-label when no label is sticky
+
+Strategies:
+  round-robin   Rotate through the team; the rotation is shared by all clones
+  weighted      Lowest active (open/in_progress) load relative to weight
+  sticky-label  A pinned label's member, else whoever holds the most active
+                issues with one of the labels, else the fallback strategy
+
+Members who are out of office (see bd ooo) are never picked: their picks go
+to their delegate, and members away with no delegate are passed over.
+Assigning to an away member by name also goes to their delegate.
+
 Examples:
   bd assign bd-123 alice
-  bd assign bd-123 ""      # unassign`,
-	Args:          cobra.ExactArgs(2),
+  bd assign bd-123 ""      # unassign
+  bd assign --auto --dry-run
+  bd assign --auto bd-123 --strategy weighted`,
+	Args:          assignArgs,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -33,6 +58,12 @@ Examples:
 			}
 		}()
 
+		if auto, _ := cmd.Flags().GetBool("auto"); auto {
+			if usesProxiedServer() {
+				return HandleErrorRespectJSON("assign --auto is not supported in proxied-server mode")
+			}
+			return runAutoAssign(cmd, args)
+		}
 		if usesProxiedServer() {
 			return runAssignProxiedServer(rootCtx, args)
 		}
@@ -63,12 +94,21 @@ Examples:
 			return HandleErrorRespectJSON("%s", err)
 		}
 
+		oooFrom := ""
+		if to, redirected := redirectForOOO(ctx, issueStore, assignee); redirected {
+			oooFrom, assignee = assignee, to
+		}
 		updates := map[string]interface{}{
 			"assignee": assignee,
 		}
 		if err := issueStore.UpdateIssue(ctx, result.ResolvedID, updates, actor); err != nil {
 			return HandleErrorRespectJSON("updating %s: %v", id, err)
 		}
+		if oooFrom != "" {
+			if err := recordOOORedirect(ctx, issueStore, oooRedirect{Person: oooFrom, Delegate: assignee, Issue: result.ResolvedID, Via: "assign"}); err != nil {
+				WarnError("%v", err)
+			}
+		}
 
 		if err := commitPendingIfEmbedded(ctx, issueStore, actor, doltAutoCommitParams{
 			Command:  "assign",
@@ -102,6 +142,10 @@ Examples:
 }
 
 func init() {
+	assignCmd.Flags().Bool("auto", false, "Pick the assignee with the configured strategy")
+	assignCmd.Flags().String("strategy", "", "Override assign.strategy ("+assign.StrategyNames()+")")
+	assignCmd.Flags().Bool("dry-run", false, "With --auto, show decisions without assigning")
+	assignCmd.Flags().IntP("limit", "n", 10, "With --auto and no IDs, assign at most this many ready issues (0 for all)")
 	assignCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(assignCmd)
 }
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/assign"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Storage keys for auto-assignment. The rotation cursor lives in database
+// metadata so every clone continues the same round-robin.
+const (
+	assignRoundRobinKey       = "assign.round_robin_last"
+	autoAssignmentMetadataKey = "auto_assignment"
+)
+
+// autoAssignment is one 'bd assign --auto' decision, also recorded in the
+// issue's metadata.auto_assignment.
+type autoAssignment struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	assign.Decision
+	At      time.Time `json:"at"`
+	Applied bool      `json:"applied"` // False for --dry-run
+}
+
+// autoAssignConfig builds the assignment policy from config.yaml, with
+// strategy overriding assign.strategy when set.
+func autoAssignConfig(strategy string) (assign.Config, error) {
+	team, err := assign.ParseTeam(config.GetString("assign.team"))
+	if err != nil {
+		return assign.Config{}, fmt.Errorf("assign.team: %w", err)
+	}
+	if strategy == "" {
+		strategy = config.GetString("assign.strategy")
+	}
+	if strategy == "" {
+		strategy = string(assign.RoundRobin)
+	}
+	cfg := assign.Config{
+		Strategy: assign.Strategy(strategy),
+		Team:     team,
+		Sticky:   config.GetStringMapString("assign.sticky"),
+		Fallback: assign.Strategy(config.GetString("assign.fallback")),
+	}
+	if err := cfg.Validate(); err != nil {
+		return assign.Config{}, fmt.Errorf("%w (see bd assign --help)", err)
+	}
+	return cfg, nil
+}
+
+// autoAssignState loads the team's current load, per-label load, and the
+// round-robin cursor.
+func autoAssignState(ctx context.Context, s storage.DoltStorage, cfg assign.Config) (*assign.State, error) {
+	active, err := s.SearchIssues(ctx, "", types.IssueFilter{
+		Statuses: []types.Status{types.StatusOpen, types.StatusInProgress},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading active issues: %w", err)
+	}
+	onTeam := make(map[string]bool, len(cfg.Team))
+	for _, m := range cfg.Team {
+		onTeam[m.Name] = true
+	}
+	st := &assign.State{Load: map[string]int{}, LabelLoad: map[string]map[string]int{}}
+	var ids []string
+	owner := make(map[string]string)
+	for _, issue := range active {
+		if onTeam[issue.Assignee] {
+			st.Load[issue.Assignee]++
+			ids = append(ids, issue.ID)
+			owner[issue.ID] = issue.Assignee
+		}
+	}
+	if len(ids) > 0 {
+		labels, err := s.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("loading labels: %w", err)
+		}
+		for id, issueLabels := range labels {
+			for _, label := range issueLabels {
+				if st.LabelLoad[label] == nil {
+					st.LabelLoad[label] = map[string]int{}
+				}
+				st.LabelLoad[label][owner[id]]++
+			}
+		}
+	}
+	if st.Last, err = s.GetMetadata(ctx, assignRoundRobinKey); err != nil {
+		return nil, fmt.Errorf("loading round-robin cursor: %w", err)
+	}
+	return st, nil
+}
+
+// runAutoAssign assigns the given issues, or unassigned ready work when no
+// IDs are given, according to the configured strategy.
+func runAutoAssign(cmd *cobra.Command, args []string) error {
+	strategy, _ := cmd.Flags().GetString("strategy")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	cfg, err := autoAssignConfig(strategy)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	ctx := rootCtx
+	records, err := loadOOO(ctx, store)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	cfg.Away = oooAwayMap(records, time.Now())
+
+	var issues []*types.Issue
+	if len(args) == 0 {
+		issues, err = store.GetReadyWork(ctx, types.WorkFilter{
+			Status:     types.StatusOpen,
+			Unassigned: true,
+			Limit:      limit,
+			SortPolicy: types.SortPolicyPriority,
+		})
+		if err != nil {
+			return HandleErrorRespectJSON("loading ready work: %v", err)
+		}
+	}
+	for _, arg := range args {
+		id, err := utils.ResolvePartialID(ctx, store, arg)
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", arg, err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil || issue == nil {
+			return HandleErrorRespectJSON("issue %s not found", id)
+		}
+		if err := validateIssueUpdatable(id, issue); err != nil {
+			return HandleErrorRespectJSON("%s", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	st, err := autoAssignState(ctx, store, cfg)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	labels := map[string][]string{}
+	if len(issues) > 0 {
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		if labels, err = store.GetLabelsForIssues(ctx, ids); err != nil {
+			return HandleErrorRespectJSON("loading labels: %v", err)
+		}
+	}
+
+	lastBefore := st.Last
+	now := time.Now().UTC()
+	results := make([]autoAssignment, 0, len(issues))
+	var assigned []string
+	for _, issue := range issues {
+		d, err := assign.Pick(cfg, labels[issue.ID], st)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		a := autoAssignment{ID: issue.ID, Title: issue.Title, Decision: d, At: now, Applied: !dryRun}
+		if !dryRun {
+			if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"assignee": d.Assignee}, actor); err != nil {
+				return HandleErrorRespectJSON("assigning %s: %v", issue.ID, err)
+			}
+			raw, err := json.Marshal(a)
+			if err != nil {
+				return HandleErrorRespectJSON("encoding assignment: %v", err)
+			}
+			if err := store.MergeMetadata(ctx, issue.ID, autoAssignmentMetadataKey, raw, actor); err != nil {
+				return HandleErrorRespectJSON("recording assignment of %s: %v", issue.ID, err)
+			}
+			if d.RedirectedFrom != "" {
+				if err := recordOOORedirect(ctx, store, oooRedirect{Person: d.RedirectedFrom, Delegate: d.Assignee, Issue: issue.ID, Via: "auto-assign"}); err != nil {
+					return HandleErrorRespectJSON("%v", err)
+				}
+			}
+			assigned = append(assigned, issue.ID)
+		}
+		results = append(results, a)
+	}
+
+	if len(assigned) > 0 {
+		if st.Last != lastBefore {
+			if err := store.SetMetadata(ctx, assignRoundRobinKey, st.Last); err != nil {
+				return HandleErrorRespectJSON("saving round-robin cursor: %v", err)
+			}
+		}
+		if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{
+			Command:  "assign",
+			IssueIDs: assigned,
+		}); err != nil {
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(assigned[len(assigned)-1])
+	}
+
+	if jsonOutput {
+		return outputJSON(results)
+	}
+	if len(results) == 0 {
+		fmt.Printf("%s No unassigned ready work\n", ui.RenderWarn("○"))
+		return nil
+	}
+	verb := "Assigned"
+	if dryRun {
+		verb = "Would assign"
+	}
+	for _, a := range results {
+		fmt.Printf("%s %s %s to %s\n", ui.RenderPass("✓"), verb, formatFeedbackID(a.ID, a.Title), a.Assignee)
+		fmt.Printf("  %s\n", ui.RenderMuted(fmt.Sprintf("%s: %s", a.Strategy, a.Reason)))
+	}
+	return nil
+}
+
+// assignArgs validates positional args: any number of IDs with --auto,
+// otherwise exactly an ID and a name.
+func assignArgs(cmd *cobra.Command, args []string) error {
+	if auto, _ := cmd.Flags().GetBool("auto"); auto {
+		return nil
+	}
+	if len(args) != 2 {
+		return fmt.Errorf("accepts 2 arg(s) (<id> <name>), received %d; use --auto to pick the assignee", len(args))
+	}
+	return nil
+}
@@ -3,12 +3,14 @@
 package main
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
 
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -356,3 +358,114 @@ func TestEmbeddedRoutedMutatingSiblingWritesCommitTargetHead(t *testing.T) {
 		}
 	})
 }
+
+func TestEmbeddedBatchAutoCommitCoalescesAtMaxOps(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt auto-commit tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "bco")
+	out := bdCommand(t, bd, dir, "config", "set", "commit.batch_max_ops", "3")
+	if strings.Contains(out, "not a recognized config key") {
+		t.Fatalf("bd config set commit.batch_max_ops warned about an unrecognized key:\n%s", out)
+	}
+	before := embeddedCurrentCommit(t, beadsDir, "bco")
+
+	bdCommand(t, bd, dir, "--dolt-auto-commit", "batch", "create", "Batched one")
+	bdCommand(t, bd, dir, "--dolt-auto-commit", "batch", "create", "Batched two")
+	assertEmbeddedHeadUnchanged(t, beadsDir, "bco", before, "create below batch_max_ops")
+
+	bdCommand(t, bd, dir, "--dolt-auto-commit", "batch", "create", "Batched three")
+	assertEmbeddedHeadAdvanced(t, beadsDir, "bco", before, "create reaching batch_max_ops")
+
+	store, err := embeddeddolt.Open(t.Context(), beadsDir, "bco", "main")
+	if err != nil {
+		t.Fatalf("open embedded store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	log, err := store.Log(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+	if len(log) != 1 || !strings.HasPrefix(log[0].Message, "bd: batch of 3 writes") {
+		t.Fatalf("HEAD commit = %+v, want a \"bd: batch of 3 writes\" commit", log)
+	}
+}
+
+func TestEmbeddedCommitCommandCommitsImpliedBatch(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt auto-commit tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "bcm")
+	// A batch threshold alone turns batch mode on.
+	bdCommand(t, bd, dir, "config", "set", "commit.batch_max_ops", "100")
+	before := embeddedCurrentCommit(t, beadsDir, "bcm")
+
+	bdCommand(t, bd, dir, "create", "Pending one")
+	bdCommand(t, bd, dir, "create", "Pending two")
+	assertEmbeddedHeadUnchanged(t, beadsDir, "bcm", before, "create with batch_max_ops set")
+
+	out := bdCommand(t, bd, dir, "commit", "--json")
+	var result struct {
+		Committed  bool     `json:"committed"`
+		Message    string   `json:"message"`
+		Operations int      `json:"operations"`
+		Issues     []string `json:"issues"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse bd commit --json: %v\n%s", err, out)
+	}
+	if !result.Committed || result.Operations != 2 || len(result.Issues) != 2 {
+		t.Fatalf("bd commit --json = %+v, want 2 committed writes on 2 issues", result)
+	}
+	if !strings.HasPrefix(result.Message, "bd: batch of 2 writes") {
+		t.Fatalf("commit message = %q, want a batch summary", result.Message)
+	}
+	assertEmbeddedHeadAdvanced(t, beadsDir, "bcm", before, "bd commit")
+
+	if out := bdCommand(t, bd, dir, "commit"); !strings.Contains(out, "Nothing to commit") {
+		t.Fatalf("second bd commit output = %q, want Nothing to commit", out)
+	}
+}
+
+func TestEmbeddedVCStatusReportsPendingBatch(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt auto-commit tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "vps")
+	issue := bdCreate(t, bd, dir, "Committed")
+	bdCommand(t, bd, dir, "--dolt-auto-commit", "batch", "update", issue.ID, "--priority", "1")
+
+	// Each bd invocation is its own process, so the pending write below was
+	// left on disk by one that has exited without committing it.
+	out := bdCommand(t, bd, dir, "--dolt-auto-commit", "batch", "vc", "status", "--json")
+	var status struct {
+		Pending struct {
+			Operations int      `json:"operations"`
+			Issues     []string `json:"issues"`
+		} `json:"pending"`
+	}
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatalf("parse bd vc status --json: %v\n%s", err, out)
+	}
+	if status.Pending.Operations != 1 || !slices.Equal(status.Pending.Issues, []string{issue.ID}) {
+		t.Fatalf("pending = %+v, want 1 write on %s", status.Pending, issue.ID)
+	}
+
+	bdCommand(t, bd, dir, "commit")
+	out = bdCommand(t, bd, dir, "vc", "status", "--json")
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatalf("parse bd vc status --json: %v\n%s", err, out)
+	}
+	if status.Pending.Operations != 0 || len(status.Pending.Issues) != 0 {
+		t.Fatalf("pending after bd commit = %+v, want none", status.Pending)
+	}
+}
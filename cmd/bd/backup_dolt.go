@@ -144,6 +144,12 @@ Run 'bd backup init <path>' first to configure a destination.`,
 			return fmt.Errorf("storage backend does not support backup operations")
 		}
 
+		release, err := beginDoltSync()
+		if err != nil {
+			return err
+		}
+		defer release()
+
 		// First, commit any pending changes so they're included in the backup
 		if err := store.Commit(ctx, "bd: pre-backup commit"); err != nil && !isDoltNothingToCommit(err) {
 			fmt.Fprintf(os.Stderr, "Warning: failed to commit pending changes: %v\n", err)
@@ -12,6 +12,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/progress"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -159,17 +160,21 @@ normal 'bd' subcommands for interactive/read operations.`,
 			ctx = context.Background()
 		}
 
+		ctx, task := progress.Start(ctx, "batch", len(ops))
 		results := make([]batchOpResult, 0, len(ops))
 		err = transact(ctx, store, commitMsg, func(tx storage.Transaction) error {
-			for _, op := range ops {
+			for i, op := range ops {
 				res, rerr := runBatchOp(ctx, tx, op)
 				if rerr != nil {
 					return fmt.Errorf("line %d (%s): %w", op.line, op.raw, rerr)
 				}
 				results = append(results, res)
+				// Set, not Add: a retried transaction starts over.
+				task.Set(i + 1)
 			}
 			return nil
 		})
+		task.Finish()
 		if err != nil {
 			if jsonOutput {
 				if jerr := outputJSONError(err, "batch_error"); jerr != nil {
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// blameValueWidth is how much of a field's value bd blame prints inline.
+const blameValueWidth = 50
+
+var blameCmd = &cobra.Command{
+	Use:     "blame <id>",
+	GroupID: "views",
+	Short:   "Show the last commit that changed each field of an issue",
+	Long: `Show, for every field of an issue, the commit that last changed it:
+its hash, who made it, and when. Use it to find out who overwrote a
+description or reopened an issue.
+
+Changes not yet committed to Dolt are shown as (uncommitted). Only issues
+have history; wisps are not versioned.
+
+Examples:
+  bd blame bd-123         # Who last changed each field of bd-123
+  bd blame bd-123 --json  # Same, as JSON`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("blame")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("blame is not supported in proxied-server mode")
+		}
+		blamer, ok := storage.UnwrapStore(store).(storage.IssueBlamer)
+		if !ok {
+			return HandleErrorRespectJSON("storage backend does not support blame")
+		}
+
+		issueID := args[0]
+		blame, err := blamer.GetIssueBlame(rootCtx, issueID)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to get blame for %s: %v", issueID, err)
+		}
+		if jsonOutput {
+			return outputJSON(blame)
+		}
+
+		fmt.Printf("\n%s Blame for %s\n\n", ui.RenderAccent("🔍"), issueID)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, b := range blame {
+			commit, actor, when := "", "", ""
+			switch {
+			case b.CommitHash == "":
+				commit = "-"
+			case b.CommitHash == "WORKING":
+				commit = "(uncommitted)"
+			default:
+				commit = shortCommitHash(b.CommitHash)
+				actor = b.Actor
+				when = b.CommitDate.Format("2006-01-02 15:04")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				ui.RenderBold(b.Field), ui.RenderMuted(commit), actor, ui.RenderMuted(when), formatBlameValue(b))
+		}
+		_ = w.Flush()
+		fmt.Println()
+		return nil
+	},
+}
+
+// formatBlameValue renders a field's current value on one line: free-text
+// fields by length, anything else cut to blameValueWidth.
+func formatBlameValue(b *storage.FieldBlame) string {
+	if b.Value == "" {
+		return ui.RenderMuted("(none)")
+	}
+	if historyTextFields[b.Field] {
+		return fmt.Sprintf("(%d chars)", len([]rune(b.Value)))
+	}
+	v := strings.ReplaceAll(b.Value, "\n", " ")
+	if r := []rune(v); len(r) > blameValueWidth {
+		v = string(r[:blameValueWidth-3]) + "..."
+	}
+	return v
+}
+
+func init() {
+	blameCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(blameCmd)
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// bondSuggestionThreshold is the default text similarity at which two wisps
+// are suggested for bonding. It is stricter than find-duplicates' default
+// because wisp titles are short and formulaic.
+const bondSuggestionThreshold = 0.6
+
+// Reasons two wisps were linked into a bond suggestion.
+const (
+	bondReasonFingerprint = "fingerprint" // same title once numbers and hashes are masked
+	bondReasonFormula     = "formula"     // poured from the same formula step
+	bondReasonText        = "text"        // title and description are similar
+)
+
+// BondSuggestion is a cluster of related open wisps that could be bonded
+// into one durable issue. The ID is derived from the member wisp IDs, so it
+// stays stable until the cluster changes.
+type BondSuggestion struct {
+	ID      string              `json:"id"`
+	Title   string              `json:"title"`
+	Reasons []string            `json:"reasons"`
+	Wisps   []BondSuggestedWisp `json:"wisps"`
+
+	members []*types.Issue
+}
+
+// BondSuggestedWisp is one member of a bond suggestion.
+type BondSuggestedWisp struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+// BondAcceptResult is the outcome of accepting a bond suggestion.
+type BondAcceptResult struct {
+	SuggestionID string   `json:"suggestion_id"`
+	IssueID      string   `json:"issue_id"`
+	Title        string   `json:"title"`
+	BondedFrom   []string `json:"bonded_from"`
+	InReview     []string `json:"in_review,omitempty"` // closed wisps waiting for human review
+	Followups    []string `json:"followups,omitempty"` // follow-ups fired by closing the wisps
+}
+
+// wispFingerprint reduces a title to its template by masking every word that
+// contains a digit, so "Heartbeat 12 from worker-3" and "Heartbeat 13 from
+// worker-7" share a fingerprint.
+func wispFingerprint(title string) string {
+	words := strings.Fields(strings.ToLower(title))
+	for i, w := range words {
+		if strings.ContainsAny(w, "0123456789") {
+			words[i] = "#"
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// suggestWispBonds clusters wisps that share a fingerprint, were poured from
+// the same formula step, or have text similarity of at least threshold.
+// Clusters are transitive: wisps A~B and B~C land in one suggestion.
+func suggestWispBonds(wisps []*types.Issue, threshold float64) []BondSuggestion {
+	parent := make([]int, len(wisps))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	reasons := make(map[int]map[string]bool)
+	link := func(i, j int, reason string) {
+		ri, rj := find(i), find(j)
+		if ri != rj {
+			parent[rj] = ri
+			for r := range reasons[rj] {
+				if reasons[ri] == nil {
+					reasons[ri] = make(map[string]bool)
+				}
+				reasons[ri][r] = true
+			}
+			delete(reasons, rj)
+		}
+		if reasons[ri] == nil {
+			reasons[ri] = make(map[string]bool)
+		}
+		reasons[ri][reason] = true
+	}
+
+	byFingerprint := make(map[string]int)
+	byFormula := make(map[string]int)
+	tokens := make([]map[string]int, len(wisps))
+	for i, w := range wisps {
+		if j, ok := byFingerprint[wispFingerprint(w.Title)]; ok {
+			link(j, i, bondReasonFingerprint)
+		} else {
+			byFingerprint[wispFingerprint(w.Title)] = i
+		}
+		if w.SourceFormula != "" {
+			key := w.SourceFormula + "\x00" + w.SourceLocation
+			if j, ok := byFormula[key]; ok {
+				link(j, i, bondReasonFormula)
+			} else {
+				byFormula[key] = i
+			}
+		}
+		tokens[i] = tokenize(issueText(w))
+	}
+	for i := 0; i < len(wisps); i++ {
+		for j := i + 1; j < len(wisps); j++ {
+			if jaccardSimilarity(tokens[i], tokens[j]) >= threshold {
+				link(i, j, bondReasonText)
+			}
+		}
+	}
+
+	clusters := make(map[int][]*types.Issue)
+	for i, w := range wisps {
+		root := find(i)
+		clusters[root] = append(clusters[root], w)
+	}
+
+	var suggestions []BondSuggestion
+	for root, members := range clusters {
+		if len(members) < 2 {
+			continue
+		}
+		slices.SortFunc(members, func(a, b *types.Issue) int {
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return strings.Compare(a.ID, b.ID)
+		})
+		sg := BondSuggestion{
+			Title:   members[0].Title,
+			members: members,
+		}
+		ids := make([]string, len(members))
+		for i, m := range members {
+			ids[i] = m.ID
+			sg.Wisps = append(sg.Wisps, BondSuggestedWisp{ID: m.ID, Title: m.Title, CreatedBy: m.CreatedBy})
+		}
+		slices.Sort(ids)
+		sum := sha256.Sum256([]byte(strings.Join(ids, ",")))
+		sg.ID = "bond-" + hex.EncodeToString(sum[:])[:8]
+		for r := range reasons[root] {
+			sg.Reasons = append(sg.Reasons, r)
+		}
+		slices.Sort(sg.Reasons)
+		suggestions = append(suggestions, sg)
+	}
+	slices.SortFunc(suggestions, func(a, b BondSuggestion) int {
+		if len(a.Wisps) != len(b.Wisps) {
+			return len(b.Wisps) - len(a.Wisps)
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return suggestions
+}
+
+// loadBondSuggestions clusters the open wisps in the store. Closed wisps,
+// including those already bonded, and infra types are left out.
+func loadBondSuggestions(ctx context.Context, s storage.DoltStorage, threshold float64) ([]BondSuggestion, error) {
+	ephemeral := true
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{
+		Ephemeral:     &ephemeral,
+		ExcludeStatus: []types.Status{types.StatusClosed},
+		Limit:         5000,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing wisps: %w", err)
+	}
+	var wisps []*types.Issue
+	for _, issue := range issues {
+		if issue.Status != types.StatusClosed && !s.IsInfraTypeCtx(ctx, issue.IssueType) {
+			wisps = append(wisps, issue)
+		}
+	}
+	return suggestWispBonds(wisps, threshold), nil
+}
+
+// acceptBondSuggestion creates a durable issue recording the suggestion's
+// wisps as BondedFrom provenance (in metadata.bonded_from) and closes the
+// wisps, so gc collects them and they are not suggested again. The wisps'
+// closes honor the close policies and, once committed, run the post-close
+// hooks.
+func acceptBondSuggestion(ctx context.Context, s storage.DoltStorage, sg BondSuggestion, title, actorName string) (*BondAcceptResult, error) {
+	// The close reason names the bonded issue, whose ID is only known once
+	// it is created; the policies read just the reason's category, which the
+	// ID does not change.
+	for _, w := range sg.members {
+		duplicateLinked := func() (bool, error) { return hasDuplicateLink(ctx, s, w.ID) }
+		if err := checkClosePolicies(w.ID, w, bondCloseReason(""), duplicateLinked); err != nil {
+			return nil, fmt.Errorf("closing %s: %w", w.ID, err)
+		}
+	}
+
+	if title == "" {
+		title = sg.Title
+	}
+	var desc strings.Builder
+	fmt.Fprintf(&desc, "Bonded from %d related wisps:\n", len(sg.members))
+	issue := &types.Issue{
+		Title:     title,
+		Status:    types.StatusOpen,
+		Priority:  sg.members[0].Priority,
+		IssueType: sg.members[0].IssueType,
+	}
+	for _, w := range sg.members {
+		fmt.Fprintf(&desc, "- %s: %s\n", w.ID, w.Title)
+		issue.Priority = minPriority(issue.Priority, w.Priority)
+		issue.BondedFrom = append(issue.BondedFrom, types.BondRef{SourceID: w.ID, BondType: types.BondTypeParallel})
+	}
+	issue.Description = desc.String()
+	// Issues have no bonded_from column, so the provenance is persisted in
+	// metadata under the same key and shape.
+	meta, err := json.Marshal(map[string][]types.BondRef{"bonded_from": issue.BondedFrom})
+	if err != nil {
+		return nil, fmt.Errorf("encoding bond provenance: %w", err)
+	}
+	issue.Metadata = meta
+
+	err = transact(ctx, s, fmt.Sprintf("bd: bond %d wisps (%s)", len(sg.members), sg.ID), func(tx storage.Transaction) error {
+		if err := tx.CreateIssue(ctx, issue, actorName); err != nil {
+			return fmt.Errorf("creating bonded issue: %w", err)
+		}
+		for _, w := range sg.members {
+			if err := tx.CloseIssue(ctx, w.ID, bondCloseReason(issue.ID), actorName, ""); err != nil {
+				return fmt.Errorf("closing %s: %w", w.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BondAcceptResult{SuggestionID: sg.ID, IssueID: issue.ID, Title: issue.Title}
+	for _, w := range sg.members {
+		result.BondedFrom = append(result.BondedFrom, w.ID)
+		inReview, followups := runPostCloseHooks(ctx, s, w.ID, w, actorName)
+		if inReview {
+			result.InReview = append(result.InReview, w.ID)
+		}
+		result.Followups = append(result.Followups, followups...)
+	}
+	return result, nil
+}
+
+// bondCloseReason is the close reason of a wisp bonded into issueID.
+func bondCloseReason(issueID string) string {
+	return "Bonded into " + issueID
+}
+
+var bondCmd = &cobra.Command{
+	Use:     "bond",
+	GroupID: "issues",
+	Short:   "Bond related wisps into durable issues",
+	Long: `Find open wisps that look like the same ongoing problem and bond them
+into one durable issue.
+
+Wisps are suggested together when they share a fingerprint (the same title
+once numbers and hashes are masked), were poured from the same formula step,
+or have similar titles and descriptions. Accepting a suggestion creates an
+issue whose metadata.bonded_from lists the wisps, and closes the wisps.
+
+To bond protos and molecules, use 'bd mol bond'.
+
+Examples:
+  bd bond suggestions                  # Review suggested bonds
+  bd bond suggestions --threshold 0.4  # Looser text matching
+  bd bond accept bond-1a2b3c4d         # Bond a suggestion's wisps`,
+}
+
+var bondSuggestionsCmd = &cobra.Command{
+	Use:           "suggestions",
+	Short:         "List open wisps that could be bonded into durable issues",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runBondSuggestions,
+}
+
+var bondAcceptCmd = &cobra.Command{
+	Use:   "accept <suggestion-id>",
+	Short: "Bond a suggestion's wisps into a durable issue",
+	Long: `Create a durable issue from a bond suggestion. The issue records each
+wisp in metadata.bonded_from, and the wisps are closed with a pointer to it.
+
+Pass the same --threshold used to list the suggestion, since suggestion IDs
+depend on which wisps are clustered together.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runBondAccept,
+}
+
+func init() {
+	bondSuggestionsCmd.Flags().Float64("threshold", bondSuggestionThreshold, "Text similarity threshold (0.0-1.0, lower = more suggestions)")
+	bondSuggestionsCmd.Flags().IntP("limit", "n", 20, "Maximum number of suggestions to show")
+	bondAcceptCmd.Flags().Float64("threshold", bondSuggestionThreshold, "Text similarity threshold the suggestion was listed with")
+	bondAcceptCmd.Flags().String("title", "", "Title for the bonded issue (default: the oldest wisp's title)")
+	bondCmd.AddCommand(bondSuggestionsCmd)
+	bondCmd.AddCommand(bondAcceptCmd)
+	rootCmd.AddCommand(bondCmd)
+}
+
+func runBondSuggestions(cmd *cobra.Command, _ []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("bond suggestions is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("bond-suggestions")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	threshold, _ := cmd.Flags().GetFloat64("threshold")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if threshold < 0 || threshold > 1 {
+		return HandleErrorRespectJSON("--threshold must be between 0 and 1")
+	}
+	if store == nil {
+		return HandleErrorWithHint("database not initialized", diagHint())
+	}
+
+	suggestions, err := loadBondSuggestions(rootCtx, store, threshold)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	total := len(suggestions)
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	if jsonOutput {
+		if suggestions == nil {
+			suggestions = []BondSuggestion{}
+		}
+		return outputJSON(map[string]interface{}{
+			"suggestions": suggestions,
+			"count":       total,
+			"threshold":   threshold,
+		})
+	}
+
+	if total == 0 {
+		fmt.Println("No bond suggestions: no related open wisps found")
+		return nil
+	}
+	fmt.Printf("%s %d bond suggestion(s):\n\n", ui.RenderAccent("🔗"), total)
+	for _, sg := range suggestions {
+		fmt.Printf("%s  %s  (%d wisps; %s)\n", ui.RenderID(sg.ID), sg.Title, len(sg.Wisps), strings.Join(sg.Reasons, ", "))
+		for _, w := range sg.Wisps {
+			fmt.Printf("  %s %s\n", ui.RenderMuted(w.ID), w.Title)
+		}
+		fmt.Printf("  Accept: bd bond accept %s\n\n", sg.ID)
+	}
+	if total > len(suggestions) {
+		fmt.Printf("Showing %d of %d suggestions (use --limit to see more)\n", len(suggestions), total)
+	}
+	return nil
+}
+
+func runBondAccept(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("bond accept is not supported in proxied-server mode")
+	}
+	CheckReadonly("bond accept")
+	evt := metrics.NewCommandEvent("bond-accept")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	threshold, _ := cmd.Flags().GetFloat64("threshold")
+	title, _ := cmd.Flags().GetString("title")
+	if store == nil {
+		return HandleErrorWithHint("database not initialized", diagHint())
+	}
+
+	ctx := rootCtx
+	suggestions, err := loadBondSuggestions(ctx, store, threshold)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	idx := slices.IndexFunc(suggestions, func(sg BondSuggestion) bool { return sg.ID == args[0] })
+	if idx < 0 {
+		return HandleErrorRespectJSON("bond suggestion %s not found; its wisps may have changed or been closed (run 'bd bond suggestions')", args[0])
+	}
+	sg := suggestions[idx]
+
+	result, err := acceptBondSuggestion(ctx, store, sg, title, actor)
+	if err != nil {
+		return HandleErrorRespectJSON("accepting %s: %v", sg.ID, err)
+	}
+	commandDidWrite.Store(true)
+
+	if jsonOutput {
+		return outputJSON(result)
+	}
+	fmt.Printf("%s Created %s: %s\n", ui.RenderPass("✓"), result.IssueID, result.Title)
+	fmt.Printf("  Bonded from %d wisps (now closed): %s\n", len(result.BondedFrom), strings.Join(result.BondedFrom, ", "))
+	for _, id := range result.InReview {
+		fmt.Printf("  %s %s waiting for human review (bd review list)\n", ui.RenderWarn("→"), id)
+	}
+	for _, f := range result.Followups {
+		fmt.Printf("  %s follow-up %s created\n", ui.RenderAccent("→"), f)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWispFingerprint(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"Heartbeat 12 from worker-3", "Heartbeat 13 from worker-7", true},
+		{"Build failed at abc123f", "build FAILED at 9e8d7c6", true},
+		{"Build failed", "Build passed", false},
+		{"Patrol 1", "Patrol 1 extra", false},
+	}
+	for _, tt := range tests {
+		if got := wispFingerprint(tt.a) == wispFingerprint(tt.b); got != tt.same {
+			t.Errorf("fingerprints of %q and %q equal = %v, want %v", tt.a, tt.b, got, tt.same)
+		}
+	}
+}
+
+func TestSuggestWispBonds(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	wisp := func(id, title string, minutes int) *types.Issue {
+		return &types.Issue{ID: id, Title: title, Priority: 2, CreatedAt: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+	w1 := wisp("bd-wisp-a", "Heartbeat 12 missed on worker-3", 2)
+	w2 := wisp("bd-wisp-b", "Heartbeat 13 missed on worker-7", 1)
+	w3 := wisp("bd-wisp-c", "Refinery queue stalled", 3)
+	w3.SourceFormula, w3.SourceLocation = "mol-refinery", "steps[2]"
+	w4 := wisp("bd-wisp-d", "Merge queue blocked", 4)
+	w4.SourceFormula, w4.SourceLocation = "mol-refinery", "steps[2]"
+	w5 := wisp("bd-wisp-e", "Unrelated one-off note", 5)
+	w6 := wisp("bd-wisp-f", "Deploy to staging failed with timeout error", 6)
+	w7 := wisp("bd-wisp-g", "Deploy to staging failed with timeout", 7)
+
+	got := suggestWispBonds([]*types.Issue{w1, w2, w3, w4, w5, w6, w7}, bondSuggestionThreshold)
+	if len(got) != 3 {
+		t.Fatalf("got %d suggestions, want 3: %+v", len(got), got)
+	}
+
+	byMembers := make(map[string]BondSuggestion)
+	for _, sg := range got {
+		byMembers[sg.Wisps[0].ID] = sg
+	}
+	tests := []struct {
+		first   string
+		members []string
+		reason  string
+		title   string
+	}{
+		{"bd-wisp-b", []string{"bd-wisp-b", "bd-wisp-a"}, bondReasonFingerprint, w2.Title},
+		{"bd-wisp-c", []string{"bd-wisp-c", "bd-wisp-d"}, bondReasonFormula, w3.Title},
+		{"bd-wisp-f", []string{"bd-wisp-f", "bd-wisp-g"}, bondReasonText, w6.Title},
+	}
+	for _, tt := range tests {
+		sg, ok := byMembers[tt.first]
+		if !ok {
+			t.Errorf("no suggestion starting with %s", tt.first)
+			continue
+		}
+		var ids []string
+		for _, w := range sg.Wisps {
+			ids = append(ids, w.ID)
+		}
+		if !slices.Equal(ids, tt.members) {
+			t.Errorf("suggestion %s members = %v, want %v (oldest first)", sg.ID, ids, tt.members)
+		}
+		if !slices.Contains(sg.Reasons, tt.reason) {
+			t.Errorf("suggestion %s reasons = %v, want to include %q", sg.ID, sg.Reasons, tt.reason)
+		}
+		if sg.Title != tt.title {
+			t.Errorf("suggestion %s title = %q, want %q", sg.ID, sg.Title, tt.title)
+		}
+	}
+
+	// IDs depend only on membership, not input order.
+	again := suggestWispBonds([]*types.Issue{w7, w6, w5, w4, w3, w2, w1}, bondSuggestionThreshold)
+	var ids, againIDs []string
+	for i := range got {
+		ids = append(ids, got[i].ID)
+		againIDs = append(againIDs, again[i].ID)
+	}
+	slices.Sort(ids)
+	slices.Sort(againIDs)
+	if !slices.Equal(ids, againIDs) {
+		t.Errorf("suggestion IDs changed with input order: %v vs %v", ids, againIDs)
+	}
+}
+
+func TestSuggestWispBonds_Transitive(t *testing.T) {
+	a := &types.Issue{ID: "a", Title: "Heartbeat 10 missed", Description: "disk full"}
+	b := &types.Issue{ID: "b", Title: "Heartbeat 20 missed", Description: "network partition", SourceFormula: "mol-patrol", SourceLocation: "steps[0]"}
+	c := &types.Issue{ID: "c", Title: "Patrol step stuck", SourceFormula: "mol-patrol", SourceLocation: "steps[0]"}
+
+	got := suggestWispBonds([]*types.Issue{a, b, c}, 1)
+	if len(got) != 1 || len(got[0].Wisps) != 3 {
+		t.Fatalf("want one suggestion with 3 wisps, got %+v", got)
+	}
+	if want := []string{bondReasonFingerprint, bondReasonFormula}; !slices.Equal(got[0].Reasons, want) {
+		t.Errorf("reasons = %v, want %v", got[0].Reasons, want)
+	}
+}
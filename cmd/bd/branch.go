@@ -1,33 +1,109 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
+// activeBranchFile holds the branch bd branch switch made active. It lives
+// in .beads/ rather than the database because the store has to know the
+// branch before it reads anything.
+const activeBranchFile = "active-branch"
+
+// defaultBranch is the branch a store opens on when none is active.
+const defaultBranch = "main"
+
 var branchCmd = &cobra.Command{
 	Use:     "branch [name]",
 	GroupID: "sync",
-	Short:   "List or create branches",
-	Long: `List all branches or create a new branch.
+	Short:   "List, create, switch, or merge branches",
+	Long: `List, create, switch between, and merge branches of the issue database.
+
+A branch is a separate line of history for your issues. Switch to one to
+draft a large restructuring (splitting epics, reparenting, bulk closes)
+without touching main, review it with bd diff, then merge it back.
+
+The active branch is remembered for this clone: after 'bd branch switch',
+every command (lists, filters, and writes) works on that branch until you
+switch back. 'bd status' shows the active branch.
 
 This command requires the Dolt storage backend. Without arguments,
 it lists all branches. With an argument, it creates a new branch.
 
 Examples:
-  bd branch                    # List all branches
-  bd branch feature-xyz        # Create a new branch named feature-xyz`,
+  bd branch                        # List all branches
+  bd branch feature-xyz            # Create a new branch named feature-xyz
+  bd branch create restructure     # Create a branch
+  bd branch switch restructure     # Work on it
+  bd diff main restructure         # Review the changes
+  bd branch switch main            # Go back
+  bd branch merge restructure      # Merge it into main`,
 	Args:          cobra.MaximumNArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return runBranchList()
+		}
+		return runBranchCreate(args[0])
+	},
+}
+
+var branchCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a branch from the current branch",
+	Long: `Create a branch from the current branch. The active branch does not
+change; use 'bd branch switch' to work on the new branch.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBranchCreate(args[0])
+	},
+}
+
+var branchListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List branches",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBranchList()
+	},
+}
+
+var branchSwitchCmd = &cobra.Command{
+	Use:   "switch <name>",
+	Short: "Make a branch the active branch",
+	Long: `Make a branch the active branch for this clone. Every later command
+reads and writes that branch until you switch again.
+
+Pending changes on the current branch are committed first, so they stay
+on the branch they were made on.
+
+Examples:
+  bd branch switch restructure   # Work on the restructure branch
+  bd branch switch main          # Go back to main`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if usesProxiedServer() {
 			return HandleErrorRespectJSON("branch is not supported in proxied-server mode")
 		}
-		evt := metrics.NewCommandEvent("branch")
+		CheckReadonly("branch switch")
+		evt := metrics.NewCommandEvent("branch-switch")
 		defer func() {
 			if c := metrics.Global(); c != nil {
 				c.CloseEventAndAdd(evt)
@@ -35,53 +111,224 @@ Examples:
 		}()
 
 		ctx := rootCtx
-
-		if len(args) == 0 {
-			branches, err := store.ListBranches(ctx)
-			if err != nil {
-				return HandleErrorRespectJSON("failed to list branches: %v", err)
+		name := args[0]
+		from, err := store.CurrentBranch(ctx)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to read current branch: %v", err)
+		}
+		if name != from {
+			if !branchExists(ctx, store, name) {
+				return HandleErrorWithHintRespectJSON(
+					fmt.Sprintf("no branch named %s", name),
+					"Run 'bd branch list' to see branches, or 'bd branch create "+name+"' to create it.")
 			}
-
-			currentBranch, err := store.CurrentBranch(ctx)
-			if err != nil {
-				currentBranch = ""
+			if _, err := store.CommitPending(ctx, actor); err != nil {
+				return HandleErrorRespectJSON("failed to commit pending changes on %s: %v", from, err)
 			}
-
-			if jsonOutput {
-				return outputJSON(map[string]interface{}{
-					"current":  currentBranch,
-					"branches": branches,
-				})
+			if err := store.Checkout(ctx, name); err != nil {
+				return HandleErrorRespectJSON("failed to switch to %s: %v", name, err)
 			}
-
-			fmt.Printf("\n%s Branches:\n\n", ui.RenderAccent("🌿"))
-			for _, branch := range branches {
-				if branch == currentBranch {
-					fmt.Printf("  * %s\n", ui.StatusInProgressStyle.Render(branch))
-				} else {
-					fmt.Printf("    %s\n", branch)
+			// Dolt-ignored tables (wisps, leases) are not part of history, so
+			// a branch checked out for the first time has none; the schema
+			// pass creates them, as bd simulate does for its scratch branch.
+			if m, ok := storage.UnwrapStore(store).(storage.SchemaMigrator); ok {
+				if _, err := m.ApplySchemaMigrations(ctx); err != nil {
+					return HandleErrorRespectJSON("failed to prepare branch %s: %v", name, err)
 				}
 			}
-			fmt.Println()
-			return nil
 		}
-
-		branchName := args[0]
-		if err := store.Branch(ctx, branchName); err != nil {
-			return HandleErrorRespectJSON("failed to create branch: %v", err)
+		if err := writeActiveBranch(name); err != nil {
+			return HandleErrorRespectJSON("switched to %s but could not remember it: %v", name, err)
 		}
 
 		if jsonOutput {
 			return outputJSON(map[string]interface{}{
-				"created": branchName,
+				"previous": from,
+				"current":  name,
 			})
 		}
-
-		fmt.Printf("Created branch: %s\n", ui.RenderAccent(branchName))
+		if name == from {
+			fmt.Printf("Already on %s\n", ui.RenderAccent(name))
+			return nil
+		}
+		fmt.Printf("Switched to branch %s\n", ui.RenderAccent(name))
 		return nil
 	},
 }
 
+var branchMergeStrategy string
+
+var branchMergeCmd = &cobra.Command{
+	Use:   "merge <name>",
+	Short: "Merge a branch into the active branch",
+	Long: `Merge a branch into the active branch.
+
+If there are merge conflicts, they will be reported. You can resolve
+conflicts with --strategy.
+
+Examples:
+  bd branch merge restructure                    # Merge into the active branch
+  bd branch merge restructure --strategy theirs  # Prefer the branch's changes on conflict`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("branch is not supported in proxied-server mode")
+		}
+		CheckReadonly("branch merge")
+		evt := metrics.NewCommandEvent("branch-merge")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		ctx := rootCtx
+		if current, err := store.CurrentBranch(ctx); err == nil && current == args[0] {
+			return HandleErrorRespectJSON("cannot merge %s into itself; switch to the target branch first", args[0])
+		}
+		if _, err := store.CommitPending(ctx, actor); err != nil {
+			return HandleErrorRespectJSON("failed to commit pending changes: %v", err)
+		}
+		return mergeBranch(cmd, args[0], branchMergeStrategy)
+	},
+}
+
+func runBranchList() error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("branch is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("branch")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	ctx := rootCtx
+	branches, err := store.ListBranches(ctx)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to list branches: %v", err)
+	}
+
+	currentBranch, err := store.CurrentBranch(ctx)
+	if err != nil {
+		currentBranch = ""
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"current":  currentBranch,
+			"branches": branches,
+		})
+	}
+
+	fmt.Printf("\n%s Branches:\n\n", ui.RenderAccent("🌿"))
+	for _, branch := range branches {
+		if branch == currentBranch {
+			fmt.Printf("  * %s\n", ui.StatusInProgressStyle.Render(branch))
+		} else {
+			fmt.Printf("    %s\n", branch)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func runBranchCreate(branchName string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("branch is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("branch")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	if err := store.Branch(rootCtx, branchName); err != nil {
+		return HandleErrorRespectJSON("failed to create branch: %v", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"created": branchName,
+		})
+	}
+
+	fmt.Printf("Created branch: %s\n", ui.RenderAccent(branchName))
+	return nil
+}
+
+// readActiveBranch returns the branch bd branch switch made active, or ""
+// when none is (the store stays on main).
+func readActiveBranch() string {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(beadsDir, activeBranchFile)) // #nosec G304 -- path constructed from beadsDir
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// writeActiveBranch records name as the active branch. Switching back to
+// main removes the file.
+func writeActiveBranch(name string) error {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return fmt.Errorf("no .beads directory found")
+	}
+	path := filepath.Join(beadsDir, activeBranchFile)
+	if name == defaultBranch {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(path, []byte(name+"\n"), 0600)
+}
+
+// restoreActiveBranch checks out the branch bd branch switch made active,
+// so every command reads and writes it. A branch that no longer exists is
+// reported and the store stays on the branch it opened on.
+func restoreActiveBranch(ctx context.Context, s storage.DoltStorage) {
+	name := readActiveBranch()
+	if name == "" {
+		return
+	}
+	if current, err := s.CurrentBranch(ctx); err == nil && current == name {
+		return
+	}
+	if !branchExists(ctx, s, name) {
+		fmt.Fprintf(os.Stderr, "Warning: active branch %s no longer exists; using %s. Run 'bd branch switch %s' to stop this warning.\n",
+			name, defaultBranch, defaultBranch)
+		return
+	}
+	if err := s.Checkout(ctx, name); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: cannot switch to active branch %s (%v); using %s.\n", name, err, defaultBranch)
+	}
+}
+
+// branchExists reports whether s has a branch called name. A failed lookup
+// counts as present so the checkout reports the real error.
+func branchExists(ctx context.Context, s storage.DoltStorage, name string) bool {
+	branches, err := s.ListBranches(ctx)
+	if err != nil {
+		return true
+	}
+	return slices.Contains(branches, name)
+}
+
 func init() {
+	branchMergeCmd.Flags().StringVar(&branchMergeStrategy, "strategy", "", "Conflict resolution strategy: 'ours' or 'theirs'")
+
+	branchCmd.AddCommand(branchCreateCmd)
+	branchCmd.AddCommand(branchListCmd)
+	branchCmd.AddCommand(branchSwitchCmd)
+	branchCmd.AddCommand(branchMergeCmd)
 	rootCmd.AddCommand(branchCmd)
 }
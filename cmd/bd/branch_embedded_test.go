@@ -105,6 +105,52 @@ func TestEmbeddedBranch(t *testing.T) {
 			t.Errorf("expected at least 4 branches, got %d", len(branches))
 		}
 	})
+
+	t.Run("switch_and_merge", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "brsw")
+		bdCreate(t, bd, dir, "on main")
+
+		bdBranch(t, bd, dir, "create", "draft")
+		bdBranch(t, bd, dir, "switch", "draft")
+		bdCreate(t, bd, dir, "on draft")
+		if got := len(bdListJSON(t, bd, dir)); got != 2 {
+			t.Errorf("draft: expected 2 issues, got %d", got)
+		}
+
+		listOut := bdBranch(t, bd, dir, "list", "--json")
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(listOut), &result); err != nil {
+			t.Fatalf("failed to parse JSON: %v\n%s", err, listOut)
+		}
+		if current, _ := result["current"].(string); current != "draft" {
+			t.Errorf("expected active branch 'draft', got %q", current)
+		}
+
+		bdBranch(t, bd, dir, "switch", "main")
+		if got := len(bdListJSON(t, bd, dir)); got != 1 {
+			t.Errorf("main before merge: expected 1 issue, got %d", got)
+		}
+
+		bdBranch(t, bd, dir, "merge", "draft")
+		if got := len(bdListJSON(t, bd, dir)); got != 2 {
+			t.Errorf("main after merge: expected 2 issues, got %d", got)
+		}
+	})
+
+	t.Run("switch_missing", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "brswm")
+
+		cmd := exec.Command(bd, "branch", "switch", "nope")
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		out, err := cmd.CombinedOutput()
+		if err == nil {
+			t.Fatalf("expected switching to a missing branch to fail, got:\n%s", out)
+		}
+		if !strings.Contains(string(out), "no branch named nope") {
+			t.Errorf("expected missing-branch error, got:\n%s", out)
+		}
+	})
 }
 
 func TestEmbeddedBranchConcurrent(t *testing.T) {
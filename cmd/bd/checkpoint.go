@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// checkpointNamePattern keeps checkpoint names usable as Dolt tag names and
+// in revision database names ("<db>/<name>").
+var checkpointNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// checkpointViewPrefix names the scratch branches --at reads from.
+const checkpointViewPrefix = "bd-at-"
+
+// checkpointViewMaxAge is how old a leftover --at branch must be before a
+// later --at deletes it; younger ones may belong to a running command.
+const checkpointViewMaxAge = time.Hour
+
+// leaveCheckpointView undoes enterCheckpointView; set while --at is active.
+var leaveCheckpointView func()
+
+// checkpointJSON is the --json shape of a checkpoint.
+type checkpointJSON struct {
+	Name    string    `json:"name"`
+	Commit  string    `json:"commit"`
+	Tagger  string    `json:"tagger"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message,omitempty"`
+}
+
+func toCheckpointJSON(c storage.Checkpoint) checkpointJSON {
+	return checkpointJSON{Name: c.Name, Commit: c.Hash, Tagger: c.Tagger, Date: c.Date, Message: c.Message}
+}
+
+func validateCheckpointName(name string) error {
+	if !checkpointNamePattern.MatchString(name) || strings.Contains(name, "..") || strings.HasSuffix(name, ".") {
+		return fmt.Errorf("invalid checkpoint name %q: use letters, digits, '.', '_' and '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+var checkpointCmd = &cobra.Command{
+	Use:     "checkpoint",
+	GroupID: "sync",
+	Short:   "Name points in the tracker's history",
+	Long: `Checkpoints are named Dolt tags on the current commit, so milestones of
+project state are easy to reference later. Any read command can look at a
+checkpoint with the global --at flag:
+
+  bd checkpoint create v1.0-planning -m "Scope agreed for 1.0"
+  bd list --at v1.0-planning
+  bd show bd-42 --at v1.0-planning
+  bd diff v1.0-planning main
+
+--at also accepts any Dolt tag, branch, or commit hash. Commands that write
+are refused while it is set. It is not yet available in server mode.`,
+}
+
+var checkpointCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Tag the current state as a checkpoint",
+	Long: `Tag the current Dolt commit as a named checkpoint. Pending changes are
+committed first so the checkpoint captures the tracker as you see it.
+
+Examples:
+  bd checkpoint create v1.0-planning
+  bd checkpoint create sprint-12-start -m "Sprint 12 kickoff"`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("checkpoint create is not supported in proxied-server mode")
+		}
+		CheckReadonly("checkpoint create")
+		evt := metrics.NewCommandEvent("checkpoint create")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+
+		name := args[0]
+		if err := validateCheckpointName(name); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		message, _ := cmd.Flags().GetString("message")
+		cp, ok := storage.UnwrapStore(store).(storage.Checkpointer)
+		if !ok {
+			return HandleErrorRespectJSON("checkpoints require the Dolt storage backend")
+		}
+		existing, err := cp.ListCheckpoints(ctx)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to list checkpoints: %v", err)
+		}
+		for _, c := range existing {
+			if c.Name == name {
+				return HandleErrorRespectJSON("checkpoint %q already exists (commit %s)", name, shortCommit(c.Hash))
+			}
+		}
+		if _, err := store.CommitPending(ctx, actor); err != nil {
+			return HandleErrorRespectJSON("failed to commit pending changes: %v", err)
+		}
+		created, err := cp.CreateCheckpoint(ctx, name, message, actor)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to create checkpoint: %v", err)
+		}
+		if created == nil {
+			return HandleErrorRespectJSON("checkpoint %q was not recorded", name)
+		}
+
+		if jsonOutput {
+			return outputJSON(toCheckpointJSON(*created))
+		}
+		fmt.Printf("%s Created checkpoint %s at commit %s\n", ui.RenderPass("✓"), ui.RenderAccent(name), shortCommit(created.Hash))
+		fmt.Printf("  %s\n", ui.RenderMuted("bd list --at "+name))
+		return nil
+	},
+}
+
+var checkpointListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List checkpoints, newest first",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("checkpoint list is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("checkpoint list")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+
+		cp, ok := storage.UnwrapStore(store).(storage.Checkpointer)
+		if !ok {
+			return HandleErrorRespectJSON("checkpoints require the Dolt storage backend")
+		}
+		checkpoints, err := cp.ListCheckpoints(ctx)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to list checkpoints: %v", err)
+		}
+
+		if jsonOutput {
+			out := make([]checkpointJSON, 0, len(checkpoints))
+			for _, c := range checkpoints {
+				out = append(out, toCheckpointJSON(c))
+			}
+			return outputJSON(out)
+		}
+		if len(checkpoints) == 0 {
+			fmt.Println("No checkpoints. Create one with: bd checkpoint create <name>")
+			return nil
+		}
+		fmt.Printf("\n%s Checkpoints:\n\n", ui.RenderAccent("🏷"))
+		for _, c := range checkpoints {
+			fmt.Printf("  %-24s %s  %s  %s\n", c.Name, shortCommit(c.Hash), c.Date.Local().Format("2006-01-02 15:04"), c.Tagger)
+			if c.Message != "" {
+				fmt.Printf("      %s\n", ui.RenderMuted(c.Message))
+			}
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+// enterCheckpointView switches s to a scratch branch created at ref, so the
+// command reads the tracker as it was there, and returns the function that
+// switches back and deletes the branch. Like bd simulate it reads from a
+// branch rather than the tag itself: dolt-ignored tables (wisps, leases) are
+// not part of history, and the schema pass recreates them empty, migrating
+// checkpoints taken by older versions along the way.
+func enterCheckpointView(ctx context.Context, s storage.DoltStorage, ref string) (func(), error) {
+	cp, ok := storage.UnwrapStore(s).(storage.Checkpointer)
+	if !ok {
+		return nil, fmt.Errorf("checkpoints require the Dolt storage backend")
+	}
+	orig, err := s.CurrentBranch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading current branch: %w", err)
+	}
+	sweepCheckpointViews(ctx, s, time.Now())
+
+	scratch := fmt.Sprintf("%s%s-%d", checkpointViewPrefix, time.Now().UTC().Format("20060102-150405"), os.Getpid())
+	if err := cp.BranchAt(ctx, scratch, ref); err != nil {
+		if strings.Contains(err.Error(), "is not a commit") {
+			return nil, fmt.Errorf("no checkpoint, branch, or commit named %q", ref)
+		}
+		return nil, err
+	}
+	if err := s.Checkout(ctx, scratch); err != nil {
+		_ = s.DeleteBranch(ctx, scratch)
+		return nil, fmt.Errorf("switching to %s: %w", scratch, err)
+	}
+	leave := func() {
+		if err := s.Checkout(ctx, orig); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: returning to %s: %v (branch %s kept)\n", orig, err, scratch)
+			return
+		}
+		if err := s.DeleteBranch(ctx, scratch); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: deleting branch %s: %v\n", scratch, err)
+		}
+	}
+	if m, ok := storage.UnwrapStore(s).(storage.SchemaMigrator); ok {
+		if _, err := m.ApplySchemaMigrations(ctx); err != nil {
+			leave()
+			return nil, fmt.Errorf("preparing %s: %w", scratch, err)
+		}
+	}
+	return leave, nil
+}
+
+// sweepCheckpointViews deletes --at branches left behind by commands that
+// exited without cleaning up.
+func sweepCheckpointViews(ctx context.Context, s storage.DoltStorage, now time.Time) {
+	branches, err := s.ListBranches(ctx)
+	if err != nil {
+		return
+	}
+	for _, b := range staleCheckpointViews(branches, now) {
+		_ = s.DeleteBranch(ctx, b)
+	}
+}
+
+// staleCheckpointViews returns the --at branches older than
+// checkpointViewMaxAge, judged by the timestamp in their names.
+func staleCheckpointViews(branches []string, now time.Time) []string {
+	var stale []string
+	for _, b := range branches {
+		rest, ok := strings.CutPrefix(b, checkpointViewPrefix)
+		if !ok || len(rest) < len("20060102-150405") {
+			continue
+		}
+		created, err := time.Parse("20060102-150405", rest[:len("20060102-150405")])
+		if err != nil {
+			continue
+		}
+		if now.Sub(created) > checkpointViewMaxAge {
+			stale = append(stale, b)
+		}
+	}
+	return stale
+}
+
+func init() {
+	checkpointCreateCmd.Flags().StringP("message", "m", "", "Describe what this checkpoint marks")
+	checkpointCmd.AddCommand(checkpointCreateCmd)
+	checkpointCmd.AddCommand(checkpointListCmd)
+	rootCmd.AddCommand(checkpointCmd)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateCheckpointName(t *testing.T) {
+	for _, name := range []string{"v1.0-planning", "sprint_12", "2026-q3"} {
+		if err := validateCheckpointName(name); err != nil {
+			t.Errorf("%q rejected: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "-v1", ".hidden", "a..b", "v1.", "has space", "a/b", "x`y"} {
+		if err := validateCheckpointName(name); err == nil {
+			t.Errorf("%q accepted", name)
+		}
+	}
+}
+
+func TestStaleCheckpointViews(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	branches := []string{
+		"main",
+		"bd-at-20260310-090000-111", // 3h old
+		"bd-at-20260310-113000-222", // 30m old, may still be in use
+		"bd-at-garbage",
+		"simulate-20260301-000000",
+	}
+	got := staleCheckpointViews(branches, now)
+	want := []string{"bd-at-20260310-090000-111"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("staleCheckpointViews = %v, want %v", got, want)
+	}
+}
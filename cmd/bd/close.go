@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
 	"github.com/steveyegge/beads/internal/validation"
 )
 
@@ -32,7 +33,22 @@ create, update, show, or close operation).
 When closing multiple issues, provide one --reason for all IDs or repeat
 --reason once per ID. Reasons map positionally: the first --reason applies
 to the first ID, the second --reason to the second ID, regardless of where
-the flags appear in the command line.`,
+the flags appear in the command line.
+
+Close reasons are categorized by their leading word against a taxonomy
+(default: fixed, duplicate, wontfix, obsolete, cannot-reproduce; override
+with close.reasons in config.yaml). Set validation.close-reason to "error"
+or "warn" to enforce it; duplicate closes must then link the canonical issue
+with --duplicate-of (or an existing duplicates dependency):
+
+  bd close bd-42 --reason "fixed: null check in parser"
+  bd close bd-43 --reason duplicate --duplicate-of bd-42
+
+'bd count --by-close-reason' and 'bd status' break closed issues down by
+category.
+
+Unchecked "- [ ]" items in the acceptance criteria produce a warning, or
+refuse the close when validation.acceptance is "error" (see 'bd ac').`,
 	Args:          cobra.MinimumNArgs(0),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -64,9 +80,24 @@ the flags appear in the command line.`,
 		}
 		args = updatedArgs
 
+		duplicateOf, _ := cmd.Flags().GetString("duplicate-of")
+		if duplicateOf != "" {
+			canonicalID, err := utils.ResolvePartialID(rootCtx, store, duplicateOf)
+			if err != nil {
+				return HandleErrorRespectJSON("resolving --duplicate-of %s: %v", duplicateOf, err)
+			}
+			duplicateOf = canonicalID
+			if len(reasons) == 1 && reasons[0] == "Closed" {
+				reasons = []string{types.CloseReasonDuplicate + ": " + canonicalID}
+			}
+		}
+
 		if err := validateCloseReasons(reasons); err != nil {
 			return HandleErrorRespectJSON("%v", err)
 		}
+		if err := validateCloseReasonCategories(reasons); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
 
 		force, _ := cmd.Flags().GetBool("force")
 		continueFlag, _ := cmd.Flags().GetBool("continue")
@@ -137,22 +168,32 @@ the flags appear in the command line.`,
 				}
 			}
 
-			// Check gate satisfaction for machine-checkable gates (GH#1467)
 			if !force {
-				if err := checkGateSatisfaction(issue); err != nil {
+				var duplicateLinked func() (bool, error)
+				if duplicateOf == "" {
+					duplicateLinked = func() (bool, error) { return hasDuplicateLink(ctx, activeStore, id) }
+				}
+				if err := checkClosePolicies(id, issue, reason, duplicateLinked); err != nil {
 					fmt.Fprintf(os.Stderr, "cannot close %s: %s\n", id, err)
 					continue
 				}
 			}
+			if duplicateOf == id {
+				fmt.Fprintf(os.Stderr, "cannot close %s: cannot mark an issue as duplicate of itself\n", id)
+				continue
+			}
 
 			// Delegate the is_blocked guard to the engine (GH#962). CloseIssueChecked
 			// runs the guard and the close in ONE transaction, so there is no
-			// read-then-write TOCTOU window between the check and the close. --force
-			// bypasses the guard; ExpectedVersion is unused on this path.
+			// read-then-write TOCTOU window between the check and the close. The
+			// --duplicate-of link rides in the same transaction: a refused close
+			// leaves no duplicates edge, and a failed link leaves the issue open.
+			// --force bypasses the guard; ExpectedVersion is unused on this path.
 			res, err := activeStore.CloseIssueChecked(ctx, id, actor, storage.CloseIssueOptions{
-				Reason:  reason,
-				Session: session,
-				Force:   force,
+				Reason:      reason,
+				Session:     session,
+				Force:       force,
+				DuplicateOf: duplicateOf,
 			})
 			if err != nil {
 				if errors.Is(err, storage.ErrCloseBlocked) {
@@ -164,6 +205,12 @@ the flags appear in the command line.`,
 				}
 				continue
 			}
+			// An already-closed issue still gained its duplicates edge.
+			if duplicateOf != "" && res.Unchanged {
+				mutatedStores[activeStore] = append(mutatedStores[activeStore], id)
+			}
+			inReview := false
+			var followups []string
 			if res.Unchanged {
 				// Already closed: an idempotent no-op on the step's stored state. The
 				// old CloseIssue path also returned nil here and still reported the
@@ -208,6 +255,9 @@ the flags appear in the command line.`,
 				// Auto-close parent molecule if all steps are now complete.
 				// Runs against the same store the step was closed in.
 				autoCloseCompletedMolecule(ctx, activeStore, id, actor, session)
+
+				inReview, followups = runPostCloseHooks(ctx, activeStore, id, issue, actor)
+				mutatedStores[activeStore] = append(mutatedStores[activeStore], followups...)
 			}
 
 			// First id this command settled as closed — a real close or an
@@ -227,6 +277,12 @@ the flags appear in the command line.`,
 				}
 			} else {
 				debug.PrintNormal("%s Closed %s: %s\n", ui.RenderPass("✓"), formatFeedbackID(id, issueTitleOrEmpty(issue)), reason)
+				if inReview {
+					debug.PrintNormal("  %s waiting for human review (bd review list)\n", ui.RenderWarn("→"))
+				}
+				for _, f := range followups {
+					debug.PrintNormal("  %s follow-up %s created\n", ui.RenderAccent("→"), f)
+				}
 			}
 		}
 
@@ -391,7 +447,8 @@ func init() {
 	closeCmd.Flags().String("comment", "", "Alias for --reason")
 	_ = closeCmd.Flags().MarkHidden("comment") // Hidden alias for agent/CLI ergonomics
 	closeCmd.Flags().String("reason-file", "", "Read close reason from file (use - for stdin)")
-	closeCmd.Flags().BoolP("force", "f", false, "Force close pinned issues or unsatisfied gates")
+	closeCmd.Flags().String("duplicate-of", "", "Close as a duplicate of this canonical issue (adds a duplicates link)")
+	closeCmd.Flags().BoolP("force", "f", false, "Force close pinned issues, unsatisfied gates, missing required validations, unlinked duplicates, or wasm policy refusals")
 	closeCmd.Flags().Bool("continue", false, "Auto-advance to next step in molecule")
 	closeCmd.Flags().Bool("no-auto", false, "With --continue, show next step but don't claim it")
 	closeCmd.Flags().Bool("suggest-next", false, "Show newly unblocked issues after closing")
@@ -534,6 +591,42 @@ func isMachineCheckableGate(issue *types.Issue) bool {
 	}
 }
 
+// checkClosePolicies runs the checks every close path makes before closing
+// issue with reason, unless --force: gate satisfaction for machine-checkable
+// gates (GH#1467), the validations the issue type requires, the canonical
+// link a duplicate close needs under validation.close-reason, the
+// workspace's wasm close policies, and unchecked acceptance criteria.
+// duplicateLinked reports whether the issue already has a duplicates link;
+// it is nil when --duplicate-of adds one with the close.
+func checkClosePolicies(id string, issue *types.Issue, reason string, duplicateLinked func() (bool, error)) error {
+	if err := checkGateSatisfaction(issue); err != nil {
+		return err
+	}
+	if err := checkRequiredValidations(issue); err != nil {
+		return err
+	}
+	if duplicateLinked != nil {
+		if err := checkDuplicateCloseLink(id, reason, duplicateLinked); err != nil {
+			return err
+		}
+	}
+	if err := checkWasmClosePolicies(issue, reason); err != nil {
+		return err
+	}
+	return checkAcceptanceCriteria(issue, reason)
+}
+
+// runPostCloseHooks does the work that follows a real close on every direct
+// close path: refreshing the quality score, entering the review queue (bd
+// review), and firing close follow-ups (bd followup). issue is the issue as
+// read before the close. It reports whether the issue went to review and
+// returns the IDs of the follow-up work it created.
+func runPostCloseHooks(ctx context.Context, s storage.DoltStorage, id string, issue *types.Issue, closedBy string) (bool, []string) {
+	refreshQualityScoreOnEvent(ctx, s, id, "close")
+	inReview := enterReviewOnClose(ctx, s, issue, closedBy)
+	return inReview, fireFollowupsOnClose(ctx, s, issue, storage.Now(ctx))
+}
+
 // checkGateSatisfaction checks whether a gate issue's condition is satisfied.
 // Returns nil if the gate is satisfied (or not a machine-checkable gate), or an error describing why it cannot be closed.
 func checkGateSatisfaction(issue *types.Issue) error {
@@ -1,9 +1,15 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -201,3 +207,78 @@ func TestCheckGateSatisfaction_ErrorMessageFormat(t *testing.T) {
 		t.Errorf("error message should mention 'gate condition not satisfied', got: %s", errMsg)
 	}
 }
+
+func TestCheckProxiedCloseHooksRefusesUnfiredFollowups(t *testing.T) {
+	initConfigForTest(t)
+
+	issue := &types.Issue{ID: "bd-1", Metadata: json.RawMessage(`{"followups":[{"on":"close","template":"mol-retro"}]}`)}
+	err := checkProxiedCloseHooks(context.Background(), nil, issue)
+	if err == nil || !strings.Contains(err.Error(), "mol-retro") {
+		t.Fatalf("checkProxiedCloseHooks = %v, want the unfired follow-up refused", err)
+	}
+
+	issue.Metadata = json.RawMessage(`{"followups":[{"on":"close","template":"mol-retro","fired_at":"2026-01-01T00:00:00Z"}]}`)
+	if err := checkProxiedCloseHooks(context.Background(), nil, issue); err != nil {
+		t.Errorf("fired follow-up refused the close: %v", err)
+	}
+}
+
+func TestCheckClosePoliciesRequiresDuplicateLink(t *testing.T) {
+	initConfigForTest(t)
+	config.Set("validation.close-reason", "error")
+
+	issue := &types.Issue{ID: "bd-2", IssueType: types.TypeTask}
+	unlinked := func() (bool, error) { return false, nil }
+	if err := checkClosePolicies(issue.ID, issue, "duplicate: bd-1", unlinked); err == nil {
+		t.Error("unlinked duplicate close passed the close policies")
+	}
+	linked := func() (bool, error) { return true, nil }
+	if err := checkClosePolicies(issue.ID, issue, "duplicate: bd-1", linked); err != nil {
+		t.Errorf("linked duplicate close refused: %v", err)
+	}
+}
+
+func TestCheckClosePoliciesRefusesUncheckedAcceptance(t *testing.T) {
+	initConfigForTest(t)
+	config.Set("validation.acceptance", "error")
+
+	issue := &types.Issue{ID: "bd-3", IssueType: types.TypeTask, AcceptanceCriteria: "- [x] parser fixed\n- [ ] regression test"}
+	if err := checkClosePolicies(issue.ID, issue, "fixed: parser", nil); err == nil {
+		t.Error("close with an unchecked acceptance item passed the close policies")
+	}
+	if err := checkClosePolicies(issue.ID, issue, "wontfix: obsolete parser", nil); err != nil {
+		t.Errorf("wontfix close refused over acceptance criteria: %v", err)
+	}
+	issue.AcceptanceCriteria = "- [x] parser fixed\n- [x] regression test"
+	if err := checkClosePolicies(issue.ID, issue, "fixed: parser", nil); err != nil {
+		t.Errorf("fully checked close refused: %v", err)
+	}
+}
+
+func TestCheckClosePoliciesConsultsWasmPolicies(t *testing.T) {
+	initConfigForTest(t)
+	beadsDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(beadsDir, "plugins"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "plugins", "bad.wasm"), []byte("not wasm"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BEADS_DIR", beadsDir)
+	oldRootCtx := rootCtx
+	rootCtx = context.Background()
+	t.Cleanup(func() { rootCtx = oldRootCtx })
+	resetWasmHooks := func() { wasmHooksOnce, wasmHooks, wasmHooksErr = sync.Once{}, nil, nil }
+	resetWasmHooks()
+	t.Cleanup(resetWasmHooks)
+
+	// A workspace whose close policies cannot be loaded must not let the
+	// close through on either close path.
+	issue := &types.Issue{ID: "bd-4", IssueType: types.TypeTask}
+	if err := checkClosePolicies(issue.ID, issue, "fixed: parser", nil); err == nil {
+		t.Error("close passed although the workspace's wasm close policies failed to load")
+	}
+}
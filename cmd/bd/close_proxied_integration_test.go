@@ -350,6 +350,36 @@ func TestProxiedServerClose(t *testing.T) {
 		}
 	})
 
+	t.Run("close_duplicate_of_links_canonical", func(t *testing.T) {
+		t.Parallel()
+		p := newSharedProxiedProject(t, bd, "cdo")
+		canonical := bdProxiedCreate(t, bd, p.dir, "Canonical")
+		dup := bdProxiedCreate(t, bd, p.dir, "Duplicate")
+		bdProxiedClose(t, bd, p.dir, dup.ID, "--duplicate-of", canonical.ID)
+		db := openProxiedDB(t, p)
+		if got := readCloseReason(t, db, dup.ID); got != "duplicate: "+canonical.ID {
+			t.Errorf("close_reason: got %q, want %q", got, "duplicate: "+canonical.ID)
+		}
+		assertProxiedDepExistsWithType(t, db, dup.ID, canonical.ID, string(types.DepDuplicates))
+	})
+
+	t.Run("close_duplicate_of_refused_leaves_no_link", func(t *testing.T) {
+		t.Parallel()
+		p := newSharedProxiedProject(t, bd, "cdor")
+		canonical := bdProxiedCreate(t, bd, p.dir, "Canonical")
+		blocker := bdProxiedCreate(t, bd, p.dir, "Blocker")
+		dup := bdProxiedCreate(t, bd, p.dir, "Blocked duplicate", "--deps", "depends-on:"+blocker.ID)
+		bdProxiedCloseFail(t, bd, p.dir, dup.ID, "--duplicate-of", canonical.ID)
+		db := openProxiedDB(t, p)
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM dependencies WHERE issue_id = ? AND type = ?", dup.ID, string(types.DepDuplicates)).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Errorf("refused close left %d duplicates link(s)", count)
+		}
+	})
+
 	t.Run("close_blocked_with_force", func(t *testing.T) {
 		t.Parallel()
 		p := newSharedProxiedProject(t, bd, "cbf")
@@ -26,6 +26,7 @@ type closeProxiedInput struct {
 	claimNext   bool
 	session     string
 	jsonOut     bool
+	duplicateOf string
 }
 
 type closeProxiedOutcome struct {
@@ -58,11 +59,17 @@ func runCloseProxiedServer(cmd *cobra.Command, ctx context.Context, args []strin
 		return HandleErrorRespectJSON("%v", err)
 	}
 	args = updatedArgs
+
+	in := gatherCloseProxiedInput(cmd)
+	if in.duplicateOf != "" && len(reasons) == 1 && reasons[0] == "Closed" {
+		reasons = []string{types.CloseReasonDuplicate + ": " + in.duplicateOf}
+	}
 	if err := validateCloseReasons(reasons); err != nil {
 		return HandleErrorRespectJSON("%v", err)
 	}
-
-	in := gatherCloseProxiedInput(cmd)
+	if err := validateCloseReasonCategories(reasons); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
 
 	if in.continueOn && len(args) > 1 {
 		return HandleErrorRespectJSON("--continue only works when closing a single issue")
@@ -78,9 +85,18 @@ func runCloseProxiedServer(cmd *cobra.Command, ctx context.Context, args []strin
 	res, err := uow.RunTxResult(ctx, uowProvider, func(ctx context.Context, uw uow.UnitOfWork) (closeProxiedTxResult, string, error) {
 		var result closeProxiedTxResult
 
+		if in.duplicateOf != "" {
+			if canonical, _ := proxiedResolveIssueOrWisp(ctx, uw, in.duplicateOf); canonical == nil {
+				return result, "", fmt.Errorf("resolving --duplicate-of %s: issue not found", in.duplicateOf)
+			}
+		}
+
 		for i, id := range args {
 			reason := reasonForCloseIndex(reasons, i)
-			outcome, ok := closeProxiedOne(ctx, uw, id, reason, in, &result.errors)
+			outcome, ok, err := closeProxiedOne(ctx, uw, id, reason, in, &result.errors)
+			if err != nil {
+				return result, "", err
+			}
 			if ok {
 				mol := autoCloseProxiedCompletedMolecule(ctx, uw, id, actor, in.session, &result.warnings)
 				if mol != nil {
@@ -199,19 +215,23 @@ func gatherCloseProxiedInput(cmd *cobra.Command) closeProxiedInput {
 		in.session = os.Getenv("CLAUDE_SESSION_ID")
 	}
 	in.jsonOut, _ = cmd.Flags().GetBool("json")
+	in.duplicateOf, _ = cmd.Flags().GetString("duplicate-of")
 	return in
 }
 
-func closeProxiedOne(ctx context.Context, uw uow.UnitOfWork, id, reason string, in closeProxiedInput, errs *[]string) (closeProxiedOutcome, bool) {
+// closeProxiedOne closes id inside the unit of work, applying the same
+// pre-close policies as a direct close. A refusal is appended to errs; an
+// error aborts the whole unit of work.
+func closeProxiedOne(ctx context.Context, uw uow.UnitOfWork, id, reason string, in closeProxiedInput, errs *[]string) (closeProxiedOutcome, bool, error) {
 	current, isWisp := proxiedResolveIssueOrWisp(ctx, uw, id)
 	if current == nil {
 		*errs = append(*errs, fmt.Sprintf("Issue %s not found", id))
-		return closeProxiedOutcome{}, false
+		return closeProxiedOutcome{}, false, nil
 	}
 
 	if err := validateIssueClosable(id, current, actor, in.force); err != nil {
 		*errs = append(*errs, err.Error())
-		return closeProxiedOutcome{}, false
+		return closeProxiedOutcome{}, false, nil
 	}
 
 	if !in.force && current.IssueType == types.TypeEpic {
@@ -224,16 +244,28 @@ func closeProxiedOne(ctx context.Context, uw uow.UnitOfWork, id, reason string,
 		}
 		if err == nil && openChildren > 0 {
 			*errs = append(*errs, fmt.Sprintf("cannot close epic %s: %d open child issue(s); close children first or use --force to override", id, openChildren))
-			return closeProxiedOutcome{}, false
+			return closeProxiedOutcome{}, false, nil
 		}
 	}
 
 	if !in.force {
-		if err := checkGateSatisfaction(current); err != nil {
+		var duplicateLinked func() (bool, error)
+		if in.duplicateOf == "" {
+			duplicateLinked = func() (bool, error) { return proxiedHasDuplicateLink(ctx, uw, id) }
+		}
+		if err := checkClosePolicies(id, current, reason, duplicateLinked); err != nil {
 			*errs = append(*errs, fmt.Sprintf("cannot close %s: %s", id, err))
-			return closeProxiedOutcome{}, false
+			return closeProxiedOutcome{}, false, nil
 		}
 	}
+	if err := checkProxiedCloseHooks(ctx, uw, current); err != nil {
+		*errs = append(*errs, fmt.Sprintf("cannot close %s: %s", id, err))
+		return closeProxiedOutcome{}, false, nil
+	}
+	if in.duplicateOf == id {
+		*errs = append(*errs, fmt.Sprintf("cannot close %s: cannot mark an issue as duplicate of itself", id))
+		return closeProxiedOutcome{}, false, nil
+	}
 
 	params := domain.CloseIssueParams{Reason: reason, Session: in.session}
 	var (
@@ -254,7 +286,21 @@ func closeProxiedOne(ctx context.Context, uw uow.UnitOfWork, id, reason string,
 		} else {
 			*errs = append(*errs, fmt.Sprintf("Error closing %s: %v", id, err))
 		}
-		return closeProxiedOutcome{}, false
+		return closeProxiedOutcome{}, false, nil
+	}
+
+	// Link the canonical issue in the same unit of work, after the close,
+	// so a refused close leaves no duplicates edge behind.
+	if in.duplicateOf != "" {
+		dep := &types.Dependency{IssueID: id, DependsOnID: in.duplicateOf, Type: types.DepDuplicates}
+		if isWisp {
+			err = uw.DependencyUseCase().AddWispDependency(ctx, dep, actor)
+		} else {
+			err = uw.DependencyUseCase().AddDependency(ctx, dep, actor)
+		}
+		if err != nil {
+			return closeProxiedOutcome{}, false, fmt.Errorf("linking %s as duplicate of %s: %w", id, in.duplicateOf, err)
+		}
 	}
 
 	oldStatus := string(current.Status)
@@ -269,7 +315,51 @@ func closeProxiedOne(ctx context.Context, uw uow.UnitOfWork, id, reason string,
 		closed:      res.Closed,
 		auditOld:    oldStatus,
 		auditReason: reason,
-	}, true
+	}, true, nil
+}
+
+// proxiedHasDuplicateLink reports whether id already has a duplicates
+// dependency.
+func proxiedHasDuplicateLink(ctx context.Context, uw uow.UnitOfWork, id string) (bool, error) {
+	deps, err := uw.DependencyUseCase().GetForIssueIDs(ctx, []string{id})
+	if err != nil {
+		return false, err
+	}
+	for _, d := range deps[id] {
+		if d.Type == types.DepDuplicates {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkProxiedCloseHooks refuses a close whose post-close work proxied-server
+// mode cannot do: entering the review queue (bd review) and firing close
+// follow-ups (bd followup). Closing the issue without them would drop the
+// review or the follow-up work silently, so --force does not override this.
+func checkProxiedCloseHooks(ctx context.Context, uw uow.UnitOfWork, issue *types.Issue) error {
+	if p := loadReviewPolicy(); p.Enabled && isAgentActor(p, actor) {
+		if len(p.Labels) > 0 && len(issue.Labels) == 0 {
+			labels, err := uw.LabelUseCase().GetLabels(ctx, issue.ID)
+			if err != nil {
+				return fmt.Errorf("reading labels: %w", err)
+			}
+			issue.Labels = labels
+		}
+		if p.Selects(issue) {
+			return fmt.Errorf("review.enabled puts this close in the review queue, which proxied-server mode does not support; close it with direct database access")
+		}
+	}
+	rules, err := types.FollowupsFromMetadata(issue.Metadata)
+	if err != nil {
+		return fmt.Errorf("reading follow-ups: %w", err)
+	}
+	for _, r := range rules {
+		if r.On == types.FollowupOnClose && !r.Fired() {
+			return fmt.Errorf("close follow-up %s cannot be fired in proxied-server mode; close it with direct database access", r.Template)
+		}
+	}
+	return nil
 }
 
 func closeProxiedCommitMessage(outcomes []closeProxiedOutcome, claimed *types.Issue, cont *ContinueResult) string {
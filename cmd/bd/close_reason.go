@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// closeReasonCategories returns the close reason taxonomy: the close.reasons
+// config list, or types.DefaultCloseReasonCategories when unset.
+func closeReasonCategories() []string {
+	raw := config.GetString("close.reasons")
+	var categories []string
+	for _, c := range strings.Split(raw, ",") {
+		if c = strings.ToLower(strings.TrimSpace(c)); c != "" {
+			categories = append(categories, c)
+		}
+	}
+	if len(categories) == 0 {
+		return types.DefaultCloseReasonCategories
+	}
+	return categories
+}
+
+// closeReasonMode returns validation.close-reason: "error" rejects close
+// reasons outside the taxonomy (and duplicate closes without a canonical
+// link), "warn" reports them, anything else disables the check.
+func closeReasonMode() string {
+	switch mode := config.GetString("validation.close-reason"); mode {
+	case "error", "warn":
+		return mode
+	default:
+		return "none"
+	}
+}
+
+// validateCloseReasonCategories checks each close reason against the
+// taxonomy according to validation.close-reason.
+func validateCloseReasonCategories(reasons []string) error {
+	mode := closeReasonMode()
+	if mode == "none" {
+		return nil
+	}
+	categories := closeReasonCategories()
+	for _, reason := range reasons {
+		if c := types.CloseReasonCategory(reason, categories); c != types.CloseReasonOther && c != types.CloseReasonNone {
+			continue
+		}
+		err := fmt.Errorf("close reason %q does not start with a known category (%s); e.g. --reason \"fixed: <what was done>\"",
+			reason, strings.Join(categories, ", "))
+		if mode == "error" {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s %v\n", ui.RenderWarn("⚠"), err)
+	}
+	return nil
+}
+
+// checkDuplicateCloseLink requires a duplicate close to be linked to its
+// canonical issue (bd close --duplicate-of, or an existing duplicates
+// dependency) when validation.close-reason is enabled.
+func checkDuplicateCloseLink(id, reason string, linked func() (bool, error)) error {
+	mode := closeReasonMode()
+	if mode == "none" || types.CloseReasonCategory(reason, closeReasonCategories()) != types.CloseReasonDuplicate {
+		return nil
+	}
+	ok, err := linked()
+	if err != nil {
+		return fmt.Errorf("checking duplicate link: %w", err)
+	}
+	if ok {
+		return nil
+	}
+	err = fmt.Errorf("duplicate close needs a link to the canonical issue (use --duplicate-of <id>)")
+	if mode == "error" {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%s %s: %v\n", ui.RenderWarn("⚠"), id, err)
+	return nil
+}
+
+// hasDuplicateLink reports whether id already has a duplicates dependency.
+func hasDuplicateLink(ctx context.Context, s storage.DoltStorage, id string) (bool, error) {
+	deps, err := s.GetDependenciesWithMetadata(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	for _, d := range deps {
+		if d.DependencyType == types.DepDuplicates {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// foldCloseReasonCounts folds per-reason counts into taxonomy categories.
+func foldCloseReasonCounts(raw map[string]int) map[string]int {
+	categories := closeReasonCategories()
+	counts := make(map[string]int, len(categories))
+	for reason, n := range raw {
+		counts[types.CloseReasonCategory(reason, categories)] += n
+	}
+	return counts
+}
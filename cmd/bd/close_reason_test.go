@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestValidateCloseReasonCategories(t *testing.T) {
+	initConfigForTest(t)
+
+	if err := validateCloseReasonCategories([]string{"Closed"}); err != nil {
+		t.Errorf("taxonomy is not enforced by default, got %v", err)
+	}
+
+	config.Set("validation.close-reason", "error")
+	if err := validateCloseReasonCategories([]string{"fixed: parser", "duplicate"}); err != nil {
+		t.Errorf("known categories rejected: %v", err)
+	}
+	if err := validateCloseReasonCategories([]string{"fixed", "Closed"}); err == nil {
+		t.Error("expected an error for a reason outside the taxonomy")
+	}
+
+	config.Set("close.reasons", "shipped, dropped")
+	if got := closeReasonCategories(); len(got) != 2 || got[0] != "shipped" {
+		t.Fatalf("closeReasonCategories = %v", got)
+	}
+	if err := validateCloseReasonCategories([]string{"fixed"}); err == nil {
+		t.Error("expected fixed to be rejected by a custom taxonomy")
+	}
+
+	config.Set("validation.close-reason", "warn")
+	if err := validateCloseReasonCategories([]string{"fixed"}); err != nil {
+		t.Errorf("warn mode should not fail, got %v", err)
+	}
+}
+
+func TestFoldCloseReasonCounts(t *testing.T) {
+	initConfigForTest(t)
+
+	got := foldCloseReasonCounts(map[string]int{
+		"fixed":              2,
+		"Fixed: parser bug":  1,
+		"duplicate: bd-1":    1,
+		"Closed":             3,
+		"":                   1,
+		"wontfix no traffic": 1,
+	})
+	want := map[string]int{
+		types.CloseReasonFixed:     3,
+		types.CloseReasonDuplicate: 1,
+		types.CloseReasonWontFix:   1,
+		types.CloseReasonOther:     3,
+		types.CloseReasonNone:      1,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %d, want %d", k, got[k], v)
+		}
+	}
+}
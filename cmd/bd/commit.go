@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var (
+	commitMessage string
+	commitDryRun  bool
+)
+
+var commitCmd = &cobra.Command{
+	Use:     "commit",
+	GroupID: "sync",
+	Short:   "Commit pending writes to Dolt history now",
+	Long: `Commit every write still pending in the working set as one Dolt commit.
+
+With auto-commit in batch mode, writes are visible right away but are only
+committed once commit.batch_interval or commit.batch_max_ops is reached
+(see 'bd config --help'). Run bd commit at a logical boundary, such as the
+end of an agent's task, to commit them without waiting. The default
+message lists how many writes are in the batch and which issues they
+touched.
+
+Examples:
+  bd commit                           # Commit the pending batch
+  bd commit -m "Triage pass"          # With your own message
+  bd commit --dry-run                 # Show what is pending`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("commit is not supported in proxied-server mode")
+		}
+		if !commitDryRun {
+			CheckReadonly("commit")
+		}
+		evt := metrics.NewCommandEvent("commit")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		ctx := rootCtx
+		var pending *storage.PendingChanges
+		if reader, ok := storage.UnwrapStore(store).(storage.PendingChangesReader); ok {
+			p, err := reader.PendingChanges(ctx)
+			if err != nil {
+				return HandleErrorRespectJSON("failed to read pending changes: %v", err)
+			}
+			pending = p
+		}
+		msg := commitMessage
+		if msg == "" {
+			if pending != nil {
+				msg = formatBatchCommitMessage(getActor(), pending)
+			} else {
+				msg = fmt.Sprintf("bd: commit by %s", getActor())
+			}
+		}
+
+		result := map[string]interface{}{
+			"committed": false,
+			"message":   msg,
+		}
+		if pending != nil {
+			result["operations"] = pending.Operations
+			result["issues"] = pending.IssueIDs
+		}
+
+		if commitDryRun {
+			result["dry_run"] = true
+			if jsonOutput {
+				return outputJSON(result)
+			}
+			if pending == nil {
+				fmt.Printf("Would commit: %s\n", msg)
+				return nil
+			}
+			if pending.Operations == 0 && len(pending.IssueIDs) == 0 {
+				fmt.Println("Nothing to commit")
+				return nil
+			}
+			fmt.Printf("Would commit %d pending write(s) touching %d issue(s): %s\n",
+				pending.Operations, len(pending.IssueIDs), msg)
+			return nil
+		}
+
+		commandDidExplicitDoltCommit = true
+		if err := store.Commit(ctx, msg); err != nil {
+			if isDoltNothingToCommit(err) {
+				if jsonOutput {
+					return outputJSON(result)
+				}
+				fmt.Println("Nothing to commit")
+				return nil
+			}
+			return HandleErrorRespectJSON("failed to commit: %v", err)
+		}
+
+		hash, err := store.GetCurrentCommit(ctx)
+		if err != nil {
+			hash = "(unknown)"
+		}
+		result["committed"] = true
+		result["hash"] = hash
+		if jsonOutput {
+			return outputJSON(result)
+		}
+		fmt.Printf("Created commit %s: %s\n", ui.RenderMuted(shortCommitHash(hash)), msg)
+		return nil
+	},
+}
+
+func init() {
+	commitCmd.Flags().StringVarP(&commitMessage, "message", "m", "", "Commit message (default: a summary of the pending writes)")
+	commitCmd.Flags().BoolVar(&commitDryRun, "dry-run", false, "Show what would be committed without committing")
+	rootCmd.AddCommand(commitCmd)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/compact"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/progress"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -48,7 +49,8 @@ actively referenced. This is permanent graceful decay - original content is disc
 
 Modes:
   - Analyze: Export candidates for agent review (no API key needed)
-  - Apply: Accept agent-provided summary (no API key needed)
+  - Apply: Accept agent-provided summary, or the issue's current bd summarize
+    result when --summary is omitted (no API key needed)
   - Auto: AI-powered compaction (requires ANTHROPIC_API_KEY or ai.api_key, legacy)
   - Dolt: Run Dolt garbage collection (for Dolt-backend repositories)
 
@@ -72,6 +74,7 @@ Examples:
   bd compact --analyze --json              # Get candidates with full content
   bd compact --apply --id bd-42 --summary summary.txt
   bd compact --apply --id bd-42 --summary - < summary.txt
+  bd compact --apply --id bd-42            # Use the stored bd summarize result
 
   # Legacy AI-powered workflow
   bd compact --auto --dry-run              # Preview candidates
@@ -104,6 +107,9 @@ Examples:
 
 		// Handle dolt GC mode
 		if compactDolt {
+			if rs, ok := storage.UnwrapStore(store).(storage.RemoteServerReporter); ok && rs.IsRemoteServer() {
+				return runCompactDoltRemote(ctx)
+			}
 			return runCompactDolt()
 		}
 
@@ -149,9 +155,6 @@ Examples:
 			if compactID == "" {
 				return HandleError("--apply requires --id")
 			}
-			if compactSummary == "" {
-				return HandleError("--apply requires --summary")
-			}
 			return runCompactApply(ctx, store)
 		}
 
@@ -411,7 +414,9 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store stor
 		fmt.Printf("Compacting %d issues (Tier %d)...\n\n", len(candidates), compactTier)
 	}
 
-	results, err := compactor.CompactTier1Batch(ctx, candidates)
+	batchCtx, task := progress.Start(ctx, "compact", len(candidates))
+	results, err := compactor.CompactTier1Batch(batchCtx, candidates)
+	task.Finish()
 	if err != nil {
 		return HandleError("batch compaction failed: %v", err)
 	}
@@ -421,11 +426,7 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store stor
 	totalSaved := 0
 	totalOriginal := 0
 
-	for i, result := range results {
-		if !jsonOutput {
-			fmt.Printf("[%s] %d/%d\r", progressBar(i+1, len(results)), i+1, len(results))
-		}
-
+	for _, result := range results {
 		if result.Err != nil {
 			failCount++
 		} else {
@@ -454,7 +455,7 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store stor
 		return nil
 	}
 
-	fmt.Printf("\n\nCompleted in %v\n\n", elapsed)
+	fmt.Printf("Completed in %v\n\n", elapsed)
 	fmt.Printf("Summary:\n")
 	fmt.Printf("  Succeeded: %d\n", successCount)
 	fmt.Printf("  Failed: %d\n", failCount)
@@ -529,6 +530,7 @@ func runCompactAnalyze(ctx context.Context, store storage.DoltStorage) error {
 		AgeDays            int    `json:"age_days"`
 		Tier               int    `json:"tier"`
 		Compacted          bool   `json:"compacted"`
+		Summary            string `json:"summary,omitempty"` // current bd summarize result
 	}
 
 	var candidates []Candidate
@@ -557,6 +559,7 @@ func runCompactAnalyze(ctx context.Context, store storage.DoltStorage) error {
 			AgeDays:            ageDays,
 			Tier:               compactTier,
 			Compacted:          issue.CompactionLevel > 0,
+			Summary:            currentSummaryText(issue),
 		})
 	} else {
 		// Get tier candidates
@@ -596,6 +599,7 @@ func runCompactAnalyze(ctx context.Context, store storage.DoltStorage) error {
 				AgeDays:            ageDays,
 				Tier:               compactTier,
 				Compacted:          issue.CompactionLevel > 0,
+				Summary:            currentSummaryText(issue),
 			})
 		}
 	}
@@ -641,16 +645,28 @@ func runCompactAnalyze(ctx context.Context, store storage.DoltStorage) error {
 func runCompactApply(ctx context.Context, store storage.DoltStorage) error {
 	start := time.Now()
 
+	// Get issue
+	issue, err := store.GetIssue(ctx, compactID)
+	if err != nil {
+		return HandleError("failed to get issue: %v", err)
+	}
+
 	// Read summary
 	var summaryBytes []byte
-	var err error
-	if compactSummary == "-" {
+	switch compactSummary {
+	case "":
+		stored := currentSummaryText(issue)
+		if stored == "" {
+			return HandleErrorWithHint("--apply requires --summary", fmt.Sprintf("or run 'bd summarize %s' first", compactID))
+		}
+		summaryBytes = []byte(stored)
+	case "-":
 		// Read from stdin
 		summaryBytes, err = io.ReadAll(os.Stdin)
 		if err != nil {
 			return HandleError("failed to read summary from stdin: %v", err)
 		}
-	} else {
+	default:
 		// #nosec G304 -- summary file path provided explicitly by operator
 		summaryBytes, err = os.ReadFile(compactSummary)
 		if err != nil {
@@ -659,12 +675,6 @@ func runCompactApply(ctx context.Context, store storage.DoltStorage) error {
 	}
 	summary := string(summaryBytes)
 
-	// Get issue
-	issue, err := store.GetIssue(ctx, compactID)
-	if err != nil {
-		return HandleError("failed to get issue: %v", err)
-	}
-
 	// Calculate sizes
 	originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
 	compactedSize := len(summary)
@@ -739,6 +749,45 @@ func runCompactApply(ctx context.Context, store storage.DoltStorage) error {
 	return nil
 }
 
+// runCompactDoltRemote runs Dolt garbage collection through SQL on a remote
+// dolt sql-server, whose data directory is not on this machine.
+func runCompactDoltRemote(ctx context.Context) error {
+	if compactDryRun {
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"dry_run":       true,
+				"remote_server": true,
+			})
+		}
+		fmt.Printf("DRY RUN - Dolt garbage collection\n\n")
+		fmt.Printf("The database is on a remote dolt sql-server; GC runs there via CALL DOLT_GC().\n")
+		fmt.Printf("\nRun without --dry-run to perform garbage collection.\n")
+		return nil
+	}
+	gc, ok := storage.UnwrapStore(store).(storage.GarbageCollector)
+	if !ok {
+		return HandleErrorRespectJSON("garbage collection is not supported by this storage backend")
+	}
+	if !jsonOutput {
+		fmt.Printf("Running Dolt garbage collection on the server...\n")
+	}
+	start := time.Now()
+	if err := gc.DoltGC(ctx); err != nil {
+		return HandleErrorRespectJSON("dolt gc failed: %v", err)
+	}
+	elapsed := time.Since(start)
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"success":       true,
+			"remote_server": true,
+			"elapsed_ms":    elapsed.Milliseconds(),
+		})
+	}
+	fmt.Printf("✓ Dolt garbage collection complete\n")
+	fmt.Printf("  Time: %v\n", elapsed)
+	return nil
+}
+
 // runCompactDolt runs Dolt garbage collection on the .beads/dolt directory
 func runCompactDolt() error {
 	start := time.Now()
@@ -922,24 +971,6 @@ func formatBytes(b int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
 }
 
-// progressBar renders a text-based progress bar.
-func progressBar(current, total int) string {
-	const width = 40
-	if total == 0 {
-		return "[" + string(make([]byte, width)) + "]"
-	}
-	filled := (current * width) / total
-	bar := ""
-	for i := 0; i < width; i++ {
-		if i < filled {
-			bar += "█"
-		} else {
-			bar += " "
-		}
-	}
-	return "[" + bar + "]"
-}
-
 func init() {
 	compactCmd.Flags().BoolVar(&compactDryRun, "dry-run", false, "Preview without compacting")
 	compactCmd.Flags().IntVar(&compactTier, "tier", 1, "Compaction tier (only tier 1 is implemented)")
@@ -955,7 +986,7 @@ func init() {
 	compactCmd.Flags().BoolVar(&compactAnalyze, "analyze", false, "Analyze mode: export candidates for agent review")
 	compactCmd.Flags().BoolVar(&compactApply, "apply", false, "Apply mode: accept agent-provided summary")
 	compactCmd.Flags().BoolVar(&compactAuto, "auto", false, "Auto mode: AI-powered compaction (legacy)")
-	compactCmd.Flags().StringVar(&compactSummary, "summary", "", "Path to summary file (use '-' for stdin)")
+	compactCmd.Flags().StringVar(&compactSummary, "summary", "", "Path to summary file (use '-' for stdin; defaults to the stored bd summarize result)")
 	compactCmd.Flags().StringVar(&compactActor, "actor", "agent", "Actor name for audit trail")
 	compactCmd.Flags().IntVar(&compactLimit, "limit", 0, "Limit number of candidates (0 = no limit)")
 	compactCmd.Flags().BoolVar(&compactDolt, "dolt", false, "Dolt mode: run Dolt garbage collection on .beads/dolt")
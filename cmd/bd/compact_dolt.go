@@ -44,7 +44,7 @@ Examples:
   bd compact --days 90 --force       # Conservative: squash 90+ day old commits`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
-	RunE: func(_ *cobra.Command, _ []string) error {
+	RunE: func(cmd *cobra.Command, _ []string) error {
 		if usesProxiedServer() {
 			return HandleErrorRespectJSON("compact is not supported in proxied-server mode")
 		}
@@ -162,6 +162,9 @@ Examples:
 					oldCommits, recentCommits),
 				"Use --force to confirm or --dry-run to preview.")
 		}
+		if err := requireConfirmation(cmd, confirmOpHistory, "compact history", []string{"compact"}); err != nil {
+			return HandleError("%v", err)
+		}
 
 		if !jsonOutput {
 			fmt.Printf("Compacting: %d old commits → 1, preserving %d recent\n",
@@ -173,7 +176,7 @@ Examples:
 			return HandleError("storage backend does not support compact")
 		}
 
-		if err := compactor.Compact(ctx, initialHash, boundaryHash, oldCommits, recentHashes); err != nil {
+		if _, err := compactor.Compact(ctx, initialHash, boundaryHash, oldCommits, recentHashes); err != nil {
 			return HandleError("compact failed: %v", err)
 		}
 
@@ -226,6 +229,7 @@ func init() {
 	compactDoltCmd.Flags().BoolVar(&compactDoltDryRun, "dry-run", false, "Preview without making changes")
 	compactDoltCmd.Flags().BoolVarP(&compactDoltForce, "force", "f", false, "Confirm commit squash")
 	compactDoltCmd.Flags().IntVar(&compactDoltDays, "days", 30, "Keep commits newer than N days")
+	addConfirmFlags(compactDoltCmd)
 
 	rootCmd.AddCommand(compactDoltCmd)
 }
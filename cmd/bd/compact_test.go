@@ -310,24 +310,6 @@ func TestCompactValidation(t *testing.T) {
 	}
 }
 
-func TestCompactProgressBar(t *testing.T) {
-	// Test progress bar formatting
-	pb := progressBar(50, 100)
-	if len(pb) == 0 {
-		t.Error("Progress bar should not be empty")
-	}
-
-	pb = progressBar(100, 100)
-	if len(pb) == 0 {
-		t.Error("Full progress bar should not be empty")
-	}
-
-	pb = progressBar(0, 100)
-	if len(pb) == 0 {
-		t.Error("Zero progress bar should not be empty")
-	}
-}
-
 func ptrTime(t time.Time) *time.Time {
 	return &t
 }
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// fieldChange is one field that differs between the two sides of a comparison.
+type fieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// issueChange is an issue-level entry in a branch comparison.
+type issueChange struct {
+	ID     string        `json:"id"`
+	Title  string        `json:"title"`
+	Fields []fieldChange `json:"fields,omitempty"`
+}
+
+// branchComparison is the report produced by bd compare-branch.
+type branchComparison struct {
+	From         string                         `json:"from"`
+	To           string                         `json:"to"`
+	Created      []*issueChange                 `json:"created"`
+	Closed       []*issueChange                 `json:"closed"`
+	Reopened     []*issueChange                 `json:"reopened"`
+	Changed      []*issueChange                 `json:"changed"`
+	Deleted      []*issueChange                 `json:"deleted"`
+	Dependencies []*storage.DependencyDiffEntry `json:"dependencies"`
+	Labels       []*storage.LabelDiffEntry      `json:"labels"`
+}
+
+func (c *branchComparison) empty() bool {
+	return len(c.Created)+len(c.Closed)+len(c.Reopened)+len(c.Changed)+len(c.Deleted)+
+		len(c.Dependencies)+len(c.Labels) == 0
+}
+
+var compareBranchCmd = &cobra.Command{
+	Use:     "compare-branch <a> <b>",
+	GroupID: "views",
+	Short:   "Report issue and graph changes between two branches",
+	Long: `Compare two branches (or any refs) of the tracker and report, issue by
+issue, what changed going from <a> to <b>: issues created, closed, reopened,
+changed (with each changed field), and deleted, plus dependency edges and
+labels added or removed.
+
+Use it to review a large planning edit before merging it, for example a
+branch kept by 'bd simulate --keep'. Changes made on <a> after <b> branched
+off show up as differences too.
+
+Examples:
+  bd compare-branch main plan-q3
+  bd compare-branch main simulate-20250101-120000 --json`,
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("compare-branch is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("compare-branch")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+
+		cmp, err := compareBranches(rootCtx, store, args[0], args[1])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			return outputJSON(cmp)
+		}
+		printBranchComparison(cmp)
+		return nil
+	},
+}
+
+// compareBranches builds the issue-level and graph-level diff from ref a to ref b.
+func compareBranches(ctx context.Context, s storage.DoltStorage, a, b string) (*branchComparison, error) {
+	entries, err := s.Diff(ctx, a, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff issues: %w", err)
+	}
+	cmp := &branchComparison{
+		From:         a,
+		To:           b,
+		Created:      []*issueChange{},
+		Closed:       []*issueChange{},
+		Reopened:     []*issueChange{},
+		Changed:      []*issueChange{},
+		Deleted:      []*issueChange{},
+		Dependencies: []*storage.DependencyDiffEntry{},
+		Labels:       []*storage.LabelDiffEntry{},
+	}
+	for _, e := range entries {
+		switch e.DiffType {
+		case "added":
+			cmp.Created = append(cmp.Created, &issueChange{ID: e.IssueID, Title: diffTitle(e)})
+		case "removed":
+			cmp.Deleted = append(cmp.Deleted, &issueChange{ID: e.IssueID, Title: diffTitle(e)})
+		case "modified":
+			if e.OldValue == nil || e.NewValue == nil {
+				continue
+			}
+			change := &issueChange{ID: e.IssueID, Title: e.NewValue.Title, Fields: issueFieldChanges(e.OldValue, e.NewValue)}
+			wasClosed := e.OldValue.Status == types.StatusClosed
+			isClosed := e.NewValue.Status == types.StatusClosed
+			switch {
+			case isClosed && !wasClosed:
+				cmp.Closed = append(cmp.Closed, change)
+			case wasClosed && !isClosed:
+				cmp.Reopened = append(cmp.Reopened, change)
+			case len(change.Fields) > 0:
+				cmp.Changed = append(cmp.Changed, change)
+			}
+		}
+	}
+
+	if gd, ok := storage.UnwrapStore(s).(storage.GraphDiffer); ok {
+		deps, err := gd.DiffDependencies(ctx, a, b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff dependencies: %w", err)
+		}
+		if deps != nil {
+			cmp.Dependencies = deps
+		}
+		labels, err := gd.DiffLabels(ctx, a, b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff labels: %w", err)
+		}
+		if labels != nil {
+			cmp.Labels = labels
+		}
+	}
+
+	for _, list := range [][]*issueChange{cmp.Created, cmp.Closed, cmp.Reopened, cmp.Changed, cmp.Deleted} {
+		sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	}
+	sort.Slice(cmp.Dependencies, func(i, j int) bool {
+		di, dj := cmp.Dependencies[i], cmp.Dependencies[j]
+		if di.IssueID != dj.IssueID {
+			return di.IssueID < dj.IssueID
+		}
+		return di.DependsOnID < dj.DependsOnID
+	})
+	sort.Slice(cmp.Labels, func(i, j int) bool {
+		if cmp.Labels[i].IssueID != cmp.Labels[j].IssueID {
+			return cmp.Labels[i].IssueID < cmp.Labels[j].IssueID
+		}
+		return cmp.Labels[i].Label < cmp.Labels[j].Label
+	})
+	return cmp, nil
+}
+
+func diffTitle(e *storage.DiffEntry) string {
+	if e.NewValue != nil {
+		return e.NewValue.Title
+	}
+	if e.OldValue != nil {
+		return e.OldValue.Title
+	}
+	return ""
+}
+
+// issueFieldChanges lists the compared fields that differ between old and new.
+func issueFieldChanges(old, new *types.Issue) []fieldChange {
+	var changes []fieldChange
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, fieldChange{Field: field, From: from, To: to})
+		}
+	}
+	add("title", old.Title, new.Title)
+	add("status", string(old.Status), string(new.Status))
+	add("priority", fmt.Sprintf("P%d", old.Priority), fmt.Sprintf("P%d", new.Priority))
+	add("type", string(old.IssueType), string(new.IssueType))
+	add("assignee", old.Assignee, new.Assignee)
+	if old.Description != new.Description {
+		// Descriptions are too long to show inline; report that they differ.
+		changes = append(changes, fieldChange{Field: "description", From: "…", To: "…"})
+	}
+	return changes
+}
+
+func printBranchComparison(c *branchComparison) {
+	fmt.Printf("\n%s Comparing %s → %s\n\n", ui.RenderAccent("📊"), ui.RenderMuted(c.From), ui.RenderMuted(c.To))
+	if c.empty() {
+		fmt.Println("No differences")
+		return
+	}
+
+	section := func(title, mark string, list []*issueChange) {
+		if len(list) == 0 {
+			return
+		}
+		fmt.Printf("%s (%d):\n", title, len(list))
+		for _, ch := range list {
+			fmt.Printf("  %s %s\n", mark, formatFeedbackID(ch.ID, ch.Title))
+			for _, f := range ch.Fields {
+				if f.Field == "description" {
+					fmt.Printf("      %s\n", ui.RenderMuted("description changed"))
+					continue
+				}
+				fmt.Printf("      %s: %s → %s\n", f.Field, orNone(f.From), orNone(f.To))
+			}
+		}
+		fmt.Println()
+	}
+	section("Created", ui.RenderPass("+"), c.Created)
+	section("Closed", ui.RenderPass("✓"), c.Closed)
+	section("Reopened", ui.RenderWarn("↺"), c.Reopened)
+	section("Changed", ui.RenderAccent("~"), c.Changed)
+	section("Deleted", ui.RenderFail("-"), c.Deleted)
+
+	if len(c.Dependencies) > 0 {
+		fmt.Printf("Dependencies (%d):\n", len(c.Dependencies))
+		for _, d := range c.Dependencies {
+			switch d.DiffType {
+			case "added":
+				fmt.Printf("  %s %s → %s (%s)\n", ui.RenderPass("+"), d.IssueID, d.DependsOnID, d.Type)
+			case "removed":
+				fmt.Printf("  %s %s → %s (%s)\n", ui.RenderFail("-"), d.IssueID, d.DependsOnID, d.Type)
+			default:
+				fmt.Printf("  %s %s → %s (%s → %s)\n", ui.RenderAccent("~"), d.IssueID, d.DependsOnID, d.OldType, d.Type)
+			}
+		}
+		fmt.Println()
+	}
+	if len(c.Labels) > 0 {
+		fmt.Printf("Labels (%d):\n", len(c.Labels))
+		for _, l := range c.Labels {
+			mark := ui.RenderPass("+")
+			if l.DiffType == "removed" {
+				mark = ui.RenderFail("-")
+			}
+			fmt.Printf("  %s %s %s\n", mark, l.IssueID, l.Label)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(ui.RenderMuted(fmt.Sprintf("%d created, %d closed, %d reopened, %d changed, %d deleted, %d dependency and %d label changes",
+		len(c.Created), len(c.Closed), len(c.Reopened), len(c.Changed), len(c.Deleted), len(c.Dependencies), len(c.Labels))))
+}
+
+func orNone(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func init() {
+	rootCmd.AddCommand(compareBranchCmd)
+}
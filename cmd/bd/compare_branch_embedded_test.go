@@ -0,0 +1,74 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedCompareBranch(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt compare-branch tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "cmp")
+
+	keep := bdCreateSilent(t, bd, dir, "Keep", "--priority", "2")
+	done := bdCreateSilent(t, bd, dir, "Done")
+	before := getCommitHash(t, beadsDir, "cmp")
+
+	added := bdCreateSilent(t, bd, dir, "Added")
+	for _, args := range [][]string{
+		{"close", done},
+		{"update", keep, "--priority", "0", "--assignee", "alice"},
+		{"dep", "add", added, keep},
+		{"label", "add", keep, "q3"},
+	} {
+		if out, err := bdRunWithFlockRetry(t, bd, dir, args...); err != nil {
+			t.Fatalf("bd %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	after := getCommitHash(t, beadsDir, "cmp")
+
+	out, err := bdRunWithFlockRetry(t, bd, dir, "compare-branch", before, after, "--json")
+	if err != nil {
+		t.Fatalf("bd compare-branch failed: %v\n%s", err, out)
+	}
+	var cmp branchComparison
+	if err := json.Unmarshal(out[strings.Index(string(out), "{"):], &cmp); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out)
+	}
+
+	if len(cmp.Created) != 1 || cmp.Created[0].ID != added {
+		t.Errorf("created = %+v, want [%s]", cmp.Created, added)
+	}
+	if len(cmp.Closed) != 1 || cmp.Closed[0].ID != done {
+		t.Errorf("closed = %+v, want [%s]", cmp.Closed, done)
+	}
+	if len(cmp.Changed) != 1 || cmp.Changed[0].ID != keep {
+		t.Fatalf("changed = %+v, want [%s]", cmp.Changed, keep)
+	}
+	fields := map[string]string{}
+	for _, f := range cmp.Changed[0].Fields {
+		fields[f.Field] = f.From + "→" + f.To
+	}
+	if fields["priority"] != "P2→P0" || fields["assignee"] != "→alice" {
+		t.Errorf("changed fields = %v", fields)
+	}
+	if len(cmp.Dependencies) != 1 || cmp.Dependencies[0].IssueID != added || cmp.Dependencies[0].DependsOnID != keep || cmp.Dependencies[0].DiffType != "added" {
+		t.Errorf("dependencies = %+v", cmp.Dependencies)
+	}
+	if len(cmp.Labels) != 1 || cmp.Labels[0].Label != "q3" || cmp.Labels[0].DiffType != "added" {
+		t.Errorf("labels = %+v", cmp.Labels)
+	}
+
+	text, err := bdRunWithFlockRetry(t, bd, dir, "compare-branch", before, before)
+	if err != nil || !strings.Contains(string(text), "No differences") {
+		t.Errorf("same-ref comparison: err=%v\n%s", err, text)
+	}
+}
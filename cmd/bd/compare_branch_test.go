@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueFieldChanges(t *testing.T) {
+	old := &types.Issue{Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Description: "x"}
+	same := *old
+	if got := issueFieldChanges(old, &same); len(got) != 0 {
+		t.Errorf("identical issues: %v", got)
+	}
+
+	changed := *old
+	changed.Priority = 0
+	changed.Assignee = "bob"
+	changed.Description = "y"
+	got := issueFieldChanges(old, &changed)
+	want := []fieldChange{
+		{Field: "priority", From: "P2", To: "P0"},
+		{Field: "assignee", From: "", To: "bob"},
+		{Field: "description", From: "…", To: "…"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
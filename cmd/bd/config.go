@@ -37,6 +37,18 @@ Common namespaces:
   - gitlab.*          GitLab integration settings
   - ado.*             Azure DevOps integration settings
   - notion.*          Notion integration settings
+  - slack.*           Slack integration settings (bd serve)
+  - alertmanager.*    Alertmanager ingest settings (bd ingest alertmanager)
+  - commit.*          Batch auto-commit thresholds (stored in config.yaml)
+  - confirm.*         Confirmation policies for destructive operations (bd confirm; stored in config.yaml)
+  - notify.*          Desktop notification settings (bd notify; stored in config.yaml)
+  - gantt.*           Gantt chart settings (bd gantt; stored in config.yaml)
+  - capacity.*        Weekly capacity per assignee (bd plan capacity; stored in config.yaml)
+  - gate.*            External-condition gate settings (bd gate check; stored in config.yaml)
+  - metadata.*        Reserved metadata namespaces for integrations (stored in config.yaml)
+  - derived.*         Query-time computed fields (stored in config.yaml)
+  - summarize.*       Summarizer command settings (bd summarize; stored in config.yaml)
+  - events.*          Event kind registry and payload schemas (bd events; stored in config.yaml)
   - custom.*          Custom integration settings
   - status.*          Issue status configuration
   - claim.*           Claim arbitration settings (pool-aware claiming)
@@ -55,6 +67,22 @@ Auto-Export (config.yaml):
     export.interval   Minimum time between exports (default: 60s)
     export.git-add    Auto-stage the export file (default: false)
 
+Batch Auto-Commit (config.yaml):
+  With dolt.auto-commit set to "batch", writes stay in the working set (and
+  are visible to every later command) instead of each making a Dolt commit.
+  These keys coalesce them into one commit, made by the write that reaches
+  either threshold (bd serve also checks every batch_interval); with neither
+  set, commit with 'bd commit'. Setting either key turns batch mode on
+  unless dolt.auto-commit is set. Pending writes are flushed on SIGINT,
+  SIGTERM, and SIGHUP.
+
+  Keys:
+    commit.batch_interval   Commit once the last commit is this old (e.g. 30s)
+    commit.batch_max_ops    Commit once this many writes are pending
+
+  Example:
+    bd config set commit.batch_interval 30s
+
 Auto-Import (config.yaml):
   Reads .beads/issues.jsonl by default when a JSONL import path is implied.
   Use a relative filename/path so the import stays within the project .beads/
@@ -73,6 +101,17 @@ Custom Status States:
   This enables issues to use statuses like 'awaiting_review' in addition to
   the built-in statuses (open, in_progress, blocked, deferred, closed).
 
+Per-Type and Per-Label ID Prefixes:
+  New issues can take their ID prefix from their type or a label instead of
+  issue_prefix. Give comma-separated name:prefix pairs; label rules win
+  over type rules:
+
+    bd config set prefix.by_type "bug:bug,feature:feat"
+    bd config set prefix.by_label "spike:spk"
+
+  IDs under these prefixes are always accepted. Run 'bd migrate prefixes'
+  to re-prefix existing issues.
+
 Claim Pools:
   A dispatcher can pre-assign issues to a pool pseudo-assignee (e.g.
   "fable-crew") and let any actor take them with --claim. List the pool
@@ -223,6 +262,11 @@ var configSetCmd = &cobra.Command{
 				return HandleError("invalid status.custom value: %v", err)
 			}
 		}
+		if key == types.ConfigKeyPrefixByType || key == types.ConfigKeyPrefixByLabel {
+			if _, err := types.ParseIDPrefixRules(value); err != nil {
+				return HandleError("invalid %s value: %v", key, err)
+			}
+		}
 
 		if err := store.SetConfig(ctx, key, value); err != nil {
 			return HandleError("setting config: %v", err)
@@ -825,6 +869,11 @@ Examples:
 					return HandleError("invalid status.custom value: %v", err)
 				}
 			}
+			if p.key == types.ConfigKeyPrefixByType || p.key == types.ConfigKeyPrefixByLabel {
+				if _, err := types.ParseIDPrefixRules(p.value); err != nil {
+					return HandleError("invalid %s value: %v", p.key, err)
+				}
+			}
 		}
 
 		var yamlPairs, gitPairs, dbPairs []kvPair
@@ -940,7 +989,8 @@ var recognizedConfigPrefixes = []string{
 	"status.", "types.", "doctor.suppress.", "routing.", "sync.", "git.",
 	"directory.", "repos.", "external_projects.", "validation.",
 	"hierarchy.", "ai.", "backup.", "federation.", "metrics.", "agent.",
-	"claim.",
+	"claim.", "slack.", "notify.", "review.", "confirm.", "gantt.", "capacity.", "gate.", "metadata.", "derived.", "summarize.", "events.", "prefix.",
+	"commit.", "slug.", "views.", "alertmanager.",
 }
 
 // allRecognizedConfigPrefixes returns the static namespaces plus the prefix of
@@ -959,8 +1009,8 @@ func allRecognizedConfigPrefixes() []string {
 
 // recognizedConfigKeys lists valid non-namespaced config keys.
 var recognizedConfigKeys = map[string]bool{
-	"no-db": true, "json": true, "db": true, "actor": true,
-	"identity": true, "no-push": true, "no-git-ops": true,
+	"no-db": true, "json": true, "progress": true, "db": true, "actor": true,
+	"identity": true, "rig": true, "no-push": true, "no-git-ops": true,
 	"create.require-description": true, "beads.role": true,
 	"auto_compact_enabled": true, "schema_version": true,
 	"output.title-length": true,
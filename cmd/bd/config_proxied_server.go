@@ -15,6 +15,11 @@ func runConfigSetProxiedServer(ctx context.Context, key, value string) error {
 			return HandleErrorRespectJSON("invalid status.custom value: %v", err)
 		}
 	}
+	if key == types.ConfigKeyPrefixByType || key == types.ConfigKeyPrefixByLabel {
+		if _, err := types.ParseIDPrefixRules(value); err != nil {
+			return HandleErrorRespectJSON("invalid %s value: %v", key, err)
+		}
+	}
 
 	if uowProvider == nil {
 		return HandleErrorRespectJSON("proxied-server UOW provider not initialized")
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Confirmation levels for destructive operations, weakest first.
+const (
+	confirmNone     = "none"     // the command's own safeguards only
+	confirmForce    = "force"    // --force is required
+	confirmPhrase   = "phrase"   // type the operation phrase, or pass --confirm "<phrase>"
+	confirmApproval = "approval" // another (human) actor approves with bd confirm approve
+)
+
+// Guarded operations; each has a confirm.<op> policy in config.yaml.
+const (
+	confirmOpDelete           = "delete"            // bd delete --force
+	confirmOpBulkUpdate       = "bulk_update"       // bd update on more than confirm.bulk_threshold issues
+	confirmOpFederationRemove = "federation_remove" // bd federation remove-peer
+	confirmOpHistory          = "history"           // bd flatten, bd compact, bd gc --keep-history, bd db restore --force
+)
+
+// confirmationPrefix namespaces approval records in the database config
+// table, so every clone sharing the database sees the same approvals.
+const confirmationPrefix = "confirmations."
+
+// confirmApprovalTTL is how long an approval stays usable.
+const confirmApprovalTTL = 24 * time.Hour
+
+const defaultBulkThreshold = 10
+
+// confirmRequest is a pending or approved request to run a guarded operation.
+type confirmRequest struct {
+	Code        string     `json:"code"`
+	Op          string     `json:"op"`
+	Phrase      string     `json:"phrase"`
+	Targets     []string   `json:"targets,omitempty"`
+	RequestedBy string     `json:"requested_by"`
+	RequestedAt time.Time  `json:"requested_at"`
+	ApprovedBy  string     `json:"approved_by,omitempty"`
+	ApprovedAt  *time.Time `json:"approved_at,omitempty"`
+}
+
+var confirmCmd = &cobra.Command{
+	Use:     "confirm",
+	GroupID: "advanced",
+	Short:   "Confirmation policies for destructive operations",
+	Long: `Require extra confirmation before destructive operations.
+
+Policies live in config.yaml. Each guarded operation takes a level:
+
+  none      the command's own safeguards only (default)
+  force     --force is required
+  phrase    type the operation phrase (e.g. "delete 3 issues") at the
+            prompt, or pass it with --confirm when not on a terminal
+  approval  another, human actor runs 'bd confirm approve <code>' first;
+            the approval is used once and expires after 24 hours
+
+  confirm:
+    delete: phrase             # bd delete --force
+    bulk_update: phrase        # bd update on more than bulk_threshold issues
+    bulk_threshold: 10
+    federation_remove: force   # bd federation remove-peer
+    history: approval          # bd flatten, bd compact, bd db restore --force
+    agent:                     # stricter levels when an agent runs the command
+      delete: approval
+
+An actor counts as an agent in agent mode (BD_AGENT_MODE=1 or CLAUDE_CODE)
+or when it matches a review.agents pattern. Approval requests are stored in
+the database, so an approver on another clone sees them after a pull.
+
+Examples:
+  bd confirm list
+  bd confirm approve op-3f2a91c0`,
+}
+
+var confirmListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List pending and approved confirmation requests",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		reqs, err := loadConfirmRequests()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			return outputJSON(reqs)
+		}
+		if len(reqs) == 0 {
+			fmt.Println("No confirmation requests")
+			return nil
+		}
+		for _, r := range reqs {
+			state := ui.RenderWarn("pending")
+			if r.ApprovedAt != nil {
+				state = ui.RenderPass("approved by " + r.ApprovedBy)
+			}
+			fmt.Printf("%s  %q requested by %s %s — %s\n", r.Code, r.Phrase, r.RequestedBy,
+				r.RequestedAt.Local().Format("2006-01-02 15:04"), state)
+		}
+		return nil
+	},
+}
+
+var confirmApproveCmd = &cobra.Command{
+	Use:   "approve <code>",
+	Short: "Approve another actor's destructive operation",
+	Long: `Approve a request made under an "approval" policy. The requester then re-runs
+the same command, which uses the approval once. You cannot approve your own
+requests, and agents cannot approve at all.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("confirm approve")
+		evt := metrics.NewCommandEvent("confirm-approve")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		if isAgentActor(loadReviewPolicy(), actor) {
+			return HandleErrorRespectJSON("approvals must come from a human; %q is an agent", actor)
+		}
+		r, err := getConfirmRequest(args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if r == nil {
+			return HandleErrorRespectJSON("no confirmation request %s", args[0])
+		}
+		if r.RequestedBy == actor {
+			return HandleErrorRespectJSON("%s was requested by you; another actor has to approve it", r.Code)
+		}
+		now := time.Now().UTC()
+		r.ApprovedBy = actor
+		r.ApprovedAt = &now
+		if err := saveConfirmRequest(r); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(r)
+		}
+		fmt.Printf("%s Approved %s: %q for %s (valid for %s)\n", ui.RenderPass("✓"), r.Code, r.Phrase, r.RequestedBy, confirmApprovalTTL)
+		return nil
+	},
+}
+
+// addConfirmFlags registers the flags requireConfirmation reads. Commands
+// that already have --force keep their own.
+func addConfirmFlags(cmd *cobra.Command) {
+	cmd.Flags().String("confirm", "", "Confirmation phrase required by a confirm.* policy")
+	if cmd.Flags().Lookup("force") == nil {
+		cmd.Flags().Bool("force", false, "Confirm the operation when a confirm.* policy requires --force")
+	}
+}
+
+// confirmLevel returns the configured level for op, preferring the stricter
+// confirm.agent.<op> policy when the current actor is an agent.
+func confirmLevel(op string) string {
+	level := strings.ToLower(strings.TrimSpace(config.GetString("confirm." + op)))
+	if isAgentActor(loadReviewPolicy(), actor) {
+		if agentLevel := strings.ToLower(strings.TrimSpace(config.GetString("confirm.agent." + op))); agentLevel != "" {
+			level = agentLevel
+		}
+	}
+	if level == "" {
+		return confirmNone
+	}
+	return level
+}
+
+// confirmBulkThreshold is the issue count above which an update is a bulk update.
+func confirmBulkThreshold() int {
+	if n := config.GetInt("confirm.bulk_threshold"); n > 0 {
+		return n
+	}
+	return defaultBulkThreshold
+}
+
+// requireConfirmation enforces the confirm.<op> policy before a destructive
+// operation. phrase describes the operation ("delete 3 issues") and is what
+// the phrase level asks for; targets identify it for approvals.
+func requireConfirmation(cmd *cobra.Command, op, phrase string, targets []string) error {
+	switch level := confirmLevel(op); level {
+	case confirmNone:
+		return nil
+	case confirmForce:
+		if force, _ := cmd.Flags().GetBool("force"); force {
+			return nil
+		}
+		return fmt.Errorf("%s requires --force (confirm.%s policy)", phrase, op)
+	case confirmPhrase:
+		return confirmByPhrase(cmd, op, phrase)
+	case confirmApproval:
+		return confirmByApproval(op, phrase, targets)
+	default:
+		return fmt.Errorf("invalid confirm.%s level %q (valid: none, force, phrase, approval)", op, level)
+	}
+}
+
+func confirmByPhrase(cmd *cobra.Command, op, phrase string) error {
+	if typed, _ := cmd.Flags().GetString("confirm"); typed != "" {
+		if strings.TrimSpace(typed) == phrase {
+			return nil
+		}
+		return fmt.Errorf("--confirm %q does not match %q", typed, phrase)
+	}
+	if jsonOutput || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("%s requires confirmation (confirm.%s policy); pass --confirm %q", phrase, op, phrase)
+	}
+	fmt.Fprintf(os.Stderr, "%s Type %q to confirm: ", ui.RenderWarn("⚠"), phrase)
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if strings.TrimSpace(line) != phrase {
+		return fmt.Errorf("confirmation did not match; %s cancelled", phrase)
+	}
+	return nil
+}
+
+// confirmByApproval lets the operation through when another actor approved
+// this exact request; otherwise it records the request and explains how to
+// get it approved.
+func confirmByApproval(op, phrase string, targets []string) error {
+	if err := ensureStoreActive(); err != nil {
+		return fmt.Errorf("approval policy needs the database: %w", err)
+	}
+	code := confirmCode(op, targets, actor)
+	r, err := getConfirmRequest(code)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if r != nil && r.ApprovedAt != nil {
+		if now.Sub(*r.ApprovedAt) <= confirmApprovalTTL && r.ApprovedBy != actor {
+			if err := store.DeleteConfig(rootCtx, confirmationPrefix+code); err != nil {
+				return fmt.Errorf("using approval %s: %w", code, err)
+			}
+			fmt.Fprintf(os.Stderr, "Using approval %s from %s\n", code, r.ApprovedBy)
+			return nil
+		}
+		r = nil // expired: ask again
+	}
+	if r == nil {
+		r = &confirmRequest{Code: code, Op: op, Phrase: phrase, Targets: targets, RequestedBy: actor, RequestedAt: now}
+		if err := saveConfirmRequest(r); err != nil {
+			return err
+		}
+		commandDidWrite.Store(true)
+	}
+	return fmt.Errorf("%s needs approval from another actor (confirm.%s policy): ask them to run 'bd confirm approve %s', then re-run this command", phrase, op, code)
+}
+
+// confirmCode identifies a request by operation, targets, and requester, so
+// re-running the same command finds its approval.
+func confirmCode(op string, targets []string, requester string) string {
+	sorted := append([]string(nil), targets...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(op + "\x00" + strings.Join(sorted, "\x00") + "\x00" + requester))
+	return "op-" + hex.EncodeToString(sum[:4])
+}
+
+func getConfirmRequest(code string) (*confirmRequest, error) {
+	raw, err := store.GetConfig(rootCtx, confirmationPrefix+code)
+	if err != nil {
+		return nil, fmt.Errorf("reading confirmation %s: %w", code, err)
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	var r confirmRequest
+	if err := json.Unmarshal([]byte(raw), &r); err != nil {
+		return nil, fmt.Errorf("parsing confirmation %s: %w", code, err)
+	}
+	return &r, nil
+}
+
+func saveConfirmRequest(r *confirmRequest) error {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encoding confirmation: %w", err)
+	}
+	if err := store.SetConfig(rootCtx, confirmationPrefix+r.Code, string(raw)); err != nil {
+		return fmt.Errorf("saving confirmation %s: %w", r.Code, err)
+	}
+	return nil
+}
+
+func loadConfirmRequests() ([]confirmRequest, error) {
+	all, err := store.GetAllConfig(rootCtx)
+	if err != nil {
+		return nil, fmt.Errorf("reading confirmations: %w", err)
+	}
+	reqs := []confirmRequest{}
+	for key, raw := range all {
+		if !strings.HasPrefix(key, confirmationPrefix) {
+			continue
+		}
+		var r confirmRequest
+		if err := json.Unmarshal([]byte(raw), &r); err != nil {
+			continue
+		}
+		reqs = append(reqs, r)
+	}
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].RequestedAt.Before(reqs[j].RequestedAt) })
+	return reqs, nil
+}
+
+func init() {
+	confirmCmd.AddCommand(confirmListCmd, confirmApproveCmd)
+	rootCmd.AddCommand(confirmCmd)
+}
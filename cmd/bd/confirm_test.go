@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+func TestRequireConfirmation(t *testing.T) {
+	initConfigForTest(t)
+	t.Setenv("BD_AGENT_MODE", "")
+	t.Setenv("CLAUDE_CODE", "")
+	oldActor := actor
+	actor = "alice"
+	t.Cleanup(func() { actor = oldActor })
+
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		addConfirmFlags(cmd)
+		if err := cmd.Flags().Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+
+	if err := requireConfirmation(newCmd(), confirmOpDelete, "delete bd-1", []string{"bd-1"}); err != nil {
+		t.Fatalf("no policy: %v", err)
+	}
+
+	config.Set("confirm.delete", "force")
+	if err := requireConfirmation(newCmd(), confirmOpDelete, "delete bd-1", nil); err == nil {
+		t.Error("force policy should require --force")
+	}
+	if err := requireConfirmation(newCmd("--force"), confirmOpDelete, "delete bd-1", nil); err != nil {
+		t.Errorf("force policy with --force: %v", err)
+	}
+
+	config.Set("confirm.delete", "phrase")
+	if err := requireConfirmation(newCmd("--confirm", "delete bd-1"), confirmOpDelete, "delete bd-1", nil); err != nil {
+		t.Errorf("matching phrase: %v", err)
+	}
+	if err := requireConfirmation(newCmd("--confirm", "delete"), confirmOpDelete, "delete bd-1", nil); err == nil {
+		t.Error("wrong phrase should be refused")
+	}
+
+	config.Set("confirm.delete", "sometimes")
+	if err := requireConfirmation(newCmd(), confirmOpDelete, "delete bd-1", nil); err == nil || !strings.Contains(err.Error(), "invalid confirm.delete") {
+		t.Errorf("invalid level: err = %v", err)
+	}
+
+	config.Set("confirm.delete", "none")
+	config.Set("confirm.agent.delete", "force")
+	if got := confirmLevel(confirmOpDelete); got != confirmNone {
+		t.Errorf("human level = %q, want none", got)
+	}
+	t.Setenv("BD_AGENT_MODE", "1")
+	if got := confirmLevel(confirmOpDelete); got != confirmForce {
+		t.Errorf("agent level = %q, want force", got)
+	}
+}
+
+func TestConfirmCode(t *testing.T) {
+	a := confirmCode("delete", []string{"bd-2", "bd-1"}, "alice")
+	if b := confirmCode("delete", []string{"bd-1", "bd-2"}, "alice"); a != b {
+		t.Errorf("code depends on target order: %s vs %s", a, b)
+	}
+	if c := confirmCode("delete", []string{"bd-1", "bd-2"}, "bob"); a == c {
+		t.Error("code should depend on the requester")
+	}
+	if !strings.HasPrefix(a, "op-") || len(a) != len("op-")+8 {
+		t.Errorf("code = %q", a)
+	}
+}
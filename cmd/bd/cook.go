@@ -448,15 +448,16 @@ func cookFormulaToSubgraph(f *formula.Formula, protoID string) (*TemplateSubgrap
 
 	// Create root proto molecule
 	rootIssue := &types.Issue{
-		ID:          protoID,
-		Title:       rootTitle,
-		Description: rootDesc,
-		Status:      types.StatusOpen,
-		Priority:    2,
-		IssueType:   types.TypeMolecule,
-		IsTemplate:  true,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:            protoID,
+		Title:         rootTitle,
+		Description:   rootDesc,
+		Status:        types.StatusOpen,
+		Priority:      2,
+		IssueType:     types.TypeMolecule,
+		IsTemplate:    true,
+		SourceFormula: f.Formula, // Source tracing: the root has no step location
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
 	}
 	issues = append(issues, rootIssue)
 	issueMap[protoID] = rootIssue
@@ -836,15 +837,16 @@ func cookFormula(ctx context.Context, s storage.DoltStorage, f *formula.Formula,
 
 	// Create root proto molecule using provided protoID (may include prefix)
 	rootIssue := &types.Issue{
-		ID:          protoID,
-		Title:       rootTitle,
-		Description: rootDesc,
-		Status:      types.StatusOpen,
-		Priority:    2,
-		IssueType:   types.TypeMolecule,
-		IsTemplate:  true,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:            protoID,
+		Title:         rootTitle,
+		Description:   rootDesc,
+		Status:        types.StatusOpen,
+		Priority:      2,
+		IssueType:     types.TypeMolecule,
+		IsTemplate:    true,
+		SourceFormula: f.Formula, // Source tracing: the root has no step location
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
 	}
 	issues = append(issues, rootIssue)
 	labels = append(labels, struct{ issueID, label string }{protoID, MoleculeLabel})
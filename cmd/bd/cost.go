@@ -0,0 +1,443 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var costCmd = &cobra.Command{
+	Use:     "cost",
+	GroupID: "views",
+	Short:   "Track token and dollar cost per issue",
+	Long: `Track the tokens and dollars agents spend on issues.
+
+Costs accumulate per issue and roll up through parents, so an epic's total
+includes every descendant. 'bd status' shows the project total.
+
+Set a budget on an epic (or any parent) to cap its total. Claims of the
+epic or its descendants ('bd update --claim', 'bd ready --claim') check the
+budget according to cost.budget-mode in config.yaml:
+
+  cost:
+    budget-mode: block   # warn (default) | block | none
+
+Examples:
+  bd cost add bd-42 --tokens 52000 --usd 1.20
+  bd cost budget bd-10 --usd 50
+  bd cost show bd-10`,
+}
+
+var costAddCmd = &cobra.Command{
+	Use:           "add <id>",
+	Short:         "Record tokens and dollars spent on an issue",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("cost add")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("cost is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("cost-add")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		tokens, _ := cmd.Flags().GetInt64("tokens")
+		usd, _ := cmd.Flags().GetFloat64("usd")
+		note, _ := cmd.Flags().GetString("note")
+		if tokens < 0 || usd < 0 {
+			return HandleErrorRespectJSON("--tokens and --usd must not be negative")
+		}
+		if tokens == 0 && usd == 0 {
+			return HandleErrorRespectJSON("specify --tokens and/or --usd")
+		}
+
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil || issue == nil {
+			return HandleErrorRespectJSON("issue %s not found", id)
+		}
+		entry := types.CostEntry{At: time.Now().UTC(), By: actor, Tokens: tokens, USD: usd, Note: note}
+		total, err := recordCost(ctx, store, issue, entry)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(id)
+
+		// Spending is never refused, but crossing a budget is worth a warning.
+		for _, over := range exceededBudgets(ctx, store, id) {
+			fmt.Fprintf(os.Stderr, "%s %s\n", ui.RenderWarn("⚠"), over)
+		}
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"id":    id,
+				"entry": entry,
+				"total": total,
+			})
+		}
+		fmt.Printf("%s Recorded %s on %s (total %s)\n", ui.RenderPass("✓"),
+			formatCost(types.Cost{Tokens: tokens, USD: usd}), formatFeedbackID(id, issue.Title), formatCost(total))
+		return nil
+	},
+}
+
+var costBudgetCmd = &cobra.Command{
+	Use:           "budget <id>",
+	Short:         "Set the cost budget of an issue and its descendants",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("cost budget")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("cost is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("cost-budget")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		clearBudget, _ := cmd.Flags().GetBool("clear")
+		if !clearBudget && !cmd.Flags().Changed("tokens") && !cmd.Flags().Changed("usd") {
+			return HandleErrorRespectJSON("specify --tokens and/or --usd, or --clear")
+		}
+		tokens, _ := cmd.Flags().GetInt64("tokens")
+		usd, _ := cmd.Flags().GetFloat64("usd")
+		if tokens < 0 || usd < 0 {
+			return HandleErrorRespectJSON("--tokens and --usd must not be negative")
+		}
+
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil || issue == nil {
+			return HandleErrorRespectJSON("issue %s not found", id)
+		}
+		budget, err := types.BudgetFromMetadata(issue.Metadata)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		switch {
+		case clearBudget:
+			budget = types.Budget{}
+		default:
+			if cmd.Flags().Changed("tokens") {
+				budget.Tokens = tokens
+			}
+			if cmd.Flags().Changed("usd") {
+				budget.USD = usd
+			}
+		}
+		if err := mergeMetadataValues(ctx, store, id, map[string]interface{}{
+			types.BudgetTokensMetadataKey: budget.Tokens,
+			types.BudgetUSDMetadataKey:    budget.USD,
+		}); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(id)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"id": id, "budget": budget})
+		}
+		if budget.IsZero() {
+			fmt.Printf("%s Cleared budget of %s\n", ui.RenderPass("✓"), formatFeedbackID(id, issue.Title))
+			return nil
+		}
+		fmt.Printf("%s Budget of %s: %s\n", ui.RenderPass("✓"), formatFeedbackID(id, issue.Title), formatBudget(budget))
+		return nil
+	},
+}
+
+// costView is the JSON shape of 'bd cost show'.
+type costView struct {
+	ID          string            `json:"id"`
+	Title       string            `json:"title"`
+	Own         types.Cost        `json:"own"`
+	Total       types.Cost        `json:"total"` // Own plus all descendants
+	Descendants int               `json:"descendants"`
+	Budget      *types.Budget     `json:"budget,omitempty"`
+	Exceeded    []string          `json:"exceeded,omitempty"`
+	Log         []types.CostEntry `json:"log,omitempty"`
+}
+
+var costShowCmd = &cobra.Command{
+	Use:           "show <id>",
+	Short:         "Show an issue's cost, rollup, and budget",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("cost is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("cost-show")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil || issue == nil {
+			return HandleErrorRespectJSON("issue %s not found", id)
+		}
+		view := costView{ID: id, Title: issue.Title}
+		if view.Own, err = types.CostFromMetadata(issue.Metadata); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if view.Total, view.Descendants, err = costRollup(ctx, store, issue); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		budget, err := types.BudgetFromMetadata(issue.Metadata)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if !budget.IsZero() {
+			view.Budget = &budget
+			view.Exceeded = budget.Exceeded(view.Total)
+		}
+		if showLog, _ := cmd.Flags().GetBool("log"); showLog {
+			if view.Log, err = types.CostLogFromMetadata(issue.Metadata); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+		}
+
+		if jsonOutput {
+			return outputJSON(view)
+		}
+		fmt.Printf("%s\n", formatFeedbackID(view.ID, view.Title))
+		fmt.Printf("  Own:    %s\n", formatCost(view.Own))
+		if view.Descendants > 0 {
+			fmt.Printf("  Total:  %s %s\n", formatCost(view.Total), ui.RenderMuted(fmt.Sprintf("(with %d descendants)", view.Descendants)))
+		}
+		if view.Budget != nil {
+			line := formatBudget(*view.Budget)
+			if len(view.Exceeded) > 0 {
+				line = ui.RenderFail(line + " — exceeded")
+			}
+			fmt.Printf("  Budget: %s\n", line)
+		}
+		for _, e := range view.Log {
+			line := fmt.Sprintf("  %s  %s  %s", e.At.Local().Format("2006-01-02 15:04"), formatCost(types.Cost{Tokens: e.Tokens, USD: e.USD}), e.By)
+			if e.Note != "" {
+				line += "  " + ui.RenderMuted(e.Note)
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+// recordCost appends entry to the issue's cost log and updates the cost
+// totals. It returns the issue's new own total.
+func recordCost(ctx context.Context, s storage.DoltStorage, issue *types.Issue, entry types.CostEntry) (types.Cost, error) {
+	log, err := types.CostLogFromMetadata(issue.Metadata)
+	if err != nil {
+		return types.Cost{}, err
+	}
+	own, err := types.CostFromMetadata(issue.Metadata)
+	if err != nil {
+		return types.Cost{}, err
+	}
+	total := own.Add(types.Cost{Tokens: entry.Tokens, USD: entry.USD})
+	err = mergeMetadataValues(ctx, s, issue.ID, map[string]interface{}{
+		types.CostLogMetadataKey:    append(log, entry),
+		types.CostTokensMetadataKey: total.Tokens,
+		types.CostUSDMetadataKey:    total.USD,
+	})
+	return total, err
+}
+
+// mergeMetadataValues merges each key into the issue's metadata.
+func mergeMetadataValues(ctx context.Context, s storage.DoltStorage, id string, values map[string]interface{}) error {
+	for key, value := range values {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("encoding metadata.%s: %w", key, err)
+		}
+		if err := s.MergeMetadata(ctx, id, key, raw, actor); err != nil {
+			return fmt.Errorf("updating metadata.%s of %s: %w", key, id, err)
+		}
+	}
+	return nil
+}
+
+// costRollup returns the cost of issue plus all its descendants, and the
+// number of descendants.
+func costRollup(ctx context.Context, s storage.DoltStorage, issue *types.Issue) (types.Cost, int, error) {
+	total, err := types.CostFromMetadata(issue.Metadata)
+	if err != nil {
+		return types.Cost{}, 0, err
+	}
+	descendants := make(map[string]*types.Issue)
+	if err := findAllDescendants(ctx, s, "", issue.ID, types.IssueFilter{}, descendants); err != nil {
+		return types.Cost{}, 0, fmt.Errorf("loading descendants of %s: %w", issue.ID, err)
+	}
+	for _, d := range descendants {
+		c, err := types.CostFromMetadata(d.Metadata)
+		if err != nil {
+			return types.Cost{}, 0, fmt.Errorf("%s: %w", d.ID, err)
+		}
+		total = total.Add(c)
+	}
+	return total, len(descendants), nil
+}
+
+// exceededBudgets returns a message for each budget over id and its
+// ancestors that has been reached. Lookup errors are logged and skipped:
+// budgets are advisory unless cost.budget-mode is block.
+func exceededBudgets(ctx context.Context, s storage.DoltStorage, id string) []string {
+	var msgs []string
+	seen := make(map[string]bool)
+	for depth := 0; id != "" && !seen[id] && depth < whyMaxDepth; depth++ {
+		seen[id] = true
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil || issue == nil {
+			break
+		}
+		if budget, err := types.BudgetFromMetadata(issue.Metadata); err == nil && !budget.IsZero() {
+			total, _, err := costRollup(ctx, s, issue)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: checking budget of %s: %v\n", id, err)
+			} else if over := budget.Exceeded(total); len(over) > 0 {
+				msgs = append(msgs, fmt.Sprintf("budget of %s exceeded (%s)", id, strings.Join(over, ", ")))
+			}
+		}
+		id = parentIssueID(ctx, s, id)
+	}
+	return msgs
+}
+
+// parentIssueID returns id's parent via its parent-child dependency, or ""
+// when it has none.
+func parentIssueID(ctx context.Context, s storage.DoltStorage, id string) string {
+	deps, err := s.GetDependenciesWithMetadata(ctx, id)
+	if err != nil {
+		return ""
+	}
+	for _, d := range deps {
+		if d.DependencyType == types.DepParentChild {
+			return d.ID
+		}
+	}
+	return ""
+}
+
+// costBudgetMode returns cost.budget-mode: "block" refuses claims under an
+// exceeded budget, "none" skips the check, anything else warns.
+func costBudgetMode() string {
+	switch mode := config.GetString("cost.budget-mode"); mode {
+	case "block", "none":
+		return mode
+	default:
+		return "warn"
+	}
+}
+
+// checkClaimBudget enforces budgets over id and its ancestors before id is
+// claimed: an error in block mode, a warning otherwise.
+func checkClaimBudget(ctx context.Context, s storage.DoltStorage, id string) error {
+	mode := costBudgetMode()
+	if mode == "none" {
+		return nil
+	}
+	msgs := exceededBudgets(ctx, s, id)
+	if len(msgs) == 0 {
+		return nil
+	}
+	if mode == "block" {
+		return fmt.Errorf("cannot claim %s: %s", id, strings.Join(msgs, "; "))
+	}
+	for _, msg := range msgs {
+		fmt.Fprintf(os.Stderr, "%s %s: %s\n", ui.RenderWarn("⚠"), id, msg)
+	}
+	return nil
+}
+
+// overBudgetReadyIDs returns the ready issues a claim under filter could
+// pick that checkClaimBudget would refuse, so bd ready --claim can pass
+// over them instead of claiming and releasing the same issue every time.
+func overBudgetReadyIDs(ctx context.Context, s storage.DoltStorage, filter types.WorkFilter) ([]string, error) {
+	if costBudgetMode() != "block" {
+		return nil, nil
+	}
+	filter.Status = types.StatusOpen
+	filter.Unassigned = true
+	filter.Assignee = nil
+	filter.Limit = 0
+	candidates, err := s.GetReadyWork(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, issue := range candidates {
+		if len(exceededBudgets(ctx, s, issue.ID)) > 0 {
+			ids = append(ids, issue.ID)
+		}
+	}
+	return ids, nil
+}
+
+func formatCost(c types.Cost) string {
+	return fmt.Sprintf("$%.2f, %d tokens", c.USD, c.Tokens)
+}
+
+func formatBudget(b types.Budget) string {
+	var parts []string
+	if b.USD > 0 {
+		parts = append(parts, fmt.Sprintf("$%.2f", b.USD))
+	}
+	if b.Tokens > 0 {
+		parts = append(parts, fmt.Sprintf("%d tokens", b.Tokens))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	costAddCmd.Flags().Int64("tokens", 0, "Tokens consumed")
+	costAddCmd.Flags().Float64("usd", 0, "Dollars spent")
+	costAddCmd.Flags().String("note", "", "What the spend was for")
+	costBudgetCmd.Flags().Int64("tokens", 0, "Token budget (0 for unlimited)")
+	costBudgetCmd.Flags().Float64("usd", 0, "Dollar budget (0 for unlimited)")
+	costBudgetCmd.Flags().Bool("clear", false, "Remove the budget")
+	costShowCmd.Flags().Bool("log", false, "Show each recorded spend")
+
+	costAddCmd.ValidArgsFunction = issueIDCompletion
+	costBudgetCmd.ValidArgsFunction = issueIDCompletion
+	costShowCmd.ValidArgsFunction = issueIDCompletion
+	costCmd.AddCommand(costAddCmd, costBudgetCmd, costShowCmd)
+	rootCmd.AddCommand(costCmd)
+}
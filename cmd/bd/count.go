@@ -30,6 +30,7 @@ Examples:
   bd count --by-type                # Group count by issue type
   bd count --by-assignee            # Group count by assignee
   bd count --by-label               # Group count by label
+  bd count --by-close-reason -s closed  # Group closed issues by close reason category
   bd count --assignee alice --by-status  # Count alice's issues by status
   bd count --include-infra          # Count issues + wisps tier (matches 'bd list --include-infra --all' cardinality)
 `,
@@ -91,6 +92,9 @@ func parseCountFilter(cmd *cobra.Command) (types.IssueFilter, string, string, bo
 
 	// Empty/null check flags
 	emptyDesc, _ := cmd.Flags().GetBool("empty-description")
+	noDesign, _ := cmd.Flags().GetBool("no-design")
+	noAcceptance, _ := cmd.Flags().GetBool("no-acceptance-criteria")
+	noNotes, _ := cmd.Flags().GetBool("no-notes")
 	noAssignee, _ := cmd.Flags().GetBool("no-assignee")
 	noLabels, _ := cmd.Flags().GetBool("no-labels")
 
@@ -104,6 +108,7 @@ func parseCountFilter(cmd *cobra.Command) (types.IssueFilter, string, string, bo
 	byType, _ := cmd.Flags().GetBool("by-type")
 	byAssignee, _ := cmd.Flags().GetBool("by-assignee")
 	byLabel, _ := cmd.Flags().GetBool("by-label")
+	byCloseReason, _ := cmd.Flags().GetBool("by-close-reason")
 
 	// Determine groupBy value
 	groupBy := ""
@@ -128,6 +133,10 @@ func parseCountFilter(cmd *cobra.Command) (types.IssueFilter, string, string, bo
 		groupBy = "label"
 		groupCount++
 	}
+	if byCloseReason {
+		groupBy = "close_reason"
+		groupCount++
+	}
 
 	if groupCount > 1 {
 		return types.IssueFilter{}, "", "", false, HandleErrorRespectJSON("only one --by-* flag can be specified")
@@ -220,6 +229,9 @@ func parseCountFilter(cmd *cobra.Command) (types.IssueFilter, string, string, bo
 
 	// Empty/null checks
 	filter.EmptyDescription = emptyDesc
+	filter.NoDesign = noDesign
+	filter.NoAcceptanceCriteria = noAcceptance
+	filter.NoNotes = noNotes
 	filter.NoAssignee = noAssignee
 	filter.NoLabels = noLabels
 
@@ -260,6 +272,9 @@ func executeCount(ctx context.Context, backend countBackend, filter types.IssueF
 	if err != nil {
 		return HandleErrorRespectJSON("%v", err)
 	}
+	if groupBy == "close_reason" {
+		counts = foldCloseReasonCounts(counts)
+	}
 
 	type GroupCount struct {
 		Group string `json:"group"`
@@ -358,6 +373,9 @@ func init() {
 
 	// Empty/null checks
 	countCmd.Flags().Bool("empty-description", false, "Filter issues with empty description")
+	countCmd.Flags().Bool("no-design", false, "Filter issues with no design notes")
+	countCmd.Flags().Bool("no-acceptance-criteria", false, "Filter issues with no acceptance criteria")
+	countCmd.Flags().Bool("no-notes", false, "Filter issues with no notes")
 	countCmd.Flags().Bool("no-assignee", false, "Filter issues with no assignee")
 	countCmd.Flags().Bool("no-labels", false, "Filter issues with no labels")
 
@@ -376,6 +394,7 @@ func init() {
 	countCmd.Flags().Bool("by-type", false, "Group count by issue type")
 	countCmd.Flags().Bool("by-assignee", false, "Group count by assignee")
 	countCmd.Flags().Bool("by-label", false, "Group count by label")
+	countCmd.Flags().Bool("by-close-reason", false, "Group count by close reason category (fixed, duplicate, ...)")
 
 	rootCmd.AddCommand(countCmd)
 }
@@ -36,6 +36,9 @@ var createCmd = &cobra.Command{
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		CheckReadonly("create")
+		if offlineQueueActive() {
+			return enqueueCreate(cmd, args)
+		}
 
 		evt := metrics.NewCommandEvent("create")
 		defer func() {
@@ -44,6 +47,11 @@ var createCmd = &cobra.Command{
 			}
 		}()
 
+		encrypted, _ := cmd.Flags().GetBool("encrypted")
+		if encrypted && usesProxiedServer() {
+			return HandleError("--encrypted is not supported with a proxied server: the issue key lives in the local .beads directory")
+		}
+
 		if usesProxiedServer() {
 			in, err := gatherCreateInput(cmd, args)
 			if err != nil {
@@ -355,6 +363,7 @@ var createCmd = &cobra.Command{
 				EstimatedMinutes:   estimatedMinutes,
 				Ephemeral:          wisp,
 				NoHistory:          noHistory,
+				Encrypted:          encrypted,
 				CreatedBy:          getActorWithGit(),
 				Owner:              getOwner(),
 				Labels:             labels,
@@ -368,6 +377,7 @@ var createCmd = &cobra.Command{
 				Actor:              eventActor,
 				Target:             eventTarget,
 				Payload:            eventPayload,
+				Rig:                rigScope,
 			})
 
 			if jsonOutput {
@@ -465,6 +475,16 @@ var createCmd = &cobra.Command{
 			return renderDryRun()
 		}
 
+		if rigScope != "" {
+			if err := requireRegisteredRig(rigScope); err != nil {
+				return HandleError("%v", err)
+			}
+		}
+		oooFrom := ""
+		if to, redirected := redirectForOOO(rootCtx, store, assignee); redirected {
+			oooFrom, assignee = assignee, to
+		}
+
 		createCtx := rootCtx
 		if parentID != "" {
 			childID, err := store.GetNextChildID(rootCtx, parentID)
@@ -487,6 +507,9 @@ var createCmd = &cobra.Command{
 			dbPrefix := selectCreateIDPrefix(globalFlag, config.GetString("issue-prefix"), storePrefix)
 			var allowedPrefixes string
 			allowedPrefixes, _ = store.GetConfig(ctx, "allowed_prefixes")
+			if byType, byLabel, err := loadIDPrefixRules(ctx, store); err == nil {
+				allowedPrefixes = types.MergeAllowedPrefixes(allowedPrefixes, byType, byLabel)
+			}
 
 			if err := validation.ValidateIDPrefixAllowed(explicitID, dbPrefix, allowedPrefixes, forceCreate); err != nil {
 				return HandleError("%v", err)
@@ -508,6 +531,7 @@ var createCmd = &cobra.Command{
 			EstimatedMinutes:   estimatedMinutes,
 			Ephemeral:          wisp,
 			NoHistory:          noHistory,
+			Encrypted:          encrypted,
 			CreatedBy:          getActorWithGit(),
 			Owner:              getOwner(),
 			Labels:             labels,
@@ -521,6 +545,7 @@ var createCmd = &cobra.Command{
 			DueAt:              dueAt,
 			DeferUntil:         deferUntil,
 			Metadata:           metadata,
+			Rig:                rigScope,
 		})
 
 		ctx := createCtx
@@ -550,6 +575,12 @@ var createCmd = &cobra.Command{
 		if err := createIssueWithDeps(ctx, store, issue, actor, edges); err != nil {
 			return HandleErrorRespectJSON("%v", err)
 		}
+		slug := autoSlugIssue(ctx, store, issue)
+		if oooFrom != "" {
+			if err := recordOOORedirect(ctx, store, oooRedirect{Person: oooFrom, Delegate: assignee, Issue: issue.ID, Via: "create"}); err != nil {
+				WarnError("%v", err)
+			}
+		}
 
 		if edges.empty() {
 			// Bare create: preserve the embedded-mode follow-up Dolt commit.
@@ -591,6 +622,9 @@ var createCmd = &cobra.Command{
 			debug.PrintNormal("%s Created issue: %s\n", ui.RenderPass("✓"), formatFeedbackID(issue.ID, issue.Title))
 			debug.PrintNormal("  Priority: P%d\n", issue.Priority)
 			debug.PrintNormal("  Status: %s\n", issue.Status)
+			if slug != "" {
+				debug.PrintNormal("  Slug: %s\n", slug)
+			}
 
 			maybeShowTip(store)
 		}
@@ -615,6 +649,7 @@ type createIssueParams struct {
 	EstimatedMinutes   *int
 	Ephemeral          bool
 	NoHistory          bool
+	Encrypted          bool
 	CreatedBy          string
 	Owner              string
 	Labels             []string
@@ -628,6 +663,7 @@ type createIssueParams struct {
 	DueAt              *time.Time
 	DeferUntil         *time.Time
 	Metadata           json.RawMessage
+	Rig                string
 }
 
 func buildCreateIssue(params createIssueParams) *types.Issue {
@@ -659,6 +695,7 @@ func buildCreateIssue(params createIssueParams) *types.Issue {
 		EstimatedMinutes:   params.EstimatedMinutes,
 		Ephemeral:          params.Ephemeral,
 		NoHistory:          params.NoHistory,
+		Encrypted:          params.Encrypted,
 		CreatedBy:          params.CreatedBy,
 		Owner:              params.Owner,
 		Labels:             append([]string(nil), params.Labels...),
@@ -671,6 +708,7 @@ func buildCreateIssue(params createIssueParams) *types.Issue {
 		DueAt:              params.DueAt,
 		DeferUntil:         params.DeferUntil,
 		Metadata:           params.Metadata,
+		Rig:                params.Rig,
 	}
 }
 
@@ -784,6 +822,7 @@ func init() {
 	createCmd.Flags().String("repo", "", "Target repository for issue (overrides auto-routing)")
 	createCmd.Flags().IntP("estimate", "e", 0, "Time estimate in minutes (e.g., 60 for 1 hour)")
 	createCmd.Flags().Bool("ephemeral", false, "Create as ephemeral (short-lived, subject to TTL compaction)")
+	createCmd.Flags().Bool("encrypted", false, "Encrypt description, design, and notes at rest with the key in .beads/.beads-issue-key (title stays readable)")
 	createCmd.Flags().Bool("no-history", false, "Skip Dolt commit history without making GC-eligible (for permanent agent beads)")
 	createCmd.Flags().String("mol-type", "", "Molecule type: swarm (multi-agent), patrol (recurring ops), work (default)")
 	createCmd.Flags().String("wisp-type", "", "Wisp type for TTL-based compaction: heartbeat, ping, patrol, gc_report, recovery, error, escalation")
@@ -804,6 +843,7 @@ func init() {
 	createCmd.Flags().String("due", "", "Due date/time. Formats: +6h, +1d, +2w, tomorrow, next monday, 2025-01-15")
 	createCmd.Flags().String("defer", "", "Defer until date (issue hidden from bd ready until then). Same formats as --due")
 	createCmd.Flags().String("metadata", "", "Set custom metadata (JSON string or @file.json to read from file)")
+	createCmd.Flags().Bool("queue", false, "If the database server is unreachable, queue the issue locally and create it once the server is back")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(createCmd)
 }
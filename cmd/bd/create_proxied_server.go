@@ -214,6 +214,7 @@ func buildCreateIssueFromInput(in createInput) *types.Issue {
 		DueAt:              in.dueAt,
 		DeferUntil:         in.deferUntil,
 		Metadata:           in.metadata,
+		Rig:                rigScope,
 	})
 }
 
@@ -285,6 +286,7 @@ func runCreateProxiedMarkdown(_ *cobra.Command, ctx context.Context, in createIn
 					Description:        t.Description,
 					Design:             t.Design,
 					AcceptanceCriteria: t.AcceptanceCriteria,
+					Notes:              t.Notes,
 					Status:             types.StatusOpen,
 					Priority:           t.Priority,
 					IssueType:          t.IssueType,
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// defaultPrimeKnowledge is how many crystallized knowledge entries bd prime
+// lists when prime.max-knowledge is unset.
+const defaultPrimeKnowledge = 10
+
+var crystallizeCmd = &cobra.Command{
+	Use:     "crystallize <id> [<id>...]",
+	GroupID: "advanced",
+	Short:   "Distill durable knowledge from closed issues",
+	Long: `Crystallize closed issues into a knowledge entry.
+
+The entry keeps what stays useful after the work is done: the problem
+(description), design, notes, resolution (close reason), and comment
+discussion. Its bonded_from field records the source issues, and it
+survives compaction of those issues. Crystallizing the same set of issues
+again updates the existing entry.
+
+Recent entries are listed in 'bd prime' output (cap with the
+prime.max-knowledge config key; a negative value omits the section).
+
+Examples:
+  bd crystallize bd-42
+  bd crystallize bd-42 bd-43 --title "Dolt merge conflicts on config"
+  bd crystallize bd-42 --content "Always run bd doctor after upgrading"
+  bd crystallize search merge
+  bd crystallize show kn-1a2b3c4d5e`,
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("crystallize")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("crystallize is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("crystallize")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		ctx := rootCtx
+		ks, err := knowledgeStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		var issues []*types.Issue
+		for _, raw := range args {
+			id, err := utils.ResolvePartialID(ctx, store, raw)
+			if err != nil {
+				return HandleErrorRespectJSON("resolving %s: %v", raw, err)
+			}
+			issue, err := store.GetIssue(ctx, id)
+			if err != nil {
+				return HandleErrorRespectJSON("issue %s not found: %v", id, err)
+			}
+			issues = append(issues, issue)
+		}
+		var comments map[string][]*types.Comment
+		if noComments, _ := cmd.Flags().GetBool("no-comments"); !noComments {
+			ids := make([]string, len(issues))
+			for i, issue := range issues {
+				ids[i] = issue.ID
+			}
+			if comments, err = store.GetCommentsForIssues(ctx, ids); err != nil {
+				return HandleErrorRespectJSON("loading comments: %v", err)
+			}
+		}
+
+		entry, err := types.CrystallizeIssues(issues, comments)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if title, _ := cmd.Flags().GetString("title"); title != "" {
+			entry.Title = title
+		}
+		if content, _ := cmd.Flags().GetString("content"); content != "" {
+			entry.Content = content
+		}
+		if tags, _ := cmd.Flags().GetStringSlice("tag"); len(tags) > 0 {
+			entry.Tags = tags
+		}
+		entry.CreatedBy = actor
+		if existing, err := ks.GetKnowledge(ctx, entry.ID); err == nil {
+			entry.CreatedAt = existing.CreatedAt
+		} else if !errors.Is(err, storage.ErrNotFound) {
+			return HandleErrorRespectJSON("checking for existing entry: %v", err)
+		}
+
+		if err := ks.UpsertKnowledge(ctx, entry); err != nil {
+			return HandleErrorRespectJSON("saving knowledge: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(entry)
+		}
+		fmt.Printf("%s Crystallized %s from %s\n", ui.RenderPass("✓"),
+			formatFeedbackID(entry.ID, entry.Title), strings.Join(entry.SourceIDs(), ", "))
+		return nil
+	},
+}
+
+var crystallizeSearchCmd = &cobra.Command{
+	Use:           "search [query]",
+	Short:         "Search crystallized knowledge",
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := rootCtx
+		ks, err := knowledgeStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		var query string
+		if len(args) == 1 {
+			query = args[0]
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		entries, err := ks.SearchKnowledge(ctx, query, limit)
+		if err != nil {
+			return HandleErrorRespectJSON("searching knowledge: %v", err)
+		}
+
+		if jsonOutput {
+			if entries == nil {
+				entries = []*types.KnowledgeEntry{}
+			}
+			return outputJSON(entries)
+		}
+		if len(entries) == 0 {
+			fmt.Println("No crystallized knowledge found")
+			return nil
+		}
+		for _, e := range entries {
+			fmt.Printf("%s  %s  %s\n", ui.RenderID(e.ID), e.Title,
+				ui.RenderMuted("(from "+strings.Join(e.SourceIDs(), ", ")+")"))
+			fmt.Printf("    %s\n", truncate(knowledgeSummary(e), 100))
+		}
+		return nil
+	},
+}
+
+var crystallizeShowCmd = &cobra.Command{
+	Use:           "show <knowledge-id>",
+	Short:         "Show a crystallized knowledge entry",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := rootCtx
+		ks, err := knowledgeStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		entry, err := ks.GetKnowledge(ctx, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			return outputJSON(entry)
+		}
+		fmt.Printf("%s %s\n", ui.RenderID(entry.ID), entry.Title)
+		fmt.Printf("Crystallized from: %s\n", strings.Join(entry.SourceIDs(), ", "))
+		if len(entry.Tags) > 0 {
+			fmt.Printf("Tags: %s\n", strings.Join(entry.Tags, ", "))
+		}
+		fmt.Printf("Updated: %s\n\n%s\n", entry.UpdatedAt.Local().Format("2006-01-02 15:04"), entry.Content)
+		return nil
+	},
+}
+
+var crystallizeDeleteCmd = &cobra.Command{
+	Use:           "delete <knowledge-id>",
+	Short:         "Delete a crystallized knowledge entry",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("crystallize delete")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("crystallize is not supported in proxied-server mode")
+		}
+		ctx := rootCtx
+		ks, err := knowledgeStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if err := ks.DeleteKnowledge(ctx, args[0]); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]string{"deleted": args[0]})
+		}
+		fmt.Printf("%s Deleted %s\n", ui.RenderPass("✓"), args[0])
+		return nil
+	},
+}
+
+// knowledgeStore returns the active store's knowledge capability.
+func knowledgeStore() (storage.KnowledgeStore, error) {
+	ks, ok := storage.UnwrapStore(store).(storage.KnowledgeStore)
+	if !ok {
+		return nil, fmt.Errorf("crystallized knowledge is not supported by this storage backend")
+	}
+	return ks, nil
+}
+
+// knowledgeSummary returns the first non-heading line of an entry's content.
+func knowledgeSummary(e *types.KnowledgeEntry) string {
+	for _, line := range strings.Split(e.Content, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+	return ""
+}
+
+// formatKnowledgeForPrime lists recent crystallized knowledge for injection
+// after the memories section. Failures are silent, like memory injection.
+func formatKnowledgeForPrime(compact bool) string {
+	if store == nil {
+		return ""
+	}
+	ks, ok := storage.UnwrapStore(store).(storage.KnowledgeStore)
+	if !ok {
+		return ""
+	}
+	limit := primeConfigInt("prime.max-knowledge")
+	if limit < 0 {
+		return ""
+	}
+	if limit == 0 {
+		limit = defaultPrimeKnowledge
+	}
+	entries, err := ks.SearchKnowledge(context.Background(), "", limit)
+	if err != nil {
+		return ""
+	}
+	return renderPrimeKnowledge(entries, compact)
+}
+
+func renderPrimeKnowledge(entries []*types.KnowledgeEntry, compact bool) string {
+	if len(entries) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	if compact {
+		sb.WriteString("\n## Knowledge\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("\n## Crystallized Knowledge (%d most recent)\n\n", len(entries)))
+		sb.WriteString("Distilled from closed issues via `bd crystallize`. Read an entry with `bd crystallize show <id>`. Search with `bd crystallize search <keyword>`.\n\n")
+	}
+	for _, e := range entries {
+		summary := strings.ReplaceAll(knowledgeSummary(e), "\n", " ")
+		if compact {
+			sb.WriteString(fmt.Sprintf("- **%s** (%s): %s\n", e.Title, e.ID, truncate(summary, 150)))
+		} else {
+			sb.WriteString(fmt.Sprintf("- **%s** (`%s`, from %s): %s\n", e.Title, e.ID, strings.Join(e.SourceIDs(), ", "), truncate(summary, 200)))
+		}
+	}
+	return sb.String()
+}
+
+func init() {
+	crystallizeCmd.Flags().String("title", "", "Entry title (default: title of the first issue)")
+	crystallizeCmd.Flags().String("content", "", "Entry content, replacing the extracted text")
+	crystallizeCmd.Flags().StringSlice("tag", nil, "Entry tags (default: the issues' labels)")
+	crystallizeCmd.Flags().Bool("no-comments", false, "Leave comment discussion out of the extracted content")
+	crystallizeSearchCmd.Flags().Int("limit", 20, "Maximum entries to show (0 = all)")
+
+	crystallizeCmd.AddCommand(crystallizeSearchCmd)
+	crystallizeCmd.AddCommand(crystallizeShowCmd)
+	crystallizeCmd.AddCommand(crystallizeDeleteCmd)
+	rootCmd.AddCommand(crystallizeCmd)
+}
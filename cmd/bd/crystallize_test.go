@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRenderPrimeKnowledge(t *testing.T) {
+	if got := renderPrimeKnowledge(nil, false); got != "" {
+		t.Errorf("no entries should render nothing, got %q", got)
+	}
+	entries := []*types.KnowledgeEntry{{
+		ID:         "kn-abc",
+		Title:      "Config merges",
+		Content:    "## Problem\nPull refuses to merge\n\n## Resolution\nCommit first",
+		BondedFrom: []types.BondRef{{SourceID: "bd-1"}, {SourceID: "bd-2"}},
+	}}
+
+	full := renderPrimeKnowledge(entries, false)
+	for _, want := range []string{"## Crystallized Knowledge (1 most recent)", "**Config merges** (`kn-abc`, from bd-1, bd-2): Pull refuses to merge"} {
+		if !strings.Contains(full, want) {
+			t.Errorf("full output missing %q:\n%s", want, full)
+		}
+	}
+	compact := renderPrimeKnowledge(entries, true)
+	if !strings.Contains(compact, "- **Config merges** (kn-abc): Pull refuses to merge") {
+		t.Errorf("compact output:\n%s", compact)
+	}
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var dbCmd = &cobra.Command{
+	Use:     "db",
+	GroupID: "maint",
+	Short:   "Garbage collect, back up, and restore the Dolt database",
+	Long: `Low-level maintenance of the Dolt database behind this workspace.
+
+Each command checks the database's integrity afterwards: every issue,
+label, and dependency committed at the relevant commit is read back and
+digested as 'bd federation verify' does, and the digests before and after
+(or of the database and its backup) must match. Constraint violations
+recorded in the working set are reported as warnings.
+
+The commands refuse to start while a Dolt sync (bd dolt push/pull,
+bd federation sync, bd backup sync, or auto-push) is in flight, and syncs
+started while one runs fail until it finishes.
+
+Examples:
+  bd db gc                          # Reclaim disk space
+  bd db backup ~/beads-backup       # Full backup, all branches and history
+  bd db restore ~/beads-backup --force`,
+}
+
+var dbGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Run Dolt garbage collection and verify the data survived it",
+	Long: `Run Dolt garbage collection (dolt_gc) to reclaim the disk space held by
+chunks no commit references any more. Unlike 'bd gc', no issues are
+deleted and no history is squashed.
+
+The issues at HEAD are digested before the collection and digested again
+at the same commit afterwards; the command fails if they differ.`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runDBGC,
+}
+
+var dbBackupCmd = &cobra.Command{
+	Use:   "backup <path>",
+	Short: "Back up the database to a directory and verify the backup",
+	Long: `Back up the whole database (all branches and full history) to the
+directory at path with dolt_backup, creating it if needed. Pending batch
+writes are committed first.
+
+The backup is then verified by restoring it into a scratch database,
+digesting the issues at its HEAD, and comparing them with this database at
+the same commit. The scratch database is dropped afterwards.
+
+Restore with 'bd db restore <path>'.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runDBBackup,
+}
+
+var dbRestoreCmd = &cobra.Command{
+	Use:   "restore <path>",
+	Short: "Verify a backup, restore the database from it, and verify the result",
+	Long: `Restore the database from a backup made by 'bd db backup' or
+'bd backup sync'.
+
+The backup is verified first, as 'bd db backup' does, and left untouched
+if it cannot be read back. After the restore the issues are digested again
+and must match the backup.
+
+Use --force to overwrite the existing database; it honors the
+confirm.history policy.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runDBRestore,
+}
+
+func init() {
+	dbRestoreCmd.Flags().Bool("force", false, "Overwrite the existing database with the backup contents")
+	addConfirmFlags(dbRestoreCmd)
+
+	dbCmd.AddCommand(dbGCCmd)
+	dbCmd.AddCommand(dbBackupCmd)
+	dbCmd.AddCommand(dbRestoreCmd)
+	rootCmd.AddCommand(dbCmd)
+}
+
+// startDBMaintenance runs the checks every bd db command shares and takes
+// the sync lock. The returned release func must be called when done.
+func startDBMaintenance(name string) (storage.IntegrityChecker, func(), error) {
+	if usesProxiedServer() {
+		return nil, nil, HandleErrorRespectJSON("%s is not supported in proxied-server mode", name)
+	}
+	if store == nil {
+		return nil, nil, HandleErrorRespectJSON("database is not initialized. Run 'bd init' first")
+	}
+	checker, ok := storage.UnwrapStore(store).(storage.IntegrityChecker)
+	if !ok {
+		return nil, nil, HandleErrorRespectJSON("storage backend does not support integrity checks")
+	}
+	release, err := beginDBMaintenance()
+	if err != nil {
+		return nil, nil, HandleErrorWithHintRespectJSON(fmt.Sprintf("refusing to run %s: %v", name, err),
+			"Wait for the sync to finish, then retry.")
+	}
+	return checker, release, nil
+}
+
+func runDBGC(cmd *cobra.Command, _ []string) error {
+	evt := metrics.NewCommandEvent("db-gc")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	CheckReadonly("db gc")
+	checker, release, err := startDBMaintenance("bd db gc")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	gc, ok := storage.UnwrapStore(store).(storage.GarbageCollector)
+	if !ok {
+		return HandleErrorRespectJSON("storage backend does not support garbage collection")
+	}
+	ctx := rootCtx
+
+	before, err := checker.CheckIntegrity(ctx, "")
+	if err != nil {
+		return HandleErrorWithHintRespectJSON(fmt.Sprintf("integrity check failed before gc: %v", err),
+			"Refusing to collect garbage in a database that cannot be read back. Run 'bd doctor'.")
+	}
+
+	sizeBefore := storeSizeBytes()
+	if !jsonOutput {
+		fmt.Println("Running Dolt GC...")
+	}
+	if err := gc.DoltGC(ctx); err != nil {
+		return HandleErrorRespectJSON("dolt gc failed: %v", err)
+	}
+	sizeAfter := storeSizeBytes()
+
+	// Check the commit HEAD was at before the collection: writes made
+	// meanwhile move HEAD, but must not change what that commit holds.
+	after, err := checker.CheckIntegrity(ctx, before.Commit)
+	if err != nil {
+		return HandleErrorRespectJSON("integrity check failed after gc: %v", err)
+	}
+	if err := compareIntegrity(before, after, "after gc"); err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		out := dbIntegrityJSON("gc", "", after)
+		addGCSizeJSON(out, sizeBefore, sizeAfter)
+		return outputJSON(out)
+	}
+	if line := gcSizeLine(sizeBefore, sizeAfter); line != "" {
+		fmt.Printf("%s GC complete: %s\n", ui.RenderPass("✓"), line)
+	} else {
+		fmt.Printf("%s GC complete\n", ui.RenderPass("✓"))
+	}
+	printIntegrity(after)
+	return nil
+}
+
+func runDBBackup(cmd *cobra.Command, args []string) error {
+	evt := metrics.NewCommandEvent("db-backup")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	checker, release, err := startDBMaintenance("bd db backup")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	bs, ok := storage.UnwrapStore(store).(storage.BackupStore)
+	if !ok {
+		return HandleErrorRespectJSON("storage backend does not support backup operations")
+	}
+	ctx := rootCtx
+	dir := args[0]
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return HandleErrorRespectJSON("failed to create backup directory: %v", err)
+	}
+	// Commit pending changes so they're included in the backup's HEAD.
+	if err := store.Commit(ctx, "bd: pre-backup commit"); err != nil && !isDoltNothingToCommit(err) {
+		WarnError("failed to commit pending changes: %v", err)
+	}
+	commandDidExplicitDoltCommit = true
+
+	if !jsonOutput {
+		fmt.Printf("Backing up to %s...\n", dir)
+	}
+	if err := bs.BackupDatabase(ctx, dir); err != nil {
+		return HandleErrorRespectJSON("backup failed: %v", err)
+	}
+
+	report, err := verifyBackup(ctx, checker, dir)
+	if err != nil {
+		return HandleErrorRespectJSON("backup written to %s but failed verification: %v", dir, err)
+	}
+
+	if jsonOutput {
+		return outputJSON(dbIntegrityJSON("backup", dir, report))
+	}
+	fmt.Printf("%s Backup complete: %s\n", ui.RenderPass("✓"), dir)
+	printIntegrity(report)
+	return nil
+}
+
+func runDBRestore(cmd *cobra.Command, args []string) error {
+	evt := metrics.NewCommandEvent("db-restore")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	CheckReadonly("db restore")
+	checker, release, err := startDBMaintenance("bd db restore")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	ctx := rootCtx
+	dir := args[0]
+	force, _ := cmd.Flags().GetBool("force")
+
+	if err := validateBackupRestoreDir(dir); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	if !jsonOutput {
+		fmt.Printf("Verifying backup %s...\n", dir)
+	}
+	backup, err := checker.CheckBackupIntegrity(ctx, dir)
+	if err != nil {
+		return HandleErrorWithHintRespectJSON(fmt.Sprintf("backup failed verification: %v", err),
+			"The database was not changed.")
+	}
+	if force {
+		if err := requireConfirmation(cmd, confirmOpHistory, "restore database", []string{"db", "restore"}); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+	}
+
+	if err := runBackupRestore(ctx, store, dir, force); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	commandDidWrite.Store(true)
+
+	restored, err := checker.CheckIntegrity(ctx, backup.Commit)
+	if err != nil {
+		return HandleErrorRespectJSON("integrity check failed after restore: %v", err)
+	}
+	if err := compareIntegrity(backup, restored, "after restore"); err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return outputJSON(dbIntegrityJSON("restore", dir, restored))
+	}
+	fmt.Printf("%s Restore complete: %s\n", ui.RenderPass("✓"), dir)
+	printIntegrity(restored)
+	return nil
+}
+
+// verifyBackup checks the backup in dir and compares it with the database
+// at the commit the backup holds as HEAD.
+func verifyBackup(ctx context.Context, checker storage.IntegrityChecker, dir string) (*storage.IntegrityReport, error) {
+	backup, err := checker.CheckBackupIntegrity(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	source, err := checker.CheckIntegrity(ctx, backup.Commit)
+	if err != nil {
+		return nil, fmt.Errorf("read database at %s: %w", backup.Commit, err)
+	}
+	if source.Root != backup.Root {
+		return nil, fmt.Errorf("issues at %s differ from the database (%d vs %d issues)",
+			shortCommitHash(backup.Commit), backup.Issues, source.Issues)
+	}
+	return backup, nil
+}
+
+// compareIntegrity fails when got does not digest the same issues as want.
+func compareIntegrity(want, got *storage.IntegrityReport, when string) error {
+	if got.Root == want.Root {
+		return nil
+	}
+	return HandleErrorWithHintRespectJSON(
+		fmt.Sprintf("integrity check failed %s: issues at %s changed (%d before, %d now)",
+			when, shortCommitHash(want.Commit), want.Issues, got.Issues),
+		"Run 'bd doctor' and restore from a backup if issues are missing.")
+}
+
+func printIntegrity(r *storage.IntegrityReport) {
+	fmt.Printf("%s Integrity verified: %d issues at %s\n", ui.RenderPass("✓"), r.Issues, shortCommitHash(r.Commit))
+	if !r.OK() {
+		fmt.Printf("%s %d constraint violation(s) recorded in the working set (see 'bd doctor')\n",
+			ui.RenderWarn("!"), r.ConstraintViolations)
+	}
+}
+
+func dbIntegrityJSON(operation, path string, r *storage.IntegrityReport) map[string]interface{} {
+	out := map[string]interface{}{
+		"operation":             operation,
+		"verified":              true,
+		"commit":                r.Commit,
+		"root":                  r.Root,
+		"issues":                r.Issues,
+		"constraint_violations": r.ConstraintViolations,
+	}
+	if path != "" {
+		out["path"] = path
+	}
+	return out
+}
@@ -0,0 +1,159 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/lockfile"
+)
+
+// bdDB runs "bd db" with the given args and returns stdout.
+func bdDB(t *testing.T, bd, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(bd, append([]string{"db"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = bdEnv(dir)
+	stdout, stderr, err := runCommandBuffers(t, cmd)
+	if err != nil {
+		t.Fatalf("bd db %s failed: %v\nstdout:\n%s\nstderr:\n%s", strings.Join(args, " "), err, stdout.String(), stderr.String())
+	}
+	return stdout.String()
+}
+
+// bdDBFail runs "bd db" expecting failure and returns combined output.
+func bdDBFail(t *testing.T, bd, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(bd, append([]string{"db"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = bdEnv(dir)
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected bd db %s to fail, but succeeded:\n%s", strings.Join(args, " "), out)
+	}
+	return string(out)
+}
+
+type dbIntegrityResult struct {
+	Operation string `json:"operation"`
+	Verified  bool   `json:"verified"`
+	Commit    string `json:"commit"`
+	Root      string `json:"root"`
+	Issues    int    `json:"issues"`
+}
+
+func parseDBIntegrity(t *testing.T, out string) dbIntegrityResult {
+	t.Helper()
+	var r dbIntegrityResult
+	if err := json.Unmarshal([]byte(out), &r); err != nil {
+		t.Fatalf("parse bd db JSON: %v\n%s", err, out)
+	}
+	if !r.Verified || r.Commit == "" || len(r.Root) != 64 {
+		t.Fatalf("bd db %s result not verified: %+v", r.Operation, r)
+	}
+	return r
+}
+
+func TestEmbeddedDB(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "tdb")
+	for _, title := range []string{"First", "Second", "Third"} {
+		bdCreate(t, bd, dir, title, "--type", "task")
+	}
+	backupDir := filepath.Join(t.TempDir(), "nested", "backup")
+
+	var root string
+	t.Run("gc", func(t *testing.T) {
+		r := parseDBIntegrity(t, bdDB(t, bd, dir, "gc", "--json"))
+		if r.Issues != 3 {
+			t.Errorf("gc verified %d issues, want 3", r.Issues)
+		}
+		root = r.Root
+	})
+
+	t.Run("backup", func(t *testing.T) {
+		r := parseDBIntegrity(t, bdDB(t, bd, dir, "backup", backupDir, "--json"))
+		if r.Issues != 3 || r.Root != root {
+			t.Errorf("backup verified %d issues with root %s, want 3 with root %s", r.Issues, r.Root, root)
+		}
+		entries, err := os.ReadDir(filepath.Join(dir, ".beads", "embeddeddolt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range entries {
+			if strings.HasPrefix(e.Name(), "bd_verify_") {
+				t.Errorf("scratch database %s left behind", e.Name())
+			}
+		}
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		extra := bdCreate(t, bd, dir, "Made after the backup", "--type", "task")
+		r := parseDBIntegrity(t, bdDB(t, bd, dir, "restore", backupDir, "--force", "--json"))
+		if r.Issues != 3 || r.Root != root {
+			t.Errorf("restore verified %d issues with root %s, want 3 with root %s", r.Issues, r.Root, root)
+		}
+		cmd := exec.Command(bd, "show", extra.ID)
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		if out, err := cmd.CombinedOutput(); err == nil {
+			t.Errorf("issue created after the backup survived the restore:\n%s", out)
+		}
+	})
+
+	t.Run("restore_missing_backup", func(t *testing.T) {
+		out := bdDBFail(t, bd, dir, "restore", filepath.Join(t.TempDir(), "absent"), "--force")
+		if !strings.Contains(out, "not found") {
+			t.Errorf("expected a not-found error: %s", out)
+		}
+	})
+
+	t.Run("restore_honors_confirm_policy", func(t *testing.T) {
+		bdConfig(t, bd, dir, "set", "confirm.history", "phrase")
+		t.Cleanup(func() { bdConfig(t, bd, dir, "set", "confirm.history", "none") })
+		extra := bdCreate(t, bd, dir, "Kept by a refused restore", "--type", "task")
+
+		out := bdDBFail(t, bd, dir, "restore", backupDir, "--force")
+		if !strings.Contains(out, "requires confirmation") {
+			t.Errorf("expected an unconfirmed restore to be refused: %s", out)
+		}
+		bdShow(t, bd, dir, extra.ID)
+
+		bdDB(t, bd, dir, "restore", backupDir, "--force", "--confirm", "restore database")
+		cmd := exec.Command(bd, "show", extra.ID)
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		if out, err := cmd.CombinedOutput(); err == nil {
+			t.Errorf("confirmed restore kept an issue made after the backup:\n%s", out)
+		}
+	})
+
+	t.Run("refuses_during_sync", func(t *testing.T) {
+		f, err := os.OpenFile(filepath.Join(dir, ".beads", doltSyncLockFileName), os.O_CREATE|os.O_RDWR, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if err := lockfile.FlockSharedNonBlock(f); err != nil {
+			t.Fatalf("take shared sync lock: %v", err)
+		}
+		defer func() { _ = lockfile.FlockUnlock(f) }()
+
+		for _, args := range [][]string{{"gc"}, {"backup", backupDir}, {"restore", backupDir, "--force"}} {
+			out := bdDBFail(t, bd, dir, args...)
+			if !strings.Contains(out, "sync") || !strings.Contains(out, "in flight") {
+				t.Errorf("bd db %s: expected a sync-in-flight refusal: %s", args[0], out)
+			}
+		}
+	})
+}
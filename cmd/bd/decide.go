@@ -0,0 +1,391 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage/domain"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var decideCmd = &cobra.Command{
+	Use:     "decide <title>",
+	GroupID: "issues",
+	Short:   "Record an architectural decision (ADR)",
+	Long: `Record an architectural decision as a decision issue.
+
+The structured fields (context, options, outcome, rationale, revisit-by) are
+stored in metadata.decision and rendered into the description using the
+decision section headings. Issues the decision affects are linked with
+"related" dependencies, so 'bd dep list <id>' on either side finds the other.
+
+A decision with --outcome is recorded as decided (closed). Without one it
+stays open as a proposal until 'bd decide resolve'.
+
+Examples:
+  bd decide "Use Dolt for storage" \
+    --context "Need versioned, mergeable issue data" \
+    --option Dolt --option SQLite --option Postgres \
+    --outcome Dolt --rationale "Cell-level merge" \
+    --revisit-by 2025-06-01 --affects bd-12,bd-34
+  bd decide "Pick a queue" --option NATS --option Kafka   # proposal
+  bd decide resolve bd-abc --outcome NATS --rationale "Ops simplicity"
+  bd decide list --revisit-due`,
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("decide")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("decide is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("decide")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		ctx := rootCtx
+		rec, err := decisionRecordFromFlags(cmd)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		affects, _ := cmd.Flags().GetStringSlice("affects")
+		var specs []domain.DependencySpec
+		for _, raw := range affects {
+			id, err := utils.ResolvePartialID(ctx, store, raw)
+			if err != nil {
+				return HandleErrorRespectJSON("resolving --affects %s: %v", raw, err)
+			}
+			specs = append(specs, domain.DependencySpec{TargetID: id, Type: types.DepRelated})
+		}
+
+		meta, err := json.Marshal(map[string]*types.DecisionRecord{types.DecisionMetadataKey: rec})
+		if err != nil {
+			return HandleErrorRespectJSON("encoding decision: %v", err)
+		}
+		priority, _ := cmd.Flags().GetInt("priority")
+		issue := &types.Issue{
+			Title:       strings.Join(args, " "),
+			Description: rec.Markdown(),
+			Status:      types.StatusOpen,
+			Priority:    priority,
+			IssueType:   types.TypeDecision,
+			Metadata:    meta,
+		}
+		if rec.IsDecided() {
+			// A new record has no links yet, so it cannot be a linked duplicate.
+			noLinks := func() (bool, error) { return false, nil }
+			if err := checkClosePolicies(issue.ID, issue, decisionCloseReason(rec), noLinks); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+		}
+		if err := createIssueWithDeps(ctx, store, issue, actor, createDepEdges{specs: specs}); err != nil {
+			return HandleErrorRespectJSON("recording decision: %v", err)
+		}
+		var inReview bool
+		var followups []string
+		if rec.IsDecided() {
+			if err := store.CloseIssue(ctx, issue.ID, decisionCloseReason(rec), actor, ""); err != nil {
+				return HandleErrorRespectJSON("closing decided record: %v", err)
+			}
+			inReview, followups = runPostCloseHooks(ctx, store, issue.ID, issue, actor)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(issue.ID)
+
+		if jsonOutput {
+			return outputJSON(decisionView(issue, rec, specTargets(specs)))
+		}
+		state := "Proposed"
+		if rec.IsDecided() {
+			state = "Decided"
+		}
+		fmt.Printf("%s %s %s\n", ui.RenderPass("✓"), state, formatFeedbackID(issue.ID, issue.Title))
+		if len(specs) > 0 {
+			fmt.Printf("  Affects: %s\n", strings.Join(specTargets(specs), ", "))
+		}
+		printDecisionCloseHooks(inReview, followups)
+		return nil
+	},
+}
+
+var decideResolveCmd = &cobra.Command{
+	Use:           "resolve <id>",
+	Short:         "Record the outcome of a proposed decision",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("decide resolve")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("decide is not supported in proxied-server mode")
+		}
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			return HandleErrorRespectJSON("decision %s not found: %v", id, err)
+		}
+		if issue.IssueType != types.TypeDecision {
+			return HandleErrorRespectJSON("%s is not a decision (type=%s)", id, issue.IssueType)
+		}
+		rec, err := types.DecisionFromMetadata(issue.Metadata)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if rec == nil {
+			rec = &types.DecisionRecord{}
+		}
+		update, err := decisionRecordFromFlags(cmd)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if !update.IsDecided() {
+			return HandleErrorRespectJSON("--outcome is required")
+		}
+		mergeDecisionRecord(rec, update)
+		if issue.Status != types.StatusClosed {
+			duplicateLinked := func() (bool, error) { return hasDuplicateLink(ctx, store, id) }
+			if err := checkClosePolicies(id, issue, decisionCloseReason(rec), duplicateLinked); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+		}
+
+		raw, err := json.Marshal(rec)
+		if err != nil {
+			return HandleErrorRespectJSON("encoding decision: %v", err)
+		}
+		if err := store.MergeMetadata(ctx, id, types.DecisionMetadataKey, raw, actor); err != nil {
+			return HandleErrorRespectJSON("saving decision: %v", err)
+		}
+		if err := store.UpdateIssue(ctx, id, map[string]interface{}{"description": rec.Markdown()}, actor); err != nil {
+			return HandleErrorRespectJSON("updating description: %v", err)
+		}
+		var inReview bool
+		var followups []string
+		if issue.Status != types.StatusClosed {
+			if err := store.CloseIssue(ctx, id, decisionCloseReason(rec), actor, ""); err != nil {
+				return HandleErrorRespectJSON("closing decision: %v", err)
+			}
+			inReview, followups = runPostCloseHooks(ctx, store, id, issue, actor)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(decisionView(issue, rec, nil))
+		}
+		fmt.Printf("%s Decided %s: %s\n", ui.RenderPass("✓"), formatFeedbackID(id, issue.Title), rec.Outcome)
+		printDecisionCloseHooks(inReview, followups)
+		return nil
+	},
+}
+
+var decideListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List decision records",
+	Long: `List decision records with their outcome and revisit date.
+
+--revisit-due narrows to decisions whose revisit-by date has passed.
+--affects narrows to decisions linked to the given issue.`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("decide is not supported in proxied-server mode")
+		}
+		ctx := rootCtx
+		revisitDue, _ := cmd.Flags().GetBool("revisit-due")
+		proposed, _ := cmd.Flags().GetBool("proposed")
+		affects, _ := cmd.Flags().GetString("affects")
+
+		decisionType := types.TypeDecision
+		filter := types.IssueFilter{IssueType: &decisionType}
+		if affects != "" {
+			id, err := utils.ResolvePartialID(ctx, store, affects)
+			if err != nil {
+				return HandleErrorRespectJSON("resolving --affects %s: %v", affects, err)
+			}
+			linked, err := store.GetDependents(ctx, id)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			deps, err := store.GetDependencies(ctx, id)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			for _, is := range append(linked, deps...) {
+				if is.IssueType == types.TypeDecision {
+					filter.IDs = append(filter.IDs, is.ID)
+				}
+			}
+			if len(filter.IDs) == 0 {
+				return printDecisionList(nil)
+			}
+		}
+
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			return HandleErrorRespectJSON("listing decisions: %v", err)
+		}
+		now := time.Now()
+		var views []decisionListItem
+		for _, is := range issues {
+			rec, err := types.DecisionFromMetadata(is.Metadata)
+			if err != nil || rec == nil {
+				rec = &types.DecisionRecord{}
+			}
+			if revisitDue && !rec.RevisitDue(now) {
+				continue
+			}
+			if proposed && rec.IsDecided() {
+				continue
+			}
+			views = append(views, decisionView(is, rec, nil))
+		}
+		return printDecisionList(views)
+	},
+}
+
+// decisionListItem is the JSON shape for decision output.
+type decisionListItem struct {
+	ID       string                `json:"id"`
+	Title    string                `json:"title"`
+	Status   types.Status          `json:"status"`
+	Decision *types.DecisionRecord `json:"decision"`
+	Affects  []string              `json:"affects,omitempty"`
+}
+
+func decisionView(issue *types.Issue, rec *types.DecisionRecord, affects []string) decisionListItem {
+	return decisionListItem{ID: issue.ID, Title: issue.Title, Status: issue.Status, Decision: rec, Affects: affects}
+}
+
+func printDecisionList(items []decisionListItem) error {
+	if jsonOutput {
+		if items == nil {
+			items = []decisionListItem{}
+		}
+		return outputJSON(items)
+	}
+	if len(items) == 0 {
+		fmt.Println("No decisions found.")
+		return nil
+	}
+	for _, d := range items {
+		outcome := ui.RenderWarn("proposed")
+		if d.Decision.IsDecided() {
+			outcome = d.Decision.Outcome
+		}
+		line := fmt.Sprintf("  %s %s → %s", ui.RenderID(d.ID), d.Title, outcome)
+		if d.Decision.RevisitBy != nil {
+			line += fmt.Sprintf(" (revisit %s)", d.Decision.RevisitBy.Format("2006-01-02"))
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// decisionRecordFromFlags reads the structured decision flags shared by
+// bd decide and bd decide resolve.
+func decisionRecordFromFlags(cmd *cobra.Command) (*types.DecisionRecord, error) {
+	rec := &types.DecisionRecord{}
+	rec.Context, _ = cmd.Flags().GetString("context")
+	rec.Options, _ = cmd.Flags().GetStringArray("option")
+	rec.Outcome, _ = cmd.Flags().GetString("outcome")
+	rec.Rationale, _ = cmd.Flags().GetString("rationale")
+	if revisit, _ := cmd.Flags().GetString("revisit-by"); revisit != "" {
+		t, err := timeparsing.ParseRelativeTime(revisit, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("invalid --revisit-by %q: %v", revisit, err)
+		}
+		rec.RevisitBy = &t
+	}
+	if rec.Outcome != "" && len(rec.Options) > 0 && !slices.Contains(rec.Options, rec.Outcome) {
+		rec.Options = append(rec.Options, rec.Outcome)
+	}
+	return rec, nil
+}
+
+// mergeDecisionRecord overlays the non-empty fields of update onto rec.
+func mergeDecisionRecord(rec, update *types.DecisionRecord) {
+	if update.Context != "" {
+		rec.Context = update.Context
+	}
+	for _, opt := range update.Options {
+		if !slices.Contains(rec.Options, opt) {
+			rec.Options = append(rec.Options, opt)
+		}
+	}
+	if update.Outcome != "" {
+		rec.Outcome = update.Outcome
+		if len(rec.Options) > 0 && !slices.Contains(rec.Options, rec.Outcome) {
+			rec.Options = append(rec.Options, rec.Outcome)
+		}
+	}
+	if update.Rationale != "" {
+		rec.Rationale = update.Rationale
+	}
+	if update.RevisitBy != nil {
+		rec.RevisitBy = update.RevisitBy
+	}
+}
+
+// decisionCloseReason is the close reason recorded when a decision is made.
+func decisionCloseReason(rec *types.DecisionRecord) string {
+	return "Decided: " + rec.Outcome
+}
+
+// printDecisionCloseHooks reports what closing a decided record set off.
+func printDecisionCloseHooks(inReview bool, followups []string) {
+	if inReview {
+		fmt.Printf("  %s waiting for human review (bd review list)\n", ui.RenderWarn("→"))
+	}
+	for _, f := range followups {
+		fmt.Printf("  %s follow-up %s created\n", ui.RenderAccent("→"), f)
+	}
+}
+
+func specTargets(specs []domain.DependencySpec) []string {
+	ids := make([]string, 0, len(specs))
+	for _, s := range specs {
+		ids = append(ids, s.TargetID)
+	}
+	return ids
+}
+
+func addDecisionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("context", "", "Background and forces behind the decision")
+	cmd.Flags().StringArray("option", nil, "Option considered (repeatable)")
+	cmd.Flags().String("outcome", "", "Chosen option (records the decision as decided)")
+	cmd.Flags().String("rationale", "", "Why the outcome was chosen")
+	cmd.Flags().String("revisit-by", "", "Date to reconsider the decision (e.g. 2025-06-01, +6m)")
+}
+
+func init() {
+	addDecisionFlags(decideCmd)
+	decideCmd.Flags().StringSlice("affects", nil, "Issue IDs this decision affects (comma-separated)")
+	decideCmd.Flags().IntP("priority", "p", 2, "Priority (0-4)")
+	addDecisionFlags(decideResolveCmd)
+
+	decideListCmd.Flags().Bool("revisit-due", false, "Only decisions whose revisit-by date has passed")
+	decideListCmd.Flags().Bool("proposed", false, "Only decisions without an outcome")
+	decideListCmd.Flags().String("affects", "", "Only decisions linked to this issue")
+
+	decideResolveCmd.ValidArgsFunction = issueIDCompletion
+	decideCmd.AddCommand(decideResolveCmd, decideListCmd)
+	rootCmd.AddCommand(decideCmd)
+}
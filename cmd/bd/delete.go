@@ -88,6 +88,16 @@ Force: Delete and orphan dependents
 			}
 		}
 
+		if force && !dryRun {
+			phrase := "delete " + issueIDs[0]
+			if len(issueIDs) > 1 {
+				phrase = fmt.Sprintf("delete %d issues", len(issueIDs))
+			}
+			if err := requireConfirmation(cmd, confirmOpDelete, phrase, issueIDs); err != nil {
+				return HandleError("%v", err)
+			}
+		}
+
 		if len(issueIDs) > 1 || cascade {
 			if err := deleteBatch(cmd, issueIDs, force, dryRun, cascade, jsonOutput, false); err != nil {
 				return HandleError("%v", err)
@@ -465,6 +475,7 @@ func init() {
 	deleteCmd.Flags().String("from-file", "", "Read issue IDs from file (one per line)")
 	deleteCmd.Flags().Bool("dry-run", false, "Preview what would be deleted without making changes")
 	deleteCmd.Flags().Bool("cascade", false, "Recursively delete all dependent issues")
+	addConfirmFlags(deleteCmd)
 	deleteCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(deleteCmd)
 }
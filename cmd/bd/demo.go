@@ -0,0 +1,512 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var (
+	demoSize string
+	demoSeed int64
+	demoDir  string
+)
+
+const (
+	demoPrefix   = "demo"
+	demoActor    = "demo"
+	demoPeerName = "demo-peer"
+)
+
+// demoEpoch is where the demo's fake clock starts, so a given seed always
+// produces the same timestamps as well as the same IDs and content.
+var demoEpoch = time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+// demoShape sizes a generated demo project.
+type demoShape struct {
+	epics        int
+	tasksPerEpic int
+	bugs         int
+	wisps        int
+}
+
+var demoShapes = map[string]demoShape{
+	"small":  {epics: 2, tasksPerEpic: 4, bugs: 3, wisps: 2},
+	"medium": {epics: 4, tasksPerEpic: 8, bugs: 8, wisps: 4},
+	"large":  {epics: 10, tasksPerEpic: 14, bugs: 25, wisps: 8},
+}
+
+var demoThemes = []struct{ name, label string }{
+	{"User authentication", "auth"},
+	{"Billing", "billing"},
+	{"Search", "search"},
+	{"Notifications", "notifications"},
+	{"Mobile app", "mobile"},
+	{"Reporting", "reporting"},
+	{"Onboarding", "onboarding"},
+	{"Public API v2", "api"},
+	{"Data export", "export"},
+	{"Admin console", "admin"},
+	{"Audit log", "audit"},
+	{"Team workspaces", "workspaces"},
+}
+
+// demoSteps are the tasks an epic's work breaks into, in the order they
+// would naturally happen; later steps depend on earlier ones.
+var demoSteps = []struct{ title, label string }{
+	{"Design %s data model", "backend"},
+	{"Write %s design doc", "docs"},
+	{"Implement %s storage layer", "backend"},
+	{"Implement %s service endpoints", "backend"},
+	{"Build %s UI", "frontend"},
+	{"Handle %s edge cases", "backend"},
+	{"Add %s metrics and alerts", "infra"},
+	{"Write integration tests for %s", "testing"},
+	{"Migrate existing %s data", "infra"},
+	{"Security review of %s", "security"},
+	{"Load-test %s", "infra"},
+	{"Polish %s error messages", "frontend"},
+	{"Document %s for users", "docs"},
+	{"Roll out %s behind a feature flag", "infra"},
+}
+
+var demoBugs = []string{
+	"%s: crash on empty input",
+	"%s: request times out under load",
+	"%s: totals off by one in summary",
+	"%s: stale cache after update",
+	"%s: typo in error message",
+	"%s: race between concurrent writes",
+	"%s: pagination skips the last page",
+	"%s: timezone shifts dates by a day",
+}
+
+// demoWisps are the wisp kinds the demo generates, each created by the
+// agent that would emit it.
+var demoWisps = []struct {
+	title    string
+	wispType types.WispType
+	source   string
+}{
+	{"Patrol: check %s health", types.WispTypePatrol, "monitor"},
+	{"Heartbeat from %s worker", types.WispTypeHeartbeat, "worker"},
+	{"GC report for %s", types.WispTypeGCReport, "janitor"},
+}
+
+var demoAssignees = []string{"alice", "bob", "carol", "dave"}
+
+// demoIssue is one planned issue. key is plan-local; dependsOn, parent, and
+// discoveredFrom refer to other keys. Real IDs are assigned when seeding.
+type demoIssue struct {
+	key            string
+	title          string
+	description    string
+	issueType      types.IssueType
+	priority       int
+	status         types.Status
+	assignee       string
+	labels         []string
+	parent         string
+	dependsOn      []string
+	discoveredFrom string
+	wispType       types.WispType // set for ephemeral wisps
+	createdBy      string         // defaults to demoActor
+}
+
+// buildDemoPlan generates a demo project: epics whose tasks form dependency
+// chains (partly done, partly in progress, partly blocked), bugs discovered
+// while working on those tasks, and a few wisps. The same shape and seed
+// always produce the same plan.
+func buildDemoPlan(shape demoShape, seed int64) []demoIssue {
+	rng := rand.New(rand.NewPCG(uint64(seed), 0)) // #nosec G404 - deterministic demo data, not security sensitive
+	var plan []demoIssue
+	var tasks []string // keys of every task, for bugs and cross-epic deps
+
+	themes := rng.Perm(len(demoThemes))
+	themeName := func(i int) (string, string) {
+		t := demoThemes[themes[i%len(themes)]]
+		if i >= len(themes) {
+			return fmt.Sprintf("%s phase %d", t.name, i/len(themes)+1), t.label
+		}
+		return t.name, t.label
+	}
+
+	for e := 0; e < shape.epics; e++ {
+		name, area := themeName(e)
+		epicKey := fmt.Sprintf("epic%d", e)
+		plan = append(plan, demoIssue{
+			key:         epicKey,
+			title:       name,
+			description: fmt.Sprintf("Deliver %s end to end.", strings.ToLower(name)),
+			issueType:   types.TypeEpic,
+			priority:    rng.IntN(3),
+			status:      types.StatusOpen,
+			labels:      []string{area},
+		})
+
+		// Pick this epic's steps, keeping their natural order.
+		n := min(shape.tasksPerEpic, len(demoSteps))
+		steps := rng.Perm(len(demoSteps))[:n]
+		slices.Sort(steps)
+		// The first done tasks are closed; the next one is in progress.
+		done := rng.IntN(n)
+		var epicTasks []string
+		for i, s := range steps {
+			step := demoSteps[s]
+			key := fmt.Sprintf("epic%d.task%d", e, i)
+			issue := demoIssue{
+				key:       key,
+				title:     fmt.Sprintf(step.title, strings.ToLower(name)),
+				issueType: types.TypeTask,
+				priority:  1 + rng.IntN(3),
+				status:    types.StatusOpen,
+				labels:    []string{area, step.label},
+				parent:    epicKey,
+			}
+			switch {
+			case i < done:
+				issue.status = types.StatusClosed
+				issue.assignee = demoAssignees[rng.IntN(len(demoAssignees))]
+			case i == done && rng.IntN(3) > 0:
+				issue.status = types.StatusInProgress
+				issue.assignee = demoAssignees[rng.IntN(len(demoAssignees))]
+			}
+			if i > 0 {
+				if rng.IntN(2) == 0 {
+					issue.dependsOn = append(issue.dependsOn, epicTasks[i-1])
+				} else if i > 1 && rng.IntN(3) == 0 {
+					issue.dependsOn = append(issue.dependsOn, epicTasks[rng.IntN(i-1)])
+				}
+			} else if e > 0 && len(tasks) > 0 && rng.IntN(3) == 0 {
+				// Occasionally an epic waits on another epic's work.
+				issue.dependsOn = append(issue.dependsOn, tasks[rng.IntN(len(tasks))])
+			}
+			if issue.status == types.StatusClosed {
+				// Closed work only waits on closed work.
+				issue.dependsOn = filterClosedDeps(plan, issue.dependsOn)
+			}
+			plan = append(plan, issue)
+			epicTasks = append(epicTasks, key)
+		}
+		tasks = append(tasks, epicTasks...)
+	}
+
+	for b := 0; b < shape.bugs; b++ {
+		name, area := themeName(rng.IntN(max(shape.epics, 1)))
+		issue := demoIssue{
+			key:       fmt.Sprintf("bug%d", b),
+			title:     fmt.Sprintf(demoBugs[rng.IntN(len(demoBugs))], name),
+			issueType: types.TypeBug,
+			priority:  rng.IntN(4),
+			status:    types.StatusOpen,
+			labels:    []string{area, "bug"},
+		}
+		if len(tasks) > 0 && rng.IntN(2) == 0 {
+			issue.discoveredFrom = tasks[rng.IntN(len(tasks))]
+		}
+		switch rng.IntN(4) {
+		case 0:
+			issue.status = types.StatusClosed
+			issue.assignee = demoAssignees[rng.IntN(len(demoAssignees))]
+		case 1:
+			issue.assignee = demoAssignees[rng.IntN(len(demoAssignees))]
+		}
+		plan = append(plan, issue)
+	}
+
+	for w := 0; w < shape.wisps; w++ {
+		name, _ := themeName(rng.IntN(max(shape.epics, 1)))
+		wisp := demoWisps[w%len(demoWisps)]
+		plan = append(plan, demoIssue{
+			key:       fmt.Sprintf("wisp%d", w),
+			title:     fmt.Sprintf(wisp.title, strings.ToLower(name)),
+			issueType: types.TypeTask,
+			priority:  3,
+			status:    types.StatusOpen,
+			wispType:  wisp.wispType,
+			createdBy: wisp.source,
+		})
+	}
+	return plan
+}
+
+// filterClosedDeps keeps only the dependencies that are already planned as
+// closed.
+func filterClosedDeps(plan []demoIssue, deps []string) []string {
+	var kept []string
+	for _, dep := range deps {
+		for _, p := range plan {
+			if p.key == dep && p.status == types.StatusClosed {
+				kept = append(kept, dep)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+var demoCmd = &cobra.Command{
+	Use:     "demo",
+	GroupID: "setup",
+	Short:   "Create a seeded sandbox project for trying out bd",
+	Long: `Create a realistic sandbox project in a new directory: epics broken into
+task chains with some work done, some in progress, and some blocked; bugs
+discovered along the way; a few wisps; and a federation peer to sync with.
+
+The project is generated from --seed, and its clock and issue IDs are fixed,
+so the same size and seed always produce the same issues, IDs (demo-1,
+demo-2, ...), and timestamps. That makes it suitable for reproducing
+documentation examples and bug reports.
+
+The sandbox is an ordinary bd workspace; cd into it to explore. Your current
+workspace is never touched.
+
+Examples:
+  bd demo                          # Medium project in a new temp directory
+  bd demo --size small             # Fewer issues
+  bd demo --seed 7 --dir ./sandbox # Reproducible project in ./sandbox`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runDemo,
+}
+
+func init() {
+	demoCmd.Flags().StringVar(&demoSize, "size", "medium", "Project size (small|medium|large)")
+	demoCmd.Flags().Int64Var(&demoSeed, "seed", 1, "Seed for the generated content")
+	demoCmd.Flags().StringVar(&demoDir, "dir", "", "Directory to create the sandbox in (default: a new temp directory)")
+	rootCmd.AddCommand(demoCmd)
+}
+
+func runDemo(cmd *cobra.Command, args []string) error {
+	evt := metrics.NewCommandEvent("demo")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	shape, ok := demoShapes[demoSize]
+	if !ok {
+		return HandleErrorRespectJSON("invalid --size %q (must be small, medium, or large)", demoSize)
+	}
+
+	dir, err := prepareDemoDir(demoDir)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if !jsonOutput {
+		fmt.Printf("Creating %s demo project in %s...\n", demoSize, dir)
+	}
+	if err := initDemoWorkspace(rootCtx, dir); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	beadsDir := filepath.Join(dir, ".beads")
+	st, err := newDoltStoreFromConfig(rootCtx, beadsDir)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to open demo store: %v", err)
+	}
+	defer func() { _ = st.Close() }()
+
+	clock := storage.NewFakeClock(demoEpoch)
+	ctx := storage.WithIDGenerator(storage.WithClock(rootCtx, clock), storage.NewSequentialIDGenerator())
+	plan := buildDemoPlan(shape, demoSeed)
+	if err := seedDemo(ctx, st, clock, plan); err != nil {
+		return HandleErrorRespectJSON("failed to seed demo project: %v", err)
+	}
+
+	peerURL := "file://" + filepath.ToSlash(filepath.Join(dir, ".demo-peer"))
+	if err := addDemoPeer(ctx, st, peerURL); err != nil {
+		return HandleErrorRespectJSON("failed to set up demo peer: %v", err)
+	}
+
+	stats, err := st.GetStatistics(ctx)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to read demo statistics: %v", err)
+	}
+	wisps := 0
+	for _, p := range plan {
+		if p.wispType != "" {
+			wisps++
+		}
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"dir":         dir,
+			"size":        demoSize,
+			"seed":        demoSeed,
+			"prefix":      demoPrefix,
+			"issues":      stats.TotalIssues,
+			"open":        stats.OpenIssues,
+			"in_progress": stats.InProgressIssues,
+			"blocked":     stats.BlockedIssues,
+			"ready":       stats.ReadyIssues,
+			"closed":      stats.ClosedIssues,
+			"wisps":       wisps,
+			"peer":        demoPeerName,
+			"peer_url":    peerURL,
+		})
+	}
+
+	fmt.Printf("\n%s Demo project ready: %s\n\n", ui.RenderPass("✓"), dir)
+	fmt.Printf("  %d issues: %d open, %d in progress, %d closed (%d blocked, %d ready)\n",
+		stats.TotalIssues, stats.OpenIssues, stats.InProgressIssues, stats.ClosedIssues, stats.BlockedIssues, stats.ReadyIssues)
+	fmt.Printf("  %d wisps\n", wisps)
+	fmt.Printf("  Federation peer %s at %s\n", demoPeerName, peerURL)
+	fmt.Printf("  Seed %d (re-run with --size %s --seed %d for the same project)\n\n", demoSeed, demoSize, demoSeed)
+	fmt.Printf("Try:\n")
+	fmt.Printf("  cd %s\n", dir)
+	for _, c := range []string{"bd ready", "bd blocked", "bd list --type epic", "bd children demo-1", "bd federation status"} {
+		fmt.Printf("  %s\n", ui.RenderAccent(c))
+	}
+	fmt.Println()
+	return nil
+}
+
+// prepareDemoDir returns the absolute sandbox directory, creating it. An
+// empty dir means a new temp directory; an existing one must not already
+// hold a beads workspace.
+func prepareDemoDir(dir string) (string, error) {
+	if dir == "" {
+		d, err := os.MkdirTemp("", "bd-demo-")
+		if err != nil {
+			return "", fmt.Errorf("failed to create temp directory: %w", err)
+		}
+		return d, nil
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid --dir %q: %w", dir, err)
+	}
+	if _, err := os.Stat(filepath.Join(abs, ".beads")); err == nil {
+		return "", fmt.Errorf("%s already contains a .beads workspace", abs)
+	}
+	if err := os.MkdirAll(abs, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", abs, err)
+	}
+	return abs, nil
+}
+
+// initDemoWorkspace runs this binary's own 'bd init' in dir, so the sandbox
+// is set up exactly like a real workspace. Workspace-selecting environment
+// variables are dropped so the caller's workspace is never touched.
+func initDemoWorkspace(ctx context.Context, dir string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate bd executable: %w", err)
+	}
+	// #nosec G204 - runs this same binary with fixed arguments
+	initCmd := exec.CommandContext(ctx, self, "init", "--prefix", demoPrefix,
+		"--quiet", "--non-interactive", "--skip-hooks", "--skip-agents")
+	initCmd.Dir = dir
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, "BEADS_DIR=") || strings.HasPrefix(kv, "BEADS_DB=") {
+			continue
+		}
+		initCmd.Env = append(initCmd.Env, kv)
+	}
+	var out bytes.Buffer
+	initCmd.Stdout = &out
+	initCmd.Stderr = &out
+	if err := initCmd.Run(); err != nil {
+		return fmt.Errorf("bd init failed in %s: %w\n%s", dir, err, strings.TrimSpace(out.String()))
+	}
+	return nil
+}
+
+// seedDemo creates the plan's issues, then links parents and dependencies,
+// and finally closes finished work, advancing clock between writes so the
+// project has a plausible history.
+func seedDemo(ctx context.Context, st storage.DoltStorage, clock *storage.FakeClock, plan []demoIssue) error {
+	ids := make(map[string]string, len(plan))
+	for _, p := range plan {
+		clock.Advance(47 * time.Minute)
+		issue := &types.Issue{
+			Title:       p.title,
+			Description: p.description,
+			IssueType:   p.issueType,
+			Priority:    p.priority,
+			Status:      p.status,
+			Assignee:    p.assignee,
+			Ephemeral:   p.wispType != "",
+			WispType:    p.wispType,
+			CreatedBy:   p.createdBy,
+		}
+		if issue.CreatedBy == "" {
+			issue.CreatedBy = demoActor
+		}
+		if p.status == types.StatusClosed {
+			issue.Status = types.StatusOpen
+		}
+		if err := st.CreateIssue(ctx, issue, demoActor); err != nil {
+			return fmt.Errorf("creating %q: %w", p.title, err)
+		}
+		ids[p.key] = issue.ID
+		for _, label := range p.labels {
+			if err := st.AddLabel(ctx, issue.ID, label, demoActor); err != nil {
+				return fmt.Errorf("labeling %s: %w", issue.ID, err)
+			}
+		}
+	}
+
+	for _, p := range plan {
+		var deps []*types.Dependency
+		if p.parent != "" {
+			deps = append(deps, &types.Dependency{IssueID: ids[p.key], DependsOnID: ids[p.parent], Type: types.DepParentChild})
+		}
+		for _, d := range p.dependsOn {
+			deps = append(deps, &types.Dependency{IssueID: ids[p.key], DependsOnID: ids[d], Type: types.DepBlocks})
+		}
+		if p.discoveredFrom != "" {
+			deps = append(deps, &types.Dependency{IssueID: ids[p.key], DependsOnID: ids[p.discoveredFrom], Type: types.DepDiscoveredFrom})
+		}
+		for _, dep := range deps {
+			if err := st.AddDependency(ctx, dep, demoActor); err != nil {
+				return fmt.Errorf("linking %s -> %s: %w", dep.IssueID, dep.DependsOnID, err)
+			}
+		}
+	}
+
+	for _, p := range plan {
+		if p.status != types.StatusClosed {
+			continue
+		}
+		clock.Advance(3 * time.Hour)
+		if err := st.CloseIssue(ctx, ids[p.key], "Done", demoActor, ""); err != nil {
+			return fmt.Errorf("closing %s: %w", ids[p.key], err)
+		}
+	}
+	return st.Commit(ctx, "bd demo: seed project")
+}
+
+// addDemoPeer registers a local file:// federation peer and pushes the
+// seeded project to it, so federation commands have something to talk to.
+func addDemoPeer(ctx context.Context, st storage.DoltStorage, url string) error {
+	peer := &storage.FederationPeer{
+		Name:      demoPeerName,
+		RemoteURL: url,
+		SyncMode:  storage.SyncModeBidirectional,
+	}
+	if err := st.AddFederationPeer(ctx, peer); err != nil {
+		return err
+	}
+	if err := st.Commit(ctx, "bd demo: add federation peer"); err != nil && !strings.Contains(err.Error(), "nothing to commit") {
+		return err
+	}
+	return st.PushTo(ctx, demoPeerName)
+}
@@ -0,0 +1,64 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmbeddedDemo(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	home := t.TempDir()
+	dir := filepath.Join(home, "sandbox")
+
+	cmd := exec.Command(bd, "demo", "--size", "small", "--seed", "3", "--dir", dir, "--json")
+	cmd.Dir = home
+	cmd.Env = bdEnv(home)
+	stdout, stderr, err := runCommandBuffers(t, cmd)
+	if err != nil {
+		t.Fatalf("bd demo failed: %v\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
+	}
+	var result struct {
+		Dir     string `json:"dir"`
+		Issues  int    `json:"issues"`
+		Blocked int    `json:"blocked"`
+		Wisps   int    `json:"wisps"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("parse demo output: %v\n%s", err, stdout.String())
+	}
+	shape := demoShapes["small"]
+	if want := shape.epics*(1+shape.tasksPerEpic) + shape.bugs; result.Issues != want {
+		t.Errorf("issues = %d, want %d", result.Issues, want)
+	}
+	if result.Wisps != shape.wisps {
+		t.Errorf("wisps = %d, want %d", result.Wisps, shape.wisps)
+	}
+
+	// The sandbox is an ordinary workspace with sequential IDs.
+	show := exec.Command(bd, "show", "demo-1", "--json")
+	show.Dir = dir
+	show.Env = bdEnv(home)
+	if out, err := show.CombinedOutput(); err != nil {
+		t.Fatalf("bd show demo-1 in sandbox failed: %v\n%s", err, out)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".beads")); err == nil {
+		t.Error("bd demo created a workspace outside --dir")
+	}
+
+	again := exec.Command(bd, "demo", "--dir", dir)
+	again.Dir = home
+	again.Env = bdEnv(home)
+	if out, err := again.CombinedOutput(); err == nil {
+		t.Errorf("bd demo into an existing workspace succeeded:\n%s", out)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildDemoPlanDeterministic(t *testing.T) {
+	shape := demoShapes["medium"]
+	a, b := buildDemoPlan(shape, 7), buildDemoPlan(shape, 7)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("same seed produced different plans")
+	}
+	if reflect.DeepEqual(a, buildDemoPlan(shape, 8)) {
+		t.Error("different seeds produced identical plans")
+	}
+}
+
+func TestBuildDemoPlanShape(t *testing.T) {
+	for name, shape := range demoShapes {
+		t.Run(name, func(t *testing.T) {
+			plan := buildDemoPlan(shape, 1)
+			byKey := make(map[string]demoIssue, len(plan))
+			counts := map[types.IssueType]int{}
+			wisps := 0
+			for _, p := range plan {
+				if _, dup := byKey[p.key]; dup {
+					t.Fatalf("duplicate key %q", p.key)
+				}
+				byKey[p.key] = p
+				if p.wispType != "" {
+					wisps++
+					continue
+				}
+				counts[p.issueType]++
+			}
+			if counts[types.TypeEpic] != shape.epics || counts[types.TypeTask] != shape.epics*shape.tasksPerEpic ||
+				counts[types.TypeBug] != shape.bugs || wisps != shape.wisps {
+				t.Errorf("counts = %v + %d wisps, want shape %+v", counts, wisps, shape)
+			}
+
+			for _, p := range plan {
+				refs := append([]string{p.parent, p.discoveredFrom}, p.dependsOn...)
+				for _, ref := range refs {
+					if ref == "" {
+						continue
+					}
+					if _, ok := byKey[ref]; !ok {
+						t.Errorf("%s references unknown key %q", p.key, ref)
+					}
+				}
+				if p.status != types.StatusClosed {
+					continue
+				}
+				for _, dep := range p.dependsOn {
+					if byKey[dep].status != types.StatusClosed {
+						t.Errorf("closed %s depends on unfinished %s", p.key, dep)
+					}
+				}
+			}
+		})
+	}
+}
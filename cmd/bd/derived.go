@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// loadDerivedFields parses the derived.fields config. Returns nil when none
+// are defined.
+func loadDerivedFields() ([]query.DerivedField, error) {
+	return query.ParseDerivedFields(config.DerivedFields())
+}
+
+// computeDerivedFields fills issue.Derived for issues, a no-op when no
+// derived fields are configured.
+func computeDerivedFields(ctx context.Context, st storage.DoltStorage, issues []*types.Issue, fields []query.DerivedField) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return query.ComputeDerived(ctx, st, issues, fields, time.Now())
+}
+
+// computeDerivedFieldsWithCounts is computeDerivedFields for JSON result rows.
+func computeDerivedFieldsWithCounts(ctx context.Context, st storage.DoltStorage, items []*types.IssueWithCounts, fields []query.DerivedField) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	issues := make([]*types.Issue, 0, len(items))
+	for _, item := range items {
+		if item != nil && item.Issue != nil {
+			issues = append(issues, item.Issue)
+		}
+	}
+	return computeDerivedFields(ctx, st, issues, fields)
+}
+
+// validateDerivedSort checks that a derived.<name> sort key names a
+// configured field.
+func validateDerivedSort(sortBy string, fields []query.DerivedField) error {
+	name, ok := strings.CutPrefix(sortBy, "derived.")
+	if !ok {
+		return nil
+	}
+	for _, f := range fields {
+		if f.Name == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown derived field %q (define it under derived.fields in config.yaml)", name)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/besteffort"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/metrics"
 	"github.com/steveyegge/beads/internal/ui"
@@ -93,7 +94,11 @@ Storage Availability:
 
 Performance Mode (--perf):
   Run performance diagnostics on your database:
-  - Times key operations (bd ready, bd list, bd show, etc.)
+  - Times key operations (bd ready, bd list, bd show, etc.) and compares
+    them to the expected latency for a database of its size
+  - Checks for missing indexes, oversized descriptions, and a bloated
+    wisps table
+  - Prints prioritized remediation steps, most important first
   - Collects system info (OS, arch, database stats)
   - Generates CPU profile for analysis
   - Outputs shareable report for bug reports
@@ -504,9 +509,9 @@ func runDiagnostics(path string) doctorResult {
 	_ = os.Setenv("BEADS_DIR", beadsDir)
 	trackBdVersion()
 	if hadBeadsDir {
-		_ = os.Setenv("BEADS_DIR", origBeadsDir)
+		besteffort.Record("restore BEADS_DIR", os.Setenv("BEADS_DIR", origBeadsDir))
 	} else {
-		_ = os.Unsetenv("BEADS_DIR")
+		besteffort.Record("restore BEADS_DIR", os.Unsetenv("BEADS_DIR"))
 	}
 
 	autoMigrateOnVersionBump(beadsDir)
@@ -124,7 +124,7 @@ func CheckLegacyCLIRemotes(path string) DoctorCheck {
 			continue
 		}
 
-		cliRemotes, err := doltutil.ListCLIRemotes(dir)
+		cliRemotes, err := doltutil.ListCLIRemotes(ctx, dir)
 		if err != nil {
 			inspectErrors = append(inspectErrors, fmt.Sprintf("%s (%s): %v", loc.label, dir, err))
 			continue
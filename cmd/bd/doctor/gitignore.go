@@ -19,6 +19,7 @@ bd.sock
 bd.sock.startlock
 sync-state.json
 last-touched
+more-state.json
 .exclusive-lock
 
 # Daemon runtime (lock, log, pid)
@@ -33,6 +34,9 @@ push-state.json
 # Credential key (encryption key for federation peer auth — never commit)
 .beads-credential-key
 
+# Issue key (encrypts fields of bd create --encrypted issues — never commit)
+.beads-issue-key
+
 # Local version tracking (prevents upgrade notification spam after git ops)
 .local_version
 
@@ -48,6 +52,9 @@ redirect
 export-state/
 export-state.json
 last_pull
+active-branch
+offline-queue.jsonl
+agent-access.json
 
 # Ephemeral store (SQLite - wisps/molecules, intentionally not versioned)
 ephemeral.sqlite3
@@ -106,11 +113,15 @@ var requiredPatterns = []string{
 	".env",
 	"redirect",
 	"last-touched",
+	"more-state.json",
 	"bd.sock.startlock",
 	".sync.lock",
 	"export-state/",
 	"export-state.json",
 	"last_pull",
+	"active-branch",
+	"offline-queue.jsonl",
+	"agent-access.json",
 	"dolt/",
 	"embeddeddolt/",
 	"proxieddb/",
@@ -124,6 +135,7 @@ var requiredPatterns = []string{
 	"*.lock",
 	"*.corrupt.backup/",
 	".beads-credential-key",
+	".beads-issue-key",
 	"proxied_server_client_info.json",
 	".local_version",
 	"backup/",
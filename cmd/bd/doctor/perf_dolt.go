@@ -39,6 +39,11 @@ type DoltPerfMetrics struct {
 	ComplexQueryTime int64 // Time for complex filter query
 	CommitLogTime    int64 // Time to query dolt_log
 
+	// Baseline is the expected latency for a database of this size, and
+	// Findings the problems found, most urgent first (see perf_health.go).
+	Baseline *PerfBaseline
+	Findings []PerfFinding
+
 	// Profile file path if profiling was enabled
 	ProfilePath string
 }
@@ -231,6 +236,7 @@ func runDoltDiagnosticQueries(ctx context.Context, db *sql.DB, metrics *DoltPerf
 		LIMIT 10
 	`)
 
+	runPerfHealthChecks(ctx, db, metrics)
 	return nil
 }
 
@@ -320,17 +326,21 @@ func PrintDoltPerfReport(metrics *DoltPerfMetrics) {
 	fmt.Printf("  Dependencies:      %d\n", metrics.Dependencies)
 	fmt.Printf("  Database size:     %s\n", metrics.DatabaseSize)
 
-	fmt.Printf("\nOperation Performance (ms):\n")
+	baseline := baselineFor(metrics.TotalIssues)
+	if metrics.Baseline != nil {
+		baseline = *metrics.Baseline
+	}
+	fmt.Printf("\nOperation Performance (ms, expected for a %s database):\n", baseline.Tier)
 	fmt.Printf("  Connection:               %s\n", formatTiming(metrics.ConnectionTime))
-	fmt.Printf("  bd ready (GetReadyWork):  %s\n", formatTiming(metrics.ReadyWorkTime))
-	fmt.Printf("  bd list --status=open:    %s\n", formatTiming(metrics.ListOpenTime))
-	fmt.Printf("  bd show <issue>:          %s\n", formatTiming(metrics.ShowIssueTime))
-	fmt.Printf("  Complex filter query:     %s\n", formatTiming(metrics.ComplexQueryTime))
-	fmt.Printf("  dolt_log query:           %s\n", formatTiming(metrics.CommitLogTime))
+	fmt.Printf("  bd ready (GetReadyWork):  %s\n", formatTimingWithBaseline(metrics.ReadyWorkTime, baseline.ReadyWork))
+	fmt.Printf("  bd list --status=open:    %s\n", formatTimingWithBaseline(metrics.ListOpenTime, baseline.ListOpen))
+	fmt.Printf("  bd show <issue>:          %s\n", formatTimingWithBaseline(metrics.ShowIssueTime, baseline.ShowIssue))
+	fmt.Printf("  Complex filter query:     %s\n", formatTimingWithBaseline(metrics.ComplexQueryTime, baseline.ComplexQuery))
+	fmt.Printf("  dolt_log query:           %s\n", formatTimingWithBaseline(metrics.CommitLogTime, baseline.CommitLog))
 
 	// Performance assessment
 	fmt.Printf("\nPerformance Assessment:\n")
-	assessDoltPerformance(metrics)
+	printPerfFindings(metrics.Findings)
 
 	if metrics.ProfilePath != "" {
 		fmt.Printf("\nCPU Profile saved: %s\n", metrics.ProfilePath)
@@ -347,41 +357,34 @@ func formatTiming(ms int64) string {
 	return fmt.Sprintf("%dms", ms)
 }
 
-// assessDoltPerformance provides performance recommendations
-func assessDoltPerformance(metrics *DoltPerfMetrics) {
-	var warnings []string
-	var recommendations []string
-
-	// Check ready work query time
-	if metrics.ReadyWorkTime > 200 {
-		warnings = append(warnings, fmt.Sprintf("Slow ready-work query (%dms)", metrics.ReadyWorkTime))
-		recommendations = append(recommendations, "Check index on issues.status column")
-	}
-
-	// Check complex query time
-	if metrics.ComplexQueryTime > 500 {
-		warnings = append(warnings, fmt.Sprintf("Slow complex query (%dms)", metrics.ComplexQueryTime))
-		recommendations = append(recommendations, "Consider reviewing query patterns and indexes")
-	}
-
-	// Check database size
-	if metrics.TotalIssues > 5000 && metrics.ClosedIssues > 4000 {
-		recommendations = append(recommendations, "Many closed issues. Consider 'bd cleanup' to prune old issues.")
+func formatTimingWithBaseline(ms, expected int64) string {
+	if ms < 0 {
+		return "failed"
 	}
+	return fmt.Sprintf("%-8s (expected under %dms)", fmt.Sprintf("%dms", ms), expected)
+}
 
-	if len(warnings) == 0 {
+// printPerfFindings prints the findings as numbered remediation steps, most
+// urgent first.
+func printPerfFindings(findings []PerfFinding) {
+	if len(findings) == 0 {
 		fmt.Println("  [OK] Performance looks healthy")
-	} else {
-		for _, w := range warnings {
-			fmt.Printf("  [WARN] %s\n", w)
-		}
+		return
+	}
+	for _, f := range findings {
+		fmt.Printf("  [%s] %s\n", f.Severity, f.Problem)
 	}
 
-	if len(recommendations) > 0 {
-		fmt.Printf("\nRecommendations:\n")
-		for _, r := range recommendations {
-			fmt.Printf("  - %s\n", r)
+	fmt.Printf("\nRemediation (most important first):\n")
+	seen := make(map[string]bool)
+	step := 0
+	for _, f := range findings {
+		if seen[f.Remedy] {
+			continue
 		}
+		seen[f.Remedy] = true
+		step++
+		fmt.Printf("  %d. %s\n", step, f.Remedy)
 	}
 }
 
@@ -410,23 +413,21 @@ func CheckDoltPerformance(path string) DoctorCheck {
 		}
 	}
 
-	// Assess performance
+	// Assess performance: low findings are advisory and only shown by --perf.
 	var issues []string
-
-	if metrics.ConnectionTime > 1000 {
-		issues = append(issues, fmt.Sprintf("slow connection (%dms)", metrics.ConnectionTime))
-	}
-
-	if metrics.ReadyWorkTime > 500 {
-		issues = append(issues, fmt.Sprintf("slow ready-work (%dms)", metrics.ReadyWorkTime))
+	for _, f := range metrics.Findings {
+		if f.Severity >= PerfMedium {
+			issues = append(issues, f.Problem)
+		}
 	}
 
 	if len(issues) > 0 {
 		return DoctorCheck{
 			Name:     "Dolt Performance",
 			Status:   StatusWarning,
-			Message:  strings.Join(issues, "; "),
-			Fix:      "Run 'bd doctor perf-dolt' for detailed analysis",
+			Message:  fmt.Sprintf("%d performance problem(s) found", len(issues)),
+			Detail:   strings.Join(issues, "\n"),
+			Fix:      "Run 'bd doctor --perf' for prioritized remediation steps",
 			Category: CategoryPerformance,
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 func TestRunDoltPerformanceDiagnostics_RequiresServer(t *testing.T) {
@@ -61,3 +62,35 @@ func TestRunDoltPerformanceDiagnosticsDeclineNonDoltBackend(t *testing.T) {
 		t.Fatalf("diagnostic error should explain the backend mismatch: %v", err)
 	}
 }
+
+func TestPerfHealthChecks(t *testing.T) {
+	store := newTestDoltStore(t, "perf")
+	ctx := t.Context()
+
+	big := &types.Issue{
+		Title:       "Pasted build log",
+		Description: strings.Repeat("x", oversizedDescriptionBytes+1),
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, big, "test"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	findings, err := checkPerfIndexes(ctx, store.DB())
+	if err != nil {
+		t.Fatalf("checkPerfIndexes: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("a migrated schema has every hot-path index; got %+v", findings)
+	}
+
+	findings, err = checkOversizedDescriptions(ctx, store.DB())
+	if err != nil {
+		t.Fatalf("checkOversizedDescriptions: %v", err)
+	}
+	if len(findings) != 1 || !strings.Contains(findings[0].Problem, big.ID) {
+		t.Errorf("findings = %+v; want one naming %s", findings, big.ID)
+	}
+}
@@ -0,0 +1,328 @@
+package doctor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// PerfSeverity ranks a performance finding; higher is more urgent.
+type PerfSeverity int
+
+const (
+	PerfLow PerfSeverity = iota
+	PerfMedium
+	PerfHigh
+)
+
+func (s PerfSeverity) String() string {
+	switch s {
+	case PerfHigh:
+		return "HIGH"
+	case PerfMedium:
+		return "MEDIUM"
+	default:
+		return "LOW"
+	}
+}
+
+// PerfFinding is one problem found by the performance health check and the
+// step that fixes it.
+type PerfFinding struct {
+	Severity PerfSeverity
+	Problem  string
+	Remedy   string
+}
+
+// PerfBaseline is the latency, in milliseconds, a healthy database of a
+// given size should stay under for each measured operation.
+type PerfBaseline struct {
+	Tier         string
+	MaxIssues    int
+	ReadyWork    int64
+	ListOpen     int64
+	ShowIssue    int64
+	ComplexQuery int64
+	CommitLog    int64
+}
+
+// perfBaselines are ordered by size; a database uses the first tier whose
+// MaxIssues it does not exceed. The numbers are what a local sql-server on
+// an SSD manages with the schema's indexes in place, with headroom.
+var perfBaselines = []PerfBaseline{
+	{Tier: "small (under 1,000 issues)", MaxIssues: 1000, ReadyWork: 50, ListOpen: 30, ShowIssue: 10, ComplexQuery: 100, CommitLog: 50},
+	{Tier: "medium (under 10,000 issues)", MaxIssues: 10000, ReadyWork: 150, ListOpen: 80, ShowIssue: 20, ComplexQuery: 300, CommitLog: 100},
+	{Tier: "large (under 50,000 issues)", MaxIssues: 50000, ReadyWork: 400, ListOpen: 200, ShowIssue: 40, ComplexQuery: 800, CommitLog: 200},
+	{Tier: "very large (50,000+ issues)", MaxIssues: math.MaxInt, ReadyWork: 1000, ListOpen: 500, ShowIssue: 80, ComplexQuery: 2000, CommitLog: 400},
+}
+
+// baselineFor returns the latency baseline for a database of totalIssues.
+func baselineFor(totalIssues int) PerfBaseline {
+	for _, b := range perfBaselines {
+		if totalIssues < b.MaxIssues {
+			return b
+		}
+	}
+	return perfBaselines[len(perfBaselines)-1]
+}
+
+const (
+	// slowConnectionMs is where connecting stops looking like a local server.
+	slowConnectionMs = 1000
+	// oversizedDescriptionBytes is where a description starts to weigh on
+	// every full-row read and on Dolt history.
+	oversizedDescriptionBytes = 64 * 1024
+	// closedWispBloat and wispBloat are where the wisps table is worth
+	// pruning: closed wisps are never read again, and every list merges the
+	// table in.
+	closedWispBloat = 1000
+	wispBloat       = 10000
+)
+
+// perfIndex is an index the hot read paths rely on: some index on Table
+// must lead with Column. Name and the CREATE statement restore the one the
+// schema migrations create.
+type perfIndex struct {
+	Table, Column, Name string
+}
+
+var perfIndexes = []perfIndex{
+	{"issues", "status", "idx_issues_status"},
+	{"issues", "created_at", "idx_issues_created_at"},
+	{"dependencies", "issue_id", "idx_dependencies_issue"},
+	{"dependencies", "depends_on_issue_id", "idx_dep_issue_target"},
+	{"labels", "label", "idx_labels_label"},
+	{"events", "issue_id", "idx_events_issue"},
+	{"comments", "issue_id", "idx_comments_issue"},
+	{"wisps", "status", "idx_wisps_status"},
+}
+
+// assessLatency compares the measured timings to the baseline for the
+// database's size. A timing over three times its baseline is HIGH.
+func assessLatency(metrics *DoltPerfMetrics, baseline PerfBaseline) []PerfFinding {
+	var findings []PerfFinding
+	if metrics.ConnectionTime > slowConnectionMs {
+		findings = append(findings, PerfFinding{
+			Severity: PerfMedium,
+			Problem:  fmt.Sprintf("Connecting took %dms", metrics.ConnectionTime),
+			Remedy:   "Check the Dolt server host and network; a local server connects in well under a second",
+		})
+	}
+
+	historyRemedy := "Run 'bd compact' to squash old Dolt commits, then 'bd gc' to reclaim space"
+	queryRemedy := "Restore any missing indexes listed here, then run 'bd gc' to drop old closed issues"
+	if metrics.TotalIssues > 0 && metrics.ClosedIssues*2 < metrics.TotalIssues {
+		queryRemedy = "Restore any missing indexes listed here; if none are missing, share this report in a bug report"
+	}
+	for _, op := range []struct {
+		name     string
+		took     int64
+		expected int64
+		remedy   string
+	}{
+		{"bd ready", metrics.ReadyWorkTime, baseline.ReadyWork, queryRemedy},
+		{"bd list", metrics.ListOpenTime, baseline.ListOpen, queryRemedy},
+		{"bd show", metrics.ShowIssueTime, baseline.ShowIssue, queryRemedy},
+		{"Complex filter query", metrics.ComplexQueryTime, baseline.ComplexQuery, queryRemedy},
+		{"dolt_log query", metrics.CommitLogTime, baseline.CommitLog, historyRemedy},
+	} {
+		if op.took <= op.expected {
+			continue
+		}
+		severity := PerfMedium
+		if op.took > 3*op.expected {
+			severity = PerfHigh
+		}
+		findings = append(findings, PerfFinding{
+			Severity: severity,
+			Problem:  fmt.Sprintf("%s took %dms (expected under %dms for a %s database)", op.name, op.took, op.expected, baseline.Tier),
+			Remedy:   op.remedy,
+		})
+	}
+	return findings
+}
+
+// checkPerfIndexes reports the hot-path indexes the database lacks. Tables
+// the database does not have (e.g. wisps before its migration) are skipped.
+func checkPerfIndexes(ctx context.Context, db *sql.DB) ([]PerfFinding, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT LOWER(table_name), LOWER(column_name)
+		FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND seq_in_index = 1`)
+	if err != nil {
+		return nil, fmt.Errorf("reading indexes: %w", err)
+	}
+	leading := make(map[string]bool)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("reading indexes: %w", err)
+		}
+		leading[table+"."+column] = true
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading indexes: %w", err)
+	}
+
+	tables, err := perfTables(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var findings []PerfFinding
+	for _, idx := range perfIndexes {
+		if !tables[idx.Table] || leading[idx.Table+"."+idx.Column] {
+			continue
+		}
+		findings = append(findings, PerfFinding{
+			Severity: PerfHigh,
+			Problem:  fmt.Sprintf("No index on %s.%s", idx.Table, idx.Column),
+			Remedy:   fmt.Sprintf("Recreate the index: bd sql 'CREATE INDEX %s ON %s (%s)'", idx.Name, idx.Table, idx.Column),
+		})
+	}
+	return findings, nil
+}
+
+// perfTables returns the names of the current database's tables.
+func perfTables(ctx context.Context, db *sql.DB) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT LOWER(table_name) FROM information_schema.tables
+		WHERE table_schema = DATABASE()`)
+	if err != nil {
+		return nil, fmt.Errorf("reading tables: %w", err)
+	}
+	defer rows.Close()
+	tables := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("reading tables: %w", err)
+		}
+		tables[name] = true
+	}
+	return tables, rows.Err()
+}
+
+// checkOversizedDescriptions reports issues whose descriptions are large
+// enough to slow every full-row read.
+func checkOversizedDescriptions(ctx context.Context, db *sql.DB) ([]PerfFinding, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, LENGTH(description) AS size FROM issues
+		WHERE LENGTH(description) > ?
+		ORDER BY size DESC`, oversizedDescriptionBytes)
+	if err != nil {
+		return nil, fmt.Errorf("measuring descriptions: %w", err)
+	}
+	defer rows.Close()
+	var examples []string
+	count := 0
+	for rows.Next() {
+		var id string
+		var size int64
+		if err := rows.Scan(&id, &size); err != nil {
+			return nil, fmt.Errorf("measuring descriptions: %w", err)
+		}
+		count++
+		if len(examples) < 3 {
+			examples = append(examples, fmt.Sprintf("%s (%d KB)", id, size/1024))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("measuring descriptions: %w", err)
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	severity := PerfLow
+	if count >= 10 {
+		severity = PerfMedium
+	}
+	problem := fmt.Sprintf("%d issue(s) with descriptions over %d KB: %s", count, oversizedDescriptionBytes/1024, strings.Join(examples, ", "))
+	if count > len(examples) {
+		problem += fmt.Sprintf(" (+%d more)", count-len(examples))
+	}
+	return []PerfFinding{{
+		Severity: severity,
+		Problem:  problem,
+		Remedy:   "Move logs and pasted output out of the description (link to a file instead) with 'bd update <id> --body-file'",
+	}}, nil
+}
+
+// checkWispBloat reports a wisps table grown past what a list should merge.
+func checkWispBloat(ctx context.Context, db *sql.DB) ([]PerfFinding, error) {
+	tables, err := perfTables(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if !tables["wisps"] {
+		return nil, nil
+	}
+	var total, closed int
+	if err := db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(CASE WHEN status = 'closed' THEN 1 ELSE 0 END), 0)
+		FROM wisps`).Scan(&total, &closed); err != nil {
+		return nil, fmt.Errorf("counting wisps: %w", err)
+	}
+	var findings []PerfFinding
+	if closed >= closedWispBloat {
+		severity := PerfMedium
+		if closed >= wispBloat {
+			severity = PerfHigh
+		}
+		findings = append(findings, PerfFinding{
+			Severity: severity,
+			Problem:  fmt.Sprintf("%d closed wisps (of %d) are still stored", closed, total),
+			Remedy:   "Run 'bd mol wisp gc --closed --force' to delete closed wisps",
+		})
+	}
+	if open := total - closed; open >= wispBloat {
+		findings = append(findings, PerfFinding{
+			Severity: PerfMedium,
+			Problem:  fmt.Sprintf("%d open wisps", open),
+			Remedy:   "Run 'bd mol wisp gc --dry-run' to find abandoned wisps, then 'bd mol wisp gc' to delete them",
+		})
+	}
+	return findings, nil
+}
+
+// sortPerfFindings orders findings most urgent first, keeping the check
+// order within a severity.
+func sortPerfFindings(findings []PerfFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+}
+
+// runPerfHealthChecks fills in the baseline and findings from the measured
+// timings and the schema and data checks. A check that cannot run is
+// reported as a low finding rather than failing the report.
+func runPerfHealthChecks(ctx context.Context, db *sql.DB, metrics *DoltPerfMetrics) {
+	baseline := baselineFor(metrics.TotalIssues)
+	metrics.Baseline = &baseline
+	findings := assessLatency(metrics, baseline)
+	for _, check := range []struct {
+		name string
+		run  func(context.Context, *sql.DB) ([]PerfFinding, error)
+	}{
+		{"index", checkPerfIndexes},
+		{"description size", checkOversizedDescriptions},
+		{"wisp table", checkWispBloat},
+	} {
+		more, err := check.run(ctx, db)
+		if err != nil {
+			findings = append(findings, PerfFinding{
+				Severity: PerfLow,
+				Problem:  fmt.Sprintf("The %s check could not run: %v", check.name, err),
+				Remedy:   "Re-run 'bd doctor --perf'; if it keeps failing, include this report in a bug report",
+			})
+			continue
+		}
+		findings = append(findings, more...)
+	}
+	sortPerfFindings(findings)
+	metrics.Findings = findings
+}
@@ -0,0 +1,67 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBaselineFor(t *testing.T) {
+	for _, tc := range []struct {
+		issues int
+		tier   string
+	}{
+		{0, "small"},
+		{999, "small"},
+		{1000, "medium"},
+		{49999, "large"},
+		{250000, "very large"},
+	} {
+		if got := baselineFor(tc.issues).Tier; !strings.HasPrefix(got, tc.tier) {
+			t.Errorf("baselineFor(%d) = %q; want the %s tier", tc.issues, got, tc.tier)
+		}
+	}
+}
+
+func TestAssessLatency(t *testing.T) {
+	baseline := baselineFor(5000)
+	metrics := &DoltPerfMetrics{
+		TotalIssues:      5000,
+		ClosedIssues:     4000,
+		ConnectionTime:   5,
+		ReadyWorkTime:    baseline.ReadyWork + 1,
+		ListOpenTime:     3*baseline.ListOpen + 1,
+		ShowIssueTime:    -1, // failed measurements are not findings
+		ComplexQueryTime: baseline.ComplexQuery,
+		CommitLogTime:    1,
+	}
+	findings := assessLatency(metrics, baseline)
+	if len(findings) != 2 {
+		t.Fatalf("findings = %+v; want the ready and list timings", findings)
+	}
+	if findings[0].Severity != PerfMedium || !strings.HasPrefix(findings[0].Problem, "bd ready") {
+		t.Errorf("ready finding = %+v; want MEDIUM", findings[0])
+	}
+	if findings[1].Severity != PerfHigh || !strings.Contains(findings[1].Problem, baseline.Tier) {
+		t.Errorf("list finding = %+v; want HIGH, naming the size tier", findings[1])
+	}
+	if !strings.Contains(findings[0].Remedy, "bd gc") {
+		t.Errorf("remedy = %q; a mostly-closed database should be pointed at bd gc", findings[0].Remedy)
+	}
+}
+
+func TestSortPerfFindings(t *testing.T) {
+	findings := []PerfFinding{
+		{Severity: PerfLow, Problem: "a"},
+		{Severity: PerfHigh, Problem: "b"},
+		{Severity: PerfMedium, Problem: "c"},
+		{Severity: PerfHigh, Problem: "d"},
+	}
+	sortPerfFindings(findings)
+	var order []string
+	for _, f := range findings {
+		order = append(order, f.Problem)
+	}
+	if got := strings.Join(order, ""); got != "bdca" {
+		t.Errorf("order = %s; want bdca (by severity, stable within one)", got)
+	}
+}
@@ -41,9 +41,13 @@ var trackedRuntimePatterns = []string{
 	"export-state.json",
 	"sync-state.json",
 	"last-touched",
+	"more-state.json",
 	"last_pull", // bd-578h9.6: gitignored since 7ebf4df6a, but gitignore cannot untrack already-committed copies
 	".local_version",
 	"redirect",
+	"active-branch",
+	"offline-queue.jsonl",
+	"agent-access.json",
 
 	// Sync / export state
 	".sync.lock",
@@ -68,6 +72,7 @@ var trackedRuntimeDirPrefixes = []string{
 // committed anywhere under .beads/.
 var sensitiveFileNames = []string{
 	".beads-credential-key",
+	".beads-issue-key",
 	"credential-key",
 }
 
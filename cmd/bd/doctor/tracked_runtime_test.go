@@ -29,6 +29,7 @@ func TestShouldFlagTrackedFile_RuntimeArtifacts(t *testing.T) {
 	}{
 		{"last_pull", true}, // bd-578h9.6
 		{"last-touched", true},
+		{"more-state.json", true},
 		{"push-state.json", true},
 		{"sync-state.json", true},
 		{"daemon.pid", true},
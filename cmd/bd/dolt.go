@@ -373,7 +373,12 @@ The remote must already exist (see 'bd dolt remote add').`,
 			fmt.Println("To re-enable remote sync: bd config unset dolt.local-only")
 			return nil
 		}
-		ctx := context.Background()
+		release, err := beginDoltSync()
+		if err != nil {
+			return HandleError("%v", err)
+		}
+		defer release()
+		ctx := rootCtx
 		st := getStore()
 		if st == nil {
 			return HandleError("no store available")
@@ -459,7 +464,12 @@ The remote must already exist (see 'bd dolt remote add').`,
 			fmt.Println("To re-enable remote sync: bd config unset dolt.local-only")
 			return nil
 		}
-		ctx := context.Background()
+		release, err := beginDoltSync()
+		if err != nil {
+			return HandleError("%v", err)
+		}
+		defer release()
+		ctx := rootCtx
 		st := getStore()
 		if st == nil {
 			return HandleError("no store available")
@@ -509,16 +519,18 @@ var doltCommitCmd = &cobra.Command{
 
 This is the primary commit point for batch mode. When auto-commit is set to
 "batch", changes accumulate in the working set across multiple bd commands and
-are committed together here with a descriptive summary message.
+are committed together here with a descriptive summary message (or
+automatically, once commit.batch_interval or commit.batch_max_ops is reached).
 
 Also useful before push operations that require a clean working set, or when
 auto-commit was off or changes were made externally.
 
-For more options (--stdin, custom messages), see: bd vc commit`,
+bd commit does the same with a message that summarizes the batch. For
+more options (--stdin, custom messages), see: bd vc commit`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := rootCtx
 		st := getStore()
 		if st == nil {
 			return HandleError("no store available")
@@ -1026,7 +1038,7 @@ Use --dry-run to see what would be dropped without actually dropping.`,
 		}
 		defer cleanup()
 
-		listCtx, listCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		listCtx, listCancel := context.WithTimeout(rootCtx, 30*time.Second)
 		defer listCancel()
 
 		rows, err := db.QueryContext(listCtx, "SHOW DATABASES")
@@ -1078,6 +1090,13 @@ Use --dry-run to see what would be dropped without actually dropping.`,
 		)
 
 		for i, name := range stale {
+			// Ctrl-C stops between drops; a drop already under way runs to
+			// completion on its own context rather than being cut off.
+			if rootCtx.Err() != nil {
+				fmt.Fprintf(os.Stderr, "\nInterrupted: dropped %d/%d before stopping.\n", dropped, len(stale))
+				return SilentExit()
+			}
+
 			// Circuit breaker: back off when server is overwhelmed
 			if consecutiveTimeouts >= timeoutThreshold {
 				fmt.Fprintf(os.Stderr, "  ⚠ %d consecutive timeouts — backing off %s\n",
@@ -1225,7 +1244,7 @@ var doltRemoteAddCmd = &cobra.Command{
 			}
 			fmt.Fprintf(os.Stderr, "Warning: %q matches the git origin — proceeding because --allow-git-origin is set.\n", args[1])
 		}
-		ctx := context.Background()
+		ctx := rootCtx
 		st := getStore()
 		if st == nil {
 			return HandleError("no store available")
@@ -1275,7 +1294,7 @@ var doltRemoteListCmd = &cobra.Command{
 	SilenceErrors: true,
 	Short:         "List configured Dolt remotes",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := rootCtx
 		st := getStore()
 		if st == nil {
 			return HandleError("no store available")
@@ -1338,7 +1357,7 @@ var doltRemoteRemoveCmd = &cobra.Command{
 	SilenceErrors: true,
 	Args:          cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := rootCtx
 		name := args[0]
 
 		if usesProxiedServer() {
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/issueops"
 )
@@ -61,7 +64,8 @@ type doltAutoCommitParams struct {
 //   - Only applies when dolt auto-commit is "on" AND the active store is versioned (Dolt).
 //   - Skips SQL server modes; the server owns transaction commit lifecycle there.
 //   - In "batch" mode, commits are deferred — changes accumulate in the working set
-//     until an explicit commit point (bd dolt commit).
+//     until commit.batch_interval or commit.batch_max_ops is reached, or an
+//     explicit commit point (bd commit).
 //   - Uses Dolt's "commit all" behavior under the hood (DOLT_COMMIT -Am).
 //   - Treats "nothing to commit" as a no-op.
 func maybeAutoCommit(ctx context.Context, p doltAutoCommitParams) error {
@@ -86,9 +90,7 @@ func maybeAutoCommitStore(ctx context.Context, st storage.DoltStorage, p doltAut
 	if err != nil {
 		return err
 	}
-	// In batch mode, skip per-command commits. Changes stay in the working set
-	// and are committed at logical boundaries (bd dolt commit).
-	if mode != doltAutoCommitOn {
+	if mode == doltAutoCommitOff {
 		return nil
 	}
 
@@ -98,6 +100,12 @@ func maybeAutoCommitStore(ctx context.Context, st storage.DoltStorage, p doltAut
 	if lm, ok := storage.UnwrapStore(st).(storage.LifecycleManager); ok && lm.IsClosed() {
 		return nil
 	}
+	// In batch mode, skip per-command commits. Changes stay in the working set
+	// and are committed together once a coalescing threshold is reached, or at
+	// logical boundaries (bd commit).
+	if mode == doltAutoCommitBatch {
+		return coalesceBatchCommit(ctx, st, loadBatchCoalescePolicy(), time.Now())
+	}
 
 	msg := p.MessageOverride
 	if strings.TrimSpace(msg) == "" {
@@ -113,6 +121,136 @@ func maybeAutoCommitStore(ctx context.Context, st storage.DoltStorage, p doltAut
 	return nil
 }
 
+// batchCoalescePolicy says when batch mode commits the writes it has
+// accumulated: once the last commit is Interval old, or once MaxOps writes
+// are pending. A zero value disables that trigger; with both zero, batch
+// mode commits only at bd commit (and on SIGTERM/SIGHUP).
+type batchCoalescePolicy struct {
+	Interval time.Duration
+	MaxOps   int
+}
+
+func loadBatchCoalescePolicy() batchCoalescePolicy {
+	return batchCoalescePolicy{
+		Interval: config.GetDuration("commit.batch_interval"),
+		MaxOps:   config.GetInt("commit.batch_max_ops"),
+	}
+}
+
+func (p batchCoalescePolicy) enabled() bool {
+	return p.Interval > 0 || p.MaxOps > 0
+}
+
+// due reports whether the pending writes should be committed at now.
+func (p batchCoalescePolicy) due(pending *storage.PendingChanges, now time.Time) bool {
+	if pending.Operations == 0 && len(pending.IssueIDs) == 0 {
+		return false
+	}
+	if p.MaxOps > 0 && pending.Operations >= p.MaxOps {
+		return true
+	}
+	return p.Interval > 0 && now.Sub(pending.HeadDate) >= p.Interval
+}
+
+// maybeCoalesceBatchCommit checks the batch thresholds after a command that
+// did not go through maybeAutoCommit. Batch-mode writes such as bd create
+// skip their own commit without marking commandDidWrite, so the thresholds
+// would otherwise only be checked by some commands.
+func maybeCoalesceBatchCommit(ctx context.Context) error {
+	if !isEmbeddedMode() {
+		return nil
+	}
+	mode, err := getDoltAutoCommitMode()
+	if err != nil || mode != doltAutoCommitBatch {
+		return err
+	}
+	st := getStore()
+	if st == nil {
+		return nil
+	}
+	if lm, ok := storage.UnwrapStore(st).(storage.LifecycleManager); ok && lm.IsClosed() {
+		return nil
+	}
+	return coalesceBatchCommit(ctx, st, loadBatchCoalescePolicy(), time.Now())
+}
+
+// runBatchCommitTicker checks the batch thresholds every
+// commit.batch_interval until ctx is done. A long-running command (bd serve)
+// otherwise only checks them when it writes, so the last writes before a
+// quiet spell would wait for the next write or for shutdown. mu is held for
+// each check so a commit never lands in the middle of the command's writes.
+func runBatchCommitTicker(ctx context.Context, st storage.DoltStorage, mu sync.Locker) {
+	mode, err := getDoltAutoCommitMode()
+	policy := loadBatchCoalescePolicy()
+	if err != nil || mode != doltAutoCommitBatch || policy.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(policy.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			mu.Lock()
+			err := coalesceBatchCommit(ctx, st, policy, now)
+			mu.Unlock()
+			if err != nil && ctx.Err() == nil {
+				WarnError("batch auto-commit failed: %v", err)
+			}
+		}
+	}
+}
+
+// coalesceBatchCommit commits the batched working set in one commit when
+// policy says it is due. The writes were already visible before the commit:
+// every command reads the working set, so the writer (and anyone else on
+// the clone) sees its own uncommitted changes.
+func coalesceBatchCommit(ctx context.Context, st storage.DoltStorage, policy batchCoalescePolicy, now time.Time) error {
+	if !policy.enabled() {
+		return nil
+	}
+	reader, ok := storage.UnwrapStore(st).(storage.PendingChangesReader)
+	if !ok {
+		return nil
+	}
+	pending, err := reader.PendingChanges(ctx)
+	if err != nil {
+		return err
+	}
+	if !policy.due(pending, now) {
+		return nil
+	}
+	if err := st.Commit(ctx, formatBatchCommitMessage(getActor(), pending)); err != nil && !isDoltNothingToCommit(err) {
+		return err
+	}
+	return nil
+}
+
+// formatBatchCommitMessage describes a batch of coalesced writes, e.g.
+// "bd: batch of 12 writes by alice [bd-1, bd-2, +3 more]".
+func formatBatchCommitMessage(actor string, pending *storage.PendingChanges) string {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		actor = "unknown"
+	}
+	writes := "writes"
+	if pending.Operations == 1 {
+		writes = "write"
+	}
+	msg := fmt.Sprintf("bd: batch of %d %s by %s", pending.Operations, writes, actor)
+	if len(pending.IssueIDs) == 0 {
+		return msg
+	}
+
+	const maxIDs = 5
+	ids := pending.IssueIDs
+	if len(ids) > maxIDs {
+		ids = append(ids[:maxIDs:maxIDs], fmt.Sprintf("+%d more", len(pending.IssueIDs)-maxIDs))
+	}
+	return msg + " [" + strings.Join(ids, ", ") + "]"
+}
+
 func isDoltNothingToCommit(err error) bool {
 	return issueops.IsNothingToCommitError(err)
 }
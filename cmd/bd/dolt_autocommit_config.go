@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
 )
 
 type doltAutoCommitMode string
@@ -31,3 +33,14 @@ func getDoltAutoCommitMode() (doltAutoCommitMode, error) {
 		return "", fmt.Errorf("invalid --dolt-auto-commit=%q (valid: off, on, batch)", doltAutoCommit)
 	}
 }
+
+// configuredDoltAutoCommit returns the auto-commit policy from config for
+// when --dolt-auto-commit is not given. Setting commit.batch_interval or
+// commit.batch_max_ops implies batch mode unless dolt.auto-commit is set
+// explicitly, so a batching threshold is all it takes to turn batching on.
+func configuredDoltAutoCommit() string {
+	if config.GetValueSource("dolt.auto-commit") == config.SourceDefault && loadBatchCoalescePolicy().enabled() {
+		return string(doltAutoCommitBatch)
+	}
+	return config.GetString("dolt.auto-commit")
+}
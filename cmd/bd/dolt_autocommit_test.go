@@ -2,7 +2,12 @@ package main
 
 import (
 	"errors"
+	"os"
 	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
 )
 
 func TestFormatDoltAutoCommitMessage(t *testing.T) {
@@ -24,6 +29,55 @@ func TestFormatDoltAutoCommitMessage(t *testing.T) {
 	}
 }
 
+func TestFormatBatchCommitMessage(t *testing.T) {
+	msg := formatBatchCommitMessage("alice", &storage.PendingChanges{Operations: 3, IssueIDs: []string{"bd-1", "bd-2"}})
+	if msg != "bd: batch of 3 writes by alice [bd-1, bd-2]" {
+		t.Fatalf("unexpected message: %q", msg)
+	}
+
+	ids := []string{"a-1", "b-2", "c-3", "d-4", "e-5", "f-6", "g-7"}
+	msg = formatBatchCommitMessage("bob", &storage.PendingChanges{Operations: 9, IssueIDs: ids})
+	if msg != "bd: batch of 9 writes by bob [a-1, b-2, c-3, d-4, e-5, +2 more]" {
+		t.Fatalf("unexpected capped message: %q", msg)
+	}
+	if len(ids) != 7 || ids[5] != "f-6" {
+		t.Fatalf("capping modified the caller's IDs: %v", ids)
+	}
+
+	msg = formatBatchCommitMessage("", &storage.PendingChanges{Operations: 1})
+	if msg != "bd: batch of 1 write by unknown" {
+		t.Fatalf("unexpected fallback message: %q", msg)
+	}
+}
+
+func TestBatchCoalescePolicyDue(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	pending := func(ops int, sinceCommit time.Duration) *storage.PendingChanges {
+		return &storage.PendingChanges{Operations: ops, IssueIDs: []string{"bd-1"}, HeadDate: now.Add(-sinceCommit)}
+	}
+	tests := []struct {
+		name    string
+		policy  batchCoalescePolicy
+		pending *storage.PendingChanges
+		want    bool
+	}{
+		{"disabled", batchCoalescePolicy{}, pending(100, time.Hour), false},
+		{"nothing pending", batchCoalescePolicy{Interval: time.Second, MaxOps: 1}, &storage.PendingChanges{HeadDate: now.Add(-time.Hour)}, false},
+		{"under both", batchCoalescePolicy{Interval: time.Minute, MaxOps: 10}, pending(3, 10*time.Second), false},
+		{"ops reached", batchCoalescePolicy{Interval: time.Minute, MaxOps: 10}, pending(10, 10*time.Second), true},
+		{"interval reached", batchCoalescePolicy{Interval: time.Minute, MaxOps: 10}, pending(3, time.Minute), true},
+		{"interval only", batchCoalescePolicy{Interval: time.Minute}, pending(500, 30*time.Second), false},
+		{"ops only", batchCoalescePolicy{MaxOps: 5}, pending(4, 24*time.Hour), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.enabled() && tt.policy.due(tt.pending, now); got != tt.want {
+				t.Errorf("due = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsDoltNothingToCommit(t *testing.T) {
 	if isDoltNothingToCommit(nil) {
 		t.Fatal("nil error should not be treated as nothing-to-commit")
@@ -78,3 +132,41 @@ func TestGetDoltAutoCommitMode_Batch(t *testing.T) {
 		t.Fatal("expected error for invalid mode")
 	}
 }
+
+func TestConfiguredDoltAutoCommitImpliesBatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		wantMode string
+	}{
+		{name: "defaults", wantMode: "on"},
+		{name: "max ops implies batch", env: map[string]string{"BD_COMMIT_BATCH_MAX_OPS": "10"}, wantMode: "batch"},
+		{name: "interval implies batch", env: map[string]string{"BD_COMMIT_BATCH_INTERVAL": "30s"}, wantMode: "batch"},
+		{
+			name:     "explicit mode wins",
+			env:      map[string]string{"BD_COMMIT_BATCH_MAX_OPS": "10", "BD_DOLT_AUTO_COMMIT": "on"},
+			wantMode: "on",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			for _, key := range []string{"BD_COMMIT_BATCH_MAX_OPS", "BD_COMMIT_BATCH_INTERVAL", "BD_DOLT_AUTO_COMMIT"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			config.ResetForTesting()
+			t.Cleanup(config.ResetForTesting)
+			if err := config.Initialize(); err != nil {
+				t.Fatalf("config.Initialize: %v", err)
+			}
+
+			if got := configuredDoltAutoCommit(); got != tt.wantMode {
+				t.Errorf("configuredDoltAutoCommit() = %q, want %q", got, tt.wantMode)
+			}
+		})
+	}
+}
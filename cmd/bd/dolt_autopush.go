@@ -157,6 +157,13 @@ func maybeAutoPush(ctx context.Context) {
 		return
 	}
 
+	release, err := beginDoltSync()
+	if err != nil {
+		debug.Logf("dolt auto-push: skipped (%v)\n", err)
+		return
+	}
+	defer release()
+
 	// Push with a bounded timeout so an unreachable remote doesn't block
 	// the CLI indefinitely (GH#3370). The timeout is configurable via
 	// dolt.auto-push-timeout (default 30s).
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/lockfile"
+)
+
+// doltSyncLockFileName marks Dolt data moving to or from a remote. Syncs
+// (bd dolt push/pull, bd federation sync, bd backup sync, auto-push) hold
+// it shared, so they can overlap one another; bd db maintenance holds it
+// exclusively, so neither starts while the other is under way.
+const doltSyncLockFileName = "dolt-sync.lock"
+
+var (
+	// errDBMaintenanceInProgress is returned by beginDoltSync while a bd db
+	// command holds the sync lock.
+	errDBMaintenanceInProgress = errors.New("database maintenance (bd db gc/backup/restore) is in progress; retry when it finishes")
+	// errDoltSyncInFlight is returned by beginDBMaintenance while a sync
+	// holds the sync lock.
+	errDoltSyncInFlight = errors.New("a Dolt sync (push, pull, federation or backup sync) is in flight")
+)
+
+func openDoltSyncLock() (*os.File, error) {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return nil, nil
+	}
+	//nolint:gosec // G304: path is inside the active .beads directory
+	return os.OpenFile(filepath.Join(beadsDir, doltSyncLockFileName), os.O_CREATE|os.O_RDWR, 0600)
+}
+
+// beginDoltSync marks a Dolt sync in flight until the returned release func
+// is called. It fails only while bd db maintenance runs; a lock file that
+// cannot be opened (e.g. a read-only .beads) does not block the sync.
+func beginDoltSync() (func(), error) {
+	f, err := openDoltSyncLock()
+	if err != nil || f == nil {
+		if err != nil {
+			debug.Logf("dolt sync lock: %v\n", err)
+		}
+		return func() {}, nil
+	}
+	if err := lockfile.FlockSharedNonBlock(f); err != nil {
+		_ = f.Close()
+		if errors.Is(err, lockfile.ErrLockBusy) {
+			return nil, errDBMaintenanceInProgress
+		}
+		debug.Logf("dolt sync lock: %v\n", err)
+		return func() {}, nil
+	}
+	return func() {
+		_ = lockfile.FlockUnlock(f)
+		_ = f.Close()
+	}, nil
+}
+
+// beginDBMaintenance takes the sync lock exclusively without waiting, so a
+// bd db command refuses to start while any sync is in flight and keeps new
+// syncs out until the returned release func is called.
+func beginDBMaintenance() (func(), error) {
+	f, err := openDoltSyncLock()
+	if err != nil {
+		return nil, fmt.Errorf("open sync lock: %w", err)
+	}
+	if f == nil {
+		return func() {}, nil
+	}
+	if err := lockfile.FlockExclusiveNonBlock(f); err != nil {
+		_ = f.Close()
+		if errors.Is(err, lockfile.ErrLockBusy) {
+			return nil, errDoltSyncInFlight
+		}
+		return nil, fmt.Errorf("acquire sync lock: %w", err)
+	}
+	return func() {
+		_ = lockfile.FlockUnlock(f)
+		_ = f.Close()
+	}, nil
+}
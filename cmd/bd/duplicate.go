@@ -110,7 +110,8 @@ func runDuplicate(cmd *cobra.Command, args []string) error {
 	// Close the duplicate issue
 	closedStatus := string(types.StatusClosed)
 	updates := map[string]interface{}{
-		"status": closedStatus,
+		"status":       closedStatus,
+		"close_reason": types.CloseReasonDuplicate + ": " + canonicalID,
 	}
 	if err := store.UpdateIssue(ctx, duplicateID, updates, actor); err != nil {
 		return fmt.Errorf("failed to close duplicate: %w", err)
@@ -25,7 +25,10 @@ Examples:
   bd edit bd-42 --title            # Edit title
   bd edit bd-42 --design           # Edit design notes
   bd edit bd-42 --notes            # Edit notes
-  bd edit bd-42 --acceptance       # Edit acceptance criteria`,
+  bd edit bd-42 --acceptance       # Edit acceptance criteria
+
+The issue is locked (see bd lock) while the editor is open, so someone
+else's bd edit or bd update fails instead of being silently overwritten.`,
 	Args:          cobra.ExactArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -82,6 +85,13 @@ Examples:
 			return HandleErrorRespectJSON("no editor found. Set $EDITOR or $VISUAL environment variable")
 		}
 
+		force, _ := cmd.Flags().GetBool("force")
+		releaseLock, err := lockForEdit(ctx, issueStore, id, force)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		defer releaseLock()
+
 		issue := result.Issue
 
 		var currentValue string
@@ -164,6 +174,7 @@ Examples:
 			return HandleErrorRespectJSON("updating issue: %v", err)
 		}
 		editSaved = true
+		releaseLock()
 		if err := commitPendingIfEmbedded(ctx, issueStore, actor, doltAutoCommitParams{
 			Command:  "edit",
 			IssueIDs: []string{id},
@@ -189,6 +200,7 @@ func init() {
 	editCmd.Flags().Bool("design", false, "Edit the design notes")
 	editCmd.Flags().Bool("notes", false, "Edit the notes")
 	editCmd.Flags().Bool("acceptance", false, "Edit the acceptance criteria")
+	editCmd.Flags().Bool("force", false, "Edit even if someone else has locked the issue (bd lock)")
 	editCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(editCmd)
 }
@@ -37,6 +37,7 @@ var epicStatusCmd = &cobra.Command{
 		if err != nil {
 			return HandleErrorRespectJSON("getting epic status: %v", err)
 		}
+		attachEpicActivity(rootCtx, store, epics)
 		return renderEpicStatus(epics, eligibleOnly)
 	},
 }
@@ -72,6 +73,9 @@ func renderEpicStatus(epics []*types.EpicStatus, eligibleOnly bool) error {
 		fmt.Printf("%s %s %s\n", statusIcon, ui.RenderAccent(epic.ID), ui.RenderBold(epic.Title))
 		fmt.Printf("   Progress: %d/%d children closed (%d%%)\n",
 			epicStatus.ClosedChildren, epicStatus.TotalChildren, percentage)
+		if epicStatus.Activity != nil {
+			fmt.Printf("   Activity: %s\n", formatTrend(epicStatus.Activity))
+		}
 		if epicStatus.EligibleForClose {
 			fmt.Printf("   %s\n", ui.RenderPass("Eligible for closure"))
 		}
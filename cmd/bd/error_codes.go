@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/dberrors"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/storage/domain"
+)
+
+// ErrorCode is the machine-readable category of a user-facing error. It is
+// reported as "code" in JSON error output and, with BD_ERROR_EXIT_CODES=1,
+// selects the exit status, so scripts can branch on the kind of failure
+// instead of parsing the message. 'bd errors' lists the codes.
+type ErrorCode string
+
+const (
+	// ErrGeneral is any failure not in a more specific category, such as
+	// invalid input or an unexpected storage error.
+	ErrGeneral ErrorCode = "general"
+	// ErrNotFound means an issue or other named object does not exist.
+	ErrNotFound ErrorCode = "not_found"
+	// ErrConflict means the request clashes with the current state: the issue
+	// is claimed by someone else, changed since it was read, or a merge left
+	// conflicts.
+	ErrConflict ErrorCode = "conflict"
+	// ErrCycle means a dependency would make an issue wait on itself.
+	ErrCycle ErrorCode = "cycle"
+	// ErrAuth means credentials for the Dolt server or a remote were
+	// missing or rejected.
+	ErrAuth ErrorCode = "auth"
+	// ErrBackendUnavailable means the Dolt server or a remote could not be
+	// reached. Retrying later may succeed.
+	ErrBackendUnavailable ErrorCode = "backend_unavailable"
+)
+
+// errorCodes describes each code for 'bd errors', in the order listed. The
+// exit codes are used only with BD_ERROR_EXIT_CODES=1; otherwise every error
+// exits 1.
+var errorCodes = []struct {
+	Code        ErrorCode
+	ExitCode    int
+	Description string
+}{
+	{ErrGeneral, 1, "Any failure not in a category below (invalid input, unexpected errors)"},
+	{ErrNotFound, 3, "An issue or other named object does not exist"},
+	{ErrConflict, 4, "Clashes with the current state (claimed, changed concurrently, merge conflicts)"},
+	{ErrCycle, 5, "A dependency would make an issue wait on itself"},
+	{ErrAuth, 6, "Credentials for the Dolt server or a remote were missing or rejected"},
+	{ErrBackendUnavailable, 7, "The Dolt server or a remote could not be reached; retry later"},
+}
+
+// errorExitCodesEnabled reports whether errors exit with their category's
+// code instead of 1. Off by default: exit 1 for every error is part of the
+// CLI contract existing scripts rely on.
+func errorExitCodesEnabled() bool {
+	return os.Getenv("BD_ERROR_EXIT_CODES") == "1"
+}
+
+// exitCodeFor returns the exit status for an error in category code.
+func exitCodeFor(code ErrorCode) int {
+	if !errorExitCodesEnabled() {
+		return 1
+	}
+	for _, c := range errorCodes {
+		if c.Code == code {
+			return c.ExitCode
+		}
+	}
+	return 1
+}
+
+// classifyError returns the category of err, matching the storage sentinels
+// and typed errors first and falling back to the message, which is all a
+// proxied-server error carries.
+func classifyError(err error) ErrorCode {
+	if err == nil {
+		return ErrGeneral
+	}
+	var hierarchy *domain.DependencyHierarchyConflictError
+	var typeConflict *domain.DependencyTypeConflictError
+	switch {
+	case dolt.IsServerUnreachable(err):
+		return ErrBackendUnavailable
+	case dberrors.IsAccessDenied(err):
+		return ErrAuth
+	case errors.Is(err, domain.ErrDependencyCycle),
+		errors.Is(err, domain.ErrSelfDependency),
+		errors.As(err, &hierarchy):
+		return ErrCycle
+	case errors.Is(err, storage.ErrAlreadyClaimed),
+		errors.Is(err, storage.ErrNotOwner),
+		errors.Is(err, storage.ErrVersionMismatch),
+		errors.Is(err, storage.ErrMergeConflicts),
+		errors.Is(err, storage.ErrIssueLocked),
+		errors.Is(err, storage.ErrSlugTaken),
+		errors.As(err, &typeConflict):
+		return ErrConflict
+	case errors.Is(err, storage.ErrNotFound), dberrors.IsNoRows(err):
+		return ErrNotFound
+	}
+	return classifyErrorMessage(err.Error())
+}
+
+// classifyErrorMessage categorizes an error by its message, for call sites
+// that report a formatted message rather than an error value.
+func classifyErrorMessage(msg string) ErrorCode {
+	if dolt.IsServerUnreachable(errors.New(msg)) {
+		return ErrBackendUnavailable
+	}
+	lower := strings.ToLower(msg)
+	contains := func(subs ...string) bool {
+		for _, s := range subs {
+			if strings.Contains(lower, s) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case contains("access denied", "authentication failed", "authentication required",
+		"permission denied (publickey)", "could not read username", "unauthorized"):
+		return ErrAuth
+	case contains("would create a cycle", "dependency cycle", "cannot depend on itself"):
+		return ErrCycle
+	case contains("already claimed", "claimed by a different actor", "version mismatch",
+		"merge conflicts", "unresolved conflicts"):
+		return ErrConflict
+	case contains("not found", "no issue found", "no issues found"):
+		return ErrNotFound
+	}
+	return ErrGeneral
+}
+
+// classifyErrorArgs categorizes a formatted error message by the first
+// error among its format args that falls in a specific category, or by the
+// message itself.
+func classifyErrorArgs(msg string, args []interface{}) ErrorCode {
+	for _, a := range args {
+		if err, ok := a.(error); ok {
+			if code := classifyError(err); code != ErrGeneral {
+				return code
+			}
+		}
+	}
+	return classifyErrorMessage(msg)
+}
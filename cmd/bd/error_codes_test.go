@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	mysql "github.com/go-sql-driver/mysql"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/storage/domain"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{"nil", nil, ErrGeneral},
+		{"not_found", fmt.Errorf("get issue bd-1: %w", storage.ErrNotFound), ErrNotFound},
+		{"already_claimed", fmt.Errorf("claim: %w", storage.ErrAlreadyClaimed), ErrConflict},
+		{"version_mismatch", storage.ErrVersionMismatch, ErrConflict},
+		{"merge_conflicts", fmt.Errorf("%w in issues", storage.ErrMergeConflicts), ErrConflict},
+		{"type_conflict", &domain.DependencyTypeConflictError{IssueID: "a", DependsOnID: "b"}, ErrConflict},
+		{"cycle", fmt.Errorf("add dep: %w", domain.ErrDependencyCycle), ErrCycle},
+		{"self_dependency", domain.ErrSelfDependency, ErrCycle},
+		{"hierarchy", &domain.DependencyHierarchyConflictError{IssueID: "a", BlockerID: "b"}, ErrCycle},
+		{"access_denied", &mysql.MySQLError{Number: 1045, Message: "Access denied for user 'root'"}, ErrAuth},
+		{"circuit_open", fmt.Errorf("open store: %w", dolt.ErrCircuitOpen), ErrBackendUnavailable},
+		{"connection_refused", errors.New("dial tcp 127.0.0.1:3307: connect: connection refused"), ErrBackendUnavailable},
+		{"validation", errors.New("invalid priority 9"), ErrGeneral},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyError(tt.err); got != tt.want {
+				t.Errorf("classifyError(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorArgs(t *testing.T) {
+	// An error arg in a specific category wins over the message.
+	err := fmt.Errorf("claim: %w", storage.ErrAlreadyClaimed)
+	if got := classifyErrorArgs("failed to claim bd-1: "+err.Error(), []interface{}{"bd-1", err}); got != ErrConflict {
+		t.Errorf("classifyErrorArgs with conflict error = %q, want %q", got, ErrConflict)
+	}
+	// Without one, the message decides.
+	if got := classifyErrorArgs("issue bd-1 not found", []interface{}{"bd-1"}); got != ErrNotFound {
+		t.Errorf("classifyErrorArgs for not-found message = %q, want %q", got, ErrNotFound)
+	}
+	if got := classifyErrorArgs("bd-1: boom", []interface{}{errors.New("boom")}); got != ErrGeneral {
+		t.Errorf("classifyErrorArgs for generic error = %q, want %q", got, ErrGeneral)
+	}
+}
+
+func TestExitCodeFor(t *testing.T) {
+	t.Setenv("BD_ERROR_EXIT_CODES", "")
+	for _, c := range errorCodes {
+		if got := exitCodeFor(c.Code); got != 1 {
+			t.Errorf("exitCodeFor(%q) without BD_ERROR_EXIT_CODES = %d, want 1", c.Code, got)
+		}
+	}
+
+	t.Setenv("BD_ERROR_EXIT_CODES", "1")
+	seen := make(map[int]ErrorCode)
+	for _, c := range errorCodes {
+		got := exitCodeFor(c.Code)
+		if got != c.ExitCode {
+			t.Errorf("exitCodeFor(%q) = %d, want %d", c.Code, got, c.ExitCode)
+		}
+		if prev, dup := seen[got]; dup {
+			t.Errorf("exit code %d used by both %q and %q", got, prev, c.Code)
+		}
+		seen[got] = c.Code
+	}
+	if got := exitCodeFor(ErrorCode("unknown")); got != 1 {
+		t.Errorf("exitCodeFor(unknown) = %d, want 1", got)
+	}
+}
+
+func TestBuildJSONErrorIncludesCode(t *testing.T) {
+	t.Setenv("BD_JSON_ENVELOPE", "")
+	flat, ok := buildJSONError("issue bd-1 not found", "", ErrNotFound).(map[string]interface{})
+	if !ok || flat["code"] != ErrNotFound {
+		t.Errorf("flat error = %v, want code %q", flat, ErrNotFound)
+	}
+
+	t.Setenv("BD_JSON_ENVELOPE", "1")
+	env, ok := buildJSONError("connection refused", "retry", ErrBackendUnavailable).(map[string]interface{})
+	if !ok {
+		t.Fatalf("envelope error is %T, want a map", env)
+	}
+	data, _ := env["data"].(map[string]interface{})
+	if data["code"] != ErrBackendUnavailable || data["hint"] != "retry" {
+		t.Errorf("envelope data = %v, want code %q and hint", data, ErrBackendUnavailable)
+	}
+}
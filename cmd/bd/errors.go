@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/steveyegge/beads/internal/besteffort"
 	"github.com/steveyegge/beads/internal/metrics"
 )
 
@@ -54,9 +55,10 @@ func workspaceDiagHint(includeWhere bool) string {
 	return "check BEADS_DIR/worktree setup, run 'bd doctor' to diagnose, or run 'bd init' to create a new database"
 }
 
-func buildJSONError(message, hint string) interface{} {
+func buildJSONError(message, hint string, code ErrorCode) interface{} {
 	inner := map[string]interface{}{
 		"error": message,
+		"code":  code,
 	}
 	if hint != "" {
 		inner["hint"] = hint
@@ -71,59 +73,100 @@ func buildJSONError(message, hint string) interface{} {
 	return inner
 }
 
-func jsonStderrError(message, hint string) {
+func jsonStderrError(message, hint string, code ErrorCode) {
 	encoder := json.NewEncoder(os.Stderr)
 	encoder.SetIndent("", "  ")
-	_ = encoder.Encode(buildJSONError(message, hint))
+	_ = encoder.Encode(buildJSONError(message, hint, code))
 }
 
-func jsonStdoutError(message, hint string) {
+func jsonStdoutError(message, hint string, code ErrorCode) {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
-	_ = encoder.Encode(buildJSONError(message, hint))
+	_ = encoder.Encode(buildJSONError(message, hint, code))
 }
 
 func HandleError(format string, args ...interface{}) error {
-	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
-	return &exitError{Code: 1}
+	message := fmt.Sprintf(format, args...)
+	clearProgressLine()
+	fmt.Fprintf(os.Stderr, "Error: %s\n", message)
+	return &exitError{Code: exitCodeFor(classifyErrorArgs(message, args))}
 }
 
 func HandleErrorRespectJSON(format string, args ...interface{}) error {
 	if jsonOutput {
-		jsonStdoutError(fmt.Sprintf(format, args...), "")
-		return &exitError{Code: 1}
+		message := fmt.Sprintf(format, args...)
+		code := classifyErrorArgs(message, args)
+		jsonStdoutError(message, "", code)
+		return &exitError{Code: exitCodeFor(code)}
 	}
 	return HandleError(format, args...)
 }
 
 func HandleErrorWithHint(message, hint string) error {
+	code := classifyErrorMessage(message)
 	if jsonOutput {
-		jsonStderrError(message, hint)
+		jsonStderrError(message, hint, code)
 	} else {
+		clearProgressLine()
 		fmt.Fprintf(os.Stderr, "Error: %s\n", message) //nolint:gosec // G705: stderr, not a browser context
 		fmt.Fprintf(os.Stderr, "Hint: %s\n", hint)     //nolint:gosec // G705: stderr, not a browser context
 	}
-	return &exitError{Code: 1}
+	return &exitError{Code: exitCodeFor(code)}
 }
 
 func HandleErrorWithHintRespectJSON(message, hint string) error {
+	code := classifyErrorMessage(message)
 	if jsonOutput {
-		jsonStdoutError(message, hint)
+		jsonStdoutError(message, hint, code)
 	} else {
+		clearProgressLine()
 		fmt.Fprintf(os.Stderr, "Error: %s\n", message)
 		fmt.Fprintf(os.Stderr, "Hint: %s\n", hint)
 	}
-	return &exitError{Code: 1}
+	return &exitError{Code: exitCodeFor(code)}
 }
 
 func SilentExit() error {
 	return &exitError{Code: 1}
 }
 
+// SilentExitWithCode is SilentExit for a failure in category code, for
+// commands that have already reported the failure themselves.
+func SilentExitWithCode(code ErrorCode) error {
+	return &exitError{Code: exitCodeFor(code)}
+}
+
 func WarnError(format string, args ...interface{}) {
+	clearProgressLine()
 	fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
 }
 
+// reportBestEffortFailures prints a summary of the best-effort failures
+// (see internal/besteffort) recorded while the command ran and not already
+// reported as "warnings" in its JSON output. main calls it once the command
+// has finished.
+func reportBestEffortFailures() {
+	warnings, dropped := besteffort.Drain()
+	if len(warnings) == 0 && dropped == 0 {
+		return
+	}
+	total := dropped
+	for _, w := range warnings {
+		total += w.Count
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %d best-effort operation(s) failed and were skipped:\n", total)
+	for _, w := range warnings {
+		if w.Count > 1 {
+			fmt.Fprintf(os.Stderr, "  %s: %s (%d times)\n", w.Op, w.Message, w.Count)
+		} else {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", w.Op, w.Message)
+		}
+	}
+	if dropped > 0 {
+		fmt.Fprintf(os.Stderr, "  ... and %d more (run with --verbose to see them)\n", dropped)
+	}
+}
+
 // CheckReadonly aborts the command when bd is running in read-only mode (the
 // worker-sandbox posture, see readonlyMode). It exits via os.Exit and so cannot
 // run the per-command deferred CloseEventAndAdd — a command blocked here records
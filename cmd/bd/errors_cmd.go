@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+)
+
+var errorsCmd = &cobra.Command{
+	Use:     "errors",
+	GroupID: "setup",
+	Short:   "List error codes and their exit codes",
+	Long: `List the error codes bd reports, so scripts and agents can branch on the
+kind of failure instead of parsing messages.
+
+With --json, every error object carries its code next to the message:
+
+  {"error": "no issues found matching the provided IDs", "code": "not_found", ...}
+
+Every error exits 1 by default, as it always has. Set BD_ERROR_EXIT_CODES=1
+to exit with the code's own status instead (3 for not_found, and so on).
+
+A few commands keep exit codes of their own: 'bd init' safety refusals
+exit 10-12 (see 'bd help init-safety'), and an interrupted prompt exits 130.
+
+Examples:
+  bd errors           # List error codes
+  bd errors --json    # Output as JSON
+`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("errors")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		if jsonOutput {
+			result := struct {
+				Codes            []errorCodeInfo `json:"codes"`
+				ExitCodesEnabled bool            `json:"exit_codes_enabled"`
+			}{ExitCodesEnabled: errorExitCodesEnabled()}
+			for _, c := range errorCodes {
+				result.Codes = append(result.Codes, errorCodeInfo{
+					Code:        string(c.Code),
+					ExitCode:    c.ExitCode,
+					Description: c.Description,
+				})
+			}
+			return outputJSON(result)
+		}
+
+		fmt.Println("Error codes:")
+		for _, c := range errorCodes {
+			fmt.Printf("  %-20s exit %d  %s\n", c.Code, c.ExitCode, c.Description)
+		}
+		if errorExitCodesEnabled() {
+			fmt.Println("\nBD_ERROR_EXIT_CODES=1: errors exit with the codes above.")
+		} else {
+			fmt.Println("\nErrors exit 1. Set BD_ERROR_EXIT_CODES=1 to exit with the codes above.")
+		}
+		return nil
+	},
+}
+
+type errorCodeInfo struct {
+	Code        string `json:"code"`
+	ExitCode    int    `json:"exit_code"`
+	Description string `json:"description"`
+}
+
+func init() {
+	rootCmd.AddCommand(errorsCmd)
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var eventsCmd = &cobra.Command{
+	Use:     "events",
+	GroupID: "issues",
+	Short:   "Emit and query typed events",
+	Long: `Emit and query events: closed issues of type "event" that record something
+that happened (a deploy, a patrol run, an agent starting) rather than work
+to be done.
+
+Each event has a kind, an actor, a target, and a JSON payload. Kinds can be
+registered in config.yaml under events.kinds, each with a payload schema
+that is enforced whenever an event of that kind is created:
+
+  events:
+    strict: true              # reject kinds that are not registered
+    kinds:
+      - name: deploy
+        description: A service rollout
+        require_target: true
+        strict: true          # reject undeclared payload fields
+        payload:
+          version: {type: string, required: true}
+          env: {type: enum, values: [staging, prod], required: true}
+          duration_s: {type: int, min: 0}
+
+Payload fields use the same schema as validation.metadata.fields.
+
+Examples:
+  bd events emit deploy --target svc/api --payload '{"version":"1.4.2","env":"prod"}'
+  bd events query --event-kind deploy --target svc/api
+  bd events query --event-kind deploy --payload env=prod --since -7d
+  bd events kinds`,
+}
+
+var eventsEmitCmd = &cobra.Command{
+	Use:           "emit <kind>",
+	Short:         "Record an event",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("events emit")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("events is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("events-emit")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		kind := args[0]
+		if err := issueops.ValidateEventKindName(kind); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		eventActor, _ := cmd.Flags().GetString("event-actor")
+		target, _ := cmd.Flags().GetString("target")
+		payload, _ := cmd.Flags().GetString("payload")
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		if eventActor == "" {
+			eventActor = actor
+		}
+		if payload != "" && !json.Valid([]byte(payload)) {
+			return HandleErrorRespectJSON("--payload is not valid JSON")
+		}
+		if title == "" {
+			title = eventTitle(kind, target)
+		}
+
+		issue := &types.Issue{
+			Title:       title,
+			Description: description,
+			Status:      types.StatusClosed,
+			Priority:    4,
+			IssueType:   types.TypeEvent,
+			EventKind:   kind,
+			Actor:       eventActor,
+			Target:      target,
+			Payload:     payload,
+			CreatedBy:   getActorWithGit(),
+		}
+		if err := store.CreateIssue(rootCtx, issue, actor); err != nil {
+			return HandleErrorRespectJSON("emitting event: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(issue)
+		}
+		fmt.Printf("%s Emitted %s event %s\n", ui.RenderPass("✓"), kind, ui.RenderID(issue.ID))
+		return nil
+	},
+}
+
+var eventsQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "List events by kind, actor, target, and payload",
+	Long: `List events, newest first.
+
+--payload key=value matches a top-level payload field. When --event-kind
+names a registered kind, the value is parsed according to the field's
+schema type, so "--payload duration_s=30" matches the number 30 and
+"--payload dry_run=true" the boolean true; otherwise values are compared
+as text. Repeat --payload to require several fields.`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("events is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("events-query")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		kind, _ := cmd.Flags().GetString("event-kind")
+		eventActor, _ := cmd.Flags().GetString("event-actor")
+		target, _ := cmd.Flags().GetString("target")
+		since, _ := cmd.Flags().GetString("since")
+		payloadSpecs, _ := cmd.Flags().GetStringArray("payload")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		var schema *storage.EventKind
+		if kind != "" {
+			kinds, err := issueops.EventKindsFromConfig()
+			if err != nil {
+				return HandleErrorRespectJSON("reading events.kinds: %v", err)
+			}
+			schema = storage.EventKindFor(kind, kinds)
+		}
+		filters, err := parseEventPayloadFilters(payloadSpecs, schema)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		eventType := types.TypeEvent
+		filter := types.IssueFilter{
+			IssueType:   &eventType,
+			EventKind:   kind,
+			EventActor:  eventActor,
+			EventTarget: target,
+		}
+		if since != "" {
+			t, err := parseTimeFlag(since)
+			if err != nil {
+				return HandleErrorRespectJSON("invalid --since: %v", err)
+			}
+			filter.CreatedAfter = &t
+		}
+		issues, err := store.SearchIssues(rootCtx, "", filter)
+		if err != nil {
+			return HandleErrorRespectJSON("querying events: %v", err)
+		}
+		events := filterEventsByPayload(issues, filters)
+		slices.SortStableFunc(events, func(a, b *types.Issue) int {
+			return b.CreatedAt.Compare(a.CreatedAt)
+		})
+		if limit > 0 && len(events) > limit {
+			events = events[:limit]
+		}
+
+		if jsonOutput {
+			return outputJSON(events)
+		}
+		if len(events) == 0 {
+			fmt.Println("No events.")
+			return nil
+		}
+		for _, e := range events {
+			line := fmt.Sprintf("  %s %s %s", e.CreatedAt.Local().Format("2006-01-02 15:04"), ui.RenderID(e.ID), ui.RenderBold(e.EventKind))
+			if e.Actor != "" {
+				line += " by " + e.Actor
+			}
+			if e.Target != "" {
+				line += " → " + e.Target
+			}
+			if e.Payload != "" {
+				line += " " + ui.RenderMuted(e.Payload)
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+var eventsKindsCmd = &cobra.Command{
+	Use:           "kinds",
+	Short:         "List the event kinds registered in events.kinds",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kinds, err := issueops.EventKindsFromConfig()
+		if err != nil {
+			return HandleErrorRespectJSON("reading events.kinds: %v", err)
+		}
+		if jsonOutput {
+			if kinds == nil {
+				kinds = []storage.EventKind{}
+			}
+			return outputJSON(kinds)
+		}
+		if len(kinds) == 0 {
+			fmt.Println("No event kinds registered (see 'bd events --help').")
+			return nil
+		}
+		for _, k := range kinds {
+			fmt.Printf("%s", ui.RenderBold(k.Name))
+			if k.Description != "" {
+				fmt.Printf(" — %s", k.Description)
+			}
+			fmt.Println()
+			fields := make([]string, 0, len(k.Payload))
+			for name := range k.Payload {
+				fields = append(fields, name)
+			}
+			slices.Sort(fields)
+			for _, name := range fields {
+				f := k.Payload[name]
+				desc := string(f.Type)
+				if f.Type == storage.MetadataFieldEnum {
+					desc += " (" + strings.Join(f.Values, "|") + ")"
+				}
+				if f.Required {
+					desc += ", required"
+				}
+				fmt.Printf("  %-20s %s\n", name, desc)
+			}
+		}
+		return nil
+	},
+}
+
+// eventTitle is the default title of an emitted event.
+func eventTitle(kind, target string) string {
+	if target == "" {
+		return kind
+	}
+	return kind + " " + target
+}
+
+// eventPayloadFilter matches one top-level payload field. Want is a string,
+// float64, or bool, as json.Unmarshal decodes payload values.
+type eventPayloadFilter struct {
+	Field string
+	Want  interface{}
+}
+
+// parseEventPayloadFilters parses --payload key=value specs. Values of
+// fields declared in kind's schema are parsed as the field's type; other
+// values stay strings.
+func parseEventPayloadFilters(specs []string, kind *storage.EventKind) ([]eventPayloadFilter, error) {
+	var filters []eventPayloadFilter
+	for _, spec := range specs {
+		field, value, ok := strings.Cut(spec, "=")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid --payload %q (expected key=value)", spec)
+		}
+		var fieldType storage.MetadataFieldType
+		if kind != nil {
+			fieldType = kind.Payload[field].Type
+		}
+		f := eventPayloadFilter{Field: field, Want: value}
+		switch fieldType {
+		case storage.MetadataFieldInt, storage.MetadataFieldFloat:
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil || (fieldType == storage.MetadataFieldInt && n != float64(int64(n))) {
+				return nil, fmt.Errorf("invalid --payload %s: field %s has type %s", spec, field, fieldType)
+			}
+			f.Want = n
+		case storage.MetadataFieldBool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --payload %s: field %s has type bool", spec, field)
+			}
+			f.Want = b
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// filterEventsByPayload keeps the events whose payload matches every filter.
+// A string filter also matches the text form of a number or bool, so untyped
+// filters like "attempt=2" still work without a schema.
+func filterEventsByPayload(events []*types.Issue, filters []eventPayloadFilter) []*types.Issue {
+	if len(filters) == 0 {
+		return events
+	}
+	var out []*types.Issue
+	for _, e := range events {
+		var payload map[string]interface{}
+		if err := json.Unmarshal([]byte(e.Payload), &payload); err != nil {
+			continue
+		}
+		if slices.ContainsFunc(filters, func(f eventPayloadFilter) bool {
+			return !eventPayloadMatches(payload[f.Field], f.Want)
+		}) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func eventPayloadMatches(got, want interface{}) bool {
+	if got == nil {
+		return false
+	}
+	if s, ok := want.(string); ok {
+		switch g := got.(type) {
+		case string:
+			return g == s
+		case float64:
+			return strconv.FormatFloat(g, 'f', -1, 64) == s
+		case bool:
+			return strconv.FormatBool(g) == s
+		}
+		return false
+	}
+	return got == want
+}
+
+func init() {
+	eventsEmitCmd.Flags().String("event-actor", "", "Who caused the event (default: the current actor)")
+	eventsEmitCmd.Flags().String("target", "", "What the event affected (entity URI or issue ID)")
+	eventsEmitCmd.Flags().String("payload", "", "Event payload as a JSON object")
+	eventsEmitCmd.Flags().String("title", "", "Event title (default: \"<kind> <target>\")")
+	eventsEmitCmd.Flags().StringP("description", "d", "", "Event description")
+
+	eventsQueryCmd.Flags().StringP("event-kind", "k", "", "Only events of this kind")
+	eventsQueryCmd.Flags().String("event-actor", "", "Only events caused by this actor")
+	eventsQueryCmd.Flags().String("target", "", "Only events affecting this target")
+	eventsQueryCmd.Flags().String("since", "", "Only events created after this time (e.g. -24h, -7d, 2025-01-02)")
+	eventsQueryCmd.Flags().StringArray("payload", nil, "Only events whose payload field matches key=value (repeatable)")
+	eventsQueryCmd.Flags().IntP("limit", "n", 50, "Maximum number of events to show (0 = all)")
+
+	eventsCmd.AddCommand(eventsEmitCmd, eventsQueryCmd, eventsKindsCmd)
+	rootCmd.AddCommand(eventsCmd)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFilterEventsByPayload(t *testing.T) {
+	deploy := &storage.EventKind{Name: "deploy", Payload: map[string]storage.MetadataFieldSchema{
+		"duration_s": {Type: storage.MetadataFieldInt},
+		"dry_run":    {Type: storage.MetadataFieldBool},
+	}}
+	events := []*types.Issue{
+		{ID: "e-1", Payload: `{"env":"prod","duration_s":30,"dry_run":false}`},
+		{ID: "e-2", Payload: `{"env":"staging","duration_s":5,"dry_run":true}`},
+		{ID: "e-3", Payload: `{"env":"prod","duration_s":"30"}`},
+		{ID: "e-4"},
+	}
+	tests := []struct {
+		specs []string
+		kind  *storage.EventKind
+		want  []string
+	}{
+		{nil, deploy, []string{"e-1", "e-2", "e-3", "e-4"}},
+		{[]string{"env=prod"}, nil, []string{"e-1", "e-3"}},
+		{[]string{"duration_s=30"}, deploy, []string{"e-1"}},     // typed: the string "30" does not match
+		{[]string{"duration_s=30"}, nil, []string{"e-1", "e-3"}}, // untyped: compared as text
+		{[]string{"dry_run=true", "env=staging"}, deploy, []string{"e-2"}},
+	}
+	for _, tt := range tests {
+		filters, err := parseEventPayloadFilters(tt.specs, tt.kind)
+		if err != nil {
+			t.Fatalf("parseEventPayloadFilters(%v): %v", tt.specs, err)
+		}
+		var got []string
+		for _, e := range filterEventsByPayload(events, filters) {
+			got = append(got, e.ID)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.specs, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%v: got %v, want %v", tt.specs, got, tt.want)
+				break
+			}
+		}
+	}
+
+	for _, spec := range []string{"duration_s=abc", "duration_s=1.5", "dry_run=maybe", "novalue", "=x"} {
+		if _, err := parseEventPayloadFilters([]string{spec}, deploy); err == nil {
+			t.Errorf("parseEventPayloadFilters(%q) accepted", spec)
+		}
+	}
+}
@@ -14,13 +14,17 @@ import (
 	"github.com/steveyegge/beads/internal/atomicfile"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/progress"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/domain"
+	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
 )
 
 var exportCmd = &cobra.Command{
 	Use:   "export",
-	Short: "Export issues to JSONL format",
+	Short: "Export issues to JSONL, GraphML, a dependency matrix, or markdown",
 	Long: `Export all issues to JSONL (newline-delimited JSON) format.
 
 Each line is a complete JSON object representing one issue, including its
@@ -39,12 +43,32 @@ Memories (from 'bd remember') are excluded by default because they may
 contain sensitive agent context. Use --include-memories or --all to
 include them.
 
+--format graphml writes the dependency graph as GraphML (issues as nodes
+with title, status, priority, type, assignee, labels, and timestamps;
+dependencies as directed edges with their type) for Gephi, yEd, or
+networkx. --format matrix writes a square CSV adjacency matrix whose cells
+name the dependency type. Edges point from the dependency to its dependent
+(blocker to blocked, parent to child), as in 'bd graph --dot'; edges to
+issues outside the export are dropped. --format markdown writes the
+## title / ### section layout 'bd create --file' reads (priority, type,
+description, design, acceptance criteria, notes, assignee, labels, and
+dependencies), so issues can be edited as a document and recreated.
+Memories are not included in these formats.
+
+Metadata keys of namespaces registered with "export: strip" under
+metadata.namespaces in config.yaml are left out, as are those of any
+namespace passed to --strip-metadata.
+
 EXAMPLES:
   bd export                              # Export issues to stdout
   bd export -o issues.jsonl              # Export issues to file
   bd export --include-memories           # Export issues + memories
   bd export --all -o full.jsonl          # Include infra + templates + gates + memories
-  bd export --scrub -o clean.jsonl       # Exclude test/pollution records`,
+  bd export --scrub -o clean.jsonl       # Exclude test/pollution records
+  bd export --strip-metadata tool.github # Drop tool.github.* metadata keys
+  bd export --format graphml -o deps.graphml
+  bd export --format matrix -o deps.csv
+  bd export --format markdown -o issues.md`,
 	GroupID:       "sync",
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -60,10 +84,13 @@ var (
 	exportIncludeMemories bool
 	exportExcludeOwners   []string
 	exportVerbose         bool
+	exportFormat          string
+	exportStripMetadata   []string
 )
 
 func init() {
 	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file path (default: stdout)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", exportFormatJSONL, "Output format: jsonl, graphml, matrix (dependency adjacency CSV), markdown")
 	exportCmd.Flags().BoolVar(&exportAll, "all", false, "Include all records (infra, templates, gates, memories)")
 	exportCmd.Flags().BoolVar(&exportIncludeInfra, "include-infra", false, "Include infrastructure beads (agents, roles, messages)")
 	exportCmd.Flags().BoolVar(&exportScrub, "scrub", false, "Exclude test/pollution records")
@@ -71,6 +98,7 @@ func init() {
 	exportCmd.Flags().BoolVar(&exportNoMemories, "no-memories", false, "Exclude persistent memories (deprecated: now the default)")
 	_ = exportCmd.Flags().MarkHidden("no-memories")
 	exportCmd.Flags().StringArrayVar(&exportExcludeOwners, "exclude-owner", nil, "Exclude issues created by this identity (repeatable; also reads export.exclude_owners config)")
+	exportCmd.Flags().StringArrayVar(&exportStripMetadata, "strip-metadata", nil, "Drop metadata keys of this namespace (repeatable; adds to namespaces registered with export: strip)")
 	exportCmd.Flags().BoolVar(&exportVerbose, "verbose", false, "Print filtered issue count when owners are excluded")
 	rootCmd.AddCommand(exportCmd)
 }
@@ -88,6 +116,12 @@ func runExport(cmd *cobra.Command, args []string) error {
 
 	ctx := rootCtx
 
+	switch exportFormat {
+	case exportFormatJSONL, exportFormatGraphML, exportFormatMatrix, exportFormatMarkdown:
+	default:
+		return HandleErrorRespectJSON("invalid --format %q (valid: jsonl, graphml, matrix, markdown)", exportFormat)
+	}
+
 	// Determine output destination. File output uses atomic writes
 	// (temp file + rename) so concurrent exports and crashes never
 	// leave a truncated or interleaved JSONL file.
@@ -144,6 +178,16 @@ func runExport(cmd *cobra.Command, args []string) error {
 		filter.Ephemeral = &persistentOnly
 	}
 
+	// Loading is most of an export's time; the task spins until the
+	// issue count is known, then counts records written. Records printed
+	// to a terminal would tangle with the spinner line, so that case
+	// reports nothing.
+	var task *progress.Task
+	if exportOutput != "" || !ui.IsTerminal() {
+		ctx, task = progress.Start(ctx, "export", 0)
+		defer task.Finish()
+	}
+
 	issues, err := store.SearchIssues(ctx, "", filter)
 	if err != nil {
 		return HandleErrorRespectJSON("failed to search issues: %v", err)
@@ -164,6 +208,12 @@ func runExport(cmd *cobra.Command, args []string) error {
 		filteredOwnerCount = before - len(issues)
 	}
 
+	stripNamespaces, err := exportStripNamespaces(exportStripMetadata)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	stripExportMetadata(issues, stripNamespaces)
+
 	if len(issues) == 0 && exportNoMemories {
 		if exportOutput != "" {
 			fmt.Fprintln(os.Stderr, "No issues to export.")
@@ -189,6 +239,29 @@ func runExport(cmd *cobra.Command, args []string) error {
 		issue.Dependencies = allDeps[issue.ID]
 		issue.Comments = commentsMap[issue.ID]
 	}
+	task.SetTotal(len(issues))
+
+	if exportFormat != exportFormatJSONL {
+		writeFormat := writeGraphMLExport
+		switch exportFormat {
+		case exportFormatMatrix:
+			writeFormat = writeDependencyMatrixExport
+		case exportFormatMarkdown:
+			writeFormat = writeMarkdownExport
+		}
+		if err := writeFormat(w, issues); err != nil {
+			return HandleErrorRespectJSON("failed to write: %v", err)
+		}
+		task.Set(len(issues))
+		task.Finish()
+		if aw != nil {
+			if err := aw.Close(); err != nil {
+				return HandleErrorRespectJSON("failed to finalize export file: %v", err)
+			}
+			fmt.Fprintf(os.Stderr, "Exported %d issues as %s to %s\n", len(issues), exportFormat, exportOutput)
+		}
+		return nil
+	}
 
 	// Write JSONL: one JSON object per line
 	count := 0
@@ -224,6 +297,7 @@ func runExport(cmd *cobra.Command, args []string) error {
 			return HandleErrorRespectJSON("failed to write newline: %v", err)
 		}
 		count++
+		task.Add(1)
 	}
 
 	// Export memories only when explicitly requested (GH#3650).
@@ -265,6 +339,8 @@ func runExport(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	task.Finish()
+
 	// Finalize atomic write if writing to file (fsync + rename).
 	if aw != nil {
 		if err := aw.Close(); err != nil {
@@ -295,6 +371,39 @@ type exportIssueRecord struct {
 	*types.IssueWithCounts
 }
 
+// exportStripNamespaces returns the metadata namespaces whose keys are left
+// out of exports: those registered with export: strip plus extra.
+func exportStripNamespaces(extra []string) ([]string, error) {
+	namespaces, err := issueops.MetadataNamespacesFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, ns := range namespaces {
+		if ns.Export == storage.MetadataExportStrip {
+			names = append(names, ns.Name)
+		}
+	}
+	for _, name := range extra {
+		if err := storage.ValidateMetadataNamespaceName(name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// stripExportMetadata removes the keys of the named metadata namespaces from
+// each issue before it is written out.
+func stripExportMetadata(issues []*types.Issue, namespaces []string) {
+	if len(namespaces) == 0 {
+		return
+	}
+	for _, issue := range issues {
+		issue.Metadata = storage.StripMetadataNamespaces(issue.Metadata, namespaces)
+	}
+}
+
 // sanitizeZeroTime replaces Go zero-value time.Time fields with Unix epoch.
 // NULL datetime columns in Dolt scan as time.Time{} (year 0001-01-01), which
 // causes json.Marshal to fail with "year outside of range [0,9999]". (GH#2488)
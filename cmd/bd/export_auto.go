@@ -380,6 +380,12 @@ func exportToFile(ctx context.Context, path string, includeMemories bool) (issue
 		issues = filterOutOwners(issues, ownerExcludes)
 	}
 
+	stripNamespaces, err := exportStripNamespaces(nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	stripExportMetadata(issues, stripNamespaces)
+
 	if err := guardAutoExportOverwrite(path, infraTypeSet, includeMemories); err != nil {
 		return 0, 0, err
 	}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Export formats accepted by bd export --format.
+const (
+	exportFormatJSONL   = "jsonl"
+	exportFormatGraphML = "graphml"
+	exportFormatMatrix  = "matrix"
+)
+
+// graphEdge is one dependency edge between two exported issues, oriented
+// like bd graph --dot: the dependency points to its dependent (blocker →
+// blocked, parent → child).
+type graphEdge struct {
+	From string
+	To   string
+	Type types.DependencyType
+}
+
+// exportGraphEdges collects the dependencies whose endpoints are both in
+// issues, sorted for deterministic output. Issue.Dependencies must be loaded.
+func exportGraphEdges(issues []*types.Issue) []graphEdge {
+	exported := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		exported[issue.ID] = true
+	}
+	var edges []graphEdge
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if !exported[dep.DependsOnID] || !exported[dep.IssueID] {
+				continue
+			}
+			edges = append(edges, graphEdge{From: dep.DependsOnID, To: dep.IssueID, Type: dep.Type})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Type < b.Type
+	})
+	return edges
+}
+
+// graphMLNodeKeys are the issue attributes written as GraphML node data.
+var graphMLNodeKeys = []struct {
+	id, typ string
+	value   func(*types.Issue) string
+}{
+	{"title", "string", func(i *types.Issue) string { return i.Title }},
+	{"status", "string", func(i *types.Issue) string { return string(i.Status) }},
+	{"priority", "int", func(i *types.Issue) string { return strconv.Itoa(i.Priority) }},
+	{"issue_type", "string", func(i *types.Issue) string { return string(i.IssueType) }},
+	{"assignee", "string", func(i *types.Issue) string { return i.Assignee }},
+	{"labels", "string", func(i *types.Issue) string { return strings.Join(i.Labels, ",") }},
+	{"created_at", "string", func(i *types.Issue) string { return graphTime(i.CreatedAt) }},
+	{"closed_at", "string", func(i *types.Issue) string {
+		if i.ClosedAt == nil {
+			return ""
+		}
+		return graphTime(*i.ClosedAt)
+	}},
+}
+
+func graphTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// writeGraphMLExport writes issues as GraphML nodes and their dependencies
+// as directed edges, for Gephi, yEd, networkx, and igraph.
+func writeGraphMLExport(w io.Writer, issues []*types.Issue) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	for _, k := range graphMLNodeKeys {
+		fmt.Fprintf(&b, "  <key id=%q for=\"node\" attr.name=%q attr.type=%q/>\n", k.id, k.id, k.typ)
+	}
+	b.WriteString(`  <key id="dep_type" for="edge" attr.name="dep_type" attr.type="string"/>` + "\n")
+	b.WriteString(`  <graph id="beads" edgedefault="directed">` + "\n")
+	for _, issue := range issues {
+		fmt.Fprintf(&b, "    <node id=\"%s\">\n", xmlEscape(issue.ID))
+		for _, k := range graphMLNodeKeys {
+			if v := k.value(issue); v != "" {
+				fmt.Fprintf(&b, "      <data key=%q>%s</data>\n", k.id, xmlEscape(v))
+			}
+		}
+		b.WriteString("    </node>\n")
+	}
+	for i, e := range exportGraphEdges(issues) {
+		fmt.Fprintf(&b, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\">\n", i, xmlEscape(e.From), xmlEscape(e.To))
+		fmt.Fprintf(&b, "      <data key=\"dep_type\">%s</data>\n", xmlEscape(string(e.Type)))
+		b.WriteString("    </edge>\n")
+	}
+	b.WriteString("  </graph>\n</graphml>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// writeDependencyMatrixExport writes the dependency graph as a square CSV
+// adjacency matrix. The first row and column hold issue IDs; the cell at
+// row A, column B names the dependency type(s) from A to B, joined with
+// ";" when there are several, and is empty when there is no edge.
+func writeDependencyMatrixExport(w io.Writer, issues []*types.Issue) error {
+	index := make(map[string]int, len(issues))
+	header := make([]string, 0, len(issues)+1)
+	header = append(header, "id")
+	for i, issue := range issues {
+		index[issue.ID] = i
+		header = append(header, issue.ID)
+	}
+	cells := make([][]string, len(issues))
+	for i := range cells {
+		cells[i] = make([]string, len(issues))
+	}
+	for _, e := range exportGraphEdges(issues) {
+		cell := &cells[index[e.From]][index[e.To]]
+		if *cell != "" {
+			*cell += ";"
+		}
+		*cell += string(e.Type)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i, issue := range issues {
+		if err := cw.Write(append([]string{issue.ID}, cells[i]...)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
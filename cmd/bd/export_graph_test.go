@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func exportGraphFixture() []*types.Issue {
+	return []*types.Issue{
+		{ID: "bd-1", Title: "Parent <epic> & co", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic},
+		{ID: "bd-2", Title: "Child", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Labels: []string{"a", "b"},
+			Dependencies: []*types.Dependency{
+				{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild},
+				{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepBlocks},
+				{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepRelated},
+				{IssueID: "bd-2", DependsOnID: "other-9", Type: types.DepBlocks}, // not exported
+			}},
+		{ID: "bd-3", Title: "Blocker", Status: types.StatusClosed, Priority: 0, IssueType: types.TypeBug},
+	}
+}
+
+func TestWriteGraphMLExport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeGraphMLExport(&buf, exportGraphFixture()); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Nodes []struct {
+			ID   string `xml:"id,attr"`
+			Data []struct {
+				Key   string `xml:"key,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"data"`
+		} `xml:"graph>node"`
+		Edges []struct {
+			Source string `xml:"source,attr"`
+			Target string `xml:"target,attr"`
+			Type   string `xml:"data"`
+		} `xml:"graph>edge"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid GraphML: %v\n%s", err, buf.String())
+	}
+	if len(doc.Nodes) != 3 {
+		t.Fatalf("got %d nodes, want 3", len(doc.Nodes))
+	}
+	if got := doc.Nodes[0].Data[0]; got.Key != "title" || got.Value != "Parent <epic> & co" {
+		t.Errorf("first node title = %+v", got)
+	}
+	var edges []string
+	for _, e := range doc.Edges {
+		edges = append(edges, e.Source+">"+e.Target+":"+e.Type)
+	}
+	want := "bd-1>bd-2:parent-child bd-3>bd-2:blocks bd-3>bd-2:related"
+	if strings.Join(edges, " ") != want {
+		t.Errorf("edges = %v, want %s", edges, want)
+	}
+}
+
+func TestWriteDependencyMatrixExport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeDependencyMatrixExport(&buf, exportGraphFixture()); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"id", "bd-1", "bd-2", "bd-3"},
+		{"bd-1", "", "parent-child", ""},
+		{"bd-2", "", "", ""},
+		{"bd-3", "", "blocks;related", ""},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %v", rows)
+	}
+	for i := range want {
+		if strings.Join(rows[i], ",") != strings.Join(want[i], ",") {
+			t.Errorf("row %d = %v, want %v", i, rows[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// exportFormatMarkdown writes issues in the layout bd create --file reads.
+const exportFormatMarkdown = "markdown"
+
+// writeMarkdownExport writes issues as the markdown bd create --file parses:
+// an H2 title per issue and an H3 section per field. Only fields the parser
+// reads are written, so status, timestamps, and comments are not carried.
+// Issue.Labels and Issue.Dependencies must be loaded.
+func writeMarkdownExport(w io.Writer, issues []*types.Issue) error {
+	var b strings.Builder
+	for i, issue := range issues {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n", issue.Title)
+		writeMarkdownSection(&b, "Priority", fmt.Sprintf("%d", issue.Priority))
+		writeMarkdownSection(&b, "Type", string(issue.IssueType))
+		writeMarkdownSection(&b, "Description", issue.Description)
+		writeMarkdownSection(&b, "Design", issue.Design)
+		writeMarkdownSection(&b, "Acceptance Criteria", issue.AcceptanceCriteria)
+		writeMarkdownSection(&b, "Notes", issue.Notes)
+		writeMarkdownSection(&b, "Assignee", issue.Assignee)
+		writeMarkdownSection(&b, "Labels", strings.Join(issue.Labels, ", "))
+
+		var deps []string
+		for _, dep := range issue.Dependencies {
+			if dep.IssueID != issue.ID {
+				continue
+			}
+			if dep.Type == types.DepBlocks {
+				deps = append(deps, dep.DependsOnID)
+			} else {
+				deps = append(deps, string(dep.Type)+":"+dep.DependsOnID)
+			}
+		}
+		writeMarkdownSection(&b, "Dependencies", strings.Join(deps, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownSection writes a non-empty field as an H3 section. Lines
+// starting with "#" (after any backslashes) get one more leading backslash,
+// which the parser drops again, so none of them can read as a header.
+func writeMarkdownSection(b *strings.Builder, name, content string) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return
+	}
+	fmt.Fprintf(b, "\n### %s\n", name)
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, `\`), "#") {
+			line = `\` + line
+		}
+		b.WriteString(line + "\n")
+	}
+}
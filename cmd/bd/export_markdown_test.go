@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWriteMarkdownExportRoundTrip(t *testing.T) {
+	issues := []*types.Issue{
+		{
+			ID: "bd-1", Title: "Login flow", Priority: 1, IssueType: types.TypeFeature,
+			Description:        "Users sign in.\n## Not a title\n### Not a section\n\\# literal",
+			Design:             "Use OAuth.",
+			AcceptanceCriteria: "- [ ] Google login\n- [ ] Logout",
+			Notes:              "Spike done.",
+			Assignee:           "alice",
+			Labels:             []string{"auth", "web"},
+			Dependencies: []*types.Dependency{
+				{IssueID: "bd-1", DependsOnID: "bd-9", Type: types.DepBlocks},
+				{IssueID: "bd-1", DependsOnID: "bd-8", Type: types.DepParentChild},
+				{IssueID: "bd-7", DependsOnID: "bd-1", Type: types.DepBlocks}, // dependent, not ours
+			},
+		},
+		{ID: "bd-2", Title: "Bare", Priority: 3, IssueType: types.TypeTask},
+	}
+	var buf bytes.Buffer
+	if err := writeMarkdownExport(&buf, issues); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "issues.md")
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parseMarkdownFile(path)
+	if err != nil {
+		t.Fatalf("parseMarkdownFile: %v\n%s", err, buf.String())
+	}
+
+	want := []*IssueTemplate{
+		{
+			Title: "Login flow", Priority: 1, IssueType: types.TypeFeature,
+			Description:        issues[0].Description,
+			Design:             "Use OAuth.",
+			AcceptanceCriteria: "- [ ] Google login\n- [ ] Logout",
+			Notes:              "Spike done.",
+			Assignee:           "alice",
+			Labels:             []string{"auth", "web"},
+			Dependencies:       []string{"bd-9", "parent-child:bd-8"},
+		},
+		{Title: "Bare", Priority: 3, IssueType: types.TypeTask},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip mismatch\n got: %+v\nwant: %+v\nmarkdown:\n%s", *got[0], *want[0], buf.String())
+	}
+}
@@ -3,11 +3,16 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/metrics"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/ui"
@@ -19,7 +24,15 @@ var (
 	federationStrategy string
 	federationUser     string
 	federationPassword string
+	federationSSHKey   string
+	federationSSHPass  string
 	federationSov      string
+	federationSyncMode string
+	federationConflict string
+	federationCredSrc  string
+	federationOwns     []string
+	federationEncMeta  bool
+	federationDryRun   bool
 )
 
 var federationCmd = &cobra.Command{
@@ -45,14 +58,28 @@ With --peer, syncs only with the specified peer.
 Handles merge conflicts using the configured strategy:
   --strategy ours    Keep local changes on conflict
   --strategy theirs  Accept remote changes on conflict
-
-If no strategy is specified and conflicts occur, the sync will pause
-and report which tables have conflicts for manual resolution.
+  --strategy newest  Keep whichever side of an issue was updated last
+  --strategy merge   Merge issues field by field; the newer side wins
+                     fields both sides changed
+
+Without --strategy, the peer's conflict strategy (add-peer
+--conflict-strategy) applies. If neither is set, or newest/merge cannot
+decide a conflict (an issue deleted on one side, tied timestamps, or a
+table other than issues), the sync stops with the merge left in place.
+Use 'bd federation conflicts' to review and resolve what remains.
+
+With --dry-run, fetches from each peer and shows what a sync would pull
+and push instead of syncing: the commits on each side that the other
+lacks, the rows changed per table since the last common commit, and the
+changed issues. Only the peers' remote-tracking refs are updated; nothing
+is merged, committed, or pushed. Add --json for machine-readable output.
 
 Examples:
   bd federation sync                      # Sync with all peers
   bd federation sync --peer town-beta     # Sync with specific peer
-  bd federation sync --strategy theirs    # Auto-resolve using remote values`,
+  bd federation sync --strategy theirs    # Auto-resolve using remote values
+  bd federation sync --strategy merge     # Field-level merge of conflicting issues
+  bd federation sync --dry-run --json     # Preview changes for automation`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE:          runFederationSync,
@@ -78,22 +105,68 @@ Examples:
 
 var federationAddPeerCmd = &cobra.Command{
 	Use:   "add-peer <name> <url>",
-	Short: "Add a federation peer with optional SQL credentials",
-	Long: `Add a new federation peer remote with optional SQL user authentication.
+	Short: "Add a federation peer with optional SQL or SSH credentials",
+	Long: `Add a new federation peer remote with optional SQL user or SSH key
+authentication.
 
 The URL can be:
   - dolthub://org/repo      DoltHub hosted repository
   - host:port/database      Direct dolt sql-server connection
+  - git+ssh://host/path     Git remote reachable over SSH
   - file:///path/to/repo    Local file path (for testing)
 
 Credentials are encrypted and stored locally. They are used automatically
 when syncing with the peer. If --user is provided without --password,
 you will be prompted for the password interactively.
 
+To keep the password out of the database entirely, give --credential-source
+instead of --password. It is resolved each time the peer is synced:
+  env:VAR_NAME          read the password from an environment variable
+  cmd:/path/to/helper   run a git-credential-helper style program with
+                        "get"; it reads protocol=, host=, path=, and
+                        username= lines on stdin and prints username= and
+                        password= lines on stdout
+
+For SSH peers, --ssh-key names the private key to use; syncs run ssh with
+only that key. If the key has a passphrase, give --ssh-passphrase without a
+value: it is prompted for on a terminal and otherwise read from the first
+line of stdin. It is stored encrypted and answered through SSH_ASKPASS, which
+needs OpenSSH 8.4 or later; keys loaded in an ssh-agent need neither flag.
+
+--sync-mode limits the directions the peer syncs in: "pull-only" for a
+read-only upstream that is never pushed to, "push-only" for a write-only
+mirror that is never pulled from, or "bidirectional" (the default). Pushes
+or pulls the mode forbids fail, and 'bd federation sync' skips them.
+
+--conflict-strategy sets how 'bd federation sync' resolves merge conflicts
+pulled from the peer when --strategy is not given: ours, theirs, newest, or
+merge (see 'bd federation sync --help').
+
+--sovereignty T2, T3, or T4 makes the peer the only place its issues change:
+local updates, closes, deletes, labels, comments, and outgoing dependencies
+on them are refused unless --override-sovereignty is given. The peer's
+issues are those whose ID starts with an --owns-prefix and those whose
+source_repo is the peer's name. T1 puts no restriction on local writes.
+
+--encrypt-metadata stores the peer's user and URL encrypted with the same
+local key as its password, so the federation_peers rows committed to Dolt
+history do not reveal internal hostnames or account names. Setting
+federation.encrypt-peer-metadata: true in config.yaml turns it on for every
+peer added; 'bd federation encrypt-metadata' upgrades peers stored before.
+
+Re-running add-peer for an existing peer updates its settings.
+
 Examples:
   bd federation add-peer town-beta dolthub://acme/town-beta-beads
   bd federation add-peer town-gamma 192.168.1.100:3306/beads --user sync-bot
-  bd federation add-peer partner https://partner.example.com/beads --user admin --password secret`,
+  bd federation add-peer partner https://partner.example.com/beads --user admin --password secret
+  bd federation add-peer partner https://partner.example.com/beads --user admin --credential-source env:PARTNER_PW
+  bd federation add-peer partner https://partner.example.com/beads --credential-source cmd:/usr/local/bin/beads-creds
+  bd federation add-peer vault git+ssh://git@vault.internal/beads.git --ssh-key ~/.ssh/beads_sync
+  bd federation add-peer upstream dolthub://acme/shared-beads --sync-mode pull-only
+  bd federation add-peer town-beta dolthub://acme/town-beta-beads --conflict-strategy newest
+  bd federation add-peer hq dolthub://acme/hq-beads --sovereignty T2 --owns-prefix hq
+  bd federation add-peer ops https://beads.corp.internal/ops --user jdoe --encrypt-metadata`,
 	Args:          cobra.ExactArgs(2),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -124,10 +197,12 @@ func init() {
 	federationCmd.AddCommand(federationAddPeerCmd)
 	federationCmd.AddCommand(federationRemovePeerCmd)
 	federationCmd.AddCommand(federationListPeersCmd)
+	addConfirmFlags(federationRemovePeerCmd)
 
 	// Flags for sync
 	federationSyncCmd.Flags().StringVar(&federationPeer, "peer", "", "Specific peer to sync with")
-	federationSyncCmd.Flags().StringVar(&federationStrategy, "strategy", "", "Conflict resolution strategy (ours|theirs)")
+	federationSyncCmd.Flags().StringVar(&federationStrategy, "strategy", "", "Conflict resolution strategy (ours|theirs|newest|merge)")
+	federationSyncCmd.Flags().BoolVar(&federationDryRun, "dry-run", false, "Show what would be pulled and pushed without syncing")
 
 	// Flags for status
 	federationStatusCmd.Flags().StringVar(&federationPeer, "peer", "", "Specific peer to check")
@@ -135,7 +210,15 @@ func init() {
 	// Flags for add-peer (SQL user authentication)
 	federationAddPeerCmd.Flags().StringVarP(&federationUser, "user", "u", "", "SQL username for authentication")
 	federationAddPeerCmd.Flags().StringVarP(&federationPassword, "password", "p", "", "SQL password (prompted if --user set without --password)")
+	federationAddPeerCmd.Flags().StringVar(&federationSSHKey, "ssh-key", "", "SSH private key for git+ssh peers")
+	federationAddPeerCmd.Flags().StringVar(&federationSSHPass, "ssh-passphrase", "", "Passphrase for --ssh-key (prompted, or read from stdin, when given without a value)")
+	federationAddPeerCmd.Flags().Lookup("ssh-passphrase").NoOptDefVal = sshPassphraseFromInput
 	federationAddPeerCmd.Flags().StringVar(&federationSov, "sovereignty", "", "Sovereignty tier (T1, T2, T3, T4)")
+	federationAddPeerCmd.Flags().StringSliceVar(&federationOwns, "owns-prefix", nil, "Issue ID prefix the peer owns (repeatable or comma-separated); T2+ peers' issues are read-only here")
+	federationAddPeerCmd.Flags().StringVar(&federationSyncMode, "sync-mode", "", "Sync directions: bidirectional, pull-only, or push-only")
+	federationAddPeerCmd.Flags().StringVar(&federationConflict, "conflict-strategy", "", "Default conflict strategy for sync: ours, theirs, newest, or merge")
+	federationAddPeerCmd.Flags().StringVar(&federationCredSrc, "credential-source", "", "Resolve the password at sync time instead of storing it: env:VAR_NAME or cmd:/path/to/helper")
+	federationAddPeerCmd.Flags().BoolVar(&federationEncMeta, "encrypt-metadata", false, "Store the peer's user and URL encrypted (default from federation.encrypt-peer-metadata)")
 
 	rootCmd.AddCommand(federationCmd)
 }
@@ -165,9 +248,15 @@ func runFederationSync(cmd *cobra.Command, args []string) error {
 		return HandleErrorRespectJSON("%v", err)
 	}
 
-	if federationStrategy != "" && federationStrategy != "ours" && federationStrategy != "theirs" {
-		return HandleErrorRespectJSON("invalid strategy %q: must be 'ours' or 'theirs'", federationStrategy)
+	if _, err := storage.ParseConflictStrategy(federationStrategy); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	release, err := beginDoltSync()
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
 	}
+	defer release()
 
 	var peers []string
 	if federationPeer != "" {
@@ -188,8 +277,13 @@ func runFederationSync(cmd *cobra.Command, args []string) error {
 		return HandleErrorRespectJSON("no federation peers configured (use 'bd federation add-peer' to add peers)")
 	}
 
+	if federationDryRun {
+		return runFederationSyncPreview(ctx, ds, peers)
+	}
+
 	// Sync with each peer
 	var results []*storage.SyncResult
+	graphChanges := map[string]*pullGraphSummary{}
 	for _, peer := range peers {
 		if !jsonOutput {
 			fmt.Printf("%s Syncing with %s...\n", ui.RenderAccent("🔄"), peer)
@@ -201,11 +295,19 @@ func runFederationSync(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			if !jsonOutput {
 				fmt.Printf("  %s %v\n", ui.RenderFail("✗"), err)
+				if errors.Is(err, storage.ErrMergeConflicts) {
+					for _, c := range result.Conflicts {
+						fmt.Printf("    - %s\n", c.Field)
+					}
+				}
 			}
 			continue
 		}
 
 		if !jsonOutput {
+			if !result.SyncMode.AllowsPull() {
+				fmt.Printf("  %s Pull skipped (%s peer)\n", ui.RenderMuted("○"), result.SyncMode)
+			}
 			if result.Fetched {
 				fmt.Printf("  %s Fetched\n", ui.RenderPass("✓"))
 			}
@@ -216,10 +318,24 @@ func runFederationSync(cmd *cobra.Command, args []string) error {
 				}
 				fmt.Println()
 			}
+		}
+		if result.Merged && result.PreMergeCommit != "" && result.PreMergeCommit != result.PostMergeCommit {
+			summary, err := summarizePull(ctx, ds, result.PreMergeCommit, result.PostMergeCommit, notifyAssignees(ctx, ds, getActorWithGit()))
+			if err != nil {
+				WarnError("could not summarize dependency changes from %s: %v", peer, err)
+			} else if summary != nil {
+				graphChanges[peer] = summary
+				if !jsonOutput {
+					printPullGraphSummary(summary)
+				}
+			}
+		}
+
+		if !jsonOutput {
 			if len(result.Conflicts) > 0 {
 				if result.ConflictsResolved {
 					fmt.Printf("  %s Resolved %d conflicts using %s strategy\n",
-						ui.RenderPass("✓"), len(result.Conflicts), federationStrategy)
+						ui.RenderPass("✓"), len(result.Conflicts), result.ConflictStrategy)
 				} else {
 					fmt.Printf("  %s %d conflicts need resolution\n",
 						ui.RenderWarn("⚠"), len(result.Conflicts))
@@ -230,6 +346,8 @@ func runFederationSync(cmd *cobra.Command, args []string) error {
 			}
 			if result.Pushed {
 				fmt.Printf("  %s Pushed\n", ui.RenderPass("✓"))
+			} else if !result.SyncMode.AllowsPush() {
+				fmt.Printf("  %s Push skipped (%s peer)\n", ui.RenderMuted("○"), result.SyncMode)
 			} else if result.PushError != nil {
 				fmt.Printf("  %s Push skipped: %v\n", ui.RenderMuted("○"), result.PushError)
 			}
@@ -238,8 +356,9 @@ func runFederationSync(cmd *cobra.Command, args []string) error {
 
 	if jsonOutput {
 		return outputJSON(map[string]interface{}{
-			"peers":   peers,
-			"results": results,
+			"peers":         peers,
+			"results":       results,
+			"graph_changes": graphChanges,
 		})
 	}
 	return nil
@@ -384,8 +503,15 @@ func runFederationAddPeer(cmd *cobra.Command, args []string) error {
 	name := args[0]
 	url := args[1]
 
+	if _, _, err := storage.ParseCredentialSource(federationCredSrc); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if federationCredSrc != "" && federationPassword != "" {
+		return HandleErrorRespectJSON("--credential-source and --password are mutually exclusive")
+	}
+
 	password := federationPassword
-	if federationUser != "" && password == "" {
+	if federationUser != "" && password == "" && federationCredSrc == "" {
 		fmt.Fprint(os.Stderr, "Password: ")
 		pwBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
 		fmt.Fprintln(os.Stderr)
@@ -403,13 +529,54 @@ func runFederationAddPeer(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if federationUser != "" {
+	sshKey := federationSSHKey
+	if sshKey == "" && federationSSHPass != "" {
+		return HandleErrorRespectJSON("--ssh-passphrase requires --ssh-key")
+	}
+	sshPassphrase := federationSSHPass
+	if sshPassphrase == sshPassphraseFromInput {
+		var err error
+		if sshPassphrase, err = readSSHPassphrase(); err != nil {
+			return HandleErrorRespectJSON("failed to read SSH key passphrase: %v", err)
+		}
+	}
+	if sshKey != "" {
+		var err error
+		if sshKey, err = resolveSSHKeyPath(sshKey); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+	}
+
+	syncMode, err := storage.ParseSyncMode(federationSyncMode)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	conflictStrategy, err := storage.ParseConflictStrategy(federationConflict)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	owned := storage.SplitOwnedPrefixes(strings.Join(federationOwns, ","))
+	for i, prefix := range owned {
+		owned[i] = strings.TrimSuffix(prefix, "-")
+	}
+
+	encryptMetadata := federationEncMeta || config.GetBool("federation.encrypt-peer-metadata")
+	if federationUser != "" || sshKey != "" || sov != "" || len(owned) > 0 || federationSyncMode != "" || conflictStrategy != "" || federationCredSrc != "" || encryptMetadata {
 		peer := &storage.FederationPeer{
-			Name:        name,
-			RemoteURL:   url,
-			Username:    federationUser,
-			Password:    password,
-			Sovereignty: sov,
+			Name:             name,
+			RemoteURL:        url,
+			Username:         federationUser,
+			Password:         password,
+			SSHKeyPath:       sshKey,
+			SSHKeyPassphrase: sshPassphrase,
+			Sovereignty:      sov,
+			SyncMode:         syncMode,
+			ConflictStrategy: conflictStrategy,
+			CredentialSource: federationCredSrc,
+			OwnedPrefixes:    owned,
+			EncryptMetadata:  encryptMetadata,
 		}
 		if err := store.AddFederationPeer(ctx, peer); err != nil {
 			return HandleErrorRespectJSON("failed to add peer: %v", err)
@@ -422,23 +589,73 @@ func runFederationAddPeer(cmd *cobra.Command, args []string) error {
 
 	if jsonOutput {
 		return outputJSON(map[string]interface{}{
-			"added":       name,
-			"url":         url,
-			"has_auth":    federationUser != "",
-			"sovereignty": sov,
+			"added":             name,
+			"url":               url,
+			"has_auth":          federationUser != "" || federationCredSrc != "",
+			"ssh_key":           sshKey,
+			"sovereignty":       sov,
+			"owned_prefixes":    owned,
+			"sync_mode":         syncMode,
+			"conflict_strategy": conflictStrategy,
+			"credential_source": federationCredSrc,
+			"encrypt_metadata":  encryptMetadata,
 		})
 	}
 
 	fmt.Printf("Added peer %s: %s\n", ui.RenderAccent(name), url)
-	if federationUser != "" {
+	switch {
+	case federationCredSrc != "" && federationUser != "":
+		fmt.Printf("  User: %s (password from %s at sync time)\n", federationUser, federationCredSrc)
+	case federationCredSrc != "":
+		fmt.Printf("  Credentials: from %s at sync time\n", federationCredSrc)
+	case federationUser != "":
 		fmt.Printf("  User: %s (credentials stored)\n", federationUser)
 	}
+	if sshKey != "" {
+		fmt.Printf("  SSH key: %s\n", sshKey)
+	}
 	if sov != "" {
 		fmt.Printf("  Sovereignty: %s\n", sov)
 	}
+	if len(owned) > 0 {
+		fmt.Printf("  Owns prefixes: %s\n", strings.Join(owned, ", "))
+	}
+	if syncMode != storage.SyncModeBidirectional {
+		fmt.Printf("  Sync mode: %s\n", syncMode)
+	}
+	if conflictStrategy != "" {
+		fmt.Printf("  Conflict strategy: %s\n", conflictStrategy)
+	}
+	if encryptMetadata {
+		fmt.Printf("  User and URL stored encrypted\n")
+	}
 	return nil
 }
 
+// resolveSSHKeyPath expands a leading ~ and makes path absolute, since syncs
+// may run from another directory. The key must exist.
+func resolveSSHKeyPath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolving --ssh-key: %w", err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("resolving --ssh-key: %w", err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("SSH key %s: %w", abs, err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("SSH key %s is a directory", abs)
+	}
+	return abs, nil
+}
+
 func runFederationRemovePeer(cmd *cobra.Command, args []string) error {
 	if usesProxiedServer() {
 		return HandleErrorRespectJSON("federation remove-peer is not supported in proxied-server mode")
@@ -453,6 +670,9 @@ func runFederationRemovePeer(cmd *cobra.Command, args []string) error {
 	ctx := rootCtx
 
 	name := args[0]
+	if err := requireConfirmation(cmd, confirmOpFederationRemove, "remove-peer "+name, args); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
 
 	if err := store.RemoveRemote(ctx, name); err != nil {
 		return HandleErrorRespectJSON("failed to remove peer: %v", err)
@@ -486,8 +706,17 @@ func runFederationListPeers(cmd *cobra.Command, args []string) error {
 		return HandleErrorRespectJSON("failed to list peers: %v", err)
 	}
 
+	// Sync modes and conflict strategies live on registered peers; plain
+	// remotes are bidirectional with no default strategy.
+	peers := map[string]*storage.FederationPeer{}
+	if list, err := store.ListFederationPeers(ctx); err == nil {
+		for _, p := range list {
+			peers[p.Name] = p
+		}
+	}
+
 	if jsonOutput {
-		return outputJSON(formatFederationPeerListJSON(remotes))
+		return outputJSON(formatFederationPeerListJSON(remotes, peers))
 	}
 
 	if len(remotes) == 0 {
@@ -497,24 +726,79 @@ func runFederationListPeers(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("\n%s Federation Peers:\n\n", ui.RenderAccent("🌐"))
 	for _, r := range remotes {
-		fmt.Printf("  %s  %s\n", ui.RenderAccent(r.Name), ui.RenderMuted(r.URL))
+		line := fmt.Sprintf("  %s  %s", ui.RenderAccent(r.Name), ui.RenderMuted(r.URL))
+		if p := peers[r.Name]; p != nil {
+			if p.SyncMode != "" && p.SyncMode != storage.SyncModeBidirectional {
+				line += "  [" + string(p.SyncMode) + "]"
+			}
+			if p.ConflictStrategy != "" {
+				line += "  [conflicts: " + string(p.ConflictStrategy) + "]"
+			}
+			if p.CredentialSource != "" {
+				line += "  [credentials: " + p.CredentialSource + "]"
+			}
+			if p.Sovereignty != "" {
+				line += "  [sovereignty: " + p.Sovereignty + "]"
+			}
+			if len(p.OwnedPrefixes) > 0 {
+				line += "  [owns: " + strings.Join(p.OwnedPrefixes, ", ") + "]"
+			}
+			if p.EncryptMetadata {
+				line += "  [encrypted]"
+			}
+		}
+		fmt.Println(line)
 	}
 	fmt.Println()
 	return nil
 }
 
 type federationPeerListJSON struct {
-	Name string `json:"Name"`
-	URL  string `json:"URL"`
+	Name             string                   `json:"Name"`
+	URL              string                   `json:"URL"`
+	SyncMode         storage.SyncMode         `json:"SyncMode,omitempty"`
+	ConflictStrategy storage.ConflictStrategy `json:"ConflictStrategy,omitempty"`
+	CredentialSource string                   `json:"CredentialSource,omitempty"`
+	Sovereignty      string                   `json:"Sovereignty,omitempty"`
+	OwnedPrefixes    []string                 `json:"OwnedPrefixes,omitempty"`
+	EncryptMetadata  bool                     `json:"EncryptMetadata,omitempty"`
 }
 
-func formatFederationPeerListJSON(remotes []storage.RemoteInfo) []federationPeerListJSON {
+func formatFederationPeerListJSON(remotes []storage.RemoteInfo, peers map[string]*storage.FederationPeer) []federationPeerListJSON {
 	out := make([]federationPeerListJSON, 0, len(remotes))
 	for _, r := range remotes {
-		out = append(out, federationPeerListJSON{
-			Name: r.Name,
-			URL:  r.URL,
-		})
+		entry := federationPeerListJSON{Name: r.Name, URL: r.URL}
+		if p := peers[r.Name]; p != nil {
+			entry.SyncMode = p.SyncMode
+			entry.ConflictStrategy = p.ConflictStrategy
+			entry.CredentialSource = p.CredentialSource
+			entry.Sovereignty = p.Sovereignty
+			entry.OwnedPrefixes = p.OwnedPrefixes
+			entry.EncryptMetadata = p.EncryptMetadata
+		}
+		out = append(out, entry)
 	}
 	return out
 }
+
+// sshPassphraseFromInput is the --ssh-passphrase value when the flag is given
+// without one: the passphrase is then read by readSSHPassphrase.
+const sshPassphraseFromInput = "-"
+
+// readSSHPassphrase prompts for an SSH key passphrase on a terminal, or
+// reads the first line of stdin when it is not one, so the passphrase never
+// appears on the command line.
+func readSSHPassphrase() (string, error) {
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "SSH key passphrase: ")
+		b, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(b), err
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,334 @@
+//go:build cgo
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+	"golang.org/x/term"
+)
+
+var (
+	federationConflictsResolve string
+	federationConflictsAbort   bool
+)
+
+var federationConflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "List and resolve merge conflicts left by a sync",
+	Long: `List and resolve the merge conflicts a 'bd federation sync' could not
+settle. A sync with no conflict strategy, or a newest/merge strategy that
+cannot decide some conflicts, leaves the merge in place until they are
+resolved here; further syncs refuse to run until then.
+
+Without flags, lists conflicted tables and, for the issues table, each
+conflicting issue with the fields that differ. In a terminal it then walks
+through them, asking how to resolve each one:
+  ours    keep the local version
+  theirs  take the peer's version
+  newest  keep whichever side was updated last
+  merge   merge field by field; the newer side wins fields both changed
+  skip    leave it for later
+
+Once nothing is left, the merge is committed.
+
+Examples:
+  bd federation conflicts                    # Review and resolve interactively
+  bd federation conflicts --json             # List conflicts as JSON
+  bd federation conflicts --resolve theirs   # Resolve everything with the peer's values
+  bd federation conflicts --abort            # Abandon the merge`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runFederationConflicts,
+}
+
+func init() {
+	federationCmd.AddCommand(federationConflictsCmd)
+	federationConflictsCmd.Flags().StringVar(&federationConflictsResolve, "resolve", "", "Resolve all conflicts with a strategy (ours|theirs|newest|merge)")
+	federationConflictsCmd.Flags().BoolVar(&federationConflictsAbort, "abort", false, "Abandon the conflicted merge, restoring the pre-merge state")
+	federationConflictsCmd.MarkFlagsMutuallyExclusive("resolve", "abort")
+}
+
+func runFederationConflicts(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("federation conflicts is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("federation-conflicts")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	ctx := rootCtx
+
+	cs, ok := storage.UnwrapStore(store).(storage.MergeConflictStore)
+	if !ok {
+		return HandleErrorRespectJSON("conflict resolution is not supported by this storage backend")
+	}
+	strategy, err := storage.ParseConflictStrategy(federationConflictsResolve)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	tables, err := store.GetConflicts(ctx)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to list conflicts: %v", err)
+	}
+	if len(tables) == 0 {
+		if federationConflictsAbort {
+			return HandleErrorRespectJSON("no conflicted merge to abort")
+		}
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"tables": []string{},
+				"issues": []storage.IssueConflict{},
+			})
+		}
+		fmt.Println("No merge conflicts.")
+		return nil
+	}
+
+	if federationConflictsAbort {
+		if err := cs.AbortMerge(ctx); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"aborted": true})
+		}
+		fmt.Println("Merge aborted; the pre-merge state is restored.")
+		return nil
+	}
+
+	if strategy != "" {
+		remaining, err := storage.ApplyConflictStrategy(ctx, store, tables, strategy)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+		return finishFederationConflicts(ctx, remaining, string(strategy))
+	}
+
+	var issues []storage.IssueConflict
+	if hasConflictTable(tables, "issues") {
+		if issues, err = cs.GetIssueConflicts(ctx); err != nil {
+			return HandleErrorRespectJSON("failed to list issue conflicts: %v", err)
+		}
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"tables": conflictTableNames(tables),
+			"issues": issues,
+		})
+	}
+
+	printFederationConflicts(tables, issues)
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Printf("Resolve with: bd federation conflicts --resolve [ours|theirs|newest|merge]\n")
+		fmt.Printf("Or abandon the merge with: bd federation conflicts --abort\n\n")
+		return nil
+	}
+
+	changed, err := resolveFederationConflictsInteractively(ctx, cs, tables, issues, bufio.NewReader(os.Stdin))
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if !changed {
+		fmt.Println("Nothing resolved.")
+		return nil
+	}
+	commandDidWrite.Store(true)
+	remaining, err := store.GetConflicts(ctx)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to list conflicts: %v", err)
+	}
+	return finishFederationConflicts(ctx, remaining, "interactive choices")
+}
+
+// finishFederationConflicts commits the merge once no conflicts remain and
+// reports the outcome. how names the resolution for the commit message.
+func finishFederationConflicts(ctx context.Context, remaining []storage.Conflict, how string) error {
+	committed := false
+	if len(remaining) == 0 {
+		if err := store.CommitMergeResolution(ctx, "Resolve federation merge conflicts using "+how); err != nil {
+			return HandleErrorRespectJSON("conflicts resolved but commit failed: %v", err)
+		}
+		// bd-578h9.11: the merged-in rows bypassed the is_blocked hooks, and
+		// the pre-merge HEAD is not known here, so recompute the whole graph.
+		if rs, ok := storage.UnwrapStore(store).(interface {
+			RecomputeBlockedAfterMerge(ctx context.Context, fromCommit string) error
+		}); ok {
+			if err := rs.RecomputeBlockedAfterMerge(ctx, ""); err != nil {
+				return HandleErrorRespectJSON("conflicts resolved but is_blocked recompute failed: %v", err)
+			}
+		}
+		committed = true
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"resolved_with": how,
+			"remaining":     conflictTableNames(remaining),
+			"committed":     committed,
+		})
+	}
+	if committed {
+		fmt.Printf("%s All conflicts resolved; merge committed\n", ui.RenderPass("✓"))
+		return nil
+	}
+	fmt.Printf("%s Conflicts remain in %s; run 'bd federation conflicts' to continue\n",
+		ui.RenderWarn("⚠"), storage.ConflictTables(remaining))
+	return nil
+}
+
+func printFederationConflicts(tables []storage.Conflict, issues []storage.IssueConflict) {
+	fmt.Printf("\n%s Merge conflicts:\n\n", ui.RenderWarn("⚠"))
+	for _, t := range tables {
+		if t.Field != "issues" {
+			fmt.Printf("  %s\n", ui.RenderAccent(t.Field))
+			continue
+		}
+		fmt.Printf("  %s (%d)\n", ui.RenderAccent("issues"), len(issues))
+		for _, c := range issues {
+			fmt.Printf("    %s  %s\n", ui.RenderID(c.IssueID), describeIssueConflict(c))
+			for _, f := range c.Fields {
+				fmt.Printf("      %-14s local %s | peer %s\n", f.Field+":", formatConflictValue(f.Ours), formatConflictValue(f.Theirs))
+			}
+		}
+	}
+	fmt.Println()
+}
+
+func describeIssueConflict(c storage.IssueConflict) string {
+	switch {
+	case c.OursDeleted:
+		return "deleted locally, changed by peer"
+	case c.TheirsDeleted:
+		return "changed locally, deleted by peer"
+	}
+	desc := "changed on both sides"
+	if c.BothAdded {
+		desc = "created on both sides"
+	}
+	if c.OursUpdatedAt != nil && c.TheirsUpdatedAt != nil {
+		desc += fmt.Sprintf(" (local %s, peer %s)",
+			c.OursUpdatedAt.Format("2006-01-02 15:04"), c.TheirsUpdatedAt.Format("2006-01-02 15:04"))
+	}
+	return desc
+}
+
+func formatConflictValue(v interface{}) string {
+	if v == nil {
+		return ui.RenderMuted("(null)")
+	}
+	s := strings.ReplaceAll(fmt.Sprint(v), "\n", " ")
+	return fmt.Sprintf("%q", truncate(s, 50))
+}
+
+// resolveFederationConflictsInteractively prompts for each conflicting issue,
+// then each other conflicted table, and reports whether anything changed.
+// Input ending early skips whatever is left.
+func resolveFederationConflictsInteractively(ctx context.Context, cs storage.MergeConflictStore, tables []storage.Conflict, issues []storage.IssueConflict, in *bufio.Reader) (bool, error) {
+	changed := false
+	for _, c := range issues {
+		for {
+			choice, ok := promptConflictChoice(in, fmt.Sprintf("%s: [o]urs, [t]heirs, [n]ewest, [m]erge, [s]kip? ", c.IssueID), "otnms")
+			if !ok {
+				return changed, nil
+			}
+			if choice == "s" {
+				break
+			}
+			strategy := conflictChoiceStrategy(choice)
+			resolved, err := cs.ResolveIssueConflict(ctx, c.IssueID, strategy)
+			if err != nil {
+				return changed, fmt.Errorf("resolving %s: %w", c.IssueID, err)
+			}
+			if !resolved {
+				fmt.Printf("  %s cannot decide this conflict; choose another option\n", strategy)
+				continue
+			}
+			changed = true
+			fmt.Printf("  %s %s resolved (%s)\n", ui.RenderPass("✓"), c.IssueID, strategy)
+			break
+		}
+	}
+
+	for _, t := range tables {
+		if t.Field == "issues" {
+			continue
+		}
+		choice, ok := promptConflictChoice(in, fmt.Sprintf("table %s: [o]urs, [t]heirs, [s]kip? ", t.Field), "ots")
+		if !ok {
+			return changed, nil
+		}
+		if choice == "s" {
+			continue
+		}
+		strategy := conflictChoiceStrategy(choice)
+		if err := store.ResolveConflicts(ctx, t.Field, string(strategy)); err != nil {
+			return changed, fmt.Errorf("resolving %s: %w", t.Field, err)
+		}
+		changed = true
+		fmt.Printf("  %s %s resolved (%s)\n", ui.RenderPass("✓"), t.Field, strategy)
+	}
+	return changed, nil
+}
+
+// promptConflictChoice reads a one-letter answer from in, re-asking until it
+// is one of valid. ok is false once input ends.
+func promptConflictChoice(in *bufio.Reader, prompt, valid string) (choice string, ok bool) {
+	for {
+		fmt.Print(prompt)
+		line, err := in.ReadString('\n')
+		answer := strings.ToLower(strings.TrimSpace(line))
+		if answer != "" && strings.Contains(valid, answer[:1]) {
+			return answer[:1], true
+		}
+		if err != nil {
+			fmt.Println()
+			return "", false
+		}
+	}
+}
+
+func conflictChoiceStrategy(choice string) storage.ConflictStrategy {
+	switch choice {
+	case "o":
+		return storage.ConflictStrategyOurs
+	case "t":
+		return storage.ConflictStrategyTheirs
+	case "n":
+		return storage.ConflictStrategyNewest
+	default:
+		return storage.ConflictStrategyMerge
+	}
+}
+
+func conflictTableNames(conflicts []storage.Conflict) []string {
+	names := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		names = append(names, c.Field)
+	}
+	return names
+}
+
+func hasConflictTable(conflicts []storage.Conflict, table string) bool {
+	for _, c := range conflicts {
+		if c.Field == table {
+			return true
+		}
+	}
+	return false
+}
@@ -3,10 +3,12 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -46,7 +48,7 @@ func TestFormatFederationPeerListJSONPreservesLegacyKeys(t *testing.T) {
 	formatted := formatFederationPeerListJSON([]storage.RemoteInfo{{
 		Name: "town-beta",
 		URL:  "file:///tmp/town-beta",
-	}})
+	}}, nil)
 
 	raw, err := json.Marshal(formatted)
 	if err != nil {
@@ -135,6 +137,32 @@ func TestEmbeddedFederation(t *testing.T) {
 		}
 	})
 
+	t.Run("add_peer_with_credential_source", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "fdsrc")
+
+		out := bdFederation(t, bd, dir, "add-peer", "src-peer", "file:///tmp/src-peer",
+			"--user", "admin", "--credential-source", "env:SRC_PEER_PW", "--json")
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("failed to parse JSON: %v\n%s", err, out)
+		}
+		if src, _ := result["credential_source"].(string); src != "env:SRC_PEER_PW" {
+			t.Errorf("expected credential_source='env:SRC_PEER_PW', got %q", src)
+		}
+
+		listOut := bdFederation(t, bd, dir, "list-peers", "--json")
+		if !strings.Contains(listOut, `"CredentialSource": "env:SRC_PEER_PW"`) {
+			t.Errorf("list-peers should report the credential source:\n%s", listOut)
+		}
+
+		if out := bdFederationFail(t, bd, dir, "add-peer", "bad-src", "file:///tmp/bad-src", "--credential-source", "file:/etc/pw"); !strings.Contains(out, "invalid credential source") {
+			t.Errorf("expected invalid credential source error, got: %s", out)
+		}
+		if out := bdFederationFail(t, bd, dir, "add-peer", "both", "file:///tmp/both", "--password", "x", "--credential-source", "env:PW"); !strings.Contains(out, "mutually exclusive") {
+			t.Errorf("expected mutually exclusive error, got: %s", out)
+		}
+	})
+
 	t.Run("add_peer_with_sovereignty", func(t *testing.T) {
 		dir, _, _ := bdInit(t, bd, "--prefix", "fdsov")
 
@@ -209,6 +237,231 @@ func TestEmbeddedFederation(t *testing.T) {
 			t.Error("missing 'peers' in status JSON")
 		}
 	})
+
+	t.Run("ping", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "fdping")
+
+		bdFederationFail(t, bd, dir, "ping")
+		if out := bdFederationFail(t, bd, dir, "ping", "missing"); !strings.Contains(out, "not found") {
+			t.Errorf("expected not found error for unknown peer, got: %s", out)
+		}
+
+		bdFederation(t, bd, dir, "add-peer", "local", "file://"+t.TempDir())
+		out := bdFederation(t, bd, dir, "ping", "local")
+		if !strings.Contains(out, "Reachable") {
+			t.Errorf("expected local peer to be reachable, got: %s", out)
+		}
+
+		// A refused connection is a network failure, not an auth failure,
+		// and fails the command.
+		bdFederation(t, bd, dir, "add-peer", "dead", "http://127.0.0.1:1/beads")
+		cmd := exec.Command(bd, "federation", "ping", "--all", "--json")
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		stdout, _, err := runCommandBuffers(t, cmd)
+		if err == nil {
+			t.Fatalf("ping --all should fail with an unreachable peer, got: %s", stdout.String())
+		}
+		var results []federationPingJSON
+		if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+			t.Fatalf("failed to parse JSON: %v\n%s", err, stdout.String())
+		}
+		got := map[string]storage.PeerHealthStatus{}
+		for _, r := range results {
+			got[r.Peer] = r.Status
+		}
+		if got["local"] != storage.PeerHealthOK || got["dead"] != storage.PeerHealthUnreachable {
+			t.Errorf("statuses = %v, want local=ok dead=unreachable", got)
+		}
+	})
+
+	t.Run("rotate-key", func(t *testing.T) {
+		dir, beadsDir, _ := bdInit(t, bd, "--prefix", "fdrot")
+		bdFederation(t, bd, dir, "add-peer", "alpha", "file://"+t.TempDir(), "--user", "alice", "--password", "s3cret")
+		keyPath := filepath.Join(beadsDir, ".beads-credential-key")
+		oldKey, err := os.ReadFile(keyPath)
+		if err != nil {
+			t.Fatalf("expected credential key after adding a peer with a password: %v", err)
+		}
+
+		out := bdFederation(t, bd, dir, "rotate-key", "--dry-run")
+		if !strings.Contains(out, "Verified credentials for alpha") {
+			t.Errorf("expected dry run to verify alpha, got: %s", out)
+		}
+		if key, _ := os.ReadFile(keyPath); !bytes.Equal(key, oldKey) {
+			t.Error("dry run must not change the key file")
+		}
+
+		cmd := exec.Command(bd, "federation", "rotate-key", "--json")
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		stdout, _, err := runCommandBuffers(t, cmd)
+		if err != nil {
+			t.Fatalf("rotate-key failed: %v\n%s", err, stdout.String())
+		}
+		var result struct {
+			Peers  []string `json:"peers"`
+			DryRun bool     `json:"dry_run"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+			t.Fatalf("failed to parse JSON: %v\n%s", err, stdout.String())
+		}
+		if len(result.Peers) != 1 || result.Peers[0] != "alpha" || result.DryRun {
+			t.Errorf("result = %+v, want alpha re-encrypted", result)
+		}
+		if key, _ := os.ReadFile(keyPath); len(key) != 32 || bytes.Equal(key, oldKey) {
+			t.Error("rotate-key did not replace the key file")
+		}
+		// The re-encrypted password still decrypts with the new key.
+		bdFederation(t, bd, dir, "rotate-key", "--dry-run")
+	})
+
+	t.Run("export_import", func(t *testing.T) {
+		src, _, _ := bdInit(t, bd, "--prefix", "fdexp")
+		bdFederation(t, bd, src, "add-peer", "beta", "file:///tmp/beta")
+		bdFederation(t, bd, src, "add-peer", "up", "file:///tmp/up", "--user", "bot", "--password", "s3cret", "--sync-mode", "pull-only")
+		exported := bdFederation(t, bd, src, "export")
+		if strings.Contains(exported, "s3cret") {
+			t.Fatalf("export leaked a password:\n%s", exported)
+		}
+		peersPath := filepath.Join(t.TempDir(), "peers.toml")
+		if err := os.WriteFile(peersPath, []byte(exported), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		dst, _, _ := bdInit(t, bd, "--prefix", "fdimp")
+		bdFederation(t, bd, dst, "add-peer", "beta", "file:///elsewhere")
+		out := bdFederation(t, bd, dst, "import", peersPath, "--json")
+		var result struct {
+			Peers []peerImportResult `json:"peers"`
+		}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("failed to parse JSON: %v\n%s", err, out)
+		}
+		got := map[string]string{}
+		for _, r := range result.Peers {
+			got[r.Name] = r.Action
+		}
+		if got["beta"] != "skipped" || got["up"] != "added" {
+			t.Errorf("actions = %v, want beta skipped, up added", got)
+		}
+		if list := bdFederation(t, bd, dst, "list-peers"); !strings.Contains(list, "[pull-only]") {
+			t.Errorf("imported peer lost its sync mode:\n%s", list)
+		}
+	})
+
+	t.Run("sync_dry_run", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "fddry")
+		bdFederation(t, bd, dir, "add-peer", "mirror", "file://"+t.TempDir())
+		bdCreate(t, bd, dir, "First issue")
+
+		preview := func() syncPreviewJSON {
+			t.Helper()
+			out := bdFederation(t, bd, dir, "sync", "--dry-run", "--json")
+			var result struct {
+				DryRun bool              `json:"dry_run"`
+				Peers  []syncPreviewJSON `json:"peers"`
+			}
+			if err := json.Unmarshal([]byte(out), &result); err != nil {
+				t.Fatalf("failed to parse JSON: %v\n%s", err, out)
+			}
+			if !result.DryRun || len(result.Peers) != 1 || result.Peers[0].Error != "" {
+				t.Fatalf("unexpected preview: %s", out)
+			}
+			return result.Peers[0]
+		}
+
+		// Nothing has been pushed yet, and the dry run must not push.
+		for i := 0; i < 2; i++ {
+			p := preview()
+			if !p.PeerBranchMissing || p.Push == nil || p.Push.CommitCount == 0 {
+				t.Fatalf("run %d: expected every local commit to be pushed: %+v", i, p)
+			}
+		}
+
+		cmd := exec.Command(bd, "dolt", "push", "--remote", "mirror")
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("bd dolt push failed: %v\n%s", err, out)
+		}
+		p := preview()
+		if p.PeerBranchMissing || p.MergeBase == "" || p.Push.CommitCount != 0 || p.Pull.CommitCount != 0 {
+			t.Fatalf("expected peer in sync after a push: %+v", p)
+		}
+
+		issue := bdCreate(t, bd, dir, "Second issue")
+		p = preview()
+		if p.Push.CommitCount == 0 || p.Pull.CommitCount != 0 {
+			t.Fatalf("expected only commits to push: %+v", p)
+		}
+		found := false
+		for _, r := range p.Push.Issues {
+			if r.ID == issue.ID && r.Change == "added" && r.Title == "Second issue" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("push preview missing %s: %+v", issue.ID, p.Push.Issues)
+		}
+
+		out := bdFederation(t, bd, dir, "sync", "--dry-run")
+		if !strings.Contains(out, "Would push") || !strings.Contains(out, issue.ID) {
+			t.Errorf("text preview missing the pending push:\n%s", out)
+		}
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "fdver")
+		bdFederation(t, bd, dir, "add-peer", "mirror", "file://"+t.TempDir())
+		kept := bdCreate(t, bd, dir, "Pushed and kept")
+		changed := bdCreate(t, bd, dir, "Pushed then changed")
+
+		verify := func() federationVerifyJSON {
+			t.Helper()
+			cmd := exec.Command(bd, "federation", "verify", "mirror", "--json")
+			cmd.Dir = dir
+			cmd.Env = bdEnv(dir)
+			stdout, _, _ := runCommandBuffers(t, cmd)
+			var results []federationVerifyJSON
+			if err := json.Unmarshal(stdout.Bytes(), &results); err != nil || len(results) != 1 || results[0].Error != "" {
+				t.Fatalf("unexpected verify output (err %v): %s", err, stdout.String())
+			}
+			return results[0]
+		}
+
+		if v := verify(); !v.PeerBranchMissing || v.Consistent || v.LocalIssues != 2 {
+			t.Fatalf("before a push: %+v, want a missing peer branch", v)
+		}
+
+		push := exec.Command(bd, "dolt", "push", "--remote", "mirror")
+		push.Dir = dir
+		push.Env = bdEnv(dir)
+		if out, err := push.CombinedOutput(); err != nil {
+			t.Fatalf("bd dolt push failed: %v\n%s", err, out)
+		}
+		v := verify()
+		if !v.Consistent || v.LocalRoot != v.PeerRoot || len(v.Differences) != 0 {
+			t.Fatalf("after a push: %+v, want consistent", v)
+		}
+		if out := bdFederation(t, bd, dir, "verify", "--all"); !strings.Contains(out, "Consistent") {
+			t.Errorf("text output missing consistency:\n%s", out)
+		}
+
+		bdCommand(t, bd, dir, "update", changed.ID, "--title", "Changed locally")
+		added := bdCreate(t, bd, dir, "Not pushed")
+		v = verify()
+		if v.Consistent || len(v.Differences) != 2 {
+			t.Fatalf("after local writes: %+v, want two differences", v)
+		}
+		kinds := map[string]storage.IssueDifferenceKind{}
+		for _, d := range v.Differences {
+			kinds[d.ID] = d.Kind
+		}
+		if kinds[changed.ID] != storage.IssueChanged || kinds[added.ID] != storage.IssueOnlyLocal || kinds[kept.ID] != "" {
+			t.Errorf("differences = %v, want %s changed and %s only_local", kinds, changed.ID, added.ID)
+		}
+	})
 }
 
 func TestEmbeddedFederationConcurrent(t *testing.T) {
@@ -0,0 +1,108 @@
+//go:build cgo
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var federationEncryptMetadataDryRun bool
+
+var federationEncryptMetadataCmd = &cobra.Command{
+	Use:   "encrypt-metadata [--dry-run]",
+	Short: "Encrypt the stored user and URL of every federation peer",
+	Long: `Encrypt the user and remote URL of every federation peer stored in
+plaintext, using the local key that already encrypts peer passwords and SSH
+key passphrases.
+
+Usernames and URLs can name internal hosts or personal accounts, and the
+federation_peers table is committed to Dolt history. Once encrypted, a
+peer's row holds neither in plaintext; bd decrypts them whenever it reads
+the peer. Peers stay encrypted when they are updated with add-peer or
+import, and 'bd federation rotate-key' re-encrypts them with the new key.
+
+All peers are encrypted in a single transaction. To encrypt peers as they
+are added, pass --encrypt-metadata to add-peer or set
+federation.encrypt-peer-metadata: true in config.yaml.
+
+Earlier Dolt commits still hold the plaintext values. The Dolt remote
+itself keeps the URL in the local repository configuration, which is not
+versioned or pushed.
+
+Examples:
+  bd federation encrypt-metadata --dry-run  # List the peers that would be encrypted
+  bd federation encrypt-metadata            # Encrypt them`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runFederationEncryptMetadata,
+}
+
+func init() {
+	federationCmd.AddCommand(federationEncryptMetadataCmd)
+	federationEncryptMetadataCmd.Flags().BoolVar(&federationEncryptMetadataDryRun, "dry-run", false, "List the peers that would be encrypted without changing them")
+}
+
+func runFederationEncryptMetadata(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("federation encrypt-metadata is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("federation-encrypt-metadata")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	ctx := rootCtx
+
+	encrypter, ok := storage.UnwrapStore(store).(storage.PeerMetadataEncrypter)
+	if !ok {
+		return HandleErrorRespectJSON("peer metadata encryption is not supported by this storage backend")
+	}
+
+	opts := storage.PeerMetadataEncryptionOptions{DryRun: federationEncryptMetadataDryRun}
+	if !jsonOutput {
+		verb := "Encrypted"
+		if opts.DryRun {
+			verb = "Would encrypt"
+		}
+		opts.Progress = func(peer string) {
+			fmt.Printf("  %s %s user and URL for %s\n", ui.RenderPass("✓"), verb, peer)
+		}
+	}
+
+	result, err := encrypter.EncryptPeerMetadata(ctx, opts)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to encrypt peer metadata: %v", err)
+	}
+	if !result.DryRun && len(result.Peers) > 0 {
+		commandDidWrite.Store(true)
+	}
+
+	if jsonOutput {
+		peers := result.Peers
+		if peers == nil {
+			peers = []string{}
+		}
+		return outputJSON(map[string]interface{}{
+			"peers":   peers,
+			"dry_run": result.DryRun,
+		})
+	}
+
+	switch {
+	case len(result.Peers) == 0:
+		fmt.Println("No plaintext peer metadata to encrypt.")
+	case result.DryRun:
+		fmt.Printf("Dry run: %d peer(s) would be encrypted\n", len(result.Peers))
+	default:
+		fmt.Printf("%s Encrypted metadata for %d peer(s)\n", ui.RenderPass("✓"), len(result.Peers))
+	}
+	return nil
+}
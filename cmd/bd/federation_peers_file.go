@@ -0,0 +1,482 @@
+//go:build cgo
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/ui"
+	"golang.org/x/term"
+)
+
+var federationImportPromptCreds bool
+
+// peersFile is the portable peer topology written by 'bd federation export'
+// and read by 'bd federation import'. It never holds secrets: passwords and
+// SSH key passphrases stay in the local database.
+type peersFile struct {
+	Peers []peersFileEntry `toml:"peer"`
+}
+
+// peersFileEntry is one [[peer]] table of a peers file.
+type peersFileEntry struct {
+	Name             string   `toml:"name" json:"name"`
+	URL              string   `toml:"url" json:"url"`
+	User             string   `toml:"user,omitempty" json:"user,omitempty"`
+	CredentialSource string   `toml:"credential_source,omitempty" json:"credential_source,omitempty"`
+	SSHKey           string   `toml:"ssh_key,omitempty" json:"ssh_key,omitempty"`
+	Sovereignty      string   `toml:"sovereignty,omitempty" json:"sovereignty,omitempty"`
+	OwnedPrefixes    []string `toml:"owned_prefixes,omitempty" json:"owned_prefixes,omitempty"`
+	SyncMode         string   `toml:"sync_mode,omitempty" json:"sync_mode,omitempty"`
+	ConflictStrategy string   `toml:"conflict_strategy,omitempty" json:"conflict_strategy,omitempty"`
+}
+
+const peersFileHeader = `# Federation peers exported by 'bd federation export'.
+# Load on another machine with 'bd federation import <file>'.
+# Secrets are not included; use --prompt-credentials on import or give
+# peers a credential_source (env:VAR or cmd:/path/to/helper).
+
+`
+
+var federationExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the peer topology as TOML, without secrets",
+	Long: `Write the configured federation peers to stdout as TOML, so a team can
+share its peer topology across machines.
+
+Each peer's URL, user, credential source, SSH key path, sovereignty tier,
+owned prefixes, sync mode, and conflict strategy are exported. Stored passwords and SSH key
+passphrases are not. SSH key paths under your home directory are written
+relative to ~ so they resolve on other machines.
+
+Examples:
+  bd federation export > peers.toml
+  bd federation export --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runFederationExport,
+}
+
+var federationImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Add or update peers from a file written by export",
+	Long: `Add or update federation peers from a TOML file written by
+'bd federation export' ("-" reads stdin).
+
+Every entry is validated before anything is written: peer names, sync
+modes, conflict strategies, sovereignty tiers, and credential sources must
+all be valid, and unknown keys are rejected. Imported peers merge with the
+existing ones:
+
+  - New peers are added.
+  - Existing peers take the settings the file gives; settings it omits
+    are left as they are. A stored password is kept unless the file
+    changes the peer's user or gives it a credential source.
+  - A peer whose URL differs from the file's is skipped; remove it with
+    'bd federation remove-peer' first to replace it.
+  - Peers not in the file are left alone.
+
+With --prompt-credentials, you are asked for each peer's password (peers
+with a user and no credential source) and SSH key passphrase (peers with an
+SSH key). Press Enter to skip a prompt and keep what is stored.
+
+Examples:
+  bd federation import peers.toml
+  bd federation import peers.toml --prompt-credentials
+  curl -s https://intranet.example.com/peers.toml | bd federation import -`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runFederationImport,
+}
+
+func init() {
+	federationCmd.AddCommand(federationExportCmd)
+	federationCmd.AddCommand(federationImportCmd)
+	federationImportCmd.Flags().BoolVar(&federationImportPromptCreds, "prompt-credentials", false, "Prompt for each peer's password and SSH key passphrase")
+}
+
+// buildPeersFile collects the exportable settings of every peer remote.
+// Plain remotes export only their URL; origin is not a federation peer.
+func buildPeersFile(remotes []storage.RemoteInfo, peers map[string]*storage.FederationPeer, home string) peersFile {
+	var f peersFile
+	for _, r := range remotes {
+		if r.Name == "origin" {
+			continue
+		}
+		entry := peersFileEntry{Name: r.Name, URL: r.URL}
+		if p := peers[r.Name]; p != nil {
+			entry.User = p.Username
+			entry.CredentialSource = p.CredentialSource
+			entry.SSHKey = portableSSHKeyPath(p.SSHKeyPath, home)
+			entry.Sovereignty = p.Sovereignty
+			entry.OwnedPrefixes = p.OwnedPrefixes
+			if p.SyncMode != storage.SyncModeBidirectional {
+				entry.SyncMode = string(p.SyncMode)
+			}
+			entry.ConflictStrategy = string(p.ConflictStrategy)
+		}
+		f.Peers = append(f.Peers, entry)
+	}
+	sort.Slice(f.Peers, func(i, j int) bool { return f.Peers[i].Name < f.Peers[j].Name })
+	return f
+}
+
+// portableSSHKeyPath rewrites a key path under home as ~/..., which import
+// expands against the importing user's home directory.
+func portableSSHKeyPath(path, home string) string {
+	if path == "" || home == "" {
+		return path
+	}
+	rel, err := filepath.Rel(home, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return "~/" + filepath.ToSlash(rel)
+}
+
+// encodePeersFile renders f as TOML with an explanatory header.
+func encodePeersFile(f peersFile) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(peersFileHeader)
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+	if err := enc.Encode(f); err != nil {
+		return nil, fmt.Errorf("encoding peers: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// parsePeersFile decodes and validates a peers file. All problems are
+// reported together so the file can be fixed in one pass; nothing should be
+// imported from a file with any.
+func parsePeersFile(data []byte) ([]peersFileEntry, error) {
+	var f peersFile
+	md, err := toml.Decode(string(data), &f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing peers file: %w", err)
+	}
+	var errs []error
+	for _, key := range md.Undecoded() {
+		errs = append(errs, fmt.Errorf("unknown key %q", key.String()))
+	}
+
+	seen := make(map[string]bool, len(f.Peers))
+	for i := range f.Peers {
+		e := &f.Peers[i]
+		where := fmt.Sprintf("peer %d", i+1)
+		if e.Name != "" {
+			where = fmt.Sprintf("peer %q", e.Name)
+		}
+		if err := issueops.ValidatePeerName(e.Name); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", where, err))
+		} else if seen[e.Name] {
+			errs = append(errs, fmt.Errorf("%s: listed more than once", where))
+		}
+		seen[e.Name] = true
+		if strings.TrimSpace(e.URL) == "" {
+			errs = append(errs, fmt.Errorf("%s: url is required", where))
+		}
+		if e.Sovereignty != "" {
+			e.Sovereignty = strings.ToUpper(e.Sovereignty)
+			switch e.Sovereignty {
+			case "T1", "T2", "T3", "T4":
+			default:
+				errs = append(errs, fmt.Errorf("%s: invalid sovereignty tier %q (must be T1, T2, T3, or T4)", where, e.Sovereignty))
+			}
+		}
+		if _, err := storage.ParseSyncMode(e.SyncMode); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", where, err))
+		}
+		if _, err := storage.ParseConflictStrategy(e.ConflictStrategy); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", where, err))
+		}
+		if _, _, err := storage.ParseCredentialSource(e.CredentialSource); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", where, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return f.Peers, nil
+}
+
+// mergePeerEntry applies a validated file entry to the existing peer record
+// (nil for none). Settings the entry omits keep their existing values. The
+// stored password is dropped when the entry changes the user or names a
+// credential source, and the SSH key passphrase when it changes the key.
+func mergePeerEntry(existing *storage.FederationPeer, e peersFileEntry, sshKey string) *storage.FederationPeer {
+	merged := &storage.FederationPeer{Name: e.Name}
+	if existing != nil {
+		*merged = *existing
+	}
+	merged.RemoteURL = e.URL
+	if e.User != "" && e.User != merged.Username {
+		merged.Username = e.User
+		merged.Password = ""
+	}
+	if e.CredentialSource != "" {
+		merged.CredentialSource = e.CredentialSource
+		merged.Password = ""
+	}
+	if sshKey != "" && sshKey != merged.SSHKeyPath {
+		merged.SSHKeyPath = sshKey
+		merged.SSHKeyPassphrase = ""
+	}
+	if e.Sovereignty != "" {
+		merged.Sovereignty = e.Sovereignty
+	}
+	if len(e.OwnedPrefixes) > 0 {
+		merged.OwnedPrefixes = e.OwnedPrefixes
+	}
+	if e.SyncMode != "" {
+		merged.SyncMode, _ = storage.ParseSyncMode(e.SyncMode)
+	}
+	if e.ConflictStrategy != "" {
+		merged.ConflictStrategy, _ = storage.ParseConflictStrategy(e.ConflictStrategy)
+	}
+	return merged
+}
+
+// peerNeedsRecord reports whether p carries settings beyond a remote URL, and
+// so must be stored as a federation peer rather than a plain remote.
+func peerNeedsRecord(p *storage.FederationPeer) bool {
+	return p.Username != "" || p.Password != "" || p.SSHKeyPath != "" || p.SSHKeyPassphrase != "" ||
+		p.CredentialSource != "" || p.Sovereignty != "" || len(p.OwnedPrefixes) > 0 || p.ConflictStrategy != "" ||
+		(p.SyncMode != "" && p.SyncMode != storage.SyncModeBidirectional) || p.EncryptMetadata
+}
+
+// samePeerSettings reports whether two peer records would store the same row.
+func samePeerSettings(a, b *storage.FederationPeer) bool {
+	syncMode := func(m storage.SyncMode) storage.SyncMode {
+		if m == "" {
+			return storage.SyncModeBidirectional
+		}
+		return m
+	}
+	return a.RemoteURL == b.RemoteURL && a.Username == b.Username && a.Password == b.Password &&
+		a.SSHKeyPath == b.SSHKeyPath && a.SSHKeyPassphrase == b.SSHKeyPassphrase &&
+		a.CredentialSource == b.CredentialSource && a.Sovereignty == b.Sovereignty &&
+		slices.Equal(a.OwnedPrefixes, b.OwnedPrefixes) && a.EncryptMetadata == b.EncryptMetadata &&
+		syncMode(a.SyncMode) == syncMode(b.SyncMode) && a.ConflictStrategy == b.ConflictStrategy
+}
+
+func runFederationExport(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("federation export is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("federation-export")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	ctx := rootCtx
+
+	remotes, err := store.ListRemotes(ctx)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to list peers: %v", err)
+	}
+	peers := map[string]*storage.FederationPeer{}
+	if list, err := store.ListFederationPeers(ctx); err == nil {
+		for _, p := range list {
+			peers[p.Name] = p
+		}
+	}
+	home, _ := os.UserHomeDir()
+	f := buildPeersFile(remotes, peers, home)
+
+	if jsonOutput {
+		if f.Peers == nil {
+			f.Peers = []peersFileEntry{}
+		}
+		return outputJSON(f.Peers)
+	}
+	data, err := encodePeersFile(f)
+	if err != nil {
+		return HandleError("%v", err)
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// peerImportResult reports what import did with one peer.
+type peerImportResult struct {
+	Name   string `json:"name"`
+	Action string `json:"action"` // added, updated, unchanged, or skipped
+	Reason string `json:"reason,omitempty"`
+}
+
+func runFederationImport(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("federation import is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("federation-import")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	ctx := rootCtx
+
+	if federationImportPromptCreds && (args[0] == "-" || !term.IsTerminal(int(os.Stdin.Fd()))) {
+		return HandleErrorRespectJSON("--prompt-credentials needs an interactive terminal on stdin")
+	}
+	data, err := readBodyFile(args[0])
+	if err != nil {
+		return HandleErrorRespectJSON("reading %s: %v", args[0], err)
+	}
+	entries, err := parsePeersFile([]byte(data))
+	if err != nil {
+		return HandleErrorRespectJSON("invalid peers file %s:\n%v", args[0], err)
+	}
+
+	remotes, err := store.ListRemotes(ctx)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to list peers: %v", err)
+	}
+	remoteURLs := make(map[string]string, len(remotes))
+	for _, r := range remotes {
+		remoteURLs[r.Name] = r.URL
+	}
+	existing := map[string]*storage.FederationPeer{}
+	if list, err := store.ListFederationPeers(ctx); err == nil {
+		for _, p := range list {
+			existing[p.Name] = p
+		}
+	}
+
+	results := make([]peerImportResult, 0, len(entries))
+	wrote := false
+	for _, e := range entries {
+		res := peerImportResult{Name: e.Name}
+		url, hasRemote := remoteURLs[e.Name]
+		if hasRemote && url != e.URL {
+			res.Action = "skipped"
+			res.Reason = fmt.Sprintf("URL differs (local %s); remove-peer first to replace it", url)
+			results = append(results, res)
+			continue
+		}
+
+		sshKey := e.SSHKey
+		if sshKey != "" {
+			if resolved, err := resolveSSHKeyPath(sshKey); err == nil {
+				sshKey = resolved
+			} else if !jsonOutput {
+				fmt.Fprintf(os.Stderr, "%s %s: %v\n", ui.RenderWarn("⚠"), e.Name, err)
+			}
+		}
+
+		// Peers that were plain remotes have no record; compare against
+		// what a bare add-peer would have stored.
+		prev := existing[e.Name]
+		if prev == nil && hasRemote {
+			prev = &storage.FederationPeer{Name: e.Name, RemoteURL: url}
+		}
+		merged := mergePeerEntry(existing[e.Name], e, sshKey)
+		merged.EncryptMetadata = merged.EncryptMetadata || config.GetBool("federation.encrypt-peer-metadata")
+		if federationImportPromptCreds {
+			if err := promptPeerCredentials(merged); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+		}
+
+		switch {
+		case prev != nil && samePeerSettings(prev, merged):
+			res.Action = "unchanged"
+			results = append(results, res)
+			continue
+		case prev != nil:
+			res.Action = "updated"
+		default:
+			res.Action = "added"
+		}
+
+		if peerNeedsRecord(merged) || existing[e.Name] != nil {
+			err = store.AddFederationPeer(ctx, merged)
+		} else {
+			err = store.AddRemote(ctx, e.Name, e.URL)
+		}
+		if err != nil {
+			return HandleErrorRespectJSON("failed to import peer %s: %v", e.Name, err)
+		}
+		wrote = true
+		results = append(results, res)
+	}
+	if wrote {
+		commandDidWrite.Store(true)
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"file":  args[0],
+			"peers": results,
+		})
+	}
+	if len(results) == 0 {
+		fmt.Println("No peers in file.")
+		return nil
+	}
+	for _, r := range results {
+		switch r.Action {
+		case "added":
+			fmt.Printf("  %s Added %s\n", ui.RenderPass("✓"), ui.RenderAccent(r.Name))
+		case "updated":
+			fmt.Printf("  %s Updated %s\n", ui.RenderPass("✓"), ui.RenderAccent(r.Name))
+		case "unchanged":
+			fmt.Printf("  %s %s unchanged\n", ui.RenderMuted("○"), r.Name)
+		case "skipped":
+			fmt.Printf("  %s Skipped %s: %s\n", ui.RenderWarn("⚠"), r.Name, r.Reason)
+		}
+	}
+	return nil
+}
+
+// promptPeerCredentials asks for the secrets a peer file cannot carry. An
+// empty answer keeps what is stored.
+func promptPeerCredentials(p *storage.FederationPeer) error {
+	read := func(prompt string) (string, error) {
+		fmt.Fprint(os.Stderr, prompt)
+		b, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read credentials for %s: %w", p.Name, err)
+		}
+		return string(b), nil
+	}
+	if p.Username != "" && p.CredentialSource == "" {
+		pw, err := read(fmt.Sprintf("Password for %s (user %s, Enter to skip): ", p.Name, p.Username))
+		if err != nil {
+			return err
+		}
+		if pw != "" {
+			p.Password = pw
+		}
+	}
+	if p.SSHKeyPath != "" {
+		pass, err := read(fmt.Sprintf("SSH key passphrase for %s (%s, Enter to skip): ", p.Name, p.SSHKeyPath))
+		if err != nil {
+			return err
+		}
+		if pass != "" {
+			p.SSHKeyPassphrase = pass
+		}
+	}
+	return nil
+}
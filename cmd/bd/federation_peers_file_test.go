@@ -0,0 +1,126 @@
+//go:build cgo
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestPeersFileRoundTrip(t *testing.T) {
+	remotes := []storage.RemoteInfo{
+		{Name: "vault", URL: "git+ssh://git@vault/beads.git"},
+		{Name: "origin", URL: "dolthub://acme/self"},
+		{Name: "beta", URL: "file:///tmp/beta"},
+	}
+	peers := map[string]*storage.FederationPeer{
+		"vault": {
+			Name:             "vault",
+			Username:         "sync",
+			Password:         "secret",
+			SSHKeyPath:       "/home/alice/.ssh/beads",
+			SSHKeyPassphrase: "hunter2",
+			SyncMode:         storage.SyncModePullOnly,
+			ConflictStrategy: storage.ConflictStrategyNewest,
+		},
+	}
+
+	data, err := encodePeersFile(buildPeersFile(remotes, peers, "/home/alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(data)
+	for _, secret := range []string{"secret", "hunter2", "origin"} {
+		if strings.Contains(text, secret) {
+			t.Errorf("export contains %q:\n%s", secret, text)
+		}
+	}
+
+	entries, err := parsePeersFile(data)
+	if err != nil {
+		t.Fatalf("parsePeersFile: %v\n%s", err, text)
+	}
+	if len(entries) != 2 || entries[0].Name != "beta" || entries[1].Name != "vault" {
+		t.Fatalf("entries = %+v, want beta, vault", entries)
+	}
+	v := entries[1]
+	if v.User != "sync" || v.SSHKey != "~/.ssh/beads" || v.SyncMode != "pull-only" || v.ConflictStrategy != "newest" {
+		t.Errorf("vault entry = %+v", v)
+	}
+}
+
+func TestParsePeersFileRejectsInvalidEntries(t *testing.T) {
+	_, err := parsePeersFile([]byte(`
+[[peer]]
+name = "bad name"
+url = "file:///a"
+password = "leaked"
+
+[[peer]]
+name = "twice"
+url = "file:///b"
+sovereignty = "t9"
+
+[[peer]]
+name = "twice"
+url = ""
+conflict_strategy = "coinflip"
+`))
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{`unknown key "peer.password"`, `peer "bad name"`, "sovereignty", "listed more than once", "url is required", "coinflip"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q:\n%v", want, err)
+		}
+	}
+
+	entries, err := parsePeersFile([]byte("[[peer]]\nname = \"ok\"\nurl = \"file:///a\"\nsovereignty = \"t2\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries[0].Sovereignty != "T2" {
+		t.Errorf("sovereignty = %q, want T2", entries[0].Sovereignty)
+	}
+}
+
+func TestMergePeerEntry(t *testing.T) {
+	existing := &storage.FederationPeer{
+		Name:             "up",
+		RemoteURL:        "dolthub://acme/x",
+		Username:         "bot",
+		Password:         "keep",
+		SSHKeyPath:       "/keys/a",
+		SSHKeyPassphrase: "pass",
+		Sovereignty:      "T1",
+	}
+
+	merged := mergePeerEntry(existing, peersFileEntry{Name: "up", URL: "dolthub://acme/x", User: "bot", SyncMode: "pull-only"}, "/keys/a")
+	if merged.Password != "keep" || merged.SSHKeyPassphrase != "pass" || merged.Sovereignty != "T1" {
+		t.Errorf("merge dropped existing settings: %+v", merged)
+	}
+	if merged.SyncMode != storage.SyncModePullOnly {
+		t.Errorf("sync mode = %q, want pull-only", merged.SyncMode)
+	}
+	if existing.SyncMode != "" {
+		t.Error("merge modified the existing record")
+	}
+
+	merged = mergePeerEntry(existing, peersFileEntry{Name: "up", URL: "dolthub://acme/x", User: "other"}, "/keys/b")
+	if merged.Password != "" || merged.SSHKeyPassphrase != "" {
+		t.Errorf("secrets should be dropped when user and key change: %+v", merged)
+	}
+	merged = mergePeerEntry(existing, peersFileEntry{Name: "up", URL: "dolthub://acme/x", CredentialSource: "env:UP_PW"}, "")
+	if merged.Password != "" || merged.CredentialSource != "env:UP_PW" {
+		t.Errorf("credential source should replace the stored password: %+v", merged)
+	}
+
+	if !samePeerSettings(existing, mergePeerEntry(existing, peersFileEntry{Name: "up", URL: "dolthub://acme/x"}, "")) {
+		t.Error("an entry with only the URL should leave the peer unchanged")
+	}
+	if peerNeedsRecord(mergePeerEntry(nil, peersFileEntry{Name: "plain", URL: "file:///p", SyncMode: "bidirectional"}, "")) {
+		t.Error("a bidirectional URL-only peer should import as a plain remote")
+	}
+}
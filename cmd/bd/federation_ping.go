@@ -0,0 +1,165 @@
+//go:build cgo
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var federationPingAll bool
+
+var federationPingCmd = &cobra.Command{
+	Use:   "ping [<peer> | --all]",
+	Short: "Check connectivity and authentication with federation peers",
+	Long: `Check that federation peers are reachable and accept this workspace's
+credentials, without merging or pushing anything.
+
+For each peer, fetches with the peer's stored credentials and reports:
+  - round-trip latency of the fetch
+  - the last commit this workspace shares with the peer
+  - on failure, whether the peer rejected the credentials (auth_failed),
+    could not be reached (unreachable), or failed for another reason (error)
+
+Exits non-zero if any peer is unhealthy.
+
+Examples:
+  bd federation ping town-beta    # Check one peer
+  bd federation ping --all        # Check every configured peer
+  bd federation ping --all --json # Structured results for monitoring`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runFederationPing,
+}
+
+func init() {
+	federationCmd.AddCommand(federationPingCmd)
+	federationPingCmd.Flags().BoolVar(&federationPingAll, "all", false, "Check every configured peer")
+}
+
+// federationPingJSON is the JSON shape of one peer health check.
+type federationPingJSON struct {
+	Peer             string                   `json:"peer"`
+	URL              string                   `json:"url"`
+	Status           storage.PeerHealthStatus `json:"status"`
+	UsedCredentials  bool                     `json:"used_credentials"`
+	LatencyMS        int64                    `json:"latency_ms"`
+	LastCommonCommit string                   `json:"last_common_commit,omitempty"`
+	Error            string                   `json:"error,omitempty"`
+}
+
+func formatFederationPingJSON(results []*storage.PeerHealth) []federationPingJSON {
+	out := make([]federationPingJSON, len(results))
+	for i, h := range results {
+		out[i] = federationPingJSON{
+			Peer:             h.Peer,
+			URL:              h.URL,
+			Status:           h.Status,
+			UsedCredentials:  h.UsedCredentials,
+			LatencyMS:        h.Latency.Milliseconds(),
+			LastCommonCommit: h.LastCommonCommit,
+		}
+		if h.Error != nil {
+			out[i].Error = h.Error.Error()
+		}
+	}
+	return out
+}
+
+func runFederationPing(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("federation ping is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("federation-ping")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	if federationPingAll == (len(args) == 1) {
+		return HandleErrorRespectJSON("specify a peer name or --all")
+	}
+
+	ctx := rootCtx
+
+	checker, ok := storage.UnwrapStore(store).(storage.PeerHealthChecker)
+	if !ok {
+		return HandleErrorRespectJSON("peer health checks are not supported by this storage backend")
+	}
+
+	var peers []string
+	if federationPingAll {
+		remotes, err := store.ListRemotes(ctx)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to list peers: %v", err)
+		}
+		for _, r := range remotes {
+			if r.Name != "origin" {
+				peers = append(peers, r.Name)
+			}
+		}
+		slices.Sort(peers)
+		if len(peers) == 0 {
+			return HandleErrorRespectJSON("no federation peers configured (use 'bd federation add-peer' to add peers)")
+		}
+	} else {
+		peers = args
+	}
+
+	results := make([]*storage.PeerHealth, 0, len(peers))
+	for _, peer := range peers {
+		health, err := checker.CheckPeer(ctx, peer)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to check peer %s: %v", peer, err)
+		}
+		results = append(results, health)
+	}
+
+	if jsonOutput {
+		if err := outputJSON(formatFederationPingJSON(results)); err != nil {
+			return err
+		}
+	} else {
+		for _, h := range results {
+			printPeerHealth(h)
+		}
+	}
+
+	for _, h := range results {
+		if !h.Healthy() {
+			return &exitError{Code: 1}
+		}
+	}
+	return nil
+}
+
+func printPeerHealth(h *storage.PeerHealth) {
+	fmt.Printf("%s  %s\n", ui.RenderAccent(h.Peer), ui.RenderMuted(h.URL))
+	latency := h.Latency.Round(time.Millisecond)
+	switch h.Status {
+	case storage.PeerHealthOK:
+		fmt.Printf("  %s Reachable (%s)\n", ui.RenderPass("✓"), latency)
+		if h.LastCommonCommit != "" {
+			fmt.Printf("  Last common commit: %s\n", h.LastCommonCommit)
+		} else {
+			fmt.Printf("  Last common commit: %s\n", ui.RenderMuted("none"))
+		}
+	case storage.PeerHealthAuthFailed:
+		fmt.Printf("  %s Authentication failed (%s): %v\n", ui.RenderFail("✗"), latency, h.Error)
+		if !h.UsedCredentials {
+			fmt.Printf("  No credentials were sent with the request\n")
+		}
+	case storage.PeerHealthUnreachable:
+		fmt.Printf("  %s Unreachable (%s): %v\n", ui.RenderFail("✗"), latency, h.Error)
+	default:
+		fmt.Printf("  %s Fetch failed (%s): %v\n", ui.RenderFail("✗"), latency, h.Error)
+	}
+}
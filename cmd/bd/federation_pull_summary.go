@@ -0,0 +1,214 @@
+//go:build cgo
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// pullGraphSummary describes how a federation pull changed the dependency
+// graph, and which of the pulled edges now hold up the local user's work.
+type pullGraphSummary struct {
+	AddedEdges   []*storage.DependencyDiffEntry `json:"added_edges"`
+	RemovedEdges []*storage.DependencyDiffEntry `json:"removed_edges"`
+	RetypedEdges []*storage.DependencyDiffEntry `json:"retyped_edges"`
+	NewBlockers  []blockingChain                `json:"new_blockers"`
+}
+
+func (s *pullGraphSummary) empty() bool {
+	return len(s.AddedEdges)+len(s.RemovedEdges)+len(s.RetypedEdges) == 0
+}
+
+// blockingChain is a path of open blockers from one of the user's issues to
+// an issue that blocks it through a pulled edge.
+type blockingChain struct {
+	Issue string   `json:"issue"`
+	Title string   `json:"title"`
+	Chain []string `json:"chain"` // Issue, then each issue blocking the one before it; the last hop was pulled
+}
+
+// depEdge identifies a dependency edge: IssueID depends on DependsOnID.
+type depEdge struct{ from, to string }
+
+// pullSummaryListLimit caps the edges printed per section of the text
+// summary; --json lists them all.
+const pullSummaryListLimit = 20
+
+// summarizePull diffs the dependency graph between the commits before and
+// after a pull and traces the pulled blocking edges to the open issues of
+// assignees. It returns nil if the store cannot diff the graph.
+func summarizePull(ctx context.Context, st storage.DoltStorage, from, to string, assignees []string) (*pullGraphSummary, error) {
+	gd, ok := storage.UnwrapStore(st).(storage.GraphDiffer)
+	if !ok {
+		return nil, nil
+	}
+	diff, err := gd.DiffDependencies(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff dependencies: %w", err)
+	}
+
+	summary := &pullGraphSummary{
+		AddedEdges:   []*storage.DependencyDiffEntry{},
+		RemovedEdges: []*storage.DependencyDiffEntry{},
+		RetypedEdges: []*storage.DependencyDiffEntry{},
+		NewBlockers:  []blockingChain{},
+	}
+	added := map[depEdge]bool{}
+	for _, d := range diff {
+		switch d.DiffType {
+		case "added":
+			summary.AddedEdges = append(summary.AddedEdges, d)
+		case "removed":
+			summary.RemovedEdges = append(summary.RemovedEdges, d)
+		default:
+			summary.RetypedEdges = append(summary.RetypedEdges, d)
+		}
+		if d.DiffType != "removed" && d.Type.IsBlockingEdge() && !d.OldType.IsBlockingEdge() {
+			added[depEdge{d.IssueID, d.DependsOnID}] = true
+		}
+	}
+	for _, list := range [][]*storage.DependencyDiffEntry{summary.AddedEdges, summary.RemovedEdges, summary.RetypedEdges} {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].IssueID != list[j].IssueID {
+				return list[i].IssueID < list[j].IssueID
+			}
+			return list[i].DependsOnID < list[j].DependsOnID
+		})
+	}
+	if len(added) == 0 {
+		return summary, nil
+	}
+
+	var mine []*types.Issue
+	for _, who := range assignees {
+		issues, err := st.SearchIssues(ctx, "", types.IssueFilter{Assignee: &who, ExcludeStatus: []types.Status{types.StatusClosed}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list assigned issues: %w", err)
+		}
+		mine = append(mine, issues...)
+	}
+	if len(mine) == 0 {
+		return summary, nil
+	}
+	deps, err := st.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+
+	// Only open blockers hold work up; look up every issue the user's work
+	// could be waiting on.
+	var start []string
+	for _, issue := range mine {
+		start = append(start, issue.ID)
+	}
+	blockers, err := st.GetIssuesByIDs(ctx, blockerClosure(start, deps))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load blockers: %w", err)
+	}
+	open := map[string]bool{}
+	for _, issue := range append(blockers, mine...) {
+		open[issue.ID] = issue.Status != types.StatusClosed
+	}
+
+	summary.NewBlockers = findNewBlockingChains(mine, deps, open, added)
+	return summary, nil
+}
+
+// blockerClosure returns every issue reachable from start through blocking
+// edges, excluding start itself.
+func blockerClosure(start []string, deps map[string][]*types.Dependency) []string {
+	seen := map[string]bool{}
+	for _, id := range start {
+		seen[id] = true
+	}
+	queue := append([]string(nil), start...)
+	var found []string
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, d := range deps[id] {
+			if !d.Type.IsBlockingEdge() || seen[d.DependsOnID] {
+				continue
+			}
+			seen[d.DependsOnID] = true
+			found = append(found, d.DependsOnID)
+			queue = append(queue, d.DependsOnID)
+		}
+	}
+	return found
+}
+
+// findNewBlockingChains walks the open blockers of each of mine, breadth
+// first, and returns the shortest chain to every pulled edge it crosses.
+func findNewBlockingChains(mine []*types.Issue, deps map[string][]*types.Dependency, open map[string]bool, added map[depEdge]bool) []blockingChain {
+	mine = append([]*types.Issue(nil), mine...)
+	sort.Slice(mine, func(i, j int) bool { return mine[i].ID < mine[j].ID })
+
+	chains := []blockingChain{}
+	for _, issue := range mine {
+		parent := map[string]string{issue.ID: ""}
+		queue := []string{issue.ID}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			edges := append([]*types.Dependency(nil), deps[id]...)
+			sort.Slice(edges, func(i, j int) bool { return edges[i].DependsOnID < edges[j].DependsOnID })
+			for _, d := range edges {
+				if !d.Type.IsBlockingEdge() || !open[d.DependsOnID] {
+					continue
+				}
+				if added[depEdge{id, d.DependsOnID}] {
+					chain := []string{d.DependsOnID}
+					for at := id; at != ""; at = parent[at] {
+						chain = append([]string{at}, chain...)
+					}
+					chains = append(chains, blockingChain{Issue: issue.ID, Title: issue.Title, Chain: chain})
+				}
+				if _, seen := parent[d.DependsOnID]; !seen {
+					parent[d.DependsOnID] = id
+					queue = append(queue, d.DependsOnID)
+				}
+			}
+		}
+	}
+	return chains
+}
+
+func printPullGraphSummary(s *pullGraphSummary) {
+	if s.empty() {
+		return
+	}
+	fmt.Printf("  %s Dependency changes: %d added, %d removed, %d retyped\n",
+		ui.RenderAccent("⇄"), len(s.AddedEdges), len(s.RemovedEdges), len(s.RetypedEdges))
+	section := func(mark string, list []*storage.DependencyDiffEntry) {
+		for i, d := range list {
+			if i == pullSummaryListLimit {
+				fmt.Printf("      %s\n", ui.RenderMuted(fmt.Sprintf("... and %d more", len(list)-i)))
+				return
+			}
+			if d.OldType != "" {
+				fmt.Printf("      %s %s → %s (%s → %s)\n", mark, d.IssueID, d.DependsOnID, d.OldType, d.Type)
+			} else {
+				fmt.Printf("      %s %s → %s (%s)\n", mark, d.IssueID, d.DependsOnID, d.Type)
+			}
+		}
+	}
+	section(ui.RenderPass("+"), s.AddedEdges)
+	section(ui.RenderFail("-"), s.RemovedEdges)
+	section(ui.RenderAccent("~"), s.RetypedEdges)
+
+	if len(s.NewBlockers) == 0 {
+		return
+	}
+	fmt.Printf("  %s Newly blocking your work:\n", ui.RenderWarn("⚠"))
+	for _, c := range s.NewBlockers {
+		fmt.Printf("      %s ← %s\n", formatFeedbackID(c.Issue, c.Title), strings.Join(c.Chain[1:], " ← "))
+	}
+}
@@ -0,0 +1,46 @@
+//go:build cgo
+
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFindNewBlockingChains(t *testing.T) {
+	dep := func(from, to string, typ types.DependencyType) *types.Dependency {
+		return &types.Dependency{IssueID: from, DependsOnID: to, Type: typ}
+	}
+	// mine-1 waits on a, which now waits on the pulled blocker b; b's own
+	// pulled blocker c is closed. mine-2 gained a related edge, which does
+	// not block.
+	deps := map[string][]*types.Dependency{
+		"mine-1": {dep("mine-1", "a", types.DepBlocks)},
+		"a":      {dep("a", "b", types.DepBlocks)},
+		"b":      {dep("b", "c", types.DepBlocks)},
+		"mine-2": {dep("mine-2", "d", types.DepRelated)},
+	}
+	open := map[string]bool{"mine-1": true, "mine-2": true, "a": true, "b": true, "c": false, "d": true}
+	added := map[depEdge]bool{{"a", "b"}: true, {"b", "c"}: true, {"mine-2", "d"}: true}
+	mine := []*types.Issue{{ID: "mine-2", Title: "Two"}, {ID: "mine-1", Title: "One"}}
+
+	if got := blockerClosure([]string{"mine-1", "mine-2"}, deps); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("blockerClosure = %v, want [a b c]", got)
+	}
+
+	chains := findNewBlockingChains(mine, deps, open, added)
+	if len(chains) != 1 {
+		t.Fatalf("chains = %+v, want one", chains)
+	}
+	if c := chains[0]; c.Issue != "mine-1" || c.Title != "One" || !slices.Equal(c.Chain, []string{"mine-1", "a", "b"}) {
+		t.Errorf("chain = %+v, want mine-1 ← a ← b", c)
+	}
+
+	// A cycle in the blocking graph must not loop forever.
+	deps["b"] = []*types.Dependency{dep("b", "a", types.DepBlocks)}
+	if chains := findNewBlockingChains(mine, deps, open, added); len(chains) != 1 {
+		t.Errorf("chains with a cycle = %+v, want one", chains)
+	}
+}
@@ -0,0 +1,101 @@
+//go:build cgo
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var federationRotateKeyDryRun bool
+
+var federationRotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key [--dry-run]",
+	Short: "Rotate the key that encrypts stored peer credentials",
+	Long: `Generate a new credential key and re-encrypt every stored peer password
+and SSH key passphrase with it.
+
+The secrets are re-encrypted in a single transaction, so a failure leaves
+the old key and credentials in place. Once the transaction commits, the old
+key file (.beads/.beads-credential-key) is overwritten with random bytes and
+replaced by the new key.
+
+Rotating does not change the credentials themselves; peers keep accepting
+the same passwords. Earlier Dolt commits still hold secrets encrypted with
+the old key, which is unrecoverable once shredded.
+
+Examples:
+  bd federation rotate-key --dry-run  # Check every secret decrypts; change nothing
+  bd federation rotate-key            # Rotate the key`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runFederationRotateKey,
+}
+
+func init() {
+	federationCmd.AddCommand(federationRotateKeyCmd)
+	federationRotateKeyCmd.Flags().BoolVar(&federationRotateKeyDryRun, "dry-run", false, "Check that every stored secret decrypts without rotating")
+}
+
+func runFederationRotateKey(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("federation rotate-key is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("federation-rotate-key")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	ctx := rootCtx
+
+	rotator, ok := storage.UnwrapStore(store).(storage.CredentialKeyRotator)
+	if !ok {
+		return HandleErrorRespectJSON("credential key rotation is not supported by this storage backend")
+	}
+
+	opts := storage.KeyRotationOptions{DryRun: federationRotateKeyDryRun}
+	if !jsonOutput {
+		verb := "Re-encrypted"
+		if opts.DryRun {
+			verb = "Verified"
+		}
+		opts.Progress = func(peer string) {
+			fmt.Printf("  %s %s credentials for %s\n", ui.RenderPass("✓"), verb, peer)
+		}
+	}
+
+	result, err := rotator.RotateCredentialKey(ctx, opts)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to rotate credential key: %v", err)
+	}
+	if !result.DryRun {
+		commandDidWrite.Store(true)
+	}
+
+	if jsonOutput {
+		peers := result.Peers
+		if peers == nil {
+			peers = []string{}
+		}
+		return outputJSON(map[string]interface{}{
+			"key_path": result.KeyPath,
+			"peers":    peers,
+			"dry_run":  result.DryRun,
+		})
+	}
+
+	if result.DryRun {
+		fmt.Printf("Dry run: %d peer(s) would be re-encrypted; key file %s unchanged\n", len(result.Peers), result.KeyPath)
+		return nil
+	}
+	fmt.Printf("%s Rotated credential key (%d peer(s) re-encrypted)\n", ui.RenderPass("✓"), len(result.Peers))
+	fmt.Printf("  Old key shredded; new key at %s\n", result.KeyPath)
+	return nil
+}
@@ -0,0 +1,195 @@
+//go:build cgo
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// syncPreviewJSON is the JSON shape of one peer's sync --dry-run preview.
+type syncPreviewJSON struct {
+	Peer              string               `json:"peer"`
+	Branch            string               `json:"branch,omitempty"`
+	SyncMode          storage.SyncMode     `json:"sync_mode,omitempty"`
+	MergeBase         string               `json:"merge_base,omitempty"`
+	PeerBranchMissing bool                 `json:"peer_branch_missing,omitempty"`
+	Uncommitted       []string             `json:"uncommitted,omitempty"`
+	Push              *syncPreviewSideJSON `json:"push,omitempty"`
+	Pull              *syncPreviewSideJSON `json:"pull,omitempty"`
+	Error             string               `json:"error,omitempty"`
+}
+
+// syncPreviewSideJSON is the JSON shape of one direction of a preview.
+type syncPreviewSideJSON struct {
+	CommitCount int                     `json:"commit_count"`
+	Commits     []syncPreviewCommitJSON `json:"commits"`
+	Tables      []syncPreviewTableJSON  `json:"tables"`
+	Issues      []syncPreviewRowJSON    `json:"issues"`
+}
+
+type syncPreviewCommitJSON struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message"`
+}
+
+type syncPreviewTableJSON struct {
+	Table    string `json:"table"`
+	Added    int    `json:"added"`
+	Modified int    `json:"modified"`
+	Removed  int    `json:"removed"`
+}
+
+type syncPreviewRowJSON struct {
+	ID     string `json:"id"`
+	Change string `json:"change"`
+	Title  string `json:"title"`
+}
+
+func formatSyncPreviewJSON(p *storage.SyncPreview, uncommitted []string) syncPreviewJSON {
+	out := syncPreviewJSON{
+		Peer:              p.Peer,
+		Branch:            p.Branch,
+		SyncMode:          p.SyncMode,
+		MergeBase:         p.MergeBase,
+		PeerBranchMissing: p.PeerBranchMissing,
+		Uncommitted:       uncommitted,
+	}
+	if p.SyncMode.AllowsPush() {
+		out.Push = formatSyncPreviewSideJSON(p.Push)
+	}
+	if p.SyncMode.AllowsPull() {
+		out.Pull = formatSyncPreviewSideJSON(p.Pull)
+	}
+	return out
+}
+
+func formatSyncPreviewSideJSON(side storage.SyncPreviewSide) *syncPreviewSideJSON {
+	out := &syncPreviewSideJSON{
+		CommitCount: side.CommitCount,
+		Commits:     make([]syncPreviewCommitJSON, len(side.Commits)),
+		Tables:      make([]syncPreviewTableJSON, len(side.Tables)),
+		Issues:      make([]syncPreviewRowJSON, len(side.Issues)),
+	}
+	for i, c := range side.Commits {
+		out.Commits[i] = syncPreviewCommitJSON{Hash: c.Hash, Author: c.Author, Date: c.Date, Message: c.Message}
+	}
+	for i, t := range side.Tables {
+		out.Tables[i] = syncPreviewTableJSON(t)
+	}
+	for i, r := range side.Issues {
+		out.Issues[i] = syncPreviewRowJSON{ID: r.IssueID, Change: r.DiffType, Title: r.Title}
+	}
+	return out
+}
+
+// runFederationSyncPreview reports what syncing with each peer would push
+// and pull. Only the peers' remote-tracking refs change.
+func runFederationSyncPreview(ctx context.Context, ds storage.DoltStorage, peers []string) error {
+	previewer, ok := storage.UnwrapStore(ds).(storage.SyncPreviewer)
+	if !ok {
+		return HandleErrorRespectJSON("sync previews are not supported by this storage backend")
+	}
+
+	// Sync commits pending changes before it pulls, so they would be pushed
+	// along with the commits listed below.
+	var uncommitted []string
+	if status, err := ds.Status(ctx); err == nil {
+		for _, e := range append(status.Staged, status.Unstaged...) {
+			uncommitted = append(uncommitted, e.Table)
+		}
+	}
+
+	results := make([]syncPreviewJSON, 0, len(peers))
+	for _, peer := range peers {
+		p, err := previewer.PreviewSync(ctx, peer)
+		if err != nil {
+			results = append(results, syncPreviewJSON{Peer: peer, Error: err.Error()})
+			if !jsonOutput {
+				fmt.Printf("%s %s\n  %s %v\n", ui.RenderAccent("🔍"), peer, ui.RenderFail("✗"), err)
+			}
+			continue
+		}
+		results = append(results, formatSyncPreviewJSON(p, uncommitted))
+		if !jsonOutput {
+			printSyncPreview(p, uncommitted)
+		}
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"dry_run": true,
+			"peers":   results,
+		})
+	}
+	return nil
+}
+
+// syncPreviewTextCommits caps the commits listed per direction in text
+// output; --json lists up to storage.SyncPreviewLimit.
+const syncPreviewTextCommits = 10
+
+func printSyncPreview(p *storage.SyncPreview, uncommitted []string) {
+	fmt.Printf("%s %s (dry run)\n", ui.RenderAccent("🔍"), p.Peer)
+	if p.PeerBranchMissing {
+		fmt.Printf("  %s Peer has no %s branch yet\n", ui.RenderMuted("○"), p.Branch)
+	}
+
+	if p.SyncMode.AllowsPull() {
+		printSyncPreviewSide("Would pull", p.Pull)
+	} else {
+		fmt.Printf("  %s Pull skipped (%s peer)\n", ui.RenderMuted("○"), p.SyncMode)
+	}
+	if p.SyncMode.AllowsPush() {
+		printSyncPreviewSide("Would push", p.Push)
+		if len(uncommitted) > 0 {
+			fmt.Printf("  %s Uncommitted changes in %d table(s) would be committed and pushed too\n",
+				ui.RenderWarn("⚠"), len(uncommitted))
+		}
+	} else {
+		fmt.Printf("  %s Push skipped (%s peer)\n", ui.RenderMuted("○"), p.SyncMode)
+	}
+}
+
+func printSyncPreviewSide(label string, side storage.SyncPreviewSide) {
+	if side.CommitCount == 0 {
+		fmt.Printf("  %s %s: nothing\n", ui.RenderPass("✓"), label)
+		return
+	}
+	fmt.Printf("  %s %s: %d commit(s)\n", ui.RenderWarn("→"), label, side.CommitCount)
+	commits := side.Commits
+	if len(commits) > syncPreviewTextCommits {
+		commits = commits[:syncPreviewTextCommits]
+	}
+	for _, c := range commits {
+		fmt.Printf("    %s %s %s\n", ui.RenderMuted(truncateHash(c.Hash)), strings.SplitN(c.Message, "\n", 2)[0], ui.RenderMuted("("+c.Author+")"))
+	}
+	if more := side.CommitCount - len(commits); more > 0 {
+		fmt.Printf("    %s\n", ui.RenderMuted(fmt.Sprintf("... and %d more", more)))
+	}
+	for _, t := range side.Tables {
+		fmt.Printf("    %s: +%d ~%d -%d rows\n", t.Table, t.Added, t.Modified, t.Removed)
+	}
+	for _, r := range side.Issues {
+		fmt.Printf("      %s %s %s\n", diffTypeMarker(r.DiffType), r.IssueID, r.Title)
+	}
+}
+
+// diffTypeMarker abbreviates a dolt_diff diff_type.
+func diffTypeMarker(diffType string) string {
+	switch diffType {
+	case "added":
+		return "+"
+	case "removed":
+		return "-"
+	default:
+		return "~"
+	}
+}
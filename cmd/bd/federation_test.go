@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReadSSHPassphraseFromStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	oldStdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = oldStdin })
+
+	go func() {
+		_, _ = w.WriteString("s3cret pass\r\nignored\n")
+		_ = w.Close()
+	}()
+
+	got, err := readSSHPassphrase()
+	if err != nil {
+		t.Fatalf("readSSHPassphrase: %v", err)
+	}
+	if got != "s3cret pass" {
+		t.Errorf("passphrase = %q, want %q", got, "s3cret pass")
+	}
+}
+
+func TestSSHPassphraseFlagWithoutValue(t *testing.T) {
+	flag := federationAddPeerCmd.Flags().Lookup("ssh-passphrase")
+	if flag == nil || flag.NoOptDefVal != sshPassphraseFromInput {
+		t.Fatalf("--ssh-passphrase without a value should read the passphrase from input, got %+v", flag)
+	}
+}
@@ -0,0 +1,196 @@
+//go:build cgo
+
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var federationVerifyAll bool
+
+// federationVerifyListLimit caps the differing issues listed per peer in
+// text output; --json lists them all.
+const federationVerifyListLimit = 50
+
+var federationVerifyCmd = &cobra.Command{
+	Use:   "verify [<peer> | --all]",
+	Short: "Check that federation peers hold the same issues as this workspace",
+	Long: `Detect silent divergence between this workspace and federation peers
+without diffing the databases.
+
+For each peer, fetches with the peer's stored credentials (which only
+refreshes the peer's remote-tracking ref) and compares Merkle digests of
+the issues committed on the current branch here and on the peer's copy of
+it. Each issue's digest covers every column of its row plus its labels and
+dependencies; issues are grouped into 256 buckets by ID, and only buckets
+whose digests differ are searched for the issues that differ:
+
+  only_local   the peer lacks the issue
+  only_peer    this workspace lacks the issue
+  changed      both have it, with different fields, labels, or dependencies
+
+The root digest identifies an issue set: two workspaces whose roots match
+hold identical issues. Uncommitted changes are not included; run
+'bd commit' first if batch mode has writes pending. Nothing is merged,
+committed, or pushed. Use 'bd federation sync --dry-run' to see the commits
+behind a difference.
+
+Exits non-zero if any peer differs or cannot be verified.
+
+Examples:
+  bd federation verify town-beta      # Compare with one peer
+  bd federation verify --all          # Compare with every configured peer
+  bd federation verify --all --json   # Structured results for monitoring`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runFederationVerify,
+}
+
+func init() {
+	federationCmd.AddCommand(federationVerifyCmd)
+	federationVerifyCmd.Flags().BoolVar(&federationVerifyAll, "all", false, "Verify every configured peer")
+}
+
+// federationVerifyJSON is the JSON shape of one peer verification.
+type federationVerifyJSON struct {
+	Peer              string                     `json:"peer"`
+	Branch            string                     `json:"branch,omitempty"`
+	Consistent        bool                       `json:"consistent"`
+	LocalRoot         string                     `json:"local_root,omitempty"`
+	PeerRoot          string                     `json:"peer_root,omitempty"`
+	LocalIssues       int                        `json:"local_issues"`
+	PeerIssues        int                        `json:"peer_issues"`
+	PeerBranchMissing bool                       `json:"peer_branch_missing,omitempty"`
+	DivergentBuckets  int                        `json:"divergent_buckets"`
+	Differences       []federationVerifyDiffJSON `json:"differences"`
+	Error             string                     `json:"error,omitempty"`
+}
+
+type federationVerifyDiffJSON struct {
+	ID   string                      `json:"id"`
+	Kind storage.IssueDifferenceKind `json:"kind"`
+}
+
+func formatFederationVerifyJSON(v *storage.PeerVerification) federationVerifyJSON {
+	out := federationVerifyJSON{
+		Peer:              v.Peer,
+		Branch:            v.Branch,
+		Consistent:        v.Consistent(),
+		LocalRoot:         v.LocalRoot,
+		PeerRoot:          v.PeerRoot,
+		LocalIssues:       v.LocalIssues,
+		PeerIssues:        v.PeerIssues,
+		PeerBranchMissing: v.PeerBranchMissing,
+		DivergentBuckets:  v.DivergentBuckets,
+		Differences:       make([]federationVerifyDiffJSON, len(v.Differences)),
+	}
+	for i, d := range v.Differences {
+		out.Differences[i] = federationVerifyDiffJSON{ID: d.IssueID, Kind: d.Kind}
+	}
+	return out
+}
+
+func runFederationVerify(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("federation verify is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("federation-verify")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	if federationVerifyAll == (len(args) == 1) {
+		return HandleErrorRespectJSON("specify a peer name or --all")
+	}
+
+	ctx := rootCtx
+
+	verifier, ok := storage.UnwrapStore(store).(storage.PeerVerifier)
+	if !ok {
+		return HandleErrorRespectJSON("peer verification is not supported by this storage backend")
+	}
+
+	var peers []string
+	if federationVerifyAll {
+		remotes, err := store.ListRemotes(ctx)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to list peers: %v", err)
+		}
+		for _, r := range remotes {
+			if r.Name != "origin" {
+				peers = append(peers, r.Name)
+			}
+		}
+		slices.Sort(peers)
+		if len(peers) == 0 {
+			return HandleErrorRespectJSON("no federation peers configured (use 'bd federation add-peer' to add peers)")
+		}
+	} else {
+		peers = args
+	}
+
+	results := make([]federationVerifyJSON, 0, len(peers))
+	failed := false
+	for i, peer := range peers {
+		if i > 0 && !jsonOutput {
+			fmt.Println()
+		}
+		v, err := verifier.VerifyPeer(ctx, peer)
+		if err != nil {
+			failed = true
+			results = append(results, federationVerifyJSON{Peer: peer, Differences: []federationVerifyDiffJSON{}, Error: err.Error()})
+			if !jsonOutput {
+				fmt.Printf("%s\n  %s Verification failed: %v\n", ui.RenderAccent(peer), ui.RenderFail("✗"), err)
+			}
+			continue
+		}
+		if !v.Consistent() {
+			failed = true
+		}
+		results = append(results, formatFederationVerifyJSON(v))
+		if !jsonOutput {
+			printPeerVerification(v)
+		}
+	}
+
+	if jsonOutput {
+		if err := outputJSON(results); err != nil {
+			return err
+		}
+	}
+	if failed {
+		return &exitError{Code: 1}
+	}
+	return nil
+}
+
+func printPeerVerification(v *storage.PeerVerification) {
+	fmt.Printf("%s  %s\n", ui.RenderAccent(v.Peer), ui.RenderMuted(v.Branch))
+	fmt.Printf("  Local root: %s (%d issues)\n", v.LocalRoot, v.LocalIssues)
+	if v.PeerBranchMissing {
+		fmt.Printf("  %s Peer has no copy of %s to compare (push it with 'bd federation sync')\n", ui.RenderWarn("!"), v.Branch)
+		return
+	}
+	fmt.Printf("  Peer root:  %s (%d issues)\n", v.PeerRoot, v.PeerIssues)
+	if v.Consistent() {
+		fmt.Printf("  %s Consistent\n", ui.RenderPass("✓"))
+		return
+	}
+	fmt.Printf("  %s Diverged: %d issues differ in %d of 256 buckets\n", ui.RenderFail("✗"), len(v.Differences), v.DivergentBuckets)
+	for i, d := range v.Differences {
+		if i == federationVerifyListLimit {
+			fmt.Printf("    ... and %d more (use --json for all)\n", len(v.Differences)-i)
+			break
+		}
+		fmt.Printf("    %-10s  %s\n", d.Kind, d.IssueID)
+	}
+}
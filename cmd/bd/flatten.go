@@ -43,7 +43,7 @@ Examples:
   bd flatten --force --json          # JSON output`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
-	RunE: func(_ *cobra.Command, _ []string) error {
+	RunE: func(cmd *cobra.Command, _ []string) error {
 		if usesProxiedServer() {
 			return HandleErrorRespectJSON("flatten is not supported in proxied-server mode")
 		}
@@ -124,6 +124,9 @@ Examples:
 				fmt.Sprintf("would squash %d commits into 1 (irreversible)", commitCount),
 				"Use --force to confirm or --dry-run to preview.")
 		}
+		if err := requireConfirmation(cmd, confirmOpHistory, "flatten history", []string{"flatten"}); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
 
 		if !jsonOutput {
 			fmt.Printf("Flattening %d commits...\n", commitCount)
@@ -175,6 +178,7 @@ Examples:
 func init() {
 	flattenCmd.Flags().BoolVar(&flattenDryRun, "dry-run", false, "Preview without making changes")
 	flattenCmd.Flags().BoolVarP(&flattenForce, "force", "f", false, "Confirm irreversible history squash")
+	addConfirmFlags(flattenCmd)
 
 	rootCmd.AddCommand(flattenCmd)
 }
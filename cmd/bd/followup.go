@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Variables bd fills in when a follow-up fires, so templates can refer back
+// to the issue that triggered them.
+const (
+	followupVarSourceID    = "source_id"
+	followupVarSourceTitle = "source_title"
+)
+
+var followupCmd = &cobra.Command{
+	Use:     "followup",
+	GroupID: "issues",
+	Short:   "Create follow-up work automatically when an issue closes",
+	Long: `Attach follow-up rules to an issue. When the issue closes, each rule
+instantiates its template (a formula name or proto ID, as for bd mol pour)
+and links the new work back with a discovered-from dependency.
+
+With --after, the follow-up is deferred until that long after the close, so
+it stays out of bd ready until it is due. Templates can use {{source_id}}
+and {{source_title}} to refer to the issue that closed.
+
+Each rule fires once. Rules fire on bd close; they are not evaluated in
+proxied-server mode.
+
+Examples:
+  bd followup add bd-abc --on close --template post-deploy-check --after 2d
+  bd followup add bd-abc --on close --template mol-retro --var team=infra
+  bd followup list bd-abc
+  bd followup remove bd-abc 1`,
+}
+
+var followupAddCmd = &cobra.Command{
+	Use:           "add <id>",
+	Short:         "Add a follow-up rule to an issue",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("followup add is not supported in proxied-server mode")
+		}
+		CheckReadonly("followup add")
+		evt := metrics.NewCommandEvent("followup add")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+
+		on, _ := cmd.Flags().GetString("on")
+		template, _ := cmd.Flags().GetString("template")
+		after, _ := cmd.Flags().GetString("after")
+		varFlags, _ := cmd.Flags().GetStringArray("var")
+		if on != types.FollowupOnClose {
+			return HandleErrorRespectJSON("invalid --on %q (valid: %s)", on, types.FollowupOnClose)
+		}
+		if template == "" {
+			return HandleErrorRespectJSON("--template is required")
+		}
+		if after != "" {
+			if strings.HasPrefix(after, "-") {
+				return HandleErrorRespectJSON("--after must not be negative")
+			}
+			if _, err := timeparsing.ParseCompactDuration(after, time.Now()); err != nil {
+				return HandleErrorRespectJSON("invalid --after %q (use e.g. 6h, 2d, 1w)", after)
+			}
+		}
+		vars := make(map[string]string)
+		for _, v := range varFlags {
+			key, value, ok := strings.Cut(v, "=")
+			if !ok {
+				return HandleErrorRespectJSON("invalid variable format '%s', expected 'key=value'", v)
+			}
+			vars[key] = value
+		}
+
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			return HandleErrorRespectJSON("issue %s not found: %v", id, err)
+		}
+		// Resolve the template now so a typo or a missing variable fails
+		// here rather than silently at close time.
+		if _, _, err := loadFollowupTemplate(ctx, store, template, followupVars(issue, vars)); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		rules, err := types.FollowupsFromMetadata(issue.Metadata)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		rule := types.FollowupRule{
+			On:       on,
+			Template: template,
+			After:    after,
+			AddedBy:  actor,
+			AddedAt:  time.Now().UTC(),
+		}
+		if len(vars) > 0 {
+			rule.Vars = vars
+		}
+		rules = append(rules, rule)
+		if err := saveFollowups(ctx, store, id, rules); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(id)
+
+		if jsonOutput {
+			return outputJSON(rules)
+		}
+		when := "on close"
+		if after != "" {
+			when += ", due " + after + " later"
+		}
+		fmt.Printf("%s Follow-up %d on %s: %s (%s)\n", ui.RenderPass("✓"), len(rules), formatFeedbackID(id, issue.Title), template, when)
+		return nil
+	},
+}
+
+var followupListCmd = &cobra.Command{
+	Use:           "list <id>",
+	Short:         "List an issue's follow-up rules",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("followup list is not supported in proxied-server mode")
+		}
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			return HandleErrorRespectJSON("issue %s not found: %v", id, err)
+		}
+		rules, err := types.FollowupsFromMetadata(issue.Metadata)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			if rules == nil {
+				rules = []types.FollowupRule{}
+			}
+			return outputJSON(rules)
+		}
+		if len(rules) == 0 {
+			fmt.Printf("No follow-up rules on %s\n", id)
+			return nil
+		}
+		fmt.Printf("Follow-ups on %s:\n", formatFeedbackID(id, issue.Title))
+		for i, r := range rules {
+			line := fmt.Sprintf("  %d. on %s → %s", i+1, r.On, r.Template)
+			if r.After != "" {
+				line += " (after " + r.After + ")"
+			}
+			if r.Fired() {
+				fmt.Println(ui.RenderMuted(line + fmt.Sprintf(" — created %s", r.CreatedID)))
+				continue
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+var followupRemoveCmd = &cobra.Command{
+	Use:           "remove <id> <n>",
+	Short:         "Remove a follow-up rule by its number in bd followup list",
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("followup remove is not supported in proxied-server mode")
+		}
+		CheckReadonly("followup remove")
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			return HandleErrorRespectJSON("issue %s not found: %v", id, err)
+		}
+		rules, err := types.FollowupsFromMetadata(issue.Metadata)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 || n > len(rules) {
+			return HandleErrorRespectJSON("no follow-up %s on %s (it has %d)", args[1], id, len(rules))
+		}
+		rules = append(rules[:n-1], rules[n:]...)
+		if err := saveFollowups(ctx, store, id, rules); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(rules)
+		}
+		fmt.Printf("%s Removed follow-up %d from %s\n", ui.RenderPass("✓"), n, id)
+		return nil
+	},
+}
+
+func saveFollowups(ctx context.Context, st storage.DoltStorage, id string, rules []types.FollowupRule) error {
+	if rules == nil {
+		rules = []types.FollowupRule{}
+	}
+	raw, err := json.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("encoding follow-ups: %w", err)
+	}
+	if err := st.MergeMetadata(ctx, id, types.FollowupsMetadataKey, raw, actor); err != nil {
+		return fmt.Errorf("saving follow-ups of %s: %w", id, err)
+	}
+	return nil
+}
+
+// followupVars is a rule's variables plus the ones describing the source
+// issue.
+func followupVars(source *types.Issue, vars map[string]string) map[string]string {
+	out := map[string]string{
+		followupVarSourceID:    source.ID,
+		followupVarSourceTitle: source.Title,
+	}
+	for k, v := range vars {
+		out[k] = v
+	}
+	return out
+}
+
+// loadFollowupTemplate resolves a template the way bd mol pour does — a
+// formula name first, then a proto ID — and applies variable defaults. It
+// fails when a required variable has no value.
+func loadFollowupTemplate(ctx context.Context, st storage.DoltStorage, name string, vars map[string]string) (*TemplateSubgraph, map[string]string, error) {
+	subgraph, err := resolveAndCookFormulaWithVars(name, nil, vars)
+	if err != nil {
+		protoID, rerr := utils.ResolvePartialID(ctx, st, name)
+		if rerr != nil {
+			return nil, nil, fmt.Errorf("template %s not found as formula or proto ID", name)
+		}
+		proto, gerr := st.GetIssue(ctx, protoID)
+		if gerr != nil {
+			return nil, nil, fmt.Errorf("loading proto %s: %w", protoID, gerr)
+		}
+		if !isProto(proto) {
+			return nil, nil, fmt.Errorf("%s is not a proto (missing '%s' label)", protoID, MoleculeLabel)
+		}
+		if subgraph, err = loadTemplateSubgraph(ctx, st, protoID); err != nil {
+			return nil, nil, fmt.Errorf("loading proto: %w", err)
+		}
+	}
+	vars = applyVariableDefaults(vars, subgraph)
+	var missing []string
+	for _, v := range extractRequiredVariables(subgraph) {
+		if _, ok := vars[v]; !ok {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("template %s needs variables: %s (pass --var %s=<value>)", name, strings.Join(missing, ", "), missing[0])
+	}
+	return subgraph, vars, nil
+}
+
+// fireFollowupsOnClose instantiates the close-triggered follow-ups of an
+// issue that was just closed and returns the IDs of the work it created.
+// Failures are warnings: the close itself has already happened, and an
+// unfired rule is retried on the next close.
+func fireFollowupsOnClose(ctx context.Context, st storage.DoltStorage, issue *types.Issue, closedAt time.Time) []string {
+	if issue == nil {
+		return nil
+	}
+	rules, err := types.FollowupsFromMetadata(issue.Metadata)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s follow-ups of %s: %v\n", ui.RenderWarn("⚠"), issue.ID, err)
+		return nil
+	}
+	var created []string
+	for i := range rules {
+		r := &rules[i]
+		if r.On != types.FollowupOnClose || r.Fired() {
+			continue
+		}
+		rootID, err := createFollowup(ctx, st, issue, r, closedAt)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s follow-up %s of %s: %v\n", ui.RenderWarn("⚠"), r.Template, issue.ID, err)
+			continue
+		}
+		firedAt := closedAt.UTC()
+		r.FiredAt = &firedAt
+		r.CreatedID = rootID
+		created = append(created, rootID)
+	}
+	if len(created) > 0 {
+		if err := saveFollowups(ctx, st, issue.ID, rules); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", ui.RenderWarn("⚠"), err)
+		}
+	}
+	return created
+}
+
+func createFollowup(ctx context.Context, st storage.DoltStorage, source *types.Issue, r *types.FollowupRule, closedAt time.Time) (string, error) {
+	subgraph, vars, err := loadFollowupTemplate(ctx, st, r.Template, followupVars(source, r.Vars))
+	if err != nil {
+		return "", err
+	}
+	var due time.Time
+	if r.After != "" {
+		if due, err = timeparsing.ParseCompactDuration(r.After, closedAt); err != nil {
+			return "", fmt.Errorf("invalid after %q: %w", r.After, err)
+		}
+	}
+	result, err := spawnMoleculeWithOptions(ctx, st, subgraph, CloneOptions{
+		Vars:          vars,
+		Actor:         actor,
+		AttachToID:    source.ID,
+		AttachDepType: types.DepDiscoveredFrom,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !due.IsZero() {
+		// The work exists either way; report a failed deferral rather than
+		// leaving the rule unfired and creating it twice.
+		if err := st.UpdateIssue(ctx, result.NewEpicID, map[string]interface{}{"defer_until": due}, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "%s deferring follow-up %s: %v\n", ui.RenderWarn("⚠"), result.NewEpicID, err)
+		}
+	}
+	return result.NewEpicID, nil
+}
+
+func init() {
+	followupAddCmd.Flags().String("on", types.FollowupOnClose, "Trigger for the follow-up (close)")
+	followupAddCmd.Flags().String("template", "", "Formula name or proto ID to instantiate (required)")
+	followupAddCmd.Flags().String("after", "", "Defer the follow-up this long after the trigger (e.g. 6h, 2d, 1w)")
+	followupAddCmd.Flags().StringArray("var", []string{}, "Template variable (key=value)")
+
+	followupAddCmd.ValidArgsFunction = issueIDCompletion
+	followupListCmd.ValidArgsFunction = issueIDCompletion
+	followupRemoveCmd.ValidArgsFunction = issueIDCompletion
+	followupCmd.AddCommand(followupAddCmd, followupListCmd, followupRemoveCmd)
+	rootCmd.AddCommand(followupCmd)
+}
@@ -0,0 +1,492 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+const (
+	ganttDateLayout       = "2006-01-02"
+	ganttDefaultHoursDay  = 8
+	ganttDefaultRenderCmd = "mmdc -i {input} -o {output}"
+)
+
+// ganttTask is one scheduled bar. start and finish are working-day offsets
+// from the plan start; the task occupies days [Start, Finish).
+type ganttTask struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Section     string   `json:"section"`
+	Status      string   `json:"status"`
+	Days        int      `json:"days"`
+	Estimated   bool     `json:"estimated"`
+	StartDate   string   `json:"start"`
+	EndDate     string   `json:"end"` // last working day, inclusive
+	DueDate     string   `json:"due,omitempty"`
+	BlockedBy   []string `json:"blocked_by,omitempty"`
+	Critical    bool     `json:"critical"`
+	Late        bool     `json:"late"`
+	start       int
+	finish      int
+	dueAt       *time.Time
+	predecessor string
+}
+
+// ganttPlan is the schedule for an epic's open work.
+type ganttPlan struct {
+	Epic         string       `json:"epic"`
+	Title        string       `json:"title"`
+	StartDate    string       `json:"start"`
+	EndDate      string       `json:"end"`
+	DueDate      string       `json:"due,omitempty"`
+	HoursPerDay  int          `json:"hours_per_day"`
+	Tasks        []*ganttTask `json:"tasks"`
+	CriticalPath []string     `json:"critical_path"`
+	Late         []string     `json:"late"`
+	Unestimated  []string     `json:"unestimated"`
+	ClosedCount  int          `json:"closed_count"`
+	start        time.Time
+}
+
+var ganttCmd = &cobra.Command{
+	Use:     "gantt",
+	GroupID: "views",
+	Short:   "Render an epic's open work as a Mermaid gantt chart",
+	Long: `Schedule an epic's open descendants and print the plan as a Mermaid
+gantt chart, with the critical path highlighted.
+
+Each open leaf issue under the epic becomes a bar. Its length comes from
+estimated_minutes divided by gantt.hours-per-day (default 8), rounded up to
+whole working days; unestimated issues count as one day and are reported.
+A bar starts the working day after its last open blocker (blocks
+dependencies inside the epic) finishes. Weekends are skipped. Closed issues
+are done and take no time; blockers outside the epic are not scheduled.
+Bars are grouped into sections by their parent issue.
+
+The critical path is the chain of blockers that determines the end date;
+those bars are marked crit. Issues whose scheduled end falls after their
+due date are reported as late, and the epic's own due date is drawn as a
+milestone.
+
+--png renders an image by running the command in gantt.renderer with
+{input} and {output} replaced by the Mermaid file and the image path
+(default: ` + ganttDefaultRenderCmd + `, from @mermaid-js/mermaid-cli).
+
+Examples:
+  bd gantt --epic bd-42
+  bd gantt --epic bd-42 --start 2025-03-03 -o plan.mmd
+  bd gantt --epic bd-42 --png plan.png
+  bd gantt --epic bd-42 --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("gantt is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("gantt")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+
+		epicArg, _ := cmd.Flags().GetString("epic")
+		startStr, _ := cmd.Flags().GetString("start")
+		output, _ := cmd.Flags().GetString("output")
+		pngPath, _ := cmd.Flags().GetString("png")
+		if epicArg == "" {
+			return HandleErrorRespectJSON("--epic is required")
+		}
+		start := time.Now()
+		if startStr != "" {
+			t, err := time.ParseInLocation(ganttDateLayout, startStr, time.Local)
+			if err != nil {
+				return HandleErrorRespectJSON("invalid --start %q: expected YYYY-MM-DD", startStr)
+			}
+			start = t
+		}
+		hoursPerDay := config.GetInt("gantt.hours-per-day")
+		if hoursPerDay <= 0 {
+			hoursPerDay = ganttDefaultHoursDay
+		}
+
+		epicID, err := utils.ResolvePartialID(ctx, store, epicArg)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		plan, err := buildGanttPlan(ctx, store, epicID, start, hoursPerDay)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			return outputJSON(plan)
+		}
+
+		chart := renderGanttMermaid(plan)
+		if pngPath != "" {
+			if err := renderGanttImage(chart, pngPath); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			fmt.Fprintf(os.Stderr, "Rendered %s\n", pngPath)
+		}
+		switch {
+		case output != "":
+			if err := os.WriteFile(output, []byte(chart), 0o644); err != nil { // #nosec G306 -- chart is meant to be shared
+				return HandleErrorRespectJSON("failed to write %s: %v", output, err)
+			}
+			fmt.Fprintf(os.Stderr, "Wrote %s\n", output)
+		case pngPath == "":
+			fmt.Print(chart)
+		}
+		printGanttWarnings(plan)
+		return nil
+	},
+}
+
+// buildGanttPlan loads the epic's descendants and schedules the open leaves.
+func buildGanttPlan(ctx context.Context, s storage.DoltStorage, epicID string, start time.Time, hoursPerDay int) (*ganttPlan, error) {
+	epic, err := s.GetIssue(ctx, epicID)
+	if err != nil {
+		return nil, err
+	}
+	if epic == nil {
+		return nil, fmt.Errorf("issue %s not found", epicID)
+	}
+
+	// Walk parent-child edges down from the epic.
+	issues := map[string]*types.Issue{}
+	parent := map[string]string{}
+	children := map[string][]string{}
+	queue := []string{epic.ID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		refs, err := s.GetDependentsWithMetadata(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			if ref.DependencyType != types.DepParentChild || issues[ref.ID] != nil || ref.ID == epic.ID {
+				continue
+			}
+			issue := ref.Issue
+			issues[ref.ID] = &issue
+			parent[ref.ID] = id
+			children[id] = append(children[id], ref.ID)
+			queue = append(queue, ref.ID)
+		}
+	}
+
+	ids := make([]string, 0, len(issues))
+	for id := range issues {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	records, err := s.GetDependencyRecordsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// leaves expands a container issue to the leaf issues under it, so a
+	// dependency on a sub-epic waits for all of its work.
+	var leaves func(id string) []string
+	leaves = func(id string) []string {
+		if len(children[id]) == 0 {
+			return []string{id}
+		}
+		var out []string
+		for _, c := range children[id] {
+			out = append(out, leaves(c)...)
+		}
+		return out
+	}
+
+	plan := &ganttPlan{
+		Epic:         epic.ID,
+		Title:        epic.Title,
+		HoursPerDay:  hoursPerDay,
+		Tasks:        []*ganttTask{},
+		CriticalPath: []string{},
+		Late:         []string{},
+		Unestimated:  []string{},
+		start:        addWorkdays(start, 0),
+	}
+	if epic.DueAt != nil {
+		plan.DueDate = epic.DueAt.Format(ganttDateLayout)
+	}
+	tasks := map[string]*ganttTask{}
+	for _, id := range ids {
+		issue := issues[id]
+		if len(children[id]) > 0 {
+			continue
+		}
+		if issue.Status == types.StatusClosed {
+			plan.ClosedCount++
+			continue
+		}
+		section := epic.Title
+		if p := parent[id]; p != epic.ID {
+			section = issues[p].Title
+		}
+		t := &ganttTask{ID: id, Title: issue.Title, Section: section, Status: string(issue.Status), Days: 1, dueAt: issue.DueAt}
+		if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+			t.Estimated = true
+			perDay := hoursPerDay * 60
+			t.Days = (*issue.EstimatedMinutes + perDay - 1) / perDay
+		} else {
+			plan.Unestimated = append(plan.Unestimated, id)
+		}
+		tasks[id] = t
+	}
+	for _, id := range ids {
+		t := tasks[id]
+		if t == nil {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, dep := range records[id] {
+			if dep.Type != types.DepBlocks || issues[dep.DependsOnID] == nil {
+				continue
+			}
+			for _, b := range leaves(dep.DependsOnID) {
+				if tasks[b] != nil && !seen[b] && b != id {
+					seen[b] = true
+					t.BlockedBy = append(t.BlockedBy, b)
+				}
+			}
+		}
+		sort.Strings(t.BlockedBy)
+	}
+
+	scheduleGantt(tasks)
+	plan.CriticalPath = ganttCriticalPath(tasks)
+
+	end := 0
+	for _, id := range ids {
+		t := tasks[id]
+		if t == nil {
+			continue
+		}
+		t.StartDate = addWorkdays(plan.start, t.start).Format(ganttDateLayout)
+		t.EndDate = addWorkdays(plan.start, t.finish-1).Format(ganttDateLayout)
+		if t.dueAt != nil {
+			t.DueDate = t.dueAt.Format(ganttDateLayout)
+			if t.EndDate > t.DueDate {
+				t.Late = true
+				plan.Late = append(plan.Late, t.ID)
+			}
+		}
+		if t.finish > end {
+			end = t.finish
+		}
+		plan.Tasks = append(plan.Tasks, t)
+	}
+	for _, id := range plan.CriticalPath {
+		tasks[id].Critical = true
+	}
+	sort.SliceStable(plan.Tasks, func(i, j int) bool {
+		if plan.Tasks[i].start != plan.Tasks[j].start {
+			return plan.Tasks[i].start < plan.Tasks[j].start
+		}
+		return plan.Tasks[i].ID < plan.Tasks[j].ID
+	})
+	plan.StartDate = plan.start.Format(ganttDateLayout)
+	plan.EndDate = plan.StartDate
+	if end > 0 {
+		plan.EndDate = addWorkdays(plan.start, end-1).Format(ganttDateLayout)
+	}
+	return plan, nil
+}
+
+// scheduleGantt sets each task's Start to the latest Finish of its blockers.
+// Blocker cycles are broken where they are found.
+func scheduleGantt(tasks map[string]*ganttTask) {
+	done := map[string]bool{}
+	visiting := map[string]bool{}
+	var visit func(t *ganttTask)
+	visit = func(t *ganttTask) {
+		if done[t.ID] {
+			return
+		}
+		visiting[t.ID] = true
+		for _, b := range t.BlockedBy {
+			bt := tasks[b]
+			if bt == nil || visiting[b] {
+				continue
+			}
+			visit(bt)
+			if bt.finish > t.start || (bt.finish == t.start && t.predecessor == "") {
+				t.start = bt.finish
+				t.predecessor = b
+			}
+		}
+		t.finish = t.start + t.Days
+		visiting[t.ID] = false
+		done[t.ID] = true
+	}
+	ids := make([]string, 0, len(tasks))
+	for id := range tasks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		visit(tasks[id])
+	}
+}
+
+// ganttCriticalPath follows driving blockers back from the task that
+// finishes last, returning the chain in schedule order.
+func ganttCriticalPath(tasks map[string]*ganttTask) []string {
+	var last *ganttTask
+	for _, t := range tasks {
+		if last == nil || t.finish > last.finish || (t.finish == last.finish && t.ID < last.ID) {
+			last = t
+		}
+	}
+	var path []string
+	for t := last; t != nil; t = tasks[t.predecessor] {
+		path = append([]string{t.ID}, path...)
+	}
+	if path == nil {
+		path = []string{}
+	}
+	return path
+}
+
+// addWorkdays returns the date n working days after t, skipping weekends.
+// n = 0 moves a weekend date forward to Monday.
+func addWorkdays(t time.Time, n int) time.Time {
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		d = d.AddDate(0, 0, 1)
+	}
+	for n > 0 {
+		d = d.AddDate(0, 0, 1)
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			n--
+		}
+	}
+	return d
+}
+
+// renderGanttMermaid renders the plan as a Mermaid gantt chart.
+func renderGanttMermaid(plan *ganttPlan) string {
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	fmt.Fprintf(&b, "    title %s (%s)\n", mermaidGanttText(plan.Title), plan.Epic)
+	b.WriteString("    dateFormat YYYY-MM-DD\n")
+	b.WriteString("    excludes weekends\n")
+
+	var sections []string
+	bySection := map[string][]*ganttTask{}
+	for _, t := range plan.Tasks {
+		if bySection[t.Section] == nil {
+			sections = append(sections, t.Section)
+		}
+		bySection[t.Section] = append(bySection[t.Section], t)
+	}
+	for _, section := range sections {
+		fmt.Fprintf(&b, "    section %s\n", mermaidGanttText(section))
+		for _, t := range bySection[section] {
+			var tags []string
+			if t.Critical {
+				tags = append(tags, "crit")
+			}
+			if t.Status == string(types.StatusInProgress) {
+				tags = append(tags, "active")
+			}
+			tags = append(tags, mermaidGanttID(t.ID), t.StartDate, fmt.Sprintf("%dd", t.Days))
+			fmt.Fprintf(&b, "    %s (%s) :%s\n", mermaidGanttText(t.Title), t.ID, strings.Join(tags, ", "))
+		}
+	}
+	if plan.DueDate != "" {
+		b.WriteString("    section Milestones\n")
+		fmt.Fprintf(&b, "    Due :milestone, due, %s, 0d\n", plan.DueDate)
+	}
+	return b.String()
+}
+
+// mermaidGanttText strips characters that end a gantt title or task name.
+func mermaidGanttText(s string) string {
+	return strings.Join(strings.Fields(strings.NewReplacer(":", " ", "#", " ", ";", " ").Replace(s)), " ")
+}
+
+// mermaidGanttID turns an issue ID into a Mermaid task ID.
+func mermaidGanttID(id string) string {
+	return "t_" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// renderGanttImage runs the gantt.renderer command on the chart.
+func renderGanttImage(chart, outPath string) error {
+	renderer := config.GetString("gantt.renderer")
+	if renderer == "" {
+		renderer = ganttDefaultRenderCmd
+	}
+	dir, err := os.MkdirTemp("", "bd-gantt-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	input := filepath.Join(dir, "gantt.mmd")
+	if err := os.WriteFile(input, []byte(chart), 0o600); err != nil {
+		return err
+	}
+	fields := strings.Fields(renderer)
+	for i, f := range fields {
+		f = strings.ReplaceAll(f, "{input}", input)
+		fields[i] = strings.ReplaceAll(f, "{output}", outPath)
+	}
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		return fmt.Errorf("renderer %q not found: install @mermaid-js/mermaid-cli or set gantt.renderer", fields[0])
+	}
+	c := exec.Command(fields[0], fields[1:]...) // #nosec G204 -- renderer comes from the user's config
+	c.Stdout, c.Stderr = os.Stderr, os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("renderer failed: %w", err)
+	}
+	return nil
+}
+
+func printGanttWarnings(plan *ganttPlan) {
+	if len(plan.Late) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d issue(s) finish after their due date: %s\n", len(plan.Late), strings.Join(plan.Late, ", "))
+	}
+	if plan.DueDate != "" && plan.EndDate > plan.DueDate {
+		fmt.Fprintf(os.Stderr, "Warning: %s is scheduled to finish %s, after its due date %s\n", plan.Epic, plan.EndDate, plan.DueDate)
+	}
+	if len(plan.Unestimated) > 0 {
+		fmt.Fprintf(os.Stderr, "Note: %d issue(s) have no estimate and were scheduled as 1 day: %s\n", len(plan.Unestimated), strings.Join(plan.Unestimated, ", "))
+	}
+}
+
+func init() {
+	ganttCmd.Flags().String("epic", "", "Epic whose descendants to schedule (required)")
+	ganttCmd.Flags().String("start", "", "Plan start date, YYYY-MM-DD (default: today)")
+	ganttCmd.Flags().StringP("output", "o", "", "Write the Mermaid chart to a file instead of stdout")
+	ganttCmd.Flags().String("png", "", "Also render an image with the gantt.renderer command")
+	rootCmd.AddCommand(ganttCmd)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddWorkdays(t *testing.T) {
+	fri := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	sat := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		from time.Time
+		n    int
+		want string
+	}{
+		{fri, 0, "2026-10-16"},
+		{fri, 1, "2026-10-19"},
+		{fri, 5, "2026-10-23"},
+		{sat, 0, "2026-10-19"},
+		{sat, 1, "2026-10-20"},
+	}
+	for _, tt := range tests {
+		if got := addWorkdays(tt.from, tt.n).Format(ganttDateLayout); got != tt.want {
+			t.Errorf("addWorkdays(%s, %d) = %s, want %s", tt.from.Format(ganttDateLayout), tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestScheduleGantt(t *testing.T) {
+	tasks := map[string]*ganttTask{
+		"a": {ID: "a", Days: 2},
+		"b": {ID: "b", Days: 3, BlockedBy: []string{"a"}},
+		"c": {ID: "c", Days: 1},
+		"d": {ID: "d", Days: 1, BlockedBy: []string{"b", "c"}},
+		"x": {ID: "x", Days: 1, BlockedBy: []string{"y"}}, // cycle
+		"y": {ID: "y", Days: 1, BlockedBy: []string{"x"}},
+	}
+	scheduleGantt(tasks)
+	want := map[string][2]int{"a": {0, 2}, "b": {2, 5}, "c": {0, 1}, "d": {5, 6}}
+	for id, w := range want {
+		if got := [2]int{tasks[id].start, tasks[id].finish}; got != w {
+			t.Errorf("%s scheduled %v, want %v", id, got, w)
+		}
+	}
+	if got := strings.Join(ganttCriticalPath(tasks), ","); got != "a,b,d" {
+		t.Errorf("critical path = %s, want a,b,d", got)
+	}
+}
+
+func TestRenderGanttMermaid(t *testing.T) {
+	plan := &ganttPlan{
+		Epic: "bd-1", Title: "Launch: v2", DueDate: "2026-10-30",
+		Tasks: []*ganttTask{
+			{ID: "bd-1.1", Title: "Design #1", Section: "Backend", Status: "in_progress", Days: 2, StartDate: "2026-10-16", Critical: true},
+			{ID: "bd-1.2", Title: "Docs", Section: "Launch: v2", Status: "open", Days: 1, StartDate: "2026-10-16"},
+		},
+	}
+	out := renderGanttMermaid(plan)
+	for _, want := range []string{
+		"title Launch v2 (bd-1)",
+		"section Backend",
+		"Design 1 (bd-1.1) :crit, active, t_bd_1_1, 2026-10-16, 2d",
+		"Docs (bd-1.2) :t_bd_1_2, 2026-10-16, 1d",
+		"Due :milestone, due, 2026-10-30, 0d",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("chart missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -4,16 +4,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/metrics"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // gateCmd is the parent command for gate operations
@@ -32,6 +37,9 @@ Gate types:
   gh:run  - Waits for GitHub workflow (Phase 3)
   gh:pr   - Waits for PR merge (Phase 3)
   bead    - Waits for another bead to close (Phase 4)
+  date    - Waits until a date passes
+  http    - Waits for a URL to return 200
+  cmd     - Waits for a shell command to exit 0
 
 For bead gates, await_id is a bead ID in this rig's database (e.g., "bd-abc123").
 The historical cross-rig form <rig>:<bead-id> can no longer be evaluated
@@ -81,7 +89,15 @@ By default, shows only open gates. Use --all to include closed gates.`,
 		// issue-id argument was silently ignored and the DB-wide list was
 		// returned, which could lead a caller to act on unrelated gates.
 		if len(args) == 1 {
-			target, err := store.GetIssue(ctx, args[0])
+			targetID, err := utils.ResolvePartialID(ctx, store, args[0])
+			var ambiguous *utils.AmbiguousIDError
+			if errors.As(err, &ambiguous) {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			if err != nil {
+				return HandleErrorRespectJSON("issue not found: %s", args[0])
+			}
+			target, err := store.GetIssue(ctx, targetID)
 			if err != nil {
 				return HandleErrorRespectJSON("issue not found: %s", args[0])
 			}
@@ -297,12 +313,24 @@ Gate types:
   timer   - Auto-resolves after --timeout duration
   gh:run  - Waits for GitHub Actions workflow
   gh:pr   - Waits for PR merge
+  bead    - Waits for another bead to close
+  date    - Auto-resolves once --await-id (YYYY-MM-DD or RFC 3339) passes
+  http    - Auto-resolves when a GET of --await-id (a URL) returns 200
+  cmd     - Auto-resolves when --await-id (a shell command) exits 0;
+            runs only if listed in gate.allowed-commands (config.yaml)
+
+Date, http, and cmd gates are evaluated by 'bd gate check' (or continuously
+by 'bd gate check --watch'), which closes them with the evidence as the
+close reason.
 
 Examples:
   bd gate create --blocks bd-abc
   bd gate create --type=human --blocks bd-abc --reason="Need design review"
   bd gate create --type=timer --blocks bd-abc --timeout=2h
-  bd gate create --type=gh:pr --blocks bd-abc --await-id=42`,
+  bd gate create --type=gh:pr --blocks bd-abc --await-id=42
+  bd gate create --type=date --blocks bd-abc --await-id=2025-07-01
+  bd gate create --type=http --blocks bd-abc --await-id=https://staging.example.com/health
+  bd gate create --type=cmd --blocks bd-abc --await-id="test -f build/done"`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -339,6 +367,9 @@ Examples:
 			}
 			timeout = parsed
 		}
+		if err := validateGateCondition(gateType, awaitID); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
 
 		title := fmt.Sprintf("Gate: %s", gateType)
 		if awaitID != "" {
@@ -533,6 +564,9 @@ Gate types:
   gh:pr    - Check pull request merge status
   timer    - Check timer gates (auto-expire based on timeout)
   bead     - Check cross-rig bead gates
+  date     - Check date gates
+  http     - Check URL health gates
+  cmd      - Check command gates
   all      - Check all gate types
 
 GitHub gates use the 'gh' CLI to query status:
@@ -544,6 +578,19 @@ A gate is resolved when:
   - gh:pr: state=MERGED
   - timer: current time > created_at + timeout
   - bead: target bead status=closed
+  - date: the await_id date has passed
+  - http: GET <await_id> returns 200 (10s timeout)
+  - cmd: 'sh -c <await_id>' exits 0 (60s timeout). The command must be
+    listed verbatim in gate.allowed-commands in config.yaml: gates sync
+    between clones and from federation peers, so running an unlisted
+    gate command would run whatever a peer wrote
+
+Resolved gates are closed with the evidence (date, HTTP status, command
+exit and last line of output) as the close reason, recorded in the gate's
+event history.
+
+--watch keeps running and re-checks every --interval (default:
+gate.check-interval, else 1m), closing gates as their conditions hold.
 
 A gate is escalated when:
   - gh:run: status=completed AND conclusion in (failure, canceled)
@@ -556,7 +603,8 @@ Examples:
   bd gate check --type=timer # Check only timer gates
   bd gate check --type=bead  # Check only cross-rig bead gates
   bd gate check --dry-run    # Show what would happen without changes
-  bd gate check --escalate   # Escalate expired/failed gates`,
+  bd gate check --escalate   # Escalate expired/failed gates
+  bd gate check --watch --interval=30s  # Keep checking until Ctrl+C`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -576,17 +624,28 @@ Examples:
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		escalateFlag, _ := cmd.Flags().GetBool("escalate")
 		limit, _ := cmd.Flags().GetInt("limit")
-
-		gateType := types.IssueType("gate")
-		filter := types.IssueFilter{
-			IssueType:     &gateType,
-			ExcludeStatus: []types.Status{types.StatusClosed},
-			Limit:         limit,
-		}
+		watch, _ := cmd.Flags().GetBool("watch")
 
 		ctx := rootCtx
 
-		gates, err := store.SearchIssues(ctx, "", filter)
+		if watch {
+			if dryRun || jsonOutput {
+				return HandleErrorRespectJSON("--watch cannot be combined with --dry-run or --json")
+			}
+			interval := config.GetDuration("gate.check-interval")
+			if cmd.Flags().Changed("interval") {
+				interval, _ = cmd.Flags().GetDuration("interval")
+			}
+			if interval <= 0 {
+				interval = time.Minute
+			}
+			if interval < 2*time.Second {
+				interval = 2 * time.Second
+			}
+			return runGateCheckWatch(ctx, gateTypeFilter, escalateFlag, limit, interval)
+		}
+
+		gates, err := store.SearchIssues(ctx, "", openGatesFilter(limit))
 		if err != nil {
 			return HandleErrorRespectJSON("%v", err)
 		}
@@ -617,6 +676,87 @@ Examples:
 	},
 }
 
+// openGatesFilter selects open gate issues for bd gate check.
+func openGatesFilter(limit int) types.IssueFilter {
+	gateType := types.IssueType("gate")
+	return types.IssueFilter{
+		IssueType:     &gateType,
+		ExcludeStatus: []types.Status{types.StatusClosed},
+		Limit:         limit,
+	}
+}
+
+// runGateCheckWatch re-evaluates open gates every interval until
+// interrupted, closing each gate as its condition holds. Only changes are
+// printed: resolutions, and escalations or errors the first time they are
+// seen for a gate.
+func runGateCheckWatch(ctx context.Context, typeFilter string, escalate bool, limit int, interval time.Duration) error {
+	fmt.Fprintf(os.Stderr, "Checking gates every %s... (Press Ctrl+C to exit)\n", interval)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	persistAwaitID := func(gateID, runID string) error {
+		return updateGateAwaitIDFunc(nil, gateID, runID)
+	}
+	reported := make(map[string]string)
+	round := func() {
+		gates, err := store.SearchIssues(ctx, "", openGatesFilter(limit))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading gates: %v\n", err)
+			return
+		}
+		var changed []gateCheckResult
+		var resolvedIDs []string
+		for _, r := range evaluateGates(ctx, filterCheckableGates(gates, typeFilter), time.Now(), store, persistAwaitID) {
+			switch {
+			case r.resolved && r.err == nil:
+				resolvedIDs = append(resolvedIDs, r.gate.ID)
+			case r.err != nil || r.escalated:
+				key := r.reason
+				if r.err != nil {
+					key = r.err.Error()
+				}
+				if reported[r.gate.ID] == key {
+					continue
+				}
+				reported[r.gate.ID] = key
+			default:
+				continue
+			}
+			changed = append(changed, r)
+		}
+		if len(changed) == 0 {
+			return
+		}
+		fmt.Println(time.Now().Format("15:04:05"))
+		applyGateCheckResults(changed, false, escalate, func(gate *types.Issue, reason string) error {
+			return closeGate(ctx, gate.ID, reason)
+		})
+		if len(resolvedIDs) > 0 {
+			if err := maybeAutoCommit(ctx, doltAutoCommitParams{Command: "gate check", IssueIDs: resolvedIDs}); err != nil {
+				fmt.Fprintf(os.Stderr, "%s dolt auto-commit failed: %v\n", ui.RenderWarn("⚠"), err)
+			}
+		}
+	}
+
+	round()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sigChan:
+			fmt.Fprintf(os.Stderr, "\nStopped checking gates.\n")
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			round()
+		}
+	}
+}
+
 type gateCheckResult struct {
 	gate      *types.Issue
 	resolved  bool
@@ -656,6 +796,12 @@ func evaluateGates(ctx context.Context, gates []*types.Issue, now time.Time, get
 			r.resolved, r.escalated, r.reason, r.err = checkTimer(gate, now)
 		case gate.AwaitType == "bead":
 			r.resolved, r.reason = checkBeadGate(ctx, getter, gate.AwaitID)
+		case gate.AwaitType == gateTypeDate:
+			r.resolved, r.reason, r.err = checkDateGate(gate, now)
+		case gate.AwaitType == gateTypeHTTP:
+			r.resolved, r.reason, r.err = checkHTTPGate(ctx, gate, now)
+		case gate.AwaitType == gateTypeCmd:
+			r.resolved, r.reason, r.err = checkCmdGate(ctx, gate, now)
 		default:
 			continue
 		}
@@ -1027,16 +1173,18 @@ func init() {
 	gateResolveCmd.Flags().StringP("reason", "r", "", "Reason for resolving the gate")
 
 	// gate check flags
-	gateCheckCmd.Flags().StringP("type", "t", "", "Gate type to check (gh, gh:run, gh:pr, timer, bead, all)")
+	gateCheckCmd.Flags().StringP("type", "t", "", "Gate type to check (gh, gh:run, gh:pr, timer, bead, date, http, cmd, all)")
 	gateCheckCmd.Flags().Bool("dry-run", false, "Show what would happen without making changes")
 	gateCheckCmd.Flags().BoolP("escalate", "e", false, "Escalate failed/expired gates")
 	gateCheckCmd.Flags().IntP("limit", "l", 100, "Limit results (default 100)")
+	gateCheckCmd.Flags().Bool("watch", false, "Keep checking gates periodically until interrupted")
+	gateCheckCmd.Flags().Duration("interval", 0, "Polling interval for --watch (default: gate.check-interval, else 1m)")
 
 	// gate create flags
 	gateCreateCmd.Flags().String("blocks", "", "Issue ID to block (required)")
-	gateCreateCmd.Flags().StringP("type", "t", "human", "Gate type (human, timer, gh:run, gh:pr)")
+	gateCreateCmd.Flags().StringP("type", "t", "human", "Gate type (human, timer, gh:run, gh:pr, bead, date, http, cmd)")
 	gateCreateCmd.Flags().StringP("reason", "r", "", "Reason for the gate")
-	gateCreateCmd.Flags().String("await-id", "", "Condition identifier (run ID, PR number, etc.)")
+	gateCreateCmd.Flags().String("await-id", "", "Condition identifier (run ID, PR number, bead ID, date, URL, or command)")
 	gateCreateCmd.Flags().String("timeout", "", "Timeout duration (e.g., 2h, 30m)")
 	_ = gateCreateCmd.MarkFlagRequired("blocks")
 
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// External-condition gate types. Each resolves on its own once the
+// condition holds; bd gate check (or bd gate check --watch) evaluates them
+// and closes the gate with the evidence as the close reason, so it lands in
+// the gate's event history.
+const (
+	gateTypeDate = "date" // await_id: RFC 3339 timestamp or YYYY-MM-DD
+	gateTypeHTTP = "http" // await_id: http(s) URL; resolves when GET returns 200
+	gateTypeCmd  = "cmd"  // await_id: shell command; resolves when it exits 0
+
+	gateHTTPTimeout = 10 * time.Second
+	gateCmdTimeout  = 60 * time.Second
+
+	// gateCmdOutputMax bounds how much command output goes into the close
+	// reason.
+	gateCmdOutputMax = 200
+)
+
+var (
+	gateHTTPClient = &http.Client{Timeout: gateHTTPTimeout}
+
+	// gateAllowedCommands returns the commands cmd gates may run: the
+	// gate.allowed-commands list in config.yaml. Gates sync between clones
+	// and federation peers, so a gate's command is untrusted input; only
+	// commands the workspace owner listed verbatim are ever run.
+	gateAllowedCommands = func() []string { return config.GetStringSlice("gate.allowed-commands") }
+)
+
+// parseGateDate parses a date gate's await_id. A bare date means local
+// midnight at the start of that day.
+func parseGateDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD or RFC 3339)", s)
+}
+
+// validateGateCondition checks the await_id of an external-condition gate
+// at creation time, so a typo fails loudly instead of a gate that never
+// resolves.
+func validateGateCondition(gateType, awaitID string) error {
+	switch gateType {
+	case gateTypeDate, gateTypeHTTP, gateTypeCmd:
+		if strings.TrimSpace(awaitID) == "" {
+			return fmt.Errorf("%s gates require --await-id", gateType)
+		}
+	}
+	switch gateType {
+	case gateTypeDate:
+		_, err := parseGateDate(awaitID)
+		return err
+	case gateTypeHTTP:
+		return validateGateURL(awaitID)
+	}
+	return nil
+}
+
+func validateGateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %v", raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL %q: must be an absolute http or https URL", raw)
+	}
+	return nil
+}
+
+// checkDateGate resolves once now reaches the gate's date.
+func checkDateGate(gate *types.Issue, now time.Time) (resolved bool, reason string, err error) {
+	at, err := parseGateDate(gate.AwaitID)
+	if err != nil {
+		return false, "", err
+	}
+	if now.Before(at) {
+		return false, fmt.Sprintf("waiting until %s (in %s)", at.Format(time.RFC3339), at.Sub(now).Round(time.Second)), nil
+	}
+	return true, fmt.Sprintf("date %s passed (checked at %s)", at.Format(time.RFC3339), now.Format(time.RFC3339)), nil
+}
+
+// checkHTTPGate resolves when a GET of the gate's URL returns 200. Other
+// statuses and network failures leave the gate pending: the service may
+// simply not be up yet.
+func checkHTTPGate(ctx context.Context, gate *types.Issue, now time.Time) (resolved bool, reason string, err error) {
+	if err := validateGateURL(gate.AwaitID); err != nil {
+		return false, "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gate.AwaitID, nil)
+	if err != nil {
+		return false, "", err
+	}
+	resp, err := gateHTTPClient.Do(req)
+	if err != nil {
+		return false, fmt.Sprintf("GET %s failed: %v", gate.AwaitID, err), nil
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Sprintf("GET %s returned %s", gate.AwaitID, resp.Status), nil
+	}
+	return true, fmt.Sprintf("GET %s returned %s at %s", gate.AwaitID, resp.Status, now.Format(time.RFC3339)), nil
+}
+
+// checkCmdGate resolves when the gate's command exits 0. Commands run
+// through the shell from the current directory, and only when they appear
+// verbatim in gate.allowed-commands.
+func checkCmdGate(ctx context.Context, gate *types.Issue, now time.Time) (resolved bool, reason string, err error) {
+	if strings.TrimSpace(gate.AwaitID) == "" {
+		return false, "", fmt.Errorf("cmd gate has no command")
+	}
+	if !gateCommandAllowed(gate.AwaitID) {
+		return false, fmt.Sprintf("command %q is not in gate.allowed-commands in config.yaml", gate.AwaitID), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, gateCmdTimeout)
+	defer cancel()
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd.exe", "/C", gate.AwaitID) // #nosec G204 -- listed in gate.allowed-commands
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", gate.AwaitID) // #nosec G204 -- listed in gate.allowed-commands
+	}
+	var out bytes.Buffer
+	c.Stdout = &out
+	c.Stderr = &out
+	runErr := c.Run()
+	output := gateCmdOutputTail(out.String())
+
+	var exitErr *exec.ExitError
+	switch {
+	case runErr == nil:
+		reason = fmt.Sprintf("command %q exited 0 at %s", gate.AwaitID, now.Format(time.RFC3339))
+		if output != "" {
+			reason += ": " + output
+		}
+		return true, reason, nil
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return false, fmt.Sprintf("command %q timed out after %s", gate.AwaitID, gateCmdTimeout), nil
+	case errors.As(runErr, &exitErr):
+		reason = fmt.Sprintf("command %q exited %d", gate.AwaitID, exitErr.ExitCode())
+		if output != "" {
+			reason += ": " + output
+		}
+		return false, reason, nil
+	default:
+		return false, "", fmt.Errorf("running %q: %w", gate.AwaitID, runErr)
+	}
+}
+
+// gateCommandAllowed reports whether command is listed in
+// gate.allowed-commands. Matching is exact, after trimming surrounding
+// space, so a synced gate cannot smuggle extra shell around an allowed
+// command.
+func gateCommandAllowed(command string) bool {
+	command = strings.TrimSpace(command)
+	for _, allowed := range gateAllowedCommands() {
+		if strings.TrimSpace(allowed) == command {
+			return true
+		}
+	}
+	return false
+}
+
+// gateCmdOutputTail returns the last line of output, truncated, for use as
+// evidence.
+func gateCmdOutputTail(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndex(s, "\n"); i >= 0 {
+		s = strings.TrimSpace(s[i+1:])
+	}
+	if len(s) > gateCmdOutputMax {
+		s = s[:gateCmdOutputMax] + "…"
+	}
+	return s
+}
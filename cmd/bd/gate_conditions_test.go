@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckDateGate(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		awaitID  string
+		resolved bool
+	}{
+		{"2025-07-01T11:59:00Z", true},
+		{"2025-07-01T12:00:00Z", true},
+		{"2025-07-01T12:01:00Z", false},
+		{"2025-06-30", true},
+		{"2025-07-03", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.awaitID, func(t *testing.T) {
+			resolved, reason, err := checkDateGate(&types.Issue{AwaitID: tt.awaitID}, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resolved != tt.resolved {
+				t.Errorf("resolved = %v, want %v (%s)", resolved, tt.resolved, reason)
+			}
+		})
+	}
+
+	if _, _, err := checkDateGate(&types.Issue{AwaitID: "next tuesday"}, now); err == nil {
+		t.Error("expected an error for an unparseable date")
+	}
+}
+
+func TestCheckHTTPGate(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	gate := &types.Issue{AwaitID: srv.URL + "/health"}
+	now := time.Now()
+	resolved, reason, err := checkHTTPGate(context.Background(), gate, now)
+	if err != nil || resolved {
+		t.Fatalf("503: resolved=%v err=%v", resolved, err)
+	}
+	if !strings.Contains(reason, "503") {
+		t.Errorf("pending reason %q should name the status", reason)
+	}
+
+	status = http.StatusOK
+	resolved, reason, err = checkHTTPGate(context.Background(), gate, now)
+	if err != nil || !resolved {
+		t.Fatalf("200: resolved=%v err=%v", resolved, err)
+	}
+	if !strings.Contains(reason, "200 OK") || !strings.Contains(reason, gate.AwaitID) {
+		t.Errorf("evidence %q should name the URL and status", reason)
+	}
+
+	srv.Close()
+	resolved, _, err = checkHTTPGate(context.Background(), gate, now)
+	if err != nil || resolved {
+		t.Errorf("unreachable server should stay pending: resolved=%v err=%v", resolved, err)
+	}
+
+	if _, _, err := checkHTTPGate(context.Background(), &types.Issue{AwaitID: "file:///etc/passwd"}, now); err == nil {
+		t.Error("expected non-http URL to be rejected")
+	}
+}
+
+func TestCheckCmdGate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell commands")
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	orig := gateAllowedCommands
+	t.Cleanup(func() { gateAllowedCommands = orig })
+	gateAllowedCommands = func() []string {
+		return []string{"echo building; echo ready", " echo not yet; exit 3 "}
+	}
+
+	for _, cmd := range []string{"true", "echo building; echo ready; rm -rf ~"} {
+		resolved, reason, err := checkCmdGate(ctx, &types.Issue{AwaitID: cmd}, now)
+		if err != nil || resolved || !strings.Contains(reason, "gate.allowed-commands") {
+			t.Fatalf("unlisted %q: resolved=%v reason=%q err=%v", cmd, resolved, reason, err)
+		}
+	}
+
+	resolved, reason, err := checkCmdGate(ctx, &types.Issue{AwaitID: "echo building; echo ready"}, now)
+	if err != nil || !resolved {
+		t.Fatalf("exit 0: resolved=%v err=%v", resolved, err)
+	}
+	if !strings.Contains(reason, "exited 0") || !strings.HasSuffix(reason, ": ready") {
+		t.Errorf("evidence %q should record the exit and last output line", reason)
+	}
+
+	resolved, reason, err = checkCmdGate(ctx, &types.Issue{AwaitID: "echo not yet; exit 3"}, now)
+	if err != nil || resolved {
+		t.Fatalf("exit 3: resolved=%v err=%v", resolved, err)
+	}
+	if !strings.Contains(reason, "exited 3") {
+		t.Errorf("pending reason %q should record the exit code", reason)
+	}
+}
+
+func TestValidateGateCondition(t *testing.T) {
+	tests := []struct {
+		gateType, awaitID string
+		wantErr           bool
+	}{
+		{"date", "2025-07-01", false},
+		{"date", "soon", true},
+		{"date", "", true},
+		{"http", "https://example.com/health", false},
+		{"http", "example.com", true},
+		{"cmd", "make check", false},
+		{"cmd", "", true},
+		{"human", "", false},
+	}
+	for _, tt := range tests {
+		err := validateGateCondition(tt.gateType, tt.awaitID)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateGateCondition(%q, %q) = %v, wantErr %v", tt.gateType, tt.awaitID, err, tt.wantErr)
+		}
+	}
+}
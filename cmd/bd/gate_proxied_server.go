@@ -51,6 +51,10 @@ func runGateCheckProxiedServer(cmd *cobra.Command, ctx context.Context) error {
 		}
 	}()
 
+	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+		return HandleErrorRespectJSON("gate check --watch is not supported in proxied-server mode")
+	}
+
 	gateTypeFilter, _ := cmd.Flags().GetString("type")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	escalateFlag, _ := cmd.Flags().GetBool("escalate")
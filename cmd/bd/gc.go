@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -16,6 +17,8 @@ var (
 	gcOlderThan int
 	gcSkipDecay bool
 	gcSkipDolt  bool
+
+	gcKeepHistory string
 )
 
 var gcCmd = &cobra.Command{
@@ -26,18 +29,27 @@ var gcCmd = &cobra.Command{
 
 Runs three phases in sequence:
   1. DECAY   — Delete closed issues older than N days (default 90)
-  2. COMPACT — Squash old Dolt commits into fewer commits (bd compact)
+  2. COMPACT — With --keep-history, squash old Dolt commits into one
   3. GC      — Run Dolt garbage collection to reclaim disk space
 
 Each phase can be skipped individually. Use --dry-run to preview all phases
 without making changes.
 
+--keep-history squashes the commits bd made before the window into a single
+base commit, keeping everything newer. Before anything changes, every
+federation peer must be in sync (nothing to push or pull), since the kept
+commits get new hashes. Squashing stops early at a checkpointed commit or a
+commit made by hand (bd vc commit -m), so those keep their place in history;
+checkpoints on kept commits are moved to the new copies and stay readable
+with --at.
+
 Examples:
   bd gc                              # Full GC with defaults (90 day decay)
   bd gc --dry-run                    # Preview what would happen
   bd gc --older-than 30              # Decay issues closed 30+ days ago
   bd gc --skip-decay                 # Skip issue deletion, just compact+GC
   bd gc --skip-dolt                  # Skip Dolt GC, just decay+compact
+  bd gc --keep-history 1y --force    # Also squash bd commits older than a year
   bd gc --force                      # Skip confirmation prompt`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -62,6 +74,25 @@ Examples:
 			return HandleErrorRespectJSON("--older-than must be non-negative")
 		}
 
+		var historyCutoff time.Time
+		if gcKeepHistory != "" {
+			cutoff, err := parseKeepHistory(gcKeepHistory, start)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			historyCutoff = cutoff
+			// Checked before any phase runs, so a refusal changes nothing.
+			problems, err := unsyncedPeers(ctx)
+			if err != nil {
+				return HandleErrorRespectJSON("checking federation peers: %v", err)
+			}
+			if len(problems) > 0 {
+				return HandleErrorWithHintRespectJSON(
+					"federation peers are not in sync; refusing to rewrite history:\n  "+strings.Join(problems, "\n  "),
+					"Run 'bd federation sync' and retry.")
+			}
+		}
+
 		type phaseResult struct {
 			name    string
 			skipped bool
@@ -138,35 +169,51 @@ Examples:
 			}
 		}
 
-		if !jsonOutput {
-			fmt.Println("Phase 2/3: Compact (Dolt commit history info)")
-		}
-
-		commitCount := 0
-		logEntries, logErr := store.Log(ctx, 0)
-		if logErr != nil {
-			WarnError("could not read Dolt commit log: %v", logErr)
-		} else {
-			commitCount = len(logEntries)
-		}
-
-		if commitCount <= 1 {
+		var historyInfo map[string]interface{}
+		if gcKeepHistory != "" {
 			if !jsonOutput {
-				fmt.Printf("  Only %d commit(s), nothing to compact\n\n", commitCount)
+				fmt.Printf("Phase 2/3: Compact (squash bd commits before %s)\n", historyCutoff.Format("2006-01-02"))
+			}
+			detail, info, err := runGCHistorySquash(cmd, historyCutoff)
+			if err != nil {
+				return err
+			}
+			historyInfo = info
+			results = append(results, phaseResult{name: "Compact", detail: detail})
+			if !jsonOutput {
+				fmt.Println()
 			}
-			results = append(results, phaseResult{name: "Compact", detail: "nothing to compact"})
 		} else {
-			if gcDryRun {
+			if !jsonOutput {
+				fmt.Println("Phase 2/3: Compact (Dolt commit history info)")
+			}
+
+			commitCount := 0
+			logEntries, logErr := store.Log(ctx, 0)
+			if logErr != nil {
+				WarnError("could not read Dolt commit log: %v", logErr)
+			} else {
+				commitCount = len(logEntries)
+			}
+
+			if commitCount <= 1 {
 				if !jsonOutput {
-					fmt.Printf("  %d commits in history (use bd flatten to squash)\n\n", commitCount)
+					fmt.Printf("  Only %d commit(s), nothing to compact\n\n", commitCount)
 				}
-				results = append(results, phaseResult{name: "Compact", detail: fmt.Sprintf("%d commits (dry-run)", commitCount)})
+				results = append(results, phaseResult{name: "Compact", detail: "nothing to compact"})
 			} else {
-				if !jsonOutput {
-					fmt.Printf("  %d commits in history\n", commitCount)
-					fmt.Printf("  Tip: use 'bd flatten' to squash all history to one commit\n\n")
+				if gcDryRun {
+					if !jsonOutput {
+						fmt.Printf("  %d commits in history (use bd flatten to squash)\n\n", commitCount)
+					}
+					results = append(results, phaseResult{name: "Compact", detail: fmt.Sprintf("%d commits (dry-run)", commitCount)})
+				} else {
+					if !jsonOutput {
+						fmt.Printf("  %d commits in history\n", commitCount)
+						fmt.Printf("  Tip: use --keep-history (e.g. 1y) to squash old commits, or 'bd flatten' for all of them\n\n")
+					}
+					results = append(results, phaseResult{name: "Compact", detail: fmt.Sprintf("%d commits", commitCount)})
 				}
-				results = append(results, phaseResult{name: "Compact", detail: fmt.Sprintf("%d commits", commitCount)})
 			}
 		}
 
@@ -207,7 +254,7 @@ Examples:
 					}
 					if !jsonOutput {
 						fmt.Printf("  Done (%s)\n", detail)
-						if len(remoteRefs)+len(tags) > 0 {
+						if historyInfo == nil && len(remoteRefs)+len(tags) > 0 {
 							fmt.Printf("  Note: %d remote-tracking ref(s) and %d tag(s) anchor history;\n", len(remoteRefs), len(tags))
 							fmt.Printf("  after a history squash, use bd flatten / bd compact so they are pruned first.\n")
 						}
@@ -246,6 +293,9 @@ Examples:
 			if gcSizeInfo != nil {
 				summaryMap["dolt_gc"] = gcSizeInfo
 			}
+			if historyInfo != nil {
+				summaryMap["history"] = historyInfo
+			}
 			return outputJSON(summaryMap)
 		}
 
@@ -271,6 +321,8 @@ func init() {
 	gcCmd.Flags().IntVar(&gcOlderThan, "older-than", 90, "Delete closed issues older than N days")
 	gcCmd.Flags().BoolVar(&gcSkipDecay, "skip-decay", false, "Skip issue deletion phase")
 	gcCmd.Flags().BoolVar(&gcSkipDolt, "skip-dolt", false, "Skip Dolt garbage collection phase")
+	gcCmd.Flags().StringVar(&gcKeepHistory, "keep-history", "", "Squash bd-made Dolt commits older than this window (e.g. 1y, 6m, 90d)")
+	addConfirmFlags(gcCmd)
 
 	rootCmd.AddCommand(gcCmd)
 }
@@ -126,6 +126,49 @@ func TestEmbeddedGC(t *testing.T) {
 		}
 	})
 
+	// ===== Keep History =====
+
+	t.Run("gc_keep_history", func(t *testing.T) {
+		khDir, _, _ := bdInit(t, bd, "--prefix", "gk")
+		for i := 0; i < 3; i++ {
+			bdCreate(t, bd, khDir, fmt.Sprintf("Before checkpoint %d", i), "--type", "task")
+		}
+		cmd := exec.Command(bd, "checkpoint", "create", "kh-mark")
+		cmd.Dir = khDir
+		cmd.Env = bdEnv(khDir)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("checkpoint create failed: %v\n%s", err, out)
+		}
+		bdCreate(t, bd, khDir, "After checkpoint", "--type", "task")
+
+		// A window of 0d puts every commit outside it; the squash stops at
+		// the checkpointed commit.
+		out := bdGC(t, bd, khDir, "--keep-history", "0d", "--skip-decay", "--skip-dolt", "--dry-run")
+		if !strings.Contains(out, "checkpointed") || !strings.Contains(out, "Would squash") {
+			t.Errorf("expected dry run to stop at the checkpoint: %s", out)
+		}
+		out = bdGCFail(t, bd, khDir, "--keep-history", "0d", "--skip-decay", "--skip-dolt")
+		if !strings.Contains(out, "--force") {
+			t.Errorf("expected --force hint: %s", out)
+		}
+
+		out = bdGC(t, bd, khDir, "--keep-history", "0d", "--skip-decay", "--skip-dolt", "--force")
+		if !strings.Contains(out, "Moved 1 checkpoint") {
+			t.Errorf("expected the checkpoint to move: %s", out)
+		}
+		if got := len(bdListJSON(t, bd, khDir)); got != 4 {
+			t.Errorf("got %d issues after squash, want 4", got)
+		}
+		if got := len(bdListJSON(t, bd, khDir, "--at", "kh-mark")); got != 3 {
+			t.Errorf("got %d issues at checkpoint, want 3", got)
+		}
+
+		out = bdGCFail(t, bd, khDir, "--keep-history", "soon", "--dry-run")
+		if !strings.Contains(out, "invalid --keep-history") {
+			t.Errorf("expected invalid window error: %s", out)
+		}
+	})
+
 	// ===== No --force prompts =====
 
 	t.Run("gc_no_force_prompts", func(t *testing.T) {
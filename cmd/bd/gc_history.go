@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/timeparsing"
+)
+
+// beadsCommitPrefixes are the message prefixes of the commits bd makes on
+// its own: command and auto-commits ("bd: create bd-1", "bd init"), schema
+// migrations and the commits their SQL makes, and earlier squashes. Only
+// these are squashed by bd gc --keep-history; a commit someone made by hand
+// (bd vc commit -m, or dolt directly) is kept.
+var beadsCommitPrefixes = []string{
+	"bd:", "bd ", "schema:", "compact:", "flatten:",
+	"create nonlocal table ", "disable nonlocal tables ",
+}
+
+// doltInitCommitMessage is the message of the commit Dolt creates with a
+// new database.
+const doltInitCommitMessage = "Initialize data repository"
+
+func isBeadsCommit(c storage.CommitInfo) bool {
+	if c.Message == doltInitCommitMessage {
+		return true
+	}
+	for _, p := range beadsCommitPrefixes {
+		if strings.HasPrefix(c.Message, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseKeepHistory turns a --keep-history window such as "1y", "6m", "90d",
+// or "2w" into the cutoff: commits older than it may be squashed.
+func parseKeepHistory(window string, now time.Time) (time.Time, error) {
+	window = strings.TrimSpace(window)
+	if strings.HasPrefix(window, "+") || strings.HasPrefix(window, "-") {
+		return time.Time{}, fmt.Errorf("invalid --keep-history %q: use a window like 1y, 6m, 90d, or 2w", window)
+	}
+	cutoff, err := timeparsing.ParseCompactDuration("-"+window, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --keep-history %q: use a window like 1y, 6m, 90d, or 2w", window)
+	}
+	return cutoff, nil
+}
+
+// historySquashPlan is what bd gc --keep-history would do to the commit log.
+type historySquashPlan struct {
+	InitialHash  string
+	BoundaryHash string   // newest commit folded into the squashed base
+	Squashed     int      // commits folded into the base
+	Kept         []string // commits replayed on top of the base, oldest first
+	// StoppedAt is the commit older than the cutoff that ended the squash
+	// early, because it is checkpointed or was not made by bd.
+	StoppedAt  *storage.CommitInfo
+	StopReason string
+}
+
+// worthwhile reports whether the squash would shorten history: folding a
+// single commit into a base just replaces it.
+func (p historySquashPlan) worthwhile() bool {
+	return p.Squashed >= 2
+}
+
+// planHistorySquash picks the commits to squash from log (newest first, as
+// store.Log returns it). Squashing folds an unbroken run of commits after
+// the initial one into a base commit, so it stops at the first commit that
+// is newer than cutoff, carries a checkpoint, or was not made by bd; that
+// commit and everything after it are replayed and keep their content. A
+// checkpointed commit cannot be squashed because its state would no longer
+// be on main for the checkpoint to move to.
+func planHistorySquash(log []storage.CommitInfo, cutoff time.Time, checkpointed map[string]bool) historySquashPlan {
+	var plan historySquashPlan
+	if len(log) == 0 {
+		return plan
+	}
+	oldest := make([]storage.CommitInfo, len(log))
+	for i, c := range log {
+		oldest[len(log)-1-i] = c
+	}
+	plan.InitialHash = oldest[0].Hash
+
+	end := 1
+	for ; end < len(oldest); end++ {
+		c := oldest[end]
+		if !c.Date.Before(cutoff) {
+			break
+		}
+		reason := ""
+		switch {
+		case checkpointed[c.Hash]:
+			reason = "checkpointed"
+		case !isBeadsCommit(c):
+			reason = "not made by bd"
+		}
+		if reason != "" {
+			plan.StoppedAt = &oldest[end]
+			plan.StopReason = reason
+			break
+		}
+	}
+	plan.Squashed = end - 1
+	if plan.Squashed > 0 {
+		plan.BoundaryHash = oldest[end-1].Hash
+	}
+	for _, c := range oldest[end:] {
+		plan.Kept = append(plan.Kept, c.Hash)
+	}
+	return plan
+}
+
+// unsyncedPeers checks every federation peer before history is rewritten
+// and describes each one that is not in sync. Squashing gives every kept
+// commit a new hash, so any change not yet exchanged with a peer would have
+// to be merged across unrelated histories. A peer that cannot be checked
+// counts as not in sync.
+func unsyncedPeers(ctx context.Context) ([]string, error) {
+	peers, err := store.ListFederationPeers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing federation peers: %w", err)
+	}
+	if len(peers) == 0 {
+		return nil, nil
+	}
+	previewer, ok := storage.UnwrapStore(store).(storage.SyncPreviewer)
+	if !ok {
+		return nil, fmt.Errorf("storage backend cannot compare history with federation peers")
+	}
+
+	var problems []string
+	for _, peer := range peers {
+		p, err := previewer.PreviewSync(ctx, peer.Name)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("%s: %v", peer.Name, err))
+		case p.SyncMode.AllowsPush() && p.PeerBranchMissing:
+			problems = append(problems, fmt.Sprintf("%s: has no copy of %s", peer.Name, p.Branch))
+		case p.SyncMode.AllowsPush() && p.Push.CommitCount > 0:
+			problems = append(problems, fmt.Sprintf("%s: %d local commit(s) not pushed", peer.Name, p.Push.CommitCount))
+		case p.SyncMode.AllowsPull() && p.Pull.CommitCount > 0:
+			problems = append(problems, fmt.Sprintf("%s: %d commit(s) not pulled", peer.Name, p.Pull.CommitCount))
+		}
+	}
+	return problems, nil
+}
+
+// moveCheckpoints re-points each checkpoint on a replayed commit at the
+// commit's new copy, so it names the same state and no longer anchors the
+// squashed history. Checkpoints elsewhere are left where they are.
+func moveCheckpoints(ctx context.Context, cp storage.Checkpointer, checkpoints []storage.Checkpoint, replayed map[string]string) int {
+	moved := 0
+	for _, c := range checkpoints {
+		newHash, ok := replayed[c.Hash]
+		if !ok {
+			continue
+		}
+		if err := cp.MoveCheckpoint(ctx, c, newHash); err != nil {
+			WarnError("checkpoint %s still points at the old history: %v", c.Name, err)
+			continue
+		}
+		moved++
+	}
+	return moved
+}
+
+// runGCHistorySquash is phase 2 of bd gc with --keep-history: it squashes
+// the bd commits older than cutoff into one base commit, moves checkpoints
+// onto the replayed commits, and prunes remote-tracking refs so the GC
+// phase can reclaim the old chain. It returns the phase summary and its
+// JSON details.
+func runGCHistorySquash(cmd *cobra.Command, cutoff time.Time) (string, map[string]interface{}, error) {
+	ctx := rootCtx
+	compactor, ok := storage.UnwrapStore(store).(storage.Compactor)
+	if !ok {
+		return "", nil, HandleErrorRespectJSON("storage backend does not support squashing history")
+	}
+	cp, _ := storage.UnwrapStore(store).(storage.Checkpointer)
+
+	if !gcDryRun {
+		// The squash rebuilds main from commits, so the decay phase's
+		// deletions and any other pending writes must be committed first.
+		if _, err := store.CommitPending(ctx, actor); err != nil {
+			return "", nil, HandleErrorRespectJSON("failed to commit pending changes: %v", err)
+		}
+	}
+	log, err := store.Log(ctx, 0)
+	if err != nil {
+		return "", nil, HandleErrorRespectJSON("failed to read commit log: %v", err)
+	}
+	var checkpoints []storage.Checkpoint
+	checkpointed := make(map[string]bool)
+	if cp != nil {
+		if checkpoints, err = cp.ListCheckpoints(ctx); err != nil {
+			return "", nil, HandleErrorRespectJSON("failed to list checkpoints: %v", err)
+		}
+		for _, c := range checkpoints {
+			checkpointed[c.Hash] = true
+		}
+	}
+
+	plan := planHistorySquash(log, cutoff, checkpointed)
+	info := map[string]interface{}{
+		"cutoff":         cutoff.Format(time.RFC3339),
+		"commits_before": len(log),
+		"squashed":       0,
+		"kept":           len(plan.Kept),
+	}
+	if plan.StoppedAt != nil {
+		info["stopped_at"] = plan.StoppedAt.Hash
+		info["stop_reason"] = plan.StopReason
+		if !jsonOutput {
+			fmt.Printf("  Stopping at %s (%s): %s\n", shortCommitHash(plan.StoppedAt.Hash), plan.StopReason, plan.StoppedAt.Message)
+		}
+	}
+	if !plan.worthwhile() {
+		if !jsonOutput {
+			fmt.Printf("  %d bd commit(s) older than the window, nothing to squash\n", plan.Squashed)
+		}
+		return "nothing to squash", info, nil
+	}
+
+	if gcDryRun {
+		info["squashed"] = plan.Squashed
+		if !jsonOutput {
+			fmt.Printf("  Would squash %d commit(s) into 1 and keep %d\n", plan.Squashed, len(plan.Kept))
+		}
+		return fmt.Sprintf("%d commits (dry-run)", plan.Squashed), info, nil
+	}
+	if !gcForce {
+		return "", nil, HandleErrorWithHintRespectJSON(
+			fmt.Sprintf("would squash %d commit(s) older than %s", plan.Squashed, cutoff.Format("2006-01-02")),
+			"Use --force to confirm or --dry-run to preview.")
+	}
+	if err := requireConfirmation(cmd, confirmOpHistory, "squash history", []string{"gc"}); err != nil {
+		return "", nil, HandleErrorRespectJSON("%v", err)
+	}
+
+	replayed, err := compactor.Compact(ctx, plan.InitialHash, plan.BoundaryHash, plan.Squashed, plan.Kept)
+	if err != nil {
+		return "", nil, HandleErrorRespectJSON("squashing history failed: %v", err)
+	}
+	commandDidWrite.Store(true)
+	moved := 0
+	if cp != nil {
+		moved = moveCheckpoints(ctx, cp, checkpoints, replayed)
+	}
+	// Remote-tracking refs still anchor the old chain; the next push or
+	// fetch re-creates them (bd-agctw).
+	pruned, _ := pruneRemoteRefsForGC(ctx)
+
+	info["squashed"] = plan.Squashed
+	info["checkpoints_moved"] = moved
+	info["remote_refs_pruned"] = len(pruned)
+	if !jsonOutput {
+		fmt.Printf("  Squashed %d commit(s) into 1, kept %d\n", plan.Squashed, len(plan.Kept))
+		if moved > 0 {
+			fmt.Printf("  Moved %d checkpoint(s) onto the kept commits\n", moved)
+		}
+		if len(pruned) > 0 {
+			fmt.Printf("  Pruned %d remote-tracking ref(s) (the next push/fetch re-creates them)\n", len(pruned))
+		}
+	}
+	return fmt.Sprintf("%d commits squashed", plan.Squashed), info, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestParseKeepHistory(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		window string
+		want   time.Time
+	}{
+		{"1y", time.Date(2025, 10, 17, 12, 0, 0, 0, time.UTC)},
+		{"6m", time.Date(2026, 4, 17, 12, 0, 0, 0, time.UTC)},
+		{"90d", time.Date(2026, 7, 19, 12, 0, 0, 0, time.UTC)},
+		{"2w", time.Date(2026, 10, 3, 12, 0, 0, 0, time.UTC)},
+	} {
+		got, err := parseKeepHistory(tc.window, now)
+		if err != nil {
+			t.Errorf("parseKeepHistory(%q): %v", tc.window, err)
+			continue
+		}
+		if !got.Equal(tc.want) {
+			t.Errorf("parseKeepHistory(%q) = %v, want %v", tc.window, got, tc.want)
+		}
+	}
+	for _, bad := range []string{"", "1", "-1y", "+1y", "1 year"} {
+		if _, err := parseKeepHistory(bad, now); err == nil {
+			t.Errorf("parseKeepHistory(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestPlanHistorySquash(t *testing.T) {
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := func(day int) time.Time { return cutoff.AddDate(0, 0, -100+day) }
+	// Oldest first here; store.Log returns newest first.
+	commits := []storage.CommitInfo{
+		{Hash: "init", Date: old(0), Message: doltInitCommitMessage},
+		{Hash: "a", Date: old(1), Message: "bd init"},
+		{Hash: "b", Date: old(2), Message: "schema: apply migrations"},
+		{Hash: "c", Date: old(3), Message: "bd: create bd-1 (auto-commit) by alice"},
+		{Hash: "d", Date: old(4), Message: "Release 1.0 planning"},
+		{Hash: "e", Date: old(5), Message: "bd: update bd-1 (auto-commit) by bob"},
+		{Hash: "f", Date: cutoff.AddDate(0, 0, 1), Message: "bd: close bd-1 (auto-commit) by bob"},
+	}
+	newestFirst := slices.Clone(commits)
+	slices.Reverse(newestFirst)
+
+	t.Run("stops at hand-made commit", func(t *testing.T) {
+		plan := planHistorySquash(newestFirst, cutoff, nil)
+		if plan.InitialHash != "init" || plan.BoundaryHash != "c" || plan.Squashed != 3 {
+			t.Errorf("got initial %q boundary %q squashed %d, want init/c/3", plan.InitialHash, plan.BoundaryHash, plan.Squashed)
+		}
+		if plan.StoppedAt == nil || plan.StoppedAt.Hash != "d" || plan.StopReason != "not made by bd" {
+			t.Errorf("expected to stop at d, got %+v (%s)", plan.StoppedAt, plan.StopReason)
+		}
+		if want := []string{"d", "e", "f"}; !slices.Equal(plan.Kept, want) {
+			t.Errorf("kept %v, want %v", plan.Kept, want)
+		}
+		if !plan.worthwhile() {
+			t.Error("expected the plan to be worthwhile")
+		}
+	})
+
+	t.Run("stops at checkpoint", func(t *testing.T) {
+		plan := planHistorySquash(newestFirst, cutoff, map[string]bool{"b": true})
+		if plan.Squashed != 1 || plan.StopReason != "checkpointed" || plan.worthwhile() {
+			t.Errorf("got squashed %d reason %q, want 1 checkpointed and not worthwhile", plan.Squashed, plan.StopReason)
+		}
+	})
+
+	t.Run("stops at cutoff", func(t *testing.T) {
+		bdOnly := slices.DeleteFunc(slices.Clone(newestFirst), func(c storage.CommitInfo) bool { return c.Hash == "d" })
+		plan := planHistorySquash(bdOnly, cutoff, nil)
+		if plan.BoundaryHash != "e" || plan.Squashed != 4 || plan.StoppedAt != nil {
+			t.Errorf("got boundary %q squashed %d stopped %+v, want e/4/nil", plan.BoundaryHash, plan.Squashed, plan.StoppedAt)
+		}
+		if want := []string{"f"}; !slices.Equal(plan.Kept, want) {
+			t.Errorf("kept %v, want %v", plan.Kept, want)
+		}
+	})
+}
@@ -41,7 +41,15 @@ Configuration can be set via 'bd config' or environment variables:
   gitlab.token / GITLAB_TOKEN                     - Personal access token
   gitlab.project_id / GITLAB_PROJECT_ID           - Project ID or path
   gitlab.group_id / GITLAB_GROUP_ID               - Group ID for group-level sync
-  gitlab.default_project_id / GITLAB_DEFAULT_PROJECT_ID - Project for creating issues in group mode`,
+  gitlab.default_project_id / GITLAB_DEFAULT_PROJECT_ID - Project for creating issues in group mode
+
+Custom label mappings extend the defaults (priority::critical..none,
+type::bug/feature/task/epic/chore):
+  gitlab.priority_map.<label> - Beads priority (0-4) for priority::<label>
+  gitlab.type_map.<label>     - Beads type for type::<label> or a bare <label>
+
+Epics sync as milestones; pulled issues in a milestone become children of
+its epic. Pulls are incremental from the last sync checkpoint.`,
 }
 
 // gitlabSyncCmd synchronizes issues between beads and GitLab.
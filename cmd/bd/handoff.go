@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// handoffEventKind is the event kind bd handoff records (see bd events).
+const handoffEventKind = "handoff"
+
+// handoffRecord describes one cross-rig handoff. It is the payload of the
+// handoff event, so its fields are what 'bd events query --event-kind
+// handoff' can filter on.
+type handoffRecord struct {
+	Issue        string `json:"issue"`
+	FromRig      string `json:"from_rig"`
+	ToRig        string `json:"to_rig"`
+	FromAssignee string `json:"from_assignee,omitempty"`
+	ToAssignee   string `json:"to_assignee,omitempty"`
+	Message      string `json:"message,omitempty"` // ID of the message sent to the target rig
+	// HeldSeconds is how long the issue had been in progress when it was
+	// handed off (0 if it was not in progress); AgeSeconds is its age.
+	HeldSeconds int64 `json:"held_s"`
+	AgeSeconds  int64 `json:"age_s"`
+}
+
+// buildHandoffRecord captures the issue's state as it leaves its rig.
+func buildHandoffRecord(issue *types.Issue, toRig, toAssignee string, now time.Time) handoffRecord {
+	rec := handoffRecord{
+		Issue:        issue.ID,
+		FromRig:      issue.Rig,
+		ToRig:        toRig,
+		FromAssignee: issue.Assignee,
+		ToAssignee:   toAssignee,
+	}
+	if !issue.CreatedAt.IsZero() {
+		rec.AgeSeconds = int64(now.Sub(issue.CreatedAt).Seconds())
+	}
+	if issue.Status == types.StatusInProgress && issue.StartedAt != nil {
+		rec.HeldSeconds = int64(now.Sub(*issue.StartedAt).Seconds())
+	}
+	return rec
+}
+
+// formatHandoffComment packages the handoff context into the comment left on
+// the issue: who handed it off and where, the context file, and the issue's
+// notes as the previous holder left them.
+func formatHandoffComment(rec handoffRecord, by, contextText, notes string) string {
+	var b strings.Builder
+	from := rec.FromRig
+	if from == "" {
+		from = "(no rig)"
+	}
+	fmt.Fprintf(&b, "Handoff from %s to rig %s by %s", from, rec.ToRig, by)
+	if rec.ToAssignee != "" {
+		fmt.Fprintf(&b, " (assigned to %s)", rec.ToAssignee)
+	}
+	b.WriteString("\n")
+	if rec.HeldSeconds > 0 {
+		fmt.Fprintf(&b, "In progress for %s", (time.Duration(rec.HeldSeconds) * time.Second).String())
+		if rec.FromAssignee != "" {
+			fmt.Fprintf(&b, " with %s", rec.FromAssignee)
+		}
+		b.WriteString("\n")
+	}
+	if s := strings.TrimSpace(contextText); s != "" {
+		b.WriteString("\n## Context\n\n")
+		b.WriteString(s)
+		b.WriteString("\n")
+	}
+	if s := strings.TrimSpace(notes); s != "" {
+		b.WriteString("\n## Notes\n\n")
+		b.WriteString(s)
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+var handoffCmd = &cobra.Command{
+	Use:     "handoff <id>",
+	GroupID: "issues",
+	Short:   "Hand an issue off to another rig",
+	Long: `Hand an issue off to another rig, carrying its context along.
+
+The issue moves to the target rig and its claim is released (or passed to
+--to), so an agent there can pick it up with 'bd ready --claim'. The
+context file and the issue's notes are added as a comment. A message is
+left in the target rig's inbox, which agents there read with
+'bd --rig <rig> list --type message', and a "handoff" event records the
+move with timing (how long the issue was held and how old it is):
+
+  bd events query --event-kind handoff --payload to_rig=west-1
+
+Handing off an issue claimed by someone else requires --force.
+
+Examples:
+  bd handoff bd-42 --to-rig west-1 --context-file notes.md
+  bd handoff bd-42 --to-rig west-1 --to agent-7 -m "needs a GPU"
+  git log -5 | bd handoff bd-42 --to-rig west-1 --context-file -`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runHandoff,
+}
+
+func init() {
+	handoffCmd.Flags().String("to-rig", "", "Rig to hand the issue to (required)")
+	handoffCmd.Flags().String("to", "", "Assign the issue to this agent in the target rig (default: unassigned)")
+	handoffCmd.Flags().String("context-file", "", "File with session context for the next holder (- for stdin)")
+	handoffCmd.Flags().StringP("message", "m", "", "Short note for the target rig's inbox")
+	handoffCmd.Flags().Bool("force", false, "Hand off an issue claimed by someone else")
+	_ = handoffCmd.MarkFlagRequired("to-rig")
+	handoffCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(handoffCmd)
+}
+
+func runHandoff(cmd *cobra.Command, args []string) error {
+	CheckReadonly("handoff")
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("handoff is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("handoff")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+	if err := ensureDirectMode("handoff requires direct database access"); err != nil {
+		return HandleError("%v", err)
+	}
+
+	toRig, _ := cmd.Flags().GetString("to-rig")
+	toAssignee, _ := cmd.Flags().GetString("to")
+	contextFile, _ := cmd.Flags().GetString("context-file")
+	note, _ := cmd.Flags().GetString("message")
+	force, _ := cmd.Flags().GetBool("force")
+	ctx := rootCtx
+
+	if err := requireRegisteredRig(toRig); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	var contextText string
+	if contextFile != "" {
+		text, err := readBodyFile(contextFile)
+		if err != nil {
+			return HandleErrorRespectJSON("reading --context-file: %v", err)
+		}
+		contextText = text
+	}
+
+	id, err := utils.ResolvePartialID(ctx, store, args[0])
+	if err != nil {
+		return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+	}
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return HandleErrorRespectJSON("issue %s not found: %v", id, err)
+	}
+	if issue.Status == types.StatusClosed {
+		return HandleErrorRespectJSON("%s is closed; reopen it before handing it off", id)
+	}
+	if issue.Rig == toRig {
+		return HandleErrorRespectJSON("%s is already in rig %s", id, toRig)
+	}
+	if issue.Status == types.StatusInProgress && issue.Assignee != "" && issue.Assignee != actor && !force {
+		return HandleErrorRespectJSON("%s is claimed by %s; coordinate with them or pass --force", id, issue.Assignee)
+	}
+
+	oooFrom := ""
+	if to, redirected := redirectForOOO(ctx, store, toAssignee); redirected {
+		oooFrom, toAssignee = toAssignee, to
+	}
+
+	rec := buildHandoffRecord(issue, toRig, toAssignee, time.Now())
+	comment := formatHandoffComment(rec, actor, contextText, issue.Notes)
+
+	inboxBody := fmt.Sprintf("%s was handed off to rig %s by %s.", id, toRig, actor)
+	if note != "" {
+		inboxBody += "\n\n" + note
+	}
+	inboxBody += fmt.Sprintf("\n\nSee the handoff comment on %s for context ('bd show %s').", id, id)
+	message := &types.Issue{
+		Title:       fmt.Sprintf("Handoff: %s (%s)", issue.Title, id),
+		Description: inboxBody,
+		Status:      types.StatusOpen,
+		Priority:    issue.Priority,
+		IssueType:   types.TypeMessage,
+		Sender:      actor,
+		Assignee:    toAssignee,
+		Rig:         toRig,
+		CreatedBy:   getActorWithGit(),
+	}
+	// Transaction-level CreateIssue does not route infra types (message is
+	// one by default) to the wisps tables, so resolve that here, as
+	// createIssueWithDeps does.
+	message.Ephemeral = store.IsInfraTypeCtx(ctx, message.IssueType)
+
+	updates := map[string]interface{}{"rig": toRig, "assignee": toAssignee}
+	if issue.Status == types.StatusInProgress {
+		// Release the claim: the next holder claims it in the target rig.
+		updates["status"] = string(types.StatusOpen)
+	}
+
+	var event *types.Issue
+	err = transact(ctx, store, fmt.Sprintf("bd: handoff %s to rig %s", id, toRig), func(tx storage.Transaction) error {
+		if err := tx.UpdateIssue(ctx, id, updates, actor); err != nil {
+			return fmt.Errorf("reassigning %s: %w", id, err)
+		}
+		if err := tx.CreateIssue(ctx, message, actor); err != nil {
+			return fmt.Errorf("notifying rig %s: %w", toRig, err)
+		}
+		if err := tx.AddDependency(ctx, &types.Dependency{
+			IssueID:     message.ID,
+			DependsOnID: id,
+			Type:        types.DepRelatesTo,
+		}, actor); err != nil {
+			return fmt.Errorf("linking inbox message: %w", err)
+		}
+
+		rec.Message = message.ID
+		payload, err := json.Marshal(rec)
+		if err != nil {
+			return fmt.Errorf("encoding handoff event: %w", err)
+		}
+		event = &types.Issue{
+			Title:     eventTitle(handoffEventKind, id),
+			Status:    types.StatusClosed,
+			Priority:  4,
+			IssueType: types.TypeEvent,
+			EventKind: handoffEventKind,
+			Actor:     actor,
+			Target:    id,
+			Payload:   string(payload),
+			CreatedBy: getActorWithGit(),
+		}
+		if err := tx.CreateIssue(ctx, event, actor); err != nil {
+			return fmt.Errorf("recording handoff event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("handing off %s: %v", id, err)
+	}
+	commandDidWrite.Store(true)
+	// Comments are not part of the storage transaction API, so the context
+	// comment is added once the handoff itself has committed.
+	if _, err := store.AddIssueComment(ctx, id, actor, comment); err != nil {
+		return HandleErrorRespectJSON("%s was handed off, but adding the context comment failed: %v", id, err)
+	}
+	if oooFrom != "" {
+		if err := recordOOORedirect(ctx, store, oooRedirect{Person: oooFrom, Delegate: toAssignee, Issue: id, Via: "handoff"}); err != nil {
+			WarnError("%v", err)
+		}
+	}
+	SetLastTouchedID(id)
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"handoff": rec,
+			"event":   event.ID,
+		})
+	}
+	fmt.Printf("%s Handed off %s to rig %s\n", ui.RenderPass("✓"), formatFeedbackID(id, issue.Title), toRig)
+	fmt.Printf("  Inbox message: %s\n", ui.RenderID(message.ID))
+	fmt.Printf("  Event:         %s\n", ui.RenderID(event.ID))
+	return nil
+}
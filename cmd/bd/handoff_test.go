@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildHandoffRecord(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	started := now.Add(-90 * time.Minute)
+	issue := &types.Issue{
+		ID:        "bd-42",
+		Status:    types.StatusInProgress,
+		Assignee:  "agent-1",
+		Rig:       "east-1",
+		CreatedAt: now.Add(-48 * time.Hour),
+		StartedAt: &started,
+	}
+
+	rec := buildHandoffRecord(issue, "west-1", "agent-7", now)
+	if rec.FromRig != "east-1" || rec.ToRig != "west-1" || rec.FromAssignee != "agent-1" || rec.ToAssignee != "agent-7" {
+		t.Errorf("record = %+v", rec)
+	}
+	if rec.HeldSeconds != 90*60 || rec.AgeSeconds != 48*3600 {
+		t.Errorf("held/age = %d/%d, want %d/%d", rec.HeldSeconds, rec.AgeSeconds, 90*60, 48*3600)
+	}
+
+	issue.Status = types.StatusOpen
+	if rec := buildHandoffRecord(issue, "west-1", "", now); rec.HeldSeconds != 0 {
+		t.Errorf("open issue held = %d, want 0", rec.HeldSeconds)
+	}
+}
+
+func TestFormatHandoffComment(t *testing.T) {
+	rec := handoffRecord{Issue: "bd-42", ToRig: "west-1", FromAssignee: "agent-1", HeldSeconds: 3600}
+	got := formatHandoffComment(rec, "alice", "Tried A and B.\n", "  Left off at step 3  ")
+	for _, want := range []string{
+		"Handoff from (no rig) to rig west-1 by alice",
+		"In progress for 1h0m0s with agent-1",
+		"## Context\n\nTried A and B.",
+		"## Notes\n\nLeft off at step 3",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("comment missing %q:\n%s", want, got)
+		}
+	}
+
+	got = formatHandoffComment(handoffRecord{FromRig: "east-1", ToRig: "west-1", ToAssignee: "agent-7"}, "alice", "", "")
+	if got != "Handoff from east-1 to rig west-1 by alice (assigned to agent-7)" {
+		t.Errorf("bare comment = %q", got)
+	}
+}
@@ -12,21 +12,27 @@ import (
 )
 
 var (
-	historyLimit  int
-	historyEvents bool
+	historyLimit   int
+	historyEvents  bool
+	historyChanges bool
 )
 
 var historyCmd = &cobra.Command{
 	Use:     "history <id>",
 	GroupID: "views",
 	Short:   "Show version history for an issue",
-	Long: `Show the complete version history of an issue, including all commits
-where the issue was modified.
+	Long: `Show the complete version history of an issue: every commit that
+changed it, newest first, with who made the commit, when, its hash, and
+each field it changed.
+
+--json prints the issue as it was at each commit; add --changes to get the
+field-by-field timeline instead.
 
 Examples:
-  bd history bd-123           # Show all history for issue bd-123
-  bd history bd-123 --limit 5 # Show last 5 changes
-  bd history bd-123 --events  # Show database audit events`,
+  bd history bd-123                  # Show all history for issue bd-123
+  bd history bd-123 --limit 5        # Show last 5 changes
+  bd history bd-123 --json --changes # Field changes as JSON
+  bd history bd-123 --events         # Show database audit events`,
 	Args:          cobra.ExactArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -41,10 +47,10 @@ Examples:
 		issueID := args[0]
 
 		if usesProxiedServer() {
-			return runHistoryProxiedServer(rootCtx, issueID, historyLimit, historyEvents)
+			return runHistoryProxiedServer(rootCtx, issueID, historyLimit, historyEvents, historyChanges)
 		}
 
-		return runHistory(rootCtx, store, issueID, historyLimit, historyEvents)
+		return runHistory(rootCtx, store, issueID, historyLimit, historyEvents, historyChanges)
 	},
 }
 
@@ -53,7 +59,7 @@ type historyBackend interface {
 	IterEvents(ctx context.Context, id string, limit int) (storage.Iter[types.Event], error)
 }
 
-func runHistory(ctx context.Context, backend historyBackend, issueID string, limit int, showEvents bool) error {
+func runHistory(ctx context.Context, backend historyBackend, issueID string, limit int, showEvents, showChanges bool) error {
 	if showEvents {
 		events, err := collectHistoryEvents(ctx, backend, issueID, limit)
 		if err != nil {
@@ -71,42 +77,48 @@ func runHistory(ctx context.Context, backend historyBackend, issueID string, lim
 		return HandleErrorRespectJSON("failed to get history: %v", err)
 	}
 
-	if len(history) == 0 {
-		if jsonOutput {
-			return outputJSON(history)
+	if jsonOutput && !showChanges {
+		if limit > 0 && limit < len(history) {
+			history = history[:limit]
 		}
-		fmt.Printf("No history found for issue %s\n", issueID)
-		return nil
+		if history == nil {
+			history = []*storage.HistoryEntry{}
+		}
+		return outputJSON(history)
 	}
 
-	if limit > 0 && limit < len(history) {
-		history = history[:limit]
+	changes := storage.IssueChangesFromHistory(history)
+	if limit > 0 && limit < len(changes) {
+		changes = changes[:limit]
 	}
-
 	if jsonOutput {
-		return outputJSON(history)
+		if changes == nil {
+			changes = []*storage.IssueChange{}
+		}
+		return outputJSON(changes)
 	}
 
-	fmt.Printf("\n%s History for %s (%d entries)\n\n",
-		ui.RenderAccent("📜"), issueID, len(history))
+	if len(changes) == 0 {
+		fmt.Printf("No history found for issue %s\n", issueID)
+		return nil
+	}
 
-	for i, entry := range history {
-		fmt.Printf("%s %s\n",
-			ui.RenderMuted(entry.CommitHash[:8]),
-			ui.RenderMuted(entry.CommitDate.Format("2006-01-02 15:04:05")))
-		fmt.Printf("  Author: %s\n", entry.Committer)
+	fmt.Printf("\n%s History for %s (%d changes)\n\n",
+		ui.RenderAccent("📜"), issueID, len(changes))
 
-		if entry.Issue != nil {
-			statusIcon := ui.GetStatusIcon(string(entry.Issue.Status))
-			fmt.Printf("  %s %s: %s [P%d - %s]\n",
-				statusIcon,
-				entry.Issue.ID,
-				entry.Issue.Title,
-				entry.Issue.Priority,
-				entry.Issue.Status)
+	for i, change := range changes {
+		fmt.Printf("%s %s\n",
+			ui.RenderMuted(shortCommitHash(change.CommitHash)),
+			ui.RenderMuted(change.CommitDate.Format("2006-01-02 15:04:05")))
+		fmt.Printf("  Author: %s\n", change.Actor)
+		if change.Created {
+			fmt.Printf("  %s\n", ui.RenderPass("Created"))
+		}
+		for _, f := range change.Fields {
+			fmt.Printf("  %s: %s\n", f.Field, formatFieldChange(f, change.Created))
 		}
 
-		if i < len(history)-1 {
+		if i < len(changes)-1 {
 			fmt.Println()
 		}
 	}
@@ -114,9 +126,51 @@ func runHistory(ctx context.Context, backend historyBackend, issueID string, lim
 	return nil
 }
 
+// historyTextFields are free-text fields too long to print inline; their
+// changes are summarized by length.
+var historyTextFields = map[string]bool{
+	"description":         true,
+	"design":              true,
+	"acceptance_criteria": true,
+	"notes":               true,
+}
+
+// formatFieldChange renders one field change for bd history: "open → closed",
+// or just the value for the fields an issue was created with.
+func formatFieldChange(f storage.FieldChange, created bool) string {
+	if historyTextFields[f.Field] {
+		switch {
+		case f.Old == "":
+			return fmt.Sprintf("set (%d chars)", len([]rune(f.New)))
+		case f.New == "":
+			return "cleared"
+		default:
+			return fmt.Sprintf("edited (%d → %d chars)", len([]rune(f.Old)), len([]rune(f.New)))
+		}
+	}
+	if created {
+		return f.New
+	}
+	show := func(v string) string {
+		if v == "" {
+			return ui.RenderMuted("(none)")
+		}
+		return v
+	}
+	return fmt.Sprintf("%s → %s", show(f.Old), show(f.New))
+}
+
+func shortCommitHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
 func init() {
 	historyCmd.Flags().IntVar(&historyLimit, "limit", 0, "Limit number of history entries (0 = all)")
 	historyCmd.Flags().BoolVar(&historyEvents, "events", false, "Show database audit events instead of commit snapshots")
+	historyCmd.Flags().BoolVar(&historyChanges, "changes", false, "With --json, output field-by-field changes instead of full snapshots")
 	historyCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(historyCmd)
 }
@@ -175,6 +175,35 @@ func TestEmbeddedHistory(t *testing.T) {
 		}
 	})
 
+	t.Run("field_changes", func(t *testing.T) {
+		out := bdHistory(t, bd, dir, issue.ID)
+		if !strings.Contains(out, "priority: 3 → 1") {
+			t.Errorf("expected the priority change in history output: %s", out)
+		}
+
+		entries := bdHistoryJSON(t, bd, dir, "--changes", issue.ID)
+		if len(entries) == 0 {
+			t.Fatal("expected non-empty change timeline")
+		}
+		latest := entries[0]
+		for _, key := range []string{"commit_hash", "actor", "commit_date", "fields"} {
+			if _, ok := latest[key]; !ok {
+				t.Errorf("expected %q key in change entry: %v", key, latest)
+			}
+		}
+		fields, _ := latest["fields"].([]interface{})
+		if len(fields) != 1 {
+			t.Fatalf("latest change fields = %v; want only the title", fields)
+		}
+		title, _ := fields[0].(map[string]interface{})
+		if title["field"] != "title" || title["old"] != "History test issue" || title["new"] != "History test issue updated" {
+			t.Errorf("latest change = %v; want the title rename", title)
+		}
+		if created, _ := entries[len(entries)-1]["created"].(bool); !created {
+			t.Errorf("oldest change should be the creation: %v", entries[len(entries)-1])
+		}
+	})
+
 	// ===== Nonexistent issue ID =====
 
 	t.Run("nonexistent_issue_empty_history", func(t *testing.T) {
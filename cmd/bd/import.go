@@ -266,7 +266,13 @@ func runImportFromReader(ctx context.Context, r io.Reader, source string) error
 	if err := scanner.Err(); err != nil {
 		return fmt.Errorf("failed to scan JSONL: %w", err)
 	}
+	return importParsedIssues(ctx, issues, memories, source)
+}
 
+// importParsedIssues writes issues and memories read from source, applying
+// --dedup, --dry-run, and --allow-stale, then commits and reports the result.
+// Board importers (bd import trello, bd import notion) share it with JSONL.
+func importParsedIssues(ctx context.Context, issues []*types.Issue, memories []memoryRecord, source string) error {
 	// Dedup: skip issues whose title matches an existing open issue
 	dedupHits := 0
 	if importDedup && len(issues) > 0 {
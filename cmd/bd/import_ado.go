@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/ado"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var importADOCmd = &cobra.Command{
+	Use:   "azure-devops",
+	Short: "Import work items from Azure DevOps",
+	Long: `Import Azure DevOps work items selected by a WIQL query.
+
+Work item types and states map to beads issue types and statuses the same
+way 'bd ado sync' maps them (see ado.type_map.* and ado.state_map.*), and
+parent/child links become parent-child dependencies. Work items imported
+before are updated in place, matched by external ref.
+
+Without --wiql, every work item in the project is imported. The import
+is one-off: it does not advance the incremental checkpoint that
+'bd ado sync' uses, so a later sync still picks up every change.
+
+Connection settings are the ones 'bd ado' uses (ado.org, ado.project,
+ado.pat, ado.url, or the AZURE_DEVOPS_* environment variables).
+
+With --attachments DIR, files attached to each work item are downloaded
+to DIR/<issue-id>/.
+
+Examples:
+  bd import azure-devops --project Fabrikam
+  bd import azure-devops --wiql "SELECT [System.Id] FROM WorkItems WHERE [System.AreaPath] UNDER 'Fabrikam\\Web'"
+  bd import azure-devops --wiql "SELECT [System.Id] FROM WorkItems WHERE [System.WorkItemType] = 'Bug'" --attachments .beads/attachments
+  bd import azure-devops --dry-run`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runImportADO,
+}
+
+// importADOResult is the JSON output of bd import azure-devops.
+type importADOResult struct {
+	DryRun      bool     `json:"dry_run"`
+	Pulled      int      `json:"pulled"`
+	Created     int      `json:"created"`
+	Updated     int      `json:"updated"`
+	Skipped     int      `json:"skipped"`
+	Errors      int      `json:"errors"`
+	Attachments int      `json:"attachments"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+func runImportADO(cmd *cobra.Command, _ []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("import azure-devops is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("import-ado")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !dryRun {
+		CheckReadonly("import azure-devops")
+	}
+	wiql, _ := cmd.Flags().GetString("wiql")
+	if wiql != "" {
+		if err := ado.ValidateWIQL(wiql); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+	}
+	attachDir, _ := cmd.Flags().GetString("attachments")
+
+	cfg := getADOConfig()
+	if project, _ := cmd.Flags().GetString("project"); project != "" {
+		cfg.Project = project
+		cfg.Projects = []string{project}
+	}
+	if err := validateADOConfig(cfg); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("database not available: %v", err)
+	}
+
+	out := cmd.OutOrStdout()
+	ctx := context.Background()
+
+	at := &ado.Tracker{}
+	if len(cfg.Projects) > 0 {
+		at.SetProjects(cfg.Projects)
+	}
+	if err := at.Init(ctx, store); err != nil {
+		return HandleErrorRespectJSON("initializing Azure DevOps tracker: %v", err)
+	}
+	if wiql != "" {
+		at.SetQuery(wiql)
+	}
+
+	engine := tracker.NewEngine(at, store, actor)
+	var warnings []string
+	if !jsonOutput {
+		engine.OnMessage = func(msg string) { _, _ = fmt.Fprintln(out, "  "+msg) }
+	}
+	engine.OnWarning = func(msg string) {
+		warnings = append(warnings, msg)
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+	}
+	engine.PullHooks = buildADOPullHooks(ctx, at, false, false, nil, engine.OnWarning)
+
+	// Remember which local issue each work item landed in, so attachments
+	// can be saved under the issue's ID once the import has committed.
+	imported := map[string]*ado.WorkItem{}
+	if attachDir != "" {
+		engine.PullHooks.AfterConvert = func(_ context.Context, extIssue *tracker.TrackerIssue, conv *tracker.IssueConversion, _ string, existing *types.Issue, _ tracker.SyncOptions) error {
+			wi, ok := extIssue.Raw.(*ado.WorkItem)
+			if !ok || conv == nil || conv.Issue == nil {
+				return nil
+			}
+			id := conv.Issue.ID
+			if existing != nil {
+				id = existing.ID
+			}
+			if id != "" {
+				imported[id] = wi
+			}
+			return nil
+		}
+	}
+
+	if dryRun && !jsonOutput {
+		_, _ = fmt.Fprintln(out, "Dry run mode - no changes will be made")
+		_, _ = fmt.Fprintln(out)
+	}
+
+	result, err := engine.Sync(ctx, tracker.SyncOptions{
+		Pull:         true,
+		DryRun:       dryRun,
+		NoCheckpoint: true,
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("importing from Azure DevOps: %v", err)
+	}
+	if !dryRun {
+		commandDidWrite.Store(true)
+	}
+
+	saved := 0
+	if attachDir != "" && !dryRun {
+		n, attachWarnings := saveADOAttachments(ctx, at.ADOClient(), imported, attachDir)
+		saved = n
+		for _, w := range attachWarnings {
+			engine.OnWarning(w)
+		}
+	}
+
+	if jsonOutput {
+		return outputJSON(importADOResult{
+			DryRun:      dryRun,
+			Pulled:      result.Stats.Pulled,
+			Created:     result.Stats.Created,
+			Updated:     result.Stats.Updated,
+			Skipped:     result.Stats.Skipped,
+			Errors:      result.Stats.Errors,
+			Attachments: saved,
+			Warnings:    append(result.Warnings, warnings...),
+		})
+	}
+	if dryRun {
+		_, _ = fmt.Fprintln(out)
+		_, _ = fmt.Fprintln(out, "Run without --dry-run to apply changes")
+		return nil
+	}
+	_, _ = fmt.Fprintf(out, "✓ Imported %d work items (%d created, %d updated)\n",
+		result.Stats.Pulled, result.Stats.Created, result.Stats.Updated)
+	if saved > 0 {
+		_, _ = fmt.Fprintf(out, "✓ Downloaded %d attachments to %s\n", saved, attachDir)
+	}
+	return nil
+}
+
+// saveADOAttachments downloads the attachments of each imported work item
+// to dir/<issue-id>/. Failures are returned as warnings so one unreadable
+// file does not undo the import.
+func saveADOAttachments(ctx context.Context, client *ado.Client, items map[string]*ado.WorkItem, dir string) (int, []string) {
+	if client == nil {
+		return 0, []string{"attachments skipped: no Azure DevOps client"}
+	}
+	saved := 0
+	var warnings []string
+	for issueID, wi := range items {
+		for _, a := range wi.Attachments() {
+			name := adoAttachmentFileName(a)
+			data, err := client.DownloadAttachment(ctx, a.URL)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("attachment %s of ADO #%d: %v", name, wi.ID, err))
+				continue
+			}
+			issueDir := filepath.Join(dir, issueID)
+			if err := os.MkdirAll(issueDir, 0o750); err != nil {
+				warnings = append(warnings, fmt.Sprintf("attachment %s of ADO #%d: %v", name, wi.ID, err))
+				continue
+			}
+			if err := os.WriteFile(filepath.Join(issueDir, name), data, 0o600); err != nil {
+				warnings = append(warnings, fmt.Sprintf("attachment %s of ADO #%d: %v", name, wi.ID, err))
+				continue
+			}
+			saved++
+		}
+	}
+	return saved, warnings
+}
+
+// adoAttachmentFileName returns a safe local file name for an attachment:
+// its display name without any directory part, falling back to the last
+// segment of its URL.
+func adoAttachmentFileName(a ado.Attachment) string {
+	name := strings.TrimSpace(a.Name)
+	if name == "" {
+		name = path.Base(strings.SplitN(a.URL, "?", 2)[0])
+	}
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == ".." || name == "/" || name == "" {
+		name = "attachment"
+	}
+	return name
+}
+
+func init() {
+	importADOCmd.Flags().String("wiql", "", "WIQL query selecting the work items to import (default: all work items in the project)")
+	importADOCmd.Flags().String("project", "", "Project to query (overrides ado.project)")
+	importADOCmd.Flags().String("attachments", "", "Download work item attachments into this directory")
+	importADOCmd.Flags().Bool("dry-run", false, "Show what would be imported without importing")
+	importCmd.AddCommand(importADOCmd)
+}
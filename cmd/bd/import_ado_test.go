@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/ado"
+)
+
+func TestADOAttachmentFileName(t *testing.T) {
+	tests := []struct {
+		a    ado.Attachment
+		want string
+	}{
+		{ado.Attachment{Name: "screenshot.png"}, "screenshot.png"},
+		{ado.Attachment{Name: "../../etc/passwd"}, "passwd"},
+		{ado.Attachment{Name: `C:\logs\build.log`}, "build.log"},
+		{ado.Attachment{URL: "https://dev.azure.com/org/_apis/wit/attachments/abc-123?fileName=x"}, "abc-123"},
+		{ado.Attachment{Name: ".."}, "attachment"},
+	}
+	for _, tt := range tests {
+		if got := adoAttachmentFileName(tt.a); got != tt.want {
+			t.Errorf("adoAttachmentFileName(%+v) = %q, want %q", tt.a, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/notion"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/trello"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var importTrelloCmd = &cobra.Command{
+	Use:   "trello <export.json|->",
+	Short: "Import cards from a Trello board export",
+	Long: `Import the cards of a Trello board from its JSON export
+(board menu → Print, export, and share → Export as JSON).
+
+Each card becomes an issue. Its status comes from the list it is in:
+lists named like "To Do" or "Backlog" are open, "Doing" or "Review" are
+in_progress, "Blocked" is blocked, "Icebox" or "Someday" are deferred, and
+"Done" is closed. Use --status-map to map other list names. Archived cards
+are imported closed (or skipped with --skip-archived). The list name is kept
+in metadata.trello_list.
+
+Card labels become labels (unnamed labels use their color), the first
+member becomes the assignee, and checklists become a markdown task list in
+the acceptance criteria, keeping each item's checked state.
+
+Cards are matched by their short URL (external_ref), so importing a newer
+export of the same board updates the issues it created.
+
+Examples:
+  bd import trello board.json
+  bd import trello board.json --status-map "QA=in_progress,Parking lot=deferred"
+  bd import trello board.json --dry-run`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		statusMap, err := boardStatusMap(cmd)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		skipArchived, _ := cmd.Flags().GetBool("skip-archived")
+		return runBoardImport("import-trello", args[0], func() ([]*types.Issue, error) {
+			return convertBoardFile(args[0], func(r io.Reader) ([]*types.Issue, error) {
+				board, err := trello.DecodeBoard(r)
+				if err != nil {
+					return nil, err
+				}
+				cfg := &trello.MappingConfig{StatusMap: statusMap, SkipArchived: skipArchived}
+				return cfg.ToIssues(board), nil
+			})
+		})
+	},
+}
+
+var importNotionCmd = &cobra.Command{
+	Use:   "notion [export.csv|-]",
+	Short: "Import rows from a Notion database export or a Notion database",
+	Long: `Import the rows of an ad-hoc Notion database, such as a team task board,
+from its CSV export (database menu → Export → Markdown & CSV), or directly
+from Notion with --url.
+
+Columns are matched by name, case-insensitively:
+  Name / Title              title (else the first column; see --title-column)
+  Status / Stage / State    status (see --status-column)
+  Tags / Labels             labels
+  Assignee / Owner          assignee (the first person)
+  Priority                  priority (Critical/High/Medium/Low or P0-P4)
+  Type                      issue type
+  Description               description
+  Due / Due date            due date
+
+Status options named like "Not started" are open, "In progress" is
+in_progress, and "Done" is closed; use --status-map for others. Checkbox
+properties become a markdown task list in the acceptance criteria. Other
+columns are kept in metadata.notion_properties.
+
+With --url, rows are read through the Notion API using notion.token (or
+NOTION_TOKEN) and matched to earlier imports by page URL, so re-importing
+updates them. CSV exports carry no page IDs; use --dedup when re-importing
+one. (For a database created by 'bd notion init', use 'bd notion sync'.)
+
+Examples:
+  bd import notion "Tasks 2f1c.csv"
+  bd import notion --url https://www.notion.so/acme/1234abcd...
+  bd import notion tasks.csv --status-map "Parked=deferred" --dedup`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		statusMap, err := boardStatusMap(cmd)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		cfg := &notion.BoardMappingConfig{StatusMap: statusMap}
+		cfg.TitleColumn, _ = cmd.Flags().GetString("title-column")
+		cfg.StatusColumn, _ = cmd.Flags().GetString("status-column")
+		toIssues := func(rows []notion.BoardRow) []*types.Issue {
+			issues := make([]*types.Issue, 0, len(rows))
+			for _, row := range rows {
+				issues = append(issues, cfg.ToIssue(row))
+			}
+			return issues
+		}
+
+		dbURL, _ := cmd.Flags().GetString("url")
+		switch {
+		case dbURL != "" && len(args) > 0:
+			return HandleErrorRespectJSON("use either an export file or --url, not both")
+		case dbURL != "":
+			return runBoardImport("import-notion", dbURL, func() ([]*types.Issue, error) {
+				rows, err := fetchNotionBoardRows(cmd.Context(), dbURL)
+				if err != nil {
+					return nil, err
+				}
+				return toIssues(rows), nil
+			})
+		case len(args) == 0:
+			return HandleErrorRespectJSON("pass a CSV export file (or - for stdin), or --url")
+		}
+		return runBoardImport("import-notion", args[0], func() ([]*types.Issue, error) {
+			return convertBoardFile(args[0], func(r io.Reader) ([]*types.Issue, error) {
+				rows, err := notion.ReadBoardCSV(r)
+				if err != nil {
+					return nil, err
+				}
+				return toIssues(rows), nil
+			})
+		})
+	},
+}
+
+// runBoardImport loads issues from a board export and imports them through
+// the same path as bd import.
+func runBoardImport(event, source string, load func() ([]*types.Issue, error)) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("import is not supported in proxied-server mode")
+	}
+	if !importDryRun {
+		CheckReadonly("import")
+	}
+	evt := metrics.NewCommandEvent(event)
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("database not available: %v", err)
+	}
+	ctx := rootCtx
+
+	issues, err := load()
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if err := linkBoardIssues(ctx, store, issues); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if source == "-" {
+		source = "stdin"
+	}
+	if err := importParsedIssues(ctx, issues, nil, source); err != nil {
+		if _, isExit := err.(*exitError); isExit {
+			return err
+		}
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if !importDryRun && len(issues) > 0 {
+		commandDidWrite.Store(true)
+	}
+	return nil
+}
+
+// convertBoardFile opens path ("-" for stdin) and converts its contents.
+func convertBoardFile(path string, convert func(io.Reader) ([]*types.Issue, error)) ([]*types.Issue, error) {
+	if path == "-" {
+		return convert(os.Stdin)
+	}
+	f, err := os.Open(path) //nolint:gosec // G304: CLI argument
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s: %w", path, err)
+	}
+	defer f.Close()
+	return convert(f)
+}
+
+// linkBoardIssues gives each converted issue the ID of the issue an earlier
+// import created for the same external ref, so re-importing updates it.
+func linkBoardIssues(ctx context.Context, st storage.DoltStorage, issues []*types.Issue) error {
+	for _, issue := range issues {
+		if issue.ExternalRef == nil || *issue.ExternalRef == "" {
+			continue
+		}
+		existing, err := st.GetIssueByExternalRef(ctx, *issue.ExternalRef)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("looking up %s: %w", *issue.ExternalRef, err)
+		}
+		if existing != nil {
+			issue.ID = existing.ID
+		}
+	}
+	return nil
+}
+
+// fetchNotionBoardRows reads every page of the Notion database at ref.
+func fetchNotionBoardRows(ctx context.Context, ref string) ([]notion.BoardRow, error) {
+	auth, err := resolveNotionAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateNotionToken(auth); err != nil {
+		return nil, err
+	}
+	client := newNotionSetupClient(auth.Token)
+	resolved, err := notion.ResolveDataSourceReference(ctx, client, ref)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := client.QueryDataSource(ctx, resolved.DataSourceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying Notion database: %w", err)
+	}
+	rows := make([]notion.BoardRow, 0, len(pages))
+	for _, page := range pages {
+		if page.InTrash || page.Archived {
+			continue
+		}
+		rows = append(rows, notion.BoardRowFromPage(page))
+	}
+	return rows, nil
+}
+
+func boardStatusMap(cmd *cobra.Command) (map[string]types.Status, error) {
+	spec, _ := cmd.Flags().GetString("status-map")
+	m, err := tracker.ParseColumnStatusMap(spec)
+	if err != nil {
+		return nil, fmt.Errorf("--status-map: %w", err)
+	}
+	return m, nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{importTrelloCmd, importNotionCmd} {
+		c.Flags().String("status-map", "", `Map column or status names to statuses, e.g. "QA=in_progress,Icebox=deferred"`)
+		c.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without importing")
+		c.Flags().BoolVar(&importDedup, "dedup", false, "Skip rows whose title matches an existing open issue")
+		c.Flags().BoolVar(&importAllowStale, "allow-stale", false, "Overwrite issues even when the export is older than the local issue")
+		importCmd.AddCommand(c)
+	}
+	importTrelloCmd.Flags().Bool("skip-archived", false, "Skip archived cards instead of importing them closed")
+	importNotionCmd.Flags().String("url", "", "Import directly from this Notion database or data source URL")
+	importNotionCmd.Flags().String("title-column", "", "Column holding the title (default: Name or Title, else the first column)")
+	importNotionCmd.Flags().String("status-column", "", "Column holding the status (default: Status, Stage, or State)")
+}
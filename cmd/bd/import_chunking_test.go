@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/progress"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -161,6 +163,50 @@ func TestImportIssuesCoreChunksLargeImports(t *testing.T) {
 	}
 }
 
+// progressRecorder is a progress.Reporter that keeps every event.
+type progressRecorder struct {
+	mu     sync.Mutex
+	events []progress.Event
+}
+
+func (r *progressRecorder) Report(ev progress.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+// With a progress reporter on the context, a chunked import reports the
+// committed row count through an "import" task instead of printing lines.
+func TestImportIssuesCoreReportsChunkProgress(t *testing.T) {
+	setImportChunkSize(t, 3)
+	recordImportPauses(t)
+	lines := setImportProgressBuffer(t)
+	rec := &progressRecorder{}
+	ctx := progress.WithReporter(context.Background(), rec)
+
+	if _, err := importIssuesCore(ctx, "", &chunkRecordingStore{}, chunkTestIssues(8), ImportOptions{SkipPrefixValidation: true}); err != nil {
+		t.Fatalf("importIssuesCore: %v", err)
+	}
+	if got := lines.String(); got != "" {
+		t.Errorf("progress lines printed alongside the reporter: %q", got)
+	}
+	var done []int
+	var finished bool
+	for _, ev := range rec.events {
+		if ev.Op != "import" || ev.Total != 8 {
+			t.Fatalf("unexpected event %+v", ev)
+		}
+		done = append(done, ev.Done)
+		finished = ev.Finished
+	}
+	if want := []int{0, 3, 6, 8, 8}; fmt.Sprint(done) != fmt.Sprint(want) {
+		t.Errorf("done counts = %v, want %v", done, want)
+	}
+	if !finished {
+		t.Error("import task not finished")
+	}
+}
+
 // Exactly chunk-size and chunk-size+1 imports: no empty trailing chunk, and
 // the boundary issue lands in a second transaction.
 func TestImportIssuesCoreChunkBoundaries(t *testing.T) {
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/progress"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/types"
@@ -43,8 +45,9 @@ var importInterChunkPause = 150 * time.Millisecond
 // importPause is the sleep seam for the inter-chunk pause, swappable in tests.
 var importPause = time.Sleep
 
-// importProgress is where chunked imports report per-chunk progress.
-// Swappable in tests.
+// importProgress is where chunked imports report per-chunk progress when
+// no progress reporter is active (see installProgressReporter). Swappable
+// in tests.
 var importProgress io.Writer = os.Stderr
 
 // ImportOptions configures import behavior.
@@ -159,13 +162,16 @@ func importIssuesCore(ctx context.Context, _ string, store storage.DoltStorage,
 		},
 	}
 	var err error
+	// The engine advances the task per row written.
+	writeCtx, task := progress.Start(ctx, "import", len(issues))
 	if len(issues) <= importChunkSize {
 		// Small import: one transaction, dependencies inline — exactly the
 		// pre-chunking behavior.
-		err = store.CreateIssuesWithFullOptions(ctx, issues, actor, batchOpts)
+		err = store.CreateIssuesWithFullOptions(writeCtx, issues, actor, batchOpts)
 	} else {
-		err = importIssuesChunked(ctx, store, issues, actor, batchOpts)
+		err = importIssuesChunked(writeCtx, store, issues, actor, batchOpts)
 	}
+	task.Finish()
 	if err != nil {
 		return nil, err
 	}
@@ -364,6 +370,7 @@ func splitDepsByChunk(deps []*types.Dependency, rowChunk int, firstChunkOf map[s
 // writeImportRowChunks writes the ordered rows (dependencies already narrowed to
 // their inline subset) in bounded transactions, pausing between commits.
 func writeImportRowChunks(ctx context.Context, store storage.DoltStorage, ordered []*types.Issue, actor string, rowOpts storage.BatchCreateOptions, pacer *importChunkPacer) error {
+	task := progress.FromContext(ctx)
 	total := len(ordered)
 	chunks := (total + importChunkSize - 1) / importChunkSize
 	for start, chunk := 0, 1; start < total; start, chunk = start+importChunkSize, chunk+1 {
@@ -372,7 +379,12 @@ func writeImportRowChunks(ctx context.Context, store storage.DoltStorage, ordere
 		if err := store.CreateIssuesWithFullOptions(ctx, ordered[start:end], actor, rowOpts); err != nil {
 			return fmt.Errorf("import chunk %d/%d failed, %d issues already committed (committed rows are durable; re-run the import to resume — it converges): %w", chunk, chunks, start, err)
 		}
-		fmt.Fprintf(importProgress, "bd import: %d/%d issues committed\n", end, total)
+		// Rows the engine counted in a retried transaction are counted
+		// twice; the commit fixes the count.
+		task.Set(end)
+		if task == nil {
+			fmt.Fprintf(importProgress, "bd import: %d/%d issues committed\n", end, total)
+		}
 	}
 	return nil
 }
@@ -409,13 +421,18 @@ func wireDeferredImportDeps(ctx context.Context, store storage.DoltStorage, defe
 	depOpts.OnStaleRejected = nil
 	depTotal := len(depRows)
 	depChunks := (depTotal + importChunkSize - 1) / importChunkSize
+	ctx, task := progress.Start(ctx, "import dependencies", depTotal)
+	defer task.Finish()
 	for start, chunk := 0, 1; start < depTotal; start, chunk = start+importChunkSize, chunk+1 {
 		end := min(start+importChunkSize, depTotal)
 		pacer.beforeTx()
 		if err := store.CreateIssuesWithFullOptions(ctx, depRows[start:end], actor, depOpts); err != nil {
 			return fmt.Errorf("import dependency pass chunk %d/%d failed (all %d issue rows are committed; re-run the import to resume — it converges): %w", chunk, depChunks, rowTotal, err)
 		}
-		fmt.Fprintf(importProgress, "bd import: deferred dependencies wired for %d/%d issues\n", end, depTotal)
+		task.Set(end)
+		if task == nil {
+			fmt.Fprintf(importProgress, "bd import: deferred dependencies wired for %d/%d issues\n", end, depTotal)
+		}
 	}
 	return nil
 }
@@ -758,12 +775,25 @@ func importRowChangeSummary(local, incoming *types.Issue) string {
 	if !intPtrEqual(local.EstimatedMinutes, incoming.EstimatedMinutes) {
 		parts = append(parts, "estimate")
 	}
-	if string(local.Metadata) != string(incoming.Metadata) {
+	if !metadataEqual(local.Metadata, incoming.Metadata) {
 		parts = append(parts, "metadata")
 	}
 	return strings.Join(parts, ", ")
 }
 
+// metadataEqual compares metadata as JSON values, since the store normalizes
+// the spacing of the JSON it returns.
+func metadataEqual(a, b json.RawMessage) bool {
+	if string(a) == string(b) {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
 func stringPtrEqual(a, b *string) bool {
 	if a == nil || b == nil {
 		return a == nil && b == nil
@@ -136,6 +136,18 @@ func TestImportRowChangeSummary(t *testing.T) {
 	}
 }
 
+func TestImportRowChangeSummaryComparesMetadataAsJSON(t *testing.T) {
+	local := &types.Issue{Title: "t", Metadata: []byte(`{"a": 1, "b": "x"}`)}
+	incoming := &types.Issue{Title: "t", Metadata: []byte(`{"b":"x","a":1}`)}
+	if s := importRowChangeSummary(local, incoming); s != "" {
+		t.Fatalf("importRowChangeSummary(reformatted metadata) = %q, want empty", s)
+	}
+	incoming.Metadata = []byte(`{"a":2,"b":"x"}`)
+	if s := importRowChangeSummary(local, incoming); s != "metadata" {
+		t.Fatalf("importRowChangeSummary(changed metadata) = %q, want metadata", s)
+	}
+}
+
 func TestImportIssuesCoreReportsStaleSkippedIDs(t *testing.T) {
 	base := time.Date(2026, 5, 27, 12, 0, 0, 0, time.UTC)
 	store := &fakeImportIssueLookupStore{issues: []*types.Issue{
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// incidentLabel marks issues opened through bd incident. It is the only
+// persistent marker: an incident is otherwise an ordinary pinned P0 bug.
+const incidentLabel = "incident"
+
+var incidentCmd = &cobra.Command{
+	Use:     "incident",
+	GroupID: "issues",
+	Short:   "Run an incident: timeline, notes, and postmortem",
+	Long: `Incident mode for operational outages.
+
+'bd incident open' creates a pinned P0 bug labeled "incident". Everything
+that happens to it afterwards — notes, status changes, comments, and the
+events of any issue linked to it by a dependency — forms the incident
+timeline. 'bd incident resolve' closes the incident and writes a postmortem
+scaffold built from that timeline.
+
+Examples:
+  bd incident open "db outage"
+  bd incident note bd-abc "failover to replica started"
+  bd dep add bd-fix bd-abc --type related   # link follow-up work
+  bd incident timeline bd-abc
+  bd incident resolve bd-abc --output postmortem.md`,
+}
+
+var incidentOpenCmd = &cobra.Command{
+	Use:           "open <title>",
+	Short:         "Open a new incident (pinned P0)",
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("incident open")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("incident is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("incident-open")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		description, _ := cmd.Flags().GetString("description")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		issue := &types.Issue{
+			Title:       strings.Join(args, " "),
+			Description: description,
+			Status:      types.StatusInProgress,
+			Priority:    0,
+			IssueType:   types.TypeBug,
+			Assignee:    assignee,
+			Pinned:      true,
+			Labels:      []string{incidentLabel},
+		}
+		if err := store.CreateIssue(rootCtx, issue, actor); err != nil {
+			return HandleErrorRespectJSON("opening incident: %v", err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(issue.ID)
+
+		if jsonOutput {
+			return outputJSON(issue)
+		}
+		fmt.Printf("%s Opened incident %s\n", ui.RenderFail("●"), formatFeedbackID(issue.ID, issue.Title))
+		fmt.Printf("  Add timeline notes: bd incident note %s \"...\"\n", issue.ID)
+		return nil
+	},
+}
+
+var incidentNoteCmd = &cobra.Command{
+	Use:           "note <id> <text...>",
+	Short:         "Add a timestamped note to the incident timeline",
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("incident note")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("incident is not supported in proxied-server mode")
+		}
+		ctx := rootCtx
+		issue, err := getIncident(ctx, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		comment, err := store.AddIssueComment(ctx, issue.ID, actor, strings.Join(args[1:], " "))
+		if err != nil {
+			return HandleErrorRespectJSON("adding note: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(comment)
+		}
+		fmt.Printf("%s %s noted on %s\n", ui.RenderPass("✓"), comment.CreatedAt.Local().Format("15:04:05"), ui.RenderID(issue.ID))
+		return nil
+	},
+}
+
+var incidentTimelineCmd = &cobra.Command{
+	Use:           "timeline <id>",
+	Short:         "Show the incident timeline",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("incident is not supported in proxied-server mode")
+		}
+		ctx := rootCtx
+		issue, err := getIncident(ctx, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		timeline, err := collectIncidentTimeline(ctx, issue)
+		if err != nil {
+			return HandleErrorRespectJSON("building timeline: %v", err)
+		}
+		if jsonOutput {
+			return outputJSON(timeline)
+		}
+		fmt.Printf("%s %s\n\n", ui.RenderID(issue.ID), issue.Title)
+		if len(timeline) == 0 {
+			fmt.Println("No timeline entries yet.")
+			return nil
+		}
+		for _, e := range timeline {
+			fmt.Printf("  %s  %-12s %s\n", e.At.Local().Format("2006-01-02 15:04:05"), e.IssueID, e.Text)
+		}
+		return nil
+	},
+}
+
+var incidentResolveCmd = &cobra.Command{
+	Use:   "resolve <id>",
+	Short: "Resolve the incident and generate a postmortem scaffold",
+	Long: `Close the incident, unpin it, and generate a postmortem scaffold.
+
+The scaffold is markdown with the incident summary, the full timeline, and
+empty root-cause / impact / action-item sections. It is printed to stdout
+unless --output names a file.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("incident resolve")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("incident is not supported in proxied-server mode")
+		}
+		ctx := rootCtx
+		issue, err := getIncident(ctx, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		reason, _ := cmd.Flags().GetString("reason")
+		output, _ := cmd.Flags().GetString("output")
+		if reason == "" {
+			reason = "Incident resolved"
+		}
+
+		if issue.Status != types.StatusClosed {
+			force, _ := cmd.Flags().GetBool("force")
+			if !force {
+				duplicateLinked := func() (bool, error) { return hasDuplicateLink(ctx, store, issue.ID) }
+				if err := checkClosePolicies(issue.ID, issue, reason, duplicateLinked); err != nil {
+					return HandleErrorRespectJSON("cannot resolve %s: %v", issue.ID, err)
+				}
+			}
+			// Unpin and close together, so a failed close cannot leave an
+			// open incident that has dropped off the pinned list.
+			err := transact(ctx, store, "bd: resolve incident "+issue.ID, func(tx storage.Transaction) error {
+				if err := tx.UpdateIssue(ctx, issue.ID, map[string]interface{}{"pinned": false}, actor); err != nil {
+					return fmt.Errorf("unpinning incident: %w", err)
+				}
+				if err := tx.CloseIssue(ctx, issue.ID, reason, actor, ""); err != nil {
+					return fmt.Errorf("closing incident: %w", err)
+				}
+				return nil
+			})
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			commandDidWrite.Store(true)
+			runPostCloseHooks(ctx, store, issue.ID, issue, actor)
+		}
+
+		resolved, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			return HandleErrorRespectJSON("reloading incident: %v", err)
+		}
+		timeline, err := collectIncidentTimeline(ctx, resolved)
+		if err != nil {
+			return HandleErrorRespectJSON("building timeline: %v", err)
+		}
+		postmortem := renderPostmortem(resolved, timeline)
+
+		if output != "" {
+			if err := os.WriteFile(output, []byte(postmortem), 0o644); err != nil { //nolint:gosec // G306: postmortem is a shareable document
+				return HandleErrorRespectJSON("writing postmortem: %v", err)
+			}
+		}
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"incident":   resolved,
+				"timeline":   timeline,
+				"postmortem": postmortem,
+				"output":     output,
+			})
+		}
+		if output != "" {
+			fmt.Printf("%s Resolved %s; postmortem written to %s\n", ui.RenderPass("✓"), formatFeedbackID(resolved.ID, resolved.Title), output)
+			return nil
+		}
+		fmt.Print(postmortem)
+		return nil
+	},
+}
+
+var incidentListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List incidents (open by default)",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("incident is not supported in proxied-server mode")
+		}
+		all, _ := cmd.Flags().GetBool("all")
+		filter := types.IssueFilter{Labels: []string{incidentLabel}}
+		if !all {
+			filter.ExcludeStatus = []types.Status{types.StatusClosed}
+		}
+		issues, err := store.SearchIssues(rootCtx, "", filter)
+		if err != nil {
+			return HandleErrorRespectJSON("listing incidents: %v", err)
+		}
+		if jsonOutput {
+			return outputJSON(issues)
+		}
+		if len(issues) == 0 {
+			fmt.Println("No incidents.")
+			return nil
+		}
+		for _, is := range issues {
+			age := time.Since(is.CreatedAt).Round(time.Minute)
+			fmt.Printf("  %s %s [%s] open %s\n", ui.RenderID(is.ID), is.Title, is.Status, age)
+		}
+		return nil
+	},
+}
+
+// getIncident resolves id and verifies it carries the incident label.
+func getIncident(ctx context.Context, id string) (*types.Issue, error) {
+	resolved, err := utils.ResolvePartialID(ctx, store, id)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", id, err)
+	}
+	issue, err := store.GetIssue(ctx, resolved)
+	if err != nil {
+		return nil, fmt.Errorf("incident %s not found: %w", id, err)
+	}
+	for _, l := range issue.Labels {
+		if l == incidentLabel {
+			return issue, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not an incident (missing %q label)", resolved, incidentLabel)
+}
+
+// incidentTimelineEntry is one timestamped line of an incident timeline.
+type incidentTimelineEntry struct {
+	At      time.Time `json:"at"`
+	IssueID string    `json:"issue_id"`
+	Kind    string    `json:"kind"` // "event" or "comment"
+	Actor   string    `json:"actor,omitempty"`
+	Text    string    `json:"text"`
+}
+
+// collectIncidentTimeline gathers events and comments from the incident and
+// every issue linked to it by a dependency in either direction.
+func collectIncidentTimeline(ctx context.Context, incident *types.Issue) ([]incidentTimelineEntry, error) {
+	ids := []string{incident.ID}
+	seen := map[string]bool{incident.ID: true}
+	deps, err := store.GetDependencies(ctx, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+	dependents, err := store.GetDependents(ctx, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, linked := range append(deps, dependents...) {
+		if !seen[linked.ID] {
+			seen[linked.ID] = true
+			ids = append(ids, linked.ID)
+		}
+	}
+
+	eventsByIssue := make(map[string][]*types.Event, len(ids))
+	commentsByIssue := make(map[string][]*types.Comment, len(ids))
+	for _, id := range ids {
+		events, err := store.GetEvents(ctx, id, 0)
+		if err != nil {
+			return nil, err
+		}
+		comments, err := store.GetIssueComments(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		eventsByIssue[id] = events
+		commentsByIssue[id] = comments
+	}
+	return buildIncidentTimeline(eventsByIssue, commentsByIssue), nil
+}
+
+// buildIncidentTimeline merges events and comments into one chronological list.
+// Comment events are dropped in favor of the comment itself, which carries
+// the full text.
+func buildIncidentTimeline(eventsByIssue map[string][]*types.Event, commentsByIssue map[string][]*types.Comment) []incidentTimelineEntry {
+	var entries []incidentTimelineEntry
+	for id, events := range eventsByIssue {
+		for _, e := range events {
+			if e.EventType == types.EventCommented {
+				continue
+			}
+			entries = append(entries, incidentTimelineEntry{
+				At: e.CreatedAt, IssueID: id, Kind: "event", Actor: e.Actor, Text: describeEvent(e),
+			})
+		}
+	}
+	for id, comments := range commentsByIssue {
+		for _, c := range comments {
+			entries = append(entries, incidentTimelineEntry{
+				At: c.CreatedAt, IssueID: id, Kind: "comment", Actor: c.Author, Text: c.Text,
+			})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].At.Equal(entries[j].At) {
+			return entries[i].At.Before(entries[j].At)
+		}
+		return entries[i].IssueID < entries[j].IssueID
+	})
+	return entries
+}
+
+// describeEvent renders an audit event as a short human-readable phrase.
+func describeEvent(e *types.Event) string {
+	val := func(p *string) string {
+		if p == nil || *p == "" {
+			return "-"
+		}
+		return *p
+	}
+	var s string
+	switch e.EventType {
+	case types.EventCreated:
+		s = "created"
+	case types.EventClosed:
+		s = "closed"
+		if e.Comment != nil && *e.Comment != "" {
+			s += ": " + *e.Comment
+		}
+	case types.EventReopened:
+		s = "reopened"
+	case types.EventStatusChanged:
+		s = fmt.Sprintf("status %s → %s", val(e.OldValue), val(e.NewValue))
+	case types.EventLabelAdded, types.EventLabelRemoved, types.EventDependencyAdded, types.EventDependencyRemoved:
+		s = fmt.Sprintf("%s %s", strings.ReplaceAll(string(e.EventType), "_", " "), val(e.NewValue))
+	default:
+		s = strings.ReplaceAll(string(e.EventType), "_", " ")
+	}
+	if e.Actor != "" {
+		s += " (" + e.Actor + ")"
+	}
+	return s
+}
+
+// renderPostmortem produces a markdown postmortem scaffold for a resolved
+// incident. The factual sections are filled from the issue and timeline; the
+// analysis sections are left as prompts for the author.
+func renderPostmortem(incident *types.Issue, timeline []incidentTimelineEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Postmortem: %s\n\n", incident.Title)
+	fmt.Fprintf(&b, "- Incident: %s\n", incident.ID)
+	fmt.Fprintf(&b, "- Opened: %s\n", incident.CreatedAt.UTC().Format(time.RFC3339))
+	if incident.ClosedAt != nil {
+		fmt.Fprintf(&b, "- Resolved: %s\n", incident.ClosedAt.UTC().Format(time.RFC3339))
+		fmt.Fprintf(&b, "- Duration: %s\n", incident.ClosedAt.Sub(incident.CreatedAt).Round(time.Minute))
+	}
+	if incident.Assignee != "" {
+		fmt.Fprintf(&b, "- Incident lead: %s\n", incident.Assignee)
+	}
+	b.WriteString("\n## Summary\n\n")
+	if incident.Description != "" {
+		b.WriteString(incident.Description + "\n")
+	} else {
+		b.WriteString("_What happened, in two or three sentences._\n")
+	}
+	b.WriteString("\n## Timeline (UTC)\n\n")
+	if len(timeline) == 0 {
+		b.WriteString("_No timeline entries were recorded._\n")
+	}
+	for _, e := range timeline {
+		text := strings.ReplaceAll(e.Text, "\n", " ")
+		if e.IssueID != incident.ID {
+			text = fmt.Sprintf("[%s] %s", e.IssueID, text)
+		}
+		fmt.Fprintf(&b, "- %s — %s\n", e.At.UTC().Format("2006-01-02 15:04:05"), text)
+	}
+	b.WriteString("\n## Impact\n\n_Who and what was affected, and for how long._\n")
+	b.WriteString("\n## Root Cause\n\n_Why it happened._\n")
+	b.WriteString("\n## What Went Well\n\n- \n")
+	b.WriteString("\n## What Went Poorly\n\n- \n")
+	b.WriteString("\n## Action Items\n\n- [ ] \n")
+	return b.String()
+}
+
+func init() {
+	incidentOpenCmd.Flags().StringP("description", "d", "", "Initial incident description")
+	incidentOpenCmd.Flags().StringP("assignee", "a", "", "Incident lead")
+	incidentResolveCmd.Flags().StringP("reason", "r", "", "Resolution summary (default \"Incident resolved\")")
+	incidentResolveCmd.Flags().StringP("output", "o", "", "Write the postmortem scaffold to this file")
+	incidentResolveCmd.Flags().BoolP("force", "f", false, "Resolve even if the close policies bd close enforces refuse it")
+	incidentListCmd.Flags().BoolP("all", "a", false, "Include resolved incidents")
+
+	incidentNoteCmd.ValidArgsFunction = issueIDCompletion
+	incidentTimelineCmd.ValidArgsFunction = issueIDCompletion
+	incidentResolveCmd.ValidArgsFunction = issueIDCompletion
+
+	incidentCmd.AddCommand(incidentOpenCmd, incidentNoteCmd, incidentTimelineCmd, incidentResolveCmd, incidentListCmd)
+	rootCmd.AddCommand(incidentCmd)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildIncidentTimelineOrdersAndMerges(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	open, inProgress := "open", "in_progress"
+	events := map[string][]*types.Event{
+		"bd-inc": {
+			{EventType: types.EventCreated, Actor: "alice", CreatedAt: base},
+			{EventType: types.EventCommented, Actor: "alice", CreatedAt: base.Add(2 * time.Minute)},
+		},
+		"bd-fix": {
+			{EventType: types.EventStatusChanged, Actor: "bob", OldValue: &open, NewValue: &inProgress, CreatedAt: base.Add(3 * time.Minute)},
+		},
+	}
+	comments := map[string][]*types.Comment{
+		"bd-inc": {{Author: "alice", Text: "failover started", CreatedAt: base.Add(2 * time.Minute)}},
+	}
+
+	got := buildIncidentTimeline(events, comments)
+	if len(got) != 3 {
+		t.Fatalf("got %d entries, want 3 (commented event folded into comment): %+v", len(got), got)
+	}
+	if got[0].Text != "created (alice)" {
+		t.Errorf("entry 0 = %q", got[0].Text)
+	}
+	if got[1].Kind != "comment" || got[1].Text != "failover started" {
+		t.Errorf("entry 1 = %+v", got[1])
+	}
+	if got[2].IssueID != "bd-fix" || got[2].Text != "status open → in_progress (bob)" {
+		t.Errorf("entry 2 = %+v", got[2])
+	}
+}
+
+func TestRenderPostmortem(t *testing.T) {
+	opened := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	closed := opened.Add(90 * time.Minute)
+	incident := &types.Issue{
+		ID:        "bd-inc",
+		Title:     "db outage",
+		Assignee:  "alice",
+		CreatedAt: opened,
+		ClosedAt:  &closed,
+	}
+	timeline := []incidentTimelineEntry{
+		{At: opened, IssueID: "bd-inc", Text: "created"},
+		{At: opened.Add(time.Minute), IssueID: "bd-fix", Text: "multi\nline"},
+	}
+
+	out := renderPostmortem(incident, timeline)
+	for _, want := range []string{
+		"# Postmortem: db outage",
+		"- Duration: 1h30m0s",
+		"- Incident lead: alice",
+		"- 2024-05-01 10:00:00 — created",
+		"- 2024-05-01 10:01:00 — [bd-fix] multi line",
+		"## Root Cause",
+		"## Action Items",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("postmortem missing %q:\n%s", want, out)
+		}
+	}
+}
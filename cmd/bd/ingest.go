@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/alertmanager"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// ingestCmd is the parent for commands that turn external signals into issues.
+var ingestCmd = &cobra.Command{
+	Use:     "ingest",
+	GroupID: "advanced",
+	Short:   "Turn external alerts into issues",
+	Long: `Convert external signals (alerts, incidents) into beads issues.
+
+Ingested issues are deduped by a stable external_ref, so the same signal
+delivered twice updates the existing issue instead of creating a new one.`,
+}
+
+var ingestAlertmanagerCmd = &cobra.Command{
+	Use:   "alertmanager [payload.json]",
+	Short: "Ingest Prometheus Alertmanager webhook payloads",
+	Long: `Ingest Prometheus Alertmanager webhook payloads as issues.
+
+Each alert is keyed by its fingerprint (external_ref "alertmanager:<fp>"):
+  - a firing alert with no issue creates one (type bug, label "alert")
+  - a firing alert whose issue was closed reopens it
+  - a resolved alert closes its open issue (disable with --no-auto-resolve)
+
+Severity maps to priority via the "severity" label:
+  critical/page=P0, high/error/major=P1, warning=P2, minor/info/low=P3, none=P4
+Override with --severity-map or config:
+  bd config set alertmanager.severity_map "critical=0,warning=1"
+
+The payload is read from a file argument, or stdin when none is given. With
+--listen, bd serves a webhook endpoint instead. The endpoint requires a
+shared secret, kept in config.yaml only so it is never pushed with the
+database:
+
+  bd config set alertmanager.webhook_secret <secret>
+
+Requests must carry it as a bearer token or as the basic-auth password;
+bd refuses to listen without it. An address without a host (":9095")
+binds to 127.0.0.1; name the host to listen more widely. Point an
+Alertmanager webhook_config at the endpoint:
+
+  receivers:
+    - name: beads
+      webhook_configs:
+        - url: http://localhost:9095/alertmanager
+          http_config:
+            authorization:
+              credentials: <secret>
+
+Examples:
+  bd ingest alertmanager payload.json
+  curl -s $AM/api/v2/alerts | ... | bd ingest alertmanager
+  bd ingest alertmanager --listen :9095`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("ingest alertmanager")
+
+		evt := metrics.NewCommandEvent("ingest")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("ingest is not supported in proxied-server mode")
+		}
+		if err := ensureDirectMode("ingest requires direct database access"); err != nil {
+			return HandleError("%v", err)
+		}
+
+		ctx := rootCtx
+		cfg, err := alertmanagerMappingConfig(ctx, cmd)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		noResolve, _ := cmd.Flags().GetBool("no-auto-resolve")
+		listen, _ := cmd.Flags().GetString("listen")
+
+		if listen != "" {
+			if len(args) > 0 {
+				return HandleError("cannot combine a payload file with --listen")
+			}
+			return serveAlertmanagerWebhook(ctx, listen, cfg, !noResolve)
+		}
+
+		var in io.Reader = os.Stdin
+		if len(args) == 1 && args[0] != "-" {
+			f, err := os.Open(args[0]) //nolint:gosec // G304: user-supplied payload path
+			if err != nil {
+				return HandleErrorRespectJSON("opening payload: %v", err)
+			}
+			defer f.Close()
+			in = f
+		}
+
+		hook, err := alertmanager.DecodeWebhook(in)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		results, err := ingestAlertmanagerWebhook(ctx, store, hook, cfg, !noResolve)
+		if len(results) > 0 {
+			commandDidWrite.Store(true)
+		}
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			return outputJSON(results)
+		}
+		printAlertIngestResults(results)
+		return nil
+	},
+}
+
+// alertIngestResult reports what happened to one alert.
+type alertIngestResult struct {
+	Fingerprint string   `json:"fingerprint"`
+	IssueID     string   `json:"issue_id,omitempty"`
+	Action      string   `json:"action"` // created, reopened, resolved, unchanged, ignored
+	Title       string   `json:"title,omitempty"`
+	InReview    bool     `json:"in_review,omitempty"` // resolved into the review queue
+	Followups   []string `json:"followups,omitempty"` // follow-ups fired by the resolve
+}
+
+// Alert ingest actions.
+const (
+	alertActionCreated   = "created"
+	alertActionReopened  = "reopened"
+	alertActionResolved  = "resolved"
+	alertActionUnchanged = "unchanged"
+	alertActionIgnored   = "ignored"
+)
+
+func alertmanagerMappingConfig(ctx context.Context, cmd *cobra.Command) (*alertmanager.MappingConfig, error) {
+	cfg := alertmanager.DefaultMappingConfig()
+	if store != nil {
+		if spec, _ := store.GetConfig(ctx, "alertmanager.severity_map"); spec != "" {
+			if err := cfg.ParseSeverityMap(spec); err != nil {
+				return nil, fmt.Errorf("config alertmanager.severity_map: %w", err)
+			}
+		}
+	}
+	if spec, _ := cmd.Flags().GetString("severity-map"); spec != "" {
+		if err := cfg.ParseSeverityMap(spec); err != nil {
+			return nil, fmt.Errorf("--severity-map: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// ingestAlertmanagerWebhook applies every alert in the payload. It keeps going
+// after a per-alert failure so one bad alert does not drop the rest of the
+// group, and returns the first error alongside the results that did apply.
+func ingestAlertmanagerWebhook(ctx context.Context, st storage.DoltStorage, hook *alertmanager.Webhook, cfg *alertmanager.MappingConfig, autoResolve bool) ([]alertIngestResult, error) {
+	results := make([]alertIngestResult, 0, len(hook.Alerts))
+	var firstErr error
+	for i := range hook.Alerts {
+		res, err := ingestAlert(ctx, st, &hook.Alerts[i], cfg, autoResolve)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("alert %s: %w", hook.Alerts[i].Fingerprint, err)
+			}
+			continue
+		}
+		results = append(results, res)
+	}
+	return results, firstErr
+}
+
+func ingestAlert(ctx context.Context, st storage.DoltStorage, a *alertmanager.Alert, cfg *alertmanager.MappingConfig, autoResolve bool) (alertIngestResult, error) {
+	res := alertIngestResult{Fingerprint: a.Fingerprint}
+
+	existing, err := st.GetIssueByExternalRef(ctx, a.ExternalRef())
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return res, err
+	}
+	if existing != nil {
+		res.IssueID = existing.ID
+		res.Title = existing.Title
+	}
+
+	if a.IsResolved() {
+		if existing == nil || existing.Status == types.StatusClosed || !autoResolve {
+			res.Action = alertActionIgnored
+			return res, nil
+		}
+		reason := "Alert resolved"
+		if !a.EndsAt.IsZero() {
+			reason = fmt.Sprintf("Alert resolved at %s", a.EndsAt.UTC().Format(time.RFC3339))
+		}
+		// An auto-resolve is a close like any other: it honors the close
+		// policies and fires review and follow-ups.
+		duplicateLinked := func() (bool, error) { return hasDuplicateLink(ctx, st, existing.ID) }
+		if err := checkClosePolicies(existing.ID, existing, reason, duplicateLinked); err != nil {
+			return res, err
+		}
+		if err := st.CloseIssue(ctx, existing.ID, reason, actor, ""); err != nil {
+			return res, err
+		}
+		res.InReview, res.Followups = runPostCloseHooks(ctx, st, existing.ID, existing, actor)
+		res.Action = alertActionResolved
+		return res, nil
+	}
+
+	switch {
+	case existing == nil:
+		issue := cfg.ToIssue(a)
+		if err := st.CreateIssue(ctx, issue, actor); err != nil {
+			return res, err
+		}
+		res.IssueID = issue.ID
+		res.Title = issue.Title
+		res.Action = alertActionCreated
+	case existing.Status == types.StatusClosed:
+		if err := st.ReopenIssue(ctx, existing.ID, "Alert firing again", actor); err != nil {
+			return res, err
+		}
+		res.Action = alertActionReopened
+	default:
+		res.Action = alertActionUnchanged
+	}
+	return res, nil
+}
+
+func printAlertIngestResults(results []alertIngestResult) {
+	if len(results) == 0 {
+		fmt.Println("No alerts in payload")
+		return
+	}
+	for _, r := range results {
+		switch r.Action {
+		case alertActionCreated:
+			fmt.Printf("%s Created %s\n", ui.RenderPass("✓"), formatFeedbackID(r.IssueID, r.Title))
+		case alertActionReopened:
+			fmt.Printf("%s Reopened %s (alert firing again)\n", ui.RenderWarn("↻"), formatFeedbackID(r.IssueID, r.Title))
+		case alertActionResolved:
+			fmt.Printf("%s Closed %s (alert resolved)\n", ui.RenderPass("✓"), formatFeedbackID(r.IssueID, r.Title))
+			if r.InReview {
+				fmt.Printf("  %s waiting for human review (bd review list)\n", ui.RenderWarn("→"))
+			}
+			for _, f := range r.Followups {
+				fmt.Printf("  %s follow-up %s created\n", ui.RenderAccent("→"), f)
+			}
+		case alertActionUnchanged:
+			fmt.Printf("  %s already open\n", formatFeedbackID(r.IssueID, r.Title))
+		default:
+			fmt.Printf("  Ignored resolved alert %s (no open issue)\n", r.Fingerprint)
+		}
+	}
+}
+
+// alertmanagerWebhookAddr binds an address without a host (":9095") to the
+// loopback interface, so the webhook is not exposed unless asked for.
+func alertmanagerWebhookAddr(addr string) string {
+	if host, port, err := net.SplitHostPort(addr); err == nil && host == "" {
+		return net.JoinHostPort("127.0.0.1", port)
+	}
+	return addr
+}
+
+// alertmanagerWebhookAuthorized reports whether r carries secret, either as a
+// bearer token or as the basic-auth password; Alertmanager's http_config
+// can send either.
+func alertmanagerWebhookAuthorized(r *http.Request, secret string) bool {
+	var got string
+	if _, pass, ok := r.BasicAuth(); ok {
+		got = pass
+	} else if auth := r.Header.Get("Authorization"); len(auth) > len("Bearer ") && strings.EqualFold(auth[:len("Bearer ")], "Bearer ") {
+		got = auth[len("Bearer "):]
+	} else {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(secret)) == 1
+}
+
+// serveAlertmanagerWebhook runs an HTTP endpoint accepting Alertmanager
+// webhook POSTs until ctx is cancelled. Requests are applied one at a time so
+// a burst of notifications for the same group cannot race on dedupe.
+func serveAlertmanagerWebhook(ctx context.Context, addr string, cfg *alertmanager.MappingConfig, autoResolve bool) error {
+	// The secret is yaml-only so it is never pushed with the database.
+	secret := config.GetString("alertmanager.webhook_secret")
+	if secret == "" {
+		return HandleError("Alertmanager webhook secret is not configured. Set it with 'bd config set alertmanager.webhook_secret <secret>' and send it from Alertmanager's http_config")
+	}
+	addr = alertmanagerWebhookAddr(addr)
+
+	var mu sync.Mutex
+	mux := http.NewServeMux()
+	mux.HandleFunc("/alertmanager", func(w http.ResponseWriter, r *http.Request) {
+		if !alertmanagerWebhookAuthorized(r, secret) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="bd"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		hook, err := alertmanager.DecodeWebhook(http.MaxBytesReader(w, r.Body, 4<<20))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		results, err := ingestAlertmanagerWebhook(r.Context(), store, hook, cfg, autoResolve)
+		if len(results) > 0 {
+			if cerr := maybeAutoCommitStore(r.Context(), store, doltAutoCommitParams{Command: "ingest alertmanager"}); cerr != nil && err == nil {
+				err = cerr
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s alertmanager ingest: %v\n", ui.RenderWarn("⚠"), err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !jsonOutput {
+			printAlertIngestResults(results)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(results)
+	})
+
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Listening for Alertmanager webhooks on %s/alertmanager (Ctrl-C to stop)\n", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return HandleError("webhook server: %v", err)
+	}
+	return nil
+}
+
+func init() {
+	ingestAlertmanagerCmd.Flags().String("severity-map", "", "Override severity mapping (e.g. critical=0,warning=1)")
+	ingestAlertmanagerCmd.Flags().Bool("no-auto-resolve", false, "Do not close issues when their alert resolves")
+	ingestAlertmanagerCmd.Flags().String("listen", "", "Serve a webhook endpoint on this address instead of reading a payload (a bare :port binds 127.0.0.1)")
+	ingestCmd.AddCommand(ingestAlertmanagerCmd)
+	rootCmd.AddCommand(ingestCmd)
+}
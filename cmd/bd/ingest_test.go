@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/alertmanager"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAlertmanagerWebhookAuthorized(t *testing.T) {
+	const secret = "s3cret"
+	tests := []struct {
+		name  string
+		setup func(r *http.Request)
+		want  bool
+	}{
+		{"no credentials", func(r *http.Request) {}, false},
+		{"bearer", func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+secret) }, true},
+		{"bearer lowercase scheme", func(r *http.Request) { r.Header.Set("Authorization", "bearer "+secret) }, true},
+		{"wrong bearer", func(r *http.Request) { r.Header.Set("Authorization", "Bearer nope") }, false},
+		{"basic auth", func(r *http.Request) { r.SetBasicAuth("alertmanager", secret) }, true},
+		{"wrong basic auth", func(r *http.Request) { r.SetBasicAuth(secret, "nope") }, false},
+	}
+	for _, tc := range tests {
+		r := httptest.NewRequest("POST", "/alertmanager", nil)
+		tc.setup(r)
+		if got := alertmanagerWebhookAuthorized(r, secret); got != tc.want {
+			t.Errorf("%s: authorized = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAlertmanagerWebhookAddrDefaultsToLoopback(t *testing.T) {
+	for in, want := range map[string]string{
+		":9095":        "127.0.0.1:9095",
+		"0.0.0.0:9095": "0.0.0.0:9095",
+		"localhost:80": "localhost:80",
+		"[::1]:9095":   "[::1]:9095",
+		"not-an-addr":  "not-an-addr",
+	} {
+		if got := alertmanagerWebhookAddr(in); got != want {
+			t.Errorf("alertmanagerWebhookAddr(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// fakeAlertStore serves one issue by external ref and records whether it
+// was closed.
+type fakeAlertStore struct {
+	storage.DoltStorage
+	issue  *types.Issue
+	closed bool
+}
+
+func (f *fakeAlertStore) GetIssueByExternalRef(context.Context, string) (*types.Issue, error) {
+	return f.issue, nil
+}
+
+func (f *fakeAlertStore) CloseIssue(context.Context, string, string, string, string) error {
+	f.closed = true
+	return nil
+}
+
+func TestIngestAlertResolveChecksClosePolicies(t *testing.T) {
+	initConfigForTest(t)
+	config.Set("validation.acceptance", "error")
+
+	st := &fakeAlertStore{issue: &types.Issue{ID: "bd-9", Title: "Disk full", Status: types.StatusOpen,
+		IssueType: types.TypeBug, AcceptanceCriteria: "- [ ] postmortem written"}}
+	alert := &alertmanager.Alert{Status: alertmanager.StatusResolved, Fingerprint: "abc"}
+	_, err := ingestAlert(context.Background(), st, alert, alertmanager.DefaultMappingConfig(), true)
+	if st.closed {
+		t.Fatal("auto-resolve skipped the acceptance criteria policy")
+	}
+	if err == nil || !strings.Contains(err.Error(), "acceptance criteria") {
+		t.Errorf("err = %v, want acceptance criteria refusal", err)
+	}
+}
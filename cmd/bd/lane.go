@@ -0,0 +1,410 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// laneLabelPrefix marks an explicit lane assignment ("lane:backend"). An
+// explicit lane always wins over lanes derived from other labels.
+const laneLabelPrefix = "lane:"
+
+// laneNone names the bucket of issues that belong to no lane.
+const laneNone = "(none)"
+
+// laneConfig is one lane from the lanes.labels / lanes.wip config maps.
+type laneConfig struct {
+	Name     string   `json:"name"`
+	Labels   []string `json:"labels,omitempty"`    // Labels that place an issue in the lane
+	WIPLimit int      `json:"wip_limit,omitempty"` // Max in_progress issues; 0 = unlimited
+}
+
+// laneConfigs returns the configured lanes sorted by name. A lane exists if
+// it appears under lanes.labels or lanes.wip. A label may map to only one
+// lane, so label-derived lanes never overlap for single-lane issues.
+func laneConfigs() ([]laneConfig, error) {
+	labelMap := config.GetStringMapString("lanes.labels")
+	wipMap := config.GetStringMapString("lanes.wip")
+	byName := make(map[string]*laneConfig)
+	lane := func(name string) *laneConfig {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if byName[name] == nil {
+			byName[name] = &laneConfig{Name: name}
+		}
+		return byName[name]
+	}
+
+	owner := make(map[string]string)
+	for name, raw := range labelMap {
+		l := lane(name)
+		for _, label := range utils.NormalizeLabels(strings.Split(raw, ",")) {
+			if prev, ok := owner[label]; ok && prev != l.Name {
+				return nil, fmt.Errorf("lanes.labels: label %q is mapped to both %s and %s", label, prev, l.Name)
+			}
+			owner[label] = l.Name
+			l.Labels = append(l.Labels, label)
+		}
+	}
+	for name, raw := range wipMap {
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("lanes.wip.%s: expected a non-negative integer, got %q", name, raw)
+		}
+		lane(name).WIPLimit = n
+	}
+
+	lanes := make([]laneConfig, 0, len(byName))
+	for _, l := range byName {
+		sort.Strings(l.Labels)
+		lanes = append(lanes, *l)
+	}
+	sort.Slice(lanes, func(i, j int) bool { return lanes[i].Name < lanes[j].Name })
+	return lanes, nil
+}
+
+// findLane returns the configured lane called name.
+func findLane(lanes []laneConfig, name string) (laneConfig, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, l := range lanes {
+		if l.Name == name {
+			return l, nil
+		}
+	}
+	if len(lanes) == 0 {
+		return laneConfig{}, fmt.Errorf("unknown lane %q: no lanes are configured (see bd lane --help)", name)
+	}
+	names := make([]string, len(lanes))
+	for i, l := range lanes {
+		names[i] = l.Name
+	}
+	return laneConfig{}, fmt.Errorf("unknown lane %q (configured: %s)", name, strings.Join(names, ", "))
+}
+
+// laneLabelFilter returns the label filters that select lane's issues: its
+// explicit lane label or any of its labels, minus issues explicitly placed in
+// another lane.
+func laneLabelFilter(lanes []laneConfig, lane laneConfig) (labelsAny, excludeLabels []string) {
+	labelsAny = append([]string{laneLabelPrefix + lane.Name}, lane.Labels...)
+	for _, l := range lanes {
+		if l.Name != lane.Name {
+			excludeLabels = append(excludeLabels, laneLabelPrefix+l.Name)
+		}
+	}
+	return labelsAny, excludeLabels
+}
+
+// noLaneLabelFilter returns the exclusions that select issues in no lane.
+func noLaneLabelFilter(lanes []laneConfig) []string {
+	var exclude []string
+	for _, l := range lanes {
+		exclude = append(exclude, laneLabelPrefix+l.Name)
+		exclude = append(exclude, l.Labels...)
+	}
+	return exclude
+}
+
+// issueLanes returns the lanes an issue's labels place it in: its explicit
+// lane if it has one, otherwise every lane whose labels it carries. More than
+// one result means the issue shows up in several lane queues.
+func issueLanes(lanes []laneConfig, labels []string) []string {
+	for _, label := range labels {
+		if name, ok := strings.CutPrefix(label, laneLabelPrefix); ok {
+			return []string{name}
+		}
+	}
+	var matched []string
+	for _, l := range lanes {
+		for _, label := range l.Labels {
+			if slices.Contains(labels, label) {
+				matched = append(matched, l.Name)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// laneWIP counts lane's in_progress issues.
+func laneWIP(ctx context.Context, s storage.DoltStorage, lanes []laneConfig, lane laneConfig) (int, error) {
+	labelsAny, excludeLabels := laneLabelFilter(lanes, lane)
+	inProgress := types.StatusInProgress
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{
+		Status:        &inProgress,
+		LabelsAny:     labelsAny,
+		ExcludeLabels: excludeLabels,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("counting in-progress issues in lane %s: %w", lane.Name, err)
+	}
+	return len(issues), nil
+}
+
+// laneStats is one row of 'bd lane list'.
+type laneStats struct {
+	laneConfig
+	Ready      int  `json:"ready"`
+	InProgress int  `json:"in_progress"`
+	AtLimit    bool `json:"at_wip_limit"`
+}
+
+var laneCmd = &cobra.Command{
+	Use:     "lane",
+	GroupID: "views",
+	Short:   "Partition ready work into lanes",
+	Long: `Lanes split the ready queue so specialized agents can pull from disjoint
+queues ('bd ready --lane backend').
+
+An issue is in a lane when it has the explicit label lane:<name> (set with
+'bd lane set'), or otherwise carries one of the lane's labels. An explicit
+lane always wins. Configure lanes and their WIP limits in config.yaml:
+
+  lanes:
+    labels:
+      backend: "api,db"
+      frontend: "ui,css"
+      docs: ""
+    wip:
+      backend: 3
+
+A label may belong to only one lane. An issue carrying labels of two lanes
+shows up in both until it is given an explicit lane.
+
+When a lane has as many in_progress issues as its WIP limit,
+'bd ready --lane' shows no new work for it and 'bd ready --lane --claim'
+claims nothing.`,
+}
+
+var laneListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "Show lanes with ready counts and WIP",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("lane is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("lane-list")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		lanes, err := laneConfigs()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		stats := make([]laneStats, 0, len(lanes)+1)
+		for _, lane := range lanes {
+			labelsAny, excludeLabels := laneLabelFilter(lanes, lane)
+			ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, LabelsAny: labelsAny, ExcludeLabels: excludeLabels})
+			if err != nil {
+				return HandleErrorRespectJSON("lane %s: %v", lane.Name, err)
+			}
+			wip, err := laneWIP(ctx, store, lanes, lane)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			stats = append(stats, laneStats{
+				laneConfig: lane,
+				Ready:      len(ready),
+				InProgress: wip,
+				AtLimit:    lane.WIPLimit > 0 && wip >= lane.WIPLimit,
+			})
+		}
+		if len(lanes) > 0 {
+			ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, ExcludeLabels: noLaneLabelFilter(lanes)})
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			stats = append(stats, laneStats{laneConfig: laneConfig{Name: laneNone}, Ready: len(ready)})
+		}
+
+		if jsonOutput {
+			return outputJSON(stats)
+		}
+		if len(lanes) == 0 {
+			fmt.Println("No lanes configured (see bd lane --help)")
+			return nil
+		}
+		fmt.Printf("%-12s %6s %6s  %s\n", "LANE", "READY", "WIP", "LABELS")
+		for _, s := range stats {
+			wip := strconv.Itoa(s.InProgress)
+			if s.WIPLimit > 0 {
+				wip = fmt.Sprintf("%d/%d", s.InProgress, s.WIPLimit)
+			}
+			if s.AtLimit {
+				wip = ui.RenderWarn(fmt.Sprintf("%6s", wip))
+			}
+			if s.Name == laneNone {
+				wip = "-"
+			}
+			fmt.Printf("%-12s %6d %6s  %s\n", s.Name, s.Ready, wip, ui.RenderMuted(strings.Join(s.Labels, ", ")))
+		}
+		return nil
+	},
+}
+
+var laneSetCmd = &cobra.Command{
+	Use:           "set <lane> <id>...",
+	Short:         "Put issues in a lane explicitly",
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("lane set")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("lane is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("lane-set")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		lanes, err := laneConfigs()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		lane, err := findLane(lanes, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		return setIssueLanes(args[1:], lane.Name)
+	},
+}
+
+var laneClearCmd = &cobra.Command{
+	Use:           "clear <id>...",
+	Short:         "Remove explicit lane assignments",
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("lane clear")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("lane is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("lane-clear")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		return setIssueLanes(args, "")
+	},
+}
+
+// setIssueLanes replaces the explicit lane label of each issue with lane's,
+// or removes it when lane is empty.
+func setIssueLanes(args []string, lane string) error {
+	ctx := rootCtx
+	ids := make([]string, 0, len(args))
+	for _, arg := range args {
+		id, err := utils.ResolvePartialID(ctx, store, arg)
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", arg, err)
+		}
+		ids = append(ids, id)
+	}
+
+	commitMsg := fmt.Sprintf("bd: lane set '%s' on %d issue(s)", lane, len(ids))
+	if lane == "" {
+		commitMsg = fmt.Sprintf("bd: lane clear on %d issue(s)", len(ids))
+	}
+	err := transactHonoringAutoCommit(ctx, store, commitMsg, func(tx storage.Transaction) error {
+		for _, id := range ids {
+			labels, err := tx.GetLabels(ctx, id)
+			if err != nil {
+				return fmt.Errorf("getting labels of %s: %w", id, err)
+			}
+			for _, label := range labels {
+				if strings.HasPrefix(label, laneLabelPrefix) && label != laneLabelPrefix+lane {
+					if err := tx.RemoveLabel(ctx, id, label, actor); err != nil {
+						return fmt.Errorf("removing %s from %s: %w", label, id, err)
+					}
+				}
+			}
+			if lane != "" && !slices.Contains(labels, laneLabelPrefix+lane) {
+				if err := tx.AddLabel(ctx, id, laneLabelPrefix+lane, actor); err != nil {
+					return fmt.Errorf("adding %s%s to %s: %w", laneLabelPrefix, lane, id, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	commandDidWrite.Store(true)
+	SetLastTouchedID(ids[len(ids)-1])
+
+	if jsonOutput {
+		results := make([]map[string]string, len(ids))
+		for i, id := range ids {
+			results[i] = map[string]string{"issue_id": id, "lane": lane}
+		}
+		return outputJSON(results)
+	}
+	for _, id := range ids {
+		if lane == "" {
+			fmt.Printf("%s Cleared lane of %s\n", ui.RenderPass("✓"), id)
+		} else {
+			fmt.Printf("%s Put %s in lane %s\n", ui.RenderPass("✓"), id, lane)
+		}
+	}
+	return nil
+}
+
+// applyReadyLane narrows filter to lane's queue. It reports whether the lane
+// is at its WIP limit, in which case callers hold back new work.
+func applyReadyLane(ctx context.Context, s storage.DoltStorage, filter *types.WorkFilter, name string) (atLimit bool, err error) {
+	lanes, err := laneConfigs()
+	if err != nil {
+		return false, err
+	}
+	lane, err := findLane(lanes, name)
+	if err != nil {
+		return false, err
+	}
+	labelsAny, excludeLabels := laneLabelFilter(lanes, lane)
+	filter.LabelsAny = labelsAny
+	filter.ExcludeLabels = append(filter.ExcludeLabels, excludeLabels...)
+	if lane.WIPLimit == 0 {
+		return false, nil
+	}
+	wip, err := laneWIP(ctx, s, lanes, lane)
+	if err != nil {
+		return false, err
+	}
+	if wip >= lane.WIPLimit {
+		fmt.Fprintf(os.Stderr, "%s Lane %s is at its WIP limit (%d/%d in progress); finish work before pulling more\n",
+			ui.RenderWarn("⚠"), lane.Name, wip, lane.WIPLimit)
+		return true, nil
+	}
+	return false, nil
+}
+
+func init() {
+	laneSetCmd.ValidArgsFunction = issueIDCompletion
+	laneClearCmd.ValidArgsFunction = issueIDCompletion
+	laneCmd.AddCommand(laneListCmd, laneSetCmd, laneClearCmd)
+	rootCmd.AddCommand(laneCmd)
+}
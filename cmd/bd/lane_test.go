@@ -0,0 +1,72 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+func TestLaneConfigs(t *testing.T) {
+	initConfigForTest(t)
+
+	if lanes, err := laneConfigs(); err != nil || len(lanes) != 0 {
+		t.Fatalf("no lanes expected by default, got %v, %v", lanes, err)
+	}
+
+	config.Set("lanes.labels", map[string]string{"backend": "db, api", "frontend": "ui"})
+	config.Set("lanes.wip", map[string]string{"backend": "2", "docs": "1"})
+	lanes, err := laneConfigs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lanes) != 3 || lanes[0].Name != "backend" || lanes[1].Name != "docs" || lanes[2].Name != "frontend" {
+		t.Fatalf("lanes = %+v, want backend, docs, frontend", lanes)
+	}
+	if !slices.Equal(lanes[0].Labels, []string{"api", "db"}) || lanes[0].WIPLimit != 2 {
+		t.Errorf("backend = %+v", lanes[0])
+	}
+
+	labelsAny, exclude := laneLabelFilter(lanes, lanes[0])
+	if !slices.Equal(labelsAny, []string{"lane:backend", "api", "db"}) {
+		t.Errorf("labelsAny = %v", labelsAny)
+	}
+	if !slices.Equal(exclude, []string{"lane:docs", "lane:frontend"}) {
+		t.Errorf("excludeLabels = %v", exclude)
+	}
+
+	if _, err := findLane(lanes, "ops"); err == nil {
+		t.Error("expected an error for an unknown lane")
+	}
+
+	config.Set("lanes.labels", map[string]string{"backend": "api", "frontend": "api"})
+	if _, err := laneConfigs(); err == nil {
+		t.Error("expected an error for a label mapped to two lanes")
+	}
+	config.Set("lanes.labels", map[string]string{})
+	config.Set("lanes.wip", map[string]string{"backend": "-1"})
+	if _, err := laneConfigs(); err == nil {
+		t.Error("expected an error for a negative WIP limit")
+	}
+}
+
+func TestIssueLanes(t *testing.T) {
+	lanes := []laneConfig{
+		{Name: "backend", Labels: []string{"api"}},
+		{Name: "frontend", Labels: []string{"ui"}},
+	}
+	tests := []struct {
+		labels []string
+		want   []string
+	}{
+		{[]string{"api"}, []string{"backend"}},
+		{[]string{"api", "ui"}, []string{"backend", "frontend"}},
+		{[]string{"api", "lane:frontend"}, []string{"frontend"}},
+		{[]string{"docs"}, nil},
+	}
+	for _, tt := range tests {
+		if got := issueLanes(lanes, tt.labels); !slices.Equal(got, tt.want) {
+			t.Errorf("issueLanes(%v) = %v, want %v", tt.labels, got, tt.want)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/query"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
@@ -48,20 +49,23 @@ func withFetchOneExtra(filter types.IssueFilter) types.IssueFilter {
 
 func readyWorkFilterFromIssueFilter(filter types.IssueFilter) types.WorkFilter {
 	wf := types.WorkFilter{
-		Status:         types.StatusOpen,
-		Limit:          filter.Limit,
-		Offset:         filter.Offset,
-		Labels:         filter.Labels,
-		LabelsAny:      filter.LabelsAny,
-		ExcludeLabels:  filter.ExcludeLabels,
-		LabelPattern:   filter.LabelPattern,
-		LabelRegex:     filter.LabelRegex,
-		ParentID:       filter.ParentID,
-		MolType:        filter.MolType,
-		WispType:       filter.WispType,
-		ExcludeTypes:   filter.ExcludeTypes,
-		MetadataFields: filter.MetadataFields,
-		HasMetadataKey: filter.HasMetadataKey,
+		Status:          types.StatusOpen,
+		Limit:           filter.Limit,
+		Offset:          filter.Offset,
+		Labels:          filter.Labels,
+		LabelsAny:       filter.LabelsAny,
+		ExcludeLabels:   filter.ExcludeLabels,
+		LabelPattern:    filter.LabelPattern,
+		LabelRegex:      filter.LabelRegex,
+		ParentID:        filter.ParentID,
+		MolType:         filter.MolType,
+		WispType:        filter.WispType,
+		ExcludeTypes:    filter.ExcludeTypes,
+		MetadataFields:  filter.MetadataFields,
+		HasMetadataKey:  filter.HasMetadataKey,
+		MinQualityScore: filter.MinQualityScore,
+		MinReopenCount:  filter.MinReopenCount,
+		Rig:             filter.Rig,
 	}
 	if filter.IssueType != nil {
 		wf.Type = string(*filter.IssueType)
@@ -283,12 +287,56 @@ func compareIssuesBy(a, b *types.Issue, sortBy string) int {
 		return cmp.Compare(a.Status, b.Status)
 	case "id":
 		return utils.NaturalCompareIDs(a.ID, b.ID)
+	case "prefix":
+		// Group by ID prefix, then number within each prefix.
+		return cmp.Or(
+			cmp.Compare(utils.ExtractIssuePrefix(a.ID), utils.ExtractIssuePrefix(b.ID)),
+			utils.NaturalCompareIDs(a.ID, b.ID),
+		)
 	case "title":
 		return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
 	case "type":
 		return cmp.Compare(a.IssueType, b.IssueType)
 	case "assignee":
 		return cmp.Compare(a.Assignee, b.Assignee)
+	case "due":
+		if a.DueAt == nil && b.DueAt == nil {
+			return 0
+		} else if a.DueAt == nil {
+			return -1
+		} else if b.DueAt == nil {
+			return 1
+		}
+		return a.DueAt.Compare(*b.DueAt)
+	}
+	if name, ok := strings.CutPrefix(sortBy, "derived."); ok {
+		return query.CompareDerived(a, b, name)
+	}
+	return 0
+}
+
+// sortKeysForSpec splits a --sort spec into the keys sortIssues compares
+// by. A field only bd sorts (prefix, derived.<name>) is a single key in its
+// default direction.
+func sortKeysForSpec(sortBy string) []types.SortKey {
+	keys, err := types.ParseSortKeys(sortBy)
+	if err != nil {
+		return []types.SortKey{{Field: sortBy}}
+	}
+	return keys
+}
+
+// compareIssuesByKeys compares by each key in turn. compareIssuesBy orders
+// a field in its default direction, so a key sorting the other way flips it.
+func compareIssuesByKeys(a, b *types.Issue, keys []types.SortKey) int {
+	for _, k := range keys {
+		r := compareIssuesBy(a, b, k.Field)
+		if k.Desc != types.DefaultSortDesc(k.Field) {
+			r = -r
+		}
+		if r != 0 {
+			return r
+		}
 	}
 	return 0
 }
@@ -297,8 +345,9 @@ func sortIssues(issues []*types.Issue, sortBy string, reverse bool) {
 	if sortBy == "" {
 		return
 	}
-	slices.SortFunc(issues, func(a, b *types.Issue) int {
-		r := compareIssuesBy(a, b, sortBy)
+	keys := sortKeysForSpec(sortBy)
+	slices.SortStableFunc(issues, func(a, b *types.Issue) int {
+		r := compareIssuesByKeys(a, b, keys)
 		if reverse {
 			return -r
 		}
@@ -310,7 +359,8 @@ func sortIssuesWithCounts(items []*types.IssueWithCounts, sortBy string, reverse
 	if sortBy == "" {
 		return
 	}
-	slices.SortFunc(items, func(a, b *types.IssueWithCounts) int {
+	keys := sortKeysForSpec(sortBy)
+	slices.SortStableFunc(items, func(a, b *types.IssueWithCounts) int {
 		ai, bi := issueOrNil(a), issueOrNil(b)
 		if ai == nil {
 			if bi == nil {
@@ -321,7 +371,7 @@ func sortIssuesWithCounts(items []*types.IssueWithCounts, sortBy string, reverse
 		if bi == nil {
 			return -1
 		}
-		r := compareIssuesBy(ai, bi, sortBy)
+		r := compareIssuesByKeys(ai, bi, keys)
 		if reverse {
 			return -r
 		}
@@ -336,6 +386,23 @@ func issueOrNil(iwc *types.IssueWithCounts) *types.Issue {
 	return iwc.Issue
 }
 
+// listDependencyRecords returns the dependency records the tree and --format
+// views draw. An --as-of listing already carries its snapshot's records on
+// the issues; otherwise they are the store's current ones.
+func listDependencyRecords(ctx context.Context, store storage.DoltStorage, in listInput, issues []*types.Issue) map[string][]*types.Dependency {
+	if in.asOf == "" {
+		deps, _ := store.GetAllDependencyRecords(ctx)
+		return deps
+	}
+	deps := make(map[string][]*types.Dependency, len(issues))
+	for _, issue := range issues {
+		if len(issue.Dependencies) > 0 {
+			deps[issue.ID] = issue.Dependencies
+		}
+	}
+	return deps
+}
+
 // skipLabelsIssueView wraps IssueWithCounts so the JSON encoder always emits
 // `labels: []` regardless of the omitempty tag on Issue.Labels. AD-02 contract:
 // with --skip-labels, every issue's labels field is present and empty.
@@ -476,23 +543,45 @@ var listCmd = &cobra.Command{
 // the `bd children` alias emits "children" exactly once. children sets listCmd's
 // flags and calls this core directly rather than listCmd.RunE, which would emit
 // a second "list" event for a single user command.
-func runListCore(cmd *cobra.Command, _ []string) error {
+func runListCore(cmd *cobra.Command, _ []string) (err error) {
 	in, err := gatherListInput(cmd)
 	if err != nil {
 		return err
 	}
 
+	if !in.watchMode && !in.selectMode {
+		listPaging, listTruncated = true, false
+		defer func() {
+			listPaging = false
+			if err == nil {
+				saveListMoreState(cmd, in, listTruncated)
+			}
+		}()
+	}
+
+	if in.selectMode {
+		if usesProxiedServer() {
+			return HandleError("list --select is not supported in proxied-server mode")
+		}
+		CheckReadonly("list --select")
+	}
+
 	if usesProxiedServer() {
+		if in.asOf != "" {
+			return HandleError("list --as-of is not supported in proxied-server mode")
+		}
+		if in.cursor != "" {
+			return HandleError("list --cursor is not supported in proxied-server mode")
+		}
+		if strings.HasPrefix(in.sortBy, "derived.") {
+			return HandleError("--sort %s is not supported in proxied-server mode", in.sortBy)
+		}
 		if err := runListProxiedServer(cmd, rootCtx, in); err != nil {
 			return HandleError("%v", err)
 		}
 		return nil
 	}
 
-	if in.offset > 0 {
-		return HandleError("--offset is only supported under --proxied-server")
-	}
-
 	cfg, err := loadDirectListFilterConfig(rootCtx, store)
 	if err != nil {
 		return HandleError("%v", err)
@@ -501,6 +590,19 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return HandleError("%v", err)
 	}
+	// Direct stores merge issues and wisps in Go rather than paging in SQL,
+	// so fetch through the offset and drop the skipped rows after sorting.
+	if in.offset > 0 {
+		if filter.Limit > 0 {
+			filter.Limit += in.offset
+		}
+		filter.Offset = 0
+	}
+	// The tree and --format views draw dependencies; at --as-of they must
+	// come from the same snapshot as the issues, not the current table.
+	if in.asOf != "" {
+		filter.IncludeDependencies = true
+	}
 
 	ctx := rootCtx
 
@@ -512,6 +614,9 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 	if routed {
 		defer func() { _ = routedStore.Close() }()
 		activeStore = routedStore
+		if in.selectMode {
+			return HandleError("--select cannot modify issues in a routed repository")
+		}
 	}
 
 	if in.watchMode {
@@ -519,6 +624,10 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	if in.cursor != "" {
+		return runListCursorPage(ctx, activeStore, filter, in)
+	}
+
 	if jsonOutput {
 		var iwc []*types.IssueWithCounts
 		var err error
@@ -530,7 +639,11 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 		if err != nil {
 			return HandleError("%v", err)
 		}
+		if err := computeDerivedFieldsWithCounts(ctx, activeStore, iwc, in.derivedFields); err != nil {
+			return HandleError("%v", err)
+		}
 		sortIssuesWithCounts(iwc, in.sortBy, in.reverse)
+		iwc = skipListOffset(iwc, in.offset)
 		truncated := in.effectiveLimit > 0 && len(iwc) > in.effectiveLimit
 		if truncated {
 			iwc = iwc[:in.effectiveLimit]
@@ -538,6 +651,9 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 		if iwc == nil {
 			iwc = []*types.IssueWithCounts{}
 		}
+		if in.includeWisps {
+			tagIssueSources(iwc)
+		}
 		if in.skipLabels {
 			if err := outputJSON(newSkipLabelsListJSONResponse(iwc)); err != nil {
 				return err
@@ -568,15 +684,23 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
+	if err := computeDerivedFields(ctx, activeStore, issues, in.derivedFields); err != nil {
+		return HandleError("%v", err)
+	}
 	sortIssues(issues, in.sortBy, in.reverse)
+	issues = skipListOffset(issues, in.offset)
 
 	truncated := in.effectiveLimit > 0 && len(issues) > in.effectiveLimit
 	if truncated {
 		issues = issues[:in.effectiveLimit]
 	}
 
+	if in.selectMode {
+		return runListSelect(ctx, activeStore, issues)
+	}
+
 	if in.prettyFormat && !jsonOutput {
-		if in.parentID != "" && !in.readyFlag {
+		if in.parentID != "" && !in.readyFlag && in.asOf == "" {
 			treeIssues, err := getHierarchicalChildren(ctx, activeStore, "", in.parentID, filter)
 			if err != nil {
 				return HandleError("%v", err)
@@ -593,7 +717,7 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 			return nil
 		}
 
-		allDeps, _ := activeStore.GetAllDependencyRecords(ctx)
+		allDeps := listDependencyRecords(ctx, activeStore, in, issues)
 		displayPrettyListWithDeps(issues, false, allDeps)
 		printTruncationHint(truncated, in.effectiveLimit)
 		printSkipLabelsFooter(in.skipLabels)
@@ -601,7 +725,7 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 	}
 
 	if in.formatStr != "" {
-		depsByIssueID, _ := activeStore.GetAllDependencyRecords(ctx)
+		depsByIssueID := listDependencyRecords(ctx, activeStore, in, issues)
 		if err := outputFormattedList(issues, depsByIssueID, in.formatStr); err != nil {
 			return HandleError("%v", err)
 		}
@@ -620,7 +744,13 @@ func runListCore(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
-	blockedByMap, blocksMap, parentMap, _ := activeStore.GetBlockingInfoForIssues(ctx, issueIDs)
+	// Blocking annotations reflect the current graph, so a historical
+	// listing goes without them.
+	var blockedByMap, blocksMap map[string][]string
+	var parentMap map[string]string
+	if in.asOf == "" {
+		blockedByMap, blocksMap, parentMap, _ = activeStore.GetBlockingInfoForIssues(ctx, issueIDs)
+	}
 
 	var buf strings.Builder
 	if ui.IsAgentMode() {
@@ -673,13 +803,16 @@ func init() {
 	listCmd.Flags().String("label-regex", "", "Filter by label regex pattern (e.g., 'tech-(debt|legacy)')")
 	listCmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
 	listCmd.Flags().String("spec", "", "Filter by spec_id prefix")
+	listCmd.Flags().String("prefix", "", "Filter by ID prefix (e.g., bug for bug-123)")
 	listCmd.Flags().String("id", "", "Filter by specific issue IDs (comma-separated, e.g., bd-1,bd-5,bd-10)")
 	listCmd.Flags().IntP("limit", "n", 50, "Limit results (default 50, use 0 for unlimited)")
-	listCmd.Flags().Int("offset", 0, "Skip the first N matching results (0-based). Only supported under --proxied-server.")
+	listCmd.Flags().Int("offset", 0, "Skip the first N matching results (0-based)")
+	listCmd.Flags().String("cursor", "", "With --json, page through results in creation order: 'start' for the first page, then the previous page's next_cursor")
+	listCmd.Flags().String("as-of", "", "List issues as they were at a commit hash, branch, or date (e.g. 2026-10-01)")
 	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
 	listCmd.Flags().Bool("all", false, "Show all issues including closed (overrides default filter)")
 	listCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, due, status, id, title, type, assignee; comma-separate several, '-' for descending, '+' ascending (e.g. priority,-updated). prefix or derived.<name> sort alone")
 	listCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
 
 	// Pattern matching
@@ -699,6 +832,9 @@ func init() {
 
 	// Empty/null checks
 	listCmd.Flags().Bool("empty-description", false, "Filter issues with empty or missing description")
+	listCmd.Flags().Bool("no-design", false, "Filter issues with no design notes")
+	listCmd.Flags().Bool("no-acceptance-criteria", false, "Filter issues with no acceptance criteria")
+	listCmd.Flags().Bool("no-notes", false, "Filter issues with no notes")
 	listCmd.Flags().Bool("no-assignee", false, "Filter issues with no assignee")
 	listCmd.Flags().Bool("no-labels", false, "Filter issues with no labels")
 
@@ -726,6 +862,9 @@ func init() {
 	// Infra type filtering: exclude agent/role/message by default
 	listCmd.Flags().Bool("include-infra", false, "Include infrastructure beads (agent/role/message) in output")
 
+	// Wisp filtering: search the wisps table alongside issues
+	listCmd.Flags().Bool("include-wisps", false, "Also list ephemeral wisps, tagging each result with its source (issue or wisp)")
+
 	// Explicit type exclusion
 	listCmd.Flags().StringSlice("exclude-type", nil, "Exclude issue types from results (comma-separated or repeatable, e.g., --exclude-type=convoy,epic)")
 
@@ -741,6 +880,9 @@ func init() {
 	// Wisp type filtering (TTL-based compaction classification)
 	listCmd.Flags().String("wisp-type", "", "Filter by wisp type: heartbeat, ping, patrol, gc_report, recovery, error, escalation")
 
+	// Formula provenance filtering
+	listCmd.Flags().String("formula", "", "Filter by the formula that generated the issue (see 'bd trace')")
+
 	// Time-based scheduling filters (GH#820)
 	listCmd.Flags().Bool("deferred", false, "Show only issues with defer_until set")
 	listCmd.Flags().String("defer-after", "", "Filter issues deferred after date (supports relative: +6h, tomorrow)")
@@ -754,10 +896,17 @@ func init() {
 	listCmd.Flags().Bool("tree", true, "Hierarchical tree format (default: true; use --flat to disable)")
 	listCmd.Flags().Bool("flat", false, "Disable tree format and use legacy flat list output")
 	listCmd.Flags().BoolP("watch", "w", false, "Watch for changes and auto-update display (implies --pretty)")
+	listCmd.Flags().Bool("select", false, "Interactively mark issues (space) and close, label, assign, or reprioritize them in one transaction")
 
 	// Metadata filtering (GH#1406)
 	listCmd.Flags().StringArray("metadata-field", nil, "Filter by metadata field (key=value, repeatable)")
 	listCmd.Flags().String("has-metadata-key", "", "Filter issues that have this metadata key set")
+	listCmd.Flags().String("validation", "", "Filter by validation state recorded with bd validate (passed, failed, needs-work)")
+	listCmd.Flags().String("review", "", "Filter by review state of agent-closed issues (pending, approved, rejected)")
+	listCmd.Flags().String("last-run", "", "Filter by the status of the most recent job run recorded with bd run (e.g. failed)")
+	listCmd.Flags().Float64("min-quality", 0, "Filter issues with a quality score (bd quality) of at least this value, 0-1")
+	listCmd.Flags().Bool("reopened", false, "Show only issues that have been reopened (bd reopen)")
+	listCmd.Flags().Int("min-reopens", 0, "Show only issues reopened at least this many times")
 
 	// Pager control (bd-jdz3)
 	listCmd.Flags().Bool("no-pager", false, "Disable pager output")
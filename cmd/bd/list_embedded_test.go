@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -644,6 +645,22 @@ func TestEmbeddedList(t *testing.T) {
 		}
 	})
 
+	t.Run("sort_multi_key", func(t *testing.T) {
+		issues := bdListJSON(t, bd, dir, "--all", "--limit", "0", "--sort", "priority,-title")
+		for i := 1; i < len(issues); i++ {
+			prev, cur := issues[i-1], issues[i]
+			if prev.Priority > cur.Priority {
+				t.Fatalf("--sort priority,-title put P%d before P%d", prev.Priority, cur.Priority)
+			}
+			if prev.Priority == cur.Priority && strings.ToLower(prev.Title) < strings.ToLower(cur.Title) {
+				t.Errorf("--sort priority,-title put %q before %q within P%d", prev.Title, cur.Title, cur.Priority)
+			}
+		}
+		if out := bdListFail(t, bd, dir, "--sort", "priority,bogus"); !strings.Contains(out, "invalid sort field") {
+			t.Errorf("expected 'invalid sort field' error, got: %s", out)
+		}
+	})
+
 	// --- I. Output formats ---
 
 	t.Run("json_output", func(t *testing.T) {
@@ -767,12 +784,103 @@ func TestEmbeddedList(t *testing.T) {
 		}
 	})
 
-	t.Run("reject_offset_in_direct_mode", func(t *testing.T) {
-		// --offset is only honored under --proxied-server; the direct
-		// (embedded) path must fatal before touching the store.
-		out := bdListFail(t, bd, dir, "--offset", "1")
-		if !strings.Contains(out, "--offset is only supported under --proxied-server") {
-			t.Errorf("expected --offset direct-mode rejection, got: %s", out)
+	t.Run("offset_and_more", func(t *testing.T) {
+		all := listIssueIDs(bdListJSON(t, bd, dir, "--sort", "created", "--limit", "0"))
+		if len(all) < 5 {
+			t.Fatalf("need at least 5 seeded issues, got %d", len(all))
+		}
+		if got := listIssueIDs(bdListJSON(t, bd, dir, "--sort", "created", "--limit", "2", "--offset", "1")); !slices.Equal(got, all[1:3]) {
+			t.Errorf("--offset 1 --limit 2 = %v, want %v", got, all[1:3])
+		}
+
+		// A truncated list leaves state bd more continues from, with the
+		// same sort and page size.
+		if got := listIssueIDs(bdListJSON(t, bd, dir, "--sort", "created", "--limit", "2")); !slices.Equal(got, all[0:2]) {
+			t.Fatalf("first page = %v, want %v", got, all[0:2])
+		}
+		more := func() []string {
+			cmd := exec.Command(bd, "more", "--json")
+			cmd.Dir = dir
+			cmd.Env = bdEnv(dir)
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("bd more --json failed: %v\n%s", err, out)
+			}
+			var issues []*types.IssueWithCounts
+			if err := json.Unmarshal(out[strings.Index(string(out), "["):], &issues); err != nil {
+				t.Fatalf("parse bd more output: %v\n%s", err, out)
+			}
+			return listIssueIDs(issues)
+		}
+		if got := more(); !slices.Equal(got, all[2:4]) {
+			t.Errorf("second page = %v, want %v", got, all[2:4])
+		}
+		if got := more(); !slices.Equal(got, all[4:min(6, len(all))]) {
+			t.Errorf("third page = %v, want %v", got, all[4:min(6, len(all))])
+		}
+
+		// A list that fits on one page leaves nothing for bd more.
+		bdListJSON(t, bd, dir, "--limit", "0")
+		if got := more(); len(got) != 0 {
+			t.Errorf("bd more after a complete list = %v, want nothing", got)
+		}
+	})
+
+	t.Run("as_of", func(t *testing.T) {
+		before := listIssueIDs(bdListJSON(t, bd, dir, "--all", "--limit", "0"))
+		// Commit dates have second precision.
+		time.Sleep(1100 * time.Millisecond)
+		date := time.Now().UTC().Format(time.RFC3339)
+		time.Sleep(1100 * time.Millisecond)
+		added := bdCreate(t, bd, dir, "Created after the snapshot")
+
+		asOf := listIssueIDs(bdListJSON(t, bd, dir, "--all", "--limit", "0", "--as-of", date))
+		if slices.Contains(asOf, added.ID) {
+			t.Errorf("--as-of %s lists %s, created afterwards", date, added.ID)
+		}
+		slices.Sort(before)
+		slices.Sort(asOf)
+		if !slices.Equal(asOf, before) {
+			t.Errorf("--as-of %s = %v, want %v", date, asOf, before)
+		}
+		if got := bdListJSON(t, bd, dir, "--as-of", "2000-01-01"); len(got) != 0 {
+			t.Errorf("--as-of before the database existed = %v, want nothing", listIssueIDs(got))
+		}
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		want := listIssueIDs(bdListJSON(t, bd, dir, "--all", "--limit", "0", "--sort", "created"))
+		var got []string
+		for cursor, pages := "start", 0; cursor != ""; pages++ {
+			if pages > len(want) {
+				t.Fatalf("cursor walk did not end after %d pages: %v", pages, got)
+			}
+			stdout, _ := bdListCapture(t, bd, dir, "--json", "--all", "--limit", "3", "--cursor", cursor)
+			var page struct {
+				Issues     []*types.IssueWithCounts `json:"issues"`
+				NextCursor string                   `json:"next_cursor"`
+			}
+			if err := json.Unmarshal([]byte(stdout), &page); err != nil {
+				t.Fatalf("parse --cursor page: %v\n%s", err, stdout)
+			}
+			if len(page.Issues) > 3 {
+				t.Fatalf("page of %d issues, want at most 3", len(page.Issues))
+			}
+			got = append(got, listIssueIDs(page.Issues)...)
+			cursor = page.NextCursor
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("cursor walk = %v, want %v", got, want)
+		}
+
+		if out := bdListFail(t, bd, dir, "--json", "--cursor", "bogus"); !strings.Contains(out, "invalid cursor") {
+			t.Errorf("bad cursor output = %s", out)
+		}
+		if out := bdListFail(t, bd, dir, "--cursor", "start"); !strings.Contains(out, "requires --json") {
+			t.Errorf("--cursor without --json output = %s", out)
+		}
+		if out := bdListFail(t, bd, dir, "--json", "--cursor", "start", "--sort", "priority"); !strings.Contains(out, "cannot be combined") {
+			t.Errorf("--cursor with --sort output = %s", out)
 		}
 	})
 }
@@ -116,12 +116,33 @@ func loadProxiedListFilterConfig(ctx context.Context, uw uow.UnitOfWork) (listFi
 	return loadListFilterConfig(ctx, proxiedConfigSource{uw: uw})
 }
 
+// listSQLSortKeys returns the store ordering for a --sort spec. Sorts bd
+// list applies only in Go (prefix, derived.<name>) leave the store's default
+// order, which --reverse flips like an explicit priority sort.
+func listSQLSortKeys(spec string, reverse bool) []types.SortKey {
+	if spec == "" {
+		if reverse {
+			return []types.SortKey{{Field: "priority", Desc: true}}
+		}
+		return nil
+	}
+	keys, err := types.ParseSortKeys(spec)
+	if err != nil {
+		return nil
+	}
+	if reverse {
+		keys = types.ReverseSortKeys(keys)
+	}
+	return keys
+}
+
 func buildListFilter(in listInput, cfg listFilterConfig) (types.IssueFilter, error) {
 	filter := types.IssueFilter{
-		Limit:    in.sqlLimit,
-		Offset:   in.offset,
-		SortBy:   in.sortBy,
-		SortDesc: in.reverse,
+		Limit:  in.sqlLimit,
+		Offset: in.offset,
+		SortBy: listSQLSortKeys(in.sortBy, in.reverse),
+		AsOf:   in.asOf,
+		Cursor: in.cursor,
 	}
 
 	if in.readyFlag {
@@ -190,6 +211,9 @@ func buildListFilter(in listInput, cfg listFilterConfig) (types.IssueFilter, err
 	if in.specPrefix != "" {
 		filter.SpecIDPrefix = in.specPrefix
 	}
+	if in.idPrefix != "" {
+		filter.IDPrefix = strings.TrimSuffix(in.idPrefix, "-") + "-"
+	}
 
 	if in.titleContains != "" {
 		filter.TitleContains = in.titleContains
@@ -217,6 +241,9 @@ func buildListFilter(in listInput, cfg listFilterConfig) (types.IssueFilter, err
 	if in.emptyDesc {
 		filter.EmptyDescription = true
 	}
+	filter.NoDesign = in.noDesign
+	filter.NoAcceptanceCriteria = in.noAcceptance
+	filter.NoNotes = in.noNotes
 	if in.noAssignee {
 		filter.NoAssignee = true
 	}
@@ -287,6 +314,8 @@ func buildListFilter(in listInput, cfg listFilterConfig) (types.IssueFilter, err
 	if in.wispType != nil {
 		filter.WispType = in.wispType
 	}
+	filter.SourceFormula = in.formula
+	filter.Rig = in.rig
 
 	if in.deferredFlag {
 		filter.Deferred = true
@@ -305,8 +334,12 @@ func buildListFilter(in listInput, cfg listFilterConfig) (types.IssueFilter, err
 	if in.hasMetadataKey != "" {
 		filter.HasMetadataKey = in.hasMetadataKey
 	}
+	filter.MinQualityScore = in.minQuality
+	filter.MinReopenCount = in.minReopens
 
-	if !in.includeInfra && (in.issueType == "" || !cfg.isInfra(in.issueType)) {
+	// --include-wisps searches the wisps table alongside issues without
+	// also pulling in infra types, which --include-infra implies.
+	if !in.includeInfra && !in.includeWisps && (in.issueType == "" || !cfg.isInfra(in.issueType)) {
 		filter.SkipWisps = true
 	}
 
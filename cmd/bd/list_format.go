@@ -7,7 +7,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/query"
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/timeparsing"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
@@ -28,6 +30,38 @@ func pinIndicator(issue *types.Issue) string {
 	return ""
 }
 
+// Result sources reported by --include-wisps, naming the table a row came from.
+const (
+	issueSourceIssue = "issue"
+	issueSourceWisp  = "wisp"
+)
+
+// issueSource returns which table an issue was read from.
+func issueSource(issue *types.Issue) string {
+	if issueops.IsWisp(issue) {
+		return issueSourceWisp
+	}
+	return issueSourceIssue
+}
+
+// tagIssueSources sets Source on each result so JSON consumers can tell
+// durable issues from wisps in a merged query.
+func tagIssueSources(issues []*types.IssueWithCounts) {
+	for _, iwc := range issues {
+		if iwc != nil && iwc.Issue != nil {
+			iwc.Source = issueSource(iwc.Issue)
+		}
+	}
+}
+
+// wispIndicator returns a [wisp] prefix for issues stored in the wisps table
+func wispIndicator(issue *types.Issue) string {
+	if issueops.IsWisp(issue) {
+		return "[wisp] "
+	}
+	return ""
+}
+
 // Priority tags for pretty output - simple text, semantic colors applied via ui package
 // Design principle: only P0/P1 get color for attention, P2-P4 are neutral
 func renderPriorityTag(priority int) string {
@@ -55,6 +89,7 @@ func formatPrettyIssue(issue *types.Issue) string {
 	case "bug":
 		typeBadge = ui.TypeBugStyle.Render("[bug]") + " "
 	}
+	typeBadge = wispIndicator(issue) + typeBadge
 
 	// Format: STATUS_ICON ID PRIORITY [Type] Title
 	// Priority uses ● icon with color, no brackets needed
@@ -87,13 +122,13 @@ func formatIssueLong(buf *strings.Builder, issue *types.Issue, labels []string,
 	status := string(issue.Status)
 	if status == "closed" {
 		line := fmt.Sprintf("%s%s [P%d] [%s] %s\n  %s",
-			pinIndicator(issue), issue.ID, issue.Priority,
+			pinIndicator(issue)+wispIndicator(issue), issue.ID, issue.Priority,
 			issue.IssueType, status, issue.Title)
 		buf.WriteString(ui.RenderClosedLine(line))
 		buf.WriteString("\n")
 	} else {
 		buf.WriteString(fmt.Sprintf("%s%s [%s] [%s] %s\n",
-			pinIndicator(issue),
+			pinIndicator(issue)+wispIndicator(issue),
 			ui.RenderID(issue.ID),
 			ui.RenderPriority(issue.Priority),
 			ui.RenderType(string(issue.IssueType)),
@@ -121,6 +156,9 @@ func formatIssueLong(buf *strings.Builder, issue *types.Issue, labels []string,
 			buf.WriteString("  Metadata: set\n")
 		}
 	}
+	if len(issue.Derived) > 0 {
+		buf.WriteString(fmt.Sprintf("  Derived: %s\n", query.FormatDerived(issue)))
+	}
 	buf.WriteString("\n")
 }
 
@@ -253,7 +291,7 @@ func formatIssueCompact(buf *strings.Builder, issue *types.Issue, labels []strin
 	if issue.Status == types.StatusClosed {
 		// Closed issues: entire line muted (fades visually)
 		line := fmt.Sprintf("%s %s%s [P%d] [%s]%s%s - %s%s",
-			statusIcon, pinIndicator(issue), issue.ID, issue.Priority,
+			statusIcon, pinIndicator(issue)+wispIndicator(issue), issue.ID, issue.Priority,
 			issue.IssueType, assigneeStr, labelsStr, issue.Title, depInfo)
 		buf.WriteString(ui.RenderClosedLine(line))
 		buf.WriteString("\n")
@@ -261,7 +299,7 @@ func formatIssueCompact(buf *strings.Builder, issue *types.Issue, labels []strin
 		// Active issues: status icon + semantic colors for priority/type
 		buf.WriteString(fmt.Sprintf("%s %s%s [%s] [%s]%s%s - %s%s\n",
 			statusIcon,
-			pinIndicator(issue),
+			pinIndicator(issue)+wispIndicator(issue),
 			ui.RenderID(issue.ID),
 			ui.RenderPriority(issue.Priority),
 			ui.RenderType(string(issue.IssueType)),
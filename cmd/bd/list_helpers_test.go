@@ -65,6 +65,77 @@ func TestListPinIndicator(t *testing.T) {
 	}
 }
 
+func TestListWispIndicatorAndSource(t *testing.T) {
+	for _, iss := range []*types.Issue{{Ephemeral: true}, {NoHistory: true}} {
+		if wispIndicator(iss) == "" {
+			t.Fatalf("expected wisp indicator for %+v", iss)
+		}
+		if got := issueSource(iss); got != issueSourceWisp {
+			t.Fatalf("issueSource = %q, want %q", got, issueSourceWisp)
+		}
+	}
+	if wispIndicator(&types.Issue{}) != "" {
+		t.Fatalf("expected empty wisp indicator")
+	}
+
+	iwc := []*types.IssueWithCounts{
+		{Issue: &types.Issue{ID: "bd-1"}},
+		{Issue: &types.Issue{ID: "bd-wisp-1", Ephemeral: true}},
+	}
+	tagIssueSources(iwc)
+	if iwc[0].Source != issueSourceIssue || iwc[1].Source != issueSourceWisp {
+		t.Fatalf("sources = %q, %q; want issue, wisp", iwc[0].Source, iwc[1].Source)
+	}
+}
+
+func TestListBuildFilter_IncludeWisps(t *testing.T) {
+	cfg := listFilterConfig{}
+	filter, err := buildListFilter(listInput{}, cfg)
+	if err != nil {
+		t.Fatalf("buildListFilter: %v", err)
+	}
+	if !filter.SkipWisps {
+		t.Fatalf("default list should skip wisps")
+	}
+
+	filter, err = buildListFilter(listInput{includeWisps: true}, cfg)
+	if err != nil {
+		t.Fatalf("buildListFilter: %v", err)
+	}
+	if filter.SkipWisps {
+		t.Fatalf("--include-wisps should search the wisps table")
+	}
+	// Unlike --include-infra, infra types stay hidden.
+	for _, typ := range cfg.infraTypes() {
+		if !slices.Contains(filter.ExcludeTypes, types.IssueType(typ)) {
+			t.Fatalf("ExcludeTypes = %v, want infra type %q excluded", filter.ExcludeTypes, typ)
+		}
+	}
+}
+
+func TestListBuildFilter_Formula(t *testing.T) {
+	filter, err := buildListFilter(listInput{formula: "mol-release"}, listFilterConfig{})
+	if err != nil {
+		t.Fatalf("buildListFilter: %v", err)
+	}
+	if filter.SourceFormula != "mol-release" {
+		t.Fatalf("SourceFormula = %q, want mol-release", filter.SourceFormula)
+	}
+}
+
+func TestListBuildFilter_Rig(t *testing.T) {
+	filter, err := buildListFilter(listInput{rig: "gpu-west"}, listFilterConfig{})
+	if err != nil {
+		t.Fatalf("buildListFilter: %v", err)
+	}
+	if filter.Rig != "gpu-west" {
+		t.Fatalf("Rig = %q, want gpu-west", filter.Rig)
+	}
+	if wf := readyWorkFilterFromIssueFilter(filter); wf.Rig != "gpu-west" {
+		t.Fatalf("ready Rig = %q, want gpu-west", wf.Rig)
+	}
+}
+
 func TestListFormatPrettyIssue_BadgesAndDefaults(t *testing.T) {
 	iss := &types.Issue{ID: "bd-1", Title: "Hello", Status: "wat", Priority: 99, IssueType: "bug"}
 	out := formatPrettyIssue(iss)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/query"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
@@ -21,6 +22,7 @@ type listInput struct {
 	assignee    string
 	titleSearch string
 	specPrefix  string
+	idPrefix    string
 	idFilter    string
 
 	labels        []string
@@ -46,10 +48,13 @@ type listInput struct {
 	dueAfter      *time.Time
 	dueBefore     *time.Time
 
-	emptyDesc  bool
-	noAssignee bool
-	noLabels   bool
-	skipLabels bool
+	emptyDesc    bool
+	noDesign     bool
+	noAcceptance bool
+	noNotes      bool
+	noAssignee   bool
+	noLabels     bool
+	skipLabels   bool
 
 	priority       int
 	prioritySet    bool
@@ -63,18 +68,23 @@ type listInput struct {
 	includeTemplates bool
 	includeGates     bool
 	includeInfra     bool
+	includeWisps     bool
 	excludeTypeStrs  []string
 
 	parentID string
 	noParent bool
 	molType  *types.MolType
 	wispType *types.WispType
+	formula  string
+	rig      string
 
 	deferredFlag bool
 	overdueFlag  bool
 
 	metadataFields map[string]string
 	hasMetadataKey string
+	minQuality     *float64
+	minReopens     int
 
 	allFlag      bool
 	readyFlag    bool
@@ -82,17 +92,25 @@ type listInput struct {
 	prettyFormat bool
 	flatFormat   bool
 	watchMode    bool
+	selectMode   bool
 	noPager      bool
 	formatStr    string
 	jsonOutput   bool
 	sortBy       string
-	reverse      bool
+	// derivedFields are the configured derived.fields, computed for every
+	// listed issue.
+	derivedFields []query.DerivedField
+	reverse       bool
 
 	limitChanged   bool
 	effectiveLimit int
 	sqlLimit       int
 
-	offset int // 0-based starting offset; honored under --proxied-server only.
+	offset int // 0-based starting offset
+
+	cursor string // --cursor: CursorStart or the next_cursor of the last page
+
+	asOf string // commit, branch, or date to list the issues as of
 
 	repoOverride    string
 	repoOverrideSet bool
@@ -133,6 +151,7 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 	in.labelRegex, _ = cmd.Flags().GetString("label-regex")
 	in.titleSearch, _ = cmd.Flags().GetString("title")
 	in.specPrefix, _ = cmd.Flags().GetString("spec")
+	in.idPrefix, _ = cmd.Flags().GetString("prefix")
 	in.idFilter, _ = cmd.Flags().GetString("id")
 	in.longFormat, _ = cmd.Flags().GetBool("long")
 	in.sortBy, _ = cmd.Flags().GetString("sort")
@@ -145,6 +164,9 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 	in.externalRef, _ = cmd.Flags().GetString("external-ref")
 
 	in.emptyDesc, _ = cmd.Flags().GetBool("empty-description")
+	in.noDesign, _ = cmd.Flags().GetBool("no-design")
+	in.noAcceptance, _ = cmd.Flags().GetBool("no-acceptance-criteria")
+	in.noNotes, _ = cmd.Flags().GetBool("no-notes")
 	in.noAssignee, _ = cmd.Flags().GetBool("no-assignee")
 	in.noLabels, _ = cmd.Flags().GetBool("no-labels")
 
@@ -194,6 +216,7 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 	in.includeTemplates, _ = cmd.Flags().GetBool("include-templates")
 	in.includeGates, _ = cmd.Flags().GetBool("include-gates")
 	in.includeInfra, _ = cmd.Flags().GetBool("include-infra")
+	in.includeWisps, _ = cmd.Flags().GetBool("include-wisps")
 	in.excludeTypeStrs, _ = cmd.Flags().GetStringSlice("exclude-type")
 
 	in.parentID, _ = cmd.Flags().GetString("parent")
@@ -219,6 +242,8 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 		}
 		in.wispType = &wt
 	}
+	in.formula, _ = cmd.Flags().GetString("formula")
+	in.rig = rigScope
 
 	in.deferredFlag, _ = cmd.Flags().GetBool("deferred")
 	in.overdueFlag, _ = cmd.Flags().GetBool("overdue")
@@ -275,6 +300,52 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 		}
 		in.hasMetadataKey = k
 	}
+	if state, _ := cmd.Flags().GetString("validation"); state != "" {
+		switch state {
+		case types.ValidationStatePassed, types.ValidationStateFailed, types.ValidationStateNeedsWork:
+		default:
+			return in, HandleErrorRespectJSON("invalid --validation %q (valid: passed, failed, needs-work)", state)
+		}
+		if in.metadataFields == nil {
+			in.metadataFields = make(map[string]string, 1)
+		}
+		in.metadataFields[types.ValidationStateMetadataKey] = state
+	}
+	if state, _ := cmd.Flags().GetString("review"); state != "" {
+		switch state {
+		case types.ReviewPending, types.ReviewApproved, types.ReviewRejected:
+		default:
+			return in, HandleErrorRespectJSON("invalid --review %q (valid: pending, approved, rejected)", state)
+		}
+		if in.metadataFields == nil {
+			in.metadataFields = make(map[string]string, 1)
+		}
+		in.metadataFields[types.ReviewStateMetadataKey] = state
+	}
+	if lastRun, _ := cmd.Flags().GetString("last-run"); lastRun != "" {
+		status, err := types.NormalizeRunStatus(lastRun)
+		if err != nil {
+			return in, HandleErrorRespectJSON("invalid --last-run: %v", err)
+		}
+		if in.metadataFields == nil {
+			in.metadataFields = make(map[string]string, 1)
+		}
+		in.metadataFields[types.LastRunStatusMetadataKey] = status
+	}
+	if cmd.Flags().Changed("min-quality") {
+		q, _ := cmd.Flags().GetFloat64("min-quality")
+		if q < 0 || q > 1 {
+			return in, HandleErrorRespectJSON("invalid --min-quality %v (must be between 0 and 1)", q)
+		}
+		in.minQuality = &q
+	}
+	in.minReopens, _ = cmd.Flags().GetInt("min-reopens")
+	if in.minReopens < 0 {
+		return in, HandleErrorRespectJSON("invalid --min-reopens %d (must be non-negative)", in.minReopens)
+	}
+	if reopened, _ := cmd.Flags().GetBool("reopened"); reopened && in.minReopens == 0 {
+		in.minReopens = 1
+	}
 
 	prettyFormat, _ := cmd.Flags().GetBool("pretty")
 	treeFormat, _ := cmd.Flags().GetBool("tree")
@@ -287,16 +358,36 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 	if in.watchMode {
 		in.prettyFormat = true
 	}
+	in.selectMode, _ = cmd.Flags().GetBool("select")
+	if in.selectMode && (in.watchMode || in.jsonOutput) {
+		return in, HandleError("--select cannot be combined with --watch or --json")
+	}
 	in.noPager, _ = cmd.Flags().GetBool("no-pager")
 	in.readyFlag, _ = cmd.Flags().GetBool("ready")
+	if in.readyFlag && in.formula != "" {
+		return in, HandleError("--formula cannot be combined with --ready")
+	}
+	in.asOf, _ = cmd.Flags().GetString("as-of")
+	if in.asOf != "" && (in.watchMode || in.selectMode || in.readyFlag) {
+		return in, HandleError("--as-of cannot be combined with --watch, --select, or --ready")
+	}
 
+	in.derivedFields, err = loadDerivedFields()
+	if err != nil {
+		return in, HandleError("%v", err)
+	}
 	if in.sortBy != "" {
-		validSortFields := map[string]bool{
-			"priority": true, "created": true, "updated": true, "closed": true,
-			"status": true, "id": true, "title": true, "type": true, "assignee": true,
-		}
-		if !validSortFields[in.sortBy] {
-			return in, HandleError("invalid sort field %q (valid: priority, created, updated, closed, status, id, title, type, assignee)", in.sortBy)
+		if strings.HasPrefix(in.sortBy, "derived.") {
+			if err := validateDerivedSort(in.sortBy, in.derivedFields); err != nil {
+				return in, HandleError("%v", err)
+			}
+			if in.watchMode {
+				return in, HandleError("--sort %s cannot be combined with --watch", in.sortBy)
+			}
+		} else if in.sortBy != "prefix" {
+			if _, err := types.ParseSortKeys(in.sortBy); err != nil {
+				return in, HandleError("%v, or prefix or derived.<name> alone", err)
+			}
 		}
 	}
 
@@ -328,21 +419,37 @@ func gatherListInput(cmd *cobra.Command) (listInput, error) {
 	// SQL can't express without a schema-side sort column. Fall back to
 	// fetching everything and sorting client-side. Other sorts (including
 	// title via LOWER()) are pushed into SQL ORDER BY.
-	if in.sortBy == "id" {
+	// Derived values are computed after the fetch, so the same applies to
+	// --sort derived.<name>.
+	if in.sortBy == "id" || strings.HasPrefix(in.sortBy, "derived.") {
 		in.sqlLimit = 0
 	}
 
+	in.cursor, _ = cmd.Flags().GetString("cursor")
+	if in.cursor != "" {
+		if !in.jsonOutput {
+			return in, HandleError("--cursor requires --json")
+		}
+		if in.readyFlag || in.watchMode || in.skipLabels || in.sortBy != "" || in.reverse || cmd.Flags().Changed("offset") {
+			return in, HandleErrorRespectJSON("--cursor cannot be combined with --ready, --watch, --skip-labels, --sort, --reverse, or --offset")
+		}
+		// Pages keep their size when stdout is piped or --all is set;
+		// only an explicit --limit 0 returns everything in one page.
+		in.effectiveLimit = limit
+		in.sqlLimit = limit
+	}
+
 	if cmd.Flags().Changed("offset") {
 		offset, _ := cmd.Flags().GetInt("offset")
 		if offset < 0 {
 			return in, HandleError("--offset must be >= 0")
 		}
-		// --offset only makes sense when pagination happens in SQL. Sorts
-		// that fall back to Go-side (currently --sort id) fetch everything
-		// regardless, so combining them with --offset is misleading — the
-		// caller would think they're paging when they're really pulling
-		// the whole result set.
-		if offset > 0 && in.sqlLimit == 0 && in.sortBy == "id" {
+		// Under --proxied-server --offset pages in SQL. Sorts that fall
+		// back to Go-side (currently --sort id) fetch everything
+		// regardless, so combining them with --offset there is misleading
+		// — the caller would think they're paging when they're really
+		// pulling the whole result set. Direct mode always skips in Go.
+		if offset > 0 && usesProxiedServer() && in.sqlLimit == 0 && (in.sortBy == "id" || strings.HasPrefix(in.sortBy, "derived.")) {
 			return in, HandleError("--offset is not supported with --sort %s (sort requires fetching the full result set)", in.sortBy)
 		}
 		in.offset = offset
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const listMoreStateFile = "more-state.json"
+
+// listPaging is set while bd list runs; listTruncated records whether the
+// page it printed was cut off by --limit (see printTruncationHint).
+var listPaging, listTruncated bool
+
+// listMoreState is where the last truncated bd list stopped: the list
+// flags it was given, and the offset and size of the next page.
+type listMoreState struct {
+	Flags  map[string][]string `json:"flags,omitempty"`
+	Offset int                 `json:"offset"`
+	Limit  int                 `json:"limit"`
+}
+
+var moreCmd = &cobra.Command{
+	Use:     "more",
+	GroupID: "issues",
+	Short:   "Show the next page of the last truncated bd list",
+	Long: `Continue the last bd list that was cut off by its --limit.
+
+A truncated bd list remembers where it stopped in this clone. bd more shows
+the next page with the same filters and page size, and each run moves one
+page further, until the last page has been shown.
+
+Examples:
+  bd list --status open    # The first 50 open issues
+  bd more                  # The next 50`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("more")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		state, err := loadListMoreState()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if state == nil {
+			if jsonOutput {
+				return outputJSON([]*types.IssueWithCounts{})
+			}
+			fmt.Println("Nothing more to show (the last bd list was shown in full)")
+			return nil
+		}
+		if err := state.apply(listCmd.Flags()); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		// Like bd children, reuse the non-emitting list core so one bd more
+		// records one "more" event.
+		return runListCore(listCmd, nil)
+	},
+}
+
+func listMoreStatePath() string {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return ""
+	}
+	return filepath.Join(beadsDir, listMoreStateFile)
+}
+
+// saveListMoreState records where a bd list page ended, or forgets the
+// previous listing when nothing was cut off. Best-effort, like
+// SetLastTouchedID.
+func saveListMoreState(cmd *cobra.Command, in listInput, truncated bool) {
+	path := listMoreStatePath()
+	if path == "" {
+		return
+	}
+	if !truncated {
+		_ = os.Remove(path)
+		return
+	}
+	state := listMoreState{
+		Flags:  listMoreFlags(cmd.LocalFlags()),
+		Offset: in.offset + in.effectiveLimit,
+		Limit:  in.effectiveLimit,
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0600)
+}
+
+// listMoreFlags returns the list flags set on the command line, minus the
+// paging flags bd more sets itself.
+func listMoreFlags(flags *pflag.FlagSet) map[string][]string {
+	set := make(map[string][]string)
+	// LocalFlags builds a fresh set, so Visit would see nothing changed.
+	flags.VisitAll(func(f *pflag.Flag) {
+		if !f.Changed || f.Name == "offset" || f.Name == "limit" {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			set[f.Name] = sv.GetSlice()
+			return
+		}
+		set[f.Name] = []string{f.Value.String()}
+	})
+	return set
+}
+
+// loadListMoreState returns the saved state, or nil when there is none.
+func loadListMoreState() (*listMoreState, error) {
+	path := listMoreStatePath()
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path constructed from beadsDir
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", listMoreStateFile, err)
+	}
+	var state listMoreState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", listMoreStateFile, err)
+	}
+	return &state, nil
+}
+
+// apply sets the saved flags and the next page on the list flag set.
+// Flags bd list no longer has are skipped.
+func (s *listMoreState) apply(flags *pflag.FlagSet) error {
+	for name, values := range s.Flags {
+		f := flags.Lookup(name)
+		if f == nil {
+			continue
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			if err := sv.Replace(values); err != nil {
+				return fmt.Errorf("restoring --%s: %w", name, err)
+			}
+			f.Changed = true
+			continue
+		}
+		for _, v := range values {
+			if err := flags.Set(name, v); err != nil {
+				return fmt.Errorf("restoring --%s: %w", name, err)
+			}
+		}
+	}
+	if err := flags.Set("offset", strconv.Itoa(s.Offset)); err != nil {
+		return err
+	}
+	return flags.Set("limit", strconv.Itoa(s.Limit))
+}
+
+// skipListOffset drops the first offset rows of a sorted result; direct
+// stores do not page in SQL (see runListCore).
+func skipListOffset[T any](rows []T, offset int) []T {
+	if offset <= 0 {
+		return rows
+	}
+	if offset >= len(rows) {
+		return nil
+	}
+	return rows[offset:]
+}
+
+// listCursorPage is the bd list --json --cursor response: one page of
+// issues and the cursor for the next, empty after the last page.
+type listCursorPage struct {
+	Issues     []*types.IssueWithCounts `json:"issues"`
+	NextCursor string                   `json:"next_cursor"`
+}
+
+// runListCursorPage prints one page of bd list --json --cursor. The store
+// returns the page in cursor order, so unlike the other list paths it is
+// not re-sorted or offset here.
+func runListCursorPage(ctx context.Context, s storage.DoltStorage, filter types.IssueFilter, in listInput) error {
+	iwc, err := s.SearchIssuesWithCounts(ctx, "", withFetchOneExtra(filter))
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if err := computeDerivedFieldsWithCounts(ctx, s, iwc, in.derivedFields); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	page := listCursorPage{Issues: iwc}
+	if in.effectiveLimit > 0 && len(iwc) > in.effectiveLimit {
+		page.Issues = iwc[:in.effectiveLimit]
+		page.NextCursor = types.IssueCursor(page.Issues[len(page.Issues)-1].Issue)
+	}
+	if page.Issues == nil {
+		page.Issues = []*types.IssueWithCounts{}
+	}
+	if in.includeWisps {
+		tagIssueSources(page.Issues)
+	}
+	return outputJSON(page)
+}
+
+func init() {
+	rootCmd.AddCommand(moreCmd)
+}
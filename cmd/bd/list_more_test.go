@@ -0,0 +1,70 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestListMoreStateRoundTrip(t *testing.T) {
+	newFlags := func() *pflag.FlagSet {
+		fs := pflag.NewFlagSet("list", pflag.ContinueOnError)
+		fs.String("sort", "", "")
+		fs.StringSlice("label", nil, "")
+		fs.Bool("flat", false, "")
+		fs.Int("limit", 50, "")
+		fs.Int("offset", 0, "")
+		return fs
+	}
+
+	fs := newFlags()
+	if err := fs.Parse([]string{"--sort", "created", "--label", "a,b", "--label", "c", "--limit", "10", "--offset", "20"}); err != nil {
+		t.Fatal(err)
+	}
+	saved := listMoreFlags(fs)
+	if _, ok := saved["limit"]; ok {
+		t.Error("saved flags include --limit")
+	}
+	if _, ok := saved["offset"]; ok {
+		t.Error("saved flags include --offset")
+	}
+	if _, ok := saved["flat"]; ok {
+		t.Error("saved flags include --flat, which was not set")
+	}
+	saved["gone"] = []string{"x"} // a flag bd list has since dropped
+
+	state := &listMoreState{Flags: saved, Offset: 30, Limit: 10}
+	replay := newFlags()
+	if err := state.apply(replay); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if got, _ := replay.GetString("sort"); got != "created" {
+		t.Errorf("sort = %q, want created", got)
+	}
+	if got, _ := replay.GetStringSlice("label"); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("label = %v, want [a b c]", got)
+	}
+	if !replay.Changed("label") {
+		t.Error("label not marked as changed")
+	}
+	if got, _ := replay.GetInt("offset"); got != 30 {
+		t.Errorf("offset = %d, want 30", got)
+	}
+	if got, _ := replay.GetInt("limit"); got != 10 || !replay.Changed("limit") {
+		t.Errorf("limit = %d (changed %v), want 10 set explicitly", got, replay.Changed("limit"))
+	}
+}
+
+func TestSkipListOffset(t *testing.T) {
+	rows := []int{1, 2, 3}
+	if got := skipListOffset(rows, 0); !slices.Equal(got, rows) {
+		t.Errorf("offset 0 = %v", got)
+	}
+	if got := skipListOffset(rows, 2); !slices.Equal(got, []int{3}) {
+		t.Errorf("offset 2 = %v", got)
+	}
+	if got := skipListOffset(rows, 5); len(got) != 0 {
+		t.Errorf("offset past the end = %v", got)
+	}
+}
@@ -13,11 +13,21 @@ import (
 // printTruncationHint emits a one-line notice to stderr when the list output
 // was truncated by --limit, so users and agents can't mistake a partial view
 // for a complete one (GH#3212, GH#788).
+// Under bd list it also marks the page as truncated, so bd more has
+// somewhere to continue from.
 func printTruncationHint(truncated bool, effectiveLimit int) {
-	if !truncated || effectiveLimit <= 0 || !ui.IsStderrTerminal() {
+	if !truncated || effectiveLimit <= 0 {
 		return
 	}
-	msg := fmt.Sprintf("\nShowing %d issues; more results matched but were hidden by --limit. Use --limit 0 for all, or --limit N to raise the cap.\n", effectiveLimit)
+	next := "Use --limit 0 for all, or --limit N to raise the cap."
+	if listPaging {
+		listTruncated = true
+		next = "Run 'bd more' for the next page, --limit 0 for all, or --limit N to raise the cap."
+	}
+	if !ui.IsStderrTerminal() {
+		return
+	}
+	msg := fmt.Sprintf("\nShowing %d issues; more results matched but were hidden by --limit. %s\n", effectiveLimit, next)
 	fmt.Fprint(os.Stderr, ui.RenderWarn(msg))
 }
 
@@ -257,6 +257,9 @@ func emitProxiedListJSONResult(iwc []*types.IssueWithCounts, in listInput, hasMo
 	if iwc == nil {
 		iwc = []*types.IssueWithCounts{}
 	}
+	if in.includeWisps {
+		tagIssueSources(iwc)
+	}
 	var err error
 	if in.skipLabels {
 		err = outputJSON(newSkipLabelsListJSONResponse(iwc))
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"charm.land/huh/v2"
+	"golang.org/x/term"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Actions offered by bd list --select.
+const (
+	bulkActionClose    = "close"
+	bulkActionLabel    = "label"
+	bulkActionAssign   = "assign"
+	bulkActionPriority = "priority"
+)
+
+// bulkAction is one action applied to every selected issue.
+type bulkAction struct {
+	Kind  string
+	Value string // close reason, label, assignee, or priority digit
+}
+
+func (a bulkAction) describe() string {
+	switch a.Kind {
+	case bulkActionClose:
+		return "close (" + a.Value + ")"
+	case bulkActionLabel:
+		return "label +" + a.Value
+	case bulkActionAssign:
+		return "assign to " + a.Value
+	case bulkActionPriority:
+		return "set priority P" + a.Value
+	}
+	return a.Kind
+}
+
+// runListSelect lets the user mark rows of a list and apply one action to
+// all of them.
+func runListSelect(ctx context.Context, st storage.DoltStorage, issues []*types.Issue) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) { // #nosec G115 -- file descriptors fit in int
+		return HandleError("--select needs an interactive terminal")
+	}
+	if len(issues) == 0 {
+		fmt.Println("No issues found.")
+		return nil
+	}
+
+	options := make([]huh.Option[string], 0, len(issues))
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+		label := fmt.Sprintf("%s  P%d  %-11s  %s", issue.ID, issue.Priority, issue.Status, issue.Title)
+		options = append(options, huh.NewOption(label, issue.ID))
+	}
+	var selected []string
+	var action bulkAction
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Select issues").
+				Description("space: mark · ctrl+a: all · /: filter · enter: continue").
+				Options(options...).
+				Height(min(len(options)+2, 20)).
+				Filterable(true).
+				Value(&selected).
+				Validate(func(ids []string) error {
+					if len(ids) == 0 {
+						return errors.New("mark at least one issue")
+					}
+					return nil
+				}),
+			huh.NewSelect[string]().
+				Title("Action").
+				Options(
+					huh.NewOption("Close", bulkActionClose),
+					huh.NewOption("Add label", bulkActionLabel),
+					huh.NewOption("Assign", bulkActionAssign),
+					huh.NewOption("Set priority", bulkActionPriority),
+				).
+				Value(&action.Kind),
+		),
+	).WithTheme(huh.ThemeFunc(huh.ThemeDracula))
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			fmt.Fprintln(os.Stderr, "Selection canceled.")
+			return nil
+		}
+		return HandleError("form error: %v", err)
+	}
+
+	var valueField huh.Field
+	switch action.Kind {
+	case bulkActionClose:
+		action.Value = "fixed"
+		valueField = huh.NewInput().Title("Close reason").Value(&action.Value)
+	case bulkActionLabel:
+		valueField = huh.NewInput().Title("Label").Value(&action.Value).Validate(requireNonEmpty("label"))
+	case bulkActionAssign:
+		valueField = huh.NewInput().Title("Assignee").Value(&action.Value).Validate(requireNonEmpty("assignee"))
+	case bulkActionPriority:
+		action.Value = "2"
+		valueField = huh.NewSelect[string]().Title("Priority").Options(
+			huh.NewOption("P0 - Critical", "0"),
+			huh.NewOption("P1 - High", "1"),
+			huh.NewOption("P2 - Medium", "2"),
+			huh.NewOption("P3 - Low", "3"),
+			huh.NewOption("P4 - Backlog", "4"),
+		).Value(&action.Value)
+	}
+	confirmed := true
+	form = huh.NewForm(
+		huh.NewGroup(valueField),
+		huh.NewGroup(
+			huh.NewConfirm().
+				TitleFunc(func() string {
+					return fmt.Sprintf("Apply %s to %d issue(s)?", action.describe(), len(selected))
+				}, &action.Value).
+				Affirmative("Apply").
+				Negative("Cancel").
+				Value(&confirmed),
+		),
+	).WithTheme(huh.ThemeFunc(huh.ThemeDracula))
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			fmt.Fprintln(os.Stderr, "Selection canceled.")
+			return nil
+		}
+		return HandleError("form error: %v", err)
+	}
+	if !confirmed {
+		fmt.Fprintln(os.Stderr, "Selection canceled.")
+		return nil
+	}
+	action.Value = strings.TrimSpace(action.Value)
+
+	if action.Kind == bulkActionClose {
+		if err := validateCloseReasons([]string{action.Value}); err != nil {
+			return HandleError("%v", err)
+		}
+		if err := validateCloseReasonCategories([]string{action.Value}); err != nil {
+			return HandleError("%v", err)
+		}
+		for _, id := range selected {
+			duplicateLinked := func() (bool, error) { return hasDuplicateLink(ctx, st, id) }
+			if err := checkClosePolicies(id, byID[id], action.Value, duplicateLinked); err != nil {
+				return HandleError("cannot close %s: %v; no issues were changed", id, err)
+			}
+		}
+	}
+	if err := applyBulkAction(ctx, st, selected, action); err != nil {
+		return HandleError("%v; no issues were changed", err)
+	}
+	commandDidWrite.Store(true)
+	fmt.Printf("%s Applied %s to %d issue(s)\n", ui.RenderPass("✓"), action.describe(), len(selected))
+	for _, id := range selected {
+		fmt.Printf("  %s\n", formatFeedbackID(id, byID[id].Title))
+		if action.Kind != bulkActionClose || byID[id].Status == types.StatusClosed {
+			continue
+		}
+		inReview, followups := runPostCloseHooks(ctx, st, id, byID[id], actor)
+		if inReview {
+			fmt.Printf("    %s waiting for human review (bd review list)\n", ui.RenderWarn("→"))
+		}
+		for _, f := range followups {
+			fmt.Printf("    %s follow-up %s created\n", ui.RenderAccent("→"), f)
+		}
+	}
+	return nil
+}
+
+func requireNonEmpty(what string) func(string) error {
+	return func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return fmt.Errorf("%s is required", what)
+		}
+		return nil
+	}
+}
+
+// applyBulkAction applies action to every id in one transaction, so either
+// all of them change or none do. Closing refuses blocked issues, as bd
+// close does without --force.
+func applyBulkAction(ctx context.Context, st storage.DoltStorage, ids []string, action bulkAction) error {
+	var priority int
+	if action.Kind == bulkActionPriority {
+		p, err := strconv.Atoi(action.Value)
+		if err != nil || p < 0 || p > 4 {
+			return fmt.Errorf("invalid priority %q", action.Value)
+		}
+		priority = p
+	}
+	if action.Kind == bulkActionClose {
+		for _, id := range ids {
+			blocked, blockers, err := st.IsBlocked(ctx, id)
+			if err != nil {
+				return fmt.Errorf("%s: %w", id, err)
+			}
+			if blocked {
+				return fmt.Errorf("%s is blocked by %s", id, strings.Join(blockers, ", "))
+			}
+		}
+	}
+	msg := fmt.Sprintf("bd: %s %d issues", action.describe(), len(ids))
+	return transact(ctx, st, msg, func(tx storage.Transaction) error {
+		for _, id := range ids {
+			var err error
+			switch action.Kind {
+			case bulkActionClose:
+				err = tx.CloseIssue(ctx, id, action.Value, actor, "")
+			case bulkActionLabel:
+				err = tx.AddLabel(ctx, id, action.Value, actor)
+			case bulkActionAssign:
+				err = tx.UpdateIssue(ctx, id, map[string]interface{}{"assignee": action.Value}, actor)
+			case bulkActionPriority:
+				err = tx.UpdateIssue(ctx, id, map[string]interface{}{"priority": priority}, actor)
+			default:
+				return fmt.Errorf("unknown action %q", action.Kind)
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// fakeBulkStore applies a transaction's writes only when fn succeeds.
+type fakeBulkStore struct {
+	storage.DoltStorage
+	blocked   map[string][]string
+	missing   string // writes to this issue fail
+	committed []string
+	txMsg     string
+}
+
+type fakeBulkTx struct {
+	storage.Transaction
+	writes  []string
+	missing string
+}
+
+func (t *fakeBulkTx) write(id, what string) error {
+	if id == t.missing {
+		return errors.New("not found")
+	}
+	t.writes = append(t.writes, id+" "+what)
+	return nil
+}
+
+func (t *fakeBulkTx) CloseIssue(_ context.Context, id, reason, _, _ string) error {
+	return t.write(id, "close "+reason)
+}
+
+func (t *fakeBulkTx) AddLabel(_ context.Context, id, label, _ string) error {
+	return t.write(id, "label "+label)
+}
+
+func (t *fakeBulkTx) UpdateIssue(_ context.Context, id string, updates map[string]interface{}, _ string) error {
+	if a, ok := updates["assignee"]; ok {
+		return t.write(id, "assignee "+a.(string))
+	}
+	if p, ok := updates["priority"].(int); ok {
+		return t.write(id, "priority "+strconv.Itoa(p))
+	}
+	return t.write(id, "update")
+}
+
+func (f *fakeBulkStore) IsBlocked(_ context.Context, id string) (bool, []string, error) {
+	return len(f.blocked[id]) > 0, f.blocked[id], nil
+}
+
+func (f *fakeBulkStore) RunInTransaction(_ context.Context, msg string, fn func(storage.Transaction) error) error {
+	tx := &fakeBulkTx{missing: f.missing}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	f.txMsg = msg
+	f.committed = append(f.committed, tx.writes...)
+	return nil
+}
+
+func TestApplyBulkAction(t *testing.T) {
+	ctx := context.Background()
+	ids := []string{"bd-1", "bd-2"}
+
+	tests := []struct {
+		action bulkAction
+		want   []string
+	}{
+		{bulkAction{Kind: bulkActionClose, Value: "fixed"}, []string{"bd-1 close fixed", "bd-2 close fixed"}},
+		{bulkAction{Kind: bulkActionLabel, Value: "ui"}, []string{"bd-1 label ui", "bd-2 label ui"}},
+		{bulkAction{Kind: bulkActionAssign, Value: "alice"}, []string{"bd-1 assignee alice", "bd-2 assignee alice"}},
+		{bulkAction{Kind: bulkActionPriority, Value: "1"}, []string{"bd-1 priority 1", "bd-2 priority 1"}},
+	}
+	for _, tt := range tests {
+		st := &fakeBulkStore{}
+		if err := applyBulkAction(ctx, st, ids, tt.action); err != nil {
+			t.Fatalf("%s: %v", tt.action.Kind, err)
+		}
+		if strings.Join(st.committed, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: committed %v, want %v", tt.action.Kind, st.committed, tt.want)
+		}
+		if !strings.Contains(st.txMsg, "2 issues") {
+			t.Errorf("%s: commit message %q does not mention the count", tt.action.Kind, st.txMsg)
+		}
+	}
+}
+
+func TestApplyBulkActionAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	ids := []string{"bd-1", "bd-2", "bd-3"}
+
+	st := &fakeBulkStore{missing: "bd-3"}
+	err := applyBulkAction(ctx, st, ids, bulkAction{Kind: bulkActionLabel, Value: "ui"})
+	if err == nil || !strings.Contains(err.Error(), "bd-3") {
+		t.Fatalf("err = %v, want failure naming bd-3", err)
+	}
+	if len(st.committed) != 0 {
+		t.Errorf("committed %v after a failed write", st.committed)
+	}
+
+	st = &fakeBulkStore{blocked: map[string][]string{"bd-2": {"bd-9"}}}
+	err = applyBulkAction(ctx, st, ids, bulkAction{Kind: bulkActionClose, Value: "fixed"})
+	if err == nil || !strings.Contains(err.Error(), "bd-2 is blocked by bd-9") {
+		t.Fatalf("err = %v, want blocked error", err)
+	}
+	if len(st.committed) != 0 {
+		t.Errorf("closed %v despite a blocked issue", st.committed)
+	}
+
+	if err := applyBulkAction(ctx, &fakeBulkStore{}, ids, bulkAction{Kind: bulkActionPriority, Value: "7"}); err == nil {
+		t.Error("priority 7 accepted")
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// editLockTTL bounds the lock bd edit holds while the editor is open, so a
+// crashed session frees the issue on its own.
+const editLockTTL = time.Hour
+
+var lockCmd = &cobra.Command{
+	Use:     "lock <id>",
+	GroupID: "issues",
+	Short:   "Lock an issue so others don't overwrite your edits",
+	Long: `Take an advisory lock on an issue while you work on it.
+
+While the lock is live, bd edit and bd update refuse changes to the issue
+from anyone else unless they pass --force, and bd show prints who holds it.
+Locks expire on their own after --ttl; running bd lock again extends yours.
+bd edit takes a lock for as long as the editor is open, so two people
+editing the same description find out before either one saves.
+
+Examples:
+  bd lock bd-42                              # Lock for 15 minutes
+  bd lock bd-42 --ttl 1h --reason "rewriting the spec"
+  bd lock bd-42 --force                      # Take over someone else's lock
+  bd lock list                               # Every live lock
+  bd unlock bd-42`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("lock")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("lock is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("lock")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+		reason, _ := cmd.Flags().GetString("reason")
+		force, _ := cmd.Flags().GetBool("force")
+		if ttl <= 0 {
+			return HandleErrorRespectJSON("--ttl must be positive")
+		}
+
+		ctx := rootCtx
+		ls, err := issueLockStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		lock, err := ls.LockIssue(ctx, id, getActor(), ttl, reason, force)
+		if errors.Is(err, storage.ErrIssueLocked) {
+			return HandleErrorRespectJSON("%v (use --force to take it over)", err)
+		}
+		if err != nil {
+			return HandleErrorRespectJSON("locking %s: %v", id, err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(id)
+
+		if jsonOutput {
+			return outputJSON(lock)
+		}
+		fmt.Printf("%s Locked %s until %s\n", ui.RenderPass("✓"), id, formatLockExpiry(lock, time.Now()))
+		return nil
+	},
+}
+
+var unlockCmd = &cobra.Command{
+	Use:     "unlock <id>",
+	GroupID: "issues",
+	Short:   "Release an issue lock",
+	Long: `Release the advisory lock taken with bd lock.
+
+Releasing someone else's live lock needs --force.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("unlock")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("unlock is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("unlock")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		force, _ := cmd.Flags().GetBool("force")
+		ctx := rootCtx
+		ls, err := issueLockStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		err = ls.UnlockIssue(ctx, id, getActor(), force)
+		if errors.Is(err, storage.ErrIssueLocked) {
+			return HandleErrorRespectJSON("%v (use --force to release it)", err)
+		}
+		if err != nil {
+			return HandleErrorRespectJSON("unlocking %s: %v", id, err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]string{"issue_id": id, "status": "unlocked"})
+		}
+		fmt.Printf("%s Unlocked %s\n", ui.RenderPass("✓"), id)
+		return nil
+	},
+}
+
+var lockListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List live issue locks",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("lock is not supported in proxied-server mode")
+		}
+		ls, err := issueLockStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		locks, err := ls.ListIssueLocks(rootCtx)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			if locks == nil {
+				locks = []*types.IssueLock{}
+			}
+			return outputJSON(locks)
+		}
+		if len(locks) == 0 {
+			fmt.Println("No issues are locked")
+			return nil
+		}
+		now := time.Now()
+		for _, l := range locks {
+			line := fmt.Sprintf("%s  %s  until %s", ui.RenderID(l.IssueID), l.Holder, formatLockExpiry(l, now))
+			if l.Reason != "" {
+				line += ui.RenderMuted("  " + l.Reason)
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+func issueLockStore() (storage.IssueLockStore, error) {
+	ls, ok := storage.UnwrapStore(store).(storage.IssueLockStore)
+	if !ok {
+		return nil, fmt.Errorf("issue locks are not supported by this storage backend")
+	}
+	return ls, nil
+}
+
+// formatLockExpiry renders when a lock expires: "15:04 (12m left)".
+func formatLockExpiry(l *types.IssueLock, now time.Time) string {
+	left := l.ExpiresAt.Sub(now).Round(time.Minute)
+	at := l.ExpiresAt.Local().Format("15:04")
+	switch {
+	case left < time.Minute:
+		return at + " (<1m left)"
+	case left < time.Hour:
+		return fmt.Sprintf("%s (%dm left)", at, left/time.Minute)
+	case left%time.Hour == 0:
+		return fmt.Sprintf("%s (%dh left)", at, left/time.Hour)
+	default:
+		return fmt.Sprintf("%s (%dh%dm left)", at, left/time.Hour, left%time.Hour/time.Minute)
+	}
+}
+
+// formatIssueLock renders a live lock for bd show.
+func formatIssueLock(l *types.IssueLock, now time.Time) string {
+	s := fmt.Sprintf("Locked by %s until %s", l.Holder, formatLockExpiry(l, now))
+	if l.Reason != "" {
+		s += ": " + l.Reason
+	}
+	return s
+}
+
+// currentIssueLock returns the issue's live lock, or nil when it is
+// unlocked or the store has no locks.
+func currentIssueLock(ctx context.Context, st storage.DoltStorage, id string) *types.IssueLock {
+	ls, ok := storage.UnwrapStore(st).(storage.IssueLockStore)
+	if !ok {
+		return nil
+	}
+	locks, err := ls.GetIssueLocks(ctx, []string{id})
+	if err != nil {
+		debug.Logf("lock: reading lock of %s: %v\n", id, err)
+		return nil
+	}
+	return locks[id]
+}
+
+// checkIssueLock refuses a change to an issue someone else has locked,
+// unless force is set.
+func checkIssueLock(ctx context.Context, st storage.DoltStorage, id string, force bool) error {
+	if force {
+		return nil
+	}
+	if l := currentIssueLock(ctx, st, id); l != nil && l.Holder != getActor() {
+		return fmt.Errorf("%s is locked by %s until %s; use --force to override",
+			id, l.Holder, formatLockExpiry(l, time.Now()))
+	}
+	return nil
+}
+
+// lockForEdit takes the lock bd edit holds while the editor is open and
+// returns the function that releases it. An issue the actor already has
+// locked stays locked afterwards; without lock support the edit proceeds
+// unlocked.
+func lockForEdit(ctx context.Context, st storage.DoltStorage, id string, force bool) (release func(), err error) {
+	noop := func() {}
+	ls, ok := storage.UnwrapStore(st).(storage.IssueLockStore)
+	if !ok {
+		return noop, nil
+	}
+	me := getActor()
+	if l := currentIssueLock(ctx, st, id); l != nil && l.Holder == me {
+		return noop, nil
+	}
+	if _, err := ls.LockIssue(ctx, id, me, editLockTTL, "bd edit", force); err != nil {
+		if errors.Is(err, storage.ErrIssueLocked) {
+			return nil, fmt.Errorf("%v; use --force to edit anyway", err)
+		}
+		debug.Logf("lock: locking %s for edit: %v\n", id, err)
+		return noop, nil
+	}
+	released := false
+	return func() {
+		if released {
+			return
+		}
+		released = true
+		if err := ls.UnlockIssue(ctx, id, me, false); err != nil {
+			debug.Logf("lock: releasing %s after edit: %v\n", id, err)
+		}
+	}, nil
+}
+
+func init() {
+	lockCmd.Flags().Duration("ttl", 15*time.Minute, "How long the lock lasts")
+	lockCmd.Flags().String("reason", "", "What you are doing, shown to others")
+	lockCmd.Flags().Bool("force", false, "Take over a lock someone else holds")
+	unlockCmd.Flags().Bool("force", false, "Release a lock someone else holds")
+	lockCmd.AddCommand(lockListCmd)
+	lockCmd.ValidArgsFunction = issueIDCompletion
+	unlockCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFormatLockExpiry(t *testing.T) {
+	now := time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		left time.Duration
+		want string
+	}{
+		{20 * time.Second, "(<1m left)"},
+		{15 * time.Minute, "(15m left)"},
+		{time.Hour, "(1h left)"},
+		{90 * time.Minute, "(1h30m left)"},
+	}
+	for _, tt := range tests {
+		l := &types.IssueLock{ExpiresAt: now.Add(tt.left)}
+		if got := formatLockExpiry(l, now); !strings.HasSuffix(got, tt.want) {
+			t.Errorf("formatLockExpiry(%s left) = %q, want suffix %q", tt.left, got, tt.want)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/subosito/gotenv"
 
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/besteffort"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/debug"
@@ -81,8 +82,11 @@ var (
 	serverMode        bool
 	proxiedServerMode bool
 	readonlyMode      bool               // Read-only mode: block write operations (for worker sandboxes)
+	atCheckpoint      string             // --at: read from this checkpoint (implies read-only mode)
+	rigScope          string             // --rig: scope filters, claims, and new issues to this rig (see bd rig)
 	storeIsReadOnly   bool               // Track if store was opened read-only (for staleness checks)
 	ignoreSchemaSkew  bool               // Proceed despite forward schema drift
+	sovereignOverride bool               // Allow writes to issues owned by sovereign federation peers
 	lockTimeout       = 30 * time.Second // Dolt open timeout (fixed default)
 	profileEnabled    bool
 	profileFile       *os.File
@@ -150,13 +154,13 @@ func isReadOnlyCommand(cmdName string) bool {
 }
 
 // isWorkingSetReconcileCommand reports whether cmd's whole purpose is to
-// reconcile the Dolt working set: "bd dolt commit" or "bd vc commit". These
-// commands are the documented recovery from a pending-migration dirty-table
-// refusal, but they also open the store, and an open runs the migration -
-// hitting that same refusal before the commit that would clear the dirty
-// state ever runs. Opening leniently (embeddeddolt.OpenForWorkingSetReconcile)
-// breaks that deadlock by skipping the migration instead of failing the open
-// (gastownhall/beads#4566).
+// reconcile the Dolt working set: "bd commit", "bd dolt commit", or "bd vc
+// commit". These commands are the documented recovery from a
+// pending-migration dirty-table refusal, but they also open the store, and
+// an open runs the migration - hitting that same refusal before the commit
+// that would clear the dirty state ever runs. Opening leniently
+// (embeddeddolt.OpenForWorkingSetReconcile) breaks that deadlock by skipping
+// the migration instead of failing the open (gastownhall/beads#4566).
 func isWorkingSetReconcileCommand(cmd *cobra.Command) bool {
 	if cmd.Name() != "commit" {
 		return false
@@ -165,7 +169,7 @@ func isWorkingSetReconcileCommand(cmd *cobra.Command) bool {
 	if parent == nil {
 		return false
 	}
-	return parent.Name() == "dolt" || parent.Name() == "vc"
+	return parent.Parent() == nil || parent.Name() == "dolt" || parent.Name() == "vc"
 }
 
 // isForcedMigrate reports whether cmd is `bd migrate` or `bd migrate schema`
@@ -497,13 +501,18 @@ func refreshBoundCommandConfig(cmd *cobra.Command) {
 		jsonOutput = config.GetBool("json")
 	}
 	if !root.PersistentFlags().Changed("readonly") {
-		readonlyMode = config.GetBool("readonly")
+		readonlyMode = config.GetBool("readonly") || atCheckpoint != ""
+	} else {
+		readonlyMode = readonlyMode || readonlyFromEnv()
 	}
 	if !root.PersistentFlags().Changed("actor") {
 		actor = config.GetString("actor")
 	}
+	if !root.PersistentFlags().Changed("rig") {
+		rigScope = config.GetString("rig")
+	}
 	if !root.PersistentFlags().Changed("dolt-auto-commit") {
-		doltAutoCommit = config.GetString("dolt.auto-commit")
+		doltAutoCommit = configuredDoltAutoCommit()
 	}
 }
 
@@ -606,13 +615,17 @@ func init() {
 	rootCmd.PersistentFlags().String("format", "", "Output format (json). Alias for --json")
 	_ = rootCmd.PersistentFlags().MarkHidden("format") // Hidden alias for CLI ergonomics
 	rootCmd.PersistentFlags().BoolVar(&sandboxMode, "sandbox", false, "Sandbox mode: disables Dolt auto-push")
-	rootCmd.PersistentFlags().BoolVar(&readonlyMode, "readonly", false, "Read-only mode: block write operations (for worker sandboxes)")
+	rootCmd.PersistentFlags().BoolVar(&readonlyMode, "readonly", false, "Read-only mode: refuse every command that can write (for worker sandboxes; also --read-only or BD_READONLY=1)")
+	rootCmd.SetGlobalNormalizationFunc(normalizeReadonlyFlag)
+	rootCmd.PersistentFlags().StringVar(&rigScope, "rig", "", "Scope list, ready, claims, and new issues to this rig (default: $BD_RIG or config key rig)")
+	rootCmd.PersistentFlags().StringVar(&atCheckpoint, "at", "", "Run a read command against a checkpoint (or any Dolt tag, branch, or commit) instead of the current state")
 	rootCmd.PersistentFlags().BoolVar(&globalFlag, "global", false, "Use the global shared-server database (beads_global)")
-	rootCmd.PersistentFlags().StringVar(&doltAutoCommit, "dolt-auto-commit", "", "Dolt auto-commit policy (off|on|batch). 'on': commit after each write. 'batch': defer commits to bd dolt commit; uncommitted changes persist in the working set until then. SIGTERM/SIGHUP flush pending batch commits. Default: off. Override via config key dolt.auto-commit")
+	rootCmd.PersistentFlags().StringVar(&doltAutoCommit, "dolt-auto-commit", "", "Dolt auto-commit policy (off|on|batch). 'on': commit after each write. 'batch': defer commits to bd commit, or until commit.batch_interval or commit.batch_max_ops is reached (setting either implies batch); uncommitted changes persist in the working set until then. SIGINT/SIGTERM/SIGHUP flush pending batch commits. Default: off. Override via config key dolt.auto-commit")
 	rootCmd.PersistentFlags().BoolVar(&profileEnabled, "profile", false, "Generate CPU profile for performance analysis")
 	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose/debug output")
 	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress non-essential output (errors only)")
 	rootCmd.PersistentFlags().BoolVar(&ignoreSchemaSkew, "ignore-schema-skew", false, "Proceed despite forward schema drift (some queries may fail)")
+	rootCmd.PersistentFlags().BoolVar(&sovereignOverride, "override-sovereignty", false, "Allow changes to issues owned by sovereign federation peers (T2+)")
 	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable color output (also: NO_COLOR=1 or CLICOLOR=0)")
 
 	// Add --version flag to root command (same behavior as version subcommand)
@@ -644,6 +657,12 @@ func resolveChangeDirBeadsDir(path string) (string, error) {
 		return "", fmt.Errorf("cannot resolve -C directory %q: %w", path, err)
 	}
 	info, err := os.Stat(absPath)
+	if errors.Is(err, os.ErrNotExist) {
+		// Not a directory here: -C also takes a registered town name.
+		if town, ok := lookupTown(path); ok {
+			return beads.FollowRedirect(town.Path), nil
+		}
+	}
 	if err != nil {
 		return "", fmt.Errorf("cannot use -C directory %q: %w", path, err)
 	}
@@ -657,13 +676,19 @@ func resolveChangeDirBeadsDir(path string) (string, error) {
 	return beadsDir, nil
 }
 
-func applyChangeDirSelection() error {
-	if strings.TrimSpace(changeDir) == "" {
-		return nil
+func applyChangeDirSelection(cmd *cobra.Command) error {
+	var beadsDir string
+	if strings.TrimSpace(changeDir) != "" {
+		dir, err := resolveChangeDirBeadsDir(changeDir)
+		if err != nil {
+			return HandleError("%v", err)
+		}
+		beadsDir = dir
+	} else {
+		beadsDir = currentTownBeadsDir(cmd)
 	}
-	beadsDir, err := resolveChangeDirBeadsDir(changeDir)
-	if err != nil {
-		return HandleError("%v", err)
+	if beadsDir == "" {
+		return nil
 	}
 	changeDirEnvSnapshot = make(map[string]envSnapshotValue, 3)
 	for _, key := range []string{"BEADS_DIR", "BEADS_DB", "BD_DB"} {
@@ -761,7 +786,7 @@ var rootCmd = &cobra.Command{
 		debug.SetVerbose(verboseFlag)
 		debug.SetQuiet(quietFlag)
 
-		if err := applyChangeDirSelection(); err != nil {
+		if err := applyChangeDirSelection(cmd); err != nil {
 			return err
 		}
 
@@ -801,11 +826,17 @@ var rootCmd = &cobra.Command{
 		if !cmd.Root().PersistentFlags().Changed("readonly") {
 			readonlyMode = config.GetBool("readonly")
 		} else {
+			// The flag can turn read-only on but never off under BD_READONLY.
+			readonlyMode = readonlyMode || readonlyFromEnv()
 			flagOverrides["readonly"] = struct {
 				Value  interface{}
 				WasSet bool
 			}{readonlyMode, true}
 		}
+		// --at reads history, so only read commands may run with it.
+		if atCheckpoint != "" {
+			readonlyMode = true
+		}
 		if !cmd.Root().PersistentFlags().Changed("db") && dbPath == "" &&
 			os.Getenv("BEADS_DB") == "" && os.Getenv("BD_DB") == "" && os.Getenv("BEADS_DIR") == "" {
 			dbPath = config.GetString("db")
@@ -823,8 +854,16 @@ var rootCmd = &cobra.Command{
 				WasSet bool
 			}{actor, true}
 		}
+		if !cmd.Root().PersistentFlags().Changed("rig") && rigScope == "" {
+			rigScope = config.GetString("rig")
+		} else if cmd.Root().PersistentFlags().Changed("rig") {
+			flagOverrides["rig"] = struct {
+				Value  interface{}
+				WasSet bool
+			}{rigScope, true}
+		}
 		if !cmd.Root().PersistentFlags().Changed("dolt-auto-commit") && strings.TrimSpace(doltAutoCommit) == "" {
-			doltAutoCommit = config.GetString("dolt.auto-commit")
+			doltAutoCommit = configuredDoltAutoCommit()
 		} else if cmd.Root().PersistentFlags().Changed("dolt-auto-commit") {
 			flagOverrides["dolt-auto-commit"] = struct {
 				Value  interface{}
@@ -858,8 +897,10 @@ var rootCmd = &cobra.Command{
 			"context", // reads config files directly, does not need DB open
 			"codex-hook",
 			"cursor-hook", // shells out to `bd prime`; never opens the store itself
+			"demo",        // creates and seeds its own sandbox workspace
 			"doctor",
-			"dolt", // bare "bd dolt" shows help only; subcommands handled below
+			"dolt",   // bare "bd dolt" shows help only; subcommands handled below
+			"errors", // static list of error codes
 			"fish",
 			"formula", // parser-only subcommands; add a store-needed guard before adding DB-backed formula subcommands
 			"help",
@@ -870,11 +911,14 @@ var rootCmd = &cobra.Command{
 			"merge",
 			"metrics", // config-only: status/on/off/example never touch the DB
 			"onboard",
+			"plugin", // lists bd-* executables on PATH; never opens the store
 			"powershell",
 			"prime",
+			"queue", // list/drop only touch the queue file; replay handled below
 			"quickstart",
 			metrics.SendMetricsSubcommand,
 			"setup",
+			"towns", // opens each registered town itself, read-only
 			"version",
 			"where",
 			"zsh",
@@ -902,6 +946,8 @@ var rootCmd = &cobra.Command{
 				// GH#2042: dolt push/pull/commit need the store — fall through to init
 			} else if slices.Contains(needsStoreDoltGrandchildren, parentName) {
 				// GH#2224: dolt remote add/list/remove need the store — fall through to init
+			} else if parentName == "queue" && cmdName == "replay" {
+				// bd queue replay applies the queue — fall through to init
 			} else if parentName == "migrate" && slices.Contains(skipStoreMigrateSubcommands, cmdName) {
 				skipsStoreInit = true
 			} else if slices.Contains(noDbCommands, parentName) {
@@ -931,6 +977,9 @@ var rootCmd = &cobra.Command{
 		// corrupts machine-readable output. No-op after the first run.
 		maybeShowMetricsFirstRunNotice(cmd)
 
+		// Progress output depends on the resolved json/quiet mode too.
+		installProgressReporter()
+
 		// Commands that skip store initialization still need early config/env
 		// setup before they inspect server mode or per-project Dolt settings.
 		// Rebind them to the selected workspace so explicit --db / BEADS_DB
@@ -953,7 +1002,18 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
+		// Read-only mode fails closed here, in the dispatch layer, so no
+		// command outside the read allowlist runs — with or without a store.
+		if err := enforceReadonlyCommand(cmd); err != nil {
+			return HandleError("%v", err)
+		}
+
 		if skipsStoreInit {
+			// Without a store the token cannot be verified, so an agent
+			// session only gets the commands that cannot write.
+			if os.Getenv(tokenEnvVar) != "" && !readonlyCommandAllowed(cmd) {
+				return HandleError("operation '%s' is not allowed with an agent token", readonlyCommandPath(cmd))
+			}
 			return nil
 		}
 
@@ -1075,6 +1135,10 @@ var rootCmd = &cobra.Command{
 		if _, err := getDoltAutoCommitMode(); err != nil {
 			return HandleError("%v", err)
 		}
+		// The target workspace's config.yaml may turn read-only mode on.
+		if err := enforceReadonlyCommand(cmd); err != nil {
+			return HandleError("%v", err)
+		}
 
 		// Resolve the backend before version tracking, migration, server startup, or
 		// any store construction. PostgreSQL/MySQL values are retained as metadata
@@ -1102,7 +1166,7 @@ var rootCmd = &cobra.Command{
 		// Check if this is a read-only command (GH#804)
 		// Read-only commands open the store in read-only mode to avoid modifying
 		// the database (which breaks file watchers).
-		useReadOnly := isReadOnlyCommand(cmd.Name())
+		useReadOnly := isReadOnlyCommand(cmd.Name()) || readonlyMode
 
 		// If the operator passed --force on `bd migrate` or `bd migrate schema`,
 		// set the programmatic gate override before both autoMigrateOnVersionBump
@@ -1124,7 +1188,9 @@ var rootCmd = &cobra.Command{
 		// and closes BEFORE the main store is opened. This ensures bd doctor and
 		// read-only commands see the correct version after a CLI upgrade.
 
-		autoMigrateOnVersionBump(beadsDir)
+		if !readonlyMode {
+			autoMigrateOnVersionBump(beadsDir)
+		}
 
 		// Initialize direct storage access
 		var err error
@@ -1244,10 +1310,14 @@ var rootCmd = &cobra.Command{
 		// Keep standalone CLI auto-start behavior centralized so doctor and
 		// other helper paths stay in lockstep with the main command path.
 		dolt.ApplyCLIAutoStart(beadsDir, doltCfg)
+		dolt.ApplyCLIPoolConfig(doltCfg)
 
 		// In proxied mode the CLI short-circuits to the uowProvider path and
 		// dispatches through the *_proxied_server.go duals.
 		if proxiedServerMode {
+			if atCheckpoint != "" {
+				return HandleError("--at is not supported in proxied-server mode")
+			}
 			p, err := newProxiedServerUOWProvider(rootCtx, beadsDir)
 			if err != nil {
 				return HandleError("failed to open uow provider: %v", err)
@@ -1256,6 +1326,9 @@ var rootCmd = &cobra.Command{
 
 			reconcileVersionProxiedServer(rootCtx)
 
+			if err := enforceAgentToken(cmd, args); err != nil {
+				return HandleError("%v", err)
+			}
 			syncCommandContext()
 			return nil
 		}
@@ -1275,6 +1348,9 @@ var rootCmd = &cobra.Command{
 		}
 
 		doltCfg.Path = doltPath
+		if atCheckpoint != "" && doltCfg.ServerMode {
+			return HandleError("--at is not supported in server mode yet")
+		}
 
 		// WARNING: DO NOT remove, delete, or modify files inside Dolt's .dolt/
 		// directory — including noms/LOCK files. These are Dolt-internal files.
@@ -1312,6 +1388,20 @@ var rootCmd = &cobra.Command{
 				}
 				return SilentExit()
 			}
+			// With --queue, a write that cannot reach the server is queued
+			// locally and replayed once the server is back.
+			if queueRequested(cmd) && dolt.IsServerUnreachable(err) {
+				t, tokErr := enforceAgentTokenOffline(cmd, beadsDir)
+				if tokErr != nil {
+					return HandleError("%v", tokErr)
+				}
+				offlineQueueToken = t
+				offlineQueueErr = err
+				offlineQueueBeadsDir = beadsDir
+				store = nil
+				syncCommandContext()
+				return nil
+			}
 			return HandleError("failed to open database: %v", err)
 		}
 
@@ -1320,6 +1410,18 @@ var rootCmd = &cobra.Command{
 		storeActive = true
 		storeMutex.Unlock()
 
+		// Work on the branch bd branch switch made active; a checkpoint
+		// view below branches from it and returns to it.
+		restoreActiveBranch(rootCtx, store)
+
+		if atCheckpoint != "" {
+			leave, err := enterCheckpointView(rootCtx, store, atCheckpoint)
+			if err != nil {
+				return HandleError("cannot open checkpoint %q: %v (see 'bd checkpoint list')", atCheckpoint, err)
+			}
+			leaveCheckpointView = leave
+		}
+
 		// Auto-import from issues.jsonl when embedded database is empty (GH#2994).
 		// This handles the upgrade path from pre-0.56 (dolt/) to 1.0+ (embeddeddolt/)
 		// where the new embedded database starts empty but the git-tracked JSONL
@@ -1356,6 +1458,18 @@ var rootCmd = &cobra.Command{
 		// run). Order matters — see wireStorageDecorators in storage_chain.go.
 		store = wireStorageDecorators(store, hookRunner, config.GetBool("no-hooks"))
 
+		// Refuse local writes to issues that sovereign federation peers own;
+		// they change on the peer and arrive here by sync.
+		if !useReadOnly && !sovereignOverride && store != nil {
+			store = storage.NewSovereigntyGuardStore(store)
+		}
+
+		// Encrypt the description, design, and notes of issues created or
+		// updated with --encrypted, and decrypt them for show.
+		if dbPath != "" && store != nil {
+			store = storage.NewEncryptingStore(store, filepath.Join(filepath.Dir(dbPath), storage.IssueKeyFile))
+		}
+
 		// Warn if multiple databases detected in directory hierarchy
 		warnMultipleDatabases(dbPath)
 
@@ -1372,6 +1486,17 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
+		// Agent tokens are checked once the store can resolve them.
+		if err := enforceAgentToken(cmd, args); err != nil {
+			return HandleError("%v", err)
+		}
+
+		// Writes queued with --queue while the server was unreachable are
+		// applied by the first write command that reaches it again.
+		if !useReadOnly && atCheckpoint == "" && !isOfflineQueueCommand(cmd) {
+			maybeReplayOfflineQueue(rootCtx, store, beadsDir)
+		}
+
 		// Sync all state to CommandContext for unified access.
 		syncCommandContext()
 
@@ -1388,12 +1513,22 @@ var rootCmd = &cobra.Command{
 				uowProvider = nil
 			}
 		} else {
+			// Return to the live branch before any post-run maintenance.
+			if leaveCheckpointView != nil {
+				leaveCheckpointView()
+				leaveCheckpointView = nil
+			}
+
 			// Dolt auto-commit: after a successful write command (and after final flush),
 			// create a Dolt commit so changes don't remain only in the working set.
 			if commandDidWrite.Load() && !commandDidExplicitDoltCommit {
 				if err := maybeAutoCommit(rootCtx, doltAutoCommitParams{Command: cmd.Name()}); err != nil {
 					return HandleError("dolt auto-commit failed: %v", err)
 				}
+			} else if !commandDidExplicitDoltCommit && !readonlyMode && !isReadOnlyCommand(cmd.Name()) {
+				if err := maybeCoalesceBatchCommit(rootCtx); err != nil {
+					return HandleError("dolt batch auto-commit failed: %v", err)
+				}
 			}
 
 			// Tip metadata auto-commit: if a tip was shown, create a separate Dolt commit for the
@@ -1448,7 +1583,7 @@ var rootCmd = &cobra.Command{
 			storeMutex.Unlock()
 
 			if store != nil {
-				_ = store.Close() // Best effort cleanup
+				besteffort.Record("close store", store.Close())
 			}
 		}
 
@@ -1607,7 +1742,13 @@ func flushBatchCommitOnShutdown() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := st.Commit(ctx, "bd: flush pending changes on shutdown"); err != nil {
+	msg := "bd: flush pending changes on shutdown"
+	if reader, ok := storage.UnwrapStore(st).(storage.PendingChangesReader); ok {
+		if pending, err := reader.PendingChanges(ctx); err == nil {
+			msg = formatBatchCommitMessage(getActor(), pending) + " (flushed on shutdown)"
+		}
+	}
+	if err := st.Commit(ctx, msg); err != nil {
 		if !isDoltNothingToCommit(err) {
 			fmt.Fprintf(os.Stderr, "\nWarning: failed to flush batch commit on shutdown: %v\n", err)
 		}
@@ -1677,7 +1818,16 @@ func main() {
 	rootCmd.InitDefaultHelpCmd()
 	registerHelpAllFlag()
 
+	// Unknown subcommands fall through to bd-<name> plugins on PATH. The
+	// completion command is registered first so it is never shadowed.
+	rootCmd.InitDefaultCompletionCmd()
+	if path, args, ok := findPlugin(os.Args[1:]); ok {
+		os.Exit(runPlugin(path, args))
+	}
+
 	executedCmd, err := rootCmd.ExecuteC()
+	closeProgressReporter()
+	reportBestEffortFailures()
 
 	// Finalize queued metrics and detach the uploader. Shared with the os.Exit
 	// guards (CheckReadonly and the pre-run gates) so every exit path flushes the
@@ -1691,7 +1841,7 @@ func main() {
 		if executedCmd != nil && executedCmd.SilenceErrors {
 			fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
 		}
-		os.Exit(1)
+		os.Exit(exitCodeFor(classifyError(err)))
 	}
 }
 
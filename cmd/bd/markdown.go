@@ -24,6 +24,10 @@ var (
 	// h3Regex matches markdown H3 headers (### Section) for issue sections.
 	// Compiled once at package init for performance.
 	h3Regex = regexp.MustCompile(`^###\s+(.+)$`)
+
+	// escapedHashRegex matches content lines escaped with a leading
+	// backslash so they don't read as headers (see handleContentLine).
+	escapedHashRegex = regexp.MustCompile(`^\\+#`)
 )
 
 // IssueTemplate represents a parsed issue from markdown
@@ -32,6 +36,7 @@ type IssueTemplate struct {
 	Description        string
 	Design             string
 	AcceptanceCriteria string
+	Notes              string
 	Priority           int
 	IssueType          types.IssueType
 	Assignee           string
@@ -92,6 +97,8 @@ func processIssueSection(issue *IssueTemplate, section, content string) {
 		issue.Design = content
 	case "acceptance criteria", "acceptance":
 		issue.AcceptanceCriteria = content
+	case "notes":
+		issue.Notes = content
 	case "assignee":
 		issue.Assignee = strings.TrimSpace(content)
 	case "labels":
@@ -152,6 +159,9 @@ func validateMarkdownPath(path string) (string, error) {
 //	- Criterion 1
 //	- Criterion 2
 //
+//	### Notes
+//	Working notes...
+//
 //	### Assignee
 //	username
 //
@@ -161,6 +171,10 @@ func validateMarkdownPath(path string) (string, error) {
 //	### Dependencies
 //	bd-10, bd-20
 //
+// A content line of backslashes followed by "#" has one backslash dropped,
+// so text that would otherwise read as a header survives (bd export
+// --format markdown escapes it that way).
+//
 // markdownParseState holds state for parsing markdown files
 type markdownParseState struct {
 	issues         []*IssueTemplate
@@ -212,6 +226,9 @@ func (s *markdownParseState) handleContentLine(line string) {
 	if s.currentIssue == nil {
 		return
 	}
+	if escapedHashRegex.MatchString(line) {
+		line = line[1:]
+	}
 
 	// Content within a section
 	if s.currentSection != "" {
@@ -333,6 +350,7 @@ func createIssuesFromMarkdown(_ *cobra.Command, filepath string) error {
 			Description:        template.Description,
 			Design:             template.Design,
 			AcceptanceCriteria: template.AcceptanceCriteria,
+			Notes:              template.Notes,
 			Status:             types.StatusOpen,
 			Priority:           template.Priority,
 			IssueType:          template.IssueType,
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var metaCmd = &cobra.Command{
+	Use:     "meta",
+	GroupID: "issues",
+	Short:   "Read and edit issue metadata keys",
+	Long: `Read and edit individual keys of an issue's metadata without rewriting the
+whole blob.
+
+Writes are sent to the storage layer as operations and applied against the
+metadata read inside the write transaction, so agents editing different
+keys of the same issue at the same time do not erase each other's changes.
+Namespace and schema validation (validation.metadata, metadata.namespaces)
+apply to the result.
+
+Examples:
+  bd meta get bd-42                      # Whole metadata object
+  bd meta get bd-42 tool.github.number   # One key
+  bd meta set bd-42 owner_team platform
+  bd meta set bd-42 retries 3 --json-value
+  bd meta delete bd-42 owner_team
+  bd meta patch bd-42 --patch '[{"op":"add","path":"/retries","value":3}]'`,
+}
+
+var metaGetCmd = &cobra.Command{
+	Use:           "get <id> [key]",
+	Short:         "Print an issue's metadata, or one key of it",
+	Args:          cobra.RangeArgs(1, 2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("meta get is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("meta get")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			return HandleErrorRespectJSON("issue %s not found: %v", id, err)
+		}
+		metadata := issue.Metadata
+		if len(metadata) == 0 || string(metadata) == "null" {
+			metadata = json.RawMessage("{}")
+		}
+
+		if len(args) == 1 {
+			if jsonOutput {
+				return outputJSON(metaResult{ID: id, Metadata: metadata})
+			}
+			fmt.Println(prettyJSON(metadata))
+			return nil
+		}
+
+		key := args[1]
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(metadata, &m); err != nil {
+			return HandleErrorRespectJSON("metadata of %s is not a JSON object: %v", id, err)
+		}
+		value, ok := m[key]
+		if !ok {
+			return HandleErrorRespectJSON("%s has no metadata key %q", id, key)
+		}
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"id": id, "key": key, "value": value})
+		}
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			fmt.Println(s)
+		} else {
+			fmt.Println(prettyJSON(value))
+		}
+		return nil
+	},
+}
+
+var metaSetCmd = &cobra.Command{
+	Use:   "set <id> <key> <value>",
+	Short: "Set one metadata key",
+	Long: `Set one metadata key. The value is stored as a JSON string, as with
+bd update --set-metadata; pass --json-value to store it as typed JSON
+(numbers, booleans, objects, arrays).`,
+	Args:          cobra.ExactArgs(3),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, value := args[1], args[2]
+		if err := storage.ValidateMetadataKey(key); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		raw := storage.MetadataEditValue(value)
+		if typed, _ := cmd.Flags().GetBool("json-value"); typed {
+			if !json.Valid([]byte(value)) {
+				return HandleErrorRespectJSON("--json-value: %q is not valid JSON", value)
+			}
+			raw = json.RawMessage(value)
+		}
+		obj, err := json.Marshal(map[string]json.RawMessage{key: raw})
+		if err != nil {
+			return HandleErrorRespectJSON("encoding metadata: %v", err)
+		}
+		return runMetaUpdate("meta set", args[0], map[string]interface{}{issueops.OpMergeMetadata: json.RawMessage(obj)},
+			"Set metadata "+key+" on")
+	},
+}
+
+var metaDeleteCmd = &cobra.Command{
+	Use:           "delete <id> <key>...",
+	Short:         "Remove metadata keys",
+	Long:          `Remove metadata keys. Keys that are not set are ignored.`,
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keys := args[1:]
+		for _, key := range keys {
+			if err := storage.ValidateMetadataKey(key); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+		}
+		return runMetaUpdate("meta delete", args[0], map[string]interface{}{issueops.OpUnsetMetadata: keys},
+			"Removed metadata "+strings.Join(keys, ", ")+" from")
+	},
+}
+
+var metaPatchCmd = &cobra.Command{
+	Use:   "patch <id>",
+	Short: "Apply a JSON Patch (RFC 6902) to an issue's metadata",
+	Long: `Apply a JSON Patch (RFC 6902) to an issue's metadata. Supports add, remove,
+replace, move, copy, and test. Pointers address top-level keys as
+"/<key>" and nested values as "/<key>/<field>" or "/<key>/<index>"
+("~1" escapes "/" and "~0" escapes "~").
+
+The patch is all or nothing: if any operation fails, including a test,
+the metadata is left unchanged. Use test to make a write conditional on
+the current value:
+
+  bd meta patch bd-42 --patch '[
+    {"op":"test","path":"/retries","value":2},
+    {"op":"replace","path":"/retries","value":3}
+  ]'
+
+Read the patch from a file, or from stdin with --patch-file -.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		patchArg, _ := cmd.Flags().GetString("patch")
+		patchFile, _ := cmd.Flags().GetString("patch-file")
+		patch, err := readMetaPatch(patchArg, patchFile)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		ops, err := storage.ParseMetadataPatch(patch)
+		if err != nil {
+			return HandleErrorRespectJSON("invalid patch: %v", err)
+		}
+		return runMetaUpdate("meta patch", args[0], map[string]interface{}{issueops.OpPatchMetadata: patch},
+			fmt.Sprintf("Applied %d patch operation(s) to", len(ops)))
+	},
+}
+
+// metaResult is the JSON shape for bd meta output.
+type metaResult struct {
+	ID       string          `json:"id"`
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+// readMetaPatch returns the patch given inline or read from a file ("-" for
+// stdin); exactly one source must be set.
+func readMetaPatch(inline, file string) (json.RawMessage, error) {
+	switch {
+	case inline != "" && file != "":
+		return nil, fmt.Errorf("use either --patch or --patch-file, not both")
+	case inline != "":
+		return json.RawMessage(inline), nil
+	case file == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading patch from stdin: %w", err)
+		}
+		return data, nil
+	case file != "":
+		data, err := os.ReadFile(file) // #nosec G304 -- user-specified patch file
+		if err != nil {
+			return nil, fmt.Errorf("reading patch file: %w", err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("--patch or --patch-file is required")
+}
+
+// runMetaUpdate sends one metadata operation for the issue ref and reports
+// the resulting metadata.
+func runMetaUpdate(command, ref string, updates map[string]interface{}, verb string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("%s is not supported in proxied-server mode", command)
+	}
+	CheckReadonly(command)
+	evt := metrics.NewCommandEvent(command)
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("database not available: %v", err)
+	}
+	ctx := rootCtx
+
+	res, title, err := applyMetaUpdate(ctx, store, ref, updates)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	commandDidWrite.Store(true)
+	SetLastTouchedID(res.ID)
+
+	if jsonOutput {
+		return outputJSON(res)
+	}
+	fmt.Printf("%s %s %s\n", ui.RenderPass("✓"), verb, formatFeedbackID(res.ID, title))
+	return nil
+}
+
+func applyMetaUpdate(ctx context.Context, st storage.DoltStorage, ref string, updates map[string]interface{}) (*metaResult, string, error) {
+	id, err := utils.ResolvePartialID(ctx, st, ref)
+	if err != nil {
+		return nil, "", fmt.Errorf("resolving %s: %w", ref, err)
+	}
+	if err := st.UpdateIssue(ctx, id, updates, actor); err != nil {
+		return nil, "", fmt.Errorf("updating %s: %w", id, err)
+	}
+	issue, err := st.GetIssue(ctx, id)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading %s: %w", id, err)
+	}
+	metadata := issue.Metadata
+	if len(metadata) == 0 {
+		metadata = json.RawMessage("{}")
+	}
+	return &metaResult{ID: id, Metadata: metadata}, issue.Title, nil
+}
+
+// prettyJSON indents raw JSON for display, falling back to the input.
+func prettyJSON(raw json.RawMessage) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, raw, "", "  "); err != nil {
+		return string(raw)
+	}
+	return out.String()
+}
+
+func init() {
+	metaSetCmd.Flags().Bool("json-value", false, "Store the value as typed JSON instead of a string")
+	metaPatchCmd.Flags().String("patch", "", "JSON Patch document (array of operations)")
+	metaPatchCmd.Flags().String("patch-file", "", "Read the JSON Patch from a file (- for stdin)")
+	metaCmd.AddCommand(metaGetCmd, metaSetCmd, metaDeleteCmd, metaPatchCmd)
+	rootCmd.AddCommand(metaCmd)
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var migratePrefixesCmd = &cobra.Command{
+	Use:   "prefixes",
+	Short: "Re-prefix existing issues to match the per-type and per-label prefix rules",
+	Long: `Rename existing issues so their IDs use the prefixes that the
+prefix.by_type and prefix.by_label rules give them. New issues pick up the
+rules when they are created; this brings the old ones in line.
+
+Rules are comma-separated name:prefix pairs. Label rules win over type
+rules, and issues no rule matches use the issue_prefix:
+
+  bd config set prefix.by_type "bug:bug,feature:feat"
+  bd config set prefix.by_label "spike:spk"
+
+Each renamed issue keeps its suffix (bd-a3f8 becomes bug-a3f8) unless that
+ID is taken, in which case it gets a fresh hash. Child issues follow their
+parent (bd-a3f8.1 becomes bug-a3f8.1). Dependencies, labels, comments, and
+events move with the issue, and references to renamed IDs in titles,
+descriptions, design, notes, and acceptance criteria are rewritten.
+
+Only issues under issue_prefix or one of the rule prefixes are touched;
+issues from other rigs or peers, molecules, and wisps keep their IDs.
+Issues are renamed one at a time; if a run stops partway, run it again to
+finish.
+
+Examples:
+  bd migrate prefixes --dry-run   # Show the renames
+  bd migrate prefixes             # Apply them`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runMigratePrefixes,
+}
+
+func init() {
+	migratePrefixesCmd.Flags().Bool("dry-run", false, "Show the renames without applying them")
+	migrateCmd.AddCommand(migratePrefixesCmd)
+}
+
+// loadIDPrefixRules reads the prefix.by_type and prefix.by_label rules.
+func loadIDPrefixRules(ctx context.Context, st storage.DoltStorage) (byType, byLabel []types.IDPrefixRule, err error) {
+	for _, key := range []string{types.ConfigKeyPrefixByType, types.ConfigKeyPrefixByLabel} {
+		value, err := st.GetConfig(ctx, key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		rules, err := types.ParseIDPrefixRules(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		if key == types.ConfigKeyPrefixByType {
+			byType = rules
+		} else {
+			byLabel = rules
+		}
+	}
+	return byType, byLabel, nil
+}
+
+// prefixRename is one planned ID change.
+type prefixRename struct {
+	OldID string `json:"old_id"`
+	NewID string `json:"new_id"`
+}
+
+// planPrefixMigration works out the renames that bring issues in line with
+// the prefix rules. Top-level issues under a local prefix (base or a rule
+// prefix) move to the prefix the rules give them; their children follow.
+func planPrefixMigration(issues []*types.Issue, base string, byType, byLabel []types.IDPrefixRule, actor string) []prefixRename {
+	local := append([]string{base}, types.RulePrefixes(byType, byLabel)...)
+	used := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		used[issue.ID] = true
+	}
+
+	renamedRoots := map[string]string{}
+	var renames []prefixRename
+	for _, issue := range issues {
+		if issue.Ephemeral || strings.Contains(issue.ID, ".") {
+			continue
+		}
+		current := utils.ExtractIssuePrefixKnown(issue.ID, local)
+		if !slices.Contains(local, current) {
+			continue
+		}
+		suffix := strings.TrimPrefix(issue.ID, current+"-")
+		if strings.HasPrefix(suffix, types.IDPrefixMol+"-") || strings.HasPrefix(suffix, types.IDPrefixWisp+"-") {
+			continue
+		}
+		target := types.ResolveIDPrefix(issue, byType, byLabel)
+		if target == "" {
+			target = base
+		}
+		if target == current {
+			continue
+		}
+		newID := target + "-" + suffix
+		if used[newID] {
+			var err error
+			if newID, err = generateRepairHashID(target, issue, actor, used); err != nil {
+				continue
+			}
+		}
+		used[newID] = true
+		renamedRoots[issue.ID] = newID
+		renames = append(renames, prefixRename{OldID: issue.ID, NewID: newID})
+	}
+
+	for _, issue := range issues {
+		root, _, depth := types.ParseHierarchicalID(issue.ID)
+		if newRoot, ok := renamedRoots[root]; ok && depth > 0 {
+			renames = append(renames, prefixRename{OldID: issue.ID, NewID: newRoot + strings.TrimPrefix(issue.ID, root)})
+		}
+	}
+	slices.SortFunc(renames, func(a, b prefixRename) int { return utils.NaturalCompareIDs(a.OldID, b.OldID) })
+	return renames
+}
+
+// issueIDReferencePattern matches anything shaped like an issue ID in text,
+// including hierarchical children.
+var issueIDReferencePattern = regexp.MustCompile(`\b[a-z][a-z0-9-]*-[a-zA-Z0-9]+(?:\.[0-9]+)*\b`)
+
+// rewriteIDReferences replaces renamed IDs in the issue's text fields and
+// returns the changed fields as an update map.
+func rewriteIDReferences(issue *types.Issue, renameMap map[string]string) map[string]interface{} {
+	replace := func(s string) string {
+		return issueIDReferencePattern.ReplaceAllStringFunc(s, func(match string) string {
+			if newID, ok := renameMap[match]; ok {
+				return newID
+			}
+			return match
+		})
+	}
+	updates := map[string]interface{}{}
+	for _, f := range []struct {
+		key string
+		val *string
+	}{
+		{"title", &issue.Title},
+		{"description", &issue.Description},
+		{"design", &issue.Design},
+		{"acceptance_criteria", &issue.AcceptanceCriteria},
+		{"notes", &issue.Notes},
+	} {
+		if replaced := replace(*f.val); replaced != *f.val {
+			*f.val = replaced
+			updates[f.key] = replaced
+		}
+	}
+	return updates
+}
+
+func runMigratePrefixes(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("migrate prefixes is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("migrate-prefixes")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !dryRun {
+		CheckReadonly("migrate prefixes")
+	}
+	ctx := rootCtx
+
+	base, err := store.GetConfig(ctx, "issue_prefix")
+	if err != nil || base == "" {
+		return HandleErrorRespectJSON("failed to get current prefix: %v", err)
+	}
+	base = strings.TrimSuffix(base, "-")
+	byType, byLabel, err := loadIDPrefixRules(ctx, store)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return HandleErrorRespectJSON("failed to list issues: %v", err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to load labels: %v", err)
+	}
+	for _, issue := range issues {
+		issue.Labels = labels[issue.ID]
+	}
+
+	actorName := getActorWithGit()
+	renames := planPrefixMigration(issues, base, byType, byLabel, actorName)
+	renameMap := make(map[string]string, len(renames))
+	for _, r := range renames {
+		renameMap[r.OldID] = r.NewID
+	}
+
+	if dryRun || len(renames) == 0 {
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"dry_run": dryRun,
+				"renames": append([]prefixRename{}, renames...),
+			})
+		}
+		if len(renames) == 0 {
+			fmt.Printf("%s All issues already match the prefix rules\n", ui.RenderPass("✓"))
+			return nil
+		}
+		fmt.Printf("DRY RUN: Would rename %d issue(s)\n\n", len(renames))
+		for _, r := range renames {
+			fmt.Printf("  %s -> %s\n", ui.RenderWarn(r.OldID), ui.RenderAccent(r.NewID))
+		}
+		return nil
+	}
+
+	// Rewrite references in issues that keep their IDs first, then rename;
+	// renamed issues carry their rewritten text into UpdateIssueID.
+	referencesUpdated := 0
+	for _, issue := range issues {
+		if _, renamed := renameMap[issue.ID]; renamed {
+			continue
+		}
+		if updates := rewriteIDReferences(issue, renameMap); len(updates) > 0 {
+			if err := store.UpdateIssue(ctx, issue.ID, updates, actorName); err != nil {
+				return HandleErrorRespectJSON("failed to update references in %s: %v", issue.ID, err)
+			}
+			commandDidWrite.Store(true)
+			referencesUpdated++
+		}
+	}
+
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	for _, r := range renames {
+		issue := byID[r.OldID]
+		if len(rewriteIDReferences(issue, renameMap)) > 0 {
+			referencesUpdated++
+		}
+		issue.ID = r.NewID
+		if err := store.UpdateIssueID(ctx, r.OldID, r.NewID, issue, actorName); err != nil {
+			return HandleErrorRespectJSON("failed to rename %s -> %s: %v", r.OldID, r.NewID, err)
+		}
+		commandDidWrite.Store(true)
+		if !jsonOutput {
+			fmt.Printf("  Renamed %s -> %s\n", ui.RenderWarn(r.OldID), ui.RenderAccent(r.NewID))
+		}
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"dry_run":            false,
+			"renames":            renames,
+			"references_updated": referencesUpdated,
+		})
+	}
+	fmt.Printf("\n%s Renamed %d issue(s); updated references in %d issue(s)\n",
+		ui.RenderPass("✓"), len(renames), referencesUpdated)
+	return nil
+}
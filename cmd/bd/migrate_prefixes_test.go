@@ -0,0 +1,69 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+func TestPlanPrefixMigration(t *testing.T) {
+	byType := []types.IDPrefixRule{{Match: "bug", Prefix: "bug"}}
+	byLabel := []types.IDPrefixRule{{Match: "spike", Prefix: "spk"}}
+	issues := []*types.Issue{
+		{ID: "bd-a1", IssueType: types.TypeBug},
+		{ID: "bd-a1.1", IssueType: types.TypeTask}, // follows its parent
+		{ID: "bd-b2", IssueType: types.TypeTask, Labels: []string{"spike"}},
+		{ID: "bd-c3", IssueType: types.TypeBug},
+		{ID: "bug-c3", IssueType: types.TypeBug},    // takes bd-c3's natural ID
+		{ID: "bug-d4", IssueType: types.TypeTask},   // rule no longer applies
+		{ID: "bd-e5", IssueType: types.TypeTask},    // already right
+		{ID: "hq-f6", IssueType: types.TypeBug},     // foreign prefix
+		{ID: "bd-mol-g7", IssueType: types.TypeBug}, // molecule
+	}
+
+	renames := planPrefixMigration(issues, "bd", byType, byLabel, "tester")
+	got := map[string]string{}
+	for _, r := range renames {
+		got[r.OldID] = r.NewID
+	}
+	want := map[string]string{
+		"bd-a1":   "bug-a1",
+		"bd-a1.1": "bug-a1.1",
+		"bd-b2":   "spk-b2",
+		"bug-d4":  "bd-d4",
+	}
+	for old, newID := range want {
+		if got[old] != newID {
+			t.Errorf("rename of %s = %q, want %q", old, got[old], newID)
+		}
+	}
+	if newID := got["bd-c3"]; newID == "" || newID == "bug-c3" || newID[:4] != "bug-" {
+		t.Errorf("rename of bd-c3 = %q, want a fresh bug- ID", newID)
+	}
+	if len(got) != len(want)+1 {
+		t.Errorf("renames = %+v, want %d", renames, len(want)+1)
+	}
+	if !slices.IsSortedFunc(renames, func(a, b prefixRename) int { return utils.NaturalCompareIDs(a.OldID, b.OldID) }) {
+		t.Errorf("renames not sorted: %+v", renames)
+	}
+}
+
+func TestRewriteIDReferences(t *testing.T) {
+	issue := &types.Issue{
+		Title:       "Follow up on bd-a1",
+		Description: "Split from bd-a1.1; see also bd-a10 and bd-a1",
+		Notes:       "nothing here",
+	}
+	updates := rewriteIDReferences(issue, map[string]string{"bd-a1": "bug-a1", "bd-a1.1": "bug-a1.1"})
+	if issue.Title != "Follow up on bug-a1" {
+		t.Errorf("title = %q", issue.Title)
+	}
+	if issue.Description != "Split from bug-a1.1; see also bd-a10 and bug-a1" {
+		t.Errorf("description = %q", issue.Description)
+	}
+	if _, ok := updates["notes"]; ok || len(updates) != 2 {
+		t.Errorf("updates = %v, want title and description", updates)
+	}
+}
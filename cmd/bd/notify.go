@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/notify"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var notifyCmd = &cobra.Command{
+	Use:     "notify",
+	GroupID: "views",
+	Short:   "Show desktop notifications for work that needs you",
+	Long: `Watch the database and show desktop notifications when:
+
+  ready      an issue assigned to you becomes ready to work on
+  blockers   a blocker of one of your open issues is closed
+  p0         a new P0 issue appears
+
+"You" is the current actor (--actor, BEADS_ACTOR, or git user.name). While
+you cover for someone who is out of office (see bd ooo), their issues
+count as yours.
+Notifications use osascript on macOS, notify-send on Linux, and a
+PowerShell balloon tip on Windows and WSL.
+
+Desktop notifications are opt-in. Enable them and tune them in config.yaml:
+
+  bd config set notify.desktop true
+  bd config set notify.events "ready,p0"          (default: all three)
+  bd config set notify.quiet_hours "22:00-07:00"  (local time)
+  bd config set notify.interval 1m                 (default: 30s)
+
+Changes during quiet hours are printed here but not shown on the desktop.
+Like bd list --watch, bd notify polls until interrupted; it notifies about
+changes after it starts, not about what is already true.
+
+Examples:
+  bd notify
+  bd notify --test`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("notify")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		sender := notify.Desktop()
+		if test, _ := cmd.Flags().GetBool("test"); test {
+			if err := sender.Send("Test notification", "Desktop notifications are working."); err != nil {
+				return HandleError("%v", err)
+			}
+			fmt.Printf("%s Sent a test notification\n", ui.RenderPass("✓"))
+			return nil
+		}
+
+		if !config.GetBool("notify.desktop") {
+			return HandleError("desktop notifications are off; enable them with 'bd config set notify.desktop true'")
+		}
+		opts, err := loadNotifyOptions(cmd)
+		if err != nil {
+			return HandleError("%v", err)
+		}
+		if usesProxiedServer() {
+			return HandleError("notify is not supported in proxied-server mode")
+		}
+		if err := ensureStoreActive(); err != nil {
+			return HandleError("database not available: %v", err)
+		}
+		return runNotifyLoop(rootCtx, store, opts, sender)
+	},
+}
+
+// notifyOptions is the resolved notify.* configuration.
+type notifyOptions struct {
+	me       string
+	kinds    map[notify.Kind]bool
+	quiet    *notify.QuietHours
+	interval time.Duration
+}
+
+func loadNotifyOptions(cmd *cobra.Command) (*notifyOptions, error) {
+	kinds, err := notify.ParseKinds(config.GetString("notify.events"))
+	if err != nil {
+		return nil, fmt.Errorf("config notify.events: %w", err)
+	}
+	quiet, err := notify.ParseQuietHours(config.GetString("notify.quiet_hours"))
+	if err != nil {
+		return nil, fmt.Errorf("config notify.quiet_hours: %w", err)
+	}
+	interval := config.GetDuration("notify.interval")
+	if cmd.Flags().Changed("interval") {
+		interval, _ = cmd.Flags().GetDuration("interval")
+	}
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	if interval < 2*time.Second {
+		interval = 2 * time.Second
+	}
+	return &notifyOptions{me: getActorWithGit(), kinds: kinds, quiet: quiet, interval: interval}, nil
+}
+
+// runNotifyLoop polls until interrupted, sending a notification for each
+// enabled event outside quiet hours.
+func runNotifyLoop(ctx context.Context, st storage.DoltStorage, opts *notifyOptions, sender notify.Sender) error {
+	prev, err := notifySnapshot(ctx, st, notifyAssignees(ctx, st, opts.me))
+	if err != nil {
+		return HandleError("reading issues: %v", err)
+	}
+	quiet := ""
+	if opts.quiet != nil {
+		quiet = fmt.Sprintf(", quiet %s", opts.quiet)
+	}
+	fmt.Fprintf(os.Stderr, "Notifying %s every %s%s... (Press Ctrl+C to exit)\n", opts.me, opts.interval, quiet)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sigChan:
+			fmt.Fprintf(os.Stderr, "\nStopped notifying.\n")
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			cur, err := notifySnapshot(ctx, st, notifyAssignees(ctx, st, opts.me))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error refreshing issues: %v\n", err)
+				continue
+			}
+			events := notify.Diff(prev, cur)
+			prev = cur
+			now := time.Now()
+			for _, e := range events {
+				if !opts.kinds[e.Kind] {
+					continue
+				}
+				stamp := now.Format("15:04")
+				if opts.quiet.Contains(now) {
+					fmt.Printf("%s %s: %s (quiet hours)\n", stamp, e.Title, e.Body)
+					continue
+				}
+				fmt.Printf("%s %s: %s\n", stamp, e.Title, e.Body)
+				if err := sender.Send(e.Title, e.Body); err != nil {
+					fmt.Fprintf(os.Stderr, "%s notify: %v\n", ui.RenderWarn("⚠"), err)
+				}
+			}
+		}
+	}
+}
+
+// notifyAssignees returns me and whoever I am covering for while they are
+// out of office. It is re-read on every poll, so coverage starts and ends
+// with the absence.
+func notifyAssignees(ctx context.Context, st storage.DoltStorage, me string) []string {
+	records, err := loadOOO(ctx, st)
+	if err != nil {
+		return []string{me}
+	}
+	return append([]string{me}, oooCovering(records, me, time.Now())...)
+}
+
+// notifySnapshot reads what notifications are computed from: the ready work
+// of the given assignees, the blockers of their open issues, and open P0s.
+func notifySnapshot(ctx context.Context, st storage.DoltStorage, assignees []string) (*notify.Snapshot, error) {
+	snap := notify.NewSnapshot()
+
+	var mine []*types.Issue
+	for _, who := range assignees {
+		ready, err := st.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, Assignee: &who})
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range ready {
+			snap.Ready[issue.ID] = issue.Title
+		}
+		open, err := st.SearchIssues(ctx, "", types.IssueFilter{Assignee: &who, ExcludeStatus: []types.Status{types.StatusClosed}})
+		if err != nil {
+			return nil, err
+		}
+		mine = append(mine, open...)
+	}
+	for _, issue := range mine {
+		deps, err := st.GetDependenciesWithMetadata(ctx, issue.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range deps {
+			if !dep.DependencyType.IsBlockingEdge() {
+				continue
+			}
+			b := snap.Blockers[dep.ID]
+			if b == nil {
+				b = &notify.Blocker{Title: dep.Title, Closed: dep.Status == types.StatusClosed}
+				snap.Blockers[dep.ID] = b
+			}
+			b.Blocks = append(b.Blocks, issue.ID)
+		}
+	}
+
+	p0 := 0
+	urgent, err := st.SearchIssues(ctx, "", types.IssueFilter{Priority: &p0, ExcludeStatus: []types.Status{types.StatusClosed}})
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range urgent {
+		snap.P0[issue.ID] = issue.Title
+	}
+	return snap, nil
+}
+
+func init() {
+	notifyCmd.Flags().Bool("test", false, "Send a test notification and exit")
+	notifyCmd.Flags().Duration("interval", 0, "Polling interval (default: notify.interval, else 30s)")
+	rootCmd.AddCommand(notifyCmd)
+}
@@ -0,0 +1,578 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/tokens"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// offlineQueueFile holds writes made with --queue while the database server
+// was unreachable, one JSON object per line. It is clone-local (gitignored):
+// the writes reach everyone else once they are replayed into the database.
+const offlineQueueFile = "offline-queue.jsonl"
+
+// offlineQueueAppliedPrefix keys the metadata rows that record which queued
+// writes have been applied. Each row is written in the same transaction as
+// the write itself, so replaying an entry twice (say, after a crash before
+// the queue file was rewritten) never applies it twice. The rows are pruned
+// once the queue drains.
+const offlineQueueAppliedPrefix = "offline_queue.applied."
+
+// offlineQueueErr is the store-open error a --queue write is being queued
+// for; nil when the database opened normally.
+var offlineQueueErr error
+
+// offlineQueueBeadsDir is the .beads directory the queue file lives in.
+var offlineQueueBeadsDir string
+
+// offlineQueueToken is the agent token the queued writes are made under,
+// checked against the clone's token cache; nil without BD_TOKEN.
+var offlineQueueToken *tokens.Token
+
+// queuedWrite is one write waiting in the offline queue.
+type queuedWrite struct {
+	ID       string    `json:"id"`
+	Op       string    `json:"op"` // "create" or "update"
+	QueuedAt time.Time `json:"queued_at"`
+	Actor    string    `json:"actor"`
+
+	// TokenID and TokenScope record the agent token (BD_TOKEN) the write
+	// was queued under; replay checks the write against it again.
+	TokenID    string        `json:"token_id,omitempty"`
+	TokenScope *tokens.Scope `json:"token_scope,omitempty"`
+
+	// create
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	IssueType   string   `json:"issue_type,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+	Assignee    *string  `json:"assignee,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+
+	// update: the issue and the fields to set (title, description, status,
+	// priority, assignee, issue_type).
+	IssueID string                 `json:"issue_id,omitempty"`
+	Updates map[string]interface{} `json:"updates,omitempty"`
+
+	// BaseUpdatedAt is the issue's updated_at after an earlier queued update
+	// to it was replayed. Changes up to then are this queue's own, so the
+	// conflict check starts from here rather than from QueuedAt.
+	BaseUpdatedAt *time.Time `json:"base_updated_at,omitempty"`
+
+	// Conflict is why the last replay left this entry in the queue.
+	Conflict string `json:"conflict,omitempty"`
+}
+
+func (w queuedWrite) describe() string {
+	if w.Op == "create" {
+		return fmt.Sprintf("create %q", w.Title)
+	}
+	fields := make([]string, 0, len(w.Updates))
+	for k := range w.Updates {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	return fmt.Sprintf("update %s (%s)", w.IssueID, strings.Join(fields, ", "))
+}
+
+// queueRequested reports whether cmd is a write run with --queue.
+func queueRequested(cmd *cobra.Command) bool {
+	if cmd.Flags().Lookup("queue") == nil {
+		return false
+	}
+	queue, _ := cmd.Flags().GetBool("queue")
+	return queue
+}
+
+func offlineQueuePath(beadsDir string) string {
+	return filepath.Join(beadsDir, offlineQueueFile)
+}
+
+func newQueuedWriteID() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return "q-" + hex.EncodeToString(b)
+}
+
+// readOfflineQueue returns the queued writes, oldest first. A missing file is
+// an empty queue.
+func readOfflineQueue(beadsDir string) ([]queuedWrite, error) {
+	f, err := os.Open(offlineQueuePath(beadsDir)) // #nosec G304 -- path constructed from beadsDir
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var writes []queuedWrite
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var w queuedWrite
+		if err := json.Unmarshal([]byte(text), &w); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", offlineQueueFile, line, err)
+		}
+		writes = append(writes, w)
+	}
+	return writes, scanner.Err()
+}
+
+// writeOfflineQueue replaces the queue with writes, removing the file when
+// none are left. The file is swapped in by rename so a crash leaves either
+// the old queue or the new one.
+func writeOfflineQueue(beadsDir string, writes []queuedWrite) error {
+	path := offlineQueuePath(beadsDir)
+	if len(writes) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	var b strings.Builder
+	for _, w := range writes {
+		data, err := json.Marshal(w)
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func appendOfflineQueue(beadsDir string, w queuedWrite) error {
+	data, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(offlineQueuePath(beadsDir), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304 -- path constructed from beadsDir
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// checkQueueableFlags rejects flags a queued write cannot carry, so nothing
+// the user asked for is silently dropped.
+func checkQueueableFlags(cmd *cobra.Command, allowed ...string) error {
+	ok := map[string]bool{"queue": true}
+	for _, name := range allowed {
+		ok[name] = true
+	}
+	var unsupported []string
+	inherited := cmd.InheritedFlags()
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if !ok[f.Name] && inherited.Lookup(f.Name) == nil {
+			unsupported = append(unsupported, "--"+f.Name)
+		}
+	})
+	if len(unsupported) > 0 {
+		return fmt.Errorf("cannot queue %s with %s while the database is unreachable (queued writes support %s)",
+			cmd.Name(), strings.Join(unsupported, ", "), "--"+strings.Join(allowed, ", --"))
+	}
+	return nil
+}
+
+var queueableDescriptionFlags = []string{"description", "body", "message", "body-file", "description-file", "stdin"}
+
+// enqueueCreate records bd create --queue in the offline queue.
+func enqueueCreate(cmd *cobra.Command, args []string) error {
+	allowed := append([]string{"title", "type", "priority", "assignee", "labels", "label"}, queueableDescriptionFlags...)
+	if err := checkQueueableFlags(cmd, allowed...); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	title, _ := cmd.Flags().GetString("title")
+	if len(args) > 0 {
+		title = strings.Join(args, " ")
+	}
+	if strings.TrimSpace(title) == "" {
+		return HandleErrorRespectJSON("title required")
+	}
+	description, _, err := getDescriptionFlag(cmd)
+	if err != nil {
+		return err
+	}
+	priorityStr, _ := cmd.Flags().GetString("priority")
+	priority, err := validation.ValidatePriority(priorityStr)
+	if err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	issueType, _ := cmd.Flags().GetString("type")
+	labels, _ := cmd.Flags().GetStringSlice("labels")
+	labelAlias, _ := cmd.Flags().GetStringSlice("label")
+
+	w := queuedWrite{
+		Op:          "create",
+		Title:       title,
+		Description: description,
+		IssueType:   utils.NormalizeIssueType(issueType),
+		Priority:    &priority,
+		Labels:      append(labels, labelAlias...),
+	}
+	if cmd.Flags().Changed("assignee") {
+		assignee, _ := cmd.Flags().GetString("assignee")
+		w.Assignee = &assignee
+	}
+	return enqueueWrites(w)
+}
+
+// enqueueUpdate records bd update --queue in the offline queue, one entry
+// per issue.
+func enqueueUpdate(cmd *cobra.Command, args []string) error {
+	allowed := append([]string{"status", "priority", "title", "type", "assignee"}, queueableDescriptionFlags...)
+	if err := checkQueueableFlags(cmd, allowed...); err != nil {
+		return HandleErrorRespectJSON("%v", err)
+	}
+	if len(args) == 0 {
+		return HandleErrorRespectJSON("queued updates need explicit issue IDs")
+	}
+	updates := make(map[string]interface{})
+	if cmd.Flags().Changed("status") {
+		status, _ := cmd.Flags().GetString("status")
+		updates["status"] = status
+	}
+	if cmd.Flags().Changed("priority") {
+		priorityStr, _ := cmd.Flags().GetString("priority")
+		priority, err := validation.ValidatePriority(priorityStr)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		updates["priority"] = priority
+	}
+	if cmd.Flags().Changed("title") {
+		title, _ := cmd.Flags().GetString("title")
+		updates["title"] = title
+	}
+	if cmd.Flags().Changed("type") {
+		issueType, _ := cmd.Flags().GetString("type")
+		updates["issue_type"] = utils.NormalizeIssueType(issueType)
+	}
+	if cmd.Flags().Changed("assignee") {
+		assignee, _ := cmd.Flags().GetString("assignee")
+		updates["assignee"] = assignee
+	}
+	description, set, err := getDescriptionFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if set {
+		updates["description"] = description
+	}
+	if len(updates) == 0 {
+		return HandleErrorRespectJSON("no updates specified")
+	}
+
+	writes := make([]queuedWrite, 0, len(args))
+	for _, id := range args {
+		writes = append(writes, queuedWrite{Op: "update", IssueID: id, Updates: updates})
+	}
+	return enqueueWrites(writes...)
+}
+
+func enqueueWrites(writes ...queuedWrite) error {
+	now := time.Now().UTC()
+	for i := range writes {
+		writes[i].ID = newQueuedWriteID()
+		writes[i].QueuedAt = now
+		writes[i].Actor = getActor()
+		if t := offlineQueueToken; t != nil {
+			writes[i].TokenID = t.ID
+			writes[i].TokenScope = &t.Scope
+		}
+		if err := appendOfflineQueue(offlineQueueBeadsDir, writes[i]); err != nil {
+			return HandleErrorRespectJSON("database unreachable and queueing failed: %v", err)
+		}
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"queued": writes,
+			"reason": offlineQueueErr.Error(),
+		})
+	}
+	fmt.Fprintf(os.Stderr, "Database unreachable: %v\n", offlineQueueErr)
+	for _, w := range writes {
+		fmt.Printf("Queued %s as %s\n", w.describe(), w.ID)
+	}
+	fmt.Println("It will be applied by the next bd write once the database is reachable, or by 'bd queue replay'.")
+	return nil
+}
+
+// queueReplayResult is what one replay of the offline queue did.
+type queueReplayResult struct {
+	Applied   []queueReplayEntry `json:"applied"`
+	Conflicts []queueReplayEntry `json:"conflicts"`
+	Remaining int                `json:"remaining"`
+}
+
+type queueReplayEntry struct {
+	ID      string `json:"id"`
+	Write   string `json:"write"`
+	IssueID string `json:"issue_id,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// replayOfflineQueue applies the queued writes in order, each in its own
+// transaction. An update whose issue is gone, or was changed after the write
+// was queued by anything but an earlier queued write, is a conflict: it stays
+// in the queue (with the reason) for the user to force or drop, unless force
+// is set. Writes that fail outright also stay queued; the error of the first
+// is returned after the rest are tried. Once nothing is left queued, the
+// applied markers are pruned.
+func replayOfflineQueue(ctx context.Context, st storage.DoltStorage, beadsDir string, force bool) (*queueReplayResult, error) {
+	writes, err := readOfflineQueue(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	result := &queueReplayResult{Applied: []queueReplayEntry{}, Conflicts: []queueReplayEntry{}}
+	var remaining []queuedWrite
+	var firstErr error
+	// replayed maps each issue updated by this replay to the updated_at the
+	// update left on it.
+	replayed := make(map[string]time.Time)
+	for _, w := range writes {
+		entry := queueReplayEntry{ID: w.ID, Write: w.describe(), IssueID: w.IssueID}
+		issueID, conflict, err := applyQueuedWrite(ctx, st, &w, force, replayed)
+		switch {
+		case err != nil:
+			if firstErr == nil {
+				firstErr = fmt.Errorf("replaying %s (%s): %w", w.ID, w.describe(), err)
+			}
+			remaining = append(remaining, w)
+		case conflict != "":
+			w.Conflict = conflict
+			entry.Reason = conflict
+			result.Conflicts = append(result.Conflicts, entry)
+			remaining = append(remaining, w)
+		default:
+			entry.IssueID = issueID
+			result.Applied = append(result.Applied, entry)
+		}
+	}
+	result.Remaining = len(remaining)
+	if len(result.Applied) > 0 || len(result.Conflicts) > 0 {
+		if err := writeOfflineQueue(beadsDir, remaining); err != nil {
+			return result, fmt.Errorf("rewriting %s: %w", offlineQueueFile, err)
+		}
+	}
+	if len(remaining) == 0 && len(writes) > 0 {
+		if err := pruneOfflineQueueApplied(ctx, st); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return result, firstErr
+}
+
+// pruneOfflineQueueApplied removes the applied markers of replayed writes.
+// Call it only once the queue file is empty: a marker guards its entry
+// against a second apply only while the entry can still be replayed.
+func pruneOfflineQueueApplied(ctx context.Context, st storage.DoltStorage) error {
+	ms, ok := storage.UnwrapStore(st).(storage.ConfigMetadataStore)
+	if !ok {
+		return nil
+	}
+	n, err := ms.DeleteMetadataPrefix(ctx, offlineQueueAppliedPrefix)
+	if err != nil {
+		return fmt.Errorf("pruning applied offline queue markers: %w", err)
+	}
+	if n > 0 {
+		commandDidWrite.Store(true)
+	}
+	return nil
+}
+
+// applyQueuedWrite applies w unless it already was, returning the issue it
+// created or updated, or why it conflicts. replayed holds the updated_at
+// left by updates applied earlier in the same replay; an update w makes is
+// added to it, and w.BaseUpdatedAt records the one w starts from.
+func applyQueuedWrite(ctx context.Context, st storage.DoltStorage, w *queuedWrite, force bool, replayed map[string]time.Time) (issueID, conflict string, err error) {
+	appliedKey := offlineQueueAppliedPrefix + w.ID
+	targetID := w.IssueID
+	if w.Op == "update" {
+		// Queued updates keep the ID as typed; resolve it now that the
+		// database can say which issue a short ID means.
+		resolved, err := utils.ResolvePartialID(ctx, st, w.IssueID)
+		if err != nil {
+			return "", fmt.Sprintf("cannot resolve %s: %v", w.IssueID, err), nil
+		}
+		targetID = resolved
+		if at, ok := replayed[targetID]; ok && (w.BaseUpdatedAt == nil || at.After(*w.BaseUpdatedAt)) {
+			w.BaseUpdatedAt = &at
+		}
+	}
+	var updatedAt time.Time
+	msg := fmt.Sprintf("bd: replay queued %s by %s", w.describe(), w.Actor)
+	err = transactHonoringAutoCommit(ctx, st, msg, func(tx storage.Transaction) error {
+		if done, err := tx.GetMetadata(ctx, appliedKey); err != nil {
+			return err
+		} else if done != "" {
+			issueID = done
+			return nil
+		}
+		if w.TokenID != "" {
+			// Not overridden by --force: the token decides whether the
+			// write may happen at all, not whether it wins a conflict.
+			if conflict, err = checkQueuedWriteTokenFor(ctx, tx, w, targetID); err != nil || conflict != "" {
+				return err
+			}
+		}
+
+		switch w.Op {
+		case "create":
+			issue := &types.Issue{
+				Title:       w.Title,
+				Description: w.Description,
+				IssueType:   types.IssueType(w.IssueType),
+				Status:      types.StatusOpen,
+				Priority:    2,
+				CreatedBy:   w.Actor,
+			}
+			if w.Priority != nil {
+				issue.Priority = *w.Priority
+			}
+			if w.Assignee != nil {
+				issue.Assignee = *w.Assignee
+			}
+			if err := tx.CreateIssue(ctx, issue, w.Actor); err != nil {
+				return err
+			}
+			for _, label := range w.Labels {
+				if err := tx.AddLabel(ctx, issue.ID, label, w.Actor); err != nil {
+					return err
+				}
+			}
+			issueID = issue.ID
+		case "update":
+			current, err := tx.GetIssue(ctx, targetID)
+			if err != nil || current == nil {
+				conflict = fmt.Sprintf("%s no longer exists", targetID)
+				return nil
+			}
+			since, sinceWhat := w.QueuedAt, "this update was queued"
+			if w.BaseUpdatedAt != nil && w.BaseUpdatedAt.After(since) {
+				since, sinceWhat = *w.BaseUpdatedAt, "an earlier queued update was replayed"
+			}
+			if !force && current.UpdatedAt.After(since) {
+				conflict = fmt.Sprintf("%s was changed at %s, after %s at %s",
+					targetID, current.UpdatedAt.UTC().Format(time.RFC3339), sinceWhat, since.UTC().Format(time.RFC3339))
+				return nil
+			}
+			if err := tx.UpdateIssue(ctx, targetID, queuedUpdates(w.Updates), w.Actor); err != nil {
+				return err
+			}
+			if updated, err := tx.GetIssue(ctx, targetID); err == nil && updated != nil {
+				updatedAt = updated.UpdatedAt
+			}
+			issueID = targetID
+		default:
+			return fmt.Errorf("unknown queued operation %q", w.Op)
+		}
+		return tx.SetMetadata(ctx, appliedKey, issueID)
+	})
+	if err == nil && !updatedAt.IsZero() {
+		replayed[targetID] = updatedAt
+	}
+	return issueID, conflict, err
+}
+
+// checkQueuedWriteTokenFor checks w against its agent token: a create by the
+// labels it carries, an update by the labels of targetID.
+func checkQueuedWriteTokenFor(ctx context.Context, tx storage.Transaction, w *queuedWrite, targetID string) (string, error) {
+	labels := w.Labels
+	if w.Op == "update" {
+		var err error
+		if labels, err = tx.GetLabels(ctx, targetID); err != nil {
+			return "", err
+		}
+	}
+	var scope tokens.Scope
+	if w.TokenScope != nil {
+		scope = *w.TokenScope
+	}
+	return checkQueuedWriteToken(ctx, tx, w.TokenID, scope, w.Op, w.QueuedAt, labels)
+}
+
+// queuedUpdates restores the update values decoded from JSON: priority comes
+// back as a float64.
+func queuedUpdates(raw map[string]interface{}) map[string]interface{} {
+	updates := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		if f, ok := v.(float64); ok && k == "priority" {
+			v = int(f)
+		}
+		updates[k] = v
+	}
+	return updates
+}
+
+// maybeReplayOfflineQueue replays queued writes at the start of a write
+// command, now that the database is reachable again, and reports the outcome
+// on stderr.
+func maybeReplayOfflineQueue(ctx context.Context, st storage.DoltStorage, beadsDir string) {
+	if _, err := os.Stat(offlineQueuePath(beadsDir)); err != nil {
+		return
+	}
+	result, err := replayOfflineQueue(ctx, st, beadsDir, false)
+	if err != nil {
+		WarnError("offline queue: %v", err)
+	}
+	if result == nil {
+		return
+	}
+	if n := len(result.Applied); n > 0 {
+		commandDidWrite.Store(true)
+		fmt.Fprintf(os.Stderr, "Applied %d queued write(s) from the offline queue\n", n)
+	}
+	if n := len(result.Conflicts); n > 0 {
+		fmt.Fprintf(os.Stderr, "%d queued write(s) conflict with changes made since they were queued; see 'bd queue list'\n", n)
+	}
+}
+
+// isOfflineQueueCommand reports whether cmd is bd queue or one of its
+// subcommands, which manage the queue themselves.
+func isOfflineQueueCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == queueCmd {
+			return true
+		}
+	}
+	return false
+}
+
+// offlineQueueActive reports whether this command's write should be queued
+// instead of applied: --queue was given and the server was unreachable.
+func offlineQueueActive() bool {
+	return offlineQueueErr != nil
+}
@@ -0,0 +1,176 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+	"github.com/steveyegge/beads/internal/tokens"
+)
+
+func TestEmbeddedQueueReplayIsIdempotentAndKeepsConflicts(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt offline queue tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "oqr")
+	fresh := bdCreate(t, bd, dir, "Untouched since queued")
+	touched := bdCreate(t, bd, dir, "Changed after queued")
+
+	// Queue writes as if made while the server was down; one of the issues
+	// is changed after that, before the queue is replayed. updated_at has
+	// one-second resolution.
+	time.Sleep(1100 * time.Millisecond)
+	queuedAt := time.Now().UTC()
+	time.Sleep(1100 * time.Millisecond)
+	bdCommand(t, bd, dir, "update", touched.ID, "--title", "Newer title")
+
+	priority := 1
+	writes := []queuedWrite{
+		{ID: "q-create", Op: "create", QueuedAt: queuedAt, Actor: "offline", Title: "Queued offline", IssueType: "task", Priority: &priority, Labels: []string{"offline"}},
+		{ID: "q-fresh", Op: "update", QueuedAt: queuedAt, Actor: "offline", IssueID: fresh.ID, Updates: map[string]interface{}{"status": "in_progress"}},
+		{ID: "q-touched", Op: "update", QueuedAt: queuedAt, Actor: "offline", IssueID: touched.ID, Updates: map[string]interface{}{"title": "Stale title"}},
+	}
+	if err := writeOfflineQueue(beadsDir, writes); err != nil {
+		t.Fatal(err)
+	}
+
+	var result queueReplayResult
+	out := bdCommand(t, bd, dir, "queue", "replay", "--json")
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse bd queue replay --json: %v\n%s", err, out)
+	}
+	if len(result.Applied) != 2 || len(result.Conflicts) != 1 || result.Conflicts[0].ID != "q-touched" {
+		t.Fatalf("replay = %+v, want q-create and q-fresh applied, q-touched kept", result)
+	}
+	if got := bdShow(t, bd, dir, fresh.ID); got.Status != "in_progress" {
+		t.Errorf("%s status = %q, want in_progress", fresh.ID, got.Status)
+	}
+	if got := bdShow(t, bd, dir, touched.ID); got.Title != "Newer title" {
+		t.Errorf("conflicting update was applied: title = %q", got.Title)
+	}
+
+	// Replaying an entry that was already applied changes nothing.
+	if err := appendOfflineQueue(beadsDir, writes[0]); err != nil {
+		t.Fatal(err)
+	}
+	bdCommand(t, bd, dir, "queue", "replay")
+	if out := bdCommand(t, bd, dir, "list", "--title", "Queued offline", "--json"); strings.Count(out, `"title": "Queued offline"`) != 1 {
+		t.Fatalf("queued create applied more than once:\n%s", out)
+	}
+
+	// The conflict stays queued until forced.
+	list := bdCommand(t, bd, dir, "queue", "list")
+	if !strings.Contains(list, "q-touched") || !strings.Contains(list, "conflict:") {
+		t.Fatalf("bd queue list = %q, want the kept conflict", list)
+	}
+	bdCommand(t, bd, dir, "queue", "replay", "--force")
+	if got := bdShow(t, bd, dir, touched.ID); got.Title != "Stale title" {
+		t.Errorf("forced update not applied: title = %q", got.Title)
+	}
+	if out := bdCommand(t, bd, dir, "queue"); !strings.Contains(out, "Offline queue is empty") {
+		t.Errorf("bd queue after forced replay = %q, want empty", out)
+	}
+}
+
+func TestEmbeddedQueueReplaysSuccessiveUpdatesAndPrunesMarkers(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt offline queue tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "oqs")
+	issue := bdCreate(t, bd, dir, "Updated twice offline")
+
+	// Two updates to one issue queued while the server was down. Replaying
+	// the first moves updated_at past both queue times (one-second
+	// resolution); that must not make the second look like a conflict.
+	time.Sleep(1100 * time.Millisecond)
+	queuedAt := time.Now().UTC()
+	time.Sleep(1100 * time.Millisecond)
+	writes := []queuedWrite{
+		{ID: "q-first", Op: "update", QueuedAt: queuedAt, Actor: "offline", IssueID: issue.ID, Updates: map[string]interface{}{"status": "in_progress"}},
+		{ID: "q-second", Op: "update", QueuedAt: queuedAt, Actor: "offline", IssueID: issue.ID, Updates: map[string]interface{}{"title": "Renamed offline"}},
+	}
+	if err := writeOfflineQueue(beadsDir, writes); err != nil {
+		t.Fatal(err)
+	}
+
+	var result queueReplayResult
+	out := bdCommand(t, bd, dir, "queue", "replay", "--json")
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse bd queue replay --json: %v\n%s", err, out)
+	}
+	if len(result.Applied) != 2 || len(result.Conflicts) != 0 {
+		t.Fatalf("replay = %+v, want both updates applied", result)
+	}
+	if got := bdShow(t, bd, dir, issue.ID); got.Status != "in_progress" || got.Title != "Renamed offline" {
+		t.Errorf("%s = %q/%q, want in_progress/Renamed offline", issue.ID, got.Status, got.Title)
+	}
+
+	// The queue drained, so the applied markers are gone.
+	store, err := embeddeddolt.Open(t.Context(), beadsDir, "oqs", "main")
+	if err != nil {
+		t.Fatalf("open embedded store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	for _, w := range writes {
+		if v, err := store.GetMetadata(t.Context(), offlineQueueAppliedPrefix+w.ID); err != nil || v != "" {
+			t.Errorf("applied marker for %s = %q, %v; want pruned", w.ID, v, err)
+		}
+	}
+}
+
+func TestEmbeddedQueueReplayChecksAgentToken(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt offline queue tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "oqt")
+	outside := bdCreate(t, bd, dir, "Outside the token scope")
+
+	var created struct {
+		Token tokens.Token `json:"token"`
+	}
+	out := bdCommand(t, bd, dir, "token", "create", "crawler", "--allow", "create,update", "--scope", "label=crawler", "--json")
+	if err := json.Unmarshal([]byte(out), &created); err != nil {
+		t.Fatalf("parse bd token create --json: %v\n%s", err, out)
+	}
+	tok := created.Token
+
+	queuedAt := time.Now().UTC()
+	writes := []queuedWrite{
+		{ID: "q-in-scope", Op: "create", QueuedAt: queuedAt, Actor: "offline", TokenID: tok.ID, TokenScope: &tok.Scope, Title: "Queued by crawler", IssueType: "task", Labels: []string{"crawler"}},
+		{ID: "q-out-of-scope", Op: "update", QueuedAt: queuedAt, Actor: "offline", TokenID: tok.ID, TokenScope: &tok.Scope, IssueID: outside.ID, Updates: map[string]interface{}{"title": "Touched by crawler"}},
+		{ID: "q-revoked", Op: "create", QueuedAt: queuedAt, Actor: "offline", TokenID: "tk-gone", Title: "Queued by a revoked token", IssueType: "task"},
+	}
+	if err := writeOfflineQueue(beadsDir, writes); err != nil {
+		t.Fatal(err)
+	}
+
+	// --force overrides edit conflicts, not the token.
+	var result queueReplayResult
+	out = bdCommand(t, bd, dir, "queue", "replay", "--force", "--json")
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("parse bd queue replay --json: %v\n%s", err, out)
+	}
+	if len(result.Applied) != 1 || len(result.Conflicts) != 2 {
+		t.Fatalf("replay = %+v, want only q-in-scope applied", result)
+	}
+	if got := bdShow(t, bd, dir, outside.ID); got.Title != "Outside the token scope" {
+		t.Errorf("out-of-scope update was applied: title = %q", got.Title)
+	}
+	if out := bdCommand(t, bd, dir, "list", "--title", "revoked", "--json"); strings.Contains(out, "Queued by a revoked token") {
+		t.Errorf("create queued under a revoked token was applied:\n%s", out)
+	}
+}
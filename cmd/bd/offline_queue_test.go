@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOfflineQueueRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	writes, err := readOfflineQueue(dir)
+	if err != nil || len(writes) != 0 {
+		t.Fatalf("readOfflineQueue on missing file = %v, %v; want empty", writes, err)
+	}
+
+	priority := 1
+	create := queuedWrite{ID: "q-1", Op: "create", QueuedAt: time.Now().UTC(), Title: "Fix login", Priority: &priority, Labels: []string{"auth"}}
+	update := queuedWrite{ID: "q-2", Op: "update", QueuedAt: time.Now().UTC(), IssueID: "bd-1", Updates: map[string]interface{}{"priority": 0, "status": "closed"}}
+	for _, w := range []queuedWrite{create, update} {
+		if err := appendOfflineQueue(dir, w); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writes, err = readOfflineQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(writes) != 2 || writes[0].ID != "q-1" || writes[1].ID != "q-2" {
+		t.Fatalf("readOfflineQueue = %+v, want q-1 then q-2", writes)
+	}
+	if writes[0].Priority == nil || *writes[0].Priority != 1 || writes[0].Labels[0] != "auth" {
+		t.Errorf("create round trip = %+v", writes[0])
+	}
+	if got := queuedUpdates(writes[1].Updates)["priority"]; got != 0 {
+		t.Errorf("queued priority = %#v, want int 0", got)
+	}
+
+	if err := writeOfflineQueue(dir, writes[1:]); err != nil {
+		t.Fatal(err)
+	}
+	writes, err = readOfflineQueue(dir)
+	if err != nil || len(writes) != 1 || writes[0].ID != "q-2" {
+		t.Fatalf("after rewrite = %+v, %v; want only q-2", writes, err)
+	}
+
+	if err := writeOfflineQueue(dir, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(offlineQueuePath(dir)); !os.IsNotExist(err) {
+		t.Errorf("empty queue left %s behind (stat err %v)", offlineQueueFile, err)
+	}
+}
+
+func TestReadOfflineQueueReportsBadLine(t *testing.T) {
+	dir := t.TempDir()
+	data, _ := json.Marshal(queuedWrite{ID: "q-1", Op: "create", Title: "ok"})
+	content := string(data) + "\n\n{not json\n"
+	if err := os.WriteFile(filepath.Join(dir, offlineQueueFile), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readOfflineQueue(dir); err == nil {
+		t.Fatal("readOfflineQueue accepted a malformed line")
+	}
+}
+
+func TestQueuedWriteDescribe(t *testing.T) {
+	tests := []struct {
+		w    queuedWrite
+		want string
+	}{
+		{queuedWrite{Op: "create", Title: "Fix login"}, `create "Fix login"`},
+		{queuedWrite{Op: "update", IssueID: "bd-1", Updates: map[string]interface{}{"status": "closed", "priority": 1}}, "update bd-1 (priority, status)"},
+	}
+	for _, tt := range tests {
+		if got := tt.w.describe(); got != tt.want {
+			t.Errorf("describe() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,528 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// oooConfigPrefix namespaces out-of-office records in the database config
+// table. Like rigs, they are synced so every clone redirects the same way.
+const oooConfigPrefix = "ooo."
+
+// oooRedirectEventKind is the event kind recorded for each assignment that
+// went to a delegate (see bd events).
+const oooRedirectEventKind = "ooo_redirect"
+
+// oooRecord is one person's absence.
+type oooRecord struct {
+	Person   string     `json:"person"`
+	Until    *time.Time `json:"until,omitempty"` // back at this time; nil means until cleared
+	Delegate string     `json:"delegate,omitempty"`
+	Reason   string     `json:"reason,omitempty"`
+	SetBy    string     `json:"set_by,omitempty"`
+	SetAt    time.Time  `json:"set_at"`
+}
+
+// activeAt reports whether the person is away at now.
+func (r oooRecord) activeAt(now time.Time) bool {
+	return r.Until == nil || now.Before(*r.Until)
+}
+
+// oooRedirect is the payload of an ooo_redirect event.
+type oooRedirect struct {
+	Person   string `json:"person"`
+	Delegate string `json:"delegate"`
+	Issue    string `json:"issue"`
+	Via      string `json:"via"` // create, update, assign, auto-assign, or handoff
+}
+
+// parseOOORecords extracts out-of-office records from the config table,
+// keyed by person.
+func parseOOORecords(all map[string]string) map[string]oooRecord {
+	records := make(map[string]oooRecord)
+	for key, value := range all {
+		person, ok := strings.CutPrefix(key, oooConfigPrefix)
+		if !ok || person == "" {
+			continue
+		}
+		var rec oooRecord
+		_ = json.Unmarshal([]byte(value), &rec)
+		rec.Person = person
+		records[person] = rec
+	}
+	return records
+}
+
+// loadOOO returns the out-of-office records in s.
+func loadOOO(ctx context.Context, s storage.DoltStorage) (map[string]oooRecord, error) {
+	all, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading out-of-office records: %w", err)
+	}
+	return parseOOORecords(all), nil
+}
+
+// oooDelegate reports whether person is away at now and, if so, who covers
+// for them: the delegate chain is followed past delegates who are away
+// themselves. The delegate is "" when nobody covers, including when the
+// chain loops back on itself.
+func oooDelegate(records map[string]oooRecord, person string, now time.Time) (string, bool) {
+	rec, ok := records[person]
+	if !ok || !rec.activeAt(now) {
+		return "", false
+	}
+	seen := map[string]bool{person: true}
+	for {
+		next := rec.Delegate
+		if next == "" || seen[next] {
+			return "", true
+		}
+		seen[next] = true
+		if rec, ok = records[next]; !ok || !rec.activeAt(now) {
+			return next, true
+		}
+	}
+}
+
+// oooAwayMap maps everyone away at now to whoever covers for them, in the
+// form assign.Config.Away takes.
+func oooAwayMap(records map[string]oooRecord, now time.Time) map[string]string {
+	away := make(map[string]string)
+	for person := range records {
+		if delegate, ok := oooDelegate(records, person, now); ok {
+			away[person] = delegate
+		}
+	}
+	return away
+}
+
+// oooCovering lists, sorted, the people away at now whose work goes to me.
+func oooCovering(records map[string]oooRecord, me string, now time.Time) []string {
+	var covering []string
+	for person := range records {
+		if delegate, ok := oooDelegate(records, person, now); ok && delegate == me {
+			covering = append(covering, person)
+		}
+	}
+	slices.Sort(covering)
+	return covering
+}
+
+// formatOOOUntil describes when an absence ends.
+func formatOOOUntil(rec oooRecord) string {
+	if rec.Until == nil {
+		return "until further notice"
+	}
+	return "until " + rec.Until.Local().Format("2006-01-02 15:04")
+}
+
+// redirectForOOO returns who should receive work addressed to person: their
+// delegate while they are out of office, else person. It tells the user on
+// stderr when it redirects, or when person is away with nobody covering (the
+// work then stays with person). Failing to read the records never blocks
+// an assignment.
+func redirectForOOO(ctx context.Context, s storage.DoltStorage, person string) (string, bool) {
+	if person == "" || s == nil {
+		return person, false
+	}
+	records, err := loadOOO(ctx, s)
+	if err != nil {
+		return person, false
+	}
+	delegate, away := oooDelegate(records, person, time.Now())
+	if !away {
+		return person, false
+	}
+	rec := records[person]
+	if delegate == "" {
+		fmt.Fprintf(os.Stderr, "%s %s is out of office %s and nobody is covering\n", ui.RenderWarn("⚠"), person, formatOOOUntil(rec))
+		return person, false
+	}
+	fmt.Fprintf(os.Stderr, "%s %s is out of office %s; assigning to %s instead\n", ui.RenderAccent("→"), person, formatOOOUntil(rec), delegate)
+	return delegate, true
+}
+
+// recordOOORedirect records an ooo_redirect event so the person can see what
+// was redirected while they were away (bd ooo report).
+func recordOOORedirect(ctx context.Context, s storage.DoltStorage, r oooRedirect) error {
+	payload, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encoding redirect: %w", err)
+	}
+	event := &types.Issue{
+		Title:     eventTitle(oooRedirectEventKind, r.Issue),
+		Status:    types.StatusClosed,
+		Priority:  4,
+		IssueType: types.TypeEvent,
+		EventKind: oooRedirectEventKind,
+		Actor:     actor,
+		Target:    r.Issue,
+		Payload:   string(payload),
+		CreatedBy: getActorWithGit(),
+	}
+	if err := s.CreateIssue(ctx, event, actor); err != nil {
+		return fmt.Errorf("recording redirect of %s: %w", r.Issue, err)
+	}
+	return nil
+}
+
+// oooReportEntry is one redirected assignment and where the issue stands now.
+type oooReportEntry struct {
+	oooRedirect
+	Title    string       `json:"title,omitempty"`
+	Status   types.Status `json:"status,omitempty"`
+	Assignee string       `json:"assignee,omitempty"` // current assignee
+	At       time.Time    `json:"at"`
+}
+
+// buildOOOReport turns ooo_redirect events into report entries, oldest
+// first, filling in each issue's current title, status, and assignee.
+func buildOOOReport(events []*types.Issue, issues map[string]*types.Issue) []oooReportEntry {
+	entries := make([]oooReportEntry, 0, len(events))
+	for _, e := range events {
+		var r oooRedirect
+		if err := json.Unmarshal([]byte(e.Payload), &r); err != nil || r.Issue == "" {
+			continue
+		}
+		entry := oooReportEntry{oooRedirect: r, At: e.CreatedAt}
+		if issue := issues[r.Issue]; issue != nil {
+			entry.Title = issue.Title
+			entry.Status = issue.Status
+			entry.Assignee = issue.Assignee
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortStableFunc(entries, func(a, b oooReportEntry) int { return a.At.Compare(b.At) })
+	return entries
+}
+
+// loadOOOReport reads the redirects made for person since the given time.
+func loadOOOReport(ctx context.Context, person string, since time.Time) ([]oooReportEntry, error) {
+	eventType := types.TypeEvent
+	filter := types.IssueFilter{IssueType: &eventType, EventKind: oooRedirectEventKind}
+	if !since.IsZero() {
+		filter.CreatedAfter = &since
+	}
+	events, err := store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, fmt.Errorf("reading redirects: %w", err)
+	}
+	events = filterEventsByPayload(events, []eventPayloadFilter{{Field: "person", Want: person}})
+
+	var ids []string
+	for _, e := range events {
+		ids = append(ids, e.Target)
+	}
+	issues := make(map[string]*types.Issue)
+	if len(ids) > 0 {
+		found, err := store.GetIssuesByIDs(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("reading redirected issues: %w", err)
+		}
+		for _, issue := range found {
+			issues[issue.ID] = issue
+		}
+	}
+	return buildOOOReport(events, issues), nil
+}
+
+// printOOOReport prints what was redirected while person was away.
+func printOOOReport(person string, entries []oooReportEntry) {
+	if len(entries) == 0 {
+		fmt.Printf("Nothing was redirected from %s.\n", person)
+		return
+	}
+	fmt.Printf("Redirected from %s while away (%d):\n", person, len(entries))
+	for _, e := range entries {
+		line := fmt.Sprintf("  %s %s → %s via %s", e.At.Local().Format("2006-01-02 15:04"), ui.RenderID(e.Issue), e.Delegate, e.Via)
+		if e.Title != "" {
+			line += fmt.Sprintf("  %s [%s", e.Title, e.Status)
+			if e.Assignee != "" && e.Assignee != e.Delegate {
+				line += ", now " + e.Assignee
+			}
+			line += "]"
+		}
+		fmt.Println(line)
+	}
+}
+
+var oooCmd = &cobra.Command{
+	Use:     "ooo",
+	GroupID: "issues",
+	Short:   "Redirect work while someone is out of office",
+	Long: `Record absences so work addressed to someone who is away goes to their
+delegate instead:
+
+  bd ooo set alice --until 2024-08-01 --delegate bob
+
+While alice is away:
+  - bd create/update --assignee alice, bd assign <id> alice, and
+    bd handoff --to alice assign to bob, with a notice
+  - bd assign --auto gives alice's turns to bob, and passes over anyone
+    away with no delegate
+  - bd notify run by bob also notifies about alice's issues
+
+Each redirected assignment is recorded as an ooo_redirect event. When alice
+is back, 'bd ooo report alice' (or 'bd ooo clear alice', which also removes
+the record) lists what was redirected and where each issue stands now.
+
+An absence ends at --until (a date or relative time such as +2w); without
+--until it lasts until cleared. If a delegate is away too, work follows
+their delegate. Records are stored in the database, so every clone sees them.
+
+Examples:
+  bd ooo set alice --until 2024-08-01 --delegate bob --reason vacation
+  bd ooo set --until +3d          # yourself, with nobody covering
+  bd ooo list
+  bd ooo report alice
+  bd ooo clear alice`,
+}
+
+var oooSetCmd = &cobra.Command{
+	Use:           "set [person]",
+	Short:         "Mark someone out of office (default: you)",
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("ooo set")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("ooo is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("ooo-set")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureDirectMode("ooo set requires direct database access"); err != nil {
+			return HandleError("%v", err)
+		}
+
+		person := getActorWithGit()
+		if len(args) == 1 {
+			person = args[0]
+		}
+		untilStr, _ := cmd.Flags().GetString("until")
+		delegate, _ := cmd.Flags().GetString("delegate")
+		reason, _ := cmd.Flags().GetString("reason")
+		now := time.Now()
+
+		rec := oooRecord{Person: person, Delegate: delegate, Reason: reason, SetBy: actor, SetAt: now.UTC()}
+		if untilStr != "" {
+			until, err := parseTimeFlag(untilStr)
+			if err != nil {
+				return HandleErrorRespectJSON("invalid --until: %v", err)
+			}
+			if !until.After(now) {
+				return HandleErrorRespectJSON("--until %s is in the past", untilStr)
+			}
+			rec.Until = &until
+		}
+		if delegate == person {
+			return HandleErrorRespectJSON("%s cannot delegate to themselves", person)
+		}
+
+		records, err := loadOOO(rootCtx, store)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		// Keep the original start so a changed return date still reports
+		// everything redirected since the absence began.
+		if prev, ok := records[person]; ok && prev.activeAt(now) {
+			rec.SetAt = prev.SetAt
+		}
+		records[person] = rec
+		if covering, _ := oooDelegate(records, person, now); delegate != "" && covering == "" {
+			return HandleErrorRespectJSON("delegating %s to %s covers nobody: %s is away too, and their delegates loop or end with nobody covering", person, delegate, delegate)
+		}
+
+		raw, err := json.Marshal(rec)
+		if err != nil {
+			return HandleErrorRespectJSON("encoding out-of-office record: %v", err)
+		}
+		if err := store.SetConfig(rootCtx, oooConfigPrefix+person, string(raw)); err != nil {
+			return HandleErrorRespectJSON("saving out-of-office record: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(rec)
+		}
+		fmt.Printf("%s %s is out of office %s\n", ui.RenderPass("✓"), person, formatOOOUntil(rec))
+		if delegate != "" {
+			fmt.Printf("  Work goes to %s\n", delegate)
+		} else {
+			fmt.Printf("  Nobody is covering; bd assign --auto will pass over %s\n", person)
+		}
+		return nil
+	},
+}
+
+var oooClearCmd = &cobra.Command{
+	Use:           "clear [person]",
+	Short:         "Mark someone back and report what was redirected (default: you)",
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("ooo clear")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("ooo is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("ooo-clear")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureDirectMode("ooo clear requires direct database access"); err != nil {
+			return HandleError("%v", err)
+		}
+
+		person := getActorWithGit()
+		if len(args) == 1 {
+			person = args[0]
+		}
+		records, err := loadOOO(rootCtx, store)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		rec, ok := records[person]
+		if !ok {
+			return HandleErrorRespectJSON("%s is not marked out of office", person)
+		}
+		entries, err := loadOOOReport(rootCtx, person, rec.SetAt)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if err := store.DeleteConfig(rootCtx, oooConfigPrefix+person); err != nil {
+			return HandleErrorRespectJSON("clearing out-of-office record: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"person": person, "status": "back", "redirected": entries})
+		}
+		fmt.Printf("%s Welcome back, %s\n", ui.RenderPass("✓"), person)
+		printOOOReport(person, entries)
+		return nil
+	},
+}
+
+var oooListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List out-of-office records",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("ooo is not supported in proxied-server mode")
+		}
+		records, err := loadOOO(rootCtx, store)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		list := make([]oooRecord, 0, len(records))
+		for _, rec := range records {
+			list = append(list, rec)
+		}
+		slices.SortFunc(list, func(a, b oooRecord) int { return strings.Compare(a.Person, b.Person) })
+
+		if jsonOutput {
+			return outputJSON(list)
+		}
+		if len(list) == 0 {
+			fmt.Println("Nobody is out of office.")
+			return nil
+		}
+		now := time.Now()
+		for _, rec := range list {
+			line := fmt.Sprintf("  %s %s", ui.RenderBold(rec.Person), formatOOOUntil(rec))
+			if !rec.activeAt(now) {
+				line = fmt.Sprintf("  %s back since %s", ui.RenderBold(rec.Person), rec.Until.Local().Format("2006-01-02 15:04"))
+			} else if delegate, _ := oooDelegate(records, rec.Person, now); delegate != "" {
+				line += " → " + delegate
+			} else {
+				line += " (nobody covering)"
+			}
+			if rec.Reason != "" {
+				line += "  " + ui.RenderMuted(rec.Reason)
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+var oooReportCmd = &cobra.Command{
+	Use:   "report [person]",
+	Short: "List what was redirected while someone was away (default: you)",
+	Long: `List the assignments redirected from someone while they were away, with
+each issue's current status and assignee.
+
+The report covers the current or most recent absence; --since overrides
+the start (for example --since -30d once the record has been cleared).`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("ooo is not supported in proxied-server mode")
+		}
+		person := getActorWithGit()
+		if len(args) == 1 {
+			person = args[0]
+		}
+		var since time.Time
+		if s, _ := cmd.Flags().GetString("since"); s != "" {
+			t, err := parseTimeFlag(s)
+			if err != nil {
+				return HandleErrorRespectJSON("invalid --since: %v", err)
+			}
+			since = t
+		} else {
+			records, err := loadOOO(rootCtx, store)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			rec, ok := records[person]
+			if !ok {
+				return HandleErrorRespectJSON("%s is not marked out of office; use --since to report an earlier absence", person)
+			}
+			since = rec.SetAt
+		}
+		entries, err := loadOOOReport(rootCtx, person, since)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			return outputJSON(entries)
+		}
+		printOOOReport(person, entries)
+		return nil
+	},
+}
+
+func init() {
+	oooSetCmd.Flags().String("until", "", "When the person is back (e.g. 2024-08-01, +2w); default: until cleared")
+	oooSetCmd.Flags().String("delegate", "", "Who receives the person's work while they are away")
+	oooSetCmd.Flags().String("reason", "", "Why (shown in bd ooo list)")
+	oooReportCmd.Flags().String("since", "", "Report redirects since this time instead of the absence start")
+
+	oooCmd.AddCommand(oooSetCmd, oooClearCmd, oooListCmd, oooReportCmd)
+	rootCmd.AddCommand(oooCmd)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestOOODelegate(t *testing.T) {
+	now := time.Date(2026, 7, 20, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(24 * time.Hour)
+	records := parseOOORecords(map[string]string{
+		"ooo.alice":    `{"delegate":"bob","until":"` + future.Format(time.RFC3339) + `"}`,
+		"ooo.bob":      `{"delegate":"carol"}`,
+		"ooo.dave":     `{"delegate":"erin","until":"` + past.Format(time.RFC3339) + `"}`,
+		"ooo.frank":    `{}`,
+		"ooo.gina":     `{"delegate":"hank"}`,
+		"ooo.hank":     `{"delegate":"gina"}`,
+		"issue_prefix": "bd",
+	})
+
+	tests := []struct {
+		person   string
+		delegate string
+		away     bool
+	}{
+		{"alice", "carol", true}, // bob is away too; follow his delegate
+		{"bob", "carol", true},
+		{"carol", "", false},
+		{"dave", "", false}, // back since the past --until
+		{"frank", "", true}, // away with nobody covering
+		{"gina", "", true},  // delegates loop
+	}
+	for _, tt := range tests {
+		delegate, away := oooDelegate(records, tt.person, now)
+		if delegate != tt.delegate || away != tt.away {
+			t.Errorf("oooDelegate(%s) = %q, %v; want %q, %v", tt.person, delegate, away, tt.delegate, tt.away)
+		}
+	}
+
+	away := oooAwayMap(records, now)
+	if len(away) != 5 || away["alice"] != "carol" || away["frank"] != "" {
+		t.Errorf("away map = %v", away)
+	}
+	if _, ok := away["dave"]; ok {
+		t.Error("dave is back and should not be in the away map")
+	}
+	if got := oooCovering(records, "carol", now); !slices.Equal(got, []string{"alice", "bob"}) {
+		t.Errorf("carol covers %v, want [alice bob]", got)
+	}
+}
+
+func TestBuildOOOReport(t *testing.T) {
+	t1 := time.Date(2026, 7, 20, 9, 0, 0, 0, time.UTC)
+	events := []*types.Issue{
+		{Payload: `{"person":"alice","delegate":"bob","issue":"bd-2","via":"update"}`, CreatedAt: t1.Add(time.Hour)},
+		{Payload: `{"person":"alice","delegate":"bob","issue":"bd-1","via":"create"}`, CreatedAt: t1},
+		{Payload: `not json`, CreatedAt: t1},
+	}
+	issues := map[string]*types.Issue{
+		"bd-1": {ID: "bd-1", Title: "Fix login", Status: types.StatusClosed, Assignee: "bob"},
+	}
+
+	entries := buildOOOReport(events, issues)
+	if len(entries) != 2 || entries[0].Issue != "bd-1" || entries[1].Issue != "bd-2" {
+		t.Fatalf("entries = %+v, want bd-1 then bd-2", entries)
+	}
+	if entries[0].Title != "Fix login" || entries[0].Status != types.StatusClosed || entries[0].Via != "create" {
+		t.Errorf("bd-1 entry = %+v", entries[0])
+	}
+	if entries[1].Title != "" {
+		t.Errorf("missing issue should leave the title empty: %+v", entries[1])
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"reflect"
 
+	"github.com/steveyegge/beads/internal/besteffort"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
@@ -40,14 +41,18 @@ func outputJSONRaw(v interface{}) error {
 
 func wrapWithSchemaVersion(v interface{}) interface{} {
 	if jsonEnvelopeEnabled() {
-		return map[string]interface{}{
+		m := map[string]interface{}{
 			"schema_version": JSONSchemaVersion,
 			"data":           v,
 		}
+		addJSONWarnings(m)
+		return m
 	}
 
 	if v == nil {
-		return map[string]interface{}{"schema_version": JSONSchemaVersion}
+		m := map[string]interface{}{"schema_version": JSONSchemaVersion}
+		addJSONWarnings(m)
+		return m
 	}
 
 	rv := reflect.ValueOf(v)
@@ -68,9 +73,24 @@ func wrapWithSchemaVersion(v interface{}) interface{} {
 		return v
 	}
 	m["schema_version"] = JSONSchemaVersion
+	addJSONWarnings(m)
 	return m
 }
 
+// addJSONWarnings adds the best-effort failures recorded so far to a JSON
+// object as "warnings". They are drained, so the stderr summary printed
+// when the command finishes does not repeat them. Array output has no
+// place for them and leaves them to the summary.
+func addJSONWarnings(m map[string]interface{}) {
+	warnings, dropped := besteffort.Drain()
+	if len(warnings) > 0 {
+		m["warnings"] = warnings
+	}
+	if dropped > 0 {
+		m["warnings_dropped"] = dropped
+	}
+}
+
 var envelopeDeprecationEmitted bool
 
 func emitEnvelopeDeprecation() {
@@ -89,9 +109,12 @@ func outputJSONError(err error, code string) error {
 	base := map[string]interface{}{
 		"error": err.Error(),
 	}
-	if code != "" {
-		base["code"] = code
+	category := classifyError(err)
+	if code == "" {
+		code = string(category)
 	}
+	base["code"] = code
+	addJSONWarnings(base)
 	if jsonEnvelopeEnabled() {
 		errObj = map[string]interface{}{
 			"schema_version": JSONSchemaVersion,
@@ -104,5 +127,5 @@ func outputJSONError(err error, code string) error {
 	encoder := json.NewEncoder(os.Stderr)
 	encoder.SetIndent("", "  ")
 	_ = encoder.Encode(errObj)
-	return &exitError{Code: 1}
+	return &exitError{Code: exitCodeFor(category)}
 }
@@ -2,7 +2,11 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
+
+	"github.com/steveyegge/beads/internal/besteffort"
 )
 
 func TestWrapWithSchemaVersion_Legacy_Object(t *testing.T) {
@@ -140,3 +144,50 @@ func TestWrapWithSchemaVersion_Envelope_RoundTrip(t *testing.T) {
 		t.Errorf("data.count = %v, want 42", innerData["count"])
 	}
 }
+
+func TestWrapWithSchemaVersion_Warnings(t *testing.T) {
+	besteffort.Drain()
+	besteffort.Record("update peer last sync", errors.New("table is locked"))
+	besteffort.Record("update peer last sync", errors.New("table is locked"))
+
+	// Arrays have no room for warnings and leave them for the summary.
+	if _, ok := wrapWithSchemaVersion([]string{"a"}).([]string); !ok {
+		t.Fatal("slice output was wrapped")
+	}
+
+	m := wrapWithSchemaVersion(map[string]string{"id": "beads-123"}).(map[string]interface{})
+	warnings, ok := m["warnings"].([]besteffort.Warning)
+	if !ok || len(warnings) != 1 {
+		t.Fatalf("warnings = %#v, want one folded warning", m["warnings"])
+	}
+	if w := warnings[0]; w.Op != "update peer last sync" || w.Message != "table is locked" || w.Count != 2 {
+		t.Errorf("warning = %+v", w)
+	}
+
+	// Reported warnings are drained: neither the next object nor the
+	// stderr summary repeats them.
+	if _, ok := wrapWithSchemaVersion(map[string]string{"id": "x"}).(map[string]interface{})["warnings"]; ok {
+		t.Error("warnings repeated in a second JSON object")
+	}
+	if out := captureStderr(t, reportBestEffortFailures); out != "" {
+		t.Errorf("summary repeated reported warnings: %q", out)
+	}
+}
+
+func TestReportBestEffortFailures(t *testing.T) {
+	besteffort.Drain()
+	besteffort.Record("close store", errors.New("flush failed"))
+	besteffort.Record("restore BEADS_DIR", errors.New("invalid argument"))
+	besteffort.Record("restore BEADS_DIR", errors.New("invalid argument"))
+
+	out := captureStderr(t, reportBestEffortFailures)
+	for _, want := range []string{
+		"Warning: 3 best-effort operation(s) failed",
+		"  close store: flush failed\n",
+		"  restore BEADS_DIR: invalid argument (2 times)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/capacity"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// capacityDefaultWeeklyHours applies to assignees missing from
+// capacity.weekly when capacity.default-hours is unset.
+const capacityDefaultWeeklyHours = 40
+
+var planCmd = &cobra.Command{
+	Use:     "plan",
+	GroupID: "views",
+	Short:   "Planning reports",
+}
+
+var planCapacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Compare assigned work with each person's capacity",
+	Long: `Sum the remaining estimates of each assignee's open work (open,
+in_progress, blocked) and compare them with the hours they have between
+now and --until, then suggest reassignments for anyone overcommitted.
+
+Weekly capacity comes from config.yaml; working days are weekdays:
+
+  capacity:
+    weekly: "alice:40,bob:20,agent-1:80"   # hours per week by assignee
+    default-hours: 40                      # anyone not listed (default 40)
+
+Everyone listed in capacity.weekly appears in the report, so idle people
+are candidates for rebalancing. Suggested moves take the least urgent
+estimated work (lowest priority, then largest) from overcommitted people
+and give it to whoever in capacity.weekly has the most spare hours, only
+when the issue fits.
+In-progress and unestimated issues are never moved; unestimated issues are
+listed so their load is not silently ignored.
+
+Examples:
+  bd plan capacity --until 2025-07-01
+  bd plan capacity --until +2w --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("plan capacity is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("plan capacity")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+
+		untilStr, _ := cmd.Flags().GetString("until")
+		if untilStr == "" {
+			return HandleErrorRespectJSON("--until is required")
+		}
+		now := time.Now()
+		until, err := timeparsing.ParseRelativeTime(untilStr, now)
+		if err != nil {
+			return HandleErrorRespectJSON("invalid --until %q: %v", untilStr, err)
+		}
+		if !until.After(now) {
+			return HandleErrorRespectJSON("--until must be in the future")
+		}
+		weekly, err := capacity.ParseWeekly(config.GetString("capacity.weekly"))
+		if err != nil {
+			return HandleErrorRespectJSON("capacity.weekly: %v", err)
+		}
+		defaultHours := float64(capacityDefaultWeeklyHours)
+		if raw := config.GetString("capacity.default-hours"); raw != "" {
+			if defaultHours, err = strconv.ParseFloat(raw, 64); err != nil || defaultHours < 0 {
+				return HandleErrorRespectJSON("capacity.default-hours: invalid hours %q", raw)
+			}
+		}
+
+		persistent := false
+		notTemplate := false
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
+			Statuses:   []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked},
+			Ephemeral:  &persistent,
+			IsTemplate: &notTemplate,
+		})
+		if err != nil {
+			return HandleErrorRespectJSON("failed to load issues: %v", err)
+		}
+
+		report := capacity.Build(issues, capacity.Options{
+			From:          now,
+			Until:         until,
+			Weekly:        weekly,
+			DefaultWeekly: defaultHours,
+		})
+		if jsonOutput {
+			return outputJSON(report)
+		}
+		printCapacityReport(report)
+		return nil
+	},
+}
+
+func printCapacityReport(r *capacity.Report) {
+	fmt.Printf("\n%s Capacity until %s (%d working days)\n\n", ui.RenderAccent("📅"), r.Until, r.WorkingDays)
+	if len(r.People) == 0 {
+		fmt.Println("No assigned open work and no capacity.weekly configured")
+		return
+	}
+	fmt.Printf("  %-20s %9s %9s %6s  %s\n", "ASSIGNEE", "ASSIGNED", "AVAILABLE", "LOAD", "ISSUES")
+	defaulted := false
+	for _, p := range r.People {
+		load := "-"
+		if p.AvailableHours > 0 {
+			load = fmt.Sprintf("%.0f%%", p.Utilization*100)
+		}
+		name := p.Name
+		if !p.Configured {
+			name += "*"
+			defaulted = true
+		}
+		line := fmt.Sprintf("  %-20s %8.1fh %8.1fh %6s  %d", name, p.AssignedHours, p.AvailableHours, load, len(p.Issues))
+		if len(p.Unestimated) > 0 {
+			line += fmt.Sprintf(" (%d unestimated)", len(p.Unestimated))
+		}
+		switch {
+		case p.Overcommitted:
+			fmt.Println(ui.RenderFail(line))
+		case p.AvailableHours > 0 && p.Utilization >= 0.8:
+			fmt.Println(ui.RenderWarn(line))
+		default:
+			fmt.Println(line)
+		}
+	}
+	if defaulted {
+		fmt.Println(ui.RenderMuted("  * not in capacity.weekly; default capacity used"))
+	}
+	fmt.Println()
+
+	if len(r.Overcommitted) == 0 {
+		fmt.Printf("%s Nobody is overcommitted\n\n", ui.RenderPass("✓"))
+		return
+	}
+	fmt.Printf("%s Overcommitted: %s\n\n", ui.RenderWarn("⚠"), strings.Join(r.Overcommitted, ", "))
+	if len(r.Moves) == 0 {
+		fmt.Println("No rebalancing move fits in anyone's spare capacity")
+		fmt.Println()
+		return
+	}
+	fmt.Println("Suggested moves:")
+	for _, m := range r.Moves {
+		fmt.Printf("  %s %s → %s (%.1fh, P%d)\n", formatFeedbackID(m.IssueID, m.Title), m.From, m.To, m.Hours, m.Priority)
+		fmt.Printf("      %s\n", ui.RenderMuted(fmt.Sprintf("bd assign %s %s", m.IssueID, m.To)))
+	}
+	fmt.Println()
+}
+
+func init() {
+	planCapacityCmd.Flags().String("until", "", "Plan up to this date (YYYY-MM-DD, +2w, next friday)")
+	planCmd.AddCommand(planCapacityCmd)
+	rootCmd.AddCommand(planCmd)
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/wasmhooks"
+)
+
+// pluginPrefix is the executable name prefix that makes a program on PATH a
+// bd plugin: "bd-foo" runs as "bd foo", "bd-foo-bar" as "bd foo bar".
+const pluginPrefix = "bd-"
+
+// pluginContextEnv carries the JSON plugin context into the plugin process.
+const pluginContextEnv = "BD_PLUGIN_CONTEXT"
+
+// pluginContextVersion is bumped on incompatible changes to pluginContext.
+const pluginContextVersion = 1
+
+// pluginContext is the JSON contract handed to plugins in BD_PLUGIN_CONTEXT.
+// It describes the workspace the plugin was invoked in; plugins read and
+// write issues by calling back into bd (the "bd" field) with --json.
+type pluginContext struct {
+	Version   int      `json:"version"`
+	BD        string   `json:"bd"`
+	BDVersion string   `json:"bd_version"`
+	Plugin    string   `json:"plugin"`
+	Args      []string `json:"args"`
+	WorkDir   string   `json:"work_dir"`
+	BeadsDir  string   `json:"beads_dir,omitempty"`
+	Database  string   `json:"database,omitempty"`
+	DoltMode  string   `json:"dolt_mode,omitempty"`
+	Server    string   `json:"server,omitempty"` // host:port in server mode
+	Actor     string   `json:"actor"`
+	ReadOnly  bool     `json:"readonly"`
+}
+
+var pluginCmd = &cobra.Command{
+	Use:     "plugin",
+	GroupID: "advanced",
+	Short:   "List bd plugins and wasm hook modules",
+	Long: `bd plugins are executables named bd-<name> on PATH. Running 'bd <name>'
+for a name that is not a built-in command runs the plugin with the remaining
+arguments, the same way git and kubectl plugins work. Dashes nest: bd-sprint-plan
+runs as 'bd sprint plan'. Built-in commands always win over plugins.
+
+The plugin inherits stdin, stdout, stderr, and the environment, plus:
+
+  BD_PLUGIN_CONTEXT  JSON describing the invocation (version, bd, bd_version,
+                     plugin, args, work_dir, beads_dir, database, dolt_mode,
+                     server, actor, readonly)
+  BEADS_DIR          the workspace's .beads directory, so callbacks to bd
+                     operate on the same database
+
+Plugins should read and write issues by running "$bd <command> --json"; those
+calls go through bd's normal checks (read-only mode, agent tokens, protection).
+
+Hooks that run per issue (ready scoring, close policies) are WebAssembly
+modules in .beads/plugins/*.wasm instead, loaded in-process with no file,
+network, or environment access. A module exports memory and
+bd_alloc(size) -> ptr, plus any of:
+
+  bd_score(ptr, len) -> i64         score the issue JSON; bd ready --sort score
+                                    orders by the sum of all scores, highest first
+  bd_close_policy(ptr, len) -> i32  given {"issue", "actor", "reason"}, return
+                                    0 to allow bd close or nonzero to refuse it
+                                    (bypassed by bd close --force)
+
+and may import set_result(ptr, len) (the refusal message) and log(ptr, len)
+from the "bd" module.
+
+Examples:
+  bd plugin list
+  bd ready --sort score        # order ready work by wasm bd_score hooks
+  bd sprint plan --weeks 2     # runs bd-sprint-plan --weeks 2`,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List plugins on PATH and wasm modules in .beads/plugins",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plugins := discoverPlugins()
+		type entry struct {
+			Command  string `json:"command"`
+			Path     string `json:"path"`
+			Shadowed bool   `json:"shadowed"`
+		}
+		entries := make([]entry, 0, len(plugins))
+		for _, p := range plugins {
+			entries = append(entries, entry{Command: p.command(), Path: p.path, Shadowed: pluginShadowed(p.words)})
+		}
+		host, err := loadWasmHooks()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		modules := host.Modules()
+		if modules == nil {
+			modules = []wasmhooks.Module{}
+		}
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"commands": entries, "wasm": modules})
+		}
+		if len(entries) == 0 {
+			fmt.Println("No bd plugins found on PATH (executables named bd-<name>)")
+		}
+		for _, e := range entries {
+			note := ""
+			if e.Shadowed {
+				note = "  (shadowed by built-in command)"
+			}
+			fmt.Printf("bd %-20s %s%s\n", e.Command, e.Path, note)
+		}
+		if len(modules) > 0 {
+			fmt.Printf("\nWasm hook modules (%s):\n", wasmPluginsDir())
+			for _, m := range modules {
+				hooks := strings.Join(m.Hooks, ", ")
+				if hooks == "" {
+					hooks = "no hooks exported"
+				}
+				fmt.Printf("  %-22s %s\n", m.Name, hooks)
+			}
+		}
+		return nil
+	},
+}
+
+type plugin struct {
+	words []string // command words: bd-sprint-plan → [sprint plan]
+	path  string
+}
+
+func (p plugin) command() string { return strings.Join(p.words, " ") }
+
+// discoverPlugins lists bd-* executables on PATH. Earlier PATH entries win,
+// as they would for exec.
+func discoverPlugins() []plugin {
+	seen := map[string]bool{}
+	var out []plugin
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if !strings.HasPrefix(name, pluginPrefix) || len(name) == len(pluginPrefix) || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if !isExecutableFile(path) {
+				continue
+			}
+			seen[name] = true
+			out = append(out, plugin{words: strings.Split(strings.TrimPrefix(name, pluginPrefix), "-"), path: path})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].command() < out[j].command() })
+	return out
+}
+
+func isExecutableFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode()&0o111 != 0
+}
+
+// pluginShadowed reports whether a built-in command owns the plugin's name.
+func pluginShadowed(words []string) bool {
+	found, _, err := rootCmd.Find(words)
+	return err == nil && found != rootCmd
+}
+
+// findPlugin resolves args to a plugin when they do not name a built-in
+// command. The longest matching bd-<w1>-<w2>... on PATH wins; the returned
+// args are what is left after the plugin's words.
+func findPlugin(args []string) (string, []string, bool) {
+	var words []string
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") {
+			break
+		}
+		words = append(words, a)
+	}
+	if len(words) == 0 || pluginShadowed(words[:1]) {
+		return "", nil, false
+	}
+	for n := len(words); n > 0; n-- {
+		name := pluginPrefix + strings.Join(words[:n], "-")
+		if path, err := exec.LookPath(name); err == nil {
+			return path, args[n:], true
+		}
+	}
+	return "", nil, false
+}
+
+// buildPluginContext describes the current workspace without opening the
+// database, so plugins start quickly and work outside a workspace too.
+func buildPluginContext(pluginPath string, args []string) pluginContext {
+	ctx := pluginContext{
+		Version:   pluginContextVersion,
+		BDVersion: Version,
+		Plugin:    filepath.Base(pluginPath),
+		Args:      args,
+		Actor:     getActorWithGit(),
+		ReadOnly:  config.GetBool("readonly"),
+	}
+	if ctx.Args == nil {
+		ctx.Args = []string{}
+	}
+	if self, err := os.Executable(); err == nil {
+		ctx.BD = self
+	}
+	ctx.WorkDir, _ = os.Getwd()
+	if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+		ctx.BeadsDir = beadsDir
+		if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil {
+			ctx.Database = cfg.GetDoltDatabase()
+			ctx.DoltMode = cfg.GetDoltMode()
+			if ctx.DoltMode == configfile.DoltModeServer {
+				ctx.Server = fmt.Sprintf("%s:%d", cfg.GetDoltServerHost(), cfg.GetDoltServerPort())
+			}
+		}
+	}
+	return ctx
+}
+
+// runPlugin runs the plugin at path with args and returns its exit code.
+func runPlugin(path string, args []string) int {
+	pctx := buildPluginContext(path, args)
+	raw, err := json.Marshal(pctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: encoding plugin context: %v\n", err)
+		return 1
+	}
+	cmd := exec.Command(path, args...) // #nosec G204 -- user-installed plugin on PATH
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), pluginContextEnv+"="+string(raw))
+	if pctx.BeadsDir != "" {
+		cmd.Env = append(cmd.Env, "BEADS_DIR="+pctx.BeadsDir)
+	}
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintf(os.Stderr, "Error: running plugin %s: %v\n", pctx.Plugin, err)
+		return 1
+	}
+	return 0
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	rootCmd.AddCommand(pluginCmd)
+}
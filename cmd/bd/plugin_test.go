@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestFindPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixtures are shell scripts")
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"bd-sprint", "bd-sprint-plan", "bd-list"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bd-notexec"), []byte("#!/bin/sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	tests := []struct {
+		args     []string
+		wantPath string
+		wantArgs []string
+	}{
+		{[]string{"sprint", "plan", "--weeks", "2"}, "bd-sprint-plan", []string{"--weeks", "2"}},
+		{[]string{"sprint", "review"}, "bd-sprint", []string{"review"}},
+		{[]string{"sprint"}, "bd-sprint", []string{}},
+		{[]string{"list"}, "", nil},             // built-in wins
+		{[]string{"notexec"}, "", nil},          // not executable
+		{[]string{"--json", "sprint"}, "", nil}, // leading flag
+		{nil, "", nil},
+	}
+	for _, tt := range tests {
+		path, args, ok := findPlugin(tt.args)
+		if tt.wantPath == "" {
+			if ok {
+				t.Errorf("findPlugin(%v) = %s, want no plugin", tt.args, path)
+			}
+			continue
+		}
+		if !ok || filepath.Base(path) != tt.wantPath {
+			t.Errorf("findPlugin(%v) = %q, %v; want %s", tt.args, path, ok, tt.wantPath)
+			continue
+		}
+		if len(args) != len(tt.wantArgs) {
+			t.Errorf("findPlugin(%v) args = %v, want %v", tt.args, args, tt.wantArgs)
+		}
+	}
+
+	plugins := discoverPlugins()
+	var got []string
+	for _, p := range plugins {
+		got = append(got, p.command())
+	}
+	want := []string{"list", "sprint", "sprint plan"}
+	if len(got) != len(want) {
+		t.Fatalf("discoverPlugins() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("discoverPlugins() = %v, want %v", got, want)
+		}
+	}
+	if !pluginShadowed(plugins[0].words) || pluginShadowed(plugins[1].words) {
+		t.Errorf("pluginShadowed: list should be shadowed, sprint should not")
+	}
+}
@@ -220,7 +220,7 @@ func init() {
 	primeCmd.Flags().BoolVar(&primeStealthMode, "stealth", false, "Stealth mode (no git operations, flush only)")
 	primeCmd.Flags().BoolVar(&primeExportMode, "export", false, "Output default content (ignores PRIME.md override)")
 	primeCmd.Flags().BoolVar(&primeMemoriesOnly, "memories-only", false, "Output only persistent memories for compact hook contexts")
-	primeCmd.Flags().BoolVar(&primeNoMemories, "no-memories", false, "Omit the persistent memories section (ignored when --memories-only is set, which wins)")
+	primeCmd.Flags().BoolVar(&primeNoMemories, "no-memories", false, "Omit the persistent memories and crystallized knowledge sections (ignored when --memories-only is set, which wins)")
 	primeCmd.Flags().BoolVar(&primeHookJSONMode, "hook-json", false, "Wrap output in the SessionStart hook JSON envelope (Claude Code, Gemini CLI, Codex)")
 	primeCmd.Flags().IntVar(&primeMaxMemories, "max-memories", 0, "Cap injected persistent memories to N entries (0 = unlimited; falls back to the prime.max-memories config key)")
 	primeCmd.Flags().IntVar(&primeMaxMemoryChars, "max-memory-chars", 0, "Cap the total bytes of injected memory entries, at whole-memory boundaries; section header and banner are not counted (0 = unlimited; falls back to the prime.max-memory-chars config key)")
@@ -635,7 +635,7 @@ func outputMCPContext(w io.Writer, stealthMode bool) error {
 	redirectNotice := getRedirectNotice(false)
 	var memories string
 	if !primeNoMemories {
-		memories = formatMemoriesForPrime(true)
+		memories = formatMemoriesForPrime(true) + formatKnowledgeForPrime(true)
 	}
 
 	context := primeTruncationDirective + `# Beads Issue Tracker Active
@@ -820,7 +820,7 @@ git status                  # Check changed files
 	redirectNotice := getRedirectNotice(true)
 	var memories string
 	if !primeNoMemories {
-		memories = formatMemoriesForPrime(false)
+		memories = formatMemoriesForPrime(false) + formatKnowledgeForPrime(false)
 	}
 
 	context := primeTruncationDirective + `# Beads Workflow Context
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/progress"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// jsonProgressInterval is how often --json mode emits progress events for
+// an operation still running, and how long it must run before the first.
+const jsonProgressInterval = time.Second
+
+// progressReporter reports the running command's long operations: see
+// installProgressReporter. nil when progress output is off.
+var progressReporter interface {
+	progress.Reporter
+	Close()
+}
+
+// installProgressReporter puts a progress reporter on rootCtx, chosen by
+// output mode: JSON progress events on stderr under --json, a spinner or
+// bar when stderr is a terminal, and nothing under --quiet, in git hooks,
+// or with progress disabled (config key progress / BD_PROGRESS=false).
+func installProgressReporter() {
+	closeProgressReporter()
+	switch {
+	case !config.GetBool("progress") || quietFlag || os.Getenv("BD_GIT_HOOK") == "1":
+		return
+	case jsonOutput:
+		progressReporter = progress.NewJSON(os.Stderr, jsonProgressInterval)
+	case ui.IsStderrTerminal() && !strings.EqualFold(os.Getenv("TERM"), "dumb"):
+		progressReporter = progress.NewTerminal(os.Stderr)
+	default:
+		return
+	}
+	rootCtx = progress.WithReporter(rootCtx, progressReporter)
+}
+
+// closeProgressReporter stops the reporter, erasing a spinner line left by
+// an interrupted operation. main calls it once the command has finished.
+func closeProgressReporter() {
+	if progressReporter != nil {
+		progressReporter.Close()
+		progressReporter = nil
+	}
+}
+
+// clearProgressLine erases a spinner line so a message bd prints on stderr
+// starts on a clean line. The next redraw puts the spinner back below it.
+func clearProgressLine() {
+	if t, ok := progressReporter.(*progress.Terminal); ok {
+		t.Clear()
+	}
+}
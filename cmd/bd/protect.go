@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var protectCmd = &cobra.Command{
+	Use:     "protect <id>...",
+	GroupID: "issues",
+	Short:   "Reserve issues or fields for specific actors",
+	Long: `Protect issues (release checklists, compliance records) so only specific
+actors can change them.
+
+Without --fields the whole issue is protected: only the listed actors may
+update, close, reopen, or claim it, and it cannot be deleted until it is
+unprotected. With --fields only those fields are immutable for everyone
+else. Field names are issue columns (title, description, status, priority,
+assignee, ...) plus "metadata" for metadata keys. Closing and reopening
+count as changing status.
+
+--actors takes glob patterns ("release-*"). You are always added, so you
+can unprotect the issue later. Protection is enforced by the storage
+layer, so every command and integration is held to it; refused changes
+fail with "issue is protected". Labels, comments, and dependencies are not
+covered.
+
+Examples:
+  bd protect bd-42 --reason "Release checklist"
+  bd protect bd-42 --actors "alice,release-*"
+  bd protect bd-42 --fields title,description,status
+  bd unprotect bd-42`,
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		actors, _ := cmd.Flags().GetStringSlice("actors")
+		fields, _ := cmd.Flags().GetStringSlice("fields")
+		reason, _ := cmd.Flags().GetString("reason")
+		for _, pattern := range actors {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return HandleErrorRespectJSON("invalid --actors pattern %q: %v", pattern, err)
+			}
+		}
+		for _, field := range fields {
+			if field != types.ProtectedMetadata && !issueops.IsAllowedUpdateField(field) {
+				return HandleErrorRespectJSON("unknown field %q in --fields", field)
+			}
+		}
+		p := &types.Protection{
+			Actors:      appendUnique(trimAll(actors), actor),
+			Fields:      trimAll(fields),
+			Reason:      reason,
+			ProtectedBy: actor,
+			ProtectedAt: time.Now().UTC(),
+		}
+		return runProtection("protect", args, p)
+	},
+}
+
+var unprotectCmd = &cobra.Command{
+	Use:           "unprotect <id>...",
+	GroupID:       "issues",
+	Short:         "Remove protection from issues",
+	Long:          `Remove protection from issues. Only actors the protection allows can remove it.`,
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runProtection("unprotect", args, nil)
+	},
+}
+
+// protectResult is the JSON shape for protect/unprotect output.
+type protectResult struct {
+	ID         string            `json:"id"`
+	Title      string            `json:"title"`
+	Protection *types.Protection `json:"protection,omitempty"`
+}
+
+// runProtection sets p on each issue, or removes the protection when p is nil.
+func runProtection(command string, args []string, p *types.Protection) error {
+	CheckReadonly(command)
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("%s is not supported in proxied-server mode", command)
+	}
+	evt := metrics.NewCommandEvent(command)
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("database not available: %v", err)
+	}
+
+	ctx := rootCtx
+	var results []protectResult
+	var failed []string
+	for _, arg := range args {
+		res, err := setProtection(ctx, store, arg, p)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", ui.RenderFail("✗"), err)
+			failed = append(failed, arg)
+			continue
+		}
+		results = append(results, *res)
+		SetLastTouchedID(res.ID)
+	}
+	if len(results) > 0 {
+		commandDidWrite.Store(true)
+	}
+
+	if jsonOutput {
+		if err := outputJSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, res := range results {
+			if p == nil {
+				fmt.Printf("%s Unprotected %s\n", ui.RenderPass("✓"), formatFeedbackID(res.ID, res.Title))
+				continue
+			}
+			scope := "all fields"
+			if len(p.Fields) > 0 {
+				scope = strings.Join(p.Fields, ", ")
+			}
+			fmt.Printf("%s Protected %s (%s; allowed: %s)\n", ui.RenderPass("✓"), formatFeedbackID(res.ID, res.Title),
+				scope, strings.Join(p.Actors, ", "))
+		}
+	}
+	if len(failed) > 0 {
+		return SilentExit()
+	}
+	return nil
+}
+
+// setProtection writes or clears one issue's protection and records the
+// change in the audit log. The storage layer refuses the write when an
+// existing protection does not allow the actor.
+func setProtection(ctx context.Context, st storage.DoltStorage, ref string, p *types.Protection) (*protectResult, error) {
+	id, err := utils.ResolvePartialID(ctx, st, ref)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", ref, err)
+	}
+	issue, err := st.GetIssue(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("issue %s not found: %w", id, err)
+	}
+	old, _ := types.ProtectionFromMetadata(issue.Metadata)
+
+	var newValue string
+	if p == nil {
+		if old == nil {
+			return nil, fmt.Errorf("%s is not protected", id)
+		}
+		err = st.UpdateIssue(ctx, id, map[string]interface{}{
+			issueops.OpUnsetMetadata: []string{types.ProtectionMetadataKey},
+		}, actor)
+	} else {
+		raw, merr := json.Marshal(p)
+		if merr != nil {
+			return nil, fmt.Errorf("encoding protection: %w", merr)
+		}
+		newValue = string(raw)
+		err = st.MergeMetadata(ctx, id, types.ProtectionMetadataKey, raw, actor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue := ""
+	if old != nil {
+		raw, _ := json.Marshal(old)
+		oldValue = string(raw)
+	}
+	reason := ""
+	if p != nil {
+		reason = p.Reason
+	}
+	audit.LogFieldChange(id, types.ProtectionMetadataKey, oldValue, newValue, actor, reason)
+	return &protectResult{ID: id, Title: issue.Title, Protection: p}, nil
+}
+
+func trimAll(items []string) []string {
+	var out []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func appendUnique(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
+
+func init() {
+	protectCmd.Flags().StringSlice("actors", nil, "Actors (glob patterns) allowed to change the issue; you are always included")
+	protectCmd.Flags().StringSlice("fields", nil, "Protect only these fields (default: the whole issue)")
+	protectCmd.Flags().String("reason", "", "Why the issue is protected")
+
+	protectCmd.ValidArgsFunction = issueIDCompletion
+	unprotectCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(protectCmd, unprotectCmd)
+}
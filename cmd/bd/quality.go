@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/quality"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var qualityCmd = &cobra.Command{
+	Use:     "quality <id>",
+	GroupID: "views",
+	Short:   "Compute and show an issue's quality score",
+	Long: `Compute an issue's quality score and show how it was derived.
+
+The score (0-1) is the weighted mean of these scorers; a scorer that has
+nothing to rate abstains:
+
+  description  Description length plus acceptance criteria and design
+  validations  Share of validators whose latest verdict is pass (bd validate)
+  reopens      Halves with each reopen
+  tests        Pass rate of linked test results in metadata.test_results
+               ({"passed": N, "failed": M})
+
+The score is stored in metadata.quality_score, with the last changes kept in
+metadata.quality_history. Filter on it with 'bd list --min-quality 0.7'.
+
+Configure scorer weights in config.yaml. When quality.scorers is set, close,
+reopen, and validate recompute the score automatically:
+
+  quality:
+    scorers: "description:1,validations:2,reopens:1,tests:2"
+
+Without quality.scorers, every scorer has weight 1 and scores are only
+updated by this command.
+
+Examples:
+  bd quality bd-42
+  bd quality bd-42 --history
+  bd quality recompute --all`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("quality")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("quality is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("quality")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		weights, err := qualityWeights()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		view, err := refreshQualityScore(ctx, store, id, weights, "bd quality")
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if view.Changed {
+			commandDidWrite.Store(true)
+		}
+
+		if jsonOutput {
+			return outputJSON(view)
+		}
+		fmt.Printf("%s %s — quality %s\n", ui.RenderID(view.ID), view.Title, renderQualityScore(view.Score))
+		if len(view.Components) == 0 {
+			fmt.Println(ui.RenderMuted("  no scorer applies"))
+		}
+		for _, c := range view.Components {
+			fmt.Printf("  %-12s %.2f  %s\n", c.Scorer, c.Score, ui.RenderMuted(fmt.Sprintf("(weight %g)", c.Weight)))
+		}
+		if showHistory, _ := cmd.Flags().GetBool("history"); showHistory && len(view.History) > 0 {
+			fmt.Println("History:")
+			for _, h := range view.History {
+				fmt.Printf("  %s  %.3f  %s\n", h.At.Local().Format("2006-01-02 15:04"), h.Score, ui.RenderMuted(h.Trigger))
+			}
+		}
+		return nil
+	},
+}
+
+var qualityRecomputeCmd = &cobra.Command{
+	Use:   "recompute [<id>...]",
+	Short: "Recompute stored quality scores",
+	Long: `Recompute quality scores for the given issues, or for every issue with
+--all (closed issues included). Useful after changing quality.scorers.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("quality recompute")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("quality is not supported in proxied-server mode")
+		}
+		all, _ := cmd.Flags().GetBool("all")
+		if all == (len(args) > 0) {
+			return HandleErrorRespectJSON("specify issue IDs or --all")
+		}
+		weights, err := qualityWeights()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		ctx := rootCtx
+		ids := args
+		if all {
+			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+			if err != nil {
+				return HandleErrorRespectJSON("listing issues: %v", err)
+			}
+			ids = make([]string, len(issues))
+			for i, issue := range issues {
+				ids[i] = issue.ID
+			}
+		}
+
+		var views []qualityView
+		changed := 0
+		for _, raw := range ids {
+			id, err := utils.ResolvePartialID(ctx, store, raw)
+			if err != nil {
+				return HandleErrorRespectJSON("resolving %s: %v", raw, err)
+			}
+			view, err := refreshQualityScore(ctx, store, id, weights, "bd quality recompute")
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			if view.Changed {
+				changed++
+			}
+			views = append(views, view)
+		}
+		if changed > 0 {
+			commandDidWrite.Store(true)
+		}
+
+		if jsonOutput {
+			if views == nil {
+				views = []qualityView{}
+			}
+			return outputJSON(views)
+		}
+		fmt.Printf("%s Recomputed %d quality score(s), %d changed\n", ui.RenderPass("✓"), len(views), changed)
+		return nil
+	},
+}
+
+// qualityView is the JSON shape for quality output.
+type qualityView struct {
+	ID         string                 `json:"id"`
+	Title      string                 `json:"title"`
+	Score      float64                `json:"score"`
+	Components []quality.Component    `json:"components"`
+	History    []quality.HistoryEntry `json:"history,omitempty"`
+	Changed    bool                   `json:"changed"`
+}
+
+// qualityWeights returns the configured scorer weights, or equal weights for
+// every scorer when quality.scorers is unset.
+func qualityWeights() (quality.Weights, error) {
+	raw := strings.TrimSpace(config.GetString("quality.scorers"))
+	if raw == "" {
+		return quality.DefaultWeights(), nil
+	}
+	return quality.ParseWeights(raw)
+}
+
+// qualityInputs gathers the scorer inputs for an issue.
+func qualityInputs(issue *types.Issue) (quality.Inputs, error) {
+	vals, err := types.ValidationsFromMetadata(issue.Metadata)
+	if err != nil {
+		return quality.Inputs{}, err
+	}
+	reopens, err := types.ReopensFromMetadata(issue.Metadata)
+	if err != nil {
+		return quality.Inputs{}, err
+	}
+	return quality.Inputs{
+		Issue:       issue,
+		Validations: vals,
+		ReopenCount: len(reopens),
+		TestResults: quality.TestResultsFromMetadata(issue.Metadata),
+	}, nil
+}
+
+// refreshQualityScore recomputes an issue's score and stores it, appending to
+// the history when the score changed.
+func refreshQualityScore(ctx context.Context, s storage.DoltStorage, id string, weights quality.Weights, trigger string) (qualityView, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return qualityView{}, fmt.Errorf("issue %s not found: %w", id, err)
+	}
+	in, err := qualityInputs(issue)
+	if err != nil {
+		return qualityView{}, err
+	}
+	res := quality.Compute(in, weights)
+	history, err := quality.HistoryFromMetadata(issue.Metadata)
+	if err != nil {
+		return qualityView{}, err
+	}
+	history, changed := quality.AppendHistory(history, res.Score, storage.Now(ctx), trigger)
+	if changed {
+		rawScore, _ := json.Marshal(res.Score)
+		if err := s.MergeMetadata(ctx, id, quality.ScoreMetadataKey, rawScore, actor); err != nil {
+			return qualityView{}, fmt.Errorf("saving quality score: %w", err)
+		}
+		rawHistory, err := json.Marshal(history)
+		if err != nil {
+			return qualityView{}, fmt.Errorf("encoding quality history: %w", err)
+		}
+		if err := s.MergeMetadata(ctx, id, quality.HistoryMetadataKey, rawHistory, actor); err != nil {
+			return qualityView{}, fmt.Errorf("saving quality history: %w", err)
+		}
+	}
+	if res.Components == nil {
+		res.Components = []quality.Component{}
+	}
+	return qualityView{
+		ID:         issue.ID,
+		Title:      issue.Title,
+		Score:      res.Score,
+		Components: res.Components,
+		History:    history,
+		Changed:    changed,
+	}, nil
+}
+
+// refreshQualityScoreOnEvent recomputes the score after a close, reopen, or
+// validation when quality.scorers is configured. It is best-effort: a scoring
+// failure is reported but never fails the command that triggered it.
+func refreshQualityScoreOnEvent(ctx context.Context, s storage.DoltStorage, id, trigger string) {
+	if strings.TrimSpace(config.GetString("quality.scorers")) == "" {
+		return
+	}
+	weights, err := qualityWeights()
+	if err == nil {
+		_, err = refreshQualityScore(ctx, s, id, weights, trigger)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: quality score for %s not updated: %v\n", id, err)
+	}
+}
+
+func renderQualityScore(score float64) string {
+	s := fmt.Sprintf("%.2f", score)
+	switch {
+	case score >= 0.7:
+		return ui.RenderPass(s)
+	case score >= 0.4:
+		return ui.RenderWarn(s)
+	default:
+		return ui.RenderFail(s)
+	}
+}
+
+func init() {
+	qualityCmd.Flags().Bool("history", false, "Show the score history")
+	qualityRecomputeCmd.Flags().Bool("all", false, "Recompute every issue")
+
+	qualityCmd.ValidArgsFunction = issueIDCompletion
+	qualityRecomputeCmd.ValidArgsFunction = issueIDCompletion
+	qualityCmd.AddCommand(qualityRecomputeCmd)
+	rootCmd.AddCommand(qualityCmd)
+}
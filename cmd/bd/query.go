@@ -46,7 +46,9 @@ Supported fields:
   label             Issue label (use "none" for unlabeled)
   title             Search in title (contains)
   description       Search in description (contains, "none" for empty)
-  notes             Search in notes (contains)
+  notes             Search in notes (contains, "none" for empty)
+  design            Search in design notes (contains, "none" for empty)
+  acceptance        Search in acceptance criteria (contains, "none" for empty; alias: acceptance_criteria)
   created           Creation date/time
   updated           Last update date/time
   started           Date/time issue first transitioned to in_progress
@@ -58,6 +60,9 @@ Supported fields:
   template          Boolean (true/false)
   parent            Parent issue ID
   mol_type          Molecule type (swarm, patrol, work)
+  metadata.<key>    Top-level metadata value (= only)
+  derived.<name>    Field computed at query time from derived.fields in
+                    config.yaml (numbers support all operators, strings = and !=)
 
 Date values:
   Relative durations: 7d (7 days ago), 24h (24 hours ago), 2w (2 weeks ago)
@@ -73,7 +78,10 @@ Examples:
   bd query "assignee=none AND type=task"
   bd query "created>30d AND status!=closed"
   bd query "label=frontend OR label=backend"
-  bd query "title=authentication AND priority=0"`,
+  bd query "title=authentication AND priority=0"
+  bd query "type=feature AND acceptance=none AND status!=closed"
+  bd query "derived.age_days>30 AND status=open" --sort derived.age_days -r
+  bd query "derived.sla_state=breached OR derived.sla_state=at_risk"`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -122,7 +130,16 @@ Examples:
 			return nil
 		}
 
+		derivedFields, err := loadDerivedFields()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if err := validateDerivedSort(sortBy, derivedFields); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
 		eval := query.NewEvaluator(time.Now())
+		eval.SetDerivedFields(derivedFields)
 		result, err := eval.Evaluate(node)
 		if err != nil {
 			return HandleErrorRespectJSON("evaluating query: %v", err)
@@ -155,6 +172,9 @@ Examples:
 			if err != nil {
 				return HandleErrorRespectJSON("%v", err)
 			}
+			if err := computeDerivedFieldsWithCounts(ctx, store, iwc, derivedFields); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
 			if result.RequiresPredicate && result.Predicate != nil {
 				filtered := make([]*types.IssueWithCounts, 0, len(iwc))
 				for _, item := range iwc {
@@ -181,6 +201,9 @@ Examples:
 		if err != nil {
 			return HandleErrorRespectJSON("%v", err)
 		}
+		if err := computeDerivedFields(ctx, store, issues, derivedFields); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
 
 		if result.RequiresPredicate && result.Predicate != nil {
 			filtered := make([]*types.Issue, 0, len(issues))
@@ -202,6 +225,22 @@ Examples:
 	},
 }
 
+// queryUsesDerived reports whether the query references a derived.<name> field.
+func queryUsesDerived(node query.Node) bool {
+	switch n := node.(type) {
+	case *query.ComparisonNode:
+		return strings.HasPrefix(n.Field, "derived.")
+	case *query.AndNode:
+		return queryUsesDerived(n.Left) || queryUsesDerived(n.Right)
+	case *query.OrNode:
+		return queryUsesDerived(n.Left) || queryUsesDerived(n.Right)
+	case *query.NotNode:
+		return queryUsesDerived(n.Operand)
+	default:
+		return false
+	}
+}
+
 // hasExplicitStatusFilter checks if the query contains an explicit status comparison
 func hasExplicitStatusFilter(node query.Node) bool {
 	switch n := node.(type) {
@@ -236,6 +275,9 @@ func outputQueryResults(issues []*types.Issue, queryStr string, longFormat bool)
 			if len(issue.Labels) > 0 {
 				fmt.Printf("  Labels: %v\n", issue.Labels)
 			}
+			if len(issue.Derived) > 0 {
+				fmt.Printf("  Derived: %s\n", query.FormatDerived(issue))
+			}
 			fmt.Println()
 		}
 	} else {
@@ -284,7 +326,7 @@ func init() {
 	queryCmd.Flags().Int("offset", 0, "Skip the first N matching results (0-based). Only supported under --proxied-server.")
 	queryCmd.Flags().BoolP("all", "a", false, "Include closed issues (default: exclude closed)")
 	queryCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	queryCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	queryCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee, derived.<name>")
 	queryCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
 	queryCmd.Flags().Bool("parse-only", false, "Only parse the query and show the AST (for debugging)")
 
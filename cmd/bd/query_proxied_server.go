@@ -45,6 +45,10 @@ func runQueryProxiedServer(cmd *cobra.Command, ctx context.Context, args []strin
 		return nil
 	}
 
+	if queryUsesDerived(node) || strings.HasPrefix(sortBy, "derived.") {
+		return HandleErrorRespectJSON("derived fields are not supported in proxied-server mode")
+	}
+
 	eval := query.NewEvaluator(time.Now())
 	result, err := eval.Evaluate(node)
 	if err != nil {
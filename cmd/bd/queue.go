@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var queueReplayForce bool
+
+var queueCmd = &cobra.Command{
+	Use:     "queue",
+	GroupID: "sync",
+	Short:   "List, replay, or drop writes queued while the database was unreachable",
+	Long: `Manage the offline queue of writes made while the database was unreachable.
+
+When the Dolt server is down or the laptop is offline, 'bd create --queue'
+and 'bd update --queue' record the write in .beads/offline-queue.jsonl
+instead of failing. The first write command that reaches the database again
+applies the queue, or run 'bd queue replay' yourself.
+
+Replay is idempotent: each applied write is recorded in the database in the
+same transaction, so a write is never applied twice. A queued update whose
+issue was deleted, or was changed after the update was queued, is a conflict
+and stays in the queue. Review it with 'bd queue list', then apply it anyway
+with 'bd queue replay --force' or discard it with 'bd queue drop'.
+
+Under an agent token (BD_TOKEN), a write is queued only if the token record
+cached by an earlier command on this clone allows it, and the write records
+the token. Replay checks it against the token in the database again; a write
+whose token was revoked or no longer covers it is a conflict that --force
+does not override.
+
+Without a subcommand, lists the queue.
+
+Examples:
+  bd create "Fix login" --queue        # Queue a create if the server is down
+  bd update bd-42 -s closed --queue    # Queue an update
+  bd queue                             # List queued writes
+  bd queue replay                      # Apply them now
+  bd queue replay --force              # Apply conflicting updates too
+  bd queue drop q-1a2b3c4d5e6f         # Discard a queued write`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runQueueList()
+	},
+}
+
+var queueListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List queued writes",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runQueueList()
+	},
+}
+
+var queueReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Apply queued writes to the database",
+	Long: `Apply queued writes to the database, oldest first.
+
+Writes that conflict with changes made since they were queued stay in the
+queue unless --force is given. --force cannot apply an update to an issue
+that no longer exists; drop those with 'bd queue drop'.`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("queue replay")
+		evt := metrics.NewCommandEvent("queue replay")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		result, err := replayOfflineQueue(rootCtx, store, beads.FindBeadsDir(), queueReplayForce)
+		if result != nil && len(result.Applied) > 0 {
+			commandDidWrite.Store(true)
+		}
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			return outputJSON(result)
+		}
+		if len(result.Applied) == 0 && len(result.Conflicts) == 0 {
+			fmt.Println("Offline queue is empty")
+			return nil
+		}
+		for _, e := range result.Applied {
+			fmt.Printf("%s Applied %s: %s\n", ui.RenderPass("✓"), e.ID, e.Write)
+		}
+		for _, e := range result.Conflicts {
+			fmt.Printf("%s Kept %s: %s (%s)\n", ui.RenderWarn("!"), e.ID, e.Write, e.Reason)
+		}
+		if len(result.Conflicts) > 0 {
+			fmt.Println("\nApply conflicting writes with 'bd queue replay --force' or discard them with 'bd queue drop'.")
+		}
+		return nil
+	},
+}
+
+var queueDropCmd = &cobra.Command{
+	Use:           "drop <op-id>...",
+	Short:         "Discard queued writes",
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		beadsDir := beads.FindBeadsDir()
+		if beadsDir == "" {
+			return HandleErrorRespectJSON("no beads database found")
+		}
+		writes, err := readOfflineQueue(beadsDir)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to read offline queue: %v", err)
+		}
+		var kept []queuedWrite
+		dropped := []string{}
+		for _, w := range writes {
+			if slices.Contains(args, w.ID) {
+				dropped = append(dropped, w.ID)
+				continue
+			}
+			kept = append(kept, w)
+		}
+		for _, id := range args {
+			if !slices.Contains(dropped, id) {
+				return HandleErrorRespectJSON("no queued write %s (see 'bd queue list')", id)
+			}
+		}
+		if err := writeOfflineQueue(beadsDir, kept); err != nil {
+			return HandleErrorRespectJSON("failed to rewrite offline queue: %v", err)
+		}
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"dropped": dropped, "remaining": len(kept)})
+		}
+		for _, id := range dropped {
+			fmt.Printf("Dropped %s\n", id)
+		}
+		return nil
+	},
+}
+
+func runQueueList() error {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return HandleErrorRespectJSON("no beads database found")
+	}
+	writes, err := readOfflineQueue(beadsDir)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to read offline queue: %v", err)
+	}
+	if jsonOutput {
+		if writes == nil {
+			writes = []queuedWrite{}
+		}
+		return outputJSON(writes)
+	}
+	if len(writes) == 0 {
+		fmt.Println("Offline queue is empty")
+		return nil
+	}
+	for _, w := range writes {
+		fmt.Printf("%s  %s  %s  %s\n", w.ID, w.QueuedAt.Local().Format("2006-01-02 15:04"), w.Actor, w.describe())
+		if w.Conflict != "" {
+			fmt.Printf("    %s %s\n", ui.RenderWarn("conflict:"), w.Conflict)
+		}
+	}
+	return nil
+}
+
+func init() {
+	queueReplayCmd.Flags().BoolVar(&queueReplayForce, "force", false, "Apply updates even if the issue changed after they were queued")
+	queueCmd.AddCommand(queueListCmd, queueReplayCmd, queueDropCmd)
+	rootCmd.AddCommand(queueCmd)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// readonlyAllowedCommands lists the command paths (without the leading "bd")
+// that may run in read-only mode. Read-only mode fails closed: a command not
+// listed here is refused in the dispatch layer before it runs, so a new
+// mutating command cannot slip through by forgetting CheckReadonly. Matches
+// are exact: listing a parent does not allow its subcommands.
+var readonlyAllowedCommands = map[string]bool{
+	// Help, shell completion, and workspace introspection.
+	"":                 true, // bare "bd" prints help
+	"help":             true,
+	"completion":       true,
+	"__complete":       true,
+	"__completeNoDesc": true,
+	"version":          true,
+	"plugin list":      true,
+	"where":            true,
+	"info":             true,
+	"context":          true,
+	"prime":            true,
+	"quickstart":       true,
+	"ping":             true,
+	"types":            true,
+	"statuses":         true,
+	"errors":           true,
+
+	// Issue queries and views.
+	"list":               true,
+	"more":               true,
+	"ready":              true,
+	"blocked":            true,
+	"my-work":            true,
+	"standup":            true,
+	"show":               true,
+	"children":           true,
+	"count":              true,
+	"search":             true,
+	"query":              true,
+	"status":             true,
+	"stale":              true,
+	"diff":               true,
+	"history":            true,
+	"blame":              true,
+	"why":                true,
+	"graph":              true,
+	"graph check":        true,
+	"gantt":              true,
+	"compare-branch":     true,
+	"plan capacity":      true,
+	"followup list":      true,
+	"ac list":            true,
+	"lint":               true,
+	"duplicates":         true,
+	"find-duplicates":    true,
+	"state":              true,
+	"state list":         true,
+	"comments":           true, // bd comments <id> lists; "comments add" is refused
+	"comments list":      true,
+	"dep list":           true,
+	"dep tree":           true,
+	"dep cycles":         true,
+	"label list":         true,
+	"label list-all":     true,
+	"lane list":          true,
+	"lock list":          true,
+	"slug list":          true,
+	"epic status":        true,
+	"gate list":          true,
+	"gate show":          true,
+	"merge-slot check":   true,
+	"decide list":        true,
+	"human list":         true,
+	"human stats":        true,
+	"events kinds":       true,
+	"events query":       true,
+	"incident list":      true,
+	"incident timeline":  true,
+	"mol current":        true,
+	"mol last-activity":  true,
+	"mol progress":       true,
+	"mol ready":          true,
+	"mol show":           true,
+	"mol stale":          true,
+	"mol wisp list":      true,
+	"mol wisp show":      true,
+	"wisp list":          true,
+	"wisp show":          true,
+	"bond suggestions":   true,
+	"trace":              true,
+	"rig list":           true,
+	"rig stats":          true,
+	"rig health":         true,
+	"ooo list":           true,
+	"ooo report":         true,
+	"swarm list":         true,
+	"swarm status":       true,
+	"swarm validate":     true,
+	"todo list":          true,
+	"queue list":         true,
+	"run list":           true,
+	"review list":        true,
+	"validate show":      true,
+	"cost show":          true,
+	"memories":           true,
+	"recall":             true,
+	"kv get":             true,
+	"kv list":            true,
+	"crystallize show":   true,
+	"crystallize search": true,
+	"formula list":       true,
+	"formula show":       true,
+	"formula schema":     true,
+	"notify":             true,
+	"serve":              true, // refuses writes itself when read-only
+	"export":             true, // writes JSONL to a file or stdout, never the database
+
+	// Configuration and version-control inspection.
+	"config get":            true,
+	"config list":           true,
+	"config show":           true,
+	"config validate":       true,
+	"config drift":          true,
+	"confirm list":          true,
+	"token list":            true,
+	"vc status":             true,
+	"branch list":           true,
+	"checkpoint list":       true,
+	"meta get":              true,
+	"dolt show":             true,
+	"dolt status":           true,
+	"dolt test":             true,
+	"dolt remote list":      true,
+	"federation export":     true,
+	"federation list-peers": true,
+	"federation ping":       true,
+	"federation status":     true,
+	"federation verify":     true,
+	"repo list":             true,
+	"towns list":            true,
+	"towns search":          true,
+	"hooks list":            true,
+	"backup status":         true,
+	"upgrade status":        true,
+	"worktree info":         true,
+	"worktree list":         true,
+	"ado status":            true,
+	"github status":         true,
+	"gitlab status":         true,
+	"jira status":           true,
+	"linear status":         true,
+	"notion status":         true,
+}
+
+// normalizeReadonlyFlag accepts --read-only as a spelling of --readonly.
+func normalizeReadonlyFlag(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == "read-only" {
+		name = "readonly"
+	}
+	return pflag.NormalizedName(name)
+}
+
+// readonlyCommandPath returns cmd's path without the root command name.
+func readonlyCommandPath(cmd *cobra.Command) string {
+	path := cmd.CommandPath()
+	if root := cmd.Root(); root != nil {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, root.Name()), " ")
+	}
+	return path
+}
+
+// readonlyFromEnv reports whether BD_READONLY turns read-only mode on. An
+// explicit --readonly=false cannot override it: the flag only tightens, so a
+// sandboxed worker cannot opt back into writes.
+func readonlyFromEnv() bool {
+	on, err := strconv.ParseBool(strings.TrimSpace(os.Getenv("BD_READONLY")))
+	return err == nil && on
+}
+
+// readonlyCommandAllowed reports whether cmd may run in read-only mode.
+func readonlyCommandAllowed(cmd *cobra.Command) bool {
+	path := readonlyCommandPath(cmd)
+	return readonlyAllowedCommands[path] || strings.HasPrefix(path, "completion ")
+}
+
+// enforceReadonlyCommand refuses commands outside the read-only allowlist
+// when read-only mode (--readonly, --read-only, BD_READONLY=1, or readonly:
+// true in config.yaml) is on.
+func enforceReadonlyCommand(cmd *cobra.Command) error {
+	if !readonlyMode || readonlyCommandAllowed(cmd) {
+		return nil
+	}
+	if atCheckpoint != "" {
+		return fmt.Errorf("operation '%s' cannot run --at a checkpoint; only read commands can", readonlyCommandPath(cmd))
+	}
+	return fmt.Errorf("operation '%s' is not allowed in read-only mode", readonlyCommandPath(cmd))
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
 )
 
 // TestReadonlyModeBlocksWrites verifies that --readonly blocks write operations
@@ -155,3 +157,128 @@ type capture struct {
 func (c *capture) Write(p []byte) (n int, err error) {
 	return c.buf.Write(p)
 }
+
+// TestEnforceReadonlyCommand verifies the dispatch-layer allowlist fails closed.
+func TestEnforceReadonlyCommand(t *testing.T) {
+	originalMode := readonlyMode
+	defer func() { readonlyMode = originalMode }()
+
+	tests := []struct {
+		args    []string
+		allowed bool
+	}{
+		{[]string{"list"}, true},
+		{[]string{"show"}, true},
+		{[]string{"dep", "tree"}, true},
+		{[]string{"comments"}, true},
+		{[]string{"blame"}, true},
+		{[]string{"branch", "list"}, true},
+		{[]string{"towns", "list"}, true},
+		{[]string{"queue", "list"}, true},
+		{[]string{"branch", "create"}, false},
+		{[]string{"queue", "drop"}, false},
+		{[]string{"create"}, false},
+		{[]string{"comments", "add"}, false},
+		{[]string{"dep", "add"}, false},
+		{[]string{"config", "set"}, false},
+		{[]string{"init"}, false},
+	}
+	for _, tc := range tests {
+		cmd, _, err := rootCmd.Find(tc.args)
+		if err != nil {
+			t.Fatalf("find %v: %v", tc.args, err)
+		}
+		readonlyMode = false
+		if err := enforceReadonlyCommand(cmd); err != nil {
+			t.Errorf("%v: refused outside read-only mode: %v", tc.args, err)
+		}
+		readonlyMode = true
+		err = enforceReadonlyCommand(cmd)
+		if tc.allowed != (err == nil) {
+			t.Errorf("%v: err = %v, want allowed=%v", tc.args, err, tc.allowed)
+		}
+		if err != nil && !strings.Contains(err.Error(), "is not allowed in read-only mode") {
+			t.Errorf("%v: unexpected message %q", tc.args, err)
+		}
+	}
+}
+
+// TestReadOnlyFlagAlias verifies --read-only is accepted as --readonly.
+func TestReadOnlyFlagAlias(t *testing.T) {
+	if got := normalizeReadonlyFlag(nil, "read-only"); got != "readonly" {
+		t.Errorf("normalize(read-only) = %q", got)
+	}
+	if got := normalizeReadonlyFlag(nil, "dry-run"); got != "dry-run" {
+		t.Errorf("normalize(dry-run) = %q", got)
+	}
+}
+
+func TestReadonlyFromEnv(t *testing.T) {
+	for _, tc := range []struct {
+		val  string
+		want bool
+	}{
+		{"", false},
+		{"1", true},
+		{"true", true},
+		{"0", false},
+		{"false", false},
+		{"bogus", false},
+	} {
+		t.Setenv("BD_READONLY", tc.val)
+		if got := readonlyFromEnv(); got != tc.want {
+			t.Errorf("BD_READONLY=%q: got %v, want %v", tc.val, got, tc.want)
+		}
+	}
+}
+
+// readonlyReadVerbs are the subcommand names that mean a query by
+// convention ("dep list", "kv get", "federation verify").
+var readonlyReadVerbs = map[string]bool{
+	"list": true, "list-all": true, "show": true, "get": true, "search": true,
+	"query": true, "status": true, "stats": true, "info": true, "tree": true,
+	"cycles": true, "timeline": true, "kinds": true, "current": true,
+	"progress": true, "report": true, "health": true, "check": true,
+	"verify": true, "validate": true, "schema": true,
+}
+
+// readonlyWritingReads are commands named like queries that write, and so
+// stay out of the read-only allowlist on purpose.
+var readonlyWritingReads = map[string]string{
+	"gate check":     "closes the gates it finds resolved",
+	"migrate schema": "applies schema migrations",
+	"validate":       "records a validation verdict on an issue",
+}
+
+// TestReadonlyAllowlistCoversReadCommands walks the command tree so a new
+// query command cannot be left out of the allowlist unnoticed: every
+// command named like a query must be allowed in read-only mode or listed in
+// readonlyWritingReads.
+func TestReadonlyAllowlistCoversReadCommands(t *testing.T) {
+	// Top-level queries have no telltale name; check them by path.
+	for _, path := range []string{"list", "show", "ready", "my-work", "standup", "more"} {
+		if !readonlyAllowedCommands[path] {
+			t.Errorf("%q is not allowed in read-only mode", path)
+		}
+	}
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			walk(sub)
+			if !sub.Runnable() || !readonlyReadVerbs[sub.Name()] {
+				continue
+			}
+			path := readonlyCommandPath(sub)
+			_, excluded := readonlyWritingReads[path]
+			if readonlyAllowedCommands[path] == excluded {
+				if excluded {
+					t.Errorf("%q is both allowed in read-only mode and excluded as a write", path)
+				} else {
+					t.Errorf("%q looks like a query but is not in readonlyAllowedCommands; allow it or add it to readonlyWritingReads", path)
+				}
+			}
+		}
+	}
+	walk(rootCmd)
+}
@@ -35,6 +35,13 @@ Use --gated to find molecules ready for gate-resume dispatch:
 Use --claim to atomically claim the first ready issue matching the filters:
   bd ready --claim --json
 
+Use --lane to pull from one lane's queue (see bd lane --help):
+  bd ready --lane backend --claim
+
+Use --sort score to order by custom scoring in wasm modules (see bd plugin --help).
+With --json, each issue's score is reported as annotations.score:
+  bd ready --sort score -n 5
+
 This is useful for agents executing molecules to see which steps can run next.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -47,6 +54,12 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		}()
 
 		if usesProxiedServer() {
+			if lane, _ := cmd.Flags().GetString("lane"); lane != "" {
+				return HandleErrorRespectJSON("--lane is not supported under --proxied-server")
+			}
+			if sortPolicy, _ := cmd.Flags().GetString("sort"); sortPolicy == sortPolicyScore {
+				return HandleErrorRespectJSON("--sort score is not supported under --proxied-server")
+			}
 			return runReadyProxiedServer(cmd, rootCtx)
 		}
 
@@ -110,13 +123,30 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			return HandleErrorRespectJSON("--claim cannot be combined with --assignee")
 		}
 
+		// Wasm scoring needs the whole ready set; the limit applies after
+		// sorting, and priority order breaks ties.
+		scoreSort := sortPolicy == sortPolicyScore
+		scoreLimit := limit
+		if scoreSort {
+			if claimReady {
+				return HandleErrorRespectJSON("--claim cannot be combined with --sort score")
+			}
+			sortPolicy = string(types.SortPolicyPriority)
+			limit = 0
+		}
+
 		// Normalize labels: trim, dedupe, remove empty
 		labels = utils.NormalizeLabels(labels)
 		labelsAny = utils.NormalizeLabels(labelsAny)
 		excludeLabels = utils.NormalizeLabels(excludeLabels)
 
+		lane, _ := cmd.Flags().GetString("lane")
+		if lane != "" && len(labelsAny) > 0 {
+			return HandleErrorRespectJSON("--lane cannot be combined with --label-any")
+		}
+
 		// Apply directory-aware label scoping if no labels explicitly provided (GH#541)
-		if len(labels) == 0 && len(labelsAny) == 0 {
+		if len(labels) == 0 && len(labelsAny) == 0 && lane == "" {
 			if dirLabels := config.GetDirectoryLabels(); len(dirLabels) > 0 {
 				labelsAny = dirLabels
 			}
@@ -144,6 +174,7 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			IncludeDeferred:  includeDeferred,  // GH#820: respect --include-deferred flag
 			IncludeEphemeral: includeEphemeral, // bd-i5k5x: allow ephemeral issues (e.g., merge-requests)
 			ExcludeTypes:     excludeTypes,
+			Rig:              rigScope,
 		}
 		// Use Changed() to properly handle P0 (priority=0)
 		if cmd.Flags().Changed("priority") {
@@ -184,7 +215,7 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		}
 
 		if !filter.SortPolicy.IsValid() {
-			return HandleErrorRespectJSON("invalid sort policy '%s'. Valid values: hybrid, priority, oldest", sortPolicy)
+			return HandleErrorRespectJSON("invalid sort policy '%s'. Valid values: hybrid, priority, oldest, score", sortPolicy)
 		}
 		ctx := rootCtx
 
@@ -202,7 +233,26 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			}
 		}
 
+		if lane != "" {
+			atLimit, err := applyReadyLane(ctx, activeStore, &filter, lane)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			if atLimit {
+				if jsonOutput {
+					return outputJSON([]*types.IssueWithCounts{})
+				}
+				fmt.Printf("\n%s No ready work in lane %s until in-progress work finishes\n\n", ui.RenderWarn("○"), lane)
+				return nil
+			}
+		}
+
 		if claimReady {
+			overBudget, err := overBudgetReadyIDs(ctx, activeStore, filter)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			filter.ExcludeIDs = append(filter.ExcludeIDs, overBudget...)
 			claimed, err := activeStore.ClaimReadyIssue(ctx, filter, actor)
 			if err != nil {
 				return HandleErrorRespectJSON("%v", err)
@@ -214,6 +264,15 @@ This is useful for agents executing molecules to see which steps can run next.`,
 				fmt.Printf("\n%s No ready work to claim\n\n", ui.RenderWarn("○"))
 				return nil
 			}
+			// Over-budget issues were left out above; the check is repeated
+			// after the atomic claim in case spend was recorded in between,
+			// and a blocked claim is released again.
+			if err := checkClaimBudget(ctx, activeStore, claimed.ID); err != nil {
+				if uerr := activeStore.UnclaimIssueIfAssignee(ctx, claimed.ID, actor, actor); uerr != nil {
+					return HandleErrorRespectJSON("%v (releasing the claim also failed: %v)", err, uerr)
+				}
+				return HandleErrorRespectJSON("%v", err)
+			}
 			if err := commitPendingIfEmbedded(ctx, activeStore, actor, doltAutoCommitParams{
 				Command:  "ready",
 				IssueIDs: []string{claimed.ID},
@@ -258,6 +317,15 @@ This is useful for agents executing molecules to see which steps can run next.`,
 					}
 				}
 			}
+			if scoreSort {
+				if err := sortByWasmScore(results, func(r *types.IssueWithCounts) *types.Issue { return r.Issue }); err != nil {
+					return HandleErrorRespectJSON("%v", err)
+				}
+				if scoreLimit > 0 && len(results) > scoreLimit {
+					totalReady, truncated = len(results), true
+					results = results[:scoreLimit]
+				}
+			}
 			if results == nil {
 				results = []*types.IssueWithCounts{}
 			}
@@ -286,6 +354,15 @@ This is useful for agents executing molecules to see which steps can run next.`,
 				truncated = true
 			}
 		}
+		if scoreSort {
+			if err := sortByWasmScore(issues, func(i *types.Issue) *types.Issue { return i }); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			if scoreLimit > 0 && len(issues) > scoreLimit {
+				totalReady, truncated = len(issues), true
+				issues = issues[:scoreLimit]
+			}
+		}
 		maybeShowUpgradeNotification()
 
 		if len(issues) == 0 {
@@ -750,7 +827,7 @@ func init() {
 	readyCmd.Flags().IntP("priority", "p", 0, "Filter by priority")
 	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	readyCmd.Flags().BoolP("unassigned", "u", false, "Show only unassigned issues")
-	readyCmd.Flags().StringP("sort", "s", "priority", "Sort policy: priority (default), hybrid, oldest")
+	readyCmd.Flags().StringP("sort", "s", "priority", "Sort policy: priority (default), hybrid, oldest, score (wasm bd_score hooks in .beads/plugins)")
 	readyCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	readyCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
 	readyCmd.Flags().StringSlice("exclude-label", []string{}, "Exclude issues that have ANY of these labels")
@@ -766,6 +843,7 @@ func init() {
 	readyCmd.Flags().StringSlice("exclude-type", nil, "Exclude issue types from results (comma-separated or repeatable, e.g., --exclude-type=convoy,epic)")
 	readyCmd.Flags().Bool("explain", false, "Show dependency-aware reasoning for why issues are ready or blocked")
 	readyCmd.Flags().Bool("claim", false, "Atomically claim the first ready issue matching the filters")
+	readyCmd.Flags().String("lane", "", "Show only this lane's queue; holds new work while the lane is at its WIP limit")
 	// Metadata filtering (GH#1406)
 	readyCmd.Flags().StringArray("metadata-field", nil, "Filter by metadata field (key=value, repeatable)")
 	readyCmd.Flags().String("has-metadata-key", "", "Filter issues that have this metadata key set")
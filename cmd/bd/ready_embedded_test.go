@@ -155,6 +155,33 @@ func TestEmbeddedReady(t *testing.T) {
 		}
 	})
 
+	t.Run("ready_claim_skips_over_budget", func(t *testing.T) {
+		bdir, _, _ := bdInit(t, bd, "--prefix", "rb")
+		bdConfig(t, bd, bdir, "set", "cost.budget-mode", "block")
+		spent := bdCreate(t, bd, bdir, "Over budget", "--type", "task", "--priority", "0")
+		within := bdCreate(t, bd, bdir, "Within budget", "--type", "task", "--priority", "2")
+		bdCommand(t, bd, bdir, "cost", "budget", spent.ID, "--usd", "1")
+		bdCommand(t, bd, bdir, "cost", "add", spent.ID, "--usd", "2")
+
+		cmd := exec.Command(bd, "ready", "--claim", "--json")
+		cmd.Dir = bdir
+		cmd.Env = bdEnv(bdir)
+		stdout, stderr, err := runCommandBuffers(t, cmd)
+		if err != nil {
+			t.Fatalf("bd ready --claim failed: %v\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
+		}
+		var claimed []types.IssueWithCounts
+		if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &claimed); err != nil {
+			t.Fatalf("parse claim JSON: %v\n%s", err, stdout.String())
+		}
+		if len(claimed) != 1 || claimed[0].ID != within.ID {
+			t.Fatalf("claimed %s, want only %s (the higher-priority issue is over budget)", stdout.String(), within.ID)
+		}
+		if got := bdShow(t, bd, bdir, spent.ID); got.Status != types.StatusOpen || got.Assignee != "" {
+			t.Errorf("over-budget issue = %s/%q, want it left open and unassigned", got.Status, got.Assignee)
+		}
+	})
+
 	// ===== With Blockers =====
 
 	t.Run("ready_excludes_blocked", func(t *testing.T) {
@@ -125,6 +125,7 @@ func gatherReadyInput(cmd *cobra.Command) (readyInput, error) {
 		IncludeDeferred:  includeDeferred,
 		IncludeEphemeral: includeEphemeral,
 		ExcludeTypes:     excludeTypes,
+		Rig:              rigScope,
 	}
 	if cmd.Flags().Changed("priority") {
 		priority, _ := cmd.Flags().GetInt("priority")
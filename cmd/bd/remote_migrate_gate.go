@@ -19,7 +19,7 @@ import (
 // migrator" precondition and annotated with its risk, so the agent surfaces a
 // human decision instead of auto-running it.
 func handleRemoteMigrateGateJSON(e *schema.RemoteMigrateGateError) {
-	outer := buildJSONError(e.Error(), e.AgentDirective())
+	outer := buildJSONError(e.Error(), e.AgentDirective(), ErrGeneral)
 	if m, ok := outer.(map[string]interface{}); ok {
 		opts := make([]map[string]interface{}, 0, len(e.Options()))
 		for _, o := range e.Options() {
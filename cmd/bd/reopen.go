@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -16,7 +17,19 @@ var reopenCmd = &cobra.Command{
 	GroupID: "issues",
 	Short:   "Reopen one or more closed issues",
 	Long: `Reopen closed issues by setting status to 'open' and clearing the closed_at timestamp.
-This is more explicit than 'bd update --status open' and emits a Reopened event.`,
+This is more explicit than 'bd update --status open' and emits a Reopened event.
+
+Every reopen, including one through 'bd update --status open', is logged in
+metadata.reopens (when, who, why, and the close reason it discarded), with
+the total in metadata.reopen_count. Find
+repeatedly reopened issues with 'bd list --reopened' or 'bd list --min-reopens N';
+'bd status' reports the reopen rate.
+
+Set reopen.root-cause-threshold in config.yaml to require a root-cause note
+(--root-cause) when an issue is reopened more than that many times:
+
+  reopen:
+    root-cause-threshold: 2`,
 	Args:          cobra.MinimumNArgs(1),
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -35,7 +48,8 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 		}
 
 		reason, _ := cmd.Flags().GetString("reason")
-		ctx := rootCtx
+		rootCause, _ := cmd.Flags().GetString("root-cause")
+		ctx := storage.WithReopenNote(rootCtx, storage.ReopenNote{RootCause: rootCause})
 
 		reopenedIssues := []*types.Issue{}
 		hasError := false
@@ -62,12 +76,13 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 				continue
 			}
 			if err := issueStore.ReopenIssue(ctx, fullID, reason, actor); err != nil {
-				fmt.Fprintf(os.Stderr, "Error reopening %s: %v\n", fullID, err)
+				fmt.Fprintln(os.Stderr, reopenErrorMessage(fullID, err))
 				hasError = true
 				result.Close()
 				continue
 			}
 			mutatedStores[issueStore] = append(mutatedStores[issueStore], fullID)
+			refreshQualityScoreOnEvent(ctx, issueStore, fullID, "reopen")
 			pendingCloseResults = append(pendingCloseResults, result)
 			if jsonOutput {
 				updated, _ := issueStore.GetIssue(ctx, fullID)
@@ -111,8 +126,18 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 	},
 }
 
+// reopenErrorMessage describes a failed reopen, naming --root-cause when
+// reopen.root-cause-threshold refused it.
+func reopenErrorMessage(id string, err error) string {
+	if errors.Is(err, storage.ErrRootCauseRequired) {
+		return fmt.Sprintf("cannot reopen %s: %v; add a note with --root-cause", id, err)
+	}
+	return fmt.Sprintf("Error reopening %s: %v", id, err)
+}
+
 func init() {
 	reopenCmd.Flags().StringP("reason", "r", "", "Reason for reopening")
+	reopenCmd.Flags().String("root-cause", "", "Root-cause note, required past reopen.root-cause-threshold")
 	reopenCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(reopenCmd)
 }
@@ -3,9 +3,11 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -139,6 +141,57 @@ func TestEmbeddedReopen(t *testing.T) {
 		}
 	})
 
+	t.Run("reopen_tracked_in_metadata", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Flaky fix", "--type", "bug")
+		bdClose(t, bd, dir, issue.ID, "--reason", "first fix")
+		bdReopen(t, bd, dir, issue.ID, "--reason", "still broken")
+		bdClose(t, bd, dir, issue.ID, "--reason", "second fix")
+		bdReopen(t, bd, dir, issue.ID)
+
+		got := bdShow(t, bd, dir, issue.ID)
+		reopens, err := types.ReopensFromMetadata(got.Metadata)
+		if err != nil {
+			t.Fatalf("ReopensFromMetadata: %v", err)
+		}
+		if len(reopens) != 2 {
+			t.Fatalf("expected 2 reopens, got %d: %s", len(reopens), got.Metadata)
+		}
+		if reopens[0].Reason != "still broken" || reopens[0].PrevCloseReason != "first fix" {
+			t.Errorf("first reopen = %+v", reopens[0])
+		}
+		var meta map[string]any
+		if err := json.Unmarshal(got.Metadata, &meta); err != nil {
+			t.Fatalf("parsing metadata: %v", err)
+		}
+		if meta[types.ReopenCountMetadataKey] != float64(2) {
+			t.Errorf("expected reopen_count 2 in metadata: %s", got.Metadata)
+		}
+
+		out := bdList(t, bd, dir, "--min-reopens", "2", "--flat")
+		if !strings.Contains(out, issue.ID) {
+			t.Errorf("expected %s in --min-reopens 2 output: %s", issue.ID, out)
+		}
+		out = bdList(t, bd, dir, "--min-reopens", "3", "--flat")
+		if strings.Contains(out, issue.ID) {
+			t.Errorf("did not expect %s in --min-reopens 3 output: %s", issue.ID, out)
+		}
+	})
+
+	t.Run("reopen_via_update_tracked", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Reopened by update", "--type", "bug")
+		bdClose(t, bd, dir, issue.ID, "--reason", "fixed")
+		bdUpdate(t, bd, dir, issue.ID, "--status", "open")
+
+		got := bdShow(t, bd, dir, issue.ID)
+		reopens, err := types.ReopensFromMetadata(got.Metadata)
+		if err != nil {
+			t.Fatalf("ReopensFromMetadata: %v", err)
+		}
+		if len(reopens) != 1 || reopens[0].PrevCloseReason != "fixed" {
+			t.Fatalf("expected one logged reopen discarding %q, got %s", "fixed", got.Metadata)
+		}
+	})
+
 	t.Run("reopen_nonexistent", func(t *testing.T) {
 		cmd := exec.Command(bd, "reopen", "ro-nonexistent999")
 		cmd.Dir = dir
@@ -150,6 +203,52 @@ func TestEmbeddedReopen(t *testing.T) {
 	})
 }
 
+// TestEmbeddedReopenRootCauseThreshold verifies that
+// reopen.root-cause-threshold counts reopens made by any command and is
+// enforced on bd update --status open as well as bd reopen.
+func TestEmbeddedReopenRootCauseThreshold(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "rc")
+	cfg, err := os.OpenFile(filepath.Join(beadsDir, "config.yaml"), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open config.yaml: %v", err)
+	}
+	if _, err := cfg.WriteString("\nreopen:\n  root-cause-threshold: 1\n"); err != nil {
+		t.Fatalf("write config.yaml: %v", err)
+	}
+	_ = cfg.Close()
+
+	issue := bdCreate(t, bd, dir, "Keeps coming back", "--type", "bug")
+	bdClose(t, bd, dir, issue.ID)
+	bdUpdate(t, bd, dir, issue.ID, "--status", "open")
+	bdClose(t, bd, dir, issue.ID)
+
+	out := bdUpdateFail(t, bd, dir, issue.ID, "--status", "open")
+	if !strings.Contains(out, "root-cause note required") {
+		t.Errorf("expected the root-cause refusal from update, got:\n%s", out)
+	}
+	cmd := exec.Command(bd, "reopen", issue.ID)
+	cmd.Dir = dir
+	cmd.Env = bdEnv(dir)
+	if out, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(out), "--root-cause") {
+		t.Errorf("expected reopen without --root-cause to fail, got err=%v:\n%s", err, out)
+	}
+
+	bdReopen(t, bd, dir, issue.ID, "--root-cause", "race in the retry loop")
+	reopens, err := types.ReopensFromMetadata(bdShow(t, bd, dir, issue.ID).Metadata)
+	if err != nil {
+		t.Fatalf("ReopensFromMetadata: %v", err)
+	}
+	if len(reopens) != 2 || reopens[1].RootCause != "race in the retry loop" {
+		t.Errorf("expected the second reopen to carry the root cause, got %+v", reopens)
+	}
+}
+
 // TestEmbeddedReopenConcurrent exercises reopen concurrently.
 func TestEmbeddedReopenConcurrent(t *testing.T) {
 	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
@@ -36,6 +36,7 @@ func runReopenProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 		return HandleErrorRespectJSON("no issue ID provided")
 	}
 	reason, _ := cmd.Flags().GetString("reason")
+	rootCause, _ := cmd.Flags().GetString("root-cause")
 	jsonOut, _ := cmd.Flags().GetBool("json")
 
 	if uowProvider == nil {
@@ -46,7 +47,7 @@ func runReopenProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 		var result reopenProxiedTxResult
 
 		for _, id := range args {
-			outcome, ok := reopenProxiedOne(ctx, uw, id, reason, &result.errors)
+			outcome, ok := reopenProxiedOne(ctx, uw, id, reason, rootCause, &result.errors)
 			if !ok {
 				result.hasError = true
 				continue
@@ -100,7 +101,7 @@ func runReopenProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 	return nil
 }
 
-func reopenProxiedOne(ctx context.Context, uw uow.UnitOfWork, id, reason string, errors *[]string) (reopenProxiedOutcome, bool) {
+func reopenProxiedOne(ctx context.Context, uw uow.UnitOfWork, id, reason, rootCause string, errors *[]string) (reopenProxiedOutcome, bool) {
 	current, isWisp := proxiedResolveIssueOrWisp(ctx, uw, id)
 	if current == nil {
 		*errors = append(*errors, fmt.Sprintf("Issue %s not found", id))
@@ -111,7 +112,7 @@ func reopenProxiedOne(ctx context.Context, uw uow.UnitOfWork, id, reason string,
 		return reopenProxiedOutcome{id: id, before: current, after: current, reopened: false}, true
 	}
 
-	params := domain.ReopenIssueParams{Reason: reason}
+	params := domain.ReopenIssueParams{Reason: reason, RootCause: rootCause}
 	var (
 		res domain.ReopenIssueResult
 		err error
@@ -122,7 +123,7 @@ func reopenProxiedOne(ctx context.Context, uw uow.UnitOfWork, id, reason string,
 		res, err = uw.IssueUseCase().ReopenIssue(ctx, id, params, actor)
 	}
 	if err != nil {
-		*errors = append(*errors, fmt.Sprintf("Error reopening %s: %v", id, err))
+		*errors = append(*errors, reopenErrorMessage(id, err))
 		return reopenProxiedOutcome{}, false
 	}
 
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:     "review",
+	GroupID: "issues",
+	Short:   "Human review queue for issues closed by agents",
+	Long: `Review issues that agents closed before they count as done.
+
+When review is enabled, an issue closed by an agent stays closed (so the
+agent can move on) but enters the review queue with metadata.review_state
+"pending". A human then approves it, or rejects it and optionally reopens
+it with the rejection as the reopen reason. Only humans can approve or
+reject: the command refuses when the current actor is an agent.
+
+Until it is approved, a pending issue is not done. Its dependents stay
+blocked and out of bd ready, an epic waiting on it is not eligible to close,
+and bd status counts it as In Review rather than Closed.
+
+A close counts as an agent's when it runs in agent mode (BD_AGENT_MODE=1
+or CLAUDE_CODE) or the actor matches a review.agents pattern. Policies in
+config.yaml select which issues enter review; every configured selector
+must match:
+
+  review:
+    enabled: true
+    agents: "claude-*,codex-*"   # actors that are agents (glob patterns)
+    labels: "security,customer"  # only issues with one of these labels
+    priority: 1                  # only P0 and P1
+
+Examples:
+  bd review list
+  bd review approve bd-42 --comment "Verified on staging"
+  bd review reject bd-42 --reason "Tests were skipped" --reopen
+  bd list --review pending`,
+}
+
+var reviewListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List issues waiting for review",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		state, _ := cmd.Flags().GetString("state")
+		switch state {
+		case types.ReviewPending, types.ReviewApproved, types.ReviewRejected:
+		default:
+			return HandleErrorRespectJSON("invalid --state %q (valid: pending, approved, rejected)", state)
+		}
+
+		ctx := rootCtx
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
+			MetadataFields: map[string]string{types.ReviewStateMetadataKey: state},
+		})
+		if err != nil {
+			return HandleErrorRespectJSON("listing reviews: %v", err)
+		}
+		items := make([]reviewListItem, 0, len(issues))
+		for _, issue := range issues {
+			r, err := types.ReviewFromMetadata(issue.Metadata)
+			if err != nil || r == nil {
+				continue
+			}
+			items = append(items, reviewListItem{ID: issue.ID, Title: issue.Title, Status: issue.Status, Priority: issue.Priority, Review: *r})
+		}
+
+		if jsonOutput {
+			return outputJSON(items)
+		}
+		if len(items) == 0 {
+			fmt.Printf("No issues %s review\n", map[string]string{
+				types.ReviewPending:  "waiting for",
+				types.ReviewApproved: "approved in",
+				types.ReviewRejected: "rejected in",
+			}[state])
+			return nil
+		}
+		for _, item := range items {
+			line := fmt.Sprintf("%s [P%d] %s — closed by %s %s", ui.RenderID(item.ID), item.Priority, item.Title,
+				item.Review.ClosedBy, item.Review.RequestedAt.Local().Format("2006-01-02 15:04"))
+			if item.Review.Reviewer != "" {
+				line += ", reviewed by " + item.Review.Reviewer
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+var reviewApproveCmd = &cobra.Command{
+	Use:           "approve <id>...",
+	Short:         "Sign off on issues an agent closed",
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		comment, _ := cmd.Flags().GetString("comment")
+		return runReviewDecision("review approve", args, types.ReviewApproved, comment, false)
+	},
+}
+
+var reviewRejectCmd = &cobra.Command{
+	Use:   "reject <id>...",
+	Short: "Turn down issues an agent closed",
+	Long: `Reject issues in the review queue. With --reopen the issues are reopened
+with the rejection reason, so the work goes back to the ready queue.`,
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reason, _ := cmd.Flags().GetString("reason")
+		if strings.TrimSpace(reason) == "" {
+			return HandleErrorRespectJSON("--reason is required when rejecting")
+		}
+		reopen, _ := cmd.Flags().GetBool("reopen")
+		return runReviewDecision("review reject", args, types.ReviewRejected, reason, reopen)
+	},
+}
+
+// reviewListItem is the JSON shape for review output.
+type reviewListItem struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Status   types.Status `json:"status"`
+	Priority int          `json:"priority"`
+	Reopened bool         `json:"reopened,omitempty"`
+	Review   types.Review `json:"review"`
+}
+
+func runReviewDecision(command string, args []string, state, comment string, reopen bool) error {
+	CheckReadonly(command)
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("review is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("review")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("database not available: %v", err)
+	}
+	if isAgentActor(loadReviewPolicy(), actor) {
+		return HandleErrorRespectJSON("%s must be done by a human; %q is an agent", command, actor)
+	}
+
+	ctx := rootCtx
+	var results []reviewListItem
+	var failed []string
+	for _, arg := range args {
+		item, err := decideReview(ctx, store, arg, state, comment, reopen)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", ui.RenderFail("✗"), err)
+			failed = append(failed, arg)
+			continue
+		}
+		results = append(results, *item)
+		SetLastTouchedID(item.ID)
+	}
+	if len(results) > 0 {
+		commandDidWrite.Store(true)
+	}
+
+	if jsonOutput {
+		if err := outputJSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, item := range results {
+			verb := "Approved"
+			if state == types.ReviewRejected {
+				verb = "Rejected"
+				if item.Reopened {
+					verb = "Rejected and reopened"
+				}
+			}
+			fmt.Printf("%s %s %s\n", ui.RenderPass("✓"), verb, formatFeedbackID(item.ID, item.Title))
+		}
+	}
+	if len(failed) > 0 {
+		return SilentExit()
+	}
+	return nil
+}
+
+// decideReview records a human decision on an issue in the review queue.
+func decideReview(ctx context.Context, st storage.DoltStorage, ref, state, comment string, reopen bool) (*reviewListItem, error) {
+	id, err := utils.ResolvePartialID(ctx, st, ref)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", ref, err)
+	}
+	issue, err := st.GetIssue(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("issue %s not found: %w", id, err)
+	}
+	r, err := types.ReviewFromMetadata(issue.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", id, err)
+	}
+	if r == nil || r.State != types.ReviewPending {
+		return nil, fmt.Errorf("%s is not waiting for review", id)
+	}
+
+	now := storage.Now(ctx)
+	r.State = state
+	r.Reviewer = actor
+	r.DecidedAt = &now
+	r.Comment = comment
+	if err := saveReview(ctx, st, id, r); err != nil {
+		return nil, err
+	}
+
+	item := &reviewListItem{ID: id, Title: issue.Title, Status: issue.Status, Priority: issue.Priority, Review: *r}
+	if reopen && issue.Status == types.StatusClosed {
+		if err := st.ReopenIssue(ctx, id, "Review rejected: "+comment, actor); err != nil {
+			return nil, fmt.Errorf("reopening %s: %w", id, err)
+		}
+		item.Status = types.StatusOpen
+		item.Reopened = true
+	}
+	return item, nil
+}
+
+func saveReview(ctx context.Context, st storage.DoltStorage, id string, r *types.Review) error {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encoding review: %w", err)
+	}
+	if err := st.MergeMetadata(ctx, id, types.ReviewMetadataKey, raw, actor); err != nil {
+		return fmt.Errorf("saving review of %s: %w", id, err)
+	}
+	rawState, _ := json.Marshal(r.State)
+	if err := st.MergeMetadata(ctx, id, types.ReviewStateMetadataKey, rawState, actor); err != nil {
+		return fmt.Errorf("saving review state of %s: %w", id, err)
+	}
+	return nil
+}
+
+// loadReviewPolicy reads the review.* settings from config.yaml.
+func loadReviewPolicy() *types.ReviewPolicy {
+	p := &types.ReviewPolicy{
+		Enabled: config.GetBool("review.enabled"),
+		Agents:  splitConfigList(config.GetString("review.agents")),
+		Labels:  splitConfigList(config.GetString("review.labels")),
+	}
+	if raw := strings.TrimSpace(config.GetString("review.priority")); raw != "" {
+		if prio := validation.ParsePriority(raw); prio >= 0 {
+			p.MaxPriority = &prio
+		}
+	}
+	return p
+}
+
+func splitConfigList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// isAgentActor reports whether name acts as an agent: the command runs in
+// agent mode, or the name matches a review.agents pattern.
+func isAgentActor(p *types.ReviewPolicy, name string) bool {
+	return ui.IsAgentMode() || p.IsAgent(name)
+}
+
+// enterReviewOnClose puts an issue an agent just closed into the review
+// queue when the review policy selects it. It reports whether it did.
+func enterReviewOnClose(ctx context.Context, st storage.DoltStorage, issue *types.Issue, closedBy string) bool {
+	p := loadReviewPolicy()
+	if issue == nil || !p.Enabled || !isAgentActor(p, closedBy) {
+		return false
+	}
+	if len(p.Labels) > 0 && len(issue.Labels) == 0 {
+		if labels, err := st.GetLabels(ctx, issue.ID); err == nil {
+			issue.Labels = labels
+		}
+	}
+	if !p.Selects(issue) {
+		return false
+	}
+	r := &types.Review{State: types.ReviewPending, ClosedBy: closedBy, RequestedAt: storage.Now(ctx)}
+	if err := saveReview(ctx, st, issue.ID, r); err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", ui.RenderWarn("⚠"), err)
+		return false
+	}
+	return true
+}
+
+func init() {
+	reviewListCmd.Flags().String("state", types.ReviewPending, "Review state to list: pending, approved, rejected")
+	reviewApproveCmd.Flags().String("comment", "", "Approval comment")
+	reviewRejectCmd.Flags().String("reason", "", "Why the work was rejected (required)")
+	reviewRejectCmd.Flags().Bool("reopen", false, "Reopen the issue so the work is picked up again")
+
+	reviewApproveCmd.ValidArgsFunction = issueIDCompletion
+	reviewRejectCmd.ValidArgsFunction = issueIDCompletion
+	reviewCmd.AddCommand(reviewListCmd, reviewApproveCmd, reviewRejectCmd)
+	rootCmd.AddCommand(reviewCmd)
+}
@@ -0,0 +1,52 @@
+//go:build cgo
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedReviewPendingKeepsDependentsBlocked(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "rv")
+	cfg, err := os.OpenFile(filepath.Join(beadsDir, "config.yaml"), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open config.yaml: %v", err)
+	}
+	if _, err := cfg.WriteString("\nreview:\n  enabled: true\n  agents: \"bot-*\"\n"); err != nil {
+		t.Fatalf("write config.yaml: %v", err)
+	}
+	_ = cfg.Close()
+
+	blocker := bdCreate(t, bd, dir, "Agent work", "--type", "task")
+	dependent := bdCreate(t, bd, dir, "Follow-on work", "--type", "task")
+	bdDepAdd(t, bd, dir, dependent.ID, blocker.ID)
+
+	ready := func() string { return bdCommand(t, bd, dir, "ready", "--json") }
+
+	// An agent close sends the blocker to review; it is not done yet.
+	out := bdClose(t, bd, dir, blocker.ID, "--actor", "bot-1")
+	if !strings.Contains(out, "waiting for human review") {
+		t.Fatalf("agent close did not enter review:\n%s", out)
+	}
+	if out := ready(); strings.Contains(out, dependent.ID) {
+		t.Errorf("%s is ready while %s is still in review:\n%s", dependent.ID, blocker.ID, out)
+	}
+	if out := bdCommand(t, bd, dir, "blocked", "--json"); !strings.Contains(out, dependent.ID) {
+		t.Errorf("%s not listed as blocked while %s is in review:\n%s", dependent.ID, blocker.ID, out)
+	}
+
+	// Approval finishes the work and releases the dependent.
+	bdCommand(t, bd, dir, "review", "approve", blocker.ID, "--actor", "alice")
+	if out := ready(); !strings.Contains(out, dependent.ID) {
+		t.Errorf("%s not ready after %s was approved:\n%s", dependent.ID, blocker.ID, out)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestLoadReviewPolicy(t *testing.T) {
+	initConfigForTest(t)
+	t.Setenv("BD_AGENT_MODE", "")
+	t.Setenv("CLAUDE_CODE", "")
+
+	if p := loadReviewPolicy(); p.Enabled || p.MaxPriority != nil {
+		t.Fatalf("default policy = %+v, want disabled", p)
+	}
+
+	config.Set("review.enabled", true)
+	config.Set("review.agents", "claude-*, codex-*")
+	config.Set("review.labels", "security")
+	config.Set("review.priority", "P1")
+	p := loadReviewPolicy()
+	if !p.Enabled || len(p.Agents) != 2 || p.Agents[1] != "codex-*" || p.MaxPriority == nil || *p.MaxPriority != 1 {
+		t.Fatalf("policy = %+v", p)
+	}
+	if !isAgentActor(p, "codex-3") || isAgentActor(p, "alice") {
+		t.Error("agent patterns not applied")
+	}
+
+	t.Setenv("BD_AGENT_MODE", "1")
+	if !isAgentActor(p, "alice") {
+		t.Error("agent mode should make any actor an agent")
+	}
+	if !p.Selects(&types.Issue{Priority: 0, Labels: []string{"security"}}) {
+		t.Error("policy should select a P0 security issue")
+	}
+}
@@ -0,0 +1,611 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// rigConfigPrefix namespaces rig registrations in the database config table.
+// Registrations are synced, so every clone in the fleet sees the same rigs.
+const rigConfigPrefix = "rigs."
+
+// validRigNameRegex matches rig names such as "gpu-west" or "build.linux".
+var validRigNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// Rig health states, from best to worst.
+const (
+	rigHealthIdle     = "idle"     // no agent has heartbeated in the rig
+	rigHealthHealthy  = "healthy"  // every agent is fresh and no lease has expired
+	rigHealthDegraded = "degraded" // some agents are stale or hold expired leases
+	rigHealthDown     = "down"     // no agent is fresh
+)
+
+// rigRecord is a registered rig.
+type rigRecord struct {
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	RegisteredBy string    `json:"registered_by,omitempty"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+// RigStats counts a rig's issues by status.
+type RigStats struct {
+	Rig        string         `json:"rig"`
+	Total      int            `json:"total"`
+	Ready      int            `json:"ready"`
+	ByStatus   map[string]int `json:"by_status"`
+	Unassigned int            `json:"unassigned"` // open issues with no assignee
+}
+
+// RigAgent rolls up one agent's heartbeats within a rig.
+type RigAgent struct {
+	Agent         string     `json:"agent"`
+	LastHeartbeat *time.Time `json:"last_heartbeat,omitempty"`
+	ActiveLeases  int        `json:"active_leases"`
+	ExpiredLeases int        `json:"expired_leases"`
+	Fresh         bool       `json:"fresh"` // heartbeated within the staleness window
+}
+
+// RigHealth rolls up agent heartbeats for one rig.
+type RigHealth struct {
+	Rig           string     `json:"rig"`
+	Status        string     `json:"status"`
+	Agents        []RigAgent `json:"agents"`
+	FreshAgents   int        `json:"fresh_agents"`
+	StaleAgents   int        `json:"stale_agents"`
+	ExpiredLeases int        `json:"expired_leases"`
+}
+
+// validateRigName checks that name can be used as a rig.
+func validateRigName(name string) error {
+	if len(name) > 255 {
+		return fmt.Errorf("invalid rig name %q: longer than 255 characters", name)
+	}
+	if !validRigNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid rig name %q: use lowercase letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// parseRigRecords extracts registered rigs from the config table, sorted by
+// name. Entries that do not decode are reported with only their name.
+func parseRigRecords(all map[string]string) []rigRecord {
+	var rigs []rigRecord
+	for key, value := range all {
+		name, ok := strings.CutPrefix(key, rigConfigPrefix)
+		if !ok || name == "" {
+			continue
+		}
+		var rec rigRecord
+		_ = json.Unmarshal([]byte(value), &rec)
+		rec.Name = name
+		rigs = append(rigs, rec)
+	}
+	slices.SortFunc(rigs, func(a, b rigRecord) int { return strings.Compare(a.Name, b.Name) })
+	return rigs
+}
+
+// loadRigs returns the registered rigs.
+func loadRigs() ([]rigRecord, error) {
+	all, err := store.GetAllConfig(rootCtx)
+	if err != nil {
+		return nil, fmt.Errorf("reading rigs: %w", err)
+	}
+	return parseRigRecords(all), nil
+}
+
+// requireRegisteredRig fails unless name is a registered rig, so a typo in
+// --rig does not silently stamp issues into a rig nobody watches.
+func requireRegisteredRig(name string) error {
+	value, err := store.GetConfig(rootCtx, rigConfigPrefix+name)
+	if err != nil {
+		return fmt.Errorf("reading rig %s: %w", name, err)
+	}
+	if value == "" {
+		return fmt.Errorf("rig %q is not registered (run 'bd rig register %s')", name, name)
+	}
+	return nil
+}
+
+// checkRigClaim refuses to claim an issue outside the --rig scope.
+func checkRigClaim(issue *types.Issue) error {
+	if rigScope == "" || issue.Rig == rigScope {
+		return nil
+	}
+	if issue.Rig == "" {
+		return fmt.Errorf("%s is not scoped to rig %s (assign it with 'bd rig assign %s %s')", issue.ID, rigScope, rigScope, issue.ID)
+	}
+	return fmt.Errorf("%s belongs to rig %s, not %s", issue.ID, issue.Rig, rigScope)
+}
+
+// buildRigStats counts a rig's issues by status. ready is the number of
+// ready issues, which the caller computes with blocker-aware semantics.
+func buildRigStats(rig string, issues []*types.Issue, ready int) RigStats {
+	stats := RigStats{Rig: rig, Ready: ready, ByStatus: make(map[string]int)}
+	for _, issue := range issues {
+		stats.Total++
+		stats.ByStatus[string(issue.Status)]++
+		if issue.Status == types.StatusOpen && issue.Assignee == "" {
+			stats.Unassigned++
+		}
+	}
+	return stats
+}
+
+// buildRigHealth rolls up agent heartbeats for a rig. leased are the rig's
+// issues holding a lease: their owner heartbeats with bd heartbeat, and a
+// lease past its expiry means the owner stopped. heartbeats are heartbeat
+// wisps agents posted in the rig. An agent is fresh when its latest
+// heartbeat of either kind is within staleAfter of now.
+func buildRigHealth(rig string, leased, heartbeats []*types.Issue, now time.Time, staleAfter time.Duration) RigHealth {
+	byAgent := make(map[string]*RigAgent)
+	agent := func(name string) *RigAgent {
+		if byAgent[name] == nil {
+			byAgent[name] = &RigAgent{Agent: name}
+		}
+		return byAgent[name]
+	}
+	seen := func(a *RigAgent, at time.Time) {
+		if a.LastHeartbeat == nil || at.After(*a.LastHeartbeat) {
+			t := at
+			a.LastHeartbeat = &t
+		}
+	}
+
+	for _, issue := range leased {
+		if issue.LeaseExpiresAt == nil || issue.Assignee == "" {
+			continue
+		}
+		a := agent(issue.Assignee)
+		if issue.LeaseExpiresAt.After(now) {
+			a.ActiveLeases++
+		} else {
+			a.ExpiredLeases++
+		}
+		if issue.HeartbeatAt != nil {
+			seen(a, *issue.HeartbeatAt)
+		}
+	}
+	for _, hb := range heartbeats {
+		name := hb.Actor
+		if name == "" {
+			name = hb.CreatedBy
+		}
+		if name == "" {
+			continue
+		}
+		seen(agent(name), hb.CreatedAt)
+	}
+
+	health := RigHealth{Rig: rig, Agents: []RigAgent{}}
+	for _, a := range byAgent {
+		a.Fresh = a.LastHeartbeat != nil && now.Sub(*a.LastHeartbeat) <= staleAfter
+		if a.Fresh {
+			health.FreshAgents++
+		} else {
+			health.StaleAgents++
+		}
+		health.ExpiredLeases += a.ExpiredLeases
+		health.Agents = append(health.Agents, *a)
+	}
+	slices.SortFunc(health.Agents, func(a, b RigAgent) int { return strings.Compare(a.Agent, b.Agent) })
+
+	switch {
+	case len(health.Agents) == 0:
+		health.Status = rigHealthIdle
+	case health.FreshAgents == 0:
+		health.Status = rigHealthDown
+	case health.StaleAgents > 0 || health.ExpiredLeases > 0:
+		health.Status = rigHealthDegraded
+	default:
+		health.Status = rigHealthHealthy
+	}
+	return health
+}
+
+// rigTargets returns the rigs a report covers: the named rig, the --rig
+// scope, or every registered rig.
+func rigTargets(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	if rigScope != "" {
+		return []string{rigScope}, nil
+	}
+	rigs, err := loadRigs()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(rigs))
+	for i, r := range rigs {
+		names[i] = r.Name
+	}
+	return names, nil
+}
+
+var rigCmd = &cobra.Command{
+	Use:     "rig",
+	GroupID: "views",
+	Short:   "Register rigs and scope work to them",
+	Long: `A rig is a machine or fleet of agents working the same beads database.
+Register rigs, assign issues to them, and scope commands with --rig:
+
+  bd --rig gpu-west create "Retrain model"   # new issue belongs to gpu-west
+  bd --rig gpu-west ready --claim            # claim only gpu-west work
+  bd --rig gpu-west list                     # list only gpu-west issues
+
+--rig defaults to $BD_RIG or the rig key in config.yaml, so an agent host
+can set it once. Issues with no rig are visible only without --rig.
+
+Rig health rolls up agent heartbeats: lease heartbeats from 'bd heartbeat'
+on the rig's claimed issues, and heartbeat wisps created in the rig
+('bd --rig <name> create --wisp-type heartbeat ...').
+
+Examples:
+  bd rig register gpu-west --description "GPU workers, us-west"
+  bd rig assign gpu-west bd-12 bd-13
+  bd rig list
+  bd rig stats
+  bd rig health gpu-west --stale-after 5m`,
+}
+
+var rigRegisterCmd = &cobra.Command{
+	Use:           "register <name>",
+	Short:         "Register a rig",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("rig register")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("rig register is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("rig-register")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureDirectMode("rig register requires direct database access"); err != nil {
+			return HandleError("%v", err)
+		}
+
+		name := args[0]
+		if err := validateRigName(name); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		description, _ := cmd.Flags().GetString("description")
+		rec := rigRecord{
+			Name:         name,
+			Description:  description,
+			RegisteredBy: actor,
+			RegisteredAt: time.Now().UTC(),
+		}
+		raw, err := json.Marshal(rec)
+		if err != nil {
+			return HandleErrorRespectJSON("encoding rig: %v", err)
+		}
+		if err := store.SetConfig(rootCtx, rigConfigPrefix+name, string(raw)); err != nil {
+			return HandleErrorRespectJSON("registering rig %s: %v", name, err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(rec)
+		}
+		fmt.Printf("%s Registered rig %s\n", ui.RenderPass("✓"), name)
+		return nil
+	},
+}
+
+var rigUnregisterCmd = &cobra.Command{
+	Use:           "unregister <name>",
+	Short:         "Unregister a rig (its issues keep their rig)",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("rig unregister")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("rig unregister is not supported in proxied-server mode")
+		}
+		if err := ensureDirectMode("rig unregister requires direct database access"); err != nil {
+			return HandleError("%v", err)
+		}
+		name := args[0]
+		if err := requireRegisteredRig(name); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if err := store.DeleteConfig(rootCtx, rigConfigPrefix+name); err != nil {
+			return HandleErrorRespectJSON("unregistering rig %s: %v", name, err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]string{"rig": name, "status": "unregistered"})
+		}
+		fmt.Printf("%s Unregistered rig %s\n", ui.RenderPass("✓"), name)
+		return nil
+	},
+}
+
+var rigListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List registered rigs",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("rig list is not supported in proxied-server mode")
+		}
+		if store == nil {
+			return HandleErrorWithHint("database not initialized", diagHint())
+		}
+		rigs, err := loadRigs()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			if rigs == nil {
+				rigs = []rigRecord{}
+			}
+			return outputJSON(rigs)
+		}
+		if len(rigs) == 0 {
+			fmt.Println("No rigs registered (use 'bd rig register <name>')")
+			return nil
+		}
+		for _, r := range rigs {
+			line := "  " + r.Name
+			if r.Name == rigScope {
+				line += " " + ui.RenderAccent("(current)")
+			}
+			if r.Description != "" {
+				line += " " + ui.RenderMuted("— "+r.Description)
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+var rigAssignCmd = &cobra.Command{
+	Use:           "assign <rig> <id>...",
+	Short:         "Move issues into a rig",
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("rig assign")
+		if err := requireRegisteredRig(args[0]); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		return setIssueRig(args[0], args[1:], "rig assign")
+	},
+}
+
+var rigUnassignCmd = &cobra.Command{
+	Use:           "unassign <id>...",
+	Short:         "Remove issues from their rig",
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("rig unassign")
+		return setIssueRig("", args, "rig unassign")
+	},
+}
+
+// setIssueRig sets the rig of each issue; an empty rig removes it.
+func setIssueRig(rig string, ids []string, command string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("%s is not supported in proxied-server mode", command)
+	}
+	if err := ensureDirectMode(command + " requires direct database access"); err != nil {
+		return HandleError("%v", err)
+	}
+	ctx := rootCtx
+	var updated []string
+	for _, arg := range ids {
+		id, err := utils.ResolvePartialID(ctx, store, arg)
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", arg, err)
+		}
+		if err := store.UpdateIssue(ctx, id, map[string]interface{}{"rig": rig}, actor); err != nil {
+			return HandleErrorRespectJSON("updating %s: %v", id, err)
+		}
+		updated = append(updated, id)
+	}
+	commandDidWrite.Store(true)
+	SetLastTouchedID(updated[len(updated)-1])
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{"rig": rig, "updated": updated})
+	}
+	for _, id := range updated {
+		if rig == "" {
+			fmt.Printf("%s Removed %s from its rig\n", ui.RenderPass("✓"), id)
+		} else {
+			fmt.Printf("%s Assigned %s to rig %s\n", ui.RenderPass("✓"), id, rig)
+		}
+	}
+	return nil
+}
+
+var rigStatsCmd = &cobra.Command{
+	Use:           "stats [rig]",
+	Short:         "Show issue counts per rig",
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("rig stats is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("rig-stats")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if store == nil {
+			return HandleErrorWithHint("database not initialized", diagHint())
+		}
+		ctx := rootCtx
+		names, err := rigTargets(args)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		all := make([]RigStats, 0, len(names))
+		for _, name := range names {
+			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Rig: name})
+			if err != nil {
+				return HandleErrorRespectJSON("reading rig %s: %v", name, err)
+			}
+			ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, Rig: name})
+			if err != nil {
+				return HandleErrorRespectJSON("reading ready work for rig %s: %v", name, err)
+			}
+			all = append(all, buildRigStats(name, issues, len(ready)))
+		}
+
+		if jsonOutput {
+			return outputJSON(all)
+		}
+		if len(all) == 0 {
+			fmt.Println("No rigs registered (use 'bd rig register <name>')")
+			return nil
+		}
+		for _, s := range all {
+			fmt.Printf("%s %s\n", ui.RenderBold("Rig:"), s.Rig)
+			fmt.Printf("  Total:       %d\n", s.Total)
+			fmt.Printf("  Ready:       %d (%d unassigned open)\n", s.Ready, s.Unassigned)
+			for _, status := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusDeferred, types.StatusClosed} {
+				fmt.Printf("  %-12s %d\n", string(status)+":", s.ByStatus[string(status)])
+			}
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+var rigHealthCmd = &cobra.Command{
+	Use:   "health [rig]",
+	Short: "Roll up agent heartbeats per rig",
+	Long: `Roll up agent heartbeats per rig. An agent is fresh when it heartbeated
+within --stale-after, either on a lease it holds ('bd heartbeat') or with a
+heartbeat wisp created in the rig.
+
+A rig is healthy when every agent is fresh and no lease has expired,
+degraded when some agents are stale or hold expired leases, down when no
+agent is fresh, and idle when no agent has heartbeated in it.`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("rig health is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("rig-health")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if store == nil {
+			return HandleErrorWithHint("database not initialized", diagHint())
+		}
+		staleAfter, _ := cmd.Flags().GetDuration("stale-after")
+		if staleAfter <= 0 {
+			return HandleErrorRespectJSON("--stale-after must be positive")
+		}
+		ctx := rootCtx
+		names, err := rigTargets(args)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		now := time.Now()
+		since := now.Add(-24 * time.Hour)
+		heartbeatType := types.WispTypeHeartbeat
+		all := make([]RigHealth, 0, len(names))
+		for _, name := range names {
+			inProgress := types.StatusInProgress
+			leased, err := store.SearchIssues(ctx, "", types.IssueFilter{Rig: name, Status: &inProgress})
+			if err != nil {
+				return HandleErrorRespectJSON("reading rig %s: %v", name, err)
+			}
+			heartbeats, err := store.SearchIssues(ctx, "", types.IssueFilter{
+				Rig:          name,
+				WispType:     &heartbeatType,
+				CreatedAfter: &since,
+			})
+			if err != nil {
+				return HandleErrorRespectJSON("reading heartbeats for rig %s: %v", name, err)
+			}
+			all = append(all, buildRigHealth(name, leased, heartbeats, now, staleAfter))
+		}
+
+		if jsonOutput {
+			return outputJSON(all)
+		}
+		if len(all) == 0 {
+			fmt.Println("No rigs registered (use 'bd rig register <name>')")
+			return nil
+		}
+		for _, h := range all {
+			fmt.Printf("%s %s  %s\n", ui.RenderBold("Rig:"), h.Rig, renderRigHealth(h.Status))
+			for _, a := range h.Agents {
+				last := "never"
+				if a.LastHeartbeat != nil {
+					last = formatTimeAgo(*a.LastHeartbeat)
+				}
+				line := fmt.Sprintf("  %-20s last heartbeat %s, %d active lease(s)", a.Agent, last, a.ActiveLeases)
+				if a.ExpiredLeases > 0 {
+					line += " " + ui.RenderFail(fmt.Sprintf("%d expired", a.ExpiredLeases))
+				}
+				if !a.Fresh {
+					line += " " + ui.RenderWarn("stale")
+				}
+				fmt.Println(line)
+			}
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+// renderRigHealth colors a rig health state.
+func renderRigHealth(status string) string {
+	switch status {
+	case rigHealthHealthy:
+		return ui.RenderPass(status)
+	case rigHealthDegraded:
+		return ui.RenderWarn(status)
+	case rigHealthDown:
+		return ui.RenderFail(status)
+	default:
+		return ui.RenderMuted(status)
+	}
+}
+
+func init() {
+	rigRegisterCmd.Flags().StringP("description", "d", "", "What the rig is (hosts, hardware, purpose)")
+	rigHealthCmd.Flags().Duration("stale-after", 10*time.Minute, "Treat agents with no heartbeat for this long as stale")
+
+	rigCmd.AddCommand(rigRegisterCmd, rigUnregisterCmd, rigListCmd, rigAssignCmd, rigUnassignCmd, rigStatsCmd, rigHealthCmd)
+	rootCmd.AddCommand(rigCmd)
+}
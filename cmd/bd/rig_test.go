@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestValidateRigName(t *testing.T) {
+	for _, name := range []string{"gpu-west", "build.linux", "r1", "a_b"} {
+		if err := validateRigName(name); err != nil {
+			t.Errorf("validateRigName(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"", "GPU", "-lead", "has space", "a/b"} {
+		if err := validateRigName(name); err == nil {
+			t.Errorf("validateRigName(%q) = nil, want error", name)
+		}
+	}
+}
+
+func TestParseRigRecords(t *testing.T) {
+	rigs := parseRigRecords(map[string]string{
+		"rigs.west":    `{"description":"us-west"}`,
+		"rigs.east":    `not json`,
+		"issue_prefix": "bd",
+		"rigs.":        `{}`,
+	})
+	if len(rigs) != 2 || rigs[0].Name != "east" || rigs[1].Name != "west" {
+		t.Fatalf("rigs = %+v, want east, west", rigs)
+	}
+	if rigs[1].Description != "us-west" {
+		t.Errorf("west description = %q", rigs[1].Description)
+	}
+}
+
+func TestCheckRigClaim(t *testing.T) {
+	old := rigScope
+	t.Cleanup(func() { rigScope = old })
+
+	rigScope = ""
+	if err := checkRigClaim(&types.Issue{ID: "bd-1", Rig: "east"}); err != nil {
+		t.Errorf("unscoped claim: %v", err)
+	}
+	rigScope = "west"
+	if err := checkRigClaim(&types.Issue{ID: "bd-1", Rig: "west"}); err != nil {
+		t.Errorf("same-rig claim: %v", err)
+	}
+	if err := checkRigClaim(&types.Issue{ID: "bd-1", Rig: "east"}); err == nil {
+		t.Error("claim in another rig should fail")
+	}
+	if err := checkRigClaim(&types.Issue{ID: "bd-1"}); err == nil {
+		t.Error("claim of an unscoped issue should fail under --rig")
+	}
+}
+
+func TestBuildRigStats(t *testing.T) {
+	s := buildRigStats("west", []*types.Issue{
+		{Status: types.StatusOpen},
+		{Status: types.StatusOpen, Assignee: "a"},
+		{Status: types.StatusInProgress, Assignee: "a"},
+		{Status: types.StatusClosed},
+	}, 1)
+	if s.Total != 4 || s.Ready != 1 || s.Unassigned != 1 {
+		t.Errorf("total/ready/unassigned = %d/%d/%d, want 4/1/1", s.Total, s.Ready, s.Unassigned)
+	}
+	if s.ByStatus["open"] != 2 || s.ByStatus["in_progress"] != 1 || s.ByStatus["closed"] != 1 {
+		t.Errorf("by status = %v", s.ByStatus)
+	}
+}
+
+func TestBuildRigHealth(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	later := now.Add(time.Minute)
+	leased := []*types.Issue{
+		{ID: "bd-1", Assignee: "alpha", LeaseExpiresAt: &later, HeartbeatAt: ago(time.Minute)},
+		{ID: "bd-2", Assignee: "beta", LeaseExpiresAt: ago(time.Minute), HeartbeatAt: ago(time.Hour)},
+		{ID: "bd-3", Assignee: "gamma"}, // claimed without a lease: not a heartbeat source
+	}
+	heartbeats := []*types.Issue{
+		{ID: "bd-wisp-1", CreatedBy: "delta", CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: "bd-wisp-2", Actor: "beta", CreatedBy: "ignored", CreatedAt: now.Add(-30 * time.Minute)},
+	}
+
+	h := buildRigHealth("west", leased, heartbeats, now, 10*time.Minute)
+	if h.Status != rigHealthDegraded {
+		t.Errorf("status = %s, want degraded", h.Status)
+	}
+	if h.FreshAgents != 2 || h.StaleAgents != 1 || h.ExpiredLeases != 1 {
+		t.Errorf("fresh/stale/expired = %d/%d/%d, want 2/1/1", h.FreshAgents, h.StaleAgents, h.ExpiredLeases)
+	}
+	if len(h.Agents) != 3 || h.Agents[0].Agent != "alpha" || h.Agents[1].Agent != "beta" || h.Agents[2].Agent != "delta" {
+		t.Fatalf("agents = %+v", h.Agents)
+	}
+	if !h.Agents[1].LastHeartbeat.Equal(now.Add(-30 * time.Minute)) {
+		t.Errorf("beta last heartbeat = %v, want the newer wisp", h.Agents[1].LastHeartbeat)
+	}
+
+	if got := buildRigHealth("west", nil, nil, now, time.Minute).Status; got != rigHealthIdle {
+		t.Errorf("empty rig status = %s, want idle", got)
+	}
+	if got := buildRigHealth("west", leased[1:2], nil, now, 10*time.Minute).Status; got != rigHealthDown {
+		t.Errorf("all-stale rig status = %s, want down", got)
+	}
+	if got := buildRigHealth("west", leased[:1], nil, now, 10*time.Minute).Status; got != rigHealthHealthy {
+		t.Errorf("all-fresh rig status = %s, want healthy", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/besteffort"
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -224,9 +225,9 @@ func resolveViaPrefixRoutingWithAccess(ctx context.Context, id string, writable
 	}
 	// Restore the original env var
 	if origDB != "" {
-		_ = os.Setenv("BEADS_DOLT_SERVER_DATABASE", origDB)
+		besteffort.Record("restore BEADS_DOLT_SERVER_DATABASE", os.Setenv("BEADS_DOLT_SERVER_DATABASE", origDB))
 	} else {
-		_ = os.Unsetenv("BEADS_DOLT_SERVER_DATABASE")
+		besteffort.Record("restore BEADS_DOLT_SERVER_DATABASE", os.Unsetenv("BEADS_DOLT_SERVER_DATABASE"))
 	}
 	if openErr != nil {
 		return nil, fmt.Errorf("opening routed store for %s: %w", matchedRoute.Path, openErr)
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// showRunsLimit is how many recent runs bd show lists.
+const showRunsLimit = 5
+
+var jobRunCmd = &cobra.Command{
+	Use:     "run",
+	GroupID: "advanced",
+	Short:   "Link issues to external job runs (CI builds, evaluations)",
+	Long: `Record executions of external jobs, such as CI builds or evaluation
+suites, against the issues they exercise.
+
+Each run has a status (queued, running, passed, failed, canceled), and
+optionally a job name, external ID, URL, duration, and artifacts. Common CI
+spellings such as "success", "failure", and "cancelled" are accepted.
+Recording a run with the same issue, name, and external ID again updates
+it, so a job can report "running" and later "passed".
+
+'bd show' lists an issue's recent runs, and 'bd list --last-run failed'
+finds issues whose most recent run failed.
+
+Webhooks: pipe the payload to 'bd run record --from-json -'. The payload is
+a run object or an array of them, using the field names of
+'bd run list --json' (issue_id, name, external_id, status, url,
+duration_ms, artifacts, started_at, finished_at).
+
+Examples:
+  bd run record bd-42 --status passed --name ci --external-id 1234 \
+    --url https://ci.example.com/builds/1234 --duration 3m20s
+  bd run record bd-42 --status failed --name eval --artifact report.html
+  echo '{"issue_id":"bd-42","name":"ci","external_id":"1235","status":"success"}' |
+    bd run record --from-json -
+  bd run list bd-42`,
+}
+
+var jobRunRecordCmd = &cobra.Command{
+	Use:           "record [id]",
+	Short:         "Record a job run against an issue",
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("run record")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("run is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("run-record")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		ctx := rootCtx
+		rs, err := runStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		var runs []*types.Run
+		if from, _ := cmd.Flags().GetString("from-json"); from != "" {
+			if runs, err = readRunPayload(from); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+		} else {
+			run, err := runFromFlags(cmd)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			runs = []*types.Run{run}
+		}
+
+		now := time.Now().UTC()
+		var touched []string
+		for _, run := range runs {
+			if len(args) == 1 {
+				run.IssueID = args[0]
+			}
+			if run.IssueID == "" {
+				return HandleErrorRespectJSON("no issue: pass an issue ID or set issue_id in the payload")
+			}
+			id, err := utils.ResolvePartialID(ctx, store, run.IssueID)
+			if err != nil {
+				return HandleErrorRespectJSON("resolving %s: %v", run.IssueID, err)
+			}
+			run.IssueID = id
+			if err := prepareRun(run, actor, now); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			if err := rs.RecordRun(ctx, run, actor); err != nil {
+				return HandleErrorRespectJSON("recording run for %s: %v", run.IssueID, err)
+			}
+			touched = append(touched, run.IssueID)
+		}
+		commandDidWrite.Store(true)
+		if len(touched) > 0 {
+			SetLastTouchedID(touched[len(touched)-1])
+		}
+
+		if jsonOutput {
+			return outputJSON(runs)
+		}
+		for _, run := range runs {
+			fmt.Printf("%s Recorded %s on %s\n", runStatusIcon(run.Status), formatRunLabel(run), run.IssueID)
+		}
+		return nil
+	},
+}
+
+var jobRunListCmd = &cobra.Command{
+	Use:           "list <id>",
+	Short:         "List an issue's job runs, most recent first",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := rootCtx
+		rs, err := runStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		runs, err := rs.ListRuns(ctx, id, limit)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			if runs == nil {
+				runs = []*types.Run{}
+			}
+			return outputJSON(runs)
+		}
+		if len(runs) == 0 {
+			fmt.Printf("No runs recorded for %s\n", id)
+			return nil
+		}
+		for _, run := range runs {
+			fmt.Println(formatRunLine(run))
+		}
+		return nil
+	},
+}
+
+// runStore returns the active store's run capability.
+func runStore() (storage.RunStore, error) {
+	rs, ok := storage.UnwrapStore(store).(storage.RunStore)
+	if !ok {
+		return nil, fmt.Errorf("job runs are not supported by this storage backend")
+	}
+	return rs, nil
+}
+
+// runFromFlags builds a run from bd run record's flags.
+func runFromFlags(cmd *cobra.Command) (*types.Run, error) {
+	run := &types.Run{}
+	run.Status, _ = cmd.Flags().GetString("status")
+	if run.Status == "" {
+		return nil, fmt.Errorf("--status is required (or use --from-json)")
+	}
+	run.Name, _ = cmd.Flags().GetString("name")
+	run.ExternalID, _ = cmd.Flags().GetString("external-id")
+	run.URL, _ = cmd.Flags().GetString("url")
+	run.Artifacts, _ = cmd.Flags().GetStringArray("artifact")
+	d, _ := cmd.Flags().GetDuration("duration")
+	if d < 0 {
+		return nil, fmt.Errorf("--duration must not be negative")
+	}
+	run.DurationMS = d.Milliseconds()
+	for _, f := range []struct {
+		flag string
+		dst  **time.Time
+	}{
+		{"started-at", &run.StartedAt},
+		{"finished-at", &run.FinishedAt},
+	} {
+		s, _ := cmd.Flags().GetString(f.flag)
+		if s == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s %q: use RFC3339, e.g. 2026-01-02T15:04:05Z", f.flag, s)
+		}
+		*f.dst = &t
+	}
+	return run, nil
+}
+
+// readRunPayload decodes one run or an array of runs from path ("-" for
+// stdin).
+func readRunPayload(path string) ([]*types.Run, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path) //nolint:gosec // G304: path is the user's own --from-json argument
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading run payload: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var runs []*types.Run
+		if err := json.Unmarshal(data, &runs); err != nil {
+			return nil, fmt.Errorf("parsing run payload: %w", err)
+		}
+		return runs, nil
+	}
+	var run types.Run
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("parsing run payload: %w", err)
+	}
+	return []*types.Run{&run}, nil
+}
+
+// prepareRun validates a run, normalizes its status, and assigns its ID.
+func prepareRun(run *types.Run, actor string, now time.Time) error {
+	status, err := types.NormalizeRunStatus(run.Status)
+	if err != nil {
+		return err
+	}
+	run.Status = status
+	if run.DurationMS < 0 {
+		return fmt.Errorf("run duration must not be negative")
+	}
+	if run.StartedAt != nil && run.FinishedAt != nil && run.FinishedAt.Before(*run.StartedAt) {
+		return fmt.Errorf("run finished_at is before started_at")
+	}
+	keyTime := now
+	if run.StartedAt != nil {
+		keyTime = *run.StartedAt
+	}
+	run.ID = types.RunID(run.IssueID, run.Name, run.ExternalID, keyTime)
+	run.CreatedBy = actor
+	run.CreatedAt = time.Time{}
+	return nil
+}
+
+func runStatusIcon(status string) string {
+	switch status {
+	case types.RunStatusPassed:
+		return ui.RenderPass("✓")
+	case types.RunStatusFailed:
+		return ui.RenderFail("✗")
+	case types.RunStatusRunning:
+		return ui.RenderWarn("◐")
+	default:
+		return ui.RenderMuted("○")
+	}
+}
+
+// formatRunLabel names a run: "failed run", "failed ci run", or
+// "failed ci run 1234".
+func formatRunLabel(run *types.Run) string {
+	parts := []string{run.Status}
+	if run.Name != "" {
+		parts = append(parts, run.Name)
+	}
+	parts = append(parts, "run")
+	if run.ExternalID != "" {
+		parts = append(parts, run.ExternalID)
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatRunLine renders a run on one line for bd run list and bd show.
+func formatRunLine(run *types.Run) string {
+	name := run.Name
+	if name == "" {
+		name = "run"
+	}
+	if run.ExternalID != "" {
+		name += " #" + run.ExternalID
+	}
+	when := run.CreatedAt
+	if run.StartedAt != nil {
+		when = *run.StartedAt
+	}
+	line := fmt.Sprintf("  %s %-8s %s  %s", runStatusIcon(run.Status), run.Status, name,
+		ui.RenderMuted(when.Local().Format("2006-01-02 15:04")))
+	if d := run.Duration(); d > 0 {
+		line += "  " + d.Round(time.Second).String()
+	}
+	if len(run.Artifacts) > 0 {
+		line += "  " + ui.RenderMuted(fmt.Sprintf("%d artifact(s)", len(run.Artifacts)))
+	}
+	if run.URL != "" {
+		line += "\n      " + ui.RenderMuted(run.URL)
+	}
+	return line
+}
+
+func init() {
+	jobRunRecordCmd.Flags().String("status", "", "Run status: queued, running, passed, failed, canceled")
+	jobRunRecordCmd.Flags().String("name", "", "Job or suite name (e.g. ci, eval/regression)")
+	jobRunRecordCmd.Flags().String("external-id", "", "The job's ID in the external system; re-recording it updates the run")
+	jobRunRecordCmd.Flags().String("url", "", "Link to the run")
+	jobRunRecordCmd.Flags().Duration("duration", 0, "Run duration (e.g. 3m20s)")
+	jobRunRecordCmd.Flags().StringArray("artifact", nil, "Artifact URL or path (repeatable)")
+	jobRunRecordCmd.Flags().String("started-at", "", "Start time (RFC3339)")
+	jobRunRecordCmd.Flags().String("finished-at", "", "Finish time (RFC3339)")
+	jobRunRecordCmd.Flags().String("from-json", "", "Read a run, or an array of runs, from a JSON file (- for stdin), e.g. a webhook payload")
+	jobRunRecordCmd.ValidArgsFunction = issueIDCompletion
+	jobRunListCmd.Flags().Int("limit", 20, "Maximum runs to show (0 = all)")
+	jobRunListCmd.ValidArgsFunction = issueIDCompletion
+
+	jobRunCmd.AddCommand(jobRunRecordCmd)
+	jobRunCmd.AddCommand(jobRunListCmd)
+	rootCmd.AddCommand(jobRunCmd)
+}
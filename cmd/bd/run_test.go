@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPrepareRun(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	run := &types.Run{IssueID: "bd-1", Name: "ci", ExternalID: "7", Status: "success"}
+	if err := prepareRun(run, "alice", now); err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != types.RunStatusPassed || run.CreatedBy != "alice" {
+		t.Errorf("run = %+v, want passed by alice", run)
+	}
+	if want := types.RunID("bd-1", "ci", "7", time.Time{}); run.ID != want {
+		t.Errorf("ID = %s, want %s", run.ID, want)
+	}
+
+	later := now.Add(-time.Hour)
+	bad := &types.Run{IssueID: "bd-1", Status: "failed", StartedAt: &now, FinishedAt: &later}
+	if err := prepareRun(bad, "alice", now); err == nil {
+		t.Error("finish before start should be rejected")
+	}
+	if err := prepareRun(&types.Run{IssueID: "bd-1", Status: "flaky"}, "alice", now); err == nil {
+		t.Error("unknown status should be rejected")
+	}
+}
+
+func TestReadRunPayload(t *testing.T) {
+	dir := t.TempDir()
+	for name, tc := range map[string]struct {
+		body string
+		want int
+	}{
+		"single": {`{"issue_id":"bd-1","status":"failure","duration_ms":1200}`, 1},
+		"array":  {` [{"issue_id":"bd-1","status":"passed"},{"issue_id":"bd-2","status":"failed"}]`, 2},
+	} {
+		path := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(path, []byte(tc.body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		runs, err := readRunPayload(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(runs) != tc.want || runs[0].IssueID != "bd-1" {
+			t.Errorf("%s: got %+v", name, runs)
+		}
+	}
+}
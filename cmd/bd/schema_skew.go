@@ -8,7 +8,7 @@ import (
 )
 
 func handleSchemaSkewJSON(e *schema.SchemaSkewError) {
-	outer := buildJSONError(e.Error(), e.EscapeHint())
+	outer := buildJSONError(e.Error(), e.EscapeHint(), ErrGeneral)
 	if m, ok := outer.(map[string]interface{}); ok {
 		m["schema_skew"] = map[string]interface{}{
 			"current_version":  e.DBVersion,
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/metrics"
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/fts"
+	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
 	"github.com/steveyegge/beads/internal/validation"
@@ -17,14 +22,23 @@ var searchCmd = &cobra.Command{
 	Use:     "search [query]",
 	GroupID: "issues",
 	Short:   "Search issues by text query",
-	Long: `Search issues across title and ID (excludes closed issues by default).
+	Long: `Search issues by full text across title, description, notes and ID
+(excludes closed issues by default).
+
+Text queries are answered from a search index and listed most relevant
+first (use --sort to order otherwise). Every word must appear; words match
+whole words, case-insensitively:
+  login timeout       issues containing both words
+  "login timeout"     the words next to each other, in order
+  time*               any word starting with "time"
 
 ID-like queries (e.g., "bd-123", "hq-319") use fast exact/prefix matching.
-Text queries search titles. Use --desc-contains for description search.
+Use --substring for the older substring match on titles and IDs.
 Use --status all to include closed issues.
 
 Examples:
   bd search "authentication bug"
+  bd search '"connection reset" retr*'
   bd search "login" --status open
   bd search "database" --label backend --limit 10
   bd search --query "performance" --assignee alice
@@ -95,8 +109,12 @@ Examples:
 
 		// Empty/null check flags
 		emptyDesc, _ := cmd.Flags().GetBool("empty-description")
+		noDesign, _ := cmd.Flags().GetBool("no-design")
+		noAcceptance, _ := cmd.Flags().GetBool("no-acceptance-criteria")
+		noNotes, _ := cmd.Flags().GetBool("no-notes")
 		noAssignee, _ := cmd.Flags().GetBool("no-assignee")
 		noLabels, _ := cmd.Flags().GetBool("no-labels")
+		includeWisps, _ := cmd.Flags().GetBool("include-wisps")
 
 		// Normalize labels
 		labels = utils.NormalizeLabels(labels)
@@ -155,6 +173,9 @@ Examples:
 		if emptyDesc {
 			filter.EmptyDescription = true
 		}
+		filter.NoDesign = noDesign
+		filter.NoAcceptanceCriteria = noAcceptance
+		filter.NoNotes = noNotes
 		if noAssignee {
 			filter.NoAssignee = true
 		}
@@ -245,9 +266,22 @@ Examples:
 
 		ctx := rootCtx
 
-		issues, err := store.SearchIssues(ctx, query, filter)
-		if err != nil {
-			return HandleError("%v", err)
+		substring, _ := cmd.Flags().GetBool("substring")
+		var issues []*types.Issue
+		indexed := false
+		if !substring && !issueops.LooksLikeIssueID(query) {
+			var err error
+			issues, indexed, err = fullTextSearch(ctx, query, filter, sortBy)
+			if err != nil {
+				return HandleError("%v", err)
+			}
+		}
+		if !indexed {
+			var err error
+			issues, err = store.SearchIssues(ctx, query, filter)
+			if err != nil {
+				return HandleError("%v", err)
+			}
 		}
 
 		// Apply sorting
@@ -294,6 +328,9 @@ Examples:
 					CommentCount:    commentCounts[issue.ID],
 				}
 			}
+			if includeWisps {
+				tagIssueSources(issuesWithCounts)
+			}
 			return outputJSON(issuesWithCounts)
 		}
 
@@ -312,6 +349,60 @@ Examples:
 	},
 }
 
+// fullTextSearch answers query from the full-text search index, refreshing
+// it first, and orders the matches by relevance unless sortBy is set. The
+// limit applies after ranking, so the best matches are never cut by the
+// store's own ordering. indexed is false when the store has no usable index
+// (or the query has no indexable words); the caller then falls back to
+// substring search.
+func fullTextSearch(ctx context.Context, query string, filter types.IssueFilter, sortBy string) (issues []*types.Issue, indexed bool, err error) {
+	indexer, ok := storage.UnwrapStore(store).(storage.SearchIndexer)
+	if !ok {
+		return nil, false, nil
+	}
+	q, err := fts.Parse(query)
+	if errors.Is(err, fts.ErrEmptyQuery) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := indexer.RefreshSearchIndex(ctx); err != nil {
+		debug.Logf("search: full-text index unavailable, using substring search: %v\n", err)
+		return nil, false, nil
+	}
+
+	limit := filter.Limit
+	if sortBy == "" {
+		filter.Limit = 0
+	}
+	filter.FullText = query
+	issues, err = store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, false, err
+	}
+	if sortBy == "" {
+		issues = rankIssues(q, issues)
+		if limit > 0 && len(issues) > limit {
+			issues = issues[:limit]
+		}
+	}
+	return issues, true, nil
+}
+
+// rankIssues orders issues by relevance to q, most relevant first.
+func rankIssues(q *fts.Query, issues []*types.Issue) []*types.Issue {
+	docs := make([]fts.Document, len(issues))
+	for i, issue := range issues {
+		docs[i] = fts.Document{ID: issue.ID, Title: issue.Title, Description: issue.Description, Notes: issue.Notes}
+	}
+	ranked := make([]*types.Issue, len(issues))
+	for i, idx := range fts.Rank(q, docs) {
+		ranked[i] = issues[idx]
+	}
+	return ranked
+}
+
 // outputSearchResults formats and displays search results
 func outputSearchResults(issues []*types.Issue, query string, longFormat bool) {
 	if len(issues) == 0 {
@@ -323,7 +414,7 @@ func outputSearchResults(issues []*types.Issue, query string, longFormat bool) {
 		// Long format: multi-line with details
 		fmt.Printf("\nFound %d issues matching '%s':\n\n", len(issues), query)
 		for _, issue := range issues {
-			fmt.Printf("%s [P%d] [%s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Status)
+			fmt.Printf("%s%s [P%d] [%s] %s\n", wispIndicator(issue), issue.ID, issue.Priority, issue.IssueType, issue.Status)
 			fmt.Printf("  %s\n", issue.Title)
 			if issue.Assignee != "" {
 				fmt.Printf("  Assignee: %s\n", issue.Assignee)
@@ -345,8 +436,8 @@ func outputSearchResults(issues []*types.Issue, query string, longFormat bool) {
 			if issue.Assignee != "" {
 				assigneeStr = fmt.Sprintf(" @%s", issue.Assignee)
 			}
-			fmt.Printf("%s [P%d] [%s] %s%s%s - %s\n",
-				issue.ID, issue.Priority, issue.IssueType, issue.Status,
+			fmt.Printf("%s%s [P%d] [%s] %s%s%s - %s\n",
+				wispIndicator(issue), issue.ID, issue.Priority, issue.IssueType, issue.Status,
 				assigneeStr, labelsStr, issue.Title)
 		}
 	}
@@ -363,6 +454,8 @@ func init() {
 	searchCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
 	searchCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
 	searchCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
+	searchCmd.Flags().Bool("substring", false, "Match the query as a substring of titles and IDs instead of full-text")
+	searchCmd.Flags().Bool("include-wisps", false, "Tag each result with its source (issue or wisp); wisps are always searched")
 
 	// Date range flags
 	searchCmd.Flags().String("created-after", "", "Filter issues created after date (YYYY-MM-DD or RFC3339)")
@@ -383,6 +476,9 @@ func init() {
 
 	// Empty/null check flags
 	searchCmd.Flags().Bool("empty-description", false, "Filter issues with empty or missing description")
+	searchCmd.Flags().Bool("no-design", false, "Filter issues with no design notes")
+	searchCmd.Flags().Bool("no-acceptance-criteria", false, "Filter issues with no acceptance criteria")
+	searchCmd.Flags().Bool("no-notes", false, "Filter issues with no notes")
 	searchCmd.Flags().Bool("no-assignee", false, "Filter issues with no assignee")
 	searchCmd.Flags().Bool("no-labels", false, "Filter issues with no labels")
 
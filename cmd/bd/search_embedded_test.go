@@ -116,6 +116,59 @@ func TestEmbeddedSearch(t *testing.T) {
 		}
 	})
 
+	// ===== Full-Text Search =====
+
+	hasID := func(results []map[string]interface{}, id string) bool {
+		for _, r := range results {
+			if r["id"] == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("search_full_text_description", func(t *testing.T) {
+		results := bdSearchJSON(t, bd, dir, "important")
+		if !hasID(results, taskA.ID) {
+			t.Errorf("expected description match %s for 'important', got %v", taskA.ID, results)
+		}
+	})
+
+	t.Run("search_full_text_prefix", func(t *testing.T) {
+		results := bdSearchJSON(t, bd, dir, "gam*")
+		if len(results) != 1 || !hasID(results, taskC.ID) {
+			t.Errorf("expected only %s for 'gam*', got %v", taskC.ID, results)
+		}
+	})
+
+	t.Run("search_full_text_phrase", func(t *testing.T) {
+		results := bdSearchJSON(t, bd, dir, `"bug description"`)
+		if len(results) != 1 || !hasID(results, taskB.ID) {
+			t.Errorf("expected only %s for the phrase, got %v", taskB.ID, results)
+		}
+		if results := bdSearchJSON(t, bd, dir, `"description bug"`); len(results) != 0 {
+			t.Errorf("phrase words out of order should not match, got %v", results)
+		}
+	})
+
+	t.Run("search_full_text_sees_updates", func(t *testing.T) {
+		bdUpdate(t, bd, dir, taskD.ID, "--notes", "zanzibar")
+		results := bdSearchJSON(t, bd, dir, "zanzibar")
+		if len(results) != 1 || !hasID(results, taskD.ID) {
+			t.Errorf("expected updated notes of %s to be searchable, got %v", taskD.ID, results)
+		}
+	})
+
+	t.Run("search_substring", func(t *testing.T) {
+		if results := bdSearchJSON(t, bd, dir, "lph"); len(results) != 0 {
+			t.Errorf("full-text search should match whole words only, got %v", results)
+		}
+		results := bdSearchJSON(t, bd, dir, "lph", "--substring")
+		if !hasID(results, taskA.ID) {
+			t.Errorf("expected --substring to find %s for 'lph', got %v", taskA.ID, results)
+		}
+	})
+
 	// ===== Status Filter =====
 
 	t.Run("search_status_open", func(t *testing.T) {
@@ -54,8 +54,12 @@ func runSearchProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 	externalContains, _ := cmd.Flags().GetString("external-contains")
 
 	emptyDesc, _ := cmd.Flags().GetBool("empty-description")
+	noDesign, _ := cmd.Flags().GetBool("no-design")
+	noAcceptance, _ := cmd.Flags().GetBool("no-acceptance-criteria")
+	noNotes, _ := cmd.Flags().GetBool("no-notes")
 	noAssignee, _ := cmd.Flags().GetBool("no-assignee")
 	noLabels, _ := cmd.Flags().GetBool("no-labels")
+	includeWisps, _ := cmd.Flags().GetBool("include-wisps")
 
 	labels = utils.NormalizeLabels(labels)
 	labelsAny = utils.NormalizeLabels(labelsAny)
@@ -98,6 +102,9 @@ func runSearchProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 	if emptyDesc {
 		filter.EmptyDescription = true
 	}
+	filter.NoDesign = noDesign
+	filter.NoAcceptanceCriteria = noAcceptance
+	filter.NoNotes = noNotes
 	if noAssignee {
 		filter.NoAssignee = true
 	}
@@ -211,6 +218,9 @@ func runSearchProxiedServer(cmd *cobra.Command, ctx context.Context, args []stri
 		if items == nil {
 			items = []*types.IssueWithCounts{}
 		}
+		if includeWisps {
+			tagIssueSources(items)
+		}
 		return outputJSON(items)
 	}
 
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/slack"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+var serveCmd = &cobra.Command{
+	Use:     "serve",
+	GroupID: "advanced",
+	Short:   "Serve chat integrations over HTTP (Slack)",
+	Long: `Serve HTTP endpoints that let a chat workspace work with this database.
+
+Slack: create a Slack app with a slash command (say /bd) whose request URL
+is <public-url>/slack/commands, and enable Interactivity with request URL
+<public-url>/slack/actions. Then configure the app's signing secret:
+
+  bd config set slack.signing_secret <secret>    (or export SLACK_SIGNING_SECRET)
+
+Requests not signed with that secret are rejected. The slash command
+supports:
+
+  /bd create <title> [-p 0-4] [-t type] [-a assignee] [-d description]
+  /bd show <id>
+  /bd close <id> [reason]
+
+Created and shown issues come with buttons to start, block, defer, close,
+or reopen them. Changes made from Slack are attributed to the beads actor
+mapped to the Slack user:
+
+  bd config set slack.users "U024BE7LH=alice,U0G9QF9C6=bob"
+
+Unmapped users act as slack:<username>.
+
+bd serve must be reachable by Slack; put it behind a tunnel or reverse
+proxy that terminates TLS. GET /healthz reports liveness.
+
+Examples:
+  bd serve --listen :9096`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evt := metrics.NewCommandEvent("serve")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("serve is not supported in proxied-server mode")
+		}
+		if err := ensureStoreActive(); err != nil {
+			return HandleError("database not available: %v", err)
+		}
+
+		ctx := rootCtx
+		srv, err := newSlackServer(ctx, store)
+		if err != nil {
+			return HandleError("%v", err)
+		}
+		go runBatchCommitTicker(ctx, store, &srv.mu)
+		listen, _ := cmd.Flags().GetString("listen")
+		return serveHTTP(ctx, listen, srv)
+	},
+}
+
+// slackServer handles Slack slash commands and button clicks against a store.
+// Writes are applied one at a time, like the Alertmanager webhook.
+type slackServer struct {
+	st     storage.DoltStorage
+	secret string
+	users  slack.UserMap
+	client *http.Client
+	now    func() time.Time
+	mu     sync.Mutex
+	// readOnly refuses every write (create, close, status buttons); set in
+	// read-only mode so a sandboxed server can still answer show.
+	readOnly bool
+}
+
+func newSlackServer(ctx context.Context, st storage.DoltStorage) (*slackServer, error) {
+	// The signing secret is yaml-only so it is never pushed with the database.
+	secret := config.GetString("slack.signing_secret")
+	if secret == "" {
+		secret = strings.TrimSpace(os.Getenv("SLACK_SIGNING_SECRET"))
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("Slack signing secret is not configured. Set slack.signing_secret with 'bd config set slack.signing_secret <secret>', or export SLACK_SIGNING_SECRET")
+	}
+	spec, _ := st.GetConfig(ctx, "slack.users")
+	users, err := slack.ParseUserMap(spec)
+	if err != nil {
+		return nil, fmt.Errorf("config slack.users: %w", err)
+	}
+	return &slackServer{
+		st:       st,
+		secret:   secret,
+		users:    users,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		now:      time.Now,
+		readOnly: readonlyMode,
+	}, nil
+}
+
+func (s *slackServer) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slack/commands", s.handleCommand)
+	mux.HandleFunc("/slack/actions", s.handleAction)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok\n")
+	})
+	return mux
+}
+
+// readSigned reads and verifies a Slack request, writing the error response
+// itself when the request is rejected.
+func (s *slackServer) readSigned(w http.ResponseWriter, r *http.Request) (url.Values, bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	if err := slack.VerifyRequest(s.secret, r.Header, body, s.now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return nil, false
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return form, true
+}
+
+func (s *slackServer) handleCommand(w http.ResponseWriter, r *http.Request) {
+	form, ok := s.readSigned(w, r)
+	if !ok {
+		return
+	}
+	sc, err := slack.ParseSlashCommand(form)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeSlackMessage(w, s.runCommand(r.Context(), sc))
+}
+
+func (s *slackServer) handleAction(w http.ResponseWriter, r *http.Request) {
+	form, ok := s.readSigned(w, r)
+	if !ok {
+		return
+	}
+	in, err := slack.ParseInteraction(form)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Slack only needs a 200 here; the updated message goes to response_url.
+	w.WriteHeader(http.StatusOK)
+
+	msg := s.runAction(r.Context(), in)
+	if msg == nil || !slack.ValidResponseURL(in.ResponseURL) {
+		return
+	}
+	if err := slack.PostResponse(r.Context(), s.client, in.ResponseURL, msg); err != nil {
+		fmt.Fprintf(os.Stderr, "%s slack: %v\n", ui.RenderWarn("⚠"), err)
+	}
+}
+
+func writeSlackMessage(w http.ResponseWriter, msg *slack.Message) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(msg)
+}
+
+// runCommand executes the text of a slash command as the mapped actor.
+func (s *slackServer) runCommand(ctx context.Context, sc *slack.SlashCommand) *slack.Message {
+	actor := s.users.Actor(sc.UserID, sc.UserName)
+	tokens, err := tokenizeBatchLine(sc.Text)
+	if err != nil {
+		return slack.Ephemeral("%v", err)
+	}
+	if len(tokens) == 0 {
+		return slack.Ephemeral("%s", slackUsage(sc.Command))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, rest := strings.ToLower(tokens[0]), tokens[1:]
+	if s.readOnly && (sub == "create" || sub == "new" || sub == "close") {
+		return slack.Ephemeral("bd is running read-only; %s is disabled", sub)
+	}
+	switch sub {
+	case "create", "new":
+		return s.createIssue(ctx, rest, actor)
+	case "show":
+		if len(rest) != 1 {
+			return slack.Ephemeral("usage: %s show <id>", sc.Command)
+		}
+		issue, err := s.resolveIssue(ctx, rest[0])
+		if err != nil {
+			return slack.Ephemeral("%v", err)
+		}
+		return &slack.Message{ResponseType: slack.ResponseEphemeral, Text: issue.ID + " " + issue.Title, Blocks: slack.IssueBlocks(issue)}
+	case "close":
+		if len(rest) == 0 {
+			return slack.Ephemeral("usage: %s close <id> [reason]", sc.Command)
+		}
+		reason := strings.Join(rest[1:], " ")
+		if reason == "" {
+			reason = "Closed from Slack"
+		}
+		issue, err := s.setStatus(ctx, rest[0], types.StatusClosed, reason, actor)
+		if err != nil {
+			return slack.Ephemeral("%v", err)
+		}
+		return &slack.Message{
+			ResponseType: slack.ResponseInChannel,
+			Text:         fmt.Sprintf("%s closed %s %s: %s", actor, issue.ID, slack.Escape(issue.Title), slack.Escape(reason)),
+		}
+	case "help":
+		return slack.Ephemeral("%s", slackUsage(sc.Command))
+	default:
+		return slack.Ephemeral("unknown subcommand %q\n%s", tokens[0], slackUsage(sc.Command))
+	}
+}
+
+func slackUsage(command string) string {
+	if command == "" {
+		command = "/bd"
+	}
+	return fmt.Sprintf("usage:\n  %[1]s create <title> [-p 0-4] [-t type] [-a assignee] [-d description]\n  %[1]s show <id>\n  %[1]s close <id> [reason]", command)
+}
+
+func (s *slackServer) createIssue(ctx context.Context, args []string, actor string) *slack.Message {
+	issue, err := parseSlackCreateArgs(args)
+	if err != nil {
+		return slack.Ephemeral("%v", err)
+	}
+	issue.CreatedBy = actor
+	if err := s.st.CreateIssue(ctx, issue, actor); err != nil {
+		return slack.Ephemeral("create failed: %v", err)
+	}
+	s.commit(ctx, "create", issue.ID)
+	return &slack.Message{
+		ResponseType: slack.ResponseInChannel,
+		Text:         fmt.Sprintf("%s created %s %s", actor, issue.ID, issue.Title),
+		Blocks:       slack.IssueBlocks(issue),
+	}
+}
+
+// parseSlackCreateArgs builds an issue from "create" arguments: flags take
+// the next token as their value and every other token is part of the title.
+func parseSlackCreateArgs(args []string) (*types.Issue, error) {
+	issue := &types.Issue{Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	var title []string
+	for i := 0; i < len(args); i++ {
+		flag := args[i]
+		switch flag {
+		case "-p", "--priority", "-t", "--type", "-a", "--assignee", "-d", "--description":
+		default:
+			title = append(title, flag)
+			continue
+		}
+		if i+1 >= len(args) {
+			return nil, fmt.Errorf("%s needs a value", flag)
+		}
+		i++
+		value := args[i]
+		switch flag {
+		case "-p", "--priority":
+			p, err := validation.ValidatePriority(value)
+			if err != nil {
+				return nil, err
+			}
+			issue.Priority = p
+		case "-t", "--type":
+			t, err := validation.ParseIssueType(value)
+			if err != nil {
+				return nil, err
+			}
+			issue.IssueType = t
+		case "-a", "--assignee":
+			issue.Assignee = strings.TrimPrefix(value, "@")
+		case "-d", "--description":
+			issue.Description = value
+		}
+	}
+	issue.Title = strings.TrimSpace(strings.Join(title, " "))
+	if issue.Title == "" {
+		return nil, fmt.Errorf("a title is required")
+	}
+	return issue, nil
+}
+
+// runAction applies the status buttons in an interaction and returns the
+// refreshed issue message, or nil when there is nothing to report.
+func (s *slackServer) runAction(ctx context.Context, in *slack.Interaction) *slack.Message {
+	actor := s.users.Actor(in.User.ID, in.User.Username)
+	if s.readOnly {
+		return slack.Ephemeral("bd is running read-only; status changes are disabled")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range in.Actions {
+		id, status, ok := slack.ParseStatusAction(a)
+		if !ok {
+			continue
+		}
+		issue, err := s.setStatus(ctx, id, status, "Closed from Slack", actor)
+		if err != nil {
+			return slack.Ephemeral("%v", err)
+		}
+		return &slack.Message{
+			ReplaceOriginal: true,
+			Text:            fmt.Sprintf("%s set %s to %s", actor, issue.ID, issue.Status),
+			Blocks:          slack.IssueBlocks(issue),
+		}
+	}
+	return nil
+}
+
+func (s *slackServer) resolveIssue(ctx context.Context, ref string) (*types.Issue, error) {
+	id, err := utils.ResolvePartialID(ctx, s.st, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.st.GetIssue(ctx, id)
+}
+
+// setStatus moves an issue to status, closing or reopening it when needed,
+// and returns the updated issue. A close is held to the same policies as bd
+// close without --force, and a refusal is returned as the error.
+func (s *slackServer) setStatus(ctx context.Context, ref string, status types.Status, closeReason, actor string) (*types.Issue, error) {
+	issue, err := s.resolveIssue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if issue.Status == status {
+		return issue, nil
+	}
+	if status == types.StatusClosed {
+		return s.closeIssue(ctx, issue, closeReason, actor)
+	}
+	switch {
+	case issue.Status == types.StatusClosed:
+		err = s.st.ReopenIssue(ctx, issue.ID, "Reopened from Slack", actor)
+		if err == nil && status != types.StatusOpen {
+			err = s.st.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(status)}, actor)
+		}
+	default:
+		err = s.st.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(status)}, actor)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating %s: %w", issue.ID, err)
+	}
+	s.commit(ctx, "update", issue.ID)
+	return s.st.GetIssue(ctx, issue.ID)
+}
+
+// closeIssue closes issue the way bd close does: the close reason checks,
+// the shared close policies, and the is_blocked guard run first, and the
+// post-close work (review queue, follow-ups, quality refresh) runs after.
+func (s *slackServer) closeIssue(ctx context.Context, issue *types.Issue, reason, actor string) (*types.Issue, error) {
+	if err := validateCloseReasons([]string{reason}); err != nil {
+		return nil, fmt.Errorf("cannot close %s: %w", issue.ID, err)
+	}
+	if err := validateCloseReasonCategories([]string{reason}); err != nil {
+		return nil, fmt.Errorf("cannot close %s: %w", issue.ID, err)
+	}
+	duplicateLinked := func() (bool, error) { return hasDuplicateLink(ctx, s.st, issue.ID) }
+	if err := checkClosePolicies(issue.ID, issue, reason, duplicateLinked); err != nil {
+		return nil, fmt.Errorf("cannot close %s: %w", issue.ID, err)
+	}
+	if _, err := s.st.CloseIssueChecked(ctx, issue.ID, actor, storage.CloseIssueOptions{Reason: reason}); err != nil {
+		return nil, fmt.Errorf("closing %s: %w", issue.ID, err)
+	}
+	_, followups := runPostCloseHooks(ctx, s.st, issue.ID, issue, actor)
+	s.commit(ctx, "close", append([]string{issue.ID}, followups...)...)
+	return s.st.GetIssue(ctx, issue.ID)
+}
+
+func (s *slackServer) commit(ctx context.Context, command string, ids ...string) {
+	if err := maybeAutoCommitStore(ctx, s.st, doltAutoCommitParams{Command: command, IssueIDs: ids}); err != nil {
+		fmt.Fprintf(os.Stderr, "%s slack: auto-commit: %v\n", ui.RenderWarn("⚠"), err)
+	}
+}
+
+// serveHTTP runs the Slack endpoints on addr until ctx is cancelled.
+func serveHTTP(ctx context.Context, addr string, s *slackServer) error {
+	srv := &http.Server{Addr: addr, Handler: s.routes(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving Slack commands on %s/slack/commands and actions on %s/slack/actions (Ctrl-C to stop)\n", addr, addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return HandleError("serve: %v", err)
+	}
+	return nil
+}
+
+func init() {
+	serveCmd.Flags().String("listen", ":9096", "Address to listen on")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/slack"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseSlackCreateArgs(t *testing.T) {
+	tokens, err := tokenizeBatchLine(`Fix login redirect -p P1 -t bug -a @alice -d "Users land on /404"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue, err := parseSlackCreateArgs(tokens)
+	if err != nil {
+		t.Fatalf("parseSlackCreateArgs: %v", err)
+	}
+	if issue.Title != "Fix login redirect" || issue.Priority != 1 || issue.IssueType != types.TypeBug ||
+		issue.Assignee != "alice" || issue.Description != "Users land on /404" {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+
+	for _, args := range [][]string{
+		{},
+		{"-p", "1"},
+		{"Title", "-p"},
+		{"Title", "-p", "high"},
+		{"Title", "-t", "nonsense"},
+	} {
+		if _, err := parseSlackCreateArgs(args); err == nil {
+			t.Errorf("parseSlackCreateArgs(%q) accepted", args)
+		}
+	}
+}
+
+func TestSlackServerRejectsUnsignedRequests(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := &slackServer{secret: "s3cret", now: func() time.Time { return now }}
+	srv := httptest.NewServer(s.routes())
+	defer srv.Close()
+
+	body := url.Values{"command": {"/bd"}, "text": {"help"}, "user_id": {"U1"}}.Encode()
+	post := func(secret string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/slack/commands", strings.NewReader(body))
+		ts := strconv.FormatInt(now.Unix(), 10)
+		req.Header.Set(slack.HeaderTimestamp, ts)
+		req.Header.Set(slack.HeaderSignature, slack.Sign(secret, ts, []byte(body)))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	if resp := post("wrong"); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("bad signature: status %d, want 401", resp.StatusCode)
+	}
+	if resp := post("s3cret"); resp.StatusCode != http.StatusOK {
+		t.Errorf("good signature: status %d, want 200", resp.StatusCode)
+	}
+	resp, err := http.Get(srv.URL + "/slack/commands")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET: status %d, want 405", resp.StatusCode)
+	}
+}
+
+func TestSlackRunCommandUsage(t *testing.T) {
+	s := &slackServer{}
+	msg := s.runCommand(t.Context(), &slack.SlashCommand{Command: "/beads", Text: "frobnicate"})
+	if msg.ResponseType != slack.ResponseEphemeral || !strings.Contains(msg.Text, `unknown subcommand "frobnicate"`) ||
+		!strings.Contains(msg.Text, "/beads create <title>") {
+		t.Errorf("unexpected reply: %+v", msg)
+	}
+	if msg := s.runCommand(t.Context(), &slack.SlashCommand{Command: "/bd", Text: "show"}); !strings.Contains(msg.Text, "usage: /bd show <id>") {
+		t.Errorf("unexpected reply: %+v", msg)
+	}
+}
+
+// fakeSlackStore serves one issue and records whether it was closed.
+type fakeSlackStore struct {
+	storage.DoltStorage
+	issue  *types.Issue
+	closed bool
+}
+
+func (f *fakeSlackStore) SearchIssues(context.Context, string, types.IssueFilter) ([]*types.Issue, error) {
+	return []*types.Issue{f.issue}, nil
+}
+
+func (f *fakeSlackStore) GetIssue(context.Context, string) (*types.Issue, error) {
+	return f.issue, nil
+}
+
+func (f *fakeSlackStore) CloseIssueChecked(context.Context, string, string, storage.CloseIssueOptions) (storage.CloseIssueResult, error) {
+	f.closed = true
+	return storage.CloseIssueResult{}, nil
+}
+
+func TestSlackCloseChecksClosePolicies(t *testing.T) {
+	initConfigForTest(t)
+	config.Set("validation.acceptance", "error")
+
+	st := &fakeSlackStore{issue: &types.Issue{ID: "bd-7", Title: "Parser", Status: types.StatusOpen,
+		IssueType: types.TypeTask, AcceptanceCriteria: "- [ ] regression test"}}
+	s := &slackServer{st: st}
+	msg := s.runCommand(t.Context(), &slack.SlashCommand{Command: "/bd", Text: "close bd-7 fixed: parser"})
+	if st.closed {
+		t.Fatal("Slack close skipped the acceptance criteria policy")
+	}
+	if msg.ResponseType != slack.ResponseEphemeral || !strings.Contains(msg.Text, "acceptance criteria") {
+		t.Errorf("refusal not sent back to Slack: %+v", msg)
+	}
+}
@@ -9,9 +9,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
-	"github.com/steveyegge/beads/internal/uimd"
 )
 
 var showCmd = &cobra.Command{
@@ -30,8 +30,16 @@ var showCmd = &cobra.Command{
 			}
 		}()
 
+		showRawMarkdown, _ = cmd.Flags().GetBool("raw")
+		noPager, _ := cmd.Flags().GetBool("no-pager")
+
 		if usesProxiedServer() {
-			return runShowProxiedServer(cmd, rootCtx, args)
+			if jsonOutput {
+				return runShowProxiedServer(cmd, rootCtx, args)
+			}
+			return ui.PageOutput(ui.PagerOptions{NoPager: noPager}, func() error {
+				return runShowProxiedServer(cmd, rootCtx, args)
+			})
 		}
 
 		showThread, _ := cmd.Flags().GetBool("thread")
@@ -48,14 +56,6 @@ var showCmd = &cobra.Command{
 		includeComments, _ := cmd.Flags().GetBool("include-comments")
 		ctx := rootCtx
 
-		// Helper to format timestamp based on --local-time flag
-		formatTime := func(t time.Time) string {
-			if localTime {
-				t = t.Local()
-			}
-			return t.Format("2006-01-02 15:04")
-		}
-
 		// Merge --id flag values with positional args
 		// This allows IDs that look like flags (e.g., --xyz or gt--abc) to be passed safely
 		args = append(args, idFlags...)
@@ -111,337 +111,403 @@ var showCmd = &cobra.Command{
 		}
 
 		// Direct mode - use routed resolution for cross-repo lookups
-		allDetails := []interface{}{}
-		foundCount := 0
-		for idx, id := range args {
-			// Resolve and get issue with routing (e.g., gt-xyz routes to another rig)
-			result, err := resolveAndGetIssueWithRouting(ctx, store, id)
-			if err != nil {
-				if result != nil {
-					result.Close()
-				}
-				fmt.Fprintf(os.Stderr, "Error fetching %s: %v\n", id, err)
-				continue
-			}
-			if result == nil || result.Issue == nil {
-				if result != nil {
-					result.Close()
-				}
-				fmt.Fprintf(os.Stderr, "Issue %s not found\n", id)
-				continue
-			}
-			issue := result.Issue
-			issueStore := result.Store // Use the store that contains this issue
-			// Note: result.Close() called at end of loop iteration
-			foundCount++
+		opts := showRenderOptions{
+			short:             shortMode,
+			long:              longMode,
+			localTime:         localTime,
+			includeDependents: includeDepends,
+			includeComments:   includeComments,
+		}
+		if jsonOutput {
+			return renderShow(ctx, args, opts)
+		}
+		return ui.PageOutput(ui.PagerOptions{NoPager: noPager}, func() error {
+			return renderShow(ctx, args, opts)
+		})
+	},
+}
+
+// showRenderOptions are the bd show flags that shape direct-mode output.
+type showRenderOptions struct {
+	short             bool
+	long              bool
+	localTime         bool
+	includeDependents bool
+	includeComments   bool
+}
+
+// renderShow prints the issues named by args in direct mode. It reports
+// failures by returning them, never by exiting, because bd show runs it
+// under ui.PageOutput with os.Stdout captured: exiting from inside would
+// drop everything printed so far.
+func renderShow(ctx context.Context, args []string, opts showRenderOptions) error {
+	// Helper to format timestamp based on --local-time flag
+	formatTime := func(t time.Time) string {
+		if opts.localTime {
+			t = t.Local()
+		}
+		return t.Format("2006-01-02 15:04")
+	}
 
-			if shortMode {
-				fmt.Println(formatShortIssue(issue))
+	allDetails := []interface{}{}
+	foundCount := 0
+	for idx, id := range args {
+		// Resolve and get issue with routing (e.g., gt-xyz routes to another rig)
+		result, err := resolveAndGetIssueWithRouting(ctx, store, id)
+		if err != nil {
+			if result != nil {
+				result.Close()
+			}
+			fmt.Fprintf(os.Stderr, "Error fetching %s: %v\n", id, err)
+			continue
+		}
+		if result == nil || result.Issue == nil {
+			if result != nil {
 				result.Close()
-				continue
 			}
+			fmt.Fprintf(os.Stderr, "Issue %s not found\n", id)
+			continue
+		}
+		issue := result.Issue
+		issueStore := result.Store // Use the store that contains this issue
+		// Note: result.Close() called at end of loop iteration
+		foundCount++
+
+		if opts.short {
+			fmt.Println(formatShortIssue(issue))
+			result.Close()
+			continue
+		}
 
-			if jsonOutput {
-				// be-ijck6q: default is count-only (no dependents/comments slice in output).
-				// Use --include-dependents / --include-comments to stream the full lists.
-				details := &types.IssueDetails{Issue: *issue}
-				details.Labels, _ = issueStore.GetLabels(ctx, issue.ID)
-				details.Dependencies, _ = issueStore.GetDependenciesWithMetadata(ctx, issue.ID)
-
-				// Aggregate counts — O(1) queries, no row materialization.
-				depCount, _ := issueStore.CountDependents(ctx, issue.ID)
-				details.DependentCount = &depCount
-				depnCount, _ := issueStore.CountDependencies(ctx, issue.ID)
-				details.DependencyCount = &depnCount
-				cmtCount, _ := issueStore.CountIssueComments(ctx, issue.ID)
-				details.CommentCount = &cmtCount
-
-				// --include-dependents: stream via Iter, shallow-copy each item.
-				// May be slow on hub beads with many dependents.
-				if includeDepends {
-					iter, err := issueStore.IterDependentsWithMetadata(ctx, issue.ID)
-					if err != nil {
-						result.Close()
-						return HandleErrorRespectJSON("iter dependents %s: %v", issue.ID, err)
-					}
-					defer iter.Close() //nolint:errcheck
-					var shallowDeps []*types.IssueWithDependencyMetadata
-					for iter.Next(ctx) {
-						item := iter.Value()
-						shallowDeps = append(shallowDeps, &types.IssueWithDependencyMetadata{
-							Issue: types.Issue{
-								ID:        item.Issue.ID,
-								Status:    item.Issue.Status,
-								IssueType: item.Issue.IssueType,
-								Priority:  item.Issue.Priority,
-								Title:     item.Issue.Title,
-							},
-							DependencyType: item.DependencyType,
-						})
-					}
-					if err := iter.Err(); err != nil {
-						result.Close()
-						return HandleErrorRespectJSON("iter dependents %s: %v", issue.ID, err)
-					}
-					details.Dependents = shallowDeps
-
-					// Epic progress from streamed dependents.
-					if issue.IssueType == types.TypeEpic && len(shallowDeps) > 0 {
-						total, closed := 0, 0
-						for _, dep := range shallowDeps {
-							if dep.DependencyType == types.DepParentChild {
-								total++
-								if dep.Issue.Status == types.StatusClosed {
-									closed++
-								}
+		if jsonOutput {
+			// be-ijck6q: default is count-only (no dependents/comments slice in output).
+			// Use --include-dependents / --include-comments to stream the full lists.
+			details := &types.IssueDetails{Issue: *issue}
+			details.Labels, _ = issueStore.GetLabels(ctx, issue.ID)
+			details.Dependencies, _ = issueStore.GetDependenciesWithMetadata(ctx, issue.ID)
+
+			// Aggregate counts — O(1) queries, no row materialization.
+			depCount, _ := issueStore.CountDependents(ctx, issue.ID)
+			details.DependentCount = &depCount
+			depnCount, _ := issueStore.CountDependencies(ctx, issue.ID)
+			details.DependencyCount = &depnCount
+			cmtCount, _ := issueStore.CountIssueComments(ctx, issue.ID)
+			details.CommentCount = &cmtCount
+
+			// --include-dependents: stream via Iter, shallow-copy each item.
+			// May be slow on hub beads with many dependents.
+			if opts.includeDependents {
+				iter, err := issueStore.IterDependentsWithMetadata(ctx, issue.ID)
+				if err != nil {
+					result.Close()
+					return HandleErrorRespectJSON("iter dependents %s: %v", issue.ID, err)
+				}
+				defer iter.Close() //nolint:errcheck
+				var shallowDeps []*types.IssueWithDependencyMetadata
+				for iter.Next(ctx) {
+					item := iter.Value()
+					shallowDeps = append(shallowDeps, &types.IssueWithDependencyMetadata{
+						Issue: types.Issue{
+							ID:        item.Issue.ID,
+							Status:    item.Issue.Status,
+							IssueType: item.Issue.IssueType,
+							Priority:  item.Issue.Priority,
+							Title:     item.Issue.Title,
+						},
+						DependencyType: item.DependencyType,
+					})
+				}
+				if err := iter.Err(); err != nil {
+					result.Close()
+					return HandleErrorRespectJSON("iter dependents %s: %v", issue.ID, err)
+				}
+				details.Dependents = shallowDeps
+
+				// Epic progress from streamed dependents.
+				if issue.IssueType == types.TypeEpic && len(shallowDeps) > 0 {
+					total, closed := 0, 0
+					for _, dep := range shallowDeps {
+						if dep.DependencyType == types.DepParentChild {
+							total++
+							if dep.Issue.Status == types.StatusClosed {
+								closed++
 							}
 						}
-						if total > 0 {
-							details.EpicTotalChildren = &total
-							details.EpicClosedChildren = &closed
-							closeable := total == closed
-							details.EpicCloseable = &closeable
-						}
-					}
-				}
-
-				// --include-comments: stream via Iter.
-				// May be slow on issues with many comments.
-				if includeComments {
-					iter, err := issueStore.IterIssueComments(ctx, issue.ID)
-					if err != nil {
-						result.Close()
-						return HandleErrorRespectJSON("iter comments %s: %v", issue.ID, err)
 					}
-					defer iter.Close() //nolint:errcheck
-					for iter.Next(ctx) {
-						details.Comments = append(details.Comments, iter.Value())
-					}
-					if err := iter.Err(); err != nil {
-						result.Close()
-						return HandleErrorRespectJSON("iter comments %s: %v", issue.ID, err)
-					}
-				}
-
-				// Compute parent from dependencies.
-				for _, dep := range details.Dependencies {
-					if dep.DependencyType == types.DepParentChild {
-						details.Parent = &dep.ID
-						break
+					if total > 0 {
+						details.EpicTotalChildren = &total
+						details.EpicClosedChildren = &closed
+						closeable := total == closed
+						details.EpicCloseable = &closeable
 					}
 				}
-				allDetails = append(allDetails, details)
-				result.Close()
-				continue
-			}
-			if idx > 0 {
-				fmt.Println("\n" + ui.RenderMuted(strings.Repeat("─", 60)))
-				fmt.Printf("\n%s\n", formatIssueHeader(issue))
-			} else {
-				fmt.Printf("%s\n", formatIssueHeader(issue))
 			}
 
-			// Metadata: Owner · Type | Created · Updated
-			fmt.Println(formatIssueMetadata(issue))
-
-			// Compaction info (if applicable)
-			if issue.CompactionLevel > 0 {
-				fmt.Println()
-				if issue.OriginalSize > 0 {
-					currentSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
-					saved := issue.OriginalSize - currentSize
-					if saved > 0 {
-						reduction := float64(saved) / float64(issue.OriginalSize) * 100
-						fmt.Printf("📊 %d → %d bytes (%.0f%% reduction)\n",
-							issue.OriginalSize, currentSize, reduction)
-					}
+			// --include-comments: stream via Iter.
+			// May be slow on issues with many comments.
+			if opts.includeComments {
+				iter, err := issueStore.IterIssueComments(ctx, issue.ID)
+				if err != nil {
+					result.Close()
+					return HandleErrorRespectJSON("iter comments %s: %v", issue.ID, err)
+				}
+				defer iter.Close() //nolint:errcheck
+				for iter.Next(ctx) {
+					details.Comments = append(details.Comments, iter.Value())
+				}
+				if err := iter.Err(); err != nil {
+					result.Close()
+					return HandleErrorRespectJSON("iter comments %s: %v", issue.ID, err)
 				}
 			}
 
-			// Content sections — always show DESCRIPTION header so the user
-			// can distinguish "empty" from "hidden" (GH#3336).
-			if issue.Description != "" {
-				fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), uimd.RenderMarkdown(issue.Description))
-			} else {
-				fmt.Printf("\n%s\n  %s\n", ui.RenderBold("DESCRIPTION"), ui.RenderMuted("(none)"))
-			}
-			if issue.Design != "" {
-				fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), uimd.RenderMarkdown(issue.Design))
+			if rs, ok := storage.UnwrapStore(issueStore).(storage.RunStore); ok {
+				details.Runs, _ = rs.ListRuns(ctx, issue.ID, showRunsLimit)
 			}
-			if issue.Notes != "" {
-				fmt.Printf("\n%s\n%s\n", ui.RenderBold("NOTES"), uimd.RenderMarkdown(issue.Notes))
-			}
-			if issue.AcceptanceCriteria != "" {
-				fmt.Printf("\n%s\n%s\n", ui.RenderBold("ACCEPTANCE CRITERIA"), uimd.RenderMarkdown(issue.AcceptanceCriteria))
+			details.Slug = currentIssueSlug(ctx, issueStore, issue.ID)
+			details.Lock = currentIssueLock(ctx, issueStore, issue.ID)
+
+			// Compute parent from dependencies.
+			for _, dep := range details.Dependencies {
+				if dep.DependencyType == types.DepParentChild {
+					details.Parent = &dep.ID
+					break
+				}
 			}
+			allDetails = append(allDetails, details)
+			result.Close()
+			continue
+		}
+		if idx > 0 {
+			fmt.Println("\n" + ui.RenderMuted(strings.Repeat("─", 60)))
+			fmt.Printf("\n%s\n", formatIssueHeader(issue))
+		} else {
+			fmt.Printf("%s\n", formatIssueHeader(issue))
+		}
 
-			// Show labels
-			labels, _ := issueStore.GetLabels(ctx, issue.ID) // Best effort: show issue even if label fetch fails
-			if len(labels) > 0 {
-				fmt.Printf("\n%s %s\n", ui.RenderBold("LABELS:"), strings.Join(labels, ", "))
-			}
+		// Metadata: Owner · Type | Created · Updated
+		fmt.Println(formatIssueMetadata(issue))
+
+		if slug := currentIssueSlug(ctx, issueStore, issue.ID); slug != "" {
+			fmt.Println(ui.RenderMuted("Slug: " + slug))
+		}
+		if lock := currentIssueLock(ctx, issueStore, issue.ID); lock != nil {
+			fmt.Println(ui.RenderWarn("🔒 " + formatIssueLock(lock, time.Now())))
+		}
+
+		// Stored summary (bd summarize) leads the content
+		if s := formatIssueSummary(issue); s != "" {
+			fmt.Printf("\n%s\n", s)
+		}
 
-			// Show custom metadata (GH#1406)
-			if metaStr := formatIssueCustomMetadata(issue); metaStr != "" {
-				fmt.Printf("\n%s\n", metaStr)
+		// Compaction info (if applicable)
+		if issue.CompactionLevel > 0 {
+			fmt.Println()
+			if issue.OriginalSize > 0 {
+				currentSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
+				saved := issue.OriginalSize - currentSize
+				if saved > 0 {
+					reduction := float64(saved) / float64(issue.OriginalSize) * 100
+					fmt.Printf("📊 %d → %d bytes (%.0f%% reduction)\n",
+						issue.OriginalSize, currentSize, reduction)
+				}
 			}
+		}
 
-			// Collect related issues from both directions for deduplication
-			// (relates-to is bidirectional, so we merge and show once)
-			relatedSeen := make(map[string]*types.IssueWithDependencyMetadata)
-
-			// Show dependencies - grouped by dependency type for clarity
-			depsWithMeta, _ := issueStore.GetDependenciesWithMetadata(ctx, issue.ID) // Best effort: show issue even if deps unavailable
-
-			if len(depsWithMeta) > 0 {
-				// Group by dependency type
-				var blocks, parent, discovered []*types.IssueWithDependencyMetadata
-				for _, dep := range depsWithMeta {
-					switch dep.DependencyType {
-					case types.DepBlocks:
-						blocks = append(blocks, dep)
-					case types.DepParentChild:
-						parent = append(parent, dep)
-					case types.DepRelated, types.DepRelatesTo:
-						relatedSeen[dep.ID] = dep
-					case types.DepDiscoveredFrom:
-						discovered = append(discovered, dep)
-					default:
-						blocks = append(blocks, dep) // Default to blocks
-					}
+		// Content sections — always show DESCRIPTION header so the user
+		// can distinguish "empty" from "hidden" (GH#3336).
+		if issue.Description != "" {
+			fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), renderShowMarkdown(issue.Description))
+		} else {
+			fmt.Printf("\n%s\n  %s\n", ui.RenderBold("DESCRIPTION"), ui.RenderMuted("(none)"))
+		}
+		if issue.Design != "" {
+			fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), renderShowMarkdown(issue.Design))
+		}
+		if issue.Notes != "" {
+			fmt.Printf("\n%s\n%s\n", ui.RenderBold("NOTES"), renderShowMarkdown(issue.Notes))
+		}
+		if issue.AcceptanceCriteria != "" {
+			fmt.Printf("\n%s\n%s\n", formatAcceptanceHeading(issue), renderShowMarkdown(issue.AcceptanceCriteria))
+		}
+
+		// Show labels
+		labels, _ := issueStore.GetLabels(ctx, issue.ID) // Best effort: show issue even if label fetch fails
+		if len(labels) > 0 {
+			fmt.Printf("\n%s %s\n", ui.RenderBold("LABELS:"), strings.Join(labels, ", "))
+		}
+
+		// Show custom metadata (GH#1406)
+		if metaStr := formatIssueCustomMetadata(issue); metaStr != "" {
+			fmt.Printf("\n%s\n", metaStr)
+		}
+
+		// Collect related issues from both directions for deduplication
+		// (relates-to is bidirectional, so we merge and show once)
+		relatedSeen := make(map[string]*types.IssueWithDependencyMetadata)
+
+		// Show dependencies - grouped by dependency type for clarity
+		depsWithMeta, _ := issueStore.GetDependenciesWithMetadata(ctx, issue.ID) // Best effort: show issue even if deps unavailable
+
+		if len(depsWithMeta) > 0 {
+			// Group by dependency type
+			var blocks, parent, discovered []*types.IssueWithDependencyMetadata
+			for _, dep := range depsWithMeta {
+				switch dep.DependencyType {
+				case types.DepBlocks:
+					blocks = append(blocks, dep)
+				case types.DepParentChild:
+					parent = append(parent, dep)
+				case types.DepRelated, types.DepRelatesTo:
+					relatedSeen[dep.ID] = dep
+				case types.DepDiscoveredFrom:
+					discovered = append(discovered, dep)
+				default:
+					blocks = append(blocks, dep) // Default to blocks
 				}
+			}
 
-				if len(parent) > 0 {
-					fmt.Printf("\n%s\n", ui.RenderBold("PARENT"))
-					for _, dep := range parent {
-						fmt.Println(formatDependencyLine("↑", dep))
-					}
+			if len(parent) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("PARENT"))
+				for _, dep := range parent {
+					fmt.Println(formatDependencyLine("↑", dep))
 				}
-				if len(blocks) > 0 {
-					fmt.Printf("\n%s\n", ui.RenderBold("DEPENDS ON"))
-					for _, dep := range blocks {
-						fmt.Println(formatDependencyLine("→", dep))
-					}
+			}
+			if len(blocks) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("DEPENDS ON"))
+				for _, dep := range blocks {
+					fmt.Println(formatDependencyLine("→", dep))
 				}
-				if len(discovered) > 0 {
-					fmt.Printf("\n%s\n", ui.RenderBold("DISCOVERED FROM"))
-					for _, dep := range discovered {
-						fmt.Println(formatDependencyLine("◊", dep))
-					}
+			}
+			if len(discovered) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("DISCOVERED FROM"))
+				for _, dep := range discovered {
+					fmt.Println(formatDependencyLine("◊", dep))
 				}
 			}
+		}
 
-			// Show dependents - grouped by dependency type for clarity
-			dependentsWithMeta, _ := issueStore.GetDependentsWithMetadata(ctx, issue.ID) // Best effort: show issue even if dependents unavailable
-			if len(dependentsWithMeta) > 0 {
-				// Group by dependency type
-				var blocks, children, discovered []*types.IssueWithDependencyMetadata
-				for _, dep := range dependentsWithMeta {
-					switch dep.DependencyType {
-					case types.DepBlocks:
-						blocks = append(blocks, dep)
-					case types.DepParentChild:
-						children = append(children, dep)
-					case types.DepRelated, types.DepRelatesTo:
-						relatedSeen[dep.ID] = dep
-					case types.DepDiscoveredFrom:
-						discovered = append(discovered, dep)
-					default:
-						blocks = append(blocks, dep) // Default to blocks
-					}
+		// Show dependents - grouped by dependency type for clarity
+		dependentsWithMeta, _ := issueStore.GetDependentsWithMetadata(ctx, issue.ID) // Best effort: show issue even if dependents unavailable
+		if len(dependentsWithMeta) > 0 {
+			// Group by dependency type
+			var blocks, children, discovered []*types.IssueWithDependencyMetadata
+			for _, dep := range dependentsWithMeta {
+				switch dep.DependencyType {
+				case types.DepBlocks:
+					blocks = append(blocks, dep)
+				case types.DepParentChild:
+					children = append(children, dep)
+				case types.DepRelated, types.DepRelatesTo:
+					relatedSeen[dep.ID] = dep
+				case types.DepDiscoveredFrom:
+					discovered = append(discovered, dep)
+				default:
+					blocks = append(blocks, dep) // Default to blocks
 				}
+			}
 
-				if len(children) > 0 {
-					fmt.Printf("\n%s\n", ui.RenderBold("CHILDREN"))
+			if len(children) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("CHILDREN"))
+				for _, dep := range children {
+					fmt.Println(formatDependencyLine("↳", dep))
+				}
+				// Epic progress summary
+				if issue.IssueType == types.TypeEpic {
+					closedCount := 0
 					for _, dep := range children {
-						fmt.Println(formatDependencyLine("↳", dep))
-					}
-					// Epic progress summary
-					if issue.IssueType == types.TypeEpic {
-						closedCount := 0
-						for _, dep := range children {
-							if dep.Issue.Status == types.StatusClosed {
-								closedCount++
-							}
-						}
-						pct := 0
-						if len(children) > 0 {
-							pct = (closedCount * 100) / len(children)
-						}
-						if closedCount == len(children) {
-							fmt.Printf("  %s %d/%d complete (%d%%) — eligible for close\n", ui.RenderPass("✓"), closedCount, len(children), pct)
-						} else {
-							fmt.Printf("  %s %d/%d complete (%d%%)\n", ui.RenderMuted("◐"), closedCount, len(children), pct)
+						if dep.Issue.Status == types.StatusClosed {
+							closedCount++
 						}
 					}
-				}
-				if len(blocks) > 0 {
-					fmt.Printf("\n%s\n", ui.RenderBold("BLOCKS"))
-					for _, dep := range blocks {
-						fmt.Println(formatDependencyLine("←", dep))
+					pct := 0
+					if len(children) > 0 {
+						pct = (closedCount * 100) / len(children)
 					}
-				}
-				if len(discovered) > 0 {
-					fmt.Printf("\n%s\n", ui.RenderBold("DISCOVERED"))
-					for _, dep := range discovered {
-						fmt.Println(formatDependencyLine("◊", dep))
+					if closedCount == len(children) {
+						fmt.Printf("  %s %d/%d complete (%d%%) — eligible for close\n", ui.RenderPass("✓"), closedCount, len(children), pct)
+					} else {
+						fmt.Printf("  %s %d/%d complete (%d%%)\n", ui.RenderMuted("◐"), closedCount, len(children), pct)
 					}
 				}
 			}
-
-			// Print deduplicated RELATED section (bidirectional links shown once)
-			if len(relatedSeen) > 0 {
-				fmt.Printf("\n%s\n", ui.RenderBold("RELATED"))
-				for _, dep := range relatedSeen {
-					fmt.Println(formatDependencyLine("↔", dep))
+			if len(blocks) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("BLOCKS"))
+				for _, dep := range blocks {
+					fmt.Println(formatDependencyLine("←", dep))
 				}
 			}
-
-			// Show comments
-			comments, _ := issueStore.GetIssueComments(ctx, issue.ID) // Best effort: show issue even if comments unavailable
-			if len(comments) > 0 {
-				fmt.Printf("\n%s\n", ui.RenderBold("COMMENTS"))
-				for _, comment := range comments {
-					fmt.Printf("  %s %s\n", ui.RenderMuted(formatTime(comment.CreatedAt)), comment.Author)
-					rendered := uimd.RenderMarkdown(comment.Text)
-					// TrimRight removes trailing newlines that Glamour adds, preventing extra blank lines
-					for _, line := range strings.Split(strings.TrimRight(rendered, "\n"), "\n") {
-						fmt.Printf("    %s\n", line)
-					}
+			if len(discovered) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("DISCOVERED"))
+				for _, dep := range discovered {
+					fmt.Println(formatDependencyLine("◊", dep))
 				}
 			}
+		}
 
-			// Long mode: show all extended fields
-			if longMode {
-				fmt.Print(formatIssueLongExtras(issue, formatTime))
+		// Print deduplicated RELATED section (bidirectional links shown once)
+		if len(relatedSeen) > 0 {
+			fmt.Printf("\n%s\n", ui.RenderBold("RELATED"))
+			for _, dep := range relatedSeen {
+				fmt.Println(formatDependencyLine("↔", dep))
 			}
-
-			fmt.Println()
-			result.Close() // Close routed storage after each iteration
 		}
 
-		if jsonOutput {
-			if len(allDetails) > 0 {
-				if jerr := outputJSON(allDetails); jerr != nil {
-					return jerr
+		// Show comments
+		comments, _ := issueStore.GetIssueComments(ctx, issue.ID) // Best effort: show issue even if comments unavailable
+		if len(comments) > 0 {
+			fmt.Printf("\n%s\n", ui.RenderBold("COMMENTS"))
+			for _, comment := range comments {
+				fmt.Printf("  %s %s\n", ui.RenderMuted(formatTime(comment.CreatedAt)), comment.Author)
+				rendered := renderShowMarkdown(comment.Text)
+				// TrimRight removes trailing newlines that Glamour adds, preventing extra blank lines
+				for _, line := range strings.Split(strings.TrimRight(rendered, "\n"), "\n") {
+					fmt.Printf("    %s\n", line)
 				}
-			} else {
-				return HandleErrorRespectJSON("no issues found matching the provided IDs")
 			}
-		} else if foundCount > 0 {
-			maybeShowTip(store)
-		} else {
-			if len(args) > 0 {
-				SetLastTouchedID(args[0])
+		}
+
+		// Show recent external job runs
+		if rs, ok := storage.UnwrapStore(issueStore).(storage.RunStore); ok {
+			runs, _ := rs.ListRuns(ctx, issue.ID, showRunsLimit) // Best effort: show issue even if runs unavailable
+			if len(runs) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("RUNS"))
+				for _, run := range runs {
+					fmt.Println(formatRunLine(run))
+				}
 			}
-			return SilentExit()
 		}
 
+		// Long mode: show all extended fields
+		if opts.long {
+			fmt.Print(formatIssueLongExtras(issue, formatTime))
+		}
+
+		fmt.Println()
+		result.Close() // Close routed storage after each iteration
+	}
+
+	if jsonOutput {
+		if len(allDetails) > 0 {
+			if jerr := outputJSON(allDetails); jerr != nil {
+				return jerr
+			}
+		} else {
+			return HandleErrorRespectJSON("no issues found matching the provided IDs")
+		}
+	} else if foundCount > 0 {
+		maybeShowTip(store)
+	} else {
 		if len(args) > 0 {
 			SetLastTouchedID(args[0])
 		}
-		return nil
-	},
+		return SilentExitWithCode(ErrNotFound)
+	}
+
+	if len(args) > 0 {
+		SetLastTouchedID(args[0])
+	}
+	return nil
 }
 
 // shallowDependentsForJSON returns a copy of raw with each embedded Issue
@@ -484,9 +550,11 @@ func init() {
 	showCmd.Flags().Bool("long", false, "Show all available fields (extended metadata, agent identity, gate fields, etc.)")
 	showCmd.Flags().Bool("refs", false, "Show issues that reference this issue (reverse lookup)")
 	showCmd.Flags().Bool("children", false, "Show only the children of this issue")
-	showCmd.Flags().String("as-of", "", "Show issue as it existed at a specific commit hash or branch (requires Dolt)")
+	showCmd.Flags().String("as-of", "", "Show issue as it existed at a specific commit hash, branch, or date (requires Dolt)")
 	showCmd.Flags().StringArray("id", nil, "Issue ID (use for IDs that look like flags, e.g., --id=gt--xyz)")
 	showCmd.Flags().Bool("local-time", false, "Show timestamps in local time instead of UTC")
+	showCmd.Flags().Bool("raw", false, "Print descriptions, notes, and comments as markdown source instead of rendering them")
+	showCmd.Flags().Bool("no-pager", false, "Disable pager output")
 	showCmd.Flags().BoolP("watch", "w", false, "Watch for changes and auto-refresh display")
 	showCmd.Flags().Bool("current", false, "Show the currently active issue (in-progress, hooked, or last touched)")
 	showCmd.Flags().Bool("include-dependents", false, "Stream full dependent issues in JSON output (--json only; may be slow on hub beads)")
@@ -9,7 +9,6 @@ import (
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
-	"github.com/steveyegge/beads/internal/uimd"
 )
 
 // showIssueChildren displays only the children of the specified issue(s)
@@ -83,7 +82,7 @@ func showIssueChildren(ctx context.Context, args []string, jsonOut bool, shortMo
 	return nil
 }
 
-// showIssueAsOf displays issues as they existed at a specific commit or branch ref.
+// showIssueAsOf displays issues as they existed at a specific commit, branch ref, or date.
 // This requires a versioned storage backend (e.g., Dolt).
 func showIssueAsOf(ctx context.Context, args []string, ref string, shortMode bool) error {
 	var allIssues []*types.Issue
@@ -117,7 +116,7 @@ func showIssueAsOf(ctx context.Context, args []string, ref string, shortMode boo
 		fmt.Println(formatIssueMetadata(issue))
 
 		if issue.Description != "" {
-			fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), uimd.RenderMarkdown(issue.Description))
+			fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), renderShowMarkdown(issue.Description))
 		}
 		fmt.Println()
 	}
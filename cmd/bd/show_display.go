@@ -14,6 +14,18 @@ import (
 	"github.com/steveyegge/beads/internal/uimd"
 )
 
+// showRawMarkdown is set by bd show --raw: print descriptions, notes, and
+// comments as their markdown source instead of rendering them.
+var showRawMarkdown bool
+
+// renderShowMarkdown renders issue text for bd show, honoring --raw.
+func renderShowMarkdown(text string) string {
+	if showRawMarkdown {
+		return strings.TrimRight(text, "\n")
+	}
+	return uimd.RenderMarkdown(text)
+}
+
 // displayShowIssue displays a single issue (reusable for watch mode).
 // Matches the full bd show output: header, metadata, content, labels, deps, comments.
 func displayShowIssue(ctx context.Context, issueID string) {
@@ -100,19 +112,22 @@ func displayShowIssueReturn(ctx context.Context, issueID string) *types.Issue {
 	// Display the issue header and metadata
 	fmt.Println(formatIssueHeader(issue))
 	fmt.Println(formatIssueMetadata(issue))
+	if s := formatIssueSummary(issue); s != "" {
+		fmt.Printf("\n%s\n", s)
+	}
 
 	// Content sections (matches standard bd show order)
 	if issue.Description != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), uimd.RenderMarkdown(issue.Description))
+		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), renderShowMarkdown(issue.Description))
 	}
 	if issue.Design != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), uimd.RenderMarkdown(issue.Design))
+		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), renderShowMarkdown(issue.Design))
 	}
 	if issue.Notes != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("NOTES"), uimd.RenderMarkdown(issue.Notes))
+		fmt.Printf("\n%s\n%s\n", ui.RenderBold("NOTES"), renderShowMarkdown(issue.Notes))
 	}
 	if issue.AcceptanceCriteria != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("ACCEPTANCE CRITERIA"), uimd.RenderMarkdown(issue.AcceptanceCriteria))
+		fmt.Printf("\n%s\n%s\n", formatAcceptanceHeading(issue), renderShowMarkdown(issue.AcceptanceCriteria))
 	}
 
 	// Labels
@@ -213,7 +228,7 @@ func displayShowIssueReturn(ctx context.Context, issueID string) *types.Issue {
 		fmt.Printf("\n%s\n", ui.RenderBold("COMMENTS"))
 		for _, comment := range comments {
 			fmt.Printf("  %s %s\n", ui.RenderMuted(comment.CreatedAt.UTC().Format("2006-01-02 15:04")), comment.Author)
-			rendered := uimd.RenderMarkdown(comment.Text)
+			rendered := renderShowMarkdown(comment.Text)
 			for _, line := range strings.Split(strings.TrimRight(rendered, "\n"), "\n") {
 				fmt.Printf("    %s\n", line)
 			}
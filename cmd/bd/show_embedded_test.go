@@ -298,6 +298,17 @@ func TestEmbeddedShow(t *testing.T) {
 		}
 	})
 
+	// ===== --raw =====
+
+	t.Run("show_raw_markdown", func(t *testing.T) {
+		desc := "# Plan\n\n```go\nfunc main() {}\n```\n\n| A | B |\n|---|---|\n| 1 | 2 |"
+		issue := bdCreate(t, bd, dir, "Raw markdown", "--type", "task", "--description", desc)
+		out := bdShowRaw(t, bd, dir, issue.ID, "--raw")
+		if !strings.Contains(out, desc) {
+			t.Errorf("expected markdown source verbatim with --raw: %s", out)
+		}
+	})
+
 	// ===== --current =====
 
 	t.Run("show_current_fallback_to_last_touched", func(t *testing.T) {
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/summary"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -146,6 +148,16 @@ func formatIssueMetadata(issue *types.Issue) string {
 		lines = append(lines, fmt.Sprintf("Spec: %s", issue.SpecID))
 	}
 
+	// Encrypted fields: say when they could not be decrypted, so ciphertext
+	// below is not mistaken for content.
+	if issue.Encrypted {
+		if storage.IsEncryptedValue(issue.Description) || storage.IsEncryptedValue(issue.Design) || storage.IsEncryptedValue(issue.Notes) {
+			lines = append(lines, ui.RenderWarn("Encrypted: key unavailable or wrong ("+storage.IssueKeyFile+")"))
+		} else {
+			lines = append(lines, ui.RenderMuted("Encrypted: description, design, notes"))
+		}
+	}
+
 	// Line 5: Wisp type (if ephemeral with classification)
 	if issue.Ephemeral && issue.WispType != "" {
 		lines = append(lines, fmt.Sprintf("Wisp type: %s", ui.RenderMuted(string(issue.WispType))))
@@ -211,6 +223,7 @@ func formatSimpleDependencyLine(prefix string, dep *types.Issue) string {
 // for bd show output. Returns empty string if no metadata is set.
 // Top-level keys are displayed sorted alphabetically, one per line.
 // Scalar values are shown inline; objects/arrays are shown as compact JSON.
+// A stored summary (see formatIssueSummary) is skipped; it has its own section.
 func formatIssueCustomMetadata(issue *types.Issue) string {
 	if len(issue.Metadata) == 0 {
 		return ""
@@ -226,6 +239,9 @@ func formatIssueCustomMetadata(issue *types.Issue) string {
 		// Not a JSON object — show raw value
 		return fmt.Sprintf("%s\n  %s", ui.RenderBold("METADATA"), trimmed)
 	}
+	if s, _ := summary.FromMetadata(issue.Metadata); s != nil {
+		delete(data, summary.MetadataKey)
+	}
 	if len(data) == 0 {
 		return ""
 	}
@@ -265,6 +281,9 @@ func formatIssueLongExtras(issue *types.Issue, formatTime func(time.Time) string
 	if issue.SourceSystem != "" {
 		closeParts = append(closeParts, fmt.Sprintf("  Source system: %s", issue.SourceSystem))
 	}
+	if issue.Rig != "" {
+		closeParts = append(closeParts, fmt.Sprintf("  Rig: %s", issue.Rig))
+	}
 	if issue.Sender != "" {
 		closeParts = append(closeParts, fmt.Sprintf("  Sender: %s", issue.Sender))
 	}
@@ -263,3 +263,16 @@ func TestFormatIssueLong_EmptyMetadata(t *testing.T) {
 		t.Errorf("expected no Metadata line for empty metadata, got: %q", result)
 	}
 }
+
+func TestFormatIssueCustomMetadata_SkipsSummary(t *testing.T) {
+	t.Parallel()
+	issue := &types.Issue{Metadata: json.RawMessage(`{"summary":{"text":"short","scope":"issue"},"team":"platform"}`)}
+	result := formatIssueCustomMetadata(issue)
+	if strings.Contains(result, "summary") || !strings.Contains(result, "team: platform") {
+		t.Errorf("expected summary to be skipped, got: %q", result)
+	}
+	issue = &types.Issue{Metadata: json.RawMessage(`{"summary":{"text":"short","scope":"issue"}}`)}
+	if result := formatIssueCustomMetadata(issue); result != "" {
+		t.Errorf("expected empty string with only a summary, got: %q", result)
+	}
+}
@@ -16,7 +16,6 @@ import (
 	"github.com/steveyegge/beads/internal/storage/uow"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
-	"github.com/steveyegge/beads/internal/uimd"
 )
 
 type showProxiedInput struct {
@@ -195,7 +194,7 @@ func runShowProxiedAsOf(ctx context.Context, uw uow.UnitOfWork, in *showProxiedI
 		fmt.Printf("\n%s (as of %s)\n", formatIssueHeader(issue), ui.RenderMuted(in.asOfRef))
 		fmt.Println(formatIssueMetadata(issue))
 		if issue.Description != "" {
-			fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), uimd.RenderMarkdown(issue.Description))
+			fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), renderShowMarkdown(issue.Description))
 		}
 		fmt.Println()
 	}
@@ -470,7 +469,7 @@ func runShowProxiedDefault(ctx context.Context, uw uow.UnitOfWork, in *showProxi
 			return HandleErrorRespectJSON("no issues found matching the provided IDs")
 		}
 	} else if foundCount == 0 {
-		return SilentExit()
+		return SilentExitWithCode(ErrNotFound)
 	}
 	return nil
 }
@@ -557,6 +556,9 @@ func proxiedRenderIssue(ctx context.Context, uw uow.UnitOfWork, issue *types.Iss
 		fmt.Printf("%s\n", formatIssueHeader(issue))
 	}
 	fmt.Println(formatIssueMetadata(issue))
+	if s := formatIssueSummary(issue); s != "" {
+		fmt.Printf("\n%s\n", s)
+	}
 
 	if issue.CompactionLevel > 0 && issue.OriginalSize > 0 {
 		currentSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
@@ -569,18 +571,18 @@ func proxiedRenderIssue(ctx context.Context, uw uow.UnitOfWork, issue *types.Iss
 	}
 
 	if issue.Description != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), uimd.RenderMarkdown(issue.Description))
+		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), renderShowMarkdown(issue.Description))
 	} else {
 		fmt.Printf("\n%s\n  %s\n", ui.RenderBold("DESCRIPTION"), ui.RenderMuted("(none)"))
 	}
 	if issue.Design != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), uimd.RenderMarkdown(issue.Design))
+		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), renderShowMarkdown(issue.Design))
 	}
 	if issue.Notes != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("NOTES"), uimd.RenderMarkdown(issue.Notes))
+		fmt.Printf("\n%s\n%s\n", ui.RenderBold("NOTES"), renderShowMarkdown(issue.Notes))
 	}
 	if issue.AcceptanceCriteria != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("ACCEPTANCE CRITERIA"), uimd.RenderMarkdown(issue.AcceptanceCriteria))
+		fmt.Printf("\n%s\n%s\n", formatAcceptanceHeading(issue), renderShowMarkdown(issue.AcceptanceCriteria))
 	}
 
 	var labels []string
@@ -707,7 +709,7 @@ func proxiedRenderIssue(ctx context.Context, uw uow.UnitOfWork, issue *types.Iss
 		fmt.Printf("\n%s\n", ui.RenderBold("COMMENTS"))
 		for _, c := range comments {
 			fmt.Printf("  %s %s\n", ui.RenderMuted(formatTime(c.CreatedAt)), c.Author)
-			rendered := uimd.RenderMarkdown(c.Text)
+			rendered := renderShowMarkdown(c.Text)
 			for _, line := range strings.Split(strings.TrimRight(rendered, "\n"), "\n") {
 				fmt.Printf("    %s\n", line)
 			}
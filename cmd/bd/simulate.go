@@ -0,0 +1,488 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// simChange is one hypothetical edit applied by bd simulate.
+type simChange struct {
+	Op       string `json:"op"` // close, reopen, priority, dep, undep
+	ID       string `json:"id"`
+	Other    string `json:"other,omitempty"` // dep/undep: the blocker
+	Priority int    `json:"priority,omitempty"`
+}
+
+func (c simChange) String() string {
+	switch c.Op {
+	case "priority":
+		return fmt.Sprintf("priority %s P%d", c.ID, c.Priority)
+	case "dep":
+		return fmt.Sprintf("dep %s blocked by %s", c.ID, c.Other)
+	case "undep":
+		return fmt.Sprintf("undep %s from %s", c.ID, c.Other)
+	}
+	return c.Op + " " + c.ID
+}
+
+// simSnapshot is the planning state bd simulate compares before and after.
+type simSnapshot struct {
+	Ready        []string `json:"ready"`
+	CriticalPath []string `json:"critical_path"`
+}
+
+// simResult is the report printed by bd simulate.
+type simResult struct {
+	Branch        string      `json:"branch"`
+	Changes       []simChange `json:"changes"`
+	Before        simSnapshot `json:"before"`
+	After         simSnapshot `json:"after"`
+	NowReady      []string    `json:"now_ready"`
+	NoLongerReady []string    `json:"no_longer_ready"`
+	Promoted      bool        `json:"promoted"`
+	Kept          bool        `json:"kept"`
+}
+
+var simulateCmd = &cobra.Command{
+	Use:     "simulate",
+	GroupID: "views",
+	Short:   "Preview the effect of planning changes on a scratch branch",
+	Long: `Apply a batch of hypothetical changes on a scratch Dolt branch and report
+how they move the ready set and the critical path (the longest chain of open
+issues linked by blocking dependencies). The live branch is not touched: the
+scratch branch is discarded afterwards unless --keep or --promote is given.
+
+Changes come from flags or from a plan file (--file, '-' for stdin) with one
+change per line:
+
+  close <id>
+  reopen <id>
+  priority <id> <0-4>
+  dep <id> <blocker>      # <id> becomes blocked by <blocker>
+  undep <id> <blocker>
+
+Examples:
+  bd simulate --close bd-12 --dep bd-20:bd-15
+  bd simulate --priority bd-7=0 --keep
+  bd simulate --file plan.txt --promote`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("simulate")
+		if !isEmbeddedMode() {
+			// Pooled server connections do not follow a branch checkout, so
+			// the hypothetical writes could land on the live branch.
+			return HandleErrorRespectJSON("simulate is only supported in embedded mode")
+		}
+		evt := metrics.NewCommandEvent("simulate")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+
+		changes, err := simChangesFromFlags(cmd)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if len(changes) == 0 {
+			return HandleErrorRespectJSON("no changes to simulate (use --close, --reopen, --priority, --dep, --undep, or --file)")
+		}
+		for i := range changes {
+			if err := resolveSimChange(ctx, &changes[i]); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+		}
+		promote, _ := cmd.Flags().GetBool("promote")
+		keep, _ := cmd.Flags().GetBool("keep")
+
+		result, err := runSimulation(ctx, store, changes, promote, keep)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if promote {
+			commandDidWrite.Store(true)
+		}
+
+		if jsonOutput {
+			return outputJSON(result)
+		}
+		printSimulation(result)
+		return nil
+	},
+}
+
+// runSimulation applies changes on a scratch branch and reports the planning
+// delta. The original branch is checked out again before returning, whatever
+// happens on the scratch branch.
+func runSimulation(ctx context.Context, s storage.DoltStorage, changes []simChange, promote, keep bool) (result *simResult, retErr error) {
+	if st, err := s.Status(ctx); err == nil && (len(st.Staged) > 0 || len(st.Unstaged) > 0) {
+		return nil, fmt.Errorf("working set has uncommitted changes; run 'bd vc commit' first")
+	}
+	orig, err := s.CurrentBranch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading current branch: %w", err)
+	}
+	before, err := takeSimSnapshot(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	scratch := "simulate-" + time.Now().UTC().Format("20060102-150405")
+	if err := s.Branch(ctx, scratch); err != nil {
+		return nil, fmt.Errorf("creating scratch branch: %w", err)
+	}
+	if err := s.Checkout(ctx, scratch); err != nil {
+		_ = s.DeleteBranch(ctx, scratch)
+		return nil, fmt.Errorf("switching to scratch branch: %w", err)
+	}
+	onScratch := true
+	defer func() {
+		if onScratch {
+			// Commit whatever a failed change left behind so the dirty
+			// working set does not follow us back to the live branch.
+			_ = s.Commit(ctx, "bd simulate: aborted")
+			if err := s.Checkout(ctx, orig); err != nil {
+				if retErr == nil {
+					retErr = fmt.Errorf("returning to %s: %w (scratch branch %s kept)", orig, err, scratch)
+				}
+				return
+			}
+		}
+		if !keep {
+			if err := s.DeleteBranch(ctx, scratch); err != nil && retErr == nil {
+				retErr = fmt.Errorf("deleting scratch branch %s: %w", scratch, err)
+			}
+		}
+	}()
+
+	// Wisp tables are dolt-ignored and so never reach a new branch; the
+	// schema pass recreates them (empty) so writes that consult them work.
+	if m, ok := storage.UnwrapStore(s).(storage.SchemaMigrator); ok {
+		if _, err := m.ApplySchemaMigrations(ctx); err != nil {
+			return nil, fmt.Errorf("preparing scratch branch: %w", err)
+		}
+	}
+	for _, c := range changes {
+		if err := applySimChange(ctx, s, c); err != nil {
+			return nil, fmt.Errorf("%s: %w", c, err)
+		}
+	}
+	after, err := takeSimSnapshot(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Commit(ctx, fmt.Sprintf("bd simulate: %d change(s)", len(changes))); err != nil && !isDoltNothingToCommit(err) {
+		return nil, fmt.Errorf("committing scratch branch: %w", err)
+	}
+
+	if err := s.Checkout(ctx, orig); err != nil {
+		return nil, fmt.Errorf("returning to %s: %w (scratch branch %s kept)", orig, err, scratch)
+	}
+	onScratch = false
+
+	result = &simResult{
+		Branch:        scratch,
+		Changes:       changes,
+		Before:        *before,
+		After:         *after,
+		NowReady:      subtractIDs(after.Ready, before.Ready),
+		NoLongerReady: subtractIDs(before.Ready, after.Ready),
+		Kept:          keep,
+	}
+	if promote {
+		conflicts, err := s.Merge(ctx, scratch)
+		if err != nil {
+			keep = true
+			return nil, fmt.Errorf("promoting %s: %w (scratch branch kept)", scratch, err)
+		}
+		if len(conflicts) > 0 {
+			keep = true
+			return nil, fmt.Errorf("promoting %s: %d conflict(s); resolve with 'bd vc merge %s' (scratch branch kept)", scratch, len(conflicts), scratch)
+		}
+		result.Promoted = true
+	}
+	return result, nil
+}
+
+func applySimChange(ctx context.Context, s storage.DoltStorage, c simChange) error {
+	switch c.Op {
+	case "close":
+		return s.CloseIssue(ctx, c.ID, "simulated", actor, "")
+	case "reopen":
+		return s.ReopenIssue(ctx, c.ID, "simulated", actor)
+	case "priority":
+		return s.UpdateIssue(ctx, c.ID, map[string]interface{}{"priority": c.Priority}, actor)
+	case "dep":
+		return s.AddDependency(ctx, &types.Dependency{IssueID: c.ID, DependsOnID: c.Other, Type: types.DepBlocks}, actor)
+	case "undep":
+		return s.RemoveDependency(ctx, c.ID, c.Other, actor)
+	}
+	return fmt.Errorf("unknown change %q", c.Op)
+}
+
+// takeSimSnapshot records the ready set and critical path of the current branch.
+func takeSimSnapshot(ctx context.Context, s storage.DoltStorage) (*simSnapshot, error) {
+	ready, err := s.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("reading ready work: %w", err)
+	}
+	snap := &simSnapshot{Ready: []string{}}
+	for _, issue := range ready {
+		snap.Ready = append(snap.Ready, issue.ID)
+	}
+	sort.Strings(snap.Ready)
+
+	var open []*types.Issue
+	for _, status := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked} {
+		statusCopy := status
+		issues, err := s.SearchIssues(ctx, "", types.IssueFilter{Status: &statusCopy})
+		if err != nil {
+			return nil, fmt.Errorf("reading open issues: %w", err)
+		}
+		open = append(open, issues...)
+	}
+	blockers := make(map[string][]string)
+	for _, issue := range open {
+		deps, err := s.GetDependencyRecords(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("reading dependencies of %s: %w", issue.ID, err)
+		}
+		for _, dep := range deps {
+			if dep.Type == types.DepBlocks {
+				blockers[issue.ID] = append(blockers[issue.ID], dep.DependsOnID)
+			}
+		}
+	}
+	snap.CriticalPath = criticalPath(open, blockers)
+	return snap, nil
+}
+
+// criticalPath returns the longest chain of open issues linked by blocking
+// dependencies, first blocker first. Ties go to the lexically smallest chain
+// so the result is stable; cycles are cut where they are found.
+func criticalPath(open []*types.Issue, blockers map[string][]string) []string {
+	isOpen := make(map[string]bool, len(open))
+	ids := make([]string, 0, len(open))
+	for _, issue := range open {
+		isOpen[issue.ID] = true
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+
+	memo := make(map[string][]string)
+	visiting := make(map[string]bool)
+	var longest func(id string) []string
+	longest = func(id string) []string {
+		if chain, ok := memo[id]; ok {
+			return chain
+		}
+		visiting[id] = true
+		var best []string
+		deps := append([]string(nil), blockers[id]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if !isOpen[dep] || visiting[dep] {
+				continue
+			}
+			if chain := longest(dep); len(chain) > len(best) {
+				best = chain
+			}
+		}
+		visiting[id] = false
+		chain := append(append([]string(nil), best...), id)
+		memo[id] = chain
+		return chain
+	}
+
+	var best []string
+	for _, id := range ids {
+		if chain := longest(id); len(chain) > len(best) {
+			best = chain
+		}
+	}
+	if best == nil {
+		best = []string{}
+	}
+	return best
+}
+
+// subtractIDs returns the IDs in a that are not in b.
+func subtractIDs(a, b []string) []string {
+	seen := make(map[string]bool, len(b))
+	for _, id := range b {
+		seen[id] = true
+	}
+	out := []string{}
+	for _, id := range a {
+		if !seen[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// parseSimChange parses one plan line ("close bd-1", "dep bd-2 bd-3", ...).
+func parseSimChange(line string) (simChange, error) {
+	f := strings.Fields(line)
+	if len(f) == 0 {
+		return simChange{}, fmt.Errorf("empty change")
+	}
+	c := simChange{Op: strings.ToLower(f[0])}
+	want := map[string]int{"close": 2, "reopen": 2, "priority": 3, "dep": 3, "undep": 3}[c.Op]
+	if want == 0 {
+		return simChange{}, fmt.Errorf("unknown change %q (want close, reopen, priority, dep, or undep)", f[0])
+	}
+	if len(f) != want {
+		return simChange{}, fmt.Errorf("%q: expected %d fields", line, want)
+	}
+	c.ID = f[1]
+	switch c.Op {
+	case "priority":
+		p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(f[2]), "P"))
+		if err != nil || p < 0 || p > 4 {
+			return simChange{}, fmt.Errorf("%q: priority must be 0-4", line)
+		}
+		c.Priority = p
+	case "dep", "undep":
+		c.Other = f[2]
+	}
+	return c, nil
+}
+
+// simChangesFromFlags collects changes from the plan file and flags, in that order.
+func simChangesFromFlags(cmd *cobra.Command) ([]simChange, error) {
+	var lines []string
+	if path, _ := cmd.Flags().GetString("file"); path != "" {
+		in := os.Stdin
+		if path != "-" {
+			f, err := os.Open(path) // #nosec G304 -- user-supplied plan file
+			if err != nil {
+				return nil, fmt.Errorf("reading plan: %w", err)
+			}
+			defer f.Close()
+			in = f
+		}
+		sc := bufio.NewScanner(in)
+		for sc.Scan() {
+			line := strings.TrimSpace(sc.Text())
+			if i := strings.Index(line, "#"); i >= 0 {
+				line = strings.TrimSpace(line[:i])
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("reading plan: %w", err)
+		}
+	}
+	for _, op := range []string{"close", "reopen"} {
+		ids, _ := cmd.Flags().GetStringSlice(op)
+		for _, id := range ids {
+			lines = append(lines, op+" "+id)
+		}
+	}
+	for _, op := range []string{"priority", "dep", "undep"} {
+		sep := ":"
+		if op == "priority" {
+			sep = "="
+		}
+		specs, _ := cmd.Flags().GetStringArray(op)
+		for _, spec := range specs {
+			a, b, ok := strings.Cut(spec, sep)
+			if !ok {
+				return nil, fmt.Errorf("--%s %q: expected <id>%s<value>", op, spec, sep)
+			}
+			lines = append(lines, op+" "+a+" "+b)
+		}
+	}
+
+	changes := make([]simChange, 0, len(lines))
+	for _, line := range lines {
+		c, err := parseSimChange(line)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+func resolveSimChange(ctx context.Context, c *simChange) error {
+	id, err := utils.ResolvePartialID(ctx, store, c.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", c, err)
+	}
+	c.ID = id
+	if c.Other != "" {
+		other, err := utils.ResolvePartialID(ctx, store, c.Other)
+		if err != nil {
+			return fmt.Errorf("%s: %w", c, err)
+		}
+		c.Other = other
+	}
+	return nil
+}
+
+func printSimulation(r *simResult) {
+	fmt.Printf("\n%s Simulated %d change(s) on %s:\n", ui.RenderAccent("🧪"), len(r.Changes), r.Branch)
+	for _, c := range r.Changes {
+		fmt.Printf("  • %s\n", c)
+	}
+
+	fmt.Printf("\nReady: %d → %d\n", len(r.Before.Ready), len(r.After.Ready))
+	for _, id := range r.NowReady {
+		fmt.Printf("  %s %s\n", ui.RenderPass("+"), id)
+	}
+	for _, id := range r.NoLongerReady {
+		fmt.Printf("  %s %s\n", ui.RenderFail("-"), id)
+	}
+
+	fmt.Printf("\nCritical path: %d → %d\n", len(r.Before.CriticalPath), len(r.After.CriticalPath))
+	if strings.Join(r.Before.CriticalPath, " ") != strings.Join(r.After.CriticalPath, " ") {
+		fmt.Printf("  before: %s\n", strings.Join(r.Before.CriticalPath, " → "))
+	}
+	fmt.Printf("  after:  %s\n", strings.Join(r.After.CriticalPath, " → "))
+
+	fmt.Println()
+	switch {
+	case r.Promoted:
+		fmt.Printf("%s Promoted %s into the current branch\n", ui.RenderPass("✓"), r.Branch)
+	case r.Kept:
+		fmt.Printf("Scratch branch %s kept (bd vc merge %s to promote)\n", r.Branch, r.Branch)
+	default:
+		fmt.Println(ui.RenderMuted("Scratch branch discarded; live data unchanged."))
+	}
+}
+
+func init() {
+	simulateCmd.Flags().StringSlice("close", nil, "Close these issues")
+	simulateCmd.Flags().StringSlice("reopen", nil, "Reopen these issues")
+	simulateCmd.Flags().StringArray("priority", nil, "Change priority (<id>=<0-4>, repeatable)")
+	simulateCmd.Flags().StringArray("dep", nil, "Add a blocking dependency (<id>:<blocker>, repeatable)")
+	simulateCmd.Flags().StringArray("undep", nil, "Remove a dependency (<id>:<blocker>, repeatable)")
+	simulateCmd.Flags().String("file", "", "Read changes from a plan file ('-' for stdin)")
+	simulateCmd.Flags().Bool("promote", false, "Merge the scratch branch into the current branch")
+	simulateCmd.Flags().Bool("keep", false, "Keep the scratch branch instead of discarding it")
+	rootCmd.AddCommand(simulateCmd)
+}
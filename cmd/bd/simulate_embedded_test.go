@@ -0,0 +1,63 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedSimulate(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt simulate tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "sim")
+	a := bdCreateSilent(t, bd, dir, "Blocker")
+	b := bdCreateSilent(t, bd, dir, "Blocked")
+	c := bdCreateSilent(t, bd, dir, "Free")
+	bdDepAdd(t, bd, dir, b, a)
+
+	run := func(args ...string) []byte {
+		t.Helper()
+		cmd := exec.Command(bd, append([]string{"simulate", "--json"}, args...)...)
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("bd simulate %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return out
+	}
+
+	t.Run("discard", func(t *testing.T) {
+		var r simResult
+		if err := json.Unmarshal(run("--close", a, "--dep", c+":"+b), &r); err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if strings.Join(r.NowReady, ",") != b {
+			t.Errorf("now_ready = %v, want [%s]", r.NowReady, b)
+		}
+		if len(r.After.CriticalPath) != 2 || r.After.CriticalPath[1] != c {
+			t.Errorf("after critical path = %v, want [%s %s]", r.After.CriticalPath, b, c)
+		}
+		if got := bdShow(t, bd, dir, a); got.Status != "open" {
+			t.Errorf("live %s status = %s, want open", a, got.Status)
+		}
+		if out := bdBranch(t, bd, dir); strings.Contains(out, "simulate-") {
+			t.Errorf("scratch branch left behind:\n%s", out)
+		}
+	})
+
+	t.Run("promote", func(t *testing.T) {
+		run("--priority", c+"=0", "--promote")
+		if got := bdShow(t, bd, dir, c); got.Priority != 0 {
+			t.Errorf("promoted priority = %d, want 0", got.Priority)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseSimChange(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    simChange
+		wantErr bool
+	}{
+		{"close bd-1", simChange{Op: "close", ID: "bd-1"}, false},
+		{"reopen bd-1", simChange{Op: "reopen", ID: "bd-1"}, false},
+		{"priority bd-2 0", simChange{Op: "priority", ID: "bd-2", Priority: 0}, false},
+		{"PRIORITY bd-2 P3", simChange{Op: "priority", ID: "bd-2", Priority: 3}, false},
+		{"dep bd-3 bd-4", simChange{Op: "dep", ID: "bd-3", Other: "bd-4"}, false},
+		{"undep bd-3 bd-4", simChange{Op: "undep", ID: "bd-3", Other: "bd-4"}, false},
+		{"priority bd-2 9", simChange{}, true},
+		{"dep bd-3", simChange{}, true},
+		{"close", simChange{}, true},
+		{"delete bd-1", simChange{}, true},
+	}
+	for _, tc := range tests {
+		got, err := parseSimChange(tc.line)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseSimChange(%q) err = %v, wantErr %v", tc.line, err, tc.wantErr)
+			continue
+		}
+		if !tc.wantErr && got != tc.want {
+			t.Errorf("parseSimChange(%q) = %+v, want %+v", tc.line, got, tc.want)
+		}
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	open := []*types.Issue{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "e"}}
+	tests := []struct {
+		name     string
+		blockers map[string][]string
+		want     []string
+	}{
+		{"no deps", nil, []string{"a"}},
+		{"chain", map[string][]string{"c": {"b"}, "b": {"a"}, "e": {"d"}}, []string{"a", "b", "c"}},
+		{"closed blocker ignored", map[string][]string{"b": {"closed"}, "e": {"d"}}, []string{"d", "e"}},
+		{"cycle cut", map[string][]string{"a": {"b"}, "b": {"a"}}, []string{"b", "a"}},
+	}
+	for _, tc := range tests {
+		if got := criticalPath(open, tc.blockers); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: criticalPath = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+	if got := criticalPath(nil, nil); len(got) != 0 {
+		t.Errorf("criticalPath(nil) = %v, want empty", got)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var slugCmd = &cobra.Command{
+	Use:     "slug",
+	GroupID: "issues",
+	Short:   "Give issues human-friendly names usable in place of IDs",
+	Long: `Give issues slugs: short names such as fix-login-timeout that every
+command accepts wherever it accepts an issue ID.
+
+  bd show fix-login-timeout
+  bd close fix-login-timeout
+
+Slugs are lowercase words joined by hyphens, generated from the title or
+chosen explicitly. No two issues share a slug, and a slug may not read as
+an issue ID: one starting with the issue prefix (bd-...) or a single hex
+word such as "cafe" is refused. Renaming keeps the old slug
+as an alias, so links and scripts that used it keep working; an old slug
+stays reserved for its issue until the issue is deleted.
+
+Set slug.auto: true in config.yaml to name new issues as they are created
+and rename them when 'bd update --title' changes a title.
+
+Examples:
+  bd slug set bd-a3f8                # Generate from the title
+  bd slug set bd-a3f8 login-timeout  # Choose the slug
+  bd slug list login-timeout         # Current and old slugs
+  bd slug generate --dry-run         # Preview slugs for open issues without one`,
+}
+
+var slugSetCmd = &cobra.Command{
+	Use:           "set <id> [slug]",
+	Short:         "Set an issue's slug, keeping the old one as an alias",
+	Args:          cobra.RangeArgs(1, 2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("slug set")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("slug is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("slug-set")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		ctx := rootCtx
+		ss, err := slugStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		var requested string
+		if len(args) == 2 {
+			requested = args[1]
+		}
+		slug, err := ss.SetIssueSlug(ctx, id, requested, actor)
+		if err != nil {
+			return HandleErrorRespectJSON("setting slug of %s: %v", id, err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(id)
+
+		if jsonOutput {
+			return outputJSON(map[string]string{"issue_id": id, "slug": slug})
+		}
+		fmt.Printf("%s %s is now %s\n", ui.RenderPass("✓"), id, slug)
+		return nil
+	},
+}
+
+var slugListCmd = &cobra.Command{
+	Use:           "list <id>",
+	Short:         "List an issue's current and old slugs",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := rootCtx
+		ss, err := slugStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		slugs, err := ss.ListIssueSlugs(ctx, id)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			if slugs == nil {
+				slugs = []*types.IssueSlug{}
+			}
+			return outputJSON(slugs)
+		}
+		if len(slugs) == 0 {
+			fmt.Printf("%s has no slug (set one with: bd slug set %s)\n", id, id)
+			return nil
+		}
+		for _, s := range slugs {
+			if s.Current {
+				fmt.Printf("%s  %s\n", s.Slug, ui.RenderMuted("current"))
+			} else {
+				fmt.Printf("%s  %s\n", ui.RenderMuted(s.Slug), ui.RenderMuted("old, set "+formatTimeAgo(s.CreatedAt)))
+			}
+		}
+		return nil
+	},
+}
+
+var slugGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate slugs from titles for issues without one",
+	Long: `Give every open issue that has no slug one generated from its title.
+Older issues are named first, so when titles collide the oldest issue gets
+the plain slug and later ones get -2, -3, and so on.
+
+Examples:
+  bd slug generate --dry-run  # Preview
+  bd slug generate --all      # Include closed issues`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !dryRun {
+			CheckReadonly("slug generate")
+		}
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("slug is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("slug-generate")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		ctx := rootCtx
+		ss, err := slugStore()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		all, _ := cmd.Flags().GetBool("all")
+		slugs, err := ss.GenerateIssueSlugs(ctx, storage.SlugGenerationOptions{IncludeClosed: all, DryRun: dryRun}, actor)
+		if err != nil {
+			return HandleErrorRespectJSON("generating slugs: %v", err)
+		}
+		if !dryRun && len(slugs) > 0 {
+			commandDidWrite.Store(true)
+		}
+
+		if jsonOutput {
+			if slugs == nil {
+				slugs = []*types.IssueSlug{}
+			}
+			return outputJSON(slugs)
+		}
+		verb := "Named"
+		if dryRun {
+			verb = "Would name"
+		}
+		for _, s := range slugs {
+			fmt.Printf("  %s %s %s\n", verb, s.IssueID, s.Slug)
+		}
+		switch {
+		case len(slugs) == 0:
+			fmt.Println("Every issue already has a slug.")
+		case dryRun:
+			fmt.Printf("Dry run: %d issue(s) would be named\n", len(slugs))
+		default:
+			fmt.Printf("%s Named %d issue(s)\n", ui.RenderPass("✓"), len(slugs))
+		}
+		return nil
+	},
+}
+
+func init() {
+	slugGenerateCmd.Flags().Bool("all", false, "Also name closed issues")
+	slugGenerateCmd.Flags().Bool("dry-run", false, "Show the slugs without saving them")
+	slugCmd.AddCommand(slugSetCmd, slugListCmd, slugGenerateCmd)
+	rootCmd.AddCommand(slugCmd)
+}
+
+// slugStore returns the active store's slug capability.
+func slugStore() (storage.SlugStore, error) {
+	ss, ok := storage.UnwrapStore(store).(storage.SlugStore)
+	if !ok {
+		return nil, fmt.Errorf("slugs are not supported by this storage backend")
+	}
+	return ss, nil
+}
+
+// currentIssueSlug returns the issue's current slug, or "" when it has none
+// or st does not support slugs.
+func currentIssueSlug(ctx context.Context, st storage.DoltStorage, id string) string {
+	ss, ok := storage.UnwrapStore(st).(storage.SlugStore)
+	if !ok {
+		return ""
+	}
+	slugs, err := ss.CurrentSlugs(ctx, []string{id})
+	if err != nil {
+		return ""
+	}
+	return slugs[id]
+}
+
+// autoSlugIssue names a newly created issue after its title when slug.auto
+// is set. Wisps are never named, and a failure only warns: the issue exists
+// either way.
+func autoSlugIssue(ctx context.Context, st storage.DoltStorage, issue *types.Issue) string {
+	if !config.GetBool("slug.auto") || issue.Ephemeral || types.Slugify(issue.Title) == "" {
+		return ""
+	}
+	ss, ok := storage.UnwrapStore(st).(storage.SlugStore)
+	if !ok {
+		return ""
+	}
+	slug, err := ss.SetIssueSlug(ctx, issue.ID, "", actor)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: no slug for %s: %v\n", issue.ID, err)
+		return ""
+	}
+	commandDidWrite.Store(true)
+	return slug
+}
+
+// refreshSlugOnRetitle renames an issue that already has a slug after its
+// new title when slug.auto is set; the old slug keeps resolving.
+func refreshSlugOnRetitle(ctx context.Context, st storage.DoltStorage, id string) {
+	if !config.GetBool("slug.auto") || currentIssueSlug(ctx, st, id) == "" {
+		return
+	}
+	ss, ok := storage.UnwrapStore(st).(storage.SlugStore)
+	if !ok {
+		return
+	}
+	if _, err := ss.SetIssueSlug(ctx, id, "", actor); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: slug for %s not renamed: %v\n", id, err)
+	}
+}
@@ -2,17 +2,24 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"sort"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
 // StatusOutput represents the complete status output
 type StatusOutput struct {
+	Branch         string                 `json:"branch,omitempty"`
 	Summary        *types.Statistics      `json:"summary"`
 	RecentActivity *RecentActivitySummary `json:"recent_activity,omitempty"`
+	Trends         *StatusTrends          `json:"trends,omitempty"`
+	Pool           *storage.PoolStats     `json:"pool,omitempty"`
 }
 
 // RecentActivitySummary represents activity from git history
@@ -33,9 +40,20 @@ var statusCmd = &cobra.Command{
 	Short:   "Show issue database overview and statistics",
 	Long: `Show a quick snapshot of the issue database state and statistics.
 
-This command provides a summary of issue counts by state (open, in_progress,
-blocked, closed), ready work, extended statistics (pinned issues,
-average lead time), and recent activity over the last 24 hours from git history.
+This command shows the active branch (see 'bd branch switch') and a
+summary of issue counts by state (open, in_progress, blocked, closed),
+ready work, extended statistics (pinned issues, average lead time), recent
+activity over the last 24 hours from git history, and sparklines of issues
+created, closed, and reopened per day over the last 14 days.
+
+With --pool, it also shows the Dolt server connection pool: its limits
+(dolt.max-conns, dolt.max-idle-conns, dolt.conn-max-lifetime,
+dolt.conn-max-idle-time in config.yaml), open and idle connections, how often
+callers waited for a connection, how many queries ran past
+dolt.slow-query-threshold, and the hit rate of the prepared statement cache
+for list and ready queries (dolt.stmt-cache-size). The counts cover this bd
+process only; with telemetry on, every process reports them as bd_db_*
+metrics. Embedded mode has no pool.
 
 Similar to how 'git status' shows working tree state, 'bd status' gives you
 a quick overview of your issue database without needing multiple queries.
@@ -48,9 +66,10 @@ Use cases:
 
 Examples:
   bd status                    # Show summary with activity
-  bd status --no-activity      # Skip git activity (faster)
+  bd status --no-activity      # Skip git activity and trends (faster)
   bd status --json             # JSON format output
   bd status --assigned         # Show issues assigned to current user
+  bd status --pool             # Include connection pool stats
   bd stats                     # Alias for bd status`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -65,12 +84,16 @@ Examples:
 		showAssigned, _ := cmd.Flags().GetBool("assigned")
 		noActivity, _ := cmd.Flags().GetBool("no-activity")
 		jsonFormat, _ := cmd.Flags().GetBool("json")
+		showPool, _ := cmd.Flags().GetBool("pool")
 
 		if jsonFormat {
 			jsonOutput = true
 		}
 
 		if usesProxiedServer() {
+			if showPool {
+				fmt.Fprintln(os.Stderr, "Connection pool stats are not available in proxied-server mode")
+			}
 			return runStatusProxiedServer(rootCtx, showAssigned, noActivity)
 		}
 
@@ -86,21 +109,46 @@ Examples:
 			if stats == nil {
 				return HandleErrorRespectJSON("failed to get assigned statistics")
 			}
+		} else {
+			closed := types.StatusClosed
+			if counts, err := store.CountIssuesByGroup(ctx, types.IssueFilter{Status: &closed, SkipWisps: true}, "close_reason"); err == nil {
+				stats.CloseReasons = foldCloseReasonCounts(counts)
+			}
 		}
 
 		var recentActivity *RecentActivitySummary
+		var trends *StatusTrends
 		if !noActivity {
 			recentActivity = getGitActivity(24)
+			if !showAssigned {
+				trends = loadStatusTrends(ctx, store)
+			}
 		}
 
-		return renderStatus(stats, recentActivity)
+		// A failed read leaves the branch out rather than failing the overview.
+		branch, _ := store.CurrentBranch(ctx)
+
+		var pool *storage.PoolStats
+		if showPool {
+			if reader, ok := storage.UnwrapStore(store).(storage.PoolStatsReader); ok {
+				p := reader.PoolStats()
+				pool = &p
+			} else {
+				fmt.Fprintln(os.Stderr, "Connection pool stats are only available in server mode; embedded mode has no pool")
+			}
+		}
+
+		return renderStatus(branch, stats, recentActivity, trends, pool)
 	},
 }
 
-func renderStatus(stats *types.Statistics, recentActivity *RecentActivitySummary) error {
+func renderStatus(branch string, stats *types.Statistics, recentActivity *RecentActivitySummary, trends *StatusTrends, pool *storage.PoolStats) error {
 	output := &StatusOutput{
+		Branch:         branch,
 		Summary:        stats,
 		RecentActivity: recentActivity,
+		Trends:         trends,
+		Pool:           pool,
 	}
 
 	if jsonOutput {
@@ -109,17 +157,24 @@ func renderStatus(stats *types.Statistics, recentActivity *RecentActivitySummary
 
 	// Human-readable colorized output using semantic ui package
 	fmt.Printf("\n%s Issue Database Status\n\n", ui.RenderAccent("📊"))
+	if branch != "" {
+		fmt.Printf("Branch: %s\n\n", ui.RenderAccent(branch))
+	}
 	fmt.Printf("Summary:\n")
 	fmt.Printf("  Total Issues:           %d\n", stats.TotalIssues)
 	fmt.Printf("  Open:                   %s\n", ui.RenderPass(fmt.Sprintf("%d", stats.OpenIssues)))
 	fmt.Printf("  In Progress:            %s\n", ui.RenderWarn(fmt.Sprintf("%d", stats.InProgressIssues)))
 	fmt.Printf("  Blocked:                %s\n", ui.RenderFail(fmt.Sprintf("%d", stats.BlockedIssues)))
 	fmt.Printf("  Closed:                 %d\n", stats.ClosedIssues)
+	if stats.InReviewIssues > 0 {
+		fmt.Printf("  In Review:              %s\n", ui.RenderWarn(fmt.Sprintf("%d", stats.InReviewIssues)))
+	}
 	fmt.Printf("  Ready to Work:          %s\n", ui.RenderPass(fmt.Sprintf("%d", stats.ReadyIssues)))
 
 	// Extended statistics (only show if non-zero)
 	hasExtended := stats.PinnedIssues > 0 ||
-		stats.EpicsEligibleForClosure > 0 || stats.AverageLeadTime > 0
+		stats.EpicsEligibleForClosure > 0 || stats.AverageLeadTime > 0 || stats.ReopenedIssues > 0 ||
+		stats.TotalCostTokens > 0 || stats.TotalCostUSD > 0
 	if hasExtended {
 		fmt.Printf("\nExtended:\n")
 		if stats.PinnedIssues > 0 {
@@ -131,6 +186,30 @@ func renderStatus(stats *types.Statistics, recentActivity *RecentActivitySummary
 		if stats.AverageLeadTime > 0 {
 			fmt.Printf("  Avg Lead Time:          %.1f hours\n", stats.AverageLeadTime)
 		}
+		if stats.ReopenedIssues > 0 {
+			fmt.Printf("  Reopened:               %s (%.0f%% of closed)\n",
+				ui.RenderWarn(fmt.Sprintf("%d", stats.ReopenedIssues)), stats.ReopenRate*100)
+		}
+		if stats.TotalCostTokens > 0 || stats.TotalCostUSD > 0 {
+			fmt.Printf("  Cost:                   %s\n", formatCost(types.Cost{Tokens: stats.TotalCostTokens, USD: stats.TotalCostUSD}))
+		}
+	}
+
+	if len(stats.CloseReasons) > 0 {
+		fmt.Printf("\nClose Reasons:\n")
+		reasons := make([]string, 0, len(stats.CloseReasons))
+		for reason := range stats.CloseReasons {
+			reasons = append(reasons, reason)
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			if stats.CloseReasons[reasons[i]] != stats.CloseReasons[reasons[j]] {
+				return stats.CloseReasons[reasons[i]] > stats.CloseReasons[reasons[j]]
+			}
+			return reasons[i] < reasons[j]
+		})
+		for _, reason := range reasons {
+			fmt.Printf("  %-23s %d\n", reason+":", stats.CloseReasons[reason])
+		}
 	}
 
 	if recentActivity != nil {
@@ -143,12 +222,49 @@ func renderStatus(stats *types.Statistics, recentActivity *RecentActivitySummary
 		fmt.Printf("  Issues Updated:         %d\n", recentActivity.IssuesUpdated)
 	}
 
+	if trends != nil {
+		fmt.Printf("\nTrends (last %d days):\n", trends.Days)
+		fmt.Printf("  Created:                %s\n", formatTrend(trends.Created))
+		fmt.Printf("  Closed:                 %s\n", formatTrend(trends.Closed))
+		fmt.Printf("  Reopened:               %s\n", formatTrend(trends.Reopened))
+	}
+
+	if pool != nil {
+		renderPoolStats(pool)
+	}
+
 	fmt.Printf("\nFor more details, use 'bd list' to see individual issues.\n")
 	fmt.Println()
 
 	return nil
 }
 
+func renderPoolStats(pool *storage.PoolStats) {
+	fmt.Printf("\nConnection Pool:\n")
+	fmt.Printf("  Open:                   %d of %d (%d in use, %d idle)\n", pool.Open, pool.MaxOpen, pool.InUse, pool.Idle)
+	fmt.Printf("  Limits:                 %d idle, %s lifetime, %s idle time\n", pool.MaxIdle, pool.ConnMaxLifetime, pool.ConnMaxIdleTime)
+	waits := fmt.Sprintf("%d", pool.WaitCount)
+	if pool.WaitCount > 0 {
+		waits = ui.RenderWarn(fmt.Sprintf("%d (%s total; consider raising dolt.max-conns)", pool.WaitCount, pool.WaitDuration.Round(time.Millisecond)))
+	}
+	fmt.Printf("  Waits:                  %s\n", waits)
+	fmt.Printf("  Closed:                 %d idle, %d idle time, %d lifetime\n", pool.MaxIdleClosed, pool.MaxIdleTimeClosed, pool.MaxLifetimeClosed)
+	slow := fmt.Sprintf("%d", pool.SlowQueries)
+	if pool.SlowQueries > 0 {
+		slow = ui.RenderWarn(slow)
+	}
+	fmt.Printf("  Queries:                %d (%s slower than %s)\n", pool.Queries, slow, pool.SlowQueryThreshold)
+	if pool.StmtCacheSize == 0 {
+		fmt.Printf("  Statement cache:        disabled\n")
+		return
+	}
+	hitRate := "n/a"
+	if lookups := pool.StmtCacheHits + pool.StmtCacheMisses; lookups > 0 {
+		hitRate = fmt.Sprintf("%.0f%% hits", 100*float64(pool.StmtCacheHits)/float64(lookups))
+	}
+	fmt.Printf("  Statement cache:        %s (%d hits, %d misses; %d of %d cached)\n", hitRate, pool.StmtCacheHits, pool.StmtCacheMisses, pool.StmtCached, pool.StmtCacheSize)
+}
+
 // getGitActivity returns recent activity statistics.
 // Previously calculated from git log of issues.jsonl; now returns nil
 // as activity tracking has moved to Dolt-native queries.
@@ -195,10 +311,38 @@ func buildAssignedStats(issues []*types.Issue, readyCount int) *types.Statistics
 		case types.StatusDeferred:
 			stats.DeferredIssues++
 		case types.StatusClosed:
-			stats.ClosedIssues++
+			if types.IsReviewPending(issue.Metadata) {
+				stats.InReviewIssues++
+			} else {
+				stats.ClosedIssues++
+			}
 		}
 	}
 
+	everClosed := stats.ClosedIssues + stats.InReviewIssues
+	categories := closeReasonCategories()
+	for _, issue := range issues {
+		if issue.Status == types.StatusClosed {
+			if stats.CloseReasons == nil {
+				stats.CloseReasons = make(map[string]int)
+			}
+			stats.CloseReasons[types.CloseReasonCategory(issue.CloseReason, categories)]++
+		}
+		if cost, _ := types.CostFromMetadata(issue.Metadata); cost != (types.Cost{}) {
+			stats.TotalCostTokens += cost.Tokens
+			stats.TotalCostUSD += cost.USD
+		}
+		if reopens, _ := types.ReopensFromMetadata(issue.Metadata); len(reopens) > 0 {
+			stats.ReopenedIssues++
+			if issue.Status != types.StatusClosed {
+				everClosed++
+			}
+		}
+	}
+	if everClosed > 0 {
+		stats.ReopenRate = float64(stats.ReopenedIssues) / float64(everClosed)
+	}
+
 	stats.ReadyIssues = readyCount
 	return stats
 }
@@ -206,7 +350,8 @@ func buildAssignedStats(issues []*types.Issue, readyCount int) *types.Statistics
 func init() {
 	statusCmd.Flags().Bool("all", false, "Show all issues (default behavior)")
 	statusCmd.Flags().Bool("assigned", false, "Show issues assigned to current user")
-	statusCmd.Flags().Bool("no-activity", false, "Skip git activity tracking (faster)")
+	statusCmd.Flags().Bool("no-activity", false, "Skip git activity tracking and trends (faster)")
+	statusCmd.Flags().Bool("pool", false, "Show Dolt server connection pool and slow query stats")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(statusCmd)
 }
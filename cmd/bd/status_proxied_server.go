@@ -31,7 +31,7 @@ func runStatusProxiedServer(ctx context.Context, showAssigned, noActivity bool)
 		recentActivity = getGitActivity(24)
 	}
 
-	return renderStatus(stats, recentActivity)
+	return renderStatus("", stats, recentActivity, nil, nil)
 }
 
 func proxiedAssignedStatistics(ctx context.Context, uw uow.UnitOfWork, assignee string) (*types.Statistics, error) {
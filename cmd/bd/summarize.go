@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/summary"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/uimd"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// defaultSummarizeTimeout bounds one summarizer run when summarize.timeout
+// is unset.
+const defaultSummarizeTimeout = 2 * time.Minute
+
+var summarizeCmd = &cobra.Command{
+	Use:     "summarize [id]",
+	GroupID: "issues",
+	Short:   "Summarize a long issue or epic with a configured command",
+	Long: `Summarize an issue, or an epic with all its descendants, by piping its
+content to a summarizer command and storing the result.
+
+bd does not call a model itself. Configure any command that reads markdown
+on stdin and prints a summary on stdout:
+
+  bd config set summarize.command "llm -s 'Summarize this issue in 3 sentences'"
+
+The command runs through the shell with BD_SUMMARY_ID and BD_SUMMARY_SCOPE
+(issue or epic) set. summarize.timeout bounds each run (default 2m).
+
+The summary is stored in metadata.summary and shown at the top of bd show,
+marked stale once the issue's title, description, design, acceptance
+criteria, or notes change. With summarize.auto set to true, bd update
+regenerates an existing summary when one of those fields changes. Epic
+summaries only track the epic's own content; rerun --epic after its
+children change. Encrypted issues (bd create --encrypted) are refused, and
+so is an epic with any encrypted descendant.
+
+bd admin compact uses a current summary: --apply falls back to it when
+--summary is omitted, and --auto uses it instead of calling the API.
+
+Examples:
+  bd summarize bd-42
+  bd summarize --epic bd-40
+  bd summarize bd-42 --dry-run     # Print what would be sent to the command`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		epicRef, _ := cmd.Flags().GetString("epic")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		ref, scope := epicRef, summary.ScopeEpic
+		switch {
+		case epicRef != "" && len(args) > 0:
+			return HandleErrorRespectJSON("pass an issue id or --epic, not both")
+		case epicRef == "" && len(args) == 0:
+			return HandleErrorRespectJSON("an issue id or --epic is required")
+		case epicRef == "":
+			ref, scope = args[0], summary.ScopeIssue
+		}
+
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("summarize is not supported in proxied-server mode")
+		}
+		if !dryRun {
+			CheckReadonly("summarize")
+		}
+		evt := metrics.NewCommandEvent("summarize")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+
+		id, err := utils.ResolvePartialID(ctx, store, ref)
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", ref, err)
+		}
+		issues, doc, err := summaryDocument(ctx, store, id, scope)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		if dryRun {
+			if jsonOutput {
+				return outputJSON(map[string]interface{}{"id": id, "scope": scope, "issues": len(issues), "document": doc})
+			}
+			fmt.Print(doc)
+			return nil
+		}
+
+		s, err := generateSummary(ctx, store, issues, doc, scope)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+		SetLastTouchedID(id)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"id": id, "issues": len(issues), "summary": s})
+		}
+		what := formatFeedbackID(id, issues[0].Title)
+		if scope == summary.ScopeEpic {
+			what = fmt.Sprintf("%s (epic, %d issues)", what, len(issues))
+		}
+		fmt.Printf("%s Summarized %s\n\n%s\n", ui.RenderPass("✓"), what, s.Text)
+		return nil
+	},
+}
+
+// summaryDocument loads the issue (and for an epic, its descendants in ID
+// order) with their comments and renders the summarizer input.
+func summaryDocument(ctx context.Context, st storage.DoltStorage, id, scope string) ([]*types.Issue, string, error) {
+	issue, err := st.GetIssue(ctx, id)
+	if err != nil {
+		return nil, "", fmt.Errorf("issue %s not found: %w", id, err)
+	}
+	issues := []*types.Issue{issue}
+	if scope == summary.ScopeEpic {
+		descendants := make(map[string]*types.Issue)
+		if err := findAllDescendants(ctx, st, "", id, types.IssueFilter{}, descendants); err != nil {
+			return nil, "", fmt.Errorf("loading descendants of %s: %w", id, err)
+		}
+		children := make([]*types.Issue, 0, len(descendants))
+		for _, d := range descendants {
+			children = append(children, d)
+		}
+		slices.SortFunc(children, func(a, b *types.Issue) int { return utils.NaturalCompareIDs(a.ID, b.ID) })
+		issues = append(issues, children...)
+	}
+	if err := refuseEncryptedSummary(issues); err != nil {
+		return nil, "", err
+	}
+
+	comments := make(map[string][]*types.Comment, len(issues))
+	for _, is := range issues {
+		cs, err := st.GetIssueComments(ctx, is.ID)
+		if err != nil {
+			return nil, "", fmt.Errorf("loading comments for %s: %w", is.ID, err)
+		}
+		comments[is.ID] = cs
+	}
+	return issues, summary.Document(issues, comments), nil
+}
+
+// refuseEncryptedSummary keeps encrypted issues away from the summarizer: it
+// is an external command, and the summary it returns would be stored in
+// plaintext metadata.
+func refuseEncryptedSummary(issues []*types.Issue) error {
+	var ids []string
+	for _, is := range issues {
+		if is.Encrypted {
+			ids = append(ids, is.ID)
+		}
+	}
+	if len(ids) > 0 {
+		return fmt.Errorf("cannot summarize encrypted issues (%s): their content would leave the database unencrypted", strings.Join(ids, ", "))
+	}
+	return nil
+}
+
+// generateSummary runs the configured summarizer over doc and stores the
+// result on issues[0].
+func generateSummary(ctx context.Context, st storage.DoltStorage, issues []*types.Issue, doc, scope string) (*summary.Summary, error) {
+	subject := issues[0]
+	timeout := defaultSummarizeTimeout
+	if raw := config.GetString("summarize.timeout"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("summarize.timeout: %w", err)
+		}
+		timeout = d
+	}
+	text, err := summary.Run(ctx, config.GetString("summarize.command"), doc, subject.ID, scope, timeout)
+	if err != nil {
+		return nil, err
+	}
+	s := &summary.Summary{
+		Text:        text,
+		Scope:       scope,
+		SourceHash:  summary.ContentHash(subject),
+		GeneratedAt: time.Now().UTC(),
+	}
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("encoding summary: %w", err)
+	}
+	if err := st.MergeMetadata(ctx, subject.ID, summary.MetadataKey, raw, actor); err != nil {
+		return nil, fmt.Errorf("storing summary on %s: %w", subject.ID, err)
+	}
+	return s, nil
+}
+
+// refreshSummaryOnUpdate regenerates an issue's existing summary after an
+// update changed the content it was built from, when summarize.auto is set.
+// It is best-effort: a summarizer failure is reported but never fails the
+// update that triggered it.
+func refreshSummaryOnUpdate(ctx context.Context, st storage.DoltStorage, id string) {
+	if !config.GetBool("summarize.auto") {
+		return
+	}
+	issue, err := st.GetIssue(ctx, id)
+	if err != nil || issue == nil {
+		return
+	}
+	s, err := summary.FromMetadata(issue.Metadata)
+	if err != nil || s == nil || s.Current(issue) {
+		return
+	}
+	issues, doc, err := summaryDocument(ctx, st, id, s.Scope)
+	if err == nil {
+		_, err = generateSummary(ctx, st, issues, doc, s.Scope)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: summary for %s not regenerated: %v\n", id, err)
+	}
+}
+
+// summaryContentFields are the update keys that change a summary's source.
+var summaryContentFields = []string{"title", "description", "design", "acceptance_criteria", "notes"}
+
+// updatesSummaryContent reports whether an update touches summarized content.
+func updatesSummaryContent(updates map[string]interface{}) bool {
+	for _, k := range summaryContentFields {
+		if _, ok := updates[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// currentSummaryText returns the issue's stored summary when it still matches
+// the issue's content, or "" when there is none or it is stale.
+func currentSummaryText(issue *types.Issue) string {
+	s, err := summary.FromMetadata(issue.Metadata)
+	if err != nil || !s.Current(issue) {
+		return ""
+	}
+	return s.Text
+}
+
+// formatIssueSummary renders the stored summary for the top of bd show, or
+// "" when the issue has none.
+func formatIssueSummary(issue *types.Issue) string {
+	s, err := summary.FromMetadata(issue.Metadata)
+	if err != nil || s == nil {
+		return ""
+	}
+	heading := ui.RenderBold("SUMMARY")
+	if !s.Current(issue) {
+		heading += " " + ui.RenderWarn("(stale: issue changed since "+s.GeneratedAt.Local().Format("2006-01-02 15:04")+")")
+	}
+	return fmt.Sprintf("%s\n%s", heading, uimd.RenderMarkdown(s.Text))
+}
+
+func init() {
+	summarizeCmd.Flags().String("epic", "", "Summarize an epic together with all its descendants")
+	summarizeCmd.Flags().Bool("dry-run", false, "Print the document that would be sent to the summarizer")
+	rootCmd.AddCommand(summarizeCmd)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/summary"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// fakeSummaryStore serves an epic and its children.
+type fakeSummaryStore struct {
+	storage.DoltStorage
+	issues   map[string]*types.Issue
+	children map[string][]*types.Issue
+}
+
+func (f *fakeSummaryStore) GetIssue(_ context.Context, id string) (*types.Issue, error) {
+	return f.issues[id], nil
+}
+
+func (f *fakeSummaryStore) SearchIssues(_ context.Context, _ string, filter types.IssueFilter) ([]*types.Issue, error) {
+	if filter.ParentID == nil {
+		return nil, nil
+	}
+	return f.children[*filter.ParentID], nil
+}
+
+func (f *fakeSummaryStore) GetIssueComments(context.Context, string) ([]*types.Comment, error) {
+	return nil, nil
+}
+
+func TestSummaryDocumentRefusesEncryptedIssues(t *testing.T) {
+	epic := &types.Issue{ID: "bd-1", Title: "Launch", IssueType: types.TypeEpic}
+	secret := &types.Issue{ID: "bd-1.2", Title: "Vendor contract", Description: "bdenc:v1:...", Encrypted: true}
+	st := &fakeSummaryStore{
+		issues: map[string]*types.Issue{
+			"bd-1":   epic,
+			"bd-1.1": {ID: "bd-1.1", Title: "Docs"},
+			"bd-1.2": secret,
+		},
+		children: map[string][]*types.Issue{"bd-1": {{ID: "bd-1.1", Title: "Docs"}, secret}},
+	}
+	ctx := context.Background()
+
+	if _, _, err := summaryDocument(ctx, st, "bd-1.1", summary.ScopeIssue); err != nil {
+		t.Fatalf("plain issue refused: %v", err)
+	}
+	_, _, err := summaryDocument(ctx, st, "bd-1.2", summary.ScopeIssue)
+	if err == nil || !strings.Contains(err.Error(), "bd-1.2") {
+		t.Fatalf("encrypted issue: err = %v, want refusal naming bd-1.2", err)
+	}
+	_, _, err = summaryDocument(ctx, st, "bd-1", summary.ScopeEpic)
+	if err == nil || !strings.Contains(err.Error(), "bd-1.2") {
+		t.Fatalf("epic with encrypted child: err = %v, want refusal naming bd-1.2", err)
+	}
+}
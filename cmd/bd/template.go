@@ -601,8 +601,11 @@ func cloneSubgraph(ctx context.Context, s storage.DoltStorage, subgraph *Templat
 				Timeout:   oldIssue.Timeout,
 				Labels:    oldIssue.Labels,
 				Metadata:  oldIssue.Metadata,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
+				// Source tracing, so 'bd trace' can find what a formula produced
+				SourceFormula:  oldIssue.SourceFormula,
+				SourceLocation: oldIssue.SourceLocation,
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
 			}
 
 			// Generate custom ID for dynamic bonding if ParentID is set
@@ -0,0 +1,544 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/tokens"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// tokenEnvVar carries an agent token's secret into a session.
+const tokenEnvVar = "BD_TOKEN"
+
+// tokenConfigPrefix namespaces token records in the database config table,
+// so a token works on every clone of the database.
+const tokenConfigPrefix = "tokens."
+
+// tokenCacheFile keeps the records of the tokens this clone has checked
+// against the database (hashes only, never secrets), so a --queue write made
+// while the database is unreachable can still be checked against its token.
+// The database stays authoritative: replay checks queued writes again.
+const tokenCacheFile = "agent-access.json"
+
+// tokenCapabilities expand the names accepted by --allow into command paths.
+// Any other --allow value must be a command path itself ("dep add").
+var tokenCapabilities = map[string][]string{
+	"create":  {"create", "q"},
+	"comment": {"comment", "comments add"},
+	"claim":   {"update"}, // only with --claim, see tokenAllowsCommand
+	"update":  {"update"},
+	"close":   {"close"},
+	"reopen":  {"reopen"},
+	"label":   {"label add", "label remove"},
+	"dep":     {"dep add", "dep remove"},
+}
+
+// tokenExplicitCommands are read-only-allowed commands that can still write
+// on someone else's behalf (bd serve acts on Slack requests), so a token must
+// name them in --allow.
+var tokenExplicitCommands = map[string]bool{
+	"serve": true,
+}
+
+var tokenCmd = &cobra.Command{
+	Use:     "token",
+	GroupID: "advanced",
+	Short:   "Manage agent tokens that limit which commands a session may run",
+	Long: `Agent tokens restrict what a bd session can do. Create a token, hand its
+secret to an agent as BD_TOKEN, and every command the agent runs is checked
+centrally, before it executes:
+
+  - queries (list, show, ready, ...) are always allowed
+  - other commands must be covered by --allow, including 'bd serve', which
+    writes on behalf of Slack users
+  - with --scope label=<name>, the agent only sees and touches issues with
+    that label, and issues it creates get the label; commands that cannot
+    be limited to the label (query, status, graph, ...) are refused
+
+--allow takes capabilities (create, comment, claim, update, close, reopen,
+label, dep) or command paths ("dep add"). claim allows 'bd update --claim'
+and nothing else. Token records hold only a hash of the secret and are
+stored in the database, so a token works on every clone. Like BD_READONLY,
+a token guards a session you hand to an agent; it does not authenticate
+users.
+
+Examples:
+  bd token create crawler --allow create,comment,claim --scope label=crawler
+  BD_TOKEN=bdt_... bd ready
+  bd token list
+  bd token revoke 3f2a91c0d4e1`,
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:           "create <name>",
+	Short:         "Create an agent token and print its secret",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("token create")
+		evt := metrics.NewCommandEvent("token-create")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureTokenStore(); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		allow, _ := cmd.Flags().GetStringSlice("allow")
+		scopeSpecs, _ := cmd.Flags().GetStringSlice("scope")
+		ttl, _ := cmd.Flags().GetDuration("expires")
+
+		allow = trimAll(allow)
+		if len(allow) == 0 {
+			return HandleErrorRespectJSON("--allow is required (e.g. --allow create,comment,claim)")
+		}
+		for _, a := range allow {
+			if _, ok := tokenCapabilities[a]; ok {
+				continue
+			}
+			if found, _, err := rootCmd.Find(strings.Fields(a)); err != nil || readonlyCommandPath(found) != a {
+				return HandleErrorRespectJSON("unknown capability or command %q in --allow", a)
+			}
+		}
+		scope, err := tokens.ParseScope(scopeSpecs)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		tok, secret, err := tokens.New(args[0], allow, scope, actor, ttl, time.Now())
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		raw, err := json.Marshal(tok)
+		if err != nil {
+			return HandleErrorRespectJSON("encoding token: %v", err)
+		}
+		if err := store.SetConfig(rootCtx, tokenConfigPrefix+tok.ID, string(raw)); err != nil {
+			return HandleErrorRespectJSON("saving token: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"token": tok, "secret": secret})
+		}
+		fmt.Printf("%s Created token %s (%s)\n", ui.RenderPass("✓"), tok.ID, tok.Name)
+		fmt.Printf("  allow: %s\n", strings.Join(tok.Allow, ", "))
+		if !tok.Scope.IsEmpty() {
+			fmt.Printf("  scope: %s\n", tok.Scope)
+		}
+		if tok.ExpiresAt != nil {
+			fmt.Printf("  expires: %s\n", tok.ExpiresAt.Local().Format("2006-01-02 15:04"))
+		}
+		fmt.Printf("\n  %s=%s\n\n", tokenEnvVar, secret)
+		fmt.Println(ui.RenderWarn("The secret is shown only once."))
+		return nil
+	},
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List agent tokens",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ensureTokenStore(); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		toks, err := loadTokens()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			return outputJSON(toks)
+		}
+		if len(toks) == 0 {
+			fmt.Println("No agent tokens")
+			return nil
+		}
+		for _, t := range toks {
+			line := fmt.Sprintf("%s  %-16s allow: %s", t.ID, t.Name, strings.Join(t.Allow, ","))
+			if !t.Scope.IsEmpty() {
+				line += "  scope: " + t.Scope.String()
+			}
+			if t.ExpiresAt != nil {
+				line += "  expires: " + t.ExpiresAt.Local().Format("2006-01-02")
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:           "revoke <id>...",
+	Short:         "Revoke agent tokens",
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("token revoke")
+		if err := ensureTokenStore(); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		for _, id := range args {
+			raw, err := store.GetConfig(rootCtx, tokenConfigPrefix+id)
+			if err != nil || raw == "" {
+				return HandleErrorRespectJSON("no token %s", id)
+			}
+			if err := store.DeleteConfig(rootCtx, tokenConfigPrefix+id); err != nil {
+				return HandleErrorRespectJSON("revoking %s: %v", id, err)
+			}
+			commandDidWrite.Store(true)
+			if !jsonOutput {
+				fmt.Printf("%s Revoked token %s\n", ui.RenderPass("✓"), id)
+			}
+		}
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"revoked": args})
+		}
+		return nil
+	},
+}
+
+func ensureTokenStore() error {
+	if os.Getenv(tokenEnvVar) != "" {
+		return fmt.Errorf("agent tokens cannot manage tokens; unset %s", tokenEnvVar)
+	}
+	if usesProxiedServer() {
+		return fmt.Errorf("token is not supported in proxied-server mode")
+	}
+	if err := ensureStoreActive(); err != nil {
+		return fmt.Errorf("database not available: %w", err)
+	}
+	return nil
+}
+
+func loadTokens() ([]*tokens.Token, error) {
+	all, err := store.GetAllConfig(rootCtx)
+	if err != nil {
+		return nil, fmt.Errorf("reading tokens: %w", err)
+	}
+	toks := []*tokens.Token{}
+	for key, raw := range all {
+		if !strings.HasPrefix(key, tokenConfigPrefix) {
+			continue
+		}
+		var t tokens.Token
+		if err := json.Unmarshal([]byte(raw), &t); err == nil {
+			toks = append(toks, &t)
+		}
+	}
+	sort.Slice(toks, func(i, j int) bool { return toks[i].CreatedAt.Before(toks[j].CreatedAt) })
+	return toks, nil
+}
+
+// lookupToken finds and verifies the token for secret.
+func lookupToken(secret string) (*tokens.Token, error) {
+	raw, err := store.GetConfig(rootCtx, tokenConfigPrefix+tokens.IDForSecret(secret))
+	if err != nil {
+		return nil, fmt.Errorf("reading token: %w", err)
+	}
+	if raw == "" {
+		return nil, fmt.Errorf("%s is not a known token (revoked?)", tokenEnvVar)
+	}
+	var t tokens.Token
+	if err := json.Unmarshal([]byte(raw), &t); err != nil {
+		return nil, fmt.Errorf("parsing token: %w", err)
+	}
+	if err := t.Verify(secret, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", tokenEnvVar, err)
+	}
+	if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+		if err := cacheTokenRecord(beadsDir, &t); err != nil {
+			debug.Logf("caching agent token %s: %v\n", t.ID, err)
+		}
+	}
+	return &t, nil
+}
+
+// readTokenCache returns the cached token records, keyed by ID. A missing
+// file is an empty cache.
+func readTokenCache(beadsDir string) (map[string]*tokens.Token, error) {
+	data, err := os.ReadFile(filepath.Join(beadsDir, tokenCacheFile)) // #nosec G304 -- path constructed from beadsDir
+	if os.IsNotExist(err) {
+		return map[string]*tokens.Token{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cache := map[string]*tokens.Token{}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("%s: %w", tokenCacheFile, err)
+	}
+	return cache, nil
+}
+
+// cacheTokenRecord records t in the clone's token cache.
+func cacheTokenRecord(beadsDir string, t *tokens.Token) error {
+	cache, err := readTokenCache(beadsDir)
+	if err != nil {
+		cache = map[string]*tokens.Token{}
+	}
+	if cached, ok := cache[t.ID]; ok && reflect.DeepEqual(cached, t) {
+		return nil
+	}
+	cache[t.ID] = t
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(beadsDir, tokenCacheFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// enforceAgentTokenOffline applies BD_TOKEN to a --queue write while the
+// database is unreachable, using the token record cached by an earlier
+// command on this clone. It refuses what enforceAgentToken would refuse
+// without reading the database: commands outside the allowlist and
+// commands the scope cannot be applied to. Scoped creates get the scope
+// labels; whether an updated issue is in scope is checked on replay. It
+// returns the token the queued writes must record, or nil without BD_TOKEN.
+func enforceAgentTokenOffline(cmd *cobra.Command, beadsDir string) (*tokens.Token, error) {
+	secret := strings.TrimSpace(os.Getenv(tokenEnvVar))
+	if secret == "" {
+		return nil, nil
+	}
+	cache, err := readTokenCache(beadsDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading token cache: %w", err)
+	}
+	t, ok := cache[tokens.IDForSecret(secret)]
+	if !ok {
+		return nil, fmt.Errorf("%s cannot be checked while the database is unreachable: it has not been used on this clone before", tokenEnvVar)
+	}
+	if err := t.Verify(secret, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", tokenEnvVar, err)
+	}
+	path := readonlyCommandPath(cmd)
+	if !tokenAllowsCommand(t, cmd) {
+		return nil, fmt.Errorf("operation '%s' is not allowed by agent token %s (%s)", path, t.ID, t.Name)
+	}
+	if t.Scope.IsEmpty() || tokenScopeIssueCommands[path] {
+		return t, nil
+	}
+	if !tokenScopeFilterCommands[path] {
+		return nil, fmt.Errorf("'%s' cannot be limited to the scope of agent token %s (%s)", path, t.ID, t.Scope)
+	}
+	return t, applyTokenScopeLabels(t, cmd, path)
+}
+
+// checkQueuedWriteToken checks a queued write against the agent token it
+// was queued under, as read from the database now: the token must still
+// exist, have been valid when the write was queued, allow the operation,
+// and (for a scoped token) cover the issue. labels are the issue's labels,
+// or the create's. It returns why the write is refused, or "".
+func checkQueuedWriteToken(ctx context.Context, tx storage.Transaction, tokenID string, scope tokens.Scope, op string, queuedAt time.Time, labels []string) (string, error) {
+	raw, err := tx.GetConfig(ctx, tokenConfigPrefix+tokenID)
+	if err != nil {
+		return "", fmt.Errorf("reading agent token %s: %w", tokenID, err)
+	}
+	if raw == "" {
+		return fmt.Sprintf("agent token %s it was queued under has been revoked", tokenID), nil
+	}
+	var t tokens.Token
+	if err := json.Unmarshal([]byte(raw), &t); err != nil {
+		return "", fmt.Errorf("parsing agent token %s: %w", tokenID, err)
+	}
+	if t.ExpiresAt != nil && queuedAt.After(*t.ExpiresAt) {
+		return fmt.Sprintf("agent token %s had expired when it was queued", tokenID), nil
+	}
+	if !tokenAllowsPath(&t, op) {
+		return fmt.Sprintf("'%s' is not allowed by agent token %s (%s)", op, t.ID, t.Name), nil
+	}
+	if !scope.Contains(labels) || !t.Scope.Contains(labels) {
+		return fmt.Sprintf("issue is outside the scope of agent token %s (%s)", t.ID, t.Scope), nil
+	}
+	return "", nil
+}
+
+// tokenAllowsCommand reports whether t covers cmd. Queries are always
+// allowed, except those in tokenExplicitCommands; a command reached only
+// through the claim capability must be a pure claim.
+func tokenAllowsCommand(t *tokens.Token, cmd *cobra.Command) bool {
+	path := readonlyCommandPath(cmd)
+	if readonlyCommandAllowed(cmd) && !tokenExplicitCommands[path] {
+		return true
+	}
+	if tokenAllowsPath(t, path) {
+		return true
+	}
+	for _, a := range t.Allow {
+		if a == "claim" && slices.Contains(tokenCapabilities[a], path) {
+			return isPureClaim(cmd)
+		}
+	}
+	return false
+}
+
+// tokenAllowsPath reports whether a capability other than claim, or a
+// command path in t's allowlist, covers the command path.
+func tokenAllowsPath(t *tokens.Token, path string) bool {
+	for _, a := range t.Allow {
+		paths, ok := tokenCapabilities[a]
+		if !ok {
+			paths = []string{a}
+		}
+		if a != "claim" && slices.Contains(paths, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPureClaim reports whether cmd is 'bd update --claim' with no other
+// command-specific flags.
+func isPureClaim(cmd *cobra.Command) bool {
+	claim, _ := cmd.Flags().GetBool("claim")
+	pure := claim
+	local := cmd.LocalNonPersistentFlags()
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Name != "claim" && local.Lookup(f.Name) != nil {
+			pure = false
+		}
+	})
+	return pure
+}
+
+// enforceAgentToken applies BD_TOKEN to the command about to run: it refuses
+// commands the token does not allow, narrows queries and creates to the
+// token's label scope, and refuses issue arguments outside the scope.
+func enforceAgentToken(cmd *cobra.Command, args []string) error {
+	secret := strings.TrimSpace(os.Getenv(tokenEnvVar))
+	if secret == "" {
+		return nil
+	}
+	if store == nil {
+		return fmt.Errorf("agent tokens need direct database access")
+	}
+	t, err := lookupToken(secret)
+	if err != nil {
+		return err
+	}
+	path := readonlyCommandPath(cmd)
+	if !tokenAllowsCommand(t, cmd) {
+		return fmt.Errorf("operation '%s' is not allowed by agent token %s (%s)", path, t.ID, t.Name)
+	}
+	if t.Scope.IsEmpty() {
+		return nil
+	}
+	return enforceTokenScope(t, cmd, args)
+}
+
+// tokenScopeFilterCommands narrow what they list to a label, or (create, q)
+// label the issues they create, through a --label or --labels flag. A
+// scoped token sets that flag to its scope labels.
+var tokenScopeFilterCommands = map[string]bool{
+	"list":    true,
+	"ready":   true,
+	"search":  true,
+	"count":   true,
+	"orphans": true,
+	"create":  true,
+	"q":       true,
+}
+
+// tokenScopeIssueCommands act only on the issues named in their arguments,
+// which a scoped token checks against its scope.
+var tokenScopeIssueCommands = map[string]bool{
+	"show":         true,
+	"comments":     true,
+	"comments add": true,
+	"comment":      true,
+	"update":       true,
+	"close":        true,
+	"reopen":       true,
+	"label add":    true,
+	"label remove": true,
+	"dep add":      true,
+	"dep remove":   true,
+}
+
+// applyTokenScopeLabels adds t's scope labels to the command's label flag,
+// which filters a listing or labels a created issue.
+func applyTokenScopeLabels(t *tokens.Token, cmd *cobra.Command, path string) error {
+	for _, name := range []string{"labels", "label"} {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Value.Type() == "stringSlice" {
+			for _, l := range t.Scope.Labels {
+				if err := cmd.Flags().Set(name, l); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("agent token %s is scoped to %s, and '%s' has no label flag to apply it", t.ID, t.Scope, path)
+}
+
+// enforceTokenScope limits cmd to the label scope of t. Commands in neither
+// tokenScopeFilterCommands nor tokenScopeIssueCommands are refused: their
+// output or effect (bd query, bd status, bd graph, ...) cannot be limited to
+// the scope, so running them would leak or touch issues outside it.
+func enforceTokenScope(t *tokens.Token, cmd *cobra.Command, args []string) error {
+	path := readonlyCommandPath(cmd)
+	switch {
+	case tokenScopeFilterCommands[path]:
+		return applyTokenScopeLabels(t, cmd, path)
+	case tokenScopeIssueCommands[path]:
+	default:
+		return fmt.Errorf("'%s' cannot be limited to the scope of agent token %s (%s)", path, t.ID, t.Scope)
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("agent token %s is scoped to %s; name the issues explicitly", t.ID, t.Scope)
+	}
+	for i, arg := range args {
+		id, err := utils.ResolvePartialID(rootCtx, store, arg)
+		if err != nil {
+			if i == 0 {
+				return fmt.Errorf("agent token %s: cannot resolve issue %s", t.ID, arg)
+			}
+			continue // not an issue reference (comment text, label names, ...)
+		}
+		labels, err := store.GetLabels(rootCtx, id)
+		if err != nil {
+			return fmt.Errorf("agent token %s: reading labels of %s: %w", t.ID, id, err)
+		}
+		if !t.Scope.Contains(labels) {
+			return fmt.Errorf("%s is outside the scope of agent token %s (%s)", id, t.ID, t.Scope)
+		}
+	}
+	return nil
+}
+
+func init() {
+	tokenCreateCmd.Flags().StringSlice("allow", nil, "Capabilities or command paths the token may run (create, comment, claim, update, close, reopen, label, dep)")
+	tokenCreateCmd.Flags().StringSlice("scope", nil, "Limit the token to issues with a label (label=<name>, repeatable)")
+	tokenCreateCmd.Flags().Duration("expires", 0, "Expire the token after this long (e.g. 720h; default: never)")
+
+	tokenCmd.AddCommand(tokenCreateCmd, tokenListCmd, tokenRevokeCmd)
+	rootCmd.AddCommand(tokenCmd)
+}
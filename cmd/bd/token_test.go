@@ -0,0 +1,165 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/steveyegge/beads/internal/tokens"
+)
+
+func TestTokenAllowsCommand(t *testing.T) {
+	tok := &tokens.Token{Allow: []string{"create", "claim", "dep add"}}
+	tests := []struct {
+		args    []string
+		flags   map[string]string
+		allowed bool
+	}{
+		{[]string{"list"}, nil, true},
+		{[]string{"show"}, nil, true},
+		{[]string{"create"}, nil, true},
+		{[]string{"q"}, nil, true},
+		{[]string{"dep", "add"}, nil, true},
+		{[]string{"dep", "remove"}, nil, false},
+		{[]string{"close"}, nil, false},
+		{[]string{"comments", "add"}, nil, false},
+		{[]string{"update"}, map[string]string{"claim": "true"}, true},
+		{[]string{"update"}, map[string]string{"claim": "true", "priority": "0"}, false},
+		{[]string{"update"}, map[string]string{"status": "closed"}, false},
+		{[]string{"serve"}, nil, false},
+	}
+	for _, tc := range tests {
+		cmd, _, err := rootCmd.Find(tc.args)
+		if err != nil {
+			t.Fatalf("find %v: %v", tc.args, err)
+		}
+		cmd.Flags().Visit(func(f *pflag.Flag) { _ = f.Value.Set(f.DefValue); f.Changed = false })
+		for name, val := range tc.flags {
+			if err := cmd.Flags().Set(name, val); err != nil {
+				t.Fatalf("set --%s: %v", name, err)
+			}
+		}
+		if got := tokenAllowsCommand(tok, cmd); got != tc.allowed {
+			t.Errorf("%v %v: allowed = %v, want %v", tc.args, tc.flags, got, tc.allowed)
+		}
+		cmd.Flags().Visit(func(f *pflag.Flag) { _ = f.Value.Set(f.DefValue); f.Changed = false })
+	}
+
+	// bd serve writes on behalf of Slack users, so only a token that names
+	// it may run it.
+	serve, _, err := rootCmd.Find([]string{"serve"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tokenAllowsCommand(&tokens.Token{Allow: []string{"serve"}}, serve) {
+		t.Error("token with --allow serve refused bd serve")
+	}
+}
+
+func TestEnforceTokenScopeRefusesUnscopableCommands(t *testing.T) {
+	scope, err := tokens.ParseScope([]string{"label=crawler"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok := &tokens.Token{ID: "t1", Allow: []string{"create"}, Scope: scope}
+	for _, args := range [][]string{
+		{"query"},
+		{"blocked"},
+		{"stale"},
+		{"status"},
+		{"graph"},
+		{"gantt"},
+		{"events", "query"},
+		{"label", "list-all"},
+		{"diff"},
+	} {
+		cmd, _, err := rootCmd.Find(args)
+		if err != nil {
+			t.Fatalf("find %v: %v", args, err)
+		}
+		if got := readonlyCommandPath(cmd); got != strings.Join(args, " ") {
+			t.Fatalf("find %v resolved to %q", args, got)
+		}
+		if err := enforceTokenScope(tok, cmd, []string{"status=open"}); err == nil {
+			t.Errorf("%v ran under a scoped token; want it refused", args)
+		}
+	}
+}
+
+func TestEnforceTokenScopeNarrowsListsAndRequiresIssueArgs(t *testing.T) {
+	scope, err := tokens.ParseScope([]string{"label=crawler"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok := &tokens.Token{ID: "t1", Allow: []string{"create"}, Scope: scope}
+
+	cmd, _, err := rootCmd.Find([]string{"list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := cmd.Flags().Lookup("label")
+	defer func() { _ = f.Value.(pflag.SliceValue).Replace(nil); f.Changed = false }()
+	if err := enforceTokenScope(tok, cmd, nil); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if got, _ := cmd.Flags().GetStringSlice("label"); len(got) != 1 || got[0] != "crawler" {
+		t.Errorf("list --label = %v, want [crawler]", got)
+	}
+
+	show, _, err := rootCmd.Find([]string{"show"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := enforceTokenScope(tok, show, nil); err == nil {
+		t.Error("show without issue IDs ran under a scoped token; want it refused")
+	}
+}
+
+func TestEnforceAgentTokenOfflineUsesCachedRecord(t *testing.T) {
+	scope, err := tokens.ParseScope([]string{"label=crawler"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, secret, err := tokens.New("crawler", []string{"create"}, scope, "tester", 0, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	beadsDir := t.TempDir()
+	t.Setenv(tokenEnvVar, secret)
+
+	create, _, err := rootCmd.Find([]string{"create"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := create.Flags().Lookup("labels")
+	defer func() { _ = f.Value.(pflag.SliceValue).Replace(nil); f.Changed = false }()
+
+	// A token never checked on this clone cannot be checked offline.
+	if _, err := enforceAgentTokenOffline(create, beadsDir); err == nil {
+		t.Fatal("uncached token was accepted offline")
+	}
+
+	if err := cacheTokenRecord(beadsDir, tok); err != nil {
+		t.Fatal(err)
+	}
+	got, err := enforceAgentTokenOffline(create, beadsDir)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got == nil || got.ID != tok.ID {
+		t.Fatalf("token = %+v, want %s", got, tok.ID)
+	}
+	if labels, _ := create.Flags().GetStringSlice("labels"); len(labels) != 1 || labels[0] != "crawler" {
+		t.Errorf("queued create --labels = %v, want [crawler]", labels)
+	}
+
+	update, _, err := rootCmd.Find([]string{"update"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := enforceAgentTokenOffline(update, beadsDir); err == nil {
+		t.Error("update was queued under a token that only allows create")
+	}
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/besteffort"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/towns"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// townSelectionSkipCommands never run against the switched-to town: they
+// create or manage workspaces, and aiming them at a registered project from
+// an unrelated directory would be surprising.
+var townSelectionSkipCommands = map[string]bool{
+	"bootstrap": true,
+	"demo":      true,
+	"init":      true,
+	"towns":     true,
+}
+
+var townsCmd = &cobra.Command{
+	Use:     "towns",
+	GroupID: "setup",
+	Short:   "Register beads projects and work across them",
+	Long: `Keep a personal registry of beads projects ("towns") so you can supervise
+many of them without changing directories.
+
+  bd towns add frontend ~/src/frontend   # Register a project
+  bd towns list                          # Dashboard of every town
+  bd towns switch frontend               # Default project outside any workspace
+  bd towns search "login timeout"        # Search every town
+  bd -C frontend ready                   # Run one command in a town
+
+After 'bd towns switch', commands run outside any beads project use that
+town; inside a project the project still wins. -C accepts a town name as
+well as a directory.
+
+The registry lives in ~/.config/bd/towns.json (override with
+BEADS_TOWNS_FILE). It stores paths only; each town keeps its own database,
+server settings, and config.`,
+}
+
+var townsAddCmd = &cobra.Command{
+	Use:           "add <name> [dir]",
+	Short:         "Register the beads project in dir (default: current directory)",
+	Args:          cobra.RangeArgs(1, 2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) == 2 {
+			dir = args[1]
+		}
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return HandleErrorRespectJSON("cannot resolve %q: %v", dir, err)
+		}
+		beadsDir := beads.FindBeadsDirFrom(absDir)
+		if beadsDir == "" {
+			return HandleErrorRespectJSON("no beads project found in %s", absDir)
+		}
+
+		path, reg, err := loadTowns()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		town, err := reg.Add(args[0], beadsDir, time.Now())
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if err := reg.Save(path); err != nil {
+			return HandleErrorRespectJSON("saving towns registry: %v", err)
+		}
+		if jsonOutput {
+			return outputJSON(town)
+		}
+		fmt.Printf("%s Registered town %s (%s)\n", ui.RenderPass("✓"), town.Name, town.Path)
+		return nil
+	},
+}
+
+var townsRemoveCmd = &cobra.Command{
+	Use:           "remove <name>",
+	Short:         "Unregister a town (its project is left untouched)",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, reg, err := loadTowns()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if err := reg.Remove(args[0]); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if err := reg.Save(path); err != nil {
+			return HandleErrorRespectJSON("saving towns registry: %v", err)
+		}
+		if jsonOutput {
+			return outputJSON(map[string]string{"removed": args[0]})
+		}
+		fmt.Printf("%s Removed town %s\n", ui.RenderPass("✓"), args[0])
+		return nil
+	},
+}
+
+var townsSwitchCmd = &cobra.Command{
+	Use:   "switch [name]",
+	Short: "Use a town for commands run outside any beads project",
+	Long: `Make a town the default for commands run outside any beads project.
+Without a name, print the current town. --clear goes back to requiring a
+project directory.`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, reg, err := loadTowns()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		clearCurrent, _ := cmd.Flags().GetBool("clear")
+		if len(args) == 0 && !clearCurrent {
+			cur := reg.CurrentTown()
+			if jsonOutput {
+				return outputJSON(map[string]interface{}{"current": cur})
+			}
+			if cur == nil {
+				fmt.Println("No current town (set one with: bd towns switch <name>)")
+				return nil
+			}
+			fmt.Printf("%s (%s)\n", cur.Name, cur.Path)
+			return nil
+		}
+		name := ""
+		if !clearCurrent {
+			name = args[0]
+		}
+		if err := reg.Switch(name); err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		if err := reg.Save(path); err != nil {
+			return HandleErrorRespectJSON("saving towns registry: %v", err)
+		}
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{"current": reg.CurrentTown()})
+		}
+		if name == "" {
+			fmt.Printf("%s Cleared the current town\n", ui.RenderPass("✓"))
+		} else {
+			fmt.Printf("%s Switched to town %s\n", ui.RenderPass("✓"), name)
+		}
+		return nil
+	},
+}
+
+// townSummary is one row of the towns dashboard.
+type townSummary struct {
+	*towns.Town
+	Current bool              `json:"current,omitempty"`
+	Stats   *types.Statistics `json:"stats,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+var townsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List towns with open, in-progress, blocked, and ready counts",
+	Long: `List registered towns. Each town's database is opened read-only to show
+its open, in-progress, blocked, and ready counts, with totals across all
+towns; --no-stats skips opening them.`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, reg, err := loadTowns()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		noStats, _ := cmd.Flags().GetBool("no-stats")
+		ctx := rootCtx
+
+		rows := make([]*townSummary, 0, len(reg.Towns))
+		for _, t := range reg.Towns {
+			row := &townSummary{Town: t, Current: t.Name == reg.Current}
+			if !noStats {
+				row.Stats, err = townStatistics(ctx, t)
+				if err != nil {
+					row.Error = err.Error()
+				}
+			}
+			rows = append(rows, row)
+		}
+
+		if jsonOutput {
+			return outputJSON(rows)
+		}
+		if len(rows) == 0 {
+			fmt.Println("No towns registered (add one with: bd towns add <name> [dir])")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		if noStats {
+			fmt.Fprintln(w, "  TOWN\tPATH")
+			for _, r := range rows {
+				fmt.Fprintf(w, "%s %s\t%s\n", currentMarker(r.Current), r.Name, r.Path)
+			}
+			return w.Flush()
+		}
+		fmt.Fprintln(w, "  TOWN\tOPEN\tIN PROGRESS\tBLOCKED\tREADY\tPATH")
+		var total types.Statistics
+		for _, r := range rows {
+			if r.Stats == nil {
+				fmt.Fprintf(w, "%s %s\t-\t-\t-\t-\t%s %s\n", currentMarker(r.Current), r.Name, r.Path, ui.RenderFail("("+r.Error+")"))
+				continue
+			}
+			s := r.Stats
+			total.OpenIssues += s.OpenIssues
+			total.InProgressIssues += s.InProgressIssues
+			total.BlockedIssues += s.BlockedIssues
+			total.ReadyIssues += s.ReadyIssues
+			fmt.Fprintf(w, "%s %s\t%d\t%d\t%d\t%d\t%s\n", currentMarker(r.Current), r.Name,
+				s.OpenIssues, s.InProgressIssues, s.BlockedIssues, s.ReadyIssues, r.Path)
+		}
+		if len(rows) > 1 {
+			fmt.Fprintf(w, "  %s\t%d\t%d\t%d\t%d\t\n", ui.RenderBold("total"),
+				total.OpenIssues, total.InProgressIssues, total.BlockedIssues, total.ReadyIssues)
+		}
+		return w.Flush()
+	},
+}
+
+// townMatch is one cross-town search hit.
+type townMatch struct {
+	Town  string       `json:"town"`
+	Issue *types.Issue `json:"issue"`
+}
+
+var townsSearchCmd = &cobra.Command{
+	Use:   "search <text>",
+	Short: "Search issues in every town",
+	Long: `Search the titles, descriptions, and IDs of issues in every registered
+town, as 'bd search' does within one project. Closed issues are left out
+unless --all is given. Towns that cannot be opened are reported and
+skipped.
+
+Examples:
+  bd towns search "login timeout"
+  bd towns search auth --all --limit 5 --json`,
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, reg, err := loadTowns()
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		text := strings.Join(args, " ")
+		all, _ := cmd.Flags().GetBool("all")
+		limit, _ := cmd.Flags().GetInt("limit")
+		filter := types.IssueFilter{Limit: limit}
+		if !all {
+			filter.ExcludeStatus = []types.Status{types.StatusClosed}
+		}
+
+		ctx := rootCtx
+		matches := []townMatch{}
+		for _, t := range reg.Towns {
+			issues, err := searchTown(ctx, t, text, filter)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping town %s: %v\n", t.Name, err)
+				continue
+			}
+			for _, issue := range issues {
+				matches = append(matches, townMatch{Town: t.Name, Issue: issue})
+			}
+		}
+
+		if jsonOutput {
+			return outputJSON(matches)
+		}
+		if len(matches) == 0 {
+			fmt.Printf("No issues found matching %q in %d town(s)\n", text, len(reg.Towns))
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, m := range matches {
+			fmt.Fprintf(w, "%s\t%s %s\t[%s]\t%s\n", ui.RenderMuted(m.Town),
+				ui.RenderStatusIcon(string(m.Issue.Status)), ui.RenderID(m.Issue.ID),
+				ui.RenderPriority(m.Issue.Priority), m.Issue.Title)
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	townsSwitchCmd.Flags().Bool("clear", false, "Clear the current town")
+	townsListCmd.Flags().Bool("no-stats", false, "Do not open each town to count issues")
+	townsSearchCmd.Flags().BoolP("all", "a", false, "Include closed issues")
+	townsSearchCmd.Flags().IntP("limit", "n", 20, "Maximum matches per town (0 = unlimited)")
+	townsCmd.AddCommand(townsAddCmd, townsRemoveCmd, townsSwitchCmd, townsListCmd, townsSearchCmd)
+	rootCmd.AddCommand(townsCmd)
+}
+
+func currentMarker(current bool) string {
+	if current {
+		return ui.RenderPass("*")
+	}
+	return " "
+}
+
+// loadTowns reads the user's towns registry and returns it with its path.
+func loadTowns() (string, *towns.Registry, error) {
+	path, err := towns.Path()
+	if err != nil {
+		return "", nil, err
+	}
+	reg, err := towns.Load(path)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, reg, nil
+}
+
+// lookupTown returns the registered town called name.
+func lookupTown(name string) (*towns.Town, bool) {
+	_, reg, err := loadTowns()
+	if err != nil {
+		return nil, false
+	}
+	t, err := reg.Get(name)
+	return t, err == nil
+}
+
+// currentTownBeadsDir returns the switched-to town's .beads directory when
+// cmd runs outside any beads project and nothing else selects one (--db,
+// BEADS_DIR, BEADS_DB). It returns "" otherwise.
+func currentTownBeadsDir(cmd *cobra.Command) string {
+	if townSelectionSkipCommands[topLevelCommandName(cmd)] {
+		return ""
+	}
+	_, reg, err := loadTowns()
+	if err != nil {
+		return ""
+	}
+	town := reg.CurrentTown()
+	if town == nil {
+		return ""
+	}
+	if dbPath != "" || os.Getenv("BEADS_DIR") != "" || os.Getenv("BEADS_DB") != "" || os.Getenv("BD_DB") != "" {
+		return ""
+	}
+	if beads.FindBeadsDir() != "" {
+		return ""
+	}
+	return beads.FollowRedirect(town.Path)
+}
+
+// withTownStore opens a town's database read-only and runs fn against it.
+// Like prefix routing, it pins BEADS_DOLT_SERVER_DATABASE to the town's own
+// database so a server-mode town does not inherit the caller's.
+func withTownStore(ctx context.Context, town *towns.Town, fn func(storage.DoltStorage) error) error {
+	beadsDir := beads.FollowRedirect(town.Path)
+	if info, err := os.Stat(beadsDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is missing", beadsDir)
+	}
+	if db := readDoltDatabase(beadsDir); db != "" {
+		orig, had := os.LookupEnv("BEADS_DOLT_SERVER_DATABASE")
+		_ = os.Setenv("BEADS_DOLT_SERVER_DATABASE", db)
+		defer func() {
+			if had {
+				besteffort.Record("restore BEADS_DOLT_SERVER_DATABASE", os.Setenv("BEADS_DOLT_SERVER_DATABASE", orig))
+			} else {
+				besteffort.Record("restore BEADS_DOLT_SERVER_DATABASE", os.Unsetenv("BEADS_DOLT_SERVER_DATABASE"))
+			}
+		}()
+	}
+	st, err := newReadOnlyStoreFromConfig(ctx, beadsDir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = st.Close() }()
+	return fn(st)
+}
+
+func townStatistics(ctx context.Context, town *towns.Town) (*types.Statistics, error) {
+	var stats *types.Statistics
+	err := withTownStore(ctx, town, func(st storage.DoltStorage) error {
+		var err error
+		stats, err = st.GetStatistics(ctx)
+		return err
+	})
+	return stats, err
+}
+
+func searchTown(ctx context.Context, town *towns.Town, text string, filter types.IssueFilter) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	err := withTownStore(ctx, town, func(st storage.DoltStorage) error {
+		var err error
+		issues, err = st.SearchIssues(ctx, text, filter)
+		return err
+	})
+	return issues, err
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/towns"
+)
+
+func TestTownSelection(t *testing.T) {
+	registry := filepath.Join(t.TempDir(), "towns.json")
+	t.Setenv(towns.FileEnv, registry)
+	for _, key := range []string{"BEADS_DIR", "BEADS_DB", "BD_DB"} {
+		t.Setenv(key, "")
+		_ = os.Unsetenv(key)
+	}
+	oldDBPath := dbPath
+	dbPath = ""
+	t.Cleanup(func() { dbPath = oldDBPath })
+
+	townDir := filepath.Join(t.TempDir(), "alpha", ".beads")
+	if err := os.MkdirAll(townDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	reg := &towns.Registry{}
+	if _, err := reg.Add("alpha", townDir, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Save(registry); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(t.TempDir())
+
+	if got, err := resolveChangeDirBeadsDir("alpha"); err != nil || got != townDir {
+		t.Errorf("resolveChangeDirBeadsDir(town) = %q, %v; want %s", got, err, townDir)
+	}
+	if _, err := resolveChangeDirBeadsDir("no-such-town"); err == nil {
+		t.Error("expected an error for an unknown -C target")
+	}
+
+	root := &cobra.Command{Use: "bd"}
+	list := &cobra.Command{Use: "list"}
+	initCmd := &cobra.Command{Use: "init"}
+	root.AddCommand(list, initCmd)
+
+	if got := currentTownBeadsDir(list); got != "" {
+		t.Errorf("no current town: got %q, want none", got)
+	}
+	if err := reg.Switch("alpha"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Save(registry); err != nil {
+		t.Fatal(err)
+	}
+	if got := currentTownBeadsDir(list); got != townDir {
+		t.Errorf("outside a project: got %q, want the current town %s", got, townDir)
+	}
+	if got := currentTownBeadsDir(initCmd); got != "" {
+		t.Errorf("bd init must not target the current town, got %q", got)
+	}
+	t.Setenv("BEADS_DIR", "/somewhere/.beads")
+	if got := currentTownBeadsDir(list); got != "" {
+		t.Errorf("BEADS_DIR set: got %q, want it to win", got)
+	}
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Outcomes of an issue generated from a formula.
+const (
+	traceOutcomeOpen      = "open"      // not yet closed
+	traceOutcomeSucceeded = "succeeded" // closed without a failure close reason
+	traceOutcomeFailed    = "failed"    // closed with a failure close reason (see types.IsFailureClose)
+)
+
+// TraceReport summarizes the issues and wisps generated from one formula.
+type TraceReport struct {
+	Formula     string         `json:"formula"`
+	Total       int            `json:"total"`
+	Issues      int            `json:"issues"`
+	Wisps       int            `json:"wisps"`
+	ByStatus    map[string]int `json:"by_status"`
+	Closed      int            `json:"closed"`
+	Failed      int            `json:"failed"`
+	FailureRate float64        `json:"failure_rate"` // failed / closed; 0 when nothing has closed
+	Steps       []TraceStep    `json:"steps"`
+	Items       []TraceItem    `json:"items"`
+}
+
+// TraceStep aggregates outcomes for one formula step, keyed by the step's
+// source location (e.g. "steps[2]"). The molecule root has no location.
+type TraceStep struct {
+	Location    string  `json:"location"`
+	Total       int     `json:"total"`
+	Closed      int     `json:"closed"`
+	Failed      int     `json:"failed"`
+	FailureRate float64 `json:"failure_rate"`
+}
+
+// TraceItem is one issue or wisp generated from the formula.
+type TraceItem struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	Source      string `json:"source"` // "issue" or "wisp"
+	Location    string `json:"location,omitempty"`
+	Outcome     string `json:"outcome"`
+	CloseReason string `json:"close_reason,omitempty"`
+}
+
+// traceOutcome classifies an issue as open, succeeded, or failed.
+func traceOutcome(issue *types.Issue) string {
+	if issue.Status != types.StatusClosed {
+		return traceOutcomeOpen
+	}
+	if types.IsFailureClose(issue.CloseReason) {
+		return traceOutcomeFailed
+	}
+	return traceOutcomeSucceeded
+}
+
+// failureRate returns failed/closed, or 0 when nothing has closed.
+func failureRate(failed, closed int) float64 {
+	if closed == 0 {
+		return 0
+	}
+	return float64(failed) / float64(closed)
+}
+
+// buildTraceReport aggregates the issues generated from formula. Items are
+// ordered oldest first; steps are ordered by location.
+func buildTraceReport(formula string, issues []*types.Issue) *TraceReport {
+	report := &TraceReport{
+		Formula:  formula,
+		ByStatus: make(map[string]int),
+		Steps:    []TraceStep{},
+		Items:    []TraceItem{},
+	}
+	sorted := slices.Clone(issues)
+	slices.SortStableFunc(sorted, func(a, b *types.Issue) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	steps := make(map[string]*TraceStep)
+	for _, issue := range sorted {
+		outcome := traceOutcome(issue)
+		source := issueSource(issue)
+		report.Total++
+		if source == issueSourceWisp {
+			report.Wisps++
+		} else {
+			report.Issues++
+		}
+		report.ByStatus[string(issue.Status)]++
+
+		step := steps[issue.SourceLocation]
+		if step == nil {
+			step = &TraceStep{Location: issue.SourceLocation}
+			steps[issue.SourceLocation] = step
+		}
+		step.Total++
+		if outcome != traceOutcomeOpen {
+			report.Closed++
+			step.Closed++
+		}
+		if outcome == traceOutcomeFailed {
+			report.Failed++
+			step.Failed++
+		}
+
+		report.Items = append(report.Items, TraceItem{
+			ID:          issue.ID,
+			Title:       issue.Title,
+			Status:      string(issue.Status),
+			Source:      source,
+			Location:    issue.SourceLocation,
+			Outcome:     outcome,
+			CloseReason: issue.CloseReason,
+		})
+	}
+	report.FailureRate = failureRate(report.Failed, report.Closed)
+
+	for _, step := range steps {
+		step.FailureRate = failureRate(step.Failed, step.Closed)
+		report.Steps = append(report.Steps, *step)
+	}
+	slices.SortFunc(report.Steps, func(a, b TraceStep) int {
+		return strings.Compare(a.Location, b.Location)
+	})
+	return report
+}
+
+var traceCmd = &cobra.Command{
+	Use:     "trace <formula>",
+	GroupID: "views",
+	Short:   "Show the issues and wisps a formula generated and how they ended",
+	Long: `Show every issue and wisp poured from a formula, with each one's outcome
+and the formula's failure rate overall and per step.
+
+An issue is "failed" when it was closed with a failure close reason
+(containing failed, rejected, timeout, aborted, ...), "succeeded" when it
+was closed with any other reason, and "open" otherwise. The failure rate is
+failed issues divided by closed issues. Protos are not counted: only the
+work poured from them is.
+
+Only issues created after formula provenance was recorded are traced. Use
+'bd list --formula <name>' to filter the regular list view instead.
+
+Examples:
+  bd trace mol-release              # Summary, per-step rates, and issues
+  bd trace mol-patrol --failed      # Only the failures
+  bd trace mol-patrol --json        # Machine-readable report`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runTrace,
+}
+
+func init() {
+	traceCmd.Flags().Bool("failed", false, "List only failed issues (the summary still counts everything)")
+	traceCmd.Flags().IntP("limit", "n", 50, "Maximum number of issues to list (0 = all)")
+	rootCmd.AddCommand(traceCmd)
+}
+
+func runTrace(cmd *cobra.Command, args []string) error {
+	if usesProxiedServer() {
+		return HandleErrorRespectJSON("trace is not supported in proxied-server mode")
+	}
+	evt := metrics.NewCommandEvent("trace")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	formula := args[0]
+	failedOnly, _ := cmd.Flags().GetBool("failed")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if store == nil {
+		return HandleErrorWithHint("database not initialized", diagHint())
+	}
+
+	// SkipWisps is left false and Ephemeral nil so both tables are searched.
+	notTemplate := false
+	issues, err := store.SearchIssues(rootCtx, "", types.IssueFilter{
+		SourceFormula: formula,
+		IsTemplate:    &notTemplate,
+	})
+	if err != nil {
+		return HandleErrorRespectJSON("tracing formula %s: %v", formula, err)
+	}
+	report := buildTraceReport(formula, issues)
+	if failedOnly {
+		report.Items = slices.DeleteFunc(report.Items, func(it TraceItem) bool {
+			return it.Outcome != traceOutcomeFailed
+		})
+	}
+	shown := report.Items
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+
+	if jsonOutput {
+		report.Items = shown
+		return outputJSON(report)
+	}
+
+	if report.Total == 0 {
+		fmt.Printf("No issues or wisps generated from formula %s\n", formula)
+		return nil
+	}
+	fmt.Printf("%s %s\n\n", ui.RenderBold("Formula:"), formula)
+	fmt.Printf("  Generated:    %d (%d issues, %d wisps)\n", report.Total, report.Issues, report.Wisps)
+	statuses := make([]string, 0, len(report.ByStatus))
+	for status := range report.ByStatus {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = fmt.Sprintf("%s %d", status, report.ByStatus[status])
+	}
+	fmt.Printf("  By status:    %s\n", strings.Join(parts, ", "))
+	fmt.Printf("  Failure rate: %.0f%% (%d of %d closed)\n", report.FailureRate*100, report.Failed, report.Closed)
+
+	if len(report.Steps) > 1 {
+		fmt.Printf("\n%s\n", ui.RenderBold("By step:"))
+		for _, step := range report.Steps {
+			location := step.Location
+			if location == "" {
+				location = "(root)"
+			}
+			fmt.Printf("  %-20s %3d generated, %3d closed, %3d failed (%.0f%%)\n",
+				location, step.Total, step.Closed, step.Failed, step.FailureRate*100)
+		}
+	}
+
+	if len(shown) == 0 {
+		return nil
+	}
+	fmt.Printf("\n%s\n", ui.RenderBold("Issues:"))
+	for _, it := range shown {
+		line := fmt.Sprintf("  %s %s %s", renderStatusIcon(types.Status(it.Status)), it.ID, it.Title)
+		if it.Source == issueSourceWisp {
+			line += " " + ui.RenderMuted("[wisp]")
+		}
+		switch it.Outcome {
+		case traceOutcomeFailed:
+			line += " " + ui.RenderFail("failed: "+it.CloseReason)
+		case traceOutcomeSucceeded:
+			line += " " + ui.RenderPass("succeeded")
+		}
+		fmt.Println(line)
+	}
+	if len(shown) < len(report.Items) {
+		fmt.Printf("\n  ... %d more (use --limit 0 to list all)\n", len(report.Items)-len(shown))
+	}
+	return nil
+}
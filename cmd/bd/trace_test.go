@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildTraceReport(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	issue := func(id string, minutes int, location string, status types.Status, reason string) *types.Issue {
+		return &types.Issue{
+			ID:             id,
+			Title:          id,
+			Status:         status,
+			CloseReason:    reason,
+			SourceFormula:  "mol-demo",
+			SourceLocation: location,
+			CreatedAt:      base.Add(time.Duration(minutes) * time.Minute),
+		}
+	}
+	wisp := issue("bd-wisp-b", 1, "steps[0]", types.StatusClosed, "Build failed: timeout")
+	wisp.Ephemeral = true
+	issues := []*types.Issue{
+		issue("bd-mol-d", 3, "steps[1]", types.StatusOpen, ""),
+		wisp,
+		issue("bd-mol-a", 0, "", types.StatusOpen, ""),
+		issue("bd-mol-c", 2, "steps[0]", types.StatusClosed, "Done"),
+		issue("bd-mol-e", 4, "steps[1]", types.StatusClosed, "rejected by review"),
+	}
+
+	r := buildTraceReport("mol-demo", issues)
+	if r.Total != 5 || r.Issues != 4 || r.Wisps != 1 {
+		t.Errorf("total/issues/wisps = %d/%d/%d, want 5/4/1", r.Total, r.Issues, r.Wisps)
+	}
+	if r.ByStatus["open"] != 2 || r.ByStatus["closed"] != 3 {
+		t.Errorf("by status = %v", r.ByStatus)
+	}
+	if r.Closed != 3 || r.Failed != 2 || r.FailureRate != 2.0/3 {
+		t.Errorf("closed/failed/rate = %d/%d/%v, want 3/2/0.667", r.Closed, r.Failed, r.FailureRate)
+	}
+
+	wantSteps := []TraceStep{
+		{Location: "", Total: 1},
+		{Location: "steps[0]", Total: 2, Closed: 2, Failed: 1, FailureRate: 0.5},
+		{Location: "steps[1]", Total: 2, Closed: 1, Failed: 1, FailureRate: 1},
+	}
+	if len(r.Steps) != len(wantSteps) {
+		t.Fatalf("steps = %+v, want %+v", r.Steps, wantSteps)
+	}
+	for i, want := range wantSteps {
+		if r.Steps[i] != want {
+			t.Errorf("steps[%d] = %+v, want %+v", i, r.Steps[i], want)
+		}
+	}
+
+	wantItems := []struct{ id, outcome, source string }{
+		{"bd-mol-a", traceOutcomeOpen, issueSourceIssue},
+		{"bd-wisp-b", traceOutcomeFailed, issueSourceWisp},
+		{"bd-mol-c", traceOutcomeSucceeded, issueSourceIssue},
+		{"bd-mol-d", traceOutcomeOpen, issueSourceIssue},
+		{"bd-mol-e", traceOutcomeFailed, issueSourceIssue},
+	}
+	for i, want := range wantItems {
+		it := r.Items[i]
+		if it.ID != want.id || it.Outcome != want.outcome || it.Source != want.source {
+			t.Errorf("items[%d] = %s %s %s, want %s %s %s (oldest first)", i, it.ID, it.Outcome, it.Source, want.id, want.outcome, want.source)
+		}
+	}
+}
+
+func TestBuildTraceReport_Empty(t *testing.T) {
+	r := buildTraceReport("mol-none", nil)
+	if r.Total != 0 || r.FailureRate != 0 || r.Steps == nil || r.Items == nil {
+		t.Errorf("empty report = %+v; want zero counts and non-nil slices for JSON", r)
+	}
+}
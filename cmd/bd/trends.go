@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// trendDays is how many days the sparkline trend columns cover.
+const trendDays = 14
+
+// StatusTrends holds the daily counts behind bd status's trend lines, oldest
+// day first.
+type StatusTrends struct {
+	Days     int   `json:"days"`
+	Created  []int `json:"created"`
+	Closed   []int `json:"closed"`
+	Reopened []int `json:"reopened"`
+}
+
+// formatTrend renders a daily series as a sparkline and its total:
+// "▁▁▃█▂  12 in 14d".
+func formatTrend(series []int) string {
+	total := 0
+	for _, v := range series {
+		total += v
+	}
+	return fmt.Sprintf("%s  %s", ui.RenderAccent(ui.Sparkline(series)),
+		ui.RenderMuted(fmt.Sprintf("%d in %dd", total, len(series))))
+}
+
+// attachEpicActivity fills in each epic's activity trend. Trends are
+// decoration: a backend without them, or a failed query, leaves them out.
+func attachEpicActivity(ctx context.Context, st storage.DoltStorage, epics []*types.EpicStatus) {
+	ts, ok := storage.UnwrapStore(st).(storage.TrendStore)
+	if !ok || len(epics) == 0 {
+		return
+	}
+	ids := make([]string, len(epics))
+	for i, e := range epics {
+		ids[i] = e.Epic.ID
+	}
+	trends, err := ts.GetActivityTrends(ctx, ids, trendDays)
+	if err != nil {
+		debug.Logf("trends: epic activity: %v\n", err)
+		return
+	}
+	for _, e := range epics {
+		e.Activity = trends[e.Epic.ID]
+	}
+}
+
+// loadStatusTrends returns the created, closed, and reopened trends for bd
+// status, or nil when the backend cannot aggregate events.
+func loadStatusTrends(ctx context.Context, st storage.DoltStorage) *StatusTrends {
+	ts, ok := storage.UnwrapStore(st).(storage.TrendStore)
+	if !ok {
+		return nil
+	}
+	trends := &StatusTrends{Days: trendDays}
+	for _, series := range []struct {
+		event types.EventType
+		into  *[]int
+	}{
+		{types.EventCreated, &trends.Created},
+		{types.EventClosed, &trends.Closed},
+		{types.EventReopened, &trends.Reopened},
+	} {
+		counts, err := ts.GetEventTrend(ctx, series.event, trendDays)
+		if err != nil {
+			debug.Logf("trends: %s: %v\n", series.event, err)
+			return nil
+		}
+		*series.into = counts
+	}
+	return trends
+}
@@ -0,0 +1,637 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// triagedMetadataKey marks an issue as triaged, so a priority-only
+// decision still takes it out of the untriaged queue.
+const triagedMetadataKey = "triaged_at"
+
+const triageKeyHelp = "[0-4] priority  [l]abel  [a]ssign  [d]efer  [c]lose  [n]ext  [u]ndo  [q]uit  [?]"
+
+var triageCmd = &cobra.Command{
+	Use:     "triage",
+	GroupID: "issues",
+	Short:   "Walk through untriaged issues interactively",
+	Long: `Walk through untriaged issues one at a time, oldest first, and decide
+each with a single key.
+
+An issue is untriaged when it is open, has no assignee and no labels, and
+has not been triaged before. Gates and molecules are not included. To
+change the queue, set views.triage.query in config.yaml to a bd query
+expression; issues already triaged are always left out.
+
+Pass --list to print the queue instead of walking it.
+
+Keys:
+  0-4    set priority
+  l      add a label
+  a      assign
+  d      defer (prompts for a date; default +1w)
+  c      close (prompts for a reason)
+  n      next issue (also Enter or space)
+  u      undo the last decision
+  q      quit
+
+Priority, label, and assign keep the issue on screen so several decisions
+can be combined; defer and close move on. Leaving an issue after any
+decision marks it triaged. At the end bd prints a summary of every decision
+and offers to undo any of them.
+
+Examples:
+  bd triage
+  bd triage --limit 20
+  bd triage --list --json`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("triage is not supported in proxied-server mode")
+		}
+		if list, _ := cmd.Flags().GetBool("list"); list {
+			return runTriageList(cmd)
+		}
+		CheckReadonly("triage")
+		if jsonOutput {
+			return HandleErrorRespectJSON("triage is interactive and has no --json output")
+		}
+		evt := metrics.NewCommandEvent("triage")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+		if err := ensureStoreActive(); err != nil {
+			return HandleErrorRespectJSON("database not available: %v", err)
+		}
+		ctx := rootCtx
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		issues, err := untriagedIssues(ctx, store, limit)
+		if err != nil {
+			return HandleErrorRespectJSON("failed to load issues: %v", err)
+		}
+		if len(issues) == 0 {
+			fmt.Printf("%s Nothing to triage\n", ui.RenderPass("✓"))
+			return nil
+		}
+
+		var in triageInput = &lineTriageInput{r: bufio.NewReader(os.Stdin), out: os.Stdout}
+		if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) { // #nosec G115 -- file descriptors fit in int
+			in = &keyTriageInput{fd: fd, lineTriageInput: lineTriageInput{r: bufio.NewReader(os.Stdin), out: os.Stdout}}
+		}
+		s := &triageSession{ctx: ctx, st: store, in: in, out: os.Stdout, now: time.Now}
+		s.run(issues)
+		s.finish()
+		if len(s.actions) > 0 {
+			commandDidWrite.Store(true)
+		}
+		return nil
+	},
+}
+
+// runTriageList prints the untriaged queue without prompting.
+func runTriageList(cmd *cobra.Command) error {
+	if err := ensureStoreActive(); err != nil {
+		return HandleErrorRespectJSON("database not available: %v", err)
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	issues, err := untriagedIssues(rootCtx, store, limit)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to load issues: %v", err)
+	}
+	if jsonOutput {
+		if issues == nil {
+			issues = []*types.Issue{}
+		}
+		return outputJSON(issues)
+	}
+	if len(issues) == 0 {
+		fmt.Printf("%s Nothing to triage\n", ui.RenderPass("✓"))
+		return nil
+	}
+	fmt.Printf("Untriaged (%d):\n", len(issues))
+	var buf strings.Builder
+	for _, issue := range issues {
+		buf.WriteString("  ")
+		formatQueryIssue(&buf, issue)
+	}
+	fmt.Print(buf.String())
+	return nil
+}
+
+// untriagedIssues returns the issues matching the triage view (by default
+// open, with no assignee and no labels) that carry no triage marker, oldest
+// first.
+func untriagedIssues(ctx context.Context, st storage.DoltStorage, limit int) ([]*types.Issue, error) {
+	view, err := resolveView("triage")
+	if err != nil {
+		return nil, err
+	}
+	sections, err := runView(ctx, st, view, getActor(), 0)
+	if err != nil {
+		return nil, err
+	}
+	var out []*types.Issue
+	for _, issue := range sections[0].Issues {
+		if !isTriaged(issue) {
+			out = append(out, issue)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func isTriaged(issue *types.Issue) bool {
+	if len(issue.Metadata) == 0 {
+		return false
+	}
+	var meta map[string]json.RawMessage
+	if err := json.Unmarshal(issue.Metadata, &meta); err != nil {
+		return false
+	}
+	raw, ok := meta[triagedMetadataKey]
+	return ok && string(raw) != "null"
+}
+
+// triageInput reads decisions: single keys, and whole lines for values
+// such as a label or a date.
+type triageInput interface {
+	ReadKey() (rune, error)
+	ReadLine(prompt string) (string, error)
+}
+
+// lineTriageInput reads one line per key, for pipes and dumb terminals.
+type lineTriageInput struct {
+	r   *bufio.Reader
+	out io.Writer
+}
+
+func (l *lineTriageInput) ReadKey() (rune, error) {
+	line, err := l.r.ReadString('\n')
+	if err != nil && line == "" {
+		return 0, err
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return '\n', nil
+	}
+	return []rune(line)[0], nil
+}
+
+func (l *lineTriageInput) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(l.out, prompt)
+	line, err := l.r.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// keyTriageInput reads single keypresses from a terminal in raw mode.
+type keyTriageInput struct {
+	fd int
+	lineTriageInput
+}
+
+func (k *keyTriageInput) ReadKey() (rune, error) {
+	old, err := term.MakeRaw(k.fd)
+	if err != nil {
+		return k.lineTriageInput.ReadKey()
+	}
+	b, err := k.r.ReadByte()
+	_ = term.Restore(k.fd, old)
+	if err != nil {
+		return 0, err
+	}
+	switch b {
+	case '\r':
+		b = '\n'
+	case 3, 4: // Ctrl-C, Ctrl-D
+		b = 'q'
+	}
+	if b != '\n' {
+		fmt.Fprintf(k.out, "%c", b)
+	}
+	fmt.Fprintln(k.out)
+	return rune(b), nil
+}
+
+// triageAction is one decision made during a session.
+type triageAction struct {
+	issue  *types.Issue
+	desc   string
+	undo   func(context.Context) error
+	undone bool
+}
+
+type triageSession struct {
+	ctx      context.Context
+	st       storage.DoltStorage
+	in       triageInput
+	out      io.Writer
+	now      func() time.Time
+	actions  []*triageAction
+	marked   map[string]bool // issues this session marked triaged
+	reviewed int
+	quit     bool
+}
+
+func (s *triageSession) run(issues []*types.Issue) {
+	for i, issue := range issues {
+		if s.quit {
+			return
+		}
+		s.reviewed++
+		s.show(issue, i+1, len(issues))
+		before := s.activeCount(issue.ID)
+		s.decide(issue)
+		if s.activeCount(issue.ID) > before {
+			s.markTriaged(issue)
+		}
+	}
+}
+
+// decide reads keys for one issue until a key moves on.
+func (s *triageSession) decide(issue *types.Issue) {
+	for {
+		fmt.Fprintf(s.out, "%s ", ui.RenderMuted(triageKeyHelp))
+		key, err := s.in.ReadKey()
+		if err != nil {
+			s.quit = true
+			return
+		}
+		var done bool
+		switch {
+		case key >= '0' && key <= '4':
+			err = s.setPriority(issue, int(key-'0'))
+		case key == 'l':
+			err = s.addLabel(issue)
+		case key == 'a':
+			err = s.assign(issue)
+		case key == 'd':
+			done, err = s.deferIssue(issue)
+		case key == 'c':
+			done, err = s.closeIssue(issue)
+		case key == 'n' || key == 's' || key == ' ' || key == '\n':
+			return
+		case key == 'u':
+			s.undoLast()
+		case key == 'q':
+			s.quit = true
+			return
+		case key == '?' || key == 'h':
+			fmt.Fprintln(s.out, "  0-4 priority · l label · a assign · d defer · c close · n/Enter next · u undo last · q quit")
+		default:
+			fmt.Fprintf(s.out, "  unknown key %q (? for help)\n", key)
+		}
+		if err != nil {
+			fmt.Fprintf(s.out, "  %s %v\n", ui.RenderFail("✗"), err)
+			continue
+		}
+		if done {
+			return
+		}
+	}
+}
+
+func (s *triageSession) show(issue *types.Issue, n, total int) {
+	fmt.Fprintf(s.out, "\n%s\n", ui.RenderMuted(fmt.Sprintf("── %d/%d ──", n, total)))
+	fmt.Fprintf(s.out, "%s %s  [%s · %s]\n", ui.RenderID(issue.ID), ui.RenderBold(issue.Title),
+		ui.RenderPriority(issue.Priority), issue.IssueType)
+	age := s.now().Sub(issue.CreatedAt).Round(time.Hour)
+	created := fmt.Sprintf("Created %s (%s ago)", issue.CreatedAt.Format("2006-01-02"), formatTriageAge(age))
+	if issue.CreatedBy != "" {
+		created += " by " + issue.CreatedBy
+	}
+	fmt.Fprintln(s.out, ui.RenderMuted(created))
+
+	if deps, err := s.st.GetDependenciesWithMetadata(s.ctx, issue.ID); err == nil {
+		for _, d := range deps {
+			label := "Depends on"
+			switch d.DependencyType {
+			case types.DepParentChild:
+				label = "Parent"
+			case types.DepBlocks:
+				label = "Blocked by"
+			}
+			fmt.Fprintf(s.out, "%s: %s [%s]\n", label, formatFeedbackID(d.ID, d.Title), d.Status)
+		}
+	}
+	if deps, err := s.st.GetDependentsWithMetadata(s.ctx, issue.ID); err == nil {
+		for _, d := range deps {
+			label := "Related"
+			switch d.DependencyType {
+			case types.DepParentChild:
+				label = "Child"
+			case types.DepBlocks:
+				label = "Blocks"
+			}
+			fmt.Fprintf(s.out, "%s: %s [%s]\n", label, formatFeedbackID(d.ID, d.Title), d.Status)
+		}
+	}
+	for _, section := range []struct{ name, text string }{
+		{"", issue.Description},
+		{"Acceptance criteria", issue.AcceptanceCriteria},
+		{"Design", issue.Design},
+		{"Notes", issue.Notes},
+	} {
+		if strings.TrimSpace(section.text) == "" {
+			continue
+		}
+		fmt.Fprintln(s.out)
+		if section.name != "" {
+			fmt.Fprintln(s.out, ui.RenderAccent(section.name))
+		}
+		fmt.Fprintln(s.out, strings.TrimRight(section.text, "\n"))
+	}
+	if comments, err := s.st.GetIssueComments(s.ctx, issue.ID); err == nil && len(comments) > 0 {
+		last := comments[len(comments)-1]
+		fmt.Fprintf(s.out, "\n%s\n", ui.RenderMuted(fmt.Sprintf("%d comment(s); latest by %s: %s",
+			len(comments), last.Author, truncateTriageText(last.Text, 120))))
+	}
+	fmt.Fprintln(s.out)
+}
+
+func formatTriageAge(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+func truncateTriageText(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if len([]rune(s)) > n {
+		return string([]rune(s)[:n]) + "…"
+	}
+	return s
+}
+
+func (s *triageSession) record(issue *types.Issue, desc string, undo func(context.Context) error) {
+	s.actions = append(s.actions, &triageAction{issue: issue, desc: desc, undo: undo})
+	fmt.Fprintf(s.out, "  %s %s\n", ui.RenderPass("✓"), desc)
+}
+
+func (s *triageSession) setPriority(issue *types.Issue, p int) error {
+	old := issue.Priority
+	if old == p {
+		return nil
+	}
+	if err := s.st.UpdateIssue(s.ctx, issue.ID, map[string]interface{}{"priority": p}, actor); err != nil {
+		return err
+	}
+	issue.Priority = p
+	s.record(issue, fmt.Sprintf("priority P%d → P%d", old, p), func(ctx context.Context) error {
+		if err := s.st.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": old}, actor); err != nil {
+			return err
+		}
+		issue.Priority = old
+		return nil
+	})
+	return nil
+}
+
+func (s *triageSession) addLabel(issue *types.Issue) error {
+	label, err := s.in.ReadLine("  label: ")
+	if err != nil || label == "" {
+		return err
+	}
+	if err := s.st.AddLabel(s.ctx, issue.ID, label, actor); err != nil {
+		return err
+	}
+	s.record(issue, "label +"+label, func(ctx context.Context) error {
+		return s.st.RemoveLabel(ctx, issue.ID, label, actor)
+	})
+	return nil
+}
+
+func (s *triageSession) assign(issue *types.Issue) error {
+	to, err := s.in.ReadLine("  assign to: ")
+	if err != nil || to == "" {
+		return err
+	}
+	old := issue.Assignee
+	if err := s.st.UpdateIssue(s.ctx, issue.ID, map[string]interface{}{"assignee": to}, actor); err != nil {
+		return err
+	}
+	issue.Assignee = to
+	s.record(issue, "assigned to "+to, func(ctx context.Context) error {
+		if err := s.st.UpdateIssue(ctx, issue.ID, map[string]interface{}{"assignee": old}, actor); err != nil {
+			return err
+		}
+		issue.Assignee = old
+		return nil
+	})
+	return nil
+}
+
+func (s *triageSession) deferIssue(issue *types.Issue) (bool, error) {
+	when, err := s.in.ReadLine("  defer until [+1w]: ")
+	if err != nil {
+		return false, err
+	}
+	if when == "" {
+		when = "+1w"
+	}
+	until, err := timeparsing.ParseRelativeTime(when, s.now())
+	if err != nil {
+		return false, fmt.Errorf("invalid date %q. Examples: +3d, next monday, 2025-01-15", when)
+	}
+	if !until.After(s.now()) {
+		return false, fmt.Errorf("defer date %s is not in the future", until.Format("2006-01-02 15:04"))
+	}
+	oldStatus, oldUntil := issue.Status, issue.DeferUntil
+	updates := map[string]interface{}{"defer_until": until, "status": string(types.StatusDeferred)}
+	if err := s.st.UpdateIssue(s.ctx, issue.ID, updates, actor); err != nil {
+		return false, err
+	}
+	s.record(issue, "deferred until "+until.Format("2006-01-02"), func(ctx context.Context) error {
+		var restore interface{}
+		if oldUntil != nil {
+			restore = *oldUntil
+		}
+		return s.st.UpdateIssue(ctx, issue.ID, map[string]interface{}{"defer_until": restore, "status": string(oldStatus)}, actor)
+	})
+	return true, nil
+}
+
+func (s *triageSession) closeIssue(issue *types.Issue) (bool, error) {
+	reason, err := s.in.ReadLine("  close reason [wontfix: closed during triage]: ")
+	if err != nil {
+		return false, err
+	}
+	if reason == "" {
+		reason = "wontfix: closed during triage"
+	}
+	if err := validateCloseReasons([]string{reason}); err != nil {
+		return false, err
+	}
+	if err := validateCloseReasonCategories([]string{reason}); err != nil {
+		return false, err
+	}
+	duplicateLinked := func() (bool, error) { return hasDuplicateLink(s.ctx, s.st, issue.ID) }
+	if err := checkClosePolicies(issue.ID, issue, reason, duplicateLinked); err != nil {
+		return false, err
+	}
+	if _, err := s.st.CloseIssueChecked(s.ctx, issue.ID, actor, storage.CloseIssueOptions{Reason: reason}); err != nil {
+		return false, err
+	}
+	inReview, followups := runPostCloseHooks(s.ctx, s.st, issue.ID, issue, actor)
+	if inReview {
+		fmt.Fprintf(s.out, "  %s waiting for human review (bd review list)\n", ui.RenderWarn("→"))
+	}
+	for _, f := range followups {
+		fmt.Fprintf(s.out, "  %s follow-up %s created\n", ui.RenderAccent("→"), f)
+	}
+	s.record(issue, "closed: "+reason, func(ctx context.Context) error {
+		return s.st.ReopenIssue(ctx, issue.ID, "undo triage close", actor)
+	})
+	return true, nil
+}
+
+func (s *triageSession) markTriaged(issue *types.Issue) {
+	raw, _ := json.Marshal(s.now().UTC().Format(time.RFC3339))
+	if err := s.st.MergeMetadata(s.ctx, issue.ID, triagedMetadataKey, raw, actor); err != nil {
+		fmt.Fprintf(s.out, "  %s marking %s triaged: %v\n", ui.RenderWarn("⚠"), issue.ID, err)
+		return
+	}
+	if s.marked == nil {
+		s.marked = make(map[string]bool)
+	}
+	s.marked[issue.ID] = true
+}
+
+func (s *triageSession) activeCount(issueID string) int {
+	n := 0
+	for _, a := range s.actions {
+		if a.issue.ID == issueID && !a.undone {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *triageSession) undoLast() {
+	for i := len(s.actions) - 1; i >= 0; i-- {
+		if !s.actions[i].undone {
+			s.undo([]int{i})
+			return
+		}
+	}
+	fmt.Fprintln(s.out, "  nothing to undo")
+}
+
+// undo reverts the given actions, newest first, then clears the triage
+// marker of any issue left with no decisions.
+func (s *triageSession) undo(indexes []int) {
+	sort.Sort(sort.Reverse(sort.IntSlice(indexes)))
+	for _, i := range indexes {
+		a := s.actions[i]
+		if a.undone {
+			continue
+		}
+		if err := a.undo(s.ctx); err != nil {
+			fmt.Fprintf(s.out, "  %s undo %s %s: %v\n", ui.RenderFail("✗"), a.issue.ID, a.desc, err)
+			continue
+		}
+		a.undone = true
+		fmt.Fprintf(s.out, "  %s undid %s %s\n", ui.RenderAccent("↶"), a.issue.ID, a.desc)
+		if s.marked[a.issue.ID] && s.activeCount(a.issue.ID) == 0 {
+			if err := s.st.MergeMetadata(s.ctx, a.issue.ID, triagedMetadataKey, json.RawMessage("null"), actor); err == nil {
+				delete(s.marked, a.issue.ID)
+			}
+		}
+	}
+}
+
+// finish prints the session summary and offers to undo decisions.
+func (s *triageSession) finish() {
+	active := 0
+	for _, a := range s.actions {
+		if !a.undone {
+			active++
+		}
+	}
+	fmt.Fprintf(s.out, "\nTriage summary: %d reviewed, %d decision(s), %d issue(s) triaged\n",
+		s.reviewed, active, len(s.marked))
+	if active == 0 {
+		return
+	}
+	for i, a := range s.actions {
+		if a.undone {
+			continue
+		}
+		fmt.Fprintf(s.out, "  %2d. %s: %s\n", i+1, formatFeedbackID(a.issue.ID, a.issue.Title), a.desc)
+	}
+	answer, err := s.in.ReadLine("\nUndo any? (numbers like 1,3, \"all\", or Enter to keep): ")
+	if err != nil && !errors.Is(err, io.EOF) || answer == "" {
+		return
+	}
+	indexes, err := parseTriageUndo(answer, len(s.actions))
+	if err != nil {
+		fmt.Fprintf(s.out, "%s %v; nothing undone\n", ui.RenderFail("✗"), err)
+		return
+	}
+	s.undo(indexes)
+}
+
+// parseTriageUndo parses the end-of-session undo answer into zero-based
+// action indexes.
+func parseTriageUndo(answer string, n int) ([]int, error) {
+	if strings.EqualFold(strings.TrimSpace(answer), "all") {
+		out := make([]int, n)
+		for i := range out {
+			out[i] = i
+		}
+		return out, nil
+	}
+	var out []int
+	for _, part := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }) {
+		i, err := strconv.Atoi(part)
+		if err != nil || i < 1 || i > n {
+			return nil, fmt.Errorf("invalid decision number %q", part)
+		}
+		out = append(out, i-1)
+	}
+	return out, nil
+}
+
+func init() {
+	triageCmd.Flags().Int("limit", 0, "Stop after this many issues (0 = all)")
+	triageCmd.Flags().Bool("list", false, "Print the untriaged queue instead of walking it")
+	rootCmd.AddCommand(triageCmd)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// fakeTriageStore records the writes a triage session makes.
+type fakeTriageStore struct {
+	storage.DoltStorage
+	priority map[string]int
+	labels   map[string][]string
+	metadata map[string]string
+}
+
+func (f *fakeTriageStore) UpdateIssue(_ context.Context, id string, updates map[string]interface{}, _ string) error {
+	if p, ok := updates["priority"].(int); ok {
+		f.priority[id] = p
+	}
+	return nil
+}
+
+func (f *fakeTriageStore) AddLabel(_ context.Context, id, label, _ string) error {
+	f.labels[id] = append(f.labels[id], label)
+	return nil
+}
+
+func (f *fakeTriageStore) RemoveLabel(_ context.Context, id, label, _ string) error {
+	var kept []string
+	for _, l := range f.labels[id] {
+		if l != label {
+			kept = append(kept, l)
+		}
+	}
+	f.labels[id] = kept
+	return nil
+}
+
+func (f *fakeTriageStore) MergeMetadata(_ context.Context, id, key string, value json.RawMessage, _ string) error {
+	f.metadata[id+"."+key] = string(value)
+	return nil
+}
+
+func (f *fakeTriageStore) GetDependenciesWithMetadata(context.Context, string) ([]*types.IssueWithDependencyMetadata, error) {
+	return nil, nil
+}
+
+func (f *fakeTriageStore) GetDependentsWithMetadata(context.Context, string) ([]*types.IssueWithDependencyMetadata, error) {
+	return nil, nil
+}
+
+func (f *fakeTriageStore) GetIssueComments(context.Context, string) ([]*types.Comment, error) {
+	return nil, nil
+}
+
+func TestTriageSession(t *testing.T) {
+	st := &fakeTriageStore{priority: map[string]int{}, labels: map[string][]string{}, metadata: map[string]string{}}
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Flaky login", Priority: 2, IssueType: types.TypeBug, CreatedAt: now.Add(-72 * time.Hour)},
+		{ID: "bd-2", Title: "Docs typo", Priority: 2, IssueType: types.TypeTask, CreatedAt: now.Add(-time.Hour)},
+		{ID: "bd-3", Title: "Slow search", Priority: 2, IssueType: types.TypeTask, CreatedAt: now},
+	}
+	// bd-1: P1 and a label; bd-2: P3 then next; bd-3: quit untouched.
+	// At the end, undo decision 3 (bd-2's priority).
+	script := "1\nl\nauth\nn\n3\n\nq\n3\n"
+	s := &triageSession{
+		ctx: context.Background(),
+		st:  st,
+		in:  &lineTriageInput{r: bufio.NewReader(strings.NewReader(script)), out: io.Discard},
+		out: io.Discard,
+		now: func() time.Time { return now },
+	}
+	s.run(issues)
+	s.finish()
+
+	if st.priority["bd-1"] != 1 || !reflect.DeepEqual(st.labels["bd-1"], []string{"auth"}) {
+		t.Errorf("bd-1: priority %d labels %v, want P1 [auth]", st.priority["bd-1"], st.labels["bd-1"])
+	}
+	if st.priority["bd-2"] != 2 {
+		t.Errorf("bd-2 priority = %d, want the undo to restore P2", st.priority["bd-2"])
+	}
+	if st.metadata["bd-1.triaged_at"] == "" || st.metadata["bd-1.triaged_at"] == "null" {
+		t.Error("bd-1 should be marked triaged")
+	}
+	if got := st.metadata["bd-2.triaged_at"]; got != "null" {
+		t.Errorf("bd-2 triage marker = %q, want cleared after undoing its only decision", got)
+	}
+	if _, ok := st.metadata["bd-3.triaged_at"]; ok {
+		t.Error("bd-3 had no decisions and should not be marked")
+	}
+	if s.reviewed != 3 {
+		t.Errorf("reviewed = %d, want 3", s.reviewed)
+	}
+}
+
+func TestParseTriageUndo(t *testing.T) {
+	if got, err := parseTriageUndo("all", 3); err != nil || !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("all = %v, %v", got, err)
+	}
+	if got, err := parseTriageUndo("1, 3", 3); err != nil || !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Errorf("1, 3 = %v, %v", got, err)
+	}
+	for _, bad := range []string{"0", "4", "x"} {
+		if _, err := parseTriageUndo(bad, 3); err == nil {
+			t.Errorf("parseTriageUndo(%q) should fail", bad)
+		}
+	}
+}
@@ -37,6 +37,9 @@ stderr, and the command exits nonzero.`,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		CheckReadonly("update")
+		if offlineQueueActive() {
+			return enqueueUpdate(cmd, args)
+		}
 
 		evt := metrics.NewCommandEvent("update")
 		defer func() {
@@ -45,6 +48,9 @@ stderr, and the command exits nonzero.`,
 			}
 		}()
 
+		if usesProxiedServer() && cmd.Flags().Changed("encrypted") {
+			return HandleErrorRespectJSON("--encrypted is not supported with a proxied server: the issue key lives in the local .beads directory")
+		}
 		if usesProxiedServer() {
 			return runUpdateProxiedServer(cmd, rootCtx, args)
 		}
@@ -109,8 +115,12 @@ stderr, and the command exits nonzero.`,
 			}
 			updates["title"] = title
 		}
+		oooFrom := ""
 		if cmd.Flags().Changed("assignee") {
 			assignee, _ := cmd.Flags().GetString("assignee")
+			if to, redirected := redirectForOOO(rootCtx, store, assignee); redirected {
+				oooFrom, assignee = assignee, to
+			}
 			updates["assignee"] = assignee
 		}
 		description, descChanged, err := getDescriptionFlag(cmd)
@@ -273,6 +283,10 @@ stderr, and the command exits nonzero.`,
 		if historyChanged {
 			updates["no_history"] = false
 		}
+		if cmd.Flags().Changed("encrypted") {
+			encrypted, _ := cmd.Flags().GetBool("encrypted")
+			updates["encrypted"] = encrypted
+		}
 		// Metadata flag (GH#1413)
 		if cmd.Flags().Changed("metadata") {
 			metadataValue, _ := cmd.Flags().GetString("metadata")
@@ -314,12 +328,19 @@ stderr, and the command exits nonzero.`,
 
 		// Get claim flag
 		claimFlag, _ := cmd.Flags().GetBool("claim")
+		forceUpdate, _ := cmd.Flags().GetBool("force")
 
 		if len(updates) == 0 && !claimFlag {
 			fmt.Println("No updates specified")
 			return nil
 		}
 
+		if len(args) > confirmBulkThreshold() {
+			if err := requireConfirmation(cmd, confirmOpBulkUpdate, fmt.Sprintf("update %d issues", len(args)), args); err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+		}
+
 		ctx := rootCtx
 
 		updatedIssues := []*types.Issue{}
@@ -385,9 +406,38 @@ stderr, and the command exits nonzero.`,
 				closeIfUnmutated(result)
 				continue
 			}
+			if err := checkIssueLock(ctx, issueStore, result.ResolvedID, forceUpdate); err != nil {
+				fmt.Fprintf(os.Stderr, "%s\n", err)
+				recordFailure(id, err.Error())
+				closeIfUnmutated(result)
+				continue
+			}
+			// --status closed is a close: it answers to the same policies as
+			// bd close, which is also the only way to override them.
+			closing := updates["status"] == string(types.StatusClosed) && issue.Status != types.StatusClosed
+			if closing {
+				if err := checkClosePolicies(result.ResolvedID, issue, "", nil); err != nil {
+					fmt.Fprintf(os.Stderr, "cannot close %s: %v (bd close --force overrides)\n", id, err)
+					recordFailure(id, err.Error())
+					closeIfUnmutated(result)
+					continue
+				}
+			}
 
 			// Handle claim operation atomically using compare-and-swap semantics
 			if claimFlag {
+				if err := checkRigClaim(issue); err != nil {
+					fmt.Fprintf(os.Stderr, "%v\n", err)
+					recordFailure(id, err.Error())
+					closeIfUnmutated(result)
+					continue
+				}
+				if err := checkClaimBudget(ctx, issueStore, result.ResolvedID); err != nil {
+					fmt.Fprintf(os.Stderr, "%v\n", err)
+					recordFailure(id, err.Error())
+					closeIfUnmutated(result)
+					continue
+				}
 				if err := issueStore.ClaimIssue(ctx, result.ResolvedID, actor); err != nil {
 					fmt.Fprintf(os.Stderr, "Error claiming %s: %v\n", id, err)
 					recordFailure(id, fmt.Sprintf("claiming issue: %v", err))
@@ -435,10 +485,32 @@ stderr, and the command exits nonzero.`,
 				}
 				if a, ok := regularUpdates["assignee"].(string); ok {
 					audit.LogFieldChange(result.ResolvedID, "assignee", issue.Assignee, a, actor, "")
+					if oooFrom != "" {
+						if err := recordOOORedirect(ctx, issueStore, oooRedirect{Person: oooFrom, Delegate: a, Issue: result.ResolvedID, Via: "update"}); err != nil {
+							WarnError("%v", err)
+						}
+					}
 				}
 				if p, ok := regularUpdates["priority"].(int); ok {
 					audit.LogFieldChange(result.ResolvedID, "priority", fmt.Sprintf("%d", issue.Priority), fmt.Sprintf("%d", p), actor, "")
 				}
+				if updatesSummaryContent(regularUpdates) {
+					refreshSummaryOnUpdate(ctx, issueStore, result.ResolvedID)
+				}
+				if _, ok := regularUpdates["title"]; ok {
+					refreshSlugOnRetitle(ctx, issueStore, result.ResolvedID)
+				}
+				if closing {
+					inReview, followups := runPostCloseHooks(ctx, issueStore, result.ResolvedID, issue, actor)
+					if !jsonOutput {
+						if inReview {
+							debug.PrintNormal("  %s %s waiting for human review (bd review list)\n", ui.RenderWarn("→"), result.ResolvedID)
+						}
+						for _, f := range followups {
+							debug.PrintNormal("  %s follow-up %s created\n", ui.RenderAccent("→"), f)
+						}
+					}
+				}
 			}
 
 			// Handle label operations
@@ -674,6 +746,7 @@ func init() {
 	updateCmd.Flags().StringP("type", "t", "", "New type (bug|feature|task|epic|chore|decision); custom types require types.custom config")
 	registerCommonIssueFlags(updateCmd)
 	updateCmd.Flags().Lookup("notes").Usage = "Additional notes (replaces existing notes; use --append-notes to append)"
+	updateCmd.Flags().Bool("force", false, "Update even if someone else has locked the issue (bd lock)")
 	updateCmd.Flags().Bool("allow-empty-description", false, "Allow empty description replacement when reading from stdin or file")
 	updateCmd.Flags().String("spec-id", "", "Link to specification document")
 	updateCmd.Flags().String("acceptance-criteria", "", "DEPRECATED: use --acceptance")
@@ -703,11 +776,14 @@ func init() {
 	updateCmd.Flags().Bool("persistent", false, "Mark issue as persistent (promote wisp to regular issue)")
 	updateCmd.Flags().Bool("no-history", false, "Mark issue as no-history (skip Dolt commits, not GC-eligible)")
 	updateCmd.Flags().Bool("history", false, "Clear no-history flag (re-enable Dolt commit history)")
+	updateCmd.Flags().Bool("encrypted", false, "Encrypt description, design, and notes at rest (--encrypted=false decrypts them)")
 	// Metadata flag (GH#1413)
 	updateCmd.Flags().String("metadata", "", "Set custom metadata (JSON string or @file.json to read from file)")
 	// Incremental metadata edits (GH#1406)
 	updateCmd.Flags().StringArray("set-metadata", nil, "Set metadata key=value (repeatable, e.g., --set-metadata team=platform)")
 	updateCmd.Flags().StringArray("unset-metadata", nil, "Remove metadata key (repeatable, e.g., --unset-metadata team)")
+	updateCmd.Flags().Bool("queue", false, "If the database server is unreachable, queue the update locally and apply it once the server is back")
+	addConfirmFlags(updateCmd)
 	updateCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(updateCmd)
 }
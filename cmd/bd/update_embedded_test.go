@@ -997,6 +997,34 @@ func TestEmbeddedUpdate(t *testing.T) {
 	})
 }
 
+// TestEmbeddedUpdateStatusClosedChecksClosePolicies verifies that
+// bd update --status closed answers to the same close policies as bd close.
+func TestEmbeddedUpdateStatusClosedChecksClosePolicies(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "tv")
+	bdCommand(t, bd, dir, "config", "set", "validation.required.bug", "reviewer")
+
+	issue := bdCreate(t, bd, dir, "Needs review", "--type", "bug")
+	out := bdUpdateFail(t, bd, dir, issue.ID, "--status", "closed")
+	if !strings.Contains(out, "required validations not met") {
+		t.Errorf("expected the required-validation refusal, got:\n%s", out)
+	}
+	if got := bdShow(t, bd, dir, issue.ID); got.Status == types.StatusClosed {
+		t.Fatal("update --status closed closed an issue missing its required validation")
+	}
+
+	bdCommand(t, bd, dir, "validate", issue.ID, "--outcome", "pass", "--as", "reviewer:alice")
+	bdUpdate(t, bd, dir, issue.ID, "--status", "closed")
+	if got := bdShow(t, bd, dir, issue.ID); got.Status != types.StatusClosed {
+		t.Errorf("expected status closed once validated, got %s", got.Status)
+	}
+}
+
 // TestEmbeddedUpdateConcurrent exercises create, update, and list operations
 // concurrently to verify EmbeddedDoltStore handles concurrent CLI invocations
 // without panics, data corruption, or deadlocks.
@@ -157,6 +157,16 @@ func applyUpdateProxiedAttempt(ctx context.Context, id string, in *updateInput)
 		fmt.Fprintf(os.Stderr, "%s\n", err)
 		return nil, err.Error(), false, nil
 	}
+	if in.fields["status"] == string(types.StatusClosed) && current.Status != types.StatusClosed {
+		if err := checkClosePolicies(id, current, "", nil); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot close %s: %v (bd close --force overrides)\n", id, err)
+			return nil, err.Error(), false, nil
+		}
+		if err := checkProxiedCloseHooks(ctx, uw, current); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot close %s: %v\n", id, err)
+			return nil, err.Error(), false, nil
+		}
+	}
 
 	spec := buildUpdateSpecForIssue(current, in)
 	notesOverwritten := replacesExistingNotes(current.Notes, in.fields)
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var validateCmd = &cobra.Command{
+	Use:     "validate <id>",
+	GroupID: "issues",
+	Short:   "Record a validation (review verdict) on an issue",
+	Long: `Record a structured validation on an issue.
+
+Each validation names a validator (an entity reference such as
+reviewer:alice; defaults to the current actor), an outcome (pass, fail,
+needs-work), and an optional comment. Validations are kept as a log in
+metadata.validations; a validator's latest record supersedes earlier ones.
+The aggregate state (passed, failed, needs-work) is mirrored to
+metadata.validation_state so 'bd list --validation <state>' can filter on it.
+
+Required validations before close are configured per issue type in
+config.yaml as a comma-separated list of validator roles ("*" accepts a
+pass from anyone):
+
+  validation:
+    required:
+      feature: reviewer
+      bug: "*"
+
+'bd close' refuses to close an issue whose required validations are missing
+or whose validators still have outstanding fail/needs-work verdicts, unless
+--force is given.
+
+Examples:
+  bd validate bd-42 --outcome pass --as reviewer:alice
+  bd validate bd-42 --outcome needs-work --as qa:bob --comment "Missing tests"
+  bd validate show bd-42
+  bd list --validation failed`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		CheckReadonly("validate")
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("validate is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("validate")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		outcomeStr, _ := cmd.Flags().GetString("outcome")
+		outcome := types.ValidationOutcome(outcomeStr)
+		if !outcome.IsValid() {
+			return HandleErrorRespectJSON("invalid --outcome %q (valid: pass, fail, needs-work)", outcomeStr)
+		}
+		validator, _ := cmd.Flags().GetString("as")
+		if validator == "" {
+			validator = actor
+		}
+		comment, _ := cmd.Flags().GetString("comment")
+
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			return HandleErrorRespectJSON("issue %s not found: %v", id, err)
+		}
+		vals, err := types.ValidationsFromMetadata(issue.Metadata)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+		v := types.Validation{
+			Validator:   validator,
+			Outcome:     outcome,
+			Comment:     comment,
+			ValidatedAt: time.Now().UTC(),
+		}
+		vals = append(vals, v)
+		state := types.ValidationState(vals)
+
+		raw, err := json.Marshal(vals)
+		if err != nil {
+			return HandleErrorRespectJSON("encoding validations: %v", err)
+		}
+		if err := store.MergeMetadata(ctx, id, types.ValidationsMetadataKey, raw, actor); err != nil {
+			return HandleErrorRespectJSON("saving validation: %v", err)
+		}
+		rawState, _ := json.Marshal(state)
+		if err := store.MergeMetadata(ctx, id, types.ValidationStateMetadataKey, rawState, actor); err != nil {
+			return HandleErrorRespectJSON("saving validation state: %v", err)
+		}
+		refreshQualityScoreOnEvent(ctx, store, id, "validate")
+		commandDidWrite.Store(true)
+		SetLastTouchedID(id)
+
+		if jsonOutput {
+			return outputJSON(validationView(issue, vals))
+		}
+		fmt.Printf("%s %s %s by %s (state: %s)\n", ui.RenderPass("✓"), renderValidationOutcome(outcome),
+			formatFeedbackID(id, issue.Title), validator, state)
+		return nil
+	},
+}
+
+var validateShowCmd = &cobra.Command{
+	Use:           "show <id>",
+	Short:         "Show validations and required-validation status for an issue",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			return HandleErrorRespectJSON("issue %s not found: %v", id, err)
+		}
+		vals, err := types.ValidationsFromMetadata(issue.Metadata)
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		view := validationView(issue, vals)
+		if jsonOutput {
+			return outputJSON(view)
+		}
+		fmt.Printf("%s %s — validation: %s\n", ui.RenderID(issue.ID), issue.Title, view.State)
+		for _, v := range vals {
+			line := fmt.Sprintf("  %s  %-10s %s", v.ValidatedAt.Local().Format("2006-01-02 15:04"), v.Outcome, v.Validator)
+			if v.Comment != "" {
+				line += " — " + v.Comment
+			}
+			fmt.Println(line)
+		}
+		if len(view.Required) > 0 {
+			fmt.Printf("Required: %s\n", strings.Join(view.Required, ", "))
+		}
+		for _, m := range view.Missing {
+			fmt.Printf("  %s needs %s\n", ui.RenderWarn("!"), m)
+		}
+		return nil
+	},
+}
+
+// validationListItem is the JSON shape for validation output.
+type validationListItem struct {
+	ID          string             `json:"id"`
+	Title       string             `json:"title"`
+	State       string             `json:"state"`
+	Validations []types.Validation `json:"validations"`
+	Required    []string           `json:"required,omitempty"`
+	Missing     []string           `json:"missing,omitempty"`
+}
+
+func validationView(issue *types.Issue, vals []types.Validation) validationListItem {
+	required := requiredValidationRoles(issue.IssueType)
+	if vals == nil {
+		vals = []types.Validation{}
+	}
+	return validationListItem{
+		ID:          issue.ID,
+		Title:       issue.Title,
+		State:       types.ValidationState(vals),
+		Validations: vals,
+		Required:    required,
+		Missing:     types.MissingValidations(vals, required),
+	}
+}
+
+// requiredValidationRoles returns the validator roles configured under
+// validation.required.<type>; empty means validations are optional.
+func requiredValidationRoles(issueType types.IssueType) []string {
+	raw := config.GetString("validation.required." + string(issueType))
+	var roles []string
+	for _, r := range strings.Split(raw, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+// checkRequiredValidations returns an error when the issue's type requires
+// validations that are missing or outstanding.
+func checkRequiredValidations(issue *types.Issue) error {
+	if issue == nil {
+		return nil
+	}
+	required := requiredValidationRoles(issue.IssueType)
+	if len(required) == 0 {
+		return nil
+	}
+	vals, err := types.ValidationsFromMetadata(issue.Metadata)
+	if err != nil {
+		return err
+	}
+	if missing := types.MissingValidations(vals, required); len(missing) > 0 {
+		return fmt.Errorf("required validations not met: needs %s (record with bd validate, or use --force)", strings.Join(missing, "; "))
+	}
+	return nil
+}
+
+func renderValidationOutcome(o types.ValidationOutcome) string {
+	switch o {
+	case types.ValidationPass:
+		return ui.RenderPass("pass")
+	case types.ValidationFail:
+		return ui.RenderFail("fail")
+	default:
+		return ui.RenderWarn(string(o))
+	}
+}
+
+func init() {
+	validateCmd.Flags().String("outcome", "", "Validation outcome: pass, fail, needs-work (required)")
+	validateCmd.Flags().String("as", "", "Validator entity reference, e.g. reviewer:alice (default: actor)")
+	validateCmd.Flags().String("comment", "", "Validation comment")
+	_ = validateCmd.MarkFlagRequired("outcome")
+
+	validateCmd.ValidArgsFunction = issueIDCompletion
+	validateShowCmd.ValidArgsFunction = issueIDCompletion
+	validateCmd.AddCommand(validateShowCmd)
+	rootCmd.AddCommand(validateCmd)
+}
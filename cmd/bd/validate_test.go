@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckRequiredValidations(t *testing.T) {
+	initConfigForTest(t)
+	config.Set("validation.required.feature", "reviewer, qa")
+
+	issue := &types.Issue{ID: "bd-1", IssueType: types.TypeFeature}
+	if got := requiredValidationRoles(issue.IssueType); len(got) != 2 || got[1] != "qa" {
+		t.Fatalf("requiredValidationRoles = %v", got)
+	}
+	err := checkRequiredValidations(issue)
+	if err == nil || !strings.Contains(err.Error(), "reviewer") || !strings.Contains(err.Error(), "qa") {
+		t.Fatalf("expected both roles missing, got %v", err)
+	}
+
+	meta, _ := json.Marshal(map[string][]types.Validation{types.ValidationsMetadataKey: {
+		{Validator: "reviewer:alice", Outcome: types.ValidationPass},
+		{Validator: "qa:bob", Outcome: types.ValidationPass},
+	}})
+	issue.Metadata = meta
+	if err := checkRequiredValidations(issue); err != nil {
+		t.Errorf("all roles passed, got %v", err)
+	}
+
+	if err := checkRequiredValidations(&types.Issue{ID: "bd-2", IssueType: types.TypeBug}); err != nil {
+		t.Errorf("type without a policy should close freely, got %v", err)
+	}
+}
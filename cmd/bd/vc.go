@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
@@ -53,85 +54,91 @@ Examples:
 			}
 		}()
 
-		ctx := rootCtx
-		branchName := args[0]
+		return mergeBranch(cmd, args[0], vcMergeStrategy)
+	},
+}
 
-		// Pre-merge HEAD scopes the post-resolution is_blocked recompute
-		// (bd-578h9.11); empty degrades to a full-graph pass.
-		preHead, _ := store.GetCurrentCommit(ctx)
+// mergeBranch merges branchName into the current branch for bd vc merge and
+// bd branch merge. With a strategy, conflicts are resolved and the merge is
+// committed; without one they are reported for the caller to resolve.
+func mergeBranch(cmd *cobra.Command, branchName, strategy string) error {
+	ctx := rootCtx
 
-		// Perform merge
-		conflicts, err := store.Merge(ctx, branchName)
-		if err != nil {
-			return HandleErrorRespectJSON("failed to merge branch: %v", err)
-		}
+	// Pre-merge HEAD scopes the post-resolution is_blocked recompute
+	// (bd-578h9.11); empty degrades to a full-graph pass.
+	preHead, _ := store.GetCurrentCommit(ctx)
 
-		if len(conflicts) > 0 {
-			if vcMergeStrategy != "" {
-				for _, conflict := range conflicts {
-					table := conflict.Field
-					if table == "" {
-						table = "issues"
-					}
-					if err := store.ResolveConflicts(ctx, table, vcMergeStrategy); err != nil {
-						return HandleErrorRespectJSON("failed to resolve conflicts: %v", err)
-					}
-				}
-				// Conclude the merge: an unresolved-then-resolved working set
-				// stays uncommitted otherwise, and the merged-in writes
-				// bypassed every is_blocked hook (bd-578h9.11). Use
-				// CommitMergeResolution, not Commit: server-mode Commit excludes
-				// config (GH#2455), so a resolved config conflict — routine now
-				// that kv.* user data syncs through config — would be silently
-				// dropped, leaving the merge unconcluded and re-wedging the next
-				// pull/sync (GH#2474).
-				if err := store.CommitMergeResolution(ctx, fmt.Sprintf("Resolve merge conflicts from %s using %s strategy", branchName, vcMergeStrategy)); err != nil {
-					return HandleErrorRespectJSON("conflicts resolved but commit failed: %v", err)
+	// Perform merge
+	conflicts, err := store.Merge(ctx, branchName)
+	if err != nil {
+		return HandleErrorRespectJSON("failed to merge branch: %v", err)
+	}
+
+	if len(conflicts) > 0 {
+		if strategy != "" {
+			for _, conflict := range conflicts {
+				table := conflict.Field
+				if table == "" {
+					table = "issues"
 				}
-				if rs, ok := store.(interface {
-					RecomputeBlockedAfterMerge(ctx context.Context, fromCommit string) error
-				}); ok {
-					if err := rs.RecomputeBlockedAfterMerge(ctx, preHead); err != nil {
-						return HandleErrorRespectJSON("conflicts resolved but is_blocked recompute failed: %v", err)
-					}
+				if err := store.ResolveConflicts(ctx, table, strategy); err != nil {
+					return HandleErrorRespectJSON("failed to resolve conflicts: %v", err)
 				}
-				if jsonOutput {
-					return outputJSON(map[string]interface{}{
-						"merged":        branchName,
-						"conflicts":     len(conflicts),
-						"resolved_with": vcMergeStrategy,
-					})
+			}
+			// Conclude the merge: an unresolved-then-resolved working set
+			// stays uncommitted otherwise, and the merged-in writes
+			// bypassed every is_blocked hook (bd-578h9.11). Use
+			// CommitMergeResolution, not Commit: server-mode Commit excludes
+			// config (GH#2455), so a resolved config conflict — routine now
+			// that kv.* user data syncs through config — would be silently
+			// dropped, leaving the merge unconcluded and re-wedging the next
+			// pull/sync (GH#2474).
+			if err := store.CommitMergeResolution(ctx, fmt.Sprintf("Resolve merge conflicts from %s using %s strategy", branchName, strategy)); err != nil {
+				return HandleErrorRespectJSON("conflicts resolved but commit failed: %v", err)
+			}
+			if rs, ok := store.(interface {
+				RecomputeBlockedAfterMerge(ctx context.Context, fromCommit string) error
+			}); ok {
+				if err := rs.RecomputeBlockedAfterMerge(ctx, preHead); err != nil {
+					return HandleErrorRespectJSON("conflicts resolved but is_blocked recompute failed: %v", err)
 				}
-				fmt.Printf("Merged %s with %d conflicts resolved using '%s' strategy\n",
-					ui.RenderAccent(branchName), len(conflicts), vcMergeStrategy)
-				return nil
 			}
-
 			if jsonOutput {
 				return outputJSON(map[string]interface{}{
-					"merged":    branchName,
-					"conflicts": conflicts,
+					"merged":        branchName,
+					"conflicts":     len(conflicts),
+					"resolved_with": strategy,
 				})
 			}
-
-			fmt.Printf("\n%s Merge completed with conflicts:\n\n", ui.RenderAccent("!!"))
-			for _, conflict := range conflicts {
-				fmt.Printf("  - %s\n", conflict.Field)
-			}
-			fmt.Printf("\nResolve conflicts with: bd vc merge %s --strategy [ours|theirs]\n\n", branchName)
+			fmt.Printf("Merged %s with %d conflicts resolved using '%s' strategy\n",
+				ui.RenderAccent(branchName), len(conflicts), strategy)
 			return nil
 		}
 
 		if jsonOutput {
 			return outputJSON(map[string]interface{}{
 				"merged":    branchName,
-				"conflicts": 0,
+				"conflicts": conflicts,
 			})
 		}
 
-		fmt.Printf("Successfully merged %s\n", ui.RenderAccent(branchName))
+		fmt.Printf("\n%s Merge completed with conflicts:\n\n", ui.RenderAccent("!!"))
+		for _, conflict := range conflicts {
+			fmt.Printf("  - %s\n", conflict.Field)
+		}
+		fmt.Printf("\nResolve conflicts with: %s %s --strategy [ours|theirs]\n\n", cmd.CommandPath(), branchName)
 		return nil
-	},
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"merged":    branchName,
+			"conflicts": 0,
+		})
+	}
+
+	fmt.Printf("Successfully merged %s\n", ui.RenderAccent(branchName))
+	return nil
 }
 
 var vcCommitMessage string
@@ -211,6 +218,11 @@ var vcStatusCmd = &cobra.Command{
 	Short: "Show current branch and uncommitted changes",
 	Long: `Show the current branch, commit hash, and any uncommitted changes.
 
+Uncommitted writes (for example a batch waiting for commit.batch_interval
+or commit.batch_max_ops) are listed as pending. They are already stored in
+the Dolt working set on disk, so they survive a crash or kill and are still
+there on the next command; 'bd commit' records them in history.
+
 Examples:
   bd vc status`,
 	SilenceUsage:  true,
@@ -238,16 +250,35 @@ Examples:
 			currentCommit = "(unknown)"
 		}
 
+		var pending *storage.PendingChanges
+		if reader, ok := storage.UnwrapStore(store).(storage.PendingChangesReader); ok {
+			if p, err := reader.PendingChanges(ctx); err == nil {
+				pending = p
+			}
+		}
+
 		if jsonOutput {
-			return outputJSON(map[string]interface{}{
+			result := map[string]interface{}{
 				"branch": currentBranch,
 				"commit": currentCommit,
-			})
+			}
+			if pending != nil {
+				result["pending"] = map[string]interface{}{
+					"operations":  pending.Operations,
+					"issues":      pending.IssueIDs,
+					"last_commit": pending.HeadDate,
+				}
+			}
+			return outputJSON(result)
 		}
 
 		fmt.Printf("\n%s Version Control Status\n\n", ui.RenderAccent("📊"))
 		fmt.Printf("  Branch: %s\n", ui.StatusInProgressStyle.Render(currentBranch))
-		fmt.Printf("  Commit: %s\n", ui.RenderMuted(currentCommit[:8]))
+		fmt.Printf("  Commit: %s\n", ui.RenderMuted(shortCommitHash(currentCommit)))
+		if pending != nil && (pending.Operations > 0 || len(pending.IssueIDs) > 0) {
+			fmt.Printf("  Pending: %d write(s) on %d issue(s) since the last commit (%s); run 'bd commit' to record them\n",
+				pending.Operations, len(pending.IssueIDs), formatTimeAgo(pending.HeadDate))
+		}
 		fmt.Println()
 		return nil
 	},
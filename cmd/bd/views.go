@@ -0,0 +1,335 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// viewMe stands for the current actor in a view query.
+const viewMe = "@me"
+
+// issueView is a built-in list preset: named sections, each a bd query
+// expression, rendered together. Every section query and the sort order
+// can be overridden under views.<name> in config.yaml.
+type issueView struct {
+	Name     string
+	Title    string
+	Sort     string // sortIssues key; "" keeps storage order
+	Sections []viewSection
+}
+
+// viewSection is one group of a view.
+type viewSection struct {
+	Key   string // Config key under views.<name>
+	Title string
+	Query string
+	// Blocked, when set, keeps only issues that are (true) or are not
+	// (false) blocked, by status or by an open blocking dependency. The
+	// query language cannot express dependency blocking.
+	Blocked *bool
+}
+
+var (
+	viewOnlyBlocked   = true
+	viewOnlyUnblocked = false
+)
+
+// builtinViews are the presets behind bd triage --list, bd my-work, and
+// bd standup.
+var builtinViews = map[string]issueView{
+	"triage": {
+		Name:  "triage",
+		Title: "Untriaged",
+		Sort:  "created",
+		Sections: []viewSection{{
+			Key:   "query",
+			Title: "Untriaged",
+			Query: "status=open AND assignee=none AND label=none AND type!=gate AND type!=molecule AND ephemeral=false AND template=false",
+		}},
+	},
+	"my-work": {
+		Name:  "my-work",
+		Title: "My work",
+		Sort:  "priority",
+		Sections: []viewSection{
+			{Key: "in_progress", Title: "Working on", Query: "assignee=@me AND status=in_progress"},
+			{Key: "ready", Title: "Ready", Query: "assignee=@me AND status=open", Blocked: &viewOnlyUnblocked},
+			{Key: "blocked", Title: "Blocked", Query: "assignee=@me AND status!=closed AND status!=deferred", Blocked: &viewOnlyBlocked},
+		},
+	},
+	"standup": {
+		Name:  "standup",
+		Title: "Standup",
+		Sort:  "priority",
+		Sections: []viewSection{
+			{Key: "done", Title: "Done", Query: "assignee=@me AND status=closed AND closed>24h"},
+			{Key: "planned", Title: "Today", Query: "assignee=@me AND status=in_progress"},
+			{Key: "blockers", Title: "Blockers", Query: "assignee=@me AND status!=closed AND status!=deferred", Blocked: &viewOnlyBlocked},
+		},
+	},
+}
+
+// resolveView returns the built-in view with config overrides applied:
+// views.<name>.<section> replaces a section query and views.<name>.sort
+// the sort order.
+func resolveView(name string) (issueView, error) {
+	builtin, ok := builtinViews[name]
+	if !ok {
+		return issueView{}, fmt.Errorf("unknown view %q", name)
+	}
+	view := builtin
+	view.Sections = append([]viewSection(nil), builtin.Sections...)
+	if sortBy := strings.TrimSpace(config.GetString("views." + name + ".sort")); sortBy != "" {
+		view.Sort = sortBy
+	}
+	for i := range view.Sections {
+		if q := strings.TrimSpace(config.GetString("views." + name + "." + view.Sections[i].Key)); q != "" {
+			view.Sections[i].Query = q
+		}
+	}
+	return view, nil
+}
+
+// expandViewQuery replaces @me with the quoted actor name.
+func expandViewQuery(q, me string) (string, error) {
+	if !strings.Contains(q, viewMe) {
+		return q, nil
+	}
+	if me == "" {
+		return "", fmt.Errorf("query uses %s but no actor is set (use --actor or BEADS_ACTOR)", viewMe)
+	}
+	return strings.ReplaceAll(q, viewMe, strconv.Quote(me)), nil
+}
+
+// viewSectionResult is one rendered section of a view.
+type viewSectionResult struct {
+	Key       string              `json:"key"`
+	Title     string              `json:"title"`
+	Issues    []*types.Issue      `json:"issues"`
+	BlockedBy map[string][]string `json:"blocked_by,omitempty"`
+}
+
+// runView evaluates every section of view. limit caps each section; 0
+// means no cap.
+func runView(ctx context.Context, st storage.DoltStorage, view issueView, me string, limit int) ([]*viewSectionResult, error) {
+	derivedFields, err := loadDerivedFields()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	var blockedBy map[string][]string
+	for _, sec := range view.Sections {
+		if sec.Blocked != nil {
+			blocked, err := st.GetBlockedIssues(ctx, types.WorkFilter{})
+			if err != nil {
+				return nil, fmt.Errorf("loading blocked issues: %w", err)
+			}
+			blockedBy = make(map[string][]string, len(blocked))
+			for _, b := range blocked {
+				blockedBy[b.ID] = b.BlockedBy
+			}
+			break
+		}
+	}
+
+	results := make([]*viewSectionResult, 0, len(view.Sections))
+	for _, sec := range view.Sections {
+		q, err := expandViewQuery(sec.Query, me)
+		if err != nil {
+			return nil, fmt.Errorf("views.%s.%s: %w", view.Name, sec.Key, err)
+		}
+		node, err := query.Parse(q)
+		if err != nil {
+			return nil, fmt.Errorf("views.%s.%s: parsing query: %w", view.Name, sec.Key, err)
+		}
+		eval := query.NewEvaluator(now)
+		eval.SetDerivedFields(derivedFields)
+		result, err := eval.Evaluate(node)
+		if err != nil {
+			return nil, fmt.Errorf("views.%s.%s: evaluating query: %w", view.Name, sec.Key, err)
+		}
+		if result.Filter.Status == nil && !hasExplicitStatusFilter(node) {
+			result.Filter.ExcludeStatus = append(result.Filter.ExcludeStatus, types.StatusClosed)
+		}
+
+		issues, err := st.SearchIssues(ctx, "", result.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if queryUsesDerived(node) || strings.HasPrefix(view.Sort, "derived.") {
+			if err := computeDerivedFields(ctx, st, issues, derivedFields); err != nil {
+				return nil, err
+			}
+		}
+
+		res := &viewSectionResult{Key: sec.Key, Title: sec.Title, Issues: []*types.Issue{}}
+		for _, issue := range issues {
+			if result.RequiresPredicate && result.Predicate != nil && !result.Predicate(issue) {
+				continue
+			}
+			if sec.Blocked != nil {
+				_, depBlocked := blockedBy[issue.ID]
+				if (issue.Status == types.StatusBlocked || depBlocked) != *sec.Blocked {
+					continue
+				}
+				if depBlocked && *sec.Blocked {
+					if res.BlockedBy == nil {
+						res.BlockedBy = make(map[string][]string)
+					}
+					res.BlockedBy[issue.ID] = blockedBy[issue.ID]
+				}
+			}
+			res.Issues = append(res.Issues, issue)
+		}
+		sortIssues(res.Issues, view.Sort, false)
+		if limit > 0 && len(res.Issues) > limit {
+			res.Issues = res.Issues[:limit]
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// outputView prints the sections of a view, or emits them as JSON.
+func outputView(view issueView, me string, sections []*viewSectionResult) error {
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"view":     view.Name,
+			"actor":    me,
+			"sections": sections,
+		})
+	}
+	heading := view.Title
+	if me != "" {
+		heading += " " + ui.RenderMuted("("+me+")")
+	}
+	fmt.Println(ui.RenderBold(heading))
+	for _, sec := range sections {
+		fmt.Printf("\n%s (%d):\n", sec.Title, len(sec.Issues))
+		if len(sec.Issues) == 0 {
+			fmt.Printf("  %s\n", ui.RenderMuted("(none)"))
+			continue
+		}
+		for _, issue := range sec.Issues {
+			var buf strings.Builder
+			formatQueryIssue(&buf, issue)
+			line := strings.TrimSuffix(buf.String(), "\n")
+			if blockers := sec.BlockedBy[issue.ID]; len(blockers) > 0 {
+				sorted := append([]string(nil), blockers...)
+				sort.Strings(sorted)
+				line += " " + ui.RenderMuted("(blocked by "+strings.Join(sorted, ", ")+")")
+			}
+			fmt.Printf("  %s\n", line)
+		}
+	}
+	return nil
+}
+
+// newViewCommand builds the command that shows a built-in view.
+func newViewCommand(name, short, long string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           name,
+		GroupID:       "views",
+		Short:         short,
+		Long:          long,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if usesProxiedServer() {
+				return HandleErrorRespectJSON("%s is not supported in proxied-server mode", name)
+			}
+			evt := metrics.NewCommandEvent(name)
+			defer func() {
+				if c := metrics.Global(); c != nil {
+					c.CloseEventAndAdd(evt)
+				}
+			}()
+			if store == nil {
+				return HandleErrorRespectJSON("no storage available")
+			}
+
+			view, err := resolveView(name)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			me := getActor()
+			if who, _ := cmd.Flags().GetString("assignee"); who != "" {
+				me = who
+			}
+			limit, _ := cmd.Flags().GetInt("limit")
+			sections, err := runView(rootCtx, store, view, me, limit)
+			if err != nil {
+				return HandleErrorRespectJSON("%v", err)
+			}
+			return outputView(view, me, sections)
+		},
+	}
+	cmd.Flags().String("assignee", "", "Show the view for this person instead of the current actor")
+	cmd.Flags().IntP("limit", "n", 0, "Show at most this many issues per section (0 = all)")
+	return cmd
+}
+
+var myWorkCmd = newViewCommand("my-work",
+	"Show your in-progress, ready, and blocked issues",
+	`Show the issues assigned to you, grouped into what you are working on,
+what is ready to pick up next, and what is blocked (with its blockers).
+
+"You" is the current actor (--actor, BEADS_ACTOR, or git user.name); pass
+--assignee to look at someone else's work.
+
+Each section is a bd query expression and can be overridden in
+config.yaml, where @me stands for the actor:
+
+  views:
+    my-work:
+      in_progress: "assignee=@me AND status=in_progress"
+      ready: "assignee=@me AND status=open AND priority<=2"
+      blocked: "assignee=@me AND status!=closed AND status!=deferred"
+      sort: priority
+
+Examples:
+  bd my-work
+  bd my-work --assignee alice
+  bd my-work --json`)
+
+var standupCmd = newViewCommand("standup",
+	"Show what you closed since yesterday, are doing today, and are blocked on",
+	`Print a standup report: issues you closed in the last 24 hours, issues
+in progress today, and open issues that are blocked, with their blockers.
+
+"You" is the current actor (--actor, BEADS_ACTOR, or git user.name); pass
+--assignee to report for someone else.
+
+Each section is a bd query expression and can be overridden in
+config.yaml, where @me stands for the actor. For example, to cover the
+weekend on Mondays:
+
+  views:
+    standup:
+      done: "assignee=@me AND status=closed AND closed>72h"
+      planned: "assignee=@me AND status=in_progress"
+      blockers: "assignee=@me AND status!=closed AND status!=deferred"
+
+Examples:
+  bd standup
+  bd standup --assignee alice
+  bd standup --json`)
+
+func init() {
+	rootCmd.AddCommand(myWorkCmd, standupCmd)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/query"
+)
+
+func TestBuiltinViewQueriesParse(t *testing.T) {
+	for name, view := range builtinViews {
+		if view.Name != name {
+			t.Errorf("builtinViews[%q].Name = %q", name, view.Name)
+		}
+		for _, sec := range view.Sections {
+			q, err := expandViewQuery(sec.Query, "alice")
+			if err != nil {
+				t.Fatalf("%s.%s: %v", name, sec.Key, err)
+			}
+			if _, err := query.Evaluate(q); err != nil {
+				t.Errorf("%s.%s: %q does not evaluate: %v", name, sec.Key, q, err)
+			}
+		}
+	}
+}
+
+func TestExpandViewQuery(t *testing.T) {
+	got, err := expandViewQuery("assignee=@me AND status=open", "Ada Lovelace")
+	if err != nil || got != `assignee="Ada Lovelace" AND status=open` {
+		t.Errorf("expandViewQuery = %q, %v", got, err)
+	}
+	if got, err := expandViewQuery("status=open", ""); err != nil || got != "status=open" {
+		t.Errorf("query without @me = %q, %v; want it unchanged", got, err)
+	}
+	if _, err := expandViewQuery("assignee=@me", ""); err == nil {
+		t.Error("expected an error for @me with no actor")
+	}
+}
+
+func TestResolveViewOverrides(t *testing.T) {
+	initConfigForTest(t)
+	config.Set("views.standup.done", "assignee=@me AND status=closed AND closed>72h")
+	config.Set("views.standup.sort", "updated")
+
+	view, err := resolveView("standup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Sort != "updated" {
+		t.Errorf("sort = %q, want the override", view.Sort)
+	}
+	if got := view.Sections[0].Query; got != "assignee=@me AND status=closed AND closed>72h" {
+		t.Errorf("done query = %q, want the override", got)
+	}
+	if got, want := view.Sections[1].Query, builtinViews["standup"].Sections[1].Query; got != want {
+		t.Errorf("planned query = %q, want the built-in %q", got, want)
+	}
+	if builtinViews["standup"].Sections[0].Query == view.Sections[0].Query {
+		t.Error("override leaked into the built-in view")
+	}
+
+	if _, err := resolveView("nope"); err == nil {
+		t.Error("expected an error for an unknown view")
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/wasmhooks"
+)
+
+// sortPolicyScore is the bd ready --sort value that orders ready work by
+// the scores of wasm hook modules instead of a built-in policy.
+const sortPolicyScore = "score"
+
+var (
+	wasmHooksOnce sync.Once
+	wasmHooks     *wasmhooks.Host
+	wasmHooksErr  error
+)
+
+// wasmPluginsDir is where the workspace keeps its wasm hook modules.
+func wasmPluginsDir() string {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return ""
+	}
+	return filepath.Join(beadsDir, "plugins")
+}
+
+// loadWasmHooks compiles the workspace's wasm modules once per process.
+// A workspace without modules gets an empty host, whose hooks are no-ops.
+func loadWasmHooks() (*wasmhooks.Host, error) {
+	wasmHooksOnce.Do(func() {
+		dir := wasmPluginsDir()
+		if dir == "" {
+			return
+		}
+		wasmHooks, wasmHooksErr = wasmhooks.Load(rootCtx, dir)
+	})
+	return wasmHooks, wasmHooksErr
+}
+
+// checkWasmClosePolicies runs the workspace's bd_close_policy hooks.
+func checkWasmClosePolicies(issue *types.Issue, reason string) error {
+	h, err := loadWasmHooks()
+	if err != nil {
+		return err
+	}
+	return h.CheckClose(rootCtx, wasmhooks.ClosePolicyInput{Issue: issue, Actor: actor, Reason: reason})
+}
+
+// sortByWasmScore orders issues by descending bd_score, keeping the store's
+// order for ties, and records each score in the issue's annotations.
+func sortByWasmScore[T any](items []T, issue func(T) *types.Issue) error {
+	h, err := loadWasmHooks()
+	if err != nil {
+		return err
+	}
+	if !h.HasScorer() {
+		return fmt.Errorf("--sort score needs a wasm module exporting bd_score in %s", wasmPluginsDir())
+	}
+	scores := make(map[string]int64, len(items))
+	for _, it := range items {
+		iss := issue(it)
+		s, err := h.Score(rootCtx, iss)
+		if err != nil {
+			return err
+		}
+		scores[iss.ID] = s
+		iss.Annotate().Score = &s
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return scores[issue(items[i]).ID] > scores[issue(items[j]).ID]
+	})
+	return nil
+}
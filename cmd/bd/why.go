@@ -0,0 +1,346 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlbuild"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// whyMaxDepth bounds how far 'bd why' follows blocker and parent chains.
+const whyMaxDepth = 10
+
+// Reason kinds reported by 'bd why'. Agents switch on these, so they are
+// part of the JSON contract.
+const (
+	whyKindStatus       = "status"        // Status is not open or in_progress
+	whyKindPinned       = "pinned"        // Pinned issues are context, not work
+	whyKindEphemeral    = "ephemeral"     // Wisps are hidden unless --include-ephemeral
+	whyKindExcludedType = "excluded-type" // Type never appears in ready work
+	whyKindDeferred     = "deferred"      // defer_until is in the future
+	whyKindBlockedBy    = "blocked-by"    // Open blocking dependencies
+	whyKindGate         = "gate"          // Open gate the issue waits on
+	whyKindParent       = "parent"        // An ancestor is blocked or deferred
+	whyKindWIPLimit     = "wip-limit"     // The issue's lane is at its WIP limit
+	whyKindStaleBlocked = "stale-blocked" // is_blocked set with no open blocker found
+)
+
+// whyBlocker is one open blocker in a blocking chain. BlockedBy holds the
+// blocker's own open blockers, so the chain can be followed to its roots.
+type whyBlocker struct {
+	ID        string               `json:"id"`
+	Title     string               `json:"title"`
+	Status    types.Status         `json:"status"`
+	IssueType types.IssueType      `json:"issue_type"`
+	DepType   types.DependencyType `json:"dep_type"`
+	AwaitType string               `json:"await_type,omitempty"`
+	AwaitID   string               `json:"await_id,omitempty"`
+	BlockedBy []whyBlocker         `json:"blocked_by,omitempty"`
+	Cycle     bool                 `json:"cycle,omitempty"` // Chain loops back to an issue already on the path
+}
+
+// whyReason is one reason an issue is not ready.
+type whyReason struct {
+	Kind     string       `json:"kind"`
+	Message  string       `json:"message"`
+	IssueID  string       `json:"issue_id,omitempty"` // Ancestor the reason comes from (parent kind)
+	Until    *time.Time   `json:"until,omitempty"`
+	Blockers []whyBlocker `json:"blockers,omitempty"`
+}
+
+// whyResult explains whether an issue is ready and, if not, why.
+type whyResult struct {
+	ID      string       `json:"id"`
+	Title   string       `json:"title"`
+	Status  types.Status `json:"status"`
+	Ready   bool         `json:"ready"`
+	Reasons []whyReason  `json:"reasons"`
+}
+
+var whyCmd = &cobra.Command{
+	Use:     "why <id>",
+	GroupID: "deps",
+	Short:   "Explain why an issue is blocked or not ready",
+	Long: `Explain why an issue does not show up in 'bd ready' or its lane's queue.
+
+Each reason has a kind, so agents can decide what to do next:
+
+  status         Status is not open or in_progress
+  pinned         Pinned issues are context markers, not work
+  ephemeral      Wisps only show with 'bd ready --include-ephemeral'
+  excluded-type  Gates, molecules, merge requests and infra types are never ready
+  deferred       defer_until is in the future
+  blocked-by     Open blocking dependencies, with each blocker's own chain
+  gate           An open gate the issue waits on (await type and id)
+  parent         An ancestor is blocked or deferred
+  wip-limit      The issue's lane is at its WIP limit (see bd lane --help)
+  stale-blocked  Marked blocked with no open blocker (run 'bd recompute-blocked')
+
+Gates are reported from stored state; 'bd why' makes no network calls.
+
+Examples:
+  bd why bd-42
+  bd why bd-42 --json`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usesProxiedServer() {
+			return HandleErrorRespectJSON("why is not supported in proxied-server mode")
+		}
+		evt := metrics.NewCommandEvent("why")
+		defer func() {
+			if c := metrics.Global(); c != nil {
+				c.CloseEventAndAdd(evt)
+			}
+		}()
+
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			return HandleErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		result, err := explainWhy(ctx, store, id, time.Now())
+		if err != nil {
+			return HandleErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			return outputJSON(result)
+		}
+		renderWhy(result)
+		return nil
+	},
+}
+
+// explainWhy collects every reason id is not ready work as of now. It mirrors
+// the ready-work predicate (sqlbuild.BuildReadyWorkWhere) but reports each
+// failing condition instead of filtering on them.
+func explainWhy(ctx context.Context, s storage.DoltStorage, id string, now time.Time) (*whyResult, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting %s: %w", id, err)
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+	result := &whyResult{ID: issue.ID, Title: issue.Title, Status: issue.Status, Reasons: []whyReason{}}
+	add := func(r whyReason) { result.Reasons = append(result.Reasons, r) }
+
+	if issue.Status != types.StatusOpen && issue.Status != types.StatusInProgress {
+		add(whyReason{Kind: whyKindStatus, Message: fmt.Sprintf("status is %s; only open and in_progress issues are ready", issue.Status)})
+	}
+	if issue.Pinned {
+		add(whyReason{Kind: whyKindPinned, Message: "pinned issues are context markers, not work items"})
+	}
+	if issue.Ephemeral {
+		add(whyReason{Kind: whyKindEphemeral, Message: "ephemeral (wisp); shown only by 'bd ready --include-ephemeral'"})
+	}
+	if slices.Contains(sqlbuild.ReadyWorkExcludeTypes(nil), issue.IssueType) {
+		add(whyReason{Kind: whyKindExcludedType, Message: fmt.Sprintf("issues of type %s never appear in ready work", issue.IssueType)})
+	}
+	if issue.DeferUntil != nil && issue.DeferUntil.After(now) {
+		add(whyReason{Kind: whyKindDeferred, Message: fmt.Sprintf("deferred until %s", issue.DeferUntil.Local().Format("2006-01-02 15:04")), Until: issue.DeferUntil})
+	}
+
+	blockers, gates, parent, err := whyOpenBlockers(ctx, s, issue.ID, map[string]bool{issue.ID: true}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockers) > 0 {
+		add(whyReason{Kind: whyKindBlockedBy, Message: fmt.Sprintf("blocked by %d open issue(s)", len(blockers)), Blockers: blockers})
+	}
+	for _, g := range gates {
+		add(whyReason{Kind: whyKindGate, Message: whyGateMessage(g), Blockers: []whyBlocker{g}})
+	}
+
+	// Walk the parent chain: a child inherits its ancestors' blockedness and
+	// is hidden while an ancestor is deferred.
+	seen := map[string]bool{issue.ID: true}
+	for depth := 0; parent != nil && !seen[parent.ID] && depth < whyMaxDepth; depth++ {
+		seen[parent.ID] = true
+		if parent.DeferUntil != nil && parent.DeferUntil.After(now) {
+			add(whyReason{
+				Kind:    whyKindParent,
+				Message: fmt.Sprintf("ancestor %s is deferred until %s", parent.ID, parent.DeferUntil.Local().Format("2006-01-02 15:04")),
+				IssueID: parent.ID,
+				Until:   parent.DeferUntil,
+			})
+		}
+		pBlockers, pGates, grandparent, err := whyOpenBlockers(ctx, s, parent.ID, map[string]bool{parent.ID: true}, 0)
+		if err != nil {
+			return nil, err
+		}
+		if all := append(pBlockers, pGates...); len(all) > 0 {
+			add(whyReason{
+				Kind:     whyKindParent,
+				Message:  fmt.Sprintf("ancestor %s is blocked by %d open issue(s)", parent.ID, len(all)),
+				IssueID:  parent.ID,
+				Blockers: all,
+			})
+		}
+		parent = grandparent
+	}
+
+	if issue.Status == types.StatusOpen {
+		reasons, err := whyLaneLimits(ctx, s, issue.ID)
+		if err != nil {
+			return nil, err
+		}
+		result.Reasons = append(result.Reasons, reasons...)
+	}
+
+	if len(result.Reasons) == 0 {
+		blocked, _, err := s.IsBlocked(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("checking blocked state of %s: %w", issue.ID, err)
+		}
+		if blocked {
+			add(whyReason{Kind: whyKindStaleBlocked, Message: "marked blocked but no open blocker was found; run 'bd recompute-blocked'"})
+		}
+	}
+	result.Ready = len(result.Reasons) == 0
+	return result, nil
+}
+
+// whyLaneLimits reports the lanes of id that are at their WIP limit, where
+// 'bd ready --lane' holds back new work.
+func whyLaneLimits(ctx context.Context, s storage.DoltStorage, id string) ([]whyReason, error) {
+	lanes, err := laneConfigs()
+	if err != nil || len(lanes) == 0 {
+		return nil, err
+	}
+	labels, err := s.GetLabels(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting labels of %s: %w", id, err)
+	}
+	var reasons []whyReason
+	for _, name := range issueLanes(lanes, labels) {
+		lane, err := findLane(lanes, name)
+		if err != nil || lane.WIPLimit == 0 {
+			continue
+		}
+		wip, err := laneWIP(ctx, s, lanes, lane)
+		if err != nil {
+			return nil, err
+		}
+		if wip >= lane.WIPLimit {
+			reasons = append(reasons, whyReason{
+				Kind:    whyKindWIPLimit,
+				Message: fmt.Sprintf("lane %s is at its WIP limit (%d/%d in progress)", lane.Name, wip, lane.WIPLimit),
+			})
+		}
+	}
+	return reasons, nil
+}
+
+// whyOpenBlockers returns id's open blockers over blocking edges, split into
+// gates and other issues, plus id's parent (nil when it has none). Each
+// non-gate blocker carries its own open blockers, depth-limited; path guards
+// against dependency cycles.
+func whyOpenBlockers(ctx context.Context, s storage.DoltStorage, id string, path map[string]bool, depth int) (blockers, gates []whyBlocker, parent *types.Issue, err error) {
+	deps, err := s.GetDependenciesWithMetadata(ctx, id)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("getting dependencies of %s: %w", id, err)
+	}
+	for _, d := range deps {
+		if d.DependencyType == types.DepParentChild && parent == nil {
+			p := d.Issue
+			parent = &p
+			continue
+		}
+		if !d.DependencyType.IsBlockingEdge() || d.Status == types.StatusClosed || d.Status == types.StatusPinned {
+			continue
+		}
+		b := whyBlocker{
+			ID:        d.ID,
+			Title:     d.Title,
+			Status:    d.Status,
+			IssueType: d.IssueType,
+			DepType:   d.DependencyType,
+			AwaitType: d.AwaitType,
+			AwaitID:   d.AwaitID,
+		}
+		if b.IssueType == types.TypeGate {
+			gates = append(gates, b)
+			continue
+		}
+		switch {
+		case path[d.ID]:
+			b.Cycle = true
+		case depth+1 < whyMaxDepth:
+			path[d.ID] = true
+			nested, nestedGates, _, err := whyOpenBlockers(ctx, s, d.ID, path, depth+1)
+			delete(path, d.ID)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			b.BlockedBy = append(nested, nestedGates...)
+		}
+		blockers = append(blockers, b)
+	}
+	return blockers, gates, parent, nil
+}
+
+// whyGateMessage describes an open gate without evaluating it.
+func whyGateMessage(g whyBlocker) string {
+	if g.AwaitType == "" {
+		return fmt.Sprintf("waiting on gate %s", g.ID)
+	}
+	await := g.AwaitType
+	if g.AwaitID != "" {
+		await += " " + g.AwaitID
+	}
+	return fmt.Sprintf("waiting on gate %s (%s)", g.ID, await)
+}
+
+func renderWhy(r *whyResult) {
+	fmt.Printf("%s [%s]\n", formatFeedbackID(r.ID, r.Title), r.Status)
+	if r.Ready {
+		fmt.Printf("%s Ready: nothing keeps this issue out of 'bd ready'\n", ui.RenderPass("✓"))
+		return
+	}
+	fmt.Printf("%s Not ready:\n", ui.RenderFail("✗"))
+	for _, reason := range r.Reasons {
+		fmt.Printf("  %s %s\n", ui.RenderWarn("•"), reason.Message)
+		if reason.Kind == whyKindGate {
+			continue
+		}
+		renderWhyBlockers(reason.Blockers, 2)
+	}
+}
+
+func renderWhyBlockers(blockers []whyBlocker, indent int) {
+	pad := strings.Repeat("  ", indent)
+	for _, b := range blockers {
+		detail := string(b.Status)
+		if b.DepType != types.DepBlocks {
+			detail += ", " + string(b.DepType)
+		}
+		if b.IssueType == types.TypeGate {
+			detail = strings.TrimPrefix(whyGateMessage(b), "waiting on ") + ", " + detail
+		}
+		line := fmt.Sprintf("%s← %s %s", pad, ui.RenderID(b.ID), b.Title)
+		fmt.Printf("%s %s\n", line, ui.RenderMuted("["+detail+"]"))
+		if b.Cycle {
+			fmt.Printf("%s  %s\n", pad, ui.RenderFail("(cycle)"))
+			continue
+		}
+		renderWhyBlockers(b.BlockedBy, indent+1)
+	}
+}
+
+func init() {
+	whyCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(whyCmd)
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// fakeWhyStore serves issues and dependency edges from maps.
+type fakeWhyStore struct {
+	storage.DoltStorage
+	issues  map[string]*types.Issue
+	deps    map[string]map[string]types.DependencyType // issue -> depends-on -> type
+	blocked map[string]bool
+	labels  map[string][]string
+}
+
+func (f *fakeWhyStore) GetIssue(_ context.Context, id string) (*types.Issue, error) {
+	return f.issues[id], nil
+}
+
+func (f *fakeWhyStore) GetDependenciesWithMetadata(_ context.Context, id string) ([]*types.IssueWithDependencyMetadata, error) {
+	var out []*types.IssueWithDependencyMetadata
+	for target, depType := range f.deps[id] {
+		out = append(out, &types.IssueWithDependencyMetadata{Issue: *f.issues[target], DependencyType: depType})
+	}
+	return out, nil
+}
+
+func (f *fakeWhyStore) IsBlocked(_ context.Context, id string) (bool, []string, error) {
+	return f.blocked[id], nil, nil
+}
+
+func (f *fakeWhyStore) GetLabels(_ context.Context, id string) ([]string, error) {
+	return f.labels[id], nil
+}
+
+// SearchIssues serves laneWIP: in_progress issues carrying any of LabelsAny.
+func (f *fakeWhyStore) SearchIssues(_ context.Context, _ string, filter types.IssueFilter) ([]*types.Issue, error) {
+	var out []*types.Issue
+	for id, issue := range f.issues {
+		if filter.Status != nil && issue.Status != *filter.Status {
+			continue
+		}
+		for _, label := range f.labels[id] {
+			if slices.Contains(filter.LabelsAny, label) {
+				out = append(out, issue)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func whyKinds(r *whyResult) []string {
+	kinds := make([]string, len(r.Reasons))
+	for i, reason := range r.Reasons {
+		kinds[i] = reason.Kind
+	}
+	return kinds
+}
+
+func TestExplainWhy(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(48 * time.Hour)
+	issue := func(id string, status types.Status, issueType types.IssueType) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: status, IssueType: issueType}
+	}
+
+	s := &fakeWhyStore{
+		issues: map[string]*types.Issue{
+			"bd-1":  issue("bd-1", types.StatusOpen, types.TypeTask),
+			"bd-2":  issue("bd-2", types.StatusInProgress, types.TypeTask),
+			"bd-3":  issue("bd-3", types.StatusOpen, types.TypeTask),
+			"bd-4":  issue("bd-4", types.StatusClosed, types.TypeTask),
+			"bd-g":  {ID: "bd-g", Title: "CI", Status: types.StatusOpen, IssueType: types.TypeGate, AwaitType: "gh:run", AwaitID: "123"},
+			"bd-e":  issue("bd-e", types.StatusOpen, types.TypeEpic),
+			"bd-c":  issue("bd-c", types.StatusOpen, types.TypeTask),
+			"bd-r":  issue("bd-r", types.StatusOpen, types.TypeTask),
+			"bd-d":  {ID: "bd-d", Title: "Later", Status: types.StatusOpen, IssueType: types.TypeTask, DeferUntil: &later},
+			"bd-x":  issue("bd-x", types.StatusOpen, types.TypeTask),
+			"bd-y":  issue("bd-y", types.StatusOpen, types.TypeTask),
+			"bd-s":  issue("bd-s", types.StatusOpen, types.TypeTask),
+			"bd-cl": issue("bd-cl", types.StatusClosed, types.TypeTask),
+		},
+		deps: map[string]map[string]types.DependencyType{
+			"bd-1": {"bd-2": types.DepBlocks, "bd-4": types.DepBlocks, "bd-g": types.DepBlocks, "bd-r": types.DepRelated},
+			"bd-2": {"bd-3": types.DepWaitsFor},
+			"bd-c": {"bd-e": types.DepParentChild},
+			"bd-e": {"bd-3": types.DepBlocks},
+			"bd-x": {"bd-y": types.DepBlocks},
+			"bd-y": {"bd-x": types.DepBlocks},
+		},
+		blocked: map[string]bool{"bd-s": true},
+	}
+	ctx := context.Background()
+
+	t.Run("chain_and_gate", func(t *testing.T) {
+		r, err := explainWhy(ctx, s, "bd-1", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Ready {
+			t.Fatal("bd-1 should not be ready")
+		}
+		kinds := whyKinds(r)
+		if len(kinds) != 2 || kinds[0] != whyKindBlockedBy || kinds[1] != whyKindGate {
+			t.Fatalf("kinds = %v, want [blocked-by gate]", kinds)
+		}
+		blockers := r.Reasons[0].Blockers
+		if len(blockers) != 1 || blockers[0].ID != "bd-2" {
+			t.Fatalf("blockers = %+v, want only bd-2 (closed and related edges ignored)", blockers)
+		}
+		if nested := blockers[0].BlockedBy; len(nested) != 1 || nested[0].ID != "bd-3" || nested[0].DepType != types.DepWaitsFor {
+			t.Errorf("bd-2 chain = %+v, want bd-3 via waits-for", nested)
+		}
+		if g := r.Reasons[1]; g.Message != "waiting on gate bd-g (gh:run 123)" {
+			t.Errorf("gate message = %q", g.Message)
+		}
+	})
+
+	t.Run("blocked_ancestor", func(t *testing.T) {
+		r, err := explainWhy(ctx, s, "bd-c", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(r.Reasons) != 1 || r.Reasons[0].Kind != whyKindParent || r.Reasons[0].IssueID != "bd-e" {
+			t.Fatalf("reasons = %+v, want one parent reason from bd-e", r.Reasons)
+		}
+	})
+
+	t.Run("deferred", func(t *testing.T) {
+		r, err := explainWhy(ctx, s, "bd-d", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kinds := whyKinds(r); len(kinds) != 1 || kinds[0] != whyKindDeferred {
+			t.Fatalf("kinds = %v, want [deferred]", kinds)
+		}
+		if r, _ := explainWhy(ctx, s, "bd-d", later.Add(time.Minute)); !r.Ready {
+			t.Errorf("bd-d should be ready once defer_until has passed: %+v", r.Reasons)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		r, err := explainWhy(ctx, s, "bd-x", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := r.Reasons[0].Blockers[0]
+		if b.ID != "bd-y" || len(b.BlockedBy) != 1 || !b.BlockedBy[0].Cycle {
+			t.Errorf("expected bd-y -> bd-x marked as a cycle, got %+v", b)
+		}
+	})
+
+	t.Run("status_and_type", func(t *testing.T) {
+		r, err := explainWhy(ctx, s, "bd-g", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kinds := whyKinds(r); len(kinds) != 1 || kinds[0] != whyKindExcludedType {
+			t.Errorf("gate kinds = %v, want [excluded-type]", kinds)
+		}
+		r, err = explainWhy(ctx, s, "bd-cl", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kinds := whyKinds(r); len(kinds) != 1 || kinds[0] != whyKindStatus {
+			t.Errorf("closed kinds = %v, want [status]", kinds)
+		}
+	})
+
+	t.Run("ready_and_stale", func(t *testing.T) {
+		r, err := explainWhy(ctx, s, "bd-3", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !r.Ready || len(r.Reasons) != 0 {
+			t.Errorf("bd-3 should be ready, got %+v", r.Reasons)
+		}
+		r, err = explainWhy(ctx, s, "bd-s", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kinds := whyKinds(r); len(kinds) != 1 || kinds[0] != whyKindStaleBlocked {
+			t.Errorf("kinds = %v, want [stale-blocked]", kinds)
+		}
+	})
+
+	t.Run("wip_limit", func(t *testing.T) {
+		initConfigForTest(t)
+		config.Set("lanes.labels", map[string]string{"backend": "api"})
+		config.Set("lanes.wip", map[string]string{"backend": "1"})
+		s.labels = map[string][]string{"bd-3": {"api"}, "bd-2": {"lane:backend"}}
+		defer func() { s.labels = nil }()
+
+		r, err := explainWhy(ctx, s, "bd-3", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kinds := whyKinds(r); len(kinds) != 1 || kinds[0] != whyKindWIPLimit {
+			t.Errorf("kinds = %v, want [wip-limit]", kinds)
+		}
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // Wisp commands - manage ephemeral molecules
@@ -22,7 +23,8 @@ import (
 // be synced via git.
 //
 // Commands:
-//   bd mol wisp list    - List all wisps in current context
+//   bd mol wisp list    - List all wisps in current context (also bd wisp list)
+//   bd mol wisp show    - Show one wisp's details (also bd wisp show)
 //   bd mol wisp gc      - Garbage collect orphaned wisps
 
 var wispCmd = &cobra.Command{
@@ -31,7 +33,7 @@ var wispCmd = &cobra.Command{
 	Long: `Create or manage wisps - EPHEMERAL molecules for operational workflows.
 
 When called with a proto-id argument, creates a wisp from that proto.
-When called with a subcommand (list, gc), manages existing wisps.
+When called with a subcommand (list, show, gc), manages existing wisps.
 
 Wisps are issues with Ephemeral=true in the main database. They're stored
 locally but NOT synced via git.
@@ -65,6 +67,7 @@ Examples:
 
 Subcommands:
   list  List all wisps in current context
+  show  Show one wisp's details
   gc    Garbage collect orphaned wisps`,
 	Args:          cobra.MaximumNArgs(1),
 	SilenceUsage:  true,
@@ -79,6 +82,8 @@ type WispListItem struct {
 	Status    string    `json:"status"`
 	Priority  int       `json:"priority"`
 	Type      string    `json:"type"`
+	WispType  string    `json:"wisp_type,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
 	Labels    []string  `json:"labels,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -201,7 +206,12 @@ func runWispCreateCore(cmd *cobra.Command, args []string) error {
 		// Try to resolve partial ID if it doesn't look like a full ID
 		if !strings.HasPrefix(protoID, "bd-") && !strings.HasPrefix(protoID, "gt-") && !strings.HasPrefix(protoID, "mol-") {
 			// Might be a partial ID, try to resolve
-			if resolved, err := resolvePartialIDDirect(ctx, protoID); err == nil {
+			resolved, err := utils.ResolvePartialID(ctx, store, protoID)
+			var ambiguous *utils.AmbiguousIDError
+			if errors.As(err, &ambiguous) {
+				return HandleError("%v", err)
+			}
+			if err == nil {
 				protoID = resolved
 			}
 		}
@@ -326,32 +336,16 @@ func isProtoIssue(issue *types.Issue) bool {
 	return false
 }
 
-// resolvePartialIDDirect resolves a partial ID directly from store
-func resolvePartialIDDirect(ctx context.Context, partial string) (string, error) {
-	// Try direct lookup first
-	if issue, err := store.GetIssue(ctx, partial); err == nil {
-		return issue.ID, nil
-	}
-	// Search by prefix
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
-		IDs: []string{partial + "*"},
-	})
-	if err != nil {
-		return "", err
-	}
-	if len(issues) == 1 {
-		return issues[0].ID, nil
-	}
-	if len(issues) > 1 {
-		return "", fmt.Errorf("ambiguous ID: %s matches %d issues", partial, len(issues))
-	}
-	return "", fmt.Errorf("not found: %s", partial)
-}
+var wispListCmd = newWispListCmd("bd mol wisp")
 
-var wispListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all wisps in current context",
-	Long: `List all wisps (ephemeral molecules) in the current context.
+// newWispListCmd builds a wisp list command; cmdPath is how its examples
+// invoke it, since the command is registered under both bd wisp and
+// bd mol wisp.
+func newWispListCmd(cmdPath string) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "List all wisps in current context",
+		Long: `List all wisps (ephemeral molecules) in the current context.
 
 Wisps are issues with Ephemeral=true in the main database. They are stored
 locally but not synced via git.
@@ -360,20 +354,46 @@ The list shows:
   - ID: Issue ID of the wisp
   - Title: Wisp title
   - Status: Current status (open, in_progress, closed)
-  - Started: When the wisp was created
+  - Wisp type: Classification for TTL-based compaction (patrol, heartbeat, ...)
   - Updated: Last modification time
 
+Filters:
+  --wisp-type  Only wisps of this wisp type (heartbeat, ping, patrol,
+               gc_report, recovery, error, escalation)
+  --source     Only wisps created by this actor (e.g., an agent name)
+  --age        Only wisps created within an age bucket: hour (under 1h),
+               day (1h-24h), week (1d-7d), or older (7d+)
+
+Reports (instead of the list, honoring the filters):
+  --summary        Counts by wisp type, age bucket, and status
+  --purge-preview  What 'bd mol wisp gc' would delete, without the cascade
+                   lookup or any deletion
+
 Old wisp detection:
   - Old wisps haven't been updated in 24+ hours
   - Use 'bd mol wisp gc' to clean up old/abandoned wisps
 
 Examples:
-  bd mol wisp list              # List all wisps
-  bd mol wisp list --json       # JSON output for programmatic use
-  bd mol wisp list --all        # Include closed wisps`,
-	SilenceUsage:  true,
-	SilenceErrors: true,
-	RunE:          runWispList,
+  ` + cmdPath + ` list                        # List all wisps
+  ` + cmdPath + ` list --json                 # JSON output for programmatic use
+  ` + cmdPath + ` list --all                  # Include closed wisps
+  ` + cmdPath + ` list --wisp-type patrol     # Only patrol wisps
+  ` + cmdPath + ` list --source witness --age day
+  ` + cmdPath + ` list --summary              # Counts by type and age
+  ` + cmdPath + ` list --purge-preview        # What gc would delete`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          runWispList,
+	}
+	c.Flags().Bool("all", false, "Include closed wisps")
+	c.Flags().String("type", "", "Filter by issue type (e.g., agent, task, patrol)")
+	c.Flags().String("wisp-type", "", "Filter by wisp type (heartbeat, ping, patrol, gc_report, recovery, error, escalation)")
+	c.Flags().String("source", "", "Filter by creating actor (source agent)")
+	c.Flags().String("age", "", "Filter by age bucket since creation (hour, day, week, older)")
+	c.Flags().Bool("summary", false, "Show counts by wisp type, age, and status instead of the list")
+	c.Flags().Bool("purge-preview", false, "Show what wisp gc would delete instead of the list")
+	c.MarkFlagsMutuallyExclusive("summary", "purge-preview")
+	return c
 }
 
 func runWispList(cmd *cobra.Command, args []string) error {
@@ -388,6 +408,18 @@ func runWispList(cmd *cobra.Command, args []string) error {
 
 	showAll, _ := cmd.Flags().GetBool("all")
 	typeFilter, _ := cmd.Flags().GetString("type")
+	wispTypeFilter, _ := cmd.Flags().GetString("wisp-type")
+	sourceFilter, _ := cmd.Flags().GetString("source")
+	ageFilter, _ := cmd.Flags().GetString("age")
+	summary, _ := cmd.Flags().GetBool("summary")
+	purgePreview, _ := cmd.Flags().GetBool("purge-preview")
+
+	if wispTypeFilter != "" && !types.WispType(wispTypeFilter).IsValid() {
+		return HandleError("invalid --wisp-type %q (must be heartbeat, ping, patrol, gc_report, recovery, error, or escalation)", wispTypeFilter)
+	}
+	if ageFilter != "" && !isWispAgeBucket(ageFilter) {
+		return HandleError("invalid --age %q (must be hour, day, week, or older)", ageFilter)
+	}
 
 	if store == nil {
 		if jsonOutput {
@@ -409,11 +441,24 @@ func runWispList(cmd *cobra.Command, args []string) error {
 		it := types.IssueType(typeFilter)
 		filter.IssueType = &it
 	}
+	if wispTypeFilter != "" {
+		wt := types.WispType(wispTypeFilter)
+		filter.WispType = &wt
+	}
 	issues, err := store.SearchIssues(ctx, "", filter)
 	if err != nil {
 		return HandleError("listing wisps: %v", err)
 	}
 
+	now := time.Now()
+	issues = filterWispsBySourceAndAge(issues, sourceFilter, ageFilter, now)
+
+	// The purge preview covers closed wisps regardless of --all, since
+	// that is what gc deletes.
+	if purgePreview {
+		return outputWispPurgePreview(ctx, issues, now)
+	}
+
 	// Filter closed issues unless --all is specified
 	if !showAll {
 		var filtered []*types.Issue
@@ -425,8 +470,11 @@ func runWispList(cmd *cobra.Command, args []string) error {
 		issues = filtered
 	}
 
+	if summary {
+		return outputWispSummary(issues, now)
+	}
+
 	// Convert to list items and detect old wisps
-	now := time.Now()
 	items := make([]WispListItem, 0, len(issues))
 	oldCount := 0
 
@@ -437,6 +485,8 @@ func runWispList(cmd *cobra.Command, args []string) error {
 			Status:    string(issue.Status),
 			Priority:  issue.Priority,
 			Type:      string(issue.IssueType),
+			WispType:  string(issue.WispType),
+			CreatedBy: issue.CreatedBy,
 			Labels:    issue.Labels,
 			CreatedAt: issue.CreatedAt,
 			UpdatedAt: issue.UpdatedAt,
@@ -474,9 +524,9 @@ func runWispList(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Wisps (%d):\n\n", len(items))
 
 	// Print header
-	fmt.Printf("%-12s %-10s %-4s %-10s %-46s %s\n",
-		"ID", "STATUS", "PRI", "TYPE", "TITLE", "UPDATED")
-	fmt.Println(strings.Repeat("-", 100))
+	fmt.Printf("%-12s %-10s %-4s %-10s %-10s %-46s %s\n",
+		"ID", "STATUS", "PRI", "TYPE", "WISP TYPE", "TITLE", "UPDATED")
+	fmt.Println(strings.Repeat("-", 111))
 
 	for _, item := range items {
 		// Truncate title if too long
@@ -494,8 +544,12 @@ func runWispList(cmd *cobra.Command, args []string) error {
 			updated = ui.RenderWarn(updated + " ⚠")
 		}
 
-		fmt.Printf("%-12s %-10s P%-3d %-10s %-46s %s\n",
-			item.ID, status, item.Priority, item.Type, title, updated)
+		wispType := item.WispType
+		if wispType == "" {
+			wispType = "-"
+		}
+		fmt.Printf("%-12s %-10s P%-3d %-10s %-10s %-46s %s\n",
+			item.ID, status, item.Priority, item.Type, wispType, title, updated)
 	}
 
 	if oldCount > 0 {
@@ -860,9 +914,6 @@ func init() {
 	wispCreateCmd.Flags().Bool("dry-run", false, "Preview what would be created")
 	wispCreateCmd.Flags().Bool("root-only", false, "Create only the root issue (no child step issues)")
 
-	wispListCmd.Flags().Bool("all", false, "Include closed wisps")
-	wispListCmd.Flags().String("type", "", "Filter by issue type (e.g., agent, task, patrol)")
-
 	wispGCCmd.Flags().Bool("dry-run", false, "Preview what would be cleaned")
 	wispGCCmd.Flags().String("age", "1h", "Age threshold for abandoned wisp detection")
 	wispGCCmd.Flags().Bool("all", false, "Also clean closed wisps older than threshold")
@@ -872,6 +923,7 @@ func init() {
 
 	wispCmd.AddCommand(wispCreateCmd)
 	wispCmd.AddCommand(wispListCmd)
+	wispCmd.AddCommand(wispShowCmd)
 	wispCmd.AddCommand(wispGCCmd)
 	molCmd.AddCommand(wispCmd)
 }
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/metrics"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Wisp inspection: bd wisp list/show give ephemeral traffic its own views
+// (wisp type, source agent, age, GC eligibility) so it can be examined
+// without mixing into the issue-centric list and show. The same commands
+// are available as bd mol wisp list/show.
+
+// wispGCDefaultAge is the abandonment threshold 'bd mol wisp gc' uses when
+// --age is not given.
+const wispGCDefaultAge = time.Hour
+
+// wispAgeBuckets are the --age buckets, by time since creation, in order.
+// The last bucket has no upper bound.
+var wispAgeBuckets = []struct {
+	name  string
+	label string
+	max   time.Duration
+}{
+	{"hour", "<1h", time.Hour},
+	{"day", "1h-24h", 24 * time.Hour},
+	{"week", "1d-7d", 7 * 24 * time.Hour},
+	{"older", "7d+", 0},
+}
+
+func isWispAgeBucket(name string) bool {
+	for _, b := range wispAgeBuckets {
+		if b.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// wispAgeBucket returns the name of the age bucket a wisp created at created
+// falls into.
+func wispAgeBucket(created, now time.Time) string {
+	age := now.Sub(created)
+	for _, b := range wispAgeBuckets {
+		if b.max == 0 || age < b.max {
+			return b.name
+		}
+	}
+	return wispAgeBuckets[len(wispAgeBuckets)-1].name
+}
+
+// filterWispsBySourceAndAge applies the filters SearchIssues cannot: the
+// creating actor and the age bucket. Empty filters match everything.
+func filterWispsBySourceAndAge(issues []*types.Issue, source, age string, now time.Time) []*types.Issue {
+	if source == "" && age == "" {
+		return issues
+	}
+	var kept []*types.Issue
+	for _, issue := range issues {
+		if source != "" && issue.CreatedBy != source {
+			continue
+		}
+		if age != "" && wispAgeBucket(issue.CreatedAt, now) != age {
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept
+}
+
+// Wisp GC states, as reported by bd wisp show and counted by the purge
+// preview.
+const (
+	wispGCActive    = "active"    // kept by gc
+	wispGCAbandoned = "abandoned" // deleted by 'bd mol wisp gc'
+	wispGCClosed    = "closed"    // deleted by 'bd mol wisp gc --closed --force'
+	wispGCProtected = "protected" // pinned or an infra type; never collected
+)
+
+// wispGCState classifies a wisp the way 'bd mol wisp gc' (default age) and
+// 'bd mol wisp gc --closed' would treat it. Step children reached only by
+// gc's cascade are classified on their own merits.
+func wispGCState(ctx context.Context, issue *types.Issue, now time.Time) string {
+	if store.IsInfraTypeCtx(ctx, issue.IssueType) {
+		return wispGCProtected
+	}
+	if issue.Status == types.StatusClosed {
+		if issue.Pinned {
+			return wispGCProtected
+		}
+		return wispGCClosed
+	}
+	if now.Sub(issue.UpdatedAt) > wispGCDefaultAge {
+		return wispGCAbandoned
+	}
+	return wispGCActive
+}
+
+// WispSummaryResult is the JSON output for wisp list --summary.
+type WispSummaryResult struct {
+	Count    int            `json:"count"`
+	ByType   map[string]int `json:"by_wisp_type"`
+	ByAge    map[string]int `json:"by_age"`
+	ByStatus map[string]int `json:"by_status"`
+}
+
+// untypedWispKey stands in for wisps without a wisp type in summary counts.
+const untypedWispKey = "untyped"
+
+func outputWispSummary(issues []*types.Issue, now time.Time) error {
+	result := WispSummaryResult{
+		Count:    len(issues),
+		ByType:   map[string]int{},
+		ByAge:    map[string]int{},
+		ByStatus: map[string]int{},
+	}
+	for _, issue := range issues {
+		wt := string(issue.WispType)
+		if wt == "" {
+			wt = untypedWispKey
+		}
+		result.ByType[wt]++
+		result.ByAge[wispAgeBucket(issue.CreatedAt, now)]++
+		result.ByStatus[string(issue.Status)]++
+	}
+
+	if jsonOutput {
+		return outputJSON(result)
+	}
+	if result.Count == 0 {
+		fmt.Println("No wisps found")
+		return nil
+	}
+
+	fmt.Printf("Wisps (%d)\n\n", result.Count)
+	fmt.Println(ui.RenderBold("By wisp type:"))
+	for _, k := range sortedCountKeys(result.ByType) {
+		fmt.Printf("  %-14s %d\n", k, result.ByType[k])
+	}
+	fmt.Println()
+	fmt.Println(ui.RenderBold("By age:"))
+	for _, b := range wispAgeBuckets {
+		fmt.Printf("  %-14s %d\n", b.name+" ("+b.label+")", result.ByAge[b.name])
+	}
+	fmt.Println()
+	fmt.Println(ui.RenderBold("By status:"))
+	for _, k := range sortedCountKeys(result.ByStatus) {
+		fmt.Printf("  %-14s %d\n", k, result.ByStatus[k])
+	}
+	return nil
+}
+
+// sortedCountKeys returns the keys of counts, largest count first, ties by
+// name.
+func sortedCountKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// WispPurgePreview is the JSON output for wisp list --purge-preview.
+type WispPurgePreview struct {
+	Closed    int            `json:"closed"`    // deleted by gc --closed --force
+	Abandoned int            `json:"abandoned"` // deleted by gc
+	Protected int            `json:"protected"` // pinned or infra; never deleted
+	Active    int            `json:"active"`
+	ByType    map[string]int `json:"by_wisp_type"` // closed + abandoned, by wisp type
+}
+
+func outputWispPurgePreview(ctx context.Context, issues []*types.Issue, now time.Time) error {
+	preview := WispPurgePreview{ByType: map[string]int{}}
+	for _, issue := range issues {
+		state := wispGCState(ctx, issue, now)
+		switch state {
+		case wispGCClosed:
+			preview.Closed++
+		case wispGCAbandoned:
+			preview.Abandoned++
+		case wispGCProtected:
+			preview.Protected++
+		default:
+			preview.Active++
+		}
+		if state == wispGCClosed || state == wispGCAbandoned {
+			wt := string(issue.WispType)
+			if wt == "" {
+				wt = untypedWispKey
+			}
+			preview.ByType[wt]++
+		}
+	}
+
+	if jsonOutput {
+		return outputJSON(preview)
+	}
+
+	fmt.Printf("Purge preview (%d wisps, nothing deleted):\n\n", len(issues))
+	fmt.Printf("  %-10s %5d  bd mol wisp gc --closed --force\n", "closed", preview.Closed)
+	fmt.Printf("  %-10s %5d  bd mol wisp gc (not updated in %s)\n", "abandoned", preview.Abandoned, formatDurationShort(wispGCDefaultAge))
+	fmt.Printf("  %-10s %5d  pinned or infra type; never collected\n", "protected", preview.Protected)
+	fmt.Printf("  %-10s %5d\n", "active", preview.Active)
+	if len(preview.ByType) > 0 {
+		fmt.Println()
+		fmt.Println(ui.RenderBold("Collectable by wisp type:"))
+		for _, k := range sortedCountKeys(preview.ByType) {
+			fmt.Printf("  %-14s %d\n", k, preview.ByType[k])
+		}
+	}
+	fmt.Printf("\nCounts exclude step children gc cascades to; run 'bd mol wisp gc --dry-run' for the full list.\n")
+	return nil
+}
+
+// formatDurationShort renders whole hours as "1h" rather than "1h0m0s".
+func formatDurationShort(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return d.String()
+}
+
+var wispShowCmd = newWispShowCmd("bd mol wisp")
+
+// newWispShowCmd builds a wisp show command; cmdPath is how its examples
+// invoke it.
+func newWispShowCmd(cmdPath string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <wisp-id>",
+		Short: "Show one wisp's details",
+		Long: `Show a wisp with its wisp-specific details: wisp type, source agent,
+age bucket, dependencies, the step wisps depending on it, and whether
+'bd mol wisp gc' would collect it.
+
+Refuses non-ephemeral issues; use 'bd show' for those.
+
+Examples:
+  ` + cmdPath + ` show bd-wisp-a1b2
+  ` + cmdPath + ` show bd-wisp-a1b2 --json`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          runWispShow,
+	}
+}
+
+// WispShowResult is the JSON output for wisp show.
+type WispShowResult struct {
+	*types.Issue
+	AgeBucket  string   `json:"age_bucket"`
+	GCState    string   `json:"gc_state"`
+	DependsOn  []string `json:"depends_on,omitempty"`
+	Dependents []string `json:"dependent_wisps,omitempty"`
+}
+
+func runWispShow(cmd *cobra.Command, args []string) error {
+	evt := metrics.NewCommandEvent("wisp-show")
+	defer func() {
+		if c := metrics.Global(); c != nil {
+			c.CloseEventAndAdd(evt)
+		}
+	}()
+
+	ctx := rootCtx
+	if store == nil {
+		return HandleErrorWithHint("no database connection", diagHint())
+	}
+
+	id, err := utils.ResolvePartialID(ctx, store, args[0])
+	if err != nil {
+		return HandleError("%v", err)
+	}
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return HandleError("%v", err)
+	}
+	if !issue.Ephemeral {
+		return HandleError("%s is not a wisp; use 'bd show %s'", id, id)
+	}
+
+	now := time.Now()
+	result := WispShowResult{
+		Issue:     issue,
+		AgeBucket: wispAgeBucket(issue.CreatedAt, now),
+		GCState:   wispGCState(ctx, issue, now),
+	}
+	deps, err := store.GetDependencies(ctx, id)
+	if err != nil {
+		return HandleError("loading dependencies: %v", err)
+	}
+	for _, d := range deps {
+		result.DependsOn = append(result.DependsOn, d.ID)
+	}
+	dependents, err := store.FindWispDependentsRecursive(ctx, []string{id})
+	if err != nil {
+		return HandleError("loading dependent wisps: %v", err)
+	}
+	for depID := range dependents {
+		if depID != id {
+			result.Dependents = append(result.Dependents, depID)
+		}
+	}
+	sort.Strings(result.Dependents)
+
+	if jsonOutput {
+		return outputJSON(result)
+	}
+
+	fmt.Printf("\n%s %s\n\n", ui.RenderID(issue.ID), ui.RenderBold(issue.Title))
+	fmt.Printf("  %-12s %s   P%d   %s\n", "Status:", ui.RenderStatus(string(issue.Status)), issue.Priority, issue.IssueType)
+	wispType := string(issue.WispType)
+	if wispType == "" {
+		wispType = ui.RenderMuted("(none)")
+	}
+	fmt.Printf("  %-12s %s\n", "Wisp type:", wispType)
+	if issue.CreatedBy != "" {
+		fmt.Printf("  %-12s %s\n", "Source:", issue.CreatedBy)
+	}
+	bucketLabel := result.AgeBucket
+	for _, b := range wispAgeBuckets {
+		if b.name == result.AgeBucket {
+			bucketLabel = b.label
+		}
+	}
+	fmt.Printf("  %-12s %s (%s, age %s)\n", "Created:", issue.CreatedAt.Local().Format("2006-01-02 15:04"), formatTimeAgo(issue.CreatedAt), bucketLabel)
+	fmt.Printf("  %-12s %s\n", "Updated:", formatTimeAgo(issue.UpdatedAt))
+	if len(issue.Labels) > 0 {
+		fmt.Printf("  %-12s %s\n", "Labels:", strings.Join(issue.Labels, ", "))
+	}
+	if len(result.DependsOn) > 0 {
+		fmt.Printf("  %-12s %s\n", "Depends on:", strings.Join(result.DependsOn, ", "))
+	}
+	if len(result.Dependents) > 0 {
+		fmt.Printf("  %-12s %d step wisp(s), removed with it by gc: %s\n", "Dependents:", len(result.Dependents), strings.Join(result.Dependents, ", "))
+	}
+	fmt.Printf("  %-12s %s\n", "GC:", describeWispGCState(result.GCState))
+	if issue.Description != "" {
+		fmt.Printf("\n%s\n", issue.Description)
+	}
+	fmt.Println()
+	return nil
+}
+
+func describeWispGCState(state string) string {
+	switch state {
+	case wispGCClosed:
+		return ui.RenderWarn("closed") + "; 'bd mol wisp gc --closed --force' would delete it"
+	case wispGCAbandoned:
+		return ui.RenderWarn("abandoned") + fmt.Sprintf("; not updated in %s, 'bd mol wisp gc' would delete it", formatDurationShort(wispGCDefaultAge))
+	case wispGCProtected:
+		return "protected; pinned or an infra type, never collected"
+	default:
+		return "active; kept by gc"
+	}
+}
+
+// wispInspectCmd exposes wisp inspection at the top level. Creating wisps
+// stays under bd mol wisp.
+var wispInspectCmd = &cobra.Command{
+	Use:     "wisp",
+	GroupID: "views",
+	Short:   "Inspect wisps (ephemeral issues)",
+	Long: `Inspect wisps - ephemeral issues kept out of git sync - separately from
+regular issues, with wisp-specific filters and reports.
+
+To create or garbage collect wisps, use 'bd mol wisp'.
+
+Examples:
+  bd wisp list                      # Open wisps, most recently updated first
+  bd wisp list --wisp-type patrol   # Only patrol wisps
+  bd wisp list --summary            # Counts by wisp type, age, and status
+  bd wisp list --purge-preview      # What gc would delete
+  bd wisp show <wisp-id>            # One wisp's details and GC status`,
+}
+
+func init() {
+	wispInspectCmd.AddCommand(newWispListCmd("bd wisp"))
+	wispInspectCmd.AddCommand(newWispShowCmd("bd wisp"))
+	rootCmd.AddCommand(wispInspectCmd)
+}
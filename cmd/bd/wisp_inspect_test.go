@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWispAgeBucket(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{0, "hour"},
+		{59 * time.Minute, "hour"},
+		{time.Hour, "day"},
+		{23 * time.Hour, "day"},
+		{24 * time.Hour, "week"},
+		{6 * 24 * time.Hour, "week"},
+		{7 * 24 * time.Hour, "older"},
+		{90 * 24 * time.Hour, "older"},
+	}
+	for _, tt := range tests {
+		if got := wispAgeBucket(now.Add(-tt.age), now); got != tt.want {
+			t.Errorf("wispAgeBucket(age %s) = %q, want %q", tt.age, got, tt.want)
+		}
+		if !isWispAgeBucket(tt.want) {
+			t.Errorf("isWispAgeBucket(%q) = false", tt.want)
+		}
+	}
+	if isWispAgeBucket("month") {
+		t.Error(`isWispAgeBucket("month") = true`)
+	}
+}
+
+func TestFilterWispsBySourceAndAge(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	issues := []*types.Issue{
+		{ID: "w-1", CreatedBy: "witness", CreatedAt: now.Add(-10 * time.Minute)},
+		{ID: "w-2", CreatedBy: "witness", CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "w-3", CreatedBy: "deacon", CreatedAt: now.Add(-20 * time.Minute)},
+	}
+	ids := func(issues []*types.Issue) []string {
+		var out []string
+		for _, i := range issues {
+			out = append(out, i.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		source, age string
+		want        []string
+	}{
+		{"", "", []string{"w-1", "w-2", "w-3"}},
+		{"witness", "", []string{"w-1", "w-2"}},
+		{"", "hour", []string{"w-1", "w-3"}},
+		{"witness", "day", []string{"w-2"}},
+		{"nobody", "", nil},
+	}
+	for _, tt := range tests {
+		got := ids(filterWispsBySourceAndAge(issues, tt.source, tt.age, now))
+		if len(got) != len(tt.want) {
+			t.Errorf("source=%q age=%q: got %v, want %v", tt.source, tt.age, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("source=%q age=%q: got %v, want %v", tt.source, tt.age, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestSortedCountKeys(t *testing.T) {
+	got := sortedCountKeys(map[string]int{"ping": 2, "patrol": 5, "error": 2})
+	want := []string{"patrol", "error", "ping"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sortedCountKeys = %v, want %v", got, want)
+		}
+	}
+}
@@ -491,6 +491,54 @@ func (c *Client) FetchAllWorkItemsMulti(ctx context.Context, projects []string,
 	return c.fetchWorkItemsByWIQL(ctx, query)
 }
 
+// FetchWorkItemsByQuery runs a caller-supplied WIQL query against the
+// client's project and fetches the matching work items. Only flat queries
+// (FROM WorkItems) are supported.
+func (c *Client) FetchWorkItemsByQuery(ctx context.Context, query string) ([]WorkItem, error) {
+	if err := ValidateWIQL(query); err != nil {
+		return nil, err
+	}
+	return c.fetchWorkItemsByWIQL(ctx, query)
+}
+
+// ValidateWIQL checks that query is a flat WIQL work item query.
+func ValidateWIQL(query string) error {
+	q := strings.ToUpper(strings.Join(strings.Fields(query), " "))
+	if !strings.HasPrefix(q, "SELECT ") {
+		return fmt.Errorf("invalid WIQL query: must start with SELECT")
+	}
+	if !strings.Contains(q, " FROM WORKITEMS") || strings.Contains(q, " FROM WORKITEMLINKS") {
+		return fmt.Errorf("invalid WIQL query: only flat queries (FROM WorkItems) are supported")
+	}
+	return nil
+}
+
+// DownloadAttachment fetches the content of a work item attachment. The
+// URL must point at the client's own Azure DevOps host so the access token
+// is never sent elsewhere.
+func (c *Client) DownloadAttachment(ctx context.Context, attachmentURL string) ([]byte, error) {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	want, err := url.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %w", base, err)
+	}
+	got, err := url.Parse(attachmentURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachment URL %q: %w", attachmentURL, err)
+	}
+	if !strings.EqualFold(got.Host, want.Host) || got.Scheme != want.Scheme {
+		return nil, fmt.Errorf("attachment URL %q is not on %s", attachmentURL, want.Host)
+	}
+	data, err := c.doRequest(ctx, http.MethodGet, attachmentURL, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	return data, nil
+}
+
 // CreateWorkItem creates a new work item of the given type with the specified fields.
 func (c *Client) CreateWorkItem(ctx context.Context, typeName string, fields map[string]interface{}) (*WorkItem, error) {
 	ops := buildPatchOps(fields)
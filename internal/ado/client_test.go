@@ -1550,3 +1550,76 @@ func TestWithBaseURL_AllowsLocalhost(t *testing.T) {
 		t.Errorf("BaseURL = %q, want %q", got.BaseURL, "http://localhost:8080")
 	}
 }
+
+func TestValidateWIQL(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{"SELECT [System.Id] FROM WorkItems WHERE [System.WorkItemType] = 'Bug'", false},
+		{"  select [System.Id]\n  from workitems", false},
+		{"SELECT [System.Id] FROM WorkItemLinks WHERE [Source].[System.Id] = 1", true},
+		{"DELETE FROM WorkItems", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		err := ValidateWIQL(tt.query)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateWIQL(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+		}
+	}
+}
+
+func TestClient_FetchWorkItemsByQuery(t *testing.T) {
+	const query = "SELECT [System.Id] FROM WorkItems WHERE [System.Tags] CONTAINS 'import'"
+	client, _ := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/wit/wiql"):
+			var req WIQLRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Query != query {
+				t.Errorf("WIQL query = %q, want %q", req.Query, query)
+			}
+			_ = json.NewEncoder(w).Encode(WIQLResult{WorkItems: []WIQLWorkItemRef{{ID: 5}}})
+		case strings.HasSuffix(r.URL.Path, "/wit/workitems"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"count": 1,
+				"value": []map[string]interface{}{{"id": 5, "rev": 1, "fields": map[string]interface{}{"System.Title": "Imported"}}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	items, err := client.FetchWorkItemsByQuery(context.Background(), query)
+	if err != nil {
+		t.Fatalf("FetchWorkItemsByQuery() error: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != 5 {
+		t.Fatalf("FetchWorkItemsByQuery() = %+v, want work item 5", items)
+	}
+	if _, err := client.FetchWorkItemsByQuery(context.Background(), "SELECT 1"); err == nil {
+		t.Error("FetchWorkItemsByQuery() with invalid WIQL: expected error")
+	}
+}
+
+func TestClient_DownloadAttachment(t *testing.T) {
+	client, ts := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			t.Error("attachment request missing Authorization header")
+		}
+		_, _ = w.Write([]byte("file contents"))
+	})
+
+	data, err := client.DownloadAttachment(context.Background(), ts.URL+"/_apis/wit/attachments/abc")
+	if err != nil {
+		t.Fatalf("DownloadAttachment() error: %v", err)
+	}
+	if string(data) != "file contents" {
+		t.Errorf("DownloadAttachment() = %q, want %q", data, "file contents")
+	}
+
+	if _, err := client.DownloadAttachment(context.Background(), "https://attacker.example.com/_apis/wit/attachments/abc"); err == nil {
+		t.Error("DownloadAttachment() to a foreign host: expected error")
+	}
+}
@@ -43,6 +43,7 @@ type Tracker struct {
 	org      string
 	projects []string     // one or more project names (first is primary)
 	filters  *PullFilters // Optional pull filters for WIQL queries
+	query    string       // Optional WIQL query replacing the generated one
 }
 
 // SetProjects sets project names before Init(). When set, Init() uses these
@@ -159,16 +160,25 @@ func (t *Tracker) ADOClient() *Client { return t.client }
 // When set, FetchIssues will only return work items matching these filters.
 func (t *Tracker) SetFilters(f *PullFilters) { t.filters = f }
 
-// FetchIssues retrieves work items from Azure DevOps. If opts.Since is set,
-// only work items changed after that time are fetched (incremental sync);
-// otherwise all matching work items in the project are returned (full sync).
+// SetQuery replaces the generated pull query with a caller-supplied WIQL
+// query. When set, FetchIssues runs it as-is, ignoring filters and
+// opts.Since. Used by 'bd import azure-devops'.
+func (t *Tracker) SetQuery(wiql string) { t.query = wiql }
+
+// FetchIssues retrieves work items from Azure DevOps. If a query was set with
+// SetQuery, it selects the work items. Otherwise, if opts.Since is set, only
+// work items changed after that time are fetched (incremental sync); else all
+// matching work items in the project are returned (full sync).
 func (t *Tracker) FetchIssues(ctx context.Context, opts tracker.FetchOptions) ([]tracker.TrackerIssue, error) {
 	var items []WorkItem
 	var err error
 
-	if opts.Since != nil {
+	switch {
+	case t.query != "":
+		items, err = t.client.FetchWorkItemsByQuery(ctx, t.query)
+	case opts.Since != nil:
 		items, err = t.client.FetchWorkItemsSinceMulti(ctx, *opts.Since, t.projects, t.filters)
-	} else {
+	default:
 		items, err = t.client.FetchAllWorkItemsMulti(ctx, t.projects, t.filters)
 	}
 	if err != nil {
@@ -889,6 +889,38 @@ func TestTracker_FetchIssues(t *testing.T) {
 	}
 }
 
+func TestTracker_FetchIssues_Query(t *testing.T) {
+	const query = "SELECT [System.Id] FROM WorkItems WHERE [System.WorkItemType] = 'Bug'"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/testproject/_apis/wit/wiql", func(w http.ResponseWriter, r *http.Request) {
+		var req WIQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Query != query {
+			t.Errorf("WIQL query = %q, want %q", req.Query, query)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(WIQLResult{WorkItems: []WIQLWorkItemRef{{ID: 101}}})
+	})
+	mux.HandleFunc("/testproject/_apis/wit/workitems", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"count": 1,
+			"value": []map[string]interface{}{workItemJSON(101, 1, "Work Item 101", "Active", "Bug")},
+		})
+	})
+
+	tr, _ := newTestTracker(t, mux)
+	tr.SetQuery(query)
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	issues, err := tr.FetchIssues(context.Background(), tracker.FetchOptions{Since: &since})
+	if err != nil {
+		t.Fatalf("FetchIssues() error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "101" {
+		t.Fatalf("FetchIssues() = %+v, want work item 101", issues)
+	}
+}
+
 func TestTracker_FetchIssues_APIError(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/testproject/_apis/wit/wiql", func(w http.ResponseWriter, _ *http.Request) {
@@ -96,6 +96,9 @@ const (
 
 	// RelDependsOn is the depends-on (predecessor) link type.
 	RelDependsOn = "System.LinkTypes.Dependency-Forward"
+
+	// RelAttachedFile is the relation type of a file attached to a work item.
+	RelAttachedFile = "AttachedFile"
 )
 
 // SecretString wraps a string value and prevents accidental exposure
@@ -172,6 +175,26 @@ func (w *WorkItem) GetIntField(name string) int {
 	}
 }
 
+// Attachment is a file attached to a work item.
+type Attachment struct {
+	Name string
+	URL  string
+}
+
+// Attachments returns the files attached to the work item. Work items must be
+// fetched with relations expanded for these to be present.
+func (w *WorkItem) Attachments() []Attachment {
+	var out []Attachment
+	for _, rel := range w.Relations {
+		if rel.Rel != RelAttachedFile || rel.URL == "" {
+			continue
+		}
+		name, _ := rel.Attributes["name"].(string)
+		out = append(out, Attachment{Name: name, URL: rel.URL})
+	}
+	return out
+}
+
 // WorkItemRelation represents a link between work items.
 type WorkItemRelation struct {
 	Rel        string                 `json:"rel"`
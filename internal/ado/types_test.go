@@ -304,3 +304,28 @@ func TestPatchOperation_JSONMarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkItemAttachments(t *testing.T) {
+	wi := &WorkItem{
+		ID: 7,
+		Relations: []WorkItemRelation{
+			{Rel: RelParent, URL: "https://dev.azure.com/org/proj/_apis/wit/workItems/1"},
+			{Rel: RelAttachedFile, URL: "https://dev.azure.com/org/_apis/wit/attachments/abc", Attributes: map[string]interface{}{"name": "log.txt"}},
+			{Rel: RelAttachedFile, URL: "https://dev.azure.com/org/_apis/wit/attachments/def"},
+			{Rel: RelAttachedFile},
+		},
+	}
+	got := wi.Attachments()
+	want := []Attachment{
+		{Name: "log.txt", URL: "https://dev.azure.com/org/_apis/wit/attachments/abc"},
+		{URL: "https://dev.azure.com/org/_apis/wit/attachments/def"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Attachments() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Attachments()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package alertmanager
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultPriority is used when an alert has no severity label or the severity
+// is not in the mapping.
+const DefaultPriority = 2
+
+// AlertLabel is attached to every issue created from an alert so ingested
+// work can be filtered with bd list --label alert.
+const AlertLabel = "alert"
+
+// severityMapping is the default severity label value -> beads priority table.
+var severityMapping = map[string]int{
+	"critical": 0,
+	"page":     0,
+	"high":     1,
+	"error":    1,
+	"major":    1,
+	"warning":  2,
+	"minor":    3,
+	"info":     3,
+	"low":      3,
+	"none":     4,
+}
+
+// MappingConfig configures how alerts map to beads issues.
+type MappingConfig struct {
+	SeverityLabel   string         // alert label holding the severity (default "severity")
+	SeverityMap     map[string]int // severity value -> beads priority (0-4)
+	DefaultPriority int            // priority when severity is missing or unmapped
+	IssueType       types.IssueType
+}
+
+// DefaultMappingConfig returns the default mapping configuration.
+func DefaultMappingConfig() *MappingConfig {
+	sev := make(map[string]int, len(severityMapping))
+	for k, v := range severityMapping {
+		sev[k] = v
+	}
+	return &MappingConfig{
+		SeverityLabel:   "severity",
+		SeverityMap:     sev,
+		DefaultPriority: DefaultPriority,
+		IssueType:       types.TypeBug,
+	}
+}
+
+// ParseSeverityMap parses a "critical=0,warning=2" override string into the
+// config's severity map. Entries not mentioned keep their defaults.
+func (c *MappingConfig) ParseSeverityMap(spec string) error {
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return fmt.Errorf("invalid severity mapping %q (want name=priority)", part)
+		}
+		p, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || p < 0 || p > 4 {
+			return fmt.Errorf("invalid priority %q for severity %q (want 0-4)", value, name)
+		}
+		c.SeverityMap[strings.ToLower(strings.TrimSpace(name))] = p
+	}
+	return nil
+}
+
+// Priority returns the beads priority for an alert based on its severity label.
+func (c *MappingConfig) Priority(a *Alert) int {
+	sev := strings.ToLower(a.Labels[c.SeverityLabel])
+	if p, ok := c.SeverityMap[sev]; ok {
+		return p
+	}
+	return c.DefaultPriority
+}
+
+// Title builds an issue title from the alert's summary annotation, falling
+// back to the alertname label and finally the fingerprint.
+func Title(a *Alert) string {
+	name := a.Labels["alertname"]
+	summary := a.Annotations["summary"]
+	switch {
+	case name != "" && summary != "":
+		return fmt.Sprintf("[%s] %s", name, summary)
+	case summary != "":
+		return summary
+	case name != "":
+		if inst := a.Labels["instance"]; inst != "" {
+			return fmt.Sprintf("%s on %s", name, inst)
+		}
+		return name
+	default:
+		return "Alert " + a.Fingerprint
+	}
+}
+
+// Description renders the alert's annotations and labels as markdown.
+func Description(a *Alert) string {
+	var b strings.Builder
+	if d := a.Annotations["description"]; d != "" {
+		b.WriteString(d)
+		b.WriteString("\n\n")
+	}
+	if rb := a.Annotations["runbook_url"]; rb != "" {
+		fmt.Fprintf(&b, "Runbook: %s\n\n", rb)
+	}
+	if a.GeneratorURL != "" {
+		fmt.Fprintf(&b, "Source: %s\n\n", a.GeneratorURL)
+	}
+	if !a.StartsAt.IsZero() {
+		fmt.Fprintf(&b, "Firing since: %s\n\n", a.StartsAt.UTC().Format("2006-01-02 15:04:05 MST"))
+	}
+	keys := make([]string, 0, len(a.Labels))
+	for k := range a.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	b.WriteString("## Alert Labels\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "- %s: %s\n", k, a.Labels[k])
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// ToIssue converts a firing alert into a new beads issue.
+func (c *MappingConfig) ToIssue(a *Alert) *types.Issue {
+	ref := a.ExternalRef()
+	labels := []string{AlertLabel}
+	if name := a.Labels["alertname"]; name != "" {
+		labels = append(labels, "alertname:"+name)
+	}
+	if sev := a.Labels[c.SeverityLabel]; sev != "" {
+		labels = append(labels, "severity:"+strings.ToLower(sev))
+	}
+	return &types.Issue{
+		Title:        Title(a),
+		Description:  Description(a),
+		Status:       types.StatusOpen,
+		Priority:     c.Priority(a),
+		IssueType:    c.IssueType,
+		ExternalRef:  &ref,
+		SourceSystem: "alertmanager",
+		Labels:       labels,
+	}
+}
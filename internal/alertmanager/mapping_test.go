@@ -0,0 +1,159 @@
+package alertmanager
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const samplePayload = `{
+  "version": "4",
+  "groupKey": "{}:{alertname=\"HighLatency\"}",
+  "status": "firing",
+  "receiver": "beads",
+  "alerts": [
+    {
+      "status": "firing",
+      "labels": {"alertname": "HighLatency", "severity": "critical", "instance": "api-1"},
+      "annotations": {"summary": "p99 latency above 2s", "description": "API latency is degraded."},
+      "startsAt": "2024-05-01T10:00:00Z",
+      "generatorURL": "http://prom/graph",
+      "fingerprint": "abc123"
+    },
+    {
+      "status": "resolved",
+      "labels": {"alertname": "DiskFull", "severity": "warning"},
+      "startsAt": "2024-05-01T09:00:00Z",
+      "endsAt": "2024-05-01T09:30:00Z",
+      "fingerprint": "def456"
+    }
+  ]
+}`
+
+func TestDecodeWebhook(t *testing.T) {
+	hook, err := DecodeWebhook(strings.NewReader(samplePayload))
+	if err != nil {
+		t.Fatalf("DecodeWebhook: %v", err)
+	}
+	if len(hook.Alerts) != 2 {
+		t.Fatalf("got %d alerts, want 2", len(hook.Alerts))
+	}
+	if got := hook.Alerts[0].ExternalRef(); got != "alertmanager:abc123" {
+		t.Errorf("ExternalRef = %q", got)
+	}
+	if hook.Alerts[0].IsResolved() || !hook.Alerts[1].IsResolved() {
+		t.Error("IsResolved mismatch")
+	}
+}
+
+func TestDecodeWebhookRejectsInvalidAlerts(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr string
+	}{
+		{"missing fingerprint", `{"alerts":[{"status":"firing"}]}`, "no fingerprint"},
+		{"unknown status", `{"alerts":[{"status":"pending","fingerprint":"x"}]}`, "unknown status"},
+		{"not json", `nope`, "decoding"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeWebhook(strings.NewReader(tt.payload))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPriority(t *testing.T) {
+	cfg := DefaultMappingConfig()
+	tests := []struct {
+		severity string
+		want     int
+	}{
+		{"critical", 0},
+		{"CRITICAL", 0},
+		{"error", 1},
+		{"warning", 2},
+		{"info", 3},
+		{"none", 4},
+		{"", DefaultPriority},
+		{"bogus", DefaultPriority},
+	}
+	for _, tt := range tests {
+		a := &Alert{Labels: map[string]string{"severity": tt.severity}}
+		if got := cfg.Priority(a); got != tt.want {
+			t.Errorf("Priority(%q) = %d, want %d", tt.severity, got, tt.want)
+		}
+	}
+}
+
+func TestParseSeverityMap(t *testing.T) {
+	cfg := DefaultMappingConfig()
+	if err := cfg.ParseSeverityMap("warning=1, Sev1=0"); err != nil {
+		t.Fatalf("ParseSeverityMap: %v", err)
+	}
+	if cfg.SeverityMap["warning"] != 1 || cfg.SeverityMap["sev1"] != 0 {
+		t.Errorf("overrides not applied: %v", cfg.SeverityMap)
+	}
+	if cfg.SeverityMap["critical"] != 0 {
+		t.Error("defaults should be kept for unmentioned severities")
+	}
+	for _, bad := range []string{"warning", "warning=9", "warning=x"} {
+		if err := DefaultMappingConfig().ParseSeverityMap(bad); err == nil {
+			t.Errorf("ParseSeverityMap(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestToIssue(t *testing.T) {
+	hook, err := DecodeWebhook(strings.NewReader(samplePayload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue := DefaultMappingConfig().ToIssue(&hook.Alerts[0])
+
+	if issue.Title != "[HighLatency] p99 latency above 2s" {
+		t.Errorf("Title = %q", issue.Title)
+	}
+	if issue.Priority != 0 {
+		t.Errorf("Priority = %d, want 0", issue.Priority)
+	}
+	if issue.IssueType != types.TypeBug {
+		t.Errorf("IssueType = %q", issue.IssueType)
+	}
+	if issue.ExternalRef == nil || *issue.ExternalRef != "alertmanager:abc123" {
+		t.Errorf("ExternalRef = %v", issue.ExternalRef)
+	}
+	for _, want := range []string{"API latency is degraded.", "Source: http://prom/graph", "- instance: api-1"} {
+		if !strings.Contains(issue.Description, want) {
+			t.Errorf("Description missing %q:\n%s", want, issue.Description)
+		}
+	}
+	wantLabels := []string{AlertLabel, "alertname:HighLatency", "severity:critical"}
+	if strings.Join(issue.Labels, ",") != strings.Join(wantLabels, ",") {
+		t.Errorf("Labels = %v, want %v", issue.Labels, wantLabels)
+	}
+	if err := issue.Validate(); err != nil {
+		t.Errorf("converted issue should validate: %v", err)
+	}
+}
+
+func TestTitleFallbacks(t *testing.T) {
+	tests := []struct {
+		alert Alert
+		want  string
+	}{
+		{Alert{Labels: map[string]string{"alertname": "Down", "instance": "db"}}, "Down on db"},
+		{Alert{Labels: map[string]string{"alertname": "Down"}}, "Down"},
+		{Alert{Annotations: map[string]string{"summary": "it broke"}}, "it broke"},
+		{Alert{Fingerprint: "ff"}, "Alert ff"},
+	}
+	for _, tt := range tests {
+		if got := Title(&tt.alert); got != tt.want {
+			t.Errorf("Title() = %q, want %q", got, tt.want)
+		}
+	}
+}
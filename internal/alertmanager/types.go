@@ -0,0 +1,78 @@
+// Package alertmanager converts Prometheus Alertmanager webhook payloads into
+// beads issues.
+//
+// Alertmanager POSTs a JSON document to its configured webhook receivers each
+// time an alert group changes. Every alert in the group carries a stable
+// fingerprint, which this package uses as the issue's external_ref so a
+// re-delivered or re-firing alert updates the existing issue instead of
+// creating a duplicate.
+package alertmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Alert status values as sent by Alertmanager.
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// ExternalRefPrefix namespaces alert fingerprints in the external_ref column.
+const ExternalRefPrefix = "alertmanager:"
+
+// Webhook is the payload Alertmanager sends to webhook receivers (version 4).
+type Webhook struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts,omitempty"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels,omitempty"`
+	CommonLabels      map[string]string `json:"commonLabels,omitempty"`
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+	ExternalURL       string            `json:"externalURL,omitempty"`
+	Alerts            []Alert           `json:"alerts"`
+}
+
+// Alert is a single alert inside a webhook payload.
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt,omitempty"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// ExternalRef returns the external_ref used to dedupe this alert.
+func (a *Alert) ExternalRef() string {
+	return ExternalRefPrefix + a.Fingerprint
+}
+
+// IsResolved reports whether Alertmanager considers the alert cleared.
+func (a *Alert) IsResolved() bool {
+	return a.Status == StatusResolved
+}
+
+// DecodeWebhook parses and validates an Alertmanager webhook payload.
+// Alerts without a fingerprint are rejected because they cannot be deduped.
+func DecodeWebhook(r io.Reader) (*Webhook, error) {
+	var w Webhook
+	if err := json.NewDecoder(r).Decode(&w); err != nil {
+		return nil, fmt.Errorf("decoding alertmanager payload: %w", err)
+	}
+	for i, a := range w.Alerts {
+		if a.Fingerprint == "" {
+			return nil, fmt.Errorf("alert %d has no fingerprint", i)
+		}
+		if a.Status != StatusFiring && a.Status != StatusResolved {
+			return nil, fmt.Errorf("alert %s has unknown status %q", a.Fingerprint, a.Status)
+		}
+	}
+	return &w, nil
+}
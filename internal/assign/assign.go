@@ -0,0 +1,293 @@
+// Package assign picks assignees for new ready work.
+//
+// A Config names a team and a Strategy. Pick chooses one member for an issue
+// and explains the choice; it updates the State it is given, so a batch of
+// picks sees the load and rotation of the picks before it.
+package assign
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Strategy selects how Pick chooses among team members.
+type Strategy string
+
+const (
+	// RoundRobin rotates through the team in order.
+	RoundRobin Strategy = "round-robin"
+	// Weighted picks the member with the lowest load relative to their
+	// weight, where load is their count of active assigned issues.
+	Weighted Strategy = "weighted"
+	// StickyLabel keeps a label's work with one member: a configured
+	// label owner, else whoever already holds the most active issues with
+	// that label. Issues with no sticky label use the fallback strategy.
+	StickyLabel Strategy = "sticky-label"
+)
+
+// Strategies lists the valid strategies.
+var Strategies = []Strategy{RoundRobin, Weighted, StickyLabel}
+
+// Valid reports whether s is a known strategy.
+func (s Strategy) Valid() bool {
+	for _, known := range Strategies {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Member is one assignable team member.
+type Member struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"` // Relative capacity for Weighted; default 1
+}
+
+// Config is an assignment policy.
+type Config struct {
+	Strategy Strategy
+	Team     []Member
+	// Sticky pins labels to members for StickyLabel.
+	Sticky map[string]string
+	// Fallback is used by StickyLabel when no label is sticky. It must not
+	// be StickyLabel; empty means RoundRobin.
+	Fallback Strategy
+	// Away maps members who are out of office to whoever covers for them,
+	// or to "" if nobody does. A pick that lands on an away member goes to
+	// their delegate; members with no delegate are passed over.
+	Away map[string]string
+}
+
+// ParseTeam parses a team list such as "alice:2,bob,carol" into members.
+// A member without a weight has weight 1.
+func ParseTeam(s string) ([]Member, error) {
+	var team []Member
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, weightStr, hasWeight := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("empty team member name in %q", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("team member %s listed twice", name)
+		}
+		seen[name] = true
+		weight := 1.0
+		if hasWeight {
+			var err error
+			weight, err = strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+			if err != nil || weight <= 0 {
+				return nil, fmt.Errorf("invalid weight %q for team member %s", weightStr, name)
+			}
+		}
+		team = append(team, Member{Name: name, Weight: weight})
+	}
+	return team, nil
+}
+
+// Validate checks that c can pick assignees.
+func (c Config) Validate() error {
+	if len(c.Team) == 0 {
+		return fmt.Errorf("no team configured")
+	}
+	if !c.Strategy.Valid() {
+		return fmt.Errorf("unknown assignment strategy %q (valid: %s)", c.Strategy, StrategyNames())
+	}
+	if c.Fallback != "" && (!c.Fallback.Valid() || c.Fallback == StickyLabel) {
+		return fmt.Errorf("invalid fallback strategy %q (valid: %s, %s)", c.Fallback, RoundRobin, Weighted)
+	}
+	for label, name := range c.Sticky {
+		if !c.hasMember(name) {
+			return fmt.Errorf("sticky label %s names %s, who is not on the team", label, name)
+		}
+	}
+	return nil
+}
+
+// skipped reports whether name is away with nobody covering.
+func (c Config) skipped(name string) bool {
+	delegate, away := c.Away[name]
+	return away && delegate == ""
+}
+
+func (c Config) hasMember(name string) bool {
+	for _, m := range c.Team {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// StrategyNames returns the valid strategies as a comma-separated list.
+func StrategyNames() string {
+	names := make([]string, len(Strategies))
+	for i, s := range Strategies {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+// State is what Pick knows about current assignments.
+type State struct {
+	// Load counts each member's active (open or in_progress) issues.
+	Load map[string]int
+	// LabelLoad counts, per label, each member's active issues with it.
+	LabelLoad map[string]map[string]int
+	// Last is the member RoundRobin picked most recently.
+	Last string
+}
+
+// Decision is one assignment and why it was made.
+type Decision struct {
+	Assignee string   `json:"assignee"`
+	Strategy Strategy `json:"strategy"`
+	Reason   string   `json:"reason"`
+	// RedirectedFrom is the away member the strategy picked before the
+	// assignment went to their delegate.
+	RedirectedFrom string `json:"redirected_from,omitempty"`
+}
+
+// Pick chooses an assignee for an issue with the given labels and records
+// the assignment in st.
+func Pick(c Config, labels []string, st *State) (Decision, error) {
+	if err := c.Validate(); err != nil {
+		return Decision{}, err
+	}
+	if st.Load == nil {
+		st.Load = make(map[string]int)
+	}
+	if st.LabelLoad == nil {
+		st.LabelLoad = make(map[string]map[string]int)
+	}
+	available := false
+	for _, m := range c.Team {
+		if !c.skipped(m.Name) {
+			available = true
+			break
+		}
+	}
+	if !available {
+		return Decision{}, fmt.Errorf("every team member is out of office with no delegate")
+	}
+
+	var d Decision
+	switch c.Strategy {
+	case RoundRobin:
+		d = pickRoundRobin(c, st)
+	case Weighted:
+		d = pickWeighted(c, st)
+	case StickyLabel:
+		var ok bool
+		if d, ok = pickSticky(c, labels, st); !ok {
+			fallback := c.Fallback
+			if fallback == "" {
+				fallback = RoundRobin
+			}
+			if fallback == Weighted {
+				d = pickWeighted(c, st)
+			} else {
+				d = pickRoundRobin(c, st)
+			}
+			d.Reason = "no sticky label; " + d.Reason
+		}
+	}
+
+	// The rotation continues from the member picked, not their delegate.
+	picked := d.Assignee
+	if delegate := c.Away[picked]; delegate != "" {
+		d.RedirectedFrom = picked
+		d.Assignee = delegate
+		d.Reason += fmt.Sprintf("; %s is out of office, covered by %s", picked, delegate)
+	}
+
+	st.Load[d.Assignee]++
+	for _, label := range labels {
+		if st.LabelLoad[label] == nil {
+			st.LabelLoad[label] = make(map[string]int)
+		}
+		st.LabelLoad[label][d.Assignee]++
+	}
+	if d.Strategy == RoundRobin {
+		st.Last = picked
+	}
+	return d, nil
+}
+
+func pickRoundRobin(c Config, st *State) Decision {
+	n := len(c.Team)
+	for i, m := range c.Team {
+		if m.Name != st.Last {
+			continue
+		}
+		for step := 1; step <= n; step++ {
+			if next := c.Team[(i+step)%n].Name; !c.skipped(next) {
+				return Decision{Assignee: next, Strategy: RoundRobin, Reason: "next in rotation after " + st.Last}
+			}
+		}
+	}
+	for _, m := range c.Team {
+		if !c.skipped(m.Name) {
+			return Decision{Assignee: m.Name, Strategy: RoundRobin, Reason: "start of rotation"}
+		}
+	}
+	return Decision{}
+}
+
+func pickWeighted(c Config, st *State) Decision {
+	best, bestLoad := -1, 0.0
+	for i, m := range c.Team {
+		if c.skipped(m.Name) {
+			continue
+		}
+		if load := float64(st.Load[m.Name]) / m.Weight; best < 0 || load < bestLoad {
+			best, bestLoad = i, load
+		}
+	}
+	m := c.Team[best]
+	return Decision{
+		Assignee: m.Name,
+		Strategy: Weighted,
+		Reason:   fmt.Sprintf("lowest load: %d active issue(s) at weight %g", st.Load[m.Name], m.Weight),
+	}
+}
+
+// pickSticky returns the owner of the first sticky label, checking
+// configured owners before label history. Labels are checked in sorted
+// order so the choice does not depend on label order.
+func pickSticky(c Config, labels []string, st *State) (Decision, bool) {
+	sorted := append([]string(nil), labels...)
+	sort.Strings(sorted)
+	for _, label := range sorted {
+		if name, ok := c.Sticky[label]; ok && !c.skipped(name) {
+			return Decision{Assignee: name, Strategy: StickyLabel, Reason: fmt.Sprintf("label %s is pinned to %s", label, name)}, true
+		}
+	}
+	for _, label := range sorted {
+		owner, most := "", 0
+		for _, m := range c.Team {
+			if c.skipped(m.Name) {
+				continue
+			}
+			if n := st.LabelLoad[label][m.Name]; n > most {
+				owner, most = m.Name, n
+			}
+		}
+		if owner != "" {
+			return Decision{
+				Assignee: owner,
+				Strategy: StickyLabel,
+				Reason:   fmt.Sprintf("%s already holds %d active issue(s) labeled %s", owner, most, label),
+			}, true
+		}
+	}
+	return Decision{}, false
+}
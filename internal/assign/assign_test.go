@@ -0,0 +1,137 @@
+package assign
+
+import "testing"
+
+func TestParseTeam(t *testing.T) {
+	team, err := ParseTeam("alice:2, bob ,carol:0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Member{{"alice", 2}, {"bob", 1}, {"carol", 0.5}}
+	if len(team) != len(want) {
+		t.Fatalf("team = %v, want %v", team, want)
+	}
+	for i := range want {
+		if team[i] != want[i] {
+			t.Errorf("team[%d] = %v, want %v", i, team[i], want[i])
+		}
+	}
+	for _, bad := range []string{"alice,alice", "bob:0", "bob:x", ":2"} {
+		if _, err := ParseTeam(bad); err == nil {
+			t.Errorf("ParseTeam(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	team := []Member{{"alice", 1}}
+	tests := []struct {
+		name string
+		cfg  Config
+		ok   bool
+	}{
+		{"ok", Config{Strategy: RoundRobin, Team: team}, true},
+		{"no team", Config{Strategy: RoundRobin}, false},
+		{"unknown strategy", Config{Strategy: "lottery", Team: team}, false},
+		{"sticky fallback", Config{Strategy: StickyLabel, Team: team, Fallback: StickyLabel}, false},
+		{"sticky outsider", Config{Strategy: StickyLabel, Team: team, Sticky: map[string]string{"ui": "bob"}}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%s: Validate() = %v, want ok=%v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func pickAll(t *testing.T, c Config, st *State, labels ...[]string) []string {
+	t.Helper()
+	var got []string
+	for _, l := range labels {
+		d, err := Pick(c, l, st)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, d.Assignee)
+	}
+	return got
+}
+
+func TestPickRoundRobin(t *testing.T) {
+	c := Config{Strategy: RoundRobin, Team: []Member{{"alice", 1}, {"bob", 1}, {"carol", 1}}}
+	st := &State{Last: "bob"}
+	got := pickAll(t, c, st, nil, nil, nil)
+	if want := []string{"carol", "alice", "bob"}; got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("rotation = %v, want %v", got, want)
+	}
+	if st.Last != "bob" || st.Load["alice"] != 1 {
+		t.Errorf("state not updated: %+v", st)
+	}
+}
+
+func TestPickWeighted(t *testing.T) {
+	c := Config{Strategy: Weighted, Team: []Member{{"alice", 2}, {"bob", 1}}}
+	st := &State{Load: map[string]int{"alice": 2, "bob": 0}}
+	got := pickAll(t, c, st, nil, nil, nil)
+	// bob 0/1, then alice 2/2=1 vs bob 1/1=1 ties to alice, then alice 3/2 vs bob 1.
+	if want := []string{"bob", "alice", "bob"}; got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("weighted = %v, want %v", got, want)
+	}
+}
+
+func TestPickSticky(t *testing.T) {
+	c := Config{
+		Strategy: StickyLabel,
+		Team:     []Member{{"alice", 1}, {"bob", 1}},
+		Sticky:   map[string]string{"ui": "bob"},
+		Fallback: Weighted,
+	}
+	st := &State{
+		Load:      map[string]int{"alice": 3},
+		LabelLoad: map[string]map[string]int{"api": {"alice": 2}},
+	}
+
+	d, err := Pick(c, []string{"api", "ui"}, st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Assignee != "bob" || d.Reason != "label ui is pinned to bob" {
+		t.Errorf("pinned label: %+v", d)
+	}
+	if d, _ = Pick(c, []string{"api"}, st); d.Assignee != "alice" || d.Strategy != StickyLabel {
+		t.Errorf("label history: %+v", d)
+	}
+	if d, _ = Pick(c, []string{"docs"}, st); d.Assignee != "bob" || d.Strategy != Weighted {
+		t.Errorf("fallback: %+v", d)
+	}
+}
+
+func TestPickAway(t *testing.T) {
+	team := []Member{{"alice", 1}, {"bob", 1}, {"carol", 1}}
+
+	c := Config{Strategy: RoundRobin, Team: team, Away: map[string]string{"alice": "bob", "carol": ""}}
+	st := &State{Last: "bob"}
+	d, err := Pick(c, nil, st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// carol is skipped; alice's turn goes to bob, and the rotation moves on
+	// from alice.
+	if d.Assignee != "bob" || d.RedirectedFrom != "alice" || st.Last != "alice" || st.Load["bob"] != 1 {
+		t.Errorf("redirect: %+v, state %+v", d, st)
+	}
+
+	c = Config{Strategy: Weighted, Team: team, Away: map[string]string{"alice": ""}}
+	if d, _ := Pick(c, nil, &State{Load: map[string]int{"bob": 2, "carol": 1}}); d.Assignee != "carol" {
+		t.Errorf("weighted skips away members: %+v", d)
+	}
+
+	c = Config{Strategy: StickyLabel, Team: team, Sticky: map[string]string{"ui": "carol"}, Away: map[string]string{"carol": ""}}
+	if d, _ := Pick(c, []string{"ui"}, &State{}); d.Assignee == "carol" || d.Strategy != RoundRobin {
+		t.Errorf("pinned owner away: %+v", d)
+	}
+
+	c = Config{Strategy: RoundRobin, Team: team[:1], Away: map[string]string{"alice": ""}}
+	if _, err := Pick(c, nil, &State{}); err == nil {
+		t.Error("expected an error when everyone is away")
+	}
+}
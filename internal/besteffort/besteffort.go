@@ -0,0 +1,93 @@
+// Package besteffort records failures of best-effort operations: work whose
+// error the caller tolerates and carries on without, such as stamping a
+// peer's last-sync time or restoring an environment variable. Recording
+// instead of discarding the error keeps such failures visible — bd prints
+// a summary on stderr and adds them to JSON output as "warnings" — and
+// counts them in the bd.best_effort.failures metric.
+package besteffort
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/steveyegge/beads/internal/debug"
+)
+
+// maxWarnings bounds the distinct warnings kept until the next Drain;
+// later ones are only counted.
+const maxWarnings = 50
+
+// Warning is a best-effort operation that failed. Repeats of the same
+// failure are folded into one Warning.
+type Warning struct {
+	Op      string `json:"op"`
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+type key struct{ op, message string }
+
+var (
+	mu       sync.Mutex
+	pending  = make(map[key]*Warning)
+	order    []key
+	overflow int
+
+	failures     metric.Int64Counter
+	failuresOnce sync.Once
+)
+
+// Record notes that the best-effort operation op failed with err. A nil
+// err is ignored, so callers can pass an error through unconditionally:
+//
+//	besteffort.Record("update peer last sync", s.updatePeerLastSync(ctx, peer))
+func Record(op string, err error) {
+	if err == nil {
+		return
+	}
+	debug.Logf("best-effort %s failed: %v\n", op, err)
+
+	failuresOnce.Do(func() {
+		failures, _ = otel.Meter("github.com/steveyegge/beads/besteffort").Int64Counter("bd.best_effort.failures",
+			metric.WithDescription("Best-effort operations that failed and were skipped"),
+			metric.WithUnit("{failure}"),
+		)
+	})
+	if failures != nil {
+		failures.Add(context.Background(), 1, metric.WithAttributes(attribute.String("op", op)))
+	}
+
+	k := key{op: op, message: err.Error()}
+	mu.Lock()
+	defer mu.Unlock()
+	if w, ok := pending[k]; ok {
+		w.Count++
+		return
+	}
+	if len(order) >= maxWarnings {
+		overflow++
+		return
+	}
+	pending[k] = &Warning{Op: op, Message: k.message, Count: 1}
+	order = append(order, k)
+}
+
+// Drain returns the warnings recorded since the last Drain, in the order
+// they were first recorded, and how many more were counted but not kept.
+// Each warning is returned once.
+func Drain() (warnings []Warning, dropped int) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, k := range order {
+		warnings = append(warnings, *pending[k])
+	}
+	dropped = overflow
+	pending = make(map[key]*Warning)
+	order = nil
+	overflow = 0
+	return warnings, dropped
+}
@@ -0,0 +1,52 @@
+package besteffort
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRecordFoldsRepeatsAndDrains(t *testing.T) {
+	Drain()
+	Record("ignored", nil)
+	Record("update peer last sync", errors.New("locked"))
+	Record("remove peer remote", errors.New("permission denied"))
+	Record("update peer last sync", errors.New("locked"))
+	Record("update peer last sync", errors.New("timeout"))
+
+	got, dropped := Drain()
+	want := []Warning{
+		{Op: "update peer last sync", Message: "locked", Count: 2},
+		{Op: "remove peer remote", Message: "permission denied", Count: 1},
+		{Op: "update peer last sync", Message: "timeout", Count: 1},
+	}
+	if dropped != 0 || len(got) != len(want) {
+		t.Fatalf("Drain() = %+v, %d; want %+v, 0", got, dropped, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("warning %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if got, dropped := Drain(); len(got) != 0 || dropped != 0 {
+		t.Errorf("second Drain() = %+v, %d; want nothing", got, dropped)
+	}
+}
+
+func TestRecordBoundsDistinctWarnings(t *testing.T) {
+	Drain()
+	for i := range maxWarnings + 3 {
+		Record("cleanup", fmt.Errorf("failure %d", i))
+	}
+	// Repeats of a kept warning are still folded in after the limit.
+	Record("cleanup", errors.New("failure 0"))
+
+	got, dropped := Drain()
+	if len(got) != maxWarnings || dropped != 3 {
+		t.Fatalf("kept %d, dropped %d; want %d, 3", len(got), dropped, maxWarnings)
+	}
+	if got[0].Count != 2 {
+		t.Errorf("first warning count = %d, want 2", got[0].Count)
+	}
+}
@@ -0,0 +1,236 @@
+// Package capacity compares assigned work against each person's capacity.
+//
+// A Report sums the remaining estimates of each assignee's open work,
+// compares it with the hours they have available before a date, and
+// suggests moving issues from overcommitted people to people with room.
+package capacity
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ParseWeekly parses a weekly capacity list such as "alice:40,bob:20,
+// agent-1:80" into hours per week by name.
+func ParseWeekly(s string) (map[string]float64, error) {
+	out := make(map[string]float64)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, hoursStr, ok := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid capacity %q: expected name:hours", part)
+		}
+		if _, dup := out[name]; dup {
+			return nil, fmt.Errorf("capacity for %s listed twice", name)
+		}
+		hours, err := strconv.ParseFloat(strings.TrimSpace(hoursStr), 64)
+		if err != nil || hours < 0 {
+			return nil, fmt.Errorf("invalid hours for %s: %q", name, hoursStr)
+		}
+		out[name] = hours
+	}
+	return out, nil
+}
+
+// WorkingDays counts the weekdays from the start of from's day up to, but
+// not including, until's day.
+func WorkingDays(from, until time.Time) int {
+	d := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	end := time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, from.Location())
+	n := 0
+	for ; d.Before(end); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			n++
+		}
+	}
+	return n
+}
+
+// Person is one assignee's load against their capacity.
+type Person struct {
+	Name           string   `json:"name"`
+	WeeklyHours    float64  `json:"weekly_hours"`
+	Configured     bool     `json:"configured"` // false when the default capacity was used
+	AvailableHours float64  `json:"available_hours"`
+	AssignedHours  float64  `json:"assigned_hours"`
+	Issues         []string `json:"issues"`
+	Unestimated    []string `json:"unestimated"`
+	Utilization    float64  `json:"utilization"` // assigned / available; 0 when nothing is available
+	Overcommitted  bool     `json:"overcommitted"`
+}
+
+// Move suggests reassigning one issue.
+type Move struct {
+	IssueID  string  `json:"issue_id"`
+	Title    string  `json:"title"`
+	Priority int     `json:"priority"`
+	Hours    float64 `json:"hours"`
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+}
+
+// Report is the capacity plan up to a date.
+type Report struct {
+	From          string    `json:"from"`
+	Until         string    `json:"until"`
+	WorkingDays   int       `json:"working_days"`
+	People        []*Person `json:"people"`
+	Moves         []*Move   `json:"moves"`
+	Overcommitted []string  `json:"overcommitted"`
+}
+
+// Options configures Build.
+type Options struct {
+	From, Until time.Time
+	// Weekly is the configured hours per week by name.
+	Weekly map[string]float64
+	// DefaultWeekly applies to assignees missing from Weekly.
+	DefaultWeekly float64
+}
+
+// Build computes the report for issues, which should be the open work to
+// plan. Unassigned issues are ignored. Everyone in Weekly appears in the
+// report, so idle people are candidates for rebalancing.
+func Build(issues []*types.Issue, opts Options) *Report {
+	days := WorkingDays(opts.From, opts.Until)
+	r := &Report{
+		From:          opts.From.Format("2006-01-02"),
+		Until:         opts.Until.Format("2006-01-02"),
+		WorkingDays:   days,
+		People:        []*Person{},
+		Moves:         []*Move{},
+		Overcommitted: []string{},
+	}
+
+	people := make(map[string]*Person)
+	person := func(name string) *Person {
+		if p, ok := people[name]; ok {
+			return p
+		}
+		weekly, configured := opts.Weekly[name]
+		if !configured {
+			weekly = opts.DefaultWeekly
+		}
+		p := &Person{
+			Name:           name,
+			WeeklyHours:    weekly,
+			Configured:     configured,
+			AvailableHours: round(weekly * float64(days) / 5),
+			Issues:         []string{},
+			Unestimated:    []string{},
+		}
+		people[name] = p
+		return p
+	}
+	for name := range opts.Weekly {
+		person(name)
+	}
+
+	sorted := append([]*types.Issue(nil), issues...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	var movable []*types.Issue
+	for _, issue := range sorted {
+		if issue.Assignee == "" {
+			continue
+		}
+		p := person(issue.Assignee)
+		p.Issues = append(p.Issues, issue.ID)
+		if issue.EstimatedMinutes == nil || *issue.EstimatedMinutes <= 0 {
+			p.Unestimated = append(p.Unestimated, issue.ID)
+			continue
+		}
+		p.AssignedHours += float64(*issue.EstimatedMinutes) / 60
+		// Work already under way stays with its owner.
+		if issue.Status != types.StatusInProgress {
+			movable = append(movable, issue)
+		}
+	}
+
+	for _, p := range people {
+		p.AssignedHours = round(p.AssignedHours)
+		r.People = append(r.People, p)
+	}
+	sort.Slice(r.People, func(i, j int) bool { return r.People[i].Name < r.People[j].Name })
+	for _, p := range r.People {
+		if p.AvailableHours > 0 {
+			p.Utilization = round(p.AssignedHours / p.AvailableHours)
+		}
+		p.Overcommitted = p.AssignedHours > p.AvailableHours
+		if p.Overcommitted {
+			r.Overcommitted = append(r.Overcommitted, p.Name)
+		}
+	}
+	r.Moves = rebalance(r.People, movable, len(opts.Weekly) > 0)
+	return r
+}
+
+// rebalance greedily moves the least urgent estimated work off
+// overcommitted people onto whoever has the most spare hours, only when
+// the issue fits in the receiver's spare time. When a team is configured
+// only its members receive work. It plans against copies of the loads; the
+// report keeps the current assignment.
+func rebalance(people []*Person, movable []*types.Issue, configuredOnly bool) []*Move {
+	load := make(map[string]float64, len(people))
+	avail := make(map[string]float64, len(people))
+	for _, p := range people {
+		load[p.Name] = p.AssignedHours
+		avail[p.Name] = p.AvailableHours
+	}
+
+	// Least urgent first: highest priority number, then largest estimate,
+	// then ID for stable output.
+	sort.SliceStable(movable, func(i, j int) bool {
+		a, b := movable[i], movable[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if *a.EstimatedMinutes != *b.EstimatedMinutes {
+			return *a.EstimatedMinutes > *b.EstimatedMinutes
+		}
+		return a.ID < b.ID
+	})
+
+	moves := []*Move{}
+	for _, issue := range movable {
+		from := issue.Assignee
+		if load[from] <= avail[from] {
+			continue
+		}
+		hours := float64(*issue.EstimatedMinutes) / 60
+		to := ""
+		best := 0.0
+		for _, p := range people {
+			spare := avail[p.Name] - load[p.Name]
+			if p.Name == from || (configuredOnly && !p.Configured) || spare < hours || spare <= best {
+				continue
+			}
+			to, best = p.Name, spare
+		}
+		if to == "" {
+			continue
+		}
+		load[from] -= hours
+		load[to] += hours
+		moves = append(moves, &Move{
+			IssueID:  issue.ID,
+			Title:    issue.Title,
+			Priority: issue.Priority,
+			Hours:    round(hours),
+			From:     from,
+			To:       to,
+		})
+	}
+	return moves
+}
+
+func round(f float64) float64 { return math.Round(f*100) / 100 }
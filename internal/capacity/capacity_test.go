@@ -0,0 +1,82 @@
+package capacity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseWeekly(t *testing.T) {
+	got, err := ParseWeekly(" alice:40, bob:12.5 ,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got["alice"] != 40 || got["bob"] != 12.5 {
+		t.Errorf("ParseWeekly = %v", got)
+	}
+	for _, bad := range []string{"alice", "alice:x", "alice:-1", "alice:1,alice:2", ":3"} {
+		if _, err := ParseWeekly(bad); err == nil {
+			t.Errorf("ParseWeekly(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestWorkingDays(t *testing.T) {
+	fri := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	if got := WorkingDays(fri, fri.AddDate(0, 0, 7)); got != 5 {
+		t.Errorf("one week = %d working days, want 5", got)
+	}
+	if got := WorkingDays(fri, fri.AddDate(0, 0, 3)); got != 1 {
+		t.Errorf("Fri→Mon = %d working days, want 1", got)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	est := func(h int) *int { m := h * 60; return &m }
+	issues := []*types.Issue{
+		{ID: "bd-1", Assignee: "alice", Priority: 1, EstimatedMinutes: est(8)},
+		{ID: "bd-2", Assignee: "alice", Priority: 3, EstimatedMinutes: est(6)},
+		{ID: "bd-3", Assignee: "alice", Priority: 2, EstimatedMinutes: est(4), Status: types.StatusInProgress},
+		{ID: "bd-4", Assignee: "alice", Priority: 2},
+		{ID: "bd-5", Assignee: "bob", Priority: 2, EstimatedMinutes: est(2)},
+		{ID: "bd-6", Assignee: "dave", Priority: 4, EstimatedMinutes: est(1)},
+		{ID: "bd-7", Priority: 0, EstimatedMinutes: est(50)},
+	}
+	from := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC) // Monday
+	r := Build(issues, Options{
+		From:          from,
+		Until:         from.AddDate(0, 0, 7),
+		Weekly:        map[string]float64{"alice": 10, "bob": 20, "carol": 5},
+		DefaultWeekly: 40,
+	})
+
+	byName := map[string]*Person{}
+	for _, p := range r.People {
+		byName[p.Name] = p
+	}
+	alice := byName["alice"]
+	if alice == nil || alice.AssignedHours != 18 || alice.AvailableHours != 10 || !alice.Overcommitted {
+		t.Fatalf("alice = %+v", alice)
+	}
+	if len(alice.Unestimated) != 1 || alice.Unestimated[0] != "bd-4" {
+		t.Errorf("alice unestimated = %v", alice.Unestimated)
+	}
+	if byName["carol"] == nil || len(byName["carol"].Issues) != 0 {
+		t.Errorf("idle configured member missing: %+v", byName["carol"])
+	}
+	if d := byName["dave"]; d == nil || d.Configured || d.AvailableHours != 40 {
+		t.Errorf("dave = %+v, want default capacity", d)
+	}
+
+	// The least urgent movable issue (bd-2, P3) goes to bob, which leaves
+	// alice at 12h, still over; bd-1 (8h) fits in bob's remaining 12h.
+	// In-progress bd-3 never moves, and dave is not a configured target.
+	if len(r.Moves) != 2 || r.Moves[0].IssueID != "bd-2" || r.Moves[0].To != "bob" ||
+		r.Moves[1].IssueID != "bd-1" || r.Moves[1].To != "bob" {
+		for _, m := range r.Moves {
+			t.Logf("move %+v", m)
+		}
+		t.Fatalf("unexpected moves")
+	}
+}
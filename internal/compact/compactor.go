@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/steveyegge/beads/internal/progress"
+	issuesummary "github.com/steveyegge/beads/internal/summary"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -106,6 +108,9 @@ func (c *Compactor) CompactTier1(ctx context.Context, issueID string) error {
 	if err != nil {
 		return fmt.Errorf("failed to fetch issue: %w", err)
 	}
+	if err := refuseEncrypted(issue); err != nil {
+		return err
+	}
 
 	// Calculate original size
 	originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
@@ -114,10 +119,15 @@ func (c *Compactor) CompactTier1(ctx context.Context, issueID string) error {
 		return fmt.Errorf("dry-run: would compact %s (original size: %d bytes)", issueID, originalSize)
 	}
 
-	// Get summary from AI
-	summary, err := c.summarizer.SummarizeTier1(ctx, issue)
-	if err != nil {
-		return fmt.Errorf("failed to summarize: %w", err)
+	// Prefer a current bd summarize result; otherwise get summary from AI
+	var summary string
+	if stored, _ := issuesummary.FromMetadata(issue.Metadata); stored.Current(issue) {
+		summary = stored.Text
+	} else {
+		summary, err = c.summarizer.SummarizeTier1(ctx, issue)
+		if err != nil {
+			return fmt.Errorf("failed to summarize: %w", err)
+		}
 	}
 
 	// Check if compaction would actually reduce size
@@ -172,7 +182,8 @@ type BatchResult struct {
 	Err           error
 }
 
-// CompactTier1Batch compacts multiple issues at Tier 1 concurrently.
+// CompactTier1Batch compacts multiple issues at Tier 1 concurrently,
+// advancing the progress task carried by ctx as each issue finishes.
 func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([]BatchResult, error) {
 	results := make([]BatchResult, len(issueIDs))
 	sem := make(chan struct{}, c.config.Concurrency)
@@ -184,6 +195,7 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 			defer wg.Done()
 			sem <- struct{}{}
 			defer func() { <-sem }()
+			defer progress.Add(ctx, 1)
 
 			// Get issue to calculate original size
 			issue, err := c.store.GetIssue(ctx, issueID)
@@ -192,6 +204,11 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 				return
 			}
 
+			if err := refuseEncrypted(issue); err != nil {
+				results[idx] = BatchResult{IssueID: issueID, Err: err}
+				return
+			}
+
 			originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
 
 			err = c.CompactTier1(ctx, issueID)
@@ -218,3 +235,13 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 	wg.Wait()
 	return results, nil
 }
+
+// refuseEncrypted keeps encrypted issues away from the summarizer. The store
+// hands them back decrypted, so summarizing one would send its plaintext to
+// the API and write the summary back unencrypted.
+func refuseEncrypted(issue *types.Issue) error {
+	if issue.Encrypted {
+		return fmt.Errorf("issue %s is encrypted: compaction would store its content unencrypted", issue.ID)
+	}
+	return nil
+}
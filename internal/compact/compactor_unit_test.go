@@ -2,12 +2,14 @@ package compact
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"testing"
 
+	issuesummary "github.com/steveyegge/beads/internal/summary"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -149,6 +151,50 @@ func TestCompactTier1_Success(t *testing.T) {
 	}
 }
 
+func TestCompactTier1_UsesStoredSummary(t *testing.T) {
+	cleanup := withGitHash(t, "deadbeef\n")
+	t.Cleanup(cleanup)
+
+	withSummary := func(hash string) *types.Issue {
+		issue := stubIssue()
+		raw, _ := json.Marshal(map[string]interface{}{
+			issuesummary.MetadataKey: issuesummary.Summary{Text: "stored", Scope: issuesummary.ScopeIssue, SourceHash: hash},
+		})
+		issue.Metadata = raw
+		return issue
+	}
+	tests := []struct {
+		name  string
+		hash  string
+		want  string
+		calls int
+	}{
+		{"current", issuesummary.ContentHash(stubIssue()), "stored", 0},
+		{"stale", "0000000000000000", "short", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			store := &stubStore{
+				checkEligibilityFn: func(context.Context, string, int) (bool, string, error) { return true, "", nil },
+				getIssueFn:         func(context.Context, string) (*types.Issue, error) { return withSummary(tt.hash), nil },
+				updateIssueFn: func(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+					got = updates["description"].(string)
+					return nil
+				},
+			}
+			summary := &stubSummarizer{summary: "short"}
+			c := &Compactor{store: store, summarizer: summary, config: &Config{}}
+			if err := c.CompactTier1(context.Background(), "bd-123"); err != nil {
+				t.Fatalf("CompactTier1 unexpected error: %v", err)
+			}
+			if got != tt.want || summary.getCalls() != tt.calls {
+				t.Errorf("description = %q after %d summarizer calls, want %q after %d", got, summary.getCalls(), tt.want, tt.calls)
+			}
+		})
+	}
+}
+
 // TestCompactTier1_SnapshotBeforeOverwrite is the data-safety guard: the
 // pre-compaction snapshot must be taken BEFORE the destructive UpdateIssue, so
 // compaction is always reversible.
@@ -504,6 +550,62 @@ func TestCompactTier1_SummaryNotSmaller_CommentError(t *testing.T) {
 
 // --- CompactTier1Batch additional tests ---
 
+func TestCompactTier1_RefusesEncrypted(t *testing.T) {
+	store := &stubStore{
+		checkEligibilityFn: func(context.Context, string, int) (bool, string, error) { return true, "", nil },
+		getIssueFn: func(context.Context, string) (*types.Issue, error) {
+			issue := stubIssue()
+			issue.Encrypted = true
+			return issue, nil
+		},
+		updateIssueFn: func(context.Context, string, map[string]interface{}, string) error {
+			t.Fatal("encrypted issue should not be updated")
+			return nil
+		},
+	}
+	summary := &stubSummarizer{summary: "short"}
+	c := &Compactor{store: store, summarizer: summary, config: &Config{}}
+
+	err := c.CompactTier1(context.Background(), "bd-123")
+	if err == nil || !strings.Contains(err.Error(), "encrypted") {
+		t.Fatalf("expected encrypted refusal, got %v", err)
+	}
+	if summary.getCalls() != 0 {
+		t.Fatalf("summarizer should not see an encrypted issue; got %d calls", summary.getCalls())
+	}
+}
+
+func TestCompactTier1Batch_SkipsEncrypted(t *testing.T) {
+	cleanup := withGitHash(t, "cafebabe\n")
+	t.Cleanup(cleanup)
+
+	store := &stubStore{
+		checkEligibilityFn: func(context.Context, string, int) (bool, string, error) { return true, "", nil },
+		getIssueFn: func(ctx context.Context, id string) (*types.Issue, error) {
+			issue := stubIssue()
+			issue.ID = id
+			issue.Encrypted = id == "bd-2"
+			return issue, nil
+		},
+	}
+	summary := &stubSummarizer{summary: "short"}
+	c := &Compactor{store: store, summarizer: summary, config: &Config{Concurrency: 1}}
+
+	results, err := c.CompactTier1Batch(context.Background(), []string{"bd-1", "bd-2"})
+	if err != nil {
+		t.Fatalf("batch should not return top-level error: %v", err)
+	}
+	if results[0].Err != nil {
+		t.Fatalf("expected success for bd-1, got %v", results[0].Err)
+	}
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "encrypted") {
+		t.Fatalf("expected encrypted refusal for bd-2, got %+v", results[1])
+	}
+	if summary.getCalls() != 1 {
+		t.Fatalf("summarizer should run for bd-1 only; got %d calls", summary.getCalls())
+	}
+}
+
 func TestCompactTier1Batch_GetIssueError(t *testing.T) {
 	store := &stubStore{
 		getIssueFn: func(ctx context.Context, id string) (*types.Issue, error) {
@@ -170,12 +170,16 @@ func Initialize() error {
 
 	// Set defaults for all flags
 	v.SetDefault("json", false)
+	// Spinner/bar on a TTY and JSON progress events under --json for long
+	// operations such as import, export, and push.
+	v.SetDefault("progress", true)
 	v.SetDefault("events-export", false)
 	v.SetDefault("audit.enabled", false)
 	v.SetDefault("no-db", false)
 	v.SetDefault("no-hooks", false)
 	v.SetDefault("db", "")
 	v.SetDefault("actor", "")
+	v.SetDefault("rig", "")
 	v.SetDefault("issue-prefix", "")
 	// Additional environment variables (not prefixed with BD_)
 	_ = v.BindEnv("identity", "BEADS_IDENTITY") // BindEnv only fails with zero args, which can't happen here
@@ -185,6 +189,11 @@ func Initialize() error {
 	// Controls whether beads should automatically create Dolt commits after write commands.
 	// Values: off | on
 	v.SetDefault("dolt.auto-commit", "on")
+	// Batch-mode coalescing (dolt.auto-commit=batch): commit the accumulated
+	// writes once the last commit is this old or this many writes are
+	// pending. Zero leaves commits to bd dolt commit.
+	v.SetDefault("commit.batch_interval", "0")
+	v.SetDefault("commit.batch_max_ops", 0)
 
 	// Routing configuration defaults
 	v.SetDefault("routing.mode", "")
@@ -203,6 +212,7 @@ func Initialize() error {
 	v.SetDefault("federation.sovereignty", "")                     // T1 | T2 | T3 | T4 (empty = no restriction)
 	v.SetDefault("federation.allowed-remote-patterns", []string{}) // glob patterns restricting allowed remote URLs (enterprise lockdown)
 	v.SetDefault("federation.exclude_types", []string{"wisp"})     // issue types excluded from federation push (privacy filter)
+	v.SetDefault("federation.encrypt-peer-metadata", false)        // store new peers' user and URL encrypted (see bd federation encrypt-metadata)
 
 	// Push configuration defaults
 	v.SetDefault("no-push", false)
@@ -953,6 +963,55 @@ func MetadataSchemaFields() map[string]interface{} {
 	return nil
 }
 
+// MetadataNamespaces returns the raw metadata.namespaces registry entries.
+// Returns nil if config is not initialized or no namespaces are registered.
+// Each entry is a map of properties (name, description, fields, strict, export).
+func MetadataNamespaces() []interface{} {
+	if v == nil {
+		return nil
+	}
+	if entries, ok := v.Get("metadata.namespaces").([]interface{}); ok {
+		return entries
+	}
+	return nil
+}
+
+// EventKinds returns the raw events.kinds registry entries.
+// Returns nil if config is not initialized or no event kinds are registered.
+// Each entry is a map of properties (name, description, payload, strict,
+// require_actor, require_target).
+func EventKinds() []interface{} {
+	if v == nil {
+		return nil
+	}
+	if entries, ok := v.Get("events.kinds").([]interface{}); ok {
+		return entries
+	}
+	return nil
+}
+
+// EventsStrict reports whether events.strict is set, which rejects event
+// issues whose kind is not in the events.kinds registry.
+func EventsStrict() bool {
+	if v == nil {
+		return false
+	}
+	return v.GetBool("events.strict")
+}
+
+// DerivedFields returns the raw derived.fields definitions from config.
+// Returns nil if config is not initialized or no derived fields are defined.
+// Each entry maps field name → map of properties (kind, from, unit, targets, ...).
+func DerivedFields() map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	if m, ok := v.Get("derived.fields").(map[string]interface{}); ok {
+		return m
+	}
+	return nil
+}
+
 // DefaultAgentsFile is the default filename for agent instructions.
 const DefaultAgentsFile = "AGENTS.md"
 
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -22,13 +23,15 @@ import (
 // at startup, not from the database).
 var YamlOnlyKeys = map[string]bool{
 	// Bootstrap flags (affect how bd starts)
-	"no-db": true,
-	"json":  true,
+	"no-db":    true,
+	"json":     true,
+	"progress": true, // Progress output for long operations
 
 	// Database and identity
 	"db":       true,
 	"actor":    true,
 	"identity": true,
+	"rig":      true, // Default --rig scope for this clone (see bd rig)
 
 	// Git settings
 	"git.author":      true,
@@ -51,10 +54,11 @@ var YamlOnlyKeys = map[string]bool{
 	// Create command settings
 	"create.require-description": true,
 
-	// Prime memory-injection caps (read at session start, possibly before
+	// Prime memory/knowledge-injection caps (read at session start, possibly before
 	// the database is reachable, so they must live in yaml)
 	"prime.max-memories":     true,
 	"prime.max-memory-chars": true,
+	"prime.max-knowledge":    true,
 
 	// Validation settings (bd-t7jq)
 	// Values: "warn" | "error" | "none"
@@ -62,6 +66,18 @@ var YamlOnlyKeys = map[string]bool{
 	"validation.on-close":  true,
 	"validation.on-sync":   true,
 
+	// Reopens beyond this count require a root-cause note (bd reopen --root-cause)
+	"reopen.root-cause-threshold": true,
+
+	// Close reason taxonomy (comma-separated categories; see bd close --help)
+	"close.reasons": true,
+
+	// Quality scoring weights, e.g. "description:1,validations:2"
+	"quality.scorers": true,
+
+	// Cost budget enforcement on claims: "warn" | "block" | "none"
+	"cost.budget-mode": true,
+
 	// Hierarchy settings (GH#995)
 	"hierarchy.max-depth": true,
 
@@ -75,21 +91,35 @@ var YamlOnlyKeys = map[string]bool{
 	"import.auto": true,
 	"import.path": true,
 
+	// Batch auto-commit thresholds (read in PersistentPostRun of every write)
+	"commit.batch_interval": true,
+	"commit.batch_max_ops":  true,
+
 	// Dolt server settings
-	"dolt.shared-server": true, // Shared Dolt server at ~/.beads/shared-server/ (GH#2377)
-	"dolt.max-conns":     true, // Connection pool size override (default 10, GH#3140)
-	"dolt.debug":         true, // Debug-mode dolt sql-server: --loglevel=debug + --prof cpu
+	"dolt.shared-server":        true, // Shared Dolt server at ~/.beads/shared-server/ (GH#2377)
+	"dolt.max-conns":            true, // Connection pool size override (default 10, GH#3140)
+	"dolt.max-idle-conns":       true, // Idle pooled connections kept warm (default 5)
+	"dolt.conn-max-lifetime":    true, // How long a pooled connection is reused (default 1h)
+	"dolt.conn-max-idle-time":   true, // How long a connection may sit idle (default 20s)
+	"dolt.slow-query-threshold": true, // When bd status --pool counts a query as slow (default 1s)
+	"dolt.stmt-cache-size":      true, // Prepared statements cached for list/ready queries (default 64, 0 disables)
+	"dolt.retry-attempts":       true, // Attempts for push/pull/fetch on transient remote errors (default 3, 1 disables)
+	"dolt.retry-backoff":        true, // Wait before the first retry (default 2s, grows 2x with jitter)
+	"dolt.retry-max-backoff":    true, // Longest wait between retries (default 30s)
+	"dolt.debug":                true, // Debug-mode dolt sql-server: --loglevel=debug + --prof cpu
 
 	// Secrets: tokens and API keys must NOT be stored in the Dolt database
 	// because that data is pushed to remotes, triggering secret-scanning
 	// blocks on GitHub. Store them in local config.yaml instead.
-	"github.token":               true,
-	"linear.api_key":             true,
-	"linear.oauth_client_id":     true,
-	"linear.oauth_client_secret": true,
-	"jira.api_token":             true,
-	"gitlab.token":               true,
-	"ado.pat":                    true,
+	"github.token":                true,
+	"linear.api_key":              true,
+	"linear.oauth_client_id":      true,
+	"linear.oauth_client_secret":  true,
+	"jira.api_token":              true,
+	"gitlab.token":                true,
+	"ado.pat":                     true,
+	"slack.signing_secret":        true,
+	"alertmanager.webhook_secret": true,
 }
 
 // IsYamlOnlyKey returns true if the given key should be stored in config.yaml
@@ -101,7 +131,7 @@ func IsYamlOnlyKey(key string) bool {
 	}
 
 	// Check prefix matches for nested keys
-	prefixes := []string{"routing.", "sync.", "git.", "directory.", "repos.", "external_projects.", "validation.", "hierarchy.", "ai.", "backup.", "export.", "dolt.", "federation.", "metrics.", "list.", "audit."}
+	prefixes := []string{"routing.", "sync.", "git.", "directory.", "repos.", "external_projects.", "validation.", "hierarchy.", "ai.", "backup.", "export.", "dolt.", "federation.", "metrics.", "list.", "audit.", "lanes.", "assign.", "notify.", "review.", "confirm.", "gantt.", "capacity.", "gate.", "metadata.", "derived.", "summarize.", "events.", "slug.", "views."}
 	for _, prefix := range prefixes {
 		if strings.HasPrefix(key, prefix) {
 			return true
@@ -817,6 +847,22 @@ func validateYamlConfigValue(key, value string) error {
 		if lower != "true" && lower != "false" {
 			return fmt.Errorf("dolt.debug must be \"true\" or \"false\", got %q", value)
 		}
+	case "dolt.max-conns", "dolt.max-idle-conns", "dolt.retry-attempts":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("%s must be a positive integer, got %q", key, value)
+		}
+	case "dolt.stmt-cache-size":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("dolt.stmt-cache-size must be a non-negative integer (0 disables the cache), got %q", value)
+		}
+	case "dolt.conn-max-lifetime", "dolt.conn-max-idle-time", "dolt.slow-query-threshold",
+		"dolt.retry-backoff", "dolt.retry-max-backoff":
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("%s must be a positive duration such as 30s or 5m, got %q", key, value)
+		}
 	case "dolt.mode":
 		lower := strings.ToLower(value)
 		if lower != "server" && lower != "embedded" {
@@ -21,13 +21,9 @@ func (m *gitlabFieldMapper) PriorityToBeads(trackerPriority interface{}) int {
 }
 
 func (m *gitlabFieldMapper) PriorityToTracker(beadsPriority int) interface{} {
-	// Inverse lookup: find the label for this priority
-	for label, p := range m.config.PriorityMap {
-		if p == beadsPriority {
-			return label
-		}
-	}
-	return "medium"
+	// Custom mappings can give a priority several labels; push the default
+	// label so the result is deterministic.
+	return priorityToLabel(beadsPriority)
 }
 
 func (m *gitlabFieldMapper) StatusToBeads(trackerState interface{}) types.Status {
@@ -84,6 +80,9 @@ func (m *gitlabFieldMapper) IssueToBeads(ti *tracker.TrackerIssue) *tracker.Issu
 	for _, d := range conv.Dependencies {
 		deps = append(deps, trackerDependencyFromGitLab(d))
 	}
+	if dep, ok := milestoneParentDependency(gl); ok {
+		deps = append(deps, dep)
+	}
 
 	return &tracker.IssueConversion{
 		Issue:        conv.Issue,
@@ -401,3 +401,21 @@ func trackerDependencyFromGitLab(dep DependencyInfo) tracker.DependencyInfo {
 		Source:         tracker.DependencySourceRelation,
 	}
 }
+
+// milestoneParentDependency links a pulled issue to the epic its project
+// milestone represents, mirroring PushEpicMilestones: epics push as
+// milestones, so an issue in a milestone is a child of that epic. The target
+// resolves only when the epic exists locally with the milestone as its
+// external ref. Group milestones have no epic counterpart and are ignored.
+func milestoneParentDependency(gl *Issue) (tracker.DependencyInfo, bool) {
+	ms := gl.Milestone
+	if ms == nil || ms.IID <= 0 || ms.GroupID != 0 {
+		return tracker.DependencyInfo{}, false
+	}
+	return tracker.DependencyInfo{
+		FromExternalID: strconv.Itoa(gl.IID),
+		ToExternalID:   gitLabMilestoneIdentifierPrefix + strconv.Itoa(ms.IID),
+		Type:           string(types.DepParentChild),
+		Source:         tracker.DependencySourceParent,
+	}, true
+}
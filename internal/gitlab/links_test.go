@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/tracker"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -473,3 +474,28 @@ func gitLabDep(id, ref string, depType types.DependencyType) *types.IssueWithDep
 		DependencyType: depType,
 	}
 }
+
+func TestMilestoneParentDependency(t *testing.T) {
+	gl := &Issue{IID: 7, Milestone: &Milestone{ID: 900, IID: 3}}
+	dep, ok := milestoneParentDependency(gl)
+	if !ok {
+		t.Fatal("expected a parent dependency for a project milestone")
+	}
+	if dep.FromExternalID != "7" || dep.ToExternalID != "milestone:3" ||
+		dep.Type != string(types.DepParentChild) || dep.Source != tracker.DependencySourceParent {
+		t.Errorf("dep = %+v", dep)
+	}
+
+	if _, ok := milestoneParentDependency(&Issue{IID: 7}); ok {
+		t.Error("issue without a milestone should have no parent")
+	}
+	if _, ok := milestoneParentDependency(&Issue{IID: 7, Milestone: &Milestone{IID: 3, GroupID: 5}}); ok {
+		t.Error("group milestones have no epic counterpart")
+	}
+
+	tr := &Tracker{}
+	epicRef := "https://gitlab.com/g/p/-/milestones/3"
+	if got := tr.ExtractIdentifier(epicRef); got != dep.ToExternalID {
+		t.Errorf("epic ref identifier %q does not match dependency target %q", got, dep.ToExternalID)
+	}
+}
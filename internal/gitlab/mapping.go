@@ -3,6 +3,7 @@ package gitlab
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -43,6 +44,38 @@ func DefaultMappingConfig() *MappingConfig {
 	}
 }
 
+// Config key prefixes for custom label mappings. Each key names a label
+// value and its value is the beads priority (0-4) or issue type, e.g.
+// gitlab.priority_map.urgent=0 maps priority::urgent to P0, and
+// gitlab.type_map.story=feature maps type::story (or a bare "story" label)
+// to a feature. Custom entries extend the defaults, which stay in effect for
+// labels that are not overridden; pushes still write the default labels.
+const (
+	priorityMapConfigPrefix = "gitlab.priority_map."
+	typeMapConfigPrefix     = "gitlab.type_map."
+)
+
+// ApplyConfig layers the gitlab.priority_map.* and gitlab.type_map.* entries
+// of allConfig over the mapping.
+func (c *MappingConfig) ApplyConfig(allConfig map[string]string) error {
+	for key, val := range allConfig {
+		val = strings.TrimSpace(val)
+		switch {
+		case val == "":
+			continue
+		case strings.HasPrefix(key, priorityMapConfigPrefix):
+			p, err := strconv.Atoi(val)
+			if err != nil || p < 0 || p > 4 {
+				return fmt.Errorf("%s: invalid priority %q (must be 0-4)", key, val)
+			}
+			c.PriorityMap[strings.ToLower(strings.TrimPrefix(key, priorityMapConfigPrefix))] = p
+		case strings.HasPrefix(key, typeMapConfigPrefix):
+			c.LabelTypeMap[strings.ToLower(strings.TrimPrefix(key, typeMapConfigPrefix))] = val
+		}
+	}
+	return nil
+}
+
 // priorityFromLabels extracts priority from GitLab labels.
 // Returns default priority (2 = medium) if no priority label found.
 func priorityFromLabels(labels []string, config *MappingConfig) int {
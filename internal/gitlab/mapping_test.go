@@ -650,3 +650,29 @@ func TestMappingConfigUsesTypesConstants(t *testing.T) {
 		t.Errorf("LabelTypeMap[enhancement] = %q, want %q (from typeMapping)", typ, typeMapping["enhancement"])
 	}
 }
+
+// TestMappingConfigApplyConfig verifies custom label mappings extend the defaults.
+func TestMappingConfigApplyConfig(t *testing.T) {
+	config := DefaultMappingConfig()
+	err := config.ApplyConfig(map[string]string{
+		"gitlab.priority_map.Urgent": "0",
+		"gitlab.type_map.story":      "feature",
+		"gitlab.project_id":          "42",
+	})
+	if err != nil {
+		t.Fatalf("ApplyConfig: %v", err)
+	}
+	if p := priorityFromLabels([]string{"priority::urgent"}, config); p != 0 {
+		t.Errorf("priority::urgent = %d, want 0", p)
+	}
+	if p := priorityFromLabels([]string{"priority::high"}, config); p != 1 {
+		t.Errorf("default priority::high = %d, want 1", p)
+	}
+	if typ := typeFromLabels([]string{"story"}, config); typ != "feature" {
+		t.Errorf("story label = %q, want feature", typ)
+	}
+
+	if err := DefaultMappingConfig().ApplyConfig(map[string]string{"gitlab.priority_map.x": "9"}); err == nil {
+		t.Error("out-of-range priority should be rejected")
+	}
+}
@@ -84,6 +84,11 @@ func (t *Tracker) Init(ctx context.Context, store storage.Storage) error {
 		t.client = t.client.WithGroupID(groupID)
 	}
 	t.config = DefaultMappingConfig()
+	if allConfig, err := store.GetAllConfig(ctx); err == nil {
+		if err := t.config.ApplyConfig(allConfig); err != nil {
+			return err
+		}
+	}
 
 	// Load project path for GraphQL (e.g., "socwave/socwave")
 	t.projectPath, _ = t.getConfig(ctx, "gitlab.project_path", "GITLAB_PROJECT_PATH")
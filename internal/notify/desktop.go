@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// AppName is shown as the source of every notification.
+const AppName = "beads"
+
+// Sender delivers a notification.
+type Sender interface {
+	Send(title, body string) error
+}
+
+// Desktop returns a Sender for the current platform: osascript on macOS,
+// notify-send on Linux, and a PowerShell balloon tip on Windows and WSL.
+func Desktop() Sender {
+	return &desktopSender{goos: runtime.GOOS, wsl: IsWSL()}
+}
+
+type desktopSender struct {
+	goos string
+	wsl  bool
+}
+
+func (d *desktopSender) Send(title, body string) error {
+	name, args, err := DesktopCommand(d.goos, d.wsl, title, body)
+	if err != nil {
+		return err
+	}
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("%s not found; desktop notifications need it on this platform", name)
+	}
+	cmd := exec.Command(name, args...) //nolint:gosec // G204: fixed program, arguments are escaped
+	if name == "powershell.exe" {
+		// The balloon tip script stays alive while the tip is shown; don't
+		// hold up the caller for it.
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		go func() { _ = cmd.Wait() }()
+		return nil
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %v: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// DesktopCommand returns the command that shows a notification on goos.
+// wsl selects the Windows host's notifier from inside WSL.
+func DesktopCommand(goos string, wsl bool, title, body string) (string, []string, error) {
+	switch {
+	case goos == "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(AppName+": "+title))
+		return "osascript", []string{"-e", script}, nil
+	case goos == "windows" || wsl:
+		name := "powershell.exe"
+		script := strings.Join([]string{
+			"Add-Type -AssemblyName System.Windows.Forms",
+			"$n = New-Object System.Windows.Forms.NotifyIcon",
+			"$n.Icon = [System.Drawing.SystemIcons]::Information",
+			"$n.Visible = $true",
+			fmt.Sprintf("$n.ShowBalloonTip(10000, %s, %s, 'Info')", powerShellString(AppName+": "+title), powerShellString(body)),
+			"Start-Sleep -Seconds 10",
+			"$n.Dispose()",
+		}, "; ")
+		return name, []string{"-NoProfile", "-NonInteractive", "-Command", script}, nil
+	case goos == "linux" || strings.HasSuffix(goos, "bsd"):
+		return "notify-send", []string{"--app-name=" + AppName, title, body}, nil
+	}
+	return "", nil, fmt.Errorf("desktop notifications are not supported on %s", goos)
+}
+
+// IsWSL reports whether bd runs under the Windows Subsystem for Linux.
+func IsWSL() bool {
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+	release, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	return err == nil && strings.Contains(strings.ToLower(string(release)), "microsoft")
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// powerShellString quotes s as a single-quoted (verbatim) PowerShell string.
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
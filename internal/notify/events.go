@@ -0,0 +1,135 @@
+// Package notify turns changes in the issue database into desktop
+// notifications.
+//
+// bd notify polls the database the same way bd list --watch does and keeps a
+// Snapshot of the issues one person cares about: ready work assigned to them,
+// the blockers of their open issues, and open P0s. Diff compares two
+// snapshots and reports what changed as Events, which are delivered through
+// the platform's notification mechanism unless they fall in quiet hours.
+package notify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kind is a category of notification. Kinds can be enabled individually.
+type Kind string
+
+// Notification kinds.
+const (
+	KindReady         Kind = "ready"    // an issue assigned to me became ready
+	KindBlockerClosed Kind = "blockers" // a blocker of one of my issues closed
+	KindP0            Kind = "p0"       // a new P0 issue appeared
+)
+
+// AllKinds lists every kind, in the order they are reported.
+var AllKinds = []Kind{KindReady, KindBlockerClosed, KindP0}
+
+// ParseKinds parses a comma-separated list of kinds, such as "ready,p0".
+// An empty list enables every kind.
+func ParseKinds(spec string) (map[Kind]bool, error) {
+	kinds := map[Kind]bool{}
+	for _, part := range strings.Split(spec, ",") {
+		k := Kind(strings.ToLower(strings.TrimSpace(part)))
+		if k == "" {
+			continue
+		}
+		valid := false
+		for _, known := range AllKinds {
+			valid = valid || k == known
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown notification kind %q (valid: ready, blockers, p0)", k)
+		}
+		kinds[k] = true
+	}
+	if len(kinds) == 0 {
+		for _, k := range AllKinds {
+			kinds[k] = true
+		}
+	}
+	return kinds, nil
+}
+
+// Blocker is an issue that blocks at least one of the watched person's open
+// issues.
+type Blocker struct {
+	Title  string
+	Closed bool
+	Blocks []string // IDs of the watched issues it blocks
+}
+
+// Snapshot is the state notifications are computed from.
+type Snapshot struct {
+	Ready    map[string]string   // ready issues assigned to me: ID → title
+	Blockers map[string]*Blocker // blockers of my open issues, by blocker ID
+	P0       map[string]string   // open P0 issues: ID → title
+}
+
+// NewSnapshot returns an empty snapshot.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{Ready: map[string]string{}, Blockers: map[string]*Blocker{}, P0: map[string]string{}}
+}
+
+// Event is one notification.
+type Event struct {
+	Kind    Kind   `json:"kind"`
+	IssueID string `json:"issue_id"`
+	Title   string `json:"title"` // notification title
+	Body    string `json:"body"`  // notification text
+}
+
+// Diff reports what changed between two snapshots. A nil prev is the first
+// poll, which only establishes the baseline and never notifies, so starting
+// bd notify does not replay everything that is already true.
+func Diff(prev, cur *Snapshot) []Event {
+	if prev == nil || cur == nil {
+		return nil
+	}
+	var events []Event
+	for _, id := range sortedKeys(cur.Ready) {
+		if _, seen := prev.Ready[id]; !seen {
+			events = append(events, Event{
+				Kind:    KindReady,
+				IssueID: id,
+				Title:   "Ready: " + id,
+				Body:    cur.Ready[id],
+			})
+		}
+	}
+	for _, id := range sortedKeys(cur.Blockers) {
+		b := cur.Blockers[id]
+		was := prev.Blockers[id]
+		if !b.Closed || was == nil || was.Closed {
+			continue
+		}
+		events = append(events, Event{
+			Kind:    KindBlockerClosed,
+			IssueID: id,
+			Title:   "Blocker closed: " + id,
+			Body:    fmt.Sprintf("%s (was blocking %s)", b.Title, strings.Join(b.Blocks, ", ")),
+		})
+	}
+	for _, id := range sortedKeys(cur.P0) {
+		if _, seen := prev.P0[id]; !seen {
+			events = append(events, Event{
+				Kind:    KindP0,
+				IssueID: id,
+				Title:   "New P0: " + id,
+				Body:    cur.P0[id],
+			})
+		}
+	}
+	return events
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,117 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	prev := NewSnapshot()
+	prev.Ready["bd-1"] = "Already ready"
+	prev.Blockers["bd-9"] = &Blocker{Title: "Schema migration", Blocks: []string{"bd-2"}}
+	prev.Blockers["bd-8"] = &Blocker{Title: "Old blocker", Closed: true, Blocks: []string{"bd-2"}}
+	prev.P0["bd-5"] = "Known outage"
+
+	cur := NewSnapshot()
+	cur.Ready["bd-1"] = "Already ready"
+	cur.Ready["bd-2"] = "Newly ready"
+	cur.Blockers["bd-9"] = &Blocker{Title: "Schema migration", Closed: true, Blocks: []string{"bd-2", "bd-3"}}
+	cur.Blockers["bd-8"] = &Blocker{Title: "Old blocker", Closed: true, Blocks: []string{"bd-2"}}
+	cur.Blockers["bd-7"] = &Blocker{Title: "New closed dependency", Closed: true, Blocks: []string{"bd-4"}}
+	cur.P0["bd-5"] = "Known outage"
+	cur.P0["bd-6"] = "Site down"
+
+	events := Diff(prev, cur)
+	var got []string
+	for _, e := range events {
+		got = append(got, string(e.Kind)+" "+e.IssueID)
+	}
+	want := "ready bd-2,blockers bd-9,p0 bd-6"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("Diff = %v, want %s", got, want)
+	}
+	if events[1].Body != "Schema migration (was blocking bd-2, bd-3)" {
+		t.Errorf("blocker body = %q", events[1].Body)
+	}
+
+	if events := Diff(nil, cur); len(events) != 0 {
+		t.Errorf("first poll should not notify, got %v", events)
+	}
+}
+
+func TestParseKinds(t *testing.T) {
+	all, err := ParseKinds("")
+	if err != nil || len(all) != len(AllKinds) {
+		t.Fatalf("ParseKinds(\"\") = %v, %v", all, err)
+	}
+	some, err := ParseKinds(" Ready , p0")
+	if err != nil || !some[KindReady] || !some[KindP0] || some[KindBlockerClosed] {
+		t.Errorf("ParseKinds = %v, %v", some, err)
+	}
+	if _, err := ParseKinds("ready,mentions"); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
+
+func TestQuietHours(t *testing.T) {
+	at := func(clock string) time.Time {
+		tm, _ := time.Parse("15:04", clock)
+		return time.Date(2024, 5, 1, tm.Hour(), tm.Minute(), 0, 0, time.Local)
+	}
+
+	overnight, err := ParseQuietHours("22:00-07:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for clock, want := range map[string]bool{"21:59": false, "22:00": true, "03:00": true, "06:59": true, "07:00": false, "12:00": false} {
+		if got := overnight.Contains(at(clock)); got != want {
+			t.Errorf("22:00-07:00 contains %s = %v, want %v", clock, got, want)
+		}
+	}
+	if overnight.String() != "22:00-07:00" {
+		t.Errorf("String() = %q", overnight.String())
+	}
+
+	lunch, err := ParseQuietHours("12:00-13:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lunch.Contains(at("13:29")) || lunch.Contains(at("13:30")) || lunch.Contains(at("11:59")) {
+		t.Error("daytime window boundaries wrong")
+	}
+
+	none, err := ParseQuietHours("")
+	if err != nil || none != nil || none.Contains(at("03:00")) {
+		t.Errorf("empty quiet hours = %v, %v", none, err)
+	}
+	for _, bad := range []string{"22:00", "25:00-07:00", "22:00-22:00", "late-early"} {
+		if _, err := ParseQuietHours(bad); err == nil {
+			t.Errorf("ParseQuietHours(%q) accepted", bad)
+		}
+	}
+}
+
+func TestDesktopCommand(t *testing.T) {
+	name, args, err := DesktopCommand("darwin", false, `Ready: bd-1`, `Fix "quoted" \ title`)
+	if err != nil || name != "osascript" {
+		t.Fatalf("darwin: %s %v %v", name, args, err)
+	}
+	if want := `display notification "Fix \"quoted\" \\ title" with title "beads: Ready: bd-1"`; args[1] != want {
+		t.Errorf("darwin script = %s\nwant %s", args[1], want)
+	}
+
+	name, args, _ = DesktopCommand("linux", false, "New P0: bd-6", "Site down")
+	if name != "notify-send" || strings.Join(args, "|") != "--app-name=beads|New P0: bd-6|Site down" {
+		t.Errorf("linux: %s %v", name, args)
+	}
+
+	name, args, _ = DesktopCommand("linux", true, "Ready: bd-1", "It's ready")
+	if name != "powershell.exe" || !strings.Contains(args[len(args)-1], "'It''s ready'") {
+		t.Errorf("wsl: %s %v", name, args)
+	}
+
+	if _, _, err := DesktopCommand("plan9", false, "t", "b"); err == nil {
+		t.Error("expected an error for an unsupported platform")
+	}
+}
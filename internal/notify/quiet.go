@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QuietHours is a daily window, in local time, during which notifications
+// are held back. The window may wrap past midnight ("22:00-07:00").
+type QuietHours struct {
+	Start time.Duration // offset from midnight
+	End   time.Duration
+}
+
+// ParseQuietHours parses an "HH:MM-HH:MM" window. An empty spec means no
+// quiet hours and returns nil.
+func ParseQuietHours(spec string) (*QuietHours, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	from, to, ok := strings.Cut(spec, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid quiet hours %q (want HH:MM-HH:MM)", spec)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet hours %q: %w", spec, err)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet hours %q: %w", spec, err)
+	}
+	if start == end {
+		return nil, fmt.Errorf("invalid quiet hours %q: start and end are the same", spec)
+	}
+	return &QuietHours{Start: start, End: end}, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", strings.TrimSpace(s))
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t falls inside the quiet window. A nil window
+// never does.
+func (q *QuietHours) Contains(t time.Time) bool {
+	if q == nil {
+		return false
+	}
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if q.Start < q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	return offset >= q.Start || offset < q.End
+}
+
+// String formats the window as HH:MM-HH:MM.
+func (q *QuietHours) String() string {
+	clock := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+	}
+	return clock(q.Start) + "-" + clock(q.End)
+}
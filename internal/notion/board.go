@@ -0,0 +1,347 @@
+package notion
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// PropertiesMetadataKey holds the database properties an imported board row
+// had that map to no issue field, so nothing in the export is dropped.
+const PropertiesMetadataKey = "notion_properties"
+
+// Well-known property names of ad-hoc Notion databases, matched
+// case-insensitively. The first present column wins.
+var (
+	boardTitleColumns       = []string{"Name", "Title", "Task name", "Task"}
+	boardStatusColumns      = []string{"Status", "Stage", "State"}
+	boardLabelColumns       = []string{"Tags", "Labels", "Label"}
+	boardAssigneeColumns    = []string{"Assignee", "Assignees", "Assigned to", "Assign", "Owner"}
+	boardPriorityColumns    = []string{"Priority"}
+	boardTypeColumns        = []string{"Type", "Issue type"}
+	boardDescriptionColumns = []string{"Description", "Summary"}
+	boardDueColumns         = []string{"Due", "Due date", "Deadline"}
+	boardCreatedColumns     = []string{"Created", "Created time", "Date created"}
+	boardUpdatedColumns     = []string{"Last edited time", "Last edited", "Updated"}
+)
+
+// boardDateLayouts are the date formats Notion uses in CSV exports, plus
+// ISO dates.
+var boardDateLayouts = []string{
+	time.RFC3339,
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006 15:04",
+	"January 2, 2006",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+var boardPriorityPattern = regexp.MustCompile(`^p([0-4])$`)
+
+// BoardRow is one row of an ad-hoc Notion database: its property values as
+// text, in column order, plus what is known about the page it came from.
+type BoardRow struct {
+	Columns     []string
+	Values      map[string]string
+	Checkboxes  map[string]bool // checkbox properties and whether each is checked
+	ExternalRef string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// BoardMappingConfig configures how rows of an ad-hoc Notion database (one
+// not created by 'bd notion init') map to beads issues.
+type BoardMappingConfig struct {
+	TitleColumn  string                  // column holding the title (default: Name, Title, ..., else the first column)
+	StatusColumn string                  // column holding the status (default: Status, Stage, State)
+	StatusMap    map[string]types.Status // status option (lowercased) → beads status, over the guess
+}
+
+// ReadBoardCSV reads a Notion database CSV export ("Export → Markdown & CSV").
+// Columns whose values are all "Yes" or "No" are treated as checkboxes.
+func ReadBoardCSV(r io.Reader) ([]BoardRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading Notion CSV export: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty Notion CSV export")
+	}
+	header := make([]string, len(records[0]))
+	for i, h := range records[0] {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+	}
+
+	rows := make([]BoardRow, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		row := BoardRow{Columns: header, Values: make(map[string]string, len(header))}
+		empty := true
+		for i, col := range header {
+			if i < len(rec) {
+				row.Values[col] = strings.TrimSpace(rec[i])
+				empty = empty && row.Values[col] == ""
+			}
+		}
+		if !empty {
+			rows = append(rows, row)
+		}
+	}
+
+	for _, col := range header {
+		if !isCheckboxColumn(rows, col) {
+			continue
+		}
+		for i := range rows {
+			if rows[i].Checkboxes == nil {
+				rows[i].Checkboxes = map[string]bool{}
+			}
+			rows[i].Checkboxes[col] = strings.EqualFold(rows[i].Values[col], "yes")
+			delete(rows[i].Values, col)
+		}
+	}
+	return rows, nil
+}
+
+func isCheckboxColumn(rows []BoardRow, col string) bool {
+	if len(rows) == 0 {
+		return false
+	}
+	for _, row := range rows {
+		v := strings.ToLower(row.Values[col])
+		if v != "yes" && v != "no" {
+			return false
+		}
+	}
+	return true
+}
+
+// BoardRowFromPage flattens a page of an ad-hoc Notion database into a row.
+func BoardRowFromPage(page Page) BoardRow {
+	row := BoardRow{
+		Values:    make(map[string]string, len(page.Properties)),
+		CreatedAt: page.CreatedTime.UTC(),
+		UpdatedAt: page.LastEditedTime.UTC(),
+	}
+	if ref, ok := CanonicalizeNotionPageURL(page.URL); ok {
+		row.ExternalRef = ref
+	} else if ref, ok := CanonicalizeNotionPageURL(page.ID); ok {
+		row.ExternalRef = ref
+	}
+	for name, prop := range page.Properties {
+		row.Columns = append(row.Columns, name)
+		if prop.Type == "checkbox" {
+			if row.Checkboxes == nil {
+				row.Checkboxes = map[string]bool{}
+			}
+			row.Checkboxes[name] = prop.Checkbox
+			continue
+		}
+		row.Values[name] = pagePropertyText(prop)
+	}
+	// Put the title property first so it is the fallback title column.
+	sort.SliceStable(row.Columns, func(i, j int) bool {
+		ti, tj := page.Properties[row.Columns[i]].Type == "title", page.Properties[row.Columns[j]].Type == "title"
+		if ti != tj {
+			return ti
+		}
+		return row.Columns[i] < row.Columns[j]
+	})
+	return row
+}
+
+// pagePropertyText renders a property value the way a CSV export would.
+func pagePropertyText(prop PageProperty) string {
+	switch {
+	case len(prop.Title) > 0:
+		return DataSourceTitle(prop.Title)
+	case len(prop.RichText) > 0:
+		return DataSourceTitle(prop.RichText)
+	case prop.Select != nil:
+		return prop.Select.Name
+	case prop.Status != nil:
+		return prop.Status.Name
+	case len(prop.MultiSelect) > 0:
+		return strings.Join(pagePropertyMultiSelect(prop), ", ")
+	case len(prop.People) > 0:
+		names := make([]string, 0, len(prop.People))
+		for _, p := range prop.People {
+			if p.Name != "" {
+				names = append(names, p.Name)
+			}
+		}
+		return strings.Join(names, ", ")
+	case prop.Date != nil:
+		return prop.Date.Start
+	}
+	return ""
+}
+
+// ToIssue converts a board row into a beads issue. Status options map
+// through the config's StatusMap, then by name (see tracker.ColumnStatus).
+// Checkbox properties become a markdown task list in the acceptance
+// criteria.
+func (c *BoardMappingConfig) ToIssue(row BoardRow) *types.Issue {
+	used := map[string]bool{}
+	pick := func(override string, names []string) string {
+		col := findBoardColumn(row.Columns, override, names)
+		if col == "" {
+			return ""
+		}
+		used[col] = true
+		return row.Values[col]
+	}
+
+	title := pick(c.TitleColumn, boardTitleColumns)
+	if title == "" && c.TitleColumn == "" {
+		for _, col := range row.Columns {
+			if _, isBox := row.Checkboxes[col]; !isBox && !used[col] {
+				used[col] = true
+				title = row.Values[col]
+				break
+			}
+		}
+	}
+	if title == "" {
+		title = "Untitled"
+	}
+
+	issue := &types.Issue{
+		Title:        title,
+		Description:  pick("", boardDescriptionColumns),
+		Status:       types.StatusOpen,
+		Priority:     2,
+		IssueType:    types.TypeTask,
+		SourceSystem: "notion",
+	}
+	if status := pick(c.StatusColumn, boardStatusColumns); status != "" {
+		issue.Status = tracker.ColumnStatus(status, c.StatusMap)
+	}
+	if labels := pick("", boardLabelColumns); labels != "" {
+		issue.Labels = splitBoardList(labels)
+	}
+	if assignees := splitBoardList(pick("", boardAssigneeColumns)); len(assignees) > 0 {
+		issue.Assignee = assignees[0]
+	}
+	if p := strings.ToLower(pick("", boardPriorityColumns)); p != "" {
+		issue.Priority = boardPriority(p)
+	}
+	if t := pick("", boardTypeColumns); t != "" {
+		issue.IssueType = typeToBeads(t, DefaultMappingConfig())
+	}
+	if due, ok := parseBoardDate(pick("", boardDueColumns)); ok {
+		issue.DueAt = &due
+	}
+	if row.ExternalRef != "" {
+		ref := row.ExternalRef
+		issue.ExternalRef = &ref
+	}
+
+	now := time.Now().UTC()
+	issue.CreatedAt = row.CreatedAt
+	if created, ok := parseBoardDate(pick("", boardCreatedColumns)); ok && issue.CreatedAt.IsZero() {
+		issue.CreatedAt = created
+	}
+	if issue.CreatedAt.IsZero() {
+		issue.CreatedAt = now
+	}
+	issue.UpdatedAt = row.UpdatedAt
+	if updated, ok := parseBoardDate(pick("", boardUpdatedColumns)); ok && issue.UpdatedAt.IsZero() {
+		issue.UpdatedAt = updated
+	}
+	if issue.UpdatedAt.Before(issue.CreatedAt) {
+		issue.UpdatedAt = issue.CreatedAt
+	}
+	if issue.Status == types.StatusClosed {
+		closed := issue.UpdatedAt
+		issue.ClosedAt = &closed
+	}
+
+	var checklist strings.Builder
+	extra := map[string]string{}
+	for _, col := range row.Columns {
+		if checked, ok := row.Checkboxes[col]; ok {
+			box := "[ ]"
+			if checked {
+				box = "[x]"
+			}
+			fmt.Fprintf(&checklist, "- %s %s\n", box, col)
+			continue
+		}
+		if !used[col] && row.Values[col] != "" {
+			extra[col] = row.Values[col]
+		}
+	}
+	issue.AcceptanceCriteria = strings.TrimRight(checklist.String(), "\n")
+	if len(extra) > 0 {
+		if meta, err := json.Marshal(map[string]map[string]string{PropertiesMetadataKey: extra}); err == nil {
+			issue.Metadata = meta
+		}
+	}
+	return issue
+}
+
+// findBoardColumn returns the row's column named override, or else the first
+// of names present, matching case-insensitively.
+func findBoardColumn(columns []string, override string, names []string) string {
+	if override != "" {
+		names = []string{override}
+	}
+	for _, name := range names {
+		for _, col := range columns {
+			if strings.EqualFold(col, name) {
+				return col
+			}
+		}
+	}
+	return ""
+}
+
+func splitBoardList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// boardPriority maps a priority option such as "High" or "P1" to a beads
+// priority, defaulting to 2.
+func boardPriority(raw string) int {
+	if m := boardPriorityPattern.FindStringSubmatch(strings.TrimSpace(raw)); m != nil {
+		return int(m[1][0] - '0')
+	}
+	if raw == "urgent" {
+		return 0
+	}
+	return priorityToBeads(raw, DefaultMappingConfig())
+}
+
+func parseBoardDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	// Date ranges export as "start → end"; the start is the date.
+	if start, _, ok := strings.Cut(s, "→"); ok {
+		s = strings.TrimSpace(start)
+	}
+	for _, layout := range boardDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,112 @@
+package notion
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestReadBoardCSV(t *testing.T) {
+	csvData := "\ufeffName,Status,Tags,Assignee,Priority,Reviewed,Sprint,Created\n" +
+		"Login page,In progress,\"frontend, auth\",\"Bob, Carol\",High,Yes,S1,\"March 3, 2024 2:15 PM\"\n" +
+		"DB migration,Done,backend,,P0,No,S1,\"March 4, 2024 9:00 AM\"\n" +
+		",,,,,,,\n"
+	rows, err := ReadBoardCSV(strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("ReadBoardCSV() error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("ReadBoardCSV() returned %d rows, want 2 (blank row skipped)", len(rows))
+	}
+	if !rows[0].Checkboxes["Reviewed"] || rows[1].Checkboxes["Reviewed"] {
+		t.Errorf("Reviewed checkboxes = %v, %v", rows[0].Checkboxes, rows[1].Checkboxes)
+	}
+
+	cfg := &BoardMappingConfig{}
+	login := cfg.ToIssue(rows[0])
+	if login.Title != "Login page" || login.Status != types.StatusInProgress || login.Priority != 1 {
+		t.Errorf("login = %+v", login)
+	}
+	if login.Assignee != "Bob" || strings.Join(login.Labels, ",") != "frontend,auth" {
+		t.Errorf("login assignee %q labels %v", login.Assignee, login.Labels)
+	}
+	if login.AcceptanceCriteria != "- [x] Reviewed" {
+		t.Errorf("login.AcceptanceCriteria = %q", login.AcceptanceCriteria)
+	}
+	if string(login.Metadata) != `{"notion_properties":{"Sprint":"S1"}}` {
+		t.Errorf("login.Metadata = %s", login.Metadata)
+	}
+	if want := time.Date(2024, 3, 3, 14, 15, 0, 0, time.UTC); !login.CreatedAt.Equal(want) {
+		t.Errorf("login.CreatedAt = %v, want %v", login.CreatedAt, want)
+	}
+
+	migration := cfg.ToIssue(rows[1])
+	if migration.Status != types.StatusClosed || migration.ClosedAt == nil || migration.Priority != 0 {
+		t.Errorf("migration = %+v", migration)
+	}
+	for _, issue := range []*types.Issue{login, migration} {
+		if err := issue.Validate(); err != nil {
+			t.Errorf("%s: Validate() error: %v", issue.Title, err)
+		}
+	}
+}
+
+func TestBoardMappingConfigColumnsAndStatusMap(t *testing.T) {
+	rows, err := ReadBoardCSV(strings.NewReader("Ref,Summary line,Phase\nA-1,Fix it,Parked\n"))
+	if err != nil {
+		t.Fatalf("ReadBoardCSV() error: %v", err)
+	}
+	cfg := &BoardMappingConfig{
+		TitleColumn:  "summary line",
+		StatusColumn: "Phase",
+		StatusMap:    map[string]types.Status{"parked": types.StatusDeferred},
+	}
+	issue := cfg.ToIssue(rows[0])
+	if issue.Title != "Fix it" || issue.Status != types.StatusDeferred {
+		t.Errorf("issue title %q status %s", issue.Title, issue.Status)
+	}
+
+	// Without a title column, the first column is the title.
+	issue = (&BoardMappingConfig{}).ToIssue(rows[0])
+	if issue.Title != "A-1" {
+		t.Errorf("fallback title = %q, want first column", issue.Title)
+	}
+}
+
+func TestBoardRowFromPage(t *testing.T) {
+	page := Page{
+		ID:             "2f1c0000-0000-0000-0000-000000000001",
+		URL:            "https://www.notion.so/Login-2f1c0000000000000000000000000001",
+		CreatedTime:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		LastEditedTime: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		Properties: map[string]PageProperty{
+			"Task":     {Type: "title", Title: []RichText{{PlainText: "Login page"}}},
+			"Stage":    {Type: "status", Status: &SelectOption{Name: "Done"}},
+			"Owner":    {Type: "people", People: []User{{Name: "Bob"}}},
+			"Reviewed": {Type: "checkbox", Checkbox: true},
+			"Due":      {Type: "date", Date: &DateValue{Start: "2024-04-01"}},
+		},
+	}
+	row := BoardRowFromPage(page)
+	if row.Columns[0] != "Task" {
+		t.Errorf("columns = %v, want the title property first", row.Columns)
+	}
+	issue := (&BoardMappingConfig{}).ToIssue(row)
+	if issue.Title != "Login page" || issue.Status != types.StatusClosed || issue.Assignee != "Bob" {
+		t.Errorf("issue = %+v", issue)
+	}
+	if issue.ExternalRef == nil || *issue.ExternalRef != "https://www.notion.so/2f1c0000000000000000000000000001" {
+		t.Errorf("issue.ExternalRef = %v", issue.ExternalRef)
+	}
+	if issue.DueAt == nil || !issue.DueAt.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("issue.DueAt = %v", issue.DueAt)
+	}
+	if !issue.UpdatedAt.Equal(page.LastEditedTime) {
+		t.Errorf("issue.UpdatedAt = %v, want last edited time", issue.UpdatedAt)
+	}
+	if issue.AcceptanceCriteria != "- [x] Reviewed" {
+		t.Errorf("issue.AcceptanceCriteria = %q", issue.AcceptanceCriteria)
+	}
+}
@@ -226,6 +226,16 @@ type PageProperty struct {
 	RichText    []RichText     `json:"rich_text,omitempty"`
 	Select      *SelectOption  `json:"select,omitempty"`
 	MultiSelect []SelectOption `json:"multi_select,omitempty"`
+	Status      *SelectOption  `json:"status,omitempty"`
+	Checkbox    bool           `json:"checkbox,omitempty"`
+	People      []User         `json:"people,omitempty"`
+	Date        *DateValue     `json:"date,omitempty"`
+}
+
+// DateValue is the value of a date property.
+type DateValue struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
 }
 
 type RichText struct {
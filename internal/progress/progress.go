@@ -0,0 +1,135 @@
+// Package progress reports how far long-running operations — import,
+// export, compaction, batch edits, push and pull — have got.
+//
+// A command installs a Reporter on its context (WithReporter) and starts a
+// Task for each operation. Deeper layers advance the task carried by their
+// context with Add, or start tasks of their own, without knowing whether
+// anyone is listening: without a reporter, Start returns a nil *Task and
+// every call is a no-op.
+//
+// Terminal draws a spinner, or a bar once the total is known, on a TTY;
+// JSON writes periodic JSON progress events for --json consumers.
+package progress
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Event is a snapshot of a task, passed to Reporter.Report.
+type Event struct {
+	ID       int64     // unique per task within the process
+	Op       string    // what the task does, e.g. "import" or "push origin"
+	Done     int       // units completed so far
+	Total    int       // units expected; 0 when unknown
+	Started  time.Time // when the task started
+	Finished bool      // set on the last event of the task
+}
+
+// Reporter receives task snapshots. Report is called on every change, from
+// whichever goroutine made it, so implementations must be safe for
+// concurrent use and should throttle their own output.
+type Reporter interface {
+	Report(Event)
+}
+
+type reporterContextKey struct{}
+
+type taskContextKey struct{}
+
+var nextTaskID atomic.Int64
+
+// WithReporter returns a context whose tasks report to r. A nil r leaves
+// progress reporting off.
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	if r == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, reporterContextKey{}, r)
+}
+
+// ReporterFrom returns the Reporter carried by ctx, or nil.
+func ReporterFrom(ctx context.Context) Reporter {
+	r, _ := ctx.Value(reporterContextKey{}).(Reporter)
+	return r
+}
+
+// Task is one operation being reported. A nil *Task is valid and ignores
+// every call, so callers need not check whether reporting is on.
+type Task struct {
+	r Reporter
+
+	mu sync.Mutex
+	ev Event
+}
+
+// Start begins reporting op, expecting total units of work (0 if unknown),
+// and returns a context carrying the task for Add. It returns ctx and a nil
+// task when ctx has no reporter. The caller must Finish the task.
+func Start(ctx context.Context, op string, total int) (context.Context, *Task) {
+	r := ReporterFrom(ctx)
+	if r == nil {
+		return ctx, nil
+	}
+	t := &Task{r: r, ev: Event{
+		ID:      nextTaskID.Add(1),
+		Op:      op,
+		Total:   max(total, 0),
+		Started: time.Now(),
+	}}
+	t.r.Report(t.ev)
+	return context.WithValue(ctx, taskContextKey{}, t), t
+}
+
+// FromContext returns the innermost task carried by ctx, or nil.
+func FromContext(ctx context.Context) *Task {
+	t, _ := ctx.Value(taskContextKey{}).(*Task)
+	return t
+}
+
+// Add advances the task carried by ctx by n units. Storage code calls it
+// per row so the command that started the task sees rows land.
+func Add(ctx context.Context, n int) {
+	FromContext(ctx).Add(n)
+}
+
+// Add advances the task by n units.
+func (t *Task) Add(n int) {
+	t.update(func(ev *Event) { ev.Done += n })
+}
+
+// Set sets the units completed. Work that may be retried from the start,
+// such as a transaction body, should Set rather than Add.
+func (t *Task) Set(done int) {
+	t.update(func(ev *Event) { ev.Done = done })
+}
+
+// SetTotal changes the units expected, for tasks that learn their size
+// after starting.
+func (t *Task) SetTotal(total int) {
+	t.update(func(ev *Event) { ev.Total = max(total, 0) })
+}
+
+// Finish ends the task. Later calls are ignored.
+func (t *Task) Finish() {
+	t.update(func(ev *Event) { ev.Finished = true })
+}
+
+func (t *Task) update(apply func(*Event)) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ev.Finished {
+		return
+	}
+	apply(&t.ev)
+	if t.ev.Total > 0 && t.ev.Done > t.ev.Total {
+		// Retried work can count rows twice; never report past the end.
+		t.ev.Done = t.ev.Total
+	}
+	t.r.Report(t.ev)
+}
@@ -0,0 +1,180 @@
+package progress
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recorder) Report(ev Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func TestStartWithoutReporterIsNoop(t *testing.T) {
+	ctx, task := Start(context.Background(), "import", 10)
+	if task != nil {
+		t.Fatalf("Start without reporter returned task %+v", task)
+	}
+	// None of these may panic on the nil task.
+	task.Add(1)
+	task.Set(2)
+	task.SetTotal(3)
+	task.Finish()
+	Add(ctx, 1)
+}
+
+func TestTaskReportsSnapshots(t *testing.T) {
+	r := &recorder{}
+	ctx, task := Start(WithReporter(context.Background(), r), "import", 4)
+	Add(ctx, 1)
+	task.Add(2)
+	task.Set(1)
+	task.Add(10) // clamped to the total
+	task.SetTotal(20)
+	task.Finish()
+	task.Add(1) // ignored after Finish
+
+	want := []struct {
+		done, total int
+		finished    bool
+	}{
+		{0, 4, false}, {1, 4, false}, {3, 4, false}, {1, 4, false},
+		{4, 4, false}, {4, 20, false}, {4, 20, true},
+	}
+	if len(r.events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(r.events), len(want), r.events)
+	}
+	for i, w := range want {
+		ev := r.events[i]
+		if ev.Done != w.done || ev.Total != w.total || ev.Finished != w.finished || ev.Op != "import" {
+			t.Errorf("event %d = %+v, want done=%d total=%d finished=%v", i, ev, w.done, w.total, w.finished)
+		}
+	}
+}
+
+func TestFromContextReturnsInnermostTask(t *testing.T) {
+	r := &recorder{}
+	ctx := WithReporter(context.Background(), r)
+	outerCtx, outer := Start(ctx, "import", 0)
+	innerCtx, inner := Start(outerCtx, "import dependencies", 0)
+	if FromContext(outerCtx) != outer || FromContext(innerCtx) != inner {
+		t.Fatal("FromContext did not return the innermost task")
+	}
+	if outer.ev.ID == inner.ev.ID {
+		t.Fatal("tasks share an ID")
+	}
+}
+
+func TestTerminalLine(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(3500 * time.Millisecond)
+	tests := []struct {
+		ev   Event
+		want string
+	}{
+		{Event{Op: "push origin", Started: start}, "⠋ push origin  3s"},
+		{Event{Op: "export", Done: 7, Started: start}, "⠋ export  7  3s"},
+		{Event{Op: "import", Done: 250, Total: 1000, Started: start},
+			"⠋ import  250/1000 [█████               ] 25%  3s"},
+	}
+	for _, tt := range tests {
+		if got := terminalLine(tt.ev, now, 0); got != tt.want {
+			t.Errorf("terminalLine(%+v) = %q, want %q", tt.ev, got, tt.want)
+		}
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for the renderers' timer goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestTerminalDrawsAndErases(t *testing.T) {
+	var out syncBuffer
+	term := &Terminal{w: &out, delay: 0, every: 5 * time.Millisecond}
+	ctx := WithReporter(context.Background(), term)
+
+	_, task := Start(ctx, "compact", 3)
+	task.Add(1)
+	waitFor(t, func() bool { return strings.Contains(out.String(), "compact  1/3") })
+	task.Finish()
+	if got := out.String(); !strings.HasSuffix(got, "\r\033[K") {
+		t.Errorf("line not erased after Finish: %q", got)
+	}
+}
+
+func TestJSONSkipsShortTasks(t *testing.T) {
+	var out syncBuffer
+	ctx := WithReporter(context.Background(), NewJSON(&out, time.Hour))
+	_, task := Start(ctx, "export", 10)
+	task.Add(10)
+	task.Finish()
+	if got := out.String(); got != "" {
+		t.Errorf("short task wrote %q, want nothing", got)
+	}
+}
+
+func TestJSONEmitsPeriodicAndFinishedEvents(t *testing.T) {
+	var out syncBuffer
+	ctx := WithReporter(context.Background(), NewJSON(&out, 5*time.Millisecond))
+	_, task := Start(ctx, "import", 100)
+	task.Add(40)
+	waitFor(t, func() bool { return strings.Contains(out.String(), `"done":40`) })
+	task.Finish()
+
+	var events []jsonEvent
+	sc := bufio.NewScanner(strings.NewReader(out.String()))
+	for sc.Scan() {
+		var ev jsonEvent
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			t.Fatalf("bad event line %q: %v", sc.Text(), err)
+		}
+		events = append(events, ev)
+	}
+	if len(events) < 2 {
+		t.Fatalf("got %d events, want periodic plus finished: %q", len(events), out.String())
+	}
+	first, last := events[0], events[len(events)-1]
+	if first.Type != "progress" || first.Op != "import" || first.Total != 100 || first.Finished {
+		t.Errorf("first event = %+v", first)
+	}
+	if !last.Finished || last.Done != 40 {
+		t.Errorf("last event = %+v, want finished with done=40", last)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 5s")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
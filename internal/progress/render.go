@@ -0,0 +1,246 @@
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Terminal draws the most recently started active task on one line of a
+// TTY: a spinner and elapsed time, plus a bar once the total is known. A
+// task is drawn only after it has run for a moment, so quick operations
+// print nothing, and its line is erased when it finishes.
+type Terminal struct {
+	w     io.Writer
+	delay time.Duration
+	every time.Duration
+
+	mu     sync.Mutex
+	active []Event
+	frame  int
+	drawn  bool
+	stop   chan struct{}
+}
+
+// NewTerminal returns a Terminal drawing to w, normally os.Stderr.
+func NewTerminal(w io.Writer) *Terminal {
+	return &Terminal{w: w, delay: 500 * time.Millisecond, every: 100 * time.Millisecond}
+}
+
+// Report implements Reporter.
+func (t *Terminal) Report(ev Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active = trackEvent(t.active, ev)
+	if len(t.active) == 0 {
+		t.stopLocked()
+		return
+	}
+	if t.stop == nil {
+		t.stop = make(chan struct{})
+		go runEvery(&t.mu, t.every, t.stop, t.drawLocked)
+	}
+}
+
+// Clear erases the line, if drawn, so the caller can print a message on a
+// clean line. Active tasks are drawn again on the next tick.
+func (t *Terminal) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.eraseLocked()
+}
+
+// Close stops drawing and erases the line. Call it before the process
+// exits so an interrupted task does not leave a stale line behind.
+func (t *Terminal) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active = nil
+	t.stopLocked()
+}
+
+func (t *Terminal) stopLocked() {
+	if t.stop != nil {
+		close(t.stop)
+		t.stop = nil
+	}
+	t.eraseLocked()
+}
+
+func (t *Terminal) eraseLocked() {
+	if t.drawn {
+		fmt.Fprint(t.w, "\r\033[K")
+		t.drawn = false
+	}
+}
+
+func (t *Terminal) drawLocked(now time.Time) {
+	for i := len(t.active) - 1; i >= 0; i-- {
+		ev := t.active[i]
+		if now.Sub(ev.Started) < t.delay {
+			continue
+		}
+		fmt.Fprint(t.w, "\r\033[K"+terminalLine(ev, now, t.frame))
+		t.frame++
+		t.drawn = true
+		return
+	}
+}
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const barWidth = 20
+
+// terminalLine renders ev as "⠹ import  250/1000 [█████     ] 25%  3s".
+func terminalLine(ev Event, now time.Time, frame int) string {
+	var b strings.Builder
+	b.WriteString(spinnerFrames[frame%len(spinnerFrames)])
+	b.WriteString(" ")
+	b.WriteString(ev.Op)
+	switch {
+	case ev.Total > 0:
+		filled := ev.Done * barWidth / ev.Total
+		fmt.Fprintf(&b, "  %d/%d [%s%s] %d%%", ev.Done, ev.Total,
+			strings.Repeat("█", filled), strings.Repeat(" ", barWidth-filled), ev.Done*100/ev.Total)
+	case ev.Done > 0:
+		fmt.Fprintf(&b, "  %d", ev.Done)
+	}
+	fmt.Fprintf(&b, "  %s", now.Sub(ev.Started).Truncate(time.Second))
+	return b.String()
+}
+
+// JSON writes a JSON progress event per line for each active task that has
+// run for at least the interval, and then once per interval, plus a
+// finished event for every task it reported on. Tasks shorter than the
+// interval produce no output.
+type JSON struct {
+	w     io.Writer
+	every time.Duration
+
+	mu      sync.Mutex
+	active  []Event
+	emitted map[int64]bool
+	stop    chan struct{}
+}
+
+// NewJSON returns a JSON reporter writing to w, normally os.Stderr, every
+// interval.
+func NewJSON(w io.Writer, interval time.Duration) *JSON {
+	return &JSON{w: w, every: interval, emitted: make(map[int64]bool)}
+}
+
+// jsonEvent is the wire form of a progress event.
+type jsonEvent struct {
+	Type      string `json:"type"`
+	ID        int64  `json:"id"`
+	Op        string `json:"op"`
+	Done      int    `json:"done"`
+	Total     int    `json:"total,omitempty"`
+	ElapsedMS int64  `json:"elapsed_ms"`
+	Finished  bool   `json:"finished,omitempty"`
+}
+
+// Report implements Reporter.
+func (j *JSON) Report(ev Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.active = trackEvent(j.active, ev)
+	if ev.Finished && j.emitted[ev.ID] {
+		j.writeLocked(ev, time.Now())
+		delete(j.emitted, ev.ID)
+	}
+	if len(j.active) == 0 {
+		j.stopLocked()
+		return
+	}
+	if j.stop == nil {
+		j.stop = make(chan struct{})
+		go runEvery(&j.mu, j.every, j.stop, j.tickLocked)
+	}
+}
+
+// Close stops emitting events.
+func (j *JSON) Close() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.active = nil
+	j.stopLocked()
+}
+
+func (j *JSON) stopLocked() {
+	if j.stop != nil {
+		close(j.stop)
+		j.stop = nil
+	}
+}
+
+func (j *JSON) tickLocked(now time.Time) {
+	for _, ev := range j.active {
+		if now.Sub(ev.Started) < j.every {
+			continue
+		}
+		j.writeLocked(ev, now)
+		j.emitted[ev.ID] = true
+	}
+}
+
+func (j *JSON) writeLocked(ev Event, now time.Time) {
+	data, err := json.Marshal(jsonEvent{
+		Type:      "progress",
+		ID:        ev.ID,
+		Op:        ev.Op,
+		Done:      ev.Done,
+		Total:     ev.Total,
+		ElapsedMS: now.Sub(ev.Started).Milliseconds(),
+		Finished:  ev.Finished,
+	})
+	if err != nil {
+		return
+	}
+	_, _ = j.w.Write(append(data, '\n'))
+}
+
+// trackEvent updates the active tasks, kept in start order, with ev:
+// replacing the task's last snapshot, adding a new task, or dropping a
+// finished one.
+func trackEvent(active []Event, ev Event) []Event {
+	for i := range active {
+		if active[i].ID != ev.ID {
+			continue
+		}
+		if ev.Finished {
+			return append(active[:i], active[i+1:]...)
+		}
+		active[i] = ev
+		return active
+	}
+	if ev.Finished {
+		return active
+	}
+	return append(active, ev)
+}
+
+// runEvery calls fn with mu held every interval until stop is closed.
+func runEvery(mu *sync.Mutex, every time.Duration, stop <-chan struct{}, fn func(time.Time)) {
+	tk := time.NewTicker(every)
+	defer tk.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-tk.C:
+			mu.Lock()
+			select {
+			case <-stop:
+				mu.Unlock()
+				return
+			default:
+			}
+			fn(now)
+			mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,290 @@
+// Package quality computes an issue quality score from pluggable scorers.
+//
+// Each scorer rates one signal (description completeness, review
+// validations, reopen count, linked test results) in [0, 1] and may abstain
+// when the signal does not apply. The issue's score is the weighted mean of
+// the scorers that did not abstain. Scores and their history are stored in
+// issue metadata (see ScoreMetadataKey and HistoryMetadataKey).
+package quality
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Metadata keys written by the scoring subsystem. ScoreMetadataKey is a
+// scalar so list filters can compare it in SQL.
+const (
+	ScoreMetadataKey   = "quality_score"
+	HistoryMetadataKey = "quality_history"
+	// TestResultsMetadataKey is where CI integrations record linked test
+	// results ({"passed": N, "failed": M}) for the tests scorer.
+	TestResultsMetadataKey = "test_results"
+)
+
+// MaxHistory bounds the stored score history per issue.
+const MaxHistory = 20
+
+// Inputs is everything a scorer may look at.
+type Inputs struct {
+	Issue       *types.Issue
+	Validations []types.Validation
+	ReopenCount int
+	TestResults *TestResults
+}
+
+// TestResults summarizes test runs linked to an issue.
+type TestResults struct {
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+}
+
+// Scorer rates one quality signal. Score returns ok=false to abstain.
+type Scorer interface {
+	Name() string
+	Score(in Inputs) (score float64, ok bool)
+}
+
+// ScorerFunc adapts a function to the Scorer interface.
+type ScorerFunc struct {
+	ScorerName string
+	Fn         func(in Inputs) (float64, bool)
+}
+
+func (f ScorerFunc) Name() string                    { return f.ScorerName }
+func (f ScorerFunc) Score(in Inputs) (float64, bool) { return f.Fn(in) }
+
+// registry holds the scorers available by name.
+var registry = map[string]Scorer{}
+
+// Register makes a scorer available to ParseWeights and Compute. Registering
+// a name twice replaces the earlier scorer.
+func Register(s Scorer) {
+	registry[s.Name()] = s
+}
+
+// Names returns the registered scorer names in sorted order.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	Register(ScorerFunc{"description", scoreDescription})
+	Register(ScorerFunc{"validations", scoreValidations})
+	Register(ScorerFunc{"reopens", scoreReopens})
+	Register(ScorerFunc{"tests", scoreTests})
+}
+
+// scoreDescription rewards a substantive description plus the structured
+// sections (acceptance criteria, design) that make work reviewable.
+func scoreDescription(in Inputs) (float64, bool) {
+	issue := in.Issue
+	var score float64
+	switch n := len(strings.TrimSpace(issue.Description)); {
+	case n >= 200:
+		score += 0.5
+	case n >= 50:
+		score += 0.35
+	case n > 0:
+		score += 0.15
+	}
+	if strings.TrimSpace(issue.AcceptanceCriteria) != "" {
+		score += 0.3
+	}
+	if strings.TrimSpace(issue.Design) != "" {
+		score += 0.2
+	}
+	return score, true
+}
+
+// scoreValidations is the share of validators whose latest verdict is pass.
+func scoreValidations(in Inputs) (float64, bool) {
+	latest := types.LatestValidations(in.Validations)
+	if len(latest) == 0 {
+		return 0, false
+	}
+	var passed int
+	for _, v := range latest {
+		if v.Outcome == types.ValidationPass {
+			passed++
+		}
+	}
+	return float64(passed) / float64(len(latest)), true
+}
+
+// scoreReopens halves the score with each reopen.
+func scoreReopens(in Inputs) (float64, bool) {
+	return math.Pow(0.5, float64(in.ReopenCount)), true
+}
+
+// scoreTests is the pass rate of linked test results.
+func scoreTests(in Inputs) (float64, bool) {
+	if in.TestResults == nil || in.TestResults.Passed+in.TestResults.Failed == 0 {
+		return 0, false
+	}
+	return float64(in.TestResults.Passed) / float64(in.TestResults.Passed+in.TestResults.Failed), true
+}
+
+// Weights maps scorer names to their relative weight.
+type Weights map[string]float64
+
+// DefaultWeights weighs every registered scorer equally.
+func DefaultWeights() Weights {
+	w := Weights{}
+	for name := range registry {
+		w[name] = 1
+	}
+	return w
+}
+
+// ParseWeights parses "description:1,validations:2,tests" (a bare name has
+// weight 1). Unknown scorer names and negative weights are errors.
+func ParseWeights(s string) (Weights, error) {
+	w := Weights{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, weightStr, hasWeight := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		if _, ok := registry[name]; !ok {
+			return nil, fmt.Errorf("unknown quality scorer %q (available: %s)", name, strings.Join(Names(), ", "))
+		}
+		weight := 1.0
+		if hasWeight {
+			var err error
+			weight, err = strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+			if err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid weight %q for quality scorer %s", weightStr, name)
+			}
+		}
+		w[name] = weight
+	}
+	if len(w) == 0 {
+		return nil, fmt.Errorf("no quality scorers configured")
+	}
+	return w, nil
+}
+
+// Component is one scorer's contribution to a Result.
+type Component struct {
+	Scorer string  `json:"scorer"`
+	Score  float64 `json:"score"`
+	Weight float64 `json:"weight"`
+}
+
+// Result is a computed quality score with its breakdown.
+type Result struct {
+	Score      float64     `json:"score"`
+	Components []Component `json:"components"`
+}
+
+// Compute runs the weighted scorers over in. When every scorer abstains the
+// score is 0 with no components.
+func Compute(in Inputs, weights Weights) Result {
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var res Result
+	var sum, total float64
+	for _, name := range names {
+		s, ok := registry[name]
+		weight := weights[name]
+		if !ok || weight == 0 {
+			continue
+		}
+		score, applies := s.Score(in)
+		if !applies {
+			continue
+		}
+		score = math.Max(0, math.Min(1, score))
+		res.Components = append(res.Components, Component{Scorer: name, Score: round(score), Weight: weight})
+		sum += score * weight
+		total += weight
+	}
+	if total > 0 {
+		res.Score = round(sum / total)
+	}
+	return res
+}
+
+func round(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
+
+// HistoryEntry records one score change.
+type HistoryEntry struct {
+	Score   float64   `json:"score"`
+	At      time.Time `json:"at"`
+	Trigger string    `json:"trigger,omitempty"` // Command or event that caused the recompute
+}
+
+// HistoryFromMetadata extracts the score history from issue metadata.
+func HistoryFromMetadata(metadata json.RawMessage) ([]HistoryEntry, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+	raw, ok := wrapper[HistoryMetadataKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var history []HistoryEntry
+	if err := json.Unmarshal(raw, &history); err != nil {
+		return nil, fmt.Errorf("parsing metadata.%s: %w", HistoryMetadataKey, err)
+	}
+	return history, nil
+}
+
+// TestResultsFromMetadata extracts linked test results, or nil when absent.
+func TestResultsFromMetadata(metadata json.RawMessage) *TestResults {
+	if len(metadata) == 0 {
+		return nil
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return nil
+	}
+	raw, ok := wrapper[TestResultsMetadataKey]
+	if !ok {
+		return nil
+	}
+	var tr TestResults
+	if err := json.Unmarshal(raw, &tr); err != nil {
+		return nil
+	}
+	return &tr
+}
+
+// AppendHistory appends a score to history when it differs from the last
+// recorded score, keeping at most MaxHistory entries. It reports whether an
+// entry was added.
+func AppendHistory(history []HistoryEntry, score float64, at time.Time, trigger string) ([]HistoryEntry, bool) {
+	if n := len(history); n > 0 && history[n-1].Score == score {
+		return history, false
+	}
+	history = append(history, HistoryEntry{Score: score, At: at, Trigger: trigger})
+	if len(history) > MaxHistory {
+		history = history[len(history)-MaxHistory:]
+	}
+	return history, true
+}
@@ -0,0 +1,152 @@
+package quality
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCompute(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		in      Inputs
+		weights Weights
+		want    float64
+		scorers []string
+	}{
+		{
+			name:    "bare issue scores only description and reopens",
+			in:      Inputs{Issue: &types.Issue{}},
+			weights: DefaultWeights(),
+			want:    0.5, // description 0, reopens 1
+			scorers: []string{"description", "reopens"},
+		},
+		{
+			name: "complete issue with passing review",
+			in: Inputs{
+				Issue: &types.Issue{
+					Description:        strings.Repeat("x", 200),
+					AcceptanceCriteria: "works",
+					Design:             "simple",
+				},
+				Validations: []types.Validation{{Validator: "reviewer:a", Outcome: types.ValidationPass, ValidatedAt: now}},
+				TestResults: &TestResults{Passed: 9, Failed: 1},
+			},
+			weights: DefaultWeights(),
+			want:    0.975, // (1 + 1 + 1 + 0.9) / 4
+			scorers: []string{"description", "reopens", "tests", "validations"},
+		},
+		{
+			name: "weights and reopens",
+			in: Inputs{
+				Issue:       &types.Issue{Description: strings.Repeat("x", 200)},
+				ReopenCount: 2,
+			},
+			weights: Weights{"description": 1, "reopens": 3},
+			want:    0.313, // (0.5 + 3*0.25) / 4
+			scorers: []string{"description", "reopens"},
+		},
+		{
+			name:    "zero weight disables a scorer",
+			in:      Inputs{Issue: &types.Issue{}, ReopenCount: 1},
+			weights: Weights{"description": 0, "reopens": 1},
+			want:    0.5,
+			scorers: []string{"reopens"},
+		},
+		{
+			name:    "all scorers abstain",
+			in:      Inputs{Issue: &types.Issue{}},
+			weights: Weights{"validations": 1, "tests": 1},
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Compute(tt.in, tt.weights)
+			if res.Score != tt.want {
+				t.Errorf("Score = %v, want %v", res.Score, tt.want)
+			}
+			var got []string
+			for _, c := range res.Components {
+				got = append(got, c.Scorer)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.scorers, ",") {
+				t.Errorf("scorers = %v, want %v", got, tt.scorers)
+			}
+		})
+	}
+}
+
+func TestValidationsScorerUsesLatestVerdict(t *testing.T) {
+	t0 := time.Now()
+	in := Inputs{Issue: &types.Issue{}, Validations: []types.Validation{
+		{Validator: "reviewer:a", Outcome: types.ValidationNeedsWork, ValidatedAt: t0},
+		{Validator: "qa:b", Outcome: types.ValidationFail, ValidatedAt: t0},
+		{Validator: "reviewer:a", Outcome: types.ValidationPass, ValidatedAt: t0.Add(time.Minute)},
+	}}
+	res := Compute(in, Weights{"validations": 1})
+	if res.Score != 0.5 {
+		t.Errorf("Score = %v, want 0.5", res.Score)
+	}
+}
+
+func TestParseWeights(t *testing.T) {
+	w, err := ParseWeights("description:1, validations:2.5,tests")
+	if err != nil {
+		t.Fatalf("ParseWeights: %v", err)
+	}
+	want := Weights{"description": 1, "validations": 2.5, "tests": 1}
+	if len(w) != len(want) {
+		t.Fatalf("got %v, want %v", w, want)
+	}
+	for k, v := range want {
+		if w[k] != v {
+			t.Errorf("weight[%s] = %v, want %v", k, w[k], v)
+		}
+	}
+
+	for _, bad := range []string{"", "coverage:1", "tests:-1", "tests:x"} {
+		if _, err := ParseWeights(bad); err == nil {
+			t.Errorf("ParseWeights(%q) should fail", bad)
+		}
+	}
+}
+
+func TestAppendHistory(t *testing.T) {
+	now := time.Now()
+	h, added := AppendHistory(nil, 0.5, now, "close")
+	if !added || len(h) != 1 {
+		t.Fatalf("first append: added=%v len=%d", added, len(h))
+	}
+	if h, added = AppendHistory(h, 0.5, now, "validate"); added || len(h) != 1 {
+		t.Errorf("unchanged score should not append: added=%v len=%d", added, len(h))
+	}
+	for i := 0; i < MaxHistory+5; i++ {
+		h, _ = AppendHistory(h, float64(i)/100, now, "recompute")
+	}
+	if len(h) != MaxHistory {
+		t.Errorf("len = %d, want %d", len(h), MaxHistory)
+	}
+	if last := h[len(h)-1].Score; last != float64(MaxHistory+4)/100 {
+		t.Errorf("last score = %v", last)
+	}
+}
+
+func TestMetadataHelpers(t *testing.T) {
+	meta := json.RawMessage(`{"test_results":{"passed":3,"failed":1},"quality_history":[{"score":0.4,"at":"2026-01-02T03:04:05Z","trigger":"close"}]}`)
+	tr := TestResultsFromMetadata(meta)
+	if tr == nil || tr.Passed != 3 || tr.Failed != 1 {
+		t.Errorf("TestResultsFromMetadata = %+v", tr)
+	}
+	h, err := HistoryFromMetadata(meta)
+	if err != nil || len(h) != 1 || h[0].Score != 0.4 || h[0].Trigger != "close" {
+		t.Errorf("HistoryFromMetadata = %+v, %v", h, err)
+	}
+	if TestResultsFromMetadata(json.RawMessage(`{}`)) != nil {
+		t.Error("expected nil test results for empty metadata")
+	}
+}
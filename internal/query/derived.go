@@ -0,0 +1,452 @@
+package query
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Derived field kinds.
+const (
+	// DerivedAge is the time elapsed since one of the issue's timestamps.
+	DerivedAge = "age"
+	// DerivedDownstream counts the open issues transitively waiting on the
+	// issue through blocking dependencies.
+	DerivedDownstream = "downstream"
+	// DerivedSLA classifies the issue against a per-priority deadline.
+	DerivedSLA = "sla"
+)
+
+// SLA states produced by DerivedSLA fields.
+const (
+	SLAStateOK       = "ok"       // open, within the warn fraction of its target
+	SLAStateAtRisk   = "at_risk"  // open, past the warn fraction but not the deadline
+	SLAStateBreached = "breached" // open, past the deadline
+	SLAStateMet      = "met"      // closed by the deadline
+	SLAStateMissed   = "missed"   // closed after the deadline
+	SLAStateNone     = "none"     // no target for the issue's priority
+)
+
+var derivedFieldNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// DerivedField is a value computed per issue at query time, defined under
+// derived.fields in config.yaml:
+//
+//	derived:
+//	  fields:
+//	    age_days: {kind: age, from: created, unit: days}
+//	    downstream_blocked_count: {kind: downstream}
+//	    sla_state: {kind: sla, targets: {p0: 4h, p1: 1d, p2: 1w}}
+type DerivedField struct {
+	Name string
+	Kind string
+	// From is the timestamp age and sla fields measure from: created,
+	// updated, started, or closed.
+	From string
+	// Unit is "days" or "hours" for age fields.
+	Unit string
+	// DepTypes are the dependency types downstream fields follow.
+	DepTypes []types.DependencyType
+	// Targets maps priority to a compact duration ("4h", "2d") for sla fields.
+	Targets map[int]string
+	// Warn is the fraction of an sla target after which an open issue is
+	// at_risk.
+	Warn float64
+}
+
+// Numeric reports whether the field produces integers (true) or strings.
+func (f DerivedField) Numeric() bool {
+	return f.Kind != DerivedSLA
+}
+
+// ParseDerivedFields converts raw derived.fields config into fields sorted
+// by name, rejecting unknown kinds and malformed options.
+func ParseDerivedFields(raw map[string]interface{}) ([]DerivedField, error) {
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]DerivedField, 0, len(names))
+	for _, name := range names {
+		if !derivedFieldNameRe.MatchString(name) {
+			return nil, fmt.Errorf("derived.fields: invalid name %q (use lowercase letters, digits, and _)", name)
+		}
+		props, ok := raw[name].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("derived.fields.%s: expected a mapping", name)
+		}
+		f, err := parseDerivedField(name, props)
+		if err != nil {
+			return nil, fmt.Errorf("derived.fields.%s: %w", name, err)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func parseDerivedField(name string, props map[string]interface{}) (DerivedField, error) {
+	f := DerivedField{Name: name}
+	f.Kind, _ = props["kind"].(string)
+	f.From, _ = props["from"].(string)
+	if f.From == "" {
+		f.From = "created"
+	}
+	switch f.From {
+	case "created", "updated", "started", "closed":
+	default:
+		return f, fmt.Errorf("from must be created, updated, started, or closed, got %q", f.From)
+	}
+
+	switch f.Kind {
+	case DerivedAge:
+		f.Unit, _ = props["unit"].(string)
+		if f.Unit == "" {
+			f.Unit = "days"
+		}
+		if f.Unit != "days" && f.Unit != "hours" {
+			return f, fmt.Errorf("unit must be days or hours, got %q", f.Unit)
+		}
+	case DerivedDownstream:
+		rawTypes, _ := props["dep_types"].([]interface{})
+		for _, rt := range rawTypes {
+			s, _ := rt.(string)
+			dt := types.DependencyType(s)
+			if !dt.IsValid() {
+				return f, fmt.Errorf("invalid dependency type %q in dep_types", s)
+			}
+			f.DepTypes = append(f.DepTypes, dt)
+		}
+		if len(f.DepTypes) == 0 {
+			f.DepTypes = []types.DependencyType{types.DepBlocks, types.DepConditionalBlocks, types.DepWaitsFor}
+		}
+	case DerivedSLA:
+		targets, ok := props["targets"].(map[string]interface{})
+		if !ok || len(targets) == 0 {
+			return f, fmt.Errorf("sla fields need targets, e.g. {p0: 4h, p1: 1d}")
+		}
+		f.Targets = make(map[int]string, len(targets))
+		for key, rawTarget := range targets {
+			p, err := parseDerivedPriority(key)
+			if err != nil {
+				return f, err
+			}
+			target := fmt.Sprint(rawTarget)
+			if _, err := timeparsing.ParseCompactDuration("+"+target, time.Time{}); err != nil {
+				return f, fmt.Errorf("target %s: %q is not a duration like 4h or 2d", key, target)
+			}
+			f.Targets[p] = target
+		}
+		f.Warn = 0.8
+		if w, ok := props["warn"]; ok {
+			switch v := w.(type) {
+			case float64:
+				f.Warn = v
+			case int:
+				f.Warn = float64(v)
+			default:
+				return f, fmt.Errorf("warn must be a number between 0 and 1")
+			}
+			if f.Warn <= 0 || f.Warn > 1 {
+				return f, fmt.Errorf("warn must be between 0 and 1, got %v", f.Warn)
+			}
+		}
+	default:
+		return f, fmt.Errorf("kind must be %s, %s, or %s, got %q", DerivedAge, DerivedDownstream, DerivedSLA, f.Kind)
+	}
+	return f, nil
+}
+
+// parseDerivedPriority accepts "p0".."p4" or a bare digit.
+func parseDerivedPriority(key string) (int, error) {
+	k := strings.TrimPrefix(strings.ToLower(key), "p")
+	if len(k) != 1 || k[0] < '0' || k[0] > '4' {
+		return 0, fmt.Errorf("invalid priority %q in targets (want p0-p4)", key)
+	}
+	return int(k[0] - '0'), nil
+}
+
+// derivedTimestamp returns the issue timestamp named by from, or nil when it
+// is unset.
+func derivedTimestamp(issue *types.Issue, from string) *time.Time {
+	switch from {
+	case "created":
+		if issue.CreatedAt.IsZero() {
+			return nil
+		}
+		return &issue.CreatedAt
+	case "updated":
+		if issue.UpdatedAt.IsZero() {
+			return nil
+		}
+		return &issue.UpdatedAt
+	case "started":
+		return issue.StartedAt
+	case "closed":
+		return issue.ClosedAt
+	}
+	return nil
+}
+
+// DerivedSource is the storage needed to compute downstream fields.
+type DerivedSource interface {
+	GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error)
+	GetIssuesByIDs(ctx context.Context, ids []string) ([]*types.Issue, error)
+}
+
+// ComputeDerived fills issue.Derived for each field. Fields that do not apply
+// to an issue (an age from an unset timestamp) are left out of its map. The
+// dependency graph is only read when a downstream field is configured.
+//
+// The work also fills issue.Annotations: sla fields set SLADue (the earliest
+// deadline when there are several), and the first downstream field's walk
+// sets BlockedByCount and InheritedPriority.
+func ComputeDerived(ctx context.Context, src DerivedSource, issues []*types.Issue, fields []DerivedField, now time.Time) error {
+	if len(fields) == 0 || len(issues) == 0 {
+		return nil
+	}
+
+	var graph *depGraph
+	for _, f := range fields {
+		if f.Kind == DerivedDownstream {
+			var err error
+			if graph, err = loadDepGraph(ctx, src, issues); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	for _, issue := range issues {
+		if issue == nil {
+			continue
+		}
+		annotated := false
+		for _, f := range fields {
+			var value interface{}
+			switch f.Kind {
+			case DerivedAge:
+				if ts := derivedTimestamp(issue, f.From); ts != nil {
+					unit := 24 * time.Hour
+					if f.Unit == "hours" {
+						unit = time.Hour
+					}
+					value = int(now.Sub(*ts) / unit)
+				}
+			case DerivedDownstream:
+				count, inherited := graph.downstream(issue, f.DepTypes)
+				value = count
+				if !annotated {
+					annotated = true
+					blockers := graph.openBlockers(issue.ID, f.DepTypes)
+					ann := issue.Annotate()
+					ann.BlockedByCount = &blockers
+					if inherited < issue.Priority {
+						ann.InheritedPriority = &inherited
+					}
+				}
+			case DerivedSLA:
+				var due *time.Time
+				value, due = slaState(issue, f, now)
+				if due != nil {
+					if ann := issue.Annotate(); ann.SLADue == nil || due.Before(*ann.SLADue) {
+						ann.SLADue = due
+					}
+				}
+			}
+			if value == nil {
+				continue
+			}
+			if issue.Derived == nil {
+				issue.Derived = make(map[string]interface{}, len(fields))
+			}
+			issue.Derived[f.Name] = value
+		}
+	}
+	return nil
+}
+
+// depGraph is the dependency graph in both directions plus the status and
+// priority of every issue it mentions.
+type depGraph struct {
+	dependents map[string]map[types.DependencyType][]string // target -> type -> sources
+	blockers   map[string]map[types.DependencyType][]string // source -> type -> targets
+	issues     map[string]*types.Issue
+}
+
+func loadDepGraph(ctx context.Context, src DerivedSource, issues []*types.Issue) (*depGraph, error) {
+	allDeps, err := src.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("computing derived fields: %w", err)
+	}
+
+	g := &depGraph{
+		dependents: make(map[string]map[types.DependencyType][]string),
+		blockers:   make(map[string]map[types.DependencyType][]string),
+		issues:     make(map[string]*types.Issue, len(issues)),
+	}
+	for _, issue := range issues {
+		if issue != nil {
+			g.issues[issue.ID] = issue
+		}
+	}
+	var missing []string
+	seen := make(map[string]bool)
+	note := func(id string) {
+		if _, ok := g.issues[id]; !ok && !seen[id] {
+			seen[id] = true
+			missing = append(missing, id)
+		}
+	}
+	for _, deps := range allDeps {
+		for _, dep := range deps {
+			if g.dependents[dep.DependsOnID] == nil {
+				g.dependents[dep.DependsOnID] = make(map[types.DependencyType][]string)
+			}
+			g.dependents[dep.DependsOnID][dep.Type] = append(g.dependents[dep.DependsOnID][dep.Type], dep.IssueID)
+			if g.blockers[dep.IssueID] == nil {
+				g.blockers[dep.IssueID] = make(map[types.DependencyType][]string)
+			}
+			g.blockers[dep.IssueID][dep.Type] = append(g.blockers[dep.IssueID][dep.Type], dep.DependsOnID)
+			note(dep.IssueID)
+			note(dep.DependsOnID)
+		}
+	}
+
+	if len(missing) > 0 {
+		fetched, err := src.GetIssuesByIDs(ctx, missing)
+		if err != nil {
+			return nil, fmt.Errorf("computing derived fields: %w", err)
+		}
+		for _, issue := range fetched {
+			g.issues[issue.ID] = issue
+		}
+	}
+	return g, nil
+}
+
+// open reports whether id is a known issue that is not closed. Dangling
+// references (deleted or external issues) count as not open.
+func (g *depGraph) open(id string) bool {
+	issue, ok := g.issues[id]
+	return ok && issue.Status != types.StatusClosed
+}
+
+// downstream counts the open issues that transitively depend on issue through
+// depTypes, and returns the most urgent priority among them and issue itself.
+// The walk stops at closed issues, which no longer wait on anything; a closed
+// issue has nothing downstream.
+func (g *depGraph) downstream(issue *types.Issue, depTypes []types.DependencyType) (int, int) {
+	inherited := issue.Priority
+	if issue.Status == types.StatusClosed {
+		return 0, inherited
+	}
+	visited := map[string]bool{issue.ID: true}
+	queue := []string{issue.ID}
+	n := 0
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, dt := range depTypes {
+			for _, dep := range g.dependents[cur][dt] {
+				if visited[dep] || !g.open(dep) {
+					continue
+				}
+				visited[dep] = true
+				n++
+				if p := g.issues[dep].Priority; p < inherited {
+					inherited = p
+				}
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return n, inherited
+}
+
+// openBlockers counts the open issues id directly depends on through depTypes.
+func (g *depGraph) openBlockers(id string, depTypes []types.DependencyType) int {
+	n := 0
+	for _, dt := range depTypes {
+		for _, target := range g.blockers[id][dt] {
+			if g.open(target) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// slaState classifies issue against the field's target for its priority and
+// returns the deadline, if there is one.
+func slaState(issue *types.Issue, f DerivedField, now time.Time) (interface{}, *time.Time) {
+	target, ok := f.Targets[issue.Priority]
+	if !ok {
+		return SLAStateNone, nil
+	}
+	start := derivedTimestamp(issue, f.From)
+	if start == nil {
+		return nil, nil
+	}
+	deadline, err := timeparsing.ParseCompactDuration("+"+target, *start)
+	if err != nil {
+		return nil, nil
+	}
+	if issue.Status == types.StatusClosed && issue.ClosedAt != nil {
+		if issue.ClosedAt.After(deadline) {
+			return SLAStateMissed, &deadline
+		}
+		return SLAStateMet, &deadline
+	}
+	if now.After(deadline) {
+		return SLAStateBreached, &deadline
+	}
+	if float64(now.Sub(*start)) >= f.Warn*float64(deadline.Sub(*start)) {
+		return SLAStateAtRisk, &deadline
+	}
+	return SLAStateOK, &deadline
+}
+
+// CompareDerived orders two issues by a derived value: numbers numerically,
+// strings lexically, and issues without the value last.
+func CompareDerived(a, b *types.Issue, name string) int {
+	av, aok := a.Derived[name]
+	bv, bok := b.Derived[name]
+	switch {
+	case !aok && !bok:
+		return 0
+	case !aok:
+		return 1
+	case !bok:
+		return -1
+	}
+	an, aNum := av.(int)
+	bn, bNum := bv.(int)
+	if aNum && bNum {
+		return cmp.Compare(an, bn)
+	}
+	return cmp.Compare(fmt.Sprint(av), fmt.Sprint(bv))
+}
+
+// FormatDerived renders an issue's derived values as "name=value" pairs
+// sorted by name, for text output.
+func FormatDerived(issue *types.Issue) string {
+	names := make([]string, 0, len(issue.Derived))
+	for name := range issue.Derived {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%v", name, issue.Derived[name])
+	}
+	return strings.Join(parts, ", ")
+}
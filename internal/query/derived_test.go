@@ -0,0 +1,256 @@
+package query
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type fakeDerivedSource struct {
+	deps   map[string][]*types.Dependency
+	issues map[string]*types.Issue
+}
+
+func (f *fakeDerivedSource) GetAllDependencyRecords(context.Context) (map[string][]*types.Dependency, error) {
+	return f.deps, nil
+}
+
+func (f *fakeDerivedSource) GetIssuesByIDs(_ context.Context, ids []string) ([]*types.Issue, error) {
+	var out []*types.Issue
+	for _, id := range ids {
+		if issue, ok := f.issues[id]; ok {
+			out = append(out, issue)
+		}
+	}
+	return out, nil
+}
+
+func TestParseDerivedFields(t *testing.T) {
+	fields, err := ParseDerivedFields(map[string]interface{}{
+		"sla_state": map[string]interface{}{"kind": "sla", "targets": map[string]interface{}{"p0": "4h", "1": "2d"}, "warn": 0.5},
+		"age_days":  map[string]interface{}{"kind": "age"},
+		"blocked":   map[string]interface{}{"kind": "downstream", "dep_types": []interface{}{"blocks"}},
+	})
+	if err != nil {
+		t.Fatalf("ParseDerivedFields: %v", err)
+	}
+	if len(fields) != 3 || fields[0].Name != "age_days" || fields[1].Name != "blocked" || fields[2].Name != "sla_state" {
+		t.Fatalf("fields not sorted by name: %+v", fields)
+	}
+	if fields[0].From != "created" || fields[0].Unit != "days" {
+		t.Errorf("age defaults = %q/%q, want created/days", fields[0].From, fields[0].Unit)
+	}
+	if len(fields[1].DepTypes) != 1 || fields[1].DepTypes[0] != types.DepBlocks {
+		t.Errorf("dep_types = %v, want [blocks]", fields[1].DepTypes)
+	}
+	if fields[2].Targets[0] != "4h" || fields[2].Targets[1] != "2d" || fields[2].Warn != 0.5 {
+		t.Errorf("sla = %+v", fields[2])
+	}
+
+	bad := map[string]map[string]interface{}{
+		"unknown kind":   {"kind": "magic"},
+		"bad from":       {"kind": "age", "from": "due"},
+		"bad unit":       {"kind": "age", "unit": "weeks"},
+		"bad dep type":   {"kind": "downstream", "dep_types": []interface{}{""}},
+		"no targets":     {"kind": "sla"},
+		"bad priority":   {"kind": "sla", "targets": map[string]interface{}{"p9": "1d"}},
+		"bad duration":   {"kind": "sla", "targets": map[string]interface{}{"p0": "soon"}},
+		"warn too large": {"kind": "sla", "targets": map[string]interface{}{"p0": "1d"}, "warn": 2.0},
+	}
+	for name, props := range bad {
+		if _, err := ParseDerivedFields(map[string]interface{}{"f": props}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := ParseDerivedFields(map[string]interface{}{"Age-Days": map[string]interface{}{"kind": "age"}}); err == nil {
+		t.Error("expected error for invalid field name")
+	}
+}
+
+func TestComputeDerived(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	closedAt := now.Add(-time.Hour)
+	a := &types.Issue{ID: "a", Status: types.StatusOpen, Priority: 0, CreatedAt: now.Add(-50 * time.Hour)}
+	b := &types.Issue{ID: "b", Status: types.StatusOpen, Priority: 1, CreatedAt: now.Add(-20 * time.Hour)}
+	c := &types.Issue{ID: "c", Status: types.StatusOpen, Priority: 1, CreatedAt: now.Add(-time.Hour)}
+	d := &types.Issue{ID: "d", Status: types.StatusClosed, Priority: 1, CreatedAt: now.Add(-3 * time.Hour), ClosedAt: &closedAt}
+	e := &types.Issue{ID: "e", Status: types.StatusOpen, Priority: 3, CreatedAt: now}
+	f := &types.Issue{ID: "f", Status: types.StatusOpen, Priority: 4, CreatedAt: now}
+
+	// b, c, and d wait on a; c also waits on b. d is closed. f is not in
+	// the result set but b waits on it too.
+	src := &fakeDerivedSource{
+		deps: map[string][]*types.Dependency{
+			"b": {{IssueID: "b", DependsOnID: "a", Type: types.DepBlocks}, {IssueID: "b", DependsOnID: "f", Type: types.DepBlocks}},
+			"c": {{IssueID: "c", DependsOnID: "b", Type: types.DepBlocks}},
+			"d": {{IssueID: "d", DependsOnID: "a", Type: types.DepBlocks}},
+			"e": {{IssueID: "e", DependsOnID: "a", Type: types.DepRelated}},
+		},
+		issues: map[string]*types.Issue{"a": a, "b": b, "c": c, "d": d, "e": e, "f": f},
+	}
+	fields, err := ParseDerivedFields(map[string]interface{}{
+		"age_days":   map[string]interface{}{"kind": "age"},
+		"started":    map[string]interface{}{"kind": "age", "from": "started"},
+		"downstream": map[string]interface{}{"kind": "downstream"},
+		"sla":        map[string]interface{}{"kind": "sla", "targets": map[string]interface{}{"p0": "1d", "p1": "1d"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ComputeDerived(context.Background(), src, []*types.Issue{a, b, c, d, e}, fields, now); err != nil {
+		t.Fatalf("ComputeDerived: %v", err)
+	}
+
+	tests := []struct {
+		issue *types.Issue
+		field string
+		want  interface{}
+	}{
+		{a, "age_days", 2},
+		{b, "age_days", 0},
+		{a, "downstream", 2}, // b and c; d is closed, e is only related
+		{b, "downstream", 1},
+		{c, "downstream", 0},
+		{d, "downstream", 0},
+		{a, "sla", SLAStateBreached},
+		{b, "sla", SLAStateAtRisk},
+		{c, "sla", SLAStateOK},
+		{d, "sla", SLAStateMet},
+		{e, "sla", SLAStateNone},
+	}
+	for _, tt := range tests {
+		if got := tt.issue.Derived[tt.field]; got != tt.want {
+			t.Errorf("%s.%s = %v, want %v", tt.issue.ID, tt.field, got, tt.want)
+		}
+	}
+	if _, ok := a.Derived["started"]; ok {
+		t.Error("age from an unset timestamp should be absent")
+	}
+	if got := FormatDerived(b); got != "age_days=0, downstream=1, sla=at_risk" {
+		t.Errorf("FormatDerived = %q", got)
+	}
+
+	blockers := map[*types.Issue]int{a: 0, b: 2, c: 1, d: 1, e: 0}
+	for issue, want := range blockers {
+		if got := issue.Annotations.BlockedByCount; got == nil || *got != want {
+			t.Errorf("%s blocked_by_count = %v, want %d", issue.ID, got, want)
+		}
+	}
+	if want := a.CreatedAt.Add(24 * time.Hour); a.Annotations.SLADue == nil || !a.Annotations.SLADue.Equal(want) {
+		t.Errorf("a sla_due = %v, want %v", a.Annotations.SLADue, want)
+	}
+	if e.Annotations.SLADue != nil {
+		t.Error("sla_due should be unset without a target for the priority")
+	}
+	if a.Annotations.InheritedPriority != nil || b.Annotations.InheritedPriority != nil {
+		t.Error("inherited_priority should be unset when nothing more urgent waits on the issue")
+	}
+}
+
+func TestComputeDerivedInheritedPriority(t *testing.T) {
+	now := time.Now()
+	urgent := &types.Issue{ID: "u", Status: types.StatusOpen, Priority: 0, CreatedAt: now}
+	mid := &types.Issue{ID: "m", Status: types.StatusOpen, Priority: 2, CreatedAt: now}
+	low := &types.Issue{ID: "l", Status: types.StatusOpen, Priority: 4, CreatedAt: now}
+	// urgent waits on mid, which waits on low: low inherits P0 through mid.
+	src := &fakeDerivedSource{
+		deps: map[string][]*types.Dependency{
+			"u": {{IssueID: "u", DependsOnID: "m", Type: types.DepBlocks}},
+			"m": {{IssueID: "m", DependsOnID: "l", Type: types.DepBlocks}},
+		},
+		issues: map[string]*types.Issue{"u": urgent, "m": mid, "l": low},
+	}
+	fields := []DerivedField{{Name: "downstream", Kind: DerivedDownstream, DepTypes: []types.DependencyType{types.DepBlocks}}}
+	if err := ComputeDerived(context.Background(), src, []*types.Issue{low, mid}, fields, now); err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range []*types.Issue{low, mid} {
+		if got := issue.Annotations.InheritedPriority; got == nil || *got != 0 {
+			t.Errorf("%s inherited_priority = %v, want 0", issue.ID, got)
+		}
+	}
+	if low.Derived["downstream"] != 2 {
+		t.Errorf("low downstream = %v, want 2", low.Derived["downstream"])
+	}
+}
+
+func TestCompareDerived(t *testing.T) {
+	low := &types.Issue{Derived: map[string]interface{}{"n": 2, "s": "at_risk"}}
+	high := &types.Issue{Derived: map[string]interface{}{"n": 10, "s": "ok"}}
+	none := &types.Issue{}
+	if CompareDerived(low, high, "n") >= 0 || CompareDerived(high, low, "n") <= 0 {
+		t.Error("numbers should compare numerically")
+	}
+	if CompareDerived(low, high, "s") >= 0 {
+		t.Error("strings should compare lexically")
+	}
+	if CompareDerived(none, low, "n") <= 0 || CompareDerived(low, none, "n") >= 0 {
+		t.Error("issues without the value should sort last")
+	}
+}
+
+func TestEvaluatorDerivedQueries(t *testing.T) {
+	fields, err := ParseDerivedFields(map[string]interface{}{
+		"age_days": map[string]interface{}{"kind": "age"},
+		"sla":      map[string]interface{}{"kind": "sla", "targets": map[string]interface{}{"p0": "1d"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := &types.Issue{Status: types.StatusOpen, Derived: map[string]interface{}{"age_days": 40, "sla": SLAStateBreached}}
+	fresh := &types.Issue{Status: types.StatusOpen, Derived: map[string]interface{}{"age_days": 1, "sla": SLAStateOK}}
+	unset := &types.Issue{Status: types.StatusOpen}
+
+	tests := []struct {
+		query string
+		want  []bool // old, fresh, unset
+	}{
+		{"derived.age_days>30", []bool{true, false, false}},
+		{"derived.age_days<=1", []bool{false, true, false}},
+		{"derived.age_days!=1", []bool{true, false, false}},
+		{"derived.sla=BREACHED", []bool{true, false, false}},
+		{"derived.sla!=breached", []bool{false, true, false}},
+		{"status=open AND derived.age_days>=1", []bool{true, true, false}},
+		{"NOT derived.sla=ok", []bool{true, false, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			node, err := Parse(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			eval := NewEvaluator(time.Now())
+			eval.SetDerivedFields(fields)
+			result, err := eval.Evaluate(node)
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if !result.RequiresPredicate || result.Predicate == nil {
+				t.Fatal("derived queries must use a predicate")
+			}
+			for i, issue := range []*types.Issue{old, fresh, unset} {
+				if got := result.Predicate(issue); got != tt.want[i] {
+					t.Errorf("issue %d: got %v, want %v", i, got, tt.want[i])
+				}
+			}
+			if strings.Contains(tt.query, "status=open") && result.Filter.Status == nil {
+				t.Error("status=open should still be pushed down as a base filter")
+			}
+		})
+	}
+
+	for _, q := range []string{"derived.missing=1", "derived.sla>ok", "derived.age_days=abc"} {
+		eval := NewEvaluator(time.Now())
+		eval.SetDerivedFields(fields)
+		node, err := Parse(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := eval.Evaluate(node); err == nil {
+			t.Errorf("%s: expected error", q)
+		}
+	}
+}
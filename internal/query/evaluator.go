@@ -33,7 +33,8 @@ type QueryResult struct {
 
 // Evaluator converts a query AST to an IssueFilter and/or predicate function.
 type Evaluator struct {
-	now time.Time
+	now     time.Time
+	derived map[string]DerivedField
 }
 
 // NewEvaluator creates a new Evaluator with the given reference time.
@@ -41,6 +42,16 @@ func NewEvaluator(now time.Time) *Evaluator {
 	return &Evaluator{now: now}
 }
 
+// SetDerivedFields makes derived.<name> queryable for the given fields. The
+// caller must fill issue.Derived (see ComputeDerived) before applying the
+// predicate.
+func (e *Evaluator) SetDerivedFields(fields []DerivedField) {
+	e.derived = make(map[string]DerivedField, len(fields))
+	for _, f := range fields {
+		e.derived[f.Name] = f
+	}
+}
+
 // Evaluate evaluates the query AST and returns a QueryResult.
 func (e *Evaluator) Evaluate(node Node) (*QueryResult, error) {
 	result := &QueryResult{
@@ -77,7 +88,8 @@ func (e *Evaluator) Evaluate(node Node) (*QueryResult, error) {
 func (e *Evaluator) canUseFilterOnly(node Node) bool {
 	switch n := node.(type) {
 	case *ComparisonNode:
-		return true
+		// Derived values only exist after the fetch, so they need a predicate.
+		return !strings.HasPrefix(n.Field, "derived.")
 	case *AndNode:
 		return e.canUseFilterOnly(n.Left) && e.canUseFilterOnly(n.Right)
 	case *NotNode:
@@ -173,7 +185,11 @@ func (e *Evaluator) applyComparison(comp *ComparisonNode, filter *types.IssueFil
 	case "description", "desc":
 		return e.applyDescriptionFilter(comp, filter)
 	case "notes":
-		return e.applyNotesFilter(comp, filter)
+		return e.applySectionFilter(comp, "notes", &filter.NotesContains, &filter.NoNotes)
+	case "design":
+		return e.applySectionFilter(comp, "design", &filter.DesignContains, &filter.NoDesign)
+	case "acceptance", "acceptance_criteria":
+		return e.applySectionFilter(comp, "acceptance_criteria", &filter.AcceptanceContains, &filter.NoAcceptanceCriteria)
 	case "created", "created_at":
 		return e.applyCreatedFilter(comp, filter)
 	case "updated", "updated_at":
@@ -202,6 +218,9 @@ func (e *Evaluator) applyComparison(comp *ComparisonNode, filter *types.IssueFil
 		if strings.HasPrefix(comp.Field, "metadata.") {
 			return e.applyMetadataFilter(comp, filter)
 		}
+		if strings.HasPrefix(comp.Field, "derived.") {
+			return fmt.Errorf("%s is computed at query time and cannot be filtered in storage", comp.Field)
+		}
 		return fmt.Errorf("unknown field: %s", comp.Field)
 	}
 }
@@ -321,11 +340,17 @@ func (e *Evaluator) applyDescriptionFilter(comp *ComparisonNode, filter *types.I
 	return nil
 }
 
-func (e *Evaluator) applyNotesFilter(comp *ComparisonNode, filter *types.IssueFilter) error {
+// applySectionFilter handles the notes, design, and acceptance criteria
+// text sections: a substring match, or "none" for an empty section.
+func (e *Evaluator) applySectionFilter(comp *ComparisonNode, name string, contains *string, empty *bool) error {
 	if comp.Op != OpEquals {
-		return fmt.Errorf("notes only supports = operator")
+		return fmt.Errorf("%s only supports = operator", name)
+	}
+	if comp.Value == "" || strings.ToLower(comp.Value) == "none" || strings.ToLower(comp.Value) == "null" {
+		*empty = true
+	} else {
+		*contains = comp.Value
 	}
-	filter.NotesContains = comp.Value
 	return nil
 }
 
@@ -579,6 +604,59 @@ func (e *Evaluator) buildHasMetadataKeyPredicate(comp *ComparisonNode) (func(*ty
 	}, nil
 }
 
+// buildDerivedPredicate builds a predicate for derived.<name> comparisons
+// against issue.Derived. Numeric fields support every operator; string
+// fields (sla) support = and !=. Issues without the value never match.
+func (e *Evaluator) buildDerivedPredicate(comp *ComparisonNode) (func(*types.Issue) bool, error) {
+	name := strings.TrimPrefix(comp.Field, "derived.")
+	field, ok := e.derived[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown derived field %q (define it under derived.fields in config.yaml)", name)
+	}
+
+	if !field.Numeric() {
+		if comp.Op != OpEquals && comp.Op != OpNotEquals {
+			return nil, fmt.Errorf("derived field %s only supports = and != operators", name)
+		}
+		want := strings.ToLower(comp.Value)
+		negate := comp.Op == OpNotEquals
+		return func(i *types.Issue) bool {
+			v, ok := i.Derived[name].(string)
+			if !ok {
+				return false
+			}
+			return (v == want) != negate
+		}, nil
+	}
+
+	target, err := strconv.Atoi(comp.Value)
+	if err != nil {
+		return nil, fmt.Errorf("derived field %s expects a whole number, got %q", name, comp.Value)
+	}
+	return func(i *types.Issue) bool {
+		v, ok := i.Derived[name].(int)
+		if !ok {
+			return false
+		}
+		switch comp.Op {
+		case OpEquals:
+			return v == target
+		case OpNotEquals:
+			return v != target
+		case OpLess:
+			return v < target
+		case OpLessEq:
+			return v <= target
+		case OpGreater:
+			return v > target
+		case OpGreaterEq:
+			return v >= target
+		default:
+			return false
+		}
+	}, nil
+}
+
 // applyNot applies a NOT expression to the filter.
 func (e *Evaluator) applyNot(not *NotNode, filter *types.IssueFilter) error {
 	comp, ok := not.Operand.(*ComparisonNode)
@@ -706,7 +784,11 @@ func (e *Evaluator) buildComparisonPredicate(comp *ComparisonNode) (func(*types.
 	case "description", "desc":
 		return e.buildDescriptionPredicate(comp)
 	case "notes":
-		return e.buildNotesPredicate(comp)
+		return e.buildSectionPredicate(comp, "notes", func(i *types.Issue) string { return i.Notes })
+	case "design":
+		return e.buildSectionPredicate(comp, "design", func(i *types.Issue) string { return i.Design })
+	case "acceptance", "acceptance_criteria":
+		return e.buildSectionPredicate(comp, "acceptance_criteria", func(i *types.Issue) string { return i.AcceptanceCriteria })
 	case "created", "created_at":
 		return e.buildCreatedPredicate(comp)
 	case "updated", "updated_at":
@@ -731,6 +813,9 @@ func (e *Evaluator) buildComparisonPredicate(comp *ComparisonNode) (func(*types.
 		if strings.HasPrefix(comp.Field, "metadata.") {
 			return e.buildMetadataPredicate(comp)
 		}
+		if strings.HasPrefix(comp.Field, "derived.") {
+			return e.buildDerivedPredicate(comp)
+		}
 		return nil, fmt.Errorf("unknown field: %s", comp.Field)
 	}
 }
@@ -885,19 +970,23 @@ func (e *Evaluator) buildDescriptionPredicate(comp *ComparisonNode) (func(*types
 	}
 }
 
-func (e *Evaluator) buildNotesPredicate(comp *ComparisonNode) (func(*types.Issue) bool, error) {
+// buildSectionPredicate is the predicate form of applySectionFilter.
+func (e *Evaluator) buildSectionPredicate(comp *ComparisonNode, name string, get func(*types.Issue) string) (func(*types.Issue) bool, error) {
 	value := strings.ToLower(comp.Value)
+	isNone := value == "" || value == "none" || value == "null"
+	var match func(*types.Issue) bool
+	if isNone {
+		match = func(i *types.Issue) bool { return get(i) == "" }
+	} else {
+		match = func(i *types.Issue) bool { return strings.Contains(strings.ToLower(get(i)), value) }
+	}
 	switch comp.Op {
 	case OpEquals:
-		return func(i *types.Issue) bool {
-			return strings.Contains(strings.ToLower(i.Notes), value)
-		}, nil
+		return match, nil
 	case OpNotEquals:
-		return func(i *types.Issue) bool {
-			return !strings.Contains(strings.ToLower(i.Notes), value)
-		}, nil
+		return func(i *types.Issue) bool { return !match(i) }, nil
 	default:
-		return nil, fmt.Errorf("notes does not support %s operator", comp.Op.String())
+		return nil, fmt.Errorf("%s does not support %s operator", name, comp.Op.String())
 	}
 }
 
@@ -332,10 +332,13 @@ var KnownFields = map[string]bool{
 	"template":  true,
 
 	// Other
-	"spec":             true,
-	"spec_id":          true, // alias
-	"parent":           true,
-	"mol_type":         true,
-	"notes":            true,
-	"has_metadata_key": true, // GH#1406
+	"spec":                true,
+	"spec_id":             true, // alias
+	"parent":              true,
+	"mol_type":            true,
+	"notes":               true,
+	"design":              true,
+	"acceptance":          true,
+	"acceptance_criteria": true, // alias
+	"has_metadata_key":    true, // GH#1406
 }
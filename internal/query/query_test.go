@@ -385,6 +385,27 @@ func TestEvaluatorSimpleQueries(t *testing.T) {
 				return f.EmptyDescription
 			},
 		},
+		{
+			name:  "acceptance empty",
+			query: "acceptance=none",
+			expectFilter: func(f *types.IssueFilter) bool {
+				return f.NoAcceptanceCriteria && f.AcceptanceContains == ""
+			},
+		},
+		{
+			name:  "design contains",
+			query: "design=cache",
+			expectFilter: func(f *types.IssueFilter) bool {
+				return f.DesignContains == "cache" && !f.NoDesign
+			},
+		},
+		{
+			name:  "notes empty",
+			query: "notes=none",
+			expectFilter: func(f *types.IssueFilter) bool {
+				return f.NoNotes && f.NotesContains == ""
+			},
+		},
 		{
 			name:  "pinned equals true",
 			query: "pinned=true",
@@ -536,13 +557,14 @@ func TestPredicateEvaluation(t *testing.T) {
 	}
 
 	blockedFeature := &types.Issue{
-		ID:        "bd-3",
-		Status:    types.StatusBlocked,
-		Priority:  0,
-		IssueType: types.TypeFeature,
-		Labels:    []string{},
-		CreatedAt: now.AddDate(0, 0, -2),
-		UpdatedAt: now,
+		ID:                 "bd-3",
+		Status:             types.StatusBlocked,
+		Priority:           0,
+		IssueType:          types.TypeFeature,
+		Labels:             []string{},
+		AcceptanceCriteria: "- [ ] Login works",
+		CreatedAt:          now.AddDate(0, 0, -2),
+		UpdatedAt:          now,
 	}
 
 	tests := []struct {
@@ -573,6 +595,12 @@ func TestPredicateEvaluation(t *testing.T) {
 		{"label=none matches unlabeled", "label=none", blockedFeature, true},
 		{"label=none doesn't match labeled", "label=none", openBug, false},
 
+		// Section tests
+		{"acceptance=none matches issue without criteria", "acceptance=none", openBug, true},
+		{"acceptance=none doesn't match issue with criteria", "acceptance=none", blockedFeature, false},
+		{"acceptance_criteria=login matches substring", "acceptance_criteria=LOGIN", blockedFeature, true},
+		{"acceptance!=none OR design=none matches open", "acceptance!=none OR design=none", openBug, true},
+
 		// OR tests
 		{"status=open OR status=blocked matches open", "status=open OR status=blocked", openBug, true},
 		{"status=open OR status=blocked matches blocked", "status=open OR status=blocked", blockedFeature, true},
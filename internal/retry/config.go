@@ -0,0 +1,55 @@
+package retry
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// RemoteFromConfig returns Remote with the attempts and waits configured in
+// the environment, then config.yaml:
+//
+//	dolt.retry-attempts     BEADS_DOLT_RETRY_ATTEMPTS     (default 3, 1 disables)
+//	dolt.retry-backoff      BEADS_DOLT_RETRY_BACKOFF      (default 2s)
+//	dolt.retry-max-backoff  BEADS_DOLT_RETRY_MAX_BACKOFF  (default 30s)
+func RemoteFromConfig() Policy {
+	p := Remote
+	if n := configInt("BEADS_DOLT_RETRY_ATTEMPTS", "dolt.retry-attempts"); n > 0 {
+		p.MaxAttempts = n
+	}
+	if d := configDuration("BEADS_DOLT_RETRY_BACKOFF", "dolt.retry-backoff"); d > 0 {
+		p.InitialInterval = d
+	}
+	if d := configDuration("BEADS_DOLT_RETRY_MAX_BACKOFF", "dolt.retry-max-backoff"); d > 0 {
+		p.MaxInterval = d
+	}
+	if p.MaxInterval < p.InitialInterval {
+		p.MaxInterval = p.InitialInterval
+	}
+	return p
+}
+
+// configInt reads a positive integer from env, then key; 0 if neither holds
+// one.
+func configInt(env, key string) int {
+	for _, v := range []string{os.Getenv(env), config.GetString(key)} {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// configDuration reads a positive duration ("2s", "1m") from env, then key;
+// 0 if neither holds one.
+func configDuration(env, key string) time.Duration {
+	for _, v := range []string{os.Getenv(env), config.GetString(key)} {
+		if d, err := time.ParseDuration(strings.TrimSpace(v)); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 0
+}
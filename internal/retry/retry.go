@@ -0,0 +1,200 @@
+// Package retry runs operations that can fail transiently — a dropped
+// connection, a remote that times out, a contended lock — with exponential
+// backoff and jitter.
+//
+// A caller picks a Policy (how often and how long to retry) and a classifier
+// (which errors are worth another attempt); any other error fails on the
+// first attempt. Server and Remote are the policies bd uses for SQL calls to
+// the Dolt server and for transfers to Dolt remotes and federation peers.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// Policy describes how an operation is retried.
+type Policy struct {
+	// MaxAttempts caps the number of attempts, counting the first. 0 means
+	// no cap: retries stop only at MaxElapsed. 1 disables retries.
+	MaxAttempts int
+	// InitialInterval is the wait before the second attempt.
+	InitialInterval time.Duration
+	// MaxInterval caps the wait between attempts.
+	MaxInterval time.Duration
+	// Multiplier grows the wait after each attempt.
+	Multiplier float64
+	// Jitter randomizes each wait by up to ±Jitter of its length (0.5 is
+	// ±50%), so processes that failed together do not retry in lockstep.
+	Jitter float64
+	// MaxElapsed stops retrying once this much time has passed since the
+	// first attempt. 0 means no limit.
+	MaxElapsed time.Duration
+	// OnRetry, if set, is called before each wait with the error that
+	// failed the attempt and the wait that follows.
+	OnRetry func(err error, wait time.Duration)
+}
+
+// Server retries SQL calls to the Dolt server across stale pool
+// connections, brief network blips, and server restarts: at most 30s in
+// all, with waits growing from 500ms.
+var Server = Policy{
+	InitialInterval: 500 * time.Millisecond,
+	MaxInterval:     10 * time.Second,
+	Multiplier:      1.5,
+	Jitter:          0.5,
+	MaxElapsed:      30 * time.Second,
+}
+
+// Remote retries push, pull, and fetch against Dolt remotes and federation
+// peers: three attempts, waiting about 2s and then 4s. Transfers are slow
+// and each attempt can take minutes, so attempts rather than elapsed time
+// bound it.
+var Remote = Policy{
+	MaxAttempts:     3,
+	InitialInterval: 2 * time.Second,
+	MaxInterval:     30 * time.Second,
+	Multiplier:      2,
+	Jitter:          0.5,
+}
+
+// permanentError marks an error that must not be retried whatever the
+// classifier says.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so Do returns it without retrying. Do returns err
+// itself, not the wrapper. Permanent(nil) is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Do calls op until it succeeds, returns an error retryable does not accept
+// (or one wrapped by Permanent), or the policy or ctx runs out. It returns
+// op's last error, or ctx's error if ctx ended first. A nil retryable
+// retries nothing.
+func Do(ctx context.Context, p Policy, retryable func(error) bool, op func() error) error {
+	var notify backoff.Notify
+	if p.OnRetry != nil {
+		notify = func(err error, wait time.Duration) { p.OnRetry(err, wait) }
+	}
+	return backoff.RetryNotify(func() error {
+		err := op()
+		if err == nil {
+			return nil
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return backoff.Permanent(perm.err)
+		}
+		if retryable == nil || !retryable(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, backoff.WithContext(p.backOff(), ctx), notify)
+}
+
+// backOff builds the backoff schedule for p.
+func (p Policy) backOff() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	if p.InitialInterval > 0 {
+		bo.InitialInterval = p.InitialInterval
+	}
+	if p.MaxInterval > 0 {
+		bo.MaxInterval = p.MaxInterval
+	}
+	if p.Multiplier > 0 {
+		bo.Multiplier = p.Multiplier
+	}
+	bo.RandomizationFactor = p.Jitter
+	bo.MaxElapsedTime = p.MaxElapsed
+	bo.Reset()
+	if p.MaxAttempts > 0 {
+		return backoff.WithMaxRetries(bo, uint64(p.MaxAttempts-1))
+	}
+	return bo
+}
+
+// Notice returns an OnRetry func that writes one warning line per retry of
+// what (e.g. "push origin") to w, so a command waiting out a backoff is not
+// mistaken for a hung one. maxAttempts is the policy's MaxAttempts.
+func Notice(w io.Writer, what string, maxAttempts int) func(err error, wait time.Duration) {
+	attempt := 1
+	return func(err error, wait time.Duration) {
+		attempt++
+		of := ""
+		if maxAttempts > 0 {
+			of = fmt.Sprintf(" of %d", maxAttempts)
+		}
+		fmt.Fprintf(w, "Warning: %s failed, retrying in %s (attempt %d%s): %v\n",
+			what, wait.Round(100*time.Millisecond), attempt, of, err)
+	}
+}
+
+// IsTransient reports whether err looks like a transient network failure:
+// a reset, refused, or timed-out connection, a temporary DNS failure, or an
+// overloaded or unavailable server (HTTP 429/502/503/504, gRPC Unavailable).
+// Cancellation of the caller's own context is never transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	return containsAny(err, transientMarkers)
+}
+
+// IsLockContention reports whether err says a lock was held by someone
+// else: a locked Dolt database, a lock wait timeout, or a deadlock the
+// server rolled back.
+func IsLockContention(err error) bool {
+	if err == nil {
+		return false
+	}
+	return containsAny(err, lockMarkers)
+}
+
+var transientMarkers = []string{
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"i/o timeout",
+	"tls handshake timeout",
+	"unexpected eof",
+	"temporary failure in name resolution",
+	"network is unreachable",
+	"no route to host",
+	"too many requests",
+	"bad gateway",
+	"service unavailable",
+	"gateway timeout",
+	"code = unavailable",
+	"code = resourceexhausted",
+}
+
+var lockMarkers = []string{
+	"database is locked",
+	"locked by another dolt process",
+	"lock wait timeout",
+	"deadlock found",
+	"serialization failure",
+}
+
+func containsAny(err error, markers []string) bool {
+	msg := strings.ToLower(err.Error())
+	for _, m := range markers {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
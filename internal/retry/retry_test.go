@@ -0,0 +1,137 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fast is a policy with waits short enough for tests.
+var fast = Policy{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Jitter: 0.5}
+
+func TestDoRetriesTransientErrors(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), fast, IsTransient, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("read tcp: connection reset by peer")
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("Do = %v after %d calls, want success after 3", err, calls)
+	}
+}
+
+func TestDoStopsAtMaxAttempts(t *testing.T) {
+	calls := 0
+	want := errors.New("i/o timeout")
+	err := Do(context.Background(), fast, IsTransient, func() error {
+		calls++
+		return want
+	})
+	if !errors.Is(err, want) || calls != 3 {
+		t.Errorf("Do = %v after %d calls, want %v after 3", err, calls, want)
+	}
+}
+
+func TestDoDoesNotRetryPermanentErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+	}{
+		{"unclassified", errors.New("authentication failed")},
+		{"wrapped", Permanent(errors.New("connection refused"))},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := Do(context.Background(), fast, IsTransient, func() error {
+				calls++
+				return tt.err
+			})
+			if calls != 1 {
+				t.Errorf("op called %d times, want 1", calls)
+			}
+			var perm *permanentError
+			if err == nil || errors.As(err, &perm) {
+				t.Errorf("Do = %#v, want the unwrapped error", err)
+			}
+		})
+	}
+}
+
+func TestDoHonorsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	slow := Policy{InitialInterval: time.Hour, MaxInterval: time.Hour}
+	calls := 0
+	err := Do(ctx, slow, IsTransient, func() error {
+		calls++
+		cancel()
+		return errors.New("connection refused")
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("Do = %v after %d calls, want context.Canceled after 1", err, calls)
+	}
+}
+
+func TestNotice(t *testing.T) {
+	var buf bytes.Buffer
+	p := fast
+	p.OnRetry = Notice(&buf, "push origin", p.MaxAttempts)
+	_ = Do(context.Background(), p, IsTransient, func() error {
+		return errors.New("503 Service Unavailable")
+	})
+	out := buf.String()
+	if strings.Count(out, "\n") != 2 || !strings.Contains(out, "push origin failed") || !strings.Contains(out, "attempt 3 of 3") {
+		t.Errorf("notices = %q, want two lines ending at attempt 3 of 3", out)
+	}
+}
+
+func TestClassifiers(t *testing.T) {
+	tests := []struct {
+		err       error
+		transient bool
+		lock      bool
+	}{
+		{errors.New("dial tcp 10.0.0.1:443: i/o timeout"), true, false},
+		{errors.New("rpc error: code = Unavailable desc = transport is closing"), true, false},
+		{errors.New("unexpected status 503 Service Unavailable"), true, false},
+		{errors.New("HTTP 429 Too Many Requests"), true, false},
+		{fmt.Errorf("push: %w", context.Canceled), false, false},
+		{errors.New("the database is locked by another dolt process"), false, true},
+		{errors.New("Error 1205: Lock wait timeout exceeded"), false, true},
+		{errors.New("failed to push: non-fast-forward"), false, false},
+		{errors.New("permission denied (publickey)"), false, false},
+		{nil, false, false},
+	}
+	for _, tt := range tests {
+		if got := IsTransient(tt.err); got != tt.transient {
+			t.Errorf("IsTransient(%v) = %v, want %v", tt.err, got, tt.transient)
+		}
+		if got := IsLockContention(tt.err); got != tt.lock {
+			t.Errorf("IsLockContention(%v) = %v, want %v", tt.err, got, tt.lock)
+		}
+	}
+}
+
+func TestRemoteFromConfig(t *testing.T) {
+	t.Setenv("BEADS_DOLT_RETRY_ATTEMPTS", "5")
+	t.Setenv("BEADS_DOLT_RETRY_BACKOFF", "1m")
+	t.Setenv("BEADS_DOLT_RETRY_MAX_BACKOFF", "")
+	p := RemoteFromConfig()
+	if p.MaxAttempts != 5 || p.InitialInterval != time.Minute {
+		t.Errorf("RemoteFromConfig = %+v, want 5 attempts from 1m", p)
+	}
+	if p.MaxInterval < p.InitialInterval {
+		t.Errorf("MaxInterval %s below InitialInterval %s", p.MaxInterval, p.InitialInterval)
+	}
+
+	t.Setenv("BEADS_DOLT_RETRY_ATTEMPTS", "zero")
+	if got := RemoteFromConfig().MaxAttempts; got != Remote.MaxAttempts {
+		t.Errorf("invalid BEADS_DOLT_RETRY_ATTEMPTS gave %d attempts, want default %d", got, Remote.MaxAttempts)
+	}
+}
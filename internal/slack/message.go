@@ -0,0 +1,103 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// StatusActionID identifies the status-change buttons on an issue message.
+// Each button's value is "<issue-id> <status>".
+const StatusActionID = "bd_set_status"
+
+// maxDescription bounds how much of an issue description is shown in a
+// message, well under Slack's 3000-character section limit.
+const maxDescription = 600
+
+// statusButtons lists the status changes offered for an issue, in order.
+var statusButtons = []struct {
+	label  string
+	status types.Status
+	style  string
+}{
+	{"Start", types.StatusInProgress, "primary"},
+	{"Block", types.StatusBlocked, ""},
+	{"Defer", types.StatusDeferred, ""},
+	{"Close", types.StatusClosed, "danger"},
+	{"Reopen", types.StatusOpen, ""},
+}
+
+// IssueBlocks renders an issue as Block Kit blocks: a summary section, its
+// fields, and buttons for the status changes that apply to it.
+func IssueBlocks(issue *types.Issue) []Block {
+	summary := fmt.Sprintf("*%s* %s", issue.ID, Escape(issue.Title))
+	if desc := strings.TrimSpace(issue.Description); desc != "" {
+		if len(desc) > maxDescription {
+			desc = strings.TrimSpace(desc[:maxDescription]) + "…"
+		}
+		summary += "\n" + Escape(desc)
+	}
+	assignee := issue.Assignee
+	if assignee == "" {
+		assignee = "unassigned"
+	}
+	blocks := []Block{
+		{Type: "section", Text: &Text{Type: "mrkdwn", Text: summary}},
+		{Type: "section", Fields: []Text{
+			{Type: "mrkdwn", Text: "*Status*\n" + string(issue.Status)},
+			{Type: "mrkdwn", Text: fmt.Sprintf("*Priority*\nP%d", issue.Priority)},
+			{Type: "mrkdwn", Text: "*Type*\n" + string(issue.IssueType)},
+			{Type: "mrkdwn", Text: "*Assignee*\n" + Escape(assignee)},
+		}},
+	}
+
+	var buttons []Element
+	for _, b := range statusButtons {
+		if !offerStatus(issue.Status, b.status) {
+			continue
+		}
+		buttons = append(buttons, Element{
+			Type:     "button",
+			Text:     &Text{Type: "plain_text", Text: b.label},
+			ActionID: StatusActionID + "_" + string(b.status),
+			Value:    issue.ID + " " + string(b.status),
+			Style:    b.style,
+		})
+	}
+	if len(buttons) > 0 {
+		blocks = append(blocks, Block{Type: "actions", BlockID: "bd_status " + issue.ID, Elements: buttons})
+	}
+	return blocks
+}
+
+// offerStatus reports whether a button moving an issue from current to next
+// makes sense: closed issues can only be reopened, and open ones cannot be.
+func offerStatus(current, next types.Status) bool {
+	if current == types.StatusClosed {
+		return next == types.StatusOpen
+	}
+	if next == types.StatusOpen {
+		return current != types.StatusOpen
+	}
+	return current != next
+}
+
+// ParseStatusAction reads the issue ID and target status from a status
+// button. ok is false for any other action.
+func ParseStatusAction(a Action) (id string, status types.Status, ok bool) {
+	if !strings.HasPrefix(a.ActionID, StatusActionID) {
+		return "", "", false
+	}
+	id, raw, found := strings.Cut(a.Value, " ")
+	status = types.Status(raw)
+	if !found || id == "" || !status.IsValid() {
+		return "", "", false
+	}
+	return id, status, true
+}
+
+// Escape escapes the characters Slack treats as markup in message text.
+func Escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
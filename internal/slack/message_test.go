@@ -0,0 +1,182 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseSlashCommand(t *testing.T) {
+	form := url.Values{
+		"command":      {"/bd"},
+		"text":         {"  create Fix login -p 1 "},
+		"user_id":      {"U024BE7LH"},
+		"user_name":    {"alice"},
+		"response_url": {"https://hooks.slack.com/commands/T1/2/3"},
+	}
+	cmd, err := ParseSlashCommand(form)
+	if err != nil {
+		t.Fatalf("ParseSlashCommand: %v", err)
+	}
+	if cmd.Text != "create Fix login -p 1" || cmd.UserID != "U024BE7LH" || cmd.UserName != "alice" {
+		t.Errorf("unexpected command: %+v", cmd)
+	}
+
+	if _, err := ParseSlashCommand(url.Values{"text": {"show bd-1"}}); err == nil {
+		t.Error("expected an error for a form without command and user_id")
+	}
+}
+
+func TestParseInteraction(t *testing.T) {
+	payload := `{"type":"block_actions","user":{"id":"U1","username":"bob"},
+		"response_url":"https://hooks.slack.com/actions/T1/2/3",
+		"actions":[{"action_id":"bd_set_status_in_progress","block_id":"bd_status bd-7","value":"bd-7 in_progress"}]}`
+	in, err := ParseInteraction(url.Values{"payload": {payload}})
+	if err != nil {
+		t.Fatalf("ParseInteraction: %v", err)
+	}
+	if in.Type != "block_actions" || in.User.Username != "bob" || len(in.Actions) != 1 {
+		t.Fatalf("unexpected interaction: %+v", in)
+	}
+	id, status, ok := ParseStatusAction(in.Actions[0])
+	if !ok || id != "bd-7" || status != types.StatusInProgress {
+		t.Errorf("ParseStatusAction = %q, %q, %v", id, status, ok)
+	}
+
+	if _, err := ParseInteraction(url.Values{}); err == nil {
+		t.Error("expected an error for a missing payload")
+	}
+	if _, err := ParseInteraction(url.Values{"payload": {"{"}}); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
+
+func TestParseStatusActionRejects(t *testing.T) {
+	for _, a := range []Action{
+		{ActionID: "other", Value: "bd-1 closed"},
+		{ActionID: StatusActionID + "_closed", Value: "bd-1"},
+		{ActionID: StatusActionID + "_bogus", Value: "bd-1 bogus"},
+		{ActionID: StatusActionID + "_closed", Value: " closed"},
+	} {
+		if _, _, ok := ParseStatusAction(a); ok {
+			t.Errorf("ParseStatusAction(%+v) accepted", a)
+		}
+	}
+}
+
+func buttonStatuses(blocks []Block) []string {
+	var out []string
+	for _, b := range blocks {
+		if b.Type != "actions" {
+			continue
+		}
+		for _, e := range b.Elements {
+			_, status, _ := ParseStatusAction(Action{ActionID: e.ActionID, Value: e.Value})
+			out = append(out, string(status))
+		}
+	}
+	return out
+}
+
+func TestIssueBlocks(t *testing.T) {
+	issue := &types.Issue{
+		ID:          "bd-7",
+		Title:       "Fix <script> & login",
+		Description: strings.Repeat("x", 2*maxDescription),
+		Status:      types.StatusOpen,
+		Priority:    1,
+		IssueType:   types.TypeBug,
+	}
+	blocks := IssueBlocks(issue)
+	summary := blocks[0].Text.Text
+	if !strings.Contains(summary, "*bd-7* Fix &lt;script&gt; &amp; login") {
+		t.Errorf("summary not escaped: %q", summary)
+	}
+	if len(summary) > maxDescription+100 {
+		t.Errorf("description not truncated: %d bytes", len(summary))
+	}
+	if got := strings.Join(buttonStatuses(blocks), ","); got != "in_progress,blocked,deferred,closed" {
+		t.Errorf("open issue buttons = %s", got)
+	}
+
+	issue.Status = types.StatusClosed
+	if got := strings.Join(buttonStatuses(IssueBlocks(issue)), ","); got != "open" {
+		t.Errorf("closed issue buttons = %s", got)
+	}
+	issue.Status = types.StatusInProgress
+	if got := strings.Join(buttonStatuses(IssueBlocks(issue)), ","); got != "blocked,deferred,closed,open" {
+		t.Errorf("in-progress issue buttons = %s", got)
+	}
+
+	// Blocks must serialize to the shape Block Kit expects.
+	raw, err := json.Marshal(blocks[len(blocks)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `"text":{"type":"plain_text","text":"Start"}`) {
+		t.Errorf("unexpected button JSON: %s", raw)
+	}
+}
+
+func TestUserMap(t *testing.T) {
+	m, err := ParseUserMap("U024BE7LH=alice, U0G9QF9C6 = bob ,")
+	if err != nil {
+		t.Fatalf("ParseUserMap: %v", err)
+	}
+	if got := m.Actor("U0G9QF9C6", "robert"); got != "bob" {
+		t.Errorf("mapped actor = %q, want bob", got)
+	}
+	if got := m.Actor("U999", "carol"); got != "slack:carol" {
+		t.Errorf("unmapped actor = %q, want slack:carol", got)
+	}
+	if got := m.Actor("U999", ""); got != "slack:U999" {
+		t.Errorf("unmapped actor without name = %q, want slack:U999", got)
+	}
+	for _, bad := range []string{"U1", "=alice", "U1="} {
+		if _, err := ParseUserMap(bad); err == nil {
+			t.Errorf("ParseUserMap(%q) accepted", bad)
+		}
+	}
+}
+
+func TestValidResponseURL(t *testing.T) {
+	for u, want := range map[string]bool{
+		"https://hooks.slack.com/actions/T1/2/3": true,
+		"https://slack.com/api/x":                true,
+		"http://hooks.slack.com/actions/T1/2/3":  false,
+		"https://hooks.slack.com.evil.test/x":    false,
+		"https://evilslack.com/x":                false,
+		"":                                       false,
+	} {
+		if got := ValidResponseURL(u); got != want {
+			t.Errorf("ValidResponseURL(%q) = %v, want %v", u, got, want)
+		}
+	}
+}
+
+func TestPostResponse(t *testing.T) {
+	var got Message
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("bad body: %v", err)
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	msg := &Message{Text: "bd-7 closed", ReplaceOriginal: true}
+	if err := PostResponse(context.Background(), srv.Client(), srv.URL, msg); err != nil {
+		t.Fatalf("PostResponse: %v", err)
+	}
+	if got.Text != "bd-7 closed" || !got.ReplaceOriginal {
+		t.Errorf("posted %+v", got)
+	}
+}
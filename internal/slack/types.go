@@ -0,0 +1,168 @@
+// Package slack implements the Slack side of the bd serve integration: slash
+// command and interactive payload parsing, request signature verification,
+// and the Block Kit messages used to show issues in a channel.
+//
+// Slack POSTs slash commands ("/bd create ...") and button clicks to
+// endpoints served by bd serve as form-encoded requests signed with the
+// app's signing secret. Every request is verified before it is parsed, and
+// Slack user IDs are mapped to beads actors through a UserMap so changes made
+// from Slack are attributed to the right person.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SlashCommand is a slash command invocation.
+type SlashCommand struct {
+	Command     string // the command as typed, e.g. "/bd"
+	Text        string // everything after the command
+	UserID      string
+	UserName    string
+	TeamID      string
+	ChannelID   string
+	ResponseURL string
+}
+
+// ParseSlashCommand reads a slash command from its form-encoded request body.
+func ParseSlashCommand(form url.Values) (*SlashCommand, error) {
+	cmd := &SlashCommand{
+		Command:     form.Get("command"),
+		Text:        strings.TrimSpace(form.Get("text")),
+		UserID:      form.Get("user_id"),
+		UserName:    form.Get("user_name"),
+		TeamID:      form.Get("team_id"),
+		ChannelID:   form.Get("channel_id"),
+		ResponseURL: form.Get("response_url"),
+	}
+	if cmd.Command == "" || cmd.UserID == "" {
+		return nil, fmt.Errorf("not a Slack slash command: missing command or user_id")
+	}
+	return cmd, nil
+}
+
+// User identifies the Slack user behind an interaction.
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+// Action is one element of an interaction, such as a clicked button.
+type Action struct {
+	ActionID string `json:"action_id"`
+	BlockID  string `json:"block_id,omitempty"`
+	Value    string `json:"value,omitempty"`
+}
+
+// Interaction is an interactive payload, sent when a user clicks a button on
+// a message the app posted.
+type Interaction struct {
+	Type        string   `json:"type"` // "block_actions" for button clicks
+	User        User     `json:"user"`
+	Actions     []Action `json:"actions"`
+	ResponseURL string   `json:"response_url,omitempty"`
+}
+
+// ParseInteraction reads an interactive payload, which Slack sends as JSON in
+// the "payload" field of a form-encoded body.
+func ParseInteraction(form url.Values) (*Interaction, error) {
+	raw := form.Get("payload")
+	if raw == "" {
+		return nil, fmt.Errorf("not a Slack interaction: missing payload")
+	}
+	var in Interaction
+	if err := json.Unmarshal([]byte(raw), &in); err != nil {
+		return nil, fmt.Errorf("decoding Slack interaction: %w", err)
+	}
+	if in.User.ID == "" {
+		return nil, fmt.Errorf("not a Slack interaction: missing user")
+	}
+	return &in, nil
+}
+
+// Response types for Message.ResponseType.
+const (
+	ResponseEphemeral = "ephemeral"  // visible only to the invoking user
+	ResponseInChannel = "in_channel" // visible to everyone in the channel
+)
+
+// Message is a reply to a slash command or interaction.
+type Message struct {
+	ResponseType    string  `json:"response_type,omitempty"`
+	Text            string  `json:"text"`
+	Blocks          []Block `json:"blocks,omitempty"`
+	ReplaceOriginal bool    `json:"replace_original,omitempty"`
+}
+
+// Block is a Block Kit layout block. Only the fields bd uses are modeled.
+type Block struct {
+	Type     string    `json:"type"` // "section" or "actions"
+	BlockID  string    `json:"block_id,omitempty"`
+	Text     *Text     `json:"text,omitempty"`
+	Fields   []Text    `json:"fields,omitempty"`
+	Elements []Element `json:"elements,omitempty"`
+}
+
+// Text is a Block Kit text object.
+type Text struct {
+	Type string `json:"type"` // "mrkdwn" or "plain_text"
+	Text string `json:"text"`
+}
+
+// Element is an interactive element of an actions block. bd only uses
+// buttons.
+type Element struct {
+	Type     string `json:"type"` // "button"
+	Text     *Text  `json:"text"`
+	ActionID string `json:"action_id,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Style    string `json:"style,omitempty"` // "primary" or "danger"
+}
+
+// Ephemeral returns a plain-text reply only the invoking user sees.
+func Ephemeral(format string, args ...any) *Message {
+	return &Message{ResponseType: ResponseEphemeral, Text: fmt.Sprintf(format, args...)}
+}
+
+// ValidResponseURL reports whether u is a Slack response URL, so replies are
+// never posted to a host taken from an unverified payload.
+func ValidResponseURL(u string) bool {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Scheme != "https" {
+		return false
+	}
+	host := parsed.Hostname()
+	return host == "slack.com" || strings.HasSuffix(host, ".slack.com")
+}
+
+// PostResponse sends msg to an interaction's response URL. Replies to button
+// clicks must go through the response URL; the HTTP response body is ignored.
+func PostResponse(ctx context.Context, client *http.Client, responseURL string, msg *Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting Slack response: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("posting Slack response: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
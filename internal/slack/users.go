@@ -0,0 +1,41 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UserMap maps Slack user IDs (such as "U024BE7LH") to beads actor names.
+type UserMap map[string]string
+
+// ParseUserMap parses a "U024BE7LH=alice,U0G9QF9C6=bob" mapping, as stored in
+// the slack.users config key.
+func ParseUserMap(spec string) (UserMap, error) {
+	m := UserMap{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, actor, ok := strings.Cut(part, "=")
+		id, actor = strings.TrimSpace(id), strings.TrimSpace(actor)
+		if !ok || id == "" || actor == "" {
+			return nil, fmt.Errorf("invalid Slack user mapping %q (want SLACK_USER_ID=actor)", part)
+		}
+		m[id] = actor
+	}
+	return m, nil
+}
+
+// Actor returns the beads actor for a Slack user. Unmapped users act as
+// "slack:<username>" (or "slack:<id>" when Slack sent no username) so their
+// changes are still traceable but never impersonate a real actor.
+func (m UserMap) Actor(userID, userName string) string {
+	if actor, ok := m[userID]; ok {
+		return actor
+	}
+	if userName != "" {
+		return "slack:" + userName
+	}
+	return "slack:" + userID
+}
@@ -0,0 +1,56 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// MaxRequestAge is how old a signed request may be before it is rejected as a
+// possible replay.
+const MaxRequestAge = 5 * time.Minute
+
+// Request signature headers.
+const (
+	HeaderTimestamp = "X-Slack-Request-Timestamp"
+	HeaderSignature = "X-Slack-Signature"
+)
+
+// ErrInvalidSignature is returned when a request was not signed with the
+// app's signing secret.
+var ErrInvalidSignature = errors.New("invalid Slack request signature")
+
+// VerifyRequest checks a request's v0 signature: an HMAC-SHA256, keyed by the
+// signing secret, of "v0:<timestamp>:<body>". Requests older than
+// MaxRequestAge are rejected even when correctly signed.
+func VerifyRequest(secret string, header http.Header, body []byte, now time.Time) error {
+	if secret == "" {
+		return fmt.Errorf("no Slack signing secret configured")
+	}
+	ts := header.Get(HeaderTimestamp)
+	secs, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: bad timestamp", ErrInvalidSignature)
+	}
+	if age := now.Sub(time.Unix(secs, 0)); age > MaxRequestAge || age < -MaxRequestAge {
+		return fmt.Errorf("%w: request timestamp too old", ErrInvalidSignature)
+	}
+	want := Sign(secret, ts, body)
+	if !hmac.Equal([]byte(header.Get(HeaderSignature)), []byte(want)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign returns the v0 signature Slack sends for body at timestamp ts.
+func Sign(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:", ts)
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
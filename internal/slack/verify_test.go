@@ -0,0 +1,61 @@
+package slack
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func signedHeader(secret string, ts time.Time, body []byte) http.Header {
+	h := http.Header{}
+	stamp := strconv.FormatInt(ts.Unix(), 10)
+	h.Set(HeaderTimestamp, stamp)
+	h.Set(HeaderSignature, Sign(secret, stamp, body))
+	return h
+}
+
+func TestSign(t *testing.T) {
+	// Example from Slack's "Verifying requests from Slack" documentation.
+	body := []byte("token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&team_domain=testteamnow&channel_id=G8PSS9T3V&channel_name=foobar&user_id=U2CERLKJA&user_name=roadrunner&command=%2Fwebhook-collect&text=&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2FT1DC2JH3J%2F397700885554%2F96rGlfmibIGlgcZRskXaIFfN&trigger_id=398738663015.47445629121.803a0bc887a14d10d2c447fce8b6703c")
+	got := Sign("8f742231b10e8888abcd99yyyzzz85a5", "1531420618", body)
+	want := "v0=a2114d57b48eac39b9ad189dd8316235a7b4a8d21a10bd27519666489c69b503"
+	if got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestVerifyRequest(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte("command=%2Fbd&text=show+bd-1&user_id=U1")
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  http.Header
+		body    []byte
+		wantErr bool
+	}{
+		{name: "valid", secret: "s3cret", header: signedHeader("s3cret", now, body), body: body},
+		{name: "slight clock skew", secret: "s3cret", header: signedHeader("s3cret", now.Add(time.Minute), body), body: body},
+		{name: "wrong secret", secret: "s3cret", header: signedHeader("other", now, body), body: body, wantErr: true},
+		{name: "tampered body", secret: "s3cret", header: signedHeader("s3cret", now, body), body: []byte("command=%2Fbd&text=close+bd-1&user_id=U1"), wantErr: true},
+		{name: "replayed", secret: "s3cret", header: signedHeader("s3cret", now.Add(-10*time.Minute), body), body: body, wantErr: true},
+		{name: "missing headers", secret: "s3cret", header: http.Header{}, body: body, wantErr: true},
+		{name: "no secret", secret: "", header: signedHeader("", now, body), body: body, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyRequest(tt.secret, tt.header, tt.body, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := VerifyRequest("s3cret", signedHeader("other", now, body), body, now)
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong secret error = %v, want ErrInvalidSignature", err)
+	}
+}
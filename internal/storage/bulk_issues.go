@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -10,6 +11,12 @@ import (
 // BulkIssueStore provides extended issue CRUD beyond the base Storage interface.
 type BulkIssueStore interface {
 	CreateIssuesWithFullOptions(ctx context.Context, issues []*types.Issue, actor string, opts BatchCreateOptions) error
+	// UpsertIssues inserts issues that do not exist yet and overwrites the
+	// ones that do, writing the rows with one multi-row INSERT ... ON
+	// DUPLICATE KEY UPDATE per batch. An issue that fails validation is
+	// reported in the result's Failed list and the rest are still written;
+	// an error means nothing was written.
+	UpsertIssues(ctx context.Context, issues []*types.Issue, actor string) (*UpsertIssuesResult, error)
 	DeleteIssues(ctx context.Context, ids []string, cascade bool, force bool, dryRun bool) (*types.DeleteIssuesResult, error)
 	DeleteIssuesBySourceRepo(ctx context.Context, sourceRepo string) (int, error)
 	UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error
@@ -26,3 +33,29 @@ type BulkIssueStore interface {
 	PromoteFromEphemeral(ctx context.Context, id string, actor string) error
 	GetNextChildID(ctx context.Context, parentID string) (string, error)
 }
+
+// UpsertIssuesResult reports what UpsertIssues did with each issue. Created
+// and Updated list IDs in input order.
+type UpsertIssuesResult struct {
+	Created []string
+	Updated []string
+	Failed  []UpsertFailure
+}
+
+// UpsertFailure is an issue UpsertIssues refused without writing.
+type UpsertFailure struct {
+	Index int    // position in the input slice
+	ID    string // empty when the issue had no ID and none was generated
+	Err   error
+}
+
+// Error implements error so a failure can be returned or wrapped directly.
+func (f UpsertFailure) Error() string {
+	if f.ID == "" {
+		return fmt.Sprintf("issue %d: %v", f.Index, f.Err)
+	}
+	return fmt.Sprintf("%s: %v", f.ID, f.Err)
+}
+
+// Unwrap returns the underlying cause.
+func (f UpsertFailure) Unwrap() error { return f.Err }
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Clock supplies the current time to storage operations. Timestamps
+// (created/updated/closed), defer and overdue checks, and ready-work recency
+// all read it, so tests and simulations can pin or advance time.
+type Clock interface {
+	Now() time.Time
+}
+
+// IDGenerator mints hash-mode issue IDs. Explicit IDs, child IDs, and
+// counter-mode IDs bypass it. nonce increments on each collision retry for the
+// same length; a generator must return a different ID for a different nonce
+// or length, or creation fails after exhausting retries.
+type IDGenerator interface {
+	GenerateID(prefix string, issue *types.Issue, actor string, length, nonce int) string
+}
+
+type clockContextKey struct{}
+
+type idGeneratorContextKey struct{}
+
+type storeDefaultsContextKey struct{}
+
+// storeDefaults is the clock and ID generator a store was opened with.
+type storeDefaults struct {
+	clock Clock
+	idGen IDGenerator
+}
+
+// WithClock returns a context whose storage operations read time from c,
+// overriding the clock the store was opened with for calls using ctx.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockContextKey{}, c)
+}
+
+// WithIDGenerator returns a context whose issue creations mint hash-mode IDs
+// with g, overriding the generator the store was opened with for calls
+// using ctx.
+func WithIDGenerator(ctx context.Context, g IDGenerator) context.Context {
+	return context.WithValue(ctx, idGeneratorContextKey{}, g)
+}
+
+// WithStoreDefaults returns ctx carrying the clock and ID generator a store
+// was opened with, for the store's own operations to pass down. A WithClock
+// or WithIDGenerator override on ctx still wins. When both are nil, ctx is
+// returned unchanged.
+func WithStoreDefaults(ctx context.Context, c Clock, g IDGenerator) context.Context {
+	if c == nil && g == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, storeDefaultsContextKey{}, storeDefaults{clock: c, idGen: g})
+}
+
+// Now returns the current UTC time from ctx's clock override, else the
+// store's clock, else time.Now.
+func Now(ctx context.Context) time.Time {
+	if c, ok := ctx.Value(clockContextKey{}).(Clock); ok && c != nil {
+		return c.Now().UTC()
+	}
+	if d, ok := ctx.Value(storeDefaultsContextKey{}).(storeDefaults); ok && d.clock != nil {
+		return d.clock.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+// IDGeneratorFrom returns ctx's IDGenerator override, else the store's, or
+// nil when issue IDs should use the default content hash.
+func IDGeneratorFrom(ctx context.Context) IDGenerator {
+	if g, ok := ctx.Value(idGeneratorContextKey{}).(IDGenerator); ok && g != nil {
+		return g
+	}
+	d, _ := ctx.Value(storeDefaultsContextKey{}).(storeDefaults)
+	return d.idGen
+}
+
+// GenerateIssueID returns a candidate hash-mode ID for issue: from the
+// IDGenerator IDGeneratorFrom finds, otherwise the content hash of title,
+// description, actor, and CreatedAt.
+func GenerateIssueID(ctx context.Context, prefix string, issue *types.Issue, actor string, length, nonce int) string {
+	if g := IDGeneratorFrom(ctx); g != nil {
+		return g.GenerateID(prefix, issue, actor, length, nonce)
+	}
+	return idgen.GenerateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+}
+
+// FakeClock is a Clock that only moves when told to. Safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a FakeClock frozen at t.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// SequentialIDGenerator mints prefix-1, prefix-2, ... per prefix, ignoring
+// issue content, so runs produce the same IDs regardless of titles or
+// timestamps. Safe for concurrent use.
+type SequentialIDGenerator struct {
+	mu   sync.Mutex
+	next map[string]int
+}
+
+// NewSequentialIDGenerator returns a generator whose first ID for every
+// prefix is prefix-1.
+func NewSequentialIDGenerator() *SequentialIDGenerator {
+	return &SequentialIDGenerator{next: make(map[string]int)}
+}
+
+// GenerateID returns the next sequential ID for prefix. Collision retries
+// simply advance the sequence.
+func (g *SequentialIDGenerator) GenerateID(prefix string, _ *types.Issue, _ string, _, _ int) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.next[prefix]++
+	return fmt.Sprintf("%s-%d", prefix, g.next[prefix])
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestNowUsesContextClock(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now().UTC()
+	if got := Now(ctx); got.Before(before) || got.Location() != time.UTC {
+		t.Errorf("Now without clock = %v, want system UTC time", got)
+	}
+
+	start := time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("EST", -5*3600))
+	clock := NewFakeClock(start)
+	ctx = WithClock(ctx, clock)
+	if got := Now(ctx); !got.Equal(start) || got.Location() != time.UTC {
+		t.Errorf("Now = %v, want %v in UTC", got, start)
+	}
+	clock.Advance(90 * time.Minute)
+	if got, want := Now(ctx), start.Add(90*time.Minute); !got.Equal(want) {
+		t.Errorf("after Advance, Now = %v, want %v", got, want)
+	}
+	clock.Set(start)
+	if got := Now(ctx); !got.Equal(start) {
+		t.Errorf("after Set, Now = %v, want %v", got, start)
+	}
+}
+
+func TestGenerateIssueID(t *testing.T) {
+	issue := &types.Issue{Title: "t", CreatedAt: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	ctx := context.Background()
+	hashed := GenerateIssueID(ctx, "bd", issue, "alice", 6, 0)
+	if !strings.HasPrefix(hashed, "bd-") || len(hashed) != len("bd-")+6 {
+		t.Errorf("default ID = %q, want 6-char hash", hashed)
+	}
+	if again := GenerateIssueID(ctx, "bd", issue, "alice", 6, 0); again != hashed {
+		t.Errorf("hash ID not deterministic: %q vs %q", again, hashed)
+	}
+
+	ctx = WithIDGenerator(ctx, NewSequentialIDGenerator())
+	for _, want := range []string{"bd-1", "bd-2"} {
+		if got := GenerateIssueID(ctx, "bd", issue, "alice", 6, 0); got != want {
+			t.Errorf("sequential ID = %q, want %q", got, want)
+		}
+	}
+	if got := GenerateIssueID(ctx, "ops", issue, "alice", 6, 0); got != "ops-1" {
+		t.Errorf("sequential ID for new prefix = %q, want ops-1", got)
+	}
+}
+
+func TestStoreDefaultsYieldToContextOverride(t *testing.T) {
+	storeTime := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := WithStoreDefaults(context.Background(), NewFakeClock(storeTime), NewSequentialIDGenerator())
+	if got := Now(ctx); !got.Equal(storeTime) {
+		t.Errorf("Now = %v, want store clock %v", got, storeTime)
+	}
+	issue := &types.Issue{Title: "t"}
+	if got := GenerateIssueID(ctx, "bd", issue, "alice", 6, 0); got != "bd-1" {
+		t.Errorf("ID = %q, want store generator's bd-1", got)
+	}
+
+	override := storeTime.Add(24 * time.Hour)
+	ctx = WithClock(ctx, NewFakeClock(override))
+	if got := Now(ctx); !got.Equal(override) {
+		t.Errorf("Now = %v, want override %v", got, override)
+	}
+	overrideGen := NewSequentialIDGenerator()
+	overrideGen.GenerateID("bd", issue, "", 0, 0)
+	overrideGen.GenerateID("bd", issue, "", 0, 0)
+	ctx = WithIDGenerator(ctx, overrideGen)
+	if got := GenerateIssueID(ctx, "bd", issue, "alice", 6, 0); got != "bd-3" {
+		t.Errorf("ID = %q, want override generator's bd-3", got)
+	}
+}
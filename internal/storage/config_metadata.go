@@ -10,6 +10,9 @@ import (
 type ConfigMetadataStore interface {
 	GetMetadata(ctx context.Context, key string) (string, error)
 	SetMetadata(ctx context.Context, key, value string) error
+	// DeleteMetadataPrefix removes every metadata row whose key starts with
+	// prefix and returns how many it removed.
+	DeleteMetadataPrefix(ctx context.Context, prefix string) (int, error)
 	DeleteConfig(ctx context.Context, key string) error
 	GetCustomStatuses(ctx context.Context) ([]string, error)
 	GetCustomStatusesDetailed(ctx context.Context) ([]types.CustomStatus, error)
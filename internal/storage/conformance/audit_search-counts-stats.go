@@ -377,7 +377,7 @@ func testAuditSearchSortByClosedNullsLast(t *testing.T, f Factory) {
 	must(t, s.CreateIssue(c, withDefaults(&types.Issue{ID: "cs-closed-new", Title: "cn", Status: types.StatusClosed, ClosedAt: &tNew}), "a"))
 	must(t, s.CreateIssue(c, withDefaults(&types.Issue{ID: "cs-closed-old", Title: "co", Status: types.StatusClosed, ClosedAt: &tOld}), "a"))
 
-	results, err := s.SearchIssues(c, "", types.IssueFilter{SortBy: "closed"})
+	results, err := s.SearchIssues(c, "", types.IssueFilter{SortBy: []types.SortKey{{Field: "closed", Desc: true}}})
 	must(t, err)
 	want := []string{"cs-closed-new", "cs-closed-old", "cs-open-a", "cs-open-b"}
 	if got := orderedIDs(results); !reflect.DeepEqual(got, want) {
@@ -0,0 +1,160 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Injected clock. A store opened with a Clock must stamp every write with it,
+// whichever path the write takes (retried write tx, read tx, bare wisp tx, or
+// RunInTransaction), without the caller putting anything on the context.
+
+// ClockFactory creates a fresh, empty store that reads the time from clock,
+// initialized like a Factory store.
+type ClockFactory func(t *testing.T, clock storage.Clock) storage.DoltStorage
+
+// clockEpoch is far enough from the wall clock that a write stamped with
+// time.Now cannot pass for one stamped with the injected clock.
+var clockEpoch = time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+
+// RunClock checks that every write path stamps the store's injected clock.
+func RunClock(t *testing.T, factory ClockFactory) {
+	t.Helper()
+	newStore := func(t *testing.T) (storage.DoltStorage, *storage.FakeClock) {
+		clock := storage.NewFakeClock(clockEpoch)
+		return factory(t, clock), clock
+	}
+
+	t.Run("CreateIssue", func(t *testing.T) {
+		s, _ := newStore(t)
+		must(t, s.CreateIssue(ctx(), withDefaults(&types.Issue{ID: "test-c1", Title: "T"}), "a"))
+		got, err := s.GetIssue(ctx(), "test-c1")
+		must(t, err)
+		wantTime(t, "created_at", got.CreatedAt, clockEpoch)
+		wantTime(t, "updated_at", got.UpdatedAt, clockEpoch)
+	})
+
+	t.Run("CreateIssues", func(t *testing.T) {
+		s, _ := newStore(t)
+		must(t, s.CreateIssues(ctx(), []*types.Issue{
+			withDefaults(&types.Issue{ID: "test-c1", Title: "A"}),
+			withDefaults(&types.Issue{ID: "test-c2", Title: "B"}),
+		}, "a"))
+		for _, id := range []string{"test-c1", "test-c2"} {
+			got, err := s.GetIssue(ctx(), id)
+			must(t, err)
+			wantTime(t, id+" created_at", got.CreatedAt, clockEpoch)
+		}
+	})
+
+	t.Run("UpdateCloseReopen", func(t *testing.T) {
+		s, clock := newStore(t)
+		must(t, s.CreateIssue(ctx(), withDefaults(&types.Issue{ID: "test-c1", Title: "T"}), "a"))
+
+		clock.Advance(time.Hour)
+		must(t, s.UpdateIssue(ctx(), "test-c1", map[string]interface{}{"title": "T2"}, "a"))
+		got, err := s.GetIssue(ctx(), "test-c1")
+		must(t, err)
+		wantTime(t, "updated_at after update", got.UpdatedAt, clock.Now())
+		wantTime(t, "created_at after update", got.CreatedAt, clockEpoch)
+
+		clock.Advance(time.Hour)
+		must(t, s.CloseIssue(ctx(), "test-c1", "done", "a", ""))
+		got, err = s.GetIssue(ctx(), "test-c1")
+		must(t, err)
+		if got.ClosedAt == nil {
+			t.Fatal("closed_at not set")
+		}
+		wantTime(t, "closed_at", *got.ClosedAt, clock.Now())
+
+		clock.Advance(time.Hour)
+		must(t, s.ReopenIssue(ctx(), "test-c1", "", "a"))
+		got, err = s.GetIssue(ctx(), "test-c1")
+		must(t, err)
+		wantTime(t, "updated_at after reopen", got.UpdatedAt, clock.Now())
+	})
+
+	t.Run("ClaimAndReclaim", func(t *testing.T) {
+		s, clock := newStore(t)
+		must(t, s.CreateIssue(ctx(), withDefaults(&types.Issue{ID: "test-c1", Title: "T"}), "a"))
+		must(t, s.ClaimIssue(ctx(), "test-c1", "worker"))
+		got, err := s.GetIssue(ctx(), "test-c1")
+		must(t, err)
+		if got.StartedAt == nil {
+			t.Fatal("started_at not set")
+		}
+		wantTime(t, "started_at", *got.StartedAt, clockEpoch)
+
+		// The lease only looks expired if both the claim and the reclaim
+		// read the injected clock.
+		clock.Advance(time.Hour)
+		reclaimed, err := s.ReclaimExpiredLeases(ctx(), 0, "reaper")
+		must(t, err)
+		if len(reclaimed) != 1 || reclaimed[0].ID != "test-c1" {
+			t.Errorf("reclaimed = %+v, want test-c1", reclaimed)
+		}
+	})
+
+	t.Run("CommentAndDependency", func(t *testing.T) {
+		s, clock := newStore(t)
+		must(t, s.CreateIssue(ctx(), withDefaults(&types.Issue{ID: "test-c1", Title: "A"}), "a"))
+		must(t, s.CreateIssue(ctx(), withDefaults(&types.Issue{ID: "test-c2", Title: "B"}), "a"))
+
+		clock.Advance(time.Hour)
+		c, err := s.AddIssueComment(ctx(), "test-c1", "a", "hello")
+		must(t, err)
+		wantTime(t, "comment created_at", c.CreatedAt, clock.Now())
+
+		must(t, s.AddDependency(ctx(), &types.Dependency{IssueID: "test-c1", DependsOnID: "test-c2", Type: types.DepBlocks}, "a"))
+		deps, err := s.GetDependencyRecords(ctx(), "test-c1")
+		must(t, err)
+		if len(deps) != 1 {
+			t.Fatalf("dependencies = %d, want 1", len(deps))
+		}
+		wantTime(t, "dependency created_at", deps[0].CreatedAt, clock.Now())
+	})
+
+	t.Run("Wisp", func(t *testing.T) {
+		s, clock := newStore(t)
+		must(t, s.CreateIssue(ctx(), withDefaults(&types.Issue{ID: "test-w1", Title: "W", Ephemeral: true}), "a"))
+		got, err := s.GetIssue(ctx(), "test-w1")
+		must(t, err)
+		wantTime(t, "wisp created_at", got.CreatedAt, clockEpoch)
+
+		clock.Advance(time.Hour)
+		must(t, s.UpdateIssue(ctx(), "test-w1", map[string]interface{}{"title": "W2"}, "a"))
+		got, err = s.GetIssue(ctx(), "test-w1")
+		must(t, err)
+		wantTime(t, "wisp updated_at", got.UpdatedAt, clock.Now())
+
+		clock.Advance(time.Hour)
+		must(t, s.CloseIssue(ctx(), "test-w1", "done", "a", ""))
+		got, err = s.GetIssue(ctx(), "test-w1")
+		must(t, err)
+		if got.ClosedAt == nil {
+			t.Fatal("wisp closed_at not set")
+		}
+		wantTime(t, "wisp closed_at", *got.ClosedAt, clock.Now())
+	})
+
+	t.Run("RunInTransaction", func(t *testing.T) {
+		s, _ := newStore(t)
+		must(t, s.RunInTransaction(ctx(), "create test-c1", func(tx storage.Transaction) error {
+			return tx.CreateIssue(ctx(), withDefaults(&types.Issue{ID: "test-c1", Title: "T"}), "a")
+		}))
+		got, err := s.GetIssue(ctx(), "test-c1")
+		must(t, err)
+		wantTime(t, "created_at in transaction", got.CreatedAt, clockEpoch)
+	})
+}
+
+// wantTime fails unless got is want, to the second (the columns are DATETIME).
+func wantTime(t *testing.T, what string, got, want time.Time) {
+	t.Helper()
+	if !got.UTC().Truncate(time.Second).Equal(want.UTC().Truncate(time.Second)) {
+		t.Errorf("%s = %v, want the injected clock's %v", what, got.UTC(), want.UTC())
+	}
+}
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Credential source kinds. A peer's CredentialSource is "<kind>:<target>".
+const (
+	CredentialSourceEnv = "env" // target is an environment variable holding the password
+	CredentialSourceCmd = "cmd" // target is a git-credential-helper style command line
+)
+
+// credentialHelperTimeout bounds a credential helper run, so a helper that
+// waits on a prompt cannot hang a sync.
+const credentialHelperTimeout = 30 * time.Second
+
+var envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseCredentialSource validates a credential source and splits it into its
+// kind and target. The empty string is returned unchanged and means the
+// peer's stored credentials are used.
+func ParseCredentialSource(s string) (kind, target string, err error) {
+	if s == "" {
+		return "", "", nil
+	}
+	kind, target, _ = strings.Cut(s, ":")
+	switch kind {
+	case CredentialSourceEnv:
+		if !envVarNameRegex.MatchString(target) {
+			return "", "", fmt.Errorf("invalid credential source %q: env: needs a variable name", s)
+		}
+	case CredentialSourceCmd:
+		if strings.TrimSpace(target) == "" {
+			return "", "", fmt.Errorf("invalid credential source %q: cmd: needs a helper command", s)
+		}
+	default:
+		return "", "", fmt.Errorf("invalid credential source %q (must be env:VAR_NAME or cmd:/path/to/helper)", s)
+	}
+	return kind, target, nil
+}
+
+// ResolveCredentialSource fetches a peer's credentials from its credential
+// source. username is the peer's configured user; a credential helper may
+// replace it. Nothing resolved here is written to disk.
+//
+// For env:VAR the password is the variable's value. For cmd:helper the
+// helper is run with the argument "get", as git runs credential helpers: it
+// reads protocol, host, path, and username lines describing the peer's
+// remote on stdin and prints username= and password= lines on stdout.
+func ResolveCredentialSource(ctx context.Context, source, remoteURL, username string) (string, string, error) {
+	kind, target, err := ParseCredentialSource(source)
+	if err != nil || kind == "" {
+		return username, "", err
+	}
+	switch kind {
+	case CredentialSourceEnv:
+		password, ok := os.LookupEnv(target)
+		if !ok {
+			return "", "", fmt.Errorf("credential source %s: $%s is not set", source, target)
+		}
+		return username, password, nil
+	default:
+		return runCredentialHelper(ctx, source, target, remoteURL, username)
+	}
+}
+
+// runCredentialHelper runs a cmd: credential source. The command line is
+// split on whitespace and run without a shell.
+func runCredentialHelper(ctx context.Context, source, commandLine, remoteURL, username string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialHelperTimeout)
+	defer cancel()
+
+	argv := strings.Fields(commandLine)
+	cmd := exec.CommandContext(ctx, argv[0], append(argv[1:], "get")...) //nolint:gosec // G204: the helper is configured by the workspace owner
+	cmd.Stdin = strings.NewReader(credentialHelperRequest(remoteURL, username))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", "", fmt.Errorf("credential source %s: %w: %s", source, err, msg)
+		}
+		return "", "", fmt.Errorf("credential source %s: %w", source, err)
+	}
+
+	var password string
+	var gotPassword bool
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "username":
+			username = value
+		case "password":
+			password, gotPassword = value, true
+		}
+	}
+	if !gotPassword {
+		return "", "", fmt.Errorf("credential source %s: helper returned no password", source)
+	}
+	return username, password, nil
+}
+
+// credentialHelperRequest describes a remote in the git credential protocol.
+// Remotes without a scheme (host:port/database) omit the protocol line.
+func credentialHelperRequest(remoteURL, username string) string {
+	var protocol, host, path string
+	if u, err := url.Parse(remoteURL); err == nil && strings.Contains(remoteURL, "://") {
+		protocol, host, path = u.Scheme, u.Host, strings.TrimPrefix(u.Path, "/")
+	} else {
+		host, path, _ = strings.Cut(remoteURL, "/")
+	}
+
+	var b strings.Builder
+	for _, kv := range [][2]string{{"protocol", protocol}, {"host", host}, {"path", path}, {"username", username}} {
+		if kv[1] != "" {
+			fmt.Fprintf(&b, "%s=%s\n", kv[0], kv[1])
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
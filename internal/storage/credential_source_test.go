@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseCredentialSource(t *testing.T) {
+	tests := []struct {
+		in           string
+		kind, target string
+		wantErr      bool
+	}{
+		{"", "", "", false},
+		{"env:PEER_PASSWORD", CredentialSourceEnv, "PEER_PASSWORD", false},
+		{"cmd:/usr/local/bin/creds --store team", CredentialSourceCmd, "/usr/local/bin/creds --store team", false},
+		{"env:", "", "", true},
+		{"env:1BAD", "", "", true},
+		{"env:WITH SPACE", "", "", true},
+		{"cmd:  ", "", "", true},
+		{"file:/etc/secret", "", "", true},
+		{"hunter2", "", "", true},
+	}
+	for _, tt := range tests {
+		kind, target, err := ParseCredentialSource(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCredentialSource(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if kind != tt.kind || target != tt.target {
+			t.Errorf("ParseCredentialSource(%q) = %q, %q; want %q, %q", tt.in, kind, target, tt.kind, tt.target)
+		}
+	}
+}
+
+func TestResolveCredentialSource_Env(t *testing.T) {
+	t.Setenv("BD_TEST_PEER_PW", "s3cret")
+
+	user, pw, err := ResolveCredentialSource(t.Context(), "env:BD_TEST_PEER_PW", "http://peer/beads", "sync-bot")
+	if err != nil {
+		t.Fatalf("ResolveCredentialSource: %v", err)
+	}
+	if user != "sync-bot" || pw != "s3cret" {
+		t.Errorf("got %q/%q, want sync-bot/s3cret", user, pw)
+	}
+
+	if _, _, err := ResolveCredentialSource(t.Context(), "env:BD_TEST_PEER_UNSET", "http://peer/beads", ""); err == nil || !strings.Contains(err.Error(), "not set") {
+		t.Errorf("unset variable: err = %v, want 'not set'", err)
+	}
+}
+
+func TestResolveCredentialSource_Cmd(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("helper is a shell script")
+	}
+	dir := t.TempDir()
+	request := filepath.Join(dir, "request")
+	helper := filepath.Join(dir, "helper")
+	// Records its arguments and stdin, then answers like a git credential helper.
+	script := "#!/bin/sh\necho \"$@\" > " + request + "\ncat >> " + request + "\necho username=helper-user\necho password=from-helper\n"
+	if err := os.WriteFile(helper, []byte(script), 0700); err != nil { // #nosec G306 -- test helper must be executable
+		t.Fatal(err)
+	}
+
+	user, pw, err := ResolveCredentialSource(t.Context(), "cmd:"+helper+" --vault team", "https://peer.example.com:8443/org/beads", "sync-bot")
+	if err != nil {
+		t.Fatalf("ResolveCredentialSource: %v", err)
+	}
+	if user != "helper-user" || pw != "from-helper" {
+		t.Errorf("got %q/%q, want helper-user/from-helper", user, pw)
+	}
+	got, _ := os.ReadFile(request)
+	want := "--vault team get\nprotocol=https\nhost=peer.example.com:8443\npath=org/beads\nusername=sync-bot\n\n"
+	if string(got) != want {
+		t.Errorf("helper saw:\n%q\nwant:\n%q", got, want)
+	}
+
+	silent := filepath.Join(dir, "silent")
+	if err := os.WriteFile(silent, []byte("#!/bin/sh\necho nothing-useful\n"), 0700); err != nil { // #nosec G306 -- test helper must be executable
+		t.Fatal(err)
+	}
+	if _, _, err := ResolveCredentialSource(t.Context(), "cmd:"+silent, "http://peer/beads", ""); err == nil || !strings.Contains(err.Error(), "no password") {
+		t.Errorf("helper without password: err = %v, want 'no password'", err)
+	}
+
+	failing := filepath.Join(dir, "failing")
+	if err := os.WriteFile(failing, []byte("#!/bin/sh\necho vault is sealed >&2\nexit 1\n"), 0700); err != nil { // #nosec G306 -- test helper must be executable
+		t.Fatal(err)
+	}
+	if _, _, err := ResolveCredentialSource(t.Context(), "cmd:"+failing, "http://peer/beads", ""); err == nil || !strings.Contains(err.Error(), "vault is sealed") {
+		t.Errorf("failing helper: err = %v, want its stderr", err)
+	}
+}
+
+func TestCredentialHelperRequest_NoScheme(t *testing.T) {
+	got := credentialHelperRequest("192.168.1.100:3306/beads", "")
+	if want := "host=192.168.1.100:3306\npath=beads\n\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
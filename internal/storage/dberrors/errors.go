@@ -40,3 +40,20 @@ func IsTableNotExist(err error) bool {
 		quotedTableMissingPattern.MatchString(s) ||
 		unquotedTableMissingPattern.MatchString(s)
 }
+
+// IsAccessDenied reports whether err is a MySQL/Dolt authentication or
+// authorization failure: bad credentials (1045) or no access to the
+// database (1044).
+func IsAccessDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1045 || mysqlErr.Number == 1044
+	}
+
+	s := strings.ToLower(err.Error())
+	return strings.Contains(s, "error 1045") || strings.Contains(s, "error 1044")
+}
@@ -0,0 +1,65 @@
+//go:build !windows
+
+package dolt
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// serveSSHPassphrase serves passphrase to the askpass helper through named
+// pipes in dir, so only the helper ever sees it and it never touches disk.
+// Each helper run makes its own reply pipe and sends its path on the
+// returned request pipe; the server writes the passphrase to that reply
+// pipe only. stop shuts the server down.
+func serveSSHPassphrase(dir, passphrase string) (string, func(), error) {
+	path := filepath.Join(dir, "requests")
+	if err := syscall.Mkfifo(path, 0600); err != nil {
+		return "", nil, fmt.Errorf("create passphrase pipe: %w", err)
+	}
+	// Holding both ends keeps the pipe from reaching EOF between helpers.
+	requests, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return "", nil, fmt.Errorf("open passphrase pipe: %w", err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		scanner := bufio.NewScanner(requests)
+		for scanner.Scan() {
+			reply := scanner.Text()
+			if filepath.Dir(reply) != dir {
+				continue
+			}
+			// The helper holds its reply pipe open, so this never blocks; a
+			// helper that already exited is skipped.
+			w, err := os.OpenFile(reply, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+			if err != nil {
+				continue
+			}
+			_, _ = w.WriteString(passphrase + "\n")
+			_ = w.Close()
+		}
+	}()
+	stop := func() {
+		_ = requests.Close()
+		<-exited
+	}
+	return path, stop, nil
+}
+
+// askpassScript returns the askpass helper that asks the server listening
+// on the request pipe at passPath for the passphrase and prints it.
+func askpassScript(passPath string) (name, script string) {
+	dir := shellQuote(filepath.Dir(passPath))
+	return "askpass.sh", "#!/bin/sh\n" +
+		"reply=" + dir + "/reply.$$\n" +
+		"mkfifo -m 600 \"$reply\" || exit 1\n" +
+		"exec 3<>\"$reply\"\n" +
+		"echo \"$reply\" > " + shellQuote(passPath) + "\n" +
+		"head -n 1 <&3\n" +
+		"rm -f \"$reply\"\n"
+}
@@ -0,0 +1,27 @@
+//go:build windows
+
+package dolt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// serveSSHPassphrase writes passphrase to a file in dir, which only the
+// current user can read, for the askpass helper to print. Windows has no
+// named pipes a batch file can read, so this is the one place it touches
+// disk; stop is a no-op because the caller removes dir.
+func serveSSHPassphrase(dir, passphrase string) (string, func(), error) {
+	path := filepath.Join(dir, "passphrase")
+	if err := os.WriteFile(path, []byte(passphrase), 0600); err != nil {
+		return "", nil, fmt.Errorf("write passphrase file: %w", err)
+	}
+	return path, func() {}, nil
+}
+
+// askpassScript returns the askpass helper that prints the passphrase from
+// the file at passPath.
+func askpassScript(passPath string) (name, script string) {
+	return "askpass.cmd", "@echo off\r\ntype \"" + passPath + "\"\r\n"
+}
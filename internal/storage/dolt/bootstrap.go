@@ -77,7 +77,14 @@ func BootstrapFromRemoteWithDB(ctx context.Context, doltDir, remoteURL, database
 	// missed) that we must not delete.
 	targetPreExisted := pathExists(cloneTarget)
 	cmd := exec.CommandContext(ctx, "dolt", doltCloneArgs(remoteURL, cloneTarget)...)
+	// Canceling ctx (Ctrl-C) interrupts the clone; the partial target it
+	// leaves behind is removed below like any other failed clone.
+	cmd.WaitDelay = cliExecWaitDelay
+	interruptOnCancel(cmd)
 	if output, err := cmd.CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			err = canceledError(ctx, err)
+		}
 		if targetPreExisted {
 			return false, fmt.Errorf("dolt clone failed: %w\nOutput: %s\nClone target %q already existed before this attempt; left untouched to avoid deleting a pre-existing Dolt repo", err, output, cloneTarget)
 		}
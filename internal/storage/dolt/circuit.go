@@ -2,6 +2,7 @@ package dolt
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -416,3 +417,19 @@ func isConnectionError(err error) bool {
 
 	return false
 }
+
+// IsServerUnreachable reports whether err means the Dolt server could not be
+// reached at all (down, offline, or failing fast behind the circuit breaker),
+// as opposed to the server rejecting the request.
+func IsServerUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrCircuitOpen) || isConnectionError(err) {
+		return true
+	}
+	errStr := strings.ToLower(err.Error())
+	return strings.Contains(errStr, "no such host") ||
+		strings.Contains(errStr, "network is unreachable") ||
+		strings.Contains(errStr, "no route to host")
+}
@@ -3,6 +3,7 @@ package dolt
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
@@ -482,3 +483,28 @@ func newTestCircuitBreakerOnPort(t *testing.T, port int) *circuitBreaker {
 		filePath: filepath.Join(dir, "circuit.json"),
 	}
 }
+
+func TestIsServerUnreachable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"circuit open", fmt.Errorf("open store: %w", ErrCircuitOpen), true},
+		{"connection refused", errors.New("Dolt server unreachable at 127.0.0.1:3307: dial tcp 127.0.0.1:3307: connect: connection refused"), true},
+		{"no such host", errors.New("dial tcp: lookup dolt.internal: no such host"), true},
+		{"network unreachable", errors.New("dial tcp 10.0.0.5:3307: connect: network is unreachable"), true},
+		{"no route", errors.New("dial tcp 10.0.0.5:3307: connect: no route to host"), true},
+		{"access denied (not unreachable)", errors.New("Error 1045: Access denied for user 'root'"), false},
+		{"unknown database (not unreachable)", errors.New("Unknown database 'test'"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsServerUnreachable(tt.err); got != tt.expected {
+				t.Errorf("IsServerUnreachable(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
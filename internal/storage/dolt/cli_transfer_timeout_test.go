@@ -120,3 +120,54 @@ func TestCLITransferErrorPlainFailure(t *testing.T) {
 		t.Fatalf("unexpected error shape: %q", msg)
 	}
 }
+
+// TestCLITransferCancelInterruptsDolt pins the Ctrl-C path: canceling the
+// caller's context interrupts the dolt child rather than killing it, so dolt
+// can release the database directory lock, and the call returns promptly
+// with an error wrapping context.Canceled.
+func TestCLITransferCancelInterruptsDolt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX shell fake; Windows kills the child instead")
+	}
+	binDir := t.TempDir()
+	ready := filepath.Join(binDir, "ready")
+	// Fake dolt: report the interrupt it would clean up after, then exit.
+	script := "#!/bin/sh\ntrap 'echo interrupted; exit 130' INT\ntouch \"$FAKE_DOLT_READY\"\nwhile :; do sleep 0.05; done\n"
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte(script), 0o755); err != nil { // #nosec G306 -- test fixture must be executable
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_DOLT_READY", ready)
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	defer cancelParent()
+	cmd, ctx, cancel := prepareDoltCLITransferCommand(parent, binDir, nil, false, "pull", "origin", "main")
+	defer cancel()
+	go func() {
+		for {
+			if _, err := os.Stat(ready); err == nil {
+				cancelParent()
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	start := time.Now()
+	out, err := cmd.CombinedOutput()
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatalf("CombinedOutput succeeded; want interrupted (out=%q)", out)
+	}
+	if elapsed > 5*time.Second {
+		t.Fatalf("CombinedOutput took %v after cancel; want prompt return", elapsed)
+	}
+	if !strings.Contains(string(out), "interrupted") {
+		t.Fatalf("dolt was not sent an interrupt before exiting (out=%q, err=%v)", out, err)
+	}
+	transferErr := cliTransferError("dolt pull", "origin", ctx, out, err)
+	if !errors.Is(transferErr, context.Canceled) {
+		t.Fatalf("cliTransferError = %v, want it to wrap context.Canceled", transferErr)
+	}
+}
@@ -38,7 +38,7 @@ func (s *DoltStore) getCompactDays(ctx context.Context, tier int) int {
 func (s *DoltStore) CheckEligibility(ctx context.Context, issueID string, tier int) (bool, string, error) {
 	var eligible bool
 	var reason string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		eligible, reason, err = issueops.CheckEligibilityInTx(ctx, tx, issueID, tier)
 		return err
@@ -48,7 +48,7 @@ func (s *DoltStore) CheckEligibility(ctx context.Context, issueID string, tier i
 
 // ApplyCompaction records a compaction result in the database.
 func (s *DoltStore) ApplyCompaction(ctx context.Context, issueID string, tier int, originalSize int, _ int, commitHash string) error {
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.ApplyCompactionInTx(ctx, tx, issueID, tier, originalSize, commitHash)
 	})
 }
@@ -56,7 +56,7 @@ func (s *DoltStore) ApplyCompaction(ctx context.Context, issueID string, tier in
 // SnapshotIssue archives an issue's current text content before a destructive
 // compaction overwrites it. See issueops.SnapshotIssueInTx.
 func (s *DoltStore) SnapshotIssue(ctx context.Context, issueID string, tier int) error {
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.SnapshotIssueInTx(ctx, tx, issueID, tier)
 	})
 }
@@ -65,7 +65,7 @@ func (s *DoltStore) SnapshotIssue(ctx context.Context, issueID string, tier int)
 // or (nil, nil) when none exists.
 func (s *DoltStore) GetCompactionSnapshot(ctx context.Context, issueID string) (*types.IssueSnapshot, error) {
 	var snap *types.IssueSnapshot
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		snap, err = issueops.GetLatestSnapshotInTx(ctx, tx, issueID)
 		return err
@@ -77,7 +77,7 @@ func (s *DoltStore) GetCompactionSnapshot(ctx context.Context, issueID string) (
 // and steps its compaction level back down. See issueops.RestoreFromSnapshotInTx.
 func (s *DoltStore) RestoreFromSnapshot(ctx context.Context, issueID string) (*types.IssueSnapshot, error) {
 	var snap *types.IssueSnapshot
-	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		snap, err = issueops.RestoreFromSnapshotInTx(ctx, tx, issueID)
 		return err
@@ -88,7 +88,7 @@ func (s *DoltStore) RestoreFromSnapshot(ctx context.Context, issueID string) (*t
 // GetTier1Candidates returns issues eligible for tier 1 compaction.
 func (s *DoltStore) GetTier1Candidates(ctx context.Context) ([]*types.CompactionCandidate, error) {
 	var result []*types.CompactionCandidate
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetTier1CandidatesInTx(ctx, tx)
 		return err
@@ -99,7 +99,7 @@ func (s *DoltStore) GetTier1Candidates(ctx context.Context) ([]*types.Compaction
 // GetTier2Candidates returns issues eligible for tier 2 compaction.
 func (s *DoltStore) GetTier2Candidates(ctx context.Context) ([]*types.CompactionCandidate, error) {
 	var result []*types.CompactionCandidate
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetTier2CandidatesInTx(ctx, tx)
 		return err
@@ -14,7 +14,7 @@ import (
 
 // SetConfig sets a configuration value
 func (s *DoltStore) SetConfig(ctx context.Context, key, value string) error {
-	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		if err := issueops.SetConfigInTx(ctx, tx, key, value); err != nil {
 			return err
 		}
@@ -56,7 +56,7 @@ func (s *DoltStore) SetConfig(ctx context.Context, key, value string) error {
 // GetConfig retrieves a configuration value
 func (s *DoltStore) GetConfig(ctx context.Context, key string) (string, error) {
 	var value string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		value, err = issueops.GetConfigInTx(ctx, tx, key)
 		return err
@@ -67,7 +67,7 @@ func (s *DoltStore) GetConfig(ctx context.Context, key string) (string, error) {
 // GetAllConfig retrieves all configuration values
 func (s *DoltStore) GetAllConfig(ctx context.Context) (map[string]string, error) {
 	var result map[string]string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetAllConfigInTx(ctx, tx)
 		return err
@@ -77,22 +77,33 @@ func (s *DoltStore) GetAllConfig(ctx context.Context) (map[string]string, error)
 
 // DeleteConfig removes a configuration value
 func (s *DoltStore) DeleteConfig(ctx context.Context, key string) error {
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.DeleteConfigInTx(ctx, tx, key)
 	})
 }
 
 // SetMetadata sets a metadata value
 func (s *DoltStore) SetMetadata(ctx context.Context, key, value string) error {
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.SetMetadataInTx(ctx, tx, key, value)
 	})
 }
 
+// DeleteMetadataPrefix removes the metadata values whose key starts with prefix
+func (s *DoltStore) DeleteMetadataPrefix(ctx context.Context, prefix string) (int, error) {
+	var n int
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		n, err = issueops.DeleteMetadataPrefixInTx(ctx, tx, prefix)
+		return err
+	})
+	return n, err
+}
+
 // GetMetadata retrieves a metadata value
 func (s *DoltStore) GetMetadata(ctx context.Context, key string) (string, error) {
 	var value string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		value, err = issueops.GetMetadataInTx(ctx, tx, key)
 		return err
@@ -103,7 +114,7 @@ func (s *DoltStore) GetMetadata(ctx context.Context, key string) (string, error)
 // SetLocalMetadata sets a value in the dolt-ignored local_metadata table.
 // Used for clone-local state that should not generate merge conflicts.
 func (s *DoltStore) SetLocalMetadata(ctx context.Context, key, value string) error {
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.SetLocalMetadataInTx(ctx, tx, key, value)
 	})
 }
@@ -112,7 +123,7 @@ func (s *DoltStore) SetLocalMetadata(ctx context.Context, key, value string) err
 // Returns ("", nil) if the key does not exist.
 func (s *DoltStore) GetLocalMetadata(ctx context.Context, key string) (string, error) {
 	var value string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		value, err = issueops.GetLocalMetadataInTx(ctx, tx, key)
 		return err
@@ -130,7 +141,7 @@ func (s *DoltStore) loadCustomConfigCache(ctx context.Context) {
 
 	var statuses []types.CustomStatus
 	var customTypes []string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var resolveErr error
 		statuses, customTypes, resolveErr = issueops.ResolveCustomConfigInTx(ctx, tx)
 		return resolveErr
@@ -201,7 +212,7 @@ func (s *DoltStore) GetInfraTypes(ctx context.Context) map[string]bool {
 	s.cacheMu.Unlock()
 
 	var result map[string]bool
-	if err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		result = issueops.ResolveInfraTypesInTx(ctx, tx)
 		return nil
 	}); err != nil || result == nil {
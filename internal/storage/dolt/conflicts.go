@@ -0,0 +1,75 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
+)
+
+// withConflictCommitsConn runs fn on a pinned pool connection that may commit
+// a working set still holding merge conflicts. @@dolt_allow_commit_conflicts
+// is session-scoped, so it is reset before the connection returns to the
+// pool; if the reset cannot run, the connection is discarded rather than
+// returned dirty (see autoResolveConflictsAfterCLIPull).
+func (s *DoltStore) withConflictCommitsConn(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(ctx, "SET @@dolt_allow_commit_conflicts = 0"); err != nil {
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		_ = conn.Close()
+	}()
+	if err := versioncontrolops.AllowConflictCommits(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// mergeKeepingConflicts is Sync's merge step: unlike Merge, a conflicted
+// merge stays in the working set so the peer's conflict strategy, or the
+// operator via bd federation conflicts, can resolve it. A conflict-free merge
+// recomputes is_blocked like Merge does.
+func (s *DoltStore) mergeKeepingConflicts(ctx context.Context, branch string) ([]storage.Conflict, error) {
+	preHead, _ := s.GetCurrentCommit(ctx) // Best effort: empty degrades to a full recompute
+	var conflicts []storage.Conflict
+	err := s.withConflictCommitsConn(ctx, func(conn *sql.Conn) error {
+		var err error
+		conflicts, err = versioncontrolops.MergeKeepingConflicts(ctx, conn, branch, s.commitAuthorString())
+		return err
+	})
+	if err == nil && len(conflicts) == 0 && !s.readOnly {
+		if rerr := s.recomputeBlockedAfterPull(ctx, preHead); rerr != nil {
+			return nil, fmt.Errorf("merge succeeded but is_blocked recompute failed: %w", rerr)
+		}
+	}
+	return conflicts, err
+}
+
+// GetIssueConflicts lists the conflicted rows of the issues table.
+func (s *DoltStore) GetIssueConflicts(ctx context.Context) ([]storage.IssueConflict, error) {
+	return versioncontrolops.GetIssueConflicts(ctx, s.db)
+}
+
+// ResolveIssueConflict resolves one issue's merge conflict with strategy,
+// reporting false when the strategy cannot decide it.
+func (s *DoltStore) ResolveIssueConflict(ctx context.Context, issueID string, strategy storage.ConflictStrategy) (bool, error) {
+	var resolved bool
+	err := s.withConflictCommitsConn(ctx, func(conn *sql.Conn) error {
+		var err error
+		resolved, err = versioncontrolops.ResolveIssueConflict(ctx, conn, issueID, strategy)
+		return err
+	})
+	return resolved, err
+}
+
+// AbortMerge abandons a merge left conflicted in the working set.
+func (s *DoltStore) AbortMerge(ctx context.Context) error {
+	return versioncontrolops.AbortMerge(ctx, s.db)
+}
@@ -24,3 +24,14 @@ func TestConformance(t *testing.T) {
 		return store
 	})
 }
+
+// TestClockConformance checks that every write path stamps the store's
+// injected clock (internal/storage/conformance RunClock).
+func TestClockConformance(t *testing.T) {
+	conformance.RunClock(t, func(t *testing.T, clock storage.Clock) storage.DoltStorage {
+		store, cleanup := setupTestStore(t)
+		t.Cleanup(cleanup)
+		store.clock = clock
+		return store
+	})
+}
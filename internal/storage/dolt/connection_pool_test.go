@@ -36,6 +36,8 @@ type mockDriver struct {
 	opens  atomic.Int64 // total Open() calls (NewConnection events)
 	closes atomic.Int64 // total Close() calls (ConnectionClosed events)
 	live   atomic.Int64 // currently-open connections
+
+	prepares atomic.Int64 // total Prepare() calls
 }
 
 func (d *mockDriver) Open(name string) (driver.Conn, error) {
@@ -52,6 +54,7 @@ type mockConn struct {
 }
 
 func (c *mockConn) Prepare(query string) (driver.Stmt, error) {
+	c.drv.prepares.Add(1)
 	return &mockStmt{}, nil
 }
 
@@ -317,3 +320,156 @@ func TestPool_CloseReleasesUnderlyingConnections(t *testing.T) {
 		t.Errorf("opens=%d closes=%d — Close should release every opened connection", opens, closes)
 	}
 }
+
+// --- PoolStats / ApplyCLIPoolConfig -----------------------------------------
+
+func TestPoolStats_ReportsLimitsUsageAndSlowQueries(t *testing.T) {
+	t.Parallel()
+
+	db, _ := openMockDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+	cfg := &Config{MaxOpenConns: 4, ConnMaxIdleTime: 10 * time.Second, SlowQueryThreshold: 50 * time.Millisecond}
+	applyPoolLimits(db, cfg)
+	store := &DoltStore{db: db, poolConfig: cfg}
+
+	ctx := context.Background()
+	rows, err := db.QueryContext(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	_ = rows.Close()
+	store.observeQuery(ctx, time.Now())
+	store.observeQuery(ctx, time.Now().Add(-time.Second))
+
+	stats := store.PoolStats()
+	if stats.MaxOpen != 4 || stats.MaxIdle != 4 {
+		t.Errorf("MaxOpen/MaxIdle = %d/%d, want 4/4 (idle clamped to open)", stats.MaxOpen, stats.MaxIdle)
+	}
+	if stats.ConnMaxLifetime != defaultConnMaxLifetime || stats.ConnMaxIdleTime != 10*time.Second {
+		t.Errorf("lifetimes = %v/%v, want %v/10s", stats.ConnMaxLifetime, stats.ConnMaxIdleTime, defaultConnMaxLifetime)
+	}
+	if stats.Open != 1 || stats.Idle != 1 || stats.InUse != 0 {
+		t.Errorf("open/idle/in-use = %d/%d/%d, want 1/1/0", stats.Open, stats.Idle, stats.InUse)
+	}
+	if stats.Queries != 2 || stats.SlowQueries != 1 || stats.SlowQueryThreshold != 50*time.Millisecond {
+		t.Errorf("queries = %d, slow = %d over %v; want 2, 1 over 50ms", stats.Queries, stats.SlowQueries, stats.SlowQueryThreshold)
+	}
+}
+
+func TestApplyCLIPoolConfig_ReadsEnvAndKeepsCallerOverrides(t *testing.T) {
+	t.Setenv("BEADS_DOLT_MAX_CONNS", "25")
+	t.Setenv("BEADS_DOLT_MAX_IDLE_CONNS", "8")
+	t.Setenv("BEADS_DOLT_CONN_MAX_LIFETIME", "10m")
+	t.Setenv("BEADS_DOLT_CONN_MAX_IDLE_TIME", "not-a-duration")
+	t.Setenv("BEADS_DOLT_SLOW_QUERY_THRESHOLD", "250ms")
+	t.Setenv("BEADS_DOLT_STMT_CACHE_SIZE", "0")
+
+	cfg := &Config{MaxOpenConns: 1}
+	ApplyCLIPoolConfig(cfg)
+
+	if cfg.MaxOpenConns != 1 {
+		t.Errorf("MaxOpenConns = %d, want the caller's 1", cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns != 8 || cfg.ConnMaxLifetime != 10*time.Minute || cfg.SlowQueryThreshold != 250*time.Millisecond {
+		t.Errorf("cfg = %+v, want idle 8, lifetime 10m, slow 250ms", cfg)
+	}
+	if cfg.ConnMaxIdleTime != 0 {
+		t.Errorf("ConnMaxIdleTime = %v from an invalid value, want 0 (default)", cfg.ConnMaxIdleTime)
+	}
+	if cfg.StmtCacheSize >= 0 || stmtCacheSize(cfg) != 0 {
+		t.Errorf("StmtCacheSize = %d from an explicit 0, want the cache disabled", cfg.StmtCacheSize)
+	}
+}
+
+// --- prepared statement cache ----------------------------------------------
+
+// waitForCached waits for background prepares to leave n statements cached.
+func waitForCached(t *testing.T, c *stmtCache, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for c.len() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("cache holds %d statements, want %d", c.len(), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStmtCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	db, drv := openMockDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+	c := newStmtCache(db, 2)
+	t.Cleanup(c.close)
+
+	if c.lookup("SELECT 1") != nil {
+		t.Fatal("first lookup hit an empty cache")
+	}
+	waitForCached(t, c, 1)
+	c.lookup("SELECT 2")
+	waitForCached(t, c, 2)
+
+	// Touch SELECT 1 so SELECT 2 is the one evicted for SELECT 3.
+	if c.lookup("SELECT 1") == nil {
+		t.Fatal("SELECT 1 not cached after its background prepare")
+	}
+	c.lookup("SELECT 3")
+	deadline := time.Now().Add(5 * time.Second)
+	for c.lookup("SELECT 3") == nil && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if c.lookup("SELECT 1") == nil || c.lookup("SELECT 3") == nil {
+		t.Error("recently used statements were evicted")
+	}
+	if _, ok := c.items["SELECT 2"]; ok {
+		t.Error("least recently used statement was kept")
+	}
+	if got := drv.prepares.Load(); got != 3 {
+		t.Errorf("prepares = %d, want one per distinct query (3)", got)
+	}
+}
+
+func TestCachedReadTx_CountsHitsAndDoesNotBlockAFullPool(t *testing.T) {
+	t.Parallel()
+
+	db, _ := openMockDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+	// One connection: the transaction below holds it, so an inline prepare
+	// on the pool would wait forever.
+	cfg := &Config{MaxOpenConns: 1}
+	applyPoolLimits(db, cfg)
+	store := &DoltStore{db: db, poolConfig: cfg, stmts: newStmtCache(db, stmtCacheSize(cfg))}
+	t.Cleanup(store.stmts.close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	query := func() {
+		t.Helper()
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+		defer func() { _ = tx.Rollback() }()
+		rows, err := store.cachedReadTx(tx).QueryContext(ctx, "SELECT x FROM issues WHERE status = ?", "open")
+		if err != nil {
+			t.Fatalf("query: %v", err)
+		}
+		if !rows.Next() {
+			t.Fatal("query returned no rows")
+		}
+		_ = rows.Close()
+	}
+
+	query()
+	waitForCached(t, store.stmts, 1)
+	query()
+
+	stats := store.PoolStats()
+	if stats.StmtCacheHits != 1 || stats.StmtCacheMisses != 1 {
+		t.Errorf("hits/misses = %d/%d, want 1/1", stats.StmtCacheHits, stats.StmtCacheMisses)
+	}
+	if stats.StmtCached != 1 || stats.StmtCacheSize != defaultStmtCacheSize {
+		t.Errorf("cached = %d of %d, want 1 of %d", stats.StmtCached, stats.StmtCacheSize, defaultStmtCacheSize)
+	}
+}
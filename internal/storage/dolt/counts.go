@@ -26,7 +26,7 @@ const depTargetExpr = "COALESCE(depends_on_issue_id, depends_on_wisp_id, depends
 // durable issues table only, otherwise the wisps tier is merged in (GH#4387).
 func (s *DoltStore) CountIssues(ctx context.Context, query string, filter types.IssueFilter) (int64, error) {
 	var n int64
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		count, err := issueops.CountIssuesInTx(ctx, tx, query, filter)
 		if err != nil {
 			return err
@@ -38,10 +38,10 @@ func (s *DoltStore) CountIssues(ctx context.Context, query string, filter types.
 }
 
 // CountIssuesByGroup returns per-group issue counts. groupBy is one of:
-// status, priority, type, assignee, label.
+// status, priority, type, assignee, label, close_reason.
 func (s *DoltStore) CountIssuesByGroup(ctx context.Context, filter types.IssueFilter, groupBy string) (map[string]int, error) {
 	var result map[string]int
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.CountIssuesByGroupInTx(ctx, tx, filter, groupBy)
 		return err
@@ -59,7 +59,7 @@ func (s *DoltStore) CountIssuesByGroup(ctx context.Context, filter types.IssueFi
 // can fail to resolve under the pure-Go GMS analyzer.
 func (s *DoltStore) CountDependents(ctx context.Context, issueID string) (int64, error) {
 	var n int64
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var perm, wisp int64
 		if err := tx.QueryRowContext(ctx,
 			`SELECT count(*) FROM dependencies WHERE `+depTargetExpr+` = ?`, issueID).Scan(&perm); err != nil {
@@ -82,7 +82,7 @@ func (s *DoltStore) CountDependents(ctx context.Context, issueID string) (int64,
 // CountDependents for why a single combined query is avoided).
 func (s *DoltStore) CountDependencies(ctx context.Context, issueID string) (int64, error) {
 	var n int64
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var perm, wisp int64
 		if err := tx.QueryRowContext(ctx,
 			`SELECT count(*) FROM dependencies WHERE issue_id = ?`, issueID).Scan(&perm); err != nil {
@@ -101,7 +101,7 @@ func (s *DoltStore) CountDependencies(ctx context.Context, issueID string) (int6
 // CountIssueComments returns the number of comments on an issue.
 func (s *DoltStore) CountIssueComments(ctx context.Context, issueID string) (int64, error) {
 	var n int64
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return tx.QueryRowContext(ctx,
 			`SELECT count(*) FROM comments WHERE issue_id = ?`, issueID).Scan(&n)
 	})
@@ -112,7 +112,7 @@ func (s *DoltStore) CountIssueComments(ctx context.Context, issueID string) (int
 // (or unbounded if limit == 0).
 func (s *DoltStore) CountEvents(ctx context.Context, issueID string, limit int) (int64, error) {
 	var n int64
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return tx.QueryRowContext(ctx,
 			`SELECT count(*) FROM events WHERE issue_id = ?`, issueID).Scan(&n)
 	})
@@ -133,7 +133,7 @@ func (s *DoltStore) CountEvents(ctx context.Context, issueID string, limit int)
 // single combined query is avoided).
 func (s *DoltStore) CountDependentsByStatus(ctx context.Context, issueID string, status types.Status) (int64, error) {
 	var n int64
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var perm, wisp int64
 		if err := tx.QueryRowContext(ctx,
 			`SELECT count(*) FROM dependencies d
@@ -2,13 +2,10 @@ package dolt
 
 import (
 	"context"
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
 	"crypto/sha256"
 	"database/sql"
+	"errors"
 	"fmt"
-	"io"
 	"log"
 	"os"
 	"os/exec"
@@ -17,7 +14,9 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/steveyegge/beads/internal/besteffort"
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
 )
 
 // Credential storage and encryption for federation peers.
@@ -28,9 +27,9 @@ const credentialKeyFile = ".beads-credential-key" //nolint:gosec // G101: not a
 
 const awsResponseChecksumValidationEnv = "AWS_RESPONSE_CHECKSUM_VALIDATION"
 
-// federationEnvMutex protects process-wide env vars from concurrent access.
-// Environment variables are process-global, so we need to serialize federation operations.
-var federationEnvMutex sync.Mutex
+// s3ChecksumEnvMutex serializes SQL-path operations on S3 remotes, which
+// toggle the process-wide AWS_RESPONSE_CHECKSUM_VALIDATION variable.
+var s3ChecksumEnvMutex sync.Mutex
 
 // validPeerNameRegex matches valid peer names (alphanumeric, hyphens, underscores)
 var validPeerNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
@@ -82,8 +81,8 @@ func (s *DoltStore) initCredentialKey(ctx context.Context) error {
 	}
 
 	// Generate new random 32-byte key (AES-256)
-	key = make([]byte, 32)
-	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+	key, err = issueops.NewCredentialKey()
+	if err != nil {
 		return fmt.Errorf("failed to generate credential encryption key: %w", err)
 	}
 
@@ -134,102 +133,128 @@ func (s *DoltStore) legacyEncryptionKey() []byte {
 }
 
 // migrateCredentialKeys re-encrypts all stored federation passwords from the
-// old dbPath-derived key to the new random key.
+// old dbPath-derived key to the new random key. Secrets the legacy key cannot
+// decrypt are left alone, since they may already use a random key.
 func (s *DoltStore) migrateCredentialKeys(ctx context.Context, newKey []byte) error {
 	if s.db == nil {
 		return nil // No database connection — nothing to migrate
 	}
 
-	oldKey := s.legacyEncryptionKey()
-
-	rows, err := s.queryContext(ctx, `
-		SELECT name, password_encrypted FROM federation_peers
-		WHERE password_encrypted IS NOT NULL AND LENGTH(password_encrypted) > 0
-	`)
-	if err != nil {
+	_, err := s.reencryptCredentials(ctx, issueops.SecretReencryption{
+		OldKey:            s.legacyEncryptionKey(),
+		NewKey:            newKey,
+		SkipUndecryptable: true,
+	}, "federation: migrate credential key")
+	if isTableNotExistError(err) {
 		// Table may not exist yet (fresh install) — not an error
 		return nil
 	}
-	defer rows.Close()
-
-	type migrationEntry struct {
-		name      string
-		plaintext string
-	}
+	return err
+}
 
-	var toMigrate []migrationEntry
-	for rows.Next() {
-		var name string
-		var encrypted []byte
-		if err := rows.Scan(&name, &encrypted); err != nil {
-			return fmt.Errorf("failed to scan peer for migration: %w", err)
+// reencryptCredentials re-encrypts federation peer secrets in a single
+// transaction and, unless r.DryRun, commits the change to Dolt history.
+func (s *DoltStore) reencryptCredentials(ctx context.Context, r issueops.SecretReencryption, commitMsg string) ([]string, error) {
+	var peers []string
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		peers, err = issueops.ReencryptFederationSecretsInTx(ctx, tx, r)
+		if err != nil || r.DryRun || len(peers) == 0 {
+			return err
 		}
+		return s.doltAddAndCommitInTx(ctx, tx, []string{"federation_peers"}, commitMsg)
+	})
+	return peers, err
+}
 
-		// Decrypt with old key
-		plaintext, err := decryptWithKey(encrypted, oldKey)
-		if err != nil {
-			// Can't decrypt with old key — skip (may already use a different scheme)
-			continue
-		}
-		toMigrate = append(toMigrate, migrationEntry{name: name, plaintext: plaintext})
+// RotateCredentialKey replaces the credential key: the new key is staged next
+// to the key file, every peer secret is re-encrypted with it in one
+// transaction, and only then is the old key file shredded and replaced. If
+// the process dies between the commit and the rename, the new key is left in
+// the staging file (.beads-credential-key.rotating).
+func (s *DoltStore) RotateCredentialKey(ctx context.Context, opts storage.KeyRotationOptions) (*storage.KeyRotationResult, error) {
+	if s.beadsDir == "" {
+		return nil, fmt.Errorf("beads directory not set; credential encryption unavailable")
 	}
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("failed to iterate peers for migration: %w", err)
+	if err := s.ensureCredentialKey(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialize credential key: %w", err)
 	}
 
-	// Re-encrypt each password with the new key
-	for _, entry := range toMigrate {
-		encrypted, err := encryptWithKey(entry.plaintext, newKey)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keyPath := filepath.Join(s.beadsDir, credentialKeyFile)
+	result := &storage.KeyRotationResult{KeyPath: keyPath, DryRun: opts.DryRun}
+	r := issueops.SecretReencryption{OldKey: s.credentialKey, DryRun: opts.DryRun, Progress: opts.Progress}
+
+	if opts.DryRun {
+		peers, err := s.reencryptCredentials(ctx, r, "")
 		if err != nil {
-			return fmt.Errorf("failed to re-encrypt password for peer %s: %w", entry.name, err)
-		}
-		if _, err := s.execContext(ctx, `
-			UPDATE federation_peers SET password_encrypted = ? WHERE name = ?
-		`, encrypted, entry.name); err != nil {
-			return fmt.Errorf("failed to update encrypted password for peer %s: %w", entry.name, err)
+			return nil, fmt.Errorf("failed to check stored credentials: %w", err)
 		}
+		result.Peers = peers
+		return result, nil
 	}
 
-	return nil
-}
-
-// encryptWithKey encrypts plaintext using AES-GCM with the given key.
-func encryptWithKey(plaintext string, key []byte) ([]byte, error) {
-	block, err := aes.NewCipher(key)
+	newKey, err := issueops.NewCredentialKey()
 	if err != nil {
 		return nil, err
 	}
-	gcm, err := cipher.NewGCM(block)
+	r.NewKey = newKey
+	stagingPath, err := issueops.StageCredentialKey(keyPath, newKey)
 	if err != nil {
 		return nil, err
 	}
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+	peers, err := s.reencryptCredentials(ctx, r, "federation: rotate credential key")
+	if err != nil {
+		_ = os.Remove(stagingPath)
+		return nil, fmt.Errorf("failed to re-encrypt credentials: %w", err)
+	}
+	if err := issueops.CommitCredentialKey(keyPath, stagingPath); err != nil {
 		return nil, err
 	}
-	return gcm.Seal(nonce, nonce, []byte(plaintext), nil), nil
+	s.credentialKey = newKey
+	result.Peers = peers
+	return result, nil
 }
 
-// decryptWithKey decrypts ciphertext using AES-GCM with the given key.
-func decryptWithKey(encrypted []byte, key []byte) (string, error) {
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", err
-	}
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", err
+// EncryptPeerMetadata encrypts the username and remote URL of every peer
+// stored in plaintext in a single transaction and, unless opts.DryRun,
+// commits the change to Dolt history.
+func (s *DoltStore) EncryptPeerMetadata(ctx context.Context, opts storage.PeerMetadataEncryptionOptions) (*storage.PeerMetadataEncryptionResult, error) {
+	if err := s.ensureCredentialKey(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialize credential key: %w", err)
 	}
-	nonceSize := gcm.NonceSize()
-	if len(encrypted) < nonceSize {
-		return "", fmt.Errorf("ciphertext too short")
+	s.mu.RLock()
+	key := s.credentialKey
+	s.mu.RUnlock()
+	if key == nil {
+		return nil, fmt.Errorf("beads directory not set; credential encryption unavailable")
 	}
-	nonce, ciphertext := encrypted[:nonceSize], encrypted[nonceSize:]
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+
+	result := &storage.PeerMetadataEncryptionResult{DryRun: opts.DryRun}
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result.Peers, err = issueops.EncryptFederationPeerMetadataInTx(ctx, tx, key, opts)
+		if err != nil || opts.DryRun || len(result.Peers) == 0 {
+			return err
+		}
+		return s.doltAddAndCommitInTx(ctx, tx, []string{"federation_peers"}, "federation: encrypt peer metadata")
+	})
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to encrypt peer metadata: %w", err)
 	}
-	return string(plaintext), nil
+	return result, nil
+}
+
+// encryptWithKey encrypts plaintext using AES-GCM with the given key.
+func encryptWithKey(plaintext string, key []byte) ([]byte, error) {
+	return issueops.EncryptWithKey(plaintext, key)
+}
+
+// decryptWithKey decrypts ciphertext using AES-GCM with the given key.
+func decryptWithKey(encrypted []byte, key []byte) (string, error) {
+	return issueops.DecryptWithKey(encrypted, key)
 }
 
 // encryptPassword encrypts a password using AES-GCM with the store's credential key.
@@ -267,31 +292,72 @@ func (s *DoltStore) AddFederationPeer(ctx context.Context, peer *storage.Federat
 	if err := validatePeerName(peer.Name); err != nil {
 		return fmt.Errorf("invalid peer name: %w", err)
 	}
+	if err := issueops.ValidatePeerCredentialSource(peer); err != nil {
+		return err
+	}
 
-	// Encrypt password before storing
-	var encryptedPwd []byte
+	// Encrypt password and SSH key passphrase before storing
+	var encryptedPwd, encryptedPassphrase []byte
 	var err error
-	if peer.Password != "" {
+	if peer.Password != "" || peer.SSHKeyPassphrase != "" {
 		if err := s.ensureCredentialKey(ctx); err != nil {
 			return fmt.Errorf("failed to initialize credential key: %w", err)
 		}
+	}
+	if peer.Password != "" {
 		encryptedPwd, err = s.encryptPassword(peer.Password)
 		if err != nil {
 			return fmt.Errorf("failed to encrypt password: %w", err)
 		}
 	}
+	if peer.SSHKeyPassphrase != "" {
+		encryptedPassphrase, err = s.encryptPassword(peer.SSHKeyPassphrase)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt SSH key passphrase: %w", err)
+		}
+	}
+
+	// A peer stored with encrypted metadata stays encrypted.
+	encryptMetadata := peer.EncryptMetadata
+	if !encryptMetadata {
+		if encryptMetadata, err = issueops.PeerMetadataEncryptedInTx(ctx, s.db, peer.Name); err != nil && !isTableNotExistError(err) {
+			return fmt.Errorf("failed to add federation peer: %w", err)
+		}
+	}
+	var metadata *issueops.EncryptedPeerMetadata
+	if encryptMetadata {
+		if err := s.ensureCredentialKey(ctx); err != nil {
+			return fmt.Errorf("failed to initialize credential key: %w", err)
+		}
+		metadata = &issueops.EncryptedPeerMetadata{}
+		if metadata.RemoteURL, err = s.encryptPassword(peer.RemoteURL); err != nil {
+			return fmt.Errorf("failed to encrypt remote URL: %w", err)
+		}
+		if metadata.Username, err = s.encryptPassword(peer.Username); err != nil {
+			return fmt.Errorf("failed to encrypt username: %w", err)
+		}
+	}
+	remoteURL, username, usernameEnc, remoteURLEnc := issueops.PeerMetadataColumns(peer, metadata)
 
 	// Upsert the peer credentials
 	_, err = s.execContext(ctx, `
-		INSERT INTO federation_peers (name, remote_url, username, password_encrypted, sovereignty)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO federation_peers (name, remote_url, username, password_encrypted, ssh_key_path, ssh_passphrase_encrypted, sovereignty, sync_mode, conflict_strategy, credential_source, owned_prefixes, username_encrypted, remote_url_encrypted)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON DUPLICATE KEY UPDATE
 			remote_url = VALUES(remote_url),
 			username = VALUES(username),
 			password_encrypted = VALUES(password_encrypted),
+			ssh_key_path = VALUES(ssh_key_path),
+			ssh_passphrase_encrypted = VALUES(ssh_passphrase_encrypted),
 			sovereignty = VALUES(sovereignty),
+			sync_mode = VALUES(sync_mode),
+			conflict_strategy = VALUES(conflict_strategy),
+			credential_source = VALUES(credential_source),
+			owned_prefixes = VALUES(owned_prefixes),
+			username_encrypted = VALUES(username_encrypted),
+			remote_url_encrypted = VALUES(remote_url_encrypted),
 			updated_at = CURRENT_TIMESTAMP
-	`, peer.Name, peer.RemoteURL, peer.Username, encryptedPwd, peer.Sovereignty)
+	`, peer.Name, remoteURL, username, encryptedPwd, peer.SSHKeyPath, encryptedPassphrase, peer.Sovereignty, string(peer.SyncMode), string(peer.ConflictStrategy), peer.CredentialSource, storage.JoinOwnedPrefixes(peer.OwnedPrefixes), usernameEnc, remoteURLEnc)
 
 	if err != nil {
 		return fmt.Errorf("failed to add federation peer: %w", err)
@@ -316,14 +382,15 @@ func (s *DoltStore) AddFederationPeer(ctx context.Context, peer *storage.Federat
 // Returns storage.ErrNotFound (wrapped) if the peer does not exist.
 func (s *DoltStore) GetFederationPeer(ctx context.Context, name string) (*storage.FederationPeer, error) {
 	var peer storage.FederationPeer
-	var encryptedPwd []byte
+	var encryptedPwd, encryptedPassphrase, encryptedUsername, encryptedURL []byte
 	var lastSync sql.NullTime
-	var username sql.NullString
+	var username, sshKeyPath sql.NullString
+	var ownedPrefixes string
 
 	err := s.db.QueryRowContext(ctx, `
-		SELECT name, remote_url, username, password_encrypted, sovereignty, last_sync, created_at, updated_at
+		SELECT name, remote_url, username, password_encrypted, ssh_key_path, ssh_passphrase_encrypted, sovereignty, sync_mode, conflict_strategy, credential_source, owned_prefixes, username_encrypted, remote_url_encrypted, last_sync, created_at, updated_at
 		FROM federation_peers WHERE name = ?
-	`, name).Scan(&peer.Name, &peer.RemoteURL, &username, &encryptedPwd, &peer.Sovereignty, &lastSync, &peer.CreatedAt, &peer.UpdatedAt)
+	`, name).Scan(&peer.Name, &peer.RemoteURL, &username, &encryptedPwd, &sshKeyPath, &encryptedPassphrase, &peer.Sovereignty, &peer.SyncMode, &peer.ConflictStrategy, &peer.CredentialSource, &ownedPrefixes, &encryptedUsername, &encryptedURL, &lastSync, &peer.CreatedAt, &peer.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("%w: federation peer %s", storage.ErrNotFound, name)
@@ -335,19 +402,16 @@ func (s *DoltStore) GetFederationPeer(ctx context.Context, name string) (*storag
 	if username.Valid {
 		peer.Username = username.String
 	}
+	if sshKeyPath.Valid {
+		peer.SSHKeyPath = sshKeyPath.String
+	}
 	if lastSync.Valid {
 		peer.LastSync = &lastSync.Time
 	}
+	peer.OwnedPrefixes = storage.SplitOwnedPrefixes(ownedPrefixes)
 
-	// Decrypt password
-	if len(encryptedPwd) > 0 {
-		if err := s.ensureCredentialKey(ctx); err != nil {
-			return nil, fmt.Errorf("failed to initialize credential key: %w", err)
-		}
-		peer.Password, err = s.decryptPassword(encryptedPwd)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt password: %w", err)
-		}
+	if err := s.decryptPeerSecrets(ctx, &peer, encryptedPwd, encryptedPassphrase, encryptedUsername, encryptedURL); err != nil {
+		return nil, err
 	}
 
 	return &peer, nil
@@ -356,7 +420,7 @@ func (s *DoltStore) GetFederationPeer(ctx context.Context, name string) (*storag
 // ListFederationPeers returns all configured federation peers.
 func (s *DoltStore) ListFederationPeers(ctx context.Context) ([]*storage.FederationPeer, error) {
 	rows, err := s.queryContext(ctx, `
-		SELECT name, remote_url, username, password_encrypted, sovereignty, last_sync, created_at, updated_at
+		SELECT name, remote_url, username, password_encrypted, ssh_key_path, ssh_passphrase_encrypted, sovereignty, sync_mode, conflict_strategy, credential_source, owned_prefixes, username_encrypted, remote_url_encrypted, last_sync, created_at, updated_at
 		FROM federation_peers ORDER BY name
 	`)
 	if err != nil {
@@ -367,30 +431,28 @@ func (s *DoltStore) ListFederationPeers(ctx context.Context) ([]*storage.Federat
 	var peers []*storage.FederationPeer
 	for rows.Next() {
 		var peer storage.FederationPeer
-		var encryptedPwd []byte
+		var encryptedPwd, encryptedPassphrase, encryptedUsername, encryptedURL []byte
 		var lastSync sql.NullTime
-		var username sql.NullString
+		var username, sshKeyPath sql.NullString
+		var ownedPrefixes string
 
-		if err := rows.Scan(&peer.Name, &peer.RemoteURL, &username, &encryptedPwd, &peer.Sovereignty, &lastSync, &peer.CreatedAt, &peer.UpdatedAt); err != nil {
+		if err := rows.Scan(&peer.Name, &peer.RemoteURL, &username, &encryptedPwd, &sshKeyPath, &encryptedPassphrase, &peer.Sovereignty, &peer.SyncMode, &peer.ConflictStrategy, &peer.CredentialSource, &ownedPrefixes, &encryptedUsername, &encryptedURL, &lastSync, &peer.CreatedAt, &peer.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan federation peer: %w", err)
 		}
 
 		if username.Valid {
 			peer.Username = username.String
 		}
+		if sshKeyPath.Valid {
+			peer.SSHKeyPath = sshKeyPath.String
+		}
 		if lastSync.Valid {
 			peer.LastSync = &lastSync.Time
 		}
+		peer.OwnedPrefixes = storage.SplitOwnedPrefixes(ownedPrefixes)
 
-		// Decrypt password
-		if len(encryptedPwd) > 0 {
-			if err := s.ensureCredentialKey(ctx); err != nil {
-				return nil, fmt.Errorf("failed to initialize credential key: %w", err)
-			}
-			peer.Password, err = s.decryptPassword(encryptedPwd)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decrypt password: %w", err)
-			}
+		if err := s.decryptPeerSecrets(ctx, &peer, encryptedPwd, encryptedPassphrase, encryptedUsername, encryptedURL); err != nil {
+			return nil, err
 		}
 
 		peers = append(peers, &peer)
@@ -399,6 +461,68 @@ func (s *DoltStore) ListFederationPeers(ctx context.Context) ([]*storage.Federat
 	return peers, rows.Err()
 }
 
+// decryptPeerSecrets decrypts a peer's stored password and SSH key
+// passphrase, and its username and remote URL if they are encrypted, into
+// peer.
+func (s *DoltStore) decryptPeerSecrets(ctx context.Context, peer *storage.FederationPeer, encryptedPwd, encryptedPassphrase, encryptedUsername, encryptedURL []byte) error {
+	if len(encryptedPwd) == 0 && len(encryptedPassphrase) == 0 && len(encryptedURL) == 0 {
+		return nil
+	}
+	if err := s.ensureCredentialKey(ctx); err != nil {
+		return fmt.Errorf("failed to initialize credential key: %w", err)
+	}
+	var err error
+	if peer.Password, err = s.decryptPassword(encryptedPwd); err != nil {
+		return fmt.Errorf("failed to decrypt password: %w", err)
+	}
+	if peer.SSHKeyPassphrase, err = s.decryptPassword(encryptedPassphrase); err != nil {
+		return fmt.Errorf("failed to decrypt SSH key passphrase: %w", err)
+	}
+	return issueops.DecryptPeerMetadata(peer, encryptedUsername, encryptedURL, s.decryptPassword)
+}
+
+// peerSyncMode returns a peer's sync mode. Remotes that are not registered
+// federation peers sync bidirectionally.
+func (s *DoltStore) peerSyncMode(ctx context.Context, peer string) (storage.SyncMode, error) {
+	var mode string
+	err := s.db.QueryRowContext(ctx, "SELECT sync_mode FROM federation_peers WHERE name = ?", peer).Scan(&mode)
+	if err == sql.ErrNoRows {
+		return storage.SyncModeBidirectional, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get sync mode for peer %s: %w", peer, err)
+	}
+	return storage.ParseSyncMode(mode)
+}
+
+// syncConflictStrategy returns the strategy Sync applies to merge
+// conflicts: the explicit one if given, otherwise the peer's default.
+// Remotes that are not registered federation peers have no default.
+func (s *DoltStore) syncConflictStrategy(ctx context.Context, peer, explicit string) (storage.ConflictStrategy, error) {
+	if strategy, err := storage.ParseConflictStrategy(explicit); err != nil || strategy != "" {
+		return strategy, err
+	}
+	var strategy string
+	err := s.db.QueryRowContext(ctx, "SELECT conflict_strategy FROM federation_peers WHERE name = ?", peer).Scan(&strategy)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get conflict strategy for peer %s: %w", peer, err)
+	}
+	return storage.ParseConflictStrategy(strategy)
+}
+
+// checkPeerDirection refuses a push to (push) or pull from (!push) a peer
+// whose sync mode does not allow it.
+func (s *DoltStore) checkPeerDirection(ctx context.Context, peer string, push bool) error {
+	mode, err := s.peerSyncMode(ctx, peer)
+	if err != nil {
+		return err
+	}
+	return storage.CheckSyncDirection(peer, mode, push)
+}
+
 // RemoveFederationPeer removes a federation peer and its credentials.
 func (s *DoltStore) RemoveFederationPeer(ctx context.Context, name string) error {
 	result, err := s.execContext(ctx, "DELETE FROM federation_peers WHERE name = ?", name)
@@ -412,8 +536,11 @@ func (s *DoltStore) RemoveFederationPeer(ctx context.Context, name string) error
 		// Continue to try removing the remote
 	}
 
-	// Also remove the Dolt remote (best-effort)
-	_ = s.RemoveRemote(ctx, name) // Best effort cleanup before re-adding remote
+	// Also remove the Dolt remote (best-effort). A peer may have no remote,
+	// so "not found" is expected rather than a failure.
+	if rmErr := s.RemoveRemote(ctx, name); rmErr != nil && !strings.Contains(rmErr.Error(), "not found") {
+		besteffort.Record("remove peer remote", rmErr)
+	}
 
 	return nil
 }
@@ -425,19 +552,56 @@ func (s *DoltStore) updatePeerLastSync(ctx context.Context, name string) error {
 }
 
 // remoteCredentials holds authentication credentials for a Dolt remote.
-// Used to pass credentials to CLI subprocesses via cmd.Env (isolated) or to
-// the SQL path via process env vars under mutex protection.
+// They reach dolt only through a CLI subprocess's cmd.Env (applyToCmd), so
+// operations against different remotes never share process state.
 type remoteCredentials struct {
-	username string
-	password string
+	username   string
+	password   string
+	sshKeyPath string // private key for SSH (git+ssh) remotes
+	sshAskpass string // helper answering sshKeyPath's passphrase, from withPeerCredentials
 }
 
 // empty returns true if no credentials are set.
 func (c *remoteCredentials) empty() bool {
-	return c == nil || (c.username == "" && c.password == "")
+	return c == nil || (c.username == "" && c.password == "" && c.sshKeyPath == "")
 }
 
-// applyToCmd sets DOLT_REMOTE_USER/PASSWORD on the subprocess environment,
+// env returns the environment entries that carry these credentials:
+// DOLT_REMOTE_USER/PASSWORD for SQL user auth, and for an SSH key a
+// GIT_SSH_COMMAND that pins the key plus, when the key has a passphrase, an
+// SSH_ASKPASS helper that answers the passphrase prompt without a terminal.
+// The passphrase itself is never in the environment; only the helper reads it.
+func (c *remoteCredentials) env() []string {
+	if c.empty() {
+		return nil
+	}
+	var env []string
+	if c.username != "" {
+		env = append(env, "DOLT_REMOTE_USER="+c.username)
+	}
+	if c.password != "" {
+		env = append(env, "DOLT_REMOTE_PASSWORD="+c.password)
+	}
+	if c.sshKeyPath != "" {
+		env = append(env, "GIT_SSH_COMMAND=ssh -i "+shellQuote(c.sshKeyPath)+" -o IdentitiesOnly=yes")
+		if c.sshAskpass != "" {
+			env = append(env,
+				"SSH_ASKPASS="+c.sshAskpass,
+				"SSH_ASKPASS_REQUIRE=force",
+			)
+		}
+	}
+	return env
+}
+
+// credentialEnvKeys are the variables env may set. applyToCmd strips them
+// from the inherited environment so stale values never reach the subprocess.
+var credentialEnvKeys = []string{
+	"DOLT_REMOTE_USER", "DOLT_REMOTE_PASSWORD",
+	"GIT_SSH_COMMAND", "SSH_ASKPASS", "SSH_ASKPASS_REQUIRE",
+}
+
+// applyToCmd sets the credential env vars on the subprocess environment,
 // isolating credentials to this specific exec.Cmd. This avoids setting
 // process-wide env vars that could leak to concurrent goroutines.
 func (c *remoteCredentials) applyToCmd(cmd *exec.Cmd) {
@@ -445,20 +609,60 @@ func (c *remoteCredentials) applyToCmd(cmd *exec.Cmd) {
 		return
 	}
 	// Start with current process env, filtering out any existing credential vars
-	// to prevent stale values from leaking into the subprocess.
-	env := make([]string, 0, len(os.Environ())+2)
+	// to prevent stale values from leaking into the subprocess. The SSH vars are
+	// only replaced when this peer has a key, so a user's own GIT_SSH_COMMAND
+	// still applies to password-only peers.
+	strip := credentialEnvKeys[:2]
+	if c.sshKeyPath != "" {
+		strip = credentialEnvKeys
+	}
+	own := c.env()
+	env := make([]string, 0, len(os.Environ())+len(own))
 	for _, e := range os.Environ() {
-		if !strings.HasPrefix(e, "DOLT_REMOTE_USER=") && !strings.HasPrefix(e, "DOLT_REMOTE_PASSWORD=") {
+		if !hasEnvKey(e, strip) {
 			env = append(env, e)
 		}
 	}
-	if c.username != "" {
-		env = append(env, "DOLT_REMOTE_USER="+c.username)
+	cmd.Env = append(env, own...)
+}
+
+func hasEnvKey(entry string, keys []string) bool {
+	for _, k := range keys {
+		if strings.HasPrefix(entry, k+"=") {
+			return true
+		}
 	}
-	if c.password != "" {
-		env = append(env, "DOLT_REMOTE_PASSWORD="+c.password)
+	return false
+}
+
+// shellQuote single-quotes s for the shell git runs GIT_SSH_COMMAND through.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// writeSSHAskpass writes a private askpass helper that prints passphrase,
+// served to it by serveSSHPassphrase. The returned cleanup removes both.
+func writeSSHAskpass(passphrase string) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "bd-askpass-")
+	if err != nil {
+		return "", nil, fmt.Errorf("create askpass directory: %w", err)
+	}
+	passPath, stop, err := serveSSHPassphrase(dir, passphrase)
+	if err != nil {
+		besteffort.Record("remove SSH askpass helper", os.RemoveAll(dir))
+		return "", nil, err
+	}
+	cleanup := func() {
+		stop()
+		besteffort.Record("remove SSH askpass helper", os.RemoveAll(dir))
+	}
+	name, script := askpassScript(passPath)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil { // #nosec G306 -- must be executable; holds no secret
+		cleanup()
+		return "", nil, fmt.Errorf("write askpass helper: %w", err)
 	}
-	cmd.Env = env
+	return path, cleanup, nil
 }
 
 func setCmdEnv(cmd *exec.Cmd, key, value string) {
@@ -501,89 +705,90 @@ func applyNoGitHooksToCmd(cmd *exec.Cmd) {
 	setCmdEnv(cmd, "GIT_CONFIG_PARAMETERS", "'core.hooksPath=/dev/null'")
 }
 
-// setFederationCredentials sets DOLT_REMOTE_USER and DOLT_REMOTE_PASSWORD env vars.
-// Returns a cleanup function that must be called (typically via defer) to unset them.
-// The caller must hold federationEnvMutex.
-// Only used for SQL-path operations where the in-process Dolt server reads from
-// the process environment. CLI operations should use remoteCredentials.applyToCmd instead.
-func setFederationCredentials(username, password string) func() {
-	if username != "" {
-		_ = os.Setenv("DOLT_REMOTE_USER", username) // Best effort: Setenv failure is extremely rare in practice
-	}
-	if password != "" {
-		_ = os.Setenv("DOLT_REMOTE_PASSWORD", password) // Best effort: Setenv failure is extremely rare in practice
-	}
-	return func() {
-		_ = os.Unsetenv("DOLT_REMOTE_USER")     // Best effort cleanup of auth env vars
-		_ = os.Unsetenv("DOLT_REMOTE_PASSWORD") // Best effort cleanup of auth env vars
-	}
-}
-
 func setS3ChecksumEnv() func() {
 	prev, hadPrev := os.LookupEnv(awsResponseChecksumValidationEnv)
-	_ = os.Setenv(awsResponseChecksumValidationEnv, "when_required")
+	besteffort.Record("set "+awsResponseChecksumValidationEnv, os.Setenv(awsResponseChecksumValidationEnv, "when_required"))
 	return func() {
 		if hadPrev {
-			_ = os.Setenv(awsResponseChecksumValidationEnv, prev)
+			besteffort.Record("restore "+awsResponseChecksumValidationEnv, os.Setenv(awsResponseChecksumValidationEnv, prev))
 		} else {
-			_ = os.Unsetenv(awsResponseChecksumValidationEnv)
+			besteffort.Record("restore "+awsResponseChecksumValidationEnv, os.Unsetenv(awsResponseChecksumValidationEnv))
 		}
 	}
 }
 
-func withRemoteOperationEnv(creds *remoteCredentials, s3Checksum bool, fn func() error) error {
-	if creds.empty() && !s3Checksum {
+// withS3ChecksumEnv runs fn with AWS_RESPONSE_CHECKSUM_VALIDATION set to
+// when_required if s3Checksum is true. Only S3 remotes need the override, so
+// other remotes run fn directly and never wait on s3ChecksumEnvMutex.
+func withS3ChecksumEnv(s3Checksum bool, fn func() error) error {
+	if !s3Checksum {
 		return fn()
 	}
-	federationEnvMutex.Lock()
-	defer federationEnvMutex.Unlock()
-
-	var cleanups []func()
-	if !creds.empty() {
-		cleanups = append(cleanups, setFederationCredentials(creds.username, creds.password))
-	}
-	if s3Checksum {
-		cleanups = append(cleanups, setS3ChecksumEnv())
-	}
-	defer func() {
-		for i := len(cleanups) - 1; i >= 0; i-- {
-			cleanups[i]()
-		}
-	}()
-
+	s3ChecksumEnvMutex.Lock()
+	defer s3ChecksumEnvMutex.Unlock()
+	defer setS3ChecksumEnv()()
 	return fn()
 }
 
-// withEnvCredentials executes fn with credentials set as process-wide env vars,
-// protected by federationEnvMutex. This is required for SQL-path operations
-// (CALL DOLT_PUSH/PULL) where the in-process Dolt server reads credentials
-// from the process environment. CLI operations should NOT use this — use
-// remoteCredentials.applyToCmd instead for race-free subprocess isolation.
-func withEnvCredentials(creds *remoteCredentials, fn func() error) error {
-	return withRemoteOperationEnv(creds, false, fn)
+// warnSQLPathCredentials reports credentials that a SQL-path remote operation
+// cannot use. CALL DOLT_PUSH/PULL/FETCH run inside the dolt sql-server
+// process, which reads a remote password only from its own environment
+// (Dolt has no per-session remote credentials), so stored credentials are
+// only delivered through the CLI route. The SQL route is taken when no local
+// CLI database exists to run dolt in.
+func (s *DoltStore) warnSQLPathCredentials(remote string, creds *remoteCredentials) {
+	if creds.empty() {
+		return
+	}
+	log.Printf("warning: stored credentials for remote %s are not sent over SQL (no dolt CLI database at %s); the sql-server's own DOLT_REMOTE_PASSWORD applies", remote, s.CLIDir())
 }
 
 // withPeerCredentials looks up credentials for a federation peer and passes
-// them to fn. The callback receives the credentials and is responsible for
-// applying them appropriately: CLI operations use creds.applyToCmd for
-// subprocess isolation; SQL operations use withEnvCredentials for mutex-protected
-// process env access.
+// them to fn. The callback applies them to its dolt subprocess with
+// creds.applyToCmd; nothing is written to the process environment, so syncs
+// with different peers can run concurrently.
 func (s *DoltStore) withPeerCredentials(ctx context.Context, peerName string, fn func(creds *remoteCredentials) error) error {
+	// A peer added without credentials is a plain remote with no
+	// federation_peers row.
 	peer, err := s.GetFederationPeer(ctx, peerName)
+	if errors.Is(err, storage.ErrNotFound) {
+		peer, err = nil, nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to get peer credentials: %w", err)
 	}
 
+	// A credential source is resolved now, for this operation only, so the
+	// password never touches disk.
+	if peer != nil && peer.CredentialSource != "" {
+		peer.Username, peer.Password, err = storage.ResolveCredentialSource(ctx, peer.CredentialSource, peer.RemoteURL, peer.Username)
+		if err != nil {
+			return fmt.Errorf("failed to resolve credentials for peer %s: %w", peerName, err)
+		}
+	}
+
 	var creds *remoteCredentials
-	if peer != nil && (peer.Username != "" || peer.Password != "") {
-		creds = &remoteCredentials{username: peer.Username, password: peer.Password}
+	if peer != nil && (peer.Username != "" || peer.Password != "" || peer.SSHKeyPath != "") {
+		creds = &remoteCredentials{
+			username:   peer.Username,
+			password:   peer.Password,
+			sshKeyPath: peer.SSHKeyPath,
+		}
+		if creds.sshKeyPath != "" && peer.SSHKeyPassphrase != "" {
+			askpass, cleanup, err := writeSSHAskpass(peer.SSHKeyPassphrase)
+			if err != nil {
+				return fmt.Errorf("failed to prepare SSH key for peer %s: %w", peerName, err)
+			}
+			defer cleanup()
+			creds.sshAskpass = askpass
+		}
 	}
 
 	err = fn(creds)
 
 	// Update last sync time on success
 	if err == nil && peer != nil {
-		_ = s.updatePeerLastSync(ctx, peerName) // Best effort: peer sync timestamp is advisory
+		besteffort.Record("update peer last sync", s.updatePeerLastSync(ctx, peerName)) // peer sync timestamp is advisory
 	}
 
 	return err
@@ -597,7 +802,7 @@ func (s *DoltStore) prepareCLIRouteForPeerCredentials(ctx context.Context, peer
 		return false, nil // no credentials to pass
 	}
 	if !s.serverMode {
-		return false, nil // embedded mode: withEnvCredentials works in-process
+		return false, nil // no external server to route around
 	}
 	if !s.hasCLIDatabase() {
 		return false, nil
@@ -608,7 +813,7 @@ func (s *DoltStore) prepareCLIRouteForPeerCredentials(ctx context.Context, peer
 	}
 	for _, r := range remotes {
 		if r.Name == peer {
-			if err := s.ensureMatchingCLIRemote(peer, r.URL); err != nil {
+			if err := s.ensureMatchingCLIRemote(ctx, peer, r.URL); err != nil {
 				return false, fmt.Errorf("peer remote %q has credentials and requires CLI routing: %w", peer, err)
 			}
 			return true, nil
@@ -625,8 +830,8 @@ func (s *DoltStore) shouldUseCLIForPeerCredentialsWithError(ctx context.Context,
 // be used instead of SQL path for credential-bearing push/pull operations.
 //
 // When true, callers should route through doltCLIPush/Pull instead of
-// CALL DOLT_PUSH/PULL, because withEnvCredentials() sets env vars on the
-// bd client process — the external server process cannot see them.
+// CALL DOLT_PUSH/PULL, because the external server process cannot see
+// credentials held by the bd client process.
 //
 // Returns true when ALL conditions are met:
 //  1. Credentials exist (remoteUser or remotePassword non-empty)
@@ -647,7 +852,7 @@ func (s *DoltStore) prepareCLIRouteForCredentials(ctx context.Context, remote st
 		return false, nil // no credentials to pass
 	}
 	if !s.serverMode {
-		return false, nil // embedded mode: withEnvCredentials works in-process
+		return false, nil // no external server to route around
 	}
 	if !s.hasCLIDatabase() {
 		return false, nil
@@ -658,7 +863,7 @@ func (s *DoltStore) prepareCLIRouteForCredentials(ctx context.Context, remote st
 	}
 	for _, r := range remotes {
 		if r.Name == remote {
-			if err := s.ensureMatchingCLIRemote(remote, r.URL); err != nil {
+			if err := s.ensureMatchingCLIRemote(ctx, remote, r.URL); err != nil {
 				return false, fmt.Errorf("remote %q has credentials and requires CLI routing: %w", remote, err)
 			}
 			return true, nil
@@ -684,7 +889,7 @@ func (s *DoltStore) shouldUseCLIForLocalRemoteWithError(ctx context.Context, rem
 	}
 	for _, r := range sqlRemotes {
 		if r.Name == remote {
-			return s.hasMatchingCLIRemote(remote, r.URL), nil
+			return s.hasMatchingCLIRemote(ctx, remote, r.URL), nil
 		}
 	}
 	return false, nil
@@ -794,7 +999,7 @@ func (s *DoltStore) prepareCLIRouteForCloudAuth(ctx context.Context, remote stri
 	for _, e := range os.Environ() {
 		for _, prefix := range prefixes {
 			if strings.HasPrefix(e, prefix) {
-				if err := s.ensureMatchingCLIRemote(remote, remoteURL); err != nil {
+				if err := s.ensureMatchingCLIRemote(ctx, remote, remoteURL); err != nil {
 					return false, fmt.Errorf("remote %q has cloud credentials and requires CLI routing: %w", remote, err)
 				}
 				return true, nil
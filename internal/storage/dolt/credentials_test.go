@@ -8,7 +8,9 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/doltutil"
@@ -99,6 +101,83 @@ func TestPrepareDoltCLITransferCommandAppliesCredentialsAndS3Env(t *testing.T) {
 	}
 }
 
+func TestApplyToCmdSSHKey(t *testing.T) {
+	t.Setenv("GIT_SSH_COMMAND", "ssh -i /stale/key")
+	t.Setenv("DOLT_REMOTE_PASSWORD", "stale")
+
+	cmd := exec.Command("dolt", "pull") // #nosec G204 -- test command is not executed
+	creds := &remoteCredentials{sshKeyPath: "/keys/it's id_ed25519", sshAskpass: "/tmp/askpass.sh"}
+	creds.applyToCmd(cmd)
+
+	got := map[string]string{}
+	for _, e := range cmd.Env {
+		if k, v, ok := strings.Cut(e, "="); ok {
+			got[k] = v
+		}
+	}
+	if want := `ssh -i '/keys/it'\''s id_ed25519' -o IdentitiesOnly=yes`; got["GIT_SSH_COMMAND"] != want {
+		t.Errorf("GIT_SSH_COMMAND = %q, want %q", got["GIT_SSH_COMMAND"], want)
+	}
+	if got["SSH_ASKPASS"] != "/tmp/askpass.sh" || got["SSH_ASKPASS_REQUIRE"] != "force" {
+		t.Errorf("askpass env = %q %q", got["SSH_ASKPASS"], got["SSH_ASKPASS_REQUIRE"])
+	}
+	if _, ok := got["DOLT_REMOTE_PASSWORD"]; ok {
+		t.Error("stale DOLT_REMOTE_PASSWORD leaked into the subprocess")
+	}
+
+	// A password-only peer keeps the user's own SSH configuration.
+	cmd = exec.Command("dolt", "pull") // #nosec G204 -- test command is not executed
+	(&remoteCredentials{username: "user", password: "pass"}).applyToCmd(cmd)
+	found := false
+	for _, e := range cmd.Env {
+		if e == "GIT_SSH_COMMAND=ssh -i /stale/key" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("GIT_SSH_COMMAND should be left alone for peers without an SSH key")
+	}
+}
+
+func TestWriteSSHAskpass(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("askpass helper is a shell script on unix")
+	}
+	path, cleanup, err := writeSSHAskpass("s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ssh may ask more than once per operation, and concurrent ssh processes
+	// share the helper; each run gets the passphrase.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out, err := exec.Command(path, "Enter passphrase for key:").Output() // #nosec G204 -- helper written by the test
+			if err != nil {
+				t.Errorf("askpass helper: %v", err)
+				return
+			}
+			if string(out) != "s3cret\n" {
+				t.Errorf("askpass output = %q, want %q", out, "s3cret\n")
+			}
+		}()
+	}
+	wg.Wait()
+	script, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(script), "s3cret") {
+		t.Errorf("askpass helper holds the passphrase: %q", script)
+	}
+	cleanup()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("cleanup left %s behind: %v", path, err)
+	}
+}
+
 func TestApplyNoGitHooksToCmd(t *testing.T) {
 	cmd := exec.Command("dolt", "push") // #nosec G204 -- test command is not executed
 	applyNoGitHooksToCmd(cmd)
@@ -142,43 +221,61 @@ func TestApplyNoGitHooksToCmdComposesWithCredentials(t *testing.T) {
 	}
 }
 
-func TestWithRemoteOperationEnvRestoresS3ChecksumEnv(t *testing.T) {
+func TestWithS3ChecksumEnvRestoresS3ChecksumEnv(t *testing.T) {
 	t.Setenv(awsResponseChecksumValidationEnv, "when_supported")
 
-	err := withRemoteOperationEnv(nil, true, func() error {
+	err := withS3ChecksumEnv(true, func() error {
 		if got := os.Getenv(awsResponseChecksumValidationEnv); got != "when_required" {
 			t.Fatalf("%s during operation = %q, want when_required", awsResponseChecksumValidationEnv, got)
 		}
 		return nil
 	})
 	if err != nil {
-		t.Fatalf("withRemoteOperationEnv returned error: %v", err)
+		t.Fatalf("withS3ChecksumEnv returned error: %v", err)
 	}
 	if got := os.Getenv(awsResponseChecksumValidationEnv); got != "when_supported" {
 		t.Fatalf("%s after operation = %q, want restored when_supported", awsResponseChecksumValidationEnv, got)
 	}
 }
 
-func TestWithRemoteOperationEnvUnsetsS3ChecksumEnv(t *testing.T) {
+func TestWithS3ChecksumEnvUnsetsS3ChecksumEnv(t *testing.T) {
 	t.Setenv(awsResponseChecksumValidationEnv, "")
 	if err := os.Unsetenv(awsResponseChecksumValidationEnv); err != nil {
 		t.Fatalf("unset %s: %v", awsResponseChecksumValidationEnv, err)
 	}
 
-	err := withRemoteOperationEnv(nil, true, func() error {
+	err := withS3ChecksumEnv(true, func() error {
 		if got := os.Getenv(awsResponseChecksumValidationEnv); got != "when_required" {
 			t.Fatalf("%s during operation = %q, want when_required", awsResponseChecksumValidationEnv, got)
 		}
 		return nil
 	})
 	if err != nil {
-		t.Fatalf("withRemoteOperationEnv returned error: %v", err)
+		t.Fatalf("withS3ChecksumEnv returned error: %v", err)
 	}
 	if _, ok := os.LookupEnv(awsResponseChecksumValidationEnv); ok {
 		t.Fatalf("%s should be unset after operation", awsResponseChecksumValidationEnv)
 	}
 }
 
+// Remote operations on non-S3 remotes must not serialize behind the S3
+// checksum lock, so concurrent syncs to different peers run in parallel.
+func TestWithS3ChecksumEnvSkipsLockForOtherRemotes(t *testing.T) {
+	s3ChecksumEnvMutex.Lock()
+	defer s3ChecksumEnvMutex.Unlock()
+
+	done := make(chan error, 1)
+	go func() { done <- withS3ChecksumEnv(false, func() error { return nil }) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("withS3ChecksumEnv returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("non-S3 operation blocked on the S3 checksum lock")
+	}
+}
+
 func TestIsS3RemoteURL(t *testing.T) {
 	tests := []struct {
 		name string
@@ -787,14 +884,14 @@ func TestCloudAuthCLIRoutingStructural(t *testing.T) {
 			t.Fatalf("AddRemote: %v", err)
 		}
 		ensureCloudAuthCLIDatabase(t, store)
-		if got := doltutil.FindCLIRemote(store.CLIDir(), "origin"); got != "" {
+		if got := doltutil.FindCLIRemote(t.Context(), store.CLIDir(), "origin"); got != "" {
 			t.Fatalf("precondition: local CLI remote = %q, want absent", got)
 		}
 		t.Setenv("AZURE_STORAGE_ACCOUNT", "myaccount")
 		if !store.shouldUseCLIForCloudAuth(context.Background(), "origin") {
 			t.Error("expected true when SQL remote can be materialized into local CLI directory")
 		}
-		if got := doltutil.FindCLIRemote(store.CLIDir(), "origin"); !doltutil.RemoteURLsMatch(got, remoteURL) {
+		if got := doltutil.FindCLIRemote(t.Context(), store.CLIDir(), "origin"); !doltutil.RemoteURLsMatch(got, remoteURL) {
 			t.Errorf("local CLI remote = %q, want %q", got, remoteURL)
 		}
 	})
@@ -49,7 +49,7 @@ func (s *DoltStore) AddDependencyWithOptions(ctx context.Context, dep *types.Dep
 	}
 
 	var eventWritten bool
-	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		opts := issueops.AddDependencyOpts{
 			SourceTable:   "issues",
 			TargetTable:   targetTable,
@@ -90,7 +90,7 @@ func (s *DoltStore) RemoveDependency(ctx context.Context, issueID, dependsOnID s
 func (s *DoltStore) RemoveDependencyWithOptions(ctx context.Context, issueID, dependsOnID string, actor string, rmOpts storage.DependencyRemoveOptions) error {
 	// Wisps live in dolt_ignored tables — skip Dolt versioning entirely.
 	if s.isActiveWisp(ctx, issueID) {
-		tx, err := s.db.BeginTx(ctx, nil)
+		ctx, tx, err := s.beginTx(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to begin transaction: %w", err)
 		}
@@ -101,7 +101,7 @@ func (s *DoltStore) RemoveDependencyWithOptions(ctx context.Context, issueID, de
 		return wrapTransactionError("commit remove wisp dependency", tx.Commit())
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -133,7 +133,7 @@ func (s *DoltStore) RemoveDependencyWithOptions(ctx context.Context, issueID, de
 // GetDependencies retrieves issues that this issue depends on
 func (s *DoltStore) GetDependencies(ctx context.Context, issueID string) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependenciesInTx(ctx, tx, issueID)
 		return err
@@ -144,7 +144,7 @@ func (s *DoltStore) GetDependencies(ctx context.Context, issueID string) ([]*typ
 // GetDependents retrieves issues that depend on this issue
 func (s *DoltStore) GetDependents(ctx context.Context, issueID string) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependentsInTx(ctx, tx, issueID)
 		return err
@@ -226,7 +226,7 @@ func (s *DoltStore) GetDependenciesWithMetadata(ctx context.Context, issueID str
 // Delegates to issueops.GetDependentsWithMetadataInTx which handles wisp routing.
 func (s *DoltStore) GetDependentsWithMetadata(ctx context.Context, issueID string) ([]*types.IssueWithDependencyMetadata, error) {
 	var result []*types.IssueWithDependencyMetadata
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependentsWithMetadataInTx(ctx, tx, issueID)
 		return err
@@ -258,7 +258,7 @@ func (s *DoltStore) GetDependencyRecords(ctx context.Context, issueID string) ([
 // issueops.GetDependentRecordsInTx for shared query logic.
 func (s *DoltStore) GetDependentRecords(ctx context.Context, targetID string, depType string, limit int, afterID string) ([]*types.Dependency, error) {
 	var result []*types.Dependency
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependentRecordsInTx(ctx, tx, targetID, depType, limit, afterID)
 		return err
@@ -270,7 +270,7 @@ func (s *DoltStore) GetDependentRecords(ctx context.Context, targetID string, de
 // both dependency tables. Delegates to issueops.CountDependentRecordsInTx.
 func (s *DoltStore) CountDependentRecords(ctx context.Context, targetID string, depType string) (int, error) {
 	var n int
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		n, err = issueops.CountDependentRecordsInTx(ctx, tx, targetID, depType)
 		return err
@@ -283,7 +283,7 @@ func (s *DoltStore) CountDependentRecords(ctx context.Context, targetID string,
 // issueops.GetDependentRecordsForIssuesInTx for shared query logic.
 func (s *DoltStore) GetDependentRecordsForIssues(ctx context.Context, targetIDs []string) (map[string][]*types.Dependency, error) {
 	var result map[string][]*types.Dependency
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependentRecordsForIssuesInTx(ctx, tx, targetIDs)
 		return err
@@ -295,7 +295,7 @@ func (s *DoltStore) GetDependentRecordsForIssues(ctx context.Context, targetIDs
 // Delegates to issueops.GetAllDependencyRecordsInTx for shared query logic.
 func (s *DoltStore) GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
 	var result map[string][]*types.Dependency
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetAllDependencyRecordsInTx(ctx, tx)
 		return err
@@ -307,7 +307,7 @@ func (s *DoltStore) GetAllDependencyRecords(ctx context.Context) (map[string][]*
 // Delegates to issueops.GetDependencyRecordsForIssuesInTx for shared query logic.
 func (s *DoltStore) GetDependencyRecordsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Dependency, error) {
 	var result map[string][]*types.Dependency
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependencyRecordsForIssuesInTx(ctx, tx, issueIDs)
 		return err
@@ -323,7 +323,7 @@ func (s *DoltStore) GetBlockingInfoForIssues(ctx context.Context, issueIDs []str
 	parentMap map[string]string,
 	err error,
 ) {
-	err = s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err = s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var txErr error
 		blockedByMap, blocksMap, parentMap, txErr = issueops.GetBlockingInfoForIssuesInTx(ctx, tx, issueIDs)
 		return txErr
@@ -335,7 +335,7 @@ func (s *DoltStore) GetBlockingInfoForIssues(ctx context.Context, issueIDs []str
 // Delegates to issueops.GetDependencyCountsInTx for shared query logic.
 func (s *DoltStore) GetDependencyCounts(ctx context.Context, issueIDs []string) (map[string]*types.DependencyCounts, error) {
 	var result map[string]*types.DependencyCounts
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependencyCountsInTx(ctx, tx, issueIDs)
 		return err
@@ -346,7 +346,7 @@ func (s *DoltStore) GetDependencyCounts(ctx context.Context, issueIDs []string)
 // GetDependencyTree returns a dependency tree for visualization
 func (s *DoltStore) GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error) {
 	var result []*types.TreeNode
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependencyTreeInTx(ctx, tx, issueID, maxDepth, showAllPaths, reverse)
 		return err
@@ -359,7 +359,7 @@ func (s *DoltStore) GetDependencyTree(ctx context.Context, issueID string, maxDe
 // cycles (e.g., permanent A -> wisp B -> permanent A). (bd-xe27)
 func (s *DoltStore) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
 	var result [][]*types.Issue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.DetectCyclesInTx(ctx, tx)
 		return err
@@ -370,7 +370,7 @@ func (s *DoltStore) DetectCycles(ctx context.Context) ([][]*types.Issue, error)
 func (s *DoltStore) IsBlocked(ctx context.Context, issueID string) (bool, []string, error) {
 	var blocked bool
 	var blockers []string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		blocked, blockers, err = issueops.IsBlockedInTx(ctx, tx, issueID)
 		return err
@@ -385,7 +385,7 @@ func (s *DoltStore) IsBlocked(ctx context.Context, issueID string) (bool, []stri
 // in one batched read. Delegates to issueops.IsBlockedBatchInTx.
 func (s *DoltStore) IsBlockedBatch(ctx context.Context, ids []string) (map[string]bool, error) {
 	var result map[string]bool
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.IsBlockedBatchInTx(ctx, tx, ids)
 		return err
@@ -399,7 +399,7 @@ func (s *DoltStore) IsBlockedBatch(ctx context.Context, ids []string) (map[strin
 // GetNewlyUnblockedByClose finds issues that become unblocked when an issue is closed.
 func (s *DoltStore) GetNewlyUnblockedByClose(ctx context.Context, closedIssueID string) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetNewlyUnblockedByCloseInTx(ctx, tx, closedIssueID)
 		return err
@@ -464,7 +464,7 @@ func (s *DoltStore) GetIssuesByIDs(ctx context.Context, ids []string) ([]*types.
 		return nil, nil
 	}
 	var result []*types.Issue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetIssuesByIDsInTx(ctx, tx, ids, nil)
 		return err
@@ -929,7 +929,7 @@ func createBenchIssueBatch(b *testing.B, store *DoltStore, issues []*types.Issue
 	b.Helper()
 	ctx := context.Background()
 	now := time.Now().UTC()
-	err := store.withRetryTx(ctx, func(tx *sql.Tx) error {
+	err := store.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		issuesByTable := map[string][]*types.Issue{
 			"issues": nil,
 			"wisps":  nil,
@@ -14,7 +14,7 @@ import (
 // AddComment adds a comment event to an issue
 func (s *DoltStore) AddComment(ctx context.Context, issueID, actor, comment string) error {
 	isWisp := s.isActiveWisp(ctx, issueID)
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("begin tx: %w", err)
 	}
@@ -35,7 +35,7 @@ func (s *DoltStore) AddComment(ctx context.Context, issueID, actor, comment stri
 // GetEvents retrieves events for an issue
 func (s *DoltStore) GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error) {
 	var result []*types.Event
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetEventsInTx(ctx, tx, issueID, limit)
 		return err
@@ -47,7 +47,7 @@ func (s *DoltStore) GetEvents(ctx context.Context, issueID string, limit int) ([
 // Queries both events and wisp_events tables.
 func (s *DoltStore) GetAllEventsSince(ctx context.Context, since time.Time) ([]*types.Event, error) {
 	var result []*types.Event
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetAllEventsSinceInTx(ctx, tx, since)
 		return err
@@ -60,7 +60,7 @@ func (s *DoltStore) GetAllEventsSince(ctx context.Context, since time.Time) ([]*
 // issueID != "" scopes the feed to one bead's history.
 func (s *DoltStore) EventsSince(ctx context.Context, cursor storage.EventCursor, issueID string, limit int) ([]*types.Event, error) {
 	var result []*types.Event
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.EventsSinceInTx(ctx, tx, cursor.CreatedAt, cursor.ID, issueID, limit)
 		return err
@@ -70,17 +70,27 @@ func (s *DoltStore) EventsSince(ctx context.Context, cursor storage.EventCursor,
 
 // AddIssueComment adds a comment to an issue (structured comment)
 func (s *DoltStore) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
-	return s.ImportIssueComment(ctx, issueID, author, text, time.Now().UTC())
+	return s.writeIssueComment(ctx, issueID, func(ctx context.Context, tx *sql.Tx) (*types.Comment, error) {
+		return issueops.AddIssueCommentInTx(ctx, tx, issueID, author, text)
+	})
 }
 
 // ImportIssueComment adds a comment during import, preserving the original timestamp.
 // This prevents comment timestamp drift across import/export cycles.
 func (s *DoltStore) ImportIssueComment(ctx context.Context, issueID, author, text string, createdAt time.Time) (*types.Comment, error) {
+	return s.writeIssueComment(ctx, issueID, func(ctx context.Context, tx *sql.Tx) (*types.Comment, error) {
+		return issueops.ImportIssueCommentInTx(ctx, tx, issueID, author, text, createdAt)
+	})
+}
+
+// writeIssueComment runs insert in a write transaction and Dolt-commits the
+// comment unless the issue is a wisp.
+func (s *DoltStore) writeIssueComment(ctx context.Context, issueID string, insert func(context.Context, *sql.Tx) (*types.Comment, error)) (*types.Comment, error) {
 	isWisp := s.isActiveWisp(ctx, issueID)
 	var result *types.Comment
-	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		result, err = issueops.ImportIssueCommentInTx(ctx, tx, issueID, author, text, createdAt)
+		result, err = insert(ctx, tx)
 		return err
 	})
 	if err != nil {
@@ -123,7 +133,7 @@ func (s *DoltStore) GetIssueComments(ctx context.Context, issueID string) ([]*ty
 // read contract.
 func (s *DoltStore) GetIssueCommentsPage(ctx context.Context, issueID string, after storage.CommentPageCursor, limit int) ([]*types.Comment, error) {
 	var result []*types.Comment
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetIssueCommentsPageInTx(ctx, tx, issueID, after, limit)
 		return err
@@ -134,7 +144,7 @@ func (s *DoltStore) GetIssueCommentsPage(ctx context.Context, issueID string, af
 // GetCommentsForIssues retrieves comments for multiple issues
 func (s *DoltStore) GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error) {
 	var result map[string][]*types.Comment
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetCommentsForIssuesInTx(ctx, tx, issueIDs)
 		return err
@@ -146,7 +156,7 @@ func (s *DoltStore) GetCommentsForIssues(ctx context.Context, issueIDs []string)
 // Delegates to issueops.GetCommentCountsInTx for shared query logic.
 func (s *DoltStore) GetCommentCounts(ctx context.Context, issueIDs []string) (map[string]int, error) {
 	var result map[string]int
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetCommentCountsInTx(ctx, tx, issueIDs)
 		return err
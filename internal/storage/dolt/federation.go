@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/steveyegge/beads/internal/besteffort"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/doltutil"
@@ -31,6 +32,16 @@ func (s *DoltStore) PushTo(ctx context.Context, peer string) error {
 // pushRefToPeer pushes a specific refspec to a peer remote. The refspec can be
 // a simple branch name ("main") or a mapping ("staging:main").
 func (s *DoltStore) pushRefToPeer(ctx context.Context, peer string, refspec string) error {
+	if err := s.checkPeerDirection(ctx, peer, true); err != nil {
+		return err
+	}
+	return withRemoteRetry(ctx, "push", peer, func() error {
+		return s.pushRefToPeerOnce(ctx, peer, refspec)
+	})
+}
+
+// pushRefToPeerOnce makes one attempt at pushRefToPeer.
+func (s *DoltStore) pushRefToPeerOnce(ctx context.Context, peer string, refspec string) error {
 	if useCLI, err := s.prepareCLIRouteForPeerGitProtocol(ctx, peer); err != nil {
 		return err
 	} else if useCLI {
@@ -44,12 +55,11 @@ func (s *DoltStore) pushRefToPeer(ctx context.Context, peer string, refspec stri
 		} else if useCLI {
 			return s.doltCLIPushRefToPeer(ctx, peer, refspec, creds)
 		}
-		return withEnvCredentials(creds, func() error {
-			if err := s.execWithLongTimeout(ctx, "CALL DOLT_PUSH(?, ?)", peer, refspec); err != nil {
-				return fmt.Errorf("failed to push to peer %s: %w", peer, err)
-			}
-			return nil
-		})
+		s.warnSQLPathCredentials(peer, creds)
+		if err := s.execWithLongTimeout(ctx, "CALL DOLT_PUSH(?, ?)", peer, refspec); err != nil {
+			return fmt.Errorf("failed to push to peer %s: %w", peer, err)
+		}
+		return nil
 	})
 }
 
@@ -58,6 +68,10 @@ func (s *DoltStore) pushRefToPeer(ctx context.Context, peer string, refspec stri
 // For git-protocol remotes, uses CLI `dolt pull` to avoid MySQL connection timeouts.
 // Returns any merge conflicts if present.
 func (s *DoltStore) PullFrom(ctx context.Context, peer string) ([]storage.Conflict, error) {
+	if err := s.checkPeerDirection(ctx, peer, false); err != nil {
+		return nil, err
+	}
+
 	// GH#2474: Auto-commit pending changes before pull to prevent
 	// "cannot merge with uncommitted changes" errors.
 	if !s.readOnly {
@@ -85,30 +99,35 @@ func (s *DoltStore) PullFrom(ctx context.Context, peer string) ([]storage.Confli
 	// dolt_conflicts, and mixed-vintage schema_migrations rows conflict on
 	// every retry.
 	var conflicts []storage.Conflict
-	var err error
+	err := withRemoteRetry(ctx, "pull", peer, func() error {
+		return s.pullFromPeerOnce(ctx, peer, &conflicts)
+	})
+	return s.finishPeerPull(ctx, conflicts, err, preHead)
+}
+
+// pullFromPeerOnce makes one attempt at PullFrom's transport and settle,
+// storing conflicts left for the caller in conflicts.
+func (s *DoltStore) pullFromPeerOnce(ctx context.Context, peer string, conflicts *[]storage.Conflict) error {
 	if useCLI, routeErr := s.prepareCLIRouteForPeerGitProtocol(ctx, peer); routeErr != nil {
-		return nil, routeErr
+		return routeErr
 	} else if useCLI {
-		err = s.withPeerCredentials(ctx, peer, func(creds *remoteCredentials) error {
+		return s.withPeerCredentials(ctx, peer, func(creds *remoteCredentials) error {
 			pullErr := s.finishCLIPull(ctx, s.doltCLIPullFromPeer(ctx, peer, creds))
-			return s.peerPullOutcome(ctx, peer, pullErr, &conflicts)
+			return s.peerPullOutcome(ctx, peer, pullErr, conflicts)
 		})
-		return s.finishPeerPull(ctx, conflicts, err, preHead)
 	}
-	err = s.withPeerCredentials(ctx, peer, func(creds *remoteCredentials) error {
+	return s.withPeerCredentials(ctx, peer, func(creds *remoteCredentials) error {
 		// Credential CLI routing: mirrors git-protocol peer pull path.
 		if useCLI, err := s.prepareCLIRouteForPeerCredentials(ctx, peer, creds); err != nil {
 			return err
 		} else if useCLI {
 			pullErr := s.finishCLIPull(ctx, s.doltCLIPullFromPeer(ctx, peer, creds))
-			return s.peerPullOutcome(ctx, peer, pullErr, &conflicts)
+			return s.peerPullOutcome(ctx, peer, pullErr, conflicts)
 		}
-		return withEnvCredentials(creds, func() error {
-			pullErr := s.pullWithAutoResolve(ctx, peer, "CALL DOLT_PULL(?)", peer)
-			return s.peerPullOutcome(ctx, peer, pullErr, &conflicts)
-		})
+		s.warnSQLPathCredentials(peer, creds)
+		pullErr := s.pullWithAutoResolve(ctx, peer, "CALL DOLT_PULL(?)", peer)
+		return s.peerPullOutcome(ctx, peer, pullErr, conflicts)
 	})
-	return s.finishPeerPull(ctx, conflicts, err, preHead)
 }
 
 // peerPullOutcome converts a settled peer pull's result into PullFrom's
@@ -152,6 +171,13 @@ func (s *DoltStore) finishPeerPull(ctx context.Context, conflicts []storage.Conf
 // If credentials are stored for this peer, they are used automatically.
 // For git-protocol remotes, uses CLI `dolt fetch` to avoid MySQL connection timeouts.
 func (s *DoltStore) Fetch(ctx context.Context, peer string) error {
+	return withRemoteRetry(ctx, "fetch", peer, func() error {
+		return s.fetchOnce(ctx, peer)
+	})
+}
+
+// fetchOnce makes one attempt at Fetch.
+func (s *DoltStore) fetchOnce(ctx context.Context, peer string) error {
 	if useCLI, err := s.prepareCLIRouteForPeerGitProtocol(ctx, peer); err != nil {
 		return err
 	} else if useCLI {
@@ -166,12 +192,11 @@ func (s *DoltStore) Fetch(ctx context.Context, peer string) error {
 		} else if useCLI {
 			return s.doltCLIFetchFromPeer(ctx, peer, creds)
 		}
-		return withEnvCredentials(creds, func() error {
-			if err := s.execWithLongTimeout(ctx, "CALL DOLT_FETCH(?)", peer); err != nil {
-				return fmt.Errorf("failed to fetch from peer %s: %w", peer, err)
-			}
-			return nil
-		})
+		s.warnSQLPathCredentials(peer, creds)
+		if err := s.execWithLongTimeout(ctx, "CALL DOLT_FETCH(?)", peer); err != nil {
+			return fmt.Errorf("failed to fetch from peer %s: %w", peer, err)
+		}
+		return nil
 	})
 }
 
@@ -199,8 +224,13 @@ func (s *DoltStore) hasPersistedCLIRemote() bool {
 
 // HasPersistedRemote is the exported on-disk probe for callers that must not
 // trust an empty dolt_remotes table at cold start: the remote-migrate gate
-// and the push/pull "no remote configured" exit-0 skip (bd-578h9.10).
+// and the push/pull "no remote configured" exit-0 skip (bd-578h9.10). A
+// remote server keeps its remotes in its own data directory, so for it the
+// SQL table is the only source and this reports false.
 func (s *DoltStore) HasPersistedRemote() bool {
+	if s.remoteServer {
+		return false
+	}
 	cliDir := s.CLIDir()
 	dirs := []string{cliDir}
 	if s.dbPath != "" && s.dbPath != cliDir {
@@ -274,6 +304,70 @@ func (s *DoltStore) SyncStatus(ctx context.Context, peer string) (*storage.SyncS
 	return status, nil
 }
 
+// CheckPeer probes a federation peer by fetching from it with the peer's
+// stored credentials, timing the round trip and classifying any failure.
+// After a successful fetch it reports the last commit the local branch
+// shares with the peer's.
+func (s *DoltStore) CheckPeer(ctx context.Context, name string) (*storage.PeerHealth, error) {
+	remotes, err := s.ListRemotes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	health := &storage.PeerHealth{Peer: name}
+	for _, r := range remotes {
+		if r.Name == name {
+			health.URL = r.URL
+		}
+	}
+	if health.URL == "" {
+		return nil, fmt.Errorf("%w: federation peer %s", storage.ErrNotFound, name)
+	}
+	// Peers added without credentials have no federation_peers row.
+	peer, err := s.GetFederationPeer(ctx, name)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+	health.UsedCredentials = peer != nil && (peer.Username != "" || peer.Password != "" || peer.SSHKeyPath != "" || peer.CredentialSource != "")
+
+	start := time.Now()
+	fetchErr := s.Fetch(ctx, name)
+	health.Latency = time.Since(start)
+	if fetchErr != nil {
+		health.Status = storage.ClassifyPeerError(fetchErr)
+		health.Error = fetchErr
+		return health, nil
+	}
+	health.Status = storage.PeerHealthOK
+
+	// A peer that has never received this branch has no common commit.
+	if base, err := versioncontrolops.MergeBase(ctx, s.db, s.branch, name+"/"+s.branch); err == nil {
+		health.LastCommonCommit = base
+	}
+	return health, nil
+}
+
+// PreviewSync fetches from a peer with its stored credentials and reports
+// what Sync would push and pull, without merging or pushing.
+func (s *DoltStore) PreviewSync(ctx context.Context, peer string) (*storage.SyncPreview, error) {
+	mode, err := s.peerSyncMode(ctx, peer)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Fetch(ctx, peer); err != nil {
+		return nil, fmt.Errorf("fetch failed: %w", err)
+	}
+	return versioncontrolops.PreviewSync(ctx, s.db, peer, s.branch, mode)
+}
+
+// VerifyPeer fetches from a peer with its stored credentials and compares
+// digests of the local issues with the peer's.
+func (s *DoltStore) VerifyPeer(ctx context.Context, peer string) (*storage.PeerVerification, error) {
+	if err := s.Fetch(ctx, peer); err != nil {
+		return nil, fmt.Errorf("fetch failed: %w", err)
+	}
+	return versioncontrolops.VerifyPeer(ctx, s.db, peer, s.branch)
+}
+
 // getLastSyncTime retrieves the last sync time for a peer from metadata.
 func (s *DoltStore) getLastSyncTime(ctx context.Context, peer string) time.Time {
 	key := "last_sync_" + peer
@@ -303,6 +397,8 @@ func (s *DoltStore) setLastSyncTime(ctx context.Context, peer string) error {
 // 2. Merge peer's changes (handling conflicts per strategy)
 // 3. Push local changes to peer
 //
+// A push-only peer skips steps 1 and 2 and a pull-only peer skips step 3.
+//
 // Returns the sync result including any conflicts encountered.
 func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*SyncResult, error) {
 	result := &SyncResult{
@@ -310,6 +406,15 @@ func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*Sy
 		StartTime: time.Now(),
 	}
 
+	// A merge an earlier sync left conflicted must be resolved or aborted
+	// first; committing pending changes over it would fail anyway.
+	if existing, err := s.GetConflicts(ctx); err == nil && len(existing) > 0 {
+		result.Conflicts = existing
+		result.Error = fmt.Errorf("%w in %s from an earlier merge; run 'bd federation conflicts'",
+			storage.ErrMergeConflicts, storage.ConflictTables(existing))
+		return result, result.Error
+	}
+
 	// GH#2474: match PullFrom — commit pending changes before the merge,
 	// INCLUDING config (where kv.memory.* rows live). Plain Commit excludes
 	// config (GH#2455), so federation metadata writes such as add-peer plus any
@@ -324,6 +429,21 @@ func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*Sy
 		}
 	}
 
+	mode, err := s.peerSyncMode(ctx, peer)
+	if err != nil {
+		result.Error = err
+		return result, result.Error
+	}
+	result.SyncMode = mode
+	if !mode.AllowsPull() {
+		s.finishSync(ctx, peer, result)
+		return result, nil
+	}
+	if result.ConflictStrategy, err = s.syncConflictStrategy(ctx, peer, strategy); err != nil {
+		result.Error = err
+		return result, result.Error
+	}
+
 	// Step 1: Fetch from peer
 	if err := s.Fetch(ctx, peer); err != nil {
 		result.Error = fmt.Errorf("fetch failed: %w", err)
@@ -334,9 +454,10 @@ func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*Sy
 	// Step 2: Get status before merge
 	beforeCommit, _ := s.GetCurrentCommit(ctx) // Best effort: empty commit hash means diff won't be logged
 
-	// Step 3: Merge peer's branch
+	// Step 3: Merge peer's branch. Conflicts stay in the working set for the
+	// strategy (or bd federation conflicts) to resolve.
 	remoteBranch := fmt.Sprintf("%s/%s", peer, s.branch)
-	conflicts, err := s.Merge(ctx, remoteBranch)
+	conflicts, err := s.mergeKeepingConflicts(ctx, remoteBranch)
 	if err != nil {
 		result.Error = fmt.Errorf("merge failed: %w", err)
 		return result, result.Error
@@ -346,18 +467,24 @@ func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*Sy
 	if len(conflicts) > 0 {
 		result.Conflicts = conflicts
 
-		if strategy == "" {
-			// No strategy specified, leave conflicts for manual resolution
-			result.Error = fmt.Errorf("merge conflicts require resolution (use --strategy ours|theirs)")
+		if result.ConflictStrategy == "" {
+			// No strategy: leave conflicts for bd federation conflicts
+			result.Error = fmt.Errorf("%w: use --strategy, set a conflict strategy on the peer, or run 'bd federation conflicts'", storage.ErrMergeConflicts)
 			return result, result.Error
 		}
 
-		// Auto-resolve using strategy
-		for _, c := range conflicts {
-			if err := s.ResolveConflicts(ctx, c.Field, strategy); err != nil {
-				result.Error = fmt.Errorf("conflict resolution failed for %s: %w", c.Field, err)
-				return result, result.Error
-			}
+		// Auto-resolve using strategy. Row-level strategies may leave rows
+		// (and non-issues tables) they cannot decide.
+		remaining, err := storage.ApplyConflictStrategy(ctx, s, conflicts, result.ConflictStrategy)
+		if err != nil {
+			result.Error = err
+			return result, result.Error
+		}
+		if len(remaining) > 0 {
+			result.Conflicts = remaining
+			result.Error = fmt.Errorf("%w: %s strategy left conflicts in %s; run 'bd federation conflicts'",
+				storage.ErrMergeConflicts, result.ConflictStrategy, storage.ConflictTables(remaining))
+			return result, result.Error
 		}
 		result.ConflictsResolved = true
 
@@ -366,7 +493,7 @@ func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*Sy
 		// conflict — routine now that kv.memory.* memories sync through config —
 		// would otherwise resolve but never commit, leaving the merge
 		// unconcluded and re-wedging the next sync.
-		if err := s.CommitMergeResolution(ctx, fmt.Sprintf("Resolve conflicts from %s using %s strategy", peer, strategy)); err != nil {
+		if err := s.CommitMergeResolution(ctx, fmt.Sprintf("Resolve conflicts from %s using %s strategy", peer, result.ConflictStrategy)); err != nil {
 			result.Error = fmt.Errorf("failed to commit conflict resolution: %w", err)
 			return result, result.Error
 		}
@@ -383,24 +510,33 @@ func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*Sy
 
 	// Count pulled commits
 	afterCommit, _ := s.GetCurrentCommit(ctx) // Best effort: empty commit hash means diff won't be logged
+	result.PreMergeCommit, result.PostMergeCommit = beforeCommit, afterCommit
 	if beforeCommit != afterCommit {
 		result.PulledCommits = 1 // Simplified - could count actual commits
 	}
 
+	s.finishSync(ctx, peer, result)
+	return result, nil
+}
+
+// finishSync runs Sync's push step, unless the peer is pull-only, and records
+// the sync time.
+func (s *DoltStore) finishSync(ctx context.Context, peer string, result *SyncResult) {
 	// Step 5: Push our changes to peer, filtering excluded types.
-	excludeTypes := config.GetFederationConfig().ExcludeTypes
-	if err := s.filteredPushToPeer(ctx, peer, excludeTypes); err != nil {
-		// Push failure is not fatal - peer may not accept pushes
-		result.PushError = err
-	} else {
-		result.Pushed = true
+	if result.SyncMode.AllowsPush() {
+		excludeTypes := config.GetFederationConfig().ExcludeTypes
+		if err := s.filteredPushToPeer(ctx, peer, excludeTypes); err != nil {
+			// Push failure is not fatal - peer may not accept pushes
+			result.PushError = err
+		} else {
+			result.Pushed = true
+		}
 	}
 
 	// Record last sync time
-	_ = s.setLastSyncTime(ctx, peer) // Best effort: sync timestamp is advisory for scheduling
+	besteffort.Record("record peer last sync", s.setLastSyncTime(ctx, peer)) // sync timestamp is advisory for scheduling
 
 	result.EndTime = time.Now()
-	return result, nil
 }
 
 // filteredPushToPeer pushes to a peer after filtering out excluded issue types.
@@ -497,7 +633,7 @@ func (s *DoltStore) prepareCLIRouteForPeerGitProtocol(ctx context.Context, peer
 			if !doltutil.IsGitProtocolURL(r.URL) {
 				return false, nil
 			}
-			if err := s.ensureMatchingCLIRemote(peer, r.URL); err != nil {
+			if err := s.ensureMatchingCLIRemote(ctx, peer, r.URL); err != nil {
 				return false, fmt.Errorf("peer remote %q uses git protocol and requires CLI routing: %w", peer, err)
 			}
 			return true, nil
@@ -1,6 +1,8 @@
 package dolt
 
 import (
+	"context"
+
 	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -14,8 +16,8 @@ var (
 )
 
 // buildIssueFilterClauses delegates to the shared issueops implementation.
-func buildIssueFilterClauses(query string, filter types.IssueFilter, tables filterTables) ([]string, []interface{}, error) {
-	return issueops.BuildIssueFilterClauses(query, filter, tables)
+func buildIssueFilterClauses(ctx context.Context, query string, filter types.IssueFilter, tables filterTables) ([]string, []interface{}, error) {
+	return issueops.BuildIssueFilterClauses(ctx, query, filter, tables)
 }
 
 // looksLikeIssueID delegates to the shared issueops implementation.
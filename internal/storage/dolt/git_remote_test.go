@@ -1219,10 +1219,10 @@ func TestGitRemoteExternalServerRouting(t *testing.T) {
 	}
 	t.Cleanup(func() { store.Close() })
 
-	require.Equal(t, "", doltutil.FindCLIRemote(clientTestdbDir, store.remote), "precondition: client CLI remote should be absent")
+	require.Equal(t, "", doltutil.FindCLIRemote(t.Context(), clientTestdbDir, store.remote), "precondition: client CLI remote should be absent")
 	require.True(t, store.isGitProtocolRemote(ctx, store.remote), "SQL-visible git remote should materialize CLI routing")
 	require.True(t,
-		doltutil.RemoteURLsMatch(doltutil.FindCLIRemote(clientTestdbDir, store.remote), "git+https://example.com/test.git"),
+		doltutil.RemoteURLsMatch(doltutil.FindCLIRemote(t.Context(), clientTestdbDir, store.remote), "git+https://example.com/test.git"),
 		"git-protocol routing should create a matching client CLI remote",
 	)
 	require.True(t, store.shouldUseCLIForCredentials(ctx, store.remote, store.mainRemoteCredentials()), "credential route should reuse matching CLI remote")
@@ -1378,8 +1378,8 @@ func TestSQLRemotePersistsAcrossExternalServerRestart(t *testing.T) {
 //
 // The test proves routing works end-to-end: if shouldUseCLIForCredentials
 // routes to doltCLIPush, the CLI uses the file:// remote and push succeeds.
-// If the guard fails and falls through to SQL withEnvCredentials, the external
-// server process cannot see the env vars and push fails (SC-001).
+// If the guard fails and falls through to SQL, the external server process
+// never sees the credentials and push fails (SC-001).
 func TestCredentialCLIRoutingE2E(t *testing.T) {
 	testutil.RequireDoltBinary(t)
 
@@ -1478,26 +1478,26 @@ func TestCredentialCLIRoutingE2E(t *testing.T) {
 	if err := store.AddRemote(ctx, "origin", remoteURL); err != nil {
 		t.Fatalf("AddRemote through bd setup path: %v", err)
 	}
-	require.Equal(t, "", doltutil.FindCLIRemote(clientTestdbDir, store.remote), "precondition: bd setup path should not manually seed CLI remote")
+	require.Equal(t, "", doltutil.FindCLIRemote(t.Context(), clientTestdbDir, store.remote), "precondition: bd setup path should not manually seed CLI remote")
 
 	// Verify preconditions: not a git-protocol remote, but credentials trigger CLI routing
 	require.False(t, store.isGitProtocolRemote(ctx, store.remote), "file:// is not git-protocol")
 	if !store.shouldUseCLIForCredentials(ctx, store.remote, store.mainRemoteCredentials()) {
 		remotes, listErr := store.ListRemotes(ctx)
-		ensureErr := doltutil.EnsureCLIRemote(clientTestdbDir, store.remote, remoteURL)
+		ensureErr := doltutil.EnsureCLIRemote(t.Context(), clientTestdbDir, store.remote, remoteURL)
 		t.Fatalf("should route through CLI for credentials; serverMode=%v remotes=%v listErr=%v cliRemote=%q ensureErr=%v",
-			store.serverMode, remotes, listErr, doltutil.FindCLIRemote(clientTestdbDir, store.remote), ensureErr)
+			store.serverMode, remotes, listErr, doltutil.FindCLIRemote(t.Context(), clientTestdbDir, store.remote), ensureErr)
 	}
 	require.True(t,
-		doltutil.RemoteURLsMatch(doltutil.FindCLIRemote(clientTestdbDir, store.remote), remoteURL),
+		doltutil.RemoteURLsMatch(doltutil.FindCLIRemote(t.Context(), clientTestdbDir, store.remote), remoteURL),
 		"credential routing should materialize a matching client CLI remote",
 	)
 	require.True(t, store.serverMode, "store should be in server mode")
 
 	// 7. Push should succeed via CLI credential routing
 	// If the guard works: doltCLIPush uses CLI dir's file:// remote → success
-	// If guard fails: withEnvCredentials + SQL CALL DOLT_PUSH('--user',...) → fails
-	// (external server can't see env vars set on bd client process)
+	// If guard fails: SQL CALL DOLT_PUSH('--user',...) → fails
+	// (external server can't see credentials held by the bd client process)
 	err = store.Push(ctx)
 	require.NoError(t, err, "Push should succeed via CLI credential routing (SC-001)")
 }
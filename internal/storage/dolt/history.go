@@ -148,7 +148,7 @@ func (s *DoltStore) getIssueHistory(ctx context.Context, issueID string) ([]*iss
 // getIssueAsOf returns an issue as it existed at a specific commit or time
 func (s *DoltStore) getIssueAsOf(ctx context.Context, issueID string, ref string) (*types.Issue, error) {
 	var result *types.Issue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.AsOfInTx(ctx, tx, issueID, ref)
 		return err
@@ -184,7 +184,11 @@ type tableConflict struct {
 	NumConflicts int
 }
 
-// ResolveConflicts resolves conflicts using the specified strategy
+// ResolveConflicts resolves conflicts using the specified strategy. The
+// session allows conflict commits so one table can be resolved while others
+// are still conflicted.
 func (s *DoltStore) ResolveConflicts(ctx context.Context, table string, strategy string) error {
-	return versioncontrolops.ResolveConflicts(ctx, s.db, table, strategy)
+	return s.withConflictCommitsConn(ctx, func(conn *sql.Conn) error {
+		return versioncontrolops.ResolveConflicts(ctx, conn, table, strategy)
+	})
 }
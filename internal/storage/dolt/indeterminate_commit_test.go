@@ -64,7 +64,7 @@ func TestIndeterminateCommitIsSurfacedNotRetried(t *testing.T) {
 	store := newFailureStore(driver)
 	defer func() { _ = store.db.Close() }()
 
-	err := store.withRetryTx(context.Background(), func(*sql.Tx) error { return nil })
+	err := store.withRetryTx(context.Background(), func(context.Context, *sql.Tx) error { return nil })
 	if err == nil {
 		t.Fatal("lost commit returned nil; indeterminacy must be surfaced")
 	}
@@ -87,7 +87,7 @@ func TestPreCommitConnectionLossIsRetriedSilently(t *testing.T) {
 	store := newFailureStore(driver)
 	defer func() { _ = store.db.Close() }()
 
-	if err := store.withRetryTx(context.Background(), func(*sql.Tx) error { return nil }); err != nil {
+	if err := store.withRetryTx(context.Background(), func(context.Context, *sql.Tx) error { return nil }); err != nil {
 		t.Fatalf("pre-commit connection loss surfaced: %v", err)
 	}
 	if got := driver.begins.Load(); got != 3 {
@@ -9,7 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/steveyegge/beads/internal/idgen"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/types"
@@ -29,7 +28,7 @@ func (s *DoltStore) CreateIssue(ctx context.Context, issue *types.Issue, actor s
 	}
 
 	var result issueops.CreateIssueResult
-	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		// SkipPrefixValidation matches legacy behavior: single-issue path does
 		// not validate prefixes for explicit IDs.
 		bc, err := issueops.NewBatchContext(ctx, tx, storage.BatchCreateOptions{
@@ -97,14 +96,14 @@ func (s *DoltStore) CreateIssuesWithFullOptions(ctx context.Context, issues []*t
 				issue.Ephemeral = true
 			}
 		}
-		return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 			_, err := issueops.CreateIssuesInTxWithResult(ctx, tx, issues, actor, opts)
 			return err
 		})
 	}
 
 	var result issueops.CreateIssuesResult
-	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.CreateIssuesInTxWithResult(ctx, tx, issues, actor, opts)
 		return err
@@ -118,11 +117,34 @@ func (s *DoltStore) CreateIssuesWithFullOptions(ctx context.Context, issues []*t
 		fmt.Sprintf("bd: create %d issue(s)", len(issues)))
 }
 
+// UpsertIssues creates or overwrites issues in one transaction, batching the
+// row writes. Delegates SQL work to issueops; commits the tables it wrote.
+func (s *DoltStore) UpsertIssues(ctx context.Context, issues []*types.Issue, actor string) (*storage.UpsertIssuesResult, error) {
+	if len(issues) == 0 {
+		return &storage.UpsertIssuesResult{}, nil
+	}
+	var out *storage.UpsertIssuesResult
+	var result issueops.CreateIssuesResult
+	if err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		out, result, err = issueops.UpsertIssuesInTx(ctx, tx, issues, actor)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	tables := createIssuesCommitTables(ctx, issues, result)
+	if len(tables) == 0 {
+		return out, nil
+	}
+	return out, s.doltAddAndCommit(ctx, tables,
+		fmt.Sprintf("bd: upsert %d issue(s)", len(out.Created)+len(out.Updated)))
+}
+
 // GetIssue retrieves an issue by ID.
 // Returns storage.ErrNotFound (wrapped) if the issue does not exist.
 func (s *DoltStore) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
 	var issue *types.Issue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		issue, err = issueops.GetIssueInTx(ctx, tx, id)
 		return err
@@ -134,7 +156,7 @@ func (s *DoltStore) GetIssue(ctx context.Context, id string) (*types.Issue, erro
 // Returns storage.ErrNotFound (wrapped) if no issue with the given external reference exists.
 func (s *DoltStore) GetIssueByExternalRef(ctx context.Context, externalRef string) (*types.Issue, error) {
 	var id string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		id, err = issueops.GetIssueByExternalRefInTx(ctx, tx, externalRef)
 		return err
@@ -203,7 +225,7 @@ func (s *DoltStore) UpdateIssue(ctx context.Context, id string, updates map[stri
 	// locking — FOR UPDATE / SKIP LOCKED are parse-only no-ops
 	// (https://www.dolthub.com/blog/2023-10-23-hold-my-beer/) — so retry is the
 	// only safety net. withRetryTx owns BeginTx and the final Commit.
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		if _, err := issueops.UpdateIssueInTx(ctx, tx, id, updates, actor); err != nil {
 			return err
 		}
@@ -248,7 +270,7 @@ func (s *DoltStore) UpdateIssueChecked(ctx context.Context, id string, updates m
 	_, settingNoHistory := updates["no_history"]
 	_, settingWisp := updates["wisp"]
 	if settingNoHistory || settingWisp {
-		return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 			if err := checkExpectedVersionInTx(ctx, tx, id, opts.ExpectedVersion); err != nil {
 				return err
 			}
@@ -265,7 +287,7 @@ func (s *DoltStore) UpdateIssueChecked(ctx context.Context, id string, updates m
 	// concurrent write that commits DURING this tx collides on the row_lock cell
 	// and is replayed by withRetryTx, which re-reads the new version here and
 	// refuses. withRetryTx owns BeginTx and the final Commit.
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		if err := checkExpectedVersionInTx(ctx, tx, id, opts.ExpectedVersion); err != nil {
 			return err
 		}
@@ -304,7 +326,7 @@ func (s *DoltStore) ClaimIssue(ctx context.Context, id string, actor string) err
 	// (https://www.dolthub.com/blog/2023-10-23-hold-my-beer/) — so retry is the
 	// only safety net under concurrent claimants. The body stays a single tx
 	// (CAS + DOLT_COMMIT); withRetryTx owns BeginTx and the final Commit.
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		if _, err := issueops.ClaimIssueInTx(ctx, tx, id, actor); err != nil {
 			return err
 		}
@@ -333,7 +355,7 @@ func (s *DoltStore) ClaimReadyIssue(ctx context.Context, filter types.WorkFilter
 	// (https://www.dolthub.com/blog/2023-10-23-hold-my-beer/) — so retry is the
 	// safety net. withRetryTx owns BeginTx and the final Commit.
 	var claimed *types.Issue
-	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		claimed, err = issueops.ClaimReadyIssueInTx(ctx, tx, filter, actor)
 		if err != nil {
@@ -372,7 +394,7 @@ func (s *DoltStore) HeartbeatIssue(ctx context.Context, id, actor string) error
 		// Wisps are ephemeral and never leased; nothing to heartbeat.
 		return fmt.Errorf("%w: %s is ephemeral", storage.ErrNotClaimable, id)
 	}
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.HeartbeatIssueInTx(ctx, tx, id, actor)
 	})
 }
@@ -382,11 +404,10 @@ func (s *DoltStore) HeartbeatIssue(ctx context.Context, id, actor string) error
 // reclaim rewrites row_lock so it conflicts with any racing heartbeat/close on
 // the same row; withRetryTx replays the loser. Returns the reclaimed issues.
 func (s *DoltStore) ReclaimExpiredLeases(ctx context.Context, olderThan time.Duration, actor string) ([]types.ReclaimedLease, error) {
-	cutoff := time.Now().UTC().Add(-olderThan)
 	var reclaimed []types.ReclaimedLease
-	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		reclaimed, err = issueops.ReclaimExpiredLeasesInTx(ctx, tx, cutoff, actor)
+		reclaimed, err = issueops.ReclaimExpiredLeasesInTx(ctx, tx, storage.Now(ctx).Add(-olderThan), actor)
 		if err != nil {
 			return err
 		}
@@ -419,7 +440,7 @@ func (s *DoltStore) ReclaimExpiredLeases(ctx context.Context, olderThan time.Dur
 // writer that loses Dolt's optimistic commit-time merge (1213/1205) is retried
 // rather than surfaced as a hard failure.
 func (s *DoltStore) UnclaimIssue(ctx context.Context, id string, actor string, force bool) error {
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		if err := issueops.UnclaimIssueInTx(ctx, tx, id, actor, force); err != nil {
 			return err
 		}
@@ -445,7 +466,7 @@ func (s *DoltStore) UnclaimIssue(ctx context.Context, id string, actor string, f
 // UnclaimIssue so a concurrent writer that loses Dolt's optimistic commit-time
 // merge is retried rather than surfaced as a hard failure.
 func (s *DoltStore) UnclaimIssueIfAssignee(ctx context.Context, id string, actor string, expectedAssignee string) error {
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		if err := issueops.UnclaimIssueIfAssigneeInTx(ctx, tx, id, actor, expectedAssignee); err != nil {
 			return err
 		}
@@ -465,8 +486,12 @@ func (s *DoltStore) UnclaimIssueIfAssignee(ctx context.Context, id string, actor
 
 // ReopenIssue reopens a closed issue, setting status to open and clearing
 // closed_at and defer_until. If reason is non-empty, it is recorded as a comment.
+// The reopen is logged in metadata.reopens with the note attached to ctx.
 // Wraps UpdateIssue for Dolt-specific concerns (wisp routing, DOLT_COMMIT, etc.).
 func (s *DoltStore) ReopenIssue(ctx context.Context, id string, reason string, actor string) error {
+	note := storage.ReopenNoteFrom(ctx)
+	note.Reason = reason
+	ctx = storage.WithReopenNote(ctx, note)
 	updates := map[string]interface{}{
 		"status":      string(types.StatusOpen),
 		"defer_until": nil,
@@ -504,7 +529,7 @@ func (s *DoltStore) CloseIssue(ctx context.Context, id string, reason string, ac
 	// locking — FOR UPDATE / SKIP LOCKED are parse-only no-ops
 	// (https://www.dolthub.com/blog/2023-10-23-hold-my-beer/) — so retry is the
 	// only safety net. withRetryTx owns BeginTx and the final Commit.
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		if _, err := issueops.CloseIssueInTx(ctx, tx, id, reason, actor, session); err != nil {
 			return err
 		}
@@ -544,16 +569,23 @@ func (s *DoltStore) CloseIssueChecked(ctx context.Context, id string, actor stri
 	// surfaces it permanently and the transaction rolls back — no close and no
 	// event are written (the atomic-refuse property).
 	var result storage.CloseIssueResult
-	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		res, err := issueops.CloseIssueCheckedInTx(ctx, tx, id, opts.Reason, actor, opts.Session, opts.Force, opts.ExpectedVersion)
 		if err != nil {
 			return err
 		}
 		result = storage.CloseIssueResult{Unchanged: res.AlreadyClosed}
+		tables := []string{"issues", "events"}
+		if opts.DuplicateOf != "" {
+			if err := issueops.LinkDuplicateInTx(ctx, tx, id, opts.DuplicateOf, actor); err != nil {
+				return err
+			}
+			tables = append(tables, "dependencies")
+		}
 
 		// Dolt versioning for permanent issues.
 		// GH#2455: Stage only the tables we modified, then commit without -A.
-		for _, table := range []string{"issues", "events"} {
+		for _, table := range tables {
 			_, _ = tx.ExecContext(ctx, "CALL DOLT_ADD(?)", table)
 		}
 		commitMsg := fmt.Sprintf("bd: close %s", id)
@@ -575,7 +607,7 @@ func (s *DoltStore) DeleteIssue(ctx context.Context, id string) error {
 		return s.deleteWisp(ctx, id)
 	}
 
-	if err := s.withWriteTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withWriteTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		if err := issueops.DeleteIssueInTx(ctx, tx, id); err != nil {
 			return err
 		}
@@ -646,7 +678,7 @@ func (s *DoltStore) DeleteIssues(ctx context.Context, ids []string, cascade bool
 	}
 
 	var result *types.DeleteIssuesResult
-	if err := s.withWriteTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withWriteTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		r, err := issueops.DeleteIssuesInTx(ctx, tx, ids, cascade, force, dryRun)
 		if err != nil {
 			result = r
@@ -827,12 +859,6 @@ func isCounterModeTx(ctx context.Context, tx *sql.Tx) (bool, error) {
 	return idMode == "counter", nil
 }
 
-// generateHashID creates a hash-based ID for a top-level issue.
-// Uses base36 encoding (0-9, a-z) for better information density than hex.
-func generateHashID(prefix, title, description, creator string, timestamp time.Time, length, nonce int) string {
-	return idgen.GenerateHashID(prefix, title, description, creator, timestamp, length, nonce)
-}
-
 // Thin wrappers around exported issueops functions, kept for internal callers.
 var (
 	isAllowedUpdateField = issueops.IsAllowedUpdateField
@@ -861,7 +887,7 @@ var (
 // Returns the number of issues deleted.
 func (s *DoltStore) DeleteIssuesBySourceRepo(ctx context.Context, sourceRepo string) (int, error) {
 	var count int
-	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		count, err = issueops.DeleteIssuesBySourceRepoInTx(ctx, tx, sourceRepo)
 		return err
@@ -871,7 +897,7 @@ func (s *DoltStore) DeleteIssuesBySourceRepo(ctx context.Context, sourceRepo str
 
 // ClearRepoMtime removes the mtime cache entry for a repository.
 func (s *DoltStore) ClearRepoMtime(ctx context.Context, repoPath string) error {
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.ClearRepoMtimeInTx(ctx, tx, repoPath)
 	})
 }
@@ -880,7 +906,7 @@ func (s *DoltStore) ClearRepoMtime(ctx context.Context, repoPath string) error {
 // Returns 0 if no cache entry exists.
 func (s *DoltStore) GetRepoMtime(ctx context.Context, repoPath string) (int64, error) {
 	var result int64
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetRepoMtimeInTx(ctx, tx, repoPath)
 		return err
@@ -890,7 +916,7 @@ func (s *DoltStore) GetRepoMtime(ctx context.Context, repoPath string) (int64, e
 
 // SetRepoMtime updates the mtime cache for a repository's data file.
 func (s *DoltStore) SetRepoMtime(ctx context.Context, repoPath, jsonlPath string, mtimeNs int64) error {
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.SetRepoMtimeInTx(ctx, tx, repoPath, jsonlPath, mtimeNs)
 	})
 }
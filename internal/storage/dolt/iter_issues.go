@@ -40,7 +40,7 @@ func (s *DoltStore) IterIssues(ctx context.Context, query string, filter types.I
 	if s.closed.Load() {
 		return nil, ErrStoreClosed
 	}
-	whereClauses, args, err := issueops.BuildIssueFilterClauses(query, filter, issueops.IssuesFilterTables)
+	whereClauses, args, err := issueops.BuildIssueFilterClauses(ctx, query, filter, issueops.IssuesFilterTables)
 	if err != nil {
 		return nil, fmt.Errorf("iter issues: build filter: %w", err)
 	}
@@ -58,7 +58,7 @@ func (s *DoltStore) IterIssues(ctx context.Context, query string, filter types.I
 		issueops.IssueSelectColumns, sqlbuild.LeaseJoin("issues"), whereSQL, limitSQL)
 
 	var issues []*types.Issue
-	txErr := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	txErr := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		rows, err := tx.QueryContext(ctx, q, args...)
 		if err != nil {
 			return fmt.Errorf("iter issues: query: %w", err)
@@ -1,6 +1,7 @@
 package dolt
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -65,7 +66,7 @@ func TestSearchIssuesKeysetSameSecondOverflow(t *testing.T) {
 
 	// One-shot ordered read (no keyset): pins created_at DESC, id ASC.
 	full, err := store.SearchIssues(ctx, "", types.IssueFilter{
-		IDPrefix: "k-", SkipWisps: true, SortBy: "created", Limit: 100,
+		IDPrefix: "k-", SkipWisps: true, SortBy: []types.SortKey{{Field: "created", Desc: true}}, Limit: 100,
 	})
 	if err != nil {
 		t.Fatalf("SearchIssues(full): %v", err)
@@ -83,7 +84,7 @@ func TestSearchIssuesKeysetSameSecondOverflow(t *testing.T) {
 	afterID := ""
 	for i := 0; i < 100; i++ {
 		f := types.IssueFilter{
-			IDPrefix: "k-", SkipWisps: true, SortBy: "created", Limit: pageSize,
+			IDPrefix: "k-", SkipWisps: true, SortBy: []types.SortKey{{Field: "created", Desc: true}}, Limit: pageSize,
 			AfterCreatedAt: afterCreatedAt, AfterID: afterID,
 		}
 		page, err := store.SearchIssues(ctx, "", f)
@@ -116,7 +117,7 @@ func TestSearchIssuesKeysetSameSecondOverflow(t *testing.T) {
 	// (after k-a3) yields exactly the strictly-later tail (a4, a5, older).
 	afterA3 := base.UTC()
 	tail, err := store.SearchIssues(ctx, "", types.IssueFilter{
-		IDPrefix: "k-", SkipWisps: true, SortBy: "created", Limit: 100,
+		IDPrefix: "k-", SkipWisps: true, SortBy: []types.SortKey{{Field: "created", Desc: true}}, Limit: 100,
 		AfterCreatedAt: &afterA3, AfterID: "k-a3",
 	})
 	if err != nil {
@@ -161,3 +162,68 @@ func TestSearchIssuesKeysetPlanIsIndexed(t *testing.T) {
 		t.Fatalf("keyset predicate does not seek idx_issues_created_at (want IndexedTableAccess on [issues.created_at]) — the sargable upper bound regressed to a full Table scan.\nplan:\n%s", plan)
 	}
 }
+
+// TestSearchIssuesCursorPages walks issues and wisps with the opaque
+// IssueFilter.Cursor through both search paths: every row exactly once, in
+// (created_at DESC, id ASC) order, however the two tables interleave.
+func TestSearchIssuesCursorPages(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	base := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	seeds := []struct {
+		id   string
+		at   time.Time
+		wisp bool
+	}{
+		{"pc-1", base.Add(3 * time.Second), false},
+		{"pc-2", base.Add(2 * time.Second), true},
+		{"pc-3", base.Add(time.Second), false},
+		{"pc-4", base.Add(time.Second), true},
+		{"pc-5", base, false},
+	}
+	want := make([]string, len(seeds))
+	for i, s := range seeds {
+		iss := &types.Issue{ID: s.id, Title: s.id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: s.at, Ephemeral: s.wisp}
+		if err := store.CreateIssue(ctx, iss, "tester"); err != nil {
+			t.Fatalf("create %s: %v", s.id, err)
+		}
+		want[i] = s.id
+	}
+
+	var issuesWalk, countsWalk []string
+	for cursor, pages := types.CursorStart, 0; cursor != ""; pages++ {
+		if pages > len(seeds) {
+			t.Fatalf("cursor walk did not end: %v", issuesWalk)
+		}
+		filter := types.IssueFilter{IDPrefix: "pc-", Limit: 2, Cursor: cursor}
+		page, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues(%q): %v", cursor, err)
+		}
+		counted, err := store.SearchIssuesWithCounts(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssuesWithCounts(%q): %v", cursor, err)
+		}
+		if len(page) > 2 || len(counted) != len(page) {
+			t.Fatalf("page sizes = %d and %d, want equal and <= 2", len(page), len(counted))
+		}
+		issuesWalk = append(issuesWalk, ksIDs(page)...)
+		for _, iwc := range counted {
+			countsWalk = append(countsWalk, iwc.Issue.ID)
+		}
+		cursor = ""
+		if len(page) == 2 {
+			cursor = types.IssueCursor(page[1])
+		}
+	}
+	if !ksEqual(issuesWalk, want) || !ksEqual(countsWalk, want) {
+		t.Fatalf("cursor walks = %v and %v, want %v", issuesWalk, countsWalk, want)
+	}
+
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{Cursor: "not-a-cursor"}); !errors.Is(err, types.ErrInvalidCursor) {
+		t.Errorf("SearchIssues(bad cursor) = %v, want ErrInvalidCursor", err)
+	}
+}
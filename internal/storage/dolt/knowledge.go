@@ -0,0 +1,45 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// UpsertKnowledge inserts or updates a crystallized knowledge entry.
+func (s *DoltStore) UpsertKnowledge(ctx context.Context, entry *types.KnowledgeEntry) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return issueops.UpsertKnowledgeInTx(ctx, tx, entry)
+	})
+}
+
+// GetKnowledge retrieves a knowledge entry by ID.
+func (s *DoltStore) GetKnowledge(ctx context.Context, id string) (*types.KnowledgeEntry, error) {
+	var result *types.KnowledgeEntry
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetKnowledgeInTx(ctx, tx, id)
+		return err
+	})
+	return result, err
+}
+
+// SearchKnowledge returns knowledge entries matching query, newest first.
+func (s *DoltStore) SearchKnowledge(ctx context.Context, query string, limit int) ([]*types.KnowledgeEntry, error) {
+	var result []*types.KnowledgeEntry
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.SearchKnowledgeInTx(ctx, tx, query, limit)
+		return err
+	})
+	return result, err
+}
+
+// DeleteKnowledge removes a knowledge entry.
+func (s *DoltStore) DeleteKnowledge(ctx context.Context, id string) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return issueops.DeleteKnowledgeInTx(ctx, tx, id)
+	})
+}
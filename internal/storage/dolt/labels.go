@@ -12,7 +12,7 @@ import (
 // AddLabel adds a label to an issue
 func (s *DoltStore) AddLabel(ctx context.Context, issueID, label, actor string) error {
 	isWisp := s.isActiveWisp(ctx, issueID)
-	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.AddLabelInTx(ctx, tx, "", "", issueID, label, actor)
 	}); err != nil {
 		return err
@@ -27,7 +27,7 @@ func (s *DoltStore) AddLabel(ctx context.Context, issueID, label, actor string)
 // Delegates SQL work to issueops.RemoveLabelInTx which handles wisp routing.
 func (s *DoltStore) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
 	isWisp := s.isActiveWisp(ctx, issueID)
-	if err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.RemoveLabelInTx(ctx, tx, "", "", issueID, label, actor)
 	}); err != nil {
 		return err
@@ -41,7 +41,7 @@ func (s *DoltStore) RemoveLabel(ctx context.Context, issueID, label, actor strin
 // GetLabels retrieves all labels for an issue
 func (s *DoltStore) GetLabels(ctx context.Context, issueID string) ([]string, error) {
 	var labels []string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		labels, err = issueops.GetLabelsInTx(ctx, tx, "", issueID)
 		return err
@@ -53,7 +53,7 @@ func (s *DoltStore) GetLabels(ctx context.Context, issueID string) ([]string, er
 // Delegates to issueops.GetLabelsForIssuesInTx for shared query logic.
 func (s *DoltStore) GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
 	var result map[string][]string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetLabelsForIssuesInTx(ctx, tx, issueIDs)
 		return err
@@ -64,7 +64,7 @@ func (s *DoltStore) GetLabelsForIssues(ctx context.Context, issueIDs []string) (
 // GetIssuesByLabel retrieves all issues with a specific label
 func (s *DoltStore) GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error) {
 	var ids []string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		ids, err = issueops.GetIssuesByLabelInTx(ctx, tx, label)
 		return err
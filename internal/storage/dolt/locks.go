@@ -0,0 +1,50 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// LockIssue takes or extends an advisory edit lock on an issue.
+func (s *DoltStore) LockIssue(ctx context.Context, issueID, holder string, ttl time.Duration, reason string, force bool) (*types.IssueLock, error) {
+	var result *types.IssueLock
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.LockIssueInTx(ctx, tx, issueID, holder, ttl, reason, force)
+		return err
+	})
+	return result, err
+}
+
+// UnlockIssue releases an issue's advisory edit lock.
+func (s *DoltStore) UnlockIssue(ctx context.Context, issueID, holder string, force bool) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return issueops.UnlockIssueInTx(ctx, tx, issueID, holder, force)
+	})
+}
+
+// GetIssueLocks returns the live locks on the given issues.
+func (s *DoltStore) GetIssueLocks(ctx context.Context, issueIDs []string) (map[string]*types.IssueLock, error) {
+	var result map[string]*types.IssueLock
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueLocksInTx(ctx, tx, issueIDs)
+		return err
+	})
+	return result, err
+}
+
+// ListIssueLocks returns every live lock.
+func (s *DoltStore) ListIssueLocks(ctx context.Context) ([]*types.IssueLock, error) {
+	var result []*types.IssueLock
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ListIssueLocksInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
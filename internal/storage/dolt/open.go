@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/configfile"
@@ -284,24 +285,76 @@ func applyResolvedConfig(ctx context.Context, beadsDir string, fileCfg *configfi
 		cfg.ServerTLS = fileCfg.GetDoltServerTLS()
 	}
 
-	// Pool size: env var > config.yaml > caller override > default (10).
-	// Useful for shared-server setups with many worktrees (GH#3140).
+	ApplyCLIPoolConfig(cfg)
+	return nil
+}
+
+// ApplyCLIPoolConfig fills the connection pool limits, slow-query threshold,
+// and statement cache size the caller left unset from the environment, then
+// config.yaml.
+// Useful for shared-server setups with many worktrees or agents (GH#3140):
+//
+//	dolt.max-conns             BEADS_DOLT_MAX_CONNS             (default 10)
+//	dolt.max-idle-conns        BEADS_DOLT_MAX_IDLE_CONNS        (default 5)
+//	dolt.conn-max-lifetime     BEADS_DOLT_CONN_MAX_LIFETIME     (default 1h)
+//	dolt.conn-max-idle-time    BEADS_DOLT_CONN_MAX_IDLE_TIME    (default 20s)
+//	dolt.slow-query-threshold  BEADS_DOLT_SLOW_QUERY_THRESHOLD  (default 1s)
+//	dolt.stmt-cache-size       BEADS_DOLT_STMT_CACHE_SIZE       (default 64, 0 disables)
+func ApplyCLIPoolConfig(cfg *Config) {
 	if cfg.MaxOpenConns == 0 {
-		if v := os.Getenv("BEADS_DOLT_MAX_CONNS"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n > 0 {
-				cfg.MaxOpenConns = n
-			}
+		cfg.MaxOpenConns = poolConfigInt("BEADS_DOLT_MAX_CONNS", "dolt.max-conns")
+	}
+	if cfg.MaxIdleConns == 0 {
+		cfg.MaxIdleConns = poolConfigInt("BEADS_DOLT_MAX_IDLE_CONNS", "dolt.max-idle-conns")
+	}
+	if cfg.ConnMaxLifetime == 0 {
+		cfg.ConnMaxLifetime = poolConfigDuration("BEADS_DOLT_CONN_MAX_LIFETIME", "dolt.conn-max-lifetime")
+	}
+	if cfg.ConnMaxIdleTime == 0 {
+		cfg.ConnMaxIdleTime = poolConfigDuration("BEADS_DOLT_CONN_MAX_IDLE_TIME", "dolt.conn-max-idle-time")
+	}
+	if cfg.SlowQueryThreshold == 0 {
+		cfg.SlowQueryThreshold = poolConfigDuration("BEADS_DOLT_SLOW_QUERY_THRESHOLD", "dolt.slow-query-threshold")
+	}
+	if cfg.StmtCacheSize == 0 {
+		cfg.StmtCacheSize = stmtCacheSizeConfig()
+	}
+}
+
+// poolConfigInt reads a positive integer from env, then key; 0 if neither
+// holds one.
+func poolConfigInt(env, key string) int {
+	for _, v := range []string{os.Getenv(env), config.GetString(key)} {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 {
+			return n
 		}
 	}
-	if cfg.MaxOpenConns == 0 {
-		if v := config.GetString("dolt.max-conns"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n > 0 {
-				cfg.MaxOpenConns = n
+	return 0
+}
+
+// stmtCacheSizeConfig reads dolt.stmt-cache-size from env, then config; an
+// explicit 0 disables the cache, which Config spells as a negative size.
+func stmtCacheSizeConfig() int {
+	for _, v := range []string{os.Getenv("BEADS_DOLT_STMT_CACHE_SIZE"), config.GetString("dolt.stmt-cache-size")} {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n >= 0 {
+			if n == 0 {
+				return -1
 			}
+			return n
 		}
 	}
+	return 0
+}
 
-	return nil
+// poolConfigDuration reads a positive duration ("30s", "2m") from env, then
+// key; 0 if neither holds one.
+func poolConfigDuration(env, key string) time.Duration {
+	for _, v := range []string{os.Getenv(env), config.GetString(key)} {
+		if d, err := time.ParseDuration(strings.TrimSpace(v)); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 0
 }
 
 // applyCentralConfigDefaults loads the central server config from
@@ -241,6 +241,25 @@ func TestCLIDirUsesDbPathOutsideSharedServerMode(t *testing.T) {
 	}
 }
 
+func TestCLIDirEmptyForRemoteServer(t *testing.T) {
+	t.Setenv("BEADS_DOLT_SHARED_SERVER", "0")
+
+	store := &DoltStore{
+		serverMode:   true,
+		remoteServer: true,
+		beadsDir:     filepath.Join(t.TempDir(), ".beads"),
+		dbPath:       filepath.Join(t.TempDir(), ".beads", "dolt"),
+		database:     "remote_db",
+	}
+
+	if got := store.CLIDir(); got != "" {
+		t.Fatalf("CLIDir() = %q, want empty so push/pull/fsck use SQL", got)
+	}
+	if !store.IsRemoteServer() {
+		t.Fatal("IsRemoteServer() = false, want true")
+	}
+}
+
 func TestApplyResolvedConfig(t *testing.T) {
 	t.Run("fills server config for legacy metadata without dolt_mode", func(t *testing.T) {
 		beadsDir := t.TempDir()
@@ -0,0 +1,69 @@
+package dolt
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// PoolStats reports the connection pool's limits and usage, and how many
+// statements and transactions have run since the store was opened. Under
+// concurrent agent load a growing WaitCount means callers are queueing for
+// one of MaxOpen connections (raise dolt.max-conns), while "too many
+// connections" errors mean the server's max_connections is exhausted across
+// all clients (lower it).
+func (s *DoltStore) PoolStats() storage.PoolStats {
+	cfg := s.poolConfig
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	maxOpen, maxIdle, lifetime, idle := poolLimits(cfg)
+	stats := storage.PoolStats{
+		MaxOpen:            maxOpen,
+		MaxIdle:            maxIdle,
+		ConnMaxLifetime:    lifetime,
+		ConnMaxIdleTime:    idle,
+		Queries:            s.queries.Load(),
+		SlowQueries:        s.slowQueries.Load(),
+		SlowQueryThreshold: slowQueryThreshold(cfg),
+		StmtCacheSize:      stmtCacheSize(cfg),
+		StmtCacheHits:      s.stmtCacheHits.Load(),
+		StmtCacheMisses:    s.stmtCacheMisses.Load(),
+	}
+	s.mu.RLock()
+	db := s.db
+	stats.StmtCached = s.stmts.len()
+	s.mu.RUnlock()
+	if db == nil {
+		return stats
+	}
+	dbStats := db.Stats()
+	stats.MaxOpen = dbStats.MaxOpenConnections
+	stats.Open = dbStats.OpenConnections
+	stats.InUse = dbStats.InUse
+	stats.Idle = dbStats.Idle
+	stats.WaitCount = dbStats.WaitCount
+	stats.WaitDuration = dbStats.WaitDuration
+	stats.MaxIdleClosed = dbStats.MaxIdleClosed
+	stats.MaxIdleTimeClosed = dbStats.MaxIdleTimeClosed
+	stats.MaxLifetimeClosed = dbStats.MaxLifetimeClosed
+	return stats
+}
+
+func slowQueryThreshold(cfg *Config) time.Duration {
+	if cfg != nil && cfg.SlowQueryThreshold > 0 {
+		return cfg.SlowQueryThreshold
+	}
+	return defaultSlowQueryThreshold
+}
+
+// observeQuery counts a statement or transaction that started at start
+// toward PoolStats, and as slow if it ran past the slow-query threshold.
+func (s *DoltStore) observeQuery(ctx context.Context, start time.Time) {
+	s.queries.Add(1)
+	if time.Since(start) >= slowQueryThreshold(s.poolConfig) {
+		s.slowQueries.Add(1)
+		doltMetrics.slowQueries.Add(ctx, 1)
+	}
+}
@@ -13,9 +13,9 @@ import (
 // Delegates to issueops.SearchIssuesInTx for shared query logic.
 func (s *DoltStore) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		result, err = issueops.SearchIssuesInTx(ctx, tx, query, filter)
+		result, err = issueops.SearchIssuesInTx(ctx, s.cachedReadTx(tx), query, filter)
 		return err
 	})
 	return result, err
@@ -25,9 +25,9 @@ func (s *DoltStore) SearchIssues(ctx context.Context, query string, filter types
 // only matching IDs.
 func (s *DoltStore) SearchIssueIDs(ctx context.Context, query string, filter types.IssueFilter) ([]string, error) {
 	var result []string
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		result, err = issueops.SearchIssueIDsInTx(ctx, tx, query, filter)
+		result, err = issueops.SearchIssueIDsInTx(ctx, s.cachedReadTx(tx), query, filter)
 		return err
 	})
 	return result, err
@@ -35,9 +35,9 @@ func (s *DoltStore) SearchIssueIDs(ctx context.Context, query string, filter typ
 
 func (s *DoltStore) SearchIssuesWithCounts(ctx context.Context, query string, filter types.IssueFilter) ([]*types.IssueWithCounts, error) {
 	var result []*types.IssueWithCounts
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		result, err = issueops.SearchIssuesWithCountsInTx(ctx, tx, query, filter)
+		result, err = issueops.SearchIssuesWithCountsInTx(ctx, s.cachedReadTx(tx), query, filter)
 		return err
 	})
 	return result, err
@@ -45,9 +45,9 @@ func (s *DoltStore) SearchIssuesWithCounts(ctx context.Context, query string, fi
 
 func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		result, err = issueops.GetReadyWorkInTx(ctx, tx, filter)
+		result, err = issueops.GetReadyWorkInTx(ctx, s.cachedReadTx(tx), filter)
 		return err
 	})
 	return result, err
@@ -55,9 +55,9 @@ func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) (
 
 func (s *DoltStore) GetReadyWorkWithCounts(ctx context.Context, filter types.WorkFilter) ([]*types.IssueWithCounts, error) {
 	var result []*types.IssueWithCounts
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		result, err = issueops.GetReadyWorkWithCountsInTx(ctx, tx, filter)
+		result, err = issueops.GetReadyWorkWithCountsInTx(ctx, s.cachedReadTx(tx), filter)
 		return err
 	})
 	return result, err
@@ -69,7 +69,7 @@ func (s *DoltStore) GetReadyWorkWithCounts(ctx context.Context, filter types.Wor
 // storage.ReadyWorkCounter capability.
 func (s *DoltStore) CountReadyWork(ctx context.Context, filter types.WorkFilter) (int, error) {
 	var n int
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		n, err = issueops.CountReadyWorkInTx(ctx, tx, filter)
 		return err
@@ -79,7 +79,7 @@ func (s *DoltStore) CountReadyWork(ctx context.Context, filter types.WorkFilter)
 
 func (s *DoltStore) GetBlockedIssues(ctx context.Context, filter types.WorkFilter) ([]*types.BlockedIssue, error) {
 	var result []*types.BlockedIssue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetBlockedIssuesInTx(ctx, tx, filter)
 		return err
@@ -90,7 +90,7 @@ func (s *DoltStore) GetBlockedIssues(ctx context.Context, filter types.WorkFilte
 // GetEpicsEligibleForClosure returns epics whose children are all closed
 func (s *DoltStore) GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error) {
 	var result []*types.EpicStatus
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetEpicsEligibleForClosureInTx(ctx, tx)
 		return err
@@ -101,7 +101,7 @@ func (s *DoltStore) GetEpicsEligibleForClosure(ctx context.Context) ([]*types.Ep
 // GetStaleIssues returns issues that haven't been updated recently
 func (s *DoltStore) GetStaleIssues(ctx context.Context, filter types.StaleFilter) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetStaleIssuesInTx(ctx, tx, filter)
 		return err
@@ -113,7 +113,7 @@ func (s *DoltStore) GetStaleIssues(ctx context.Context, filter types.StaleFilter
 func (s *DoltStore) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	stats := &types.Statistics{}
 
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.ScanIssueCountsInTx(ctx, tx, stats)
 	})
 	if err != nil {
@@ -121,7 +121,7 @@ func (s *DoltStore) GetStatistics(ctx context.Context) (*types.Statistics, error
 	}
 
 	var blockedCount int
-	if err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	if err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		return tx.QueryRowContext(ctx, `
 			SELECT COUNT(*) FROM issues
 			WHERE is_blocked = 1 AND status <> 'closed' AND status <> 'pinned'
@@ -238,7 +238,7 @@ func (s *DoltStore) GetMoleculeProgress(ctx context.Context, moleculeID string)
 // GetMoleculeLastActivity returns the most recent activity timestamp for a molecule.
 func (s *DoltStore) GetMoleculeLastActivity(ctx context.Context, moleculeID string) (*types.MoleculeLastActivity, error) {
 	var result *types.MoleculeLastActivity
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetMoleculeLastActivityInTx(ctx, tx, moleculeID)
 		return err
@@ -250,7 +250,7 @@ func (s *DoltStore) GetMoleculeLastActivity(ctx context.Context, moleculeID stri
 // Delegates SQL work to issueops.GetNextChildIDTx.
 func (s *DoltStore) GetNextChildID(ctx context.Context, parentID string) (string, error) {
 	var childID string
-	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		childID, err = issueops.GetNextChildIDTx(ctx, tx, parentID)
 		return err
@@ -0,0 +1,45 @@
+package dolt
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/steveyegge/beads/internal/progress"
+	"github.com/steveyegge/beads/internal/retry"
+)
+
+// isRetryableRemoteError reports whether a failed push, pull, or fetch is
+// worth another attempt: the network or remote failed transiently, or a
+// Dolt lock was briefly held. Rejections (auth, non-fast-forward, merge
+// conflicts) and the CLI transfer timeout are not: the next attempt would
+// fail the same way, or run as long again.
+func isRetryableRemoteError(err error) bool {
+	if err == nil || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	if strings.Contains(err.Error(), cliExecTimeoutEnv) {
+		return false
+	}
+	return retry.IsTransient(err) || retry.IsLockContention(err) || isRetryableError(err)
+}
+
+// withRemoteRetry runs one push, pull, or fetch against remote under
+// retry.RemoteFromConfig, counting and announcing each retry. The transfer
+// is reported as a progress task, so a long push shows a spinner.
+func withRemoteRetry(ctx context.Context, op, remote string, fn func() error) error {
+	_, task := progress.Start(ctx, op+" "+remote, 0)
+	defer task.Finish()
+	policy := retry.RemoteFromConfig()
+	notice := retry.Notice(os.Stderr, op+" "+remote, policy.MaxAttempts)
+	policy.OnRetry = func(err error, wait time.Duration) {
+		doltMetrics.remoteRetries.Add(ctx, 1, metric.WithAttributes(attribute.String("op", op)))
+		notice(err, wait)
+	}
+	return retry.Do(ctx, policy, isRetryableRemoteError, fn)
+}
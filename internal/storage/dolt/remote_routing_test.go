@@ -14,7 +14,7 @@ func TestEnsureMatchingCLIRemoteSurfacesValidationErrors(t *testing.T) {
 		database: "beads",
 	}
 
-	err := store.ensureMatchingCLIRemote("origin", "ftp://server/path")
+	err := store.ensureMatchingCLIRemote(context.Background(), "origin", "ftp://server/path")
 	if err == nil {
 		t.Fatal("expected invalid remote URL to be returned as an error")
 	}
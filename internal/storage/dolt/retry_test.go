@@ -3,7 +3,11 @@ package dolt
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/progress"
 )
 
 func TestIsRetryableError(t *testing.T) {
@@ -17,6 +21,16 @@ func TestIsRetryableError(t *testing.T) {
 			err:      nil,
 			expected: false,
 		},
+		{
+			name:     "caller canceled",
+			err:      fmt.Errorf("query issues: %w", context.Canceled),
+			expected: false,
+		},
+		{
+			name:     "caller deadline exceeded",
+			err:      fmt.Errorf("query issues: %w", context.DeadlineExceeded),
+			expected: false,
+		},
 		{
 			name:     "driver bad connection",
 			err:      errors.New("driver: bad connection"),
@@ -183,3 +197,112 @@ func TestWithRetry_NonRetryableError(t *testing.T) {
 		t.Errorf("expected 1 call for non-retryable error, got %d", callCount)
 	}
 }
+
+// TestWithRetry_CanceledStopsWithoutTrippingBreaker pins that an operation
+// aborted by Ctrl-C is neither retried nor counted against the server: the
+// driver reports the aborted query as "invalid connection", which would
+// otherwise feed the cross-process circuit breaker.
+func TestWithRetry_CanceledStopsWithoutTrippingBreaker(t *testing.T) {
+	t.Setenv("BEADS_TEST_MODE", "")
+	store := &DoltStore{breaker: newTestCircuitBreaker(t)}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	callCount := 0
+	err := store.withRetry(ctx, func() error {
+		callCount++
+		cancel()
+		return errors.New("invalid connection")
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want it to wrap context.Canceled", err)
+	}
+	if callCount != 1 {
+		t.Errorf("expected 1 call after cancellation, got %d", callCount)
+	}
+	if state := store.breaker.readState(); state.Failures != 0 {
+		t.Errorf("breaker recorded %d failures for a canceled operation, want 0", state.Failures)
+	}
+}
+
+func TestWithRetry_DeadlineStopsRetrying(t *testing.T) {
+	store := &DoltStore{}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := store.withRetry(ctx, func() error {
+		return errors.New("driver: bad connection")
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+	// retry.Server would keep retrying for 30s without the deadline.
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("withRetry ran %v past a 50ms deadline", elapsed)
+	}
+}
+
+func TestIsRetryableRemoteError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"remote timeout", errors.New("dolt push failed: rpc error: code = Unavailable desc = i/o timeout"), true},
+		{"lock contention", errors.New("dolt pull failed: database is locked by another dolt process"), true},
+		{"server blip", errors.New("failed to push to origin/main: driver: bad connection"), true},
+		{"transfer timeout", errors.New("dolt push to \"origin\" timed out after 15m0s (override with " + cliExecTimeoutEnv + "=<duration>): i/o timeout"), false},
+		{"circuit open", ErrCircuitOpen, false},
+		{"rejected", errors.New("failed to push to origin/main: non-fast-forward"), false},
+		{"auth", errors.New("dolt push failed: permission denied (publickey)"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableRemoteError(tt.err); got != tt.want {
+				t.Errorf("isRetryableRemoteError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRemoteRetry(t *testing.T) {
+	t.Setenv("BEADS_DOLT_RETRY_ATTEMPTS", "2")
+	t.Setenv("BEADS_DOLT_RETRY_BACKOFF", "1ms")
+	calls := 0
+	err := withRemoteRetry(context.Background(), "push", "origin", func() error {
+		calls++
+		if calls == 1 {
+			return errors.New("connection reset by peer")
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("withRemoteRetry = %v after %d calls, want success after 2", err, calls)
+	}
+
+	calls = 0
+	_ = withRemoteRetry(context.Background(), "push", "origin", func() error {
+		calls++
+		return errors.New("non-fast-forward")
+	})
+	if calls != 1 {
+		t.Errorf("non-retryable error attempted %d times, want 1", calls)
+	}
+}
+
+type progressEvents []progress.Event
+
+func (e *progressEvents) Report(ev progress.Event) { *e = append(*e, ev) }
+
+func TestWithRemoteRetryReportsProgress(t *testing.T) {
+	var events progressEvents
+	ctx := progress.WithReporter(context.Background(), &events)
+	if err := withRemoteRetry(ctx, "push", "origin", func() error { return nil }); err != nil {
+		t.Fatalf("withRemoteRetry: %v", err)
+	}
+	if len(events) != 2 || events[0].Op != "push origin" || events[0].Finished || !events[1].Finished {
+		t.Errorf("events = %+v, want a started and a finished \"push origin\" task", events)
+	}
+}
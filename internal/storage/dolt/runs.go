@@ -0,0 +1,27 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// RecordRun inserts or updates a run and refreshes the issue's last run status.
+func (s *DoltStore) RecordRun(ctx context.Context, run *types.Run, actor string) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return issueops.RecordRunInTx(ctx, tx, run, actor)
+	})
+}
+
+// ListRuns returns an issue's runs, most recent first.
+func (s *DoltStore) ListRuns(ctx context.Context, issueID string, limit int) ([]*types.Run, error) {
+	var result []*types.Run
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ListRunsInTx(ctx, tx, issueID, limit)
+		return err
+	})
+	return result, err
+}
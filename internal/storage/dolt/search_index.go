@@ -0,0 +1,20 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// RefreshSearchIndex brings the full-text search index up to date. The
+// index tables are dolt_ignored, so this writes no Dolt commit.
+func (s *DoltStore) RefreshSearchIndex(ctx context.Context) (int, error) {
+	var n int
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		n, err = issueops.RefreshSearchIndexInTx(ctx, tx)
+		return err
+	})
+	return n, err
+}
@@ -32,7 +32,7 @@ func (s *DoltStore) MergeMetadata(ctx context.Context, issueID, key string, valu
 	// withRetryTx owns BeginTx and the final Commit. The read+merge+write inside
 	// the fn is a single transaction; the retry is what fixes the cross-tx
 	// clobber the old SlotSet suffered from.
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		if err := issueops.MergeMetadataInTx(ctx, tx, issueID, key, value, actor); err != nil {
 			return err
 		}
@@ -56,7 +56,7 @@ func (s *DoltStore) MergeMetadata(ctx context.Context, issueID, key string, valu
 // versioning since wisps live in dolt_ignored tables. The read and write still
 // share the one transaction, so the atomic-merge property holds.
 func (s *DoltStore) mergeMetadataWisp(ctx context.Context, issueID, key string, value json.RawMessage, actor string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -133,7 +133,7 @@ func (s *DoltStore) SlotClear(ctx context.Context, issueID, key, actor string) e
 		return s.clearMetadataWisp(ctx, issueID, key, actor)
 	}
 
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+	return s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		if err := issueops.DeleteMetadataInTx(ctx, tx, issueID, key, actor); err != nil {
 			return err
 		}
@@ -156,7 +156,7 @@ func (s *DoltStore) SlotClear(ctx context.Context, issueID, key, actor string) e
 // clearMetadataWisp clears a metadata key on a wisp. Mirrors mergeMetadataWisp /
 // closeWisp: no Dolt versioning since wisps live in dolt_ignored tables.
 func (s *DoltStore) clearMetadataWisp(ctx context.Context, issueID, key, actor string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -0,0 +1,65 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetIssueSlug sets an issue's current slug, keeping the previous one.
+func (s *DoltStore) SetIssueSlug(ctx context.Context, issueID, slug, actor string) (string, error) {
+	var result string
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.SetIssueSlugInTx(ctx, tx, issueID, slug, actor)
+		return err
+	})
+	return result, err
+}
+
+// ListIssueSlugs returns an issue's current and old slugs.
+func (s *DoltStore) ListIssueSlugs(ctx context.Context, issueID string) ([]*types.IssueSlug, error) {
+	var result []*types.IssueSlug
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ListIssueSlugsInTx(ctx, tx, issueID)
+		return err
+	})
+	return result, err
+}
+
+// ResolveSlug returns the ID of the issue holding slug.
+func (s *DoltStore) ResolveSlug(ctx context.Context, slug string) (string, error) {
+	var result string
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ResolveSlugInTx(ctx, tx, slug)
+		return err
+	})
+	return result, err
+}
+
+// CurrentSlugs returns the current slugs of the given issues.
+func (s *DoltStore) CurrentSlugs(ctx context.Context, issueIDs []string) (map[string]string, error) {
+	var result map[string]string
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.CurrentSlugsInTx(ctx, tx, issueIDs)
+		return err
+	})
+	return result, err
+}
+
+// GenerateIssueSlugs names every issue without a slug after its title.
+func (s *DoltStore) GenerateIssueSlugs(ctx context.Context, opts storage.SlugGenerationOptions, actor string) ([]*types.IssueSlug, error) {
+	var result []*types.IssueSlug
+	err := s.withRetryTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GenerateIssueSlugsInTx(ctx, tx, opts, actor)
+		return err
+	})
+	return result, err
+}
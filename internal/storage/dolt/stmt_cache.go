@@ -0,0 +1,200 @@
+package dolt
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// defaultStmtCacheSize bounds the prepared statements kept per store. The
+// filter builder emits a handful of shapes for everyday `bd ready` and
+// `bd list` calls; the rest are one-offs that age out.
+const defaultStmtCacheSize = 64
+
+// stmtPrepareTimeout caps a background prepare that is waiting for a free
+// pooled connection.
+const stmtPrepareTimeout = 30 * time.Second
+
+// stmtCache is an LRU of statements prepared on one *sql.DB, keyed by SQL
+// text. A hit runs through tx.StmtContext, which reuses the statement
+// already prepared on the transaction's connection (or prepares it there
+// once), so the server skips parsing and planning the query again.
+//
+// A miss never prepares inline: preparing on the *sql.DB needs a second
+// pooled connection while the caller's transaction holds one, which
+// deadlocks once the pool is exhausted. The miss runs as a plain query and
+// the statement is prepared in the background for the next caller.
+type stmtCache struct {
+	db   *sql.DB
+	size int
+
+	mu      sync.Mutex
+	ll      *list.List               // most recently used at the front
+	items   map[string]*list.Element // SQL text -> *stmtCacheEntry element
+	pending map[string]bool          // SQL text being prepared in the background
+	closed  bool
+}
+
+type stmtCacheEntry struct {
+	query string
+	stmt  *sql.Stmt // nil when the server refused to prepare the query
+}
+
+// newStmtCache returns a cache of up to size statements prepared on db, or
+// nil (caching disabled) when size is not positive.
+func newStmtCache(db *sql.DB, size int) *stmtCache {
+	if db == nil || size <= 0 {
+		return nil
+	}
+	return &stmtCache{
+		db:      db,
+		size:    size,
+		ll:      list.New(),
+		items:   make(map[string]*list.Element),
+		pending: make(map[string]bool),
+	}
+}
+
+// lookup returns the cached statement for query, or nil on a miss. A miss
+// starts preparing query in the background unless that is already under
+// way or the server refused it before.
+func (c *stmtCache) lookup(query string) *sql.Stmt {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	if el, ok := c.items[query]; ok {
+		c.ll.MoveToFront(el)
+		return el.Value.(*stmtCacheEntry).stmt
+	}
+	if !c.pending[query] {
+		c.pending[query] = true
+		go c.prepare(query)
+	}
+	return nil
+}
+
+func (c *stmtCache) prepare(query string) {
+	ctx, cancel := context.WithTimeout(context.Background(), stmtPrepareTimeout)
+	defer cancel()
+	stmt, err := c.db.PrepareContext(ctx, query)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, query)
+	if c.closed {
+		if stmt != nil {
+			_ = stmt.Close()
+		}
+		return
+	}
+	if err != nil {
+		// Remember only refusals from the server, not a pool that was busy
+		// or closed, so a transient failure is retried by the next miss.
+		if ctx.Err() != nil || isRetryableError(err) {
+			return
+		}
+		stmt = nil
+	}
+	c.items[query] = c.ll.PushFront(&stmtCacheEntry{query: query, stmt: stmt})
+	for c.ll.Len() > c.size {
+		c.removeLocked(c.ll.Back())
+	}
+}
+
+// evict drops query from the cache, e.g. after its statement failed.
+func (c *stmtCache) evict(query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[query]; ok {
+		c.removeLocked(el)
+	}
+}
+
+func (c *stmtCache) removeLocked(el *list.Element) {
+	entry := c.ll.Remove(el).(*stmtCacheEntry)
+	delete(c.items, entry.query)
+	if entry.stmt != nil {
+		// Transactions still using the statement keep it alive until they
+		// finish; database/sql defers the real close until then.
+		_ = entry.stmt.Close()
+	}
+}
+
+// len reports how many statements are cached.
+func (c *stmtCache) len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// close releases every cached statement. Background prepares still in
+// flight close their statement when they finish.
+func (c *stmtCache) close() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for c.ll.Len() > 0 {
+		c.removeLocked(c.ll.Back())
+	}
+}
+
+func stmtCacheSize(cfg *Config) int {
+	switch {
+	case cfg == nil || cfg.StmtCacheSize == 0:
+		return defaultStmtCacheSize
+	case cfg.StmtCacheSize < 0:
+		return 0
+	default:
+		return cfg.StmtCacheSize
+	}
+}
+
+// cachedStmtTx runs a read transaction's queries through the store's
+// statement cache. Only QueryContext is cached: it is what the search and
+// ready-work queries use, and a failed prepared query can fall back to the
+// plain one before any rows are returned. QueryRowContext and ExecContext
+// pass straight through.
+type cachedStmtTx struct {
+	*sql.Tx
+	s *DoltStore
+}
+
+// cachedReadTx wraps tx for issueops calls on hot read paths. The caller
+// must hold s.mu (as withReadTx does), so the cache belongs to the same
+// *sql.DB as tx.
+func (s *DoltStore) cachedReadTx(tx *sql.Tx) cachedStmtTx {
+	return cachedStmtTx{Tx: tx, s: s}
+}
+
+func (t cachedStmtTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	cache := t.s.stmts
+	if cache == nil {
+		return t.Tx.QueryContext(ctx, query, args...)
+	}
+	stmt := cache.lookup(query)
+	if stmt == nil {
+		t.s.stmtCacheMisses.Add(1)
+		doltMetrics.stmtCacheMisses.Add(ctx, 1)
+		return t.Tx.QueryContext(ctx, query, args...)
+	}
+	t.s.stmtCacheHits.Add(1)
+	doltMetrics.stmtCacheHits.Add(ctx, 1)
+	rows, err := t.Tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	if err != nil && ctx.Err() == nil {
+		// A statement the server no longer accepts (e.g. after a schema
+		// change) must not keep failing the query it caches.
+		cache.evict(query)
+		return t.Tx.QueryContext(ctx, query, args...)
+	}
+	return rows, err
+}
@@ -24,13 +24,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	mysql "github.com/go-sql-driver/mysql"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -40,6 +40,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/doltserver"
+	"github.com/steveyegge/beads/internal/retry"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/doltutil"
 	"github.com/steveyegge/beads/internal/storage/issueops"
@@ -148,12 +149,28 @@ var _ storage.DoltStorage = (*DoltStore)(nil)
 var _ storage.RawDBAccessor = (*DoltStore)(nil)
 var _ storage.StoreLocator = (*DoltStore)(nil)
 var _ storage.LifecycleManager = (*DoltStore)(nil)
+var _ storage.MergeConflictStore = (*DoltStore)(nil)
+var _ storage.PeerHealthChecker = (*DoltStore)(nil)
+var _ storage.CredentialKeyRotator = (*DoltStore)(nil)
+var _ storage.SyncPreviewer = (*DoltStore)(nil)
+var _ storage.PeerVerifier = (*DoltStore)(nil)
 var _ storage.PendingCommitter = (*DoltStore)(nil)
 var _ storage.GarbageCollector = (*DoltStore)(nil)
+var _ storage.IntegrityChecker = (*DoltStore)(nil)
 var _ storage.Flattener = (*DoltStore)(nil)
 var _ storage.Compactor = (*DoltStore)(nil)
 var _ storage.SchemaMigrator = (*DoltStore)(nil)
 var _ storage.ExternalRefHistoryQuerier = (*DoltStore)(nil)
+var _ storage.GraphDiffer = (*DoltStore)(nil)
+var _ storage.KnowledgeStore = (*DoltStore)(nil)
+var _ storage.RunStore = (*DoltStore)(nil)
+var _ storage.SlugStore = (*DoltStore)(nil)
+var _ storage.SearchIndexer = (*DoltStore)(nil)
+var _ storage.IssueLockStore = (*DoltStore)(nil)
+var _ storage.TrendStore = (*DoltStore)(nil)
+var _ storage.IssueHistoryReader = (*DoltStore)(nil)
+var _ storage.IssueBlamer = (*DoltStore)(nil)
+var _ storage.PendingChangesReader = (*DoltStore)(nil)
 
 // DoltStore implements the Storage interface using Dolt
 type DoltStore struct {
@@ -167,6 +184,9 @@ type DoltStore struct {
 	readOnly      bool         // True if opened in read-only mode
 	credentialKey []byte       // Random encryption key for federation credentials
 
+	clock storage.Clock       // Config.Clock; nil reads the system clock
+	idGen storage.IDGenerator // Config.IDGenerator; nil uses content-hash IDs
+
 	customStatusDetailedCache []types.CustomStatus
 	customStatusCache         []string
 	customStatusCached        bool
@@ -186,16 +206,27 @@ type DoltStore struct {
 	// Version control config
 	committerName  string
 	committerEmail string
-	remote         string // Default remote for push/pull
-	branch         string // Current branch
-	remoteUser     string // Remote auth user for Hosted Dolt push/pull (optional)
-	remotePassword string // Remote auth password for Hosted Dolt push/pull (optional)
-	serverMode     bool   // true when connected to external dolt sql-server (not embedded)
+	remote         string  // Default remote for push/pull
+	branch         string  // Current branch
+	poolConfig     *Config // Pool limits, reapplied when Checkout reopens the pool
+	remoteUser     string  // Remote auth user for Hosted Dolt push/pull (optional)
+	remotePassword string  // Remote auth password for Hosted Dolt push/pull (optional)
+	serverMode     bool    // true when connected to external dolt sql-server (not embedded)
+	remoteServer   bool    // true when the sql-server is on another machine (see IsRemoteServer)
 
 	// autoStartedServerDir is set when this store triggered a dolt sql-server
 	// auto-start. Close() uses it to stop the server when the last store
 	// referencing it is closed (tracked via autoStartRefs).
 	autoStartedServerDir string
+
+	// Query timings reported by PoolStats.
+	queries     atomic.Int64
+	slowQueries atomic.Int64
+
+	// Prepared statements for hot read paths; replaced with s.db.
+	stmts           *stmtCache
+	stmtCacheHits   atomic.Int64
+	stmtCacheMisses atomic.Int64
 }
 
 // Config holds Dolt database configuration
@@ -292,6 +323,20 @@ type Config struct {
 	// connection before the server reaps it server-side; otherwise the next
 	// query handed a server-reaped connection fails with "invalid connection".
 	ConnMaxIdleTime time.Duration
+
+	// SlowQueryThreshold is how long a statement or transaction may take
+	// before PoolStats counts it as slow (0 = default 1s).
+	SlowQueryThreshold time.Duration
+
+	// StmtCacheSize bounds the prepared statements cached for search and
+	// ready-work queries (0 = default 64, negative disables the cache).
+	StmtCacheSize int
+
+	// Clock and IDGenerator, when set, supply the current time and hash-mode
+	// issue IDs to every operation on the store. A storage.WithClock or
+	// storage.WithIDGenerator context overrides them for one call.
+	Clock       storage.Clock
+	IDGenerator storage.IDGenerator
 }
 
 // Defaults for the *sql.DB connection pool. Exported for tests/callers that
@@ -306,6 +351,8 @@ const (
 	// before the server reaps it; this prevents the next read from picking up a
 	// server-closed connection and failing with "invalid connection".
 	defaultConnMaxIdleTime = 20 * time.Second
+
+	defaultSlowQueryThreshold = time.Second
 )
 
 // cliExecTimeout is the default maximum time to wait for dolt CLI
@@ -321,11 +368,27 @@ const cliExecTimeout = 5 * time.Minute
 const cliExecTimeoutEnv = "BEADS_CLI_TRANSFER_TIMEOUT"
 
 // cliExecWaitDelay bounds how long Wait/CombinedOutput may keep waiting after
-// the transfer context expires. CommandContext kills only the direct dolt
+// the transfer context expires. CommandContext signals only the direct dolt
 // child; a grandchild (e.g. a cloud credential helper) that inherited the
 // output pipes would otherwise keep Wait blocked indefinitely after the kill.
+// It is also the grace period an interrupted dolt gets before it is killed
+// (see interruptOnCancel).
 const cliExecWaitDelay = 10 * time.Second
 
+// interruptOnCancel makes canceling cmd's context interrupt the dolt
+// subprocess instead of killing it outright, so dolt can abort its transfer
+// and release the database directory lock the way it does on Ctrl-C. A dolt
+// still running cmd.WaitDelay later is killed. Windows cannot deliver an
+// interrupt to a child process, so there it is killed at once.
+func interruptOnCancel(cmd *exec.Cmd) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+}
+
 // cliExecTimeoutDuration returns the configured CLI transfer timeout. The env
 // var BEADS_CLI_TRANSFER_TIMEOUT overrides the compiled-in cliExecTimeout
 // const; valid time.ParseDuration strings (e.g. "20m", "90s") or bare numbers
@@ -381,25 +444,25 @@ func fsckTimeoutDuration() time.Duration {
 	return timeoutFromEnv(fsckTimeoutEnv, fsckTimeout)
 }
 
-// Retry configuration for transient connection errors (stale pool connections,
-// brief network issues, server restarts).
-const serverRetryMaxElapsed = 30 * time.Second
-
-func newServerRetryBackoff() backoff.BackOff {
-	bo := backoff.NewExponentialBackOff()
-	bo.MaxElapsedTime = serverRetryMaxElapsed
-	return bo
-}
-
 // isRetryableError returns true if the error is a transient connection error
 // that should be retried in server mode.
 func isRetryableError(err error) bool {
 	if err == nil {
 		return false
 	}
+	// The caller gave up (Ctrl-C, or a deadline): nothing to retry.
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
 	if schema.IsMigrationLockError(err) {
 		return true
 	}
+	// Network blips shared with remote transfers: resets, broken pipes, read
+	// and write timeouts, and "connection refused" while the server restarts
+	// (it may come back within retry.Server's 30s window).
+	if retry.IsTransient(err) {
+		return true
+	}
 	errStr := strings.ToLower(err.Error())
 	// MySQL driver transient errors
 	if strings.Contains(errStr, "driver: bad connection") {
@@ -408,19 +471,6 @@ func isRetryableError(err error) bool {
 	if strings.Contains(errStr, "invalid connection") {
 		return true
 	}
-	// Network transient errors (brief blips, not persistent failures)
-	if strings.Contains(errStr, "broken pipe") {
-		return true
-	}
-	if strings.Contains(errStr, "connection reset") {
-		return true
-	}
-	// Server restart: "connection refused" is transient — the server may
-	// come back within the backoff window (30s). Retrying here prevents
-	// a brief server outage from cascading into permanent failures.
-	if strings.Contains(errStr, "connection refused") {
-		return true
-	}
 	// Dolt read-only mode: under load, Dolt may enter read-only mode with
 	// "cannot update manifest: database is read only". This clears after
 	// a server restart, so it's worth retrying.
@@ -435,10 +485,6 @@ func isRetryableError(err error) bool {
 	if strings.Contains(errStr, "gone away") {
 		return true
 	}
-	// Go net package timeout on read/write
-	if strings.Contains(errStr, "i/o timeout") {
-		return true
-	}
 	// Dolt server catalog race: after CREATE DATABASE, the server's in-memory
 	// catalog may not have registered the new database yet. The immediately
 	// following USE (implicit via DSN) fails with "Unknown database". This is
@@ -524,6 +570,9 @@ func lockProcessHint() string {
 // withRetry executes an operation with retry for transient errors.
 // If a circuit breaker is configured, it checks the breaker before each attempt
 // and records connection failures/successes to coordinate fail-fast across processes.
+// Once ctx is done it stops at the first failure without recording it: the
+// driver reports a query aborted by cancellation as a bad connection, which
+// says nothing about the server's health.
 func (s *DoltStore) withRetry(ctx context.Context, op func() error) error {
 	// Circuit breaker: fail-fast if the server is known to be down.
 	if s.breaker != nil && !s.breaker.Allow() {
@@ -532,10 +581,12 @@ func (s *DoltStore) withRetry(ctx context.Context, op func() error) error {
 	}
 
 	attempts := 0
-	bo := newServerRetryBackoff()
-	err := backoff.Retry(func() error {
+	err := retry.Do(ctx, retry.Server, isRetryableError, func() error {
 		attempts++
 		err := op()
+		if err != nil && ctx.Err() != nil {
+			return retry.Permanent(canceledError(ctx, err))
+		}
 		if err != nil && isRetryableError(err) {
 			// Record connection-level failures to the circuit breaker
 			if s.breaker != nil && isConnectionError(err) {
@@ -543,20 +594,20 @@ func (s *DoltStore) withRetry(ctx context.Context, op func() error) error {
 				// Check if the breaker just tripped — if so, stop retrying
 				if s.breaker.State() == circuitOpen {
 					doltMetrics.circuitTrips.Add(ctx, 1)
-					return backoff.Permanent(fmt.Errorf("%w (circuit breaker tripped)", err))
+					return retry.Permanent(fmt.Errorf("%w (circuit breaker tripped)", err))
 				}
 			}
-			return err // Retryable - backoff will retry
+			return err // Retryable - retry.Do will retry
 		}
 		if err != nil {
-			return backoff.Permanent(err) // Non-retryable - stop immediately
+			return err // Non-retryable - retry.Do stops immediately
 		}
 		// Success — reset the circuit breaker
 		if s.breaker != nil {
 			s.breaker.RecordSuccess()
 		}
 		return nil
-	}, backoff.WithContext(bo, ctx))
+	})
 	if attempts > 1 {
 		doltMetrics.retryCount.Add(ctx, int64(attempts-1))
 	}
@@ -580,6 +631,10 @@ var doltMetrics struct {
 	connAcquireMs       metric.Float64Histogram
 	poolWaitCount       metric.Int64Counter
 	poolWaitMs          metric.Float64Histogram
+	slowQueries         metric.Int64Counter
+	stmtCacheHits       metric.Int64Counter
+	stmtCacheMisses     metric.Int64Counter
+	remoteRetries       metric.Int64Counter
 }
 
 func init() {
@@ -620,6 +675,22 @@ func init() {
 		metric.WithDescription("Total time connections spent waiting due to pool exhaustion"),
 		metric.WithUnit("ms"),
 	)
+	doltMetrics.slowQueries, _ = m.Int64Counter("bd.db.slow_queries",
+		metric.WithDescription("Statements and transactions that ran past the slow-query threshold"),
+		metric.WithUnit("{query}"),
+	)
+	doltMetrics.stmtCacheHits, _ = m.Int64Counter("bd.db.stmt_cache_hits",
+		metric.WithDescription("Search and ready-work queries run from a cached prepared statement"),
+		metric.WithUnit("{query}"),
+	)
+	doltMetrics.stmtCacheMisses, _ = m.Int64Counter("bd.db.stmt_cache_misses",
+		metric.WithDescription("Search and ready-work queries with no cached prepared statement yet"),
+		metric.WithUnit("{query}"),
+	)
+	doltMetrics.remoteRetries, _ = m.Int64Counter("bd.remote.retry_count",
+		metric.WithDescription("Push, pull, and fetch attempts retried after transient remote errors (label: op)"),
+		metric.WithUnit("{retry}"),
+	)
 }
 
 // registerPoolGauges registers observable gauges that report sql.DB pool stats
@@ -708,34 +779,48 @@ var ErrStoreClosed = errors.New("store is closed")
 // that has been idle past its wait_timeout) is retried rather than surfaced to
 // the caller. This is safe because fn is read-only and the transaction is always
 // rolled back, so re-running the operation has no side effects.
-func (s *DoltStore) withReadTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+//
+// fn receives ctx carrying the store's clock and ID generator; the tx helpers
+// are the one place store methods pick them up.
+func (s *DoltStore) withReadTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
 	if s.closed.Load() {
 		return ErrStoreClosed
 	}
+	ctx = s.opContext(ctx)
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	defer s.observeQuery(ctx, time.Now())
 	return s.withRetry(ctx, func() error {
 		tx, err := s.db.BeginTx(ctx, nil)
 		if err != nil {
 			return fmt.Errorf("begin read tx: %w", err)
 		}
 		defer func() { _ = tx.Rollback() }()
-		return fn(tx)
+		return fn(ctx, tx)
 	})
 }
 
-func (s *DoltStore) withRetryTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
-	bo := backoff.NewExponentialBackOff()
-	bo.InitialInterval = 25 * time.Millisecond
-	bo.MaxElapsedTime = 5 * time.Second
+// withRetryTx runs fn in a write transaction, replaying it on serialization
+// failures and pre-commit connection errors. Like withReadTx, fn receives ctx
+// carrying the store's clock and ID generator.
+func (s *DoltStore) withRetryTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	policy := retry.Server
+	policy.InitialInterval = 25 * time.Millisecond
+	policy.MaxElapsed = 5 * time.Second
 	if s.serverMode {
-		bo.MaxElapsedTime = 15 * time.Second
+		policy.MaxElapsed = 15 * time.Second
 	}
-	return backoff.Retry(func() error {
+	return retry.Do(ctx, policy, isRetryableTxError, func() error {
 		err := s.withWriteTx(ctx, fn)
 		if err == nil {
 			return nil
 		}
+		// Canceled: never replay. Canceling BeginTx's context before commit
+		// rolls the transaction back; a cancel during commit leaves
+		// errCommitPhase in err, as for a dropped connection.
+		if ctx.Err() != nil {
+			return retry.Permanent(canceledError(ctx, err))
+		}
 		// Serialization failures (1213/1205) guarantee a server-side rollback,
 		// so the write never landed — safe to replay at any phase.
 		if isSerializationError(err) {
@@ -749,24 +834,42 @@ func (s *DoltStore) withRetryTx(ctx context.Context, fn func(tx *sql.Tx) error)
 		// so replaying could double-apply the write. Surface it instead.
 		if isRetryableError(err) {
 			if errors.Is(err, errCommitPhase) {
-				return backoff.Permanent(fmt.Errorf("write commit result indeterminate after connection loss (not retried to avoid double-apply): %w", err))
+				return retry.Permanent(fmt.Errorf("write commit result indeterminate after connection loss (not retried to avoid double-apply): %w", err))
 			}
 			doltMetrics.writeRetries.Add(ctx, 1, metric.WithAttributes(attribute.String("type", "connection")))
 			return err // pre-commit transient: retryable
 		}
-		return backoff.Permanent(err)
-	}, backoff.WithContext(bo, ctx))
+		return err
+	})
+}
+
+// canceledError returns err from an operation interrupted because ctx ended,
+// wrapping ctx's error when err does not already, so callers can test for
+// context.Canceled even when the driver reported a bad connection.
+func canceledError(ctx context.Context, err error) error {
+	if errors.Is(err, ctx.Err()) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ctx.Err(), err)
+}
+
+// isRetryableTxError reports whether withRetryTx may replay a failed write
+// transaction: a serialization failure, or a transient connection error.
+func isRetryableTxError(err error) bool {
+	return isSerializationError(err) || isRetryableError(err)
 }
 
-func (s *DoltStore) withWriteTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+func (s *DoltStore) withWriteTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
 	if s.closed.Load() {
 		return ErrStoreClosed
 	}
+	ctx = s.opContext(ctx)
+	defer s.observeQuery(ctx, time.Now())
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin write tx: %w", err)
 	}
-	if err := fn(tx); err != nil {
+	if err := fn(ctx, tx); err != nil {
 		return errors.Join(err, tx.Rollback())
 	}
 	if err := tx.Commit(); err != nil {
@@ -777,12 +880,22 @@ func (s *DoltStore) withWriteTx(ctx context.Context, fn func(tx *sql.Tx) error)
 	return nil
 }
 
+// beginTx begins a bare transaction for paths that commit it themselves, such
+// as wisp writes, which skip retries and Dolt versioning. The returned ctx
+// carries the store's clock and ID generator, as in withReadTx.
+func (s *DoltStore) beginTx(ctx context.Context) (context.Context, *sql.Tx, error) {
+	ctx = s.opContext(ctx)
+	tx, err := s.db.BeginTx(ctx, nil)
+	return ctx, tx, err
+}
+
 // uncommitted implicit transaction that Dolt rolls back on connection close,
 // causing silent data loss for callers that do not use db.BeginTx themselves.
 func (s *DoltStore) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
 	if s.closed.Load() {
 		return nil, ErrStoreClosed
 	}
+	defer s.observeQuery(ctx, time.Now())
 	ctx, span := doltTracer.Start(ctx, "dolt.exec",
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(append(s.doltSpanAttrs(),
@@ -826,9 +939,12 @@ func (s *DoltStore) BackupAdd(ctx context.Context, name, url string) error {
 	return versioncontrolops.BackupAdd(ctx, s.db, name, url)
 }
 
-// BackupSync pushes the database to the named backup destination.
+// BackupSync pushes the database to the named backup destination, retrying
+// transient failures like a push.
 func (s *DoltStore) BackupSync(ctx context.Context, name string) error {
-	return versioncontrolops.BackupSync(ctx, s.db, name)
+	return withRemoteRetry(ctx, "backup sync", name, func() error {
+		return versioncontrolops.BackupSync(ctx, s.db, name)
+	})
 }
 
 // BackupRemove removes a configured Dolt backup destination.
@@ -891,6 +1007,30 @@ func (s *DoltStore) RestoreDatabase(ctx context.Context, dir string, force bool)
 	return versioncontrolops.BackupRestore(ctx, s.db, backupURL, s.database, force)
 }
 
+// CheckIntegrity reads back the issues committed at commit (HEAD when
+// empty) and counts recorded constraint violations.
+func (s *DoltStore) CheckIntegrity(ctx context.Context, commit string) (*storage.IntegrityReport, error) {
+	return versioncontrolops.CheckIntegrity(ctx, s.db, commit)
+}
+
+// CheckBackupIntegrity checks the Dolt backup at dir by restoring it into a
+// scratch database on the server and dropping it afterwards.
+func (s *DoltStore) CheckBackupIntegrity(ctx context.Context, dir string) (*storage.IntegrityReport, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("backup does not exist: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("backup is not a directory: %s", dir)
+	}
+
+	backupURL, err := versioncontrolops.DirToFileURL(dir)
+	if err != nil {
+		return nil, err
+	}
+	return versioncontrolops.CheckBackupIntegrity(ctx, s.db, backupURL)
+}
+
 // QueryContext wraps s.db.QueryContext with retry for transient errors.
 // Exported so callers (e.g. backup) can run ad-hoc queries with retry
 // instead of going through the raw *sql.DB.
@@ -903,6 +1043,7 @@ func (s *DoltStore) queryContext(ctx context.Context, query string, args ...any)
 	if s.closed.Load() {
 		return nil, ErrStoreClosed
 	}
+	defer s.observeQuery(ctx, time.Now())
 	ctx, span := doltTracer.Start(ctx, "dolt.query",
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(append(s.doltSpanAttrs(),
@@ -932,6 +1073,7 @@ func (s *DoltStore) queryRowContext(ctx context.Context, scan func(*sql.Row) err
 	if s.closed.Load() {
 		return ErrStoreClosed
 	}
+	defer s.observeQuery(ctx, time.Now())
 	ctx, span := doltTracer.Start(ctx, "dolt.query_row",
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(append(s.doltSpanAttrs(),
@@ -1054,6 +1196,13 @@ func applyConfigDefaults(cfg *Config) {
 	}
 }
 
+// opContext returns ctx carrying the store's clock and ID generator. Store
+// methods get it from withReadTx, withWriteTx and beginTx; transaction
+// methods, which receive the caller's ctx, call it directly.
+func (s *DoltStore) opContext(ctx context.Context) context.Context {
+	return storage.WithStoreDefaults(ctx, s.clock, s.idGen)
+}
+
 // New creates a new Dolt storage backend.
 // Connects to a running dolt sql-server via MySQL protocol (pure Go).
 func New(ctx context.Context, cfg *Config) (*DoltStore, error) {
@@ -1230,11 +1379,16 @@ func newServerMode(ctx context.Context, cfg *Config) (*DoltStore, error) {
 		committerEmail:       cfg.CommitterEmail,
 		remote:               cfg.Remote,
 		branch:               "main",
+		poolConfig:           cfg,
+		stmts:                newStmtCache(db, stmtCacheSize(cfg)),
 		remoteUser:           cfg.RemoteUser,
 		remotePassword:       cfg.RemotePassword,
 		serverMode:           true,
+		remoteServer:         cfg.ServerSocket == "" && !isLocalHost(cfg.ServerHost),
 		readOnly:             cfg.ReadOnly,
 		autoStartedServerDir: autoStartedDir,
+		clock:                cfg.Clock,
+		idGen:                cfg.IDGenerator,
 	}
 
 	// Forward-drift guard runs on read-only AND writable opens. A binary older
@@ -1454,12 +1608,21 @@ func (s *DoltStore) execWithLongTimeoutNoTx(ctx context.Context, query string, a
 // for thousands of queries, so dolt-server.log no longer shows a
 // NewConnection/ConnectionClosed pair every few queries.
 func applyPoolLimits(db *sql.DB, cfg *Config) {
-	maxOpen := defaultMaxOpenConns
+	maxOpen, maxIdle, lifetime, idle := poolLimits(cfg)
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxLifetime(lifetime)
+	db.SetConnMaxIdleTime(idle)
+}
+
+// poolLimits resolves cfg's pool overrides against the defaults.
+func poolLimits(cfg *Config) (maxOpen, maxIdle int, lifetime, idle time.Duration) {
+	maxOpen = defaultMaxOpenConns
 	if cfg.MaxOpenConns > 0 {
 		maxOpen = cfg.MaxOpenConns
 	}
 
-	maxIdle := defaultMaxIdleConns
+	maxIdle = defaultMaxIdleConns
 	if cfg.MaxIdleConns > 0 {
 		maxIdle = cfg.MaxIdleConns
 	}
@@ -1469,20 +1632,16 @@ func applyPoolLimits(db *sql.DB, cfg *Config) {
 		maxIdle = maxOpen
 	}
 
-	lifetime := defaultConnMaxLifetime
+	lifetime = defaultConnMaxLifetime
 	if cfg.ConnMaxLifetime > 0 {
 		lifetime = cfg.ConnMaxLifetime
 	}
 
-	idle := defaultConnMaxIdleTime
+	idle = defaultConnMaxIdleTime
 	if cfg.ConnMaxIdleTime > 0 {
 		idle = cfg.ConnMaxIdleTime
 	}
-
-	db.SetMaxOpenConns(maxOpen)
-	db.SetMaxIdleConns(maxIdle)
-	db.SetConnMaxLifetime(lifetime)
-	db.SetConnMaxIdleTime(idle)
+	return maxOpen, maxIdle, lifetime, idle
 }
 
 // openServerConnection opens a connection to a dolt sql-server via MySQL protocol
@@ -1587,19 +1746,12 @@ func openServerConnection(ctx context.Context, cfg *Config) (*sql.DB, string, er
 	// database on disk but hasn't updated its catalog yet. Pinging db (which
 	// has the database in the DSN) will fail with "Unknown database" until the
 	// catalog catches up. We retry with exponential backoff. (GH-1851)
-	bo := backoff.NewExponentialBackOff()
-	bo.InitialInterval = 100 * time.Millisecond
-	bo.MaxElapsedTime = 10 * time.Second
-	if err := backoff.Retry(func() error {
-		pingErr := db.PingContext(ctx)
-		if pingErr != nil && isRetryableError(pingErr) {
-			return pingErr // retryable — backoff will retry
-		}
-		if pingErr != nil {
-			return backoff.Permanent(pingErr)
-		}
-		return nil
-	}, backoff.WithContext(bo, ctx)); err != nil {
+	pingPolicy := retry.Server
+	pingPolicy.InitialInterval = 100 * time.Millisecond
+	pingPolicy.MaxElapsed = 10 * time.Second
+	if err := retry.Do(ctx, pingPolicy, isRetryableError, func() error {
+		return db.PingContext(ctx)
+	}); err != nil {
 		return nil, "", fmt.Errorf("database %q not available after CREATE DATABASE: %w", cfg.Database, err)
 	}
 
@@ -1665,31 +1817,25 @@ func initSchemaOnDBWithRetryAndGate(ctx context.Context, db *sql.DB, gate func(c
 	// Schema initialization for server mode is idempotent. Retry transient
 	// Dolt startup/catalog races and contended migration-lock attempts so
 	// concurrent bd processes converge instead of failing one unlucky waiter.
-	schemaBO := backoff.NewExponentialBackOff()
-	schemaBO.InitialInterval = 100 * time.Millisecond
-	// Must exceed schema.MigrateUpWithLock's 5s GET_LOCK wait so a contended
-	// schema migration can time out once and still retry.
-	schemaBO.MaxElapsedTime = serverRetryMaxElapsed
+	schemaPolicy := retry.Server
+	schemaPolicy.InitialInterval = 100 * time.Millisecond
+	// retry.Server's 30s MaxElapsed must exceed schema.MigrateUpWithLock's 5s
+	// GET_LOCK wait so a contended schema migration can time out once and
+	// still retry.
 	var applied int
-	err := backoff.Retry(func() error {
+	err := retry.Do(ctx, schemaPolicy, isRetryableError, func() error {
 		if gate != nil {
 			if gateErr := gate(ctx, db); gateErr != nil {
-				if !schema.IsRemoteMigrateGateError(gateErr) && isRetryableError(gateErr) {
-					return gateErr
+				if schema.IsRemoteMigrateGateError(gateErr) {
+					return retry.Permanent(gateErr)
 				}
-				return backoff.Permanent(gateErr)
+				return gateErr
 			}
 		}
 		var schemaErr error
 		applied, schemaErr = initSchemaOnDB(ctx, db)
-		if schemaErr != nil && isRetryableError(schemaErr) {
-			return schemaErr
-		}
-		if schemaErr != nil {
-			return backoff.Permanent(schemaErr)
-		}
-		return nil
-	}, backoff.WithContext(schemaBO, ctx))
+		return schemaErr
+	})
 	return applied, err
 }
 
@@ -1791,6 +1937,8 @@ func (s *DoltStore) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	var err error
+	s.stmts.close()
+	s.stmts = nil
 	if s.db != nil {
 		if cerr := doltutil.CloseWithTimeout("db", s.db.Close); cerr != nil {
 			// Timeout is non-fatal for cleanup - just log it
@@ -1835,11 +1983,25 @@ func (s *DoltStore) IsReadOnly() bool {
 	return s.readOnly
 }
 
+// IsRemoteServer reports whether the store talks to a dolt sql-server on
+// another machine over TCP. The server's data directory is not on the local
+// filesystem then, so every operation, including push, pull, fetch, and GC,
+// must go through SQL rather than a dolt CLI subprocess.
+func (s *DoltStore) IsRemoteServer() bool {
+	return s.remoteServer
+}
+
 // CLIDir returns the directory for dolt CLI operations (push/pull/remote/fetch).
 // The actual database lives in a subdirectory of Path() named after the database.
 // Use this instead of Path() when running dolt CLI commands that target the
 // actual database (e.g., remote add/remove, push, pull).
+//
+// It returns "" for a remote server: a local directory, if any, is not the
+// server's database, and routing a push through it would publish stale data.
 func (s *DoltStore) CLIDir() string {
+	if s.remoteServer {
+		return ""
+	}
 	if s.serverMode && doltserver.IsSharedServerMode() && s.beadsDir != "" {
 		return filepath.Join(doltserver.ResolveDoltDir(s.beadsDir), s.database)
 	}
@@ -1896,10 +2058,10 @@ func (s *DoltStore) Flatten(ctx context.Context) error {
 
 // Compact squashes old Dolt commits while preserving recent ones.
 // Pins a single connection for session-scoped stored procedures.
-func (s *DoltStore) Compact(ctx context.Context, initialHash, boundaryHash string, oldCommits int, recentHashes []string) error {
+func (s *DoltStore) Compact(ctx context.Context, initialHash, boundaryHash string, oldCommits int, recentHashes []string) (map[string]string, error) {
 	conn, err := s.db.Conn(ctx)
 	if err != nil {
-		return fmt.Errorf("acquire connection for compact: %w", err)
+		return nil, fmt.Errorf("acquire connection for compact: %w", err)
 	}
 	defer conn.Close()
 	return versioncontrolops.Compact(ctx, conn, initialHash, boundaryHash, oldCommits, recentHashes)
@@ -2301,7 +2463,7 @@ func (s *DoltStore) buildBatchCommitMessage(ctx context.Context, actor string) s
 // hasMatchingCLIRemote reports whether the local CLI directory contains the
 // same remote URL that SQL reports. CLI push/pull/fetch run from CLIDir, so
 // SQL visibility alone is not enough to route safely.
-func (s *DoltStore) hasMatchingCLIRemote(remote, expectedURL string) bool {
+func (s *DoltStore) hasMatchingCLIRemote(ctx context.Context, remote, expectedURL string) bool {
 	if expectedURL == "" {
 		return false
 	}
@@ -2312,7 +2474,7 @@ func (s *DoltStore) hasMatchingCLIRemote(remote, expectedURL string) bool {
 	if !s.hasCLIDatabase() {
 		return false
 	}
-	return doltutil.RemoteURLsMatch(doltutil.FindCLIRemote(cliDir, remote), expectedURL)
+	return doltutil.RemoteURLsMatch(doltutil.FindCLIRemote(ctx, cliDir, remote), expectedURL)
 }
 
 // hasCLIDatabase reports whether CLIDir points at an initialized Dolt database.
@@ -2330,8 +2492,8 @@ func (s *DoltStore) hasCLIDatabase() bool {
 // ensureMatchingCLIRemote materializes the local CLI remote needed before
 // subprocess push/pull/fetch routing. SQL remains the source of truth; the CLI
 // remote is only the local transport surface that dolt subprocesses read.
-func (s *DoltStore) ensureMatchingCLIRemote(remote, expectedURL string) error {
-	if s.hasMatchingCLIRemote(remote, expectedURL) {
+func (s *DoltStore) ensureMatchingCLIRemote(ctx context.Context, remote, expectedURL string) error {
+	if s.hasMatchingCLIRemote(ctx, remote, expectedURL) {
 		return nil
 	}
 	cliDir := s.CLIDir()
@@ -2341,10 +2503,10 @@ func (s *DoltStore) ensureMatchingCLIRemote(remote, expectedURL string) error {
 	if cliDir == "" {
 		return fmt.Errorf("remote %q (%s) requires CLI routing but no CLI directory is configured", remote, expectedURL)
 	}
-	if err := doltutil.EnsureCLIRemote(cliDir, remote, expectedURL); err != nil {
+	if err := doltutil.EnsureCLIRemote(ctx, cliDir, remote, expectedURL); err != nil {
 		return fmt.Errorf("materialize CLI remote %q (%s) in %s: %w", remote, expectedURL, cliDir, err)
 	}
-	if !s.hasMatchingCLIRemote(remote, expectedURL) {
+	if !s.hasMatchingCLIRemote(ctx, remote, expectedURL) {
 		return fmt.Errorf("materialized CLI remote %q in %s, but its URL does not match SQL URL %q", remote, cliDir, expectedURL)
 	}
 	return nil
@@ -2357,10 +2519,11 @@ func (s *DoltStore) prepareDoltCLITransfer(ctx context.Context, remote string, c
 func prepareDoltCLITransferCommand(ctx context.Context, cliDir string, creds *remoteCredentials, s3Remote bool, args ...string) (*exec.Cmd, context.Context, context.CancelFunc) {
 	ctx, cancel := withCLIExecTimeout(ctx)
 	cmd := exec.CommandContext(ctx, "dolt", args...) // #nosec G204 -- fixed command with validated remote/ref args
-	// CommandContext kills only the direct dolt child on expiry; a grandchild
+	// CommandContext signals only the direct dolt child on expiry; a grandchild
 	// (e.g. a cloud credential helper) holding the inherited output pipes
 	// would otherwise keep Wait/CombinedOutput blocked forever after the kill.
 	cmd.WaitDelay = cliExecWaitDelay
+	interruptOnCancel(cmd)
 	cmd.Dir = cliDir
 	creds.applyToCmd(cmd)
 	if s3Remote {
@@ -2387,7 +2550,7 @@ func (s *DoltStore) prepareCLIRouteForGitProtocol(ctx context.Context, remote st
 			if !doltutil.IsGitProtocolURL(r.URL) {
 				return false, nil
 			}
-			if err := s.ensureMatchingCLIRemote(remote, r.URL); err != nil {
+			if err := s.ensureMatchingCLIRemote(ctx, remote, r.URL); err != nil {
 				return false, fmt.Errorf("remote %q uses git protocol and requires CLI routing: %w", remote, err)
 			}
 			return true, nil
@@ -2615,8 +2778,12 @@ func (s *DoltStore) doltCLIPush(ctx context.Context, remote string, force bool,
 
 // cliTransferError wraps a failed CLI transfer, distinguishing a transfer that
 // hit the bounded timeout (actionable: raise BEADS_CLI_TRANSFER_TIMEOUT, or
-// check what holds the database directory busy) from an ordinary failure.
+// check what holds the database directory busy) and one the caller canceled
+// (wrapping context.Canceled) from an ordinary failure.
 func cliTransferError(op, remote string, transferCtx context.Context, out []byte, err error) error {
+	if errors.Is(transferCtx.Err(), context.Canceled) {
+		return fmt.Errorf("%s with %q interrupted: %s: %w: %w", op, remote, strings.TrimSpace(string(out)), context.Canceled, err)
+	}
 	if errors.Is(transferCtx.Err(), context.DeadlineExceeded) {
 		return fmt.Errorf("%s to %q timed out after %s (override with %s=<duration>; large transfers to cloud remotes can run long, and a busy dolt sql-server serving the database directory can stall CLI transfers): %s: %w",
 			op, remote, cliExecTimeoutDuration(), cliExecTimeoutEnv, strings.TrimSpace(string(out)), err)
@@ -2675,6 +2842,16 @@ func (s *DoltStore) pushToRemote(ctx context.Context, remote string, force bool)
 		)...),
 	)
 	defer func() { endSpan(span, retErr) }()
+	// Transient remote failures (timeouts, resets, a briefly held lock) are
+	// retried under the remote retry policy; pushes are idempotent.
+	return withRemoteRetry(ctx, "push", remote, func() error {
+		return s.pushTransport(ctx, remote, force)
+	})
+}
+
+// pushTransport routes one push attempt through CLI or SQL based on the
+// remote's protocol and credentials.
+func (s *DoltStore) pushTransport(ctx context.Context, remote string, force bool) error {
 	creds := s.credentialsForRemote(remote)
 	// Git-protocol remotes: use CLI to avoid MySQL connection timeout during transfer.
 	// Must check before remoteUser — Hosted Dolt SSH remotes have remoteUser set
@@ -2688,8 +2865,8 @@ func (s *DoltStore) pushToRemote(ctx context.Context, remote string, force bool)
 	}
 	// Credential CLI routing: when credentials are set and server is external,
 	// route through CLI subprocess so credentials reach the dolt process via
-	// cmd.Env (applyToCmd). The SQL path's withEnvCredentials sets process-wide
-	// env vars that an external server cannot see.
+	// cmd.Env (applyToCmd). The external server cannot see credentials held
+	// by this process.
 	if useCLI, err := s.prepareCLIRouteForCredentials(ctx, remote, creds); err != nil {
 		return err
 	} else if useCLI {
@@ -2709,8 +2886,10 @@ func (s *DoltStore) pushToRemote(ctx context.Context, remote string, force bool)
 	} else if useCLI {
 		return s.doltCLIPush(ctx, remote, force, creds)
 	}
+	// --user makes the sql-server read DOLT_REMOTE_PASSWORD from its own
+	// environment, which it inherits when bd auto-starts it.
 	if s.remoteUser != "" && remote == s.remote {
-		return withRemoteOperationEnv(creds, s.isS3Remote(ctx, remote), func() error {
+		return withS3ChecksumEnv(s.isS3Remote(ctx, remote), func() error {
 			if force {
 				if err := s.execWithLongTimeoutNoTx(ctx, "CALL DOLT_PUSH('--force', '--user', ?, ?, ?)", s.remoteUser, remote, s.branch); err != nil {
 					return fmt.Errorf("failed to force push to %s/%s: %w", remote, s.branch, err)
@@ -2723,7 +2902,7 @@ func (s *DoltStore) pushToRemote(ctx context.Context, remote string, force bool)
 			return nil
 		})
 	}
-	return withRemoteOperationEnv(nil, s.isS3Remote(ctx, remote), func() error {
+	return withS3ChecksumEnv(s.isS3Remote(ctx, remote), func() error {
 		if force {
 			if err := s.execWithLongTimeoutNoTx(ctx, "CALL DOLT_PUSH('--force', ?, ?)", remote, s.branch); err != nil {
 				return fmt.Errorf("failed to force push to %s/%s: %w", remote, s.branch, err)
@@ -2792,7 +2971,11 @@ func (s *DoltStore) pullFromRemote(ctx context.Context, remote string) (retErr e
 		}
 	}
 
-	if err := s.pullTransport(ctx, remote); err != nil {
+	// A pull that failed in transport merged nothing, so transient failures
+	// are retried; merge conflicts are not transient and surface at once.
+	if err := withRemoteRetry(ctx, "pull", remote, func() error {
+		return s.pullTransport(ctx, remote)
+	}); err != nil {
 		return err
 	}
 
@@ -2839,14 +3022,14 @@ func (s *DoltStore) pullTransport(ctx context.Context, remote string) error {
 	// guard is a push-only optimization; SQL pull keeps pullWithAutoResolve in
 	// charge of metadata-only conflict repair.
 	if s.remoteUser != "" && remote == s.remote {
-		return withRemoteOperationEnv(creds, s.isS3Remote(ctx, remote), func() error {
+		return withS3ChecksumEnv(s.isS3Remote(ctx, remote), func() error {
 			if err := s.pullWithAutoResolve(ctx, remote, "CALL DOLT_PULL('--user', ?, ?, ?)", s.remoteUser, remote, s.branch); err != nil {
 				return fmt.Errorf("failed to pull from %s/%s: %w", remote, s.branch, err)
 			}
 			return nil
 		})
 	}
-	return withRemoteOperationEnv(nil, s.isS3Remote(ctx, remote), func() error {
+	return withS3ChecksumEnv(s.isS3Remote(ctx, remote), func() error {
 		if err := s.pullWithAutoResolve(ctx, remote, "CALL DOLT_PULL(?, ?)", remote, s.branch); err != nil {
 			return fmt.Errorf("failed to pull from %s/%s: %w", remote, s.branch, err)
 		}
@@ -3262,11 +3445,47 @@ func (s *DoltStore) Checkout(ctx context.Context, branch string) (retErr error)
 	if err != nil {
 		return fmt.Errorf("acquire connection for checkout: %w", err)
 	}
-	defer conn.Close()
-	if err := versioncontrolops.CheckoutBranch(ctx, conn, branch); err != nil {
+	err = versioncontrolops.CheckoutBranch(ctx, conn, branch)
+	_ = conn.Close()
+	if err != nil {
 		return err
 	}
+
+	// Each pooled connection is its own server session with its own branch,
+	// so the checkout above moved only one of them. Reopen the pool with the
+	// branch pinned in the DSN: the driver sets @@<db>_head_ref on every new
+	// connection, so all queries after this one read and write the branch.
+	parsed, err := mysql.ParseDSN(s.connStr)
+	if err != nil {
+		return fmt.Errorf("parse DSN for checkout: %w", err)
+	}
+	if parsed.Params == nil {
+		parsed.Params = make(map[string]string)
+	}
+	parsed.Params["@@"+s.database+"_head_ref"] = "'" + strings.ReplaceAll(branch, "'", "''") + "'"
+	connStr := parsed.FormatDSN()
+	db, err := sql.Open("mysql", connStr)
+	if err != nil {
+		return fmt.Errorf("reopen connection pool on %s: %w", branch, err)
+	}
+	limits := s.poolConfig
+	if limits == nil {
+		limits = &Config{}
+	}
+	applyPoolLimits(db, limits)
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return fmt.Errorf("reopen connection pool on %s: %w", branch, err)
+	}
+	s.mu.Lock()
+	old, oldStmts := s.db, s.stmts
+	s.db = db
+	s.stmts = newStmtCache(db, stmtCacheSize(limits))
+	s.connStr = connStr
 	s.branch = branch
+	s.mu.Unlock()
+	oldStmts.close()
+	_ = old.Close()
 	return nil
 }
 
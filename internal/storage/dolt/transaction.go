@@ -14,6 +14,7 @@ import (
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/domain"
 	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/storage/sqlbuild"
 	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -56,6 +57,7 @@ func (t *doltTransaction) isActiveWisp(ctx context.Context, id string) bool {
 // CreateIssueImport is the import-friendly issue creation hook.
 // Dolt does not enforce prefix validation at the storage layer, so this delegates to CreateIssue.
 func (t *doltTransaction) CreateIssueImport(ctx context.Context, issue *types.Issue, actor string, skipPrefixValidation bool) error {
+	ctx = t.store.opContext(ctx)
 	return t.CreateIssue(ctx, issue, actor)
 }
 
@@ -64,6 +66,7 @@ func (t *doltTransaction) CreateIssueImport(ctx context.Context, issue *types.Is
 // making the write atomically visible in Dolt's version history.
 // Wisp routing is handled within individual transaction methods based on ID/Ephemeral flag.
 func (s *DoltStore) RunInTransaction(ctx context.Context, commitMsg string, fn func(tx storage.Transaction) error) error {
+	defer s.observeQuery(ctx, time.Now())
 	return s.withRetry(ctx, func() error {
 		return s.runDoltTransaction(ctx, commitMsg, fn)
 	})
@@ -193,6 +196,7 @@ func isDoltNothingToCommit(err error) bool {
 // CreateIssue creates an issue within the transaction.
 // Routes ephemeral issues to the wisps table.
 func (t *doltTransaction) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	ctx = t.store.opContext(ctx)
 	if issue == nil {
 		return fmt.Errorf("issue must not be nil")
 	}
@@ -222,6 +226,7 @@ func (t *doltTransaction) CreateIssue(ctx context.Context, issue *types.Issue, a
 
 // CreateIssues creates multiple issues within the transaction
 func (t *doltTransaction) CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error {
+	ctx = t.store.opContext(ctx)
 	if len(issues) == 0 {
 		return nil
 	}
@@ -269,6 +274,7 @@ func (t *doltTransaction) CreateIssues(ctx context.Context, issues []*types.Issu
 // GetIssue retrieves an issue within the transaction.
 // Checks wisps table for active wisps (including explicit-ID ephemerals).
 func (t *doltTransaction) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	ctx = t.store.opContext(ctx)
 	table := "issues"
 	if t.isActiveWisp(ctx, id) {
 		table = "wisps"
@@ -282,6 +288,7 @@ func (t *doltTransaction) GetIssue(ctx context.Context, id string) (*types.Issue
 // regularTx/ignoredTx (see txFor). Not worth re-implementing: partial-ID
 // resolution calls the (fast) store path, never a transaction, so this is cold.
 func (t *doltTransaction) SearchIssueIDs(ctx context.Context, query string, filter types.IssueFilter) ([]string, error) {
+	ctx = t.store.opContext(ctx)
 	issues, err := t.SearchIssues(ctx, query, filter)
 	if err != nil {
 		return nil, err
@@ -296,6 +303,7 @@ func (t *doltTransaction) SearchIssueIDs(ctx context.Context, query string, filt
 // SearchIssues searches for issues within the transaction.
 // Supports the same filter fields as DoltStore.SearchIssues (bd-v6v8).
 func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	ctx = t.store.opContext(ctx)
 	table := "issues"
 	if filter.Ephemeral != nil && *filter.Ephemeral {
 		table = "wisps"
@@ -345,6 +353,14 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 		whereClauses = append(whereClauses, "LOWER(notes) LIKE ?")
 		args = append(args, "%"+strings.ToLower(filter.NotesContains)+"%")
 	}
+	if filter.DesignContains != "" {
+		whereClauses = append(whereClauses, "LOWER(design) LIKE ?")
+		args = append(args, "%"+strings.ToLower(filter.DesignContains)+"%")
+	}
+	if filter.AcceptanceContains != "" {
+		whereClauses = append(whereClauses, "LOWER(acceptance_criteria) LIKE ?")
+		args = append(args, "%"+strings.ToLower(filter.AcceptanceContains)+"%")
+	}
 	if filter.ExternalRefContains != "" {
 		whereClauses = append(whereClauses, "LOWER(external_ref) LIKE ?")
 		args = append(args, "%"+strings.ToLower(filter.ExternalRefContains)+"%")
@@ -450,6 +466,15 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 	if filter.EmptyDescription {
 		whereClauses = append(whereClauses, "(description IS NULL OR description = '')")
 	}
+	if filter.NoDesign {
+		whereClauses = append(whereClauses, "(design IS NULL OR design = '')")
+	}
+	if filter.NoAcceptanceCriteria {
+		whereClauses = append(whereClauses, "(acceptance_criteria IS NULL OR acceptance_criteria = '')")
+	}
+	if filter.NoNotes {
+		whereClauses = append(whereClauses, "(notes IS NULL OR notes = '')")
+	}
 	if filter.NoAssignee {
 		whereClauses = append(whereClauses, "(assignee IS NULL OR assignee = '')")
 	}
@@ -555,6 +580,26 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 		whereClauses = append(whereClauses, "wisp_type = ?")
 		args = append(args, string(*filter.WispType))
 	}
+	if filter.SourceFormula != "" {
+		whereClauses = append(whereClauses, "source_formula = ?")
+		args = append(args, filter.SourceFormula)
+	}
+	if filter.Rig != "" {
+		whereClauses = append(whereClauses, "rig = ?")
+		args = append(args, filter.Rig)
+	}
+	if filter.EventKind != "" {
+		whereClauses = append(whereClauses, "event_kind = ?")
+		args = append(args, filter.EventKind)
+	}
+	if filter.EventActor != "" {
+		whereClauses = append(whereClauses, "actor = ?")
+		args = append(args, filter.EventActor)
+	}
+	if filter.EventTarget != "" {
+		whereClauses = append(whereClauses, "target = ?")
+		args = append(args, filter.EventTarget)
+	}
 
 	// Time-based scheduling filters
 	if filter.Deferred {
@@ -563,7 +608,7 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 	}
 	if filter.Overdue {
 		whereClauses = append(whereClauses, "due_at IS NOT NULL AND due_at < ? AND status != ?")
-		args = append(args, time.Now().UTC().Format(time.RFC3339), types.StatusClosed)
+		args = append(args, storage.Now(ctx).Format(time.RFC3339), types.StatusClosed)
 	}
 
 	// Metadata existence check
@@ -590,6 +635,14 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 			args = append(args, storage.JSONMetadataPath(k), filter.MetadataFields[k])
 		}
 	}
+	if filter.MinQualityScore != nil {
+		whereClauses = append(whereClauses, sqlbuild.MinQualityScoreClause)
+		args = append(args, *filter.MinQualityScore)
+	}
+	if filter.MinReopenCount > 0 {
+		whereClauses = append(whereClauses, sqlbuild.MinReopenCountClause)
+		args = append(args, filter.MinReopenCount)
+	}
 
 	whereSQL := ""
 	if len(whereClauses) > 0 {
@@ -636,6 +689,7 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 }
 
 func (t *doltTransaction) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	ctx = t.store.opContext(ctx)
 	table := "issues"
 	if t.isActiveWisp(ctx, id) {
 		table = "wisps"
@@ -659,6 +713,7 @@ func (t *doltTransaction) UpdateIssue(ctx context.Context, id string, updates ma
 }
 
 func (t *doltTransaction) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
+	ctx = t.store.opContext(ctx)
 	table := "issues"
 	eventTable := "events"
 	if t.isActiveWisp(ctx, id) {
@@ -679,6 +734,7 @@ func (t *doltTransaction) CloseIssue(ctx context.Context, id string, reason stri
 }
 
 func (t *doltTransaction) DeleteIssue(ctx context.Context, id string) error {
+	ctx = t.store.opContext(ctx)
 	table := "issues"
 	if t.isActiveWisp(ctx, id) {
 		table = "wisps"
@@ -693,10 +749,12 @@ func (t *doltTransaction) DeleteIssue(ctx context.Context, id string) error {
 // AddDependency adds a dependency within the transaction.
 // Checks for existing pairs to prevent silent type overwrites.
 func (t *doltTransaction) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	ctx = t.store.opContext(ctx)
 	return t.AddDependencyWithOptions(ctx, dep, actor, storage.DependencyAddOptions{})
 }
 
 func (t *doltTransaction) AddDependencyWithOptions(ctx context.Context, dep *types.Dependency, actor string, addOpts storage.DependencyAddOptions) error {
+	ctx = t.store.opContext(ctx)
 	table := "dependencies"
 	sourceTable := "issues"
 	eventTable := "events"
@@ -871,6 +929,7 @@ func (t *doltTransaction) checkCrossTierSchedulingCycle(ctx context.Context, dep
 // previous DetectCycles ran only on the regular tx and let bulk wisp edges
 // commit scheduling cycles (bd-578h9.9).
 func (t *doltTransaction) CycleThroughEdges(ctx context.Context, edges [][2]string) (string, error) {
+	ctx = t.store.opContext(ctx)
 	graph := make(map[string][]string)
 	if err := issueops.AppendSchedulingGraphInTx(ctx, t.txFor("dependencies"), []string{"dependencies"}, graph); err != nil {
 		return "", err
@@ -882,6 +941,7 @@ func (t *doltTransaction) CycleThroughEdges(ctx context.Context, edges [][2]stri
 }
 
 func (t *doltTransaction) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
+	ctx = t.store.opContext(ctx)
 	table := "dependencies"
 	if t.isActiveWisp(ctx, issueID) {
 		table = "wisp_dependencies"
@@ -918,10 +978,12 @@ func (t *doltTransaction) GetDependencyRecords(ctx context.Context, issueID stri
 }
 
 func (t *doltTransaction) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	ctx = t.store.opContext(ctx)
 	return t.RemoveDependencyWithOptions(ctx, issueID, dependsOnID, actor, storage.DependencyRemoveOptions{})
 }
 
 func (t *doltTransaction) RemoveDependencyWithOptions(ctx context.Context, issueID, dependsOnID string, actor string, rmOpts storage.DependencyRemoveOptions) error {
+	ctx = t.store.opContext(ctx)
 	table := "dependencies"
 	eventTable := "events"
 	if t.isActiveWisp(ctx, issueID) {
@@ -945,6 +1007,7 @@ func (t *doltTransaction) RemoveDependencyWithOptions(ctx context.Context, issue
 
 // AddLabel adds a label within the transaction
 func (t *doltTransaction) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	ctx = t.store.opContext(ctx)
 	table := "labels"
 	eventTable := "events"
 	if t.isActiveWisp(ctx, issueID) {
@@ -961,6 +1024,7 @@ func (t *doltTransaction) AddLabel(ctx context.Context, issueID, label, actor st
 }
 
 func (t *doltTransaction) GetLabels(ctx context.Context, issueID string) ([]string, error) {
+	ctx = t.store.opContext(ctx)
 	table := "labels"
 	if t.isActiveWisp(ctx, issueID) {
 		table = "wisp_labels"
@@ -985,6 +1049,7 @@ func (t *doltTransaction) GetLabels(ctx context.Context, issueID string) ([]stri
 
 // RemoveLabel removes a label within the transaction
 func (t *doltTransaction) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	ctx = t.store.opContext(ctx)
 	table := "labels"
 	eventTable := "events"
 	if t.isActiveWisp(ctx, issueID) {
@@ -1002,6 +1067,7 @@ func (t *doltTransaction) RemoveLabel(ctx context.Context, issueID, label, actor
 
 // SetConfig sets a config value within the transaction
 func (t *doltTransaction) SetConfig(ctx context.Context, key, value string) error {
+	ctx = t.store.opContext(ctx)
 	_, err := t.regularTx.ExecContext(ctx, `
 		INSERT INTO config (`+"`key`"+`, value) VALUES (?, ?)
 		ON DUPLICATE KEY UPDATE value = VALUES(value)
@@ -1014,6 +1080,7 @@ func (t *doltTransaction) SetConfig(ctx context.Context, key, value string) erro
 
 // GetConfig gets a config value within the transaction
 func (t *doltTransaction) GetConfig(ctx context.Context, key string) (string, error) {
+	ctx = t.store.opContext(ctx)
 	var value string
 	err := t.regularTx.QueryRowContext(ctx, "SELECT value FROM config WHERE `key` = ?", key).Scan(&value)
 	if err == sql.ErrNoRows {
@@ -1024,6 +1091,7 @@ func (t *doltTransaction) GetConfig(ctx context.Context, key string) (string, er
 
 // SetMetadata sets a metadata value within the transaction
 func (t *doltTransaction) SetMetadata(ctx context.Context, key, value string) error {
+	ctx = t.store.opContext(ctx)
 	_, err := t.regularTx.ExecContext(ctx, `
 		INSERT INTO metadata (`+"`key`"+`, value) VALUES (?, ?)
 		ON DUPLICATE KEY UPDATE value = VALUES(value)
@@ -1036,6 +1104,7 @@ func (t *doltTransaction) SetMetadata(ctx context.Context, key, value string) er
 
 // GetMetadata gets a metadata value within the transaction
 func (t *doltTransaction) GetMetadata(ctx context.Context, key string) (string, error) {
+	ctx = t.store.opContext(ctx)
 	var value string
 	err := t.regularTx.QueryRowContext(ctx, "SELECT value FROM metadata WHERE `key` = ?", key).Scan(&value)
 	if err == sql.ErrNoRows {
@@ -1046,12 +1115,14 @@ func (t *doltTransaction) GetMetadata(ctx context.Context, key string) (string,
 
 // SetLocalMetadata sets a value in the dolt-ignored local_metadata table within the transaction.
 func (t *doltTransaction) SetLocalMetadata(ctx context.Context, key, value string) error {
+	ctx = t.store.opContext(ctx)
 	_, err := t.ignoredTx.ExecContext(ctx, "REPLACE INTO local_metadata (`key`, value) VALUES (?, ?)", key, value)
 	return wrapExecError("set local metadata in tx", err)
 }
 
 // GetLocalMetadata gets a value from the dolt-ignored local_metadata table within the transaction.
 func (t *doltTransaction) GetLocalMetadata(ctx context.Context, key string) (string, error) {
+	ctx = t.store.opContext(ctx)
 	var value string
 	err := t.ignoredTx.QueryRowContext(ctx, "SELECT value FROM local_metadata WHERE `key` = ?", key).Scan(&value)
 	if err == sql.ErrNoRows {
@@ -1061,6 +1132,7 @@ func (t *doltTransaction) GetLocalMetadata(ctx context.Context, key string) (str
 }
 
 func (t *doltTransaction) ImportIssueComment(ctx context.Context, issueID, author, text string, createdAt time.Time) (*types.Comment, error) {
+	ctx = t.store.opContext(ctx)
 	_, err := t.GetIssue(ctx, issueID)
 	if err != nil {
 		return nil, err
@@ -1087,6 +1159,7 @@ func (t *doltTransaction) ImportIssueComment(ctx context.Context, issueID, autho
 }
 
 func (t *doltTransaction) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
+	ctx = t.store.opContext(ctx)
 	table := "comments"
 	if t.isActiveWisp(ctx, issueID) {
 		table = "wisp_comments"
@@ -1116,6 +1189,7 @@ func (t *doltTransaction) GetIssueComments(ctx context.Context, issueID string)
 
 // AddComment adds a comment within the transaction
 func (t *doltTransaction) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	ctx = t.store.opContext(ctx)
 	table := "events"
 	if t.isActiveWisp(ctx, issueID) {
 		table = "wisp_events"
@@ -1139,6 +1213,7 @@ func (t *doltTransaction) AddComment(ctx context.Context, issueID, actor, commen
 // THIS uncommitted transaction is visible (durable rows live on regularTx, wisp
 // rows on ignoredTx — see the struct comment on the two-session split).
 func (t *doltTransaction) GetIssueCommentsPage(ctx context.Context, issueID string, after storage.CommentPageCursor, limit int) ([]*types.Comment, error) {
+	ctx = t.store.opContext(ctx)
 	tx := t.regularTx
 	if t.isActiveWisp(ctx, issueID) {
 		tx = t.ignoredTx
@@ -1158,6 +1233,7 @@ func (t *doltTransaction) GetIssueCommentsPage(ctx context.Context, issueID stri
 // committed wisps exist. The embedded backend has no session split and sees
 // in-tx wisps here.
 func (t *doltTransaction) CountIssuesByGroup(ctx context.Context, filter types.IssueFilter, groupBy string) (map[string]int, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.CountIssuesByGroupInTx(ctx, t.regularTx, filter, groupBy)
 }
 
@@ -1172,6 +1248,7 @@ func (t *doltTransaction) CountIssuesByGroup(ctx context.Context, filter types.I
 // transaction (those live on the ignored session and become visible after
 // commit). The embedded backend has no session split and sees in-tx wisp edges.
 func (t *doltTransaction) GetDependentRecords(ctx context.Context, targetID string, depType string, limit int, afterID string) ([]*types.Dependency, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.GetDependentRecordsInTx(ctx, t.regularTx, targetID, depType, limit, afterID)
 }
 
@@ -1181,6 +1258,7 @@ func (t *doltTransaction) GetDependentRecords(ctx context.Context, targetID stri
 // server backend; wisp edges written in this same transaction are visible after
 // commit. The embedded backend sees in-tx wisp edges.
 func (t *doltTransaction) GetDependentRecordsForIssues(ctx context.Context, targetIDs []string) (map[string][]*types.Dependency, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.GetDependentRecordsForIssuesInTx(ctx, t.regularTx, targetIDs)
 }
 
@@ -1190,6 +1268,7 @@ func (t *doltTransaction) GetDependentRecordsForIssues(ctx context.Context, targ
 // server backend it excludes wisp edges written in this same uncommitted
 // transaction (visible after commit). The embedded backend sees them.
 func (t *doltTransaction) CountDependentRecords(ctx context.Context, targetID string, depType string) (int, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.CountDependentRecordsInTx(ctx, t.regularTx, targetID, depType)
 }
 
@@ -1199,6 +1278,7 @@ func (t *doltTransaction) CountDependentRecords(ctx context.Context, targetID st
 // is_blocked flag and blocker edges written for issueID earlier in THIS
 // uncommitted transaction are visible on either tier.
 func (t *doltTransaction) IsBlocked(ctx context.Context, issueID string) (bool, []string, error) {
+	ctx = t.store.opContext(ctx)
 	tx := t.regularTx
 	if t.isActiveWisp(ctx, issueID) {
 		tx = t.ignoredTx
@@ -1214,6 +1294,7 @@ func (t *doltTransaction) IsBlocked(ctx context.Context, issueID string) (bool,
 // is_blocked on its owning session, then merges. Every id therefore reflects the
 // flag written earlier in THIS uncommitted transaction, on either tier.
 func (t *doltTransaction) IsBlockedBatch(ctx context.Context, ids []string) (map[string]bool, error) {
+	ctx = t.store.opContext(ctx)
 	if len(ids) == 0 {
 		return map[string]bool{}, nil
 	}
@@ -1249,5 +1330,6 @@ func (t *doltTransaction) IsBlockedBatch(ctx context.Context, ids []string) (map
 // land on regularTx, so an event recorded earlier in THIS uncommitted
 // transaction is visible.
 func (t *doltTransaction) EventsSince(ctx context.Context, cursor storage.EventCursor, issueID string, limit int) ([]*types.Event, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.EventsSinceInTx(ctx, t.regularTx, cursor.CreatedAt, cursor.ID, issueID, limit)
 }
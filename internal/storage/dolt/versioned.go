@@ -15,7 +15,7 @@ import (
 // History returns the complete version history for an issue.
 func (s *DoltStore) History(ctx context.Context, issueID string) ([]*storage.HistoryEntry, error) {
 	var result []*storage.HistoryEntry
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.HistoryInTx(ctx, tx, issueID)
 		if err != nil {
@@ -26,6 +26,51 @@ func (s *DoltStore) History(ctx context.Context, issueID string) ([]*storage.His
 	return result, err
 }
 
+// GetIssueHistory returns the commits that changed an issue, field by field.
+// Implements storage.IssueHistoryReader.
+func (s *DoltStore) GetIssueHistory(ctx context.Context, issueID string) ([]*storage.IssueChange, error) {
+	var result []*storage.IssueChange
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueHistoryInTx(ctx, tx, issueID)
+		if err != nil {
+			return wrapQueryError("get issue history", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// GetIssueBlame returns, for each field of an issue, the commit that last
+// changed it. Implements storage.IssueBlamer.
+func (s *DoltStore) GetIssueBlame(ctx context.Context, issueID string) ([]*storage.FieldBlame, error) {
+	var result []*storage.FieldBlame
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueBlameInTx(ctx, tx, issueID)
+		if err != nil {
+			return wrapQueryError("get issue blame", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// PendingChanges summarizes the uncommitted writes in the working set.
+// Implements storage.PendingChangesReader.
+func (s *DoltStore) PendingChanges(ctx context.Context) (*storage.PendingChanges, error) {
+	var result *storage.PendingChanges
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.PendingChangesInTx(ctx, tx)
+		if err != nil {
+			return wrapQueryError("pending changes", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
 // AsOf returns the state of an issue at a specific commit hash or branch ref.
 // Implements storage.VersionedStorage.
 func (s *DoltStore) AsOf(ctx context.Context, issueID string, ref string) (*types.Issue, error) {
@@ -36,7 +81,7 @@ func (s *DoltStore) AsOf(ctx context.Context, issueID string, ref string) (*type
 // Implements storage.VersionedStorage.
 func (s *DoltStore) Diff(ctx context.Context, fromRef, toRef string) ([]*storage.DiffEntry, error) {
 	var result []*storage.DiffEntry
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.DiffInTx(ctx, tx, fromRef, toRef)
 		return err
@@ -44,13 +89,37 @@ func (s *DoltStore) Diff(ctx context.Context, fromRef, toRef string) ([]*storage
 	return result, err
 }
 
+// DiffDependencies returns dependency edges changed between two refs.
+// Implements storage.GraphDiffer.
+func (s *DoltStore) DiffDependencies(ctx context.Context, fromRef, toRef string) ([]*storage.DependencyDiffEntry, error) {
+	var result []*storage.DependencyDiffEntry
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.DependencyDiffInTx(ctx, tx, fromRef, toRef)
+		return err
+	})
+	return result, err
+}
+
+// DiffLabels returns labels changed between two refs.
+// Implements storage.GraphDiffer.
+func (s *DoltStore) DiffLabels(ctx context.Context, fromRef, toRef string) ([]*storage.LabelDiffEntry, error) {
+	var result []*storage.LabelDiffEntry
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.LabelDiffInTx(ctx, tx, fromRef, toRef)
+		return err
+	})
+	return result, err
+}
+
 // PreviousExternalRef returns the external_ref value recorded for issueID
 // as of the most recent commit at or before asOf.
 // Implements storage.ExternalRefHistoryQuerier.
 func (s *DoltStore) PreviousExternalRef(ctx context.Context, issueID string, asOf time.Time) (string, bool, error) {
 	var ref string
 	var found bool
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		ref, found, err = issueops.PreviousExternalRefInTx(ctx, tx, issueID, asOf)
 		if err != nil {
@@ -67,6 +136,41 @@ func (s *DoltStore) ListBranches(ctx context.Context) ([]string, error) {
 	return versioncontrolops.ListBranches(ctx, s.db)
 }
 
+// CreateCheckpoint tags HEAD as name. Implements storage.Checkpointer.
+func (s *DoltStore) CreateCheckpoint(ctx context.Context, name, message, actor string) (*storage.Checkpoint, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection for checkpoint: %w", err)
+	}
+	defer conn.Close()
+	if err := versioncontrolops.CreateTag(ctx, conn, name, "HEAD", message, fmt.Sprintf("%s <%s>", actor, s.committerEmail)); err != nil {
+		return nil, err
+	}
+	return versioncontrolops.GetTag(ctx, conn, name)
+}
+
+// BranchAt creates branch at ref. Implements storage.Checkpointer.
+func (s *DoltStore) BranchAt(ctx context.Context, branch, ref string) error {
+	return versioncontrolops.CreateBranchAt(ctx, s.db, branch, ref)
+}
+
+// MoveCheckpoint re-points checkpoint c at hash. Implements
+// storage.Checkpointer.
+func (s *DoltStore) MoveCheckpoint(ctx context.Context, c storage.Checkpoint, hash string) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection for checkpoint: %w", err)
+	}
+	defer conn.Close()
+	return versioncontrolops.MoveTag(ctx, conn, c, hash)
+}
+
+// ListCheckpoints returns every tag, newest first. Implements
+// storage.Checkpointer.
+func (s *DoltStore) ListCheckpoints(ctx context.Context) ([]storage.Checkpoint, error) {
+	return versioncontrolops.ListTagsWithInfo(ctx, s.db)
+}
+
 // GetCurrentCommit returns the hash of the current HEAD commit.
 // Implements storage.VersionedStorage.
 func (s *DoltStore) GetCurrentCommit(ctx context.Context) (string, error) {
@@ -55,7 +55,7 @@ func createWisp(t *testing.T, ctx context.Context, store *DoltStore, id string)
 // partitionInTx runs PartitionWispIDsInTx inside a store read transaction.
 func partitionInTx(t *testing.T, ctx context.Context, store *DoltStore, ids []string) (wispIDs, permIDs []string) {
 	t.Helper()
-	err := store.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := store.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		wispIDs, permIDs, err = issueops.PartitionWispIDsInTx(ctx, tx, ids)
 		return err
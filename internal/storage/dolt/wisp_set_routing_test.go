@@ -1,6 +1,7 @@
 package dolt
 
 import (
+	"context"
 	"database/sql"
 	"reflect"
 	"sort"
@@ -64,7 +65,7 @@ func TestWispIDSetInTx_HardGate(t *testing.T) {
 	// Run assertions inside one read tx so the WispIDSetInTx result is
 	// visible alongside the partitioned reads.
 	ids := []string{perm.ID, wisp.ID}
-	if err := store.withReadTx(ctx, func(tx *sql.Tx) error {
+	if err := store.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		// WispIDSetInTx should contain only the wisp ID, not the perm.
 		set, err := issueops.WispIDSetInTx(ctx, tx, ids)
 		if err != nil {
@@ -142,7 +143,7 @@ func TestWispIDSetInTx_Empty(t *testing.T) {
 	ctx, cancel := testContext(t)
 	defer cancel()
 
-	if err := store.withReadTx(ctx, func(tx *sql.Tx) error {
+	if err := store.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		labelMap, err := issueops.GetLabelsForIssuesInTx(ctx, tx, nil, nil)
 		if err != nil {
 			t.Fatalf("GetLabelsForIssuesInTx empty: %v", err)
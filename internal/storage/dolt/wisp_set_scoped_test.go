@@ -1,6 +1,7 @@
 package dolt
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -44,7 +45,7 @@ func TestWispIDSetInTx_Scoped_ReturnsOnlyInputIDs(t *testing.T) {
 
 	target := wispIDs[2]
 
-	if err := store.withReadTx(ctx, func(tx *sql.Tx) error {
+	if err := store.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		set, err := issueops.WispIDSetInTx(ctx, tx, []string{target})
 		if err != nil {
 			return fmt.Errorf("WispIDSetInTx: %w", err)
@@ -96,7 +97,7 @@ func TestWispIDSetInTx_Scoped_AcrossBatchBoundary(t *testing.T) {
 		wispIDs = append(wispIDs, iss.ID)
 	}
 
-	if err := store.withReadTx(ctx, func(tx *sql.Tx) error {
+	if err := store.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		set, err := issueops.WispIDSetInTx(ctx, tx, wispIDs)
 		if err != nil {
 			return fmt.Errorf("WispIDSetInTx: %w", err)
@@ -137,7 +138,7 @@ func TestWispIDSetInTx_Scoped_UnknownIDsReturnEmpty(t *testing.T) {
 		t.Fatalf("create bait: %v", err)
 	}
 
-	if err := store.withReadTx(ctx, func(tx *sql.Tx) error {
+	if err := store.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		set, err := issueops.WispIDSetInTx(ctx, tx, []string{"nonexistent-a", "nonexistent-b"})
 		if err != nil {
 			return fmt.Errorf("WispIDSetInTx: %w", err)
@@ -220,7 +221,7 @@ func TestGetLabelsForIssuesInTx_SmallInputLargeWispTable(t *testing.T) {
 
 	input := []string{perm.ID, target.ID}
 
-	if err := store.withReadTx(ctx, func(tx *sql.Tx) error {
+	if err := store.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		// Nil wispSet exercises the internal scoped build on input IDs.
 		labelMap, err := issueops.GetLabelsForIssuesInTx(ctx, tx, input, nil)
 		if err != nil {
@@ -293,7 +294,7 @@ func TestGetIssuesByIDsInTx_SmallInputLargeWispTable(t *testing.T) {
 
 	input := []string{perm.ID, target.ID}
 
-	if err := store.withReadTx(ctx, func(tx *sql.Tx) error {
+	if err := store.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		issues, err := issueops.GetIssuesByIDsInTx(ctx, tx, input, nil)
 		if err != nil {
 			return fmt.Errorf("GetIssuesByIDsInTx: %w", err)
@@ -68,7 +68,7 @@ func generateIssueIDInTable(ctx context.Context, tx *sql.Tx, table, prefix strin
 
 	for length := baseLength; length <= maxLength; length++ {
 		for nonce := 0; nonce < 10; nonce++ {
-			candidate := generateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+			candidate := storage.GenerateIssueID(ctx, prefix, issue, actor, length, nonce)
 
 			var count int
 			err = tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE id = ?`, table), candidate).Scan(&count) //nolint:gosec // G201
@@ -186,7 +186,7 @@ func (s *DoltStore) getWispLabels(ctx context.Context, issueID string) ([]string
 // Delegates SQL work to issueops.UpdateIssueInTx; no Dolt versioning needed
 // since wisps live in dolt_ignored tables.
 func (s *DoltStore) updateWisp(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -208,7 +208,7 @@ func (s *DoltStore) updateWisp(ctx context.Context, id string, updates map[strin
 // write path — do not add one here); wisps live in dolt_ignored tables, so there
 // is no DOLT_COMMIT.
 func (s *DoltStore) updateWispChecked(ctx context.Context, id string, updates map[string]interface{}, actor string, expectedVersion *int64) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -230,7 +230,7 @@ func (s *DoltStore) updateWispChecked(ctx context.Context, id string, updates ma
 // Delegates SQL work to issueops.CloseIssueInTx; no Dolt versioning needed
 // since wisps live in dolt_ignored tables.
 func (s *DoltStore) closeWisp(ctx context.Context, id string, reason string, actor string, session string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -260,7 +260,7 @@ func (s *DoltStore) closeWisp(ctx context.Context, id string, reason string, act
 // rather than ErrVersionMismatch. Either way atomicity holds: no lost update and
 // no stale close.
 func (s *DoltStore) closeWispChecked(ctx context.Context, id string, actor string, opts storage.CloseIssueOptions) (storage.CloseIssueResult, error) {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return storage.CloseIssueResult{}, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -270,6 +270,11 @@ func (s *DoltStore) closeWispChecked(ctx context.Context, id string, actor strin
 	if err != nil {
 		return storage.CloseIssueResult{}, err
 	}
+	if opts.DuplicateOf != "" {
+		if err := issueops.LinkDuplicateInTx(ctx, tx, id, opts.DuplicateOf, actor); err != nil {
+			return storage.CloseIssueResult{}, err
+		}
+	}
 
 	if err := wrapTransactionError("commit close wisp", tx.Commit()); err != nil {
 		return storage.CloseIssueResult{}, err
@@ -279,7 +284,7 @@ func (s *DoltStore) closeWispChecked(ctx context.Context, id string, actor strin
 
 // deleteWisp permanently removes a wisp and its related data.
 func (s *DoltStore) deleteWisp(ctx context.Context, id string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -352,7 +357,7 @@ func (s *DoltStore) deleteWispBatch(ctx context.Context, ids []string) (int, err
 // Keeping each transaction to ≤200 wisps (6 DELETE statements) ensures it
 // completes well within Dolt's 10 s write timeout.
 func (s *DoltStore) deleteWispBatchTx(ctx context.Context, ids []string) (int, error) {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -393,7 +398,7 @@ func (s *DoltStore) deleteWispBatchTx(ctx context.Context, ids []string) (int, e
 // Delegates SQL work to issueops.ClaimIssueInTx; no Dolt versioning needed
 // since wisps live in dolt_ignored tables.
 func (s *DoltStore) claimWisp(ctx context.Context, id string, actor string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -418,7 +423,7 @@ func (s *DoltStore) searchWisps(ctx context.Context, query string, filter types.
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	whereClauses, args, err := buildIssueFilterClauses(query, filter, wispsFilterTables)
+	whereClauses, args, err := buildIssueFilterClauses(ctx, query, filter, wispsFilterTables)
 	if err != nil {
 		return nil, err
 	}
@@ -579,7 +584,7 @@ func (s *DoltStore) getWispsByIDs(ctx context.Context, ids []string) ([]*types.I
 
 // addWispDependency adds a dependency to the wisp_dependencies table.
 func (s *DoltStore) addWispDependency(ctx context.Context, dep *types.Dependency, actor string, isCrossPrefix, emitEvent bool) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, tx, err := s.beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -724,7 +729,7 @@ func (s *DoltStore) getWispDependenciesWithMetadata(ctx context.Context, issueID
 // input IDs). Capped at maxRecursiveResults to prevent runaway traversal.
 func (s *DoltStore) FindWispDependentsRecursive(ctx context.Context, ids []string) (map[string]bool, error) {
 	var result map[string]bool
-	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+	err := s.withReadTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.FindWispDependentsRecursiveInTx(ctx, tx, ids)
 		return err
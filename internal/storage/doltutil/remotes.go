@@ -1,6 +1,7 @@
 package doltutil
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -82,8 +83,9 @@ func PersistedRemotes(dbPath string) ([]storage.RemoteInfo, error) {
 // ListCLIRemotes parses `dolt remote -v` output from the given database
 // directory. This is a read-only guard for deciding whether CLI push/pull/fetch
 // can safely run from that directory; remote mutation still goes through SQL.
-func ListCLIRemotes(dbPath string) ([]storage.RemoteInfo, error) {
-	cmd := exec.Command("dolt", "remote", "-v") // #nosec G204 -- fixed command
+// Canceling ctx kills the dolt subprocess.
+func ListCLIRemotes(ctx context.Context, dbPath string) ([]storage.RemoteInfo, error) {
+	cmd := exec.CommandContext(ctx, "dolt", "remote", "-v") // #nosec G204 -- fixed command
 	cmd.Dir = dbPath
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -120,14 +122,14 @@ func RemoteURLsMatch(got, want string) bool {
 // AddCLIRemote adds a remote at the filesystem level via dolt CLI.
 // Remote mutation should normally go through SQL; this is reserved for the
 // local CLI mirror required by subprocess push/pull/fetch routing.
-func AddCLIRemote(dbPath, name, url string) error {
+func AddCLIRemote(ctx context.Context, dbPath, name, url string) error {
 	if err := remotecache.ValidateRemoteName(name); err != nil {
 		return fmt.Errorf("invalid remote name: %w", err)
 	}
 	if err := remotecache.ValidateRemoteURL(url); err != nil {
 		return fmt.Errorf("invalid remote URL: %w", err)
 	}
-	cmd := exec.Command("dolt", "remote", "add", name, url) // #nosec G204 -- validated argv
+	cmd := exec.CommandContext(ctx, "dolt", "remote", "add", name, url) // #nosec G204 -- validated argv
 	cmd.Dir = dbPath
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -137,11 +139,11 @@ func AddCLIRemote(dbPath, name, url string) error {
 }
 
 // RemoveCLIRemote removes a remote at the filesystem level via dolt CLI.
-func RemoveCLIRemote(dbPath, name string) error {
+func RemoveCLIRemote(ctx context.Context, dbPath, name string) error {
 	if err := remotecache.ValidateRemoteName(name); err != nil {
 		return fmt.Errorf("invalid remote name: %w", err)
 	}
-	cmd := exec.Command("dolt", "remote", "remove", name) // #nosec G204 -- validated argv
+	cmd := exec.CommandContext(ctx, "dolt", "remote", "remove", name) // #nosec G204 -- validated argv
 	cmd.Dir = dbPath
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -152,8 +154,8 @@ func RemoveCLIRemote(dbPath, name string) error {
 
 // FindCLIRemote returns the URL for a named remote in dbPath, or "" when the
 // directory cannot be inspected or the remote is absent.
-func FindCLIRemote(dbPath, name string) string {
-	remotes, err := ListCLIRemotes(dbPath)
+func FindCLIRemote(ctx context.Context, dbPath, name string) string {
+	remotes, err := ListCLIRemotes(ctx, dbPath)
 	if err != nil {
 		return ""
 	}
@@ -167,8 +169,10 @@ func FindCLIRemote(dbPath, name string) string {
 
 // EnsureCLIRemote makes the local CLI remote match the SQL-visible remote URL.
 // It is intentionally idempotent and only mutates the CLI surface when the
-// remote is absent or points somewhere else.
-func EnsureCLIRemote(dbPath, name, url string) error {
+// remote is absent or points somewhere else. If a replacement is interrupted
+// (including by canceling ctx), the previous URL is restored regardless of ctx
+// so the CLI mirror is never left without the remote.
+func EnsureCLIRemote(ctx context.Context, dbPath, name, url string) error {
 	if err := remotecache.ValidateRemoteName(name); err != nil {
 		return fmt.Errorf("invalid remote name: %w", err)
 	}
@@ -180,20 +184,20 @@ func EnsureCLIRemote(dbPath, name, url string) error {
 	lock.Lock()
 	defer lock.Unlock()
 
-	current := FindCLIRemote(dbPath, name)
+	current := FindCLIRemote(ctx, dbPath, name)
 	if RemoteURLsMatch(current, url) {
 		return nil
 	}
 	if current != "" {
-		if err := RemoveCLIRemote(dbPath, name); err != nil {
+		if err := RemoveCLIRemote(ctx, dbPath, name); err != nil {
 			return err
 		}
 	}
-	if err := AddCLIRemote(dbPath, name, url); err != nil {
+	if err := AddCLIRemote(ctx, dbPath, name, url); err != nil {
 		if current == "" {
 			return err
 		}
-		if restoreErr := AddCLIRemote(dbPath, name, current); restoreErr != nil {
+		if restoreErr := AddCLIRemote(context.WithoutCancel(ctx), dbPath, name, current); restoreErr != nil {
 			return fmt.Errorf("add replacement CLI remote failed: %w; additionally failed to restore previous URL %q: %v", err, current, restoreErr)
 		}
 		return fmt.Errorf("add replacement CLI remote failed; previous URL %q restored: %w", current, err)
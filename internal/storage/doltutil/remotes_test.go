@@ -1,6 +1,14 @@
 package doltutil
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
 
 func TestShellQuote(t *testing.T) {
 	tests := []struct {
@@ -122,3 +130,31 @@ func TestRemoteURLsMatchNormalizesGitURLs(t *testing.T) {
 		})
 	}
 }
+
+// TestListCLIRemotesHonorsCancel pins that a hung `dolt remote -v` (e.g. a
+// dolt blocked on the database directory lock) does not outlive the caller's
+// context.
+func TestListCLIRemotesHonorsCancel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX shell fake")
+	}
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte("#!/bin/sh\nexec sleep 60\n"), 0o755); err != nil { // #nosec G306 -- test fixture must be executable
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := ListCLIRemotes(ctx, binDir)
+	if err == nil {
+		t.Fatal("ListCLIRemotes succeeded; want an error after the deadline")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("ListCLIRemotes ran %v past a 100ms deadline", elapsed)
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("ctx.Err() = %v, want DeadlineExceeded", ctx.Err())
+	}
+}
@@ -279,6 +279,29 @@ func compareVersions(v1, v2 string) int {
 	return 0
 }
 
+// readIDPrefixRules reads the prefix.by_type and prefix.by_label config
+// rules, mirroring issueops.ReadIDPrefixRulesTx.
+func readIDPrefixRules(ctx context.Context, cfgRepo ConfigSQLRepository) (byType, byLabel []types.IDPrefixRule, err error) {
+	read := func(key string) ([]types.IDPrefixRule, error) {
+		value, err := cfgRepo.GetConfig(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", key, err)
+		}
+		rules, err := types.ParseIDPrefixRules(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s config: %w", key, err)
+		}
+		return rules, nil
+	}
+	if byType, err = read(types.ConfigKeyPrefixByType); err != nil {
+		return nil, nil, err
+	}
+	if byLabel, err = read(types.ConfigKeyPrefixByLabel); err != nil {
+		return nil, nil, err
+	}
+	return byType, byLabel, nil
+}
+
 func (u *configUseCaseImpl) LoadCreateContext(ctx context.Context) (CreateContext, error) {
 	prefix, err := u.cfgRepo.GetConfig(ctx, "issue_prefix")
 	if err != nil {
@@ -288,13 +311,17 @@ func (u *configUseCaseImpl) LoadCreateContext(ctx context.Context) (CreateContex
 	if err != nil {
 		return CreateContext{}, fmt.Errorf("LoadCreateContext: read allowed_prefixes: %w", err)
 	}
+	byType, byLabel, err := readIDPrefixRules(ctx, u.cfgRepo)
+	if err != nil {
+		return CreateContext{}, fmt.Errorf("LoadCreateContext: %w", err)
+	}
 	customTypes, err := u.cfgRepo.GetCustomTypes(ctx)
 	if err != nil {
 		return CreateContext{}, fmt.Errorf("LoadCreateContext: read custom types: %w", err)
 	}
 	return CreateContext{
 		IssuePrefix:     prefix,
-		AllowedPrefixes: allowed,
+		AllowedPrefixes: types.MergeAllowedPrefixes(allowed, byType, byLabel),
 		CustomTypes:     customTypes,
 	}, nil
 }
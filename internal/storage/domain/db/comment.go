@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -130,7 +129,7 @@ func (r *commentSQLRepositoryImpl) Insert(ctx context.Context, issueID, author,
 		return nil, fmt.Errorf("db: CommentSQLRepository.Insert: issue %s not found", issueID)
 	}
 
-	createdAt := time.Now().UTC()
+	createdAt := storage.Now(ctx)
 	id := uuid.Must(uuid.NewV7()).String()
 	commentTable := pickCommentTable(opts.UseWispsTable)
 	//nolint:gosec // G201: commentTable is one of two hardcoded constants
@@ -41,14 +41,15 @@ var allowedUpdateFields = map[string]struct{}{
 	"description": {}, "design": {}, "acceptance_criteria": {}, "notes": {},
 	"issue_type": {}, "estimated_minutes": {}, "external_ref": {}, "spec_id": {},
 	"started_at": {}, "closed_at": {}, "close_reason": {}, "closed_by_session": {},
-	"source_repo": {}, "sender": {}, "wisp": {}, "wisp_type": {}, "no_history": {}, "pinned": {},
+	"source_repo": {}, "rig": {}, "encrypted": {}, "sender": {}, "wisp": {}, "wisp_type": {}, "no_history": {}, "pinned": {},
 	"mol_type": {}, "event_kind": {}, "actor": {}, "target": {}, "payload": {},
 	"due_at": {}, "defer_until": {}, "await_id": {}, "waiters": {},
 	"metadata": {},
 }
 
 var updateFieldColumnRename = map[string]string{
-	"wisp": "ephemeral",
+	"wisp":      "ephemeral",
+	"encrypted": "is_encrypted",
 }
 
 func (r *issueSQLRepositoryImpl) Insert(ctx context.Context, issue *types.Issue, actor string, opts domain.InsertIssueOpts) error {
@@ -56,7 +57,7 @@ func (r *issueSQLRepositoryImpl) Insert(ctx context.Context, issue *types.Issue,
 		return errors.New("db: Insert: issue must not be nil")
 	}
 
-	normalizeIssueTimestamps(issue)
+	normalizeIssueTimestamps(ctx, issue)
 	if issue.ContentHash == "" {
 		issue.ContentHash = issue.ComputeContentHash()
 	}
@@ -132,7 +133,7 @@ func (r *issueSQLRepositoryImpl) Update(ctx context.Context, id string, updates
 	}
 
 	// Resolve read-merge-write operation keys (issueops.OpMergeMetadata,
-	// OpSetMetadata, OpUnsetMetadata, OpAppendNotes) into concrete column
+	// OpSetMetadata, OpUnsetMetadata, OpPatchMetadata, OpAppendNotes) into concrete column
 	// values inside the mutation transaction, mirroring the embedded path
 	// (issueops.updateIssueInTx). Callers must pass the OPERATION, never a
 	// value pre-merged from an earlier read: this runner is a Dolt sql-server
@@ -148,6 +149,13 @@ func (r *issueSQLRepositoryImpl) Update(ctx context.Context, id string, updates
 		}
 		updates = resolved
 	}
+	if statusChanging {
+		tracked, err := issueops.ApplyReopenTracking(ctx, oldIssue, updates, actor)
+		if err != nil {
+			return fmt.Errorf("db: Update %s: %w", id, err)
+		}
+		updates = tracked
+	}
 
 	setClauses := make([]string, 0, len(updates)+3)
 	args := make([]any, 0, len(updates)+4)
@@ -163,14 +171,14 @@ func (r *issueSQLRepositoryImpl) Update(ctx context.Context, id string, updates
 		args = append(args, normalizeUpdateValue(key, value))
 	}
 	setClauses = append(setClauses, "updated_at = ?")
-	args = append(args, time.Now().UTC())
+	args = append(args, storage.Now(ctx))
 
 	// Lifecycle parity with issueops.updateIssueInTx: auto-manage closed_at and
 	// started_at from the status transition unless the caller set them
 	// explicitly. Both helpers no-op when the status is unchanged.
 	if statusChanging {
-		setClauses, args = issueops.ManageClosedAt(oldIssue, updates, setClauses, args)
-		setClauses, args = issueops.ManageStartedAt(oldIssue, updates, setClauses, args)
+		setClauses, args = issueops.ManageClosedAt(ctx, oldIssue, updates, setClauses, args)
+		setClauses, args = issueops.ManageStartedAt(ctx, oldIssue, updates, setClauses, args)
 	}
 
 	// Rewrite row_lock on every generic update, mirroring the classic
@@ -214,11 +222,12 @@ func (r *issueSQLRepositoryImpl) Update(ctx context.Context, id string, updates
 		return err
 	}
 
-	if statusChanging {
-		newStatus := coerceStatus(updates["status"])
-		oldActive := oldIssue.Status != types.StatusClosed && oldIssue.Status != types.StatusPinned
-		newActive := newStatus != types.StatusClosed && newStatus != types.StatusPinned
-		if oldActive != newActive {
+	if oldIssue != nil {
+		changed, err := issueops.BlockingChanged(oldIssue, updates)
+		if err != nil {
+			return fmt.Errorf("db: Update %s: %w", id, err)
+		}
+		if changed {
 			var (
 				affectedIssues, affectedWisps []string
 				aerr                          error
@@ -239,17 +248,6 @@ func (r *issueSQLRepositoryImpl) Update(ctx context.Context, id string, updates
 	return nil
 }
 
-func coerceStatus(v any) types.Status {
-	switch s := v.(type) {
-	case string:
-		return types.Status(s)
-	case types.Status:
-		return s
-	default:
-		return ""
-	}
-}
-
 func (r *issueSQLRepositoryImpl) Claim(ctx context.Context, id, actor string, opts domain.IssueTableOpts) (domain.ClaimRowResult, error) {
 	if id == "" {
 		return domain.ClaimRowResult{}, errors.New("db: Claim: id must not be empty")
@@ -267,7 +265,7 @@ func (r *issueSQLRepositoryImpl) Claim(ctx context.Context, id, actor string, op
 	}
 
 	table := pickIssueTable(opts.UseWispsTable)
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 	startedWasZero := oldIssue.StartedAt == nil
 
 	// Rewrite row_lock exactly like the primary claim path (issueops.
@@ -563,8 +561,8 @@ func (r *issueSQLRepositoryImpl) seedCounterFromExisting(ctx context.Context, pr
 	return nil
 }
 
-func normalizeIssueTimestamps(issue *types.Issue) {
-	now := time.Now().UTC()
+func normalizeIssueTimestamps(ctx context.Context, issue *types.Issue) {
+	now := storage.Now(ctx)
 	if issue.CreatedAt.IsZero() {
 		issue.CreatedAt = now
 	} else {
@@ -610,7 +608,7 @@ func insertIssueRow(ctx context.Context, runner Runner, table string, issue *typ
 			created_at, created_by, owner, updated_at, started_at, closed_at, external_ref, spec_id,
 			compaction_level, compacted_at, compacted_at_commit, original_size,
 			sender, ephemeral, no_history, wisp_type, pinned, is_template,
-			mol_type, work_type, source_system, source_repo, close_reason,
+			mol_type, work_type, source_system, source_formula, source_location, rig, is_encrypted, source_repo, close_reason,
 			event_kind, actor, target, payload,
 			await_type, await_id, timeout_ns, waiters,
 			due_at, defer_until, metadata,
@@ -621,7 +619,7 @@ func insertIssueRow(ctx context.Context, runner Runner, table string, issue *typ
 			?, ?, ?, ?, ?, ?, ?, ?,
 			?, ?, ?, ?,
 			?, ?, ?, ?, ?, ?,
-			?, ?, ?, ?, ?,
+			?, ?, ?, ?, ?, ?, ?, ?, ?,
 			?, ?, ?, ?,
 			?, ?, ?, ?,
 			?, ?, ?,
@@ -634,6 +632,7 @@ func insertIssueRow(ctx context.Context, runner Runner, table string, issue *typ
 			design = VALUES(design),
 			acceptance_criteria = VALUES(acceptance_criteria),
 			notes = VALUES(notes),
+			is_encrypted = VALUES(is_encrypted),
 			status = VALUES(status),
 			priority = VALUES(priority),
 			issue_type = VALUES(issue_type),
@@ -653,7 +652,7 @@ func insertIssueRow(ctx context.Context, runner Runner, table string, issue *typ
 		issue.CreatedAt, issue.CreatedBy, issue.Owner, issue.UpdatedAt, issue.StartedAt, issue.ClosedAt, nullStringPtr(issue.ExternalRef), issue.SpecID,
 		issue.CompactionLevel, issue.CompactedAt, nullStringPtr(issue.CompactedAtCommit), nullIntVal(issue.OriginalSize),
 		issue.Sender, issue.Ephemeral, issue.NoHistory, string(issue.WispType), issue.Pinned, issue.IsTemplate,
-		string(issue.MolType), string(issue.WorkType), issue.SourceSystem, issue.SourceRepo, issue.CloseReason,
+		string(issue.MolType), string(issue.WorkType), issue.SourceSystem, issue.SourceFormula, issue.SourceLocation, issue.Rig, issue.Encrypted, issue.SourceRepo, issue.CloseReason,
 		issue.EventKind, issue.Actor, issue.Target, issue.Payload,
 		issue.AwaitType, issue.AwaitID, issue.Timeout.Nanoseconds(), formatJSONStringArray(issue.Waiters),
 		issue.DueAt, issue.DeferUntil, jsonMetadata(issue.Metadata),
@@ -1008,7 +1007,7 @@ func (r *issueSQLRepositoryImpl) UnclaimIssue(ctx context.Context, id, actor str
 }
 
 func (r *issueSQLRepositoryImpl) ReclaimExpiredLeases(ctx context.Context, olderThan time.Duration, actor string) ([]types.ReclaimedLease, error) {
-	cutoff := time.Now().UTC().Add(-olderThan)
+	cutoff := storage.Now(ctx).Add(-olderThan)
 	out, err := issueops.ReclaimExpiredLeasesInTx(ctx, r.runner, cutoff, actor)
 	if err != nil {
 		return nil, fmt.Errorf("db: IssueSQLRepository.ReclaimExpiredLeases: %w", err)
@@ -41,7 +41,7 @@ func (r *issueSQLRepositoryImpl) GetDescendants(ctx context.Context, rootID stri
 	levelFilter.Limit = 0
 	levelFilter.Offset = 0
 
-	issueWhereClauses, issueArgs, err := buildIssueFilterClauses("", levelFilter, issuesFilterTables)
+	issueWhereClauses, issueArgs, err := buildIssueFilterClauses(ctx, "", levelFilter, issuesFilterTables)
 	if err != nil {
 		return nil, fmt.Errorf("descendants: issues filter: %w", err)
 	}
@@ -62,7 +62,7 @@ func (r *issueSQLRepositoryImpl) GetDescendants(ctx context.Context, rootID stri
 	var wispWhereClauses []string
 	var wispArgs []any
 	if walkWisps {
-		wispWhereClauses, wispArgs, err = buildIssueFilterClauses("", levelFilter, wispsFilterTables)
+		wispWhereClauses, wispArgs, err = buildIssueFilterClauses(ctx, "", levelFilter, wispsFilterTables)
 		if err != nil {
 			return nil, fmt.Errorf("descendants: wisps filter: %w", err)
 		}
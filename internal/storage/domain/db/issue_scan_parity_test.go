@@ -42,7 +42,7 @@ func TestScanIssue_StringTimestamps(t *testing.T) {
 	for i := range cols {
 		cols[i] = strings.TrimSpace(cols[i])
 	}
-	require.Len(t, cols, 49)
+	require.Len(t, cols, 53)
 
 	row := []driver.Value{
 		"bd-test.1", nil, "title", "desc", "", "", "", // id..notes
@@ -54,11 +54,15 @@ func TestScanIssue_StringTimestamps(t *testing.T) {
 		nil,                // mol_type
 		nil, nil, nil, nil, // event_kind..payload
 		nil, nil, // due_at, defer_until
-		nil, nil, nil, // work_type, source_system, metadata
+		nil, nil, // work_type, source_system
+		nil, nil, // source_formula, source_location
+		nil,          // rig
+		nil,          // is_encrypted
+		nil,          // metadata
 		int64(12345), // row_lock
 		nil, nil,     // lease_expires_at, heartbeat_at
 	}
-	require.Len(t, row, 49)
+	require.Len(t, row, 53)
 
 	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(cols).AddRow(row...))
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/dberrors"
 	"github.com/steveyegge/beads/internal/storage/domain"
 	"github.com/steveyegge/beads/internal/storage/sqlbuild"
@@ -59,16 +60,16 @@ func (r *issueSQLRepositoryImpl) searchAcrossIssuesAndWisps(ctx context.Context,
 }
 
 func (r *issueSQLRepositoryImpl) searchUnion(ctx context.Context, query string, filter types.IssueFilter) (domain.SearchPage, error) {
-	iSub, iArgs, err := r.buildUnionSubquery(query, filter, issuesFilterTables, "i")
+	iSub, iArgs, err := r.buildUnionSubquery(ctx, query, filter, issuesFilterTables, "i")
 	if err != nil {
 		return domain.SearchPage{}, fmt.Errorf("search union (issues): %w", err)
 	}
-	wSub, wArgs, err := r.buildUnionSubquery(query, filter, wispsFilterTables, "w")
+	wSub, wArgs, err := r.buildUnionSubquery(ctx, query, filter, wispsFilterTables, "w")
 	if err != nil {
 		return domain.SearchPage{}, fmt.Errorf("search union (wisps): %w", err)
 	}
 
-	outerOrderBy := unionOrderBySQL(filter.SortBy, filter.SortDesc)
+	outerOrderBy := unionOrderBySQL(filter.SortBy)
 	outerLimit := limitOffsetSQL(filter.Limit, filter.Offset)
 
 	//nolint:gosec // G201: subqueries built from hardcoded table names and ? placeholders.
@@ -102,9 +103,9 @@ func (r *issueSQLRepositoryImpl) searchUnion(ctx context.Context, query string,
 	return domain.SearchPage{Items: out, HasMore: hasMore}, nil
 }
 
-func (r *issueSQLRepositoryImpl) buildUnionSubquery(query string, filter types.IssueFilter, tables filterTables, srcTag string) (string, []any, error) {
+func (r *issueSQLRepositoryImpl) buildUnionSubquery(ctx context.Context, query string, filter types.IssueFilter, tables filterTables, srcTag string) (string, []any, error) {
 	plan := buildLabelDrivenSearch(filter, tables)
-	whereClauses, args, err := buildIssueFilterClauses(query, plan.Filter, tables)
+	whereClauses, args, err := buildIssueFilterClauses(ctx, query, plan.Filter, tables)
 	if err != nil {
 		return "", nil, err
 	}
@@ -163,7 +164,7 @@ func (r *issueSQLRepositoryImpl) fetchIssuesByIDs(ctx context.Context, ids []str
 
 func (r *issueSQLRepositoryImpl) searchTable(ctx context.Context, query string, filter types.IssueFilter, tables filterTables) (domain.SearchPage, error) {
 	plan := buildLabelDrivenSearch(filter, tables)
-	whereClauses, args, err := buildIssueFilterClauses(query, plan.Filter, tables)
+	whereClauses, args, err := buildIssueFilterClauses(ctx, query, plan.Filter, tables)
 	if err != nil {
 		return domain.SearchPage{}, err
 	}
@@ -194,7 +195,7 @@ func (r *issueSQLRepositoryImpl) searchTable(ctx context.Context, query string,
 		return domain.SearchPage{Items: orderByIDs(ids, byID), HasMore: hasMore}, nil
 	}
 
-	orderBy := orderBySQL(filter.SortBy, filter.SortDesc, "")
+	orderBy := orderBySQL(filter.SortBy, "")
 	limitSQL := limitOffsetSQL(filter.Limit, filter.Offset)
 
 	//nolint:gosec // G201: SQL fragments from fixed table names and parameterized filters.
@@ -235,7 +236,7 @@ func (r *issueSQLRepositoryImpl) searchTable(ctx context.Context, query string,
 }
 
 func (r *issueSQLRepositoryImpl) scanFilterIDs(ctx context.Context, selectKw, fromSQL, whereSQL string, args []any, filter types.IssueFilter, tables filterTables) ([]string, bool, error) {
-	orderBy := orderBySQL(filter.SortBy, filter.SortDesc, tables.Main)
+	orderBy := orderBySQL(filter.SortBy, tables.Main)
 	limitSQL := limitOffsetSQL(filter.Limit, filter.Offset)
 	//nolint:gosec // G201: SQL fragments from fixed table names and parameterized filters.
 	idQuery := fmt.Sprintf(`%s%s.id FROM %s %s %s %s`,
@@ -404,8 +405,8 @@ func buildLabelDrivenSearch(filter types.IssueFilter, tables filterTables) sqlbu
 	return sqlbuild.BuildLabelDrivenSearch(filter, tables)
 }
 
-func buildIssueFilterClauses(query string, filter types.IssueFilter, tables filterTables) ([]string, []any, error) {
-	return sqlbuild.BuildIssueFilterClauses(query, filter, tables)
+func buildIssueFilterClauses(ctx context.Context, query string, filter types.IssueFilter, tables filterTables) ([]string, []any, error) {
+	return sqlbuild.BuildIssueFilterClauses(query, filter, tables, storage.Now(ctx))
 }
 
 type idSrcPage struct {
@@ -495,8 +496,8 @@ type idSrcRef struct{ id, src string }
 
 const unionSortColumnsSQL = sqlbuild.UnionSortColumnsSQL
 
-func unionOrderBySQL(sortBy string, sortDesc bool) string {
-	return sqlbuild.OrderByForColumns(sortBy, sortDesc, func(k string) string {
+func unionOrderBySQL(keys []types.SortKey) string {
+	return sqlbuild.OrderByForColumns(keys, func(k string) string {
 		if k == "id" {
 			return "id"
 		}
@@ -504,8 +505,8 @@ func unionOrderBySQL(sortBy string, sortDesc bool) string {
 	})
 }
 
-func orderBySQL(sortBy string, sortDesc bool, prefix string) string {
-	return sqlbuild.OrderBy(sortBy, sortDesc, prefix)
+func orderBySQL(keys []types.SortKey, prefix string) string {
+	return sqlbuild.OrderBy(keys, prefix)
 }
 
 func limitOffsetSQL(limit, offset int) string {
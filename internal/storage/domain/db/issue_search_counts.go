@@ -59,16 +59,16 @@ func (r *issueSQLRepositoryImpl) searchAcrossIssuesAndWispsWithCounts(ctx contex
 }
 
 func (r *issueSQLRepositoryImpl) searchUnionWithCounts(ctx context.Context, query string, filter types.IssueFilter, wispDepsExist bool) (domain.SearchCountsPage, error) {
-	iSub, iArgs, err := r.buildUnionSubquery(query, filter, issuesFilterTables, "i")
+	iSub, iArgs, err := r.buildUnionSubquery(ctx, query, filter, issuesFilterTables, "i")
 	if err != nil {
 		return domain.SearchCountsPage{}, fmt.Errorf("search union with counts (issues): %w", err)
 	}
-	wSub, wArgs, err := r.buildUnionSubquery(query, filter, wispsFilterTables, "w")
+	wSub, wArgs, err := r.buildUnionSubquery(ctx, query, filter, wispsFilterTables, "w")
 	if err != nil {
 		return domain.SearchCountsPage{}, fmt.Errorf("search union with counts (wisps): %w", err)
 	}
 
-	outerOrderBy := unionOrderBySQL(filter.SortBy, filter.SortDesc)
+	outerOrderBy := unionOrderBySQL(filter.SortBy)
 	outerLimit := limitOffsetSQL(filter.Limit, filter.Offset)
 
 	//nolint:gosec // G201: subqueries built from hardcoded table names and ? placeholders.
@@ -123,7 +123,7 @@ func (r *issueSQLRepositoryImpl) fetchCountsByIDs(ctx context.Context, ids []str
 }
 
 func (r *issueSQLRepositoryImpl) runFilterSearchQuery(ctx context.Context, query string, filter types.IssueFilter, tables filterTables, includeWispReverseDeps bool) ([]*types.IssueWithCounts, error) {
-	whereClauses, args, err := buildIssueFilterClauses(query, filter, tables)
+	whereClauses, args, err := buildIssueFilterClauses(ctx, query, filter, tables)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +131,7 @@ func (r *issueSQLRepositoryImpl) runFilterSearchQuery(ctx context.Context, query
 	if len(whereClauses) > 0 {
 		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
 	}
-	orderBy := orderBySQL(filter.SortBy, filter.SortDesc, "i")
+	orderBy := orderBySQL(filter.SortBy, "i")
 	limitSQL := limitOffsetSQL(filter.Limit, filter.Offset)
 	return r.runSearchQuery(ctx, tables, whereSQL, orderBy, limitSQL, args, includeWispReverseDeps, filter.SkipLabels)
 }
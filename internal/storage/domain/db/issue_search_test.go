@@ -478,7 +478,7 @@ func (s *testSuite) paginationSearchSortDesc() {
 	_ = expected
 
 	page, err := r.SearchAcrossIssuesAndWisps(s.Ctx(), "",
-		types.IssueFilter{IDPrefix: "bd-pgs-sd-", SkipWisps: true, SortDesc: true})
+		types.IssueFilter{IDPrefix: "bd-pgs-sd-", SkipWisps: true, SortBy: []types.SortKey{{Field: "priority", Desc: true}}})
 	s.Require().NoError(err)
 	s.Equal(reversedExpected, idsFrom(page))
 }
@@ -543,7 +543,7 @@ func (s *testSuite) paginationSearchSortByCreated() {
 	var walked []string
 	for off := 0; ; off += 2 {
 		page, err := r.SearchAcrossIssuesAndWisps(s.Ctx(), "",
-			types.IssueFilter{IDPrefix: "bd-pgs-sc-", Limit: 2, Offset: off, SkipWisps: true, SortBy: "created"})
+			types.IssueFilter{IDPrefix: "bd-pgs-sc-", Limit: 2, Offset: off, SkipWisps: true, SortBy: []types.SortKey{{Field: "created", Desc: true}}})
 		s.Require().NoError(err)
 		walked = append(walked, idsFrom(page)...)
 		if !page.HasMore {
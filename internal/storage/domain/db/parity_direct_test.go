@@ -416,16 +416,16 @@ func (s *testSuite) TestParitySortTieBreak() {
 	}
 	wantLexical := []string{"bd-par-tie-a", "bd-par-tie-b", "bd-par-tie-c", "bd-par-tie-d"}
 
-	for _, sortBy := range []string{"", "priority", "created"} {
+	for _, sortBy := range [][]types.SortKey{nil, {{Field: "priority"}}, {{Field: "created", Desc: true}}} {
 		filter := types.IssueFilter{SortBy: sortBy}
 		classic := idsOf(s.classicList(filter))
 		dom := idsOf(s.domainList(filter))
-		s.Equal(classic, dom, "sort=%q: same sequence", sortBy)
-		s.Equal(wantLexical, dom, "sort=%q: ties must break on id ASC", sortBy)
+		s.Equal(classic, dom, "sort=%v: same sequence", sortBy)
+		s.Equal(wantLexical, dom, "sort=%v: ties must break on id ASC", sortBy)
 
 		classicCounts := idsOfCounts(s.classicListWithCounts(filter))
 		domCounts := idsOfCounts(s.domainListWithCounts(filter))
-		s.Equal(classicCounts, domCounts, "sort=%q (counts): same sequence", sortBy)
-		s.Equal(wantLexical, domCounts, "sort=%q (counts): ties must break on id ASC", sortBy)
+		s.Equal(classicCounts, domCounts, "sort=%v (counts): same sequence", sortBy)
+		s.Equal(wantLexical, domCounts, "sort=%v (counts): ties must break on id ASC", sortBy)
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/dberrors"
 	"github.com/steveyegge/beads/internal/storage/sqlbuild"
 	"github.com/steveyegge/beads/internal/types"
@@ -19,8 +20,8 @@ type readyWorkPredicates struct {
 // buildReadyWorkOrder orders by the sort_* aliases projected by
 // sqlbuild.UnionSortColumnsSQL, since ready work always sorts at the UNION
 // outer query here.
-func buildReadyWorkOrder(policy types.SortPolicy) sqlbuild.ReadyWorkOrder {
-	return sqlbuild.BuildReadyWorkOrder(policy, "sort_created", "sort_priority")
+func buildReadyWorkOrder(ctx context.Context, policy types.SortPolicy) sqlbuild.ReadyWorkOrder {
+	return sqlbuild.BuildReadyWorkOrder(policy, "sort_created", "sort_priority", storage.Now(ctx))
 }
 
 // buildReadyWorkPredicates computes the ID sets the ready-work WHERE clause
@@ -28,7 +29,7 @@ func buildReadyWorkOrder(policy types.SortPolicy) sqlbuild.ReadyWorkOrder {
 // the clause text to sqlbuild so both stacks share ready semantics. Unlike
 // the classic stack, ORDER BY and LIMIT are applied at the UNION outer query.
 func (r *issueSQLRepositoryImpl) buildReadyWorkPredicates(ctx context.Context, filter types.WorkFilter, tables filterTables) (*readyWorkPredicates, error) {
-	var inputs sqlbuild.ReadyWorkWhereInputs
+	inputs := sqlbuild.ReadyWorkWhereInputs{Now: storage.Now(ctx)}
 	if !filter.IncludeDeferred {
 		deferredChildIDs, dcErr := r.getChildrenOfDeferredParents(ctx)
 		if dcErr != nil {
@@ -75,8 +76,8 @@ func (r *issueSQLRepositoryImpl) anyFutureDeferredParent(ctx context.Context) (b
 		var probe int
 		//nolint:gosec // G201: table is a hardcoded constant.
 		err := r.runner.QueryRowContext(ctx, fmt.Sprintf(
-			`SELECT 1 FROM %s WHERE defer_until IS NOT NULL AND defer_until > UTC_TIMESTAMP() LIMIT 1`,
-			table)).Scan(&probe)
+			`SELECT 1 FROM %s WHERE defer_until IS NOT NULL AND defer_until > ? LIMIT 1`,
+			table), storage.Now(ctx)).Scan(&probe)
 		switch {
 		case err == nil:
 			return true, nil
@@ -90,6 +91,7 @@ func (r *issueSQLRepositoryImpl) anyFutureDeferredParent(ctx context.Context) (b
 }
 
 func (r *issueSQLRepositoryImpl) descendantsOfFutureDeferredParents(ctx context.Context) ([]string, error) {
+	now := storage.Now(ctx)
 	var childIDs []string
 	for _, e := range deferredParentEdges {
 		//nolint:gosec // G201: depTable/issueTable/targetCol are hardcoded.
@@ -99,9 +101,9 @@ func (r *issueSQLRepositoryImpl) descendantsOfFutureDeferredParents(ctx context.
 			JOIN %s parent ON parent.id = dep.%s
 			WHERE dep.type = 'parent-child'
 			  AND parent.defer_until IS NOT NULL
-			  AND parent.defer_until > UTC_TIMESTAMP()
+			  AND parent.defer_until > ?
 		`, e.depTable, e.issueTable, e.targetCol)
-		rows, err := r.runner.QueryContext(ctx, q)
+		rows, err := r.runner.QueryContext(ctx, q, now)
 		if err != nil {
 			if dberrors.IsTableNotExist(err) {
 				continue
@@ -50,7 +50,7 @@ func (r *issueSQLRepositoryImpl) getReadyWorkIDPage(ctx context.Context, filter
 		allArgs = append(allArgs, wispPreds.args...)
 	}
 
-	sortOrder := buildReadyWorkOrder(filter.SortPolicy)
+	sortOrder := buildReadyWorkOrder(ctx, filter.SortPolicy)
 	// limitOffsetSQL keeps the +1 overfetch for hasMore AND honors Offset
 	// when Limit is 0 (the hand-rolled guard here used to drop the offset
 	// entirely in that case, bd-6dnrw.44 P3).
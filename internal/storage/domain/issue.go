@@ -7,7 +7,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/steveyegge/beads/internal/idgen"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/dberrors"
 	"github.com/steveyegge/beads/internal/types"
@@ -290,6 +289,9 @@ type CloseIssueResult struct {
 
 type ReopenIssueParams struct {
 	Reason string
+	// RootCause is logged with the reopen; past reopen.root-cause-threshold
+	// a reopen without one fails with storage.ErrRootCauseRequired.
+	RootCause string
 }
 
 type ReopenIssueResult struct {
@@ -627,7 +629,7 @@ func (u *issueUseCaseImpl) create(ctx context.Context, params CreateIssueParams,
 	// db.Insert (which would otherwise normalize a zero value to a later
 	// time and break candidate reproducibility on retry).
 	if issue.CreatedAt.IsZero() {
-		issue.CreatedAt = time.Now().UTC()
+		issue.CreatedAt = storage.Now(ctx)
 	}
 
 	switch {
@@ -1221,7 +1223,8 @@ func (u *issueUseCaseImpl) graphHasPath(
 
 // resolveTopLevelPrefix picks the prefix for a freshly-minted top-level ID,
 // mirroring the embedded path's precedence (issueops/create.go:88-96 and
-// dolt/wisps.go wispPrefix). Reads issue_prefix from config once and trims
+// dolt/wisps.go wispPrefix), including the prefix.by_type and
+// prefix.by_label rules. Reads issue_prefix from config once and trims
 // the trailing hyphen so a config value of "bd-" yields "bd-<hash>" rather
 // than "bd--<hash>".
 func (u *issueUseCaseImpl) resolveTopLevelPrefix(ctx context.Context, issue *types.Issue, useWisp bool) (string, error) {
@@ -1238,9 +1241,17 @@ func (u *issueUseCaseImpl) resolveTopLevelPrefix(ctx context.Context, issue *typ
 		return "", fmt.Errorf("issue_prefix config is missing")
 	}
 
+	byType, byLabel, err := readIDPrefixRules(ctx, u.cfgRepo)
+	if err != nil {
+		return "", err
+	}
+	rulePrefix := types.ResolveIDPrefix(issue, byType, byLabel)
+
 	switch {
 	case issue.IDPrefix != "":
 		return configPrefix + "-" + issue.IDPrefix, nil
+	case rulePrefix != "":
+		return rulePrefix, nil
 	case useWisp:
 		return configPrefix + "-wisp", nil
 	}
@@ -1292,7 +1303,7 @@ func (u *issueUseCaseImpl) mintTopLevelID(ctx context.Context, issue *types.Issu
 
 	for length := baseLength; length <= cfg.MaxLength; length++ {
 		for nonce := 0; nonce < 10; nonce++ {
-			candidate := idgen.GenerateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+			candidate := storage.GenerateIssueID(ctx, prefix, issue, actor, length, nonce)
 			exists, err := u.issueRepo.Exists(ctx, candidate, tableOpts)
 			if err != nil {
 				return "", err
@@ -1427,6 +1438,7 @@ func (u *issueUseCaseImpl) reopen(ctx context.Context, id string, params ReopenI
 	if actor == "" {
 		return ReopenIssueResult{}, fmt.Errorf("reopen: actor must not be empty")
 	}
+	ctx = storage.WithReopenNote(ctx, storage.ReopenNote{Reason: params.Reason, RootCause: params.RootCause})
 	row, err := u.issueRepo.Reopen(ctx, id, ReopenRowParams{Reason: params.Reason}, actor, IssueTableOpts{UseWispsTable: useWisp})
 	if err != nil {
 		return ReopenIssueResult{}, fmt.Errorf("reopen %s: %w", id, err)
@@ -0,0 +1,91 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSearchIssuesAsOf(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "ao")
+	ctx := t.Context()
+
+	before := &types.Issue{Title: "Before", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := te.store.CreateIssue(ctx, before, "tester"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if err := te.store.AddLabel(ctx, before.ID, "sprint", "tester"); err != nil {
+		t.Fatalf("AddLabel: %v", err)
+	}
+	if err := te.store.Commit(ctx, "sprint start"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	ref, err := te.store.GetCurrentCommit(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentCommit: %v", err)
+	}
+	// Commit dates have second precision; keep the later writes clear of
+	// the timestamp the date query uses.
+	time.Sleep(1100 * time.Millisecond)
+	date := time.Now().Format(time.RFC3339)
+	time.Sleep(1100 * time.Millisecond)
+
+	if err := te.store.UpdateIssue(ctx, before.ID, map[string]interface{}{"title": "After"}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	later := &types.Issue{Title: "Later", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := te.store.CreateIssue(ctx, later, "tester"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if err := te.store.Commit(ctx, "mid-sprint"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		filter types.IssueFilter
+	}{
+		{"commit", types.IssueFilter{AsOf: ref}},
+		{"date", types.IssueFilter{AsOf: date}},
+		{"label", types.IssueFilter{AsOf: ref, Labels: []string{"sprint"}}},
+		{"limit", types.IssueFilter{AsOf: ref, Limit: 10}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, err := te.store.SearchIssues(ctx, "", tc.filter)
+			if err != nil {
+				t.Fatalf("SearchIssues: %v", err)
+			}
+			if len(issues) != 1 || issues[0].ID != before.ID {
+				t.Fatalf("SearchIssues as of %s = %v; want only %s", tc.filter.AsOf, issues, before.ID)
+			}
+			if issues[0].Title != "Before" || !slices.Equal(issues[0].Labels, []string{"sprint"}) {
+				t.Errorf("got %q %v; want the snapshot's title and labels", issues[0].Title, issues[0].Labels)
+			}
+
+			counted, err := te.store.SearchIssuesWithCounts(ctx, "", tc.filter)
+			if err != nil {
+				t.Fatalf("SearchIssuesWithCounts: %v", err)
+			}
+			if len(counted) != 1 || counted[0].Issue.Title != "Before" {
+				t.Errorf("SearchIssuesWithCounts as of %s = %v; want the snapshot of %s", tc.filter.AsOf, counted, before.ID)
+			}
+		})
+	}
+
+	got, err := te.store.AsOf(ctx, before.ID, date)
+	if err != nil {
+		t.Fatalf("AsOf date: %v", err)
+	}
+	if got.Title != "Before" {
+		t.Errorf("AsOf(%s).Title = %q; want Before", date, got.Title)
+	}
+
+	if _, err := te.store.SearchIssues(ctx, "", types.IssueFilter{AsOf: "main'; DROP TABLE issues"}); err == nil {
+		t.Error("SearchIssues accepted an invalid ref")
+	}
+}
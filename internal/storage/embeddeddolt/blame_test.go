@@ -0,0 +1,75 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGetIssueBlame(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "bl")
+	ctx := t.Context()
+
+	issue := &types.Issue{Title: "Blamed", Description: "original", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := te.store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if err := te.store.Commit(ctx, "create"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	created, err := te.store.GetCurrentCommit(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentCommit: %v", err)
+	}
+	if err := te.store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"description": "clobbered"}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if err := te.store.Commit(ctx, "clobber"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	clobbered, err := te.store.GetCurrentCommit(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentCommit: %v", err)
+	}
+	if err := te.store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+
+	blame, err := te.store.GetIssueBlame(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueBlame: %v", err)
+	}
+	if len(blame) != len(storage.IssueFieldNames()) {
+		t.Fatalf("got %d fields, want %d", len(blame), len(storage.IssueFieldNames()))
+	}
+	byField := make(map[string]*storage.FieldBlame)
+	for _, b := range blame {
+		byField[b.Field] = b
+	}
+	for _, tc := range []struct {
+		field, value, commit string
+	}{
+		{"title", "Blamed", created},
+		{"description", "clobbered", clobbered},
+		{"priority", "0", "WORKING"},
+	} {
+		b := byField[tc.field]
+		if b == nil {
+			t.Fatalf("no blame for %s", tc.field)
+		}
+		if b.Value != tc.value || b.CommitHash != tc.commit {
+			t.Errorf("%s: got value %q at %s, want %q at %s", tc.field, b.Value, b.CommitHash, tc.value, tc.commit)
+		}
+	}
+	if b := byField["description"]; b.Actor == "" || b.CommitDate.IsZero() {
+		t.Errorf("description blame missing committer or date: %+v", b)
+	}
+
+	if _, err := te.store.GetIssueBlame(ctx, "bl-missing"); err == nil {
+		t.Error("expected an error for a missing issue")
+	}
+}
@@ -28,8 +28,23 @@ type cacheEntry struct {
 //
 // This prevents redundant engine initializations when multiple code paths open
 // connectors against the same data directory in the same process.
-func Open(ctx context.Context, beadsDir, database, branch string) (*EmbeddedDoltStore, error) {
-	return openCached(ctx, beadsDir, database, branch, openStrict)
+//
+// A store opened with options bypasses the cache, so its clock and ID
+// generator are never handed to, or taken from, another opener.
+func Open(ctx context.Context, beadsDir, database, branch string, opts ...Option) (*EmbeddedDoltStore, error) {
+	if len(opts) == 0 {
+		return openCached(ctx, beadsDir, database, branch, openStrict)
+	}
+	var o openOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	s, err := newStore(ctx, beadsDir, database, branch, openStrict)
+	if err != nil {
+		return nil, err
+	}
+	s.clock, s.idGen = o.clock, o.idGen
+	return s, nil
 }
 
 // OpenForReadOnlyCommand opens like Open, except that a #4259 remote-migrate
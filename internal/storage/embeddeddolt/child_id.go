@@ -11,7 +11,7 @@ import (
 
 func (s *EmbeddedDoltStore) GetNextChildID(ctx context.Context, parentID string) (string, error) {
 	var childID string
-	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		childID, err = issueops.GetNextChildIDTx(ctx, tx, parentID)
 		return err
@@ -14,7 +14,7 @@ import (
 )
 
 func (s *EmbeddedDoltStore) SetConfig(ctx context.Context, key, value string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		if err := issueops.SetConfigInTx(ctx, tx, key, value); err != nil {
 			return err
 		}
@@ -35,7 +35,7 @@ func (s *EmbeddedDoltStore) SetConfig(ctx context.Context, key, value string) er
 
 func (s *EmbeddedDoltStore) GetConfig(ctx context.Context, key string) (string, error) {
 	var value string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		value, err = issueops.GetConfigInTx(ctx, tx, key)
 		return err
@@ -45,7 +45,7 @@ func (s *EmbeddedDoltStore) GetConfig(ctx context.Context, key string) (string,
 
 func (s *EmbeddedDoltStore) GetAllConfig(ctx context.Context) (map[string]string, error) {
 	var result map[string]string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetAllConfigInTx(ctx, tx)
 		return err
@@ -55,7 +55,7 @@ func (s *EmbeddedDoltStore) GetAllConfig(ctx context.Context) (map[string]string
 
 func (s *EmbeddedDoltStore) GetMetadata(ctx context.Context, key string) (string, error) {
 	var value string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		value, err = issueops.GetMetadataInTx(ctx, tx, key)
 		return err
@@ -64,20 +64,30 @@ func (s *EmbeddedDoltStore) GetMetadata(ctx context.Context, key string) (string
 }
 
 func (s *EmbeddedDoltStore) SetMetadata(ctx context.Context, key, value string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.SetMetadataInTx(ctx, tx, key, value)
 	})
 }
 
+func (s *EmbeddedDoltStore) DeleteMetadataPrefix(ctx context.Context, prefix string) (int, error) {
+	var n int
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		n, err = issueops.DeleteMetadataPrefixInTx(ctx, tx, prefix)
+		return err
+	})
+	return n, err
+}
+
 func (s *EmbeddedDoltStore) SetLocalMetadata(ctx context.Context, key, value string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.SetLocalMetadataInTx(ctx, tx, key, value)
 	})
 }
 
 func (s *EmbeddedDoltStore) GetLocalMetadata(ctx context.Context, key string) (string, error) {
 	var value string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		value, err = issueops.GetLocalMetadataInTx(ctx, tx, key)
 		return err
@@ -90,7 +100,7 @@ func (s *EmbeddedDoltStore) GetLocalMetadata(ctx context.Context, key string) (s
 // then to hardcoded defaults (agent, role, message).
 func (s *EmbeddedDoltStore) GetInfraTypes(ctx context.Context) map[string]bool {
 	var result map[string]bool
-	if err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	if err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		result = issueops.ResolveInfraTypesInTx(ctx, tx)
 		return nil
 	}); err != nil || result == nil {
@@ -0,0 +1,173 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
+)
+
+// seedIssueConflicts diverges main and a peer branch so merging the peer
+// conflicts on two issues: cs-edit (main retitles it on day 2, the peer
+// rewrites its description on day 3) and cs-gone (main deletes it, the peer
+// edits it). It merges the peer keeping the conflicts and leaves main
+// checked out.
+func seedIssueConflicts(t *testing.T, ctx context.Context, conn *sql.Conn) {
+	t.Helper()
+	exec := func(query string, args ...any) {
+		t.Helper()
+		if _, err := conn.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+	for _, id := range []string{"cs-edit", "cs-gone"} {
+		exec("INSERT INTO issues (id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, updated_at) "+
+			"VALUES (?, 'base title', 'base desc', '', '', '', 'open', 2, 'task', '2026-01-01 00:00:00')", id)
+	}
+	exec("CALL DOLT_COMMIT('-Am', 'seed issues')")
+	exec("CALL DOLT_BRANCH('cspeer', 'HEAD')")
+
+	exec("UPDATE issues SET title = 'our title', updated_at = '2026-01-02 00:00:00' WHERE id = 'cs-edit'")
+	exec("DELETE FROM issues WHERE id = 'cs-gone'")
+	exec("CALL DOLT_COMMIT('-Am', 'local edits')")
+
+	exec("CALL DOLT_CHECKOUT('cspeer')")
+	exec("UPDATE issues SET description = 'their desc', updated_at = '2026-01-03 00:00:00' WHERE id = 'cs-edit'")
+	exec("UPDATE issues SET priority = 0, updated_at = '2026-01-03 00:00:00' WHERE id = 'cs-gone'")
+	exec("CALL DOLT_COMMIT('-Am', 'peer edits')")
+	exec("CALL DOLT_CHECKOUT('main')")
+
+	conflicts, err := versioncontrolops.MergeKeepingConflicts(ctx, conn, "cspeer", "test <test@example.com>")
+	if err != nil {
+		t.Fatalf("MergeKeepingConflicts: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Field != "issues" {
+		t.Fatalf("conflicts = %+v, want the issues table", conflicts)
+	}
+}
+
+func TestResolveIssueConflictsStrategies(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+
+	tests := []struct {
+		strategy       storage.ConflictStrategy
+		wantTitle      string
+		wantDesc       string
+		wantGone       bool // cs-gone deleted after resolution
+		wantUnresolved int
+	}{
+		{strategy: storage.ConflictStrategyOurs, wantTitle: "our title", wantDesc: "base desc", wantGone: true},
+		{strategy: storage.ConflictStrategyTheirs, wantTitle: "base title", wantDesc: "their desc"},
+		// newest and merge cannot decide the delete/modify row on cs-gone.
+		{strategy: storage.ConflictStrategyNewest, wantTitle: "base title", wantDesc: "their desc", wantGone: true, wantUnresolved: 1},
+		{strategy: storage.ConflictStrategyMerge, wantTitle: "our title", wantDesc: "their desc", wantGone: true, wantUnresolved: 1},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			te := newTestEnv(t, "cs")
+			ctx := t.Context()
+			conn := openSettleConn(t, ctx, te)
+			seedIssueConflicts(t, ctx, conn)
+
+			unresolved, err := versioncontrolops.ResolveIssueConflicts(ctx, conn, tt.strategy)
+			if err != nil {
+				t.Fatalf("ResolveIssueConflicts: %v", err)
+			}
+			if unresolved != tt.wantUnresolved {
+				t.Errorf("unresolved = %d, want %d", unresolved, tt.wantUnresolved)
+			}
+
+			var title, desc string
+			if err := conn.QueryRowContext(ctx, "SELECT title, description FROM issues WHERE id = 'cs-edit'").Scan(&title, &desc); err != nil {
+				t.Fatalf("read cs-edit: %v", err)
+			}
+			if title != tt.wantTitle || desc != tt.wantDesc {
+				t.Errorf("cs-edit = (%q, %q), want (%q, %q)", title, desc, tt.wantTitle, tt.wantDesc)
+			}
+			var count int
+			if err := conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM issues WHERE id = 'cs-gone'").Scan(&count); err != nil {
+				t.Fatalf("count cs-gone: %v", err)
+			}
+			if gone := count == 0; gone != tt.wantGone {
+				t.Errorf("cs-gone deleted = %v, want %v", gone, tt.wantGone)
+			}
+			if tt.wantUnresolved > 0 {
+				return
+			}
+			if _, err := conn.ExecContext(ctx, "CALL DOLT_COMMIT('-Am', 'resolved')"); err != nil {
+				t.Errorf("commit resolution: %v", err)
+			}
+		})
+	}
+}
+
+// TestIssueConflictStore covers the store-level API bd federation conflicts
+// uses: conflicts a sync left in the working set are listed with their
+// differing fields and resolved one issue at a time.
+func TestIssueConflictStore(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "csstore")
+	ctx := t.Context()
+	// The seeding connection holds the database lock; release it before the
+	// store opens its own.
+	db, cleanup, err := embeddeddolt.OpenSQL(ctx, te.dataDir, te.database, "main")
+	if err != nil {
+		t.Fatalf("OpenSQL: %v", err)
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("pin connection: %v", err)
+	}
+	seedIssueConflicts(t, ctx, conn)
+	_ = conn.Close()
+	if err := cleanup(); err != nil {
+		t.Fatalf("close seeding connection: %v", err)
+	}
+
+	conflicts, err := te.store.GetIssueConflicts(ctx)
+	if err != nil {
+		t.Fatalf("GetIssueConflicts: %v", err)
+	}
+	byID := map[string]storage.IssueConflict{}
+	for _, c := range conflicts {
+		byID[c.IssueID] = c
+	}
+	edit, gone := byID["cs-edit"], byID["cs-gone"]
+	if len(edit.Fields) != 2 || edit.Fields[0].Field != "title" || edit.Fields[1].Field != "description" {
+		t.Errorf("cs-edit fields = %+v, want title and description", edit.Fields)
+	}
+	if !gone.OursDeleted || gone.TheirsDeleted {
+		t.Errorf("cs-gone = %+v, want deleted locally only", gone)
+	}
+
+	if ok, err := te.store.ResolveIssueConflict(ctx, "cs-gone", storage.ConflictStrategyNewest); err != nil || ok {
+		t.Errorf("newest on delete/modify = (%v, %v), want undecided", ok, err)
+	}
+	for _, id := range []string{"cs-edit", "cs-gone"} {
+		if ok, err := te.store.ResolveIssueConflict(ctx, id, storage.ConflictStrategyTheirs); err != nil || !ok {
+			t.Fatalf("ResolveIssueConflict(%s, theirs) = (%v, %v)", id, ok, err)
+		}
+	}
+	remaining, err := te.store.GetConflicts(ctx)
+	if err != nil {
+		t.Fatalf("GetConflicts: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("remaining conflicts = %+v", remaining)
+	}
+	if err := te.store.Commit(ctx, "resolve conflicts"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	issue, err := te.store.GetIssue(ctx, "cs-gone")
+	if err != nil {
+		t.Fatalf("GetIssue(cs-gone): %v", err)
+	}
+	if issue.Priority != 0 {
+		t.Errorf("cs-gone priority = %d, want the peer's 0", issue.Priority)
+	}
+}
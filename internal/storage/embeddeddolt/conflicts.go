@@ -0,0 +1,64 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
+)
+
+// mergeKeepingConflicts is Sync's merge step: unlike Merge, a conflicted
+// merge stays in the working set so the peer's conflict strategy, or the
+// operator via bd federation conflicts, can resolve it. A conflict-free merge
+// recomputes is_blocked like Merge does.
+func (s *EmbeddedDoltStore) mergeKeepingConflicts(ctx context.Context, branch string) ([]storage.Conflict, error) {
+	preHead := s.preMergeHead(ctx)
+	var conflicts []storage.Conflict
+	err := s.withMutatingPinnedDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		var err error
+		conflicts, err = versioncontrolops.MergeKeepingConflicts(ctx, db, branch, commitAuthor)
+		return err
+	})
+	if err == nil && len(conflicts) == 0 {
+		if rerr := s.recomputeBlockedAfterPull(ctx, preHead); rerr != nil {
+			return nil, fmt.Errorf("merge succeeded but is_blocked recompute failed: %w", rerr)
+		}
+	}
+	return conflicts, err
+}
+
+// GetIssueConflicts lists the conflicted rows of the issues table.
+func (s *EmbeddedDoltStore) GetIssueConflicts(ctx context.Context) ([]storage.IssueConflict, error) {
+	var conflicts []storage.IssueConflict
+	err := s.withDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		var err error
+		conflicts, err = versioncontrolops.GetIssueConflicts(ctx, db)
+		return err
+	})
+	return conflicts, err
+}
+
+// ResolveIssueConflict resolves one issue's merge conflict with strategy,
+// reporting false when the strategy cannot decide it.
+func (s *EmbeddedDoltStore) ResolveIssueConflict(ctx context.Context, issueID string, strategy storage.ConflictStrategy) (bool, error) {
+	var resolved bool
+	err := s.withMutatingPinnedDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		if err := versioncontrolops.AllowConflictCommits(ctx, db); err != nil {
+			return err
+		}
+		var err error
+		resolved, err = versioncontrolops.ResolveIssueConflict(ctx, db, issueID, strategy)
+		return err
+	})
+	return resolved, err
+}
+
+// AbortMerge abandons a merge left conflicted in the working set.
+func (s *EmbeddedDoltStore) AbortMerge(ctx context.Context) error {
+	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		return versioncontrolops.AbortMerge(ctx, db)
+	})
+}
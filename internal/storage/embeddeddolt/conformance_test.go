@@ -21,22 +21,40 @@ func TestConformance(t *testing.T) {
 		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt tests")
 	}
 	conformance.RunAll(t, func(t *testing.T) storage.DoltStorage {
-		ctx := t.Context()
-		beadsDir := filepath.Join(t.TempDir(), ".beads")
-		store, err := embeddeddolt.Open(ctx, beadsDir, "test", "main")
-		if err != nil {
-			t.Fatalf("Open embedded store: %v", err)
-		}
-		t.Cleanup(func() { _ = store.Close() })
+		return openConformanceStore(t)
+	})
+}
 
-		// A fresh store is uninitialized; the conformance factory contract
-		// requires an init'd store (issue_prefix set), as `bd init` leaves it.
-		if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
-			t.Fatalf("SetConfig(issue_prefix): %v", err)
-		}
-		if err := store.Commit(ctx, "bd init"); err != nil {
-			t.Fatalf("Commit: %v", err)
-		}
-		return store
+// TestClockConformance checks that every write path stamps the store's
+// injected clock (internal/storage/conformance RunClock).
+func TestClockConformance(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt tests")
+	}
+	conformance.RunClock(t, func(t *testing.T, clock storage.Clock) storage.DoltStorage {
+		return openConformanceStore(t, embeddeddolt.WithClock(clock))
 	})
 }
+
+// openConformanceStore opens a fresh, empty in-process store initialized as
+// `bd init` leaves it.
+func openConformanceStore(t *testing.T, opts ...embeddeddolt.Option) storage.DoltStorage {
+	t.Helper()
+	ctx := t.Context()
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	store, err := embeddeddolt.Open(ctx, beadsDir, "test", "main", opts...)
+	if err != nil {
+		t.Fatalf("Open embedded store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	// A fresh store is uninitialized; the conformance factory contract
+	// requires an init'd store (issue_prefix set), as `bd init` leaves it.
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("SetConfig(issue_prefix): %v", err)
+	}
+	if err := store.Commit(ctx, "bd init"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	return store
+}
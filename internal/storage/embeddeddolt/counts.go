@@ -28,7 +28,7 @@ const depTargetExpr = "COALESCE(depends_on_issue_id, depends_on_wisp_id, depends
 // durable issues table only, otherwise the wisps tier is merged in (GH#4387).
 func (s *EmbeddedDoltStore) CountIssues(ctx context.Context, query string, filter types.IssueFilter) (int64, error) {
 	var n int64
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		count, err := issueops.CountIssuesInTx(ctx, tx, query, filter)
 		if err != nil {
 			return err
@@ -43,7 +43,7 @@ func (s *EmbeddedDoltStore) CountIssues(ctx context.Context, query string, filte
 // status, priority, type, assignee, label.
 func (s *EmbeddedDoltStore) CountIssuesByGroup(ctx context.Context, filter types.IssueFilter, groupBy string) (map[string]int, error) {
 	var result map[string]int
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.CountIssuesByGroupInTx(ctx, tx, filter, groupBy)
 		return err
@@ -61,7 +61,7 @@ func (s *EmbeddedDoltStore) CountIssuesByGroup(ctx context.Context, filter types
 // can fail to resolve under the pure-Go GMS analyzer.
 func (s *EmbeddedDoltStore) CountDependents(ctx context.Context, issueID string) (int64, error) {
 	var n int64
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var perm, wisp int64
 		if err := tx.QueryRowContext(ctx,
 			`SELECT count(*) FROM dependencies WHERE `+depTargetExpr+` = ?`, issueID).Scan(&perm); err != nil {
@@ -84,7 +84,7 @@ func (s *EmbeddedDoltStore) CountDependents(ctx context.Context, issueID string)
 // query is avoided).
 func (s *EmbeddedDoltStore) CountDependencies(ctx context.Context, issueID string) (int64, error) {
 	var n int64
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var perm, wisp int64
 		if err := tx.QueryRowContext(ctx,
 			`SELECT count(*) FROM dependencies WHERE issue_id = ?`, issueID).Scan(&perm); err != nil {
@@ -102,7 +102,7 @@ func (s *EmbeddedDoltStore) CountDependencies(ctx context.Context, issueID strin
 
 func (s *EmbeddedDoltStore) CountIssueComments(ctx context.Context, issueID string) (int64, error) {
 	var n int64
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		return tx.QueryRowContext(ctx,
 			`SELECT count(*) FROM comments WHERE issue_id = ?`, issueID).Scan(&n)
 	})
@@ -111,7 +111,7 @@ func (s *EmbeddedDoltStore) CountIssueComments(ctx context.Context, issueID stri
 
 func (s *EmbeddedDoltStore) CountEvents(ctx context.Context, issueID string, limit int) (int64, error) {
 	var n int64
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		return tx.QueryRowContext(ctx,
 			`SELECT count(*) FROM events WHERE issue_id = ?`, issueID).Scan(&n)
 	})
@@ -131,7 +131,7 @@ func (s *EmbeddedDoltStore) CountEvents(ctx context.Context, issueID string, lim
 // single combined query is avoided).
 func (s *EmbeddedDoltStore) CountDependentsByStatus(ctx context.Context, issueID string, status types.Status) (int64, error) {
 	var n int64
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var perm, wisp int64
 		if err := tx.QueryRowContext(ctx,
 			`SELECT count(*) FROM dependencies d
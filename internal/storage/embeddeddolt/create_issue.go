@@ -21,7 +21,7 @@ func (s *EmbeddedDoltStore) CreateIssue(ctx context.Context, issue *types.Issue,
 		issue.Ephemeral = true
 	}
 
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		// SkipPrefixValidation matches DoltStore.CreateIssue, which does not
 		// validate prefixes for explicit IDs on the single-issue path.
 		bc, err := issueops.NewBatchContext(ctx, tx, storage.BatchCreateOptions{
@@ -65,7 +65,23 @@ func (s *EmbeddedDoltStore) CreateIssuesWithFullOptions(ctx context.Context, iss
 		}
 	}
 
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.CreateIssuesInTx(ctx, tx, issues, actor, opts)
 	})
 }
+
+func (s *EmbeddedDoltStore) UpsertIssues(ctx context.Context, issues []*types.Issue, actor string) (*storage.UpsertIssuesResult, error) {
+	if len(issues) == 0 {
+		return &storage.UpsertIssuesResult{}, nil
+	}
+	var out *storage.UpsertIssuesResult
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		out, _, err = issueops.UpsertIssuesInTx(ctx, tx, issues, actor)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -20,7 +20,7 @@ func (s *EmbeddedDoltStore) AddDependency(ctx context.Context, dep *types.Depend
 // AddDependencyWithOptions adds a dependency; EmitEvent records a
 // dependency_added history event for the explicit dep verbs.
 func (s *EmbeddedDoltStore) AddDependencyWithOptions(ctx context.Context, dep *types.Dependency, actor string, addOpts storage.DependencyAddOptions) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		// Embedded commits the whole working set on the connection, so the
 		// event-written flag is not needed for selective staging (unlike DoltStore).
 		_, err := issueops.AddDependencyInTx(ctx, tx, dep, actor, issueops.AddDependencyOpts{
@@ -42,7 +42,7 @@ func (s *EmbeddedDoltStore) RemoveDependency(ctx context.Context, issueID, depen
 // RemoveDependencyWithOptions removes a dependency; EmitEvent records a
 // dependency_removed history event for the explicit dep verb.
 func (s *EmbeddedDoltStore) RemoveDependencyWithOptions(ctx context.Context, issueID, dependsOnID string, actor string, rmOpts storage.DependencyRemoveOptions) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		// Embedded commits the whole working set on the connection, so the
 		// event-written flag is not needed for selective staging (unlike DoltStore).
 		_, err := issueops.RemoveDependencyInTx(ctx, tx, issueID, dependsOnID, actor, rmOpts.EmitEvent)
@@ -53,7 +53,7 @@ func (s *EmbeddedDoltStore) RemoveDependencyWithOptions(ctx context.Context, iss
 // GetIssuesByIDs retrieves multiple issues by ID.
 func (s *EmbeddedDoltStore) GetIssuesByIDs(ctx context.Context, ids []string) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetIssuesByIDsInTx(ctx, tx, ids, nil)
 		return err
@@ -65,7 +65,7 @@ func (s *EmbeddedDoltStore) GetIssuesByIDs(ctx context.Context, ids []string) ([
 // along with the dependency type.
 func (s *EmbeddedDoltStore) GetDependenciesWithMetadata(ctx context.Context, issueID string) ([]*types.IssueWithDependencyMetadata, error) {
 	var result []*types.IssueWithDependencyMetadata
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependenciesWithMetadataInTx(ctx, tx, issueID)
 		return err
@@ -77,7 +77,7 @@ func (s *EmbeddedDoltStore) GetDependenciesWithMetadata(ctx context.Context, iss
 // along with the dependency type.
 func (s *EmbeddedDoltStore) GetDependentsWithMetadata(ctx context.Context, issueID string) ([]*types.IssueWithDependencyMetadata, error) {
 	var result []*types.IssueWithDependencyMetadata
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependentsWithMetadataInTx(ctx, tx, issueID)
 		return err
@@ -88,7 +88,7 @@ func (s *EmbeddedDoltStore) GetDependentsWithMetadata(ctx context.Context, issue
 // DetectCycles finds dependency cycles across both permanent and wisp dependencies.
 func (s *EmbeddedDoltStore) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
 	var result [][]*types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.DetectCyclesInTx(ctx, tx)
 		return err
@@ -4,17 +4,14 @@ package embeddeddolt
 
 import (
 	"context"
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
 	"database/sql"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/besteffort"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
@@ -42,9 +39,9 @@ func (s *EmbeddedDoltStore) ensureCredentialKey() error {
 	}
 
 	// Generate new random 32-byte key (AES-256).
-	key = make([]byte, 32)
-	if _, err := io.ReadFull(rand.Reader, key); err != nil {
-		return fmt.Errorf("generate credential key: %w", err)
+	key, err = issueops.NewCredentialKey()
+	if err != nil {
+		return err
 	}
 	if err := os.WriteFile(keyPath, key, 0600); err != nil {
 		return fmt.Errorf("write credential key: %w", err)
@@ -61,19 +58,7 @@ func (s *EmbeddedDoltStore) encryptPassword(password string) ([]byte, error) {
 	if err := s.ensureCredentialKey(); err != nil {
 		return nil, err
 	}
-	block, err := aes.NewCipher(s.credentialKey)
-	if err != nil {
-		return nil, err
-	}
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return nil, err
-	}
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return nil, err
-	}
-	return gcm.Seal(nonce, nonce, []byte(password), nil), nil
+	return issueops.EncryptWithKey(password, s.credentialKey)
 }
 
 func (s *EmbeddedDoltStore) decryptPassword(encrypted []byte) (string, error) {
@@ -83,24 +68,86 @@ func (s *EmbeddedDoltStore) decryptPassword(encrypted []byte) (string, error) {
 	if err := s.ensureCredentialKey(); err != nil {
 		return "", err
 	}
-	block, err := aes.NewCipher(s.credentialKey)
+	return issueops.DecryptWithKey(encrypted, s.credentialKey)
+}
+
+// encryptPeerMetadata encrypts a peer's username and remote URL with the
+// credential key.
+func (s *EmbeddedDoltStore) encryptPeerMetadata(peer *storage.FederationPeer) (*issueops.EncryptedPeerMetadata, error) {
+	if err := s.ensureCredentialKey(); err != nil {
+		return nil, err
+	}
+	url, err := issueops.EncryptWithKey(peer.RemoteURL, s.credentialKey)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("encrypt remote URL: %w", err)
 	}
-	gcm, err := cipher.NewGCM(block)
+	username, err := s.encryptPassword(peer.Username)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("encrypt username: %w", err)
 	}
-	nonceSize := gcm.NonceSize()
-	if len(encrypted) < nonceSize {
-		return "", fmt.Errorf("ciphertext too short")
+	return &issueops.EncryptedPeerMetadata{Username: username, RemoteURL: url}, nil
+}
+
+// EncryptPeerMetadata encrypts the username and remote URL of every peer
+// stored in plaintext, in one transaction.
+func (s *EmbeddedDoltStore) EncryptPeerMetadata(ctx context.Context, opts storage.PeerMetadataEncryptionOptions) (*storage.PeerMetadataEncryptionResult, error) {
+	if err := s.ensureCredentialKey(); err != nil {
+		return nil, err
 	}
-	nonce, ciphertext := encrypted[:nonceSize], encrypted[nonceSize:]
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	result := &storage.PeerMetadataEncryptionResult{DryRun: opts.DryRun}
+	err := s.withConn(ctx, !opts.DryRun, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result.Peers, err = issueops.EncryptFederationPeerMetadataInTx(ctx, tx, s.credentialKey, opts)
+		return err
+	})
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("encrypt peer metadata: %w", err)
 	}
-	return string(plaintext), nil
+	return result, nil
+}
+
+// RotateCredentialKey stages a new key next to the key file, re-encrypts
+// every peer secret with it in one transaction, and then shreds the old key
+// file and moves the new key into place.
+func (s *EmbeddedDoltStore) RotateCredentialKey(ctx context.Context, opts storage.KeyRotationOptions) (*storage.KeyRotationResult, error) {
+	if err := s.ensureCredentialKey(); err != nil {
+		return nil, err
+	}
+	keyPath := filepath.Join(s.beadsDir, credentialKeyFile)
+	result := &storage.KeyRotationResult{KeyPath: keyPath, DryRun: opts.DryRun}
+	r := issueops.SecretReencryption{OldKey: s.credentialKey, DryRun: opts.DryRun, Progress: opts.Progress}
+
+	var stagingPath string
+	if !opts.DryRun {
+		newKey, err := issueops.NewCredentialKey()
+		if err != nil {
+			return nil, err
+		}
+		r.NewKey = newKey
+		if stagingPath, err = issueops.StageCredentialKey(keyPath, newKey); err != nil {
+			return nil, err
+		}
+	}
+
+	err := s.withConn(ctx, !opts.DryRun, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result.Peers, err = issueops.ReencryptFederationSecretsInTx(ctx, tx, r)
+		return err
+	})
+	if err != nil {
+		if stagingPath != "" {
+			_ = os.Remove(stagingPath)
+		}
+		return nil, fmt.Errorf("re-encrypt credentials: %w", err)
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+	if err := issueops.CommitCredentialKey(keyPath, stagingPath); err != nil {
+		return nil, err
+	}
+	s.credentialKey = r.NewKey
+	return result, nil
 }
 
 // ---------------------------------------------------------------------------
@@ -112,9 +159,27 @@ func (s *EmbeddedDoltStore) AddFederationPeer(ctx context.Context, peer *storage
 	if err != nil {
 		return fmt.Errorf("encrypt password: %w", err)
 	}
+	encryptedPassphrase, err := s.encryptPassword(peer.SSHKeyPassphrase)
+	if err != nil {
+		return fmt.Errorf("encrypt SSH key passphrase: %w", err)
+	}
 
-	if err := s.withConn(ctx, true, func(tx *sql.Tx) error {
-		if err := issueops.AddFederationPeerInTx(ctx, tx, peer, encryptedPwd); err != nil {
+	if err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		// A peer stored with encrypted metadata stays encrypted.
+		encrypt := peer.EncryptMetadata
+		var err error
+		if !encrypt {
+			if encrypt, err = issueops.PeerMetadataEncryptedInTx(ctx, tx, peer.Name); err != nil {
+				return err
+			}
+		}
+		var metadata *issueops.EncryptedPeerMetadata
+		if encrypt {
+			if metadata, err = s.encryptPeerMetadata(peer); err != nil {
+				return err
+			}
+		}
+		if err := issueops.AddFederationPeerInTx(ctx, tx, peer, encryptedPwd, encryptedPassphrase, metadata); err != nil {
 			return err
 		}
 		// Also add the Dolt remote.
@@ -127,7 +192,7 @@ func (s *EmbeddedDoltStore) AddFederationPeer(ctx context.Context, peer *storage
 
 func (s *EmbeddedDoltStore) GetFederationPeer(ctx context.Context, name string) (*storage.FederationPeer, error) {
 	var row *issueops.FederationPeerRow
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		row, err = issueops.GetFederationPeerInTx(ctx, tx, name)
 		return err
@@ -142,12 +207,21 @@ func (s *EmbeddedDoltStore) GetFederationPeer(ctx context.Context, name string)
 			return nil, fmt.Errorf("decrypt password: %w", err)
 		}
 	}
+	if len(row.EncryptedPassphrase) > 0 {
+		row.Peer.SSHKeyPassphrase, err = s.decryptPassword(row.EncryptedPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt SSH key passphrase: %w", err)
+		}
+	}
+	if err := issueops.DecryptPeerMetadata(&row.Peer, row.EncryptedUsername, row.EncryptedRemoteURL, s.decryptPassword); err != nil {
+		return nil, err
+	}
 	return &row.Peer, nil
 }
 
 func (s *EmbeddedDoltStore) ListFederationPeers(ctx context.Context) ([]*storage.FederationPeer, error) {
 	var rows []*issueops.FederationPeerRow
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		rows, err = issueops.ListFederationPeersInTx(ctx, tx)
 		return err
@@ -165,28 +239,72 @@ func (s *EmbeddedDoltStore) ListFederationPeers(ctx context.Context) ([]*storage
 			}
 			row.Peer.Password = pwd
 		}
+		if len(row.EncryptedPassphrase) > 0 {
+			passphrase, err := s.decryptPassword(row.EncryptedPassphrase)
+			if err != nil {
+				return nil, fmt.Errorf("decrypt SSH key passphrase for peer %s: %w", row.Peer.Name, err)
+			}
+			row.Peer.SSHKeyPassphrase = passphrase
+		}
+		if err := issueops.DecryptPeerMetadata(&row.Peer, row.EncryptedUsername, row.EncryptedRemoteURL, s.decryptPassword); err != nil {
+			return nil, fmt.Errorf("peer %s: %w", row.Peer.Name, err)
+		}
 		peers = append(peers, &row.Peer)
 	}
 	return peers, nil
 }
 
 func (s *EmbeddedDoltStore) RemoveFederationPeer(ctx context.Context, name string) error {
-	if err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	if err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.RemoveFederationPeerInTx(ctx, tx, name)
 	}); err != nil {
 		return err
 	}
 
-	// Also remove the Dolt remote (best-effort).
-	if rmErr := s.RemoveRemote(ctx, name); rmErr != nil {
-		if !strings.Contains(rmErr.Error(), "not found") {
-			// Silently ignore "not found" — the remote may not exist.
-			_ = rmErr
-		}
+	// Also remove the Dolt remote (best-effort). A peer may have no remote,
+	// so "not found" is expected rather than a failure.
+	if rmErr := s.RemoveRemote(ctx, name); rmErr != nil && !strings.Contains(rmErr.Error(), "not found") {
+		besteffort.Record("remove peer remote", rmErr)
 	}
 	return nil
 }
 
+// peerSyncMode returns a peer's sync mode. Remotes that are not registered
+// federation peers sync bidirectionally.
+func (s *EmbeddedDoltStore) peerSyncMode(ctx context.Context, peer string) (storage.SyncMode, error) {
+	var mode storage.SyncMode
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		mode, err = issueops.GetFederationPeerSyncModeInTx(ctx, tx, peer)
+		return err
+	})
+	return mode, err
+}
+
+// syncConflictStrategy returns the strategy Sync applies to merge
+// conflicts: the explicit one if given, otherwise the peer's default.
+func (s *EmbeddedDoltStore) syncConflictStrategy(ctx context.Context, peer, explicit string) (storage.ConflictStrategy, error) {
+	strategy, err := storage.ParseConflictStrategy(explicit)
+	if err != nil || strategy != "" {
+		return strategy, err
+	}
+	err = s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		strategy, err = issueops.GetFederationPeerConflictStrategyInTx(ctx, tx, peer)
+		return err
+	})
+	return strategy, err
+}
+
+// checkPeerDirection refuses a push to (push) or pull from (!push) a peer
+// whose sync mode does not allow it.
+func (s *EmbeddedDoltStore) checkPeerDirection(ctx context.Context, peer string, push bool) error {
+	mode, err := s.peerSyncMode(ctx, peer)
+	if err != nil {
+		return err
+	}
+	return storage.CheckSyncDirection(peer, mode, push)
+}
+
 // ---------------------------------------------------------------------------
 // SyncStore implementation
 // ---------------------------------------------------------------------------
@@ -195,12 +313,23 @@ func (s *EmbeddedDoltStore) RemoveFederationPeer(ctx context.Context, name strin
 // 1. Fetch from peer
 // 2. Merge peer's changes (handling conflicts per strategy)
 // 3. Push local changes to peer
+//
+// A push-only peer skips steps 1 and 2 and a pull-only peer skips step 3.
 func (s *EmbeddedDoltStore) Sync(ctx context.Context, peer string, strategy string) (*storage.SyncResult, error) {
 	result := &storage.SyncResult{
 		Peer:      peer,
 		StartTime: time.Now(),
 	}
 
+	// A merge an earlier sync left conflicted must be resolved or aborted
+	// first; committing pending changes over it would fail anyway.
+	if existing, err := s.GetConflicts(ctx); err == nil && len(existing) > 0 {
+		result.Conflicts = existing
+		result.Error = fmt.Errorf("%w in %s from an earlier merge; run 'bd federation conflicts'",
+			storage.ErrMergeConflicts, storage.ConflictTables(existing))
+		return result, result.Error
+	}
+
 	// GH#2474 / bd-578h9.2: commit pending changes before the merge, matching
 	// embedded Pull/PullRemote/PullFrom and server-mode Sync. Embedded Commit is
 	// DOLT_COMMIT('-Am'), so it stages config — where kv.memory.* memories live —
@@ -212,6 +341,21 @@ func (s *EmbeddedDoltStore) Sync(ctx context.Context, peer string, strategy stri
 		return result, result.Error
 	}
 
+	mode, err := s.peerSyncMode(ctx, peer)
+	if err != nil {
+		result.Error = err
+		return result, result.Error
+	}
+	result.SyncMode = mode
+	if !mode.AllowsPull() {
+		s.finishSync(ctx, peer, result)
+		return result, nil
+	}
+	if result.ConflictStrategy, err = s.syncConflictStrategy(ctx, peer, strategy); err != nil {
+		result.Error = err
+		return result, result.Error
+	}
+
 	// Step 1: Fetch
 	if err := s.Fetch(ctx, peer); err != nil {
 		result.Error = fmt.Errorf("fetch failed: %w", err)
@@ -222,9 +366,10 @@ func (s *EmbeddedDoltStore) Sync(ctx context.Context, peer string, strategy stri
 	// Step 2: Get commit before merge for change detection
 	beforeCommit, _ := s.GetCurrentCommit(ctx)
 
-	// Step 3: Merge peer's branch
+	// Step 3: Merge peer's branch. Conflicts stay in the working set for the
+	// strategy (or bd federation conflicts) to resolve.
 	remoteBranch := fmt.Sprintf("%s/%s", peer, s.branch)
-	conflicts, err := s.Merge(ctx, remoteBranch)
+	conflicts, err := s.mergeKeepingConflicts(ctx, remoteBranch)
 	if err != nil {
 		result.Error = fmt.Errorf("merge failed: %w", err)
 		return result, result.Error
@@ -234,20 +379,25 @@ func (s *EmbeddedDoltStore) Sync(ctx context.Context, peer string, strategy stri
 	if len(conflicts) > 0 {
 		result.Conflicts = conflicts
 
-		if strategy == "" {
-			result.Error = fmt.Errorf("merge conflicts require resolution (use --strategy ours|theirs)")
+		if result.ConflictStrategy == "" {
+			result.Error = fmt.Errorf("%w: use --strategy, set a conflict strategy on the peer, or run 'bd federation conflicts'", storage.ErrMergeConflicts)
 			return result, result.Error
 		}
 
-		for _, c := range conflicts {
-			if err := s.ResolveConflicts(ctx, c.Field, strategy); err != nil {
-				result.Error = fmt.Errorf("conflict resolution failed for %s: %w", c.Field, err)
-				return result, result.Error
-			}
+		remaining, err := storage.ApplyConflictStrategy(ctx, s, conflicts, result.ConflictStrategy)
+		if err != nil {
+			result.Error = err
+			return result, result.Error
+		}
+		if len(remaining) > 0 {
+			result.Conflicts = remaining
+			result.Error = fmt.Errorf("%w: %s strategy left conflicts in %s; run 'bd federation conflicts'",
+				storage.ErrMergeConflicts, result.ConflictStrategy, storage.ConflictTables(remaining))
+			return result, result.Error
 		}
 		result.ConflictsResolved = true
 
-		if err := s.Commit(ctx, fmt.Sprintf("Resolve conflicts from %s using %s strategy", peer, strategy)); err != nil {
+		if err := s.Commit(ctx, fmt.Sprintf("Resolve conflicts from %s using %s strategy", peer, result.ConflictStrategy)); err != nil {
 			result.Error = fmt.Errorf("commit conflict resolution: %w", err)
 			return result, result.Error
 		}
@@ -263,22 +413,31 @@ func (s *EmbeddedDoltStore) Sync(ctx context.Context, peer string, strategy stri
 	result.Merged = true
 
 	afterCommit, _ := s.GetCurrentCommit(ctx)
+	result.PreMergeCommit, result.PostMergeCommit = beforeCommit, afterCommit
 	if beforeCommit != afterCommit {
 		result.PulledCommits = 1
 	}
 
+	s.finishSync(ctx, peer, result)
+	return result, nil
+}
+
+// finishSync runs Sync's push step, unless the peer is pull-only, and records
+// the sync time.
+func (s *EmbeddedDoltStore) finishSync(ctx context.Context, peer string, result *storage.SyncResult) {
 	// Step 5: Push
-	if err := s.PushTo(ctx, peer); err != nil {
-		result.PushError = err
-	} else {
-		result.Pushed = true
+	if result.SyncMode.AllowsPush() {
+		if err := s.PushTo(ctx, peer); err != nil {
+			result.PushError = err
+		} else {
+			result.Pushed = true
+		}
 	}
 
 	// Record last sync time in metadata.
-	_ = s.setLastSyncTime(ctx, peer)
+	besteffort.Record("record peer last sync", s.setLastSyncTime(ctx, peer))
 
 	result.EndTime = time.Now()
-	return result, nil
 }
 
 // SyncStatus returns the synchronization status with a peer.
@@ -324,11 +483,85 @@ func (s *EmbeddedDoltStore) SyncStatus(ctx context.Context, peer string) (*stora
 	return status, nil
 }
 
+// CheckPeer probes a federation peer by fetching from it, timing the round
+// trip and classifying any failure. Like Fetch, the embedded engine
+// authenticates as DOLT_REMOTE_USER rather than with the peer's stored
+// credentials. After a successful fetch it reports the last commit the
+// local branch shares with the peer's.
+func (s *EmbeddedDoltStore) CheckPeer(ctx context.Context, name string) (*storage.PeerHealth, error) {
+	remotes, err := s.ListRemotes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	health := &storage.PeerHealth{Peer: name}
+	for _, r := range remotes {
+		if r.Name == name {
+			health.URL = r.URL
+		}
+	}
+	if health.URL == "" {
+		return nil, fmt.Errorf("%w: federation peer %s", storage.ErrNotFound, name)
+	}
+	health.UsedCredentials = remoteAuthUser() != ""
+
+	start := time.Now()
+	fetchErr := s.Fetch(ctx, name)
+	health.Latency = time.Since(start)
+	if fetchErr != nil {
+		health.Status = storage.ClassifyPeerError(fetchErr)
+		health.Error = fetchErr
+		return health, nil
+	}
+	health.Status = storage.PeerHealthOK
+
+	// A peer that has never received this branch has no common commit.
+	_ = s.withDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		if base, err := versioncontrolops.MergeBase(ctx, db, s.branch, name+"/"+s.branch); err == nil {
+			health.LastCommonCommit = base
+		}
+		return nil
+	})
+	return health, nil
+}
+
+// PreviewSync fetches from a peer and reports what Sync would push and
+// pull, without merging or pushing.
+func (s *EmbeddedDoltStore) PreviewSync(ctx context.Context, peer string) (*storage.SyncPreview, error) {
+	mode, err := s.peerSyncMode(ctx, peer)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Fetch(ctx, peer); err != nil {
+		return nil, fmt.Errorf("fetch failed: %w", err)
+	}
+	var preview *storage.SyncPreview
+	err = s.withDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		preview, err = versioncontrolops.PreviewSync(ctx, db, peer, s.branch, mode)
+		return err
+	})
+	return preview, err
+}
+
+// VerifyPeer fetches from a peer and compares digests of the local issues
+// with the peer's.
+func (s *EmbeddedDoltStore) VerifyPeer(ctx context.Context, peer string) (*storage.PeerVerification, error) {
+	if err := s.Fetch(ctx, peer); err != nil {
+		return nil, fmt.Errorf("fetch failed: %w", err)
+	}
+	var v *storage.PeerVerification
+	err := s.withDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		var err error
+		v, err = versioncontrolops.VerifyPeer(ctx, db, peer, s.branch)
+		return err
+	})
+	return v, err
+}
+
 // setLastSyncTime records the last sync time for a peer in metadata.
 func (s *EmbeddedDoltStore) setLastSyncTime(ctx context.Context, peer string) error {
 	key := "last_sync_" + peer
 	value := time.Now().Format(time.RFC3339)
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		_, err := tx.ExecContext(ctx,
 			"REPLACE INTO metadata (`key`, value) VALUES (?, ?)", key, value)
 		return err
@@ -339,7 +572,7 @@ func (s *EmbeddedDoltStore) setLastSyncTime(ctx context.Context, peer string) er
 func (s *EmbeddedDoltStore) getLastSyncTime(ctx context.Context, peer string) time.Time {
 	key := "last_sync_" + peer
 	var value string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		return tx.QueryRowContext(ctx, "SELECT value FROM metadata WHERE `key` = ?", key).Scan(&value)
 	})
 	if err != nil {
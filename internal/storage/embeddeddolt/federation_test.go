@@ -0,0 +1,170 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+)
+
+func TestFederationPeerSSHKey(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "fsk")
+	ctx := t.Context()
+
+	peer := &storage.FederationPeer{
+		Name:             "vault",
+		RemoteURL:        "git+ssh://git@vault.invalid/beads.git",
+		SSHKeyPath:       "/home/sync/.ssh/beads_sync",
+		SSHKeyPassphrase: "s3cret",
+	}
+	if err := te.store.AddFederationPeer(ctx, peer); err != nil {
+		t.Fatalf("AddFederationPeer: %v", err)
+	}
+
+	got, err := te.store.GetFederationPeer(ctx, "vault")
+	if err != nil {
+		t.Fatalf("GetFederationPeer: %v", err)
+	}
+	if got.SSHKeyPath != peer.SSHKeyPath || got.SSHKeyPassphrase != peer.SSHKeyPassphrase {
+		t.Errorf("got key %q passphrase %q, want %q %q", got.SSHKeyPath, got.SSHKeyPassphrase, peer.SSHKeyPath, peer.SSHKeyPassphrase)
+	}
+	if got.Username != "" || got.Password != "" {
+		t.Errorf("SQL credentials should be empty, got %q/%q", got.Username, got.Password)
+	}
+
+	peers, err := te.store.ListFederationPeers(ctx)
+	if err != nil {
+		t.Fatalf("ListFederationPeers: %v", err)
+	}
+	if len(peers) != 1 || peers[0].SSHKeyPassphrase != "s3cret" {
+		t.Errorf("ListFederationPeers = %+v", peers)
+	}
+}
+
+func TestFederationPeerSyncMode(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "fsm")
+	ctx := t.Context()
+
+	peer := &storage.FederationPeer{
+		Name:      "upstream",
+		RemoteURL: "file:///tmp/beads-no-such-embedded-upstream",
+		SyncMode:  storage.SyncModePullOnly,
+	}
+	if err := te.store.AddFederationPeer(ctx, peer); err != nil {
+		t.Fatalf("AddFederationPeer: %v", err)
+	}
+	got, err := te.store.GetFederationPeer(ctx, "upstream")
+	if err != nil {
+		t.Fatalf("GetFederationPeer: %v", err)
+	}
+	if got.SyncMode != storage.SyncModePullOnly {
+		t.Errorf("SyncMode = %q, want pull-only", got.SyncMode)
+	}
+
+	if err := te.store.PushTo(ctx, "upstream"); !errors.Is(err, storage.ErrSyncDirection) {
+		t.Errorf("PushTo pull-only peer: err = %v, want ErrSyncDirection", err)
+	}
+
+	peer.SyncMode = storage.SyncModePushOnly
+	if err := te.store.AddFederationPeer(ctx, peer); err != nil {
+		t.Fatalf("AddFederationPeer (update): %v", err)
+	}
+	if _, err := te.store.PullFrom(ctx, "upstream"); !errors.Is(err, storage.ErrSyncDirection) {
+		t.Errorf("PullFrom push-only peer: err = %v, want ErrSyncDirection", err)
+	}
+}
+
+func TestFederationPeerConflictStrategy(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "fcs")
+	ctx := t.Context()
+
+	peer := &storage.FederationPeer{
+		Name:             "upstream",
+		RemoteURL:        "file:///tmp/beads-no-such-embedded-upstream",
+		ConflictStrategy: storage.ConflictStrategyNewest,
+	}
+	if err := te.store.AddFederationPeer(ctx, peer); err != nil {
+		t.Fatalf("AddFederationPeer: %v", err)
+	}
+	got, err := te.store.GetFederationPeer(ctx, "upstream")
+	if err != nil {
+		t.Fatalf("GetFederationPeer: %v", err)
+	}
+	if got.ConflictStrategy != storage.ConflictStrategyNewest {
+		t.Errorf("ConflictStrategy = %q, want newest", got.ConflictStrategy)
+	}
+}
+
+func TestRotateCredentialKey(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "frk")
+	ctx := t.Context()
+
+	peers := []*storage.FederationPeer{
+		{Name: "alpha", RemoteURL: "file:///tmp/beads-no-such-alpha", Username: "alice", Password: "pw-alpha"},
+		{Name: "beta", RemoteURL: "git+ssh://git@beta.invalid/beads.git", SSHKeyPath: "/home/sync/.ssh/beta", SSHKeyPassphrase: "pp-beta"},
+		{Name: "plain", RemoteURL: "file:///tmp/beads-no-such-plain", Sovereignty: "T2"},
+	}
+	for _, p := range peers {
+		if err := te.store.AddFederationPeer(ctx, p); err != nil {
+			t.Fatalf("AddFederationPeer %s: %v", p.Name, err)
+		}
+	}
+
+	dry, err := te.store.RotateCredentialKey(ctx, storage.KeyRotationOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("RotateCredentialKey dry run: %v", err)
+	}
+	oldKey, err := os.ReadFile(dry.KeyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []string
+	result, err := te.store.RotateCredentialKey(ctx, storage.KeyRotationOptions{
+		Progress: func(peer string) { progress = append(progress, peer) },
+	})
+	if err != nil {
+		t.Fatalf("RotateCredentialKey: %v", err)
+	}
+	want := []string{"alpha", "beta"}
+	if !slices.Equal(dry.Peers, want) || !slices.Equal(result.Peers, want) || !slices.Equal(progress, want) {
+		t.Errorf("dry run peers %v, peers %v, progress %v, want %v", dry.Peers, result.Peers, progress, want)
+	}
+	newKey, err := os.ReadFile(result.KeyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newKey) != 32 || bytes.Equal(newKey, oldKey) {
+		t.Fatal("key file was not replaced with a new key")
+	}
+
+	// A fresh store reads the new key from disk and decrypts every secret.
+	if err := te.store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	reopened, err := embeddeddolt.Open(ctx, filepath.Dir(te.dataDir), te.database, "main")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer reopened.Close()
+	for _, p := range peers[:2] {
+		got, err := reopened.GetFederationPeer(ctx, p.Name)
+		if err != nil {
+			t.Fatalf("GetFederationPeer %s: %v", p.Name, err)
+		}
+		if got.Password != p.Password || got.SSHKeyPassphrase != p.SSHKeyPassphrase {
+			t.Errorf("%s: got password %q passphrase %q after rotation", p.Name, got.Password, got.SSHKeyPassphrase)
+		}
+	}
+}
@@ -12,7 +12,7 @@ import (
 
 func (s *EmbeddedDoltStore) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
 	var issue *types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		issue, err = issueops.GetIssueInTx(ctx, tx, id)
 		return err
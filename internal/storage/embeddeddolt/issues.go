@@ -17,7 +17,7 @@ import (
 // ClaimIssue atomically claims an issue using compare-and-swap semantics.
 // Delegates SQL work to issueops; EmbeddedDolt auto-commits the transaction.
 func (s *EmbeddedDoltStore) ClaimIssue(ctx context.Context, id string, actor string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		_, err := issueops.ClaimIssueInTx(ctx, tx, id, actor)
 		return err
 	})
@@ -26,7 +26,7 @@ func (s *EmbeddedDoltStore) ClaimIssue(ctx context.Context, id string, actor str
 // ClaimReadyIssue atomically claims the first ready issue matching filter.
 func (s *EmbeddedDoltStore) ClaimReadyIssue(ctx context.Context, filter types.WorkFilter, actor string) (*types.Issue, error) {
 	var claimed *types.Issue
-	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		claimed, err = issueops.ClaimReadyIssueInTx(ctx, tx, filter, actor)
 		return err
@@ -38,7 +38,7 @@ func (s *EmbeddedDoltStore) ClaimReadyIssue(ctx context.Context, filter types.Wo
 // and resetting status to "open". Records an "unclaimed" event.
 // Delegates SQL work to issueops; EmbeddedDolt auto-commits the transaction.
 func (s *EmbeddedDoltStore) UnclaimIssue(ctx context.Context, id string, actor string, force bool) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.UnclaimIssueInTx(ctx, tx, id, actor, force)
 	})
 }
@@ -49,7 +49,7 @@ func (s *EmbeddedDoltStore) UnclaimIssue(ctx context.Context, id string, actor s
 // assignee differs. Delegates SQL work to issueops; EmbeddedDolt auto-commits
 // the transaction.
 func (s *EmbeddedDoltStore) UnclaimIssueIfAssignee(ctx context.Context, id string, actor string, expectedAssignee string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.UnclaimIssueIfAssigneeInTx(ctx, tx, id, actor, expectedAssignee)
 	})
 }
@@ -68,7 +68,7 @@ func (s *EmbeddedDoltStore) UpdateIssue(ctx context.Context, id string, updates
 		}
 	}
 
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		_, err := issueops.UpdateIssueInTx(ctx, tx, id, updates, actor)
 		return err
 	})
@@ -95,7 +95,7 @@ func (s *EmbeddedDoltStore) UpdateIssueChecked(ctx context.Context, id string, u
 		}
 	}
 
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		if opts.ExpectedVersion != nil {
 			if err := issueops.CheckVersionInTx(ctx, tx, id, *opts.ExpectedVersion); err != nil {
 				return err
@@ -109,7 +109,7 @@ func (s *EmbeddedDoltStore) UpdateIssueChecked(ctx context.Context, id string, u
 // HeartbeatIssue refreshes the lease on an issue actor holds in_progress.
 // Delegates SQL work to issueops; EmbeddedDolt auto-commits the transaction.
 func (s *EmbeddedDoltStore) HeartbeatIssue(ctx context.Context, id, actor string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		if issueops.IsActiveWispInTx(ctx, tx, id) {
 			// Wisps are ephemeral and never leased; nothing to heartbeat.
 			return fmt.Errorf("%w: %s is ephemeral", storage.ErrNotClaimable, id)
@@ -121,11 +121,10 @@ func (s *EmbeddedDoltStore) HeartbeatIssue(ctx context.Context, id, actor string
 // ReclaimExpiredLeases reverts in_progress issues whose lease expired more than
 // olderThan ago back to ready, recovering work stranded by dead workers.
 func (s *EmbeddedDoltStore) ReclaimExpiredLeases(ctx context.Context, olderThan time.Duration, actor string) ([]types.ReclaimedLease, error) {
-	cutoff := time.Now().UTC().Add(-olderThan)
 	var reclaimed []types.ReclaimedLease
-	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		reclaimed, err = issueops.ReclaimExpiredLeasesInTx(ctx, tx, cutoff, actor)
+		reclaimed, err = issueops.ReclaimExpiredLeasesInTx(ctx, tx, storage.Now(ctx).Add(-olderThan), actor)
 		return err
 	})
 	return reclaimed, err
@@ -133,8 +132,12 @@ func (s *EmbeddedDoltStore) ReclaimExpiredLeases(ctx context.Context, olderThan
 
 // ReopenIssue reopens a closed issue, setting status to open and clearing
 // closed_at and defer_until. If reason is non-empty, it is recorded as a comment.
+// The reopen is logged in metadata.reopens with the note attached to ctx.
 // Wraps UpdateIssue; EmbeddedDolt auto-commits the transaction.
 func (s *EmbeddedDoltStore) ReopenIssue(ctx context.Context, id string, reason string, actor string) error {
+	note := storage.ReopenNoteFrom(ctx)
+	note.Reason = reason
+	ctx = storage.WithReopenNote(ctx, note)
 	updates := map[string]interface{}{
 		"status":      string(types.StatusOpen),
 		"defer_until": nil,
@@ -159,7 +162,7 @@ func (s *EmbeddedDoltStore) UpdateIssueType(ctx context.Context, id string, issu
 // CloseIssue closes an issue with a reason.
 // Delegates SQL work to issueops; EmbeddedDolt auto-commits the transaction.
 func (s *EmbeddedDoltStore) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		_, err := issueops.CloseIssueInTx(ctx, tx, id, reason, actor, session)
 		return err
 	})
@@ -174,12 +177,15 @@ func (s *EmbeddedDoltStore) CloseIssue(ctx context.Context, id string, reason st
 // the transaction.
 func (s *EmbeddedDoltStore) CloseIssueChecked(ctx context.Context, id string, actor string, opts storage.CloseIssueOptions) (storage.CloseIssueResult, error) {
 	var result storage.CloseIssueResult
-	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		res, err := issueops.CloseIssueCheckedInTx(ctx, tx, id, opts.Reason, actor, opts.Session, opts.Force, opts.ExpectedVersion)
 		if err != nil {
 			return err
 		}
 		result = storage.CloseIssueResult{Unchanged: res.AlreadyClosed}
+		if opts.DuplicateOf != "" {
+			return issueops.LinkDuplicateInTx(ctx, tx, id, opts.DuplicateOf, actor)
+		}
 		return nil
 	})
 	if err != nil {
@@ -192,7 +198,7 @@ func (s *EmbeddedDoltStore) CloseIssueChecked(ctx context.Context, id string, ac
 func (s *EmbeddedDoltStore) IsBlocked(ctx context.Context, issueID string) (bool, []string, error) {
 	var blocked bool
 	var blockers []string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		blocked, blockers, err = issueops.IsBlockedInTx(ctx, tx, issueID)
 		return err
@@ -204,7 +210,7 @@ func (s *EmbeddedDoltStore) IsBlocked(ctx context.Context, issueID string) (bool
 // in one batched read. Delegates to issueops.IsBlockedBatchInTx.
 func (s *EmbeddedDoltStore) IsBlockedBatch(ctx context.Context, ids []string) (map[string]bool, error) {
 	var result map[string]bool
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.IsBlockedBatchInTx(ctx, tx, ids)
 		return err
@@ -215,7 +221,7 @@ func (s *EmbeddedDoltStore) IsBlockedBatch(ctx context.Context, ids []string) (m
 // GetNewlyUnblockedByClose finds issues that become unblocked when closedIssueID is closed.
 func (s *EmbeddedDoltStore) GetNewlyUnblockedByClose(ctx context.Context, closedIssueID string) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetNewlyUnblockedByCloseInTx(ctx, tx, closedIssueID)
 		return err
@@ -53,7 +53,7 @@ func TestSearchIssuesKeysetEmbedded(t *testing.T) {
 		return true
 	}
 
-	full, err := te.store.SearchIssues(ctx, "", types.IssueFilter{IDPrefix: "k-", SkipWisps: true, SortBy: "created", Limit: 100})
+	full, err := te.store.SearchIssues(ctx, "", types.IssueFilter{IDPrefix: "k-", SkipWisps: true, SortBy: []types.SortKey{{Field: "created", Desc: true}}, Limit: 100})
 	if err != nil {
 		t.Fatalf("SearchIssues(full): %v", err)
 	}
@@ -68,7 +68,7 @@ func TestSearchIssuesKeysetEmbedded(t *testing.T) {
 	afterID := ""
 	for i := 0; i < 100; i++ {
 		page, err := te.store.SearchIssues(ctx, "", types.IssueFilter{
-			IDPrefix: "k-", SkipWisps: true, SortBy: "created", Limit: pageSize,
+			IDPrefix: "k-", SkipWisps: true, SortBy: []types.SortKey{{Field: "created", Desc: true}}, Limit: pageSize,
 			AfterCreatedAt: afterCreatedAt, AfterID: afterID,
 		})
 		if err != nil {
@@ -93,3 +93,52 @@ func TestSearchIssuesKeysetEmbedded(t *testing.T) {
 		t.Fatalf("keyset paged order = %v, want %v (no drop/dup)", collected, want)
 	}
 }
+
+// TestSearchIssuesSortByAssigneeMergesNullAndEmpty pins that a page merged
+// from issues and wisps sorted by assignee keeps the rows SQL would select
+// when NULL and empty assignees are mixed: SQL must not sort NULL before an
+// empty string while the in-memory merge sees both as "".
+func TestSearchIssuesSortByAssigneeMergesNullAndEmpty(t *testing.T) {
+	te := newTestEnv(t, "as")
+	ctx := t.Context()
+
+	seeds := []struct {
+		id        string
+		ephemeral bool
+		empty     bool // store '' rather than NULL
+	}{
+		{"as-a", false, true},
+		{"as-y", false, false},
+		{"as-z", false, false},
+		{"as-b", true, false},
+		{"as-c", true, true},
+	}
+	for _, s := range seeds {
+		iss := &types.Issue{ID: s.id, Title: s.id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Ephemeral: s.ephemeral}
+		if err := te.store.CreateIssue(ctx, iss, "tester"); err != nil {
+			t.Fatalf("create %s: %v", s.id, err)
+		}
+		if s.empty {
+			table := "issues"
+			if s.ephemeral {
+				table = "wisps"
+			}
+			te.exec(t, ctx, "UPDATE "+table+" SET assignee = '' WHERE id = ?", s.id)
+		}
+	}
+
+	filter := types.IssueFilter{IDPrefix: "as-", SortBy: []types.SortKey{{Field: "assignee"}}, Limit: 2}
+	want := []string{"as-a", "as-b"}
+
+	items, err := te.store.SearchIssuesWithCounts(ctx, "", filter)
+	if err != nil {
+		t.Fatalf("SearchIssuesWithCounts: %v", err)
+	}
+	got := make([]string, len(items))
+	for i, iwc := range items {
+		got[i] = iwc.Issue.ID
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("SearchIssuesWithCounts page = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,43 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func (s *EmbeddedDoltStore) UpsertKnowledge(ctx context.Context, entry *types.KnowledgeEntry) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		return issueops.UpsertKnowledgeInTx(ctx, tx, entry)
+	})
+}
+
+func (s *EmbeddedDoltStore) GetKnowledge(ctx context.Context, id string) (*types.KnowledgeEntry, error) {
+	var result *types.KnowledgeEntry
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetKnowledgeInTx(ctx, tx, id)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) SearchKnowledge(ctx context.Context, query string, limit int) ([]*types.KnowledgeEntry, error) {
+	var result []*types.KnowledgeEntry
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.SearchKnowledgeInTx(ctx, tx, query, limit)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) DeleteKnowledge(ctx context.Context, id string) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		return issueops.DeleteKnowledgeInTx(ctx, tx, id)
+	})
+}
@@ -0,0 +1,62 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestKnowledge(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "kn")
+	ctx := t.Context()
+
+	entry := &types.KnowledgeEntry{
+		ID:         types.KnowledgeID([]string{"kn-1"}),
+		Title:      "Config merges",
+		Content:    "## Resolution\nCommit config before pulling",
+		Tags:       []string{"dolt"},
+		BondedFrom: []types.BondRef{{SourceID: "kn-1", BondType: types.BondTypeCrystallized}},
+		CreatedBy:  "tester",
+	}
+	if err := te.store.UpsertKnowledge(ctx, entry); err != nil {
+		t.Fatalf("UpsertKnowledge: %v", err)
+	}
+	created := entry.CreatedAt
+
+	entry.Content = "## Resolution\nUse bd dolt commit first"
+	if err := te.store.UpsertKnowledge(ctx, entry); err != nil {
+		t.Fatalf("UpsertKnowledge (update): %v", err)
+	}
+	got, err := te.store.GetKnowledge(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("GetKnowledge: %v", err)
+	}
+	if got.Content != entry.Content || !got.CreatedAt.Equal(created) {
+		t.Errorf("after update got %+v, want content %q and created_at %v", got, entry.Content, created)
+	}
+	if len(got.BondedFrom) != 1 || got.BondedFrom[0].SourceID != "kn-1" {
+		t.Errorf("bonded_from = %+v", got.BondedFrom)
+	}
+
+	for query, want := range map[string]int{"": 1, "DOLT": 1, "bd dolt commit": 1, "nothing": 0} {
+		found, err := te.store.SearchKnowledge(ctx, query, 0)
+		if err != nil {
+			t.Fatalf("SearchKnowledge(%q): %v", query, err)
+		}
+		if len(found) != want {
+			t.Errorf("SearchKnowledge(%q) = %d entries, want %d", query, len(found), want)
+		}
+	}
+
+	if err := te.store.DeleteKnowledge(ctx, entry.ID); err != nil {
+		t.Fatalf("DeleteKnowledge: %v", err)
+	}
+	if _, err := te.store.GetKnowledge(ctx, entry.ID); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("GetKnowledge after delete: err = %v, want ErrNotFound", err)
+	}
+}
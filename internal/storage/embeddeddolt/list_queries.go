@@ -12,7 +12,7 @@ import (
 
 func (s *EmbeddedDoltStore) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.SearchIssuesInTx(ctx, tx, query, filter)
 		return err
@@ -23,7 +23,7 @@ func (s *EmbeddedDoltStore) SearchIssues(ctx context.Context, query string, filt
 // SearchIssueIDs is the narrow-projection variant of SearchIssues.
 func (s *EmbeddedDoltStore) SearchIssueIDs(ctx context.Context, query string, filter types.IssueFilter) ([]string, error) {
 	var result []string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.SearchIssueIDsInTx(ctx, tx, query, filter)
 		return err
@@ -33,7 +33,7 @@ func (s *EmbeddedDoltStore) SearchIssueIDs(ctx context.Context, query string, fi
 
 func (s *EmbeddedDoltStore) SearchIssuesWithCounts(ctx context.Context, query string, filter types.IssueFilter) ([]*types.IssueWithCounts, error) {
 	var result []*types.IssueWithCounts
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.SearchIssuesWithCountsInTx(ctx, tx, query, filter)
 		return err
@@ -44,7 +44,7 @@ func (s *EmbeddedDoltStore) SearchIssuesWithCounts(ctx context.Context, query st
 func (s *EmbeddedDoltStore) ListWisps(ctx context.Context, filter types.WispFilter) ([]*types.Issue, error) {
 	issueFilter := issueops.WispFilterToIssueFilter(filter)
 	var result []*types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.SearchIssuesInTx(ctx, tx, "", issueFilter)
 		return err
@@ -54,7 +54,7 @@ func (s *EmbeddedDoltStore) ListWisps(ctx context.Context, filter types.WispFilt
 
 func (s *EmbeddedDoltStore) GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
 	var result map[string][]string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetLabelsForIssuesInTx(ctx, tx, issueIDs)
 		return err
@@ -64,7 +64,7 @@ func (s *EmbeddedDoltStore) GetLabelsForIssues(ctx context.Context, issueIDs []s
 
 func (s *EmbeddedDoltStore) GetCommentCounts(ctx context.Context, issueIDs []string) (map[string]int, error) {
 	var result map[string]int
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetCommentCountsInTx(ctx, tx, issueIDs)
 		return err
@@ -74,7 +74,7 @@ func (s *EmbeddedDoltStore) GetCommentCounts(ctx context.Context, issueIDs []str
 
 func (s *EmbeddedDoltStore) GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
 	var result map[string][]*types.Dependency
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetAllDependencyRecordsInTx(ctx, tx)
 		return err
@@ -84,7 +84,7 @@ func (s *EmbeddedDoltStore) GetAllDependencyRecords(ctx context.Context) (map[st
 
 func (s *EmbeddedDoltStore) GetDependencyRecordsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Dependency, error) {
 	var result map[string][]*types.Dependency
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependencyRecordsForIssuesInTx(ctx, tx, issueIDs)
 		return err
@@ -97,7 +97,7 @@ func (s *EmbeddedDoltStore) GetDependencyRecordsForIssues(ctx context.Context, i
 // issueops.GetDependentRecordsForIssuesInTx for shared query logic.
 func (s *EmbeddedDoltStore) GetDependentRecordsForIssues(ctx context.Context, targetIDs []string) (map[string][]*types.Dependency, error) {
 	var result map[string][]*types.Dependency
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependentRecordsForIssuesInTx(ctx, tx, targetIDs)
 		return err
@@ -107,7 +107,7 @@ func (s *EmbeddedDoltStore) GetDependentRecordsForIssues(ctx context.Context, ta
 
 func (s *EmbeddedDoltStore) GetDependencyCounts(ctx context.Context, issueIDs []string) (map[string]*types.DependencyCounts, error) {
 	var result map[string]*types.DependencyCounts
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependencyCountsInTx(ctx, tx, issueIDs)
 		return err
@@ -121,7 +121,7 @@ func (s *EmbeddedDoltStore) GetBlockingInfoForIssues(ctx context.Context, issueI
 	parentMap map[string]string,
 	err error,
 ) {
-	err = s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err = s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var txErr error
 		blockedByMap, blocksMap, parentMap, txErr = issueops.GetBlockingInfoForIssuesInTx(ctx, tx, issueIDs)
 		return txErr
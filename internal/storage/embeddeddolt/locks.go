@@ -0,0 +1,52 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// LockIssue takes or extends an advisory edit lock on an issue.
+func (s *EmbeddedDoltStore) LockIssue(ctx context.Context, issueID, holder string, ttl time.Duration, reason string, force bool) (*types.IssueLock, error) {
+	var result *types.IssueLock
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.LockIssueInTx(ctx, tx, issueID, holder, ttl, reason, force)
+		return err
+	})
+	return result, err
+}
+
+// UnlockIssue releases an issue's advisory edit lock.
+func (s *EmbeddedDoltStore) UnlockIssue(ctx context.Context, issueID, holder string, force bool) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		return issueops.UnlockIssueInTx(ctx, tx, issueID, holder, force)
+	})
+}
+
+// GetIssueLocks returns the live locks on the given issues.
+func (s *EmbeddedDoltStore) GetIssueLocks(ctx context.Context, issueIDs []string) (map[string]*types.IssueLock, error) {
+	var result map[string]*types.IssueLock
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueLocksInTx(ctx, tx, issueIDs)
+		return err
+	})
+	return result, err
+}
+
+// ListIssueLocks returns every live lock.
+func (s *EmbeddedDoltStore) ListIssueLocks(ctx context.Context) ([]*types.IssueLock, error) {
+	var result []*types.IssueLock
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ListIssueLocksInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
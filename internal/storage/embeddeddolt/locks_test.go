@@ -0,0 +1,81 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueLocks(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "lk")
+	clock := storage.NewFakeClock(time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC))
+	ctx := storage.WithClock(t.Context(), clock)
+
+	issue := &types.Issue{Title: "Rewrite the spec", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := te.store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	lock, err := te.store.LockIssue(ctx, issue.ID, "alice", 15*time.Minute, "rewriting", false)
+	if err != nil {
+		t.Fatalf("LockIssue: %v", err)
+	}
+	if lock.Holder != "alice" || !lock.ExpiresAt.Equal(clock.Now().Add(15*time.Minute)) {
+		t.Errorf("lock = %+v; want alice until 09:15", lock)
+	}
+	if _, err := te.store.LockIssue(ctx, issue.ID, "bob", time.Hour, "", false); !errors.Is(err, storage.ErrIssueLocked) {
+		t.Fatalf("LockIssue by bob = %v; want ErrIssueLocked", err)
+	}
+	if err := te.store.UnlockIssue(ctx, issue.ID, "bob", false); !errors.Is(err, storage.ErrIssueLocked) {
+		t.Fatalf("UnlockIssue by bob = %v; want ErrIssueLocked", err)
+	}
+
+	// Locking again extends the holder's lock and keeps when it was taken.
+	clock.Advance(10 * time.Minute)
+	extended, err := te.store.LockIssue(ctx, issue.ID, "alice", 15*time.Minute, "", false)
+	if err != nil {
+		t.Fatalf("extending: %v", err)
+	}
+	if !extended.LockedAt.Equal(lock.LockedAt) || extended.Reason != "rewriting" ||
+		!extended.ExpiresAt.Equal(clock.Now().Add(15*time.Minute)) {
+		t.Errorf("extended lock = %+v; want original LockedAt and reason, new expiry", extended)
+	}
+
+	locks, err := te.store.GetIssueLocks(ctx, []string{issue.ID})
+	if err != nil || locks[issue.ID] == nil || locks[issue.ID].Holder != "alice" {
+		t.Fatalf("GetIssueLocks = %v, %v; want alice's lock", locks, err)
+	}
+
+	// An expired lock no longer counts and can be taken by anyone.
+	clock.Advance(16 * time.Minute)
+	if locks, err := te.store.GetIssueLocks(ctx, []string{issue.ID}); err != nil || len(locks) != 0 {
+		t.Errorf("GetIssueLocks after expiry = %v, %v; want none", locks, err)
+	}
+	if listed, err := te.store.ListIssueLocks(ctx); err != nil || len(listed) != 0 {
+		t.Errorf("ListIssueLocks after expiry = %v, %v; want none", listed, err)
+	}
+	if _, err := te.store.LockIssue(ctx, issue.ID, "bob", time.Hour, "", false); err != nil {
+		t.Fatalf("LockIssue by bob after expiry: %v", err)
+	}
+
+	// --force takes over and releases someone else's lock.
+	if lock, err := te.store.LockIssue(ctx, issue.ID, "alice", time.Hour, "", true); err != nil || lock.Holder != "alice" {
+		t.Fatalf("forced LockIssue = %+v, %v; want alice", lock, err)
+	}
+	if err := te.store.UnlockIssue(ctx, issue.ID, "bob", true); err != nil {
+		t.Fatalf("forced UnlockIssue: %v", err)
+	}
+	if listed, err := te.store.ListIssueLocks(ctx); err != nil || len(listed) != 0 {
+		t.Errorf("ListIssueLocks after unlock = %v, %v; want none", listed, err)
+	}
+
+	if _, err := te.store.LockIssue(ctx, "lk-missing", "alice", time.Hour, "", false); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("LockIssue on missing issue = %v; want ErrNotFound", err)
+	}
+}
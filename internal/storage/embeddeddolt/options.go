@@ -0,0 +1,23 @@
+package embeddeddolt
+
+import "github.com/steveyegge/beads/internal/storage"
+
+// Option configures a store opened with Open.
+type Option func(*openOptions)
+
+type openOptions struct {
+	clock storage.Clock
+	idGen storage.IDGenerator
+}
+
+// WithClock makes the store read the current time from c in every
+// operation. A storage.WithClock context overrides it for one call.
+func WithClock(c storage.Clock) Option {
+	return func(o *openOptions) { o.clock = c }
+}
+
+// WithIDGenerator makes the store mint hash-mode issue IDs with g. A
+// storage.WithIDGenerator context overrides it for one call.
+func WithIDGenerator(g storage.IDGenerator) Option {
+	return func(o *openOptions) { o.idGen = g }
+}
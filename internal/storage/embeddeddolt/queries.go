@@ -12,7 +12,7 @@ import (
 
 func (s *EmbeddedDoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetReadyWorkInTx(ctx, tx, filter)
 		return err
@@ -22,7 +22,7 @@ func (s *EmbeddedDoltStore) GetReadyWork(ctx context.Context, filter types.WorkF
 
 func (s *EmbeddedDoltStore) GetReadyWorkWithCounts(ctx context.Context, filter types.WorkFilter) ([]*types.IssueWithCounts, error) {
 	var result []*types.IssueWithCounts
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetReadyWorkWithCountsInTx(ctx, tx, filter)
 		return err
@@ -36,7 +36,7 @@ func (s *EmbeddedDoltStore) GetReadyWorkWithCounts(ctx context.Context, filter t
 // storage.ReadyWorkCounter capability.
 func (s *EmbeddedDoltStore) CountReadyWork(ctx context.Context, filter types.WorkFilter) (int, error) {
 	var n int
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		n, err = issueops.CountReadyWorkInTx(ctx, tx, filter)
 		return err
@@ -46,7 +46,7 @@ func (s *EmbeddedDoltStore) CountReadyWork(ctx context.Context, filter types.Wor
 
 func (s *EmbeddedDoltStore) GetMoleculeProgress(ctx context.Context, moleculeID string) (*types.MoleculeProgressStats, error) {
 	var result *types.MoleculeProgressStats
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetMoleculeProgressInTx(ctx, tx, moleculeID)
 		return err
@@ -0,0 +1,27 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func (s *EmbeddedDoltStore) RecordRun(ctx context.Context, run *types.Run, actor string) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		return issueops.RecordRunInTx(ctx, tx, run, actor)
+	})
+}
+
+func (s *EmbeddedDoltStore) ListRuns(ctx context.Context, issueID string, limit int) ([]*types.Run, error) {
+	var result []*types.Run
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ListRunsInTx(ctx, tx, issueID, limit)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,74 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRuns(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "rn")
+	ctx := t.Context()
+
+	issue := &types.Issue{Title: "Flaky build", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := te.store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	early := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := &types.Run{IssueID: issue.ID, Name: "eval", Status: types.RunStatusPassed, StartedAt: &early}
+	old.ID = types.RunID(issue.ID, old.Name, "", early)
+	ci := &types.Run{IssueID: issue.ID, Name: "ci", ExternalID: "7", Status: types.RunStatusRunning, Artifacts: []string{"log.txt"}}
+	ci.ID = types.RunID(issue.ID, ci.Name, ci.ExternalID, time.Time{})
+	for _, run := range []*types.Run{old, ci} {
+		if err := te.store.RecordRun(ctx, run, "tester"); err != nil {
+			t.Fatalf("RecordRun: %v", err)
+		}
+	}
+	created := ci.CreatedAt
+
+	ci.Status = types.RunStatusFailed
+	ci.DurationMS = 1500
+	if err := te.store.RecordRun(ctx, ci, "tester"); err != nil {
+		t.Fatalf("RecordRun (update): %v", err)
+	}
+
+	runs, err := te.store.ListRuns(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("ListRuns: %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != ci.ID || runs[1].ID != old.ID {
+		t.Fatalf("ListRuns = %+v, want the ci run then the older eval run", runs)
+	}
+	if got := runs[0]; got.Status != types.RunStatusFailed || got.DurationMS != 1500 ||
+		len(got.Artifacts) != 1 || !got.CreatedAt.Equal(created) {
+		t.Errorf("updated run = %+v, want failed, 1500ms, one artifact, created_at %v", got, created)
+	}
+
+	got, err := te.store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	var meta map[string]string
+	if err := json.Unmarshal(got.Metadata, &meta); err != nil || meta[types.LastRunStatusMetadataKey] != types.RunStatusFailed {
+		t.Errorf("metadata = %s, want last_run_status mirrored as failed", got.Metadata)
+	}
+	failed, err := te.store.SearchIssues(ctx, "", types.IssueFilter{
+		MetadataFields: map[string]string{types.LastRunStatusMetadataKey: types.RunStatusFailed},
+	})
+	if err != nil || len(failed) != 1 {
+		t.Errorf("SearchIssues by last run = %d issues (err %v), want 1", len(failed), err)
+	}
+
+	missing := &types.Run{ID: "run-x", IssueID: "rn-nope", Status: types.RunStatusPassed}
+	if err := te.store.RecordRun(ctx, missing, "tester"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("RecordRun on missing issue: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// RefreshSearchIndex brings the full-text search index up to date.
+func (s *EmbeddedDoltStore) RefreshSearchIndex(ctx context.Context) (int, error) {
+	var n int
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		n, err = issueops.RefreshSearchIndexInTx(ctx, tx)
+		return err
+	})
+	return n, err
+}
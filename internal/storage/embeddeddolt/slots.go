@@ -20,7 +20,7 @@ import (
 // validation, exactly as the old SlotSet did. Routes to the issues or wisps
 // table automatically. SlotSet is built on this.
 func (s *EmbeddedDoltStore) MergeMetadata(ctx context.Context, issueID, key string, value json.RawMessage, actor string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.MergeMetadataInTx(ctx, tx, issueID, key, value, actor)
 	})
 }
@@ -79,7 +79,7 @@ func (s *EmbeddedDoltStore) SlotGet(ctx context.Context, issueID, key string) (s
 // a different key can no longer clobber this write. Clearing an absent key is a
 // no-op that writes nothing.
 func (s *EmbeddedDoltStore) SlotClear(ctx context.Context, issueID, key, actor string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.DeleteMetadataInTx(ctx, tx, issueID, key, actor)
 	})
 }
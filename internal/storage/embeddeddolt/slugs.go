@@ -0,0 +1,62 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func (s *EmbeddedDoltStore) SetIssueSlug(ctx context.Context, issueID, slug, actor string) (string, error) {
+	var result string
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.SetIssueSlugInTx(ctx, tx, issueID, slug, actor)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) ListIssueSlugs(ctx context.Context, issueID string) ([]*types.IssueSlug, error) {
+	var result []*types.IssueSlug
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ListIssueSlugsInTx(ctx, tx, issueID)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) ResolveSlug(ctx context.Context, slug string) (string, error) {
+	var result string
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ResolveSlugInTx(ctx, tx, slug)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) CurrentSlugs(ctx context.Context, issueIDs []string) (map[string]string, error) {
+	var result map[string]string
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.CurrentSlugsInTx(ctx, tx, issueIDs)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) GenerateIssueSlugs(ctx context.Context, opts storage.SlugGenerationOptions, actor string) ([]*types.IssueSlug, error) {
+	var result []*types.IssueSlug
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GenerateIssueSlugsInTx(ctx, tx, opts, actor)
+		return err
+	})
+	return result, err
+}
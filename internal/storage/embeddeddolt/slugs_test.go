@@ -0,0 +1,101 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSlugs(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "sl")
+	ctx := t.Context()
+
+	var issues []*types.Issue
+	for _, title := range []string{"Fix login timeout", "Fix login timeout", "Closed work"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := te.store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+	first, second, closed := issues[0], issues[1], issues[2]
+	if err := te.store.CloseIssue(ctx, closed.ID, "done", "tester", ""); err != nil {
+		t.Fatalf("CloseIssue: %v", err)
+	}
+
+	preview, err := te.store.GenerateIssueSlugs(ctx, storage.SlugGenerationOptions{DryRun: true}, "tester")
+	if err != nil || len(preview) != 2 || preview[1].Slug != "fix-login-timeout-2" {
+		t.Fatalf("dry run = %+v, %v; want two slugs, the second suffixed", preview, err)
+	}
+	if got, _ := te.store.CurrentSlugs(ctx, []string{first.ID}); len(got) != 0 {
+		t.Fatalf("dry run wrote slugs: %v", got)
+	}
+	if _, err := te.store.GenerateIssueSlugs(ctx, storage.SlugGenerationOptions{}, "tester"); err != nil {
+		t.Fatalf("GenerateIssueSlugs: %v", err)
+	}
+	current, err := te.store.CurrentSlugs(ctx, []string{first.ID, second.ID, closed.ID})
+	if err != nil {
+		t.Fatalf("CurrentSlugs: %v", err)
+	}
+	if current[first.ID] != "fix-login-timeout" || current[second.ID] != "fix-login-timeout-2" || current[closed.ID] != "" {
+		t.Errorf("current slugs = %v", current)
+	}
+
+	// Renaming keeps the old slug resolving to the same issue.
+	if _, err := te.store.SetIssueSlug(ctx, first.ID, "login-hang", "tester"); err != nil {
+		t.Fatalf("SetIssueSlug: %v", err)
+	}
+	for _, slug := range []string{"login-hang", "fix-login-timeout"} {
+		if id, err := te.store.ResolveSlug(ctx, slug); err != nil || id != first.ID {
+			t.Errorf("ResolveSlug(%q) = %q, %v; want %s", slug, id, err, first.ID)
+		}
+	}
+	history, err := te.store.ListIssueSlugs(ctx, first.ID)
+	if err != nil || len(history) != 2 || !history[0].Current || history[0].Slug != "login-hang" || history[1].Current {
+		t.Errorf("ListIssueSlugs = %+v, %v; want login-hang current, then the old slug", history, err)
+	}
+
+	// Current and old slugs of another issue, issue IDs, and anything that
+	// reads as an ID with the issue prefix are taken.
+	for _, slug := range []string{"login-hang", "fix-login-timeout", second.ID, "sl-a3f", "sl-not-an-id"} {
+		if _, err := te.store.SetIssueSlug(ctx, closed.ID, slug, "tester"); !errors.Is(err, storage.ErrSlugTaken) {
+			t.Errorf("SetIssueSlug(%q) err = %v, want ErrSlugTaken", slug, err)
+		}
+	}
+
+	// Bare hex words read as partial IDs and are refused outright, whether
+	// passed explicitly or generated from a title.
+	for _, slug := range []string{"cafe", "add"} {
+		if _, err := te.store.SetIssueSlug(ctx, closed.ID, slug, "tester"); err == nil {
+			t.Errorf("SetIssueSlug(%q) succeeded, want an error", slug)
+		}
+	}
+	hexTitled := &types.Issue{Title: "Add", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := te.store.CreateIssue(ctx, hexTitled, "tester"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if slug, err := te.store.SetIssueSlug(ctx, hexTitled.ID, "", "tester"); err == nil {
+		t.Errorf("SetIssueSlug(generated from %q) = %q, want an error", hexTitled.Title, slug)
+	}
+
+	// An old slug can be made current again.
+	if _, err := te.store.SetIssueSlug(ctx, first.ID, "fix-login-timeout", "tester"); err != nil {
+		t.Fatalf("SetIssueSlug (revive): %v", err)
+	}
+	if got, _ := te.store.CurrentSlugs(ctx, []string{first.ID}); got[first.ID] != "fix-login-timeout" {
+		t.Errorf("current slug after revive = %q", got[first.ID])
+	}
+
+	// Deleting the issue frees its slugs.
+	if err := te.store.DeleteIssue(ctx, first.ID); err != nil {
+		t.Fatalf("DeleteIssue: %v", err)
+	}
+	if _, err := te.store.ResolveSlug(ctx, "login-hang"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("ResolveSlug after delete err = %v, want ErrNotFound", err)
+	}
+}
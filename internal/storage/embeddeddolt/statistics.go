@@ -13,7 +13,7 @@ import (
 
 func (s *EmbeddedDoltStore) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	stats := &types.Statistics{}
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		if err := issueops.ScanIssueCountsInTx(ctx, tx, stats); err != nil {
 			return err
 		}
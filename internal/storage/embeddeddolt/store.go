@@ -26,10 +26,26 @@ import (
 var _ storage.DoltStorage = (*EmbeddedDoltStore)(nil)
 var _ storage.StoreLocator = (*EmbeddedDoltStore)(nil)
 var _ storage.GarbageCollector = (*EmbeddedDoltStore)(nil)
+var _ storage.IntegrityChecker = (*EmbeddedDoltStore)(nil)
 var _ storage.Flattener = (*EmbeddedDoltStore)(nil)
 var _ storage.Compactor = (*EmbeddedDoltStore)(nil)
 var _ storage.SchemaMigrator = (*EmbeddedDoltStore)(nil)
 var _ storage.ExternalRefHistoryQuerier = (*EmbeddedDoltStore)(nil)
+var _ storage.GraphDiffer = (*EmbeddedDoltStore)(nil)
+var _ storage.KnowledgeStore = (*EmbeddedDoltStore)(nil)
+var _ storage.RunStore = (*EmbeddedDoltStore)(nil)
+var _ storage.SlugStore = (*EmbeddedDoltStore)(nil)
+var _ storage.SearchIndexer = (*EmbeddedDoltStore)(nil)
+var _ storage.IssueLockStore = (*EmbeddedDoltStore)(nil)
+var _ storage.TrendStore = (*EmbeddedDoltStore)(nil)
+var _ storage.IssueHistoryReader = (*EmbeddedDoltStore)(nil)
+var _ storage.IssueBlamer = (*EmbeddedDoltStore)(nil)
+var _ storage.PendingChangesReader = (*EmbeddedDoltStore)(nil)
+var _ storage.MergeConflictStore = (*EmbeddedDoltStore)(nil)
+var _ storage.PeerHealthChecker = (*EmbeddedDoltStore)(nil)
+var _ storage.CredentialKeyRotator = (*EmbeddedDoltStore)(nil)
+var _ storage.SyncPreviewer = (*EmbeddedDoltStore)(nil)
+var _ storage.PeerVerifier = (*EmbeddedDoltStore)(nil)
 
 // EmbeddedDoltStore implements storage.DoltStorage backed by the embedded Dolt engine.
 // Each method call opens a short-lived connection, executes within an explicit
@@ -56,6 +72,9 @@ type EmbeddedDoltStore struct {
 	// (e.g. the post-command autocommit net, or the commit itself) - only the
 	// migration step is skipped.
 	intent openIntent
+
+	clock storage.Clock       // WithClock; nil reads the system clock
+	idGen storage.IDGenerator // WithIDGenerator; nil uses content-hash IDs
 }
 
 // openIntent classifies why a store is being opened. openStrict fails the
@@ -94,6 +113,13 @@ func (s *EmbeddedDoltStore) IsClosed() bool {
 	return s.closed.Load()
 }
 
+// opContext returns ctx carrying the store's clock and ID generator. Store
+// methods get it from withConn; transaction methods, which receive the
+// caller's ctx, call it directly.
+func (s *EmbeddedDoltStore) opContext(ctx context.Context) context.Context {
+	return storage.WithStoreDefaults(ctx, s.clock, s.idGen)
+}
+
 // newStore creates an EmbeddedDoltStore using the embedded Dolt engine.
 // beadsDir is the .beads/ root; the data directory is derived as <beadsDir>/embeddeddolt/.
 // The database is created automatically if it doesn't exist (initSchema handles this).
@@ -194,8 +220,11 @@ func OpenReadOnly(ctx context.Context, beadsDir, database, branch string) (*Embe
 // otherwise it is rolled back. The connection is closed before withConn
 // returns regardless of outcome.
 //
+// fn receives ctx carrying the store's clock and ID generator; withConn is the
+// one place store methods pick them up.
+//
 // The database must already exist (created during initSchema).
-func (s *EmbeddedDoltStore) withConn(ctx context.Context, commit bool, fn func(tx *sql.Tx) error) (err error) {
+func (s *EmbeddedDoltStore) withConn(ctx context.Context, commit bool, fn func(ctx context.Context, tx *sql.Tx) error) (err error) {
 	if s.closed.Load() {
 		err = errClosed
 		return
@@ -204,6 +233,7 @@ func (s *EmbeddedDoltStore) withConn(ctx context.Context, commit bool, fn func(t
 		err = errReadOnly
 		return
 	}
+	ctx = s.opContext(ctx)
 
 	var db *sql.DB
 	var cleanup func() error
@@ -216,14 +246,23 @@ func (s *EmbeddedDoltStore) withConn(ctx context.Context, commit bool, fn func(t
 		err = errors.Join(err, cleanup())
 	}()
 
+	var conn *sql.Conn
+	conn, err = s.pinBranch(ctx, db)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, conn.Close())
+	}()
+
 	var tx *sql.Tx
-	tx, err = db.BeginTx(ctx, nil)
+	tx, err = conn.BeginTx(ctx, nil)
 	if err != nil {
 		err = fmt.Errorf("embeddeddolt: begin tx: %w", err)
 		return
 	}
 
-	if fnErr := fn(tx); fnErr != nil {
+	if fnErr := fn(ctx, tx); fnErr != nil {
 		err = errors.Join(fnErr, tx.Rollback())
 		return
 	}
@@ -240,6 +279,23 @@ func (s *EmbeddedDoltStore) withConn(ctx context.Context, commit bool, fn func(t
 	return
 }
 
+// pinBranch pins one connection from db to the store's branch. OpenSQL sets
+// the head ref through the pool, but the embedded driver resets session state
+// whenever a connection goes back to the pool, so after Checkout the branch
+// has to be applied to the connection that actually runs the statements.
+func (s *EmbeddedDoltStore) pinBranch(ctx context.Context, db *sql.DB) (*sql.Conn, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("embeddeddolt: pin connection: %w", err)
+	}
+	if s.database != "" && s.branch != "" {
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("SET @@%s_head_ref = %s", s.database, sqlStringLiteral(s.branch))); err != nil {
+			return nil, errors.Join(fmt.Errorf("embeddeddolt: setting branch: %w", err), conn.Close())
+		}
+	}
+	return conn, nil
+}
+
 func (s *EmbeddedDoltStore) ApplySchemaMigrations(ctx context.Context) (int, error) {
 	if s.closed.Load() {
 		return 0, errClosed
@@ -253,9 +309,9 @@ func (s *EmbeddedDoltStore) ApplySchemaMigrations(ctx context.Context) (int, err
 	}
 	defer func() { _ = cleanup() }()
 
-	conn, err := db.Conn(ctx)
+	conn, err := s.pinBranch(ctx, db)
 	if err != nil {
-		return 0, fmt.Errorf("embeddeddolt: pin connection: %w", err)
+		return 0, err
 	}
 	defer conn.Close()
 
@@ -415,7 +471,7 @@ func (s *EmbeddedDoltStore) initSchema(ctx context.Context) error {
 
 func (s *EmbeddedDoltStore) GetIssueByExternalRef(ctx context.Context, externalRef string) (*types.Issue, error) {
 	var id string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		id, err = issueops.GetIssueByExternalRefInTx(ctx, tx, externalRef)
 		return err
@@ -433,7 +489,7 @@ func (s *EmbeddedDoltStore) GetIssueByExternalRef(ctx context.Context, externalR
 // CloseIssue is implemented in issues.go.
 
 func (s *EmbeddedDoltStore) DeleteIssue(ctx context.Context, id string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.DeleteIssueInTx(ctx, tx, id)
 	})
 }
@@ -444,7 +500,7 @@ func (s *EmbeddedDoltStore) DeleteIssue(ctx context.Context, id string) error {
 
 func (s *EmbeddedDoltStore) GetDependencies(ctx context.Context, issueID string) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependenciesInTx(ctx, tx, issueID)
 		return err
@@ -454,7 +510,7 @@ func (s *EmbeddedDoltStore) GetDependencies(ctx context.Context, issueID string)
 
 func (s *EmbeddedDoltStore) GetDependents(ctx context.Context, issueID string) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependentsInTx(ctx, tx, issueID)
 		return err
@@ -468,7 +524,7 @@ func (s *EmbeddedDoltStore) GetDependents(ctx context.Context, issueID string) (
 
 func (s *EmbeddedDoltStore) GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error) {
 	var result []*types.TreeNode
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependencyTreeInTx(ctx, tx, issueID, maxDepth, showAllPaths, reverse)
 		return err
@@ -484,7 +540,7 @@ func (s *EmbeddedDoltStore) GetDependencyTree(ctx context.Context, issueID strin
 
 func (s *EmbeddedDoltStore) GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error) {
 	var ids []string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		ids, err = issueops.GetIssuesByLabelInTx(ctx, tx, label)
 		return err
@@ -499,7 +555,7 @@ func (s *EmbeddedDoltStore) GetIssuesByLabel(ctx context.Context, label string)
 
 func (s *EmbeddedDoltStore) GetBlockedIssues(ctx context.Context, filter types.WorkFilter) ([]*types.BlockedIssue, error) {
 	var result []*types.BlockedIssue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetBlockedIssuesInTx(ctx, tx, filter)
 		return err
@@ -509,7 +565,7 @@ func (s *EmbeddedDoltStore) GetBlockedIssues(ctx context.Context, filter types.W
 
 func (s *EmbeddedDoltStore) GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error) {
 	var result []*types.EpicStatus
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetEpicsEligibleForClosureInTx(ctx, tx)
 		return err
@@ -519,7 +575,7 @@ func (s *EmbeddedDoltStore) GetEpicsEligibleForClosure(ctx context.Context) ([]*
 
 func (s *EmbeddedDoltStore) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
 	var result *types.Comment
-	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.AddIssueCommentInTx(ctx, tx, issueID, author, text)
 		return err
@@ -529,7 +585,7 @@ func (s *EmbeddedDoltStore) AddIssueComment(ctx context.Context, issueID, author
 
 func (s *EmbeddedDoltStore) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
 	var result []*types.Comment
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetIssueCommentsInTx(ctx, tx, issueID)
 		return err
@@ -543,7 +599,7 @@ func (s *EmbeddedDoltStore) GetIssueComments(ctx context.Context, issueID string
 // read contract.
 func (s *EmbeddedDoltStore) GetIssueCommentsPage(ctx context.Context, issueID string, after storage.CommentPageCursor, limit int) ([]*types.Comment, error) {
 	var result []*types.Comment
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetIssueCommentsPageInTx(ctx, tx, issueID, after, limit)
 		return err
@@ -553,7 +609,7 @@ func (s *EmbeddedDoltStore) GetIssueCommentsPage(ctx context.Context, issueID st
 
 func (s *EmbeddedDoltStore) GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error) {
 	var result []*types.Event
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetEventsInTx(ctx, tx, issueID, limit)
 		return err
@@ -563,7 +619,7 @@ func (s *EmbeddedDoltStore) GetEvents(ctx context.Context, issueID string, limit
 
 func (s *EmbeddedDoltStore) GetAllEventsSince(ctx context.Context, since time.Time) ([]*types.Event, error) {
 	var result []*types.Event
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetAllEventsSinceInTx(ctx, tx, since)
 		return err
@@ -576,7 +632,7 @@ func (s *EmbeddedDoltStore) GetAllEventsSince(ctx context.Context, since time.Ti
 // issueID != "" scopes the feed to one bead's history.
 func (s *EmbeddedDoltStore) EventsSince(ctx context.Context, cursor storage.EventCursor, issueID string, limit int) ([]*types.Event, error) {
 	var result []*types.Event
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.EventsSinceInTx(ctx, tx, cursor.CreatedAt, cursor.ID, issueID, limit)
 		return err
@@ -656,7 +712,7 @@ func (s *EmbeddedDoltStore) ImportJSONLData(
 	actor string,
 ) (int, error) {
 	var imported int
-	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		// Atomically check: is the database empty?
 		stats := &types.Statistics{}
 		if err := issueops.ScanIssueCountsInTx(ctx, tx, stats); err != nil {
@@ -725,20 +781,25 @@ func (s *EmbeddedDoltStore) Flatten(ctx context.Context) error {
 
 // Compact squashes old Dolt commits while preserving recent ones.
 // Pins a single *sql.Conn for session-scoped stored procedures.
-func (s *EmbeddedDoltStore) Compact(ctx context.Context, initialHash, boundaryHash string, oldCommits int, recentHashes []string) error {
-	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+func (s *EmbeddedDoltStore) Compact(ctx context.Context, initialHash, boundaryHash string, oldCommits int, recentHashes []string) (map[string]string, error) {
+	var replayed map[string]string
+	err := s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
 		// withDBConn returns *sql.DB; pin a single connection for
 		// session-scoped operations (checkout, reset, cherry-pick).
+		var err error
 		if pooled, ok := db.(*sql.DB); ok {
 			conn, err := pooled.Conn(ctx)
 			if err != nil {
 				return err
 			}
 			defer conn.Close()
-			return versioncontrolops.Compact(ctx, conn, initialHash, boundaryHash, oldCommits, recentHashes)
+			replayed, err = versioncontrolops.Compact(ctx, conn, initialHash, boundaryHash, oldCommits, recentHashes)
+			return err
 		}
-		return versioncontrolops.Compact(ctx, db, initialHash, boundaryHash, oldCommits, recentHashes)
+		replayed, err = versioncontrolops.Compact(ctx, db, initialHash, boundaryHash, oldCommits, recentHashes)
+		return err
 	})
+	return replayed, err
 }
 
 // Path returns the embedded dolt data directory (.beads/embeddeddolt/).
@@ -777,7 +838,7 @@ func (s *EmbeddedDoltStore) CommitPending(ctx context.Context, actor string) (bo
 
 func (s *EmbeddedDoltStore) GetCurrentCommit(ctx context.Context) (string, error) {
 	var hash string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		return tx.QueryRowContext(ctx, "SELECT HASHOF('HEAD')").Scan(&hash)
 	})
 	return hash, err
@@ -792,7 +853,7 @@ func (s *EmbeddedDoltStore) GetCurrentCommit(ctx context.Context) (string, error
 
 func (s *EmbeddedDoltStore) History(ctx context.Context, issueID string) ([]*storage.HistoryEntry, error) {
 	var result []*storage.HistoryEntry
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.HistoryInTx(ctx, tx, issueID)
 		return err
@@ -800,9 +861,39 @@ func (s *EmbeddedDoltStore) History(ctx context.Context, issueID string) ([]*sto
 	return result, err
 }
 
+func (s *EmbeddedDoltStore) GetIssueHistory(ctx context.Context, issueID string) ([]*storage.IssueChange, error) {
+	var result []*storage.IssueChange
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueHistoryInTx(ctx, tx, issueID)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) GetIssueBlame(ctx context.Context, issueID string) ([]*storage.FieldBlame, error) {
+	var result []*storage.FieldBlame
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueBlameInTx(ctx, tx, issueID)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) PendingChanges(ctx context.Context) (*storage.PendingChanges, error) {
+	var result *storage.PendingChanges
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.PendingChangesInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
 func (s *EmbeddedDoltStore) AsOf(ctx context.Context, issueID string, ref string) (*types.Issue, error) {
 	var result *types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.AsOfInTx(ctx, tx, issueID, ref)
 		return err
@@ -812,7 +903,7 @@ func (s *EmbeddedDoltStore) AsOf(ctx context.Context, issueID string, ref string
 
 func (s *EmbeddedDoltStore) Diff(ctx context.Context, fromRef, toRef string) ([]*storage.DiffEntry, error) {
 	var result []*storage.DiffEntry
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.DiffInTx(ctx, tx, fromRef, toRef)
 		return err
@@ -820,13 +911,37 @@ func (s *EmbeddedDoltStore) Diff(ctx context.Context, fromRef, toRef string) ([]
 	return result, err
 }
 
+// DiffDependencies returns dependency edges changed between two refs.
+// Implements storage.GraphDiffer.
+func (s *EmbeddedDoltStore) DiffDependencies(ctx context.Context, fromRef, toRef string) ([]*storage.DependencyDiffEntry, error) {
+	var result []*storage.DependencyDiffEntry
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.DependencyDiffInTx(ctx, tx, fromRef, toRef)
+		return err
+	})
+	return result, err
+}
+
+// DiffLabels returns labels changed between two refs.
+// Implements storage.GraphDiffer.
+func (s *EmbeddedDoltStore) DiffLabels(ctx context.Context, fromRef, toRef string) ([]*storage.LabelDiffEntry, error) {
+	var result []*storage.LabelDiffEntry
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.LabelDiffInTx(ctx, tx, fromRef, toRef)
+		return err
+	})
+	return result, err
+}
+
 // PreviousExternalRef returns the external_ref value recorded for issueID
 // as of the most recent commit at or before asOf.
 // Implements storage.ExternalRefHistoryQuerier.
 func (s *EmbeddedDoltStore) PreviousExternalRef(ctx context.Context, issueID string, asOf time.Time) (string, bool, error) {
 	var ref string
 	var found bool
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		ref, found, err = issueops.PreviousExternalRefInTx(ctx, tx, issueID, asOf)
 		return err
@@ -862,7 +977,7 @@ func (s *EmbeddedDoltStore) PreviousExternalRef(ctx context.Context, issueID str
 
 func (s *EmbeddedDoltStore) DeleteIssues(ctx context.Context, ids []string, cascade bool, force bool, dryRun bool) (*types.DeleteIssuesResult, error) {
 	var result *types.DeleteIssuesResult
-	err := s.withConn(ctx, !dryRun, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, !dryRun, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.DeleteIssuesInTx(ctx, tx, ids, cascade, force, dryRun)
 		return err
@@ -872,7 +987,7 @@ func (s *EmbeddedDoltStore) DeleteIssues(ctx context.Context, ids []string, casc
 
 func (s *EmbeddedDoltStore) DeleteIssuesBySourceRepo(ctx context.Context, sourceRepo string) (int, error) {
 	var count int
-	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		count, err = issueops.DeleteIssuesBySourceRepoInTx(ctx, tx, sourceRepo)
 		return err
@@ -881,7 +996,7 @@ func (s *EmbeddedDoltStore) DeleteIssuesBySourceRepo(ctx context.Context, source
 }
 
 func (s *EmbeddedDoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.UpdateIssueIDInTx(ctx, tx, oldID, newID, issue, actor)
 	})
 }
@@ -889,7 +1004,7 @@ func (s *EmbeddedDoltStore) UpdateIssueID(ctx context.Context, oldID, newID stri
 // ClaimIssue is implemented in issues.go.
 
 func (s *EmbeddedDoltStore) PromoteFromEphemeral(ctx context.Context, id string, actor string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.PromoteFromEphemeralInTx(ctx, tx, id, actor)
 	})
 }
@@ -902,7 +1017,7 @@ func (s *EmbeddedDoltStore) PromoteFromEphemeral(ctx context.Context, id string,
 
 func (s *EmbeddedDoltStore) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
 	var result []*types.Dependency
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		m, err := issueops.GetDependencyRecordsForIssuesInTx(ctx, tx, []string{issueID})
 		if err != nil {
 			return err
@@ -917,7 +1032,7 @@ func (s *EmbeddedDoltStore) GetDependencyRecords(ctx context.Context, issueID st
 // without hydrating the source issues. Delegates to shared query logic.
 func (s *EmbeddedDoltStore) GetDependentRecords(ctx context.Context, targetID string, depType string, limit int, afterID string) ([]*types.Dependency, error) {
 	var result []*types.Dependency
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetDependentRecordsInTx(ctx, tx, targetID, depType, limit, afterID)
 		return err
@@ -929,7 +1044,7 @@ func (s *EmbeddedDoltStore) GetDependentRecords(ctx context.Context, targetID st
 // both dependency tables. Delegates to issueops.CountDependentRecordsInTx.
 func (s *EmbeddedDoltStore) CountDependentRecords(ctx context.Context, targetID string, depType string) (int, error) {
 	var n int
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		n, err = issueops.CountDependentRecordsInTx(ctx, tx, targetID, depType)
 		return err
@@ -945,7 +1060,7 @@ func (s *EmbeddedDoltStore) CountDependentRecords(ctx context.Context, targetID
 
 func (s *EmbeddedDoltStore) FindWispDependentsRecursive(ctx context.Context, ids []string) (map[string]bool, error) {
 	var result map[string]bool
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.FindWispDependentsRecursiveInTx(ctx, tx, ids)
 		return err
@@ -958,14 +1073,14 @@ func (s *EmbeddedDoltStore) FindWispDependentsRecursive(ctx context.Context, ids
 // ---------------------------------------------------------------------------
 
 func (s *EmbeddedDoltStore) AddComment(ctx context.Context, issueID, actor, comment string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.AddCommentEventInTx(ctx, tx, issueID, actor, comment)
 	})
 }
 
 func (s *EmbeddedDoltStore) ImportIssueComment(ctx context.Context, issueID, author, text string, createdAt time.Time) (*types.Comment, error) {
 	var result *types.Comment
-	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.ImportIssueCommentInTx(ctx, tx, issueID, author, text, createdAt)
 		return err
@@ -975,7 +1090,7 @@ func (s *EmbeddedDoltStore) ImportIssueComment(ctx context.Context, issueID, aut
 
 func (s *EmbeddedDoltStore) GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error) {
 	var result map[string][]*types.Comment
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetCommentsForIssuesInTx(ctx, tx, issueIDs)
 		return err
@@ -988,7 +1103,7 @@ func (s *EmbeddedDoltStore) GetCommentsForIssues(ctx context.Context, issueIDs [
 // ---------------------------------------------------------------------------
 
 func (s *EmbeddedDoltStore) DeleteConfig(ctx context.Context, key string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.DeleteConfigInTx(ctx, tx, key)
 	})
 }
@@ -1003,7 +1118,7 @@ func (s *EmbeddedDoltStore) GetCustomStatuses(ctx context.Context) ([]string, er
 
 func (s *EmbeddedDoltStore) GetCustomStatusesDetailed(ctx context.Context) ([]types.CustomStatus, error) {
 	var result []types.CustomStatus
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var txErr error
 		result, txErr = issueops.ResolveCustomStatusesDetailedInTx(ctx, tx)
 		return txErr
@@ -1020,7 +1135,7 @@ func (s *EmbeddedDoltStore) GetCustomStatusesDetailed(ctx context.Context) ([]ty
 
 func (s *EmbeddedDoltStore) GetCustomTypes(ctx context.Context) ([]string, error) {
 	var result []string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var txErr error
 		result, txErr = issueops.ResolveCustomTypesInTx(ctx, tx)
 		return txErr
@@ -1042,7 +1157,7 @@ func (s *EmbeddedDoltStore) GetCustomTypes(ctx context.Context) ([]string, error
 func (s *EmbeddedDoltStore) CheckEligibility(ctx context.Context, issueID string, tier int) (bool, string, error) {
 	var eligible bool
 	var reason string
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		eligible, reason, err = issueops.CheckEligibilityInTx(ctx, tx, issueID, tier)
 		return err
@@ -1051,20 +1166,20 @@ func (s *EmbeddedDoltStore) CheckEligibility(ctx context.Context, issueID string
 }
 
 func (s *EmbeddedDoltStore) ApplyCompaction(ctx context.Context, issueID string, tier int, originalSize int, _ int, commitHash string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.ApplyCompactionInTx(ctx, tx, issueID, tier, originalSize, commitHash)
 	})
 }
 
 func (s *EmbeddedDoltStore) SnapshotIssue(ctx context.Context, issueID string, tier int) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.SnapshotIssueInTx(ctx, tx, issueID, tier)
 	})
 }
 
 func (s *EmbeddedDoltStore) GetCompactionSnapshot(ctx context.Context, issueID string) (*types.IssueSnapshot, error) {
 	var snap *types.IssueSnapshot
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		snap, err = issueops.GetLatestSnapshotInTx(ctx, tx, issueID)
 		return err
@@ -1074,7 +1189,7 @@ func (s *EmbeddedDoltStore) GetCompactionSnapshot(ctx context.Context, issueID s
 
 func (s *EmbeddedDoltStore) RestoreFromSnapshot(ctx context.Context, issueID string) (*types.IssueSnapshot, error) {
 	var snap *types.IssueSnapshot
-	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		snap, err = issueops.RestoreFromSnapshotInTx(ctx, tx, issueID)
 		return err
@@ -1084,7 +1199,7 @@ func (s *EmbeddedDoltStore) RestoreFromSnapshot(ctx context.Context, issueID str
 
 func (s *EmbeddedDoltStore) GetTier1Candidates(ctx context.Context) ([]*types.CompactionCandidate, error) {
 	var result []*types.CompactionCandidate
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetTier1CandidatesInTx(ctx, tx)
 		return err
@@ -1094,7 +1209,7 @@ func (s *EmbeddedDoltStore) GetTier1Candidates(ctx context.Context) ([]*types.Co
 
 func (s *EmbeddedDoltStore) GetTier2Candidates(ctx context.Context) ([]*types.CompactionCandidate, error) {
 	var result []*types.CompactionCandidate
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetTier2CandidatesInTx(ctx, tx)
 		return err
@@ -1108,7 +1223,7 @@ func (s *EmbeddedDoltStore) GetTier2Candidates(ctx context.Context) ([]*types.Co
 
 func (s *EmbeddedDoltStore) GetRepoMtime(ctx context.Context, repoPath string) (int64, error) {
 	var result int64
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetRepoMtimeInTx(ctx, tx, repoPath)
 		return err
@@ -1117,13 +1232,13 @@ func (s *EmbeddedDoltStore) GetRepoMtime(ctx context.Context, repoPath string) (
 }
 
 func (s *EmbeddedDoltStore) SetRepoMtime(ctx context.Context, repoPath, jsonlPath string, mtimeNs int64) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.SetRepoMtimeInTx(ctx, tx, repoPath, jsonlPath, mtimeNs)
 	})
 }
 
 func (s *EmbeddedDoltStore) ClearRepoMtime(ctx context.Context, repoPath string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.ClearRepoMtimeInTx(ctx, tx, repoPath)
 	})
 }
@@ -1132,7 +1247,7 @@ func (s *EmbeddedDoltStore) ClearRepoMtime(ctx context.Context, repoPath string)
 
 func (s *EmbeddedDoltStore) GetMoleculeLastActivity(ctx context.Context, moleculeID string) (*types.MoleculeLastActivity, error) {
 	var result *types.MoleculeLastActivity
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetMoleculeLastActivityInTx(ctx, tx, moleculeID)
 		return err
@@ -1142,7 +1257,7 @@ func (s *EmbeddedDoltStore) GetMoleculeLastActivity(ctx context.Context, molecul
 
 func (s *EmbeddedDoltStore) GetStaleIssues(ctx context.Context, filter types.StaleFilter) ([]*types.Issue, error) {
 	var result []*types.Issue
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		result, err = issueops.GetStaleIssuesInTx(ctx, tx, filter)
 		return err
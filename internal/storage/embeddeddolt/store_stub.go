@@ -17,7 +17,7 @@ type EmbeddedDoltStore struct {
 var errNoCGO = errors.New("embeddeddolt: requires CGO (build with CGO_ENABLED=1)")
 
 // Open returns an error when CGO is not enabled.
-func Open(_ context.Context, _, _, _ string) (*EmbeddedDoltStore, error) {
+func Open(_ context.Context, _, _, _ string, _ ...Option) (*EmbeddedDoltStore, error) {
 	return nil, errNoCGO
 }
 
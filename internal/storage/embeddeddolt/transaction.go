@@ -20,8 +20,8 @@ import (
 func (s *EmbeddedDoltStore) RunInTransaction(ctx context.Context, commitMsg string, fn func(tx storage.Transaction) error) error {
 	var tracker versioncontrolops.DirtyTableTracker
 
-	if err := s.withConn(ctx, true, func(tx *sql.Tx) error {
-		return fn(&embeddedTransaction{tx: tx, dirty: &tracker})
+	if err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
+		return fn(&embeddedTransaction{tx: tx, dirty: &tracker, store: s})
 	}); err != nil {
 		return err
 	}
@@ -38,9 +38,11 @@ func (s *EmbeddedDoltStore) RunInTransaction(ctx context.Context, commitMsg stri
 type embeddedTransaction struct {
 	tx    *sql.Tx
 	dirty *versioncontrolops.DirtyTableTracker
+	store *EmbeddedDoltStore
 }
 
 func (t *embeddedTransaction) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	ctx = t.store.opContext(ctx)
 	bc, err := issueops.NewBatchContext(ctx, t.tx, storage.BatchCreateOptions{SkipPrefixValidation: true})
 	if err != nil {
 		return err
@@ -56,6 +58,7 @@ func (t *embeddedTransaction) CreateIssue(ctx context.Context, issue *types.Issu
 }
 
 func (t *embeddedTransaction) CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error {
+	ctx = t.store.opContext(ctx)
 	result, err := issueops.CreateIssuesInTxWithResult(ctx, t.tx, issues, actor, storage.BatchCreateOptions{
 		OrphanHandling:       storage.OrphanAllow,
 		SkipPrefixValidation: true,
@@ -70,6 +73,7 @@ func (t *embeddedTransaction) CreateIssues(ctx context.Context, issues []*types.
 }
 
 func (t *embeddedTransaction) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	ctx = t.store.opContext(ctx)
 	t.dirty.MarkDirty("issues")
 	t.dirty.MarkDirty("events")
 	_, err := issueops.UpdateIssueInTx(ctx, t.tx, id, updates, actor)
@@ -77,6 +81,7 @@ func (t *embeddedTransaction) UpdateIssue(ctx context.Context, id string, update
 }
 
 func (t *embeddedTransaction) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
+	ctx = t.store.opContext(ctx)
 	t.dirty.MarkDirty("issues")
 	t.dirty.MarkDirty("events")
 	_, err := issueops.CloseIssueInTx(ctx, t.tx, id, reason, actor, session)
@@ -84,6 +89,7 @@ func (t *embeddedTransaction) CloseIssue(ctx context.Context, id string, reason
 }
 
 func (t *embeddedTransaction) DeleteIssue(ctx context.Context, id string) error {
+	ctx = t.store.opContext(ctx)
 	t.dirty.MarkDirty("issues")
 	t.dirty.MarkDirty("dependencies")
 	t.dirty.MarkDirty("labels")
@@ -93,23 +99,28 @@ func (t *embeddedTransaction) DeleteIssue(ctx context.Context, id string) error
 }
 
 func (t *embeddedTransaction) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.GetIssueInTx(ctx, t.tx, id)
 }
 
 func (t *embeddedTransaction) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.SearchIssuesInTx(ctx, t.tx, query, filter)
 }
 
 // SearchIssueIDs returns matching IDs only via issueops.SearchIssueIDsInTx.
 func (t *embeddedTransaction) SearchIssueIDs(ctx context.Context, query string, filter types.IssueFilter) ([]string, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.SearchIssueIDsInTx(ctx, t.tx, query, filter)
 }
 
 func (t *embeddedTransaction) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	ctx = t.store.opContext(ctx)
 	return t.AddDependencyWithOptions(ctx, dep, actor, storage.DependencyAddOptions{})
 }
 
 func (t *embeddedTransaction) AddDependencyWithOptions(ctx context.Context, dep *types.Dependency, actor string, addOpts storage.DependencyAddOptions) error {
+	ctx = t.store.opContext(ctx)
 	_, _, eventTable, depTable := issueops.WispTableRouting(issueops.IsActiveWispInTx(ctx, t.tx, dep.IssueID))
 	eventWritten, err := issueops.AddDependencyInTx(ctx, t.tx, dep, actor, issueops.AddDependencyOpts{
 		IsCrossPrefix:  types.ExtractPrefix(dep.IssueID) != types.ExtractPrefix(dep.DependsOnID),
@@ -133,6 +144,7 @@ func (t *embeddedTransaction) AddDependencyWithOptions(ctx context.Context, dep
 // including the transaction's own uncommitted dependency writes
 // (bd-6dnrw.8, bd-578h9.9).
 func (t *embeddedTransaction) CycleThroughEdges(ctx context.Context, edges [][2]string) (string, error) {
+	ctx = t.store.opContext(ctx)
 	graph := make(map[string][]string)
 	if err := issueops.AppendSchedulingGraphInTx(ctx, t.tx, []string{"dependencies", "wisp_dependencies"}, graph); err != nil {
 		return "", err
@@ -141,10 +153,12 @@ func (t *embeddedTransaction) CycleThroughEdges(ctx context.Context, edges [][2]
 }
 
 func (t *embeddedTransaction) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	ctx = t.store.opContext(ctx)
 	return t.RemoveDependencyWithOptions(ctx, issueID, dependsOnID, actor, storage.DependencyRemoveOptions{})
 }
 
 func (t *embeddedTransaction) RemoveDependencyWithOptions(ctx context.Context, issueID, dependsOnID string, actor string, rmOpts storage.DependencyRemoveOptions) error {
+	ctx = t.store.opContext(ctx)
 	// Route dirty marking on the source's wisp status: a wisp-source remove
 	// stages wisp_dependencies/wisp_events, a permanent one dependencies/events.
 	_, _, eventTable, depTable := issueops.WispTableRouting(issueops.IsActiveWispInTx(ctx, t.tx, issueID))
@@ -164,6 +178,7 @@ func (t *embeddedTransaction) RemoveDependencyWithOptions(ctx context.Context, i
 }
 
 func (t *embeddedTransaction) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
+	ctx = t.store.opContext(ctx)
 	m, err := issueops.GetDependencyRecordsForIssuesInTx(ctx, t.tx, []string{issueID})
 	if err != nil {
 		return nil, err
@@ -172,20 +187,24 @@ func (t *embeddedTransaction) GetDependencyRecords(ctx context.Context, issueID
 }
 
 func (t *embeddedTransaction) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	ctx = t.store.opContext(ctx)
 	t.dirty.MarkDirty("labels")
 	return issueops.AddLabelInTx(ctx, t.tx, "", "", issueID, label, actor)
 }
 
 func (t *embeddedTransaction) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	ctx = t.store.opContext(ctx)
 	t.dirty.MarkDirty("labels")
 	return issueops.RemoveLabelInTx(ctx, t.tx, "", "", issueID, label, actor)
 }
 
 func (t *embeddedTransaction) GetLabels(ctx context.Context, issueID string) ([]string, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.GetLabelsInTx(ctx, t.tx, "", issueID)
 }
 
 func (t *embeddedTransaction) SetConfig(ctx context.Context, key, value string) error {
+	ctx = t.store.opContext(ctx)
 	t.dirty.MarkDirty("config")
 	if err := issueops.SetConfigInTx(ctx, t.tx, key, value); err != nil {
 		return err
@@ -207,23 +226,28 @@ func (t *embeddedTransaction) SetConfig(ctx context.Context, key, value string)
 }
 
 func (t *embeddedTransaction) GetConfig(ctx context.Context, key string) (string, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.GetConfigInTx(ctx, t.tx, key)
 }
 
 func (t *embeddedTransaction) SetMetadata(ctx context.Context, key, value string) error {
+	ctx = t.store.opContext(ctx)
 	t.dirty.MarkDirty("metadata")
 	return issueops.SetMetadataInTx(ctx, t.tx, key, value)
 }
 
 func (t *embeddedTransaction) GetMetadata(ctx context.Context, key string) (string, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.GetMetadataInTx(ctx, t.tx, key)
 }
 
 func (t *embeddedTransaction) SetLocalMetadata(ctx context.Context, key, value string) error {
+	ctx = t.store.opContext(ctx)
 	return issueops.SetLocalMetadataInTx(ctx, t.tx, key, value)
 }
 
 func (t *embeddedTransaction) GetLocalMetadata(ctx context.Context, key string) (string, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.GetLocalMetadataInTx(ctx, t.tx, key)
 }
 
@@ -240,6 +264,7 @@ func (t *embeddedTransaction) GetIssueComments(ctx context.Context, issueID stri
 }
 
 func (t *embeddedTransaction) CreateIssueImport(ctx context.Context, issue *types.Issue, actor string, skipPrefixValidation bool) error {
+	ctx = t.store.opContext(ctx)
 	bc, err := issueops.NewBatchContext(ctx, t.tx, storage.BatchCreateOptions{SkipPrefixValidation: skipPrefixValidation})
 	if err != nil {
 		return err
@@ -265,12 +290,14 @@ func (t *embeddedTransaction) CreateIssueImport(ctx context.Context, issue *type
 // transaction. Mirrors EmbeddedDoltStore.GetIssueCommentsPage's issueops
 // delegation.
 func (t *embeddedTransaction) GetIssueCommentsPage(ctx context.Context, issueID string, after storage.CommentPageCursor, limit int) ([]*types.Comment, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.GetIssueCommentsPageInTx(ctx, t.tx, issueID, after, limit)
 }
 
 // CountIssuesByGroup returns per-group issue counts within the transaction.
 // Mirrors EmbeddedDoltStore.CountIssuesByGroup's issueops delegation.
 func (t *embeddedTransaction) CountIssuesByGroup(ctx context.Context, filter types.IssueFilter, groupBy string) (map[string]int, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.CountIssuesByGroupInTx(ctx, t.tx, filter, groupBy)
 }
 
@@ -278,6 +305,7 @@ func (t *embeddedTransaction) CountIssuesByGroup(ctx context.Context, filter typ
 // the transaction. Mirrors EmbeddedDoltStore.GetDependentRecords; the InTx
 // function spans both dependency tables itself.
 func (t *embeddedTransaction) GetDependentRecords(ctx context.Context, targetID string, depType string, limit int, afterID string) ([]*types.Dependency, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.GetDependentRecordsInTx(ctx, t.tx, targetID, depType, limit, afterID)
 }
 
@@ -285,12 +313,14 @@ func (t *embeddedTransaction) GetDependentRecords(ctx context.Context, targetID
 // of target ids within the transaction, keyed by target id. Mirrors
 // EmbeddedDoltStore.GetDependentRecordsForIssues.
 func (t *embeddedTransaction) GetDependentRecordsForIssues(ctx context.Context, targetIDs []string) (map[string][]*types.Dependency, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.GetDependentRecordsForIssuesInTx(ctx, t.tx, targetIDs)
 }
 
 // CountDependentRecords returns the total inbound-edge count of targetID within
 // the transaction. Mirrors EmbeddedDoltStore.CountDependentRecords.
 func (t *embeddedTransaction) CountDependentRecords(ctx context.Context, targetID string, depType string) (int, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.CountDependentRecordsInTx(ctx, t.tx, targetID, depType)
 }
 
@@ -298,6 +328,7 @@ func (t *embeddedTransaction) CountDependentRecords(ctx context.Context, targetI
 // blockers of issueID within the transaction. Mirrors
 // EmbeddedDoltStore.IsBlocked's issueops delegation.
 func (t *embeddedTransaction) IsBlocked(ctx context.Context, issueID string) (bool, []string, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.IsBlockedInTx(ctx, t.tx, issueID)
 }
 
@@ -305,11 +336,13 @@ func (t *embeddedTransaction) IsBlocked(ctx context.Context, issueID string) (bo
 // of ids within the transaction. Mirrors EmbeddedDoltStore.IsBlockedBatch; the
 // InTx function batches over both the issues and wisps tables itself.
 func (t *embeddedTransaction) IsBlockedBatch(ctx context.Context, ids []string) (map[string]bool, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.IsBlockedBatchInTx(ctx, t.tx, ids)
 }
 
 // EventsSince returns durable events strictly after the keyset cursor within the
 // transaction. Mirrors EmbeddedDoltStore.EventsSince's issueops delegation.
 func (t *embeddedTransaction) EventsSince(ctx context.Context, cursor storage.EventCursor, issueID string, limit int) ([]*types.Event, error) {
+	ctx = t.store.opContext(ctx)
 	return issueops.EventsSinceInTx(ctx, t.tx, cursor.CreatedAt, cursor.ID, issueID, limit)
 }
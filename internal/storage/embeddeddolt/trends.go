@@ -0,0 +1,33 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetActivityTrends returns daily event counts per issue and its children.
+func (s *EmbeddedDoltStore) GetActivityTrends(ctx context.Context, issueIDs []string, days int) (map[string][]int, error) {
+	var result map[string][]int
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetActivityTrendsInTx(ctx, tx, issueIDs, days)
+		return err
+	})
+	return result, err
+}
+
+// GetEventTrend returns daily counts of one event type across all issues.
+func (s *EmbeddedDoltStore) GetEventTrend(ctx context.Context, eventType types.EventType, days int) ([]int, error) {
+	var result []int
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetEventTrendInTx(ctx, tx, eventType, days)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,82 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestTrends(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "tr")
+	ctx := t.Context()
+
+	create := func(title string, typ types.IssueType) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: typ}
+		if err := te.store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("CreateIssue %s: %v", title, err)
+		}
+		return issue
+	}
+	epic := create("Epic", types.TypeEpic)
+	child := create("Child", types.TypeTask)
+	other := create("Unrelated", types.TypeTask)
+	if err := te.store.AddDependency(ctx, &types.Dependency{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild}, "tester"); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+	if err := te.store.CloseIssue(ctx, child.ID, "done", "tester", ""); err != nil {
+		t.Fatalf("CloseIssue: %v", err)
+	}
+
+	const days = 14
+	total := func(series []int) int {
+		n := 0
+		for _, v := range series {
+			n += v
+		}
+		return n
+	}
+
+	trends, err := te.store.GetActivityTrends(ctx, []string{epic.ID, child.ID, other.ID, "tr-missing"}, days)
+	if err != nil {
+		t.Fatalf("GetActivityTrends: %v", err)
+	}
+	for id, series := range trends {
+		if len(series) != days {
+			t.Fatalf("%s: %d days, want %d", id, len(series), days)
+		}
+		if total(series) != series[days-1] {
+			t.Errorf("%s: %v; want every event in today's bucket", id, series)
+		}
+	}
+	if got := total(trends[child.ID]); got < 2 {
+		t.Errorf("child activity = %d; want at least created and closed", got)
+	}
+	if got, want := total(trends[epic.ID]), total(trends[child.ID])+1; got < want {
+		t.Errorf("epic activity = %d; want its own creation plus its child's %d events", got, want-1)
+	}
+	if got := total(trends[other.ID]); got != 1 {
+		t.Errorf("unrelated issue activity = %d; want 1 (created)", got)
+	}
+	if got := total(trends["tr-missing"]); got != 0 {
+		t.Errorf("missing issue activity = %d; want 0", got)
+	}
+
+	closed, err := te.store.GetEventTrend(ctx, types.EventClosed, days)
+	if err != nil {
+		t.Fatalf("GetEventTrend closed: %v", err)
+	}
+	if len(closed) != days || closed[days-1] != 1 || total(closed) != 1 {
+		t.Errorf("closed trend = %v; want one close today", closed)
+	}
+	reopened, err := te.store.GetEventTrend(ctx, types.EventReopened, days)
+	if err != nil {
+		t.Fatalf("GetEventTrend reopened: %v", err)
+	}
+	if total(reopened) != 0 {
+		t.Errorf("reopened trend = %v; want none", reopened)
+	}
+}
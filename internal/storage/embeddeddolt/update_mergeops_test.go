@@ -100,3 +100,44 @@ func TestUpdateMergeOps_DoltEngine_TwoIssues(t *testing.T) {
 		t.Errorf("two-2 notes: got %q, want %q", got2.Notes, "added-2")
 	}
 }
+
+// TestUpdateMergeOps_PatchMetadata checks that a JSON Patch is applied inside
+// the update and that a failing operation leaves the metadata untouched.
+func TestUpdateMergeOps_PatchMetadata(t *testing.T) {
+	te := newTestEnv(t, "patchops")
+	ctx := t.Context()
+
+	issue := &types.Issue{
+		ID:        "patchops-1",
+		Title:     "patch ops on dolt",
+		Status:    types.StatusOpen,
+		IssueType: types.TypeTask,
+		Priority:  2,
+		Metadata:  json.RawMessage(`{"keep":"x","retries":2}`),
+	}
+	if err := te.store.CreateIssue(ctx, issue, "actor"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	patch := `[{"op":"test","path":"/retries","value":2},{"op":"replace","path":"/retries","value":3}]`
+	if err := te.store.UpdateIssue(ctx, issue.ID, map[string]interface{}{issueops.OpPatchMetadata: patch}, "actor"); err != nil {
+		t.Fatalf("UpdateIssue(patch): %v", err)
+	}
+	// A patch whose test fails must not apply its earlier operations.
+	failing := `[{"op":"remove","path":"/keep"},{"op":"test","path":"/retries","value":2}]`
+	if err := te.store.UpdateIssue(ctx, issue.ID, map[string]interface{}{issueops.OpPatchMetadata: failing}, "actor"); err == nil {
+		t.Fatal("UpdateIssue(failing patch): want error")
+	}
+
+	got, err := te.store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	meta := map[string]any{}
+	if err := json.Unmarshal(got.Metadata, &meta); err != nil {
+		t.Fatalf("unmarshal metadata %q: %v", got.Metadata, err)
+	}
+	if meta["keep"] != "x" || meta["retries"] != float64(3) {
+		t.Errorf("metadata = %s, want keep=x retries=3", got.Metadata)
+	}
+}
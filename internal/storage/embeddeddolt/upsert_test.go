@@ -0,0 +1,135 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestUpsertIssues(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "up")
+	ctx := t.Context()
+
+	existing := &types.Issue{ID: "up-keep", Title: "Old title", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := te.store.CreateIssue(ctx, existing, "tester"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	// More issues than one upsert batch holds, plus one of each kind of
+	// per-issue failure.
+	var issues []*types.Issue
+	for i := range 150 {
+		issues = append(issues, &types.Issue{
+			ID: fmt.Sprintf("up-%03d", i), Title: fmt.Sprintf("Imported %d", i),
+			Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+			Labels: []string{"imported"},
+		})
+	}
+	issues = append(issues,
+		&types.Issue{ID: "up-keep", Title: "New title", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeBug},
+		&types.Issue{Title: "Generated ID", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		&types.Issue{ID: "up-bad", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}, // no title
+		&types.Issue{ID: "other-1", Title: "Wrong prefix", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		&types.Issue{ID: "up-000", Title: "Repeated", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	)
+
+	result, err := te.store.UpsertIssues(ctx, issues, "tester")
+	if err != nil {
+		t.Fatalf("UpsertIssues: %v", err)
+	}
+	if len(result.Created) != 151 || len(result.Updated) != 1 || result.Updated[0] != "up-keep" {
+		t.Errorf("created %d, updated %v; want 151 created and up-keep updated", len(result.Created), result.Updated)
+	}
+	var failedIdx []int
+	for _, f := range result.Failed {
+		failedIdx = append(failedIdx, f.Index)
+	}
+	if fmt.Sprint(failedIdx) != "[152 153 154]" {
+		t.Errorf("failed indexes = %v (%v); want [152 153 154]", failedIdx, result.Failed)
+	}
+	if issues[151].ID == "" {
+		t.Error("issue without an ID was not given one")
+	}
+
+	got, err := te.store.GetIssue(ctx, "up-keep")
+	if err != nil || got.Title != "New title" || got.Status != types.StatusInProgress || got.IssueType != types.TypeBug {
+		t.Errorf("up-keep = %+v, %v; want overwritten", got, err)
+	}
+	if got, err := te.store.GetIssue(ctx, "up-149"); err != nil || got.Title != "Imported 149" {
+		t.Errorf("up-149 = %+v, %v; want the second batch written", got, err)
+	}
+	if labels, err := te.store.GetLabels(ctx, "up-042"); err != nil || len(labels) != 1 || labels[0] != "imported" {
+		t.Errorf("labels of up-042 = %v, %v; want [imported]", labels, err)
+	}
+	for _, id := range []string{"up-bad", "other-1"} {
+		if _, err := te.store.GetIssue(ctx, id); err == nil {
+			t.Errorf("%s was written despite failing validation", id)
+		}
+	}
+
+	// Upserting the same issues again updates rather than duplicates.
+	again, err := te.store.UpsertIssues(ctx, issues[:3], "tester")
+	if err != nil || len(again.Created) != 0 || len(again.Updated) != 3 {
+		t.Errorf("second upsert = %+v, %v; want 3 updated", again, err)
+	}
+}
+
+func TestUpsertIssuesHonorsProtectionAndRecordsUpdates(t *testing.T) {
+	skipUnlessEmbeddedDolt(t)
+	te := newTestEnv(t, "upp")
+	ctx := t.Context()
+
+	meta, _ := json.Marshal(map[string]any{types.ProtectionMetadataKey: types.Protection{Actors: []string{"alice"}, ProtectedBy: "alice"}})
+	locked := &types.Issue{ID: "upp-locked", Title: "Locked", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Metadata: meta}
+	plain := &types.Issue{ID: "upp-plain", Title: "Plain", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{locked, plain} {
+		if err := te.store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue %s: %v", issue.ID, err)
+		}
+	}
+	countEvents := func(id string) int {
+		t.Helper()
+		events, err := te.store.GetEvents(ctx, id, 0)
+		if err != nil {
+			t.Fatalf("GetEvents %s: %v", id, err)
+		}
+		return len(events)
+	}
+	plainEvents := countEvents("upp-plain")
+
+	result, err := te.store.UpsertIssues(ctx, []*types.Issue{
+		{ID: "upp-locked", Title: "Taken over", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Metadata: meta},
+		{ID: "upp-plain", Title: "Renamed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}, "bob")
+	if err != nil {
+		t.Fatalf("UpsertIssues: %v", err)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Index != 0 || !errors.Is(result.Failed[0].Err, storage.ErrProtected) {
+		t.Fatalf("failed = %+v; want upp-locked refused with ErrProtected", result.Failed)
+	}
+	if got, err := te.store.GetIssue(ctx, "upp-locked"); err != nil || got.Title != "Locked" {
+		t.Errorf("upp-locked = %+v, %v; want it left unchanged", got, err)
+	}
+	if got := countEvents("upp-plain"); got != plainEvents+1 {
+		t.Errorf("upp-plain has %d events after the overwrite, want %d", got, plainEvents+1)
+	}
+
+	// Re-upserting the issue as stored changes nothing and records nothing.
+	current, err := te.store.GetIssue(ctx, "upp-plain")
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if _, err := te.store.UpsertIssues(ctx, []*types.Issue{current}, "bob"); err != nil {
+		t.Fatalf("UpsertIssues again: %v", err)
+	}
+	if got := countEvents("upp-plain"); got != plainEvents+1 {
+		t.Errorf("unchanged re-upsert recorded an event: %d events, want %d", got, plainEvents+1)
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
+	"github.com/steveyegge/beads/internal/progress"
+	"github.com/steveyegge/beads/internal/retry"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
@@ -37,7 +40,13 @@ func (s *EmbeddedDoltStore) withDBConn(ctx context.Context, fn func(db versionco
 		s.cleanGitRemoteCacheGarbage()
 	}()
 
-	return fn(db)
+	conn, err := s.pinBranch(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return fn(conn)
 }
 
 // withPinnedDBConn is withDBConn pinned to a single *sql.Conn, for operation
@@ -46,10 +55,8 @@ func (s *EmbeddedDoltStore) withDBConn(ctx context.Context, fn func(db versionco
 // the subsequent DOLT_MERGE and settle statements to see them (bd-6dnrw.40).
 // A *sql.DB may rotate connections between statements; a pinned conn cannot.
 //
-// The pinned conn inherits the database/branch session setup OpenSQL applied:
-// the pool holds exactly the one connection OpenSQL configured (sequential
-// Ping/USE/SET on a fresh pool), and db.Conn returns it — the same invariant
-// ApplySchemaMigrations relies on.
+// The pinned conn gets the store's branch from pinBranch, since session state
+// set through the pool does not survive the connection's return to it.
 func (s *EmbeddedDoltStore) withPinnedDBConn(ctx context.Context, fn func(db versioncontrolops.DBConn) error) (err error) {
 	if s.closed.Load() {
 		return errClosed
@@ -68,9 +75,9 @@ func (s *EmbeddedDoltStore) withPinnedDBConn(ctx context.Context, fn func(db ver
 		s.cleanGitRemoteCacheGarbage()
 	}()
 
-	conn, connErr := db.Conn(ctx)
-	if connErr != nil {
-		return fmt.Errorf("embeddeddolt: pin connection: %w", connErr)
+	conn, err := s.pinBranch(ctx, db)
+	if err != nil {
+		return err
 	}
 	defer conn.Close()
 
@@ -99,7 +106,7 @@ func (s *EmbeddedDoltStore) withMutatingPinnedDBConn(ctx context.Context, fn fun
 }
 
 func (s *EmbeddedDoltStore) Commit(ctx context.Context, message string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+	return s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		if _, err := tx.ExecContext(ctx, "CALL DOLT_COMMIT('-Am', ?)", message); err != nil {
 			return fmt.Errorf("dolt commit: %w", err)
 		}
@@ -131,7 +138,7 @@ func (s *EmbeddedDoltStore) AddRemote(ctx context.Context, name, url string) err
 
 func (s *EmbeddedDoltStore) HasRemote(ctx context.Context, name string) (bool, error) {
 	var count int
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+	err := s.withConn(ctx, false, func(ctx context.Context, tx *sql.Tx) error {
 		return tx.QueryRowContext(ctx, "SELECT count(*) FROM dolt_remotes WHERE name = ?", name).Scan(&count)
 	})
 	if err != nil {
@@ -150,10 +157,32 @@ func (s *EmbeddedDoltStore) Branch(ctx context.Context, name string) error {
 	})
 }
 
+// Checkout switches the store to branch. Every connection is opened fresh
+// with the store's head ref, so the branch is recorded on the store rather
+// than left on the session that ran DOLT_CHECKOUT.
+// Checkout switches the store to branch. Every connection is pinned to the
+// store's branch, so a read-only store only has to confirm the branch exists
+// to read from it.
 func (s *EmbeddedDoltStore) Checkout(ctx context.Context, branch string) error {
-	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+	if s.readOnly {
+		branches, err := s.ListBranches(ctx)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(branches, branch) {
+			return fmt.Errorf("checkout branch %s: branch not found", branch)
+		}
+		s.branch = branch
+		return nil
+	}
+	err := s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
 		return versioncontrolops.CheckoutBranch(ctx, db, branch)
 	})
+	if err != nil {
+		return err
+	}
+	s.branch = branch
+	return nil
 }
 
 func (s *EmbeddedDoltStore) CurrentBranch(ctx context.Context) (string, error) {
@@ -209,6 +238,47 @@ func (s *EmbeddedDoltStore) Status(ctx context.Context) (*storage.Status, error)
 	return status, err
 }
 
+// CreateCheckpoint tags HEAD as name. Implements storage.Checkpointer.
+func (s *EmbeddedDoltStore) CreateCheckpoint(ctx context.Context, name, message, actor string) (*storage.Checkpoint, error) {
+	var cp *storage.Checkpoint
+	err := s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		if err := versioncontrolops.CreateTag(ctx, db, name, "HEAD", message, actor+" <"+commitEmail+">"); err != nil {
+			return err
+		}
+		var err error
+		cp, err = versioncontrolops.GetTag(ctx, db, name)
+		return err
+	})
+	return cp, err
+}
+
+// BranchAt creates branch at ref. Implements storage.Checkpointer.
+func (s *EmbeddedDoltStore) BranchAt(ctx context.Context, branch, ref string) error {
+	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		return versioncontrolops.CreateBranchAt(ctx, db, branch, ref)
+	})
+}
+
+// MoveCheckpoint re-points checkpoint c at hash. Implements
+// storage.Checkpointer.
+func (s *EmbeddedDoltStore) MoveCheckpoint(ctx context.Context, c storage.Checkpoint, hash string) error {
+	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		return versioncontrolops.MoveTag(ctx, db, c, hash)
+	})
+}
+
+// ListCheckpoints returns every tag, newest first. Implements
+// storage.Checkpointer.
+func (s *EmbeddedDoltStore) ListCheckpoints(ctx context.Context) ([]storage.Checkpoint, error) {
+	var cps []storage.Checkpoint
+	err := s.withDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		var err error
+		cps, err = versioncontrolops.ListTagsWithInfo(ctx, db)
+		return err
+	})
+	return cps, err
+}
+
 func (s *EmbeddedDoltStore) Log(ctx context.Context, limit int) ([]storage.CommitInfo, error) {
 	var commits []storage.CommitInfo
 	err := s.withDBConn(ctx, func(db versioncontrolops.DBConn) error {
@@ -259,7 +329,7 @@ func (s *EmbeddedDoltStore) RecomputeBlockedAfterMerge(ctx context.Context, from
 // of the mode-independent repair (bd-6dnrw.37); see DoltStore.RecomputeAllBlocked.
 func (s *EmbeddedDoltStore) RecomputeAllBlocked(ctx context.Context) (int, error) {
 	var changed int64
-	if err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	if err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		// Refuse to derive and commit is_blocked from a dirty graph (see
 		// DoltStore.RecomputeAllBlocked); checked inside the recompute tx so it
 		// sees the same working set the recompute will read (bd-6dnrw.37).
@@ -295,8 +365,14 @@ func (s *EmbeddedDoltStore) GetConflicts(ctx context.Context) ([]storage.Conflic
 	return conflicts, err
 }
 
+// ResolveConflicts resolves a table's conflicts with strategy. The session
+// allows conflict commits so one table can be resolved while others are
+// still conflicted.
 func (s *EmbeddedDoltStore) ResolveConflicts(ctx context.Context, table string, strategy string) error {
-	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+	return s.withMutatingPinnedDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		if err := versioncontrolops.AllowConflictCommits(ctx, db); err != nil {
+			return err
+		}
 		return versioncontrolops.ResolveConflicts(ctx, db, table, strategy)
 	})
 }
@@ -333,9 +409,24 @@ func (s *EmbeddedDoltStore) ListRemotes(ctx context.Context) ([]storage.RemoteIn
 	return remotes, err
 }
 
+// withRemoteRetry runs one push, pull, or fetch against remote under
+// retry.RemoteFromConfig, reported as a progress task. Each attempt opens
+// its own connection, so the store's lock is not held while waiting.
+func withRemoteRetry(ctx context.Context, op, remote string, fn func() error) error {
+	_, task := progress.Start(ctx, op+" "+remote, 0)
+	defer task.Finish()
+	policy := retry.RemoteFromConfig()
+	policy.OnRetry = retry.Notice(os.Stderr, op+" "+remote, policy.MaxAttempts)
+	return retry.Do(ctx, policy, func(err error) bool {
+		return retry.IsTransient(err) || retry.IsLockContention(err)
+	}, fn)
+}
+
 func (s *EmbeddedDoltStore) Push(ctx context.Context) error {
-	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
-		return versioncontrolops.Push(ctx, db, defaultRemote, s.branch, remoteAuthUser())
+	return withRemoteRetry(ctx, "push", defaultRemote, func() error {
+		return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+			return versioncontrolops.Push(ctx, db, defaultRemote, s.branch, remoteAuthUser())
+		})
 	})
 }
 
@@ -347,8 +438,10 @@ func (s *EmbeddedDoltStore) Pull(ctx context.Context) error {
 		return fmt.Errorf("commit pending before pull: %w", err)
 	}
 	preHead := s.preMergeHead(ctx)
-	err := s.withMutatingPinnedDBConn(ctx, func(db versioncontrolops.DBConn) error {
-		return versioncontrolops.Pull(ctx, db, defaultRemote, s.branch, remoteAuthUser())
+	err := withRemoteRetry(ctx, "pull", defaultRemote, func() error {
+		return s.withMutatingPinnedDBConn(ctx, func(db versioncontrolops.DBConn) error {
+			return versioncontrolops.Pull(ctx, db, defaultRemote, s.branch, remoteAuthUser())
+		})
 	})
 	if err != nil {
 		return err
@@ -357,17 +450,21 @@ func (s *EmbeddedDoltStore) Pull(ctx context.Context) error {
 }
 
 func (s *EmbeddedDoltStore) ForcePush(ctx context.Context) error {
-	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
-		return versioncontrolops.ForcePush(ctx, db, defaultRemote, s.branch, remoteAuthUser())
+	return withRemoteRetry(ctx, "push", defaultRemote, func() error {
+		return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+			return versioncontrolops.ForcePush(ctx, db, defaultRemote, s.branch, remoteAuthUser())
+		})
 	})
 }
 
 func (s *EmbeddedDoltStore) PushRemote(ctx context.Context, remote string, force bool) error {
-	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
-		if force {
-			return versioncontrolops.ForcePush(ctx, db, remote, s.branch, remoteAuthUser())
-		}
-		return versioncontrolops.Push(ctx, db, remote, s.branch, remoteAuthUser())
+	return withRemoteRetry(ctx, "push", remote, func() error {
+		return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+			if force {
+				return versioncontrolops.ForcePush(ctx, db, remote, s.branch, remoteAuthUser())
+			}
+			return versioncontrolops.Push(ctx, db, remote, s.branch, remoteAuthUser())
+		})
 	})
 }
 
@@ -377,8 +474,10 @@ func (s *EmbeddedDoltStore) PullRemote(ctx context.Context, remote string) error
 		return fmt.Errorf("commit pending before pull: %w", err)
 	}
 	preHead := s.preMergeHead(ctx)
-	err := s.withMutatingPinnedDBConn(ctx, func(db versioncontrolops.DBConn) error {
-		return versioncontrolops.Pull(ctx, db, remote, s.branch, remoteAuthUser())
+	err := withRemoteRetry(ctx, "pull", remote, func() error {
+		return s.withMutatingPinnedDBConn(ctx, func(db versioncontrolops.DBConn) error {
+			return versioncontrolops.Pull(ctx, db, remote, s.branch, remoteAuthUser())
+		})
 	})
 	if err != nil {
 		return err
@@ -387,18 +486,29 @@ func (s *EmbeddedDoltStore) PullRemote(ctx context.Context, remote string) error
 }
 
 func (s *EmbeddedDoltStore) Fetch(ctx context.Context, peer string) error {
-	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
-		return versioncontrolops.Fetch(ctx, db, peer, remoteAuthUser())
+	return withRemoteRetry(ctx, "fetch", peer, func() error {
+		return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+			return versioncontrolops.Fetch(ctx, db, peer, remoteAuthUser())
+		})
 	})
 }
 
 func (s *EmbeddedDoltStore) PushTo(ctx context.Context, peer string) error {
-	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
-		return versioncontrolops.Push(ctx, db, peer, s.branch, remoteAuthUser())
+	if err := s.checkPeerDirection(ctx, peer, true); err != nil {
+		return err
+	}
+	return withRemoteRetry(ctx, "push", peer, func() error {
+		return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+			return versioncontrolops.Push(ctx, db, peer, s.branch, remoteAuthUser())
+		})
 	})
 }
 
 func (s *EmbeddedDoltStore) PullFrom(ctx context.Context, peer string) ([]storage.Conflict, error) {
+	if err := s.checkPeerDirection(ctx, peer, false); err != nil {
+		return nil, err
+	}
+
 	// Auto-commit pending changes before pull to prevent
 	// "cannot merge with uncommitted changes" errors.
 	if _, err := s.CommitPending(ctx, "beads"); err != nil {
@@ -407,20 +517,22 @@ func (s *EmbeddedDoltStore) PullFrom(ctx context.Context, peer string) ([]storag
 
 	preHead := s.preMergeHead(ctx)
 	var conflicts []storage.Conflict
-	err := s.withMutatingPinnedDBConn(ctx, func(db versioncontrolops.DBConn) error {
-		if pullErr := versioncontrolops.Pull(ctx, db, peer, s.branch, remoteAuthUser()); pullErr != nil {
-			// bd-578h9.15: the settle machinery aborts a merge it cannot
-			// auto-resolve before returning, so dolt_conflicts is already
-			// empty here; the conflicts arrive captured pre-abort inside
-			// MergeConflictsError instead.
-			var mce *versioncontrolops.MergeConflictsError
-			if errors.As(pullErr, &mce) {
-				conflicts = mce.Conflicts
-				return nil
+	err := withRemoteRetry(ctx, "pull", peer, func() error {
+		return s.withMutatingPinnedDBConn(ctx, func(db versioncontrolops.DBConn) error {
+			if pullErr := versioncontrolops.Pull(ctx, db, peer, s.branch, remoteAuthUser()); pullErr != nil {
+				// bd-578h9.15: the settle machinery aborts a merge it cannot
+				// auto-resolve before returning, so dolt_conflicts is already
+				// empty here; the conflicts arrive captured pre-abort inside
+				// MergeConflictsError instead.
+				var mce *versioncontrolops.MergeConflictsError
+				if errors.As(pullErr, &mce) {
+					conflicts = mce.Conflicts
+					return nil
+				}
+				return fmt.Errorf("pull from %s: %w", peer, pullErr)
 			}
-			return fmt.Errorf("pull from %s: %w", peer, pullErr)
-		}
-		return nil
+			return nil
+		})
 	})
 	if err != nil || len(conflicts) > 0 {
 		// Conflicted pulls skip the recompute: the operator resolves first,
@@ -452,14 +564,14 @@ func (s *EmbeddedDoltStore) preMergeHead(ctx context.Context) string {
 // unchanged) is a no-op; derived state converges, so committing it on every
 // clone is merge-safe.
 func (s *EmbeddedDoltStore) recomputeBlockedAfterPull(ctx context.Context, preHead string) error {
-	if err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+	if err := s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 		return issueops.RecomputeIsBlockedAfterMergeInTx(ctx, tx, preHead)
 	}); err != nil {
 		// The merge this recompute covers is already committed, so a plain
 		// retry on the next pull would skip as "nothing merged" — leave a
 		// marker so it widens its window instead (bd-578h9.11). Best-effort:
 		// the recompute error is what matters.
-		_ = s.withConn(ctx, true, func(tx *sql.Tx) error {
+		_ = s.withConn(ctx, true, func(ctx context.Context, tx *sql.Tx) error {
 			return issueops.MarkIsBlockedRecomputePendingInTx(ctx, tx, preHead)
 		})
 		return err
@@ -481,8 +593,10 @@ func (s *EmbeddedDoltStore) BackupAdd(ctx context.Context, name, url string) err
 }
 
 func (s *EmbeddedDoltStore) BackupSync(ctx context.Context, name string) error {
-	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
-		return versioncontrolops.BackupSync(ctx, db, name)
+	return withRemoteRetry(ctx, "backup sync", name, func() error {
+		return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+			return versioncontrolops.BackupSync(ctx, db, name)
+		})
 	})
 }
 
@@ -553,3 +667,39 @@ func (s *EmbeddedDoltStore) RestoreDatabase(ctx context.Context, dir string, for
 		return versioncontrolops.BackupRestore(ctx, db, backupURL, s.database, force)
 	})
 }
+
+// CheckIntegrity reads back the issues committed at commit (HEAD when
+// empty) and counts recorded constraint violations.
+func (s *EmbeddedDoltStore) CheckIntegrity(ctx context.Context, commit string) (*storage.IntegrityReport, error) {
+	var report *storage.IntegrityReport
+	err := s.withDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		var err error
+		report, err = versioncontrolops.CheckIntegrity(ctx, db, commit)
+		return err
+	})
+	return report, err
+}
+
+// CheckBackupIntegrity checks the Dolt backup at dir by restoring it into a
+// scratch database beside this one and dropping it afterwards.
+func (s *EmbeddedDoltStore) CheckBackupIntegrity(ctx context.Context, dir string) (*storage.IntegrityReport, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("backup does not exist: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("backup is not a directory: %s", dir)
+	}
+
+	backupURL, err := versioncontrolops.DirToFileURL(dir)
+	if err != nil {
+		return nil, err
+	}
+	var report *storage.IntegrityReport
+	err = s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		var err error
+		report, err = versioncontrolops.CheckBackupIntegrity(ctx, db, backupURL)
+		return err
+	})
+	return report, err
+}
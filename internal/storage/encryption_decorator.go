@@ -0,0 +1,462 @@
+// Package storage — encryption_decorator.go
+//
+// EncryptingStore is a decorator around DoltStorage that keeps the
+// description, design, and notes of issues flagged Encrypted as AES-GCM
+// ciphertext at rest. Values are sealed with the workspace's issue key
+// before they are written and opened again by GetIssue.
+//
+// Usage:
+//
+//	store = storage.NewEncryptingStore(rawStore, filepath.Join(beadsDir, storage.IssueKeyFile))
+//
+// Only those three fields are encrypted. Titles, labels, comments, and the
+// rest of the row stay readable, so list, ready, and search keep working,
+// but search does not match encrypted text. Reads other than GetIssue
+// (SearchIssues, export) return the ciphertext, which keeps plaintext out of
+// issues.jsonl; import writes it back unchanged.
+//
+// The key is created on the first encrypted write. Without it, GetIssue
+// returns the ciphertext rather than failing, and IsEncryptedValue tells
+// callers the value could not be opened.
+package storage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// IssueKeyFile is the name of the issue encryption key in the .beads
+// directory. It must never be committed; share it with teammates out of
+// band.
+const IssueKeyFile = ".beads-issue-key" //nolint:gosec // G101: filename, not a credential
+
+// issueKeySize is the AES-256 key length.
+const issueKeySize = 32
+
+// encryptedValuePrefix marks a sealed field value. A value that carries it
+// is only left alone when it is real ciphertext: one that opens with the
+// issue key, or, on import, one shaped like ciphertext. Anything else, such
+// as user text that happens to start with the prefix, is sealed.
+const encryptedValuePrefix = "bdenc:v1:"
+
+// ErrIssueKeyUnavailable is returned when an encrypted value must be opened
+// (for example to turn encryption off) and the issue key file is missing.
+var ErrIssueKeyUnavailable = errors.New("issue encryption key not found")
+
+// encryptedFields are the issue fields sealed for Encrypted issues, keyed by
+// their UpdateIssue column name.
+var encryptedFields = []struct {
+	column string
+	field  func(*types.Issue) *string
+}{
+	{"description", func(i *types.Issue) *string { return &i.Description }},
+	{"design", func(i *types.Issue) *string { return &i.Design }},
+	{"notes", func(i *types.Issue) *string { return &i.Notes }},
+}
+
+// IsEncryptedValue reports whether s is a sealed field value, i.e. an
+// encrypted field that has not been decrypted.
+func IsEncryptedValue(s string) bool {
+	return strings.HasPrefix(s, encryptedValuePrefix)
+}
+
+// EncryptingStore wraps a DoltStorage and encrypts the sensitive fields of
+// Encrypted issues. Methods that do not write or read those fields pass
+// through to the inner store unchanged.
+type EncryptingStore struct {
+	DoltStorage             // embed for passthrough of non-overridden methods
+	inner       DoltStorage // the real store
+	keyPath     string
+
+	mu  sync.Mutex
+	key []byte
+}
+
+// NewEncryptingStore wraps store with field encryption using the key at
+// keyPath. The key is read on first use.
+func NewEncryptingStore(store DoltStorage, keyPath string) *EncryptingStore {
+	return &EncryptingStore{DoltStorage: store, inner: store, keyPath: keyPath}
+}
+
+// Unwrap returns the underlying store, satisfying Unwrapper.
+func (e *EncryptingStore) Unwrap() DoltStorage { return e.inner }
+
+// ── Issue mutations ─────────────────────────────────────────────────
+
+// CreateIssue seals the sensitive fields of an Encrypted issue before
+// creating it. The caller's issue keeps its plaintext.
+func (e *EncryptingStore) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	restore, err := e.sealIssues([]*types.Issue{issue}, false)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	return e.inner.CreateIssue(ctx, issue, actor)
+}
+
+// CreateIssues seals the sensitive fields of each Encrypted issue before
+// creating them.
+func (e *EncryptingStore) CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error {
+	restore, err := e.sealIssues(issues, false)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	return e.inner.CreateIssues(ctx, issues, actor)
+}
+
+// UpsertIssues seals the sensitive fields of each Encrypted issue. Values
+// that are already sealed, as in an exported JSONL file, are kept as is,
+// even when this clone lacks the key to open them.
+func (e *EncryptingStore) UpsertIssues(ctx context.Context, issues []*types.Issue, actor string) (*UpsertIssuesResult, error) {
+	restore, err := e.sealIssues(issues, true)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	return e.inner.UpsertIssues(ctx, issues, actor)
+}
+
+// UpdateIssue seals updated fields of an Encrypted issue, and re-encodes the
+// stored fields when the update turns encryption on or off.
+func (e *EncryptingStore) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	sealed, err := e.sealUpdates(ctx, id, updates, e.inner.GetIssue)
+	if err != nil {
+		return err
+	}
+	return e.inner.UpdateIssue(ctx, id, sealed, actor)
+}
+
+// UpdateIssueChecked is UpdateIssue with UpdateIssueOptions.
+func (e *EncryptingStore) UpdateIssueChecked(ctx context.Context, id string, updates map[string]interface{}, actor string, opts UpdateIssueOptions) error {
+	sealed, err := e.sealUpdates(ctx, id, updates, e.inner.GetIssue)
+	if err != nil {
+		return err
+	}
+	return e.inner.UpdateIssueChecked(ctx, id, sealed, actor, opts)
+}
+
+// ── Reads ───────────────────────────────────────────────────────────
+
+// GetIssue decrypts the sensitive fields of an Encrypted issue when the key
+// is available.
+func (e *EncryptingStore) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	issue, err := e.inner.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	e.openIssue(issue)
+	return issue, nil
+}
+
+// ── Transactions ────────────────────────────────────────────────────
+
+// RunInTransaction wraps the callback's transaction so its creates, updates,
+// and reads are encrypted like the store's.
+func (e *EncryptingStore) RunInTransaction(ctx context.Context, commitMsg string, fn func(tx Transaction) error) error {
+	return e.inner.RunInTransaction(ctx, commitMsg, func(tx Transaction) error {
+		return fn(&encryptingTransaction{Transaction: tx, store: e})
+	})
+}
+
+// encryptingTransaction wraps a Transaction with the store's encryption.
+type encryptingTransaction struct {
+	Transaction
+	store *EncryptingStore
+}
+
+func (t *encryptingTransaction) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	restore, err := t.store.sealIssues([]*types.Issue{issue}, false)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	return t.Transaction.CreateIssue(ctx, issue, actor)
+}
+
+func (t *encryptingTransaction) CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error {
+	restore, err := t.store.sealIssues(issues, false)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	return t.Transaction.CreateIssues(ctx, issues, actor)
+}
+
+func (t *encryptingTransaction) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	sealed, err := t.store.sealUpdates(ctx, id, updates, t.Transaction.GetIssue)
+	if err != nil {
+		return err
+	}
+	return t.Transaction.UpdateIssue(ctx, id, sealed, actor)
+}
+
+func (t *encryptingTransaction) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	issue, err := t.Transaction.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	t.store.openIssue(issue)
+	return issue, nil
+}
+
+// ── Internal helpers ────────────────────────────────────────────────
+
+// sealIssues encrypts the sensitive fields of the Encrypted issues in place
+// and returns a func that puts the caller's plaintext back. With
+// keepCiphertext, values shaped like ciphertext are kept without checking
+// that this clone's key opens them.
+func (e *EncryptingStore) sealIssues(issues []*types.Issue, keepCiphertext bool) (func(), error) {
+	type saved struct {
+		field *string
+		value string
+	}
+	var originals []saved
+	restore := func() {
+		for _, s := range originals {
+			*s.field = s.value
+		}
+	}
+	for _, issue := range issues {
+		if issue == nil || !issue.Encrypted {
+			continue
+		}
+		for _, f := range encryptedFields {
+			p := f.field(issue)
+			if keepCiphertext && looksSealed(*p) {
+				continue
+			}
+			sealed, err := e.seal(*p)
+			if err != nil {
+				restore()
+				return nil, err
+			}
+			originals = append(originals, saved{p, *p})
+			*p = sealed
+		}
+	}
+	return restore, nil
+}
+
+// sealUpdates returns updates with the sensitive fields sealed as the issue's
+// encryption state after the update requires. Turning encryption on seals
+// the stored fields the update leaves alone; turning it off opens them.
+// The caller's map is not modified.
+func (e *EncryptingStore) sealUpdates(ctx context.Context, id string, updates map[string]interface{}, get func(context.Context, string) (*types.Issue, error)) (map[string]interface{}, error) {
+	want, explicit := updates["encrypted"].(bool)
+	touched := false
+	for _, f := range encryptedFields {
+		if _, ok := updates[f.column]; ok {
+			touched = true
+		}
+	}
+	if !explicit && !touched {
+		return updates, nil
+	}
+	current, err := get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !explicit {
+		want = current.Encrypted
+	}
+	if !want && !current.Encrypted {
+		return updates, nil
+	}
+
+	out := maps.Clone(updates)
+	for _, f := range encryptedFields {
+		v, ok := out[f.column]
+		if !ok {
+			if want == current.Encrypted {
+				continue
+			}
+			v = *f.field(current)
+		}
+		s, isString := v.(string)
+		if !isString {
+			continue
+		}
+		if want {
+			s, err = e.seal(s)
+		} else {
+			s, err = e.open(s)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s of %s: %w", f.column, id, err)
+		}
+		out[f.column] = s
+	}
+	return out, nil
+}
+
+// openIssue decrypts the sensitive fields of an Encrypted issue in place.
+// Fields that cannot be opened, because the key is missing or wrong, keep
+// their ciphertext.
+func (e *EncryptingStore) openIssue(issue *types.Issue) {
+	if issue == nil || !issue.Encrypted {
+		return
+	}
+	for _, f := range encryptedFields {
+		p := f.field(issue)
+		if opened, err := e.open(*p); err == nil {
+			*p = opened
+		}
+	}
+}
+
+// seal encrypts s, creating the key if needed. Empty values, and values
+// already sealed with the issue key, are returned unchanged.
+func (e *EncryptingStore) seal(s string) (string, error) {
+	if s == "" {
+		return s, nil
+	}
+	if IsEncryptedValue(s) {
+		if _, err := e.open(s); err == nil {
+			return s, nil
+		}
+	}
+	key, err := e.loadKey(true)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newIssueGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(s), nil)
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// looksSealed reports whether s has the form of a sealed value: the prefix
+// followed by base64 of at least a nonce and an authentication tag.
+func looksSealed(s string) bool {
+	if !IsEncryptedValue(s) {
+		return false
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, encryptedValuePrefix))
+	return err == nil && len(data) >= 12+16
+}
+
+// open decrypts a sealed value. Values that are not sealed are returned
+// unchanged.
+func (e *EncryptingStore) open(s string) (string, error) {
+	if !IsEncryptedValue(s) {
+		return s, nil
+	}
+	key, err := e.loadKey(false)
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, encryptedValuePrefix))
+	if err != nil {
+		return "", fmt.Errorf("decode encrypted value: %w", err)
+	}
+	gcm, err := newIssueGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("encrypted value too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypt value (wrong issue key?): %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// loadKey returns the issue key, reading it from keyPath on first use. With
+// create, a missing key file is generated with mode 0600.
+func (e *EncryptingStore) loadKey(create bool) ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.key != nil {
+		return e.key, nil
+	}
+	key, err := os.ReadFile(e.keyPath) // #nosec G304 -- path is .beads/.beads-issue-key
+	switch {
+	case err == nil:
+		if len(key) != issueKeySize {
+			return nil, fmt.Errorf("issue key %s: want %d bytes, got %d", e.keyPath, issueKeySize, len(key))
+		}
+	case errors.Is(err, os.ErrNotExist) && create:
+		if key, err = createIssueKey(e.keyPath); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("%w: %s", ErrIssueKeyUnavailable, e.keyPath)
+	default:
+		return nil, fmt.Errorf("read issue key: %w", err)
+	}
+	e.key = key
+	return key, nil
+}
+
+// createIssueKey writes a new random key to path. If another process created
+// the file first, its key is used instead.
+func createIssueKey(path string) ([]byte, error) {
+	key := make([]byte, issueKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("generate issue key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create issue key directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) // #nosec G304 -- path is .beads/.beads-issue-key
+	if errors.Is(err, os.ErrExist) {
+		existing, readErr := os.ReadFile(path) // #nosec G304 -- path is .beads/.beads-issue-key
+		if readErr != nil {
+			return nil, fmt.Errorf("read issue key: %w", readErr)
+		}
+		if len(existing) != issueKeySize {
+			return nil, fmt.Errorf("issue key %s: want %d bytes, got %d", path, issueKeySize, len(existing))
+		}
+		return existing, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create issue key: %w", err)
+	}
+	if _, err := f.Write(key); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("write issue key: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("write issue key: %w", err)
+	}
+	return key, nil
+}
+
+func newIssueGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// Compile-time interface checks.
+var _ DoltStorage = (*EncryptingStore)(nil)
+var _ Transaction = (*encryptingTransaction)(nil)
@@ -0,0 +1,215 @@
+package storage_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// encryptionStubStore keeps issues in memory and applies string updates, so
+// the test can see exactly what the decorator writes.
+type encryptionStubStore struct {
+	storage.DoltStorage
+	issues map[string]*types.Issue
+}
+
+func (s *encryptionStubStore) CreateIssue(_ context.Context, issue *types.Issue, _ string) error {
+	stored := *issue
+	s.issues[issue.ID] = &stored
+	return nil
+}
+
+func (s *encryptionStubStore) UpsertIssues(ctx context.Context, issues []*types.Issue, actor string) (*storage.UpsertIssuesResult, error) {
+	for _, issue := range issues {
+		_ = s.CreateIssue(ctx, issue, actor)
+	}
+	return &storage.UpsertIssuesResult{}, nil
+}
+
+func (s *encryptionStubStore) GetIssue(_ context.Context, id string) (*types.Issue, error) {
+	issue, ok := s.issues[id]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	copied := *issue
+	return &copied, nil
+}
+
+func (s *encryptionStubStore) UpdateIssue(_ context.Context, id string, updates map[string]interface{}, _ string) error {
+	issue, ok := s.issues[id]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	for k, v := range updates {
+		switch k {
+		case "description":
+			issue.Description = v.(string)
+		case "design":
+			issue.Design = v.(string)
+		case "notes":
+			issue.Notes = v.(string)
+		case "encrypted":
+			issue.Encrypted = v.(bool)
+		}
+	}
+	return nil
+}
+
+func (s *encryptionStubStore) RunInTransaction(_ context.Context, _ string, fn func(storage.Transaction) error) error {
+	return fn(&encryptionStubTx{store: s})
+}
+
+type encryptionStubTx struct {
+	storage.Transaction
+	store *encryptionStubStore
+}
+
+func (t *encryptionStubTx) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	return t.store.CreateIssue(ctx, issue, actor)
+}
+
+func (t *encryptionStubTx) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	return t.store.GetIssue(ctx, id)
+}
+
+func TestEncryptingStore(t *testing.T) {
+	ctx := context.Background()
+	keyPath := filepath.Join(t.TempDir(), storage.IssueKeyFile)
+	inner := &encryptionStubStore{issues: map[string]*types.Issue{}}
+	store := storage.NewEncryptingStore(inner, keyPath)
+	if storage.UnwrapStore(store) != storage.DoltStorage(inner) {
+		t.Fatal("UnwrapStore did not reach the inner store")
+	}
+
+	// A plain issue is stored as is and creates no key.
+	if err := store.CreateIssue(ctx, &types.Issue{ID: "bd-1", Description: "public"}, "me"); err != nil {
+		t.Fatal(err)
+	}
+	if inner.issues["bd-1"].Description != "public" {
+		t.Errorf("plain description stored as %q", inner.issues["bd-1"].Description)
+	}
+	if _, err := os.Stat(keyPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("key file created for a plain issue: %v", err)
+	}
+
+	// An encrypted issue is sealed at rest; the caller keeps its plaintext.
+	issue := &types.Issue{ID: "bd-2", Title: "title", Description: "secret", Notes: "note", Encrypted: true}
+	if err := store.CreateIssue(ctx, issue, "me"); err != nil {
+		t.Fatal(err)
+	}
+	if issue.Description != "secret" {
+		t.Errorf("caller's description changed to %q", issue.Description)
+	}
+	raw := inner.issues["bd-2"]
+	if !storage.IsEncryptedValue(raw.Description) || !storage.IsEncryptedValue(raw.Notes) {
+		t.Errorf("stored fields not sealed: %q, %q", raw.Description, raw.Notes)
+	}
+	if raw.Title != "title" || raw.Design != "" {
+		t.Errorf("title or empty design altered: %q, %q", raw.Title, raw.Design)
+	}
+	if info, err := os.Stat(keyPath); err != nil {
+		t.Fatalf("key file not created: %v", err)
+	} else if info.Mode().Perm() != 0o600 {
+		t.Errorf("key file mode = %v, want 0600", info.Mode().Perm())
+	}
+	got, err := store.GetIssue(ctx, "bd-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Description != "secret" || got.Notes != "note" {
+		t.Errorf("GetIssue = %q, %q; want decrypted", got.Description, got.Notes)
+	}
+
+	// Updates to an encrypted issue are sealed too.
+	if err := store.UpdateIssue(ctx, "bd-2", map[string]interface{}{"design": "plan"}, "me"); err != nil {
+		t.Fatal(err)
+	}
+	if !storage.IsEncryptedValue(inner.issues["bd-2"].Design) {
+		t.Errorf("updated design stored as %q", inner.issues["bd-2"].Design)
+	}
+
+	// Turning encryption off decrypts the stored fields; on seals them.
+	if err := store.UpdateIssue(ctx, "bd-2", map[string]interface{}{"encrypted": false}, "me"); err != nil {
+		t.Fatal(err)
+	}
+	if raw := inner.issues["bd-2"]; raw.Description != "secret" || raw.Design != "plan" || raw.Encrypted {
+		t.Errorf("after --encrypted=false: %+v", raw)
+	}
+	if err := store.UpdateIssue(ctx, "bd-1", map[string]interface{}{"encrypted": true}, "me"); err != nil {
+		t.Fatal(err)
+	}
+	if !storage.IsEncryptedValue(inner.issues["bd-1"].Description) {
+		t.Errorf("after --encrypted: description stored as %q", inner.issues["bd-1"].Description)
+	}
+
+	// Transactional creates and reads are encrypted like the store's.
+	err = store.RunInTransaction(ctx, "create", func(tx storage.Transaction) error {
+		if err := tx.CreateIssue(ctx, &types.Issue{ID: "bd-3", Notes: "tx note", Encrypted: true}, "me"); err != nil {
+			return err
+		}
+		got, err := tx.GetIssue(ctx, "bd-3")
+		if err != nil {
+			return err
+		}
+		if got.Notes != "tx note" {
+			t.Errorf("tx GetIssue notes = %q, want decrypted", got.Notes)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !storage.IsEncryptedValue(inner.issues["bd-3"].Notes) {
+		t.Errorf("transactional notes stored as %q", inner.issues["bd-3"].Notes)
+	}
+
+	// Text that merely starts with the sealed-value prefix is still sealed,
+	// and reads back as typed.
+	fake := "bdenc:v1:not really sealed"
+	if err := store.UpdateIssue(ctx, "bd-2", map[string]interface{}{"encrypted": true, "notes": fake}, "me"); err != nil {
+		t.Fatal(err)
+	}
+	if stored := inner.issues["bd-2"].Notes; stored == fake || !storage.IsEncryptedValue(stored) {
+		t.Errorf("prefixed notes stored as %q, want sealed", stored)
+	}
+	if got, err := store.GetIssue(ctx, "bd-2"); err != nil || got.Notes != fake {
+		t.Errorf("GetIssue notes = %q, %v; want %q", got.Notes, err, fake)
+	}
+
+	// Import keeps ciphertext as is, even when this clone cannot open it,
+	// but seals prefixed text that is not ciphertext.
+	foreign := "bdenc:v1:" + base64.StdEncoding.EncodeToString(make([]byte, 40))
+	imported := []*types.Issue{
+		{ID: "bd-4", Description: foreign, Encrypted: true},
+		{ID: "bd-5", Description: fake, Encrypted: true},
+	}
+	if _, err := store.UpsertIssues(ctx, imported, "me"); err != nil {
+		t.Fatal(err)
+	}
+	if got := inner.issues["bd-4"].Description; got != foreign {
+		t.Errorf("imported ciphertext stored as %q", got)
+	}
+	if got := inner.issues["bd-5"].Description; got == fake || !storage.IsEncryptedValue(got) {
+		t.Errorf("imported prefixed text stored as %q, want sealed", got)
+	}
+
+	// Without the key, reads return the ciphertext and decrypting fails.
+	locked := storage.NewEncryptingStore(inner, filepath.Join(t.TempDir(), storage.IssueKeyFile))
+	got, err = locked.GetIssue(ctx, "bd-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !storage.IsEncryptedValue(got.Description) {
+		t.Errorf("GetIssue without key = %q, want ciphertext", got.Description)
+	}
+	err = locked.UpdateIssue(ctx, "bd-1", map[string]interface{}{"encrypted": false}, "me")
+	if !errors.Is(err, storage.ErrIssueKeyUnavailable) {
+		t.Errorf("decrypt without key = %v, want ErrIssueKeyUnavailable", err)
+	}
+}
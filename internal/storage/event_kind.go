@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// EventKind is a registered kind of event issue (issue_type "event"). Its
+// Payload schema validates the JSON payload of events of that kind, so
+// consumers querying for, say, "deploy" events can rely on their shape.
+type EventKind struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Payload validates top-level fields of the event's JSON payload.
+	Payload map[string]MetadataFieldSchema `json:"payload,omitempty"`
+	// Strict rejects payload fields that Payload does not declare.
+	Strict bool `json:"strict"`
+	// RequireActor and RequireTarget reject events of this kind without an
+	// actor or target.
+	RequireActor  bool `json:"require_actor"`
+	RequireTarget bool `json:"require_target"`
+}
+
+// EventKindFor returns the registered kind named name, or nil.
+func EventKindFor(name string, kinds []EventKind) *EventKind {
+	for i := range kinds {
+		if kinds[i].Name == name {
+			return &kinds[i]
+		}
+	}
+	return nil
+}
+
+// ValidateEvent checks an event's actor, target, and payload against its
+// kind. Error fields are "actor", "target", or "payload.<field>". An empty
+// payload is treated as an empty object. An empty list means validation
+// passed.
+func ValidateEvent(kind EventKind, actor, target, payload string) []MetadataValidationError {
+	var errs []MetadataValidationError
+	if kind.RequireActor && actor == "" {
+		errs = append(errs, MetadataValidationError{Field: "actor", Message: "required for " + kind.Name + " events"})
+	}
+	if kind.RequireTarget && target == "" {
+		errs = append(errs, MetadataValidationError{Field: "target", Message: "required for " + kind.Name + " events"})
+	}
+
+	parsed := map[string]interface{}{}
+	if payload != "" {
+		if err := json.Unmarshal([]byte(payload), &parsed); err != nil {
+			return append(errs, MetadataValidationError{Field: "payload", Message: "must be a JSON object"})
+		}
+	}
+
+	names := make([]string, 0, len(parsed))
+	for name := range parsed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		schema, declared := kind.Payload[name]
+		if !declared {
+			if kind.Strict {
+				errs = append(errs, MetadataValidationError{
+					Field:   "payload." + name,
+					Message: fmt.Sprintf("not a declared field of event kind %q", kind.Name),
+				})
+			}
+			continue
+		}
+		errs = append(errs, validateMetadataField("payload."+name, parsed[name], schema)...)
+	}
+
+	fields := make([]string, 0, len(kind.Payload))
+	for name := range kind.Payload {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	for _, name := range fields {
+		if _, ok := parsed[name]; !ok && kind.Payload[name].Required {
+			errs = append(errs, MetadataValidationError{Field: "payload." + name, Message: "required field is missing"})
+		}
+	}
+	return errs
+}
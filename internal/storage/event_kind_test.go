@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateEvent(t *testing.T) {
+	deploy := EventKind{
+		Name:          "deploy",
+		Strict:        true,
+		RequireTarget: true,
+		Payload: map[string]MetadataFieldSchema{
+			"version":    {Type: MetadataFieldString, Required: true},
+			"env":        {Type: MetadataFieldEnum, Values: []string{"staging", "prod"}},
+			"duration_s": {Type: MetadataFieldInt},
+		},
+	}
+	tests := []struct {
+		name    string
+		target  string
+		payload string
+		want    []string // substrings, one per expected error
+	}{
+		{"valid", "svc/api", `{"version":"1.2","env":"prod","duration_s":30}`, nil},
+		{"missing target", "", `{"version":"1.2"}`, []string{"target: required"}},
+		{"wrong type", "svc/api", `{"version":"1.2","duration_s":"30"}`, []string{"payload.duration_s: expected int"}},
+		{"bad enum", "svc/api", `{"version":"1.2","env":"dev"}`, []string{"payload.env: value \"dev\""}},
+		{"undeclared in strict", "svc/api", `{"version":"1.2","extra":1}`, []string{"payload.extra: not a declared field"}},
+		{"required missing", "svc/api", ``, []string{"payload.version: required field is missing"}},
+		{"not an object", "svc/api", `[1]`, []string{"payload: must be a JSON object"}},
+	}
+	for _, tt := range tests {
+		errs := ValidateEvent(deploy, "ci", tt.target, tt.payload)
+		if len(errs) != len(tt.want) {
+			t.Errorf("%s: got %v, want %d errors", tt.name, errs, len(tt.want))
+			continue
+		}
+		for i, e := range errs {
+			if got := e.Field + ": " + e.Message; !strings.Contains(got, tt.want[i]) {
+				t.Errorf("%s: error %q does not contain %q", tt.name, got, tt.want[i])
+			}
+		}
+	}
+
+	lax := EventKind{Name: "note", Payload: map[string]MetadataFieldSchema{"n": {Type: MetadataFieldInt}}}
+	if errs := ValidateEvent(lax, "", "", `{"other":"x"}`); len(errs) != 0 {
+		t.Errorf("lax kind rejected undeclared field: %v", errs)
+	}
+}
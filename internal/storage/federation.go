@@ -1,6 +1,11 @@
 package storage
 
-import "context"
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
 
 // FederationStore provides federation peer management.
 type FederationStore interface {
@@ -9,3 +14,179 @@ type FederationStore interface {
 	ListFederationPeers(ctx context.Context) ([]*FederationPeer, error)
 	RemoveFederationPeer(ctx context.Context, name string) error
 }
+
+// PeerHealthChecker probes connectivity with a federation peer.
+type PeerHealthChecker interface {
+	// CheckPeer fetches from the named peer and reports the outcome. Probe
+	// failures are described by the returned PeerHealth; the error is
+	// reserved for a peer that is not configured or a local failure.
+	CheckPeer(ctx context.Context, name string) (*PeerHealth, error)
+}
+
+// CredentialKeyRotator replaces the local key that encrypts federation peer
+// passwords and SSH key passphrases.
+type CredentialKeyRotator interface {
+	// RotateCredentialKey generates a new key, re-encrypts every stored peer
+	// secret with it in one transaction, and then replaces the key file,
+	// shredding the old one. If re-encryption fails, nothing changes.
+	RotateCredentialKey(ctx context.Context, opts KeyRotationOptions) (*KeyRotationResult, error)
+}
+
+// PeerMetadataEncrypter upgrades federation peers stored with a plaintext
+// username and remote URL to encrypted metadata.
+type PeerMetadataEncrypter interface {
+	// EncryptPeerMetadata encrypts the username and remote URL of every peer
+	// stored in plaintext, in one transaction, with the credential key that
+	// encrypts peer passwords. Peers already encrypted are left alone.
+	EncryptPeerMetadata(ctx context.Context, opts PeerMetadataEncryptionOptions) (*PeerMetadataEncryptionResult, error)
+}
+
+// SyncPreviewer computes what a sync with a peer would transfer.
+type SyncPreviewer interface {
+	// PreviewSync fetches from the peer, which only refreshes its
+	// remote-tracking ref, and compares that ref with the local branch. It
+	// merges, pushes, and commits nothing.
+	PreviewSync(ctx context.Context, peer string) (*SyncPreview, error)
+}
+
+// PeerVerifier checks a federation peer's issues against the local ones.
+type PeerVerifier interface {
+	// VerifyPeer fetches from the peer, which only refreshes its
+	// remote-tracking ref, and compares Merkle digests of the issues
+	// committed on the local branch and on the peer's copy of it. It
+	// merges, pushes, and commits nothing.
+	VerifyPeer(ctx context.Context, peer string) (*PeerVerification, error)
+}
+
+// KeyRotationOptions configures RotateCredentialKey.
+type KeyRotationOptions struct {
+	// DryRun checks that every secret decrypts with the current key without
+	// generating a key or writing anything.
+	DryRun bool
+	// Progress, if set, is called once for each peer whose secrets are
+	// re-encrypted.
+	Progress func(peer string)
+}
+
+// KeyRotationResult reports the outcome of RotateCredentialKey.
+type KeyRotationResult struct {
+	KeyPath string   // credential key file
+	Peers   []string // peers whose secrets were (or, for a dry run, would be) re-encrypted
+	DryRun  bool
+}
+
+// PeerMetadataEncryptionOptions configures EncryptPeerMetadata.
+type PeerMetadataEncryptionOptions struct {
+	// DryRun lists the peers that would be encrypted without writing.
+	DryRun bool
+	// Progress, if set, is called once for each peer that is encrypted.
+	Progress func(peer string)
+}
+
+// PeerMetadataEncryptionResult reports the outcome of EncryptPeerMetadata.
+type PeerMetadataEncryptionResult struct {
+	Peers  []string // peers whose metadata was (or, for a dry run, would be) encrypted
+	DryRun bool
+}
+
+// PeerHealthStatus classifies the outcome of a peer health check.
+type PeerHealthStatus string
+
+const (
+	PeerHealthOK          PeerHealthStatus = "ok"          // fetch succeeded
+	PeerHealthAuthFailed  PeerHealthStatus = "auth_failed" // peer answered but rejected the credentials
+	PeerHealthUnreachable PeerHealthStatus = "unreachable" // network failure: DNS, refused, timeout
+	PeerHealthError       PeerHealthStatus = "error"       // any other failure, e.g. a missing repository
+)
+
+// PeerHealth is the result of a federation peer health check.
+type PeerHealth struct {
+	Peer             string
+	URL              string
+	Status           PeerHealthStatus
+	UsedCredentials  bool          // the probe presented credentials
+	Latency          time.Duration // round trip of the fetch probe
+	LastCommonCommit string        // merge base of the local and peer branches; empty if unknown
+	Error            error         // probe failure; nil when Status is ok
+}
+
+// Healthy reports whether the probe reached and authenticated with the peer.
+func (h *PeerHealth) Healthy() bool {
+	return h.Status == PeerHealthOK
+}
+
+// IssueDifferenceKind says how an issue differs between this workspace and
+// a peer.
+type IssueDifferenceKind string
+
+const (
+	IssueOnlyLocal IssueDifferenceKind = "only_local" // the peer lacks the issue
+	IssueOnlyPeer  IssueDifferenceKind = "only_peer"  // this workspace lacks the issue
+	IssueChanged   IssueDifferenceKind = "changed"    // both have it, with different fields, labels, or dependencies
+)
+
+// IssueDifference is an issue whose digest differs from the peer's.
+type IssueDifference struct {
+	IssueID string
+	Kind    IssueDifferenceKind
+}
+
+// PeerVerification is the result of comparing issue digests with a peer.
+// The roots are hex SHA-256 digests over every issue row with its labels
+// and dependencies; equal roots mean identical issue sets.
+type PeerVerification struct {
+	Peer              string
+	Branch            string
+	LocalRoot         string
+	PeerRoot          string // empty if PeerBranchMissing
+	LocalIssues       int
+	PeerIssues        int
+	PeerBranchMissing bool              // the peer has no copy of Branch to compare
+	DivergentBuckets  int               // digest buckets (of 256) whose issues differ
+	Differences       []IssueDifference // sorted by issue ID
+}
+
+// Consistent reports whether the peer holds exactly the local issues.
+func (v *PeerVerification) Consistent() bool {
+	return !v.PeerBranchMissing && v.LocalRoot == v.PeerRoot
+}
+
+// peerAuthMarkers and peerNetworkMarkers are lowercase fragments of the
+// errors Dolt, git, ssh, and the HTTP and gRPC remote clients report for
+// rejected credentials and for a peer that could not be reached.
+var (
+	peerAuthMarkers = []string{
+		"unauthenticated", "unauthorized", "authentication", "permission denied",
+		"access denied", "forbidden", "invalid credentials", "clone_admin",
+		"could not read username", "publickey", "401", "403",
+	}
+	peerNetworkMarkers = []string{
+		"connection refused", "connection reset", "no such host", "no route to host",
+		"network is unreachable", "i/o timeout", "deadline exceeded", "timed out",
+		"could not resolve host", "tls handshake", "unavailable", "eof",
+	}
+)
+
+// ClassifyPeerError maps a failed fetch from a peer to a health status,
+// telling rejected credentials apart from network failures. Auth markers
+// are checked first: an auth failure proves the peer was reachable.
+func ClassifyPeerError(err error) PeerHealthStatus {
+	if err == nil {
+		return PeerHealthOK
+	}
+	msg := strings.ToLower(err.Error())
+	for _, m := range peerAuthMarkers {
+		if strings.Contains(msg, m) {
+			return PeerHealthAuthFailed
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return PeerHealthUnreachable
+	}
+	for _, m := range peerNetworkMarkers {
+		if strings.Contains(msg, m) {
+			return PeerHealthUnreachable
+		}
+	}
+	return PeerHealthError
+}
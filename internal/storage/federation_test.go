@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifyPeerError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want PeerHealthStatus
+	}{
+		{nil, PeerHealthOK},
+		{errors.New(`could not access dolt url 'http://127.0.0.1:1/beads': rpc error: code = Unavailable desc = dial tcp 127.0.0.1:1: connect: connection refused`), PeerHealthUnreachable},
+		{errors.New("dial tcp: lookup nohost.invalid: no such host"), PeerHealthUnreachable},
+		{fmt.Errorf("fetch from peer: %w", context.DeadlineExceeded), PeerHealthUnreachable},
+		{errors.New("rpc error: code = Unauthenticated desc = invalid credentials"), PeerHealthAuthFailed},
+		{errors.New("git@host: Permission denied (publickey)."), PeerHealthAuthFailed},
+		{errors.New("Access denied for user 'sync-bot': CLONE_ADMIN required"), PeerHealthAuthFailed},
+		{errors.New("remote 'town-beta' has no branch 'main'"), PeerHealthError},
+	}
+	for _, tt := range tests {
+		if got := ClassifyPeerError(tt.err); got != tt.want {
+			t.Errorf("ClassifyPeerError(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package fts implements full-text issue search: the tokenizer that feeds
+// the search index, the query syntax accepted by bd search, and relevance
+// ranking of matches.
+//
+// The index itself is two clone-local tables (see ignored migration 0017):
+// search_index_terms holds one row per (term, issue, field) with the term's
+// frequency, and search_index_docs records which revision of each issue was
+// indexed. issueops.RefreshSearchIndexInTx keeps them current and
+// sqlbuild turns IssueFilter.FullText into lookups against them, so a
+// search reads a few index ranges instead of LIKE-scanning every issue's
+// text.
+package fts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Index table names.
+const (
+	TermsTable = "search_index_terms"
+	DocsTable  = "search_index_docs"
+)
+
+// MaxTermLength caps indexed terms (in runes) to the width of the term
+// column. Query terms are truncated the same way so long words still match.
+const MaxTermLength = 64
+
+// ErrEmptyQuery is returned when a query has no searchable words.
+var ErrEmptyQuery = errors.New("full-text query has no searchable words")
+
+// Field identifies which part of an issue a term came from.
+type Field int
+
+// Indexed fields, in the order they are stored in search_index_terms.field.
+const (
+	FieldTitle Field = iota
+	FieldDescription
+	FieldNotes
+	FieldID
+)
+
+// Fields lists every indexed field.
+var Fields = []Field{FieldTitle, FieldDescription, FieldNotes, FieldID}
+
+// weight is the field's contribution to relevance: a match in the title
+// or ID says more about an issue than one buried in its notes.
+func (f Field) weight() float64 {
+	switch f {
+	case FieldTitle:
+		return 3
+	case FieldID:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// Document is the indexed text of one issue.
+type Document struct {
+	ID          string
+	Title       string
+	Description string
+	Notes       string
+}
+
+// Text returns the document's text for field f.
+func (d Document) Text(f Field) string {
+	switch f {
+	case FieldTitle:
+		return d.Title
+	case FieldDescription:
+		return d.Description
+	case FieldNotes:
+		return d.Notes
+	case FieldID:
+		return d.ID
+	}
+	return ""
+}
+
+// Tokenize splits text into lowercase terms. Any rune that is not a letter
+// or digit separates terms, so "login-timeout" and "login_timeout" both
+// index as "login" and "timeout".
+func Tokenize(text string) []string {
+	var terms []string
+	for _, word := range strings.FieldsFunc(text, isSeparator) {
+		terms = append(terms, normalize(word))
+	}
+	return terms
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func normalize(word string) string {
+	word = strings.ToLower(word)
+	if utf8.RuneCountInString(word) <= MaxTermLength {
+		return word
+	}
+	runes := []rune(word)
+	return string(runes[:MaxTermLength])
+}
+
+// Posting is one row of search_index_terms.
+type Posting struct {
+	Term  string
+	Field Field
+	Count int
+}
+
+// Postings returns the index rows for d and its length in terms.
+func Postings(d Document) ([]Posting, int) {
+	var postings []Posting
+	length := 0
+	for _, f := range Fields {
+		counts := make(map[string]int)
+		var order []string
+		for _, term := range Tokenize(d.Text(f)) {
+			if counts[term] == 0 {
+				order = append(order, term)
+			}
+			counts[term]++
+			length++
+		}
+		for _, term := range order {
+			postings = append(postings, Posting{Term: term, Field: f, Count: counts[term]})
+		}
+	}
+	return postings, length
+}
+
+// Term is one query word. A Prefix term matches every indexed term that
+// starts with Text.
+type Term struct {
+	Text   string
+	Prefix bool
+}
+
+// Query is a parsed full-text query. A document matches when it contains
+// every term and every phrase.
+type Query struct {
+	Terms   []Term
+	Phrases [][]string
+}
+
+// Parse parses a full-text query:
+//
+//	login timeout     both words, anywhere in the issue
+//	"login timeout"   the words next to each other, in order
+//	time*             any word starting with "time"
+//
+// A bare word that tokenizes into several terms ("login-timeout") is
+// treated as a phrase.
+func Parse(s string) (*Query, error) {
+	q := &Query{}
+	rest := s
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+		if rest[0] == '"' {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in %q", s)
+			}
+			q.addWords(Tokenize(rest[1:end+1]), false)
+			rest = rest[end+2:]
+			continue
+		}
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			end = len(rest)
+		}
+		word := rest[:end]
+		rest = rest[end:]
+		prefix := strings.HasSuffix(word, "*")
+		q.addWords(Tokenize(strings.TrimRight(word, "*")), prefix)
+	}
+	if len(q.Terms) == 0 && len(q.Phrases) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	return q, nil
+}
+
+func (q *Query) addWords(words []string, prefix bool) {
+	switch len(words) {
+	case 0:
+	case 1:
+		q.Terms = append(q.Terms, Term{Text: words[0], Prefix: prefix})
+	default:
+		q.Phrases = append(q.Phrases, words)
+	}
+}
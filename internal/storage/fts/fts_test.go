@@ -0,0 +1,89 @@
+package fts
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Fix login-timeout in bd-a1b2 (Ünïcode_OK)")
+	want := []string{"fix", "login", "timeout", "in", "bd", "a1b2", "ünïcode", "ok"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize = %q, want %q", got, want)
+	}
+	long := strings.Repeat("x", MaxTermLength+10)
+	if got := Tokenize(long); len(got) != 1 || len(got[0]) != MaxTermLength {
+		t.Errorf("long word not truncated to %d: %q", MaxTermLength, got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	q, err := Parse(`login "connection reset" retr* login-timeout`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantTerms := []Term{{Text: "login"}, {Text: "retr", Prefix: true}}
+	if !reflect.DeepEqual(q.Terms, wantTerms) {
+		t.Errorf("Terms = %+v, want %+v", q.Terms, wantTerms)
+	}
+	wantPhrases := [][]string{{"connection", "reset"}, {"login", "timeout"}}
+	if !reflect.DeepEqual(q.Phrases, wantPhrases) {
+		t.Errorf("Phrases = %q, want %q", q.Phrases, wantPhrases)
+	}
+
+	if _, err := Parse(`"unterminated`); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+	for _, s := range []string{"", "  ", "!!! --", `""`} {
+		if _, err := Parse(s); !errors.Is(err, ErrEmptyQuery) {
+			t.Errorf("Parse(%q) err = %v, want ErrEmptyQuery", s, err)
+		}
+	}
+}
+
+func TestPostings(t *testing.T) {
+	postings, length := Postings(Document{ID: "bd-1", Title: "Retry retry", Notes: "retry later"})
+	want := []Posting{
+		{Term: "retry", Field: FieldTitle, Count: 2},
+		{Term: "retry", Field: FieldNotes, Count: 1},
+		{Term: "later", Field: FieldNotes, Count: 1},
+		{Term: "bd", Field: FieldID, Count: 1},
+		{Term: "1", Field: FieldID, Count: 1},
+	}
+	if !reflect.DeepEqual(postings, want) || length != 6 {
+		t.Errorf("Postings = %+v (length %d), want %+v (length 6)", postings, length, want)
+	}
+}
+
+func TestRank(t *testing.T) {
+	docs := []Document{
+		{ID: "bd-1", Title: "Unrelated chore", Notes: "mentions timeout once in a long note about many other things entirely"},
+		{ID: "bd-2", Title: "Login timeout", Description: "The login timeout fires too early"},
+		{ID: "bd-3", Title: "Timeout on login page", Description: "login"},
+	}
+	q, err := Parse("login timeout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Rank(q, docs); got[0] != 1 || got[2] != 0 {
+		t.Errorf("Rank(login timeout) = %v, want bd-2 first and bd-1 last", got)
+	}
+
+	q, err = Parse(`"timeout on login"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Rank(q, docs); got[0] != 2 {
+		t.Errorf("Rank(phrase) = %v, want the exact phrase (bd-3) first", got)
+	}
+
+	q, err = Parse("time*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scores := Scores(q, docs); scores[0] <= 0 || scores[1] <= scores[0] {
+		t.Errorf("Scores(time*) = %v, want every doc matched and bd-2 above bd-1", scores)
+	}
+}
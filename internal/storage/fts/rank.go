@@ -0,0 +1,117 @@
+package fts
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// BM25 parameters. k1 saturates repeated terms; b scales the score down
+// for long documents.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Rank returns the indexes of docs ordered by relevance to q, most
+// relevant first. Ties keep their input order. Scores are BM25 over the
+// field-weighted term counts, with each phrase scored as one more term;
+// document frequencies come from docs itself, which is the candidate set
+// the index returned.
+func Rank(q *Query, docs []Document) []int {
+	scores := Scores(q, docs)
+	order := make([]int, len(docs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+	return order
+}
+
+// Scores returns the relevance of each of docs to q.
+func Scores(q *Query, docs []Document) []float64 {
+	if len(docs) == 0 {
+		return nil
+	}
+	tokens := make([][][]string, len(docs))
+	lengths := make([]float64, len(docs))
+	total := 0.0
+	for i, d := range docs {
+		tokens[i] = make([][]string, len(Fields))
+		for _, f := range Fields {
+			tokens[i][f] = Tokenize(d.Text(f))
+			lengths[i] += f.weight() * float64(len(tokens[i][f]))
+		}
+		total += lengths[i]
+	}
+	avgLength := total / float64(len(docs))
+	if avgLength == 0 {
+		avgLength = 1
+	}
+
+	// One weighted frequency column per term and phrase.
+	var freqs [][]float64
+	for _, term := range q.Terms {
+		freqs = append(freqs, weightedCounts(tokens, func(ts []string) int { return countTerm(ts, term) }))
+	}
+	for _, phrase := range q.Phrases {
+		freqs = append(freqs, weightedCounts(tokens, func(ts []string) int { return countPhrase(ts, phrase) }))
+	}
+
+	scores := make([]float64, len(docs))
+	n := float64(len(docs))
+	for _, col := range freqs {
+		df := 0.0
+		for _, tf := range col {
+			if tf > 0 {
+				df++
+			}
+		}
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for i, tf := range col {
+			if tf == 0 {
+				continue
+			}
+			norm := bm25K1 * (1 - bm25B + bm25B*lengths[i]/avgLength)
+			scores[i] += idf * tf * (bm25K1 + 1) / (tf + norm)
+		}
+	}
+	return scores
+}
+
+func weightedCounts(tokens [][][]string, count func([]string) int) []float64 {
+	col := make([]float64, len(tokens))
+	for i, fields := range tokens {
+		for _, f := range Fields {
+			col[i] += f.weight() * float64(count(fields[f]))
+		}
+	}
+	return col
+}
+
+func countTerm(tokens []string, term Term) int {
+	n := 0
+	for _, t := range tokens {
+		if t == term.Text || (term.Prefix && strings.HasPrefix(t, term.Text)) {
+			n++
+		}
+	}
+	return n
+}
+
+func countPhrase(tokens []string, phrase []string) int {
+	n := 0
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		match := true
+		for j, word := range phrase {
+			if tokens[i+j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			n++
+		}
+	}
+	return n
+}
@@ -39,3 +39,11 @@ type ExternalRefHistoryQuerier interface {
 	// column was NULL.
 	PreviousExternalRef(ctx context.Context, issueID string, asOf time.Time) (ref string, found bool, err error)
 }
+
+// GraphDiffer is implemented by Dolt-backed stores that can diff the
+// dependency graph and labels between two refs, complementing
+// HistoryViewer.Diff's issue-row diff.
+type GraphDiffer interface {
+	DiffDependencies(ctx context.Context, fromRef, toRef string) ([]*DependencyDiffEntry, error)
+	DiffLabels(ctx context.Context, fromRef, toRef string) ([]*LabelDiffEntry, error)
+}
@@ -17,6 +17,7 @@ package storage
 
 import (
 	"context"
+	"maps"
 
 	"github.com/steveyegge/beads/internal/hooks"
 	"github.com/steveyegge/beads/internal/types"
@@ -107,6 +108,43 @@ func (h *HookFiringStore) CreateIssues(ctx context.Context, issues []*types.Issu
 	return nil
 }
 
+// UpsertIssues upserts issues and fires create-time hooks for the ones it
+// created, on_update for the ones it overwrote, and dependency update hooks.
+func (h *HookFiringStore) UpsertIssues(ctx context.Context, issues []*types.Issue, actor string) (*UpsertIssuesResult, error) {
+	result, err := h.inner.UpsertIssues(ctx, issues, actor)
+	if err != nil || h.runner == nil {
+		return result, err
+	}
+	created := make(map[string]bool, len(result.Created))
+	for _, id := range result.Created {
+		created[id] = true
+	}
+	updated := make(map[string]bool, len(result.Updated))
+	for _, id := range result.Updated {
+		updated[id] = true
+	}
+	var written []*types.Issue
+	for _, issue := range issues {
+		switch {
+		case issue == nil:
+			continue
+		case created[issue.ID]:
+			for _, p := range createHookEvents(issue) {
+				h.fireHook(p.event, p.issue)
+			}
+		case updated[issue.ID]:
+			h.fireHookByID(ctx, hooks.EventUpdate, issue.ID)
+		default:
+			continue
+		}
+		written = append(written, issue)
+	}
+	for _, p := range dependencyHookEvents(ctx, written, h.inner.GetIssue, h.inner.GetDependencyRecords) {
+		h.fireHook(p.event, p.issue)
+	}
+	return result, nil
+}
+
 // UpdateIssue updates an issue and fires on_update.
 func (h *HookFiringStore) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
 	if err := h.inner.UpdateIssue(ctx, id, updates, actor); err != nil {
@@ -450,6 +488,15 @@ func cloneIssueForHook(issue *types.Issue) *types.Issue {
 	}
 	clone.BondedFrom = append([]types.BondRef(nil), issue.BondedFrom...)
 	clone.Waiters = append([]string(nil), issue.Waiters...)
+	clone.Derived = maps.Clone(issue.Derived)
+	if issue.Annotations != nil {
+		annotations := *issue.Annotations
+		annotations.Score = clonePtr(annotations.Score)
+		annotations.InheritedPriority = clonePtr(annotations.InheritedPriority)
+		annotations.SLADue = clonePtr(annotations.SLADue)
+		annotations.BlockedByCount = clonePtr(annotations.BlockedByCount)
+		clone.Annotations = &annotations
+	}
 	return &clone
 }
 
@@ -289,6 +289,8 @@ func TestCloneIssueForHookCoversReferenceFields(t *testing.T) {
 		"Comments":          {},
 		"BondedFrom":        {},
 		"Waiters":           {},
+		"Derived":           {},
+		"Annotations":       {},
 	}
 	issueType := reflect.TypeOf(types.Issue{})
 	for i := 0; i < issueType.NumField(); i++ {
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// IssueBlamer is implemented by Dolt-backed stores that can attribute each
+// field of an issue to the commit that last changed it.
+type IssueBlamer interface {
+	// GetIssueBlame returns one entry per field in IssueFieldNames order.
+	// A field nobody has changed since creation is attributed to the commit
+	// that created the issue.
+	GetIssueBlame(ctx context.Context, issueID string) ([]*FieldBlame, error)
+}
+
+// FieldBlame is a field's current value and the commit that set it. An
+// uncommitted change has CommitHash "WORKING" and no actor.
+type FieldBlame struct {
+	Field      string    `json:"field"`
+	Value      string    `json:"value"`
+	CommitHash string    `json:"commit_hash"`
+	Actor      string    `json:"actor"`
+	CommitDate time.Time `json:"commit_date"`
+}
+
+// IssueFieldNames returns the issue fields that change timelines and blame
+// report, in display order. They are also the issues table's column names.
+func IssueFieldNames() []string {
+	names := make([]string, len(issueHistoryFields))
+	for i, f := range issueHistoryFields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// IssueFieldValue returns issue's value of one of IssueFieldNames, formatted
+// as change timelines show it, or "" for an unknown field.
+func IssueFieldValue(issue *types.Issue, field string) string {
+	for _, f := range issueHistoryFields {
+		if f.name == field {
+			return f.value(issue)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// IssueHistoryReader is implemented by Dolt-backed stores that can turn an
+// issue's rows in dolt_history_issues into a change timeline.
+type IssueHistoryReader interface {
+	// GetIssueHistory returns the commits that changed the issue, newest
+	// first, each with the fields it changed.
+	GetIssueHistory(ctx context.Context, issueID string) ([]*IssueChange, error)
+}
+
+// IssueChange is one commit's changes to an issue.
+type IssueChange struct {
+	CommitHash string    `json:"commit_hash"`
+	Actor      string    `json:"actor"`
+	CommitDate time.Time `json:"commit_date"`
+	// Created marks the first commit holding the issue; its Fields are the
+	// values the issue was created with.
+	Created bool          `json:"created,omitempty"`
+	Fields  []FieldChange `json:"fields"`
+}
+
+// FieldChange is one field's value before and after a commit.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// issueHistoryFields are the issue fields a change timeline reports, in
+// display order. updated_at is left out: every commit touches it.
+var issueHistoryFields = []struct {
+	name  string
+	value func(*types.Issue) string
+}{
+	{"title", func(i *types.Issue) string { return i.Title }},
+	{"status", func(i *types.Issue) string { return string(i.Status) }},
+	{"priority", func(i *types.Issue) string { return strconv.Itoa(i.Priority) }},
+	{"issue_type", func(i *types.Issue) string { return string(i.IssueType) }},
+	{"assignee", func(i *types.Issue) string { return i.Assignee }},
+	{"owner", func(i *types.Issue) string { return i.Owner }},
+	{"description", func(i *types.Issue) string { return i.Description }},
+	{"design", func(i *types.Issue) string { return i.Design }},
+	{"acceptance_criteria", func(i *types.Issue) string { return i.AcceptanceCriteria }},
+	{"notes", func(i *types.Issue) string { return i.Notes }},
+	{"estimated_minutes", func(i *types.Issue) string {
+		if i.EstimatedMinutes == nil {
+			return ""
+		}
+		return strconv.Itoa(*i.EstimatedMinutes)
+	}},
+	{"close_reason", func(i *types.Issue) string { return i.CloseReason }},
+	{"closed_at", func(i *types.Issue) string {
+		if i.ClosedAt == nil {
+			return ""
+		}
+		return i.ClosedAt.UTC().Format(time.RFC3339)
+	}},
+	{"pinned", func(i *types.Issue) string {
+		if !i.Pinned {
+			return ""
+		}
+		return "true"
+	}},
+	{"mol_type", func(i *types.Issue) string { return string(i.MolType) }},
+}
+
+// IssueChangesFromHistory diffs consecutive snapshots of one issue, as
+// returned by HistoryViewer.History, into a change timeline, newest first.
+// dolt_history_issues holds a row for every commit the issue exists in, so
+// commits that left every reported field alone are dropped.
+func IssueChangesFromHistory(entries []*HistoryEntry) []*IssueChange {
+	snapshots := make([]*HistoryEntry, 0, len(entries))
+	for _, e := range entries {
+		if e != nil && e.Issue != nil {
+			snapshots = append(snapshots, e)
+		}
+	}
+	sort.SliceStable(snapshots, func(a, b int) bool {
+		return snapshots[a].CommitDate.Before(snapshots[b].CommitDate)
+	})
+
+	var changes []*IssueChange
+	var prev *types.Issue
+	for _, e := range snapshots {
+		change := &IssueChange{
+			CommitHash: e.CommitHash,
+			Actor:      e.Committer,
+			CommitDate: e.CommitDate,
+			Created:    prev == nil,
+			Fields:     []FieldChange{},
+		}
+		for _, f := range issueHistoryFields {
+			next := f.value(e.Issue)
+			old := ""
+			if prev != nil {
+				old = f.value(prev)
+			}
+			if next != old {
+				change.Fields = append(change.Fields, FieldChange{Field: f.name, Old: old, New: next})
+			}
+		}
+		prev = e.Issue
+		if change.Created || len(change.Fields) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+		changes[i], changes[j] = changes[j], changes[i]
+	}
+	return changes
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueChangesFromHistory(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	snapshot := func(hash string, minutes int, mutate func(*types.Issue)) *HistoryEntry {
+		issue := &types.Issue{ID: "bd-1", Title: "Fix login", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+		if mutate != nil {
+			mutate(issue)
+		}
+		return &HistoryEntry{CommitHash: hash, Committer: "alice", CommitDate: base.Add(time.Duration(minutes) * time.Minute), Issue: issue}
+	}
+	// Newest first, as History returns them; c2 is an unrelated commit the
+	// issue merely exists in.
+	entries := []*HistoryEntry{
+		snapshot("c4", 30, func(i *types.Issue) {
+			i.Status = types.StatusClosed
+			i.Assignee = "bob"
+			i.CloseReason = "fixed"
+		}),
+		snapshot("c3", 20, func(i *types.Issue) { i.Assignee = "bob" }),
+		snapshot("c2", 10, func(i *types.Issue) { i.UpdatedAt = base.Add(10 * time.Minute) }),
+		snapshot("c1", 0, nil),
+	}
+
+	changes := IssueChangesFromHistory(entries)
+	if len(changes) != 3 {
+		t.Fatalf("got %d changes, want 3 (c2 changed nothing reported): %+v", len(changes), changes)
+	}
+	if changes[0].CommitHash != "c4" || changes[2].CommitHash != "c1" {
+		t.Errorf("order = %s..%s; want newest first", changes[0].CommitHash, changes[2].CommitHash)
+	}
+
+	created := changes[2]
+	if !created.Created || created.Actor != "alice" {
+		t.Errorf("first change = %+v; want Created by alice", created)
+	}
+	for _, f := range created.Fields {
+		if f.Old != "" || f.New == "" {
+			t.Errorf("created field %+v; want only set fields, with no old value", f)
+		}
+	}
+
+	want := []FieldChange{
+		{Field: "status", Old: "open", New: "closed"},
+		{Field: "close_reason", Old: "", New: "fixed"},
+	}
+	got := changes[0].Fields
+	if len(got) != len(want) {
+		t.Fatalf("c4 fields = %+v; want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("c4 field %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+
+	if got := IssueChangesFromHistory(nil); len(got) != 0 {
+		t.Errorf("no history = %+v; want no changes", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -29,12 +30,42 @@ func ValidateRef(ref string) error {
 	return nil
 }
 
-// AsOfInTx returns the state of an issue at a specific commit hash or branch ref.
+// asOfTimeLayouts are the date forms AsOfClause accepts in place of a
+// commit or branch. Forms without a zone are local time.
+var asOfTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// AsOfClause returns the Dolt AS OF clause that reads tables at ref: a
+// commit hash, branch, or tag, or a date such as "2026-10-01" for the last
+// commit before that moment (a bare date is its local midnight).
+//
+// Dolt requires a literal in AS OF, so the clause embeds ref; it is
+// validated by ValidateRef or rewritten as a formatted timestamp first.
+func AsOfClause(ref string) (string, error) {
+	for _, layout := range asOfTimeLayouts {
+		if t, err := time.ParseInLocation(layout, ref, time.Local); err == nil {
+			return fmt.Sprintf("AS OF TIMESTAMP('%s')", t.UTC().Format("2006-01-02 15:04:05")), nil
+		}
+	}
+	if err := ValidateRef(ref); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("AS OF '%s'", ref), nil
+}
+
+// AsOfInTx returns the state of an issue at a specific commit hash, branch
+// ref, or date (see AsOfClause).
 // Uses Dolt's AS OF syntax which works in both server and embedded modes.
 //
-// nolint:gosec // G201: ref is validated by ValidateRef() above - AS OF requires literal
+// nolint:gosec // G201: the clause is built by AsOfClause() above - AS OF requires literal
 func AsOfInTx(ctx context.Context, tx DBTX, issueID string, ref string) (*types.Issue, error) {
-	if err := ValidateRef(ref); err != nil {
+	asOf, err := AsOfClause(ref)
+	if err != nil {
 		return nil, fmt.Errorf("invalid ref: %w", err)
 	}
 
@@ -47,11 +78,11 @@ func AsOfInTx(ctx context.Context, tx DBTX, issueID string, ref string) (*types.
 	query := fmt.Sprintf(`
 		SELECT id, content_hash, title, description, status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, created_by, owner, updated_at, closed_at
-		FROM issues AS OF '%s'
+		FROM issues %s
 		WHERE id = ?
-	`, ref)
+	`, asOf)
 
-	err := tx.QueryRowContext(ctx, query, issueID).Scan(
+	err = tx.QueryRowContext(ctx, query, issueID).Scan(
 		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Status, &issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 		&createdAtStr, &issue.CreatedBy, &owner, &updatedAtStr, &closedAt,
 	)
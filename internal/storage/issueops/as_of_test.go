@@ -0,0 +1,35 @@
+package issueops
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAsOfClause(t *testing.T) {
+	t.Parallel()
+
+	midnight := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local).UTC().Format("2006-01-02 15:04:05")
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{ref: "main", want: "AS OF 'main'"},
+		{ref: "h3idaapu0pb0q5ov6hidu655p153tk63", want: "AS OF 'h3idaapu0pb0q5ov6hidu655p153tk63'"},
+		{ref: "release/v2.0", want: "AS OF 'release/v2.0'"},
+		{ref: "2026-10-01", want: "AS OF TIMESTAMP('" + midnight + "')"},
+		{ref: "2026-10-01T09:30:00Z", want: "AS OF TIMESTAMP('2026-10-01 09:30:00')"},
+		{ref: "2026-10-01T11:30:00+02:00", want: "AS OF TIMESTAMP('2026-10-01 09:30:00')"},
+		{ref: "", wantErr: true},
+		{ref: "main'; DROP TABLE issues", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := AsOfClause(tt.ref)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("AsOfClause(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("AsOfClause(%q) = %q; want %q", tt.ref, got, tt.want)
+		}
+	}
+}
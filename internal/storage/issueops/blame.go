@@ -0,0 +1,91 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// GetIssueBlameInTx attributes each field of an issue to the commit that
+// last changed it, from dolt_diff_issues: walking the issue's diff rows
+// newest first, a field is blamed on the first row whose from_ and to_
+// values differ, or on the row that added the issue. dolt_diff_issues has
+// no author, so committers come from dolt_log.
+//
+// Only issues are covered: wisps are dolt_ignore'd and have no history.
+func GetIssueBlameInTx(ctx context.Context, tx DBTX, issueID string) ([]*storage.FieldBlame, error) {
+	issue, err := getIssueFromTableInTx(ctx, tx, "issues", "labels", issueID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("%w: issue %s", storage.ErrNotFound, issueID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := storage.IssueFieldNames()
+	cols := make([]string, 0, 2*len(fields))
+	for _, f := range fields {
+		cols = append(cols, "d.from_"+f, "d.to_"+f)
+	}
+	// The working set sorts first: its diff row has no commit date.
+	//nolint:gosec // G201: column names come from storage.IssueFieldNames.
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+		SELECT d.to_commit, d.to_commit_date, d.diff_type, COALESCE(l.committer, ''), %s
+		FROM dolt_diff_issues d
+		LEFT JOIN dolt_log l ON l.commit_hash = d.to_commit
+		WHERE d.to_id = ?
+		ORDER BY d.to_commit = 'WORKING' DESC, d.to_commit_date DESC`,
+		strings.Join(cols, ", ")), issueID)
+	if err != nil {
+		return nil, fmt.Errorf("get issue blame: %w", err)
+	}
+	defer rows.Close()
+
+	blame := make([]*storage.FieldBlame, len(fields))
+	left := len(fields)
+	values := make([]sql.NullString, 2*len(fields))
+	for rows.Next() && left > 0 {
+		var commit, diffType, committer string
+		var commitDate sql.NullTime
+		dest := []any{&commit, &commitDate, &diffType, &committer}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("get issue blame: scan: %w", err)
+		}
+		for i, f := range fields {
+			if blame[i] != nil {
+				continue
+			}
+			from, to := values[2*i], values[2*i+1]
+			if diffType != "added" && from.String == to.String {
+				continue
+			}
+			blame[i] = &storage.FieldBlame{
+				Field:      f,
+				Value:      storage.IssueFieldValue(issue, f),
+				CommitHash: commit,
+				Actor:      committer,
+				CommitDate: commitDate.Time,
+			}
+			left--
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get issue blame: %w", err)
+	}
+
+	// Fields the diff never attributed (history squashed past the issue's
+	// creation) keep their value with no commit.
+	for i, f := range fields {
+		if blame[i] == nil {
+			blame[i] = &storage.FieldBlame{Field: f, Value: storage.IssueFieldValue(issue, f)}
+		}
+	}
+	return blame, nil
+}
@@ -196,14 +196,14 @@ func shouldBeBlockedDisjunction(alias, depTable string) string {
 		      JOIN issues t ON t.id = d.depends_on_issue_id
 		      WHERE d.issue_id = %[1]s.id
 		        AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
-		        AND t.status <> 'closed' AND t.status <> 'pinned'
+		        AND `+activeRowSQL("t")+`
 		    )
 		    OR EXISTS (
 		      SELECT 1 FROM %[2]s d
 		      JOIN wisps t ON t.id = d.depends_on_wisp_id
 		      WHERE d.issue_id = %[1]s.id
 		        AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
-		        AND t.status <> 'closed' AND t.status <> 'pinned'
+		        AND `+activeRowSQL("t")+`
 		    )
 		    OR EXISTS (
 		      SELECT 1 FROM %[2]s d
@@ -3,8 +3,10 @@ package issueops
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -19,21 +21,71 @@ type DBTX interface {
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
-const waitsForGateBlockedSQL = `
+// reviewPendingSQL matches a row, by table alias, that was closed while its
+// human review is still pending (metadata.review_state, see bd review). Its
+// work is not accepted yet, so it is not done: it keeps blocking its
+// dependents and gating its waits-for spawner until the review is approved.
+func reviewPendingSQL(alias string) string {
+	return "(COALESCE(JSON_UNQUOTE(JSON_EXTRACT(" + alias + ".metadata, '$.review_state')), '') = '" + types.ReviewPending + "')"
+}
+
+// activeRowSQL matches a row that still blocks its dependents: not pinned,
+// and either not closed or closed with its review pending.
+func activeRowSQL(alias string) string {
+	return "(" + alias + ".status <> 'closed' OR " + reviewPendingSQL(alias) + ") AND " + alias + ".status <> 'pinned'"
+}
+
+// BlocksDependents is activeRowSQL for an issue already in memory.
+func BlocksDependents(status types.Status, metadata json.RawMessage) bool {
+	if status == types.StatusPinned {
+		return false
+	}
+	return status != types.StatusClosed || types.IsReviewPending(metadata)
+}
+
+// BlockingChanged reports whether applying updates to oldIssue changes
+// whether it blocks its dependents (see BlocksDependents): a close, a reopen,
+// pinning, or a review state entering or leaving pending. Callers recompute
+// is_blocked around the issue when it does.
+func BlockingChanged(oldIssue *types.Issue, updates map[string]interface{}) (bool, error) {
+	_, hasStatus := updates["status"]
+	rawMeta, hasMeta := updates["metadata"]
+	if !hasStatus && !hasMeta {
+		return false, nil
+	}
+	newStatus := oldIssue.Status
+	switch v := updates["status"].(type) {
+	case string:
+		newStatus = types.Status(v)
+	case types.Status:
+		newStatus = v
+	}
+	newMeta := oldIssue.Metadata
+	if hasMeta {
+		s, err := storage.NormalizeMetadataValue(rawMeta)
+		if err != nil {
+			return false, fmt.Errorf("invalid metadata: %w", err)
+		}
+		newMeta = json.RawMessage(s)
+	}
+	return BlocksDependents(oldIssue.Status, oldIssue.Metadata) != BlocksDependents(newStatus, newMeta), nil
+}
+
+var waitsForGateBlockedSQL = `
 		(
 		  EXISTS (
 		    SELECT 1 FROM dependencies cd JOIN issues child ON child.id = cd.issue_id
 		    WHERE cd.type = 'parent-child'
 		      AND ((d.depends_on_issue_id IS NOT NULL AND cd.depends_on_issue_id = d.depends_on_issue_id)
 		        OR (d.depends_on_wisp_id IS NOT NULL AND cd.depends_on_wisp_id = d.depends_on_wisp_id))
-		      AND child.status <> 'closed' AND child.status <> 'pinned'
+		      AND ` + activeRowSQL("child") + `
 		  )
 		  OR EXISTS (
 		    SELECT 1 FROM wisp_dependencies cd JOIN wisps child ON child.id = cd.issue_id
 		    WHERE cd.type = 'parent-child'
 		      AND ((d.depends_on_issue_id IS NOT NULL AND cd.depends_on_issue_id = d.depends_on_issue_id)
 		        OR (d.depends_on_wisp_id IS NOT NULL AND cd.depends_on_wisp_id = d.depends_on_wisp_id))
-		      AND child.status <> 'closed' AND child.status <> 'pinned'
+		      AND ` + activeRowSQL("child") + `
 		  )
 		)
 		AND NOT (
@@ -44,14 +96,14 @@ const waitsForGateBlockedSQL = `
 		      WHERE cd.type = 'parent-child'
 		        AND ((d.depends_on_issue_id IS NOT NULL AND cd.depends_on_issue_id = d.depends_on_issue_id)
 		          OR (d.depends_on_wisp_id IS NOT NULL AND cd.depends_on_wisp_id = d.depends_on_wisp_id))
-		        AND child.status = 'closed'
+		        AND child.status = 'closed' AND NOT ` + reviewPendingSQL("child") + `
 		    )
 		    OR EXISTS (
 		      SELECT 1 FROM wisp_dependencies cd JOIN wisps child ON child.id = cd.issue_id
 		      WHERE cd.type = 'parent-child'
 		        AND ((d.depends_on_issue_id IS NOT NULL AND cd.depends_on_issue_id = d.depends_on_issue_id)
 		          OR (d.depends_on_wisp_id IS NOT NULL AND cd.depends_on_wisp_id = d.depends_on_wisp_id))
-		        AND child.status = 'closed'
+		        AND child.status = 'closed' AND NOT ` + reviewPendingSQL("child") + `
 		    )
 		  )
 		)
@@ -150,14 +202,14 @@ func markBlockedTemplateForIssues() string {
 		      JOIN issues t ON t.id = d.depends_on_issue_id
 		      WHERE d.issue_id = i.id
 		        AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
-		        AND t.status <> 'closed' AND t.status <> 'pinned'
+		        AND `+activeRowSQL("t")+`
 		    )
 		    OR EXISTS (
 		      SELECT 1 FROM dependencies d
 		      JOIN wisps t ON t.id = d.depends_on_wisp_id
 		      WHERE d.issue_id = i.id
 		        AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
-		        AND t.status <> 'closed' AND t.status <> 'pinned'
+		        AND `+activeRowSQL("t")+`
 		    )
 		    OR EXISTS (
 		      SELECT 1 FROM dependencies d
@@ -195,14 +247,14 @@ func unmarkBlockedTemplateForIssues() string {
 		        JOIN issues t ON t.id = d.depends_on_issue_id
 		        WHERE d.issue_id = i.id
 		          AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
-		          AND t.status <> 'closed' AND t.status <> 'pinned'
+		          AND `+activeRowSQL("t")+`
 		      )
 		      AND NOT EXISTS (
 		        SELECT 1 FROM dependencies d
 		        JOIN wisps t ON t.id = d.depends_on_wisp_id
 		        WHERE d.issue_id = i.id
 		          AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
-		          AND t.status <> 'closed' AND t.status <> 'pinned'
+		          AND `+activeRowSQL("t")+`
 		      )
 		      AND NOT EXISTS (
 		        SELECT 1 FROM dependencies d
@@ -256,14 +308,14 @@ func markBlockedTemplateForWisps() string {
 		      JOIN issues t ON t.id = d.depends_on_issue_id
 		      WHERE d.issue_id = w.id
 		        AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
-		        AND t.status <> 'closed' AND t.status <> 'pinned'
+		        AND `+activeRowSQL("t")+`
 		    )
 		    OR EXISTS (
 		      SELECT 1 FROM wisp_dependencies d
 		      JOIN wisps t ON t.id = d.depends_on_wisp_id
 		      WHERE d.issue_id = w.id
 		        AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
-		        AND t.status <> 'closed' AND t.status <> 'pinned'
+		        AND `+activeRowSQL("t")+`
 		    )
 		    OR EXISTS (
 		      SELECT 1 FROM wisp_dependencies d
@@ -301,14 +353,14 @@ func unmarkBlockedTemplateForWisps() string {
 		        JOIN issues t ON t.id = d.depends_on_issue_id
 		        WHERE d.issue_id = w.id
 		          AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
-		          AND t.status <> 'closed' AND t.status <> 'pinned'
+		          AND `+activeRowSQL("t")+`
 		      )
 		      AND NOT EXISTS (
 		        SELECT 1 FROM wisp_dependencies d
 		        JOIN wisps t ON t.id = d.depends_on_wisp_id
 		        WHERE d.issue_id = w.id
 		          AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
-		          AND t.status <> 'closed' AND t.status <> 'pinned'
+		          AND `+activeRowSQL("t")+`
 		      )
 		      AND NOT EXISTS (
 		        SELECT 1 FROM wisp_dependencies d
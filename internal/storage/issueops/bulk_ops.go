@@ -7,7 +7,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -230,7 +229,7 @@ func UpdateIssueIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, iss
 }
 
 func updateIssueIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, issue *types.Issue, actor string) error {
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 	result, err := tx.ExecContext(ctx, `
 		UPDATE issues
 		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
@@ -252,6 +251,15 @@ func updateIssueIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, iss
 		`UPDATE leases SET issue_id = ? WHERE issue_id = ?`, newID, oldID); err != nil {
 		return fmt.Errorf("rename lease row: %w", err)
 	}
+	// So do its slugs and its lock.
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE issue_slugs SET issue_id = ? WHERE issue_id = ?`, newID, oldID); err != nil {
+		return fmt.Errorf("rename slug rows: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE issue_locks SET issue_id = ? WHERE issue_id = ?`, newID, oldID); err != nil {
+		return fmt.Errorf("rename lock row: %w", err)
+	}
 
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO events (id, issue_id, event_type, actor, old_value, new_value)
@@ -261,7 +269,7 @@ func updateIssueIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, iss
 }
 
 func updateWispIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, issue *types.Issue, actor string) error {
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 	result, err := tx.ExecContext(ctx, `
 		UPDATE wisps
 		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"slices"
 	"strings"
-	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -47,8 +46,11 @@ func ClaimIssueInTx(ctx context.Context, tx DBTX, id string, actor string) (*Cla
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issue for claim: %w", err)
 	}
+	if err := checkUpdateProtection(oldIssue, map[string]interface{}{"assignee": actor, "status": types.StatusInProgress}, actor); err != nil {
+		return nil, err
+	}
 
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 
 	// Rewrite row_lock with the claim (see lease.go): a concurrent reclaim or
 	// close on the same row is forced to conflict rather than silently
@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -113,6 +112,9 @@ func isClosedInTx(ctx context.Context, tx DBTX, id string) (bool, error) {
 
 //nolint:gosec // G201: table names come from WispTableRouting (hardcoded constants)
 func closeIssueInTx(ctx context.Context, tx DBTX, id string, reason, actor, session string, recordEvent bool) (*CloseResult, error) {
+	if err := checkStatusProtection(ctx, tx, id, actor, types.ProtectedStatus); err != nil {
+		return nil, err
+	}
 	isWisp := IsActiveWispInTx(ctx, tx, id)
 	issueTable, _, eventTable, _ := WispTableRouting(isWisp)
 
@@ -127,7 +129,7 @@ func closeIssueInTx(ctx context.Context, tx DBTX, id string, reason, actor, sess
 		return nil, fmt.Errorf("affected by close for %s: %w", id, aerr)
 	}
 
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 
 	// row_lock is rewritten on close so a concurrent reclaim (which also rewrites
 	// row_lock) collides on this cell and is forced to conflict-and-retry rather
@@ -180,3 +182,19 @@ func closeIssueInTx(ctx context.Context, tx DBTX, id string, reason, actor, sess
 
 	return &CloseResult{IsWisp: isWisp}, nil
 }
+
+// LinkDuplicateInTx records id as a duplicate of canonicalID (a duplicates
+// dependency, the edge bd duplicate adds) within an existing transaction, so
+// a close can carry its duplicate link atomically.
+func LinkDuplicateInTx(ctx context.Context, tx *sql.Tx, id, canonicalID, actor string) error {
+	if id == canonicalID {
+		return fmt.Errorf("cannot mark an issue as duplicate of itself")
+	}
+	dep := &types.Dependency{IssueID: id, DependsOnID: canonicalID, Type: types.DepDuplicates}
+	if _, err := AddDependencyInTx(ctx, tx, dep, actor, AddDependencyOpts{
+		IsCrossPrefix: types.ExtractPrefix(id) != types.ExtractPrefix(canonicalID),
+	}); err != nil {
+		return fmt.Errorf("linking %s as duplicate of %s: %w", id, canonicalID, err)
+	}
+	return nil
+}
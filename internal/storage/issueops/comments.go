@@ -226,7 +226,7 @@ func GetCommentCountsInTx(ctx context.Context, tx *sql.Tx, issueIDs []string) (m
 //
 //nolint:gosec // G201: table names come from hardcoded constants
 func AddIssueCommentInTx(ctx context.Context, tx *sql.Tx, issueID, author, text string) (*types.Comment, error) {
-	return ImportIssueCommentInTx(ctx, tx, issueID, author, text, time.Now().UTC().Truncate(time.Second))
+	return ImportIssueCommentInTx(ctx, tx, issueID, author, text, storage.Now(ctx).Truncate(time.Second))
 }
 
 // ImportIssueCommentInTx adds a comment preserving the original timestamp.
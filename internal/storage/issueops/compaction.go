@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -88,7 +89,7 @@ func CheckEligibilityInTx(ctx context.Context, tx *sql.Tx, issueID string, tier
 
 // ApplyCompactionInTx records a compaction result.
 func ApplyCompactionInTx(ctx context.Context, tx *sql.Tx, issueID string, tier int, originalSize int, commitHash string) error {
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 	_, err := tx.ExecContext(ctx,
 		`UPDATE issues SET compaction_level = ?, compacted_at = ?, compacted_at_commit = ?, original_size = ?, updated_at = ? WHERE id = ?`,
 		tier, now, commitHash, originalSize, now, issueID)
@@ -121,7 +122,7 @@ func SnapshotIssueInTx(ctx context.Context, tx *sql.Tx, issueID string, tier int
 		return fmt.Errorf("snapshot issue %s: marshal: %w", issueID, err)
 	}
 
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 	if _, err := tx.ExecContext(ctx,
 		`INSERT INTO compaction_snapshots (id, issue_id, compaction_level, snapshot_json, created_at) VALUES (?, ?, ?, ?, ?)`,
 		NewEventID(), issueID, tier, payload, now,
@@ -167,7 +168,7 @@ func RestoreFromSnapshotInTx(ctx context.Context, tx *sql.Tx, issueID string) (*
 		return nil, nil
 	}
 
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 	newLevel := max(snap.CompactionLevel-1, 0)
 
 	if newLevel == 0 {
@@ -207,7 +208,7 @@ func GetTier1CandidatesInTx(ctx context.Context, tx *sql.Tx) ([]*types.Compactio
 			AND i.closed_at <= ?
 			AND (i.compaction_level = 0 OR i.compaction_level IS NULL)
 		ORDER BY i.closed_at ASC`,
-		string(types.StatusClosed), time.Now().UTC().Add(-time.Duration(days)*24*time.Hour))
+		string(types.StatusClosed), storage.Now(ctx).Add(-time.Duration(days)*24*time.Hour))
 	if err != nil {
 		return nil, fmt.Errorf("query tier 1 candidates: %w", err)
 	}
@@ -228,7 +229,7 @@ func GetTier2CandidatesInTx(ctx context.Context, tx *sql.Tx) ([]*types.Compactio
 			AND i.closed_at <= ?
 			AND i.compaction_level = 1
 		ORDER BY i.closed_at ASC`,
-		string(types.StatusClosed), time.Now().UTC().Add(-time.Duration(days)*24*time.Hour))
+		string(types.StatusClosed), storage.Now(ctx).Add(-time.Duration(days)*24*time.Hour))
 	if err != nil {
 		return nil, fmt.Errorf("query tier 2 candidates: %w", err)
 	}
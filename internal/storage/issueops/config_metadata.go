@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 // SetConfigInTx sets a configuration value within an existing transaction.
@@ -76,6 +77,20 @@ func GetMetadataInTx(ctx context.Context, tx DBTX, key string) (string, error) {
 	return value, nil
 }
 
+// DeleteMetadataPrefixInTx removes the metadata rows whose key starts with
+// prefix within an existing transaction.
+func DeleteMetadataPrefixInTx(ctx context.Context, tx DBTX, prefix string) (int, error) {
+	res, err := tx.ExecContext(ctx, "DELETE FROM metadata WHERE LEFT(`key`, ?) = ?", utf8.RuneCountInString(prefix), prefix)
+	if err != nil {
+		return 0, fmt.Errorf("delete metadata %s*: %w", prefix, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete metadata %s*: %w", prefix, err)
+	}
+	return int(n), nil
+}
+
 // SetLocalMetadataInTx sets a value in the dolt-ignored local_metadata table
 // within an existing transaction. Used for clone-local state that should not
 // generate merge conflicts (tip timestamps, version stamps, sync cursors).
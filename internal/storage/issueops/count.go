@@ -60,7 +60,7 @@ func CountIssuesInTx(ctx context.Context, tx DBTX, query string, filter types.Is
 }
 
 // CountIssuesByGroupInTx counts issues grouped by a field within a transaction.
-// groupBy must be one of: status, priority, type, assignee, label.
+// groupBy must be one of: status, priority, type, assignee, label, close_reason.
 // Returns a map of group value → count, using the same display format as bd count.
 //
 // Mirrors CountIssuesInTx's wisps-merge semantics: ephemeral-only filters
@@ -123,10 +123,11 @@ func countGroupForTablesInTx(ctx context.Context, tx DBTX, filter types.IssueFil
 
 	// Map user-facing groupBy name to SQL column name.
 	groupByToColumn := map[string]string{
-		"status":   "status",
-		"priority": "priority",
-		"type":     "issue_type",
-		"assignee": "assignee",
+		"status":       "status",
+		"priority":     "priority",
+		"type":         "issue_type",
+		"assignee":     "assignee",
+		"close_reason": "close_reason",
 	}
 	col, ok := groupByToColumn[groupBy]
 	if !ok {
@@ -156,7 +157,7 @@ func countGroupForTablesInTx(ctx context.Context, tx DBTX, filter types.IssueFil
 
 // countTableInTx runs SELECT COUNT(*) FROM <table> WHERE <query+filter>.
 func countTableInTx(ctx context.Context, tx DBTX, query string, filter types.IssueFilter, tables FilterTables) (int, error) {
-	clauses, args, err := BuildIssueFilterClauses(query, filter, tables)
+	clauses, args, err := BuildIssueFilterClauses(ctx, query, filter, tables)
 	if err != nil {
 		return 0, err
 	}
@@ -176,7 +177,7 @@ func countTableInTx(ctx context.Context, tx DBTX, query string, filter types.Iss
 // countByColumnInTx runs SELECT <col>, COUNT(*) GROUP BY <col> against a table.
 // Returns raw column values as keys (callers normalize for display).
 func countByColumnInTx(ctx context.Context, tx DBTX, filter types.IssueFilter, col string, tables FilterTables) (map[string]int, error) {
-	clauses, args, err := BuildIssueFilterClauses("", filter, tables)
+	clauses, args, err := BuildIssueFilterClauses(ctx, "", filter, tables)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +210,7 @@ func countByColumnInTx(ctx context.Context, tx DBTX, filter types.IssueFilter, c
 // Dolt's joinIter panic (join_iters.go:192). Issues with no labels are counted
 // under "(no labels)".
 func countByLabelInTx(ctx context.Context, tx DBTX, filter types.IssueFilter, tables FilterTables) (map[string]int, error) {
-	clauses, args, err := BuildIssueFilterClauses("", filter, tables)
+	clauses, args, err := BuildIssueFilterClauses(ctx, "", filter, tables)
 	if err != nil {
 		return nil, err
 	}
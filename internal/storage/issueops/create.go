@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/steveyegge/beads/internal/progress"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/depid"
 	"github.com/steveyegge/beads/internal/types"
@@ -18,7 +19,9 @@ type BatchContext struct {
 	CustomStatuses  []string
 	CustomTypes     []string
 	ConfigPrefix    string
-	AllowedPrefixes string
+	AllowedPrefixes string // includes the prefixes PrefixByType and PrefixByLabel assign
+	PrefixByType    []types.IDPrefixRule
+	PrefixByLabel   []types.IDPrefixRule
 	Opts            storage.BatchCreateOptions
 }
 
@@ -38,16 +41,42 @@ func NewBatchContext(ctx context.Context, tx *sql.Tx, opts storage.BatchCreateOp
 	}
 	var allowedPrefixes string
 	_ = tx.QueryRowContext(ctx, "SELECT value FROM config WHERE `key` = ?", "allowed_prefixes").Scan(&allowedPrefixes)
+	byType, byLabel, err := ReadIDPrefixRulesTx(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
 
 	return &BatchContext{
 		CustomStatuses:  customStatuses,
 		CustomTypes:     customTypes,
 		ConfigPrefix:    configPrefix,
-		AllowedPrefixes: allowedPrefixes,
+		AllowedPrefixes: types.MergeAllowedPrefixes(allowedPrefixes, byType, byLabel),
+		PrefixByType:    byType,
+		PrefixByLabel:   byLabel,
 		Opts:            opts,
 	}, nil
 }
 
+// IDPrefixFor returns the prefix a generated ID for issue starts with: an
+// explicit override, the issue's IDPrefix under the configured prefix, a
+// matching type or label rule, or the configured prefix (with "-wisp" for
+// wisps).
+func (bc *BatchContext) IDPrefixFor(issue *types.Issue) string {
+	switch {
+	case issue.PrefixOverride != "":
+		return issue.PrefixOverride
+	case issue.IDPrefix != "":
+		return bc.ConfigPrefix + "-" + issue.IDPrefix
+	}
+	if rulePrefix := types.ResolveIDPrefix(issue, bc.PrefixByType, bc.PrefixByLabel); rulePrefix != "" {
+		return rulePrefix
+	}
+	if IsWisp(issue) {
+		return bc.ConfigPrefix + "-wisp"
+	}
+	return bc.ConfigPrefix
+}
+
 func CreateIssueInTx(ctx context.Context, tx *sql.Tx, bc *BatchContext, issue *types.Issue, actor string) error {
 	_, err := CreateIssueInTxWithResult(ctx, tx, bc, issue, actor)
 	return err
@@ -84,23 +113,15 @@ func mergeChangedTables(dst map[string]bool, src map[string]bool) map[string]boo
 
 func CreateIssueInTxWithResult(ctx context.Context, tx *sql.Tx, bc *BatchContext, issue *types.Issue, actor string) (CreateIssueResult, error) {
 	var result CreateIssueResult
-	if err := PrepareIssueForInsert(issue, bc.CustomStatuses, bc.CustomTypes); err != nil {
+	if err := PrepareIssueForInsert(ctx, issue, bc.CustomStatuses, bc.CustomTypes); err != nil {
 		return result, err
 	}
 
 	issueTable, eventTable := TableRouting(issue)
 
 	if issue.ID == "" {
-		prefix := bc.ConfigPrefix
-		if issue.PrefixOverride != "" {
-			prefix = issue.PrefixOverride
-		} else if issue.IDPrefix != "" {
-			prefix = bc.ConfigPrefix + "-" + issue.IDPrefix
-		} else if IsWisp(issue) {
-			prefix = bc.ConfigPrefix + "-wisp"
-		}
 		var err error
-		issue.ID, err = GenerateIssueIDInTable(ctx, tx, issueTable, prefix, issue, actor)
+		issue.ID, err = GenerateIssueIDInTable(ctx, tx, issueTable, bc.IDPrefixFor(issue), issue, actor)
 		if err != nil {
 			return result, fmt.Errorf("failed to generate issue ID: %w", err)
 		}
@@ -216,6 +237,7 @@ func CreateIssuesInTxWithResult(ctx context.Context, tx *sql.Tx, issues []*types
 			return CreateIssuesResult{}, err
 		}
 		result.merge(issueResult.ChangedTables)
+		progress.Add(ctx, 1)
 		if issueResult.StaleRejected {
 			continue // stale snapshot: keep its deps out of the batch too
 		}
@@ -429,13 +451,16 @@ func createBlockedRecomputeIDs(issues []*types.Issue) ([]string, []string) {
 }
 
 // PrepareIssueForInsert normalizes timestamps, validates, and computes the content hash.
-func PrepareIssueForInsert(issue *types.Issue, customStatuses, customTypes []string) error {
+func PrepareIssueForInsert(ctx context.Context, issue *types.Issue, customStatuses, customTypes []string) error {
 	if err := ValidateMetadataIfConfigured(issue.Metadata); err != nil {
 		return fmt.Errorf("metadata validation failed for issue %s: %w", issue.ID, err)
 	}
+	if err := ValidateEventIfConfigured(issue); err != nil {
+		return fmt.Errorf("event validation failed for issue %s: %w", issue.ID, err)
+	}
 
 	// Normalize timestamps to UTC, defaulting to now.
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 	if issue.CreatedAt.IsZero() {
 		issue.CreatedAt = now
 	} else {
@@ -693,7 +718,7 @@ func PersistComments(ctx context.Context, tx *sql.Tx, issue *types.Issue) (Creat
 	for _, comment := range issue.Comments {
 		createdAt := comment.CreatedAt
 		if createdAt.IsZero() {
-			createdAt = time.Now().UTC()
+			createdAt = storage.Now(ctx)
 		}
 		// Check for existing identical comment to prevent duplicates on re-import.
 		// The UUID PK means ON DUPLICATE KEY UPDATE would never fire,
@@ -812,7 +837,7 @@ func PersistDependenciesWithOptionsResult(ctx context.Context, tx *sql.Tx, issue
 
 			createdAt := dep.CreatedAt
 			if createdAt.IsZero() {
-				createdAt = time.Now().UTC()
+				createdAt = storage.Now(ctx)
 			}
 			// Deterministic id from (issue_id, target) keeps bulk-imported edges
 			// merge-safe across clones — two clones importing the same JSONL get the
@@ -0,0 +1,303 @@
+package issueops
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// CredentialKeySize is the length of a federation credential key (AES-256).
+const CredentialKeySize = 32
+
+// NewCredentialKey returns a random federation credential key.
+func NewCredentialKey() ([]byte, error) {
+	key := make([]byte, CredentialKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("generate credential key: %w", err)
+	}
+	return key, nil
+}
+
+// EncryptWithKey encrypts plaintext using AES-GCM with the given key. The
+// nonce is prepended to the ciphertext.
+func EncryptWithKey(plaintext string, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, []byte(plaintext), nil), nil
+}
+
+// DecryptWithKey decrypts ciphertext produced by EncryptWithKey.
+func DecryptWithKey(encrypted []byte, key []byte) (string, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonceSize := gcm.NonceSize()
+	if len(encrypted) < nonceSize {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := encrypted[:nonceSize], encrypted[nonceSize:]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// SecretReencryption describes a re-encryption of the federation_peers
+// password, SSH key passphrase, and encrypted username and remote URL
+// columns from OldKey to NewKey.
+type SecretReencryption struct {
+	OldKey []byte
+	NewKey []byte
+
+	// SkipUndecryptable leaves secrets that OldKey cannot decrypt untouched
+	// instead of failing. Migrations from an older key scheme set it, since
+	// some rows may already use the current key.
+	SkipUndecryptable bool
+
+	// DryRun decrypts every secret with OldKey but writes nothing.
+	DryRun bool
+
+	// Progress, if set, is called once per peer whose secrets were (or, for
+	// a dry run, would be) re-encrypted.
+	Progress func(peer string)
+}
+
+// ReencryptFederationSecretsInTx re-encrypts every stored peer secret as
+// described by r and returns the names of the peers it re-encrypted. All
+// rows are read before any are written, so a failure leaves the transaction
+// for the caller to roll back with nothing half-rotated.
+func ReencryptFederationSecretsInTx(ctx context.Context, tx *sql.Tx, r SecretReencryption) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT name, password_encrypted, ssh_passphrase_encrypted, username_encrypted, remote_url_encrypted FROM federation_peers
+		WHERE LENGTH(password_encrypted) > 0 OR LENGTH(ssh_passphrase_encrypted) > 0 OR LENGTH(remote_url_encrypted) > 0
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list federation peer secrets: %w", err)
+	}
+
+	type peerSecrets struct {
+		name                                string
+		password, passphrase, username, url []byte
+	}
+	var toUpdate []peerSecrets
+	for rows.Next() {
+		var p peerSecrets
+		if err := rows.Scan(&p.name, &p.password, &p.passphrase, &p.username, &p.url); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan federation peer secrets: %w", err)
+		}
+		reencrypted, ok, err := reencryptPeerSecrets(r, p.password, p.passphrase, p.username, p.url)
+		if err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("peer %s: %w", p.name, err)
+		}
+		if !ok {
+			continue
+		}
+		p.password, p.passphrase, p.username, p.url = reencrypted[0], reencrypted[1], reencrypted[2], reencrypted[3]
+		toUpdate = append(toUpdate, p)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("list federation peer secrets: %w", err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list federation peer secrets: %w", err)
+	}
+
+	names := make([]string, 0, len(toUpdate))
+	for _, p := range toUpdate {
+		if !r.DryRun {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE federation_peers
+				SET password_encrypted = ?, ssh_passphrase_encrypted = ?, username_encrypted = ?, remote_url_encrypted = ?
+				WHERE name = ?
+			`, p.password, p.passphrase, p.username, p.url, p.name); err != nil {
+				return nil, fmt.Errorf("update secrets for peer %s: %w", p.name, err)
+			}
+		}
+		names = append(names, p.name)
+		if r.Progress != nil {
+			r.Progress(p.name)
+		}
+	}
+	return names, nil
+}
+
+// EncryptFederationPeerMetadataInTx encrypts, with key, the username and
+// remote URL of every peer stored in plaintext, clearing the plaintext
+// columns, and returns the names of the peers it encrypted. All rows are
+// read before any are written.
+func EncryptFederationPeerMetadataInTx(ctx context.Context, tx *sql.Tx, key []byte, opts storage.PeerMetadataEncryptionOptions) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT name, remote_url, username FROM federation_peers
+		WHERE COALESCE(LENGTH(remote_url_encrypted), 0) = 0
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list federation peers: %w", err)
+	}
+
+	type peerMetadata struct {
+		name          string
+		url, username []byte
+	}
+	var toUpdate []peerMetadata
+	for rows.Next() {
+		var name, url string
+		var username sql.NullString
+		if err := rows.Scan(&name, &url, &username); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan federation peer: %w", err)
+		}
+		p := peerMetadata{name: name}
+		if !opts.DryRun {
+			if p.url, err = EncryptWithKey(url, key); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("peer %s: encrypt remote URL: %w", name, err)
+			}
+			if username.String != "" {
+				if p.username, err = EncryptWithKey(username.String, key); err != nil {
+					_ = rows.Close()
+					return nil, fmt.Errorf("peer %s: encrypt username: %w", name, err)
+				}
+			}
+		}
+		toUpdate = append(toUpdate, p)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("list federation peers: %w", err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list federation peers: %w", err)
+	}
+
+	names := make([]string, 0, len(toUpdate))
+	for _, p := range toUpdate {
+		if !opts.DryRun {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE federation_peers
+				SET remote_url = '', username = NULL, username_encrypted = ?, remote_url_encrypted = ?
+				WHERE name = ?
+			`, p.username, p.url, p.name); err != nil {
+				return nil, fmt.Errorf("encrypt metadata for peer %s: %w", p.name, err)
+			}
+		}
+		names = append(names, p.name)
+		if opts.Progress != nil {
+			opts.Progress(p.name)
+		}
+	}
+	return names, nil
+}
+
+// reencryptPeerSecrets re-encrypts a peer's non-empty secrets. It returns
+// ok=false when a secret cannot be decrypted and r.SkipUndecryptable is set.
+func reencryptPeerSecrets(r SecretReencryption, secrets ...[]byte) ([][]byte, bool, error) {
+	out := make([][]byte, len(secrets))
+	for i, encrypted := range secrets {
+		if len(encrypted) == 0 {
+			continue
+		}
+		plaintext, err := DecryptWithKey(encrypted, r.OldKey)
+		if err != nil {
+			if r.SkipUndecryptable {
+				return nil, false, nil
+			}
+			return nil, false, fmt.Errorf("decrypt with current credential key: %w", err)
+		}
+		if r.DryRun {
+			out[i] = encrypted
+			continue
+		}
+		if out[i], err = EncryptWithKey(plaintext, r.NewKey); err != nil {
+			return nil, false, fmt.Errorf("re-encrypt: %w", err)
+		}
+	}
+	return out, true, nil
+}
+
+// StageCredentialKey writes key to a staging file next to keyPath, synced to
+// disk, and returns its path. CommitCredentialKey moves it into place once
+// the secrets encrypted with it have been committed; if that never happens,
+// the caller removes the staging file.
+func StageCredentialKey(keyPath string, key []byte) (string, error) {
+	stagingPath := keyPath + ".rotating"
+	f, err := os.OpenFile(stagingPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600) //nolint:gosec // G304: keyPath is derived from the trusted beads directory
+	if err != nil {
+		return "", fmt.Errorf("write new credential key: %w", err)
+	}
+	if _, err := f.Write(key); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("write new credential key: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("sync new credential key: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("write new credential key: %w", err)
+	}
+	return stagingPath, nil
+}
+
+// CommitCredentialKey shreds the key file at keyPath by overwriting it with
+// random bytes, then renames stagingPath over it. Renaming alone would only
+// unlink the old key, leaving its bytes recoverable on disk.
+func CommitCredentialKey(keyPath, stagingPath string) error {
+	if err := shredFileContents(keyPath); err != nil {
+		return fmt.Errorf("shred old credential key: %w", err)
+	}
+	if err := os.Rename(stagingPath, keyPath); err != nil {
+		return fmt.Errorf("install new credential key from %s: %w", stagingPath, err)
+	}
+	return nil
+}
+
+// shredFileContents overwrites a file in place with random bytes of the
+// same length and syncs it. A missing file is not an error.
+func shredFileContents(path string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY, 0) //nolint:gosec // G304: path is the trusted credential key file
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(f, rand.Reader, info.Size()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
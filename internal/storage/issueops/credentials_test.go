@@ -0,0 +1,198 @@
+package issueops
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func mustEncrypt(t *testing.T, plaintext string, key []byte) []byte {
+	t.Helper()
+	encrypted, err := EncryptWithKey(plaintext, key)
+	if err != nil {
+		t.Fatalf("EncryptWithKey: %v", err)
+	}
+	return encrypted
+}
+
+func TestReencryptFederationSecretsInTx(t *testing.T) {
+	oldKey, _ := NewCredentialKey()
+	newKey, _ := NewCredentialKey()
+	otherKey, _ := NewCredentialKey()
+
+	secretRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"name", "password_encrypted", "ssh_passphrase_encrypted", "username_encrypted", "remote_url_encrypted"}).
+			AddRow("alpha", mustEncrypt(t, "pw", oldKey), nil, nil, nil).
+			AddRow("beta", nil, mustEncrypt(t, "pp", oldKey), mustEncrypt(t, "me", oldKey), mustEncrypt(t, "https://h/db", oldKey)).
+			AddRow("gamma", mustEncrypt(t, "pw", otherKey), nil, nil, nil)
+	}
+
+	t.Run("strict fails on undecryptable secret", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT name, password_encrypted").WillReturnRows(secretRows())
+		tx, _ := db.Begin()
+
+		_, err = ReencryptFederationSecretsInTx(t.Context(), tx, SecretReencryption{OldKey: oldKey, NewKey: newKey})
+		if err == nil {
+			t.Fatal("expected error for peer gamma")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("no rows should be updated: %v", err)
+		}
+	})
+
+	t.Run("skip undecryptable and report progress", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT name, password_encrypted").WillReturnRows(secretRows())
+		mock.ExpectExec("UPDATE federation_peers").WithArgs(sqlmock.AnyArg(), []byte(nil), []byte(nil), []byte(nil), "alpha").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE federation_peers").WithArgs([]byte(nil), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "beta").WillReturnResult(sqlmock.NewResult(0, 1))
+		tx, _ := db.Begin()
+
+		var progress []string
+		peers, err := ReencryptFederationSecretsInTx(t.Context(), tx, SecretReencryption{
+			OldKey:            oldKey,
+			NewKey:            newKey,
+			SkipUndecryptable: true,
+			Progress:          func(peer string) { progress = append(progress, peer) },
+		})
+		if err != nil {
+			t.Fatalf("ReencryptFederationSecretsInTx: %v", err)
+		}
+		if want := []string{"alpha", "beta"}; !slices.Equal(peers, want) || !slices.Equal(progress, want) {
+			t.Errorf("peers = %v, progress = %v, want %v", peers, progress, want)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT name, password_encrypted").WillReturnRows(secretRows())
+		tx, _ := db.Begin()
+
+		peers, err := ReencryptFederationSecretsInTx(t.Context(), tx, SecretReencryption{OldKey: oldKey, SkipUndecryptable: true, DryRun: true})
+		if err != nil {
+			t.Fatalf("ReencryptFederationSecretsInTx: %v", err)
+		}
+		if want := []string{"alpha", "beta"}; !slices.Equal(peers, want) {
+			t.Errorf("peers = %v, want %v", peers, want)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestEncryptFederationPeerMetadataInTx(t *testing.T) {
+	key, _ := NewCredentialKey()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT name, remote_url, username FROM federation_peers").WillReturnRows(
+		sqlmock.NewRows([]string{"name", "remote_url", "username"}).
+			AddRow("alpha", "https://beads.corp.internal/db", "jdoe").
+			AddRow("beta", "dolthub://acme/beads", nil))
+	mock.ExpectExec("UPDATE federation_peers").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "alpha").WillReturnResult(sqlmock.NewResult(0, 1))
+	// beta has no username, so none is encrypted.
+	mock.ExpectExec("UPDATE federation_peers").WithArgs([]byte(nil), sqlmock.AnyArg(), "beta").WillReturnResult(sqlmock.NewResult(0, 1))
+	tx, _ := db.Begin()
+
+	peers, err := EncryptFederationPeerMetadataInTx(t.Context(), tx, key, storage.PeerMetadataEncryptionOptions{})
+	if err != nil {
+		t.Fatalf("EncryptFederationPeerMetadataInTx: %v", err)
+	}
+	if want := []string{"alpha", "beta"}; !slices.Equal(peers, want) {
+		t.Errorf("peers = %v, want %v", peers, want)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecryptPeerMetadata(t *testing.T) {
+	key, _ := NewCredentialKey()
+	peer := &storage.FederationPeer{Name: "alpha"}
+	decrypt := func(b []byte) (string, error) {
+		if len(b) == 0 {
+			return "", nil
+		}
+		return DecryptWithKey(b, key)
+	}
+	if err := DecryptPeerMetadata(peer, mustEncrypt(t, "jdoe", key), mustEncrypt(t, "https://h/db", key), decrypt); err != nil {
+		t.Fatalf("DecryptPeerMetadata: %v", err)
+	}
+	if peer.Username != "jdoe" || peer.RemoteURL != "https://h/db" || !peer.EncryptMetadata {
+		t.Errorf("decrypted peer = %+v", peer)
+	}
+
+	plain := &storage.FederationPeer{Name: "beta", RemoteURL: "dolthub://acme/beads"}
+	if err := DecryptPeerMetadata(plain, nil, nil, decrypt); err != nil || plain.RemoteURL != "dolthub://acme/beads" || plain.EncryptMetadata {
+		t.Errorf("plaintext peer changed: %+v, %v", plain, err)
+	}
+}
+
+func TestStageAndCommitCredentialKey(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "key")
+	oldKey, _ := NewCredentialKey()
+	newKey, _ := NewCredentialKey()
+	if err := os.WriteFile(keyPath, oldKey, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	stagingPath, err := StageCredentialKey(keyPath, newKey)
+	if err != nil {
+		t.Fatalf("StageCredentialKey: %v", err)
+	}
+	if got, _ := os.ReadFile(keyPath); !bytes.Equal(got, oldKey) {
+		t.Fatal("staging must not touch the current key file")
+	}
+
+	// Keep a handle on the old key's inode to check it was overwritten, not
+	// just unlinked.
+	old, err := os.Open(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer old.Close()
+
+	if err := CommitCredentialKey(keyPath, stagingPath); err != nil {
+		t.Fatalf("CommitCredentialKey: %v", err)
+	}
+	if got, _ := os.ReadFile(keyPath); !bytes.Equal(got, newKey) {
+		t.Error("key file does not hold the new key")
+	}
+	if _, err := os.Stat(stagingPath); !os.IsNotExist(err) {
+		t.Errorf("staging file should be gone, stat err = %v", err)
+	}
+	shredded := make([]byte, len(oldKey))
+	if _, err := old.ReadAt(shredded, 0); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(shredded, oldKey) {
+		t.Error("old key bytes were not overwritten")
+	}
+}
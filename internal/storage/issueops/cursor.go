@@ -0,0 +1,27 @@
+package issueops
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// resolveCursor turns filter.Cursor into the keyset predicate and the
+// (created_at DESC, id ASC) order it pages in. Filters without a Cursor are
+// returned unchanged.
+func resolveCursor(filter types.IssueFilter) (types.IssueFilter, error) {
+	if filter.Cursor == "" {
+		return filter, nil
+	}
+	if filter.Cursor != types.CursorStart {
+		createdAt, id, err := types.ParseIssueCursor(filter.Cursor)
+		if err != nil {
+			return filter, fmt.Errorf("%w %q", err, filter.Cursor)
+		}
+		filter.AfterCreatedAt = &createdAt
+		filter.AfterID = id
+	}
+	filter.SortBy = []types.SortKey{{Field: "created", Desc: true}}
+	filter.Offset = 0
+	return filter, nil
+}
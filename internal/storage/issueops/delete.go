@@ -19,6 +19,9 @@ const maxRecursiveResults = 10000
 
 //nolint:gosec // G201: table names come from WispTableRouting (hardcoded constants)
 func DeleteIssueInTx(ctx context.Context, tx *sql.Tx, id string) error {
+	if err := checkDeleteProtection(ctx, tx, id); err != nil {
+		return err
+	}
 	isWisp := IsActiveWispInTx(ctx, tx, id)
 
 	var deletedIssues, deletedWisps []string
@@ -67,6 +70,8 @@ func deleteIssueRowInTx(ctx context.Context, tx *sql.Tx, id string, isWisp bool)
 	} else if err := DeleteLeaseInTx(ctx, tx, id); err != nil {
 		// A deleted issue holds no lease.
 		return err
+	} else if err := DeleteIssueSlugsInTx(ctx, tx, id); err != nil {
+		return err
 	}
 	return nil
 }
@@ -164,6 +169,11 @@ func DeleteIssuesInTx(ctx context.Context, tx *sql.Tx, ids []string, cascade boo
 	for _, id := range allWispIDs {
 		allDeletedSet[id] = true
 	}
+	for _, id := range append(append([]string{}, finalRegularIDs...), allWispIDs...) {
+		if err := checkDeleteProtection(ctx, tx, id); err != nil {
+			return nil, err
+		}
+	}
 
 	var depsCount, labelsCount, eventsCount int
 	if depsCount, err = CountRowsForIssueIDsInTx(ctx, tx, "dependencies", finalRegularIDs); err != nil {
@@ -266,12 +276,22 @@ func DeleteIssuesInTx(ctx context.Context, tx *sql.Tx, ids []string, cascade boo
 		rowsAffected, _ := deleteResult.RowsAffected()
 		totalRegularsDeleted += int(rowsAffected)
 
-		// Deleted issues hold no leases.
+		// Deleted issues hold no leases or locks, and their slugs are freed.
 		if _, err := tx.ExecContext(ctx,
 			fmt.Sprintf(`DELETE FROM leases WHERE issue_id IN (%s)`, batchInClause),
 			batchArgs...); err != nil {
 			return nil, fmt.Errorf("delete leases: %w", err)
 		}
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf(`DELETE FROM issue_slugs WHERE issue_id IN (%s)`, batchInClause),
+			batchArgs...); err != nil {
+			return nil, fmt.Errorf("delete slugs: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf(`DELETE FROM issue_locks WHERE issue_id IN (%s)`, batchInClause),
+			batchArgs...); err != nil {
+			return nil, fmt.Errorf("delete locks: %w", err)
+		}
 	}
 	result.DeletedCount = totalRegularsDeleted + len(allWispIDs)
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/depid"
 	"github.com/steveyegge/beads/internal/storage/domain"
 	"github.com/steveyegge/beads/internal/storage/sqlbuild"
@@ -269,8 +270,8 @@ func AddDependencyInTx(ctx context.Context, tx *sql.Tx, dep *types.Dependency, a
 	//nolint:gosec // G201: writeTable from WispTableRouting; targetCol from DepTargetKind.Column()
 	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
 		INSERT INTO %s (id, issue_id, %s, type, created_at, created_by, metadata, thread_id)
-		VALUES (?, ?, ?, ?, NOW(), ?, ?, ?)
-	`, writeTable, targetCol), depid.New(dep.IssueID, dep.DependsOnID), dep.IssueID, dep.DependsOnID, dep.Type, actor, metadata, dep.ThreadID); err != nil {
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, writeTable, targetCol), depid.New(dep.IssueID, dep.DependsOnID), dep.IssueID, dep.DependsOnID, dep.Type, storage.Now(ctx), actor, metadata, dep.ThreadID); err != nil {
 		return false, fmt.Errorf("failed to add dependency: %w", err)
 	}
 
@@ -391,11 +392,11 @@ func markDirectBlockingDependencySourceInTx(ctx context.Context, tx *sql.Tx, sou
 		  AND s.status <> 'closed' AND s.status <> 'pinned'
 		  AND EXISTS (
 		    SELECT 1 FROM (
-		      SELECT id, status FROM %s WHERE id = ?
+		      SELECT id, status, metadata FROM %s WHERE id = ?
 		    ) AS t
-		    WHERE t.status <> 'closed' AND t.status <> 'pinned'
+		    WHERE %s
 		  )
-	`, sourceTable, targetTable), source, target)
+	`, sourceTable, targetTable, activeRowSQL("t")), source, target)
 	return err
 }
 
@@ -715,6 +715,14 @@ func queryBlocksInfo(
 	return nil
 }
 
+// statusInReview is what loadStatusByIDInTx reports for a closed issue whose
+// review is still pending. It is never stored; it only fails the "is closed"
+// checks below, so such an issue keeps blocking its dependents, as
+// activeRowSQL does in SQL.
+const statusInReview types.Status = "in_review"
+
+// loadStatusByIDInTx returns the status of each of ids found in issues or
+// wisps, reporting closed issues awaiting review as statusInReview.
 func loadStatusByIDInTx(ctx context.Context, tx DBTX, ids []string) (map[string]types.Status, error) {
 	statusByID := make(map[string]types.Status)
 	if len(ids) == 0 {
@@ -730,8 +738,9 @@ func loadStatusByIDInTx(ctx context.Context, tx DBTX, ids []string) (map[string]
 			}
 			placeholders, args := buildSQLInClause(ids[start:end])
 			rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
-				SELECT id, status FROM %s WHERE id IN (%s)
-			`, issueTable, placeholders), args...)
+				SELECT id, CASE WHEN t.status = 'closed' AND %s THEN '%s' ELSE t.status END
+				FROM %s t WHERE id IN (%s)
+			`, reviewPendingSQL("t"), statusInReview, issueTable, placeholders), args...)
 			if err != nil {
 				if optionalBlockedTable(issueTable) && isTableNotExistError(err) {
 					break
@@ -770,6 +779,15 @@ func loadStatusByIDInTx(ctx context.Context, tx DBTX, ids []string) (map[string]
 //
 //nolint:gosec // G201: table names come from hardcoded constants
 func GetNewlyUnblockedByCloseInTx(ctx context.Context, tx DBTX, closedIssueID string) ([]*types.Issue, error) {
+	// A close still awaiting review unblocks nothing yet.
+	closedStatus, err := loadStatusByIDInTx(ctx, tx, []string{closedIssueID})
+	if err != nil {
+		return nil, fmt.Errorf("check closed issue status: %w", err)
+	}
+	if closedStatus[closedIssueID] == statusInReview {
+		return nil, nil
+	}
+
 	candidateSet := make(map[string]bool)
 	for _, depTable := range []string{"dependencies", "wisp_dependencies"} {
 		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
@@ -812,7 +830,7 @@ func GetNewlyUnblockedByCloseInTx(ctx context.Context, tx DBTX, closedIssueID st
 	activeCandidateIDs := candidateIDs[:0]
 	for _, id := range candidateIDs {
 		status, ok := candidateStatusByID[id]
-		if !ok || status == types.StatusClosed || status == types.StatusPinned {
+		if !ok || status == types.StatusClosed || status == statusInReview || status == types.StatusPinned {
 			continue
 		}
 		activeCandidateIDs = append(activeCandidateIDs, id)
@@ -29,7 +29,9 @@ func DiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storag
 			from_title, to_title,
 			from_description, to_description,
 			from_status, to_status,
-			from_priority, to_priority
+			from_priority, to_priority,
+			from_assignee, to_assignee,
+			from_issue_type, to_issue_type
 		FROM dolt_diff('%s', '%s', 'issues')
 	`, fromRef, toRef)
 
@@ -44,12 +46,15 @@ func DiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storag
 		var fromID, toID, diffType string
 		var fromTitle, toTitle, fromDesc, toDesc, fromStatus, toStatus *string
 		var fromPriority, toPriority *int
+		var fromAssignee, toAssignee, fromType, toType *string
 
 		if err := rows.Scan(&fromID, &toID, &diffType,
 			&fromTitle, &toTitle,
 			&fromDesc, &toDesc,
 			&fromStatus, &toStatus,
-			&fromPriority, &toPriority); err != nil {
+			&fromPriority, &toPriority,
+			&fromAssignee, &toAssignee,
+			&fromType, &toType); err != nil {
 			return nil, fmt.Errorf("failed to scan diff: %w", err)
 		}
 
@@ -80,6 +85,12 @@ func DiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storag
 			if fromPriority != nil {
 				entry.OldValue.Priority = *fromPriority
 			}
+			if fromAssignee != nil {
+				entry.OldValue.Assignee = *fromAssignee
+			}
+			if fromType != nil {
+				entry.OldValue.IssueType = types.IssueType(*fromType)
+			}
 		}
 
 		// Build new value for modified/added
@@ -99,6 +110,12 @@ func DiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storag
 			if toPriority != nil {
 				entry.NewValue.Priority = *toPriority
 			}
+			if toAssignee != nil {
+				entry.NewValue.Assignee = *toAssignee
+			}
+			if toType != nil {
+				entry.NewValue.IssueType = types.IssueType(*toType)
+			}
 		}
 
 		entries = append(entries, entry)
@@ -106,3 +123,94 @@ func DiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storag
 
 	return entries, rows.Err()
 }
+
+// DependencyDiffInTx returns the dependency edges added, removed, or retyped
+// between two commits or branches, via dolt_diff() on the dependencies table.
+//
+// nolint:gosec // G201: refs are validated by ValidateRef() - dolt_diff requires literal refs
+func DependencyDiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storage.DependencyDiffEntry, error) {
+	if err := ValidateRef(fromRef); err != nil {
+		return nil, fmt.Errorf("invalid fromRef: %w", err)
+	}
+	if err := ValidateRef(toRef); err != nil {
+		return nil, fmt.Errorf("invalid toRef: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			COALESCE(from_issue_id, ''),
+			COALESCE(from_depends_on_issue_id, from_depends_on_wisp_id, from_depends_on_external, ''),
+			COALESCE(from_type, ''),
+			COALESCE(to_issue_id, ''),
+			COALESCE(to_depends_on_issue_id, to_depends_on_wisp_id, to_depends_on_external, ''),
+			COALESCE(to_type, ''),
+			diff_type
+		FROM dolt_diff('%s', '%s', 'dependencies')
+	`, fromRef, toRef)
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency diff: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*storage.DependencyDiffEntry
+	for rows.Next() {
+		var fromIssue, fromTarget, fromType, toIssue, toTarget, toType, diffType string
+		if err := rows.Scan(&fromIssue, &fromTarget, &fromType, &toIssue, &toTarget, &toType, &diffType); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency diff: %w", err)
+		}
+		entry := &storage.DependencyDiffEntry{DiffType: diffType}
+		switch diffType {
+		case "removed":
+			entry.IssueID, entry.DependsOnID, entry.Type = fromIssue, fromTarget, types.DependencyType(fromType)
+		default:
+			entry.IssueID, entry.DependsOnID, entry.Type = toIssue, toTarget, types.DependencyType(toType)
+		}
+		if diffType == "modified" {
+			if fromType == toType {
+				continue // metadata-only change; the edge itself is unchanged
+			}
+			entry.OldType = types.DependencyType(fromType)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// LabelDiffInTx returns the labels added or removed between two commits or
+// branches, via dolt_diff() on the labels table.
+//
+// nolint:gosec // G201: refs are validated by ValidateRef() - dolt_diff requires literal refs
+func LabelDiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storage.LabelDiffEntry, error) {
+	if err := ValidateRef(fromRef); err != nil {
+		return nil, fmt.Errorf("invalid fromRef: %w", err)
+	}
+	if err := ValidateRef(toRef); err != nil {
+		return nil, fmt.Errorf("invalid toRef: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			COALESCE(to_issue_id, from_issue_id, ''),
+			COALESCE(to_label, from_label, ''),
+			diff_type
+		FROM dolt_diff('%s', '%s', 'labels')
+	`, fromRef, toRef)
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label diff: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*storage.LabelDiffEntry
+	for rows.Next() {
+		entry := &storage.LabelDiffEntry{}
+		if err := rows.Scan(&entry.IssueID, &entry.Label, &entry.DiffType); err != nil {
+			return nil, fmt.Errorf("failed to scan label diff: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
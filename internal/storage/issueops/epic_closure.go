@@ -83,7 +83,9 @@ func GetEpicsEligibleForClosureInTx(ctx context.Context, tx DBTX) ([]*types.Epic
 				batch := allChildIDs[start:end]
 				placeholders, args := buildSQLInClause(batch)
 
-				statusQuery := fmt.Sprintf("SELECT id, status FROM %s WHERE id IN (%s)", table, placeholders)
+				// A child still in review is not done (statusInReview).
+				statusQuery := fmt.Sprintf("SELECT id, CASE WHEN t.status = 'closed' AND %s THEN '%s' ELSE t.status END FROM %s t WHERE id IN (%s)",
+					reviewPendingSQL("t"), statusInReview, table, placeholders)
 				statusRows, err := tx.QueryContext(ctx, statusQuery, args...)
 				if err != nil {
 					if isTableNotExistError(err) {
@@ -0,0 +1,95 @@
+package issueops
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// validEventKindRegex matches event kind names such as "deploy" or
+// "patrol.muted". Kinds are stored in the 32-character event_kind column.
+var validEventKindRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]*(\.[a-z][a-z0-9_-]*)*$`)
+
+// ValidateEventKindName checks that name can be stored as an event kind.
+func ValidateEventKindName(name string) error {
+	if len(name) > 32 {
+		return fmt.Errorf("invalid event kind %q: longer than 32 characters", name)
+	}
+	if !validEventKindRegex.MatchString(name) {
+		return fmt.Errorf("invalid event kind %q: use lowercase dot-separated words such as \"deploy\" or \"patrol.muted\"", name)
+	}
+	return nil
+}
+
+// EventKindsFromConfig parses the events.kinds registry from config.yaml.
+// Returns nil when no event kinds are registered.
+func EventKindsFromConfig() ([]storage.EventKind, error) {
+	return ParseEventKinds(config.EventKinds())
+}
+
+// ParseEventKinds converts raw registry entries into event kinds, rejecting
+// entries without a valid name and duplicates.
+func ParseEventKinds(entries []interface{}) ([]storage.EventKind, error) {
+	var kinds []storage.EventKind
+	seen := map[string]bool{}
+	for i, raw := range entries {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("events.kinds[%d]: expected a mapping", i)
+		}
+		var kind storage.EventKind
+		kind.Name, _ = m["name"].(string)
+		if err := ValidateEventKindName(kind.Name); err != nil {
+			return nil, fmt.Errorf("events.kinds[%d]: %w", i, err)
+		}
+		if seen[kind.Name] {
+			return nil, fmt.Errorf("events.kinds: %q is registered twice", kind.Name)
+		}
+		seen[kind.Name] = true
+		kind.Description, _ = m["description"].(string)
+		kind.Strict, _ = m["strict"].(bool)
+		kind.RequireActor, _ = m["require_actor"].(bool)
+		kind.RequireTarget, _ = m["require_target"].(bool)
+		if fields, ok := m["payload"].(map[string]interface{}); ok {
+			kind.Payload = make(map[string]storage.MetadataFieldSchema, len(fields))
+			for field, rawField := range fields {
+				fieldMap, ok := rawField.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				kind.Payload[field] = ParseFieldSchema(fieldMap)
+			}
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+// ValidateEventIfConfigured checks an event issue against the events.kinds
+// registry. Issues of other types, and events without a kind (such as the
+// audit events bd set-state records), are not checked. Events of an
+// unregistered kind are rejected only when events.strict is set.
+func ValidateEventIfConfigured(issue *types.Issue) error {
+	if issue.IssueType != types.TypeEvent || issue.EventKind == "" {
+		return nil
+	}
+	kinds, err := EventKindsFromConfig()
+	if err != nil {
+		return err
+	}
+	kind := storage.EventKindFor(issue.EventKind, kinds)
+	if kind == nil {
+		if config.EventsStrict() {
+			return fmt.Errorf("event kind %q is not registered in events.kinds", issue.EventKind)
+		}
+		return nil
+	}
+	errs := storage.ValidateEvent(*kind, issue.Actor, issue.Target, issue.Payload)
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("event schema violation: %s: %s", errs[0].Field, errs[0].Message)
+}
@@ -0,0 +1,38 @@
+package issueops
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEventKinds(t *testing.T) {
+	entries := []interface{}{
+		map[string]interface{}{
+			"name":           "deploy",
+			"strict":         true,
+			"require_target": true,
+			"payload":        map[string]interface{}{"version": map[string]interface{}{"type": "string", "required": true}},
+		},
+		map[string]interface{}{"name": "patrol.muted"},
+	}
+	kinds, err := ParseEventKinds(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kinds) != 2 || !kinds[0].Strict || !kinds[0].RequireTarget || !kinds[0].Payload["version"].Required {
+		t.Errorf("parsed %+v", kinds)
+	}
+
+	bad := [][]interface{}{
+		{map[string]interface{}{"name": "Deploy"}},
+		{map[string]interface{}{"name": "patrol."}},
+		{map[string]interface{}{"name": strings.Repeat("a", 33)}},
+		{map[string]interface{}{"name": "deploy"}, map[string]interface{}{"name": "deploy"}},
+		{"deploy"},
+	}
+	for _, entries := range bad {
+		if _, err := ParseEventKinds(entries); err == nil {
+			t.Errorf("ParseEventKinds(%v) accepted", entries)
+		}
+	}
+}
@@ -27,23 +27,67 @@ func ValidatePeerName(name string) error {
 	return nil
 }
 
+// ValidatePeerCredentialSource checks a peer's credential source. A peer
+// that resolves its password from a source must not also store one.
+func ValidatePeerCredentialSource(peer *storage.FederationPeer) error {
+	if _, _, err := storage.ParseCredentialSource(peer.CredentialSource); err != nil {
+		return err
+	}
+	if peer.CredentialSource != "" && peer.Password != "" {
+		return fmt.Errorf("peer %s has a credential source; it cannot also store a password", peer.Name)
+	}
+	return nil
+}
+
+// EncryptedPeerMetadata is a peer's username and remote URL encrypted with
+// the credential key. A row stored with it has an empty plaintext
+// remote_url and a NULL username.
+type EncryptedPeerMetadata struct {
+	Username  []byte // nil when the peer has no username
+	RemoteURL []byte
+}
+
+// PeerMetadataColumns returns the remote_url, username, username_encrypted,
+// and remote_url_encrypted values that store peer, in plaintext when
+// metadata is nil.
+func PeerMetadataColumns(peer *storage.FederationPeer, metadata *EncryptedPeerMetadata) (remoteURL string, username any, usernameEnc, remoteURLEnc []byte) {
+	if metadata == nil {
+		return peer.RemoteURL, peer.Username, nil, nil
+	}
+	return "", nil, metadata.Username, metadata.RemoteURL
+}
+
 // AddFederationPeerInTx upserts a federation peer record. The encryptedPwd
-// should already be encrypted by the caller; pass nil for no password.
-func AddFederationPeerInTx(ctx context.Context, tx *sql.Tx, peer *storage.FederationPeer, encryptedPwd []byte) error {
+// and encryptedPassphrase should already be encrypted by the caller; pass nil
+// for no password or SSH key passphrase. metadata, if non-nil, stores the
+// username and remote URL encrypted instead of in plaintext.
+func AddFederationPeerInTx(ctx context.Context, tx *sql.Tx, peer *storage.FederationPeer, encryptedPwd, encryptedPassphrase []byte, metadata *EncryptedPeerMetadata) error {
 	if err := ValidatePeerName(peer.Name); err != nil {
 		return fmt.Errorf("invalid peer name: %w", err)
 	}
+	if err := ValidatePeerCredentialSource(peer); err != nil {
+		return err
+	}
 
+	remoteURL, username, usernameEnc, remoteURLEnc := PeerMetadataColumns(peer, metadata)
 	_, err := tx.ExecContext(ctx, `
-		INSERT INTO federation_peers (name, remote_url, username, password_encrypted, sovereignty)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO federation_peers (name, remote_url, username, password_encrypted, ssh_key_path, ssh_passphrase_encrypted, sovereignty, sync_mode, conflict_strategy, credential_source, owned_prefixes, username_encrypted, remote_url_encrypted)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON DUPLICATE KEY UPDATE
 			remote_url = VALUES(remote_url),
 			username = VALUES(username),
 			password_encrypted = VALUES(password_encrypted),
+			ssh_key_path = VALUES(ssh_key_path),
+			ssh_passphrase_encrypted = VALUES(ssh_passphrase_encrypted),
 			sovereignty = VALUES(sovereignty),
+			sync_mode = VALUES(sync_mode),
+			conflict_strategy = VALUES(conflict_strategy),
+			credential_source = VALUES(credential_source),
+			owned_prefixes = VALUES(owned_prefixes),
+			username_encrypted = VALUES(username_encrypted),
+			remote_url_encrypted = VALUES(remote_url_encrypted),
 			updated_at = CURRENT_TIMESTAMP
-	`, peer.Name, peer.RemoteURL, peer.Username, encryptedPwd, peer.Sovereignty)
+	`, peer.Name, remoteURL, username, encryptedPwd, peer.SSHKeyPath, encryptedPassphrase, peer.Sovereignty, string(peer.SyncMode), string(peer.ConflictStrategy), peer.CredentialSource, storage.JoinOwnedPrefixes(peer.OwnedPrefixes), usernameEnc, remoteURLEnc)
 
 	if err != nil {
 		return fmt.Errorf("add federation peer: %w", err)
@@ -51,11 +95,49 @@ func AddFederationPeerInTx(ctx context.Context, tx *sql.Tx, peer *storage.Federa
 	return nil
 }
 
+// PeerMetadataEncryptedInTx reports whether the named peer is stored with
+// encrypted metadata. A peer that does not exist is not.
+func PeerMetadataEncryptedInTx(ctx context.Context, tx DBTX, name string) (bool, error) {
+	var encrypted bool
+	err := tx.QueryRowContext(ctx,
+		"SELECT COALESCE(LENGTH(remote_url_encrypted), 0) > 0 FROM federation_peers WHERE name = ?", name).Scan(&encrypted)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("check federation peer %s: %w", name, err)
+	}
+	return encrypted, nil
+}
+
+// DecryptPeerMetadata fills in the username and remote URL of a peer stored
+// with encrypted metadata, using the store's credential decryption. Peers
+// stored in plaintext are left as they are.
+func DecryptPeerMetadata(peer *storage.FederationPeer, encryptedUsername, encryptedRemoteURL []byte, decrypt func([]byte) (string, error)) error {
+	if len(encryptedRemoteURL) == 0 {
+		return nil
+	}
+	var err error
+	if peer.RemoteURL, err = decrypt(encryptedRemoteURL); err != nil {
+		return fmt.Errorf("decrypt remote URL: %w", err)
+	}
+	if peer.Username, err = decrypt(encryptedUsername); err != nil {
+		return fmt.Errorf("decrypt username: %w", err)
+	}
+	peer.EncryptMetadata = true
+	return nil
+}
+
 // FederationPeerRow holds raw database fields for a federation peer.
-// The caller is responsible for decrypting EncryptedPwd.
+// The caller is responsible for decrypting EncryptedPwd and
+// EncryptedPassphrase, and EncryptedUsername and EncryptedRemoteURL with
+// DecryptPeerMetadata.
 type FederationPeerRow struct {
-	Peer         storage.FederationPeer
-	EncryptedPwd []byte
+	Peer                storage.FederationPeer
+	EncryptedPwd        []byte
+	EncryptedPassphrase []byte
+	EncryptedUsername   []byte
+	EncryptedRemoteURL  []byte
 }
 
 // GetFederationPeerInTx retrieves a federation peer by name.
@@ -63,14 +145,15 @@ type FederationPeerRow struct {
 func GetFederationPeerInTx(ctx context.Context, tx *sql.Tx, name string) (*FederationPeerRow, error) {
 	var row FederationPeerRow
 	var lastSync sql.NullTime
-	var username sql.NullString
+	var username, sshKeyPath sql.NullString
+	var ownedPrefixes string
 
 	err := tx.QueryRowContext(ctx, `
-		SELECT name, remote_url, username, password_encrypted, sovereignty, last_sync, created_at, updated_at
+		SELECT name, remote_url, username, password_encrypted, ssh_key_path, ssh_passphrase_encrypted, sovereignty, sync_mode, conflict_strategy, credential_source, owned_prefixes, username_encrypted, remote_url_encrypted, last_sync, created_at, updated_at
 		FROM federation_peers WHERE name = ?
 	`, name).Scan(
-		&row.Peer.Name, &row.Peer.RemoteURL, &username, &row.EncryptedPwd,
-		&row.Peer.Sovereignty, &lastSync, &row.Peer.CreatedAt, &row.Peer.UpdatedAt,
+		&row.Peer.Name, &row.Peer.RemoteURL, &username, &row.EncryptedPwd, &sshKeyPath, &row.EncryptedPassphrase,
+		&row.Peer.Sovereignty, &row.Peer.SyncMode, &row.Peer.ConflictStrategy, &row.Peer.CredentialSource, &ownedPrefixes, &row.EncryptedUsername, &row.EncryptedRemoteURL, &lastSync, &row.Peer.CreatedAt, &row.Peer.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -83,9 +166,13 @@ func GetFederationPeerInTx(ctx context.Context, tx *sql.Tx, name string) (*Feder
 	if username.Valid {
 		row.Peer.Username = username.String
 	}
+	if sshKeyPath.Valid {
+		row.Peer.SSHKeyPath = sshKeyPath.String
+	}
 	if lastSync.Valid {
 		row.Peer.LastSync = &lastSync.Time
 	}
+	row.Peer.OwnedPrefixes = storage.SplitOwnedPrefixes(ownedPrefixes)
 
 	return &row, nil
 }
@@ -93,7 +180,7 @@ func GetFederationPeerInTx(ctx context.Context, tx *sql.Tx, name string) (*Feder
 // ListFederationPeersInTx returns all configured federation peer rows.
 func ListFederationPeersInTx(ctx context.Context, tx *sql.Tx) ([]*FederationPeerRow, error) {
 	rows, err := tx.QueryContext(ctx, `
-		SELECT name, remote_url, username, password_encrypted, sovereignty, last_sync, created_at, updated_at
+		SELECT name, remote_url, username, password_encrypted, ssh_key_path, ssh_passphrase_encrypted, sovereignty, sync_mode, conflict_strategy, credential_source, owned_prefixes, username_encrypted, remote_url_encrypted, last_sync, created_at, updated_at
 		FROM federation_peers ORDER BY name
 	`)
 	if err != nil {
@@ -105,11 +192,12 @@ func ListFederationPeersInTx(ctx context.Context, tx *sql.Tx) ([]*FederationPeer
 	for rows.Next() {
 		var row FederationPeerRow
 		var lastSync sql.NullTime
-		var username sql.NullString
+		var username, sshKeyPath sql.NullString
+		var ownedPrefixes string
 
 		if err := rows.Scan(
-			&row.Peer.Name, &row.Peer.RemoteURL, &username, &row.EncryptedPwd,
-			&row.Peer.Sovereignty, &lastSync, &row.Peer.CreatedAt, &row.Peer.UpdatedAt,
+			&row.Peer.Name, &row.Peer.RemoteURL, &username, &row.EncryptedPwd, &sshKeyPath, &row.EncryptedPassphrase,
+			&row.Peer.Sovereignty, &row.Peer.SyncMode, &row.Peer.ConflictStrategy, &row.Peer.CredentialSource, &ownedPrefixes, &row.EncryptedUsername, &row.EncryptedRemoteURL, &lastSync, &row.Peer.CreatedAt, &row.Peer.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scan federation peer: %w", err)
 		}
@@ -117,15 +205,47 @@ func ListFederationPeersInTx(ctx context.Context, tx *sql.Tx) ([]*FederationPeer
 		if username.Valid {
 			row.Peer.Username = username.String
 		}
+		if sshKeyPath.Valid {
+			row.Peer.SSHKeyPath = sshKeyPath.String
+		}
 		if lastSync.Valid {
 			row.Peer.LastSync = &lastSync.Time
 		}
+		row.Peer.OwnedPrefixes = storage.SplitOwnedPrefixes(ownedPrefixes)
 
 		peers = append(peers, &row)
 	}
 	return peers, rows.Err()
 }
 
+// GetFederationPeerSyncModeInTx returns a peer's sync mode. Remotes that
+// are not registered federation peers sync bidirectionally.
+func GetFederationPeerSyncModeInTx(ctx context.Context, tx *sql.Tx, name string) (storage.SyncMode, error) {
+	var mode string
+	err := tx.QueryRowContext(ctx, "SELECT sync_mode FROM federation_peers WHERE name = ?", name).Scan(&mode)
+	if err == sql.ErrNoRows {
+		return storage.SyncModeBidirectional, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get federation peer sync mode: %w", err)
+	}
+	return storage.ParseSyncMode(mode)
+}
+
+// GetFederationPeerConflictStrategyInTx returns a peer's default conflict
+// strategy. Remotes that are not registered federation peers have none.
+func GetFederationPeerConflictStrategyInTx(ctx context.Context, tx *sql.Tx, name string) (storage.ConflictStrategy, error) {
+	var strategy string
+	err := tx.QueryRowContext(ctx, "SELECT conflict_strategy FROM federation_peers WHERE name = ?", name).Scan(&strategy)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get federation peer conflict strategy: %w", err)
+	}
+	return storage.ParseConflictStrategy(strategy)
+}
+
 // RemoveFederationPeerInTx deletes a federation peer by name.
 func RemoveFederationPeerInTx(ctx context.Context, tx *sql.Tx, name string) error {
 	_, err := tx.ExecContext(ctx, "DELETE FROM federation_peers WHERE name = ?", name)
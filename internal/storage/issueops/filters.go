@@ -1,6 +1,9 @@
 package issueops
 
 import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlbuild"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -18,9 +21,10 @@ var (
 
 // BuildIssueFilterClauses builds WHERE clause fragments and args from a query
 // string and IssueFilter. The tables parameter controls which table names are
-// referenced in subqueries (issues vs wisps).
-func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables FilterTables) ([]string, []interface{}, error) {
-	return sqlbuild.BuildIssueFilterClauses(query, filter, tables)
+// referenced in subqueries (issues vs wisps). Time-relative filters use
+// storage.Now(ctx).
+func BuildIssueFilterClauses(ctx context.Context, query string, filter types.IssueFilter, tables FilterTables) ([]string, []interface{}, error) {
+	return sqlbuild.BuildIssueFilterClauses(query, filter, tables, storage.Now(ctx))
 }
 
 // LooksLikeIssueID returns true if the query string looks like a beads issue ID.
@@ -1,6 +1,7 @@
 package issueops
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"testing"
@@ -49,7 +50,7 @@ func TestLooksLikeIssueID(t *testing.T) {
 func TestBuildIssueFilterClauses_EmptyFilter(t *testing.T) {
 	t.Parallel()
 
-	clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{}, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", types.IssueFilter{}, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -64,7 +65,7 @@ func TestBuildIssueFilterClauses_EmptyFilter(t *testing.T) {
 func TestBuildIssueFilterClauses_QueryAsIssueID(t *testing.T) {
 	t.Parallel()
 
-	clauses, args, err := BuildIssueFilterClauses("bd-abc123", types.IssueFilter{}, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "bd-abc123", types.IssueFilter{}, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -80,7 +81,7 @@ func TestBuildIssueFilterClauses_QueryAsIssueID(t *testing.T) {
 func TestBuildIssueFilterClauses_QueryAsText(t *testing.T) {
 	t.Parallel()
 
-	clauses, args, err := BuildIssueFilterClauses("fix the bug", types.IssueFilter{}, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "fix the bug", types.IssueFilter{}, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -97,7 +98,7 @@ func TestBuildIssueFilterClauses_StatusFilter(t *testing.T) {
 	t.Parallel()
 
 	status := types.StatusOpen
-	clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{Status: &status}, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", types.IssueFilter{Status: &status}, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -118,7 +119,7 @@ func TestBuildIssueFilterClauses_ExcludeStatus(t *testing.T) {
 	filter := types.IssueFilter{
 		ExcludeStatus: []types.Status{types.StatusClosed, types.StatusOpen},
 	}
-	clauses, args, err := BuildIssueFilterClauses("", filter, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", filter, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -134,7 +135,7 @@ func TestBuildIssueFilterClausesUsesDirectIssueTypePredicates(t *testing.T) {
 	t.Parallel()
 
 	issueType := types.TypeTask
-	clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{IssueType: &issueType}, WispsFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", types.IssueFilter{IssueType: &issueType}, WispsFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -149,7 +150,7 @@ func TestBuildIssueFilterClausesUsesDirectIssueTypePredicates(t *testing.T) {
 		t.Fatalf("args = %#v", args)
 	}
 
-	clauses, args, err = BuildIssueFilterClauses("", types.IssueFilter{
+	clauses, args, err = BuildIssueFilterClauses(context.Background(), "", types.IssueFilter{
 		ExcludeTypes: []types.IssueType{types.TypeTask, types.TypeBug},
 	}, WispsFilterTables)
 	if err != nil {
@@ -172,7 +173,7 @@ func TestBuildIssueFilterClauses_PriorityRange(t *testing.T) {
 
 	min, max := 1, 3
 	filter := types.IssueFilter{PriorityMin: &min, PriorityMax: &max}
-	clauses, args, err := BuildIssueFilterClauses("", filter, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", filter, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -188,7 +189,7 @@ func TestBuildIssueFilterClauses_Labels(t *testing.T) {
 	t.Parallel()
 
 	filter := types.IssueFilter{Labels: []string{"bug", "urgent"}}
-	clauses, args, err := BuildIssueFilterClauses("", filter, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", filter, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -205,7 +206,7 @@ func TestBuildIssueFilterClauses_LabelsAny(t *testing.T) {
 	t.Parallel()
 
 	filter := types.IssueFilter{LabelsAny: []string{"bug", "feature", "docs"}}
-	clauses, args, err := BuildIssueFilterClauses("", filter, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", filter, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -252,7 +253,7 @@ func TestBuildIssueFilterClauses_ExcludeLabels(t *testing.T) {
 	t.Parallel()
 
 	filter := types.IssueFilter{ExcludeLabels: []string{"triage:pending", "wontfix"}}
-	clauses, args, err := BuildIssueFilterClauses("", filter, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", filter, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -275,7 +276,7 @@ func TestBuildIssueFilterClauses_ExcludeLabelsWithInclude(t *testing.T) {
 		Labels:        []string{"backend"},
 		ExcludeLabels: []string{"triage:pending"},
 	}
-	clauses, args, err := BuildIssueFilterClauses("", filter, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", filter, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -297,7 +298,7 @@ func TestBuildIssueFilterClauses_DateFilters(t *testing.T) {
 		CreatedAfter:  &yesterday,
 		CreatedBefore: &now,
 	}
-	clauses, args, err := BuildIssueFilterClauses("", filter, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", filter, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -311,7 +312,7 @@ func TestBuildIssueFilterClauses_DateFilters(t *testing.T) {
 
 func TestBuildIssueFilterClauses_DeferredIncludesStatus(t *testing.T) {
 	t.Parallel()
-	clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{Deferred: true}, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", types.IssueFilter{Deferred: true}, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -349,7 +350,7 @@ func TestBuildIssueFilterClauses_BooleanFilters(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			clauses, _, err := BuildIssueFilterClauses("", tt.filter, IssuesFilterTables)
+			clauses, _, err := BuildIssueFilterClauses(context.Background(), "", tt.filter, IssuesFilterTables)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -378,7 +379,7 @@ func TestBuildIssueFilterClauses_PinnedFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			clauses, _, err := BuildIssueFilterClauses("", types.IssueFilter{Pinned: tt.pinned}, IssuesFilterTables)
+			clauses, _, err := BuildIssueFilterClauses(context.Background(), "", types.IssueFilter{Pinned: tt.pinned}, IssuesFilterTables)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -408,7 +409,7 @@ func TestBuildIssueFilterClauses_IsBlockedFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{IsBlocked: tt.isBlocked}, IssuesFilterTables)
+			clauses, args, err := BuildIssueFilterClauses(context.Background(), "", types.IssueFilter{IsBlocked: tt.isBlocked}, IssuesFilterTables)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -422,7 +423,7 @@ func TestBuildIssueFilterClauses_IsBlockedFilter(t *testing.T) {
 	}
 
 	// nil is the unset case: no is_blocked clause is emitted (filter is inert).
-	clauses, _, err := BuildIssueFilterClauses("", types.IssueFilter{IsBlocked: nil}, IssuesFilterTables)
+	clauses, _, err := BuildIssueFilterClauses(context.Background(), "", types.IssueFilter{IsBlocked: nil}, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -440,7 +441,7 @@ func TestBuildIssueFilterClauses_IDFilters(t *testing.T) {
 		IDs:      []string{"bd-1", "bd-2", "bd-3"},
 		IDPrefix: "bd-",
 	}
-	clauses, args, err := BuildIssueFilterClauses("", filter, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "", filter, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -459,11 +460,11 @@ func TestBuildIssueFilterClauses_WispsTables(t *testing.T) {
 	// Verify that wisps tables produce different SQL than issues tables
 	filter := types.IssueFilter{NoParent: true}
 
-	issuesClauses, _, err := BuildIssueFilterClauses("", filter, IssuesFilterTables)
+	issuesClauses, _, err := BuildIssueFilterClauses(context.Background(), "", filter, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	wispsClauses, _, err := BuildIssueFilterClauses("", filter, WispsFilterTables)
+	wispsClauses, _, err := BuildIssueFilterClauses(context.Background(), "", filter, WispsFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -489,7 +490,7 @@ func TestBuildIssueFilterClauses_CombinedFilters(t *testing.T) {
 		CreatedAfter: &now,
 		NoAssignee:   true,
 	}
-	clauses, args, err := BuildIssueFilterClauses("search term", filter, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses(context.Background(), "search term", filter, IssuesFilterTables)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
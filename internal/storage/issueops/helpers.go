@@ -17,7 +17,6 @@ import (
 	"github.com/google/uuid"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
-	"github.com/steveyegge/beads/internal/idgen"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -67,7 +66,7 @@ func TableRouting(issue *types.Issue) (issueTable, eventTable string) {
 // concurrent reclaim/close on the same row (see freshRowLock in lease.go).
 var issueUpsertColumns = []string{
 	"content_hash", "title", "description", "design", "acceptance_criteria",
-	"notes", "status", "priority", "issue_type", "assignee",
+	"notes", "is_encrypted", "status", "priority", "issue_type", "assignee",
 	"estimated_minutes", "started_at", "closed_at", "external_ref",
 	"source_repo", "close_reason", "metadata",
 	"row_lock", "updated_at",
@@ -107,45 +106,48 @@ func InsertIssueIntoTable(ctx context.Context, tx *sql.Tx, table string, issue *
 	return insertIssueIntoTable(ctx, tx, table, issue, false)
 }
 
-//nolint:gosec // G201: table is a hardcoded constant ("issues" or "wisps")
-func insertIssueIntoTable(ctx context.Context, tx *sql.Tx, table string, issue *types.Issue, rejectStaleUpdate bool) error {
-	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
-		INSERT INTO %s (
-			id, content_hash, title, description, design, acceptance_criteria, notes,
+// issueInsertColumns lists the columns every issue INSERT writes, in the
+// order issueInsertArgs returns their values.
+const issueInsertColumns = `id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, created_by, owner, updated_at, started_at, closed_at, external_ref, spec_id,
 			compaction_level, compacted_at, compacted_at_commit, original_size,
 			sender, ephemeral, no_history, wisp_type, pinned, is_template,
-			mol_type, work_type, source_system, source_repo, close_reason,
+			mol_type, work_type, source_system, source_formula, source_location, rig, is_encrypted, source_repo, close_reason,
 			event_kind, actor, target, payload,
 			await_type, await_id, timeout_ns, waiters,
 			due_at, defer_until, metadata,
-			row_lock
-		) VALUES (
-			?, ?, ?, ?, ?, ?, ?,
-			?, ?, ?, ?, ?,
-			?, ?, ?, ?, ?, ?, ?, ?,
-			?, ?, ?, ?,
-			?, ?, ?, ?, ?, ?,
-			?, ?, ?, ?, ?,
-			?, ?, ?, ?,
-			?, ?, ?, ?,
-			?, ?, ?,
-			?
-		)
-		ON DUPLICATE KEY UPDATE
-			%s
-	`, table, issueUpsertAssignments(table, rejectStaleUpdate)),
+			row_lock`
+
+// issueInsertPlaceholders is one VALUES tuple for issueInsertColumns.
+var issueInsertPlaceholders = "(" + strings.TrimSuffix(strings.Repeat("?, ", strings.Count(issueInsertColumns, ",")+1), ", ") + ")"
+
+// issueInsertArgs returns issue's values for issueInsertColumns.
+func issueInsertArgs(issue *types.Issue) []any {
+	return []any{
 		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
 		issue.Status, issue.Priority, issue.IssueType, NullString(issue.Assignee), NullInt(issue.EstimatedMinutes),
 		issue.CreatedAt, issue.CreatedBy, issue.Owner, issue.UpdatedAt, issue.StartedAt, issue.ClosedAt, NullStringPtr(issue.ExternalRef), issue.SpecID,
 		issue.CompactionLevel, issue.CompactedAt, NullStringPtr(issue.CompactedAtCommit), NullIntVal(issue.OriginalSize),
 		issue.Sender, issue.Ephemeral, issue.NoHistory, issue.WispType, issue.Pinned, issue.IsTemplate,
-		issue.MolType, issue.WorkType, issue.SourceSystem, issue.SourceRepo, issue.CloseReason,
+		issue.MolType, issue.WorkType, issue.SourceSystem, issue.SourceFormula, issue.SourceLocation, issue.Rig, issue.Encrypted, issue.SourceRepo, issue.CloseReason,
 		issue.EventKind, issue.Actor, issue.Target, issue.Payload,
 		issue.AwaitType, issue.AwaitID, issue.Timeout.Nanoseconds(), FormatJSONStringArray(issue.Waiters),
 		issue.DueAt, issue.DeferUntil, JSONMetadata(issue.Metadata),
 		freshRowLock(),
+	}
+}
+
+//nolint:gosec // G201: table is a hardcoded constant ("issues" or "wisps")
+func insertIssueIntoTable(ctx context.Context, tx *sql.Tx, table string, issue *types.Issue, rejectStaleUpdate bool) error {
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO %s (
+			%s
+		) VALUES %s
+		ON DUPLICATE KEY UPDATE
+			%s
+	`, table, issueInsertColumns, issueInsertPlaceholders, issueUpsertAssignments(table, rejectStaleUpdate)),
+		issueInsertArgs(issue)...,
 	)
 	if err != nil {
 		return fmt.Errorf("insert issue into %s: %w", table, err)
@@ -169,9 +171,15 @@ func RecordEventInTable(ctx context.Context, tx DBTX, table, issueID string, eve
 
 // GenerateIssueIDInTable generates a unique ID, checking for collisions
 // in the specified table. Supports counter mode for non-ephemeral issues.
+func GenerateIssueIDInTable(ctx context.Context, tx *sql.Tx, table, prefix string, issue *types.Issue, actor string) (string, error) {
+	return generateIssueIDInTable(ctx, tx, table, prefix, issue, actor, nil)
+}
+
+// generateIssueIDInTable is GenerateIssueIDInTable that also skips hash IDs
+// in taken: IDs handed to earlier issues of a batch that are not written yet.
 //
 //nolint:gosec // G201: table is a hardcoded constant
-func GenerateIssueIDInTable(ctx context.Context, tx *sql.Tx, table, prefix string, issue *types.Issue, actor string) (string, error) {
+func generateIssueIDInTable(ctx context.Context, tx *sql.Tx, table, prefix string, issue *types.Issue, actor string, taken map[string]bool) (string, error) {
 	// Counter mode only applies to the issues table (not wisps).
 	if table == "issues" {
 		counterMode, err := IsCounterModeTx(ctx, tx)
@@ -196,7 +204,10 @@ func GenerateIssueIDInTable(ctx context.Context, tx *sql.Tx, table, prefix strin
 
 	for length := baseLength; length <= maxLength; length++ {
 		for nonce := 0; nonce < 10; nonce++ {
-			candidate := idgen.GenerateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+			candidate := storage.GenerateIssueID(ctx, prefix, issue, actor, length, nonce)
+			if taken[candidate] {
+				continue
+			}
 
 			var count int
 			err = tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE id = ?`, table), candidate).Scan(&count)
@@ -423,7 +434,11 @@ func GetCustomTypesTx(ctx context.Context, tx *sql.Tx) ([]string, error) {
 }
 
 // ValidateMetadataIfConfigured checks metadata against the schema from config.
+// Registered metadata namespaces are checked first, whatever the mode.
 func ValidateMetadataIfConfigured(metadata json.RawMessage) error {
+	if err := ValidateMetadataNamespacesIfConfigured(metadata); err != nil {
+		return err
+	}
 	mode := config.MetadataValidationMode()
 	if mode == "none" || mode == "" {
 		return nil
@@ -550,6 +565,32 @@ func ReadConfigPrefix(ctx context.Context, tx *sql.Tx) (string, error) {
 	return strings.TrimSuffix(configPrefix, "-"), nil
 }
 
+// ReadIDPrefixRulesTx reads the prefix.by_type and prefix.by_label config
+// rules. Missing keys yield no rules.
+func ReadIDPrefixRulesTx(ctx context.Context, tx *sql.Tx) (byType, byLabel []types.IDPrefixRule, err error) {
+	read := func(key string) ([]types.IDPrefixRule, error) {
+		var value string
+		err := tx.QueryRowContext(ctx, "SELECT value FROM config WHERE `key` = ?", key).Scan(&value)
+		if err == sql.ErrNoRows {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get config %s: %w", key, err)
+		}
+		rules, err := types.ParseIDPrefixRules(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s config: %w", key, err)
+		}
+		return rules, nil
+	}
+	if byType, err = read(types.ConfigKeyPrefixByType); err != nil {
+		return nil, nil, err
+	}
+	if byLabel, err = read(types.ConfigKeyPrefixByLabel); err != nil {
+		return nil, nil, err
+	}
+	return byType, byLabel, nil
+}
+
 // ---------------------------------------------------------------------------
 // Nullable value helpers
 // ---------------------------------------------------------------------------
@@ -99,6 +99,16 @@ func HistoryInTx(ctx context.Context, tx DBTX, issueID string) ([]*storage.Histo
 	return entries, rows.Err()
 }
 
+// GetIssueHistoryInTx returns the commits that changed an issue, newest
+// first, with the fields each one changed, from dolt_history_issues.
+func GetIssueHistoryInTx(ctx context.Context, tx DBTX, issueID string) ([]*storage.IssueChange, error) {
+	entries, err := HistoryInTx(ctx, tx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	return storage.IssueChangesFromHistory(entries), nil
+}
+
 // PreviousExternalRefInTx returns the external_ref value recorded for
 // issueID as of the most recent commit at or before asOf, by querying the
 // dolt_history_issues system table. found is false if no history entry
@@ -0,0 +1,149 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const knowledgeColumns = "id, title, content, tags, bonded_from, created_by, created_at, updated_at"
+
+// UpsertKnowledgeInTx inserts a knowledge entry or updates the entry with the
+// same ID in place. created_at is preserved on update; both timestamps are
+// supplied here rather than defaulted so every clone writes identical rows.
+func UpsertKnowledgeInTx(ctx context.Context, tx *sql.Tx, entry *types.KnowledgeEntry) error {
+	if entry.ID == "" {
+		return fmt.Errorf("knowledge entry ID is required")
+	}
+	if len(entry.BondedFrom) == 0 {
+		return fmt.Errorf("knowledge entry %s has no provenance (bonded_from is empty)", entry.ID)
+	}
+	tags, err := json.Marshal(entry.Tags)
+	if err != nil {
+		return fmt.Errorf("marshal knowledge tags: %w", err)
+	}
+	bonded, err := json.Marshal(entry.BondedFrom)
+	if err != nil {
+		return fmt.Errorf("marshal knowledge bonded_from: %w", err)
+	}
+	now := storage.Now(ctx).Truncate(time.Second)
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = now
+	}
+	entry.UpdatedAt = now
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO knowledge (`+knowledgeColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			title = VALUES(title),
+			content = VALUES(content),
+			tags = VALUES(tags),
+			bonded_from = VALUES(bonded_from),
+			updated_at = VALUES(updated_at)
+	`, entry.ID, entry.Title, entry.Content, string(tags), string(bonded),
+		entry.CreatedBy, entry.CreatedAt, entry.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert knowledge %s: %w", entry.ID, err)
+	}
+	return nil
+}
+
+// GetKnowledgeInTx returns the knowledge entry with the given ID, or
+// storage.ErrNotFound.
+func GetKnowledgeInTx(ctx context.Context, tx *sql.Tx, id string) (*types.KnowledgeEntry, error) {
+	row := tx.QueryRowContext(ctx, "SELECT "+knowledgeColumns+" FROM knowledge WHERE id = ?", id)
+	entry, err := scanKnowledge(row.Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("knowledge %s: %w", id, storage.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get knowledge %s: %w", id, err)
+	}
+	return entry, nil
+}
+
+// SearchKnowledgeInTx returns entries matching query, newest first. Matching
+// is a case-insensitive substring test over title, content, and tags, done in
+// Go so it behaves the same under every collation.
+func SearchKnowledgeInTx(ctx context.Context, tx *sql.Tx, query string, limit int) ([]*types.KnowledgeEntry, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT "+knowledgeColumns+" FROM knowledge ORDER BY updated_at DESC, id ASC")
+	if err != nil {
+		return nil, fmt.Errorf("search knowledge: %w", err)
+	}
+	defer rows.Close()
+
+	needle := strings.ToLower(strings.TrimSpace(query))
+	var result []*types.KnowledgeEntry
+	for rows.Next() {
+		entry, err := scanKnowledge(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("search knowledge: scan: %w", err)
+		}
+		if needle != "" && !knowledgeMatches(entry, needle) {
+			continue
+		}
+		result = append(result, entry)
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, rows.Err()
+}
+
+// DeleteKnowledgeInTx removes a knowledge entry. Deleting a missing entry
+// returns storage.ErrNotFound.
+func DeleteKnowledgeInTx(ctx context.Context, tx *sql.Tx, id string) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM knowledge WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("delete knowledge %s: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("knowledge %s: %w", id, storage.ErrNotFound)
+	}
+	return nil
+}
+
+func knowledgeMatches(entry *types.KnowledgeEntry, needle string) bool {
+	if strings.Contains(strings.ToLower(entry.Title), needle) ||
+		strings.Contains(strings.ToLower(entry.Content), needle) {
+		return true
+	}
+	for _, tag := range entry.Tags {
+		if strings.Contains(strings.ToLower(tag), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func scanKnowledge(scan func(dest ...any) error) (*types.KnowledgeEntry, error) {
+	var (
+		entry     types.KnowledgeEntry
+		tags      sql.NullString
+		bonded    sql.NullString
+		createdBy sql.NullString
+	)
+	if err := scan(&entry.ID, &entry.Title, &entry.Content, &tags, &bonded, &createdBy, &entry.CreatedAt, &entry.UpdatedAt); err != nil {
+		return nil, err
+	}
+	entry.CreatedBy = createdBy.String
+	if tags.Valid && tags.String != "" {
+		if err := json.Unmarshal([]byte(tags.String), &entry.Tags); err != nil {
+			return nil, fmt.Errorf("parse tags for %s: %w", entry.ID, err)
+		}
+	}
+	if bonded.Valid && bonded.String != "" {
+		if err := json.Unmarshal([]byte(bonded.String), &entry.BondedFrom); err != nil {
+			return nil, fmt.Errorf("parse bonded_from for %s: %w", entry.ID, err)
+		}
+	}
+	return &entry, nil
+}
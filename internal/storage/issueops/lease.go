@@ -162,7 +162,7 @@ func DeleteLeaseInTx(ctx context.Context, tx DBTX, id string) error {
 //
 // Wisps are never leased; callers route them away before calling this.
 func RestoreLeaseOnImportInTx(ctx context.Context, tx DBTX, issue *types.Issue, isNew bool) error {
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 
 	if issue.LeaseExpiresAt != nil {
 		var status, assignee string
@@ -231,7 +231,7 @@ func RestoreLeaseOnImportInTx(ctx context.Context, tx DBTX, issue *types.Issue,
 //
 //nolint:gosec // G201: table names come from WispTableRouting (hardcoded constants)
 func HeartbeatIssueInTx(ctx context.Context, tx DBTX, id, actor string) error {
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 	result, err := tx.ExecContext(ctx, `
 		UPDATE leases SET lease_expires_at = ?, heartbeat_at = ?
 		WHERE issue_id = ? AND holder = ?
@@ -352,7 +352,7 @@ func ReclaimExpiredLeasesInTx(ctx context.Context, tx DBTX, cutoff time.Time, ac
 			SET status = 'open', assignee = NULL, started_at = NULL,
 			    updated_at = ?, row_lock = ?
 			WHERE id = ? AND status = 'in_progress'
-		`, time.Now().UTC(), freshRowLock(), r.ID)
+		`, storage.Now(ctx), freshRowLock(), r.ID)
 		if err != nil {
 			return nil, fmt.Errorf("reclaim %s: %w", r.ID, err)
 		}
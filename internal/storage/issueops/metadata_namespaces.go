@@ -0,0 +1,74 @@
+package issueops
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// MetadataNamespacesFromConfig parses the metadata.namespaces registry from
+// config.yaml. Returns nil when no namespaces are registered.
+func MetadataNamespacesFromConfig() ([]storage.MetadataNamespace, error) {
+	return ParseMetadataNamespaces(config.MetadataNamespaces())
+}
+
+// ParseMetadataNamespaces converts raw registry entries into namespaces,
+// rejecting entries without a valid name, duplicates, and unknown export
+// policies.
+func ParseMetadataNamespaces(entries []interface{}) ([]storage.MetadataNamespace, error) {
+	var namespaces []storage.MetadataNamespace
+	seen := map[string]bool{}
+	for i, raw := range entries {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("metadata.namespaces[%d]: expected a mapping", i)
+		}
+		ns := storage.MetadataNamespace{Export: storage.MetadataExportKeep}
+		ns.Name, _ = m["name"].(string)
+		if err := storage.ValidateMetadataNamespaceName(ns.Name); err != nil {
+			return nil, fmt.Errorf("metadata.namespaces[%d]: %w", i, err)
+		}
+		if seen[ns.Name] {
+			return nil, fmt.Errorf("metadata.namespaces: %q is registered twice", ns.Name)
+		}
+		seen[ns.Name] = true
+		ns.Description, _ = m["description"].(string)
+		ns.Strict, _ = m["strict"].(bool)
+		if export, ok := m["export"].(string); ok && export != "" {
+			if export != storage.MetadataExportKeep && export != storage.MetadataExportStrip {
+				return nil, fmt.Errorf("metadata.namespaces %q: export must be %q or %q, got %q",
+					ns.Name, storage.MetadataExportKeep, storage.MetadataExportStrip, export)
+			}
+			ns.Export = export
+		}
+		if fields, ok := m["fields"].(map[string]interface{}); ok {
+			ns.Fields = make(map[string]storage.MetadataFieldSchema, len(fields))
+			for field, rawField := range fields {
+				fieldMap, ok := rawField.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				ns.Fields[field] = ParseFieldSchema(fieldMap)
+			}
+		}
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces, nil
+}
+
+// ValidateMetadataNamespacesIfConfigured checks metadata against the
+// registered namespaces. Registering a namespace with fields opts into
+// enforcement, so violations are errors regardless of validation.metadata.mode.
+func ValidateMetadataNamespacesIfConfigured(metadata json.RawMessage) error {
+	namespaces, err := MetadataNamespacesFromConfig()
+	if err != nil {
+		return err
+	}
+	errs := storage.ValidateMetadataNamespaces(metadata, namespaces)
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("metadata namespace violation: %s", errs[0].Error())
+}
@@ -120,3 +120,37 @@ func TestDeleteMetadataInTx(t *testing.T) {
 		}
 	})
 }
+
+func TestParseMetadataNamespaces(t *testing.T) {
+	entries := []interface{}{
+		map[string]interface{}{
+			"name":   "tool.github",
+			"export": "strip",
+			"strict": true,
+			"fields": map[string]interface{}{"number": map[string]interface{}{"type": "int", "required": true}},
+		},
+		map[string]interface{}{"name": "jira"},
+	}
+	ns, err := ParseMetadataNamespaces(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ns) != 2 || ns[0].Export != storage.MetadataExportStrip || !ns[0].Strict || !ns[0].Fields["number"].Required {
+		t.Errorf("parsed %+v", ns)
+	}
+	if ns[1].Export != storage.MetadataExportKeep {
+		t.Errorf("default export = %q, want keep", ns[1].Export)
+	}
+
+	bad := [][]interface{}{
+		{map[string]interface{}{"name": "tool."}},
+		{map[string]interface{}{"name": "jira"}, map[string]interface{}{"name": "jira"}},
+		{map[string]interface{}{"name": "jira", "export": "drop"}},
+		{"jira"},
+	}
+	for _, entries := range bad {
+		if _, err := ParseMetadataNamespaces(entries); err == nil {
+			t.Errorf("ParseMetadataNamespaces(%v) accepted", entries)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package issueops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// PendingChangesInTx summarizes the working set against HEAD: the events
+// added (one per bd write), the issues touched, and when HEAD was committed.
+// Wisps are dolt_ignore'd and never committed, so they are not counted.
+func PendingChangesInTx(ctx context.Context, tx DBTX) (*storage.PendingChanges, error) {
+	var p storage.PendingChanges
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM dolt_diff('HEAD', 'WORKING', 'events')
+		WHERE diff_type = 'added'`).Scan(&p.Operations); err != nil {
+		return nil, fmt.Errorf("pending changes: count events: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT DISTINCT COALESCE(to_id, from_id)
+		FROM dolt_diff('HEAD', 'WORKING', 'issues')`)
+	if err != nil {
+		return nil, fmt.Errorf("pending changes: diff issues: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("pending changes: scan: %w", err)
+		}
+		p.IssueIDs = append(p.IssueIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pending changes: diff issues: %w", err)
+	}
+	sort.Strings(p.IssueIDs)
+
+	if err := tx.QueryRowContext(ctx, "SELECT date FROM dolt_log LIMIT 1").Scan(&p.HeadDate); err != nil {
+		return nil, fmt.Errorf("pending changes: read HEAD: %w", err)
+	}
+	return &p, nil
+}
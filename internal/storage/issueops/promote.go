@@ -30,7 +30,7 @@ func PromoteFromEphemeralInTx(ctx context.Context, tx *sql.Tx, id string, actor
 	if err != nil {
 		return fmt.Errorf("new batch context: %w", err)
 	}
-	if err := PrepareIssueForInsert(issue, bc.CustomStatuses, bc.CustomTypes); err != nil {
+	if err := PrepareIssueForInsert(ctx, issue, bc.CustomStatuses, bc.CustomTypes); err != nil {
 		return fmt.Errorf("promote wisp to issues: %w", err)
 	}
 	if _, _, err := InsertIssueIfNew(ctx, tx, "issues", issue, storage.BatchCreateOptions{}); err != nil {
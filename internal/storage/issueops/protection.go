@@ -0,0 +1,153 @@
+package issueops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CheckProtection returns a wrapped storage.ErrProtected when p forbids actor
+// from changing any of fields on issue id. A nil protection allows everything.
+func CheckProtection(id string, p *types.Protection, fields []string, actor string) error {
+	denied := p.Denied(actor, fields)
+	if len(denied) == 0 {
+		return nil
+	}
+	what := "is protected"
+	if len(p.Fields) > 0 {
+		what = "has protected fields: " + strings.Join(denied, ", ")
+	}
+	detail := "only " + strings.Join(p.Actors, ", ") + " may change it"
+	if len(p.Actors) == 0 {
+		detail = "no actor may change it"
+	}
+	if p.Reason != "" {
+		detail += " (" + p.Reason + ")"
+	}
+	return fmt.Errorf("%w: %s %s; %s, not %q", storage.ErrProtected, id, what, detail, actor)
+}
+
+// checkUpdateProtection guards updateIssueInTx: updates are the resolved
+// column values about to be written over oldIssue.
+func checkUpdateProtection(oldIssue *types.Issue, updates map[string]interface{}, actor string) error {
+	p, err := types.ProtectionFromMetadata(oldIssue.Metadata)
+	if err != nil || p == nil {
+		// An unreadable record protects nothing; it can still be overwritten
+		// so the issue is never locked by a corrupt value.
+		return nil
+	}
+	fields := make([]string, 0, len(updates))
+	for key, value := range updates {
+		if key != "metadata" {
+			fields = append(fields, key)
+			continue
+		}
+		metaFields, err := changedMetadataFields(oldIssue.Metadata, value)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, metaFields...)
+	}
+	sort.Strings(fields)
+	return CheckProtection(oldIssue.ID, p, fields, actor)
+}
+
+// changedMetadataFields reports which protectable fields a metadata write
+// touches: "protection" when the protection record changes, and "metadata"
+// when any other key does.
+func changedMetadataFields(oldMeta json.RawMessage, value interface{}) ([]string, error) {
+	normalized, err := storage.NormalizeMetadataValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+	before, after := map[string]json.RawMessage{}, map[string]json.RawMessage{}
+	if len(oldMeta) > 0 {
+		_ = json.Unmarshal(oldMeta, &before)
+	}
+	if normalized != "" {
+		_ = json.Unmarshal([]byte(normalized), &after)
+	}
+	var fields []string
+	other := false
+	for _, key := range unionKeys(before, after) {
+		if jsonEqual(before[key], after[key]) {
+			continue
+		}
+		if key == types.ProtectionMetadataKey {
+			fields = append(fields, types.ProtectionMetadataKey)
+		} else {
+			other = true
+		}
+	}
+	if other {
+		fields = append(fields, types.ProtectedMetadata)
+	}
+	return fields, nil
+}
+
+func unionKeys(a, b map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return bytes.Equal(ja, jb)
+}
+
+// checkStatusProtection guards paths that change status without going through
+// updateIssueInTx (close, reopen, claim).
+func checkStatusProtection(ctx context.Context, tx DBTX, id, actor string, fields ...string) error {
+	p, err := readProtectionInTx(ctx, tx, id)
+	if err != nil || p == nil {
+		return err
+	}
+	return CheckProtection(id, p, fields, actor)
+}
+
+// checkDeleteProtection refuses to delete a protected issue. Deletes carry no
+// actor, so a protection has to be removed (by an allowed actor) first.
+func checkDeleteProtection(ctx context.Context, tx DBTX, id string) error {
+	p, err := readProtectionInTx(ctx, tx, id)
+	if err != nil || p == nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s is protected and cannot be deleted; remove the protection first (bd unprotect %s)", storage.ErrProtected, id, id)
+}
+
+// readProtectionInTx returns an issue's protection, or nil when it has none,
+// when the issue does not exist (the caller reports that), or when the record
+// is unreadable.
+func readProtectionInTx(ctx context.Context, tx DBTX, id string) (*types.Protection, error) {
+	m, err := readMetadataMapInTx(ctx, tx, id)
+	if err != nil {
+		return nil, nil //nolint:nilerr // missing issues are reported by the caller's own query
+	}
+	p, err := types.ProtectionFromRaw(m[types.ProtectionMetadataKey])
+	if err != nil {
+		return nil, nil //nolint:nilerr // see checkUpdateProtection
+	}
+	return p, nil
+}
@@ -0,0 +1,43 @@
+package issueops
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckUpdateProtection(t *testing.T) {
+	protected := &types.Issue{
+		ID:       "bd-1",
+		Metadata: json.RawMessage(`{"protection":{"actors":["alice"],"fields":["title","metadata"]},"team":"core"}`),
+	}
+
+	tests := []struct {
+		name    string
+		issue   *types.Issue
+		updates map[string]interface{}
+		actor   string
+		denied  bool
+	}{
+		{"unprotected issue", &types.Issue{ID: "bd-2"}, map[string]interface{}{"title": "x"}, "bob", false},
+		{"protect a fresh issue", &types.Issue{ID: "bd-2"}, map[string]interface{}{"metadata": `{"protection":{"actors":["bob"]}}`}, "bob", false},
+		{"allowed actor", protected, map[string]interface{}{"title": "x"}, "alice", false},
+		{"unprotected field", protected, map[string]interface{}{"priority": 2}, "bob", false},
+		{"protected field", protected, map[string]interface{}{"title": "x"}, "bob", true},
+		{"unchanged metadata", protected, map[string]interface{}{"metadata": `{"team":"core","protection":{"actors":["alice"],"fields":["title","metadata"]}}`}, "bob", false},
+		{"other metadata key", protected, map[string]interface{}{"metadata": `{"team":"infra","protection":{"actors":["alice"],"fields":["title","metadata"]}}`}, "bob", true},
+		{"remove protection", protected, map[string]interface{}{"metadata": `{"team":"core"}`}, "bob", true},
+	}
+	for _, tt := range tests {
+		err := checkUpdateProtection(tt.issue, tt.updates, tt.actor)
+		if tt.denied != (err != nil) {
+			t.Errorf("%s: err = %v, want denied=%v", tt.name, err, tt.denied)
+		}
+		if err != nil && !errors.Is(err, storage.ErrProtected) {
+			t.Errorf("%s: err = %v, want storage.ErrProtected", tt.name, err)
+		}
+	}
+}
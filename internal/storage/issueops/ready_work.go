@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlbuild"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -36,15 +37,15 @@ func readyWorkPageSize(limit int) int {
 	return limit
 }
 
-func buildReadyWorkOrder(policy types.SortPolicy) sqlbuild.ReadyWorkOrder {
-	return sqlbuild.BuildReadyWorkOrder(policy, "created_at", "priority")
+func buildReadyWorkOrder(ctx context.Context, policy types.SortPolicy) sqlbuild.ReadyWorkOrder {
+	return sqlbuild.BuildReadyWorkOrder(policy, "created_at", "priority", storage.Now(ctx))
 }
 
 // buildReadyWorkPredicates computes the ID sets the ready-work WHERE clause
 // needs (children of deferred parents, parent descendants), then delegates
 // the clause text to sqlbuild so both stacks share ready semantics.
 func buildReadyWorkPredicates(ctx context.Context, tx DBTX, filter types.WorkFilter, tables FilterTables) (*readyWorkPredicates, error) {
-	var inputs sqlbuild.ReadyWorkWhereInputs
+	inputs := sqlbuild.ReadyWorkWhereInputs{Now: storage.Now(ctx)}
 	if !filter.IncludeDeferred {
 		deferredChildIDs, dcErr := getChildrenOfDeferredParentsInTx(ctx, tx)
 		if dcErr != nil {
@@ -69,7 +70,7 @@ func buildReadyWorkPredicates(ctx context.Context, tx DBTX, filter types.WorkFil
 		return nil, err
 	}
 
-	orderBy := buildReadyWorkOrder(filter.SortPolicy)
+	orderBy := buildReadyWorkOrder(ctx, filter.SortPolicy)
 	args := make([]interface{}, 0, len(whereArgs)+len(orderBy.Args))
 	args = append(args, whereArgs...)
 	args = append(args, orderBy.Args...)
@@ -133,13 +134,13 @@ func GetReadyWorkInTx(
 		return nil, wErr
 	}
 	if len(wisps) > 0 {
-		ordered = mergeReadyWisps(ordered, wisps, filter)
+		ordered = mergeReadyWisps(ctx, ordered, wisps, filter)
 	}
 
 	return ordered, nil
 }
 
-func mergeReadyWisps(ordered []*types.Issue, wisps []*types.Issue, filter types.WorkFilter) []*types.Issue {
+func mergeReadyWisps(ctx context.Context, ordered []*types.Issue, wisps []*types.Issue, filter types.WorkFilter) []*types.Issue {
 	// Prefer the canonical wisp record when an ID exists in both tables (be-iabdi).
 	wispByID := make(map[string]*types.Issue, len(wisps))
 	for _, w := range wisps {
@@ -152,7 +153,7 @@ func mergeReadyWisps(ordered []*types.Issue, wisps []*types.Issue, filter types.
 		}
 	}
 	kept = append(kept, wisps...)
-	sortReadyIssues(kept, filter.SortPolicy)
+	sortReadyIssues(ctx, kept, filter.SortPolicy)
 	if filter.Limit > 0 && len(kept) > filter.Limit {
 		kept = kept[:filter.Limit]
 	}
@@ -182,7 +183,7 @@ func getReadyWispsInTx(ctx context.Context, tx DBTX, filter types.WorkFilter, de
 	}
 
 	pageSize := readyWorkPageSize(filter.Limit)
-	orderBy := buildReadyWorkOrder(filter.SortPolicy)
+	orderBy := buildReadyWorkOrder(ctx, filter.SortPolicy)
 	ready := make([]*types.Issue, 0, filter.Limit)
 	for offset := 0; len(ready) < filter.Limit; offset += pageSize {
 		pageIDs, err := queryReadyWispIssueIDPage(ctx, tx, wispFilter, !filter.IncludeDeferred, orderBy, pageSize, offset)
@@ -219,13 +220,14 @@ func getReadyWispsInTx(ctx context.Context, tx DBTX, filter types.WorkFilter, de
 
 func queryReadyWispIssueIDPage(ctx context.Context, tx DBTX, filter types.IssueFilter, excludeDeferred bool, orderBy sqlbuild.ReadyWorkOrder, limit, offset int) ([]string, error) {
 	plan := sqlbuild.BuildLabelDrivenSearch(filter, WispsFilterTables)
-	whereClauses, args, err := BuildIssueFilterClauses("", plan.Filter, WispsFilterTables)
+	whereClauses, args, err := BuildIssueFilterClauses(ctx, "", plan.Filter, WispsFilterTables)
 	if err != nil {
 		return nil, err
 	}
 	whereClauses, args = plan.MergeInto(whereClauses, args)
 	if excludeDeferred {
-		whereClauses = append(whereClauses, "(defer_until IS NULL OR defer_until <= UTC_TIMESTAMP())")
+		whereClauses = append(whereClauses, "(defer_until IS NULL OR defer_until <= ?)")
+		args = append(args, storage.Now(ctx))
 	}
 
 	whereSQL := ""
@@ -294,16 +296,19 @@ func readyWorkExcludeTypes(extra []types.IssueType) []types.IssueType {
 func readyWorkWispIssueFilter(filter types.WorkFilter) types.IssueFilter {
 	pinnedFalse := false
 	wispFilter := types.IssueFilter{
-		Priority:       filter.Priority,
-		Labels:         filter.Labels,
-		LabelsAny:      filter.LabelsAny,
-		ExcludeLabels:  filter.ExcludeLabels,
-		Limit:          filter.Limit,
-		MolType:        filter.MolType,
-		WispType:       filter.WispType,
-		Pinned:         &pinnedFalse,
-		MetadataFields: filter.MetadataFields,
-		HasMetadataKey: filter.HasMetadataKey,
+		Priority:        filter.Priority,
+		Labels:          filter.Labels,
+		LabelsAny:       filter.LabelsAny,
+		ExcludeLabels:   filter.ExcludeLabels,
+		Limit:           filter.Limit,
+		MolType:         filter.MolType,
+		WispType:        filter.WispType,
+		Pinned:          &pinnedFalse,
+		MetadataFields:  filter.MetadataFields,
+		HasMetadataKey:  filter.HasMetadataKey,
+		MinQualityScore: filter.MinQualityScore,
+		MinReopenCount:  filter.MinReopenCount,
+		Rig:             filter.Rig,
 	}
 	if filter.Status != "" {
 		s := filter.Status
@@ -344,6 +349,9 @@ func filterReadyWispsInTx(ctx context.Context, tx DBTX, filter types.WorkFilter,
 	}
 
 	excluded := make(map[string]struct{})
+	for _, id := range filter.ExcludeIDs {
+		excluded[id] = struct{}{}
+	}
 	if filter.ParentID != nil {
 		parentID := *filter.ParentID
 		descendantIDs, err := GetDescendantIDsInTx(ctx, tx, parentID, 0)
@@ -372,7 +380,7 @@ func filterReadyWispsInTx(ctx context.Context, tx DBTX, filter types.WorkFilter,
 	}
 
 	if !filter.IncludeDeferred {
-		now := time.Now().UTC()
+		now := storage.Now(ctx)
 		for _, wisp := range wisps {
 			if wisp.DeferUntil != nil && wisp.DeferUntil.After(now) {
 				excluded[wisp.ID] = struct{}{}
@@ -423,8 +431,8 @@ func filterReadyWispsInTx(ctx context.Context, tx DBTX, filter types.WorkFilter,
 	return ready, nil
 }
 
-func sortReadyIssues(issues []*types.Issue, policy types.SortPolicy) {
-	recentCutoff := time.Now().UTC().Add(-48 * time.Hour)
+func sortReadyIssues(ctx context.Context, issues []*types.Issue, policy types.SortPolicy) {
+	recentCutoff := storage.Now(ctx).Add(-48 * time.Hour)
 	sort.SliceStable(issues, func(i, j int) bool {
 		a, b := issues[i], issues[j]
 		switch policy {
@@ -492,6 +500,7 @@ func queryReadyIssueIDPage(ctx context.Context, tx DBTX, query string, args []in
 //
 //nolint:gosec // G201: depTable is selected from a hardcoded list below.
 func getChildrenOfDeferredParentsInTx(ctx context.Context, tx DBTX) ([]string, error) {
+	now := storage.Now(ctx)
 	hasDeferredParent := false
 	for _, issueTable := range []string{"issues", "wisps"} {
 		//nolint:gosec // G201: issueTable is hardcoded to "issues" or "wisps"
@@ -499,9 +508,9 @@ func getChildrenOfDeferredParentsInTx(ctx context.Context, tx DBTX) ([]string, e
 		err := tx.QueryRowContext(ctx, fmt.Sprintf(`
 			SELECT 1 FROM %s
 			WHERE defer_until IS NOT NULL
-			  AND defer_until > UTC_TIMESTAMP()
+			  AND defer_until > ?
 			LIMIT 1
-		`, issueTable)).Scan(&exists)
+		`, issueTable), now).Scan(&exists)
 		if err == nil {
 			hasDeferredParent = true
 			break
@@ -531,8 +540,8 @@ func getChildrenOfDeferredParentsInTx(ctx context.Context, tx DBTX) ([]string, e
 				JOIN %s parent ON parent.id = dep.%s
 				WHERE dep.type = 'parent-child'
 				  AND parent.defer_until IS NOT NULL
-				  AND parent.defer_until > UTC_TIMESTAMP()
-			`, depTable, issueTable, targetCol))
+				  AND parent.defer_until > ?
+			`, depTable, issueTable, targetCol), now)
 			if err != nil {
 				if depTable == "wisp_dependencies" && isTableNotExistError(err) {
 					break
@@ -11,7 +11,7 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
-func GetReadyWorkWithCountsInTx(ctx context.Context, tx *sql.Tx, filter types.WorkFilter) ([]*types.IssueWithCounts, error) {
+func GetReadyWorkWithCountsInTx(ctx context.Context, tx DBTX, filter types.WorkFilter) ([]*types.IssueWithCounts, error) {
 	wispDepsExist, err := optionalTableExistsInTx(ctx, tx, "wisp_dependencies")
 	if err != nil {
 		return nil, fmt.Errorf("get ready work with counts: wisp dependency probe: %w", err)
@@ -70,7 +70,7 @@ func GetReadyWorkWithCountsInTx(ctx context.Context, tx *sql.Tx, filter types.Wo
 		}
 	}
 	kept = append(kept, wisps...)
-	sortIssuesWithCountsByPolicy(kept, filter.SortPolicy)
+	sortIssuesWithCountsByPolicy(ctx, kept, filter.SortPolicy)
 	if filter.Limit > 0 && len(kept) > filter.Limit {
 		kept = kept[:filter.Limit]
 	}
@@ -101,7 +101,7 @@ func GetReadyWorkWithCountsInTx(ctx context.Context, tx *sql.Tx, filter types.Wo
 // predicate-form mega-query unchanged.
 //
 //nolint:gosec // G201: whereSQL/orderBySQL/limitSQL are hardcoded fragments; user input rides ? placeholders.
-func runReadyCountsInTx(ctx context.Context, tx *sql.Tx, tables FilterTables, limit int, preds *readyWorkPredicates, includeWispReverseDeps, skipLabels bool) ([]*types.IssueWithCounts, error) {
+func runReadyCountsInTx(ctx context.Context, tx DBTX, tables FilterTables, limit int, preds *readyWorkPredicates, includeWispReverseDeps, skipLabels bool) ([]*types.IssueWithCounts, error) {
 	if limit <= 0 {
 		return runSearchQueryInTx(ctx, tx, tables, preds.whereSQL, preds.orderBySQL, preds.limitSQL, preds.args, includeWispReverseDeps, skipLabels)
 	}
@@ -155,7 +155,7 @@ func runReadyCountsInTx(ctx context.Context, tx *sql.Tx, tables FilterTables, li
 // re-runs the mega-query, so a single wisp no longer disables the fast path.
 // This backs the "Showing X of N" total `bd ready` prints when the page is
 // capped.
-func CountReadyWorkInTx(ctx context.Context, tx *sql.Tx, filter types.WorkFilter) (int, error) {
+func CountReadyWorkInTx(ctx context.Context, tx DBTX, filter types.WorkFilter) (int, error) {
 	countFilter := filter
 	countFilter.Limit = 0
 
@@ -210,7 +210,7 @@ func CountReadyWorkInTx(ctx context.Context, tx *sql.Tx, filter types.WorkFilter
 // only its placeholders (no ORDER BY params).
 //
 //nolint:gosec // G201: whereSQL is hardcoded fragments; user input rides ? placeholders.
-func countReadyPredicateInTx(ctx context.Context, tx *sql.Tx, table, whereSQL string, whereArgs []interface{}) (int, error) {
+func countReadyPredicateInTx(ctx context.Context, tx DBTX, table, whereSQL string, whereArgs []interface{}) (int, error) {
 	var n int
 	if err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s %s", table, whereSQL), whereArgs...).Scan(&n); err != nil {
 		return 0, err
@@ -223,7 +223,7 @@ func countReadyPredicateInTx(ctx context.Context, tx *sql.Tx, table, whereSQL st
 // the issue row for such an ID, so |ready| = issueCount + wispCount - overlap.
 //
 //nolint:gosec // G201: whereSQL fragments are hardcoded; user input rides ? placeholders.
-func countReadyOverlapInTx(ctx context.Context, tx *sql.Tx, issuePreds, wispPreds *readyWorkPredicates) (int, error) {
+func countReadyOverlapInTx(ctx context.Context, tx DBTX, issuePreds, wispPreds *readyWorkPredicates) (int, error) {
 	q := fmt.Sprintf("SELECT COUNT(*) FROM issues %s AND id IN (SELECT id FROM wisps %s)", issuePreds.whereSQL, wispPreds.whereSQL)
 	args := make([]interface{}, 0, len(issuePreds.whereArgs)+len(wispPreds.whereArgs))
 	args = append(args, issuePreds.whereArgs...)
@@ -235,7 +235,7 @@ func countReadyOverlapInTx(ctx context.Context, tx *sql.Tx, issuePreds, wispPred
 	return n, nil
 }
 
-func sortIssuesWithCountsByPolicy(items []*types.IssueWithCounts, policy types.SortPolicy) {
+func sortIssuesWithCountsByPolicy(ctx context.Context, items []*types.IssueWithCounts, policy types.SortPolicy) {
 	if len(items) <= 1 {
 		return
 	}
@@ -249,7 +249,7 @@ func sortIssuesWithCountsByPolicy(items []*types.IssueWithCounts, policy types.S
 	if len(issues) != len(items) {
 		return
 	}
-	sortReadyIssues(issues, policy)
+	sortReadyIssues(ctx, issues, policy)
 	byID := make(map[string]int, len(issues))
 	for i, iss := range issues {
 		byID[iss.ID] = i
@@ -3,9 +3,12 @@ package issueops
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
-	"time"
+	"maps"
 
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -16,6 +19,9 @@ type ReopenResult struct {
 
 //nolint:gosec // G201: table names come from WispTableRouting (hardcoded constants)
 func ReopenIssueInTx(ctx context.Context, tx DBTX, id, reason, actor string) (*ReopenResult, error) {
+	if err := checkStatusProtection(ctx, tx, id, actor, types.ProtectedStatus); err != nil {
+		return nil, err
+	}
 	isWisp := IsActiveWispInTx(ctx, tx, id)
 	issueTable, _, eventTable, _ := WispTableRouting(isWisp)
 
@@ -30,12 +36,35 @@ func ReopenIssueInTx(ctx context.Context, tx DBTX, id, reason, actor string) (*R
 		return nil, fmt.Errorf("affected by reopen for %s: %w", id, aerr)
 	}
 
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
+
+	// Log the reopen in metadata in the same statement, against the row as
+	// this transaction sees it. A row that is not closed is left alone by
+	// the status guard below, so its metadata is never touched.
+	var (
+		prevStatus, prevCloseReason string
+		prevMetadata                sql.NullString
+	)
+	err := tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT status, close_reason, metadata FROM %s WHERE id = ?`, issueTable), id).
+		Scan(&prevStatus, &prevCloseReason, &prevMetadata)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to read issue for reopen: %w", err)
+	}
+	metadata := prevMetadata
+	if types.Status(prevStatus) == types.StatusClosed {
+		note := storage.ReopenNoteFrom(ctx)
+		note.Reason = reason
+		tracked, err := reopenTrackedMetadata(ctx, id, json.RawMessage(prevMetadata.String), prevCloseReason, note, actor)
+		if err != nil {
+			return nil, err
+		}
+		metadata = sql.NullString{String: string(tracked), Valid: true}
+	}
 
 	result, err := tx.ExecContext(ctx, fmt.Sprintf(`
-		UPDATE %s SET status = ?, closed_at = NULL, close_reason = '', closed_by_session = '', defer_until = NULL, updated_at = ?
+		UPDATE %s SET status = ?, closed_at = NULL, close_reason = '', closed_by_session = '', defer_until = NULL, metadata = ?, updated_at = ?
 		WHERE id = ? AND status = ?
-	`, issueTable), types.StatusOpen, now, id, types.StatusClosed)
+	`, issueTable), types.StatusOpen, metadata, now, id, types.StatusClosed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to reopen issue: %w", err)
 	}
@@ -77,3 +106,73 @@ func ReopenIssueInTx(ctx context.Context, tx DBTX, id, reason, actor string) (*R
 
 	return &ReopenResult{IsWisp: isWisp}, nil
 }
+
+// ApplyReopenTracking returns updates extended with the reopen log when
+// they move oldIssue out of closed: the reopen (with the note attached to
+// ctx, see storage.WithReopenNote) is appended to metadata.reopens and the
+// new total stored in metadata.reopen_count. Past
+// reopen.root-cause-threshold a reopen without a root cause fails with
+// storage.ErrRootCauseRequired. Any other update is returned unchanged.
+func ApplyReopenTracking(ctx context.Context, oldIssue *types.Issue, updates map[string]interface{}, actor string) (map[string]interface{}, error) {
+	rawStatus, ok := updates["status"]
+	if !ok || oldIssue.Status != types.StatusClosed {
+		return updates, nil
+	}
+	switch v := rawStatus.(type) {
+	case string:
+		if v == string(types.StatusClosed) {
+			return updates, nil
+		}
+	case types.Status:
+		if v == types.StatusClosed {
+			return updates, nil
+		}
+	}
+	base := oldIssue.Metadata
+	if v, ok := updates["metadata"]; ok {
+		s, err := storage.NormalizeMetadataValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata: %w", err)
+		}
+		base = json.RawMessage(s)
+	}
+	tracked, err := reopenTrackedMetadata(ctx, oldIssue.ID, base, oldIssue.CloseReason, storage.ReopenNoteFrom(ctx), actor)
+	if err != nil {
+		return nil, err
+	}
+	out := maps.Clone(updates)
+	out["metadata"] = string(tracked)
+	return out, nil
+}
+
+// reopenTrackedMetadata returns metadata with one more reopen logged.
+// prevCloseReason is the close reason the reopen discards.
+func reopenTrackedMetadata(ctx context.Context, id string, metadata json.RawMessage, prevCloseReason string, note storage.ReopenNote, actor string) (json.RawMessage, error) {
+	reopens, err := types.ReopensFromMetadata(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("reopen log of %s: %w", id, err)
+	}
+	if threshold := config.GetInt("reopen.root-cause-threshold"); threshold > 0 && len(reopens) >= threshold && note.RootCause == "" {
+		return nil, fmt.Errorf("%w: %s has been reopened %d time(s) (threshold %d)", storage.ErrRootCauseRequired, id, len(reopens), threshold)
+	}
+	reopens = append(reopens, types.Reopen{
+		At:              storage.Now(ctx),
+		By:              actor,
+		Reason:          note.Reason,
+		RootCause:       note.RootCause,
+		PrevCloseReason: prevCloseReason,
+	})
+	m := map[string]json.RawMessage{}
+	if len(metadata) > 0 && string(metadata) != "null" {
+		if err := json.Unmarshal(metadata, &m); err != nil {
+			return nil, fmt.Errorf("parsing metadata of %s: %w", id, err)
+		}
+	}
+	log, err := json.Marshal(reopens)
+	if err != nil {
+		return nil, err
+	}
+	m[types.ReopensMetadataKey] = log
+	m[types.ReopenCountMetadataKey] = json.RawMessage(fmt.Sprint(len(reopens)))
+	return json.Marshal(m)
+}
@@ -0,0 +1,137 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const runColumns = "id, issue_id, name, external_id, status, url, duration_ms, artifacts, started_at, finished_at, created_by, created_at, updated_at"
+
+// runRecency orders an issue's runs most recent first: by start time when
+// reported, else by when the run was first recorded.
+const runRecency = "COALESCE(started_at, created_at) DESC, id ASC"
+
+// RecordRunInTx inserts a run or updates the run with the same ID in place,
+// then mirrors the status of the issue's most recent run into
+// metadata.last_run_status. created_at is preserved on update; both
+// timestamps are supplied here rather than defaulted so every clone writes
+// identical rows. The issue must exist.
+func RecordRunInTx(ctx context.Context, tx *sql.Tx, run *types.Run, actor string) error {
+	if run.ID == "" {
+		return fmt.Errorf("run ID is required")
+	}
+	if run.IssueID == "" {
+		return fmt.Errorf("run %s has no issue", run.ID)
+	}
+	m, err := readMetadataMapInTx(ctx, tx, run.IssueID)
+	if err != nil {
+		return err
+	}
+	artifacts, err := json.Marshal(run.Artifacts)
+	if err != nil {
+		return fmt.Errorf("marshal run artifacts: %w", err)
+	}
+	now := storage.Now(ctx).Truncate(time.Second)
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = now
+	}
+	run.UpdatedAt = now
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO runs (`+runColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			status = VALUES(status),
+			url = VALUES(url),
+			duration_ms = VALUES(duration_ms),
+			artifacts = VALUES(artifacts),
+			started_at = VALUES(started_at),
+			finished_at = VALUES(finished_at),
+			updated_at = VALUES(updated_at)
+	`, run.ID, run.IssueID, run.Name, run.ExternalID, run.Status, run.URL, run.DurationMS,
+		string(artifacts), run.StartedAt, run.FinishedAt, run.CreatedBy, run.CreatedAt, run.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("record run %s: %w", run.ID, err)
+	}
+
+	var latest string
+	err = tx.QueryRowContext(ctx,
+		"SELECT status FROM runs WHERE issue_id = ? ORDER BY "+runRecency+" LIMIT 1", run.IssueID,
+	).Scan(&latest)
+	if err != nil {
+		return fmt.Errorf("read last run of %s: %w", run.IssueID, err)
+	}
+	value, _ := json.Marshal(latest)
+	if string(m[types.LastRunStatusMetadataKey]) == string(value) {
+		return nil
+	}
+	m[types.LastRunStatusMetadataKey] = value
+	return writeMergedMetadataInTx(ctx, tx, run.IssueID, m, actor)
+}
+
+// ListRunsInTx returns an issue's runs, most recent first. limit <= 0 means
+// no limit.
+func ListRunsInTx(ctx context.Context, tx *sql.Tx, issueID string, limit int) ([]*types.Run, error) {
+	query := "SELECT " + runColumns + " FROM runs WHERE issue_id = ? ORDER BY " + runRecency
+	args := []any{issueID}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list runs for %s: %w", issueID, err)
+	}
+	defer rows.Close()
+
+	var result []*types.Run
+	for rows.Next() {
+		run, err := scanRun(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("list runs for %s: scan: %w", issueID, err)
+		}
+		result = append(result, run)
+	}
+	return result, rows.Err()
+}
+
+func scanRun(scan func(dest ...any) error) (*types.Run, error) {
+	var (
+		run        types.Run
+		name       sql.NullString
+		externalID sql.NullString
+		url        sql.NullString
+		artifacts  sql.NullString
+		startedAt  sql.NullTime
+		finishedAt sql.NullTime
+		createdBy  sql.NullString
+	)
+	if err := scan(&run.ID, &run.IssueID, &name, &externalID, &run.Status, &url, &run.DurationMS,
+		&artifacts, &startedAt, &finishedAt, &createdBy, &run.CreatedAt, &run.UpdatedAt); err != nil {
+		return nil, err
+	}
+	run.Name = name.String
+	run.ExternalID = externalID.String
+	run.URL = url.String
+	run.CreatedBy = createdBy.String
+	if startedAt.Valid {
+		t := startedAt.Time
+		run.StartedAt = &t
+	}
+	if finishedAt.Valid {
+		t := finishedAt.Time
+		run.FinishedAt = &t
+	}
+	if artifacts.Valid && artifacts.String != "" {
+		if err := json.Unmarshal([]byte(artifacts.String), &run.Artifacts); err != nil {
+			return nil, fmt.Errorf("parse artifacts for %s: %w", run.ID, err)
+		}
+	}
+	return &run, nil
+}
@@ -35,10 +35,10 @@ func ScanIssueFrom(s IssueScanner, extra ...any) (*types.Issue, error) {
 	var createdBy sql.NullString
 	var assignee, externalRef, specID, compactedAtCommit, owner sql.NullString
 	var contentHash, sourceRepo, closeReason sql.NullString
-	var workType, sourceSystem sql.NullString
+	var workType, sourceSystem, sourceFormula, sourceLocation, rig sql.NullString
 	var sender, wispType, molType, eventKind, actor, target, payload sql.NullString
 	var awaitType, awaitID, waiters sql.NullString
-	var ephemeral, noHistory, pinned, isTemplate sql.NullInt64
+	var ephemeral, noHistory, pinned, isTemplate, encrypted sql.NullInt64
 	var metadata sql.NullString
 	var rowLock sql.NullInt64 // row_lock column (NOT NULL DEFAULT 0); scanned defensively so NULL maps to 0
 
@@ -53,7 +53,7 @@ func ScanIssueFrom(s IssueScanner, extra ...any) (*types.Issue, error) {
 		&molType,
 		&eventKind, &actor, &target, &payload,
 		&dueAt, &deferUntil,
-		&workType, &sourceSystem, &metadata, &rowLock,
+		&workType, &sourceSystem, &sourceFormula, &sourceLocation, &rig, &encrypted, &metadata, &rowLock,
 		&leaseExpiresAt, &heartbeatAt,
 	}
 	dests = append(dests, extra...)
@@ -170,6 +170,18 @@ func ScanIssueFrom(s IssueScanner, extra ...any) (*types.Issue, error) {
 	if sourceSystem.Valid {
 		issue.SourceSystem = sourceSystem.String
 	}
+	if sourceFormula.Valid {
+		issue.SourceFormula = sourceFormula.String
+	}
+	if sourceLocation.Valid {
+		issue.SourceLocation = sourceLocation.String
+	}
+	if rig.Valid {
+		issue.Rig = rig.String
+	}
+	if encrypted.Valid && encrypted.Int64 != 0 {
+		issue.Encrypted = true
+	}
 	// Custom metadata field (GH#1406)
 	if metadata.Valid && metadata.String != "" && metadata.String != "{}" {
 		issue.Metadata = []byte(metadata.String)
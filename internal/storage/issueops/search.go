@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/beads/internal/storage/sqlbuild"
@@ -14,8 +15,27 @@ import (
 // transaction and returns hydrated issues (labels, and optionally
 // dependencies via filter.IncludeDependencies). Routing, wisp-merge, and
 // overlap detection live in the shared searchInTx wrapper.
+//
+// With filter.Cursor set it returns one page of at most filter.Limit issues
+// in (created_at DESC, id ASC) order; see types.IssueFilter.Cursor.
 func SearchIssuesInTx(ctx context.Context, tx DBTX, query string, filter types.IssueFilter) ([]*types.Issue, error) {
-	return searchInTx(ctx, tx, query, filter, issueProjection)
+	filter, err := resolveCursor(filter)
+	if err != nil {
+		return nil, err
+	}
+	results, err := searchInTx(ctx, tx, query, filter, issueProjection)
+	if err != nil || filter.Cursor == "" {
+		return results, err
+	}
+	// Issues and wisps are each limited on their own; merge them in page
+	// order and cut, so the next page's cursor skips nothing.
+	sort.SliceStable(results, func(i, j int) bool {
+		return sqlbuild.Less(results[i], results[j], filter.SortBy)
+	})
+	if filter.Limit > 0 && len(results) > filter.Limit {
+		results = results[:filter.Limit]
+	}
+	return results, nil
 }
 
 // SearchIssueIDsInTx is the narrow-projection variant of SearchIssuesInTx:
@@ -23,6 +43,10 @@ func SearchIssuesInTx(ctx context.Context, tx DBTX, query string, filter types.I
 // projects only `id` and returns []string. Use when full row hydration is
 // wasted (e.g., partial-ID resolution in internal/utils/id_parser.go).
 func SearchIssueIDsInTx(ctx context.Context, tx DBTX, query string, filter types.IssueFilter) ([]string, error) {
+	if filter.Cursor != "" {
+		// Paging merges issues and wisps by created_at, which ids alone lack.
+		return nil, fmt.Errorf("search issue ids: cursor pagination is not supported")
+	}
 	return searchInTx(ctx, tx, query, filter, idProjection)
 }
 
@@ -62,7 +86,7 @@ var issueProjection = searchProjection[*types.Issue]{
 }
 
 var idProjection = searchProjection[string]{
-	columns: func(tables FilterTables) string { return tables.Main + ".id" },
+	columns: func(tables FilterTables) string { return tables.Ref() + ".id" },
 	scan: func(rows *sql.Rows) (string, error) {
 		var id string
 		err := rows.Scan(&id)
@@ -113,6 +137,21 @@ func hydrateIssueLabelsAndDeps(ctx context.Context, tx DBTX, tables FilterTables
 // here once. Both SearchIssuesInTx and SearchIssueIDsInTx use this body —
 // future projections pick up improvements (e.g., the empty-probe) for free.
 func searchInTx[T any](ctx context.Context, tx DBTX, query string, filter types.IssueFilter, proj searchProjection[T]) ([]T, error) {
+	if filter.AsOf != "" {
+		tables, err := asOfFilterTables(filter.AsOf)
+		if err != nil {
+			return nil, err
+		}
+		results, err := searchTableInTxT(ctx, tx, query, filter, tables, proj)
+		if isTableNotExistError(err) {
+			return nil, nil // the ref predates the issues table
+		}
+		if err != nil {
+			return nil, fmt.Errorf("search issues as of %s: %w", filter.AsOf, err)
+		}
+		return results, nil
+	}
+
 	// Route ephemeral-only queries to wisps table.
 	if filter.Ephemeral != nil && *filter.Ephemeral {
 		results, err := searchTableInTxT(ctx, tx, query, filter, WispsFilterTables, proj)
@@ -175,6 +214,16 @@ func searchInTx[T any](ctx context.Context, tx DBTX, query string, filter types.
 	return results, nil
 }
 
+// asOfFilterTables returns the issues tables read at ref (see AsOfClause).
+// Only issues have history: wisps are dolt_ignore'd.
+func asOfFilterTables(ref string) (FilterTables, error) {
+	asOf, err := AsOfClause(ref)
+	if err != nil {
+		return FilterTables{}, fmt.Errorf("invalid ref: %w", err)
+	}
+	return IssuesFilterTables.WithAsOf(asOf), nil
+}
+
 // searchTableInTxT runs a filtered search against a specific table set
 // (issues or wisps) under the given projection.
 //
@@ -195,7 +244,7 @@ func searchTableInTxT[T any](ctx context.Context, tx DBTX, query string, filter
 	}
 
 	plan := sqlbuild.BuildLabelDrivenSearch(filter, tables)
-	whereClauses, args, err := BuildIssueFilterClauses(query, plan.Filter, tables)
+	whereClauses, args, err := BuildIssueFilterClauses(ctx, query, plan.Filter, tables)
 	if err != nil {
 		return nil, err
 	}
@@ -217,12 +266,12 @@ func searchTableInTxT[T any](ctx context.Context, tx DBTX, query string, filter
 	}
 	fromSQL := plan.FromSQL
 	if proj.joinLeases {
-		fromSQL += " " + sqlbuild.LeaseJoin(tables.Main)
+		fromSQL += " " + sqlbuild.LeaseJoin(tables.Ref())
 	}
 
 	//nolint:gosec // G201: SQL fragments are built from fixed table/column names and parameterized filters.
 	querySQL := fmt.Sprintf(`%s%s FROM %s %s %s %s`,
-		selectKeyword, proj.columns(tables), fromSQL, whereSQL, sqlbuild.OrderBy(filter.SortBy, filter.SortDesc, ""), limitSQL)
+		selectKeyword, proj.columns(tables), fromSQL, whereSQL, sqlbuild.OrderBy(filter.SortBy, ""), limitSQL)
 
 	rows, err := tx.QueryContext(ctx, querySQL, args...)
 	if err != nil {
@@ -283,7 +332,7 @@ func searchTablePatternBT[T any](ctx context.Context, tx DBTX, query string, fil
 	}
 	fetchFrom := tables.Main
 	if proj.joinLeases {
-		fetchFrom += " " + sqlbuild.LeaseJoin(tables.Main)
+		fetchFrom += " " + sqlbuild.LeaseJoin(tables.Ref())
 	}
 	//nolint:gosec // G201: column expression and table name are fixed; ids are parameterized.
 	fetchSQL := fmt.Sprintf(`SELECT %s FROM %s WHERE id IN (%s)`,
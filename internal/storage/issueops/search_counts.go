@@ -2,7 +2,6 @@ package issueops
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"sort"
 
@@ -10,7 +9,26 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
-func SearchIssuesWithCountsInTx(ctx context.Context, tx *sql.Tx, query string, filter types.IssueFilter) ([]*types.IssueWithCounts, error) {
+func SearchIssuesWithCountsInTx(ctx context.Context, tx DBTX, query string, filter types.IssueFilter) ([]*types.IssueWithCounts, error) {
+	filter, err := resolveCursor(filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.AsOf != "" {
+		tables, err := asOfFilterTables(filter.AsOf)
+		if err != nil {
+			return nil, err
+		}
+		out, err := runFilterSearchQueryInTx(ctx, tx, query, filter, tables, false)
+		if isTableNotExistError(err) {
+			return nil, nil // the ref predates the issues table
+		}
+		if err != nil {
+			return nil, fmt.Errorf("search issues with counts as of %s: %w", filter.AsOf, err)
+		}
+		return finishSearchIssuesWithCounts(out, filter), nil
+	}
+
 	wispDepsExist, err := optionalTableExistsInTx(ctx, tx, "wisp_dependencies")
 	if err != nil {
 		return nil, fmt.Errorf("search issues with counts: wisp dependency probe: %w", err)
@@ -96,8 +114,8 @@ func SearchIssuesWithCountsInTx(ctx context.Context, tx *sql.Tx, query string, f
 	return finishSearchIssuesWithCounts(kept, filter), nil
 }
 
-func runFilterSearchQueryInTx(ctx context.Context, tx *sql.Tx, query string, filter types.IssueFilter, tables FilterTables, includeWispReverseDeps bool) ([]*types.IssueWithCounts, error) {
-	whereClauses, args, err := BuildIssueFilterClauses(query, filter, tables)
+func runFilterSearchQueryInTx(ctx context.Context, tx DBTX, query string, filter types.IssueFilter, tables FilterTables, includeWispReverseDeps bool) ([]*types.IssueWithCounts, error) {
+	whereClauses, args, err := BuildIssueFilterClauses(ctx, query, filter, tables)
 	if err != nil {
 		return nil, err
 	}
@@ -109,12 +127,12 @@ func runFilterSearchQueryInTx(ctx context.Context, tx *sql.Tx, query string, fil
 	if filter.Limit > 0 {
 		limitSQL = fmt.Sprintf("LIMIT %d", filter.Limit)
 	}
-	orderBy := sqlbuild.OrderBy(filter.SortBy, filter.SortDesc, "i")
+	orderBy := sqlbuild.OrderBy(filter.SortBy, "i")
 	return runSearchQueryInTx(ctx, tx, tables, whereSQL, orderBy, limitSQL, args, includeWispReverseDeps, filter.SkipLabels)
 }
 
 //nolint:gosec // G201: SQL fragments are caller-built from hardcoded shapes
-func runSearchQueryInTx(ctx context.Context, tx *sql.Tx, tables FilterTables, whereSQL, orderBySQL, limitSQL string, args []interface{}, includeWispReverseDeps bool, skipLabels bool) ([]*types.IssueWithCounts, error) {
+func runSearchQueryInTx(ctx context.Context, tx DBTX, tables FilterTables, whereSQL, orderBySQL, limitSQL string, args []interface{}, includeWispReverseDeps bool, skipLabels bool) ([]*types.IssueWithCounts, error) {
 	searchSQL, _ := sqlbuild.SearchCountsSQL(tables, nil, whereSQL, orderBySQL, limitSQL, includeWispReverseDeps, skipLabels)
 	return scanCountsRowsInTx(ctx, tx, tables.Main, searchSQL, args)
 }
@@ -125,7 +143,7 @@ func runSearchQueryInTx(ctx context.Context, tx *sql.Tx, tables FilterTables, wh
 // ready-counts path.
 //
 //nolint:gosec // G201: query is builder-produced; user input rides ? placeholders.
-func scanCountsRowsInTx(ctx context.Context, tx *sql.Tx, mainTable, query string, args []interface{}) ([]*types.IssueWithCounts, error) {
+func scanCountsRowsInTx(ctx context.Context, tx DBTX, mainTable, query string, args []interface{}) ([]*types.IssueWithCounts, error) {
 	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("search count %s: %w", mainTable, err)
@@ -155,7 +173,7 @@ func scanCountsRowsInTx(ctx context.Context, tx *sql.Tx, mainTable, query string
 }
 
 func finishSearchIssuesWithCounts(items []*types.IssueWithCounts, filter types.IssueFilter) []*types.IssueWithCounts {
-	sortSearchIssuesWithCounts(items, filter.SortBy, filter.SortDesc)
+	sortSearchIssuesWithCounts(items, filter.SortBy)
 	if filter.Limit > 0 && len(items) > filter.Limit {
 		return items[:filter.Limit]
 	}
@@ -165,7 +183,7 @@ func finishSearchIssuesWithCounts(items []*types.IssueWithCounts, filter types.I
 // sortSearchIssuesWithCounts must order the merged issues+wisps rows the same
 // way sqlbuild.OrderBy orders each per-table query; otherwise the limit cut in
 // finishSearchIssuesWithCounts keeps a different row set than SQL selected.
-func sortSearchIssuesWithCounts(items []*types.IssueWithCounts, sortBy string, sortDesc bool) {
+func sortSearchIssuesWithCounts(items []*types.IssueWithCounts, keys []types.SortKey) {
 	if len(items) <= 1 {
 		return
 	}
@@ -177,7 +195,7 @@ func sortSearchIssuesWithCounts(items []*types.IssueWithCounts, sortBy string, s
 		if b == nil || b.Issue == nil {
 			return true
 		}
-		return sqlbuild.Less(a.Issue, b.Issue, sortBy, sortDesc)
+		return sqlbuild.Less(a.Issue, b.Issue, keys)
 	})
 }
 
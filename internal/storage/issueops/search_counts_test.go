@@ -59,7 +59,7 @@ func TestSearchIssuesWithCountsHonorsSkipWisps(t *testing.T) {
 	}
 }
 
-// Regression for bd-6dnrw.43: SortBy/SortDesc must reach the per-table SQL
+// Regression for bd-6dnrw.43: SortBy must reach the per-table SQL
 // ORDER BY; otherwise LIMIT is applied under priority order and the wrong
 // row SET survives (not just the wrong order).
 func TestSearchIssuesWithCountsPushesSortIntoSQL(t *testing.T) {
@@ -75,7 +75,7 @@ func TestSearchIssuesWithCountsPushesSortIntoSQL(t *testing.T) {
 	mock.ExpectQuery(`(?s)FROM wisps i.*ORDER BY i\.created_at DESC, i\.id ASC\s+LIMIT 2`).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
-	filter := types.IssueFilter{SortBy: "created", Limit: 2}
+	filter := types.IssueFilter{SortBy: []types.SortKey{{Field: "created", Desc: true}}, Limit: 2}
 	if _, err := SearchIssuesWithCountsInTx(context.Background(), tx, "", filter); err != nil {
 		t.Fatalf("SearchIssuesWithCountsInTx: %v", err)
 	}
@@ -104,7 +104,7 @@ func TestFinishSearchIssuesWithCountsTruncatesByRequestedSort(t *testing.T) {
 		iwc("bd-newest-p4", 4, at(10)),
 	}
 
-	got := finishSearchIssuesWithCounts(items, types.IssueFilter{SortBy: "created", Limit: 2})
+	got := finishSearchIssuesWithCounts(items, types.IssueFilter{SortBy: []types.SortKey{{Field: "created", Desc: true}}, Limit: 2})
 	if len(got) != 2 || got[0].Issue.ID != "bd-newest-p4" || got[1].Issue.ID != "bd-new-p3" {
 		ids := make([]string, len(got))
 		for i, g := range got {
@@ -113,14 +113,14 @@ func TestFinishSearchIssuesWithCountsTruncatesByRequestedSort(t *testing.T) {
 		t.Fatalf("sort=created limit=2 kept %v, want [bd-newest-p4 bd-new-p3]", ids)
 	}
 
-	// SortDesc flips the per-key default direction: created becomes ASC.
-	got = finishSearchIssuesWithCounts(items, types.IssueFilter{SortBy: "created", SortDesc: true, Limit: 2})
+	// An ascending created key keeps the oldest instead.
+	got = finishSearchIssuesWithCounts(items, types.IssueFilter{SortBy: []types.SortKey{{Field: "created"}}, Limit: 2})
 	if len(got) != 2 || got[0].Issue.ID != "bd-old-p0" || got[1].Issue.ID != "bd-mid-p2" {
 		ids := make([]string, len(got))
 		for i, g := range got {
 			ids[i] = g.Issue.ID
 		}
-		t.Fatalf("sort=created desc limit=2 kept %v, want [bd-old-p0 bd-mid-p2]", ids)
+		t.Fatalf("sort=+created limit=2 kept %v, want [bd-old-p0 bd-mid-p2]", ids)
 	}
 
 	// Default (no SortBy) keeps the historical priority/created/id order.
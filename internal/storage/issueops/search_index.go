@@ -0,0 +1,211 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/fts"
+)
+
+// searchIndexInsertBatch bounds the rows in one multi-row INSERT into the
+// search index; an issue with a long description yields hundreds of terms.
+const searchIndexInsertBatch = 500
+
+// searchIndexSources are the tables the full-text index covers, in the
+// order they are read: a wisp wins over a same-ID issue, as in searchInTx.
+var searchIndexSources = []string{"issues", "wisps"}
+
+type searchIndexDoc struct {
+	doc       fts.Document
+	updatedAt *time.Time
+}
+
+// RefreshSearchIndexInTx brings the full-text search index up to date with
+// the issues and wisps tables and returns how many issues it (re)indexed.
+//
+// An issue is re-read when the index has never seen it, when its
+// updated_at differs from the one indexed (local edits and pulled changes
+// alike), or when it was updated in the same second it was last indexed —
+// updated_at alone cannot order two writes within one second. Index rows
+// of issues that no longer exist are dropped. The index tables are
+// dolt_ignored, so callers must not DOLT_COMMIT for it.
+func RefreshSearchIndexInTx(ctx context.Context, tx DBTX) (int, error) {
+	now := storage.Now(ctx).UTC().Truncate(time.Second)
+
+	var sources []string
+	byID := make(map[string]int)
+	var stale []searchIndexDoc
+	for _, table := range searchIndexSources {
+		docs, err := staleSearchDocsInTx(ctx, tx, table)
+		if err != nil {
+			if table == "wisps" && isTableNotExistError(err) {
+				continue
+			}
+			return 0, err
+		}
+		sources = append(sources, table)
+		for _, d := range docs {
+			if i, dup := byID[d.doc.ID]; dup {
+				stale[i] = d
+				continue
+			}
+			byID[d.doc.ID] = len(stale)
+			stale = append(stale, d)
+		}
+	}
+
+	for start := 0; start < len(stale); start += queryBatchSize {
+		end := min(start+queryBatchSize, len(stale))
+		if err := indexSearchDocsInTx(ctx, tx, stale[start:end], now); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := pruneSearchIndexInTx(ctx, tx, sources); err != nil {
+		return 0, err
+	}
+	return len(stale), nil
+}
+
+// staleSearchDocsInTx returns the rows of table the index is missing or
+// holds an outdated copy of.
+func staleSearchDocsInTx(ctx context.Context, tx DBTX, table string) ([]searchIndexDoc, error) {
+	//nolint:gosec // G201: table is one of searchIndexSources.
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+		SELECT t.id, t.title, t.description, t.notes, t.updated_at
+		FROM %s t
+		LEFT JOIN %s d ON d.issue_id = t.id
+		WHERE d.issue_id IS NULL OR d.updated_at IS NULL
+			OR d.updated_at <> t.updated_at OR t.updated_at >= d.indexed_at`,
+		table, fts.DocsTable))
+	if err != nil {
+		return nil, fmt.Errorf("search index: scan %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var docs []searchIndexDoc
+	for rows.Next() {
+		var id string
+		var title, description, notes, updatedAt sql.NullString
+		if err := rows.Scan(&id, &title, &description, &notes, &updatedAt); err != nil {
+			return nil, fmt.Errorf("search index: scan %s: %w", table, err)
+		}
+		d := searchIndexDoc{doc: fts.Document{
+			ID:          id,
+			Title:       title.String,
+			Description: description.String,
+			Notes:       notes.String,
+		}}
+		if updatedAt.Valid {
+			t := ParseTimeString(updatedAt.String)
+			d.updatedAt = &t
+		}
+		docs = append(docs, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search index: scan %s: %w", table, err)
+	}
+	return docs, nil
+}
+
+// indexSearchDocsInTx replaces the index rows of docs.
+func indexSearchDocsInTx(ctx context.Context, tx DBTX, docs []searchIndexDoc, now time.Time) error {
+	ids := make([]any, len(docs))
+	for i, d := range docs {
+		ids[i] = d.doc.ID
+	}
+	inClause := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	//nolint:gosec // G201: inClause is only placeholders.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE issue_id IN (%s)", fts.TermsTable, inClause), ids...); err != nil {
+		return fmt.Errorf("search index: clear terms: %w", err)
+	}
+
+	var values []string
+	var args []any
+	flush := func() error {
+		if len(values) == 0 {
+			return nil
+		}
+		//nolint:gosec // G201: values are only placeholders.
+		query := fmt.Sprintf("INSERT INTO %s (term, issue_id, field, frequency) VALUES %s", fts.TermsTable, strings.Join(values, ","))
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("search index: insert terms: %w", err)
+		}
+		values, args = values[:0], args[:0]
+		return nil
+	}
+
+	docValues := make([]string, len(docs))
+	var docArgs []any
+	for i, d := range docs {
+		postings, length := fts.Postings(d.doc)
+		for _, p := range postings {
+			values = append(values, "(?, ?, ?, ?)")
+			args = append(args, p.Term, d.doc.ID, int(p.Field), p.Count)
+			if len(values) == searchIndexInsertBatch {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		docValues[i] = "(?, ?, ?, ?)"
+		var updatedAt any
+		if d.updatedAt != nil {
+			updatedAt = *d.updatedAt
+		}
+		docArgs = append(docArgs, d.doc.ID, updatedAt, now, length)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	//nolint:gosec // G201: docValues are only placeholders.
+	query := fmt.Sprintf("REPLACE INTO %s (issue_id, updated_at, indexed_at, term_count) VALUES %s", fts.DocsTable, strings.Join(docValues, ","))
+	if _, err := tx.ExecContext(ctx, query, docArgs...); err != nil {
+		return fmt.Errorf("search index: record documents: %w", err)
+	}
+	return nil
+}
+
+// pruneSearchIndexInTx drops the index rows of issues deleted from every
+// source table.
+func pruneSearchIndexInTx(ctx context.Context, tx DBTX, sources []string) error {
+	conds := make([]string, len(sources))
+	for i, table := range sources {
+		conds[i] = fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s s WHERE s.id = d.issue_id)", table)
+	}
+	//nolint:gosec // G201: tables are from searchIndexSources.
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT d.issue_id FROM %s d WHERE %s", fts.DocsTable, strings.Join(conds, " AND ")))
+	if err != nil {
+		return fmt.Errorf("search index: find deleted issues: %w", err)
+	}
+	var gone []any
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("search index: find deleted issues: %w", err)
+		}
+		gone = append(gone, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("search index: find deleted issues: %w", err)
+	}
+
+	for start := 0; start < len(gone); start += queryBatchSize {
+		batch := gone[start:min(start+queryBatchSize, len(gone))]
+		inClause := strings.TrimSuffix(strings.Repeat("?,", len(batch)), ",")
+		for _, table := range []string{fts.TermsTable, fts.DocsTable} {
+			//nolint:gosec // G201: table is an index table name; inClause is only placeholders.
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE issue_id IN (%s)", table, inClause), batch...); err != nil {
+				return fmt.Errorf("search index: drop deleted issues: %w", err)
+			}
+		}
+	}
+	return nil
+}
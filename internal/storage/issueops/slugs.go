@@ -0,0 +1,276 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const slugColumns = "slug, issue_id, is_current, created_by, created_at"
+
+// maxSlugSuffix bounds the numeric suffixes tried when a generated slug is
+// taken ("fix-login", "fix-login-2", ... "fix-login-99").
+const maxSlugSuffix = 99
+
+// SetIssueSlugInTx makes slug the current slug of the issue, generating it
+// from the title when empty. The previous current slug stays as an old
+// name. A slug the issue held before becomes current again; one held by
+// another issue, equal to an issue ID, or starting with an issue prefix is
+// refused with storage.ErrSlugTaken. Slugs name issues, not wisps.
+func SetIssueSlugInTx(ctx context.Context, tx *sql.Tx, issueID, slug, actor string) (string, error) {
+	var title string
+	err := tx.QueryRowContext(ctx, "SELECT title FROM issues WHERE id = ?", issueID).Scan(&title)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: issue %s", storage.ErrNotFound, issueID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("read issue %s: %w", issueID, err)
+	}
+
+	if slug == "" {
+		if slug, err = availableSlugInTx(ctx, tx, issueID, title, nil); err != nil {
+			return "", err
+		}
+	} else {
+		if err := types.ValidateSlug(slug); err != nil {
+			return "", err
+		}
+		if err := checkSlugNotIDInTx(ctx, tx, slug); err != nil {
+			return "", err
+		}
+		owner, err := slugOwnerInTx(ctx, tx, slug)
+		if err != nil {
+			return "", err
+		}
+		if owner != "" && owner != issueID {
+			return "", fmt.Errorf("%w: %q names %s", storage.ErrSlugTaken, slug, owner)
+		}
+	}
+	return slug, assignSlugInTx(ctx, tx, issueID, slug, actor)
+}
+
+// ListIssueSlugsInTx returns an issue's slugs, current first, then newest
+// first.
+func ListIssueSlugsInTx(ctx context.Context, tx *sql.Tx, issueID string) ([]*types.IssueSlug, error) {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT "+slugColumns+" FROM issue_slugs WHERE issue_id = ? ORDER BY is_current DESC, created_at DESC, slug ASC", issueID)
+	if err != nil {
+		return nil, fmt.Errorf("list slugs for %s: %w", issueID, err)
+	}
+	defer rows.Close()
+
+	var result []*types.IssueSlug
+	for rows.Next() {
+		var s types.IssueSlug
+		var createdBy sql.NullString
+		if err := rows.Scan(&s.Slug, &s.IssueID, &s.Current, &createdBy, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("list slugs for %s: scan: %w", issueID, err)
+		}
+		s.CreatedBy = createdBy.String
+		result = append(result, &s)
+	}
+	return result, rows.Err()
+}
+
+// ResolveSlugInTx returns the ID of the issue holding slug, currently or as
+// an old name.
+func ResolveSlugInTx(ctx context.Context, tx DBTX, slug string) (string, error) {
+	var issueID string
+	err := tx.QueryRowContext(ctx, "SELECT issue_id FROM issue_slugs WHERE slug = ?", slug).Scan(&issueID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: slug %s", storage.ErrNotFound, slug)
+	}
+	if err != nil {
+		return "", fmt.Errorf("resolve slug %s: %w", slug, err)
+	}
+	return issueID, nil
+}
+
+// CurrentSlugsInTx returns the current slugs of issueIDs, keyed by issue ID.
+//
+//nolint:gosec // G201: inClause contains only ? placeholders
+func CurrentSlugsInTx(ctx context.Context, tx DBTX, issueIDs []string) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	inClause, args := buildSQLInClause(issueIDs)
+	rows, err := tx.QueryContext(ctx,
+		fmt.Sprintf("SELECT issue_id, slug FROM issue_slugs WHERE is_current = 1 AND issue_id IN (%s)", inClause), args...)
+	if err != nil {
+		return nil, fmt.Errorf("read current slugs: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var issueID, slug string
+		if err := rows.Scan(&issueID, &slug); err != nil {
+			return nil, fmt.Errorf("read current slugs: scan: %w", err)
+		}
+		result[issueID] = slug
+	}
+	return result, rows.Err()
+}
+
+// GenerateIssueSlugsInTx gives every issue without a slug one generated
+// from its title, oldest issue first so earlier issues get the unsuffixed
+// slugs. Issues whose titles yield no slug are skipped.
+func GenerateIssueSlugsInTx(ctx context.Context, tx *sql.Tx, opts storage.SlugGenerationOptions, actor string) ([]*types.IssueSlug, error) {
+	query := `SELECT id, title FROM issues
+		WHERE id NOT IN (SELECT issue_id FROM issue_slugs)`
+	if !opts.IncludeClosed {
+		query += " AND status != 'closed'"
+	}
+	query += " ORDER BY created_at ASC, id ASC"
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list issues without slugs: %w", err)
+	}
+	type unnamed struct{ id, title string }
+	var issues []unnamed
+	for rows.Next() {
+		var u unnamed
+		if err := rows.Scan(&u.id, &u.title); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("list issues without slugs: scan: %w", err)
+		}
+		issues = append(issues, u)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("list issues without slugs: %w", err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list issues without slugs: %w", err)
+	}
+
+	// claimed tracks slugs handed out by this run, which a dry run never
+	// writes for availableSlugInTx to see.
+	claimed := make(map[string]bool)
+	var result []*types.IssueSlug
+	for _, u := range issues {
+		slug, err := availableSlugInTx(ctx, tx, u.id, u.title, claimed)
+		if err != nil {
+			continue
+		}
+		claimed[slug] = true
+		if !opts.DryRun {
+			if err := assignSlugInTx(ctx, tx, u.id, slug, actor); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, &types.IssueSlug{Slug: slug, IssueID: u.id, Current: true, CreatedBy: actor})
+	}
+	return result, nil
+}
+
+// DeleteIssueSlugsInTx removes every slug of the issue, freeing them for
+// reuse.
+func DeleteIssueSlugsInTx(ctx context.Context, tx *sql.Tx, issueID string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM issue_slugs WHERE issue_id = ?", issueID); err != nil {
+		return fmt.Errorf("delete slugs for %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// availableSlugInTx generates a slug for the issue from title, appending
+// -2, -3, ... while another issue holds it or claimed marks it taken. A
+// slug the issue itself held is reused as is.
+func availableSlugInTx(ctx context.Context, tx *sql.Tx, issueID, title string, claimed map[string]bool) (string, error) {
+	base := types.Slugify(title)
+	if base == "" {
+		return "", fmt.Errorf("title of %s has no letters to make a slug from; pass one explicitly", issueID)
+	}
+	if err := types.ValidateSlug(base); err != nil {
+		return "", fmt.Errorf("title of %s makes no usable slug (%v); pass one explicitly", issueID, err)
+	}
+	if err := checkSlugNotIDInTx(ctx, tx, base); err != nil {
+		return "", err
+	}
+	for n := 1; n <= maxSlugSuffix; n++ {
+		slug := base
+		if n > 1 {
+			suffix := "-" + strconv.Itoa(n)
+			slug = base[:min(len(base), types.MaxSlugLength-len(suffix))] + suffix
+		}
+		if claimed[slug] {
+			continue
+		}
+		owner, err := slugOwnerInTx(ctx, tx, slug)
+		if err != nil {
+			return "", err
+		}
+		if owner == "" || owner == issueID {
+			return slug, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q and its numbered variants are all in use", storage.ErrSlugTaken, base)
+}
+
+// slugOwnerInTx returns the issue holding slug, or the issue or wisp whose ID
+// equals it, so a slug never shadows an ID. It returns "" when the slug is
+// free.
+func slugOwnerInTx(ctx context.Context, tx *sql.Tx, slug string) (string, error) {
+	owner, err := ResolveSlugInTx(ctx, tx, slug)
+	if err == nil {
+		return owner, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return "", err
+	}
+	var probe int
+	err = tx.QueryRowContext(ctx, "SELECT 1 FROM issues WHERE id = ?", slug).Scan(&probe)
+	if err == nil || IsActiveWispInTx(ctx, tx, slug) {
+		return slug, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("check slug %s against issue IDs: %w", slug, err)
+	}
+	return "", nil
+}
+
+// checkSlugNotIDInTx refuses a slug that ID resolution would read as an
+// issue ID because it starts with the issue prefix or a prefix.by_type or
+// prefix.by_label prefix, as "bd-a3f" does.
+func checkSlugNotIDInTx(ctx context.Context, tx *sql.Tx, slug string) error {
+	byType, byLabel, err := ReadIDPrefixRulesTx(ctx, tx)
+	if err != nil {
+		return err
+	}
+	prefixes := types.RulePrefixes(byType, byLabel)
+	if prefix, err := ReadConfigPrefix(ctx, tx); err == nil {
+		prefixes = append(prefixes, prefix)
+	} else if !errors.Is(err, storage.ErrNotInitialized) {
+		return err
+	}
+	for _, prefix := range prefixes {
+		prefix = strings.ToLower(strings.TrimSuffix(prefix, "-"))
+		if prefix != "" && strings.HasPrefix(slug, prefix+"-") {
+			return fmt.Errorf("%w: %q reads as an issue ID with prefix %s-", storage.ErrSlugTaken, slug, prefix)
+		}
+	}
+	return nil
+}
+
+// assignSlugInTx makes slug the issue's only current slug, reviving the row
+// when the issue held it before.
+func assignSlugInTx(ctx context.Context, tx *sql.Tx, issueID, slug, actor string) error {
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE issue_slugs SET is_current = 0 WHERE issue_id = ? AND is_current = 1 AND slug != ?", issueID, slug); err != nil {
+		return fmt.Errorf("retire slugs of %s: %w", issueID, err)
+	}
+	now := storage.Now(ctx).Truncate(time.Second)
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO issue_slugs (`+slugColumns+`)
+		VALUES (?, ?, 1, ?, ?)
+		ON DUPLICATE KEY UPDATE is_current = 1
+	`, slug, issueID, actor, now); err != nil {
+		return fmt.Errorf("set slug of %s: %w", issueID, err)
+	}
+	return nil
+}
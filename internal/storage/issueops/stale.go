@@ -3,8 +3,8 @@ package issueops
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -15,7 +15,7 @@ import (
 //
 // nolint:gosec // G201: statusClause contains only literal SQL or a single ? placeholder
 func GetStaleIssuesInTx(ctx context.Context, tx DBTX, filter types.StaleFilter) ([]*types.Issue, error) {
-	cutoff := time.Now().UTC().AddDate(0, 0, -filter.Days)
+	cutoff := storage.Now(ctx).AddDate(0, 0, -filter.Days)
 
 	statusClause := "status IN ('open', 'in_progress')"
 	if filter.Status != "" {
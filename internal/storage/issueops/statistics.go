@@ -4,33 +4,52 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/steveyegge/beads/internal/storage/sqlbuild"
 	"github.com/steveyegge/beads/internal/types"
 )
 
 // ScanIssueCountsInTx populates the count fields (TotalIssues, OpenIssues,
-// InProgressIssues, ClosedIssues, DeferredIssues, PinnedIssues) of stats from
-// the issues table. It does NOT compute BlockedIssues or ReadyIssues — callers
-// fill those in using their own blocked-ID computation strategy.
+// InProgressIssues, ClosedIssues, InReviewIssues, DeferredIssues,
+// PinnedIssues, ReopenedIssues) and ReopenRate of stats from the issues
+// table. Closed issues still awaiting review count as InReviewIssues, not
+// ClosedIssues. It does NOT
+// compute BlockedIssues or ReadyIssues — callers fill those in using their own
+// blocked-ID computation strategy.
 func ScanIssueCountsInTx(ctx context.Context, tx DBTX, stats *types.Statistics) error {
+	var everClosed int
 	if err := tx.QueryRowContext(ctx, `
 		SELECT
 			COUNT(*) AS total,
 			COALESCE(SUM(CASE WHEN status = 'open' THEN 1 ELSE 0 END), 0),
 			COALESCE(SUM(CASE WHEN status = 'in_progress' THEN 1 ELSE 0 END), 0),
-			COALESCE(SUM(CASE WHEN status = 'closed' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN status = 'closed' AND NOT `+reviewPendingSQL("issues")+` THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN status = 'closed' AND `+reviewPendingSQL("issues")+` THEN 1 ELSE 0 END), 0),
 			COALESCE(SUM(CASE WHEN status = 'deferred' THEN 1 ELSE 0 END), 0),
-			COALESCE(SUM(CASE WHEN pinned = 1 THEN 1 ELSE 0 END), 0)
+			COALESCE(SUM(CASE WHEN pinned = 1 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN `+sqlbuild.MinReopenCountClause+` THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN status = 'closed' OR `+sqlbuild.MinReopenCountClause+` THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(`+sqlbuild.CostTokensExpr+`), 0),
+			COALESCE(SUM(`+sqlbuild.CostUSDExpr+`), 0)
 		FROM issues
-	`).Scan(
+	`, 1, 1).Scan(
 		&stats.TotalIssues,
 		&stats.OpenIssues,
 		&stats.InProgressIssues,
 		&stats.ClosedIssues,
+		&stats.InReviewIssues,
 		&stats.DeferredIssues,
 		&stats.PinnedIssues,
+		&stats.ReopenedIssues,
+		&everClosed,
+		&stats.TotalCostTokens,
+		&stats.TotalCostUSD,
 	); err != nil {
 		return fmt.Errorf("scan issue counts: %w", err)
 	}
+	// Reopen rate: share of issues that were ever closed and later reopened.
+	if everClosed > 0 {
+		stats.ReopenRate = float64(stats.ReopenedIssues) / float64(everClosed)
+	}
 	return nil
 }
 
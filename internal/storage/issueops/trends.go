@@ -0,0 +1,130 @@
+package issueops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const trendDayFormat = "2006-01-02"
+
+// trendWindow returns the first day of a days-long series ending today
+// (UTC) and the bucket a "YYYY-MM-DD" day falls into, or -1 outside it.
+func trendWindow(ctx context.Context, days int) (time.Time, func(day string) int) {
+	today := storage.Now(ctx).UTC().Truncate(24 * time.Hour)
+	start := today.AddDate(0, 0, -(days - 1))
+	return start, func(day string) int {
+		t, err := time.Parse(trendDayFormat, day)
+		if err != nil {
+			return -1
+		}
+		i := int(t.Sub(start) / (24 * time.Hour))
+		if i < 0 || i >= days {
+			return -1
+		}
+		return i
+	}
+}
+
+// GetActivityTrendsInTx returns the daily event counts of each issue over
+// the last days days, counting events on the issue and on its direct
+// children. Counting happens in SQL, grouped by owner and day. Only issues
+// are counted: wisps and their events live in the wisp tables.
+func GetActivityTrendsInTx(ctx context.Context, tx DBTX, issueIDs []string, days int) (map[string][]int, error) {
+	if days <= 0 {
+		return nil, fmt.Errorf("activity trends: days must be positive, got %d", days)
+	}
+	start, bucket := trendWindow(ctx, days)
+	result := make(map[string][]int, len(issueIDs))
+	for _, id := range issueIDs {
+		result[id] = make([]int, days)
+	}
+
+	for i := 0; i < len(issueIDs); i += queryBatchSize {
+		batch := issueIDs[i:min(i+queryBatchSize, len(issueIDs))]
+		inClause := strings.TrimSuffix(strings.Repeat("?,", len(batch)), ",")
+		args := make([]any, 0, 2*len(batch)+2)
+		for _, id := range batch {
+			args = append(args, id)
+		}
+		args = append(args, start)
+		for _, id := range batch {
+			args = append(args, id)
+		}
+		args = append(args, start)
+
+		//nolint:gosec // G201: inClause is only placeholders.
+		query := fmt.Sprintf(`
+			SELECT owner, day, COUNT(*) FROM (
+				SELECT e.issue_id AS owner, DATE_FORMAT(e.created_at, '%%Y-%%m-%%d') AS day
+				FROM events e
+				WHERE e.issue_id IN (%s) AND e.created_at >= ?
+				UNION ALL
+				SELECT d.depends_on_issue_id AS owner, DATE_FORMAT(e.created_at, '%%Y-%%m-%%d') AS day
+				FROM events e
+				JOIN dependencies d ON d.issue_id = e.issue_id AND d.type = 'parent-child'
+				WHERE d.depends_on_issue_id IN (%s) AND e.created_at >= ?
+			) activity
+			GROUP BY owner, day`, inClause, inClause)
+		rows, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("activity trends: %w", err)
+		}
+		for rows.Next() {
+			var owner, day string
+			var count int
+			if err := rows.Scan(&owner, &day, &count); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("activity trends: %w", err)
+			}
+			if series, ok := result[owner]; ok {
+				if b := bucket(day); b >= 0 {
+					series[b] += count
+				}
+			}
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("activity trends: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// GetEventTrendInTx returns the daily count of eventType events across
+// all issues over the last days days.
+func GetEventTrendInTx(ctx context.Context, tx DBTX, eventType types.EventType, days int) ([]int, error) {
+	if days <= 0 {
+		return nil, fmt.Errorf("%s trend: days must be positive, got %d", eventType, days)
+	}
+	start, bucket := trendWindow(ctx, days)
+	rows, err := tx.QueryContext(ctx, `
+		SELECT DATE_FORMAT(created_at, '%Y-%m-%d') AS day, COUNT(*)
+		FROM events
+		WHERE event_type = ? AND created_at >= ?
+		GROUP BY day`, eventType, start)
+	if err != nil {
+		return nil, fmt.Errorf("%s trend: %w", eventType, err)
+	}
+	defer rows.Close()
+
+	series := make([]int, days)
+	for rows.Next() {
+		var day string
+		var count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, fmt.Errorf("%s trend: %w", eventType, err)
+		}
+		if b := bucket(day); b >= 0 {
+			series[b] += count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s trend: %w", eventType, err)
+	}
+	return series, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -57,7 +56,7 @@ func UnclaimIssueInTx(ctx context.Context, tx DBTX, id string, actor string, for
 			storage.ErrNotOwner, id, oldIssue.Assignee)
 	}
 
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 
 	// Atomic UPDATE: clear assignee, reset status to open, clear started_at,
 	// and rewrite row_lock. The predicate re-checks ownership (unless forced)
@@ -166,7 +165,7 @@ func UnclaimIssueIfAssigneeInTx(ctx context.Context, tx DBTX, id string, actor s
 		return fmt.Errorf("%w: %s is held by %q, expected %q", storage.ErrAssigneeMismatch, id, oldIssue.Assignee, expectedAssignee)
 	}
 
-	now := time.Now().UTC()
+	now := storage.Now(ctx)
 
 	// Atomic UPDATE pinned to the expected assignee (CAS), applying the same
 	// transition as UnclaimIssueInTx: clear assignee, reset status to open,
@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
-	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -19,8 +19,8 @@ func IsAllowedUpdateField(key string) bool {
 		"issue_type": true, "estimated_minutes": true, "external_ref": true, "spec_id": true,
 		"started_at": true,
 		"closed_at":  true, "close_reason": true, "closed_by_session": true,
-		"source_repo": true,
-		"sender":      true, "wisp": true, "wisp_type": true, "no_history": true, "pinned": true,
+		"source_repo": true, "rig": true, "encrypted": true,
+		"sender": true, "wisp": true, "wisp_type": true, "no_history": true, "pinned": true,
 		"mol_type":       true,
 		"event_category": true, "event_actor": true, "event_target": true, "event_payload": true,
 		"due_at": true, "defer_until": true, "await_id": true, "waiters": true,
@@ -30,7 +30,7 @@ func IsAllowedUpdateField(key string) bool {
 }
 
 // ManageClosedAt auto-sets closed_at when closing or clears it when reopening.
-func ManageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setClauses []string, args []interface{}) ([]string, []interface{}) {
+func ManageClosedAt(ctx context.Context, oldIssue *types.Issue, updates map[string]interface{}, setClauses []string, args []interface{}) ([]string, []interface{}) {
 	statusVal, hasStatus := updates["status"]
 	_, hasExplicitClosedAt := updates["closed_at"]
 	if hasExplicitClosedAt || !hasStatus {
@@ -48,7 +48,7 @@ func ManageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 	}
 
 	if newStatus == string(types.StatusClosed) {
-		now := time.Now().UTC()
+		now := storage.Now(ctx)
 		setClauses = append(setClauses, "closed_at = ?")
 		args = append(args, now)
 	} else if oldIssue.Status == types.StatusClosed {
@@ -61,7 +61,7 @@ func ManageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 
 // ManageStartedAt auto-sets started_at when transitioning to in_progress.
 // If the issue already has a started_at, it is preserved (not overwritten).
-func ManageStartedAt(oldIssue *types.Issue, updates map[string]interface{}, setClauses []string, args []interface{}) ([]string, []interface{}) {
+func ManageStartedAt(ctx context.Context, oldIssue *types.Issue, updates map[string]interface{}, setClauses []string, args []interface{}) ([]string, []interface{}) {
 	statusVal, hasStatus := updates["status"]
 	_, hasExplicitStartedAt := updates["started_at"]
 	if hasExplicitStartedAt || !hasStatus {
@@ -79,7 +79,7 @@ func ManageStartedAt(oldIssue *types.Issue, updates map[string]interface{}, setC
 	}
 
 	if newStatus == string(types.StatusInProgress) && oldIssue.StartedAt == nil {
-		now := time.Now().UTC()
+		now := storage.Now(ctx)
 		setClauses = append(setClauses, "started_at = ?")
 		args = append(args, now)
 	}
@@ -210,6 +210,14 @@ func updateIssueInTx(ctx context.Context, tx DBTX, id string, updates map[string
 	if err != nil {
 		return nil, err
 	}
+	if err := checkUpdateProtection(oldIssue, updates, actor); err != nil {
+		return nil, err
+	}
+	// Reopen bookkeeping is storage's own write, not the caller's, so it
+	// is folded in after the protection check.
+	if updates, err = ApplyReopenTracking(ctx, oldIssue, updates, actor); err != nil {
+		return nil, err
+	}
 
 	// Validate issue_type against built-in + custom types (GH#3030).
 	// This mirrors the create path (PrepareIssueForInsert → ValidateWithCustom)
@@ -243,7 +251,7 @@ func updateIssueInTx(ctx context.Context, tx DBTX, id string, updates map[string
 
 	// Build SET clauses.
 	setClauses := []string{"updated_at = ?"}
-	args := []interface{}{time.Now().UTC()}
+	args := []interface{}{storage.Now(ctx)}
 
 	for key, value := range updates {
 		if !IsAllowedUpdateField(key) {
@@ -251,8 +259,11 @@ func updateIssueInTx(ctx context.Context, tx DBTX, id string, updates map[string
 		}
 
 		columnName := key
-		if key == "wisp" {
+		switch key {
+		case "wisp":
 			columnName = "ephemeral"
+		case "encrypted":
+			columnName = "is_encrypted"
 		}
 		setClauses = append(setClauses, fmt.Sprintf("`%s` = ?", columnName))
 
@@ -289,10 +300,10 @@ func updateIssueInTx(ctx context.Context, tx DBTX, id string, updates map[string
 	}
 
 	// Auto-manage closed_at (set on close, clear on reopen).
-	setClauses, args = ManageClosedAt(oldIssue, updates, setClauses, args)
+	setClauses, args = ManageClosedAt(ctx, oldIssue, updates, setClauses, args)
 
 	// Auto-manage started_at (set on transition to in_progress). (GH#2796)
-	setClauses, args = ManageStartedAt(oldIssue, updates, setClauses, args)
+	setClauses, args = ManageStartedAt(ctx, oldIssue, updates, setClauses, args)
 
 	// Auto-manage leases when direct updates change status or assignee.
 	// Clears stale leases only; arming is reserved for claim/heartbeat.
@@ -321,8 +332,9 @@ func updateIssueInTx(ctx context.Context, tx DBTX, id string, updates map[string
 	}
 
 	if recordEvent {
-		oldData, _ := json.Marshal(oldIssue)
-		newData, _ := json.Marshal(updates)
+		eventOld, eventUpdates := redactEncryptedFieldsForEvent(oldIssue, updates)
+		oldData, _ := json.Marshal(eventOld)
+		newData, _ := json.Marshal(eventUpdates)
 		eventType := DetermineEventType(oldIssue, updates)
 
 		if err := RecordFullEventInTable(ctx, tx, eventTable, id, eventType, actor, string(oldData), string(newData)); err != nil {
@@ -330,30 +342,23 @@ func updateIssueInTx(ctx context.Context, tx DBTX, id string, updates map[string
 		}
 	}
 
-	if rawStatus, hasStatus := updates["status"]; hasStatus {
-		var newStatus string
-		switch v := rawStatus.(type) {
-		case string:
-			newStatus = v
-		case types.Status:
-			newStatus = string(v)
+	changed, err := BlockingChanged(oldIssue, updates)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		var affectedIssues, affectedWisps []string
+		var aerr error
+		if isWisp {
+			affectedIssues, affectedWisps, aerr = AffectedByStatusChangeForWispInTx(ctx, tx, id)
+		} else {
+			affectedIssues, affectedWisps, aerr = AffectedByStatusChangeInTx(ctx, tx, id)
 		}
-		oldActive := oldIssue.Status != types.StatusClosed && oldIssue.Status != types.StatusPinned
-		newActive := newStatus != string(types.StatusClosed) && newStatus != string(types.StatusPinned)
-		if oldActive != newActive {
-			var affectedIssues, affectedWisps []string
-			var aerr error
-			if isWisp {
-				affectedIssues, affectedWisps, aerr = AffectedByStatusChangeForWispInTx(ctx, tx, id)
-			} else {
-				affectedIssues, affectedWisps, aerr = AffectedByStatusChangeInTx(ctx, tx, id)
-			}
-			if aerr != nil {
-				return nil, fmt.Errorf("affected by status change for %s: %w", id, aerr)
-			}
-			if err := RecomputeIsBlockedInTx(ctx, tx, affectedIssues, affectedWisps); err != nil {
-				return nil, fmt.Errorf("recompute is_blocked after status change for %s: %w", id, err)
-			}
+		if aerr != nil {
+			return nil, fmt.Errorf("affected by status change for %s: %w", id, aerr)
+		}
+		if err := RecomputeIsBlockedInTx(ctx, tx, affectedIssues, affectedWisps); err != nil {
+			return nil, fmt.Errorf("recompute is_blocked after status change for %s: %w", id, err)
 		}
 	}
 
@@ -375,6 +380,9 @@ const (
 	// OpUnsetMetadata removes metadata keys (bd update --unset-metadata).
 	// Value: []string.
 	OpUnsetMetadata = "_unset_metadata"
+	// OpPatchMetadata applies an RFC 6902 JSON Patch to the issue's metadata
+	// (bd meta patch). Value: string, []byte, or json.RawMessage.
+	OpPatchMetadata = "_patch_metadata"
 	// OpAppendNotes appends a line to the issue's notes
 	// (bd update --append-notes). Value: string.
 	OpAppendNotes = "append_notes"
@@ -386,7 +394,7 @@ const (
 // store's whole-attempt retry then re-runs that resolution against the winning
 // writer's committed row.
 func HasMergeOps(updates map[string]interface{}) bool {
-	for _, op := range []string{OpMergeMetadata, OpSetMetadata, OpUnsetMetadata, OpAppendNotes} {
+	for _, op := range []string{OpMergeMetadata, OpSetMetadata, OpUnsetMetadata, OpPatchMetadata, OpAppendNotes} {
 		if _, ok := updates[op]; ok {
 			return true
 		}
@@ -423,22 +431,23 @@ func ResolveMergeOps(oldIssue *types.Issue, updates map[string]interface{}) (map
 // ResolveMergeOps rather than a concrete column value to pass through unchanged.
 func isMergeOpKey(k string) bool {
 	switch k {
-	case OpMergeMetadata, OpSetMetadata, OpUnsetMetadata, OpAppendNotes:
+	case OpMergeMetadata, OpSetMetadata, OpUnsetMetadata, OpPatchMetadata, OpAppendNotes:
 		return true
 	default:
 		return false
 	}
 }
 
-// resolveMetadataMergeOps folds OpMergeMetadata/OpSetMetadata/OpUnsetMetadata
-// into a concrete "metadata" value on resolved, using oldIssue.Metadata (read in
-// the same mutation transaction) as the base. It is a no-op when no metadata
-// operation keys are present.
+// resolveMetadataMergeOps folds OpMergeMetadata/OpSetMetadata/OpUnsetMetadata/
+// OpPatchMetadata into a concrete "metadata" value on resolved, using
+// oldIssue.Metadata (read in the same mutation transaction) as the base. It is
+// a no-op when no metadata operation keys are present.
 func resolveMetadataMergeOps(oldIssue *types.Issue, updates, resolved map[string]interface{}) error {
 	_, hasMerge := updates[OpMergeMetadata]
 	_, hasSet := updates[OpSetMetadata]
 	_, hasUnset := updates[OpUnsetMetadata]
-	if !hasMerge && !hasSet && !hasUnset {
+	_, hasPatch := updates[OpPatchMetadata]
+	if !hasMerge && !hasSet && !hasUnset && !hasPatch {
 		return nil
 	}
 	if _, direct := resolved["metadata"]; direct {
@@ -472,6 +481,17 @@ func resolveMetadataMergeOps(oldIssue *types.Issue, updates, resolved map[string
 		}
 		current = merged
 	}
+	if hasPatch {
+		normalized, err := storage.NormalizeMetadataValue(updates[OpPatchMetadata])
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", OpPatchMetadata, err)
+		}
+		patched, err := storage.ApplyMetadataPatch(current, json.RawMessage(normalized))
+		if err != nil {
+			return fmt.Errorf("metadata patch failed: %w", err)
+		}
+		current = patched
+	}
 	// Validate the merged result, matching the schema check stores apply to
 	// direct metadata replacements (GH#1416 Phase 2).
 	if err := ValidateMetadataIfConfigured(current); err != nil {
@@ -546,6 +566,41 @@ func readIssueAndResolveMergeOps(ctx context.Context, tx DBTX, id string, update
 	return oldIssue, resolved, nil
 }
 
+// redactedEncryptedValue replaces the plaintext of an encrypted field in an
+// event's old and new values.
+const redactedEncryptedValue = "[encrypted]"
+
+// redactEncryptedFieldsForEvent returns copies of oldIssue and updates for
+// the event log. When the issue is encrypted before or after the update,
+// description, design, and notes values that are not sealed (the old values
+// when encryption is turned on, the new ones when it is turned off) are
+// replaced with redactedEncryptedValue, so events never hold their
+// plaintext. Otherwise both are returned as is.
+func redactEncryptedFieldsForEvent(oldIssue *types.Issue, updates map[string]interface{}) (*types.Issue, map[string]interface{}) {
+	encryptedAfter := oldIssue.Encrypted
+	if v, ok := updates["encrypted"].(bool); ok {
+		encryptedAfter = v
+	}
+	if !oldIssue.Encrypted && !encryptedAfter {
+		return oldIssue, updates
+	}
+	redact := func(s string) string {
+		if s == "" || storage.IsEncryptedValue(s) {
+			return s
+		}
+		return redactedEncryptedValue
+	}
+	old := *oldIssue
+	old.Description, old.Design, old.Notes = redact(old.Description), redact(old.Design), redact(old.Notes)
+	redacted := maps.Clone(updates)
+	for _, field := range []string{"description", "design", "notes"} {
+		if v, ok := redacted[field].(string); ok {
+			redacted[field] = redact(v)
+		}
+	}
+	return &old, redacted
+}
+
 // RecordFullEventInTable records an event with both old and new values.
 //
 //nolint:gosec // G201: table is from WispTableRouting ("events" or "wisp_events")
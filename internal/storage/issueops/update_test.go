@@ -86,3 +86,39 @@ func TestManageLeaseOnUpdate(t *testing.T) {
 		})
 	}
 }
+
+// TestRedactEncryptedFieldsForEvent checks that the event log never gets the
+// plaintext of an encrypted issue's description, design, or notes.
+func TestRedactEncryptedFieldsForEvent(t *testing.T) {
+	sealed := "bdenc:v1:c2VhbGVk"
+
+	// Turning encryption on: the old plaintext is redacted, the sealed new
+	// values are kept.
+	old := &types.Issue{Title: "t", Description: "secret", Notes: "note"}
+	updates := map[string]interface{}{"encrypted": true, "description": sealed, "notes": sealed}
+	gotOld, gotUpdates := redactEncryptedFieldsForEvent(old, updates)
+	if gotOld.Description != redactedEncryptedValue || gotOld.Notes != redactedEncryptedValue || gotOld.Design != "" || gotOld.Title != "t" {
+		t.Errorf("old = %+v, want plaintext fields redacted", gotOld)
+	}
+	if gotUpdates["description"] != sealed || gotUpdates["notes"] != sealed {
+		t.Errorf("updates = %v, want sealed values kept", gotUpdates)
+	}
+	if old.Description != "secret" || updates["description"] != sealed {
+		t.Error("caller's issue or updates modified")
+	}
+
+	// Turning encryption off: the new plaintext is redacted.
+	old = &types.Issue{Description: sealed, Encrypted: true}
+	_, gotUpdates = redactEncryptedFieldsForEvent(old, map[string]interface{}{"encrypted": false, "description": "secret", "title": "t"})
+	if gotUpdates["description"] != redactedEncryptedValue || gotUpdates["title"] != "t" {
+		t.Errorf("updates = %v, want description redacted", gotUpdates)
+	}
+
+	// Plain issues are recorded as is.
+	old = &types.Issue{Description: "public"}
+	updates = map[string]interface{}{"notes": "public note"}
+	gotOld, gotUpdates = redactEncryptedFieldsForEvent(old, updates)
+	if gotOld != old || gotUpdates["notes"] != "public note" {
+		t.Errorf("plain issue event altered: %+v, %v", gotOld, gotUpdates)
+	}
+}
@@ -0,0 +1,495 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// upsertIssueBatch bounds the rows in one multi-row issue UPSERT. Each row
+// binds one placeholder per issueInsertColumns entry, so 100 rows stay well
+// under the server's prepared-statement placeholder limit.
+const upsertIssueBatch = 100
+
+// upsertCandidate is an issue that passed validation, with its position in
+// the caller's slice for failure reporting.
+type upsertCandidate struct {
+	index int
+	issue *types.Issue
+	table string
+	isNew bool
+
+	// For an existing issue: the stored row and the fields the upsert
+	// changes in it, keyed like an UpdateIssue updates map.
+	old     *types.Issue
+	changes map[string]interface{}
+}
+
+// UpsertIssuesInTx inserts the issues that do not exist and overwrites the
+// ones that do, one multi-row INSERT ... ON DUPLICATE KEY UPDATE per batch
+// of upsertIssueBatch rows per table. Issues without an ID get one
+// generated, as in CreateIssuesInTx.
+//
+// An issue that fails validation (bad fields, a prefix that is not allowed,
+// an ID repeated in the batch or already used by the other of issues and
+// wisps) is reported in the result's Failed list and skipped; the rest are
+// written. Errors from the writes themselves are returned and leave the
+// caller to roll back, so a call either writes every valid issue or none.
+//
+// Like bd import, an overwritten issue takes every column from the incoming
+// row. Overwrites are held to the issue's protection like UpdateIssue: one
+// that changes a field the actor may not change is reported in Failed with
+// storage.ErrProtected. New issues get a created event and overwrites that
+// change the issue an update event. Labels, comments and dependencies
+// carried on the issues are merged in additively.
+func UpsertIssuesInTx(ctx context.Context, tx *sql.Tx, issues []*types.Issue, actor string) (*storage.UpsertIssuesResult, CreateIssuesResult, error) {
+	out := &storage.UpsertIssuesResult{}
+	var changed CreateIssuesResult
+	opts := storage.BatchCreateOptions{OrphanHandling: storage.OrphanAllow}
+
+	bc, err := NewBatchContext(ctx, tx, opts)
+	if err != nil {
+		return nil, changed, err
+	}
+	fail := func(index int, issue *types.Issue, err error) {
+		f := storage.UpsertFailure{Index: index, Err: err}
+		if issue != nil {
+			f.ID = issue.ID
+		}
+		out.Failed = append(out.Failed, f)
+	}
+
+	seen := make(map[string]bool, len(issues))
+	var candidates []*upsertCandidate
+	for i, issue := range issues {
+		if issue == nil {
+			fail(i, nil, errors.New("issue is nil"))
+			continue
+		}
+		table, err := prepareUpsertIssue(ctx, tx, bc, issue, actor, seen)
+		if err != nil {
+			fail(i, issue, err)
+			continue
+		}
+		seen[issue.ID] = true
+		candidates = append(candidates, &upsertCandidate{index: i, issue: issue, table: table})
+	}
+
+	candidates, err = classifyUpsertCandidatesInTx(ctx, tx, candidates, fail)
+	if err != nil {
+		return nil, changed, err
+	}
+	candidates, err = checkUpsertOverwritesInTx(ctx, tx, candidates, actor, fail)
+	if err != nil {
+		return nil, changed, err
+	}
+	sort.SliceStable(out.Failed, func(i, j int) bool { return out.Failed[i].Index < out.Failed[j].Index })
+	if len(candidates) == 0 {
+		return out, changed, nil
+	}
+
+	written := make([]*types.Issue, len(candidates))
+	for i, c := range candidates {
+		written[i] = c.issue
+	}
+	if _, err := filterCreateIssuesMixedBucketDependencies(written, opts); err != nil {
+		return nil, changed, err
+	}
+
+	for _, table := range []string{"issues", "wisps"} {
+		var rows []*upsertCandidate
+		for _, c := range candidates {
+			if c.table == table {
+				rows = append(rows, c)
+			}
+		}
+		for start := 0; start < len(rows); start += upsertIssueBatch {
+			if err := upsertIssueRowsInTx(ctx, tx, table, rows[start:min(start+upsertIssueBatch, len(rows))]); err != nil {
+				return nil, changed, err
+			}
+		}
+		if len(rows) > 0 {
+			changed.markChanged(table)
+		}
+	}
+
+	var created []*upsertCandidate
+	var updatedIDs []string
+	for _, c := range candidates {
+		if c.isNew {
+			created = append(created, c)
+			out.Created = append(out.Created, c.issue.ID)
+			continue
+		}
+		out.Updated = append(out.Updated, c.issue.ID)
+		if c.table == "issues" && c.issue.LeaseExpiresAt == nil {
+			updatedIDs = append(updatedIDs, c.issue.ID)
+		}
+	}
+	createdEvents, err := recordCreatedEventsInTx(ctx, tx, created, actor)
+	if err != nil {
+		return nil, changed, err
+	}
+	changed.merge(createdEvents)
+	updatedEvents, err := recordUpdatedEventsInTx(ctx, tx, candidates, actor)
+	if err != nil {
+		return nil, changed, err
+	}
+	changed.merge(updatedEvents)
+
+	for _, c := range candidates {
+		if c.table == "issues" && c.issue.LeaseExpiresAt != nil {
+			if err := RestoreLeaseOnImportInTx(ctx, tx, c.issue, c.isNew); err != nil {
+				return nil, changed, err
+			}
+		}
+	}
+	if err := reconcileLeasesInTx(ctx, tx, updatedIDs); err != nil {
+		return nil, changed, err
+	}
+
+	for _, c := range candidates {
+		_, eventTable := TableRouting(c.issue)
+		labelResult, err := PersistLabels(ctx, tx, c.issue, actor, eventTable)
+		if err != nil {
+			return nil, changed, err
+		}
+		changed.merge(labelResult.ChangedTables)
+		commentResult, err := PersistComments(ctx, tx, c.issue)
+		if err != nil {
+			return nil, changed, err
+		}
+		changed.merge(commentResult.ChangedTables)
+	}
+	depResult, err := PersistDependenciesWithOptionsResult(ctx, tx, written, actor, opts)
+	if err != nil {
+		return nil, changed, err
+	}
+	changed.merge(depResult.ChangedTables)
+
+	changedCounters, err := ReconcileChildCounters(ctx, tx, written)
+	if err != nil {
+		return nil, changed, err
+	}
+	changed.ChangedChildCounterTables = changedCounters
+	for table := range changedCounters {
+		changed.markChanged(table)
+	}
+	issueIDs, wispIDs := createBlockedRecomputeIDs(written)
+	if err := RecomputeIsBlockedInTx(ctx, tx, issueIDs, wispIDs); err != nil {
+		return nil, changed, err
+	}
+	return out, changed, nil
+}
+
+// prepareUpsertIssue validates issue, generates its ID if it has none, and
+// returns the table it belongs in. seen holds the IDs of earlier issues in
+// the batch.
+func prepareUpsertIssue(ctx context.Context, tx *sql.Tx, bc *BatchContext, issue *types.Issue, actor string, seen map[string]bool) (string, error) {
+	// The stored hash must describe the row being written, not whatever
+	// version of the issue the caller started from.
+	issue.ContentHash = ""
+	if err := PrepareIssueForInsert(ctx, issue, bc.CustomStatuses, bc.CustomTypes); err != nil {
+		return "", err
+	}
+	table, _ := TableRouting(issue)
+	if issue.ID == "" {
+		id, err := generateIssueIDInTable(ctx, tx, table, bc.IDPrefixFor(issue), issue, actor, seen)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate issue ID: %w", err)
+		}
+		issue.ID = id
+		return table, nil
+	}
+	if seen[issue.ID] {
+		return "", fmt.Errorf("issue %s appears more than once in the batch", issue.ID)
+	}
+	if err := ValidateIssueIDPrefix(issue.ID, bc.ConfigPrefix, bc.AllowedPrefixes); err != nil {
+		return "", err
+	}
+	return table, nil
+}
+
+// classifyUpsertCandidatesInTx marks which candidates already exist in
+// their table and fails the ones whose ID the other table holds (issues and
+// wisps share one ID space). It returns the candidates left to write.
+func classifyUpsertCandidatesInTx(ctx context.Context, tx *sql.Tx, candidates []*upsertCandidate, fail func(int, *types.Issue, error)) ([]*upsertCandidate, error) {
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.issue.ID
+	}
+	inTable := make(map[string]map[string]bool, 2)
+	for _, table := range []string{"issues", "wisps"} {
+		found, err := existingIDsInTx(ctx, tx, table, ids)
+		if err != nil {
+			return nil, err
+		}
+		inTable[table] = found
+	}
+
+	kept := candidates[:0]
+	for _, c := range candidates {
+		sibling := "wisps"
+		if c.table == "wisps" {
+			sibling = "issues"
+		}
+		if inTable[sibling][c.issue.ID] {
+			fail(c.index, c.issue, fmt.Errorf("ID already exists in the %s table (issues and wisps share one ID space)", sibling))
+			continue
+		}
+		c.isNew = !inTable[c.table][c.issue.ID]
+		kept = append(kept, c)
+	}
+	return kept, nil
+}
+
+// checkUpsertOverwritesInTx reads the stored rows of the candidates that
+// overwrite an existing issue, records what each changes, and fails the
+// ones the issue's protection forbids actor to make. It returns the
+// candidates left to write.
+func checkUpsertOverwritesInTx(ctx context.Context, tx *sql.Tx, candidates []*upsertCandidate, actor string, fail func(int, *types.Issue, error)) ([]*upsertCandidate, error) {
+	var ids []string
+	for _, c := range candidates {
+		if !c.isNew {
+			ids = append(ids, c.issue.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return candidates, nil
+	}
+	stored, err := GetIssuesByIDsInTx(ctx, tx, ids, nil)
+	if err != nil {
+		return nil, fmt.Errorf("read issues to overwrite: %w", err)
+	}
+	byID := make(map[string]*types.Issue, len(stored))
+	for _, issue := range stored {
+		byID[issue.ID] = issue
+	}
+
+	kept := candidates[:0]
+	for _, c := range candidates {
+		if c.isNew || byID[c.issue.ID] == nil {
+			kept = append(kept, c)
+			continue
+		}
+		c.old = byID[c.issue.ID]
+		c.changes, err = upsertChangedFields(c.old, c.issue)
+		if err != nil {
+			fail(c.index, c.issue, err)
+			continue
+		}
+		if err := checkUpdateProtection(c.old, c.changes, actor); err != nil {
+			fail(c.index, c.issue, err)
+			continue
+		}
+		kept = append(kept, c)
+	}
+	return kept, nil
+}
+
+// upsertIgnoredFields are the Issue JSON fields an overwrite does not count
+// as changes: identity and bookkeeping the write sets itself, relations
+// merged in rather than overwritten, and values computed at read time.
+var upsertIgnoredFields = map[string]bool{
+	"id": true, "created_at": true, "updated_at": true,
+	"labels": true, "dependencies": true, "comments": true,
+	"derived": true, "annotations": true,
+}
+
+// upsertChangedFields returns the fields issue changes in old, keyed and
+// valued like an UpdateIssue updates map. Timestamps are compared at the
+// one-second resolution the columns store.
+func upsertChangedFields(old, issue *types.Issue) (map[string]interface{}, error) {
+	before, err := issueJSONFields(old)
+	if err != nil {
+		return nil, err
+	}
+	after, err := issueJSONFields(issue)
+	if err != nil {
+		return nil, err
+	}
+	changes := map[string]interface{}{}
+	for _, key := range unionKeys(before, after) {
+		if upsertIgnoredFields[key] || jsonEqual(before[key], after[key]) || sameSecond(before[key], after[key]) {
+			continue
+		}
+		if key == "metadata" {
+			value := string(after[key])
+			if value == "" {
+				value = "{}"
+			}
+			fields, err := changedMetadataFields(old.Metadata, value)
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) > 0 {
+				changes[key] = value
+			}
+			continue
+		}
+		var value interface{}
+		if raw := after[key]; len(raw) > 0 {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, err
+			}
+		}
+		changes[key] = value
+	}
+	return changes, nil
+}
+
+func issueJSONFields(issue *types.Issue) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// sameSecond reports whether a and b are timestamps in the same second.
+func sameSecond(a, b json.RawMessage) bool {
+	var sa, sb string
+	if json.Unmarshal(a, &sa) != nil || json.Unmarshal(b, &sb) != nil {
+		return false
+	}
+	ta, errA := time.Parse(time.RFC3339Nano, sa)
+	tb, errB := time.Parse(time.RFC3339Nano, sb)
+	return errA == nil && errB == nil && ta.Truncate(time.Second).Equal(tb.Truncate(time.Second))
+}
+
+// existingIDsInTx returns which of ids exist in table.
+//
+//nolint:gosec // G201: table is a hardcoded constant; inClause is only placeholders.
+func existingIDsInTx(ctx context.Context, tx *sql.Tx, table string, ids []string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	for start := 0; start < len(ids); start += queryBatchSize {
+		inClause, args := buildSQLInClause(ids[start:min(start+queryBatchSize, len(ids))])
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT id FROM %s WHERE id IN (%s)", table, inClause), args...)
+		if err != nil {
+			return nil, fmt.Errorf("check existing issues in %s: %w", table, err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("check existing issues in %s: %w", table, err)
+			}
+			found[id] = true
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("check existing issues in %s: %w", table, err)
+		}
+	}
+	return found, nil
+}
+
+// upsertIssueRowsInTx writes rows to table with one multi-row UPSERT.
+//
+//nolint:gosec // G201: table is a hardcoded constant; tuples are only placeholders.
+func upsertIssueRowsInTx(ctx context.Context, tx *sql.Tx, table string, rows []*upsertCandidate) error {
+	tuples := make([]string, len(rows))
+	args := make([]any, 0, len(rows)*strings.Count(issueInsertPlaceholders, "?"))
+	for i, c := range rows {
+		tuples[i] = issueInsertPlaceholders
+		args = append(args, issueInsertArgs(c.issue)...)
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO %s (
+			%s
+		) VALUES %s
+		ON DUPLICATE KEY UPDATE
+			%s
+	`, table, issueInsertColumns, strings.Join(tuples, ", "), issueUpsertAssignments(table, false)), args...)
+	if err != nil {
+		return fmt.Errorf("upsert %d issue(s) into %s: %w", len(rows), table, err)
+	}
+	return nil
+}
+
+// recordCreatedEventsInTx records a created event for each new issue, one
+// multi-row INSERT per events table, and returns the tables written.
+//
+//nolint:gosec // G201: table is a hardcoded constant; tuples are only placeholders.
+func recordCreatedEventsInTx(ctx context.Context, tx *sql.Tx, created []*upsertCandidate, actor string) (map[string]bool, error) {
+	changed := map[string]bool{}
+	byTable := map[string][]string{}
+	for _, c := range created {
+		_, eventTable := TableRouting(c.issue)
+		byTable[eventTable] = append(byTable[eventTable], c.issue.ID)
+	}
+	for _, table := range []string{"events", "wisp_events"} {
+		ids := byTable[table]
+		for start := 0; start < len(ids); start += queryBatchSize {
+			batch := ids[start:min(start+queryBatchSize, len(ids))]
+			tuples := make([]string, len(batch))
+			args := make([]any, 0, len(batch)*6)
+			for i, id := range batch {
+				tuples[i] = "(?, ?, ?, ?, ?, ?)"
+				args = append(args, NewEventID(), id, types.EventCreated, actor, "", "")
+			}
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+				"INSERT INTO %s (id, issue_id, event_type, actor, old_value, new_value) VALUES %s",
+				table, strings.Join(tuples, ", ")), args...); err != nil {
+				return nil, fmt.Errorf("record created events in %s: %w", table, err)
+			}
+			changed[table] = true
+		}
+	}
+	return changed, nil
+}
+
+// recordUpdatedEventsInTx records an update event for each overwritten
+// issue the upsert changed, as UpdateIssue does, and returns the tables
+// written.
+func recordUpdatedEventsInTx(ctx context.Context, tx *sql.Tx, candidates []*upsertCandidate, actor string) (map[string]bool, error) {
+	changed := map[string]bool{}
+	for _, c := range candidates {
+		if c.isNew || len(c.changes) == 0 {
+			continue
+		}
+		eventOld, eventUpdates := redactEncryptedFieldsForEvent(c.old, c.changes)
+		oldData, _ := json.Marshal(eventOld)
+		newData, _ := json.Marshal(eventUpdates)
+		_, eventTable := TableRouting(c.issue)
+		if err := RecordFullEventInTable(ctx, tx, eventTable, c.issue.ID, DetermineEventType(c.old, c.changes), actor, string(oldData), string(newData)); err != nil {
+			return nil, fmt.Errorf("record update event for %s: %w", c.issue.ID, err)
+		}
+		changed[eventTable] = true
+	}
+	return changed, nil
+}
+
+// reconcileLeasesInTx drops the lease rows of overwritten issues whose new
+// state is no longer a live claim by the lease holder — the batch form of
+// the reconcile half of RestoreLeaseOnImportInTx, for issues that carry no
+// lease of their own to restore.
+//
+//nolint:gosec // G201: inClause is only placeholders.
+func reconcileLeasesInTx(ctx context.Context, tx *sql.Tx, ids []string) error {
+	for start := 0; start < len(ids); start += queryBatchSize {
+		inClause, args := buildSQLInClause(ids[start:min(start+queryBatchSize, len(ids))])
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			DELETE FROM leases WHERE issue_id IN (%s)
+			  AND NOT EXISTS (
+				SELECT 1 FROM issues i
+				WHERE i.id = leases.issue_id AND i.status = 'in_progress' AND i.assignee = leases.holder
+			  )
+		`, inClause), args...); err != nil {
+			return fmt.Errorf("reconcile leases: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// KnowledgeStore persists knowledge entries crystallized from closed issues
+// (bd crystallize). Callers should type-assert to this interface; backends
+// without the knowledge table do not implement it.
+type KnowledgeStore interface {
+	// UpsertKnowledge inserts the entry, or replaces the title, content, tags,
+	// and provenance of an existing entry with the same ID while keeping its
+	// original created_at.
+	UpsertKnowledge(ctx context.Context, entry *types.KnowledgeEntry) error
+	GetKnowledge(ctx context.Context, id string) (*types.KnowledgeEntry, error)
+	// SearchKnowledge returns entries whose title, content, or tags contain
+	// query (case-insensitive), most recently updated first. An empty query
+	// matches every entry; limit <= 0 means no limit.
+	SearchKnowledge(ctx context.Context, query string, limit int) ([]*types.KnowledgeEntry, error)
+	DeleteKnowledge(ctx context.Context, id string) error
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ErrIssueLocked is returned when another holder has a live lock on an
+// issue.
+var ErrIssueLocked = errors.New("issue is locked")
+
+// IssueLockStore persists advisory edit locks (bd lock). Callers should
+// type-assert to this interface; backends without the issue_locks table do
+// not implement it.
+type IssueLockStore interface {
+	// LockIssue locks an issue for holder until ttl from now. Re-locking an
+	// issue holder already holds extends it. A live lock held by someone
+	// else fails with ErrIssueLocked unless force is set, which takes it
+	// over.
+	LockIssue(ctx context.Context, issueID, holder string, ttl time.Duration, reason string, force bool) (*types.IssueLock, error)
+	// UnlockIssue releases an issue's lock. Releasing a live lock held by
+	// someone else fails with ErrIssueLocked unless force is set.
+	// Unlocking an unlocked issue is a no-op.
+	UnlockIssue(ctx context.Context, issueID, holder string, force bool) error
+	// GetIssueLocks returns the live locks on the given issues, keyed by
+	// issue ID. Unlocked issues are absent.
+	GetIssueLocks(ctx context.Context, issueIDs []string) (map[string]*types.IssueLock, error)
+	// ListIssueLocks returns every live lock, soonest to expire first.
+	ListIssueLocks(ctx context.Context) ([]*types.IssueLock, error)
+}
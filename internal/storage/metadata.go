@@ -108,100 +108,106 @@ func ValidateMetadataSchema(metadata json.RawMessage, schema MetadataSchemaConfi
 			continue
 		}
 
-		// Type-check the value
-		switch fieldSchema.Type {
-		case MetadataFieldString:
-			if _, ok := val.(string); !ok {
-				errs = append(errs, MetadataValidationError{
-					Field:   fieldName,
-					Message: fmt.Sprintf("expected string, got %T", val),
-				})
-			}
+		errs = append(errs, validateMetadataField(fieldName, val, fieldSchema)...)
+	}
 
-		case MetadataFieldInt:
-			num, ok := val.(float64)
-			if !ok {
-				errs = append(errs, MetadataValidationError{
-					Field:   fieldName,
-					Message: fmt.Sprintf("expected int, got %T", val),
-				})
-			} else {
-				if num != float64(int64(num)) {
-					errs = append(errs, MetadataValidationError{
-						Field:   fieldName,
-						Message: fmt.Sprintf("expected int, got float %v", num),
-					})
-				} else {
-					if fieldSchema.Min != nil && num < *fieldSchema.Min {
-						errs = append(errs, MetadataValidationError{
-							Field:   fieldName,
-							Message: fmt.Sprintf("value %v is below minimum %v", num, *fieldSchema.Min),
-						})
-					}
-					if fieldSchema.Max != nil && num > *fieldSchema.Max {
-						errs = append(errs, MetadataValidationError{
-							Field:   fieldName,
-							Message: fmt.Sprintf("value %v exceeds maximum %v", num, *fieldSchema.Max),
-						})
-					}
-				}
-			}
+	return errs
+}
+
+// validateMetadataField type-checks one metadata value against its schema.
+func validateMetadataField(name string, val interface{}, schema MetadataFieldSchema) []MetadataValidationError {
+	var errs []MetadataValidationError
+	switch schema.Type {
+	case MetadataFieldString:
+		if _, ok := val.(string); !ok {
+			errs = append(errs, MetadataValidationError{
+				Field:   name,
+				Message: fmt.Sprintf("expected string, got %T", val),
+			})
+		}
 
-		case MetadataFieldFloat:
-			num, ok := val.(float64)
-			if !ok {
+	case MetadataFieldInt:
+		num, ok := val.(float64)
+		if !ok {
+			errs = append(errs, MetadataValidationError{
+				Field:   name,
+				Message: fmt.Sprintf("expected int, got %T", val),
+			})
+		} else {
+			if num != float64(int64(num)) {
 				errs = append(errs, MetadataValidationError{
-					Field:   fieldName,
-					Message: fmt.Sprintf("expected float, got %T", val),
+					Field:   name,
+					Message: fmt.Sprintf("expected int, got float %v", num),
 				})
 			} else {
-				if fieldSchema.Min != nil && num < *fieldSchema.Min {
+				if schema.Min != nil && num < *schema.Min {
 					errs = append(errs, MetadataValidationError{
-						Field:   fieldName,
-						Message: fmt.Sprintf("value %v is below minimum %v", num, *fieldSchema.Min),
+						Field:   name,
+						Message: fmt.Sprintf("value %v is below minimum %v", num, *schema.Min),
 					})
 				}
-				if fieldSchema.Max != nil && num > *fieldSchema.Max {
+				if schema.Max != nil && num > *schema.Max {
 					errs = append(errs, MetadataValidationError{
-						Field:   fieldName,
-						Message: fmt.Sprintf("value %v exceeds maximum %v", num, *fieldSchema.Max),
+						Field:   name,
+						Message: fmt.Sprintf("value %v exceeds maximum %v", num, *schema.Max),
 					})
 				}
 			}
+		}
 
-		case MetadataFieldBool:
-			if _, ok := val.(bool); !ok {
+	case MetadataFieldFloat:
+		num, ok := val.(float64)
+		if !ok {
+			errs = append(errs, MetadataValidationError{
+				Field:   name,
+				Message: fmt.Sprintf("expected float, got %T", val),
+			})
+		} else {
+			if schema.Min != nil && num < *schema.Min {
 				errs = append(errs, MetadataValidationError{
-					Field:   fieldName,
-					Message: fmt.Sprintf("expected bool, got %T", val),
+					Field:   name,
+					Message: fmt.Sprintf("value %v is below minimum %v", num, *schema.Min),
 				})
 			}
-
-		case MetadataFieldEnum:
-			str, ok := val.(string)
-			if !ok {
+			if schema.Max != nil && num > *schema.Max {
 				errs = append(errs, MetadataValidationError{
-					Field:   fieldName,
-					Message: fmt.Sprintf("expected string (enum), got %T", val),
+					Field:   name,
+					Message: fmt.Sprintf("value %v exceeds maximum %v", num, *schema.Max),
 				})
-			} else {
-				found := false
-				for _, allowed := range fieldSchema.Values {
-					if str == allowed {
-						found = true
-						break
-					}
-				}
-				if !found {
-					errs = append(errs, MetadataValidationError{
-						Field:   fieldName,
-						Message: fmt.Sprintf("value %q is not one of: %v", str, fieldSchema.Values),
-					})
+			}
+		}
+
+	case MetadataFieldBool:
+		if _, ok := val.(bool); !ok {
+			errs = append(errs, MetadataValidationError{
+				Field:   name,
+				Message: fmt.Sprintf("expected bool, got %T", val),
+			})
+		}
+
+	case MetadataFieldEnum:
+		str, ok := val.(string)
+		if !ok {
+			errs = append(errs, MetadataValidationError{
+				Field:   name,
+				Message: fmt.Sprintf("expected string (enum), got %T", val),
+			})
+		} else {
+			found := false
+			for _, allowed := range schema.Values {
+				if str == allowed {
+					found = true
+					break
 				}
 			}
+			if !found {
+				errs = append(errs, MetadataValidationError{
+					Field:   name,
+					Message: fmt.Sprintf("value %q is not one of: %v", str, schema.Values),
+				})
+			}
 		}
 	}
-
 	return errs
 }
 
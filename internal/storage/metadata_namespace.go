@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Export policies for a metadata namespace.
+const (
+	MetadataExportKeep  = "keep"
+	MetadataExportStrip = "strip"
+)
+
+// MetadataNamespace reserves the metadata keys "<Name>.<field>" for one tool
+// or integration (e.g. "tool.github" owns "tool.github.number"), so tools
+// sharing the metadata blob don't overwrite each other's keys.
+type MetadataNamespace struct {
+	Name        string
+	Description string
+	// Fields validates "<Name>.<field>" keys on write. Required fields are
+	// only enforced on issues that carry at least one key of the namespace.
+	Fields map[string]MetadataFieldSchema
+	// Strict rejects keys in the namespace that Fields does not declare.
+	Strict bool
+	// Export is MetadataExportKeep or MetadataExportStrip.
+	Export string
+}
+
+// Owns reports whether key belongs to the namespace.
+func (ns MetadataNamespace) Owns(key string) bool {
+	return strings.HasPrefix(key, ns.Name+".")
+}
+
+// ValidateMetadataNamespaceName checks that name can prefix metadata keys.
+func ValidateMetadataNamespaceName(name string) error {
+	if name == "" || strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid metadata namespace %q: use dot-separated words such as \"tool.github\"", name)
+	}
+	return ValidateMetadataKey(name)
+}
+
+// MetadataNamespaceFor returns the namespace owning key, preferring the most
+// specific when namespaces nest ("tool.github" over "tool"), or nil.
+func MetadataNamespaceFor(key string, namespaces []MetadataNamespace) *MetadataNamespace {
+	var best *MetadataNamespace
+	for i := range namespaces {
+		ns := &namespaces[i]
+		if ns.Owns(key) && (best == nil || len(ns.Name) > len(best.Name)) {
+			best = ns
+		}
+	}
+	return best
+}
+
+// ValidateMetadataNamespaces checks the namespaced keys of a metadata blob
+// against the registered namespaces. Keys outside every namespace are not
+// checked here. An empty list means validation passed.
+func ValidateMetadataNamespaces(metadata json.RawMessage, namespaces []MetadataNamespace) []MetadataValidationError {
+	if len(namespaces) == 0 {
+		return nil
+	}
+	parsed := map[string]interface{}{}
+	if len(metadata) > 0 && string(metadata) != "null" {
+		if err := json.Unmarshal(metadata, &parsed); err != nil {
+			return []MetadataValidationError{{Field: "(root)", Message: "metadata must be a JSON object for namespace validation"}}
+		}
+	}
+
+	keys := make([]string, 0, len(parsed))
+	for k := range parsed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs []MetadataValidationError
+	present := map[string]bool{}
+	for _, key := range keys {
+		ns := MetadataNamespaceFor(key, namespaces)
+		if ns == nil {
+			continue
+		}
+		present[ns.Name] = true
+		field := strings.TrimPrefix(key, ns.Name+".")
+		schema, declared := ns.Fields[field]
+		if !declared {
+			if ns.Strict {
+				errs = append(errs, MetadataValidationError{
+					Field:   key,
+					Message: fmt.Sprintf("not a declared field of namespace %q", ns.Name),
+				})
+			}
+			continue
+		}
+		errs = append(errs, validateMetadataField(key, parsed[key], schema)...)
+	}
+
+	for _, ns := range namespaces {
+		if !present[ns.Name] {
+			continue
+		}
+		fields := make([]string, 0, len(ns.Fields))
+		for f := range ns.Fields {
+			fields = append(fields, f)
+		}
+		sort.Strings(fields)
+		for _, f := range fields {
+			if _, ok := parsed[ns.Name+"."+f]; !ok && ns.Fields[f].Required {
+				errs = append(errs, MetadataValidationError{
+					Field:   ns.Name + "." + f,
+					Message: "required field is missing",
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// StripMetadataNamespaces removes every key owned by one of the named
+// namespaces. It returns metadata unchanged when nothing is removed or when
+// metadata is not a JSON object.
+func StripMetadataNamespaces(metadata json.RawMessage, names []string) json.RawMessage {
+	if len(names) == 0 || len(metadata) == 0 {
+		return metadata
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &m); err != nil || m == nil {
+		return metadata
+	}
+	removed := false
+	for key := range m {
+		for _, name := range names {
+			if (MetadataNamespace{Name: name}).Owns(key) {
+				delete(m, key)
+				removed = true
+				break
+			}
+		}
+	}
+	if !removed {
+		return metadata
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	out, err := json.Marshal(m)
+	if err != nil {
+		return metadata
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testNamespaces() []MetadataNamespace {
+	return []MetadataNamespace{
+		{Name: "tool", Fields: map[string]MetadataFieldSchema{"owner": {Type: MetadataFieldString}}},
+		{Name: "tool.github", Strict: true, Fields: map[string]MetadataFieldSchema{
+			"number": {Type: MetadataFieldInt, Required: true},
+			"url":    {Type: MetadataFieldString},
+		}},
+	}
+}
+
+func TestMetadataNamespaceFor(t *testing.T) {
+	ns := testNamespaces()
+	tests := map[string]string{
+		"tool.github.number": "tool.github",
+		"tool.owner":         "tool",
+		"tool.githubx":       "tool",
+		"toolkit":            "",
+		"jira_key":           "",
+	}
+	for key, want := range tests {
+		got := ""
+		if n := MetadataNamespaceFor(key, ns); n != nil {
+			got = n.Name
+		}
+		if got != want {
+			t.Errorf("MetadataNamespaceFor(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestValidateMetadataNamespaces(t *testing.T) {
+	ns := testNamespaces()
+	tests := []struct {
+		name     string
+		metadata string
+		want     []string // substrings, one per expected error
+	}{
+		{"outside namespaces", `{"jira_key":"X-1","other":3}`, nil},
+		{"valid", `{"tool.github.number":12,"tool.github.url":"u","tool.owner":"me"}`, nil},
+		{"wrong type", `{"tool.github.number":"12"}`, []string{"tool.github.number: expected int"}},
+		{"undeclared in strict", `{"tool.github.number":1,"tool.github.state":"open"}`, []string{"tool.github.state: not a declared field"}},
+		{"undeclared in lax", `{"tool.extra":true}`, nil},
+		{"required missing", `{"tool.github.url":"u"}`, []string{"tool.github.number: required field is missing"}},
+		{"required only when used", `{"tool.owner":"me"}`, nil},
+	}
+	for _, tt := range tests {
+		errs := ValidateMetadataNamespaces(json.RawMessage(tt.metadata), ns)
+		if len(errs) != len(tt.want) {
+			t.Errorf("%s: got %v, want %d errors", tt.name, errs, len(tt.want))
+			continue
+		}
+		for i, e := range errs {
+			if !strings.Contains(e.Error(), tt.want[i]) {
+				t.Errorf("%s: error %q does not contain %q", tt.name, e.Error(), tt.want[i])
+			}
+		}
+	}
+}
+
+func TestStripMetadataNamespaces(t *testing.T) {
+	meta := json.RawMessage(`{"tool.github.number":12,"tool.githubx":1,"keep":"y"}`)
+	got := StripMetadataNamespaces(meta, []string{"tool.github"})
+	var m map[string]interface{}
+	if err := json.Unmarshal(got, &m); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m["tool.github.number"]; ok || len(m) != 2 {
+		t.Errorf("stripped = %s", got)
+	}
+	if got := StripMetadataNamespaces(json.RawMessage(`{"tool.github.n":1}`), []string{"tool.github"}); got != nil {
+		t.Errorf("fully stripped = %s, want nil", got)
+	}
+	if got := StripMetadataNamespaces(meta, []string{"gitlab"}); string(got) != string(meta) {
+		t.Errorf("untouched metadata rewritten: %s", got)
+	}
+}
@@ -0,0 +1,361 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MetadataPatchOp is one operation of an RFC 6902 JSON Patch.
+type MetadataPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"` // "null" for an explicit null, nil when absent
+}
+
+// ParseMetadataPatch decodes and checks a JSON Patch document: an array of
+// add, remove, replace, move, copy, and test operations.
+func ParseMetadataPatch(patch json.RawMessage) ([]MetadataPatchOp, error) {
+	var ops []MetadataPatchOp
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("patch must be a JSON array of operations: %w", err)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("patch has no operations")
+	}
+	for i, op := range ops {
+		if _, err := parseJSONPointer(op.Path); err != nil {
+			return nil, fmt.Errorf("patch op %d: path: %w", i, err)
+		}
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return nil, fmt.Errorf("patch op %d (%s): missing value", i, op.Op)
+			}
+		case "remove":
+		case "move", "copy":
+			if _, err := parseJSONPointer(op.From); err != nil {
+				return nil, fmt.Errorf("patch op %d (%s): from: %w", i, op.Op, err)
+			}
+			if op.Op == "move" && strings.HasPrefix(op.Path+"/", op.From+"/") && op.Path != op.From {
+				return nil, fmt.Errorf("patch op %d (move): cannot move %s into itself", i, op.From)
+			}
+		default:
+			return nil, fmt.Errorf("patch op %d: unknown op %q (want add, remove, replace, move, copy, or test)", i, op.Op)
+		}
+	}
+	return ops, nil
+}
+
+// ApplyMetadataPatch applies a JSON Patch document to existing metadata and
+// returns the result. Operations apply in order and the patch is atomic: if
+// any operation (including a failed test) errors, nothing is returned. Top-
+// level keys created by the patch must pass ValidateMetadataKey, and the
+// result must still be a JSON object.
+func ApplyMetadataPatch(existing, patch json.RawMessage) (json.RawMessage, error) {
+	ops, err := ParseMetadataPatch(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{} = map[string]interface{}{}
+	if trimmed := bytes.TrimSpace(existing); len(trimmed) > 0 && string(trimmed) != "null" {
+		if doc, err = decodeJSONValue(existing); err != nil {
+			return nil, fmt.Errorf("existing metadata is not valid JSON: %w", err)
+		}
+	}
+
+	for i, op := range ops {
+		path, _ := parseJSONPointer(op.Path)
+		switch op.Op {
+		case "add", "replace":
+			value, err := decodeJSONValue(op.Value)
+			if err != nil {
+				return nil, fmt.Errorf("patch op %d (%s): value: %w", i, op.Op, err)
+			}
+			doc, err = patchSet(doc, path, value, op.Op == "replace")
+			if err != nil {
+				return nil, fmt.Errorf("patch op %d (%s %s): %w", i, op.Op, op.Path, err)
+			}
+		case "remove":
+			if doc, _, err = patchRemove(doc, path); err != nil {
+				return nil, fmt.Errorf("patch op %d (remove %s): %w", i, op.Path, err)
+			}
+		case "move":
+			from, _ := parseJSONPointer(op.From)
+			var value interface{}
+			if doc, value, err = patchRemove(doc, from); err != nil {
+				return nil, fmt.Errorf("patch op %d (move from %s): %w", i, op.From, err)
+			}
+			if doc, err = patchSet(doc, path, value, false); err != nil {
+				return nil, fmt.Errorf("patch op %d (move to %s): %w", i, op.Path, err)
+			}
+		case "copy":
+			from, _ := parseJSONPointer(op.From)
+			value, err := patchGet(doc, from)
+			if err != nil {
+				return nil, fmt.Errorf("patch op %d (copy from %s): %w", i, op.From, err)
+			}
+			if value, err = deepCopyJSONValue(value); err != nil {
+				return nil, fmt.Errorf("patch op %d (copy): %w", i, err)
+			}
+			if doc, err = patchSet(doc, path, value, false); err != nil {
+				return nil, fmt.Errorf("patch op %d (copy to %s): %w", i, op.Path, err)
+			}
+		case "test":
+			got, err := patchGet(doc, path)
+			if err != nil {
+				return nil, fmt.Errorf("patch op %d (test %s): %w", i, op.Path, err)
+			}
+			want, err := decodeJSONValue(op.Value)
+			if err != nil {
+				return nil, fmt.Errorf("patch op %d (test): value: %w", i, err)
+			}
+			if !jsonValuesEqual(got, want) {
+				return nil, fmt.Errorf("patch op %d: test failed: %s is not %s", i, op.Path, strings.TrimSpace(string(op.Value)))
+			}
+		}
+	}
+
+	obj, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("patched metadata must be a JSON object")
+	}
+	for key := range obj {
+		if err := ValidateMetadataKey(key); err != nil && !topLevelKeyExisted(existing, key) {
+			return nil, err
+		}
+	}
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patched metadata: %w", err)
+	}
+	return out, nil
+}
+
+// parseJSONPointer splits an RFC 6901 pointer into unescaped reference tokens.
+// The empty pointer refers to the whole document.
+func parseJSONPointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("JSON pointer %q must start with /", p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// patchGet returns the value path refers to in doc.
+func patchGet(doc interface{}, path []string) (interface{}, error) {
+	node := doc
+	for _, tok := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			v, ok := n[tok]
+			if !ok {
+				return nil, fmt.Errorf("no member %q", tok)
+			}
+			node = v
+		case []interface{}:
+			i, err := arrayIndex(tok, len(n), false)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("cannot descend into %s with %q", jsonKind(node), tok)
+		}
+	}
+	return node, nil
+}
+
+// patchSet adds value at path (insert for arrays, set for objects), or with
+// mustExist replaces the existing value there. It returns the updated doc.
+func patchSet(doc interface{}, path []string, value interface{}, mustExist bool) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return patchEdit(doc, path, func(parent interface{}, tok string) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := p[tok]; mustExist && !ok {
+				return nil, fmt.Errorf("no member %q to replace", tok)
+			}
+			p[tok] = value
+			return p, nil
+		case []interface{}:
+			if mustExist {
+				i, err := arrayIndex(tok, len(p), false)
+				if err != nil {
+					return nil, err
+				}
+				p[i] = value
+				return p, nil
+			}
+			i, err := arrayIndex(tok, len(p), true)
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, nil)
+			copy(p[i+1:], p[i:])
+			p[i] = value
+			return p, nil
+		default:
+			return nil, fmt.Errorf("cannot set %q on %s", tok, jsonKind(parent))
+		}
+	})
+}
+
+// patchRemove deletes the value at path and returns the updated doc and the
+// removed value.
+func patchRemove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the whole metadata object")
+	}
+	var removed interface{}
+	doc, err := patchEdit(doc, path, func(parent interface{}, tok string) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			v, ok := p[tok]
+			if !ok {
+				return nil, fmt.Errorf("no member %q to remove", tok)
+			}
+			removed = v
+			delete(p, tok)
+			return p, nil
+		case []interface{}:
+			i, err := arrayIndex(tok, len(p), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = p[i]
+			return append(p[:i], p[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove %q from %s", tok, jsonKind(parent))
+		}
+	})
+	return doc, removed, err
+}
+
+// patchEdit walks to the parent of path's last token and lets edit return
+// the replacement parent, rebuilding the containers above it (arrays may be
+// reallocated by an insert or removal).
+func patchEdit(node interface{}, path []string, edit func(parent interface{}, tok string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return edit(node, path[0])
+	}
+	tok := path[0]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[tok]
+		if !ok {
+			return nil, fmt.Errorf("no member %q", tok)
+		}
+		updated, err := patchEdit(child, path[1:], edit)
+		if err != nil {
+			return nil, err
+		}
+		n[tok] = updated
+		return n, nil
+	case []interface{}:
+		i, err := arrayIndex(tok, len(n), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := patchEdit(n[i], path[1:], edit)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("cannot descend into %s with %q", jsonKind(node), tok)
+	}
+}
+
+// arrayIndex parses an array reference token. With forInsert, "-" and n
+// (one past the end) are accepted and mean append.
+func arrayIndex(tok string, n int, forInsert bool) (int, error) {
+	if forInsert && tok == "-" {
+		return n, nil
+	}
+	if tok == "" || (len(tok) > 1 && tok[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+	i, err := strconv.Atoi(tok)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+	limit := n - 1
+	if forInsert {
+		limit = n
+	}
+	if i > limit {
+		return 0, fmt.Errorf("array index %d out of range (length %d)", i, n)
+	}
+	return i, nil
+}
+
+func decodeJSONValue(raw json.RawMessage) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func deepCopyJSONValue(v interface{}) (interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSONValue(raw)
+}
+
+// jsonValuesEqual compares two decoded values as JSON, so 1 and 1.0 match.
+func jsonValuesEqual(a, b interface{}) bool {
+	ra, errA := json.Marshal(a)
+	rb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	var va, vb interface{}
+	if json.Unmarshal(ra, &va) != nil || json.Unmarshal(rb, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func jsonKind(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case nil:
+		return "null"
+	default:
+		return "a scalar"
+	}
+}
+
+// topLevelKeyExisted reports whether key was already present in metadata, so
+// legacy keys that predate ValidateMetadataKey can still be patched in place.
+func topLevelKeyExisted(metadata json.RawMessage, key string) bool {
+	var m map[string]json.RawMessage
+	if json.Unmarshal(metadata, &m) != nil {
+		return false
+	}
+	_, ok := m[key]
+	return ok
+}
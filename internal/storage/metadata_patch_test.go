@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyMetadataPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		patch    string
+		want     string
+	}{
+		{"add to empty", ``, `[{"op":"add","path":"/retries","value":3}]`, `{"retries":3}`},
+		{"add replaces member", `{"a":1}`, `[{"op":"add","path":"/a","value":2}]`, `{"a":2}`},
+		{"add null", `{}`, `[{"op":"add","path":"/a","value":null}]`, `{"a":null}`},
+		{"nested add", `{"a":{"b":1}}`, `[{"op":"add","path":"/a/c","value":[]}]`, `{"a":{"b":1,"c":[]}}`},
+		{"array insert", `{"l":[1,3]}`, `[{"op":"add","path":"/l/1","value":2}]`, `{"l":[1,2,3]}`},
+		{"array append", `{"l":[1]}`, `[{"op":"add","path":"/l/-","value":2}]`, `{"l":[1,2]}`},
+		{"remove", `{"a":1,"b":2}`, `[{"op":"remove","path":"/a"}]`, `{"b":2}`},
+		{"remove array item", `{"l":[1,2,3]}`, `[{"op":"remove","path":"/l/0"}]`, `{"l":[2,3]}`},
+		{"replace", `{"a":{"b":1}}`, `[{"op":"replace","path":"/a/b","value":"x"}]`, `{"a":{"b":"x"}}`},
+		{"move", `{"a":{"b":1}}`, `[{"op":"move","from":"/a/b","path":"/c"}]`, `{"a":{},"c":1}`},
+		{"copy is deep", `{"a":{"b":1}}`, `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`, `{"a":{"b":1},"c":{"b":2}}`},
+		{"test passes", `{"n":1,"s":"x"}`, `[{"op":"test","path":"/n","value":1.0},{"op":"test","path":"/s","value":"x"}]`, `{"n":1,"s":"x"}`},
+		{"escaped pointer", `{"a/b":1,"m~n":2}`, `[{"op":"remove","path":"/a~1b"},{"op":"replace","path":"/m~0n","value":3}]`, `{"m~n":3}`},
+		{"dotted key", `{}`, `[{"op":"add","path":"/tool.github.number","value":12}]`, `{"tool.github.number":12}`},
+	}
+	for _, tt := range tests {
+		got, err := ApplyMetadataPatch(json.RawMessage(tt.existing), json.RawMessage(tt.patch))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !sameJSON(t, got, tt.want) {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// Untouched numbers keep their exact digits.
+	got, err := ApplyMetadataPatch(json.RawMessage(`{"id":9007199254740993}`), json.RawMessage(`[{"op":"add","path":"/x","value":1}]`))
+	if err != nil || !strings.Contains(string(got), "9007199254740993") {
+		t.Errorf("large number: got %s, %v", got, err)
+	}
+}
+
+func TestApplyMetadataPatchErrors(t *testing.T) {
+	tests := []struct {
+		name, existing, patch, want string
+	}{
+		{"not an array", `{}`, `{"op":"add"}`, "JSON array"},
+		{"empty", `{}`, `[]`, "no operations"},
+		{"unknown op", `{}`, `[{"op":"merge","path":"/a"}]`, "unknown op"},
+		{"missing value", `{}`, `[{"op":"add","path":"/a"}]`, "missing value"},
+		{"bad pointer", `{}`, `[{"op":"remove","path":"a"}]`, "must start with /"},
+		{"remove absent", `{}`, `[{"op":"remove","path":"/a"}]`, "no member"},
+		{"replace absent", `{}`, `[{"op":"replace","path":"/a","value":1}]`, "no member"},
+		{"missing parent", `{}`, `[{"op":"add","path":"/a/b","value":1}]`, "no member"},
+		{"index out of range", `{"l":[1]}`, `[{"op":"add","path":"/l/3","value":1}]`, "out of range"},
+		{"leading zero index", `{"l":[1,2]}`, `[{"op":"remove","path":"/l/01"}]`, "invalid array index"},
+		{"test fails", `{"n":1}`, `[{"op":"test","path":"/n","value":2}]`, "test failed"},
+		{"move into itself", `{"a":{}}`, `[{"op":"move","from":"/a","path":"/a/b"}]`, "into itself"},
+		{"root not object", `{}`, `[{"op":"replace","path":"","value":[1]}]`, "must be a JSON object"},
+		{"remove root", `{}`, `[{"op":"remove","path":""}]`, "whole metadata"},
+		{"invalid new key", `{}`, `[{"op":"add","path":"/bad key","value":1}]`, "invalid metadata key"},
+	}
+	for _, tt := range tests {
+		_, err := ApplyMetadataPatch(json.RawMessage(tt.existing), json.RawMessage(tt.patch))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestApplyMetadataPatchLegacyKey(t *testing.T) {
+	// Keys that predate ValidateMetadataKey can still be edited in place.
+	got, err := ApplyMetadataPatch(json.RawMessage(`{"legacy key":1}`), json.RawMessage(`[{"op":"replace","path":"/legacy key","value":2}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameJSON(t, got, `{"legacy key":2}`) {
+		t.Errorf("got %s", got)
+	}
+}
+
+func sameJSON(t *testing.T, got json.RawMessage, want string) bool {
+	t.Helper()
+	var a, b interface{}
+	if err := json.Unmarshal(got, &a); err != nil {
+		t.Fatalf("result %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &b); err != nil {
+		t.Fatalf("want %s: %v", want, err)
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrRootCauseRequired is returned when a reopen would take an issue past
+// reopen.root-cause-threshold without a root-cause note (see
+// WithReopenNote).
+var ErrRootCauseRequired = errors.New("root-cause note required")
+
+type reopenNoteKey struct{}
+
+// ReopenNote describes a reopen beyond the status change: why it happened
+// and, past reopen.root-cause-threshold, its root cause. Storage logs it in
+// metadata.reopens whenever a closed issue is reopened, whether through
+// ReopenIssue or a status update.
+type ReopenNote struct {
+	Reason    string
+	RootCause string
+}
+
+// WithReopenNote attaches n to reopens made with ctx.
+func WithReopenNote(ctx context.Context, n ReopenNote) context.Context {
+	return context.WithValue(ctx, reopenNoteKey{}, n)
+}
+
+// ReopenNoteFrom returns the note attached to ctx, or the zero note.
+func ReopenNoteFrom(ctx context.Context) ReopenNote {
+	n, _ := ctx.Value(reopenNoteKey{}).(ReopenNote)
+	return n
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// RunStore persists external job executions linked to issues (bd run).
+// Callers should type-assert to this interface; backends without the runs
+// table do not implement it.
+type RunStore interface {
+	// RecordRun inserts the run, or updates the status, URL, duration,
+	// artifacts, and timestamps of an existing run with the same ID while
+	// keeping its original created_at. It also mirrors the status of the
+	// issue's most recent run into metadata.last_run_status.
+	RecordRun(ctx context.Context, run *types.Run, actor string) error
+	// ListRuns returns an issue's runs, most recent first. limit <= 0 means
+	// no limit.
+	ListRuns(ctx context.Context, issueID string, limit int) ([]*types.Run, error)
+}
@@ -60,6 +60,17 @@ func cliCompatibleMigrationSQL(name, sqlText string) string {
 		// schema delta: create the ephemeral leases table, drop the issues/
 		// wisps lease columns 0054 added. row_lock stays (see the migration).
 		return cliMigration0055MoveLeasesToTable
+	case "0064_add_source_formula_columns.up.sql":
+		// Direct DDL for the same reason as 0054. The fresh bundle's wisps
+		// table comes from 0020, so it gets the columns here rather than from
+		// ignored migration 0015.
+		return cliMigration0064AddSourceFormulaColumns
+	case "0066_add_rig_column.up.sql":
+		// Direct DDL for the same reason as 0064.
+		return cliMigration0066AddRigColumn
+	case "0071_add_encrypted_column.up.sql":
+		// Direct DDL for the same reason as 0064.
+		return cliMigration0071AddEncryptedColumn
 	default:
 		return sqlText
 	}
@@ -101,6 +112,19 @@ ALTER TABLE issues DROP COLUMN heartbeat_at;
 ALTER TABLE wisps DROP COLUMN lease_expires_at;
 ALTER TABLE wisps DROP COLUMN heartbeat_at;`
 
+const cliMigration0064AddSourceFormulaColumns = `ALTER TABLE issues ADD COLUMN source_formula VARCHAR(255) DEFAULT '';
+ALTER TABLE issues ADD COLUMN source_location VARCHAR(255) DEFAULT '';
+CREATE INDEX idx_issues_source_formula ON issues (source_formula);
+ALTER TABLE wisps ADD COLUMN source_formula VARCHAR(255) DEFAULT '';
+ALTER TABLE wisps ADD COLUMN source_location VARCHAR(255) DEFAULT '';`
+
+const cliMigration0066AddRigColumn = `ALTER TABLE issues ADD COLUMN rig VARCHAR(255) DEFAULT '';
+CREATE INDEX idx_issues_rig ON issues (rig);
+ALTER TABLE wisps ADD COLUMN rig VARCHAR(255) DEFAULT '';`
+
+const cliMigration0071AddEncryptedColumn = `ALTER TABLE issues ADD COLUMN is_encrypted TINYINT(1) DEFAULT 0;
+ALTER TABLE wisps ADD COLUMN is_encrypted TINYINT(1) DEFAULT 0;`
+
 const cliMigration0041SplitDependenciesTarget = `DELETE FROM dolt_nonlocal_tables;
 CALL DOLT_COMMIT('-Am', 'disable nonlocal tables for fk migrations');
 SET FOREIGN_KEY_CHECKS = 0;
@@ -0,0 +1,19 @@
+-- Migration 0059: Create the knowledge table for crystallized knowledge.
+--
+-- `bd crystallize` distills durable knowledge (what was learned, how it was
+-- resolved) out of closed beads into standalone entries that outlive issue
+-- compaction and feed `bd prime`. Entries replicate like issues, so the
+-- primary key is computed in application code from the source issue IDs
+-- (never UUID()), and bonded_from records that provenance as a JSON array of
+-- BondRef objects.
+CREATE TABLE IF NOT EXISTS knowledge (
+    id VARCHAR(64) PRIMARY KEY,
+    title VARCHAR(500) NOT NULL,
+    content LONGTEXT NOT NULL,
+    tags JSON,
+    bonded_from JSON NOT NULL,
+    created_by VARCHAR(255) DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_knowledge_updated_at (updated_at)
+);
@@ -0,0 +1,24 @@
+-- Migration 0060: Create the runs table for external job executions.
+--
+-- A run links an issue to one execution of an external job such as a CI
+-- build or an evaluation suite, recorded with `bd run record` (directly or
+-- from a webhook payload). Runs replicate like issues, so the primary key is
+-- computed in application code from the issue, job name, and external ID
+-- (never UUID()); re-recording the same external job updates its row. There
+-- is no foreign key because runs may reference wisps as well as issues.
+CREATE TABLE IF NOT EXISTS runs (
+    id VARCHAR(64) PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) DEFAULT '',
+    external_id VARCHAR(255) DEFAULT '',
+    status VARCHAR(32) NOT NULL,
+    url TEXT,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    artifacts JSON,
+    started_at DATETIME NULL,
+    finished_at DATETIME NULL,
+    created_by VARCHAR(255) DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_runs_issue_id (issue_id, created_at)
+);
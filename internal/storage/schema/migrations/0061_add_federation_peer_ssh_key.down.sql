@@ -0,0 +1,19 @@
+SET @sql = IF(
+  (SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'ssh_passphrase_encrypted') > 0,
+  'ALTER TABLE federation_peers DROP COLUMN ssh_passphrase_encrypted',
+  'SELECT 1'
+);
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
+
+SET @sql = IF(
+  (SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'ssh_key_path') > 0,
+  'ALTER TABLE federation_peers DROP COLUMN ssh_key_path',
+  'SELECT 1'
+);
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
@@ -0,0 +1,33 @@
+-- Migration 0061: SSH key authentication for federation peers.
+--
+-- Peers reachable only over SSH (git+ssh remotes) authenticate with a private
+-- key rather than a SQL user. ssh_key_path is the key file on this machine;
+-- ssh_passphrase_encrypted is its passphrase, encrypted with the same
+-- credential key as password_encrypted.
+--
+-- Guarded so the migration is idempotent on a schema_migrations row that
+-- regressed without its DDL rolled back (see 0054).
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'ssh_key_path'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE federation_peers ADD COLUMN ssh_key_path VARCHAR(1024)',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'ssh_passphrase_encrypted'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE federation_peers ADD COLUMN ssh_passphrase_encrypted BLOB',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
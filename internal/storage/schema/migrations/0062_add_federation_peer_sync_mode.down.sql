@@ -0,0 +1,9 @@
+SET @sql = IF(
+  (SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'sync_mode') > 0,
+  'ALTER TABLE federation_peers DROP COLUMN sync_mode',
+  'SELECT 1'
+);
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
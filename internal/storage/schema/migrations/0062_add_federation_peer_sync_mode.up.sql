@@ -0,0 +1,19 @@
+-- Migration 0062: per-peer sync direction for federation peers.
+--
+-- sync_mode is bidirectional, pull-only (a read-only upstream), or push-only
+-- (a write-only mirror). Existing rows get an empty value, which means
+-- bidirectional, so peers added before this migration keep syncing both ways.
+--
+-- Guarded so the migration is idempotent (see 0054).
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'sync_mode'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE federation_peers ADD COLUMN sync_mode VARCHAR(16) NOT NULL DEFAULT ''''',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
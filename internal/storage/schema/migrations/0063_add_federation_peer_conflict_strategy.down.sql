@@ -0,0 +1,9 @@
+SET @sql = IF(
+  (SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'conflict_strategy') > 0,
+  'ALTER TABLE federation_peers DROP COLUMN conflict_strategy',
+  'SELECT 1'
+);
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
@@ -0,0 +1,19 @@
+-- Migration 0063: per-peer conflict strategy for federation peers.
+--
+-- conflict_strategy is ours, theirs, newest (later updated_at wins), or merge
+-- (field-level three-way merge). Existing rows get an empty value, which
+-- leaves merge conflicts for manual resolution as before.
+--
+-- Guarded so the migration is idempotent (see 0054).
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'conflict_strategy'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE federation_peers ADD COLUMN conflict_strategy VARCHAR(16) NOT NULL DEFAULT ''''',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
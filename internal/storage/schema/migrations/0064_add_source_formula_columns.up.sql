@@ -0,0 +1,50 @@
+-- Migration 0064: persist formula source tracing on issues.
+--
+-- Cooking a formula stamps each step's issue with the formula that defined
+-- the step (source_formula) and its path within it (source_location, e.g.
+-- "steps[2].children[0]"). Until now those fields only lived in memory during
+-- cook and were dropped on insert, so nothing could ask which issues a
+-- formula produced. Existing rows get empty values.
+--
+-- Only issues is altered here. wisps is dolt-ignored, so its schema is
+-- clone-local and gets the same columns from ignored migration 0015 (see
+-- ignored/0013 for why synced migrations cannot reliably reach it).
+--
+-- Guarded so the migration is idempotent (see 0054).
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'issues'
+      AND COLUMN_NAME = 'source_formula'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE issues ADD COLUMN source_formula VARCHAR(255) DEFAULT ''''',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'issues'
+      AND COLUMN_NAME = 'source_location'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE issues ADD COLUMN source_location VARCHAR(255) DEFAULT ''''',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
+
+-- idx_issues_source_formula: bd list --formula and bd trace filter on it.
+SET @needs_index = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.STATISTICS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'issues'
+      AND INDEX_NAME = 'idx_issues_source_formula'
+);
+SET @sql = IF(@needs_index = 1,
+    'CREATE INDEX idx_issues_source_formula ON issues (source_formula)',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
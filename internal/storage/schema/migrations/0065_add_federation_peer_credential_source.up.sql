@@ -0,0 +1,20 @@
+-- Migration 0065: external credential sources for federation peers.
+--
+-- credential_source is env:VAR_NAME (read the password from an environment
+-- variable) or cmd:helper (ask a git-credential-helper style program). It is
+-- resolved at sync time, so a peer using it has no password stored. Existing
+-- rows get an empty value and keep using their stored credentials.
+--
+-- Guarded so the migration is idempotent (see 0054).
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'credential_source'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE federation_peers ADD COLUMN credential_source VARCHAR(1024) NOT NULL DEFAULT ''''',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
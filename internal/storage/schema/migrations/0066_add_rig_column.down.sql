@@ -0,0 +1,13 @@
+-- Roll back the rig column. Guarded like the up migration.
+
+DROP INDEX IF EXISTS idx_issues_rig ON issues;
+
+SET @sql = IF(
+  (SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'issues'
+      AND COLUMN_NAME = 'rig') > 0,
+  'ALTER TABLE issues DROP COLUMN rig',
+  'SELECT 1'
+);
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
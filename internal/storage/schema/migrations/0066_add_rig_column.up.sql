@@ -0,0 +1,36 @@
+-- Migration 0066: scope issues to a rig.
+--
+-- A rig is a machine or fleet of agents working one beads database. bd
+-- --rig <name> stamps new issues with the rig and scopes list, ready, and
+-- claims to it, and bd rig stats/health roll up per rig. Existing rows get
+-- an empty rig, which belongs to no rig and is visible without --rig.
+--
+-- Only issues is altered here; wisps get the column from ignored migration
+-- 0016 (see 0064).
+--
+-- Guarded so the migration is idempotent (see 0054).
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'issues'
+      AND COLUMN_NAME = 'rig'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE issues ADD COLUMN rig VARCHAR(255) DEFAULT ''''',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
+
+-- idx_issues_rig: --rig scoping and bd rig stats filter on it.
+SET @needs_index = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.STATISTICS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'issues'
+      AND INDEX_NAME = 'idx_issues_rig'
+);
+SET @sql = IF(@needs_index = 1,
+    'CREATE INDEX idx_issues_rig ON issues (rig)',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
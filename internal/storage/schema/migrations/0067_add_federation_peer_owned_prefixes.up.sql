@@ -0,0 +1,21 @@
+-- Migration 0067: issue ownership for sovereign federation peers.
+--
+-- owned_prefixes is a comma-separated list of the issue ID prefixes a peer
+-- owns. Issues with those prefixes (or whose source_repo names the peer) can
+-- only be changed locally with --override-sovereignty when the peer has a
+-- sovereignty tier of T2 or above. Existing rows get an empty value and own
+-- nothing.
+--
+-- Guarded so the migration is idempotent (see 0054).
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'owned_prefixes'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE federation_peers ADD COLUMN owned_prefixes VARCHAR(1024) NOT NULL DEFAULT ''''',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
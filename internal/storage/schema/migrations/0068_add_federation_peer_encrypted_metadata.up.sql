@@ -0,0 +1,35 @@
+-- Migration 0068: optional encryption of federation peer usernames and URLs.
+--
+-- Usernames and remote URLs can name internal hosts or personal accounts,
+-- and federation_peers is committed to Dolt history. A peer stored with
+-- encrypted metadata keeps its username and URL in these columns, encrypted
+-- with the same local credential key as password_encrypted; its plaintext
+-- remote_url is left empty and username NULL. Existing rows stay plaintext
+-- until 'bd federation encrypt-metadata' upgrades them, since the key lives
+-- on disk and is not available to SQL.
+--
+-- Guarded so the migration is idempotent (see 0054).
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'remote_url_encrypted'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE federation_peers ADD COLUMN remote_url_encrypted BLOB',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'federation_peers'
+      AND COLUMN_NAME = 'username_encrypted'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE federation_peers ADD COLUMN username_encrypted BLOB',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
@@ -0,0 +1,16 @@
+-- Migration 0069: Create the issue_slugs table for human-friendly issue names.
+--
+-- A slug (fix-login-timeout) names an issue anywhere its ID is accepted. The
+-- slug is the primary key, so no two issues can share one, and renaming an
+-- issue's slug keeps the old row with is_current = 0 so links using it keep
+-- resolving. created_at is set in application code (never NOW()) so every
+-- clone writes identical rows. There is no foreign key; deleting an issue
+-- deletes its slugs in the same transaction.
+CREATE TABLE IF NOT EXISTS issue_slugs (
+    slug VARCHAR(64) PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    is_current TINYINT(1) NOT NULL DEFAULT 1,
+    created_by VARCHAR(255) DEFAULT '',
+    created_at DATETIME NOT NULL,
+    INDEX idx_issue_slugs_issue_id (issue_id, is_current)
+);
@@ -0,0 +1,17 @@
+-- Migration 0070: Create the issue_locks table for advisory edit locks.
+--
+-- A lock (bd lock) tells other people that someone is editing an issue, so
+-- bd edit and bd update refuse to overwrite it unless forced. One row per
+-- locked issue; a row past expires_at is no lock at all and is replaced by
+-- the next bd lock. The table is versioned so locks travel with sync to
+-- every clone. Timestamps are set in application code (never NOW()) so
+-- clones write identical rows. There is no foreign key; deleting an issue
+-- deletes its lock in the same transaction.
+CREATE TABLE IF NOT EXISTS issue_locks (
+    issue_id VARCHAR(255) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    reason TEXT,
+    locked_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    INDEX idx_issue_locks_expires (expires_at)
+);
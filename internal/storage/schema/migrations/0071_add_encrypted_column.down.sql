@@ -0,0 +1,12 @@
+-- Roll back the encrypted column. Guarded like the up migration. Rows that
+-- were encrypted keep their ciphertext.
+
+SET @sql = IF(
+  (SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'issues'
+      AND COLUMN_NAME = 'is_encrypted') > 0,
+  'ALTER TABLE issues DROP COLUMN is_encrypted',
+  'SELECT 1'
+);
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
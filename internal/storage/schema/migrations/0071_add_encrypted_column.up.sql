@@ -0,0 +1,26 @@
+-- Migration 0071: flag issues whose description, design, and notes are
+-- stored encrypted.
+--
+-- bd create/update --encrypted encrypts those fields with the workspace's
+-- issue key (.beads/.beads-issue-key) before they are written; GetIssue
+-- decrypts them. Existing rows are unencrypted.
+--
+-- ENCRYPTED is a keyword to Dolt's parser, so the column is is_encrypted;
+-- the "encrypted" update field maps to it, as "wisp" maps to ephemeral.
+--
+-- Only issues is altered here; wisps get the column from ignored migration
+-- 0018 (see 0064).
+--
+-- Guarded so the migration is idempotent (see 0054).
+
+SET @needs_add = (
+    SELECT IF(COUNT(*) = 0, 1, 0)
+    FROM INFORMATION_SCHEMA.COLUMNS
+    WHERE TABLE_SCHEMA = DATABASE()
+      AND TABLE_NAME = 'issues'
+      AND COLUMN_NAME = 'is_encrypted'
+);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE issues ADD COLUMN is_encrypted TINYINT(1) DEFAULT 0',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
@@ -0,0 +1,33 @@
+-- Ignored migration 0015: wisps.source_formula and wisps.source_location.
+--
+-- Synced migration 0064 adds these columns to issues. wisps is dolt-ignored,
+-- so its schema is clone-local and is carried on this track instead (see
+-- 0013): fresh bootstraps get the columns right after ignored/0001, and
+-- upgraded workspaces get them on their next store open. Guarded so it is a
+-- no-op when the columns exist or there is no local wisps table yet.
+SET @has_wisps = (
+    SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES
+    WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'wisps'
+);
+
+SET @needs_add = IF(@has_wisps > 0 AND
+    (SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE TABLE_SCHEMA = DATABASE()
+          AND TABLE_NAME = 'wisps'
+          AND COLUMN_NAME = 'source_formula') = 0,
+    1, 0);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE wisps ADD COLUMN source_formula VARCHAR(255) DEFAULT ''''',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
+
+SET @needs_add = IF(@has_wisps > 0 AND
+    (SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE TABLE_SCHEMA = DATABASE()
+          AND TABLE_NAME = 'wisps'
+          AND COLUMN_NAME = 'source_location') = 0,
+    1, 0);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE wisps ADD COLUMN source_location VARCHAR(255) DEFAULT ''''',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
@@ -0,0 +1,34 @@
+-- Full-text search index (bd search). Both tables are derived data, rebuilt
+-- from issues and wisps by issueops.RefreshSearchIndexInTx, so they are
+-- dolt_ignored ("search_index_%") and live on this clone-local track: each
+-- clone indexes whatever it has pulled, and a pull never conflicts on them.
+--
+-- search_index_terms has one row per (term, issue, field) with the term's
+-- frequency; the (term, issue_id, field) primary key serves both exact
+-- and prefix lookups. search_index_docs records the updated_at each issue
+-- was indexed at, so a refresh re-reads only issues that changed since.
+-- Same __temp__ + conditional RENAME pattern as 0012: create only when
+-- absent, never touch an existing table.
+DROP TABLE IF EXISTS __temp__search_index_terms;
+CREATE TABLE __temp__search_index_terms (
+    term VARCHAR(64) NOT NULL,
+    issue_id VARCHAR(255) NOT NULL,
+    field TINYINT NOT NULL,
+    frequency INT NOT NULL,
+    PRIMARY KEY (term, issue_id, field),
+    INDEX idx_search_index_terms_issue (issue_id)
+);
+SET @exists = (SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'search_index_terms');
+SET @sql = IF(@exists = 0, 'RENAME TABLE __temp__search_index_terms TO search_index_terms', 'DROP TABLE __temp__search_index_terms');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
+
+DROP TABLE IF EXISTS __temp__search_index_docs;
+CREATE TABLE __temp__search_index_docs (
+    issue_id VARCHAR(255) PRIMARY KEY,
+    updated_at DATETIME,
+    indexed_at DATETIME NOT NULL,
+    term_count INT NOT NULL
+);
+SET @exists = (SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'search_index_docs');
+SET @sql = IF(@exists = 0, 'RENAME TABLE __temp__search_index_docs TO search_index_docs', 'DROP TABLE __temp__search_index_docs');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
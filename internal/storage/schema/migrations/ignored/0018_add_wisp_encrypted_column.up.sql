@@ -0,0 +1,20 @@
+-- Ignored migration 0018: wisps.is_encrypted.
+--
+-- Synced migration 0071 adds the column to issues. wisps is dolt-ignored,
+-- so it is carried on this track instead (see 0013 and 0015). Guarded so it
+-- is a no-op when the column exists or there is no local wisps table yet.
+SET @has_wisps = (
+    SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES
+    WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'wisps'
+);
+
+SET @needs_add = IF(@has_wisps > 0 AND
+    (SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE TABLE_SCHEMA = DATABASE()
+          AND TABLE_NAME = 'wisps'
+          AND COLUMN_NAME = 'is_encrypted') = 0,
+    1, 0);
+SET @sql = IF(@needs_add = 1,
+    'ALTER TABLE wisps ADD COLUMN is_encrypted TINYINT(1) DEFAULT 0',
+    'SELECT 1');
+PREPARE stmt FROM @sql; EXECUTE stmt; DEALLOCATE PREPARE stmt;
@@ -224,6 +224,7 @@ var doltIgnorePatterns = []string{
 	"leases",
 	"local_metadata",
 	"repo_mtimes",
+	"search_index_%",
 	"wisp_%",
 	"wisps",
 }
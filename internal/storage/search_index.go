@@ -0,0 +1,14 @@
+package storage
+
+import "context"
+
+// SearchIndexer maintains the full-text search index that answers
+// IssueFilter.FullText. The index is derived, clone-local data: callers
+// refresh it before a full-text search rather than on every write, so
+// issues changed by a pull are picked up too. Backends without the index
+// tables do not implement it; callers then fall back to substring search.
+type SearchIndexer interface {
+	// RefreshSearchIndex indexes issues added or changed since the last
+	// refresh, drops deleted ones, and returns how many it indexed.
+	RefreshSearchIndex(ctx context.Context) (int, error)
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ErrSlugTaken is returned when a slug is already held by another issue,
+// currently or as an old name.
+var ErrSlugTaken = errors.New("slug already taken")
+
+// SlugStore persists human-friendly issue slugs (bd slug). Callers should
+// type-assert to this interface; backends without the issue_slugs table do
+// not implement it.
+type SlugStore interface {
+	// SetIssueSlug makes slug the issue's current slug, keeping its previous
+	// slug as an old name. An empty slug is generated from the issue's
+	// title, with a numeric suffix if another issue holds it. It returns
+	// the slug set.
+	SetIssueSlug(ctx context.Context, issueID, slug, actor string) (string, error)
+	// ListIssueSlugs returns an issue's slugs, current first, then newest
+	// first.
+	ListIssueSlugs(ctx context.Context, issueID string) ([]*types.IssueSlug, error)
+	// ResolveSlug returns the ID of the issue holding slug, currently or as
+	// an old name, or an error wrapping ErrNotFound.
+	ResolveSlug(ctx context.Context, slug string) (string, error)
+	// CurrentSlugs returns the current slugs of the given issues, keyed by
+	// issue ID. Issues without a slug are absent.
+	CurrentSlugs(ctx context.Context, issueIDs []string) (map[string]string, error)
+	// GenerateIssueSlugs gives every issue without a slug one generated
+	// from its title.
+	GenerateIssueSlugs(ctx context.Context, opts SlugGenerationOptions, actor string) ([]*types.IssueSlug, error)
+}
+
+// SlugGenerationOptions selects the issues GenerateIssueSlugs names.
+type SlugGenerationOptions struct {
+	// IncludeClosed also names closed issues.
+	IncludeClosed bool
+	// DryRun computes the slugs without writing them.
+	DryRun bool
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SovereignTier reports whether a sovereignty tier restricts local writes to
+// the peer's issues. T1 means no restrictions, and peers without a tier are
+// unrestricted too.
+func SovereignTier(tier string) bool {
+	switch strings.ToUpper(strings.TrimSpace(tier)) {
+	case "T2", "T3", "T4":
+		return true
+	}
+	return false
+}
+
+// SplitOwnedPrefixes parses the comma-separated owned_prefixes column.
+func SplitOwnedPrefixes(s string) []string {
+	var prefixes []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	return prefixes
+}
+
+// JoinOwnedPrefixes formats prefixes for the owned_prefixes column.
+func JoinOwnedPrefixes(prefixes []string) string {
+	return strings.Join(prefixes, ",")
+}
+
+// SovereigntyPolicy decides which issues belong to sovereign federation
+// peers. A peer owns the issues whose ID starts with one of its owned
+// prefixes followed by "-", and the issues whose source_repo is the peer's
+// name. Issues with the local prefix are never foreign.
+type SovereigntyPolicy struct {
+	localPrefix string
+	owners      []sovereignOwner
+}
+
+type sovereignOwner struct {
+	peer     string
+	tier     string
+	prefixes []string
+}
+
+// NewSovereigntyPolicy builds the policy for the sovereign peers among
+// peers. localPrefix is this workspace's issue prefix.
+func NewSovereigntyPolicy(peers []*FederationPeer, localPrefix string) *SovereigntyPolicy {
+	p := &SovereigntyPolicy{localPrefix: localPrefix}
+	for _, peer := range peers {
+		if peer == nil || !SovereignTier(peer.Sovereignty) {
+			continue
+		}
+		p.owners = append(p.owners, sovereignOwner{
+			peer:     peer.Name,
+			tier:     strings.ToUpper(peer.Sovereignty),
+			prefixes: peer.OwnedPrefixes,
+		})
+	}
+	sort.Slice(p.owners, func(i, j int) bool { return p.owners[i].peer < p.owners[j].peer })
+	return p
+}
+
+// Empty reports whether no sovereign peer owns anything, so no write can be
+// refused.
+func (p *SovereigntyPolicy) Empty() bool {
+	return p == nil || len(p.owners) == 0
+}
+
+// Owner returns the sovereign peer that owns the issue and its tier, or ""
+// if the issue is local. When several prefixes match, the longest wins.
+func (p *SovereigntyPolicy) Owner(id, sourceRepo string) (peer, tier string) {
+	if p.Empty() || (p.localPrefix != "" && strings.HasPrefix(id, p.localPrefix+"-")) {
+		return "", ""
+	}
+	best := -1
+	for _, o := range p.owners {
+		if sourceRepo != "" && sourceRepo == o.peer && best < 0 {
+			peer, tier = o.peer, o.tier
+		}
+		for _, prefix := range o.prefixes {
+			if len(prefix) > best && strings.HasPrefix(id, prefix+"-") {
+				peer, tier, best = o.peer, o.tier, len(prefix)
+			}
+		}
+	}
+	return peer, tier
+}
+
+// Check returns a wrapped ErrSovereignIssue if a sovereign peer owns the
+// issue.
+func (p *SovereigntyPolicy) Check(id, sourceRepo string) error {
+	peer, tier := p.Owner(id, sourceRepo)
+	if peer == "" {
+		return nil
+	}
+	return fmt.Errorf("%w: %s belongs to peer %s (sovereignty %s); make the change on %s and sync it here, or pass --override-sovereignty",
+		ErrSovereignIssue, id, peer, tier, peer)
+}
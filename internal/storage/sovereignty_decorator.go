@@ -0,0 +1,407 @@
+// Package storage — sovereignty_decorator.go
+//
+// SovereigntyGuardStore is a decorator around DoltStorage that refuses local
+// writes to issues owned by a sovereign federation peer (sovereignty T2 or
+// stricter). Such issues change on the owning peer and arrive here by sync;
+// editing them locally forks the peer's record and loses to the next pull.
+//
+// Usage:
+//
+//	store = storage.NewSovereigntyGuardStore(rawStore)
+//
+// The CLI leaves the guard out of the chain for --override-sovereignty.
+// Creating issues is never refused, and neither are writes to issues with
+// the local prefix.
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SovereigntyGuardStore wraps a DoltStorage and rejects mutations of issues
+// owned by sovereign federation peers with ErrSovereignIssue. Non-mutation
+// methods pass through to the inner store unchanged.
+type SovereigntyGuardStore struct {
+	DoltStorage             // embed for passthrough of non-overridden methods
+	inner       DoltStorage // the real store
+
+	once      sync.Once
+	policy    *SovereigntyPolicy
+	policyErr error
+}
+
+// NewSovereigntyGuardStore wraps store with sovereignty write guards. The
+// peer list is read on the first guarded write.
+func NewSovereigntyGuardStore(store DoltStorage) *SovereigntyGuardStore {
+	return &SovereigntyGuardStore{DoltStorage: store, inner: store}
+}
+
+// Unwrap returns the underlying store, satisfying Unwrapper.
+func (g *SovereigntyGuardStore) Unwrap() DoltStorage { return g.inner }
+
+// ── Issue mutations ─────────────────────────────────────────────────
+
+// UpdateIssue refuses to update a sovereign peer's issue.
+func (g *SovereigntyGuardStore) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.UpdateIssue(ctx, id, updates, actor)
+}
+
+// UpdateIssueChecked refuses to update a sovereign peer's issue.
+func (g *SovereigntyGuardStore) UpdateIssueChecked(ctx context.Context, id string, updates map[string]interface{}, actor string, opts UpdateIssueOptions) error {
+	if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.UpdateIssueChecked(ctx, id, updates, actor, opts)
+}
+
+// ReopenIssue refuses to reopen a sovereign peer's issue.
+func (g *SovereigntyGuardStore) ReopenIssue(ctx context.Context, id string, reason string, actor string) error {
+	if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.ReopenIssue(ctx, id, reason, actor)
+}
+
+// UpdateIssueType refuses to retype a sovereign peer's issue.
+func (g *SovereigntyGuardStore) UpdateIssueType(ctx context.Context, id string, issueType string, actor string) error {
+	if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.UpdateIssueType(ctx, id, issueType, actor)
+}
+
+// UpdateIssueID refuses to rename a sovereign peer's issue.
+func (g *SovereigntyGuardStore) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
+	if err := g.check(ctx, oldID, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.UpdateIssueID(ctx, oldID, newID, issue, actor)
+}
+
+// CloseIssue refuses to close a sovereign peer's issue.
+func (g *SovereigntyGuardStore) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
+	if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.CloseIssue(ctx, id, reason, actor, session)
+}
+
+// CloseIssueChecked refuses to close a sovereign peer's issue.
+func (g *SovereigntyGuardStore) CloseIssueChecked(ctx context.Context, id string, actor string, opts CloseIssueOptions) (CloseIssueResult, error) {
+	if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+		return CloseIssueResult{}, err
+	}
+	return g.inner.CloseIssueChecked(ctx, id, actor, opts)
+}
+
+// DeleteIssue refuses to delete a sovereign peer's issue.
+func (g *SovereigntyGuardStore) DeleteIssue(ctx context.Context, id string) error {
+	if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.DeleteIssue(ctx, id)
+}
+
+// DeleteIssues refuses the whole batch if any issue in it belongs to a
+// sovereign peer. Dry runs pass through so the preview still lists them.
+func (g *SovereigntyGuardStore) DeleteIssues(ctx context.Context, ids []string, cascade bool, force bool, dryRun bool) (*types.DeleteIssuesResult, error) {
+	if !dryRun {
+		for _, id := range ids {
+			if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return g.inner.DeleteIssues(ctx, ids, cascade, force, dryRun)
+}
+
+// UpsertIssues reports each issue that belongs to a sovereign peer as
+// failed and upserts the rest, so one peer issue does not sink a batch.
+// Issues without an ID are new and never refused.
+func (g *SovereigntyGuardStore) UpsertIssues(ctx context.Context, issues []*types.Issue, actor string) (*UpsertIssuesResult, error) {
+	var refused []UpsertFailure
+	var allowed []*types.Issue
+	var positions []int // index in issues of each allowed issue
+	for i, issue := range issues {
+		if issue != nil && issue.ID != "" {
+			if err := g.check(ctx, issue.ID, g.inner.GetIssue); err != nil {
+				refused = append(refused, UpsertFailure{Index: i, ID: issue.ID, Err: err})
+				continue
+			}
+		}
+		allowed = append(allowed, issue)
+		positions = append(positions, i)
+	}
+	if len(refused) == 0 {
+		return g.inner.UpsertIssues(ctx, issues, actor)
+	}
+	result := &UpsertIssuesResult{}
+	if len(allowed) > 0 {
+		inner, err := g.inner.UpsertIssues(ctx, allowed, actor)
+		if err != nil {
+			return nil, err
+		}
+		result = inner
+		for i := range result.Failed {
+			result.Failed[i].Index = positions[result.Failed[i].Index]
+		}
+	}
+	result.Failed = append(result.Failed, refused...)
+	sort.SliceStable(result.Failed, func(i, j int) bool { return result.Failed[i].Index < result.Failed[j].Index })
+	return result, nil
+}
+
+// ClaimIssue refuses to claim a sovereign peer's issue.
+func (g *SovereigntyGuardStore) ClaimIssue(ctx context.Context, id string, actor string) error {
+	if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.ClaimIssue(ctx, id, actor)
+}
+
+// UnclaimIssue refuses to release a claim on a sovereign peer's issue.
+func (g *SovereigntyGuardStore) UnclaimIssue(ctx context.Context, id string, actor string, force bool) error {
+	if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.UnclaimIssue(ctx, id, actor, force)
+}
+
+// UnclaimIssueIfAssignee refuses to release a claim on a sovereign peer's issue.
+func (g *SovereigntyGuardStore) UnclaimIssueIfAssignee(ctx context.Context, id string, actor string, expectedAssignee string) error {
+	if err := g.check(ctx, id, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.UnclaimIssueIfAssignee(ctx, id, actor, expectedAssignee)
+}
+
+// ── Dependency mutations ────────────────────────────────────────────
+
+// AddDependency refuses to add an edge from a sovereign peer's issue.
+// Depending on a peer's issue from a local one is allowed.
+func (g *SovereigntyGuardStore) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	if err := g.check(ctx, dep.IssueID, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.AddDependency(ctx, dep, actor)
+}
+
+// AddDependencyWithOptions refuses to add an edge from a sovereign peer's issue.
+func (g *SovereigntyGuardStore) AddDependencyWithOptions(ctx context.Context, dep *types.Dependency, actor string, opts DependencyAddOptions) error {
+	if err := g.check(ctx, dep.IssueID, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.AddDependencyWithOptions(ctx, dep, actor, opts)
+}
+
+// RemoveDependency refuses to remove an edge from a sovereign peer's issue.
+func (g *SovereigntyGuardStore) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	if err := g.check(ctx, issueID, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.RemoveDependency(ctx, issueID, dependsOnID, actor)
+}
+
+// RemoveDependencyWithOptions refuses to remove an edge from a sovereign peer's issue.
+func (g *SovereigntyGuardStore) RemoveDependencyWithOptions(ctx context.Context, issueID, dependsOnID string, actor string, opts DependencyRemoveOptions) error {
+	if err := g.check(ctx, issueID, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.RemoveDependencyWithOptions(ctx, issueID, dependsOnID, actor, opts)
+}
+
+// ── Label and comment mutations ─────────────────────────────────────
+
+// AddLabel refuses to label a sovereign peer's issue.
+func (g *SovereigntyGuardStore) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	if err := g.check(ctx, issueID, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.AddLabel(ctx, issueID, label, actor)
+}
+
+// RemoveLabel refuses to unlabel a sovereign peer's issue.
+func (g *SovereigntyGuardStore) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	if err := g.check(ctx, issueID, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.RemoveLabel(ctx, issueID, label, actor)
+}
+
+// AddIssueComment refuses to comment on a sovereign peer's issue.
+func (g *SovereigntyGuardStore) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
+	if err := g.check(ctx, issueID, g.inner.GetIssue); err != nil {
+		return nil, err
+	}
+	return g.inner.AddIssueComment(ctx, issueID, author, text)
+}
+
+// ── Metadata mutations ──────────────────────────────────────────────
+
+// SlotSet refuses to set a slot on a sovereign peer's issue.
+func (g *SovereigntyGuardStore) SlotSet(ctx context.Context, issueID, key, value, actor string) error {
+	if err := g.check(ctx, issueID, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.SlotSet(ctx, issueID, key, value, actor)
+}
+
+// SlotClear refuses to clear a slot on a sovereign peer's issue.
+func (g *SovereigntyGuardStore) SlotClear(ctx context.Context, issueID, key, actor string) error {
+	if err := g.check(ctx, issueID, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.SlotClear(ctx, issueID, key, actor)
+}
+
+// MergeMetadata refuses to write metadata on a sovereign peer's issue.
+func (g *SovereigntyGuardStore) MergeMetadata(ctx context.Context, issueID, key string, value json.RawMessage, actor string) error {
+	if err := g.check(ctx, issueID, g.inner.GetIssue); err != nil {
+		return err
+	}
+	return g.inner.MergeMetadata(ctx, issueID, key, value, actor)
+}
+
+// ── Transaction support ─────────────────────────────────────────────
+
+// RunInTransaction applies the same guards to writes made through the
+// callback's transaction. A refused write fails the callback, so the
+// transaction rolls back as a whole. The policy is loaded before the
+// transaction starts, since the embedded store serves one caller at a time
+// and reading the peers from inside the callback would deadlock.
+func (g *SovereigntyGuardStore) RunInTransaction(ctx context.Context, commitMsg string, fn func(tx Transaction) error) error {
+	if _, err := g.loadPolicy(ctx); err != nil {
+		return err
+	}
+	return g.inner.RunInTransaction(ctx, commitMsg, func(tx Transaction) error {
+		return fn(&sovereigntyGuardTransaction{Transaction: tx, guard: g})
+	})
+}
+
+// ── Internal helpers ────────────────────────────────────────────────
+
+// check returns ErrSovereignIssue if a sovereign peer owns id. Ownership by
+// ID prefix needs no lookup; ownership by source_repo reads the issue with
+// get. A missing issue passes so the inner store reports it.
+func (g *SovereigntyGuardStore) check(ctx context.Context, id string, get issueGetter) error {
+	policy, err := g.loadPolicy(ctx)
+	if err != nil || policy.Empty() {
+		return err
+	}
+	if err := policy.Check(id, ""); err != nil {
+		return err
+	}
+	issue, err := get(ctx, id)
+	if err != nil || issue == nil {
+		return nil
+	}
+	return policy.Check(id, issue.SourceRepo)
+}
+
+func (g *SovereigntyGuardStore) loadPolicy(ctx context.Context) (*SovereigntyPolicy, error) {
+	g.once.Do(func() {
+		peers, err := g.inner.ListFederationPeers(ctx)
+		if err != nil {
+			g.policyErr = fmt.Errorf("failed to load federation peers for sovereignty check: %w", err)
+			return
+		}
+		prefix, _ := g.inner.GetConfig(ctx, "issue_prefix")
+		g.policy = NewSovereigntyPolicy(peers, prefix)
+	})
+	return g.policy, g.policyErr
+}
+
+// ── Guarded transaction ─────────────────────────────────────────────
+
+// sovereigntyGuardTransaction wraps a Transaction, checking writes against
+// the guard's policy. Lookups read through the transaction so issues it
+// created or changed are seen as they are now.
+type sovereigntyGuardTransaction struct {
+	Transaction
+	guard *SovereigntyGuardStore
+}
+
+func (t *sovereigntyGuardTransaction) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	if err := t.guard.check(ctx, id, t.Transaction.GetIssue); err != nil {
+		return err
+	}
+	return t.Transaction.UpdateIssue(ctx, id, updates, actor)
+}
+
+func (t *sovereigntyGuardTransaction) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
+	if err := t.guard.check(ctx, id, t.Transaction.GetIssue); err != nil {
+		return err
+	}
+	return t.Transaction.CloseIssue(ctx, id, reason, actor, session)
+}
+
+func (t *sovereigntyGuardTransaction) DeleteIssue(ctx context.Context, id string) error {
+	if err := t.guard.check(ctx, id, t.Transaction.GetIssue); err != nil {
+		return err
+	}
+	return t.Transaction.DeleteIssue(ctx, id)
+}
+
+func (t *sovereigntyGuardTransaction) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	return t.AddDependencyWithOptions(ctx, dep, actor, DependencyAddOptions{})
+}
+
+func (t *sovereigntyGuardTransaction) AddDependencyWithOptions(ctx context.Context, dep *types.Dependency, actor string, opts DependencyAddOptions) error {
+	if err := t.guard.check(ctx, dep.IssueID, t.Transaction.GetIssue); err != nil {
+		return err
+	}
+	return t.Transaction.AddDependencyWithOptions(ctx, dep, actor, opts)
+}
+
+func (t *sovereigntyGuardTransaction) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	return t.RemoveDependencyWithOptions(ctx, issueID, dependsOnID, actor, DependencyRemoveOptions{})
+}
+
+func (t *sovereigntyGuardTransaction) RemoveDependencyWithOptions(ctx context.Context, issueID, dependsOnID string, actor string, opts DependencyRemoveOptions) error {
+	if err := t.guard.check(ctx, issueID, t.Transaction.GetIssue); err != nil {
+		return err
+	}
+	return t.Transaction.RemoveDependencyWithOptions(ctx, issueID, dependsOnID, actor, opts)
+}
+
+func (t *sovereigntyGuardTransaction) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	if err := t.guard.check(ctx, issueID, t.Transaction.GetIssue); err != nil {
+		return err
+	}
+	return t.Transaction.AddLabel(ctx, issueID, label, actor)
+}
+
+func (t *sovereigntyGuardTransaction) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	if err := t.guard.check(ctx, issueID, t.Transaction.GetIssue); err != nil {
+		return err
+	}
+	return t.Transaction.RemoveLabel(ctx, issueID, label, actor)
+}
+
+func (t *sovereigntyGuardTransaction) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	if err := t.guard.check(ctx, issueID, t.Transaction.GetIssue); err != nil {
+		return err
+	}
+	return t.Transaction.AddComment(ctx, issueID, actor, comment)
+}
+
+func (t *sovereigntyGuardTransaction) ImportIssueComment(ctx context.Context, issueID, author, text string, createdAt time.Time) (*types.Comment, error) {
+	if err := t.guard.check(ctx, issueID, t.Transaction.GetIssue); err != nil {
+		return nil, err
+	}
+	return t.Transaction.ImportIssueComment(ctx, issueID, author, text, createdAt)
+}
+
+// Ensure compile-time interface satisfaction.
+var _ DoltStorage = (*SovereigntyGuardStore)(nil)
+var _ Transaction = (*sovereigntyGuardTransaction)(nil)
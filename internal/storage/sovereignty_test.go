@@ -0,0 +1,177 @@
+package storage_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestOwnedPrefixesRoundTrip(t *testing.T) {
+	got := storage.SplitOwnedPrefixes(" hq, ,ops-core ,")
+	if !slices.Equal(got, []string{"hq", "ops-core"}) {
+		t.Fatalf("SplitOwnedPrefixes = %q", got)
+	}
+	if s := storage.JoinOwnedPrefixes(got); s != "hq,ops-core" {
+		t.Errorf("JoinOwnedPrefixes = %q", s)
+	}
+	if got := storage.SplitOwnedPrefixes(""); got != nil {
+		t.Errorf("SplitOwnedPrefixes(\"\") = %q, want nil", got)
+	}
+}
+
+func TestSovereigntyPolicyOwner(t *testing.T) {
+	policy := storage.NewSovereigntyPolicy([]*storage.FederationPeer{
+		{Name: "hq", Sovereignty: "t2", OwnedPrefixes: []string{"hq"}},
+		{Name: "core", Sovereignty: "T3", OwnedPrefixes: []string{"hq-core"}},
+		{Name: "open", Sovereignty: "T1", OwnedPrefixes: []string{"open"}},
+		{Name: "plain", OwnedPrefixes: []string{"plain"}},
+		{Name: "vault", Sovereignty: "T4"},
+	}, "bd")
+
+	tests := []struct {
+		id, sourceRepo string
+		peer, tier     string
+	}{
+		{"hq-abc", "", "hq", "T2"},
+		{"hq-core-abc", "", "core", "T3"}, // longest prefix wins
+		{"hqx-abc", "", "", ""},           // prefixes end at the dash
+		{"open-abc", "", "", ""},          // T1 is unrestricted
+		{"plain-abc", "", "", ""},         // no tier is unrestricted
+		{"ext-abc", "vault", "vault", "T4"},
+		{"hq-abc", "vault", "hq", "T2"}, // a prefix match is more specific
+		{"bd-abc", "vault", "", ""},     // local issues are never foreign
+	}
+	for _, tt := range tests {
+		peer, tier := policy.Owner(tt.id, tt.sourceRepo)
+		if peer != tt.peer || tier != tt.tier {
+			t.Errorf("Owner(%q, %q) = %q, %q; want %q, %q", tt.id, tt.sourceRepo, peer, tier, tt.peer, tt.tier)
+		}
+	}
+
+	err := policy.Check("hq-abc", "")
+	if !errors.Is(err, storage.ErrSovereignIssue) {
+		t.Fatalf("Check = %v, want ErrSovereignIssue", err)
+	}
+	for _, want := range []string{"hq-abc", "peer hq", "T2", "--override-sovereignty"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Check error %q does not mention %q", err, want)
+		}
+	}
+	if storage.NewSovereigntyPolicy(nil, "bd").Check("hq-abc", "") != nil {
+		t.Error("empty policy refused a write")
+	}
+}
+
+// sovereigntyStubStore implements just enough of DoltStorage for the
+// guard: peers, the issue prefix, issue lookups, and a few writes that it
+// counts.
+type sovereigntyStubStore struct {
+	storage.DoltStorage
+	issues map[string]*types.Issue
+	writes int
+}
+
+func (s *sovereigntyStubStore) ListFederationPeers(context.Context) ([]*storage.FederationPeer, error) {
+	return []*storage.FederationPeer{{Name: "hq", Sovereignty: "T2", OwnedPrefixes: []string{"hq"}}}, nil
+}
+
+func (s *sovereigntyStubStore) GetConfig(_ context.Context, key string) (string, error) {
+	if key == "issue_prefix" {
+		return "bd", nil
+	}
+	return "", nil
+}
+
+func (s *sovereigntyStubStore) GetIssue(_ context.Context, id string) (*types.Issue, error) {
+	if issue, ok := s.issues[id]; ok {
+		return issue, nil
+	}
+	return nil, storage.ErrNotFound
+}
+
+func (s *sovereigntyStubStore) UpdateIssue(context.Context, string, map[string]interface{}, string) error {
+	s.writes++
+	return nil
+}
+
+func (s *sovereigntyStubStore) AddDependency(context.Context, *types.Dependency, string) error {
+	s.writes++
+	return nil
+}
+
+func (s *sovereigntyStubStore) MergeMetadata(context.Context, string, string, json.RawMessage, string) error {
+	s.writes++
+	return nil
+}
+
+func (s *sovereigntyStubStore) RunInTransaction(ctx context.Context, _ string, fn func(storage.Transaction) error) error {
+	return fn(&sovereigntyStubTx{store: s})
+}
+
+type sovereigntyStubTx struct {
+	storage.Transaction
+	store *sovereigntyStubStore
+}
+
+func (t *sovereigntyStubTx) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	return t.store.GetIssue(ctx, id)
+}
+
+func (t *sovereigntyStubTx) AddLabel(context.Context, string, string, string) error {
+	t.store.writes++
+	return nil
+}
+
+func TestSovereigntyGuardStore(t *testing.T) {
+	ctx := context.Background()
+	inner := &sovereigntyStubStore{issues: map[string]*types.Issue{
+		"bd-1":  {ID: "bd-1"},
+		"hq-1":  {ID: "hq-1"},
+		"ext-1": {ID: "ext-1", SourceRepo: "hq"},
+	}}
+	guard := storage.NewSovereigntyGuardStore(inner)
+	if storage.UnwrapStore(guard) != storage.DoltStorage(inner) {
+		t.Fatal("UnwrapStore did not reach the inner store")
+	}
+
+	if err := guard.UpdateIssue(ctx, "bd-1", nil, "me"); err != nil {
+		t.Errorf("local update refused: %v", err)
+	}
+	if err := guard.UpdateIssue(ctx, "hq-1", nil, "me"); !errors.Is(err, storage.ErrSovereignIssue) {
+		t.Errorf("update of hq-1 = %v, want ErrSovereignIssue", err)
+	}
+	if err := guard.UpdateIssue(ctx, "ext-1", nil, "me"); !errors.Is(err, storage.ErrSovereignIssue) {
+		t.Errorf("update of ext-1 (source_repo hq) = %v, want ErrSovereignIssue", err)
+	}
+	// Depending on a peer's issue is a local change; adding edges to it is not.
+	if err := guard.AddDependency(ctx, &types.Dependency{IssueID: "bd-1", DependsOnID: "hq-1"}, "me"); err != nil {
+		t.Errorf("local edge to hq-1 refused: %v", err)
+	}
+	if err := guard.AddDependency(ctx, &types.Dependency{IssueID: "hq-1", DependsOnID: "bd-1"}, "me"); !errors.Is(err, storage.ErrSovereignIssue) {
+		t.Errorf("edge from hq-1 = %v, want ErrSovereignIssue", err)
+	}
+	if err := guard.MergeMetadata(ctx, "bd-1", "cost", json.RawMessage(`1`), "me"); err != nil {
+		t.Errorf("local metadata merge refused: %v", err)
+	}
+	if err := guard.MergeMetadata(ctx, "hq-1", "cost", json.RawMessage(`1`), "me"); !errors.Is(err, storage.ErrSovereignIssue) {
+		t.Errorf("metadata merge on hq-1 = %v, want ErrSovereignIssue", err)
+	}
+	err := guard.RunInTransaction(ctx, "label", func(tx storage.Transaction) error {
+		if err := tx.AddLabel(ctx, "bd-1", "x", "me"); err != nil {
+			return err
+		}
+		return tx.AddLabel(ctx, "hq-1", "x", "me")
+	})
+	if !errors.Is(err, storage.ErrSovereignIssue) {
+		t.Errorf("transactional label of hq-1 = %v, want ErrSovereignIssue", err)
+	}
+	if inner.writes != 4 {
+		t.Errorf("inner writes = %d, want 4", inner.writes)
+	}
+}
@@ -70,10 +70,16 @@ func SearchCountsSQL(tables FilterTables, ids []string, whereSQL, orderBySQL, li
 		depWhere = fmt.Sprintf("WHERE issue_id IN (%s)", inSQL)
 	}
 
+	// Reverse blockers always come from the issues family's dependencies,
+	// read at the same point in time as the rest of the query.
+	issueDeps := "dependencies"
+	if asOf := tables.AsOf(); asOf != "" {
+		issueDeps += " " + asOf
+	}
 	reverseBlockerSelect := fmt.Sprintf(`
 				SELECT %s AS dep_id
-				FROM dependencies WHERE type = 'blocks'%s
-	`, DepTargetExpr, rcDepExtra)
+				FROM %s WHERE type = 'blocks'%s
+	`, DepTargetExpr, issueDeps, rcDepExtra)
 	if includeWispReverseDeps {
 		reverseBlockerSelect += fmt.Sprintf(`
 				UNION ALL
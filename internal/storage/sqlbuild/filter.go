@@ -29,8 +29,9 @@ const KeysetCreatedAtIDPredicate = "(created_at <= ? AND ((created_at < ?) OR (i
 
 // BuildIssueFilterClauses builds WHERE clause fragments and args from a query
 // string and IssueFilter. The tables parameter controls which table names are
-// referenced in subqueries (issues vs wisps).
-func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables FilterTables) ([]string, []any, error) {
+// referenced in subqueries (issues vs wisps). now is the instant Overdue
+// compares due_at against.
+func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables FilterTables, now time.Time) ([]string, []any, error) {
 	var whereClauses []string
 	var args []any
 
@@ -50,6 +51,14 @@ func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables Filt
 		whereClauses = append(whereClauses, "LOWER(title) LIKE ?")
 		args = append(args, "%"+strings.ToLower(filter.TitleSearch)+"%")
 	}
+	if filter.FullText != "" {
+		clauses, ftArgs, err := FullTextClauses(filter.FullText)
+		if err != nil {
+			return nil, nil, err
+		}
+		whereClauses = append(whereClauses, clauses...)
+		args = append(args, ftArgs...)
+	}
 	if filter.TitleContains != "" {
 		whereClauses = append(whereClauses, "LOWER(title) LIKE ?")
 		args = append(args, "%"+strings.ToLower(filter.TitleContains)+"%")
@@ -62,6 +71,14 @@ func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables Filt
 		whereClauses = append(whereClauses, "LOWER(notes) LIKE ?")
 		args = append(args, "%"+strings.ToLower(filter.NotesContains)+"%")
 	}
+	if filter.DesignContains != "" {
+		whereClauses = append(whereClauses, "LOWER(design) LIKE ?")
+		args = append(args, "%"+strings.ToLower(filter.DesignContains)+"%")
+	}
+	if filter.AcceptanceContains != "" {
+		whereClauses = append(whereClauses, "LOWER(acceptance_criteria) LIKE ?")
+		args = append(args, "%"+strings.ToLower(filter.AcceptanceContains)+"%")
+	}
 	if filter.ExternalRefContains != "" {
 		whereClauses = append(whereClauses, "LOWER(external_ref) LIKE ?")
 		args = append(args, "%"+strings.ToLower(filter.ExternalRefContains)+"%")
@@ -157,6 +174,26 @@ func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables Filt
 		whereClauses = append(whereClauses, "wisp_type = ?")
 		args = append(args, string(*filter.WispType))
 	}
+	if filter.SourceFormula != "" {
+		whereClauses = append(whereClauses, "source_formula = ?")
+		args = append(args, filter.SourceFormula)
+	}
+	if filter.Rig != "" {
+		whereClauses = append(whereClauses, "rig = ?")
+		args = append(args, filter.Rig)
+	}
+	if filter.EventKind != "" {
+		whereClauses = append(whereClauses, "event_kind = ?")
+		args = append(args, filter.EventKind)
+	}
+	if filter.EventActor != "" {
+		whereClauses = append(whereClauses, "actor = ?")
+		args = append(args, filter.EventActor)
+	}
+	if filter.EventTarget != "" {
+		whereClauses = append(whereClauses, "target = ?")
+		args = append(args, filter.EventTarget)
+	}
 
 	if len(filter.Labels) > 0 {
 		for _, label := range filter.Labels {
@@ -226,6 +263,15 @@ func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables Filt
 	if filter.EmptyDescription {
 		whereClauses = append(whereClauses, "(description IS NULL OR description = '')")
 	}
+	if filter.NoDesign {
+		whereClauses = append(whereClauses, "(design IS NULL OR design = '')")
+	}
+	if filter.NoAcceptanceCriteria {
+		whereClauses = append(whereClauses, "(acceptance_criteria IS NULL OR acceptance_criteria = '')")
+	}
+	if filter.NoNotes {
+		whereClauses = append(whereClauses, "(notes IS NULL OR notes = '')")
+	}
 	if filter.NoAssignee {
 		whereClauses = append(whereClauses, "(assignee IS NULL OR assignee = '')")
 	}
@@ -271,7 +317,7 @@ func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables Filt
 	}
 	if filter.Overdue {
 		whereClauses = append(whereClauses, "due_at IS NOT NULL AND due_at < ? AND status != ?")
-		args = append(args, time.Now().UTC().Format(time.RFC3339), types.StatusClosed)
+		args = append(args, now.UTC().Format(time.RFC3339), types.StatusClosed)
 	}
 
 	var err error
@@ -279,10 +325,34 @@ func BuildIssueFilterClauses(query string, filter types.IssueFilter, tables Filt
 	if err != nil {
 		return nil, nil, err
 	}
+	if filter.MinQualityScore != nil {
+		whereClauses = append(whereClauses, MinQualityScoreClause)
+		args = append(args, *filter.MinQualityScore)
+	}
+	if filter.MinReopenCount > 0 {
+		whereClauses = append(whereClauses, MinReopenCountClause)
+		args = append(args, filter.MinReopenCount)
+	}
 
 	return whereClauses, args, nil
 }
 
+// MinQualityScoreClause matches issues whose metadata.quality_score is at
+// least the bound argument. Issues that were never scored do not match.
+const MinQualityScoreClause = "CAST(JSON_EXTRACT(metadata, '$.quality_score') AS DECIMAL(10,4)) >= ?"
+
+// MinReopenCountClause matches issues whose metadata.reopen_count is at least
+// the bound argument.
+const MinReopenCountClause = "CAST(JSON_EXTRACT(metadata, '$.reopen_count') AS SIGNED) >= ?"
+
+// CostTokensExpr and CostUSDExpr read an issue's accumulated cost
+// (metadata.cost_tokens / metadata.cost_usd) for SQL aggregates. Issues with
+// no recorded cost yield NULL.
+const (
+	CostTokensExpr = "CAST(JSON_EXTRACT(metadata, '$.cost_tokens') AS SIGNED)"
+	CostUSDExpr    = "CAST(JSON_EXTRACT(metadata, '$.cost_usd') AS DECIMAL(14,4))"
+)
+
 // AppendMetadataClauses appends JSON metadata predicates (has-key and exact
 // field matches, keys in sorted order) to an existing clause/arg list.
 func AppendMetadataClauses(where []string, args []any, hasKey string, fields map[string]string) ([]string, []any, error) {
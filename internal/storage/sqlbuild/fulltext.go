@@ -0,0 +1,47 @@
+package sqlbuild
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage/fts"
+)
+
+// FullTextClauses builds the WHERE fragments for IssueFilter.FullText. Every
+// term becomes an id IN (...) lookup on the search index's primary key
+// (term, issue_id, field): an equality seek for plain words and a range
+// seek for prefix words. A phrase requires each of its words the same way,
+// then confirms the words are adjacent with a LIKE over the rows the index
+// already narrowed to; the single-character wildcard between words matches
+// whatever separator the text used ("login timeout", "login-timeout").
+func FullTextClauses(query string) ([]string, []any, error) {
+	q, err := fts.Parse(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	var clauses []string
+	var args []any
+	require := func(term fts.Term) {
+		if term.Prefix {
+			clauses = append(clauses, fmt.Sprintf("id IN (SELECT issue_id FROM %s WHERE term LIKE ?)", fts.TermsTable))
+			args = append(args, term.Text+"%")
+			return
+		}
+		clauses = append(clauses, fmt.Sprintf("id IN (SELECT issue_id FROM %s WHERE term = ?)", fts.TermsTable))
+		args = append(args, term.Text)
+	}
+	for _, term := range q.Terms {
+		require(term)
+	}
+	for _, phrase := range q.Phrases {
+		for _, word := range phrase {
+			require(fts.Term{Text: word})
+		}
+		// Terms are letters and digits only, so they carry no LIKE
+		// metacharacters of their own.
+		pattern := "%" + strings.Join(phrase, "_") + "%"
+		clauses = append(clauses, "(LOWER(title) LIKE ? OR LOWER(description) LIKE ? OR LOWER(notes) LIKE ?)")
+		args = append(args, pattern, pattern, pattern)
+	}
+	return clauses, args, nil
+}
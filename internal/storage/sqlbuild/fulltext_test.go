@@ -0,0 +1,30 @@
+package sqlbuild
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFullTextClauses(t *testing.T) {
+	t.Parallel()
+
+	clauses, args, err := FullTextClauses(`retr* "login timeout"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clauses) != 4 {
+		t.Fatalf("got %d clauses, want prefix + two phrase words + adjacency:\n%s", len(clauses), strings.Join(clauses, "\n"))
+	}
+	if !strings.Contains(clauses[0], "term LIKE ?") || !strings.Contains(clauses[1], "term = ?") {
+		t.Errorf("want a range lookup for the prefix and equality for words, got %q", clauses[:2])
+	}
+	wantArgs := []any{"retr%", "login", "timeout", "%login_timeout%", "%login_timeout%", "%login_timeout%"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+
+	if _, _, err := FullTextClauses("!!!"); err == nil {
+		t.Error("expected an error for a query with no words")
+	}
+}
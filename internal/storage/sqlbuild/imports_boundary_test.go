@@ -34,6 +34,7 @@ func TestImportBoundaries(t *testing.T) {
 			allowed: []string{
 				"internal/storage",
 				"internal/storage/domain",
+				"internal/storage/fts",
 				"internal/types",
 			},
 		},
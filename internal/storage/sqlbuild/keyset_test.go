@@ -18,7 +18,7 @@ func TestKeysetPredicateEmission(t *testing.T) {
 	cur := time.Date(2024, 3, 2, 1, 0, 0, 0, time.UTC)
 
 	// No keyset set: predicate absent.
-	clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{}, IssuesFilterTables)
+	clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{}, IssuesFilterTables, time.Now())
 	if err != nil {
 		t.Fatalf("BuildIssueFilterClauses (no keyset): %v", err)
 	}
@@ -34,7 +34,7 @@ func TestKeysetPredicateEmission(t *testing.T) {
 	clauses, args, err = BuildIssueFilterClauses("", types.IssueFilter{
 		AfterCreatedAt: &cur,
 		AfterID:        "bd-42",
-	}, IssuesFilterTables)
+	}, IssuesFilterTables, time.Now())
 	if err != nil {
 		t.Fatalf("BuildIssueFilterClauses (keyset): %v", err)
 	}
@@ -74,7 +74,7 @@ func TestKeysetComposesWithCreatedBefore(t *testing.T) {
 		CreatedBefore:  &before,
 		AfterCreatedAt: &cur,
 		AfterID:        "bd-7",
-	}, IssuesFilterTables)
+	}, IssuesFilterTables, time.Now())
 	if err != nil {
 		t.Fatalf("BuildIssueFilterClauses: %v", err)
 	}
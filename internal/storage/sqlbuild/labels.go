@@ -49,14 +49,14 @@ func BuildLabelDrivenSearch(filter types.IssueFilter, tables FilterTables) Label
 
 	for i, label := range labels {
 		alias := fmt.Sprintf("label_filter_%d", i)
-		joins = append(joins, fmt.Sprintf("JOIN %s %s ON %s.issue_id = %s.id", tables.Labels, alias, alias, tables.Main))
+		joins = append(joins, fmt.Sprintf("JOIN %s %s ON %s.issue_id = %s.id", tables.Labels, alias, alias, tables.Ref()))
 		where = append(where, fmt.Sprintf("%s.label = ?", alias))
 		args = append(args, label)
 	}
 
 	if len(labelsAny) > 0 {
 		alias := "label_filter_any"
-		joins = append(joins, fmt.Sprintf("JOIN %s %s ON %s.issue_id = %s.id", tables.Labels, alias, alias, tables.Main))
+		joins = append(joins, fmt.Sprintf("JOIN %s %s ON %s.issue_id = %s.id", tables.Labels, alias, alias, tables.Ref()))
 		placeholders := make([]string, len(labelsAny))
 		for i, label := range labelsAny {
 			placeholders[i] = "?"
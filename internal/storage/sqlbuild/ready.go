@@ -47,15 +47,16 @@ type ReadyWorkOrder struct {
 // BuildReadyWorkOrder renders the ready-work ORDER BY for a sort policy.
 // createdCol/priorityCol name the sortable columns: real columns
 // ("created_at"/"priority") for per-table queries, or the sort_* aliases
-// ("sort_created"/"sort_priority") for UNION outer queries.
-func BuildReadyWorkOrder(policy types.SortPolicy, createdCol, priorityCol string) ReadyWorkOrder {
+// ("sort_created"/"sort_priority") for UNION outer queries. now anchors the
+// hybrid policy's recency window.
+func BuildReadyWorkOrder(policy types.SortPolicy, createdCol, priorityCol string, now time.Time) ReadyWorkOrder {
 	switch policy {
 	case types.SortPolicyOldest:
 		return ReadyWorkOrder{SQL: fmt.Sprintf("ORDER BY %s ASC, id ASC", createdCol)}
 	case types.SortPolicyPriority:
 		return ReadyWorkOrder{SQL: fmt.Sprintf("ORDER BY %s ASC, %s ASC, id ASC", priorityCol, createdCol)}
 	case types.SortPolicyHybrid, "":
-		recentCutoff := time.Now().UTC().Add(-48 * time.Hour)
+		recentCutoff := now.Add(-48 * time.Hour)
 		return ReadyWorkOrder{
 			SQL: fmt.Sprintf(`ORDER BY
 			CASE WHEN %s >= ? THEN 0 ELSE 1 END ASC,
@@ -78,6 +79,9 @@ type ReadyWorkWhereInputs struct {
 	// ParentDescendantIDs are the transitive descendants of *filter.ParentID;
 	// consulted only when filter.ParentID != nil.
 	ParentDescendantIDs []string
+	// Now is the instant defer_until is compared against (see storage.Now);
+	// zero means the current system time.
+	Now time.Time
 }
 
 // BuildReadyWorkWhere renders the full ready-work WHERE clause for one table
@@ -123,7 +127,12 @@ func BuildReadyWorkWhere(filter types.WorkFilter, tables FilterTables, in ReadyW
 	}
 
 	if !filter.IncludeDeferred {
-		whereClauses = append(whereClauses, "(defer_until IS NULL OR defer_until <= UTC_TIMESTAMP())")
+		whereClauses = append(whereClauses, "(defer_until IS NULL OR defer_until <= ?)")
+		now := in.Now
+		if now.IsZero() {
+			now = time.Now().UTC()
+		}
+		args = append(args, now)
 		for start := 0; start < len(in.DeferredChildIDs); start += QueryBatchSize {
 			end := start + QueryBatchSize
 			if end > len(in.DeferredChildIDs) {
@@ -162,6 +171,11 @@ func BuildReadyWorkWhere(filter types.WorkFilter, tables FilterTables, in ReadyW
 		}
 		whereClauses = append(whereClauses, fmt.Sprintf("id NOT IN (SELECT issue_id FROM %s WHERE label IN (%s))", tables.Labels, strings.Join(placeholders, ", ")))
 	}
+	if len(filter.ExcludeIDs) > 0 {
+		placeholders, idArgs := InPlaceholders(filter.ExcludeIDs)
+		args = append(args, idArgs...)
+		whereClauses = append(whereClauses, fmt.Sprintf("id NOT IN (%s)", placeholders))
+	}
 
 	// Parent filtering: return all transitive descendants of parentID.
 	// GH#3396: a one-hop subquery silently dropped grandchildren despite the
@@ -192,6 +206,18 @@ func BuildReadyWorkWhere(filter types.WorkFilter, tables FilterTables, in ReadyW
 	if err != nil {
 		return "", nil, err
 	}
+	if filter.MinQualityScore != nil {
+		whereClauses = append(whereClauses, MinQualityScoreClause)
+		args = append(args, *filter.MinQualityScore)
+	}
+	if filter.MinReopenCount > 0 {
+		whereClauses = append(whereClauses, MinReopenCountClause)
+		args = append(args, filter.MinReopenCount)
+	}
+	if filter.Rig != "" {
+		whereClauses = append(whereClauses, "rig = ?")
+		args = append(args, filter.Rig)
+	}
 
 	return "WHERE " + strings.Join(whereClauses, " AND "), args, nil
 }
@@ -8,24 +8,30 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
-// SortDef maps a user-facing sort key to its column and default direction.
-type SortDef struct {
-	Column     string
-	DefaultDir string
+// SortColumns maps each sort field (types.SortFields) to the issues column
+// it orders by. ORDER BY clauses are built only from these names, never from
+// the caller's spec.
+var SortColumns = map[string]string{
+	"priority": "priority",
+	"created":  "created_at",
+	"updated":  "updated_at",
+	"closed":   "closed_at",
+	"due":      "due_at",
+	"status":   "status",
+	"type":     "issue_type",
+	"assignee": "assignee",
+	"title":    "title",
+	"id":       "id",
 }
 
-// SortDefs is the canonical sort-key table for issue list/search ordering.
-var SortDefs = map[string]SortDef{
-	"":         {"priority", "ASC"},
-	"priority": {"priority", "ASC"},
-	"created":  {"created_at", "DESC"},
-	"updated":  {"updated_at", "DESC"},
-	"closed":   {"closed_at", "DESC"},
-	"status":   {"status", "ASC"},
-	"type":     {"issue_type", "ASC"},
-	"assignee": {"assignee", "ASC"},
-	"title":    {"title", "ASC"},
-}
+// nullableSortFields are the sort fields whose columns may be NULL. The
+// assignee column may be NULL too, but sortColumnExpr coalesces it to the
+// empty string: Issue.Assignee reads NULL and empty alike as "", so Less
+// could not tell them apart.
+var nullableSortFields = map[string]bool{"closed": true, "due": true}
+
+// DefaultSort is the ordering of an IssueFilter without SortBy.
+var DefaultSort = []types.SortKey{{Field: "priority"}, {Field: "created", Desc: true}}
 
 // UnionSortColumnsSQL projects every sortable column under a stable sort_*
 // alias so a UNION ALL outer query can ORDER BY any sort key.
@@ -33,119 +39,129 @@ const UnionSortColumnsSQL = `priority AS sort_priority,
 	created_at AS sort_created,
 	updated_at AS sort_updated,
 	closed_at AS sort_closed,
+	due_at AS sort_due,
 	status AS sort_status,
 	issue_type AS sort_type,
-	assignee AS sort_assignee,
+	COALESCE(assignee, '') AS sort_assignee,
 	LOWER(title) AS sort_title`
 
-// IsGoSideSort reports sort keys that are applied in Go after the query
-// instead of in SQL.
-func IsGoSideSort(sortBy string) bool {
-	return sortBy == "id"
+// IsGoSideSort reports orderings that are applied in Go after the query
+// instead of in SQL: a lone id key, which sorts naturally (bd-9 before
+// bd-10) rather than as SQL compares strings.
+func IsGoSideSort(keys []types.SortKey) bool {
+	return len(keys) == 1 && keys[0].Field == "id"
+}
+
+// effectiveSortKeys returns the keys OrderBy and Less order by: keys minus
+// unknown fields, or DefaultSort when none remain. A lone priority key keeps
+// the default's newest-first tie-break.
+func effectiveSortKeys(keys []types.SortKey) []types.SortKey {
+	var out []types.SortKey
+	for _, k := range keys {
+		if _, ok := SortColumns[k.Field]; ok {
+			out = append(out, k)
+		}
+	}
+	switch {
+	case len(out) == 0:
+		return DefaultSort
+	case len(out) == 1 && out[0].Field == "priority":
+		return append(out, types.SortKey{Field: "created", Desc: true})
+	}
+	return out
 }
 
-func flipDir(dir string) string {
-	if dir == "ASC" {
+func sortDir(desc bool) string {
+	if desc {
 		return "DESC"
 	}
 	return "ASC"
 }
 
-// OrderByForColumns renders the ORDER BY clause for a sort key, mapping sort
-// keys to column expressions via col. Used directly by UNION consumers whose
-// columns are aliased; per-table callers should use OrderBy.
-func OrderByForColumns(sortBy string, sortDesc bool, col func(sortKey string) string) string {
-	if IsGoSideSort(sortBy) {
+// OrderByForColumns renders the ORDER BY clause for keys, mapping sort
+// fields to column expressions via col. Used directly by UNION consumers
+// whose columns are aliased; per-table callers should use OrderBy.
+func OrderByForColumns(keys []types.SortKey, col func(field string) string) string {
+	if IsGoSideSort(keys) {
 		return ""
 	}
-	def, ok := SortDefs[sortBy]
-	if !ok {
-		def = SortDefs[""]
-		sortBy = ""
-	}
-	dir := def.DefaultDir
-	if sortDesc {
-		dir = flipDir(dir)
-	}
-	if sortBy == "" || sortBy == "priority" {
-		return fmt.Sprintf("ORDER BY %s %s, %s DESC, %s ASC", col("priority"), dir, col("created"), col("id"))
+	var terms []string
+	byID := false
+	for _, k := range effectiveSortKeys(keys) {
+		dir := sortDir(k.Desc)
+		// A nullable sort column treats NULL as lowest: first on ASC and last
+		// on DESC. Lead with an explicit (col IS NULL) key so the contract does
+		// not depend on a driver's default NULL ordering.
+		if nullableSortFields[k.Field] {
+			terms = append(terms, fmt.Sprintf("(%s IS NULL) %s", col(k.Field), sortDir(!k.Desc)))
+		}
+		terms = append(terms, col(k.Field)+" "+dir)
+		byID = byID || k.Field == "id"
 	}
-	// A nullable sort column (closed_at, assignee) treats NULL as lowest: first on ASC
-	// and last on DESC. Lead with an explicit (col IS NULL) key so the contract does not
-	// depend on a driver's default NULL ordering. NOT NULL columns keep the plain clause.
-	if sortBy == "closed" || sortBy == "assignee" {
-		return fmt.Sprintf("ORDER BY (%s IS NULL) %s, %s %s, %s ASC", col(sortBy), flipDir(dir), col(sortBy), dir, col("id"))
+	if !byID {
+		terms = append(terms, col("id")+" ASC")
 	}
-	return fmt.Sprintf("ORDER BY %s %s, %s ASC", col(sortBy), dir, col("id"))
+	return "ORDER BY " + strings.Join(terms, ", ")
 }
 
 // OrderBy renders the ORDER BY clause against real table columns, optionally
 // qualified ("i" -> "i.priority"). Ties always break by id ASC; the default
 // priority sort additionally breaks by created_at DESC.
-func OrderBy(sortBy string, sortDesc bool, table string) string {
+func OrderBy(keys []types.SortKey, table string) string {
 	qual := ""
 	if table != "" {
 		qual = table + "."
 	}
-	return OrderByForColumns(sortBy, sortDesc, func(k string) string {
-		switch k {
-		case "id":
-			return qual + "id"
-		case "title":
-			return "LOWER(" + qual + "title)"
-		}
-		return qual + SortDefs[k].Column
+	return OrderByForColumns(keys, func(field string) string {
+		return sortColumnExpr(field, qual)
 	})
 }
 
+// sortColumnExpr is the expression OrderBy sorts field by, on columns
+// qualified with qual. It matches the sort_* projection in
+// UnionSortColumnsSQL.
+func sortColumnExpr(field, qual string) string {
+	switch field {
+	case "title":
+		return "LOWER(" + qual + "title)"
+	case "assignee":
+		return "COALESCE(" + qual + "assignee, '')"
+	}
+	return qual + SortColumns[field]
+}
+
 // Less is the Go-side mirror of OrderBy for merge sorts over rows fetched
 // from separate queries (issues + wisps). It must order exactly the way the
 // SQL does, including NULL-first ascending semantics for nullable columns;
 // otherwise a post-merge limit cut keeps a different row set than SQL
 // selected.
-func Less(a, b *types.Issue, sortBy string, sortDesc bool) bool {
-	if sortBy == "id" {
-		return a.ID < b.ID
-	}
-	def, ok := SortDefs[sortBy]
-	if !ok {
-		def = SortDefs[""]
-		sortBy = ""
-	}
-	descending := def.DefaultDir == "DESC"
-	if sortDesc {
-		descending = !descending
-	}
-	if c := sortKeyCompare(a, b, sortBy); c != 0 {
-		if descending {
-			return c > 0
+func Less(a, b *types.Issue, keys []types.SortKey) bool {
+	if !IsGoSideSort(keys) {
+		for _, k := range effectiveSortKeys(keys) {
+			if c := sortKeyCompare(a, b, k.Field); c != 0 {
+				if k.Desc {
+					return c > 0
+				}
+				return c < 0
+			}
 		}
-		return c < 0
-	}
-	if (sortBy == "" || sortBy == "priority") && !a.CreatedAt.Equal(b.CreatedAt) {
-		return a.CreatedAt.After(b.CreatedAt)
 	}
 	return a.ID < b.ID
 }
 
-// sortKeyCompare three-way compares the primary sort column in ascending
-// order, with MySQL NULL-first semantics for nullable columns.
-func sortKeyCompare(a, b *types.Issue, sortBy string) int {
-	switch sortBy {
+// sortKeyCompare three-way compares one sort field in ascending order, with
+// MySQL NULL-first semantics for nullable columns. An unset assignee
+// compares as "", matching the coalesced assignee OrderBy sorts by.
+func sortKeyCompare(a, b *types.Issue, field string) int {
+	switch field {
 	case "created":
 		return compareTimesAsc(a.CreatedAt, b.CreatedAt)
 	case "updated":
 		return compareTimesAsc(a.UpdatedAt, b.UpdatedAt)
 	case "closed":
-		switch {
-		case a.ClosedAt == nil && b.ClosedAt == nil:
-			return 0
-		case a.ClosedAt == nil:
-			return -1
-		case b.ClosedAt == nil:
-			return 1
-		}
-		return compareTimesAsc(*a.ClosedAt, *b.ClosedAt)
+		return compareNullableTimesAsc(a.ClosedAt, b.ClosedAt)
+	case "due":
+		return compareNullableTimesAsc(a.DueAt, b.DueAt)
 	case "status":
 		return strings.Compare(string(a.Status), string(b.Status))
 	case "type":
@@ -154,10 +170,24 @@ func sortKeyCompare(a, b *types.Issue, sortBy string) int {
 		return strings.Compare(a.Assignee, b.Assignee)
 	case "title":
 		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case "id":
+		return strings.Compare(a.ID, b.ID)
 	}
 	return a.Priority - b.Priority
 }
 
+func compareNullableTimesAsc(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return compareTimesAsc(*a, *b)
+}
+
 func compareTimesAsc(a, b time.Time) int {
 	switch {
 	case a.Before(b):
@@ -27,6 +27,35 @@ var (
 	WispsFilterTables  = FilterTables{Main: "wisps", Labels: "wisp_labels", Dependencies: "wisp_dependencies", Comments: "wisp_comments"}
 )
 
+// WithAsOf returns the tables read at a point in time: every name gets the
+// Dolt AS OF clause asOf (e.g. "AS OF 'main~3'"), built by the caller from a
+// validated ref. The names stay usable wherever a FROM takes a table; use
+// Ref to qualify columns of Main.
+func (t FilterTables) WithAsOf(asOf string) FilterTables {
+	if asOf == "" {
+		return t
+	}
+	return FilterTables{
+		Main:         t.Main + " " + asOf,
+		Labels:       t.Labels + " " + asOf,
+		Dependencies: t.Dependencies + " " + asOf,
+		Comments:     t.Comments + " " + asOf,
+	}
+}
+
+// Ref returns the name that qualifies Main's columns ("issues.id"): Main
+// without any AS OF clause, which Dolt does not allow in a qualifier.
+func (t FilterTables) Ref() string {
+	name, _, _ := strings.Cut(t.Main, " ")
+	return name
+}
+
+// AsOf returns the AS OF clause WithAsOf appended, or "".
+func (t FilterTables) AsOf() string {
+	_, clause, _ := strings.Cut(t.Main, " ")
+	return clause
+}
+
 // DepTargetExpr resolves a dependency row's target across the three
 // mutually-exclusive target columns.
 const DepTargetExpr = "COALESCE(depends_on_issue_id, depends_on_wisp_id, depends_on_external)"
@@ -44,7 +73,7 @@ const IssueBaseColumns = `id, content_hash, title, description, design, acceptan
 	       mol_type,
 	       event_kind, actor, target, payload,
 	       due_at, defer_until,
-	       work_type, source_system, metadata, row_lock`
+	       work_type, source_system, source_formula, source_location, rig, is_encrypted, metadata, row_lock`
 
 // LeaseSelectColumns is the lease overlay for full issue hydration. Leases
 // live in the ephemeral leases table (bd-lrgn1), not on the issues row, so
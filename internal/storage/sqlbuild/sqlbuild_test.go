@@ -1,6 +1,7 @@
 package sqlbuild
 
 import (
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -11,41 +12,53 @@ import (
 func TestOrderByKnownKeys(t *testing.T) {
 	t.Parallel()
 
+	key := func(field string, desc bool) types.SortKey { return types.SortKey{Field: field, Desc: desc} }
 	cases := []struct {
-		sortBy   string
-		sortDesc bool
-		table    string
-		want     string
+		keys  []types.SortKey
+		table string
+		want  string
 	}{
-		{"", false, "", "ORDER BY priority ASC, created_at DESC, id ASC"},
-		{"priority", true, "", "ORDER BY priority DESC, created_at DESC, id ASC"},
-		{"created", false, "", "ORDER BY created_at DESC, id ASC"},
-		{"created", true, "", "ORDER BY created_at ASC, id ASC"},
-		{"title", false, "i", "ORDER BY LOWER(i.title) ASC, i.id ASC"},
-		{"updated", false, "i", "ORDER BY i.updated_at DESC, i.id ASC"},
-		{"bogus-key", false, "", "ORDER BY priority ASC, created_at DESC, id ASC"},
-		{"id", false, "", ""}, // Go-side sort
+		{nil, "", "ORDER BY priority ASC, created_at DESC, id ASC"},
+		{[]types.SortKey{key("priority", true)}, "", "ORDER BY priority DESC, created_at DESC, id ASC"},
+		{[]types.SortKey{key("created", true)}, "", "ORDER BY created_at DESC, id ASC"},
+		{[]types.SortKey{key("created", false)}, "", "ORDER BY created_at ASC, id ASC"},
+		{[]types.SortKey{key("title", false)}, "i", "ORDER BY LOWER(i.title) ASC, i.id ASC"},
+		{[]types.SortKey{key("updated", true)}, "i", "ORDER BY i.updated_at DESC, i.id ASC"},
+		{[]types.SortKey{key("assignee", false)}, "i", "ORDER BY COALESCE(i.assignee, '') ASC, i.id ASC"},
+		{[]types.SortKey{key("bogus-key", false)}, "", "ORDER BY priority ASC, created_at DESC, id ASC"},
+		{[]types.SortKey{key("id", false)}, "", ""}, // Go-side sort
+		{
+			[]types.SortKey{key("priority", false), key("updated", true)}, "",
+			"ORDER BY priority ASC, updated_at DESC, id ASC",
+		},
+		{
+			[]types.SortKey{key("due", false), key("id", true)}, "i",
+			"ORDER BY (i.due_at IS NULL) DESC, i.due_at ASC, i.id DESC",
+		},
+		{
+			[]types.SortKey{key("status", false), key("closed", true)}, "",
+			"ORDER BY status ASC, (closed_at IS NULL) ASC, closed_at DESC, id ASC",
+		},
 	}
 	for _, tc := range cases {
-		if got := OrderBy(tc.sortBy, tc.sortDesc, tc.table); got != tc.want {
-			t.Errorf("OrderBy(%q, %v, %q) = %q, want %q", tc.sortBy, tc.sortDesc, tc.table, got, tc.want)
+		if got := OrderBy(tc.keys, tc.table); got != tc.want {
+			t.Errorf("OrderBy(%v, %q) = %q, want %q", tc.keys, tc.table, got, tc.want)
 		}
 	}
 }
 
-// TestUnionSortColumnsCoverSortDefs pins that every SQL-side sort key has a
+// TestUnionSortColumnsCoverSortColumns pins that every SQL-side sort key has a
 // sort_* alias in UnionSortColumnsSQL, so UNION consumers can order by any
 // key OrderByForColumns may emit.
-func TestUnionSortColumnsCoverSortDefs(t *testing.T) {
+func TestUnionSortColumnsCoverSortColumns(t *testing.T) {
 	t.Parallel()
 
-	for key := range SortDefs {
-		alias := "sort_" + key
-		if key == "" {
-			alias = "sort_priority"
+	for field := range SortColumns {
+		if field == "id" {
+			continue // UNION consumers select id itself
 		}
-		if !strings.Contains(UnionSortColumnsSQL, alias) {
-			t.Errorf("UnionSortColumnsSQL missing alias %q for sort key %q", alias, key)
+		if alias := "sort_" + field; !strings.Contains(UnionSortColumnsSQL, alias) {
+			t.Errorf("UnionSortColumnsSQL missing alias %q for sort field %q", alias, field)
 		}
 	}
 }
@@ -60,17 +73,27 @@ func TestLessMirrorsOrderBy(t *testing.T) {
 	older := now.Add(-time.Hour)
 	a := &types.Issue{ID: "a", Priority: 1, CreatedAt: now}
 	b := &types.Issue{ID: "b", Priority: 2, CreatedAt: now}
-	if !Less(a, b, "", false) || Less(b, a, "", false) {
+	if !Less(a, b, nil) || Less(b, a, nil) {
 		t.Error("default sort must order priority 1 before priority 2")
 	}
 	c := &types.Issue{ID: "c", Priority: 1, CreatedAt: older}
-	if !Less(a, c, "", false) {
+	if !Less(a, c, nil) {
 		t.Error("equal priority must order newer created_at first (created_at DESC)")
 	}
 	d := &types.Issue{ID: "d", Priority: 1, CreatedAt: now}
-	if !Less(a, d, "", false) || Less(d, a, "", false) {
+	if !Less(a, d, nil) || Less(d, a, nil) {
 		t.Error("full tie must break by id ASC")
 	}
+
+	due := now.Add(time.Hour)
+	e := &types.Issue{ID: "e", Priority: 1, DueAt: &due}
+	keys := []types.SortKey{{Field: "priority"}, {Field: "due"}}
+	if !Less(a, e, keys) || Less(e, a, keys) {
+		t.Error("ascending due must order a missing due date first (NULL lowest)")
+	}
+	if !Less(e, b, keys) {
+		t.Error("priority must lead a priority,due sort")
+	}
 }
 
 func TestBuildReadyWorkOrderPriorityFIFO(t *testing.T) {
@@ -96,7 +119,7 @@ func TestBuildReadyWorkOrderPriorityFIFO(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			got := BuildReadyWorkOrder(tc.policy, "created_at", "priority")
+			got := BuildReadyWorkOrder(tc.policy, "created_at", "priority", time.Now())
 			if got.SQL != tc.want {
 				t.Fatalf("BuildReadyWorkOrder(%q).SQL = %q, want %q", tc.policy, got.SQL, tc.want)
 			}
@@ -141,7 +164,7 @@ func TestBuildReadyWorkWhereBatchesIDSets(t *testing.T) {
 	if got := strings.Count(where, "id NOT IN ("); got != 2 {
 		t.Errorf("expected 2 batched NOT IN clauses for %d IDs, got %d", len(ids), got)
 	}
-	wantArgs := len(ids) + len(ReadyWorkExcludeTypes(nil))
+	wantArgs := len(ids) + len(ReadyWorkExcludeTypes(nil)) + 1 // +1: defer_until cutoff
 	if len(args) != wantArgs {
 		t.Errorf("args = %d, want %d", len(args), wantArgs)
 	}
@@ -194,6 +217,86 @@ func TestBuildReadyWorkWhereLabelsAny(t *testing.T) {
 	}
 }
 
+func TestMinQualityScoreClause(t *testing.T) {
+	t.Parallel()
+
+	minScore := 0.7
+	clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{MinQualityScore: &minScore}, IssuesFilterTables, time.Now())
+	if err != nil {
+		t.Fatalf("BuildIssueFilterClauses: %v", err)
+	}
+	if clauses[len(clauses)-1] != MinQualityScoreClause || args[len(args)-1] != minScore {
+		t.Errorf("issue filter: clauses = %v, args = %v", clauses, args)
+	}
+
+	where, args, err := BuildReadyWorkWhere(types.WorkFilter{MinQualityScore: &minScore}, IssuesFilterTables, ReadyWorkWhereInputs{})
+	if err != nil {
+		t.Fatalf("BuildReadyWorkWhere: %v", err)
+	}
+	if !strings.Contains(where, MinQualityScoreClause) || args[len(args)-1] != minScore {
+		t.Errorf("ready filter: where = %s, args = %v", where, args)
+	}
+}
+
+func TestSourceFormulaClause(t *testing.T) {
+	t.Parallel()
+
+	clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{SourceFormula: "mol-release"}, IssuesFilterTables, time.Now())
+	if err != nil {
+		t.Fatalf("BuildIssueFilterClauses: %v", err)
+	}
+	if !slices.Contains(clauses, "source_formula = ?") || !slices.Contains(args, any("mol-release")) {
+		t.Errorf("clauses = %v, args = %v", clauses, args)
+	}
+
+	clauses, _, err = BuildIssueFilterClauses("", types.IssueFilter{}, IssuesFilterTables, time.Now())
+	if err != nil {
+		t.Fatalf("BuildIssueFilterClauses: %v", err)
+	}
+	if slices.Contains(clauses, "source_formula = ?") {
+		t.Errorf("empty SourceFormula should not filter: %v", clauses)
+	}
+}
+
+func TestRigClause(t *testing.T) {
+	t.Parallel()
+
+	clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{Rig: "gpu-west"}, IssuesFilterTables, time.Now())
+	if err != nil {
+		t.Fatalf("BuildIssueFilterClauses: %v", err)
+	}
+	if !slices.Contains(clauses, "rig = ?") || !slices.Contains(args, any("gpu-west")) {
+		t.Errorf("issue filter: clauses = %v, args = %v", clauses, args)
+	}
+
+	where, args, err := BuildReadyWorkWhere(types.WorkFilter{Rig: "gpu-west"}, IssuesFilterTables, ReadyWorkWhereInputs{})
+	if err != nil {
+		t.Fatalf("BuildReadyWorkWhere: %v", err)
+	}
+	if !strings.Contains(where, "rig = ?") || args[len(args)-1] != "gpu-west" {
+		t.Errorf("ready filter: where = %s, args = %v", where, args)
+	}
+}
+
+func TestEventClauses(t *testing.T) {
+	t.Parallel()
+
+	clauses, args, err := BuildIssueFilterClauses("", types.IssueFilter{EventKind: "deploy", EventActor: "ci", EventTarget: "svc/api"}, IssuesFilterTables, time.Now())
+	if err != nil {
+		t.Fatalf("BuildIssueFilterClauses: %v", err)
+	}
+	for _, want := range []string{"event_kind = ?", "actor = ?", "target = ?"} {
+		if !slices.Contains(clauses, want) {
+			t.Errorf("clauses = %v, missing %q", clauses, want)
+		}
+	}
+	for _, want := range []any{"deploy", "ci", "svc/api"} {
+		if !slices.Contains(args, want) {
+			t.Errorf("args = %v, missing %v", args, want)
+		}
+	}
+}
+
 func TestSearchCountsSQLShape(t *testing.T) {
 	t.Parallel()
 
@@ -248,3 +351,31 @@ func TestSearchCountsSQLShape(t *testing.T) {
 		t.Errorf("by-IDs args (skipLabels, no wisp deps) = %d, want %d", len(idArgsNoLabels), 6*2)
 	}
 }
+
+func TestFilterTablesWithAsOf(t *testing.T) {
+	t.Parallel()
+
+	tables := IssuesFilterTables.WithAsOf("AS OF TIMESTAMP('2026-10-01 00:00:00')")
+	if tables.Labels != "labels AS OF TIMESTAMP('2026-10-01 00:00:00')" {
+		t.Errorf("Labels = %q", tables.Labels)
+	}
+	if tables.Ref() != "issues" {
+		t.Errorf("Ref() = %q; want issues", tables.Ref())
+	}
+	if tables.AsOf() != "AS OF TIMESTAMP('2026-10-01 00:00:00')" {
+		t.Errorf("AsOf() = %q", tables.AsOf())
+	}
+	if IssuesFilterTables.WithAsOf("") != IssuesFilterTables || IssuesFilterTables.AsOf() != "" {
+		t.Error("an empty clause must leave the tables unchanged")
+	}
+
+	plan := BuildLabelDrivenSearch(types.IssueFilter{Labels: []string{"sprint"}}, tables)
+	want := "issues AS OF TIMESTAMP('2026-10-01 00:00:00') JOIN labels AS OF TIMESTAMP('2026-10-01 00:00:00') label_filter_0 ON label_filter_0.issue_id = issues.id"
+	if plan.FromSQL != want {
+		t.Errorf("FromSQL = %q; want %q", plan.FromSQL, want)
+	}
+	sqlText, _ := SearchCountsSQL(IssuesFilterTables.WithAsOf("AS OF 'main'"), nil, "", "", "", false, false)
+	if strings.Contains(sqlText, "FROM dependencies WHERE") {
+		t.Error("reverse blockers read the current dependencies table")
+	}
+}
@@ -51,6 +51,15 @@ const (
 // ErrNotFound is returned when a requested entity does not exist in the database.
 var ErrNotFound = errors.New("not found")
 
+// ErrSyncDirection is returned when a push or pull is attempted against a
+// federation peer whose sync mode does not allow that direction.
+var ErrSyncDirection = errors.New("sync direction not allowed")
+
+// ErrMergeConflicts is returned when a merge leaves conflicts in the working
+// set that no conflict strategy resolved. They stay there until resolved
+// (bd federation conflicts) or the merge is aborted.
+var ErrMergeConflicts = errors.New("unresolved merge conflicts")
+
 // ErrNotInitialized is returned when the database has not been initialized
 // (e.g., issue_prefix config is missing).
 var ErrNotInitialized = errors.New("database not initialized")
@@ -69,6 +78,16 @@ var ErrCloseBlocked = errors.New("cannot close blocked issue")
 // precondition from other errors.
 var ErrVersionMismatch = errors.New("version mismatch")
 
+// ErrProtected is returned when an actor changes, closes, reopens, or deletes
+// an issue (or protected fields of it) that metadata.protection reserves for
+// other actors.
+var ErrProtected = errors.New("issue is protected")
+
+// ErrSovereignIssue is returned when a write would change an issue that a
+// sovereign federation peer owns (see SovereigntyPolicy). The owning peer
+// makes the change and it arrives by sync.
+var ErrSovereignIssue = errors.New("issue is owned by a sovereign federation peer")
+
 // CommentPageCursor is the resume position for a keyset page of an issue's
 // comments: the (created_at, id) of the last comment already returned. The zero
 // value starts a walk from the beginning of the thread.
@@ -206,7 +225,7 @@ type Storage interface {
 	// CountIssues returns the number of issues matching query and filter.
 	CountIssues(ctx context.Context, query string, filter types.IssueFilter) (int64, error)
 	// CountIssuesByGroup returns per-group counts. groupBy is one of:
-	// status, priority, type, assignee, label.
+	// status, priority, type, assignee, label, close_reason.
 	CountIssuesByGroup(ctx context.Context, filter types.IssueFilter, groupBy string) (map[string]int, error)
 	// CountDependents returns the number of issues that depend on issueID.
 	CountDependents(ctx context.Context, issueID string) (int64, error)
@@ -297,6 +316,10 @@ type CloseIssueOptions struct {
 	Reason  string
 	Session string
 	Force   bool // bypass the is_blocked guard (mirrors `bd close --force`)
+	// DuplicateOf, when set, records the issue as a duplicate of this
+	// canonical issue (a duplicates dependency) in the close's transaction:
+	// a failed link rolls the close back, and a refused close adds no link.
+	DuplicateOf string
 	// ExpectedVersion, when non-nil, gates the close on an optimistic-concurrency
 	// check: the close proceeds only if the issue's current RowVersion (the
 	// row_lock token) equals *ExpectedVersion, otherwise it refuses with
@@ -389,12 +412,46 @@ type StoreLocator interface {
 	CLIDir() string
 }
 
+// RemoteServerReporter reports whether the store talks to a dolt sql-server
+// on another machine, whose data directory bd cannot reach. Callers that
+// would otherwise shell out to the dolt CLI on the local database directory
+// should type-assert to this interface and use SQL instead.
+type RemoteServerReporter interface {
+	IsRemoteServer() bool
+}
+
 // GarbageCollector provides Dolt garbage collection capability.
 // Callers that need to reclaim disk space should type-assert to this interface.
 type GarbageCollector interface {
 	DoltGC(ctx context.Context) error
 }
 
+// IntegrityChecker reads committed issue data back to check it for damage,
+// in the store itself or in a Dolt backup of it. Callers that maintain the
+// database (bd db gc/backup/restore) should type-assert to this interface.
+type IntegrityChecker interface {
+	// CheckIntegrity checks the issues committed at commit (HEAD when empty).
+	CheckIntegrity(ctx context.Context, commit string) (*IntegrityReport, error)
+	// CheckBackupIntegrity checks the HEAD of the Dolt backup in dir by
+	// restoring it into a scratch database that is dropped afterwards.
+	CheckBackupIntegrity(ctx context.Context, dir string) (*IntegrityReport, error)
+}
+
+// IntegrityReport is the result of an integrity check. Root digests the
+// issues, labels, and dependencies at Commit as 'bd federation verify'
+// does, so two checks of the same data report the same Root.
+type IntegrityReport struct {
+	Commit               string
+	Root                 string
+	Issues               int
+	ConstraintViolations int
+}
+
+// OK reports whether the check found nothing wrong.
+func (r *IntegrityReport) OK() bool {
+	return r.ConstraintViolations == 0
+}
+
 // Flattener squashes all Dolt commit history into a single commit.
 // Callers should type-assert to this interface for history compaction.
 type Flattener interface {
@@ -421,7 +478,9 @@ type SchemaMigrator interface {
 // Compactor squashes old Dolt commits while preserving recent ones.
 // Callers should type-assert to this interface for selective history compaction.
 type Compactor interface {
-	Compact(ctx context.Context, initialHash, boundaryHash string, oldCommits int, recentHashes []string) error
+	// Compact returns the new hash of each preserved commit, keyed by its
+	// old hash.
+	Compact(ctx context.Context, initialHash, boundaryHash string, oldCommits int, recentHashes []string) (map[string]string, error)
 }
 
 // BlockedRecomputer recomputes the denormalized is_blocked column for every
@@ -458,6 +517,70 @@ type PendingCommitter interface {
 	CommitPending(ctx context.Context, actor string) (bool, error)
 }
 
+// PendingChangesReader summarizes the writes in the working set that are not
+// yet committed. Batch auto-commit uses it to decide when the accumulated
+// writes are due for a commit and to describe them in one message.
+type PendingChangesReader interface {
+	PendingChanges(ctx context.Context) (*PendingChanges, error)
+}
+
+// PendingChanges describes the uncommitted writes since HEAD.
+type PendingChanges struct {
+	// Operations is the number of events recorded since HEAD: bd records
+	// one for every create, update, close, comment, and dependency change.
+	Operations int
+	// IssueIDs are the issues created, changed, or deleted since HEAD,
+	// sorted.
+	IssueIDs []string
+	// HeadDate is when HEAD was committed.
+	HeadDate time.Time
+}
+
+// PoolStatsReader reports on a store's SQL connection pool. Only stores
+// that keep a pool to a sql-server implement it; embedded stores open the
+// database in-process and have nothing to report.
+type PoolStatsReader interface {
+	PoolStats() PoolStats
+}
+
+// PoolStats is a snapshot of a store's connection pool and query timings
+// since the store was opened.
+type PoolStats struct {
+	MaxOpen         int           `json:"max_open"`
+	MaxIdle         int           `json:"max_idle"`
+	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `json:"conn_max_idle_time"`
+
+	Open  int `json:"open"`
+	InUse int `json:"in_use"`
+	Idle  int `json:"idle"`
+
+	// WaitCount and WaitDuration count the times a caller had to wait for
+	// a connection because MaxOpen were all in use.
+	WaitCount    int64         `json:"wait_count"`
+	WaitDuration time.Duration `json:"wait_duration"`
+
+	// Connections the pool closed for exceeding MaxIdle, ConnMaxIdleTime,
+	// and ConnMaxLifetime.
+	MaxIdleClosed     int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed int64 `json:"max_lifetime_closed"`
+
+	// Queries counts statements and transactions run through the store;
+	// SlowQueries those that took at least SlowQueryThreshold.
+	Queries            int64         `json:"queries"`
+	SlowQueries        int64         `json:"slow_queries"`
+	SlowQueryThreshold time.Duration `json:"slow_query_threshold"`
+
+	// StmtCacheHits and StmtCacheMisses count search and ready-work queries
+	// that did and did not find a cached prepared statement; StmtCached of
+	// at most StmtCacheSize statements are cached (size 0: cache disabled).
+	StmtCacheSize   int   `json:"stmt_cache_size"`
+	StmtCached      int   `json:"stmt_cached"`
+	StmtCacheHits   int64 `json:"stmt_cache_hits"`
+	StmtCacheMisses int64 `json:"stmt_cache_misses"`
+}
+
 // BackupStore provides Dolt backup operations (CALL DOLT_BACKUP) for
 // disaster recovery.
 // Callers that need backup functionality should type-assert to this interface.
@@ -609,7 +732,7 @@ type Transaction interface {
 	// both-tiers-spanning reads is governed by the TWO-SESSION WISP CAVEAT above.
 
 	// CountIssuesByGroup returns per-group issue counts. groupBy is one of:
-	// status, priority, type, assignee, label. SPANS BOTH TIERS (merges wisps):
+	// status, priority, type, assignee, label, close_reason. SPANS BOTH TIERS (merges wisps):
 	// subject to the two-session wisp caveat on the server backend. Note it merges
 	// committed wisps into the buckets while the transaction's SearchIssues reads
 	// the issues table only, so their totals need not agree when committed wisps
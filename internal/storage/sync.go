@@ -2,27 +2,114 @@ package storage
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 )
 
 // SyncResult contains the outcome of a Sync operation.
 type SyncResult struct {
 	Peer              string
+	SyncMode          SyncMode // Peer's sync mode; disallowed steps are skipped
 	StartTime         time.Time
 	EndTime           time.Time
 	Fetched           bool
 	Merged            bool
 	Pushed            bool
 	PulledCommits     int
+	PreMergeCommit    string // HEAD before the merge; with PostMergeCommit, bounds what the pull changed
+	PostMergeCommit   string // HEAD after the merge; equal to PreMergeCommit when nothing was pulled
 	PushedCommits     int
 	Conflicts         []Conflict
+	ConflictStrategy  ConflictStrategy // Strategy applied to conflicts (flag or peer default)
 	ConflictsResolved bool
 	Error             error
 	PushError         error // Non-fatal push error
 }
 
+// SyncPreview describes what a Sync with a peer would transfer, computed
+// from the local branch and the peer's remote-tracking ref without merging
+// or pushing anything.
+type SyncPreview struct {
+	Peer              string
+	Branch            string
+	SyncMode          SyncMode // Peer's sync mode; a disallowed direction is left empty
+	MergeBase         string   // Last commit shared with the peer; empty if PeerBranchMissing
+	PeerBranchMissing bool     // The peer has no copy of Branch, so a push would create it
+	Push              SyncPreviewSide
+	Pull              SyncPreviewSide
+}
+
+// SyncPreviewSide lists the changes one direction of a sync would carry.
+type SyncPreviewSide struct {
+	CommitCount int              // Commits the other side lacks
+	Commits     []CommitInfo     // Newest first, at most SyncPreviewLimit
+	Tables      []TableDiffStat  // Row counts changed since the merge base, by table
+	Issues      []SyncPreviewRow // Issue rows changed since the merge base, at most SyncPreviewLimit
+}
+
+// SyncPreviewLimit caps the commits and issue rows listed per direction of a
+// SyncPreview; the counts cover everything.
+const SyncPreviewLimit = 50
+
+// TableDiffStat counts the rows of one table that differ between two commits.
+type TableDiffStat struct {
+	Table    string
+	Added    int
+	Modified int
+	Removed  int
+}
+
+// SyncPreviewRow is an issue row a sync would add, modify, or remove.
+type SyncPreviewRow struct {
+	IssueID  string
+	DiffType string // "added", "modified", or "removed"
+	Title    string
+}
+
 // SyncStore provides sync operations with peers.
 type SyncStore interface {
 	Sync(ctx context.Context, peer string, strategy string) (*SyncResult, error)
 	SyncStatus(ctx context.Context, peer string) (*SyncStatus, error)
 }
+
+// MergeConflictStore inspects and resolves a merge left conflicted in the
+// working set, one issue at a time, for conflicts a sync strategy did not
+// settle.
+type MergeConflictStore interface {
+	GetIssueConflicts(ctx context.Context) ([]IssueConflict, error)
+	// ResolveIssueConflict resolves issueID's conflict with strategy. It
+	// reports false, leaving the conflict in place, when a row-level strategy
+	// cannot decide the row.
+	ResolveIssueConflict(ctx context.Context, issueID string, strategy ConflictStrategy) (bool, error)
+	// AbortMerge abandons the merge, restoring the pre-merge working set.
+	AbortMerge(ctx context.Context) error
+}
+
+// ApplyConflictStrategy resolves merge conflicts with strategy and returns
+// the conflicts left over. ours and theirs resolve every conflicted table;
+// newest and merge resolve the issues rows they can decide and leave the
+// rest, including conflicts on other tables, for the operator.
+func ApplyConflictStrategy(ctx context.Context, vc interface {
+	GetConflicts(ctx context.Context) ([]Conflict, error)
+	ResolveConflicts(ctx context.Context, table string, strategy string) error
+}, conflicts []Conflict, strategy ConflictStrategy) ([]Conflict, error) {
+	for _, c := range conflicts {
+		if strategy.RowLevel() && c.Field != "issues" {
+			continue
+		}
+		if err := vc.ResolveConflicts(ctx, c.Field, string(strategy)); err != nil {
+			return nil, fmt.Errorf("conflict resolution failed for %s: %w", c.Field, err)
+		}
+	}
+	return vc.GetConflicts(ctx)
+}
+
+// ConflictTables returns the comma-separated table names of conflicts.
+func ConflictTables(conflicts []Conflict) string {
+	tables := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		tables[i] = c.Field
+	}
+	return strings.Join(tables, ", ")
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// TrendStore aggregates the events table into per-day counts for the
+// sparkline columns of bd epic status and bd status. Every series covers
+// the last days days, oldest first, with today (UTC) as the last entry.
+// Backends that cannot aggregate events do not implement it; callers then
+// omit the trend columns.
+type TrendStore interface {
+	// GetActivityTrends returns the daily event counts of each of
+	// issueIDs, counting events on the issue itself and on its direct
+	// children. Every requested ID is present in the result.
+	GetActivityTrends(ctx context.Context, issueIDs []string, days int) (map[string][]int, error)
+	// GetEventTrend returns the daily count of events of eventType across
+	// all issues.
+	GetEventTrend(ctx context.Context, eventType types.EventType, days int) ([]int, error)
+}
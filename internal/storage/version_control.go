@@ -26,6 +26,32 @@ type Status struct {
 	Unstaged []StatusEntry
 }
 
+// Checkpoint is a named tag on a Dolt commit, marking a state of the
+// tracker that read commands can revisit with --at.
+type Checkpoint struct {
+	Name    string
+	Hash    string // tagged commit
+	Tagger  string
+	Email   string
+	Date    time.Time
+	Message string
+}
+
+// Checkpointer is implemented by Dolt-backed stores that can tag commits.
+type Checkpointer interface {
+	// CreateCheckpoint tags the current HEAD commit as name, recording
+	// actor as the tagger. It fails if a tag with that name exists.
+	CreateCheckpoint(ctx context.Context, name, message, actor string) (*Checkpoint, error)
+	// ListCheckpoints returns every checkpoint, newest first.
+	ListCheckpoints(ctx context.Context) ([]Checkpoint, error)
+	// BranchAt creates branch pointing at ref: a checkpoint, another
+	// branch, or a commit hash.
+	BranchAt(ctx context.Context, branch, ref string) error
+	// MoveCheckpoint re-points checkpoint c at hash, keeping its name,
+	// message, and tagger.
+	MoveCheckpoint(ctx context.Context, c Checkpoint, hash string) error
+}
+
 // VersionControl provides branch, commit, merge, and status operations.
 type VersionControl interface {
 	Branch(ctx context.Context, name string) error
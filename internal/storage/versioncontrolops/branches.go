@@ -41,6 +41,15 @@ func CreateBranch(ctx context.Context, db DBConn, name string) error {
 	return nil
 }
 
+// CreateBranchAt creates a new Dolt branch pointing at ref (a tag, branch,
+// or commit hash).
+func CreateBranchAt(ctx context.Context, db DBConn, name, ref string) error {
+	if _, err := db.ExecContext(ctx, "CALL DOLT_BRANCH(?, ?)", name, ref); err != nil {
+		return fmt.Errorf("create branch %s at %s: %w", name, ref, err)
+	}
+	return nil
+}
+
 // DeleteBranch force-deletes a Dolt branch.
 func DeleteBranch(ctx context.Context, db DBConn, name string) error {
 	if _, err := db.ExecContext(ctx, "CALL DOLT_BRANCH('-D', ?)", name); err != nil {
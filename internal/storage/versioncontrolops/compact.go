@@ -15,13 +15,16 @@ import (
 //  6. Checkout main, hard-reset to temp branch
 //  7. Delete temp branch
 //
+// It returns the new hash of each replayed commit, keyed by its old hash, so
+// callers can carry tags over to the rewritten history.
+//
 // Callers should run PruneRemoteRefs and then DoltGC afterward to reclaim disk
 // space — remote-tracking refs still anchor the pre-compact chain, and GC
 // alone reclaims nothing while they exist (bd-agctw).
 //
 // conn must be a single database connection (not a pooled *sql.DB) since the
 // stored procedures rely on session-scoped state (current branch, working set).
-func Compact(ctx context.Context, conn DBConn, initialHash, boundaryHash string, oldCommits int, recentHashes []string) (retErr error) {
+func Compact(ctx context.Context, conn DBConn, initialHash, boundaryHash string, oldCommits int, recentHashes []string) (_ map[string]string, retErr error) {
 	branchCreated := false
 
 	// Best-effort cleanup: if any step fails after creating the temp branch,
@@ -42,19 +45,19 @@ func Compact(ctx context.Context, conn DBConn, initialHash, boundaryHash string,
 	}
 
 	if err := execSQL("create temp branch", "CALL DOLT_BRANCH('compact-tmp', ?)", boundaryHash); err != nil {
-		return err
+		return nil, err
 	}
 	branchCreated = true
 
 	if err := execSQL("checkout temp", "CALL DOLT_CHECKOUT('compact-tmp')"); err != nil {
-		return err
+		return nil, err
 	}
 	if err := execSQL("soft reset to initial", "CALL DOLT_RESET('--soft', ?)", initialHash); err != nil {
-		return err
+		return nil, err
 	}
 	msg := fmt.Sprintf("compact: squash %d commits into base snapshot", oldCommits)
 	if err := execSQL("commit squashed base", "CALL DOLT_COMMIT('-Am', ?)", msg); err != nil {
-		return err
+		return nil, err
 	}
 
 	// --allow-empty: the preserved window can contain empty commits (a Dolt
@@ -63,21 +66,28 @@ func Compact(ctx context.Context, conn DBConn, initialHash, boundaryHash string,
 	// aborts the entire replay at the first empty commit with Error 1105
 	// ("The previous cherry-pick commit is empty. Use --allow-empty ..."),
 	// leaving compaction permanently blocked on active databases. See #3815.
+	replayed := make(map[string]string, len(recentHashes))
 	for _, hash := range recentHashes {
-		if err := execSQL(fmt.Sprintf("cherry-pick %s", hash[:min(8, len(hash))]), "CALL DOLT_CHERRY_PICK('--allow-empty', ?)", hash); err != nil {
-			return err
+		step := fmt.Sprintf("cherry-pick %s", hash[:min(8, len(hash))])
+		if err := execSQL(step, "CALL DOLT_CHERRY_PICK('--allow-empty', ?)", hash); err != nil {
+			return nil, err
+		}
+		var newHash string
+		if err := conn.QueryRowContext(ctx, "SELECT DOLT_HASHOF('HEAD')").Scan(&newHash); err != nil {
+			return nil, fmt.Errorf("compact step %q: %w", step, err)
 		}
+		replayed[hash] = newHash
 	}
 
 	if err := execSQL("checkout main", "CALL DOLT_CHECKOUT('main')"); err != nil {
-		return err
+		return nil, err
 	}
 	if err := execSQL("reset main to compacted", "CALL DOLT_RESET('--hard', 'compact-tmp')"); err != nil {
-		return err
+		return nil, err
 	}
 	if err := execSQL("delete temp branch", "CALL DOLT_BRANCH('-D', 'compact-tmp')"); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return replayed, nil
 }
@@ -0,0 +1,391 @@
+package versioncontrolops
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// issueConflictRow is one row of dolt_conflicts_issues split into its base,
+// ours, and theirs images keyed by issues column name. A nil image means that
+// side has no row: it deleted the issue or, for base, both sides added it.
+type issueConflictRow struct {
+	conflictID string
+	cols       []string
+	base       map[string]any
+	ours       map[string]any
+	theirs     map[string]any
+}
+
+func (r *issueConflictRow) issueID() string {
+	for _, image := range []map[string]any{r.ours, r.theirs, r.base} {
+		if image != nil {
+			return conflictString(image["id"])
+		}
+	}
+	return ""
+}
+
+// loadIssueConflictRows reads dolt_conflicts_issues, optionally restricted
+// to one issue. Rows are fully read before returning so callers can write
+// through the same pinned connection.
+func loadIssueConflictRows(ctx context.Context, db DBConn, issueID string) ([]*issueConflictRow, error) {
+	query := "SELECT * FROM dolt_conflicts_issues"
+	var args []any
+	if issueID != "" {
+		query += " WHERE our_id = ? OR their_id = ? OR base_id = ?"
+		args = []any{issueID, issueID, issueID}
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query issues conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("read issues conflict columns: %w", err)
+	}
+	var cols []string
+	for _, name := range names {
+		if col, ok := strings.CutPrefix(name, "base_"); ok {
+			cols = append(cols, col)
+		}
+	}
+
+	var out []*issueConflictRow
+	for rows.Next() {
+		values := make([]any, len(names))
+		ptrs := make([]any, len(names))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, fmt.Errorf("scan issues conflict: %w", err)
+		}
+		byName := make(map[string]any, len(names))
+		for i, name := range names {
+			byName[name] = values[i]
+		}
+		row := &issueConflictRow{conflictID: conflictString(byName["dolt_conflict_id"]), cols: cols}
+		for _, side := range []struct {
+			prefix string
+			image  *map[string]any
+		}{{"base_", &row.base}, {"our_", &row.ours}, {"their_", &row.theirs}} {
+			if byName[side.prefix+"id"] == nil {
+				continue
+			}
+			image := make(map[string]any, len(cols))
+			for _, col := range cols {
+				image[col] = byName[side.prefix+col]
+			}
+			*side.image = image
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// conflictBookkeepingColumns change on every write, so they differ in every
+// conflicted row and are left out of IssueConflict.Fields: updated_at is
+// reported separately and row_lock is a random serialization token.
+var conflictBookkeepingColumns = map[string]bool{"updated_at": true, "row_lock": true}
+
+// describe summarizes the row for display. Fields lists the columns whose
+// local and peer values differ, other than bookkeeping columns, and is empty
+// when one side deleted the issue.
+func (r *issueConflictRow) describe() storage.IssueConflict {
+	c := storage.IssueConflict{
+		IssueID:       r.issueID(),
+		OursDeleted:   r.ours == nil,
+		TheirsDeleted: r.theirs == nil,
+		BothAdded:     r.base == nil,
+	}
+	if t, ok := conflictTime(r.ours["updated_at"]); ok {
+		c.OursUpdatedAt = &t
+	}
+	if t, ok := conflictTime(r.theirs["updated_at"]); ok {
+		c.TheirsUpdatedAt = &t
+	}
+	if r.ours == nil || r.theirs == nil {
+		return c
+	}
+	for _, col := range r.cols {
+		if conflictBookkeepingColumns[col] || conflictValuesEqual(r.ours[col], r.theirs[col]) {
+			continue
+		}
+		c.Fields = append(c.Fields, storage.FieldConflict{
+			Field:  col,
+			Base:   normalizeConflictValue(r.base[col]),
+			Ours:   normalizeConflictValue(r.ours[col]),
+			Theirs: normalizeConflictValue(r.theirs[col]),
+		})
+	}
+	return c
+}
+
+// newer returns the image with the strictly later updated_at, or nil when
+// either side is missing or the timestamps tie.
+func (r *issueConflictRow) newer() map[string]any {
+	if r.ours == nil || r.theirs == nil {
+		return nil
+	}
+	ours, okOurs := conflictTime(r.ours["updated_at"])
+	theirs, okTheirs := conflictTime(r.theirs["updated_at"])
+	switch {
+	case !okOurs || !okTheirs || ours.Equal(theirs):
+		return nil
+	case ours.After(theirs):
+		return r.ours
+	default:
+		return r.theirs
+	}
+}
+
+// decide returns the row the strategy resolves to (nil means the issue is
+// deleted) and whether the strategy could decide at all. newest and merge
+// never decide a delete/modify conflict or a tie they cannot break.
+func (r *issueConflictRow) decide(strategy storage.ConflictStrategy) (map[string]any, bool) {
+	switch strategy {
+	case storage.ConflictStrategyOurs:
+		return r.ours, true
+	case storage.ConflictStrategyTheirs:
+		return r.theirs, true
+	case storage.ConflictStrategyNewest:
+		winner := r.newer()
+		return winner, winner != nil
+	case storage.ConflictStrategyMerge:
+		return r.merge()
+	default:
+		return nil, false
+	}
+}
+
+// merge performs a three-way merge per column: a column changed on only one
+// side since the base takes that side's value, and a column changed on both
+// sides takes the newer side's value (so updated_at ends up the later of the
+// two).
+func (r *issueConflictRow) merge() (map[string]any, bool) {
+	if r.ours == nil || r.theirs == nil {
+		return nil, false
+	}
+	newer := r.newer()
+	merged := make(map[string]any, len(r.cols))
+	for _, col := range r.cols {
+		ours, theirs := r.ours[col], r.theirs[col]
+		switch {
+		case conflictValuesEqual(ours, theirs):
+			merged[col] = ours
+		case r.base != nil && conflictValuesEqual(ours, r.base[col]):
+			merged[col] = theirs
+		case r.base != nil && conflictValuesEqual(theirs, r.base[col]):
+			merged[col] = ours
+		case newer != nil:
+			merged[col] = newer[col]
+		default:
+			return nil, false
+		}
+	}
+	return merged, true
+}
+
+// apply writes the resolved row to the issues table and clears the conflict.
+// Keeping our row as-is only needs the conflict entry removed: the working
+// set already holds our values.
+func (r *issueConflictRow) apply(ctx context.Context, db DBConn, resolved map[string]any) error {
+	id := r.issueID()
+	switch {
+	case resolved == nil && r.ours != nil:
+		if _, err := db.ExecContext(ctx, "DELETE FROM issues WHERE id = ?", id); err != nil {
+			return fmt.Errorf("delete issue %s: %w", id, err)
+		}
+	case resolved != nil && r.ours == nil:
+		cols := make([]string, len(r.cols))
+		args := make([]any, len(r.cols))
+		for i, col := range r.cols {
+			cols[i] = "`" + col + "`"
+			args[i] = resolved[col]
+		}
+		query := fmt.Sprintf("INSERT INTO issues (%s) VALUES (%s)",
+			strings.Join(cols, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", "))
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("restore issue %s: %w", id, err)
+		}
+	case resolved != nil:
+		var sets []string
+		var args []any
+		for _, col := range r.cols {
+			if col == "id" || conflictValuesEqual(resolved[col], r.ours[col]) {
+				continue
+			}
+			sets = append(sets, "`"+col+"` = ?")
+			args = append(args, resolved[col])
+		}
+		if len(sets) > 0 {
+			query := "UPDATE issues SET " + strings.Join(sets, ", ") + " WHERE id = ?"
+			if _, err := db.ExecContext(ctx, query, append(args, id)...); err != nil {
+				return fmt.Errorf("update issue %s: %w", id, err)
+			}
+		}
+	}
+	if _, err := db.ExecContext(ctx,
+		"DELETE FROM dolt_conflicts_issues WHERE dolt_conflict_id = ?", r.conflictID); err != nil {
+		return fmt.Errorf("clear conflict for issue %s: %w", id, err)
+	}
+	return nil
+}
+
+// AllowConflictCommits lets the session commit a working set that still
+// holds merge conflicts. Without it an autocommit DOLT_MERGE that conflicts
+// rolls back, and resolving one conflict while others remain cannot commit.
+// The setting is session-scoped: db must be a pinned connection.
+func AllowConflictCommits(ctx context.Context, db DBConn) error {
+	if _, err := db.ExecContext(ctx, "SET @@dolt_allow_commit_conflicts = 1"); err != nil {
+		return fmt.Errorf("set dolt_allow_commit_conflicts: %w", err)
+	}
+	return nil
+}
+
+// MergeKeepingConflicts merges branch like Merge, but a conflicted merge
+// lands in the working set instead of rolling back, so a conflict strategy
+// or the operator can resolve it afterwards. Conflicts the pull path would
+// auto-resolve are settled first: all of them, committing the merge, when
+// TryAutoResolveMergeConflicts accepts every table, otherwise just the
+// machine-convergent tables (ResolveConvergentConflicts). It returns the
+// conflicts left. db must be a pinned connection (see AllowConflictCommits).
+func MergeKeepingConflicts(ctx context.Context, db DBConn, branch, author string) ([]storage.Conflict, error) {
+	if err := AllowConflictCommits(ctx, db); err != nil {
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, "CALL DOLT_MERGE('--author', ?, ?)", author, branch); err != nil &&
+		!strings.Contains(err.Error(), "up to date") {
+		return nil, fmt.Errorf("merge branch %s: %w", branch, err)
+	}
+	conflicts, err := GetConflicts(ctx, db)
+	if err != nil || len(conflicts) == 0 {
+		return conflicts, err
+	}
+	resolved, err := TryAutoResolveMergeConflicts(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if resolved {
+		return nil, CommitResolvedConflicts(ctx, db)
+	}
+	if err := ResolveConvergentConflicts(ctx, db); err != nil {
+		return nil, err
+	}
+	return GetConflicts(ctx, db)
+}
+
+// AbortMerge abandons an in-progress merge, restoring the pre-merge working
+// set.
+func AbortMerge(ctx context.Context, db DBConn) error {
+	if _, err := db.ExecContext(ctx, "CALL DOLT_MERGE('--abort')"); err != nil {
+		return fmt.Errorf("abort merge: %w", err)
+	}
+	return nil
+}
+
+// GetIssueConflicts lists the conflicted rows of the issues table.
+func GetIssueConflicts(ctx context.Context, db DBConn) ([]storage.IssueConflict, error) {
+	rows, err := loadIssueConflictRows(ctx, db, "")
+	if err != nil {
+		return nil, err
+	}
+	conflicts := make([]storage.IssueConflict, 0, len(rows))
+	for _, row := range rows {
+		conflicts = append(conflicts, row.describe())
+	}
+	return conflicts, nil
+}
+
+// ResolveIssueConflict resolves the conflict on one issue with strategy. It
+// reports false, leaving the conflict in place, when the strategy cannot
+// decide the row.
+func ResolveIssueConflict(ctx context.Context, db DBConn, issueID string, strategy storage.ConflictStrategy) (bool, error) {
+	rows, err := loadIssueConflictRows(ctx, db, issueID)
+	if err != nil {
+		return false, err
+	}
+	if len(rows) == 0 {
+		return false, fmt.Errorf("no merge conflict for issue %s", issueID)
+	}
+	for _, row := range rows {
+		resolved, ok := row.decide(strategy)
+		if !ok {
+			return false, nil
+		}
+		if err := row.apply(ctx, db, resolved); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ResolveIssueConflicts applies strategy to every conflicted issues row and
+// returns how many rows it could not decide; those stay conflicted for
+// manual resolution.
+func ResolveIssueConflicts(ctx context.Context, db DBConn, strategy storage.ConflictStrategy) (int, error) {
+	rows, err := loadIssueConflictRows(ctx, db, "")
+	if err != nil {
+		return 0, err
+	}
+	unresolved := 0
+	for _, row := range rows {
+		resolved, ok := row.decide(strategy)
+		if !ok {
+			unresolved++
+			continue
+		}
+		if err := row.apply(ctx, db, resolved); err != nil {
+			return unresolved, err
+		}
+	}
+	return unresolved, nil
+}
+
+// normalizeConflictValue maps driver byte slices to strings so values from
+// different images compare and render consistently.
+func normalizeConflictValue(v any) any {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v
+}
+
+func conflictValuesEqual(a, b any) bool {
+	a, b = normalizeConflictValue(a), normalizeConflictValue(b)
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func conflictString(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(normalizeConflictValue(v))
+}
+
+// conflictTime reads a DATETIME value, which drivers return either as
+// time.Time or as text depending on parseTime.
+func conflictTime(v any) (time.Time, bool) {
+	switch t := normalizeConflictValue(v).(type) {
+	case time.Time:
+		return t, true
+	case string:
+		for _, layout := range []string{"2006-01-02 15:04:05.999999", time.RFC3339Nano} {
+			if parsed, err := time.Parse(layout, t); err == nil {
+				return parsed, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,88 @@
+package versioncontrolops
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// CheckIntegrity reads back every issue, label, and dependency committed
+// at commit (HEAD when empty) and digests them as VerifyPeer does, then
+// counts the constraint violations recorded in the working set. Storage
+// damage under the committed rows surfaces as an error from the reads.
+func CheckIntegrity(ctx context.Context, db DBConn, commit string) (*storage.IntegrityReport, error) {
+	return checkIntegrity(ctx, db, "", commit)
+}
+
+// CheckBackupIntegrity restores the Dolt backup at url into a scratch
+// database, checks the scratch copy's HEAD as CheckIntegrity does, and drops
+// it again. The report's Commit is the backed-up HEAD, so the caller can
+// check the source database at the same commit and compare roots.
+func CheckBackupIntegrity(ctx context.Context, db DBConn, url string) (report *storage.IntegrityReport, err error) {
+	var suffix [4]byte
+	if _, err := rand.Read(suffix[:]); err != nil {
+		return nil, fmt.Errorf("name scratch database: %w", err)
+	}
+	scratch := "bd_verify_" + hex.EncodeToString(suffix[:])
+
+	if err := BackupRestore(ctx, db, url, scratch, false); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, dropErr := db.ExecContext(ctx, "DROP DATABASE IF EXISTS "+quoteIdent(scratch)); dropErr != nil {
+			err = errors.Join(err, fmt.Errorf("drop scratch database %s: %w", scratch, dropErr))
+			return
+		}
+		// Dolt keeps dropped databases around so they can be undropped;
+		// the scratch copy would otherwise double the disk the backup
+		// takes. Older servers lack the procedure, so this is best effort.
+		_, _ = db.ExecContext(ctx, "CALL DOLT_PURGE_DROPPED_DATABASES()")
+	}()
+	return checkIntegrity(ctx, db, scratch, "")
+}
+
+func checkIntegrity(ctx context.Context, db DBConn, database, commit string) (*storage.IntegrityReport, error) {
+	q := qualifier(database)
+	if commit == "" {
+		if err := db.QueryRowContext(ctx, "SELECT commit_hash FROM "+q+"dolt_log LIMIT 1").Scan(&commit); err != nil {
+			return nil, fmt.Errorf("resolve HEAD: %w", err)
+		}
+	} else if err := issueops.ValidateRef(commit); err != nil {
+		return nil, fmt.Errorf("invalid commit: %w", err)
+	}
+
+	d, err := readIssueDigest(ctx, db, database, commit)
+	if err != nil {
+		return nil, fmt.Errorf("read issues at %s: %w", commit, err)
+	}
+	report := &storage.IntegrityReport{
+		Commit: commit,
+		Root:   hex.EncodeToString(d.root[:]),
+		Issues: len(d.leaves),
+	}
+	if err := db.QueryRowContext(ctx,
+		"SELECT COALESCE(SUM(num_violations), 0) FROM "+q+"dolt_constraint_violations").
+		Scan(&report.ConstraintViolations); err != nil {
+		return nil, fmt.Errorf("count constraint violations: %w", err)
+	}
+	return report, nil
+}
+
+// qualifier returns the "`database`." prefix for table names in database,
+// or "" for the connection's current database.
+func qualifier(database string) string {
+	if database == "" {
+		return ""
+	}
+	return quoteIdent(database) + "."
+}
+
+func quoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
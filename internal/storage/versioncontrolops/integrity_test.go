@@ -0,0 +1,90 @@
+package versioncontrolops
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestCheckIntegrityResolvesHeadAndPinsCommit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	issues := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "title"}).AddRow("bd-a", "One").AddRow("bd-b", "Two")
+	}
+	labels := func() *sqlmock.Rows { return sqlmock.NewRows([]string{"issue_id", "label"}) }
+	violations := func(n int) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(num_violations), 0) FROM dolt_constraint_violations")).
+			WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(n))
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT commit_hash FROM dolt_log LIMIT 1")).
+		WillReturnRows(sqlmock.NewRows([]string{"commit_hash"}).AddRow("abc123"))
+	expectIssueDigest(mock, "abc123", issues(), labels())
+	violations(0)
+	head, err := CheckIntegrity(context.Background(), db, "")
+	if err != nil {
+		t.Fatalf("CheckIntegrity: %v", err)
+	}
+	if head.Commit != "abc123" || head.Issues != 2 || len(head.Root) != 64 || !head.OK() {
+		t.Errorf("HEAD check = %+v, want 2 issues at abc123", head)
+	}
+
+	// Checking the same commit again reads it directly and digests the
+	// same issues to the same root.
+	expectIssueDigest(mock, "abc123", issues(), labels())
+	violations(2)
+	again, err := CheckIntegrity(context.Background(), db, "abc123")
+	if err != nil {
+		t.Fatalf("CheckIntegrity: %v", err)
+	}
+	if again.Root != head.Root || again.ConstraintViolations != 2 || again.OK() {
+		t.Errorf("pinned check = %+v, want root %s and 2 violations", again, head.Root)
+	}
+
+	if _, err := CheckIntegrity(context.Background(), db, "abc'; DROP"); err == nil {
+		t.Error("CheckIntegrity accepted an invalid commit")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckBackupIntegrityDropsScratchDatabase(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	scratch := "`bd_verify_[0-9a-f]{8}`"
+	mock.ExpectExec(regexp.QuoteMeta("CALL DOLT_BACKUP('restore', ?, ?)")).
+		WithArgs("file:///backups/beads", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT commit_hash FROM " + scratch + `\.dolt_log LIMIT 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"commit_hash"}).AddRow("def456"))
+	for _, table := range []string{"issues", "labels", "dependencies"} {
+		mock.ExpectQuery("SELECT \\* FROM " + scratch + `\.` + table + regexp.QuoteMeta(" AS OF 'def456'")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "issue_id"}).AddRow("bd-a", "bd-a"))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(num_violations), 0) FROM ") + scratch + `\.dolt_constraint_violations`).
+		WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(0))
+	mock.ExpectExec("DROP DATABASE IF EXISTS " + scratch).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CALL DOLT_PURGE_DROPPED_DATABASES()")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	r, err := CheckBackupIntegrity(context.Background(), db, "file:///backups/beads")
+	if err != nil {
+		t.Fatalf("CheckBackupIntegrity: %v", err)
+	}
+	if r.Commit != "def456" || r.Issues != 1 {
+		t.Errorf("backup check = %+v, want 1 issue at def456", r)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -279,94 +279,114 @@ func TryAutoResolveMergeConflicts(ctx context.Context, db DBConn) (bool, error)
 
 	// Decide which conflicted tables are safe to auto-resolve. If any conflict is
 	// not safely resolvable, resolve nothing and let the pull fail.
-	var resolvable []string
 	for _, c := range conflicts {
-		switch c.table {
-		case "metadata":
-			resolvable = append(resolvable, "metadata")
-		case "dependencies":
-			auditOnly, err := dependencyConflictsAreAuditOnly(ctx, db)
-			if err != nil {
-				return false, err
-			}
-			if !auditOnly {
-				return false, nil
-			}
-			resolvable = append(resolvable, "dependencies")
-		case "schema_migrations":
-			vintageOnly, err := schemaMigrationsConflictsAreVintageOnly(ctx, db)
-			if err != nil {
-				return false, err
-			}
-			if !vintageOnly {
-				return false, nil
-			}
-			resolvable = append(resolvable, "schema_migrations")
-		case "config":
-			memoryOnly, err := configConflictsAreMemoryConvergent(ctx, db)
-			if err != nil {
-				return false, err
-			}
-			if !memoryOnly {
-				return false, nil
-			}
-			resolvable = append(resolvable, "config")
-		case "issues":
-			lwwSafe, err := issuesConflictsAreLWWSafe(ctx, db)
-			if err != nil {
-				return false, err
-			}
-			if !lwwSafe {
-				return false, nil
-			}
-			resolvable = append(resolvable, "issues")
-		default:
-			return false, nil
+		safe, err := conflictTableAutoResolvable(ctx, db, c.table)
+		if err != nil || !safe {
+			return false, err
 		}
 	}
 
 	// Resolve each safe table and stage only that table (GH#2455).
-	// table is from the fixed allowlist above, never user input.
-	for _, table := range resolvable {
-		switch table {
-		case "schema_migrations":
-			// Row-wise: keep whichever side recorded a content hash, so the
-			// table-level --ours/--theirs choice can never drop one.
-			if err := resolveSchemaMigrationsVintageConflicts(ctx, db); err != nil {
-				return false, err
-			}
-		case "config":
-			// --theirs makes this clone's local kv.memory.* edit lose to the
-			// remote value (the same convergent trade-off metadata makes). That
-			// supersession is otherwise undiagnosable, so name the resolved keys
-			// first. Best-effort: a diagnostics query failure must not abort an
-			// otherwise-correct resolution.
-			if keys, kerr := resolvedConfigConflictKeys(ctx, db); kerr == nil && len(keys) > 0 {
-				fmt.Fprintf(os.Stderr,
-					"Notice: auto-resolved %d memory config conflict(s) with the remote value (--theirs); "+
-						"local edits to %s were superseded\n",
-					len(keys), strings.Join(keys, ", "))
-			}
-			if _, err := db.ExecContext(ctx, "CALL DOLT_CONFLICTS_RESOLVE('--theirs', 'config')"); err != nil {
-				return false, fmt.Errorf("failed to resolve config conflicts: %w", err)
-			}
-		case "issues":
-			if err := resolveIssuesLWWConflicts(ctx, db); err != nil {
-				return false, err
-			}
-		default:
-			//nolint:gosec // G201: table is one of the hardcoded constants above.
-			if _, err := db.ExecContext(ctx, "CALL DOLT_CONFLICTS_RESOLVE('--theirs', '"+table+"')"); err != nil {
-				return false, fmt.Errorf("failed to resolve %s conflicts: %w", table, err)
-			}
+	for _, c := range conflicts {
+		if err := autoResolveConflictTable(ctx, db, c.table); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// conflictTableAutoResolvable reports whether every conflict on table falls
+// in one of the classes TryAutoResolveMergeConflicts resolves without
+// operator input.
+func conflictTableAutoResolvable(ctx context.Context, db DBConn, table string) (bool, error) {
+	switch table {
+	case "metadata":
+		return true, nil
+	case "dependencies":
+		return dependencyConflictsAreAuditOnly(ctx, db)
+	case "schema_migrations":
+		return schemaMigrationsConflictsAreVintageOnly(ctx, db)
+	case "config":
+		return configConflictsAreMemoryConvergent(ctx, db)
+	case "issues":
+		return issuesConflictsAreLWWSafe(ctx, db)
+	default:
+		return false, nil
+	}
+}
+
+// autoResolveConflictTable resolves a table conflictTableAutoResolvable
+// accepted and stages only that table (GH#2455).
+func autoResolveConflictTable(ctx context.Context, db DBConn, table string) error {
+	// table is from the fixed allowlist in conflictTableAutoResolvable, never
+	// user input.
+	switch table {
+	case "schema_migrations":
+		// Row-wise: keep whichever side recorded a content hash, so the
+		// table-level --ours/--theirs choice can never drop one.
+		if err := resolveSchemaMigrationsVintageConflicts(ctx, db); err != nil {
+			return err
+		}
+	case "config":
+		// --theirs makes this clone's local kv.memory.* edit lose to the
+		// remote value (the same convergent trade-off metadata makes). That
+		// supersession is otherwise undiagnosable, so name the resolved keys
+		// first. Best-effort: a diagnostics query failure must not abort an
+		// otherwise-correct resolution.
+		if keys, kerr := resolvedConfigConflictKeys(ctx, db); kerr == nil && len(keys) > 0 {
+			fmt.Fprintf(os.Stderr,
+				"Notice: auto-resolved %d memory config conflict(s) with the remote value (--theirs); "+
+					"local edits to %s were superseded\n",
+				len(keys), strings.Join(keys, ", "))
+		}
+		if _, err := db.ExecContext(ctx, "CALL DOLT_CONFLICTS_RESOLVE('--theirs', 'config')"); err != nil {
+			return fmt.Errorf("failed to resolve config conflicts: %w", err)
+		}
+	case "issues":
+		if err := resolveIssuesLWWConflicts(ctx, db); err != nil {
+			return err
 		}
+	default:
 		//nolint:gosec // G201: table is one of the hardcoded constants above.
-		if _, err := db.ExecContext(ctx, "CALL DOLT_ADD('"+table+"')"); err != nil {
-			return false, fmt.Errorf("failed to stage %s: %w", table, err)
+		if _, err := db.ExecContext(ctx, "CALL DOLT_CONFLICTS_RESOLVE('--theirs', '"+table+"')"); err != nil {
+			return fmt.Errorf("failed to resolve %s conflicts: %w", table, err)
 		}
 	}
+	//nolint:gosec // G201: table is one of the hardcoded constants above.
+	if _, err := db.ExecContext(ctx, "CALL DOLT_ADD('"+table+"')"); err != nil {
+		return fmt.Errorf("failed to stage %s: %w", table, err)
+	}
+	return nil
+}
 
-	return true, nil
+// ResolveConvergentConflicts auto-resolves the machine-convergent conflicted
+// tables (metadata, memory-only config, audit-only dependencies, migration
+// vintage skew) on their own, even when other tables hold conflicts that
+// need a strategy or the operator. Unlike TryAutoResolveMergeConflicts it
+// leaves issues conflicts alone: the caller's strategy decides those. db must
+// allow conflict commits (see AllowConflictCommits).
+func ResolveConvergentConflicts(ctx context.Context, db DBConn) error {
+	conflicts, err := GetConflicts(ctx, db)
+	if err != nil {
+		return err
+	}
+	for _, c := range conflicts {
+		if c.Field == "issues" {
+			continue
+		}
+		safe, err := conflictTableAutoResolvable(ctx, db, c.Field)
+		if err != nil {
+			return err
+		}
+		if !safe {
+			continue
+		}
+		if err := autoResolveConflictTable(ctx, db, c.Field); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // CommitResolvedConflicts creates the dolt commit that concludes a merge whose
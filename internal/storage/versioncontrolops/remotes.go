@@ -115,3 +115,13 @@ func Pull(ctx context.Context, db DBConn, remote, branch, user string) error {
 	}
 	return nil
 }
+
+// MergeBase returns the most recent common ancestor commit of two refs, such
+// as a local branch and a fetched remote-tracking branch.
+func MergeBase(ctx context.Context, db DBConn, left, right string) (string, error) {
+	var hash string
+	if err := db.QueryRowContext(ctx, "SELECT DOLT_MERGE_BASE(?, ?)", left, right).Scan(&hash); err != nil {
+		return "", fmt.Errorf("merge base of %s and %s: %w", left, right, err)
+	}
+	return hash, nil
+}
@@ -0,0 +1,168 @@
+package versioncontrolops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// PreviewSync compares the local HEAD with the cached remote-tracking ref
+// peer/branch and reports what a sync would push and pull, skipping the
+// directions mode forbids. It reads only; the caller fetches first so the
+// tracking ref is current.
+//
+// When the peer has no copy of branch, the preview lists the local commits a
+// push would create it with and leaves the table and row diffs empty.
+func PreviewSync(ctx context.Context, db DBConn, peer, branch string, mode storage.SyncMode) (*storage.SyncPreview, error) {
+	preview := &storage.SyncPreview{Peer: peer, Branch: branch, SyncMode: mode}
+	remoteRef := peer + "/" + branch
+	if err := issueops.ValidateRef(remoteRef); err != nil {
+		return nil, fmt.Errorf("invalid peer ref: %w", err)
+	}
+
+	var tracked int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM dolt_remote_branches WHERE name = ?",
+		"remotes/"+remoteRef).Scan(&tracked); err != nil {
+		return nil, fmt.Errorf("look up remote-tracking ref %s: %w", remoteRef, err)
+	}
+	if tracked == 0 {
+		preview.PeerBranchMissing = true
+		if mode.AllowsPush() {
+			var err error
+			if preview.Push.CommitCount, preview.Push.Commits, err = previewCommits(ctx, db, "dolt_log", ""); err != nil {
+				return nil, err
+			}
+		}
+		return preview, nil
+	}
+
+	base, err := MergeBase(ctx, db, "HEAD", remoteRef)
+	if err != nil {
+		return nil, err
+	}
+	preview.MergeBase = base
+
+	// Dolt's AS OF and table functions require literal refs; remoteRef is
+	// validated above and base is a commit hash.
+	remoteLog := fmt.Sprintf("dolt_log AS OF '%s'", remoteRef)
+	if mode.AllowsPush() {
+		if err := previewSide(ctx, db, &preview.Push, "dolt_log", remoteLog, base, "HEAD"); err != nil {
+			return nil, fmt.Errorf("preview push to %s: %w", peer, err)
+		}
+	}
+	if mode.AllowsPull() {
+		if err := previewSide(ctx, db, &preview.Pull, remoteLog, "dolt_log", base, remoteRef); err != nil {
+			return nil, fmt.Errorf("preview pull from %s: %w", peer, err)
+		}
+	}
+	return preview, nil
+}
+
+// previewSide fills side with the commits in fromLog missing from otherLog
+// and the rows that changed between base and tip.
+func previewSide(ctx context.Context, db DBConn, side *storage.SyncPreviewSide, fromLog, otherLog, base, tip string) error {
+	var err error
+	if side.CommitCount, side.Commits, err = previewCommits(ctx, db, fromLog, "SELECT commit_hash FROM "+otherLog); err != nil {
+		return err
+	}
+	if side.CommitCount == 0 {
+		return nil
+	}
+	if side.Tables, err = diffStat(ctx, db, base, tip); err != nil {
+		return err
+	}
+	side.Issues, err = diffIssueRows(ctx, db, base, tip)
+	return err
+}
+
+// previewCommits counts the commits of fromLog whose hashes the exclude
+// subquery does not return (all of them if exclude is empty) and lists the
+// newest storage.SyncPreviewLimit of them.
+//
+//nolint:gosec // G201: fromLog and exclude are built from validated refs — AS OF requires a literal
+func previewCommits(ctx context.Context, db DBConn, fromLog, exclude string) (int, []storage.CommitInfo, error) {
+	where := "FROM " + fromLog
+	if exclude != "" {
+		where += fmt.Sprintf(" WHERE commit_hash NOT IN (%s)", exclude)
+	}
+
+	var count int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) "+where).Scan(&count); err != nil {
+		return 0, nil, fmt.Errorf("count commits: %w", err)
+	}
+	if count == 0 {
+		return 0, nil, nil
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT commit_hash, committer, email, date, message "+where+
+		" ORDER BY date DESC LIMIT ?", storage.SyncPreviewLimit)
+	if err != nil {
+		return 0, nil, fmt.Errorf("list commits: %w", err)
+	}
+	defer rows.Close()
+
+	var commits []storage.CommitInfo
+	for rows.Next() {
+		var c storage.CommitInfo
+		if err := rows.Scan(&c.Hash, &c.Author, &c.Email, &c.Date, &c.Message); err != nil {
+			return 0, nil, fmt.Errorf("scan commit: %w", err)
+		}
+		commits = append(commits, c)
+	}
+	return count, commits, rows.Err()
+}
+
+// diffStat returns the per-table row counts that differ between two refs,
+// sorted by table name.
+//
+//nolint:gosec // G201: refs are validated by the caller — dolt_diff_stat requires literal refs
+func diffStat(ctx context.Context, db DBConn, fromRef, toRef string) ([]storage.TableDiffStat, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT table_name, COALESCE(rows_added, 0), COALESCE(rows_modified, 0), COALESCE(rows_deleted, 0)
+		FROM dolt_diff_stat('%s', '%s')
+		ORDER BY table_name`, fromRef, toRef))
+	if err != nil {
+		return nil, fmt.Errorf("diff stat: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []storage.TableDiffStat
+	for rows.Next() {
+		var st storage.TableDiffStat
+		if err := rows.Scan(&st.Table, &st.Added, &st.Modified, &st.Removed); err != nil {
+			return nil, fmt.Errorf("scan diff stat: %w", err)
+		}
+		if st.Added+st.Modified+st.Removed > 0 {
+			stats = append(stats, st)
+		}
+	}
+	return stats, rows.Err()
+}
+
+// diffIssueRows lists up to storage.SyncPreviewLimit issue rows that differ
+// between two refs.
+//
+//nolint:gosec // G201: refs are validated by the caller — dolt_diff requires literal refs
+func diffIssueRows(ctx context.Context, db DBConn, fromRef, toRef string) ([]storage.SyncPreviewRow, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT COALESCE(to_id, from_id), diff_type, COALESCE(to_title, from_title, '')
+		FROM dolt_diff('%s', '%s', 'issues')
+		ORDER BY COALESCE(to_id, from_id)
+		LIMIT %d`, fromRef, toRef, storage.SyncPreviewLimit))
+	if err != nil {
+		return nil, fmt.Errorf("diff issues: %w", err)
+	}
+	defer rows.Close()
+
+	var changed []storage.SyncPreviewRow
+	for rows.Next() {
+		var r storage.SyncPreviewRow
+		if err := rows.Scan(&r.IssueID, &r.DiffType, &r.Title); err != nil {
+			return nil, fmt.Errorf("scan issue diff: %w", err)
+		}
+		changed = append(changed, r)
+	}
+	return changed, rows.Err()
+}
@@ -0,0 +1,71 @@
+package versioncontrolops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+const tagColumns = "tag_name, tag_hash, tagger, email, date, message"
+
+// CreateTag creates an annotated Dolt tag on ref. author must have the
+// form "Name <email>".
+func CreateTag(ctx context.Context, db DBConn, name, ref, message, author string) error {
+	if _, err := db.ExecContext(ctx, "CALL DOLT_TAG('-m', ?, '--author', ?, ?, ?)", message, author, name, ref); err != nil {
+		return fmt.Errorf("create tag %s: %w", name, err)
+	}
+	return nil
+}
+
+// MoveTag re-points tag c at hash, keeping its name, message, and tagger.
+// If the tag cannot be recreated on hash it is restored where it was.
+func MoveTag(ctx context.Context, db DBConn, c storage.Checkpoint, hash string) error {
+	if _, err := db.ExecContext(ctx, "CALL DOLT_TAG('-d', ?)", c.Name); err != nil {
+		return fmt.Errorf("move tag %s: %w", c.Name, err)
+	}
+	author := fmt.Sprintf("%s <%s>", c.Tagger, c.Email)
+	if err := CreateTag(ctx, db, c.Name, hash, c.Message, author); err != nil {
+		if restoreErr := CreateTag(ctx, db, c.Name, c.Hash, c.Message, author); restoreErr != nil {
+			return fmt.Errorf("move tag %s: %w (restoring it also failed: %v)", c.Name, err, restoreErr)
+		}
+		return fmt.Errorf("move tag %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// GetTag returns the named tag, or nil if it does not exist.
+func GetTag(ctx context.Context, db DBConn, name string) (*storage.Checkpoint, error) {
+	var c storage.Checkpoint
+	err := db.QueryRowContext(ctx, "SELECT "+tagColumns+" FROM dolt_tags WHERE tag_name = ?", name).
+		Scan(&c.Name, &c.Hash, &c.Tagger, &c.Email, &c.Date, &c.Message)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get tag %s: %w", name, err)
+	}
+	return &c, nil
+}
+
+// ListTagsWithInfo returns every Dolt tag with its target and annotation,
+// newest first.
+func ListTagsWithInfo(ctx context.Context, db DBConn) ([]storage.Checkpoint, error) {
+	rows, err := db.QueryContext(ctx, "SELECT "+tagColumns+" FROM dolt_tags ORDER BY date DESC, tag_name")
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []storage.Checkpoint
+	for rows.Next() {
+		var c storage.Checkpoint
+		if err := rows.Scan(&c.Name, &c.Hash, &c.Tagger, &c.Email, &c.Date, &c.Message); err != nil {
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		tags = append(tags, c)
+	}
+	return tags, rows.Err()
+}
@@ -0,0 +1,222 @@
+package versioncontrolops
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// issueDigestBuckets is the fan-out of an issueDigest: issues are grouped by
+// the first byte of the SHA-256 of their ID.
+const issueDigestBuckets = 256
+
+type digest = [sha256.Size]byte
+
+// issueDigest is a two-level Merkle tree over the issues at one ref. Each
+// leaf hashes an issue row with its labels and dependencies; each bucket
+// hashes its leaves in ID order; the root hashes the buckets. Two refs hold
+// the same issues exactly when their roots match, and only the buckets whose
+// digests differ need their leaves compared.
+type issueDigest struct {
+	leaves  map[string]digest
+	ids     [issueDigestBuckets][]string // sorted
+	buckets [issueDigestBuckets]digest
+	root    digest
+}
+
+// VerifyPeer compares digests of the issues committed on the local branch
+// (HEAD) with the cached remote-tracking ref peer/branch, and lists the
+// issues that differ. It reads only; the caller fetches first so the
+// tracking ref is current.
+//
+// When the peer has no copy of branch, only the local root is reported.
+func VerifyPeer(ctx context.Context, db DBConn, peer, branch string) (*storage.PeerVerification, error) {
+	v := &storage.PeerVerification{Peer: peer, Branch: branch}
+	remoteRef := peer + "/" + branch
+	if err := issueops.ValidateRef(remoteRef); err != nil {
+		return nil, fmt.Errorf("invalid peer ref: %w", err)
+	}
+
+	local, err := readIssueDigest(ctx, db, "", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("digest local issues: %w", err)
+	}
+	v.LocalRoot = hex.EncodeToString(local.root[:])
+	v.LocalIssues = len(local.leaves)
+
+	var tracked int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM dolt_remote_branches WHERE name = ?",
+		"remotes/"+remoteRef).Scan(&tracked); err != nil {
+		return nil, fmt.Errorf("look up remote-tracking ref %s: %w", remoteRef, err)
+	}
+	if tracked == 0 {
+		v.PeerBranchMissing = true
+		return v, nil
+	}
+
+	remote, err := readIssueDigest(ctx, db, "", remoteRef)
+	if err != nil {
+		return nil, fmt.Errorf("digest issues of %s: %w", remoteRef, err)
+	}
+	v.PeerRoot = hex.EncodeToString(remote.root[:])
+	v.PeerIssues = len(remote.leaves)
+	compareIssueDigests(v, local, remote)
+	return v, nil
+}
+
+// compareIssueDigests records in v the buckets whose digests differ and,
+// within them, the issues missing from one side or hashing differently.
+func compareIssueDigests(v *storage.PeerVerification, local, remote *issueDigest) {
+	if local.root == remote.root {
+		return
+	}
+	for b := range issueDigestBuckets {
+		if local.buckets[b] == remote.buckets[b] {
+			continue
+		}
+		v.DivergentBuckets++
+		for _, id := range local.ids[b] {
+			peerLeaf, ok := remote.leaves[id]
+			switch {
+			case !ok:
+				v.Differences = append(v.Differences, storage.IssueDifference{IssueID: id, Kind: storage.IssueOnlyLocal})
+			case peerLeaf != local.leaves[id]:
+				v.Differences = append(v.Differences, storage.IssueDifference{IssueID: id, Kind: storage.IssueChanged})
+			}
+		}
+		for _, id := range remote.ids[b] {
+			if _, ok := local.leaves[id]; !ok {
+				v.Differences = append(v.Differences, storage.IssueDifference{IssueID: id, Kind: storage.IssueOnlyPeer})
+			}
+		}
+	}
+	slices.SortFunc(v.Differences, func(a, b storage.IssueDifference) int {
+		return strings.Compare(a.IssueID, b.IssueID)
+	})
+}
+
+// readIssueDigest builds the issueDigest of the issues at ref in database
+// (the connection's current database when empty). Every column of each row
+// is hashed, so any change a sync would carry shows up.
+//
+//nolint:gosec // G201: ref is HEAD or validated by the caller — AS OF requires a literal
+func readIssueDigest(ctx context.Context, db DBConn, database, ref string) (*issueDigest, error) {
+	q := qualifier(database)
+	issues, err := hashRows(ctx, db, fmt.Sprintf("SELECT * FROM %sissues AS OF '%s'", q, ref), "id")
+	if err != nil {
+		return nil, fmt.Errorf("issues: %w", err)
+	}
+	labels, err := hashRows(ctx, db, fmt.Sprintf("SELECT * FROM %slabels AS OF '%s'", q, ref), "issue_id")
+	if err != nil {
+		return nil, fmt.Errorf("labels: %w", err)
+	}
+	deps, err := hashRows(ctx, db, fmt.Sprintf("SELECT * FROM %sdependencies AS OF '%s'", q, ref), "issue_id")
+	if err != nil {
+		return nil, fmt.Errorf("dependencies: %w", err)
+	}
+
+	d := &issueDigest{leaves: make(map[string]digest, len(issues))}
+	for id, rows := range issues {
+		h := sha256.New()
+		for _, part := range []struct {
+			table string
+			rows  []digest
+		}{{"issues", rows}, {"labels", labels[id]}, {"dependencies", deps[id]}} {
+			slices.SortFunc(part.rows, func(a, b digest) int { return slices.Compare(a[:], b[:]) })
+			writeField(h, []byte(part.table))
+			for _, r := range part.rows {
+				h.Write(r[:])
+			}
+		}
+		var leaf digest
+		h.Sum(leaf[:0])
+		d.leaves[id] = leaf
+		b := sha256.Sum256([]byte(id))[0]
+		d.ids[b] = append(d.ids[b], id)
+	}
+
+	root := sha256.New()
+	for b := range issueDigestBuckets {
+		slices.Sort(d.ids[b])
+		h := sha256.New()
+		for _, id := range d.ids[b] {
+			leaf := d.leaves[id]
+			writeField(h, []byte(id))
+			h.Write(leaf[:])
+		}
+		h.Sum(d.buckets[b][:0])
+		root.Write(d.buckets[b][:])
+	}
+	root.Sum(d.root[:0])
+	return d, nil
+}
+
+// hashRows runs query and hashes each row, grouped by the value of keyCol.
+// Columns are hashed by name in sorted order and NULLs are skipped, so
+// column order and a nullable column only one side has added do not change
+// the hash.
+func hashRows(ctx context.Context, db DBConn, query, keyCol string) (map[string][]digest, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	order := make([]int, len(cols))
+	key := -1
+	for i, c := range cols {
+		order[i] = i
+		if c == keyCol {
+			key = i
+		}
+	}
+	if key < 0 {
+		return nil, fmt.Errorf("no %s column", keyCol)
+	}
+	slices.SortFunc(order, func(a, b int) int { return strings.Compare(cols[a], cols[b]) })
+
+	vals := make([]sql.NullString, len(cols))
+	dest := make([]any, len(cols))
+	for i := range vals {
+		dest[i] = &vals[i]
+	}
+	out := make(map[string][]digest)
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		h := sha256.New()
+		for _, i := range order {
+			if vals[i].Valid {
+				writeField(h, []byte(cols[i]))
+				writeField(h, []byte(vals[i].String))
+			}
+		}
+		var d digest
+		h.Sum(d[:0])
+		out[vals[key].String] = append(out[vals[key].String], d)
+	}
+	return out, rows.Err()
+}
+
+// writeField writes b length-prefixed, so adjacent fields cannot run
+// together into the same bytes.
+func writeField(h hash.Hash, b []byte) {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(b)))
+	h.Write(n[:])
+	h.Write(b)
+}
@@ -0,0 +1,120 @@
+package versioncontrolops
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// expectIssueDigest queues the three queries readIssueDigest runs for ref.
+func expectIssueDigest(mock sqlmock.Sqlmock, ref string, issues, labels *sqlmock.Rows) {
+	asOf := " AS OF '" + ref + "'"
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM issues" + asOf)).WillReturnRows(issues)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM labels" + asOf)).WillReturnRows(labels)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM dependencies" + asOf)).
+		WillReturnRows(sqlmock.NewRows([]string{"issue_id", "depends_on_id", "type"}))
+}
+
+func TestVerifyPeerPinpointsDifferingIssues(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	expectIssueDigest(mock, "HEAD",
+		sqlmock.NewRows([]string{"id", "title", "closed_at"}).
+			AddRow("bd-a", "Same", nil).
+			AddRow("bd-b", "Local title", nil).
+			AddRow("bd-c", "Only here", nil).
+			AddRow("bd-e", "Label differs", nil),
+		sqlmock.NewRows([]string{"issue_id", "label"}).AddRow("bd-a", "x").AddRow("bd-e", "old"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM dolt_remote_branches WHERE name = ?")).
+		WithArgs("remotes/peer/main").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+	// The peer lists columns in another order and lacks the nullable
+	// closed_at column; neither changes an issue's digest.
+	expectIssueDigest(mock, "peer/main",
+		sqlmock.NewRows([]string{"title", "id"}).
+			AddRow("Same", "bd-a").
+			AddRow("Peer title", "bd-b").
+			AddRow("Label differs", "bd-e").
+			AddRow("Only there", "bd-d"),
+		sqlmock.NewRows([]string{"label", "issue_id"}).AddRow("x", "bd-a").AddRow("new", "bd-e"))
+
+	v, err := VerifyPeer(context.Background(), db, "peer", "main")
+	if err != nil {
+		t.Fatalf("VerifyPeer: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []storage.IssueDifference{
+		{IssueID: "bd-b", Kind: storage.IssueChanged},
+		{IssueID: "bd-c", Kind: storage.IssueOnlyLocal},
+		{IssueID: "bd-d", Kind: storage.IssueOnlyPeer},
+		{IssueID: "bd-e", Kind: storage.IssueChanged},
+	}
+	if len(v.Differences) != len(want) {
+		t.Fatalf("differences = %+v, want %+v", v.Differences, want)
+	}
+	for i := range want {
+		if v.Differences[i] != want[i] {
+			t.Errorf("differences[%d] = %+v, want %+v", i, v.Differences[i], want[i])
+		}
+	}
+	if v.Consistent() || v.LocalRoot == v.PeerRoot || v.LocalIssues != 4 || v.PeerIssues != 4 {
+		t.Errorf("verification = %+v, want diverged roots over 4 issues each", v)
+	}
+	if v.DivergentBuckets < 1 || v.DivergentBuckets > 4 {
+		t.Errorf("divergent buckets = %d, want 1-4", v.DivergentBuckets)
+	}
+}
+
+func TestVerifyPeerConsistentAndMissingBranch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	issues := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "title"}).AddRow("bd-a", "One").AddRow("bd-b", "Two")
+	}
+	labels := func() *sqlmock.Rows { return sqlmock.NewRows([]string{"issue_id", "label"}) }
+	countRemote := func(n int) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM dolt_remote_branches WHERE name = ?")).
+			WithArgs("remotes/peer/main").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(n))
+	}
+
+	expectIssueDigest(mock, "HEAD", issues(), labels())
+	countRemote(1)
+	expectIssueDigest(mock, "peer/main", issues(), labels())
+	v, err := VerifyPeer(context.Background(), db, "peer", "main")
+	if err != nil {
+		t.Fatalf("VerifyPeer: %v", err)
+	}
+	if !v.Consistent() || len(v.Differences) != 0 || v.DivergentBuckets != 0 || len(v.LocalRoot) != 64 {
+		t.Errorf("identical sides: %+v, want consistent", v)
+	}
+	root := v.LocalRoot
+
+	expectIssueDigest(mock, "HEAD", issues(), labels())
+	countRemote(0)
+	v, err = VerifyPeer(context.Background(), db, "peer", "main")
+	if err != nil {
+		t.Fatalf("VerifyPeer: %v", err)
+	}
+	if !v.PeerBranchMissing || v.Consistent() || v.PeerRoot != "" {
+		t.Errorf("missing peer branch: %+v, want PeerBranchMissing and not consistent", v)
+	}
+	if v.LocalRoot != root {
+		t.Errorf("local root changed between runs: %s vs %s", v.LocalRoot, root)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
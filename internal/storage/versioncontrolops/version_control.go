@@ -129,8 +129,10 @@ func GetConflicts(ctx context.Context, db DBConn) ([]storage.Conflict, error) {
 	return conflicts, rows.Err()
 }
 
-// ResolveConflicts resolves conflicts for a table using the given strategy
-// ("ours" or "theirs").
+// ResolveConflicts resolves conflicts for a table using the given strategy.
+// "ours" and "theirs" resolve the whole table; "newest" and "merge" apply
+// only to the issues table and leave rows they cannot decide conflicted
+// (see ResolveIssueConflicts).
 func ResolveConflicts(ctx context.Context, db DBConn, table, strategy string) error {
 	if err := validateTableName(table); err != nil {
 		return fmt.Errorf("invalid table name: %w", err)
@@ -142,6 +144,12 @@ func ResolveConflicts(ctx context.Context, db DBConn, table, strategy string) er
 		query = fmt.Sprintf("CALL DOLT_CONFLICTS_RESOLVE('--ours', '%s')", table)
 	case "theirs":
 		query = fmt.Sprintf("CALL DOLT_CONFLICTS_RESOLVE('--theirs', '%s')", table)
+	case "newest", "merge":
+		if table != "issues" {
+			return fmt.Errorf("%s strategy only applies to the issues table, not %s", strategy, table)
+		}
+		_, err := ResolveIssueConflicts(ctx, db, storage.ConflictStrategy(strategy))
+		return err
 	default:
 		return fmt.Errorf("unknown conflict resolution strategy: %s", strategy)
 	}
@@ -2,6 +2,7 @@
 package storage
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -23,6 +24,24 @@ type DiffEntry struct {
 	NewValue *types.Issue // State after (nil for "removed")
 }
 
+// DependencyDiffEntry represents a dependency edge that changed between two
+// commits.
+type DependencyDiffEntry struct {
+	IssueID     string               // The dependent issue
+	DependsOnID string               // The issue (or external ref) it depends on
+	DiffType    string               // "added", "modified", or "removed"
+	Type        types.DependencyType // Edge type after the change (before, for "removed")
+	OldType     types.DependencyType // Edge type before a "modified" change
+}
+
+// LabelDiffEntry represents a label added to or removed from an issue
+// between two commits.
+type LabelDiffEntry struct {
+	IssueID  string
+	Label    string
+	DiffType string // "added" or "removed"
+}
+
 // Conflict represents a merge conflict.
 type Conflict struct {
 	IssueID     string      // The ID of the conflicting issue
@@ -47,14 +66,115 @@ type SyncStatus struct {
 }
 
 // FederationPeer represents a remote peer with authentication credentials.
-// Used for peer-to-peer Dolt remotes between workspaces with SQL user auth.
+// Used for peer-to-peer Dolt remotes between workspaces with SQL user auth,
+// or SSH key auth for peers reachable only over SSH.
 type FederationPeer struct {
-	Name        string     // Unique name for this peer (used as remote name)
-	RemoteURL   string     // Dolt remote URL (e.g., http://host:port/org/db)
-	Username    string     // SQL username for authentication
-	Password    string     // Password (decrypted, not stored directly)
-	Sovereignty string     // Sovereignty tier: T1, T2, T3, T4
-	LastSync    *time.Time // Last successful sync time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Name             string           // Unique name for this peer (used as remote name)
+	RemoteURL        string           // Dolt remote URL (e.g., http://host:port/org/db)
+	Username         string           // SQL username for authentication
+	Password         string           // Password (decrypted, not stored directly)
+	SSHKeyPath       string           // Private key file for SSH remotes
+	SSHKeyPassphrase string           // Key passphrase (decrypted, not stored directly)
+	CredentialSource string           // env:VAR or cmd:helper resolved at sync time instead of a stored password (see ParseCredentialSource)
+	Sovereignty      string           // Sovereignty tier: T1, T2, T3, T4
+	OwnedPrefixes    []string         // ID prefixes of the issues this peer owns (see SovereigntyPolicy)
+	SyncMode         SyncMode         // Directions this peer syncs in (empty means bidirectional)
+	ConflictStrategy ConflictStrategy // How Sync resolves merge conflicts from this peer (empty means fail)
+	EncryptMetadata  bool             // Store Username and RemoteURL encrypted with the credential key
+	LastSync         *time.Time       // Last successful sync time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// SyncMode restricts the directions a federation peer syncs in.
+type SyncMode string
+
+const (
+	SyncModeBidirectional SyncMode = "bidirectional" // pull and push (the default)
+	SyncModePullOnly      SyncMode = "pull-only"     // read-only upstream: never pushed to
+	SyncModePushOnly      SyncMode = "push-only"     // write-only mirror: never pulled from
+)
+
+// ParseSyncMode validates a sync mode name. The empty string is
+// bidirectional, so peers stored before sync modes existed keep syncing both
+// ways.
+func ParseSyncMode(s string) (SyncMode, error) {
+	switch mode := SyncMode(s); mode {
+	case "", SyncModeBidirectional:
+		return SyncModeBidirectional, nil
+	case SyncModePullOnly, SyncModePushOnly:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid sync mode %q (must be bidirectional, pull-only, or push-only)", s)
+	}
+}
+
+// ConflictStrategy selects how merge conflicts pulled from a federation peer
+// are resolved.
+type ConflictStrategy string
+
+const (
+	ConflictStrategyOurs   ConflictStrategy = "ours"   // keep the local row
+	ConflictStrategyTheirs ConflictStrategy = "theirs" // take the peer's row
+	ConflictStrategyNewest ConflictStrategy = "newest" // the row with the later updated_at wins
+	ConflictStrategyMerge  ConflictStrategy = "merge"  // field-level three-way merge, newer side breaks ties
+)
+
+// ParseConflictStrategy validates a conflict strategy name. The empty string
+// is returned unchanged and means conflicts are left for manual resolution.
+func ParseConflictStrategy(s string) (ConflictStrategy, error) {
+	switch strategy := ConflictStrategy(s); strategy {
+	case "", ConflictStrategyOurs, ConflictStrategyTheirs, ConflictStrategyNewest, ConflictStrategyMerge:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid conflict strategy %q (must be ours, theirs, newest, or merge)", s)
+	}
+}
+
+// RowLevel reports whether the strategy decides issues-table conflicts row
+// by row (newest, merge) rather than resolving a whole table to one side.
+// Row-level strategies only apply to the issues table and may leave rows
+// they cannot decide for manual resolution.
+func (c ConflictStrategy) RowLevel() bool {
+	return c == ConflictStrategyNewest || c == ConflictStrategyMerge
+}
+
+// IssueConflict describes one conflicted row of the issues table after a
+// merge. A nil OursUpdatedAt or TheirsUpdatedAt with the matching Deleted
+// flag means that side deleted the issue.
+type IssueConflict struct {
+	IssueID         string          `json:"issue_id"`
+	OursDeleted     bool            `json:"ours_deleted,omitempty"`
+	TheirsDeleted   bool            `json:"theirs_deleted,omitempty"`
+	BothAdded       bool            `json:"both_added,omitempty"` // no common ancestor row
+	OursUpdatedAt   *time.Time      `json:"ours_updated_at,omitempty"`
+	TheirsUpdatedAt *time.Time      `json:"theirs_updated_at,omitempty"`
+	Fields          []FieldConflict `json:"fields,omitempty"`
+}
+
+// FieldConflict is a column whose local and peer values differ.
+type FieldConflict struct {
+	Field  string      `json:"field"`
+	Base   interface{} `json:"base"`
+	Ours   interface{} `json:"ours"`
+	Theirs interface{} `json:"theirs"`
+}
+
+// AllowsPull reports whether changes may be pulled (fetched and merged) from
+// a peer in this mode.
+func (m SyncMode) AllowsPull() bool { return m != SyncModePushOnly }
+
+// AllowsPush reports whether changes may be pushed to a peer in this mode.
+func (m SyncMode) AllowsPush() bool { return m != SyncModePullOnly }
+
+// CheckSyncDirection returns an ErrSyncDirection error when mode does not
+// allow pushing to (push) or pulling from (!push) peer.
+func CheckSyncDirection(peer string, mode SyncMode, push bool) error {
+	switch {
+	case push && !mode.AllowsPush():
+		return fmt.Errorf("%w: peer %s is %s and cannot be pushed to", ErrSyncDirection, peer, mode)
+	case !push && !mode.AllowsPull():
+		return fmt.Errorf("%w: peer %s is %s and cannot be pulled from", ErrSyncDirection, peer, mode)
+	}
+	return nil
 }
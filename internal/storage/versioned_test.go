@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSyncMode(t *testing.T) {
+	for in, want := range map[string]SyncMode{
+		"":              SyncModeBidirectional,
+		"bidirectional": SyncModeBidirectional,
+		"pull-only":     SyncModePullOnly,
+		"push-only":     SyncModePushOnly,
+	} {
+		got, err := ParseSyncMode(in)
+		if err != nil || got != want {
+			t.Errorf("ParseSyncMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseSyncMode("sideways"); err == nil {
+		t.Error("expected error for unknown sync mode")
+	}
+}
+
+func TestCheckSyncDirection(t *testing.T) {
+	tests := []struct {
+		mode    SyncMode
+		push    bool
+		allowed bool
+	}{
+		{SyncModeBidirectional, true, true},
+		{SyncModeBidirectional, false, true},
+		{"", true, true},
+		{SyncModePullOnly, false, true},
+		{SyncModePullOnly, true, false},
+		{SyncModePushOnly, true, true},
+		{SyncModePushOnly, false, false},
+	}
+	for _, tt := range tests {
+		err := CheckSyncDirection("upstream", tt.mode, tt.push)
+		if tt.allowed && err != nil {
+			t.Errorf("%q push=%v: unexpected error %v", tt.mode, tt.push, err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrSyncDirection) {
+			t.Errorf("%q push=%v: err = %v, want ErrSyncDirection", tt.mode, tt.push, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Package summary runs a user-configured summarizer command over an issue
+// (or an epic and its descendants) and stores the result in issue metadata
+// (see MetadataKey).
+//
+// bd does not talk to a model itself: the command reads the rendered issue
+// on stdin and prints the summary on stdout, so any CLI (llm, an API script,
+// a local model) can be plugged in.
+package summary
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MetadataKey is the metadata key the summary is stored under.
+const MetadataKey = "summary"
+
+// Scopes a summary can cover.
+const (
+	ScopeIssue = "issue"
+	ScopeEpic  = "epic"
+)
+
+// Summary is the stored result of a summarizer run.
+type Summary struct {
+	Text        string    `json:"text"`
+	Scope       string    `json:"scope"`
+	SourceHash  string    `json:"source_hash"` // ContentHash of the issue when summarized
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// FromMetadata returns the summary stored in metadata, or nil when there is
+// none.
+func FromMetadata(metadata json.RawMessage) (*Summary, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return nil, nil // not an object: nothing of ours in it
+	}
+	raw, ok := wrapper[MetadataKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var s Summary
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("parsing metadata.%s: %w", MetadataKey, err)
+	}
+	if strings.TrimSpace(s.Text) == "" {
+		return nil, nil
+	}
+	return &s, nil
+}
+
+// ContentHash fingerprints the issue text a summary is built from: title,
+// description, design, acceptance criteria, and notes.
+func ContentHash(issue *types.Issue) string {
+	h := sha256.New()
+	for _, part := range []string{issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Current reports whether s was generated from the issue's present content.
+// Epic summaries only track the epic's own content, not its descendants.
+func (s *Summary) Current(issue *types.Issue) bool {
+	return s != nil && s.SourceHash == ContentHash(issue)
+}
+
+// Document renders issues as the markdown the summarizer reads. The first
+// issue is the subject; for an epic the rest are its descendants.
+func Document(issues []*types.Issue, comments map[string][]*types.Comment) string {
+	var b strings.Builder
+	for i, issue := range issues {
+		if i > 0 {
+			b.WriteString("\n---\n\n")
+		}
+		fmt.Fprintf(&b, "# %s: %s\n\n", issue.ID, issue.Title)
+		fmt.Fprintf(&b, "Type: %s · Status: %s · Priority: P%d\n", issue.IssueType, issue.Status, issue.Priority)
+		for _, sec := range []struct{ name, text string }{
+			{"Description", issue.Description},
+			{"Design", issue.Design},
+			{"Acceptance Criteria", issue.AcceptanceCriteria},
+			{"Notes", issue.Notes},
+		} {
+			if text := strings.TrimSpace(sec.text); text != "" {
+				fmt.Fprintf(&b, "\n## %s\n\n%s\n", sec.name, text)
+			}
+		}
+		if cs := comments[issue.ID]; len(cs) > 0 {
+			b.WriteString("\n## Comments\n\n")
+			for _, c := range cs {
+				fmt.Fprintf(&b, "- %s (%s): %s\n", c.Author, c.CreatedAt.Format("2006-01-02"), strings.TrimSpace(c.Text))
+			}
+		}
+	}
+	return b.String()
+}
+
+// Run pipes document to command through the shell and returns its trimmed
+// stdout. BD_SUMMARY_ID and BD_SUMMARY_SCOPE tell the command what it is
+// summarizing.
+func Run(ctx context.Context, command, document, id, scope string, timeout time.Duration) (string, error) {
+	if strings.TrimSpace(command) == "" {
+		return "", fmt.Errorf("no summarizer configured (bd config set summarize.command \"<command>\")")
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", command) // #nosec G204 -- command comes from the user's own config
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command) // #nosec G204 -- command comes from the user's own config
+	}
+	cmd.Env = append(os.Environ(), "BD_SUMMARY_ID="+id, "BD_SUMMARY_SCOPE="+scope)
+	// Don't wait on grandchildren that outlive a killed shell and hold the
+	// output pipes open.
+	cmd.WaitDelay = time.Second
+	cmd.Stdin = strings.NewReader(document)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("summarizer timed out after %s", timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("summarizer failed: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("summarizer failed: %w", err)
+	}
+	text := strings.TrimSpace(stdout.String())
+	if text == "" {
+		return "", fmt.Errorf("summarizer printed nothing")
+	}
+	return text, nil
+}
@@ -0,0 +1,118 @@
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFromMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		want     string
+		wantErr  bool
+	}{
+		{"empty", ``, "", false},
+		{"not an object", `[1,2]`, "", false},
+		{"no summary", `{"team":"platform"}`, "", false},
+		{"null summary", `{"summary":null}`, "", false},
+		{"blank text", `{"summary":{"text":"  "}}`, "", false},
+		{"present", `{"summary":{"text":"short","scope":"issue"},"team":"x"}`, "short", false},
+		{"malformed", `{"summary":"short"}`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := FromMetadata(json.RawMessage(tt.metadata))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := ""
+			if s != nil {
+				got = s.Text
+			}
+			if got != tt.want {
+				t.Errorf("text = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	issue := &types.Issue{Title: "Fix login", Description: "Long story"}
+	s := &Summary{Text: "short", SourceHash: ContentHash(issue)}
+	if !s.Current(issue) {
+		t.Fatal("summary should be current for unchanged content")
+	}
+	issue.Status = types.StatusClosed
+	issue.Priority = 0
+	if !s.Current(issue) {
+		t.Error("status and priority changes should not make a summary stale")
+	}
+	issue.Notes = "new finding"
+	if s.Current(issue) {
+		t.Error("a notes change should make the summary stale")
+	}
+	var none *Summary
+	if none.Current(issue) {
+		t.Error("nil summary is never current")
+	}
+}
+
+func TestDocument(t *testing.T) {
+	epic := &types.Issue{ID: "bd-1", Title: "Auth epic", IssueType: types.TypeEpic, Status: types.StatusOpen, Priority: 1, Description: "Rework auth"}
+	child := &types.Issue{ID: "bd-1.1", Title: "Login", IssueType: types.TypeTask, Status: types.StatusClosed, Priority: 2, Notes: "done via OAuth"}
+	comments := map[string][]*types.Comment{
+		"bd-1.1": {{Author: "alice", Text: "shipped", CreatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+	doc := Document([]*types.Issue{epic, child}, comments)
+	for _, want := range []string{
+		"# bd-1: Auth epic",
+		"Type: epic · Status: open · Priority: P1",
+		"## Description\n\nRework auth",
+		"\n---\n",
+		"# bd-1.1: Login",
+		"## Notes\n\ndone via OAuth",
+		"- alice (2025-03-01): shipped",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document missing %q:\n%s", want, doc)
+		}
+	}
+	if strings.Contains(doc, "## Design") {
+		t.Error("empty sections should be omitted")
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	ctx := context.Background()
+
+	got, err := Run(ctx, `printf '%s:%s:' "$BD_SUMMARY_ID" "$BD_SUMMARY_SCOPE"; wc -l | tr -d ' '`, "a\nb\n", "bd-7", ScopeEpic, time.Minute)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got != "bd-7:epic:2" {
+		t.Errorf("Run = %q, want %q", got, "bd-7:epic:2")
+	}
+
+	for name, command := range map[string]string{
+		"unconfigured": "  ",
+		"failure":      "echo boom >&2; exit 3",
+		"empty output": "cat >/dev/null",
+	} {
+		if _, err := Run(ctx, command, "doc", "bd-7", ScopeIssue, time.Minute); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := Run(ctx, "sleep 5", "doc", "bd-7", ScopeIssue, 50*time.Millisecond); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+}
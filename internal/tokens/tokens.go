@@ -0,0 +1,133 @@
+// Package tokens implements agent tokens: secrets handed to an agent session
+// (BD_TOKEN) that limit which bd commands it may run and which issues it may
+// touch. Only a hash of each secret is stored.
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SecretPrefix starts every token secret, so leaked tokens are recognizable.
+const SecretPrefix = "bdt_"
+
+// ErrExpired is returned by Verify for a token past its expiry.
+var ErrExpired = errors.New("token expired")
+
+// ErrInvalid is returned by Verify when the secret does not match the token.
+var ErrInvalid = errors.New("invalid token")
+
+// Token is the stored record of an agent token.
+type Token struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Hash      string     `json:"hash"`
+	Allow     []string   `json:"allow"`
+	Scope     Scope      `json:"scope,omitempty"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Scope limits the issues a token may touch. Every listed label must be on
+// an issue for the token to read or change it.
+type Scope struct {
+	Labels []string `json:"labels,omitempty"`
+}
+
+// ParseScope parses --scope values of the form "label=<name>".
+func ParseScope(specs []string) (Scope, error) {
+	var s Scope
+	for _, spec := range specs {
+		key, value, ok := strings.Cut(strings.TrimSpace(spec), "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || value == "" {
+			return Scope{}, fmt.Errorf("invalid scope %q (want label=<name>)", spec)
+		}
+		switch key {
+		case "label":
+			s.Labels = append(s.Labels, value)
+		default:
+			return Scope{}, fmt.Errorf("unknown scope %q (supported: label)", key)
+		}
+	}
+	return s, nil
+}
+
+// IsEmpty reports whether the scope allows every issue.
+func (s Scope) IsEmpty() bool { return len(s.Labels) == 0 }
+
+// String renders the scope as it is given to --scope.
+func (s Scope) String() string {
+	parts := make([]string, 0, len(s.Labels))
+	for _, l := range s.Labels {
+		parts = append(parts, "label="+l)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Contains reports whether an issue with labels is inside the scope.
+func (s Scope) Contains(labels []string) bool {
+	for _, want := range s.Labels {
+		found := false
+		for _, have := range labels {
+			found = found || have == want
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// New creates a token and returns it with its secret. The secret is shown
+// once; only its hash is kept on the token.
+func New(name string, allow []string, scope Scope, createdBy string, ttl time.Duration, now time.Time) (*Token, string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", fmt.Errorf("generating token: %w", err)
+	}
+	secret := SecretPrefix + hex.EncodeToString(buf)
+	t := &Token{
+		ID:        IDForSecret(secret),
+		Name:      name,
+		Hash:      hashSecret(secret),
+		Allow:     allow,
+		Scope:     scope,
+		CreatedBy: createdBy,
+		CreatedAt: now.UTC(),
+	}
+	if ttl > 0 {
+		expires := now.Add(ttl).UTC()
+		t.ExpiresAt = &expires
+	}
+	return t, secret, nil
+}
+
+// IDForSecret derives the public ID a secret is stored under.
+func IDForSecret(secret string) string {
+	sum := sha256.Sum256([]byte("id:" + secret))
+	return hex.EncodeToString(sum[:6])
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks secret against the token and its expiry.
+func (t *Token) Verify(secret string, now time.Time) error {
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(t.Hash)) != 1 {
+		return ErrInvalid
+	}
+	if t.ExpiresAt != nil && now.After(*t.ExpiresAt) {
+		return fmt.Errorf("%w at %s", ErrExpired, t.ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package tokens
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAndVerify(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tok, secret, err := New("crawler", []string{"create"}, Scope{}, "alice", time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(secret, SecretPrefix) || strings.Contains(tok.Hash, secret) {
+		t.Fatalf("secret %q / hash %q", secret, tok.Hash)
+	}
+	if tok.ID != IDForSecret(secret) {
+		t.Errorf("ID = %s, want %s", tok.ID, IDForSecret(secret))
+	}
+	if err := tok.Verify(secret, now); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if err := tok.Verify(secret+"x", now); !errors.Is(err, ErrInvalid) {
+		t.Errorf("wrong secret: %v", err)
+	}
+	if err := tok.Verify(secret, now.Add(2*time.Hour)); !errors.Is(err, ErrExpired) {
+		t.Errorf("expired: %v", err)
+	}
+}
+
+func TestParseScope(t *testing.T) {
+	s, err := ParseScope([]string{"label=crawler", " label = infra "})
+	if err != nil || len(s.Labels) != 2 || s.Labels[1] != "infra" {
+		t.Fatalf("ParseScope = %+v, %v", s, err)
+	}
+	if s.String() != "label=crawler,label=infra" {
+		t.Errorf("String = %q", s.String())
+	}
+	if !s.Contains([]string{"infra", "crawler", "x"}) || s.Contains([]string{"crawler"}) {
+		t.Error("Contains should require every scope label")
+	}
+	for _, bad := range []string{"label", "label=", "owner=bob"} {
+		if _, err := ParseScope([]string{bad}); err == nil {
+			t.Errorf("ParseScope(%q) should fail", bad)
+		}
+	}
+	if !(Scope{}).Contains(nil) {
+		t.Error("empty scope should contain everything")
+	}
+}
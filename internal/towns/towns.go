@@ -0,0 +1,155 @@
+// Package towns keeps the user's registry of beads projects ("towns").
+//
+// Operators who supervise many agent projects register each project's
+// .beads directory under a short name once, then switch between them,
+// search across them, and see them side by side without changing
+// directories. The registry is a small JSON file in the user config
+// directory and never lives inside a project.
+package towns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/atomicfile"
+)
+
+// FileEnv overrides the registry location (used by tests and by operators
+// who keep one registry per profile).
+const FileEnv = "BEADS_TOWNS_FILE"
+
+// ErrNotFound is returned when no town has the requested name.
+var ErrNotFound = errors.New("town not found")
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Town is one registered beads project.
+type Town struct {
+	Name    string    `json:"name"`
+	Path    string    `json:"path"` // Absolute path of the project's .beads directory
+	AddedAt time.Time `json:"added_at"`
+}
+
+// Registry is the set of known towns and the one commands use when run
+// outside any beads project.
+type Registry struct {
+	Current string  `json:"current,omitempty"`
+	Towns   []*Town `json:"towns"`
+}
+
+// Path returns the registry file: $BEADS_TOWNS_FILE, or towns.json in
+// ~/.config/bd.
+func Path() (string, error) {
+	if p := os.Getenv(FileEnv); p != "" {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locate towns registry: %w", err)
+	}
+	return filepath.Join(home, ".config", "bd", "towns.json"), nil
+}
+
+// Load reads the registry at path. A missing file is an empty registry.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the user's own registry file
+	if errors.Is(err, os.ErrNotExist) {
+		return &Registry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read towns registry: %w", err)
+	}
+	var r Registry
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse towns registry %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// Save writes the registry to path atomically, towns sorted by name.
+func (r *Registry) Save(path string) error {
+	sort.Slice(r.Towns, func(i, j int) bool { return r.Towns[i].Name < r.Towns[j].Name })
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create towns registry directory: %w", err)
+	}
+	return atomicfile.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// Get returns the town called name.
+func (r *Registry) Get(name string) (*Town, error) {
+	for _, t := range r.Towns {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// CurrentTown returns the switched-to town, or nil when none is set.
+func (r *Registry) CurrentTown() *Town {
+	if r.Current == "" {
+		return nil
+	}
+	t, err := r.Get(r.Current)
+	if err != nil {
+		return nil
+	}
+	return t
+}
+
+// Add registers beadsDir under name. Names and directories are unique:
+// re-adding the same pair is a no-op, anything else that clashes is an
+// error.
+func (r *Registry) Add(name, beadsDir string, now time.Time) (*Town, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid town name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	for _, t := range r.Towns {
+		switch {
+		case t.Name == name && t.Path == beadsDir:
+			return t, nil
+		case t.Name == name:
+			return nil, fmt.Errorf("town %s already points at %s", name, t.Path)
+		case t.Path == beadsDir:
+			return nil, fmt.Errorf("%s is already registered as town %s", beadsDir, t.Name)
+		}
+	}
+	t := &Town{Name: name, Path: beadsDir, AddedAt: now.UTC().Truncate(time.Second)}
+	r.Towns = append(r.Towns, t)
+	return t, nil
+}
+
+// Remove unregisters the town called name, clearing it as current.
+func (r *Registry) Remove(name string) error {
+	for i, t := range r.Towns {
+		if t.Name == name {
+			r.Towns = append(r.Towns[:i], r.Towns[i+1:]...)
+			if r.Current == name {
+				r.Current = ""
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// Switch makes the town called name current; an empty name clears it.
+func (r *Registry) Switch(name string) error {
+	if name != "" {
+		if _, err := r.Get(name); err != nil {
+			return err
+		}
+	}
+	r.Current = name
+	return nil
+}
@@ -0,0 +1,75 @@
+package towns
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bd", "towns.json")
+	r, err := Load(path)
+	if err != nil || len(r.Towns) != 0 {
+		t.Fatalf("Load(missing) = %+v, %v; want an empty registry", r, err)
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if _, err := r.Add("beta", "/work/beta/.beads", now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Add("alpha", "/work/alpha/.beads", now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Add("alpha", "/work/alpha/.beads", now); err != nil {
+		t.Errorf("re-adding the same town: %v", err)
+	}
+	for _, tc := range []struct{ name, dir string }{
+		{"alpha", "/elsewhere/.beads"},
+		{"gamma", "/work/beta/.beads"},
+		{"-bad", "/work/bad/.beads"},
+		{"has space", "/work/space/.beads"},
+	} {
+		if _, err := r.Add(tc.name, tc.dir, now); err == nil {
+			t.Errorf("Add(%q, %q) succeeded, want an error", tc.name, tc.dir)
+		}
+	}
+
+	if err := r.Switch("nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Switch(unknown) err = %v, want ErrNotFound", err)
+	}
+	if err := r.Switch("beta"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Towns) != 2 || r.Towns[0].Name != "alpha" || r.Towns[1].Name != "beta" {
+		t.Fatalf("towns after reload = %+v, want alpha then beta", r.Towns)
+	}
+	if cur := r.CurrentTown(); cur == nil || cur.Path != "/work/beta/.beads" {
+		t.Errorf("CurrentTown() = %+v, want beta", cur)
+	}
+
+	if err := r.Remove("beta"); err != nil {
+		t.Fatal(err)
+	}
+	if r.Current != "" || r.CurrentTown() != nil {
+		t.Errorf("removing the current town left Current = %q", r.Current)
+	}
+	if err := r.Remove("beta"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Remove(removed) err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestPathHonorsEnv(t *testing.T) {
+	t.Setenv(FileEnv, "/tmp/custom-towns.json")
+	if p, err := Path(); err != nil || p != "/tmp/custom-towns.json" {
+		t.Errorf("Path() = %q, %v", p, err)
+	}
+}
@@ -0,0 +1,88 @@
+package tracker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// columnStatusKeywords maps words found in board column names (Trello lists,
+// Notion status options) to beads statuses. Earlier entries win, so "Not
+// started" is checked before "started" and "Done" before anything else.
+var columnStatusKeywords = []struct {
+	keyword string
+	status  types.Status
+}{
+	{"not started", types.StatusOpen},
+	{"to do", types.StatusOpen},
+	{"todo", types.StatusOpen},
+	{"done", types.StatusClosed},
+	{"complete", types.StatusClosed},
+	{"closed", types.StatusClosed},
+	{"finished", types.StatusClosed},
+	{"shipped", types.StatusClosed},
+	{"released", types.StatusClosed},
+	{"resolved", types.StatusClosed},
+	{"archived", types.StatusClosed},
+	{"cancel", types.StatusClosed},
+	{"block", types.StatusBlocked},
+	{"waiting", types.StatusBlocked},
+	{"on hold", types.StatusBlocked},
+	{"icebox", types.StatusDeferred},
+	{"someday", types.StatusDeferred},
+	{"later", types.StatusDeferred},
+	{"deferred", types.StatusDeferred},
+	{"progress", types.StatusInProgress},
+	{"doing", types.StatusInProgress},
+	{"review", types.StatusInProgress},
+	{"testing", types.StatusInProgress},
+	{"qa", types.StatusInProgress},
+	{"wip", types.StatusInProgress},
+	{"started", types.StatusInProgress},
+	{"active", types.StatusInProgress},
+}
+
+// ColumnStatus guesses the beads status for a board column or status option
+// name, such as a Trello list ("Doing") or a Notion status ("Not started").
+// Entries in overrides, keyed by lowercased column name, win over the guess.
+// Unrecognized names are open.
+func ColumnStatus(name string, overrides map[string]types.Status) types.Status {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if s, ok := overrides[key]; ok {
+		return s
+	}
+	normalized := strings.NewReplacer("_", " ", "-", " ").Replace(key)
+	if s := types.Status(strings.ReplaceAll(normalized, " ", "_")); s.IsValid() {
+		return s
+	}
+	words := " " + strings.Join(strings.Fields(normalized), " ") + " "
+	for _, kw := range columnStatusKeywords {
+		if strings.Contains(words, " "+kw.keyword) {
+			return kw.status
+		}
+	}
+	return types.StatusOpen
+}
+
+// ParseColumnStatusMap parses a "Backlog=deferred,QA=in_progress" override
+// string for ColumnStatus. Column names are matched case-insensitively.
+func ParseColumnStatusMap(spec string) (map[string]types.Status, error) {
+	out := map[string]types.Status{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid status mapping %q (want column=status)", part)
+		}
+		status := types.Status(strings.ToLower(strings.TrimSpace(value)))
+		if !status.IsValid() {
+			return nil, fmt.Errorf("invalid status %q for column %q", value, name)
+		}
+		out[strings.ToLower(strings.TrimSpace(name))] = status
+	}
+	return out, nil
+}
@@ -0,0 +1,49 @@
+package tracker
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestColumnStatus(t *testing.T) {
+	overrides := map[string]types.Status{"parking lot": types.StatusDeferred}
+	tests := map[string]types.Status{
+		"To Do":           types.StatusOpen,
+		"Backlog":         types.StatusOpen,
+		"Not started":     types.StatusOpen,
+		"Doing":           types.StatusInProgress,
+		"In Progress":     types.StatusInProgress,
+		"Code review":     types.StatusInProgress,
+		"Blocked":         types.StatusBlocked,
+		"Icebox":          types.StatusDeferred,
+		"Done":            types.StatusClosed,
+		"Done ✅":          types.StatusClosed,
+		"Completed":       types.StatusClosed,
+		"closed":          types.StatusClosed,
+		"in_progress":     types.StatusInProgress,
+		"Parking Lot":     types.StatusDeferred,
+		"Ideas":           types.StatusOpen,
+		"Quality backlog": types.StatusOpen,
+	}
+	for name, want := range tests {
+		if got := ColumnStatus(name, overrides); got != want {
+			t.Errorf("ColumnStatus(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestParseColumnStatusMap(t *testing.T) {
+	got, err := ParseColumnStatusMap("QA=in_progress, Parking Lot = deferred,")
+	if err != nil {
+		t.Fatalf("ParseColumnStatusMap() error: %v", err)
+	}
+	if got["qa"] != types.StatusInProgress || got["parking lot"] != types.StatusDeferred || len(got) != 2 {
+		t.Errorf("ParseColumnStatusMap() = %v", got)
+	}
+	for _, bad := range []string{"QA", "QA=later"} {
+		if _, err := ParseColumnStatusMap(bad); err == nil {
+			t.Errorf("ParseColumnStatusMap(%q): expected error", bad)
+		}
+	}
+}
@@ -997,6 +997,47 @@ func TestEngineSyncDoesNotCreateFalseConflictsAfterPull(t *testing.T) {
 	}
 }
 
+func TestEngineSyncNoCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	defer store.Close()
+
+	lastSync := time.Now().UTC().Add(-1 * time.Hour).Format(time.RFC3339)
+	if err := store.SetLocalMetadata(ctx, "test.last_sync", lastSync); err != nil {
+		t.Fatalf("SetLocalMetadata() error: %v", err)
+	}
+
+	tracker := newMockTracker("test")
+	tracker.issues = []TrackerIssue{{ID: "EXT-1", Identifier: "EXT-1", URL: "https://test.test/EXT-1", Title: "Imported"}}
+	var sawSince bool
+	tracker.fetchIssues = func(_ context.Context, opts FetchOptions) ([]TrackerIssue, error) {
+		sawSince = opts.Since != nil
+		return tracker.issues, nil
+	}
+
+	engine := NewEngine(tracker, store, "test-actor")
+	result, err := engine.Sync(ctx, SyncOptions{Pull: true, NoCheckpoint: true})
+	if err != nil {
+		t.Fatalf("Sync() error: %v", err)
+	}
+	if sawSince {
+		t.Error("NoCheckpoint pull fetched incrementally; want a full fetch")
+	}
+	if result.Stats.Created != 1 {
+		t.Errorf("created = %d, want 1", result.Stats.Created)
+	}
+	if result.LastSync != "" {
+		t.Errorf("result.LastSync = %q, want empty", result.LastSync)
+	}
+	got, err := store.GetLocalMetadata(ctx, "test.last_sync")
+	if err != nil {
+		t.Fatalf("GetLocalMetadata() error: %v", err)
+	}
+	if got != lastSync {
+		t.Errorf("last_sync = %q, want it left at %q", got, lastSync)
+	}
+}
+
 func TestEnginePullWithShouldImport(t *testing.T) {
 	ctx := context.Background()
 	store := newTestStore(t)
@@ -112,6 +112,10 @@ type SyncOptions struct {
 	// DependencySources limits which dependency sources pull creates from tracker
 	// mapper output. Empty means all dependency sources are created.
 	DependencySources []DependencySource
+	// NoCheckpoint makes a one-off sync, such as an import: pull fetches
+	// everything rather than only changes since last_sync, and the
+	// last_sync checkpoint is left untouched for the next regular sync.
+	NoCheckpoint bool
 }
 
 // SyncResult is the complete result of a sync operation.
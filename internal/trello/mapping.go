@@ -0,0 +1,176 @@
+package trello
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ListMetadataKey records the Trello list a card came from, since several
+// lists (say "Backlog" and "Next") can map to the same status.
+const ListMetadataKey = "trello_list"
+
+// MappingConfig configures how cards map to beads issues.
+type MappingConfig struct {
+	// StatusMap overrides the status guessed from a list's name, keyed by
+	// lowercased list name.
+	StatusMap map[string]types.Status
+	// SkipArchived drops archived cards instead of importing them closed.
+	SkipArchived bool
+}
+
+// ToIssues converts every card on the board into a beads issue, in board
+// order (by list, then by position within the list).
+func (c *MappingConfig) ToIssues(b *Board) []*types.Issue {
+	lists := make(map[string]*List, len(b.Lists))
+	for i := range b.Lists {
+		lists[b.Lists[i].ID] = &b.Lists[i]
+	}
+	members := make(map[string]*Member, len(b.Members))
+	for i := range b.Members {
+		members[b.Members[i].ID] = &b.Members[i]
+	}
+	checklists := make(map[string][]*Checklist)
+	for i := range b.Checklists {
+		cl := &b.Checklists[i]
+		checklists[cl.IDCard] = append(checklists[cl.IDCard], cl)
+	}
+
+	cards := make([]*Card, 0, len(b.Cards))
+	for i := range b.Cards {
+		cards = append(cards, &b.Cards[i])
+	}
+	listPos := func(id string) float64 {
+		if l := lists[id]; l != nil {
+			return l.Pos
+		}
+		return 0
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		if pi, pj := listPos(cards[i].IDList), listPos(cards[j].IDList); pi != pj {
+			return pi < pj
+		}
+		return cards[i].Pos < cards[j].Pos
+	})
+
+	issues := make([]*types.Issue, 0, len(cards))
+	for _, card := range cards {
+		list := lists[card.IDList]
+		archived := card.Closed || (list != nil && list.Closed)
+		if archived && c.SkipArchived {
+			continue
+		}
+		issues = append(issues, c.toIssue(card, list, archived, members, checklists[card.ID]))
+	}
+	return issues
+}
+
+func (c *MappingConfig) toIssue(card *Card, list *List, archived bool, members map[string]*Member, checklists []*Checklist) *types.Issue {
+	ref := card.ExternalRef()
+	title := strings.TrimSpace(card.Name)
+	if title == "" {
+		title = "Untitled card"
+	}
+	issue := &types.Issue{
+		Title:              title,
+		Description:        strings.TrimSpace(card.Desc),
+		AcceptanceCriteria: RenderChecklists(checklists),
+		Status:             types.StatusOpen,
+		Priority:           2,
+		IssueType:          types.TypeTask,
+		Labels:             cardLabels(card),
+		ExternalRef:        &ref,
+		SourceSystem:       "trello",
+	}
+	if list != nil {
+		issue.Status = tracker.ColumnStatus(list.Name, c.StatusMap)
+		if meta, err := json.Marshal(map[string]string{ListMetadataKey: list.Name}); err == nil {
+			issue.Metadata = meta
+		}
+	}
+	if archived {
+		issue.Status = types.StatusClosed
+	}
+	for _, id := range card.IDMembers {
+		if m := members[id]; m != nil {
+			issue.Assignee = m.Username
+			if issue.Assignee == "" {
+				issue.Assignee = m.FullName
+			}
+			break
+		}
+	}
+	if card.Due != nil && !card.DueComplete {
+		due := card.Due.UTC()
+		issue.DueAt = &due
+	}
+
+	now := time.Now().UTC()
+	issue.CreatedAt = now
+	if created, ok := card.CreatedAt(); ok {
+		issue.CreatedAt = created
+	}
+	issue.UpdatedAt = issue.CreatedAt
+	if card.DateLastActivity != nil && card.DateLastActivity.After(issue.CreatedAt) {
+		issue.UpdatedAt = card.DateLastActivity.UTC()
+	}
+	if issue.Status == types.StatusClosed {
+		closed := issue.UpdatedAt
+		issue.ClosedAt = &closed
+	}
+	return issue
+}
+
+// cardLabels returns the card's label names, using the color for labels
+// that have no name.
+func cardLabels(card *Card) []string {
+	var labels []string
+	seen := map[string]bool{}
+	for _, l := range card.Labels {
+		name := strings.TrimSpace(l.Name)
+		if name == "" {
+			name = strings.TrimSpace(l.Color)
+		}
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		labels = append(labels, name)
+	}
+	return labels
+}
+
+// RenderChecklists renders checklists as markdown task lists, one section per
+// checklist, preserving each item's checked state.
+func RenderChecklists(checklists []*Checklist) string {
+	sorted := append([]*Checklist(nil), checklists...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Pos < sorted[j].Pos })
+
+	var b strings.Builder
+	for _, cl := range sorted {
+		if len(cl.CheckItems) == 0 {
+			continue
+		}
+		items := append([]CheckItem(nil), cl.CheckItems...)
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		if name := strings.TrimSpace(cl.Name); name != "" {
+			fmt.Fprintf(&b, "%s\n", name)
+		}
+		for _, item := range items {
+			box := "[ ]"
+			if item.IsComplete() {
+				box = "[x]"
+			}
+			fmt.Fprintf(&b, "- %s %s\n", box, strings.TrimSpace(item.Name))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,122 @@
+package trello
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const testBoard = `{
+  "id": "5f0000000000000000000001",
+  "name": "Team",
+  "lists": [
+    {"id": "L2", "name": "Doing", "pos": 2},
+    {"id": "L1", "name": "To Do", "pos": 1},
+    {"id": "L3", "name": "Parking lot", "pos": 3, "closed": true}
+  ],
+  "members": [{"id": "M1", "username": "alice", "fullName": "Alice"}],
+  "cards": [
+    {"id": "5f1a2b3c0000000000000002", "name": "Build it", "idList": "L2", "pos": 1,
+     "shortUrl": "https://trello.com/c/bbb222", "dateLastActivity": "2024-03-02T10:00:00.000Z",
+     "due": "2024-04-01T12:00:00.000Z", "idMembers": ["M1"],
+     "labels": [{"id": "a", "name": "backend"}, {"id": "b", "name": "", "color": "red"}]},
+    {"id": "5f1a2b3c0000000000000001", "name": "  Write docs ", "desc": "The docs", "idList": "L1", "pos": 1},
+    {"id": "5f1a2b3c0000000000000003", "name": "Archived", "idList": "L1", "pos": 2, "closed": true},
+    {"id": "5f1a2b3c0000000000000004", "name": "Old", "idList": "L3", "pos": 1}
+  ],
+  "checklists": [
+    {"id": "C2", "name": "Later", "idCard": "5f1a2b3c0000000000000002", "pos": 2,
+     "checkItems": [{"id": "i3", "name": "third", "state": "incomplete", "pos": 1}]},
+    {"id": "C1", "name": "Steps", "idCard": "5f1a2b3c0000000000000002", "pos": 1,
+     "checkItems": [{"id": "i2", "name": "second", "state": "incomplete", "pos": 2},
+                    {"id": "i1", "name": "first", "state": "complete", "pos": 1}]}
+  ]
+}`
+
+func TestToIssues(t *testing.T) {
+	board, err := DecodeBoard(strings.NewReader(testBoard))
+	if err != nil {
+		t.Fatalf("DecodeBoard() error: %v", err)
+	}
+	issues := (&MappingConfig{}).ToIssues(board)
+	if len(issues) != 4 {
+		t.Fatalf("ToIssues() returned %d issues, want 4", len(issues))
+	}
+
+	// Board order: To Do cards, then Doing, then the archived list.
+	var titles []string
+	for _, issue := range issues {
+		titles = append(titles, issue.Title)
+	}
+	if got := strings.Join(titles, ","); got != "Write docs,Archived,Build it,Old" {
+		t.Errorf("titles = %s", got)
+	}
+
+	docs, archived, build, old := issues[0], issues[1], issues[2], issues[3]
+	if docs.Status != types.StatusOpen || docs.Description != "The docs" || docs.Priority != 2 {
+		t.Errorf("docs = %+v", docs)
+	}
+	if want := time.Date(2020, 7, 24, 0, 28, 44, 0, time.UTC); !docs.CreatedAt.Equal(want) {
+		t.Errorf("docs.CreatedAt = %v, want %v (from the card ID)", docs.CreatedAt, want)
+	}
+	if docs.ExternalRef == nil || *docs.ExternalRef != "trello:5f1a2b3c0000000000000001" {
+		t.Errorf("docs.ExternalRef = %v", docs.ExternalRef)
+	}
+	if archived.Status != types.StatusClosed || archived.ClosedAt == nil {
+		t.Errorf("archived card: status %s closed_at %v, want closed", archived.Status, archived.ClosedAt)
+	}
+	if old.Status != types.StatusClosed {
+		t.Errorf("card in archived list: status %s, want closed", old.Status)
+	}
+
+	if build.Status != types.StatusInProgress || build.Assignee != "alice" {
+		t.Errorf("build: status %s assignee %q", build.Status, build.Assignee)
+	}
+	if got := strings.Join(build.Labels, ","); got != "backend,red" {
+		t.Errorf("build.Labels = %s", got)
+	}
+	if build.DueAt == nil || !build.DueAt.Equal(time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("build.DueAt = %v", build.DueAt)
+	}
+	if !build.UpdatedAt.Equal(time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("build.UpdatedAt = %v", build.UpdatedAt)
+	}
+	wantAC := "Steps\n- [x] first\n- [ ] second\n\nLater\n- [ ] third"
+	if build.AcceptanceCriteria != wantAC {
+		t.Errorf("build.AcceptanceCriteria = %q, want %q", build.AcceptanceCriteria, wantAC)
+	}
+	if string(build.Metadata) != `{"trello_list":"Doing"}` {
+		t.Errorf("build.Metadata = %s", build.Metadata)
+	}
+	for _, issue := range issues {
+		if err := issue.Validate(); err != nil {
+			t.Errorf("%s: Validate() error: %v", issue.Title, err)
+		}
+	}
+}
+
+func TestToIssuesStatusMapAndSkipArchived(t *testing.T) {
+	board, err := DecodeBoard(strings.NewReader(testBoard))
+	if err != nil {
+		t.Fatalf("DecodeBoard() error: %v", err)
+	}
+	cfg := &MappingConfig{
+		StatusMap:    map[string]types.Status{"to do": types.StatusDeferred},
+		SkipArchived: true,
+	}
+	issues := cfg.ToIssues(board)
+	if len(issues) != 2 {
+		t.Fatalf("ToIssues() returned %d issues, want 2 (archived skipped)", len(issues))
+	}
+	if issues[0].Status != types.StatusDeferred {
+		t.Errorf("status = %s, want deferred from --status-map", issues[0].Status)
+	}
+}
+
+func TestDecodeBoardRejectsOtherJSON(t *testing.T) {
+	if _, err := DecodeBoard(strings.NewReader(`{"title": "not a board"}`)); err == nil {
+		t.Error("DecodeBoard() on non-board JSON: expected error")
+	}
+}
@@ -0,0 +1,127 @@
+// Package trello converts Trello board exports into beads issues.
+//
+// A board export (Menu → Print, export, and share → Export as JSON) holds the
+// board's lists, cards, labels, members, and checklists in one document. Each
+// card becomes an issue whose status comes from the list it sits in, and its
+// checklists become a markdown task list in the acceptance criteria. The
+// card's short URL is used as the issue's external_ref, so importing a newer
+// export of the same board updates the issues instead of duplicating them.
+package trello
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExternalRefPrefix namespaces card IDs in the external_ref column when a card
+// has no short URL.
+const ExternalRefPrefix = "trello:"
+
+// Board is a Trello board export.
+type Board struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	URL        string      `json:"url,omitempty"`
+	Lists      []List      `json:"lists"`
+	Cards      []Card      `json:"cards"`
+	Checklists []Checklist `json:"checklists,omitempty"`
+	Members    []Member    `json:"members,omitempty"`
+}
+
+// List is a column on the board.
+type List struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+// Card is a single card.
+type Card struct {
+	ID               string     `json:"id"`
+	Name             string     `json:"name"`
+	Desc             string     `json:"desc,omitempty"`
+	IDList           string     `json:"idList"`
+	Closed           bool       `json:"closed"`
+	Pos              float64    `json:"pos"`
+	Due              *time.Time `json:"due,omitempty"`
+	DueComplete      bool       `json:"dueComplete,omitempty"`
+	DateLastActivity *time.Time `json:"dateLastActivity,omitempty"`
+	URL              string     `json:"url,omitempty"`
+	ShortURL         string     `json:"shortUrl,omitempty"`
+	Labels           []Label    `json:"labels,omitempty"`
+	IDMembers        []string   `json:"idMembers,omitempty"`
+	IDChecklists     []string   `json:"idChecklists,omitempty"`
+}
+
+// Label is a card label. Trello allows labels with a color but no name.
+type Label struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+// Member is a board member.
+type Member struct {
+	ID       string `json:"id"`
+	FullName string `json:"fullName,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// Checklist is a named checklist on a card.
+type Checklist struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	IDCard     string      `json:"idCard"`
+	Pos        float64     `json:"pos"`
+	CheckItems []CheckItem `json:"checkItems"`
+}
+
+// CheckItem is one checklist entry.
+type CheckItem struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	State string  `json:"state"` // "complete" or "incomplete"
+	Pos   float64 `json:"pos"`
+}
+
+// IsComplete reports whether the item is checked off.
+func (c *CheckItem) IsComplete() bool {
+	return c.State == "complete"
+}
+
+// ExternalRef returns the external_ref used to match the card on re-import.
+func (c *Card) ExternalRef() string {
+	if c.ShortURL != "" {
+		return c.ShortURL
+	}
+	return ExternalRefPrefix + c.ID
+}
+
+// CreatedAt returns when the card was created. Trello object IDs are Mongo
+// ObjectIDs whose first four bytes are the creation time in Unix seconds.
+func (c *Card) CreatedAt() (time.Time, bool) {
+	if len(c.ID) < 8 {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseInt(c.ID[:8], 16, 64)
+	if err != nil || secs <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0).UTC(), true
+}
+
+// DecodeBoard reads a board export.
+func DecodeBoard(r io.Reader) (*Board, error) {
+	var b Board
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, fmt.Errorf("decoding Trello export: %w", err)
+	}
+	if b.Lists == nil && b.Cards == nil {
+		return nil, fmt.Errorf("not a Trello board export: no lists or cards")
+	}
+	return &b, nil
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// AcceptanceEvidenceMetadataKey holds the append-only []AcceptanceEvidence
+// log recorded when acceptance criteria items are ticked.
+const AcceptanceEvidenceMetadataKey = "acceptance_evidence"
+
+// acceptanceItemRegex matches a markdown task-list line: "- [ ] text" or
+// "* [x] text", optionally indented. Group 1 is everything before the box
+// character, group 2 the box character, group 3 the item text.
+var acceptanceItemRegex = regexp.MustCompile(`^(\s*[-*+]\s+\[)([ xX])\]\s+(.*)$`)
+
+// AcceptanceItem is one checkable line in an issue's acceptance criteria.
+type AcceptanceItem struct {
+	Number  int    `json:"number"` // 1-based position among the checkable items
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+	line    int    // 0-based line index in the criteria text
+}
+
+// ParseAcceptanceItems returns the checkable items ("- [ ] ..." lines) in
+// acceptance criteria text, numbered from 1. Other lines are prose and are
+// not items.
+func ParseAcceptanceItems(criteria string) []AcceptanceItem {
+	var items []AcceptanceItem
+	for i, line := range strings.Split(criteria, "\n") {
+		m := acceptanceItemRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		items = append(items, AcceptanceItem{
+			Number:  len(items) + 1,
+			Text:    strings.TrimSpace(m[3]),
+			Checked: m[2] != " ",
+			line:    i,
+		})
+	}
+	return items
+}
+
+// UncheckedAcceptanceItems returns the items still unchecked.
+func UncheckedAcceptanceItems(items []AcceptanceItem) []AcceptanceItem {
+	var out []AcceptanceItem
+	for _, item := range items {
+		if !item.Checked {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// SetAcceptanceItem checks or unchecks item number in criteria, leaving
+// every other byte of the text unchanged. It returns the updated text and
+// the item as it now stands.
+func SetAcceptanceItem(criteria string, number int, checked bool) (string, AcceptanceItem, error) {
+	items := ParseAcceptanceItems(criteria)
+	if len(items) == 0 {
+		return "", AcceptanceItem{}, fmt.Errorf("acceptance criteria have no checkable items (write them as \"- [ ] ...\" lines)")
+	}
+	if number < 1 || number > len(items) {
+		return "", AcceptanceItem{}, fmt.Errorf("no acceptance criteria item %d (have %d)", number, len(items))
+	}
+	item := items[number-1]
+	box := " "
+	if checked {
+		box = "x"
+	}
+	lines := strings.Split(criteria, "\n")
+	line := lines[item.line]
+	loc := acceptanceItemRegex.FindStringSubmatchIndex(line) // group 2 is the box character
+	lines[item.line] = line[:loc[4]] + box + line[loc[5]:]
+	item.Checked = checked
+	return strings.Join(lines, "\n"), item, nil
+}
+
+// AcceptanceEvidence records who ticked an acceptance criteria item and the
+// link that backs it. Text is kept so the record stays meaningful if the
+// criteria are later reworded or renumbered.
+type AcceptanceEvidence struct {
+	Item       int       `json:"item"`
+	Text       string    `json:"text"`
+	URL        string    `json:"url,omitempty"`
+	Actor      string    `json:"actor"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// AcceptanceEvidenceFromMetadata extracts the evidence log from an issue's
+// metadata. It returns nil when the metadata has no evidence key.
+func AcceptanceEvidenceFromMetadata(metadata json.RawMessage) ([]AcceptanceEvidence, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+	raw, ok := wrapper[AcceptanceEvidenceMetadataKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var evidence []AcceptanceEvidence
+	if err := json.Unmarshal(raw, &evidence); err != nil {
+		return nil, fmt.Errorf("parsing metadata.%s: %w", AcceptanceEvidenceMetadataKey, err)
+	}
+	return evidence, nil
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAcceptanceItems(t *testing.T) {
+	criteria := "Must:\n- [ ] Google login\n  * [x] Logout\nprose [ ] here\n+ [X]   Audit log  "
+	items := ParseAcceptanceItems(criteria)
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3: %+v", len(items), items)
+	}
+	want := []AcceptanceItem{
+		{Number: 1, Text: "Google login", Checked: false},
+		{Number: 2, Text: "Logout", Checked: true},
+		{Number: 3, Text: "Audit log", Checked: true},
+	}
+	for i, w := range want {
+		got := items[i]
+		if got.Number != w.Number || got.Text != w.Text || got.Checked != w.Checked {
+			t.Errorf("item %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if unchecked := UncheckedAcceptanceItems(items); len(unchecked) != 1 || unchecked[0].Number != 1 {
+		t.Errorf("unchecked = %+v, want only item 1", unchecked)
+	}
+}
+
+func TestSetAcceptanceItem(t *testing.T) {
+	criteria := "Must:\n  -   [ ] Google login\n- [x] Logout"
+	got, item, err := SetAcceptanceItem(criteria, 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Must:\n  -   [x] Google login\n- [x] Logout"; got != want {
+		t.Errorf("tick 1 = %q, want %q", got, want)
+	}
+	if item.Number != 1 || !item.Checked || item.Text != "Google login" {
+		t.Errorf("item = %+v", item)
+	}
+
+	got, _, err = SetAcceptanceItem(got, 2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Must:\n  -   [x] Google login\n- [ ] Logout"; got != want {
+		t.Errorf("untick 2 = %q, want %q", got, want)
+	}
+
+	if _, _, err := SetAcceptanceItem(criteria, 3, true); err == nil {
+		t.Error("expected error for out-of-range item")
+	}
+	if _, _, err := SetAcceptanceItem("just prose", 1, true); err == nil {
+		t.Error("expected error when there are no checkable items")
+	}
+}
+
+func TestAcceptanceEvidenceFromMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		want     int
+		wantErr  bool
+	}{
+		{"empty", ``, 0, false},
+		{"no key", `{"team":"x"}`, 0, false},
+		{"null", `{"acceptance_evidence":null}`, 0, false},
+		{"present", `{"acceptance_evidence":[{"item":2,"text":"Logout","url":"https://ci/1","actor":"alice","recorded_at":"2025-03-01T00:00:00Z"}]}`, 1, false},
+		{"malformed", `{"acceptance_evidence":"https://ci/1"}`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AcceptanceEvidenceFromMetadata(json.RawMessage(tt.metadata))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("got %d records, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
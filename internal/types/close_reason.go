@@ -0,0 +1,60 @@
+package types
+
+import (
+	"slices"
+	"strings"
+)
+
+// Close reason categories. A close reason is categorized by its leading
+// word: "duplicate" and "duplicate: same crash as bd-12" are both in the
+// duplicate category.
+const (
+	CloseReasonFixed           = "fixed"
+	CloseReasonDuplicate       = "duplicate"
+	CloseReasonWontFix         = "wontfix"
+	CloseReasonObsolete        = "obsolete"
+	CloseReasonCannotReproduce = "cannot-reproduce"
+)
+
+// DefaultCloseReasonCategories is the close reason taxonomy used when the
+// close.reasons config key is unset.
+var DefaultCloseReasonCategories = []string{
+	CloseReasonFixed,
+	CloseReasonDuplicate,
+	CloseReasonWontFix,
+	CloseReasonObsolete,
+	CloseReasonCannotReproduce,
+}
+
+// Buckets for close reasons outside the taxonomy.
+const (
+	CloseReasonOther = "(other)"
+	CloseReasonNone  = "(none)"
+)
+
+// ParseCloseReason splits a close reason into its lowercased category word
+// and the free-text detail after it. The category ends at the first colon,
+// or at the first space when there is no colon.
+func ParseCloseReason(reason string) (category, detail string) {
+	reason = strings.TrimSpace(reason)
+	if head, rest, ok := strings.Cut(reason, ":"); ok && !strings.ContainsAny(strings.TrimSpace(head), " \t") {
+		return strings.ToLower(strings.TrimSpace(head)), strings.TrimSpace(rest)
+	}
+	head, rest, _ := strings.Cut(reason, " ")
+	return strings.ToLower(head), strings.TrimSpace(rest)
+}
+
+// CloseReasonCategory returns the taxonomy category of a close reason, or
+// CloseReasonOther when its leading word is not in categories and
+// CloseReasonNone when it is empty.
+func CloseReasonCategory(reason string, categories []string) string {
+	category, _ := ParseCloseReason(reason)
+	switch {
+	case category == "":
+		return CloseReasonNone
+	case slices.Contains(categories, category):
+		return category
+	default:
+		return CloseReasonOther
+	}
+}
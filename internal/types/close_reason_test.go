@@ -0,0 +1,42 @@
+package types
+
+import "testing"
+
+func TestParseCloseReason(t *testing.T) {
+	tests := []struct {
+		reason, category, detail string
+	}{
+		{"fixed", "fixed", ""},
+		{"Fixed: null check in parser", "fixed", "null check in parser"},
+		{"duplicate: bd-12", "duplicate", "bd-12"},
+		{"wontfix not worth it", "wontfix", "not worth it"},
+		{"  cannot-reproduce  ", "cannot-reproduce", ""},
+		{"Done with the work: finally", "done", "with the work: finally"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		category, detail := ParseCloseReason(tt.reason)
+		if category != tt.category || detail != tt.detail {
+			t.Errorf("ParseCloseReason(%q) = (%q, %q), want (%q, %q)", tt.reason, category, detail, tt.category, tt.detail)
+		}
+	}
+}
+
+func TestCloseReasonCategory(t *testing.T) {
+	tests := []struct {
+		reason, want string
+	}{
+		{"fixed: it", CloseReasonFixed},
+		{"Obsolete", CloseReasonObsolete},
+		{"Closed", CloseReasonOther},
+		{"", CloseReasonNone},
+	}
+	for _, tt := range tests {
+		if got := CloseReasonCategory(tt.reason, DefaultCloseReasonCategories); got != tt.want {
+			t.Errorf("CloseReasonCategory(%q) = %q, want %q", tt.reason, got, tt.want)
+		}
+	}
+	if got := CloseReasonCategory("shipped: v2", []string{"shipped"}); got != "shipped" {
+		t.Errorf("custom taxonomy: got %q, want shipped", got)
+	}
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Metadata keys for cost tracking. CostLogMetadataKey holds the append-only
+// []CostEntry log; CostTokensMetadataKey and CostUSDMetadataKey mirror its
+// totals as scalars so statistics can sum them in SQL. Budgets are set on
+// epics (or any parent) and cover the issue and all its descendants.
+const (
+	CostLogMetadataKey      = "cost_log"
+	CostTokensMetadataKey   = "cost_tokens"
+	CostUSDMetadataKey      = "cost_usd"
+	BudgetTokensMetadataKey = "budget_tokens"
+	BudgetUSDMetadataKey    = "budget_usd"
+)
+
+// CostEntry records one reported spend against an issue.
+type CostEntry struct {
+	At     time.Time `json:"at"`
+	By     string    `json:"by"`
+	Tokens int64     `json:"tokens,omitempty"`
+	USD    float64   `json:"usd,omitempty"`
+	Note   string    `json:"note,omitempty"`
+}
+
+// Cost is an amount of tokens and dollars.
+type Cost struct {
+	Tokens int64   `json:"tokens"`
+	USD    float64 `json:"usd"`
+}
+
+// Add returns the sum of c and o. Dollars are rounded to a millionth so
+// repeated sums of cents stay exact in JSON.
+func (c Cost) Add(o Cost) Cost {
+	return Cost{Tokens: c.Tokens + o.Tokens, USD: math.Round((c.USD+o.USD)*1e6) / 1e6}
+}
+
+// Budget caps the cost of an issue and its descendants. A zero field is
+// unlimited.
+type Budget struct {
+	Tokens int64   `json:"tokens,omitempty"`
+	USD    float64 `json:"usd,omitempty"`
+}
+
+// IsZero reports whether b sets no limit.
+func (b Budget) IsZero() bool {
+	return b.Tokens == 0 && b.USD == 0
+}
+
+// Exceeded describes each limit of b that spent has reached, or returns nil
+// when spent is within budget.
+func (b Budget) Exceeded(spent Cost) []string {
+	var over []string
+	if b.Tokens > 0 && spent.Tokens >= b.Tokens {
+		over = append(over, fmt.Sprintf("%d/%d tokens", spent.Tokens, b.Tokens))
+	}
+	if b.USD > 0 && spent.USD >= b.USD {
+		over = append(over, fmt.Sprintf("$%.2f/$%.2f", spent.USD, b.USD))
+	}
+	return over
+}
+
+// costMetadata is the subset of issue metadata used for cost tracking.
+type costMetadata struct {
+	Log          []CostEntry `json:"cost_log"`
+	Tokens       int64       `json:"cost_tokens"`
+	USD          float64     `json:"cost_usd"`
+	BudgetTokens int64       `json:"budget_tokens"`
+	BudgetUSD    float64     `json:"budget_usd"`
+}
+
+func parseCostMetadata(metadata json.RawMessage) (costMetadata, error) {
+	var m costMetadata
+	if len(metadata) == 0 {
+		return m, nil
+	}
+	if err := json.Unmarshal(metadata, &m); err != nil {
+		return m, fmt.Errorf("parsing cost metadata: %w", err)
+	}
+	return m, nil
+}
+
+// CostFromMetadata returns an issue's own accumulated cost.
+func CostFromMetadata(metadata json.RawMessage) (Cost, error) {
+	m, err := parseCostMetadata(metadata)
+	return Cost{Tokens: m.Tokens, USD: m.USD}, err
+}
+
+// CostLogFromMetadata returns an issue's cost log.
+func CostLogFromMetadata(metadata json.RawMessage) ([]CostEntry, error) {
+	m, err := parseCostMetadata(metadata)
+	return m.Log, err
+}
+
+// BudgetFromMetadata returns the budget set on an issue, zero when unset.
+func BudgetFromMetadata(metadata json.RawMessage) (Budget, error) {
+	m, err := parseCostMetadata(metadata)
+	return Budget{Tokens: m.BudgetTokens, USD: m.BudgetUSD}, err
+}
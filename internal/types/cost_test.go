@@ -0,0 +1,50 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCostFromMetadata(t *testing.T) {
+	md := json.RawMessage(`{"cost_tokens": 52000, "cost_usd": 1.2, "budget_usd": 5, "other": "x"}`)
+	cost, err := CostFromMetadata(md)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost != (Cost{Tokens: 52000, USD: 1.2}) {
+		t.Errorf("cost = %+v", cost)
+	}
+	budget, err := BudgetFromMetadata(md)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if budget != (Budget{USD: 5}) {
+		t.Errorf("budget = %+v", budget)
+	}
+	if cost, err := CostFromMetadata(nil); err != nil || cost != (Cost{}) {
+		t.Errorf("empty metadata: %+v, %v", cost, err)
+	}
+}
+
+func TestCostAdd(t *testing.T) {
+	got := Cost{USD: 0.1}.Add(Cost{Tokens: 3, USD: 0.2})
+	if got != (Cost{Tokens: 3, USD: 0.3}) {
+		t.Errorf("Add = %+v, want {3 0.3}", got)
+	}
+}
+
+func TestBudgetExceeded(t *testing.T) {
+	b := Budget{Tokens: 1000, USD: 2}
+	if over := b.Exceeded(Cost{Tokens: 999, USD: 1.99}); over != nil {
+		t.Errorf("within budget, got %v", over)
+	}
+	if over := b.Exceeded(Cost{Tokens: 1000, USD: 1}); len(over) != 1 || over[0] != "1000/1000 tokens" {
+		t.Errorf("token limit: %v", over)
+	}
+	if over := b.Exceeded(Cost{Tokens: 5000, USD: 3}); len(over) != 2 {
+		t.Errorf("both limits: %v", over)
+	}
+	if over := (Budget{}).Exceeded(Cost{Tokens: 1 << 40, USD: 1e9}); over != nil {
+		t.Errorf("zero budget is unlimited, got %v", over)
+	}
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+// CursorStart is the IssueFilter.Cursor that requests the first page of a
+// cursor-paginated search.
+const CursorStart = "start"
+
+// ErrInvalidCursor is returned for a cursor token that was not produced by
+// IssueCursor.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorVersion prefixes the encoded position so the format can change
+// without old tokens being misread.
+const cursorVersion = "v1"
+
+// IssueCursor returns the opaque token that resumes a cursor-paginated
+// search after issue: pass it as IssueFilter.Cursor to get the next page.
+func IssueCursor(issue *Issue) string {
+	raw := cursorVersion + " " + issue.CreatedAt.Format(time.RFC3339Nano) + " " + issue.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseIssueCursor decodes a token from IssueCursor into the keyset
+// position it names: the created_at and id of the last issue of the
+// previous page.
+func ParseIssueCursor(token string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	parts := strings.SplitN(string(raw), " ", 3)
+	if len(parts) != 3 || parts[0] != cursorVersion || parts[2] == "" {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	return createdAt, parts[2], nil
+}
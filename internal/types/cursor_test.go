@@ -0,0 +1,25 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIssueCursorRoundTrip(t *testing.T) {
+	created := time.Date(2026, 3, 4, 5, 6, 7, 890, time.FixedZone("X", 3600))
+	token := IssueCursor(&Issue{ID: "bd-a1b2", CreatedAt: created})
+	gotAt, gotID, err := ParseIssueCursor(token)
+	if err != nil {
+		t.Fatalf("ParseIssueCursor(%q): %v", token, err)
+	}
+	if !gotAt.Equal(created) || gotID != "bd-a1b2" {
+		t.Errorf("ParseIssueCursor = %v, %q; want %v, bd-a1b2", gotAt, gotID, created)
+	}
+
+	for _, bad := range []string{"", CursorStart, "!!!", "djIgeCB5", IssueCursor(&Issue{CreatedAt: created})} {
+		if _, _, err := ParseIssueCursor(bad); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("ParseIssueCursor(%q) = %v, want ErrInvalidCursor", bad, err)
+		}
+	}
+}
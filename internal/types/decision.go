@@ -0,0 +1,92 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DecisionMetadataKey is the top-level metadata key holding a DecisionRecord
+// on issues of type decision.
+const DecisionMetadataKey = "decision"
+
+// DecisionRecord holds the structured fields of an architecture decision
+// record (ADR). It is stored under metadata.decision on a decision issue so
+// the fields stay queryable (bd list --metadata-field) while the description
+// carries the same content as readable markdown.
+type DecisionRecord struct {
+	Context   string     `json:"context,omitempty"`    // Forces and background that prompted the decision
+	Options   []string   `json:"options,omitempty"`    // Options considered
+	Outcome   string     `json:"outcome,omitempty"`    // The chosen option; empty while proposed
+	Rationale string     `json:"rationale,omitempty"`  // Why the outcome was chosen
+	RevisitBy *time.Time `json:"revisit_by,omitempty"` // When the decision should be reconsidered
+}
+
+// IsDecided reports whether an outcome has been recorded.
+func (d *DecisionRecord) IsDecided() bool {
+	return strings.TrimSpace(d.Outcome) != ""
+}
+
+// RevisitDue reports whether the revisit-by date has passed at now.
+func (d *DecisionRecord) RevisitDue(now time.Time) bool {
+	return d.RevisitBy != nil && !now.Before(*d.RevisitBy)
+}
+
+// Markdown renders the record using the TypeDecision required-section
+// headings, so decision issues pass bd lint.
+func (d *DecisionRecord) Markdown() string {
+	var b strings.Builder
+	if d.Context != "" {
+		fmt.Fprintf(&b, "## Context\n%s\n\n", d.Context)
+	}
+	b.WriteString("## Decision\n")
+	if d.IsDecided() {
+		b.WriteString(d.Outcome + "\n\n")
+	} else {
+		b.WriteString("_Proposed — no outcome recorded yet._\n\n")
+	}
+	b.WriteString("## Rationale\n")
+	if d.Rationale != "" {
+		b.WriteString(d.Rationale + "\n\n")
+	} else {
+		b.WriteString("_Not recorded._\n\n")
+	}
+	b.WriteString("## Alternatives Considered\n")
+	var alts int
+	for _, opt := range d.Options {
+		if opt == d.Outcome {
+			continue
+		}
+		fmt.Fprintf(&b, "- %s\n", opt)
+		alts++
+	}
+	if alts == 0 {
+		b.WriteString("_None recorded._\n")
+	}
+	if d.RevisitBy != nil {
+		fmt.Fprintf(&b, "\n## Revisit By\n%s\n", d.RevisitBy.Format("2006-01-02"))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// DecisionFromMetadata extracts the DecisionRecord from an issue's metadata.
+// It returns (nil, nil) when the metadata has no decision key.
+func DecisionFromMetadata(metadata json.RawMessage) (*DecisionRecord, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+	raw, ok := wrapper[DecisionMetadataKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var rec DecisionRecord
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		return nil, fmt.Errorf("parsing metadata.%s: %w", DecisionMetadataKey, err)
+	}
+	return &rec, nil
+}
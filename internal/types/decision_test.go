@@ -0,0 +1,69 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecisionFromMetadata(t *testing.T) {
+	revisit := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	meta, err := json.Marshal(map[string]interface{}{
+		"other": 1,
+		DecisionMetadataKey: DecisionRecord{
+			Context: "need storage", Options: []string{"Dolt", "SQLite"},
+			Outcome: "Dolt", RevisitBy: &revisit,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := DecisionFromMetadata(meta)
+	if err != nil {
+		t.Fatalf("DecisionFromMetadata: %v", err)
+	}
+	if rec == nil || rec.Outcome != "Dolt" || len(rec.Options) != 2 {
+		t.Fatalf("unexpected record: %+v", rec)
+	}
+	if !rec.IsDecided() {
+		t.Error("IsDecided = false, want true")
+	}
+	if rec.RevisitDue(revisit.Add(-time.Hour)) || !rec.RevisitDue(revisit) {
+		t.Error("RevisitDue boundary mismatch")
+	}
+
+	for _, empty := range []string{"", `{}`, `{"decision":null}`} {
+		rec, err := DecisionFromMetadata(json.RawMessage(empty))
+		if err != nil || rec != nil {
+			t.Errorf("DecisionFromMetadata(%q) = %+v, %v; want nil, nil", empty, rec, err)
+		}
+	}
+	if _, err := DecisionFromMetadata(json.RawMessage(`{"decision":"nope"}`)); err == nil {
+		t.Error("expected error for malformed decision record")
+	}
+}
+
+func TestDecisionRecordMarkdownUsesRequiredSections(t *testing.T) {
+	rec := &DecisionRecord{
+		Context:   "Need versioned data",
+		Options:   []string{"Dolt", "SQLite", "Postgres"},
+		Outcome:   "Dolt",
+		Rationale: "Cell-level merge",
+	}
+	md := rec.Markdown()
+	for _, sec := range TypeDecision.RequiredSections() {
+		if !strings.Contains(md, sec.Heading) {
+			t.Errorf("markdown missing required heading %q:\n%s", sec.Heading, md)
+		}
+	}
+	if !strings.Contains(md, "- SQLite\n- Postgres") || strings.Contains(md, "- Dolt") {
+		t.Errorf("alternatives should list non-chosen options only:\n%s", md)
+	}
+
+	proposed := (&DecisionRecord{Options: []string{"A"}}).Markdown()
+	if !strings.Contains(proposed, "Proposed") {
+		t.Errorf("proposal markdown should say Proposed:\n%s", proposed)
+	}
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// FollowupsMetadataKey holds an issue's []FollowupRule in its metadata.
+const FollowupsMetadataKey = "followups"
+
+// Follow-up triggers.
+const (
+	FollowupOnClose = "close"
+)
+
+// FollowupRule creates follow-up work from a template when its trigger
+// fires on the issue that owns it. Each rule fires at most once; CreatedID
+// records the root of the work it created.
+type FollowupRule struct {
+	On        string            `json:"on"`
+	Template  string            `json:"template"`        // formula name or proto ID
+	After     string            `json:"after,omitempty"` // compact duration (2d, 1w) to defer the follow-up by
+	Vars      map[string]string `json:"vars,omitempty"`  // template variables
+	AddedBy   string            `json:"added_by,omitempty"`
+	AddedAt   time.Time         `json:"added_at"`
+	FiredAt   *time.Time        `json:"fired_at,omitempty"`
+	CreatedID string            `json:"created_id,omitempty"`
+}
+
+// Fired reports whether the rule has already created its follow-up.
+func (r FollowupRule) Fired() bool {
+	return r.FiredAt != nil
+}
+
+// FollowupsFromMetadata extracts the follow-up rules from an issue's
+// metadata. It returns nil when the metadata has no followups key.
+func FollowupsFromMetadata(metadata json.RawMessage) ([]FollowupRule, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+	raw, ok := wrapper[FollowupsMetadataKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var rules []FollowupRule
+	if err := json.Unmarshal(raw, &rules); err != nil {
+		return nil, fmt.Errorf("parsing metadata.%s: %w", FollowupsMetadataKey, err)
+	}
+	return rules, nil
+}
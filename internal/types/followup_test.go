@@ -0,0 +1,36 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFollowupsFromMetadata(t *testing.T) {
+	if rules, err := FollowupsFromMetadata(nil); rules != nil || err != nil {
+		t.Fatalf("empty metadata = %v, %v", rules, err)
+	}
+	if rules, err := FollowupsFromMetadata(json.RawMessage(`{"other":1}`)); rules != nil || err != nil {
+		t.Fatalf("metadata without followups = %v, %v", rules, err)
+	}
+	fired := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	meta, _ := json.Marshal(map[string]any{
+		FollowupsMetadataKey: []FollowupRule{
+			{On: FollowupOnClose, Template: "post-deploy-check", After: "2d"},
+			{On: FollowupOnClose, Template: "mol-retro", FiredAt: &fired, CreatedID: "bd-9"},
+		},
+	})
+	rules, err := FollowupsFromMetadata(meta)
+	if err != nil || len(rules) != 2 {
+		t.Fatalf("FollowupsFromMetadata = %+v, %v", rules, err)
+	}
+	if rules[0].Fired() || rules[0].After != "2d" {
+		t.Errorf("rule 0 = %+v, want unfired with after 2d", rules[0])
+	}
+	if !rules[1].Fired() || rules[1].CreatedID != "bd-9" {
+		t.Errorf("rule 1 = %+v, want fired creating bd-9", rules[1])
+	}
+	if _, err := FollowupsFromMetadata(json.RawMessage(`{"followups":"soon"}`)); err == nil {
+		t.Error("expected an error for malformed follow-up rules")
+	}
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Config keys for per-type and per-label ID prefixes. Both hold
+// comma-separated "name:prefix" pairs, e.g. "bug:bug,feature:feat".
+const (
+	ConfigKeyPrefixByType  = "prefix.by_type"
+	ConfigKeyPrefixByLabel = "prefix.by_label"
+)
+
+// IDPrefixRule maps an issue type or label to the ID prefix new issues with
+// it are minted under.
+type IDPrefixRule struct {
+	Match  string // issue type or label
+	Prefix string // without the trailing hyphen
+}
+
+// idPrefixRegexp matches a usable ID prefix: lowercase, letter-first, and
+// not ending in a hyphen.
+var idPrefixRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*[a-z0-9]$|^[a-z]$`)
+
+// ParseIDPrefixRules parses a prefix.by_type or prefix.by_label config value.
+// A trailing hyphen on a prefix is dropped ("bug-" is "bug").
+func ParseIDPrefixRules(value string) ([]IDPrefixRule, error) {
+	var rules []IDPrefixRule
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		match, prefix, ok := strings.Cut(part, ":")
+		match, prefix = strings.TrimSpace(match), strings.TrimSuffix(strings.TrimSpace(prefix), "-")
+		if !ok || match == "" || prefix == "" {
+			return nil, fmt.Errorf("invalid prefix rule %q: want name:prefix", part)
+		}
+		if !idPrefixRegexp.MatchString(prefix) || strings.Contains(prefix, "--") {
+			return nil, fmt.Errorf("invalid prefix %q for %s: must be lowercase letters, digits, and hyphens, starting with a letter", prefix, match)
+		}
+		if seen[match] {
+			return nil, fmt.Errorf("prefix rule for %s given more than once", match)
+		}
+		seen[match] = true
+		rules = append(rules, IDPrefixRule{Match: match, Prefix: prefix})
+	}
+	return rules, nil
+}
+
+// ResolveIDPrefix returns the prefix the rules assign to issue, or "" when
+// none applies. Label rules win over type rules, since labels are the more
+// specific choice; among labels, the first matching rule wins.
+func ResolveIDPrefix(issue *Issue, byType, byLabel []IDPrefixRule) string {
+	if issue == nil {
+		return ""
+	}
+	for _, r := range byLabel {
+		if slices.Contains(issue.Labels, r.Match) {
+			return r.Prefix
+		}
+	}
+	for _, r := range byType {
+		if string(issue.IssueType) == r.Match {
+			return r.Prefix
+		}
+	}
+	return ""
+}
+
+// RulePrefixes returns the distinct prefixes the rules can assign, sorted.
+func RulePrefixes(ruleSets ...[]IDPrefixRule) []string {
+	var prefixes []string
+	for _, rules := range ruleSets {
+		for _, r := range rules {
+			if !slices.Contains(prefixes, r.Prefix) {
+				prefixes = append(prefixes, r.Prefix)
+			}
+		}
+	}
+	slices.Sort(prefixes)
+	return prefixes
+}
+
+// MergeAllowedPrefixes appends the prefixes the rules can assign to an
+// allowed_prefixes value, so IDs minted under them pass prefix validation.
+func MergeAllowedPrefixes(allowed string, ruleSets ...[]IDPrefixRule) string {
+	extra := RulePrefixes(ruleSets...)
+	if len(extra) == 0 {
+		return allowed
+	}
+	if allowed == "" {
+		return strings.Join(extra, ",")
+	}
+	return allowed + "," + strings.Join(extra, ",")
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseIDPrefixRules(t *testing.T) {
+	rules, err := ParseIDPrefixRules(" bug:bug- , feature:feat,,")
+	if err != nil {
+		t.Fatalf("ParseIDPrefixRules: %v", err)
+	}
+	want := []IDPrefixRule{{Match: "bug", Prefix: "bug"}, {Match: "feature", Prefix: "feat"}}
+	if !slices.Equal(rules, want) {
+		t.Errorf("rules = %+v, want %+v", rules, want)
+	}
+	if rules, err := ParseIDPrefixRules(""); err != nil || rules != nil {
+		t.Errorf("empty value = %+v, %v; want no rules", rules, err)
+	}
+
+	for _, bad := range []string{"bug", "bug:", ":bug", "bug:Bug", "bug:1x", "bug:a--b", "bug:a,bug:b"} {
+		if _, err := ParseIDPrefixRules(bad); err == nil {
+			t.Errorf("ParseIDPrefixRules(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestResolveIDPrefix(t *testing.T) {
+	byType := []IDPrefixRule{{Match: "bug", Prefix: "bug"}, {Match: "feature", Prefix: "feat"}}
+	byLabel := []IDPrefixRule{{Match: "spike", Prefix: "spk"}, {Match: "ops", Prefix: "ops"}}
+
+	tests := []struct {
+		issue *Issue
+		want  string
+	}{
+		{&Issue{IssueType: TypeBug}, "bug"},
+		{&Issue{IssueType: TypeTask}, ""},
+		{&Issue{IssueType: TypeBug, Labels: []string{"ops", "spike"}}, "spk"}, // labels win, in rule order
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := ResolveIDPrefix(tt.issue, byType, byLabel); got != tt.want {
+			t.Errorf("ResolveIDPrefix(%+v) = %q, want %q", tt.issue, got, tt.want)
+		}
+	}
+
+	if got := MergeAllowedPrefixes("hq", byType, byLabel); got != "hq,bug,feat,ops,spk" {
+		t.Errorf("MergeAllowedPrefixes = %q", got)
+	}
+	if got := MergeAllowedPrefixes("hq"); got != "hq" {
+		t.Errorf("MergeAllowedPrefixes without rules = %q", got)
+	}
+}
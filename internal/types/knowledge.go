@@ -0,0 +1,100 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// BondTypeCrystallized marks a BondRef linking a knowledge entry to a closed
+// issue it was distilled from.
+const BondTypeCrystallized = "crystallized"
+
+// KnowledgeEntry is durable knowledge crystallized from one or more closed
+// issues. Unlike the issues themselves, entries survive compaction and are
+// surfaced to agents by bd prime.
+type KnowledgeEntry struct {
+	ID         string    `json:"id"`
+	Title      string    `json:"title"`
+	Content    string    `json:"content"`
+	Tags       []string  `json:"tags,omitempty"`
+	BondedFrom []BondRef `json:"bonded_from"` // Provenance: the issues this entry was crystallized from
+	CreatedBy  string    `json:"created_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SourceIDs returns the IDs of the issues the entry was crystallized from.
+func (k *KnowledgeEntry) SourceIDs() []string {
+	ids := make([]string, 0, len(k.BondedFrom))
+	for _, b := range k.BondedFrom {
+		ids = append(ids, b.SourceID)
+	}
+	return ids
+}
+
+// KnowledgeID derives the entry ID from its source issue IDs, so
+// re-crystallizing the same issues updates the existing entry instead of
+// adding a duplicate, and every clone computes the same key.
+func KnowledgeID(sourceIDs []string) string {
+	ids := slices.Clone(sourceIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	sum := sha256.Sum256([]byte(strings.Join(ids, "\n")))
+	return "kn-" + hex.EncodeToString(sum[:])[:10]
+}
+
+// CrystallizeIssues builds a knowledge entry from closed issues and their
+// comments. The content keeps only the parts that stay useful after the
+// work is done: what the problem was, the design that solved it, notes, the
+// resolution, and any discussion. The first issue supplies the title.
+func CrystallizeIssues(issues []*Issue, comments map[string][]*Comment) (*KnowledgeEntry, error) {
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("no issues to crystallize")
+	}
+	entry := &KnowledgeEntry{Title: issues[0].Title}
+	var b strings.Builder
+	for _, issue := range issues {
+		if issue.Status != StatusClosed {
+			return nil, fmt.Errorf("%s is %s; only closed issues can be crystallized", issue.ID, issue.Status)
+		}
+		entry.BondedFrom = append(entry.BondedFrom, BondRef{SourceID: issue.ID, BondType: BondTypeCrystallized})
+		for _, l := range issue.Labels {
+			if !slices.Contains(entry.Tags, l) {
+				entry.Tags = append(entry.Tags, l)
+			}
+		}
+
+		if len(issues) > 1 {
+			fmt.Fprintf(&b, "# %s: %s\n\n", issue.ID, issue.Title)
+		}
+		writeKnowledgeSection(&b, "Problem", issue.Description)
+		writeKnowledgeSection(&b, "Design", issue.Design)
+		writeKnowledgeSection(&b, "Notes", issue.Notes)
+		writeKnowledgeSection(&b, "Resolution", issue.CloseReason)
+		var discussion []string
+		for _, c := range comments[issue.ID] {
+			if text := strings.TrimSpace(c.Text); text != "" {
+				discussion = append(discussion, fmt.Sprintf("- %s: %s", c.Author, strings.ReplaceAll(text, "\n", " ")))
+			}
+		}
+		writeKnowledgeSection(&b, "Discussion", strings.Join(discussion, "\n"))
+	}
+	entry.ID = KnowledgeID(entry.SourceIDs())
+	entry.Content = strings.TrimRight(b.String(), "\n")
+	if entry.Content == "" {
+		return nil, fmt.Errorf("nothing to crystallize: issues have no description, design, notes, close reason, or comments")
+	}
+	return entry, nil
+}
+
+func writeKnowledgeSection(b *strings.Builder, heading, body string) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return
+	}
+	fmt.Fprintf(b, "## %s\n%s\n\n", heading, body)
+}
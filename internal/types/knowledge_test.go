@@ -0,0 +1,64 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKnowledgeIDIsOrderIndependent(t *testing.T) {
+	a := KnowledgeID([]string{"bd-2", "bd-1"})
+	if b := KnowledgeID([]string{"bd-1", "bd-2", "bd-1"}); a != b {
+		t.Errorf("KnowledgeID differs by order/duplicates: %s vs %s", a, b)
+	}
+	if c := KnowledgeID([]string{"bd-1"}); c == a {
+		t.Error("different source sets produced the same ID")
+	}
+	if !strings.HasPrefix(a, "kn-") {
+		t.Errorf("KnowledgeID = %q, want kn- prefix", a)
+	}
+}
+
+func TestCrystallizeIssues(t *testing.T) {
+	issue := &Issue{
+		ID:          "bd-1",
+		Title:       "Pull fails on dirty config",
+		Description: "bd dolt pull refuses to merge",
+		CloseReason: "Commit config before merging",
+		Status:      StatusClosed,
+		Labels:      []string{"dolt", "sync"},
+	}
+	comments := map[string][]*Comment{
+		"bd-1": {{Author: "alice", Text: "seen on\nCI too"}},
+	}
+
+	entry, err := CrystallizeIssues([]*Issue{issue}, comments)
+	if err != nil {
+		t.Fatalf("CrystallizeIssues: %v", err)
+	}
+	if entry.Title != issue.Title || entry.ID != KnowledgeID([]string{"bd-1"}) {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	for _, want := range []string{"## Problem\nbd dolt pull", "## Resolution\nCommit config", "- alice: seen on CI too"} {
+		if !strings.Contains(entry.Content, want) {
+			t.Errorf("content missing %q:\n%s", want, entry.Content)
+		}
+	}
+	if strings.Contains(entry.Content, "## Design") {
+		t.Errorf("empty sections should be omitted:\n%s", entry.Content)
+	}
+	if len(entry.BondedFrom) != 1 || entry.BondedFrom[0].BondType != BondTypeCrystallized {
+		t.Errorf("bonded_from = %+v", entry.BondedFrom)
+	}
+	if len(entry.Tags) != 2 {
+		t.Errorf("tags = %v, want issue labels", entry.Tags)
+	}
+
+	open := &Issue{ID: "bd-2", Status: StatusOpen, Description: "x"}
+	if _, err := CrystallizeIssues([]*Issue{issue, open}, nil); err == nil {
+		t.Error("expected error crystallizing an open issue")
+	}
+	empty := &Issue{ID: "bd-3", Status: StatusClosed}
+	if _, err := CrystallizeIssues([]*Issue{empty}, nil); err == nil {
+		t.Error("expected error when there is nothing to crystallize")
+	}
+}
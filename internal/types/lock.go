@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// IssueLock is an advisory edit lock on an issue (bd lock). While it is
+// live, bd edit and bd update refuse changes from anyone but the holder
+// unless forced. A lock past ExpiresAt no longer counts.
+type IssueLock struct {
+	IssueID   string    `json:"issue_id"`
+	Holder    string    `json:"holder"`
+	Reason    string    `json:"reason,omitempty"`
+	LockedAt  time.Time `json:"locked_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Live reports whether the lock still holds at now.
+func (l *IssueLock) Live(now time.Time) bool {
+	return l != nil && now.Before(l.ExpiresAt)
+}
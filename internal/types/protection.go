@@ -0,0 +1,100 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+)
+
+// ProtectionMetadataKey holds an issue's Protection record.
+const ProtectionMetadataKey = "protection"
+
+// Pseudo-fields a Protection can name besides issue columns.
+const (
+	// ProtectedStatus covers status changes, including close and reopen.
+	ProtectedStatus = "status"
+	// ProtectedMetadata covers metadata keys other than the protection itself.
+	ProtectedMetadata = "metadata"
+)
+
+// Protection restricts who may change an issue. With no Fields the whole
+// issue is protected: only the listed actors may update, close, reopen, or
+// delete it. With Fields, only those fields are immutable to everyone else.
+// The protection record itself can only be changed by the listed actors.
+type Protection struct {
+	Actors      []string  `json:"actors"`           // glob patterns, e.g. "alice" or "release-*"
+	Fields      []string  `json:"fields,omitempty"` // empty means every field
+	Reason      string    `json:"reason,omitempty"`
+	ProtectedBy string    `json:"protected_by"`
+	ProtectedAt time.Time `json:"protected_at"`
+}
+
+// ProtectionFromMetadata extracts the protection record from an issue's
+// metadata. It returns nil when the issue is not protected.
+func ProtectionFromMetadata(metadata json.RawMessage) (*Protection, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+	return ProtectionFromRaw(wrapper[ProtectionMetadataKey])
+}
+
+// ProtectionFromRaw decodes a raw metadata.protection value; nil or null
+// means no protection.
+func ProtectionFromRaw(raw json.RawMessage) (*Protection, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var p Protection
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, fmt.Errorf("parsing metadata.%s: %w", ProtectionMetadataKey, err)
+	}
+	return &p, nil
+}
+
+// Allows reports whether actor may change what the protection covers.
+func (p *Protection) Allows(actor string) bool {
+	if p == nil {
+		return true
+	}
+	for _, pattern := range p.Actors {
+		if ok, err := path.Match(pattern, actor); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers reports whether field is protected.
+func (p *Protection) Covers(field string) bool {
+	if p == nil {
+		return false
+	}
+	if len(p.Fields) == 0 || field == ProtectionMetadataKey {
+		return true
+	}
+	for _, f := range p.Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Denied returns the fields in changed that actor may not change.
+func (p *Protection) Denied(actor string, changed []string) []string {
+	if p.Allows(actor) {
+		return nil
+	}
+	var denied []string
+	for _, f := range changed {
+		if p.Covers(f) {
+			denied = append(denied, f)
+		}
+	}
+	return denied
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestProtectionFromMetadata(t *testing.T) {
+	if p, err := ProtectionFromMetadata(nil); p != nil || err != nil {
+		t.Fatalf("empty metadata = %v, %v", p, err)
+	}
+	if p, err := ProtectionFromMetadata(json.RawMessage(`{"protection":null}`)); p != nil || err != nil {
+		t.Fatalf("null protection = %v, %v", p, err)
+	}
+	p, err := ProtectionFromMetadata(json.RawMessage(`{"protection":{"actors":["alice"],"fields":["title"]}}`))
+	if err != nil || p == nil || !reflect.DeepEqual(p.Actors, []string{"alice"}) || !reflect.DeepEqual(p.Fields, []string{"title"}) {
+		t.Fatalf("ProtectionFromMetadata = %+v, %v", p, err)
+	}
+	if _, err := ProtectionFromMetadata(json.RawMessage(`{"protection":"yes"}`)); err == nil {
+		t.Error("expected an error for a malformed protection record")
+	}
+}
+
+func TestProtectionDenied(t *testing.T) {
+	whole := &Protection{Actors: []string{"alice", "release-*"}}
+	fields := &Protection{Actors: []string{"alice"}, Fields: []string{"title", ProtectedStatus}}
+
+	tests := []struct {
+		name    string
+		p       *Protection
+		actor   string
+		changed []string
+		want    []string
+	}{
+		{"nil protection", nil, "bob", []string{"title"}, nil},
+		{"allowed actor", whole, "alice", []string{"title"}, nil},
+		{"allowed by glob", whole, "release-bot", []string{"status"}, nil},
+		{"whole issue", whole, "bob", []string{"priority", "title"}, []string{"priority", "title"}},
+		{"unprotected field", fields, "bob", []string{"priority"}, nil},
+		{"protected field", fields, "bob", []string{"priority", "status"}, []string{"status"}},
+		{"protection record", fields, "bob", []string{ProtectionMetadataKey}, []string{ProtectionMetadataKey}},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Denied(tt.actor, tt.changed); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Denied = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Metadata keys for reopen tracking. ReopensMetadataKey holds the
+// append-only []Reopen log; ReopenCountMetadataKey mirrors its length as a
+// scalar so list filters can compare it in SQL.
+const (
+	ReopensMetadataKey     = "reopens"
+	ReopenCountMetadataKey = "reopen_count"
+)
+
+// Reopen records one reopen of a closed issue.
+type Reopen struct {
+	At        time.Time `json:"at"`
+	By        string    `json:"by"`
+	Reason    string    `json:"reason,omitempty"`
+	RootCause string    `json:"root_cause,omitempty"` // Required once an issue exceeds the reopen threshold
+	// PrevCloseReason is the close reason the reopen discarded.
+	PrevCloseReason string `json:"prev_close_reason,omitempty"`
+}
+
+// ReopensFromMetadata extracts the reopen log from an issue's metadata. It
+// returns nil when the metadata has no reopens key.
+func ReopensFromMetadata(metadata json.RawMessage) ([]Reopen, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+	raw, ok := wrapper[ReopensMetadataKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var reopens []Reopen
+	if err := json.Unmarshal(raw, &reopens); err != nil {
+		return nil, fmt.Errorf("parsing metadata.%s: %w", ReopensMetadataKey, err)
+	}
+	return reopens, nil
+}
@@ -0,0 +1,119 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// Metadata keys for human review of agent-closed work. ReviewMetadataKey
+// holds the Review record; ReviewStateMetadataKey mirrors its state as a
+// scalar so list filters can match it in SQL.
+const (
+	ReviewMetadataKey      = "review"
+	ReviewStateMetadataKey = "review_state"
+)
+
+// Review states.
+const (
+	ReviewPending  = "pending"  // closed by an agent, awaiting human sign-off
+	ReviewApproved = "approved" // signed off by a human
+	ReviewRejected = "rejected" // turned down by a human
+)
+
+// Review records why an issue entered the review queue and how it left.
+type Review struct {
+	State       string     `json:"state"`
+	ClosedBy    string     `json:"closed_by"`
+	RequestedAt time.Time  `json:"requested_at"`
+	Reviewer    string     `json:"reviewer,omitempty"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+	Comment     string     `json:"comment,omitempty"`
+}
+
+// ReviewFromMetadata extracts the review record from an issue's metadata. It
+// returns nil when the issue has never been in review.
+func ReviewFromMetadata(metadata json.RawMessage) (*Review, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+	raw, ok := wrapper[ReviewMetadataKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var r Review
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil, fmt.Errorf("parsing metadata.%s: %w", ReviewMetadataKey, err)
+	}
+	return &r, nil
+}
+
+// IsReviewPending reports whether metadata marks the issue as waiting for
+// human review (metadata.review_state is pending). A closed issue in that
+// state is not done yet: it still blocks its dependents.
+func IsReviewPending(metadata json.RawMessage) bool {
+	if len(metadata) == 0 {
+		return false
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return false
+	}
+	var state string
+	_ = json.Unmarshal(wrapper[ReviewStateMetadataKey], &state)
+	return state == ReviewPending
+}
+
+// ReviewPolicy selects which agent-closed issues enter review. An empty
+// selector matches everything, so a policy with only Enabled set sends every
+// close by an agent to review.
+type ReviewPolicy struct {
+	Enabled bool
+	// Agents are glob patterns ("claude-*") naming the actors that count as
+	// agents. Closes made in agent mode always count as agent closes.
+	Agents []string
+	// Labels restricts review to issues carrying at least one of these labels.
+	Labels []string
+	// MaxPriority restricts review to issues at this priority or more urgent
+	// (1 means P0 and P1).
+	MaxPriority *int
+}
+
+// IsAgent reports whether actor matches one of the policy's agent patterns.
+func (p *ReviewPolicy) IsAgent(actor string) bool {
+	for _, pattern := range p.Agents {
+		if ok, err := path.Match(pattern, actor); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Selects reports whether an agent's close of issue must be reviewed. Every
+// configured selector (labels, priority) has to match.
+func (p *ReviewPolicy) Selects(issue *Issue) bool {
+	if p == nil || !p.Enabled || issue == nil {
+		return false
+	}
+	if p.MaxPriority != nil && issue.Priority > *p.MaxPriority {
+		return false
+	}
+	if len(p.Labels) > 0 {
+		matched := false
+		for _, want := range p.Labels {
+			for _, have := range issue.Labels {
+				matched = matched || strings.EqualFold(want, have)
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestReviewFromMetadata(t *testing.T) {
+	if r, err := ReviewFromMetadata(nil); r != nil || err != nil {
+		t.Fatalf("empty metadata = %v, %v", r, err)
+	}
+	if r, err := ReviewFromMetadata(json.RawMessage(`{"other":1}`)); r != nil || err != nil {
+		t.Fatalf("metadata without review = %v, %v", r, err)
+	}
+	requested := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	meta, _ := json.Marshal(map[string]any{
+		ReviewMetadataKey:      Review{State: ReviewPending, ClosedBy: "claude-1", RequestedAt: requested},
+		ReviewStateMetadataKey: ReviewPending,
+	})
+	r, err := ReviewFromMetadata(meta)
+	if err != nil || r == nil || r.State != ReviewPending || r.ClosedBy != "claude-1" || !r.RequestedAt.Equal(requested) {
+		t.Fatalf("ReviewFromMetadata = %+v, %v", r, err)
+	}
+	if _, err := ReviewFromMetadata(json.RawMessage(`{"review":"yes"}`)); err == nil {
+		t.Error("expected an error for a malformed review record")
+	}
+}
+
+func TestReviewPolicy(t *testing.T) {
+	one := 1
+	p := &ReviewPolicy{Enabled: true, Agents: []string{"claude-*", "ci-bot"}, Labels: []string{"security"}, MaxPriority: &one}
+
+	for actor, want := range map[string]bool{"claude-7": true, "ci-bot": true, "ci-bot-2": false, "alice": false} {
+		if got := p.IsAgent(actor); got != want {
+			t.Errorf("IsAgent(%q) = %v, want %v", actor, got, want)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		issue *Issue
+		want  bool
+	}{
+		{"label and priority match", &Issue{Priority: 0, Labels: []string{"Security"}}, true},
+		{"priority too low", &Issue{Priority: 2, Labels: []string{"security"}}, false},
+		{"label missing", &Issue{Priority: 1, Labels: []string{"ui"}}, false},
+		{"nil issue", nil, false},
+	}
+	for _, tt := range tests {
+		if got := p.Selects(tt.issue); got != tt.want {
+			t.Errorf("%s: Selects = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if !(&ReviewPolicy{Enabled: true}).Selects(&Issue{Priority: 4}) {
+		t.Error("a policy without selectors should select every issue")
+	}
+	if (&ReviewPolicy{}).Selects(&Issue{}) {
+		t.Error("a disabled policy should select nothing")
+	}
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LastRunStatusMetadataKey mirrors the status of an issue's most recent run
+// as a scalar in issue metadata so list filters can match it in SQL. The
+// runs table is the source of truth.
+const LastRunStatusMetadataKey = "last_run_status"
+
+// Run statuses.
+const (
+	RunStatusQueued   = "queued"
+	RunStatusRunning  = "running"
+	RunStatusPassed   = "passed"
+	RunStatusFailed   = "failed"
+	RunStatusCanceled = "canceled"
+)
+
+// RunStatuses lists the valid run statuses.
+var RunStatuses = []string{RunStatusQueued, RunStatusRunning, RunStatusPassed, RunStatusFailed, RunStatusCanceled}
+
+// runStatusAliases maps the spellings CI systems use to run statuses, so
+// webhook payloads can be recorded without translation.
+var runStatusAliases = map[string]string{
+	"pending":     RunStatusQueued,
+	"waiting":     RunStatusQueued,
+	"in_progress": RunStatusRunning,
+	"started":     RunStatusRunning,
+	"pass":        RunStatusPassed,
+	"success":     RunStatusPassed,
+	"succeeded":   RunStatusPassed,
+	"fail":        RunStatusFailed,
+	"failure":     RunStatusFailed,
+	"error":       RunStatusFailed,
+	"errored":     RunStatusFailed,
+	"timed_out":   RunStatusFailed,
+	"cancelled":   RunStatusCanceled,
+	"skipped":     RunStatusCanceled,
+}
+
+// NormalizeRunStatus returns the run status for s, accepting common CI
+// aliases such as "success" and "failure".
+func NormalizeRunStatus(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, known := range RunStatuses {
+		if s == known {
+			return s, nil
+		}
+	}
+	if status, ok := runStatusAliases[s]; ok {
+		return status, nil
+	}
+	return "", fmt.Errorf("invalid run status %q (valid: %s)", s, strings.Join(RunStatuses, ", "))
+}
+
+// Run is one execution of an external job (a CI build, an evaluation suite)
+// linked to an issue.
+type Run struct {
+	ID         string     `json:"id"`
+	IssueID    string     `json:"issue_id"`
+	Name       string     `json:"name,omitempty"`        // Job or suite name, e.g. "ci" or "eval/regression"
+	ExternalID string     `json:"external_id,omitempty"` // The job's ID in the external system
+	Status     string     `json:"status"`
+	URL        string     `json:"url,omitempty"`
+	DurationMS int64      `json:"duration_ms,omitempty"`
+	Artifacts  []string   `json:"artifacts,omitempty"` // Artifact URLs or paths
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Duration returns the run's duration, falling back to the span between its
+// start and finish when no duration was reported.
+func (r *Run) Duration() time.Duration {
+	if r.DurationMS > 0 {
+		return time.Duration(r.DurationMS) * time.Millisecond
+	}
+	if r.StartedAt != nil && r.FinishedAt != nil && r.FinishedAt.After(*r.StartedAt) {
+		return r.FinishedAt.Sub(*r.StartedAt)
+	}
+	return 0
+}
+
+// RunID derives a run's ID from its issue, name, and external ID, so
+// recording the same external job again (for example a webhook reporting
+// "running" and then "failed") updates one run, and every clone computes the
+// same key. Runs without an external ID are keyed by start time instead.
+func RunID(issueID, name, externalID string, startedAt time.Time) string {
+	key := externalID
+	if key == "" {
+		key = "@" + startedAt.UTC().Format(time.RFC3339Nano)
+	}
+	sum := sha256.Sum256([]byte(issueID + "\n" + name + "\n" + key))
+	return "run-" + hex.EncodeToString(sum[:])[:10]
+}
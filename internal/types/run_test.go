@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNormalizeRunStatus(t *testing.T) {
+	for in, want := range map[string]string{
+		"passed":    RunStatusPassed,
+		"SUCCESS":   RunStatusPassed,
+		"failure":   RunStatusFailed,
+		"timed_out": RunStatusFailed,
+		"cancelled": RunStatusCanceled,
+		"pending":   RunStatusQueued,
+		" running ": RunStatusRunning,
+	} {
+		got, err := NormalizeRunStatus(in)
+		if err != nil || got != want {
+			t.Errorf("NormalizeRunStatus(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := NormalizeRunStatus("flaky"); err == nil {
+		t.Error("NormalizeRunStatus(flaky) should fail")
+	}
+}
+
+func TestRunID(t *testing.T) {
+	t1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Second)
+	if RunID("bd-1", "ci", "7", t1) != RunID("bd-1", "ci", "7", t2) {
+		t.Error("runs with the same external ID should share an ID regardless of time")
+	}
+	if RunID("bd-1", "ci", "", t1) == RunID("bd-1", "ci", "", t2) {
+		t.Error("runs without an external ID should be keyed by start time")
+	}
+	if RunID("bd-1", "ci", "7", t1) == RunID("bd-1", "eval", "7", t1) {
+		t.Error("different job names should not collide")
+	}
+}
+
+func TestRunDuration(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Second)
+	if d := (&Run{StartedAt: &start, FinishedAt: &end}).Duration(); d != 90*time.Second {
+		t.Errorf("Duration from timestamps = %v, want 1m30s", d)
+	}
+	if d := (&Run{DurationMS: 2000, StartedAt: &start, FinishedAt: &end}).Duration(); d != 2*time.Second {
+		t.Errorf("reported duration should win, got %v", d)
+	}
+}
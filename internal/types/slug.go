@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxSlugLength bounds slugs so they stay typeable; generated slugs are cut
+// at a word boundary to fit.
+const MaxSlugLength = 48
+
+// IssueSlug is a human-friendly name for an issue, usable anywhere its ID
+// is. An issue has at most one current slug; its earlier slugs are kept so
+// they keep resolving after a rename.
+type IssueSlug struct {
+	Slug      string    `json:"slug"`
+	IssueID   string    `json:"issue_id"`
+	Current   bool      `json:"current"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// slugRegexp matches a valid slug: lowercase words of letters and digits
+// joined by single hyphens, starting with a letter.
+var slugRegexp = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// hexWordRegexp matches a single word of hex digits, such as "cafe" or
+// "add", which ID resolution would read as a partial issue hash.
+var hexWordRegexp = regexp.MustCompile(`^[0-9a-f]+$`)
+
+// Slugify derives a slug from an issue title: "Fix login timeout!" becomes
+// "fix-login-timeout". Characters other than ASCII letters and digits
+// separate words, leading digits are dropped so the slug starts with a
+// letter, and long titles are cut at a word boundary. It returns "" for
+// titles with no usable letters.
+func Slugify(title string) string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			word.WriteRune(r)
+		case r == '\'' || r == '’':
+			// Keep contractions whole: "don't" is "dont", not "don-t".
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	slug := strings.TrimLeft(strings.Join(words, "-"), "0123456789-")
+	for len(slug) > MaxSlugLength {
+		idx := strings.LastIndex(slug[:MaxSlugLength+1], "-")
+		if idx <= 0 {
+			slug = slug[:MaxSlugLength]
+			break
+		}
+		slug = slug[:idx]
+	}
+	return slug
+}
+
+// ValidateSlug checks that slug is well formed and cannot be mistaken for
+// a partial issue ID.
+func ValidateSlug(slug string) error {
+	if slug == "" {
+		return fmt.Errorf("slug is empty")
+	}
+	if len(slug) > MaxSlugLength {
+		return fmt.Errorf("slug %q is longer than %d characters", slug, MaxSlugLength)
+	}
+	if !slugRegexp.MatchString(slug) {
+		return fmt.Errorf("invalid slug %q: use lowercase letters, digits, and single hyphens, starting with a letter", slug)
+	}
+	if hexWordRegexp.MatchString(slug) {
+		return fmt.Errorf("invalid slug %q: a single hex word reads as an issue ID; add a word", slug)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Fix login timeout!":               "fix-login-timeout",
+		"  Don't crash on   empty input  ": "dont-crash-on-empty-input",
+		"OAuth2: refresh tokens (v2)":      "oauth2-refresh-tokens-v2",
+		"404 page is blank":                "page-is-blank",
+		"Café menu — ünïcode":              "caf-menu-n-code",
+		"!!!":                              "",
+		"12345":                            "",
+	}
+	for title, want := range tests {
+		if got := Slugify(title); got != want {
+			t.Errorf("Slugify(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestSlugifyTruncatesAtWordBoundary(t *testing.T) {
+	title := strings.Repeat("word ", 20)
+	got := Slugify(title)
+	if len(got) > MaxSlugLength || strings.HasSuffix(got, "-") || !strings.HasSuffix(got, "word") {
+		t.Errorf("Slugify(long title) = %q (%d chars)", got, len(got))
+	}
+	if err := ValidateSlug(got); err != nil {
+		t.Errorf("generated slug invalid: %v", err)
+	}
+}
+
+func TestValidateSlug(t *testing.T) {
+	for _, ok := range []string{"fix-login", "z", "v2-api", "x1", "add-logging"} {
+		if err := ValidateSlug(ok); err != nil {
+			t.Errorf("ValidateSlug(%q) = %v, want nil", ok, err)
+		}
+	}
+	for _, bad := range []string{"", "Fix", "1st", "-a", "a-", "a--b", "a_b", "a b", "a", "cafe", "add", "deadbeef", strings.Repeat("a", MaxSlugLength+1)} {
+		if err := ValidateSlug(bad); err == nil {
+			t.Errorf("ValidateSlug(%q) succeeded, want error", bad)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortKey is one key of an IssueFilter ordering: a sort field and its
+// direction.
+type SortKey struct {
+	Field string // one of SortFields
+	Desc  bool
+}
+
+// sortFieldDescByDefault lists the sort fields and the direction a spec
+// that names a field without a sign sorts it in: newest first for
+// timestamps, ascending for everything else.
+var sortFieldDescByDefault = map[string]bool{
+	"priority": false,
+	"created":  true,
+	"updated":  true,
+	"closed":   true,
+	"due":      false,
+	"status":   false,
+	"type":     false,
+	"assignee": false,
+	"title":    false,
+	"id":       false,
+}
+
+// sortFieldAliases accepts column names for the timestamp fields.
+var sortFieldAliases = map[string]string{
+	"created_at": "created",
+	"updated_at": "updated",
+	"closed_at":  "closed",
+	"due_at":     "due",
+	"issue_type": "type",
+}
+
+// SortFields returns the sort field names, sorted.
+func SortFields() []string {
+	fields := make([]string, 0, len(sortFieldDescByDefault))
+	for f := range sortFieldDescByDefault {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// IsSortField reports whether field is one of SortFields.
+func IsSortField(field string) bool {
+	_, ok := sortFieldDescByDefault[field]
+	return ok
+}
+
+// DefaultSortDesc reports whether field sorts descending when a sort spec
+// names it without a sign.
+func DefaultSortDesc(field string) bool {
+	return sortFieldDescByDefault[field]
+}
+
+// ParseSortKeys parses a comma-separated sort spec such as
+// "priority,-updated". A leading "-" sorts a field descending and "+"
+// ascending; a bare field takes its default direction (DefaultSortDesc).
+// Column names such as updated_at are accepted for the fields they back.
+func ParseSortKeys(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field := strings.ToLower(strings.TrimLeft(part, "+-"))
+		if alias, ok := sortFieldAliases[field]; ok {
+			field = alias
+		}
+		if !IsSortField(field) {
+			return nil, fmt.Errorf("invalid sort field %q (valid: %s)", part, strings.Join(SortFields(), ", "))
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("sort field %q given more than once", field)
+		}
+		seen[field] = true
+		key := SortKey{Field: field, Desc: DefaultSortDesc(field)}
+		switch part[0] {
+		case '-':
+			key.Desc = true
+		case '+':
+			key.Desc = false
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("empty sort spec %q", spec)
+	}
+	return keys, nil
+}
+
+// ReverseSortKeys returns keys with every direction flipped.
+func ReverseSortKeys(keys []SortKey) []SortKey {
+	out := make([]SortKey, len(keys))
+	for i, k := range keys {
+		out[i] = SortKey{Field: k.Field, Desc: !k.Desc}
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []SortKey
+		wantErr bool
+	}{
+		{spec: "priority", want: []SortKey{{Field: "priority"}}},
+		{spec: "updated", want: []SortKey{{Field: "updated", Desc: true}}},
+		{spec: "priority,-updated", want: []SortKey{{Field: "priority"}, {Field: "updated", Desc: true}}},
+		{spec: "+created_at, -due_at ,ID", want: []SortKey{{Field: "created"}, {Field: "due", Desc: true}, {Field: "id"}}},
+		{spec: "-priority,status", want: []SortKey{{Field: "priority", Desc: true}, {Field: "status"}}},
+		{spec: "bogus", wantErr: true},
+		{spec: "priority,-priority", wantErr: true},
+		{spec: " , ", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSortKeys(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSortKeys(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseSortKeys(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+
+	keys := ReverseSortKeys([]SortKey{{Field: "priority"}, {Field: "updated", Desc: true}})
+	if want := []SortKey{{Field: "priority", Desc: true}, {Field: "updated"}}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("ReverseSortKeys = %v, want %v", keys, want)
+	}
+}
@@ -91,6 +91,15 @@ type Issue struct {
 	// Validated as well-formed JSON on create/update. See GH#1406.
 	Metadata json.RawMessage `json:"metadata,omitempty"`
 
+	// Derived holds values computed at query time from the derived.fields
+	// config (age_days, sla_state, ...). Never stored.
+	Derived map[string]interface{} `json:"derived,omitempty"`
+
+	// Annotations carries values bd computed while answering a query
+	// (scores, inherited priority, SLA deadlines) so JSON consumers don't
+	// have to re-derive them. Never stored.
+	Annotations *IssueAnnotations `json:"annotations,omitempty"`
+
 	// ===== Compaction Metadata =====
 	CompactionLevel   int        `json:"compaction_level,omitempty"`
 	CompactedAt       *time.Time `json:"compacted_at,omitempty"`
@@ -132,6 +141,14 @@ type Issue struct {
 	SourceFormula  string `json:"source_formula,omitempty"`  // Formula name where step was defined
 	SourceLocation string `json:"source_location,omitempty"` // Path: "steps[0]", "advice[0].after"
 
+	// ===== Rig Fields (machine/fleet grouping) =====
+	Rig string `json:"rig,omitempty"` // Rig (machine or fleet) the issue is scoped to; see bd rig
+
+	// ===== Encryption =====
+	// Description, Design, and Notes are stored encrypted with the
+	// workspace's issue key; GetIssue decrypts them (see storage.EncryptingStore).
+	Encrypted bool `json:"encrypted,omitempty"`
+
 	// ===== Molecule Type Fields (swarm coordination) =====
 	MolType MolType `json:"mol_type,omitempty"` // Molecule type: swarm|patrol|work (empty = work)
 
@@ -145,6 +162,23 @@ type Issue struct {
 	Payload   string `json:"payload,omitempty"`    // Event-specific JSON data
 }
 
+// IssueAnnotations are computed values attached to an issue for output. Each
+// field is set only when the computation that produces it ran.
+type IssueAnnotations struct {
+	Score             *int64     `json:"score,omitempty"`              // wasm bd_score (bd ready --sort score)
+	InheritedPriority *int       `json:"inherited_priority,omitempty"` // most urgent priority among open issues waiting on this one, when more urgent than its own
+	SLADue            *time.Time `json:"sla_due,omitempty"`            // earliest deadline of the configured sla derived fields
+	BlockedByCount    *int       `json:"blocked_by_count,omitempty"`   // open issues this one is directly blocked by
+}
+
+// Annotate returns the issue's annotations, allocating them on first use.
+func (i *Issue) Annotate() *IssueAnnotations {
+	if i.Annotations == nil {
+		i.Annotations = &IssueAnnotations{}
+	}
+	return i.Annotations
+}
+
 // ComputeContentHash creates a deterministic hash of the issue's content.
 // Uses all substantive fields (excluding ID, timestamps, and compaction metadata)
 // to ensure that identical content produces identical hashes across all clones.
@@ -822,6 +856,7 @@ type IssueWithCounts struct {
 	DependentCount  int     `json:"dependent_count"`
 	CommentCount    int     `json:"comment_count"`
 	Parent          *string `json:"parent,omitempty"` // Computed parent from parent-child dep (bd-ym8c)
+	Source          string  `json:"source,omitempty"` // "issue" or "wisp"; set when a query spans both tables
 }
 
 // IssueDetails extends Issue with labels, dependencies, dependents, and comments.
@@ -833,6 +868,9 @@ type IssueDetails struct {
 	Dependents   []*IssueWithDependencyMetadata `json:"dependents,omitempty"`
 	Comments     []*Comment                     `json:"comments,omitempty"`
 	Parent       *string                        `json:"parent,omitempty"`
+	Runs         []*Run                         `json:"runs,omitempty"` // Most recent external job runs (bd run)
+	Slug         string                         `json:"slug,omitempty"` // Current human-friendly name (bd slug)
+	Lock         *IssueLock                     `json:"lock,omitempty"` // Live advisory edit lock (bd lock)
 
 	// Cardinality fields — emitted by default (count-only mode).
 	// Slice fields (Dependents, Comments) are nil when count-only is active.
@@ -1262,12 +1300,20 @@ type Statistics struct {
 	OpenIssues              int     `json:"open_issues"`
 	InProgressIssues        int     `json:"in_progress_issues"`
 	ClosedIssues            int     `json:"closed_issues"`
+	InReviewIssues          int     `json:"in_review_issues"` // Closed, awaiting human review (bd review)
 	BlockedIssues           int     `json:"blocked_issues"`
 	DeferredIssues          int     `json:"deferred_issues"` // Issues on ice
 	ReadyIssues             int     `json:"ready_issues"`
 	PinnedIssues            int     `json:"pinned_issues"` // Persistent issues
 	EpicsEligibleForClosure int     `json:"epics_eligible_for_closure"`
 	AverageLeadTime         float64 `json:"average_lead_time_hours"`
+	ReopenedIssues          int     `json:"reopened_issues"`   // Issues reopened at least once (metadata.reopen_count)
+	ReopenRate              float64 `json:"reopen_rate"`       // ReopenedIssues / issues ever closed
+	TotalCostTokens         int64   `json:"total_cost_tokens"` // Sum of metadata.cost_tokens (bd cost add)
+	TotalCostUSD            float64 `json:"total_cost_usd"`    // Sum of metadata.cost_usd
+	// CloseReasons counts closed issues by close reason category (see
+	// CloseReasonCategory). Filled in by bd status, not by the storage layer.
+	CloseReasons map[string]int `json:"close_reasons,omitempty"`
 }
 
 // IssueFilter is used to filter issue queries
@@ -1288,11 +1334,17 @@ type IssueFilter struct {
 	SpecIDPrefix  string   // Filter by spec_id prefix
 	Limit         int
 
+	// Full-text query over title, description, notes and ID, answered from
+	// the search index (see internal/storage/fts for the syntax).
+	FullText string
+
 	// Pattern matching
 	TitleContains       string
 	DescriptionContains string
 	NotesContains       string
 	ExternalRefContains string
+	DesignContains      string
+	AcceptanceContains  string
 	ExternalRef         *string // exact match on external_ref
 
 	// Date ranges
@@ -1317,15 +1369,25 @@ type IssueFilter struct {
 	// same-second group from its first id.
 	//
 	// This composes with every other filter (including CreatedBefore, which it
-	// does not replace). Pair it with SortBy="created", SortDesc=false so the
-	// ORDER BY is created_at DESC, id ASC — the order the predicate assumes.
+	// does not replace). Pair it with SortBy = {Field: "created", Desc: true}
+	// so the ORDER BY is created_at DESC, id ASC — the order the predicate
+	// assumes.
 	AfterCreatedAt *time.Time
 	AfterID        string
 
+	// Cursor pages through the results in that same (created_at DESC, id ASC)
+	// order, Limit issues at a time: CursorStart for the first page, then
+	// IssueCursor of the last issue returned for each page after it. It sets
+	// AfterCreatedAt/AfterID and the sort itself, and ignores Offset.
+	Cursor string
+
 	// Empty/null checks
-	EmptyDescription bool
-	NoAssignee       bool
-	NoLabels         bool
+	EmptyDescription     bool
+	NoDesign             bool
+	NoAcceptanceCriteria bool
+	NoNotes              bool
+	NoAssignee           bool
+	NoLabels             bool
 
 	// Numeric ranges
 	PriorityMin *int
@@ -1359,6 +1421,17 @@ type IssueFilter struct {
 	// Wisp type filtering (TTL-based compaction classification)
 	WispType *WispType // Filter by wisp type (nil = any, heartbeat/ping/patrol/gc_report/recovery/error/escalation)
 
+	// Formula provenance filtering: issues and wisps cooked or poured from a formula
+	SourceFormula string // Filter by source formula name (empty = any)
+
+	// Rig filtering: issues scoped to a rig (see bd rig)
+	Rig string // Filter by rig name (empty = any)
+
+	// Event filtering: event issues by kind, actor, and target (empty = any)
+	EventKind   string
+	EventActor  string
+	EventTarget string
+
 	// Status exclusion (for default non-closed behavior)
 	ExcludeStatus []Status // Exclude issues with these statuses
 
@@ -1377,6 +1450,12 @@ type IssueFilter struct {
 	MetadataFields map[string]string // Top-level key=value equality; AND semantics (all must match)
 	HasMetadataKey string            // Existence check: issue has this top-level key set (non-null)
 
+	// Quality score filtering (metadata.quality_score, see internal/quality)
+	MinQualityScore *float64 // Filter issues whose quality score is >= this value
+
+	// Reopen filtering (metadata.reopen_count, maintained by bd reopen)
+	MinReopenCount int // Filter issues reopened at least this many times (0 = no filter)
+
 	// Hydration options — control which relational data is populated on returned issues.
 	// Labels are always hydrated. Dependencies are not by default (for performance).
 	IncludeDependencies bool // When true, populate Issue.Dependencies with []*Dependency records
@@ -1386,13 +1465,20 @@ type IssueFilter struct {
 	// Opt-in performance flag for the bd list --skip-labels code path.
 	SkipLabels bool
 
+	// Point-in-time reads: search the issues as they were at a commit hash,
+	// branch, or date (Dolt AS OF). Wisps are never versioned, so an AsOf
+	// search covers the issues table only.
+	AsOf string
+
 	// Performance escape hatches
 	SkipWisps  bool // Q2: skip wisps table merge entirely (for callers that never return ephemeral results)
 	NoIDShrink bool // Q3: force Pattern A (full 47-col scan) even when Limit > 0
 
-	Offset   int
-	SortBy   string
-	SortDesc bool
+	Offset int
+	// SortBy orders results by each key in turn, then by id. Empty sorts by
+	// priority, then newest first. Nullable fields (closed, due, assignee)
+	// sort NULL lowest: first ascending, last descending.
+	SortBy []SortKey
 }
 
 // SortPolicy determines how ready work is ordered
@@ -1442,6 +1528,7 @@ type WorkFilter struct {
 	Labels        []string // AND semantics: issue must have ALL these labels
 	LabelsAny     []string // OR semantics: issue must have AT LEAST ONE of these labels
 	ExcludeLabels []string // Exclusion: issue must NOT have ANY of these labels
+	ExcludeIDs    []string // Exclusion: issue must NOT be one of these
 	LabelPattern  string   // Glob pattern for label matching (e.g., "tech-*")
 	LabelRegex    string   // Regex pattern for label matching (e.g., "tech-(debt|legacy)")
 	Limit         int
@@ -1477,6 +1564,15 @@ type WorkFilter struct {
 	MetadataFields map[string]string // Top-level key=value equality; AND semantics (all must match)
 	HasMetadataKey string            // Existence check: issue has this top-level key set (non-null)
 
+	// Quality score filtering (metadata.quality_score, see internal/quality)
+	MinQualityScore *float64 // Filter issues whose quality score is >= this value
+
+	// Reopen filtering (metadata.reopen_count, maintained by bd reopen)
+	MinReopenCount int // Filter issues reopened at least this many times (0 = no filter)
+
+	// Rig filtering: issues scoped to a rig (see bd rig)
+	Rig string // Filter by rig name (empty = any)
+
 	Offset int
 }
 
@@ -1521,6 +1617,9 @@ type EpicStatus struct {
 	TotalChildren    int    `json:"total_children"`
 	ClosedChildren   int    `json:"closed_children"`
 	EligibleForClose bool   `json:"eligible_for_close"`
+	// Activity is the daily event count on the epic and its children,
+	// oldest day first, when the backend can aggregate events.
+	Activity []int `json:"activity,omitempty"`
 }
 
 // BondRef tracks compound molecule lineage.
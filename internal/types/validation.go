@@ -0,0 +1,153 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Metadata keys for validation records. ValidationsMetadataKey holds the
+// append-only []Validation log; ValidationStateMetadataKey mirrors the
+// derived state as a scalar so list filters can match it in SQL.
+const (
+	ValidationsMetadataKey     = "validations"
+	ValidationStateMetadataKey = "validation_state"
+)
+
+// ValidationOutcome is a validator's verdict on an issue's work.
+type ValidationOutcome string
+
+const (
+	ValidationPass      ValidationOutcome = "pass"
+	ValidationFail      ValidationOutcome = "fail"
+	ValidationNeedsWork ValidationOutcome = "needs-work"
+)
+
+// IsValid reports whether o is a known outcome.
+func (o ValidationOutcome) IsValid() bool {
+	switch o {
+	case ValidationPass, ValidationFail, ValidationNeedsWork:
+		return true
+	}
+	return false
+}
+
+// Aggregate validation states, derived from each validator's latest record.
+const (
+	ValidationStateNone      = "none"       // No validations recorded
+	ValidationStatePassed    = "passed"     // Every validator's latest outcome is pass
+	ValidationStateFailed    = "failed"     // At least one validator's latest outcome is fail
+	ValidationStateNeedsWork = "needs-work" // No fails, but at least one needs-work
+)
+
+// Validation is one validator's verdict on an issue (HOP validation).
+// Validator is an entity reference such as "reviewer:alice"; the part before
+// the colon is the role that required-validation policies match against.
+type Validation struct {
+	Validator   string            `json:"validator"`
+	Outcome     ValidationOutcome `json:"outcome"`
+	Comment     string            `json:"comment,omitempty"`
+	ValidatedAt time.Time         `json:"validated_at"`
+}
+
+// Role returns the role part of the validator reference ("reviewer" for
+// "reviewer:alice"), or "" when the reference has no role.
+func (v Validation) Role() string {
+	role, _, ok := strings.Cut(v.Validator, ":")
+	if !ok {
+		return ""
+	}
+	return role
+}
+
+// ValidationsFromMetadata extracts the validation log from an issue's
+// metadata. It returns nil when the metadata has no validations key.
+func ValidationsFromMetadata(metadata json.RawMessage) ([]Validation, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &wrapper); err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+	raw, ok := wrapper[ValidationsMetadataKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var vals []Validation
+	if err := json.Unmarshal(raw, &vals); err != nil {
+		return nil, fmt.Errorf("parsing metadata.%s: %w", ValidationsMetadataKey, err)
+	}
+	return vals, nil
+}
+
+// LatestValidations returns each validator's most recent validation, in the
+// order validators first appear in the log. A later record from the same
+// validator supersedes an earlier one (e.g. needs-work followed by pass).
+func LatestValidations(vals []Validation) []Validation {
+	index := make(map[string]int, len(vals))
+	var latest []Validation
+	for _, v := range vals {
+		if i, ok := index[v.Validator]; ok {
+			if !v.ValidatedAt.Before(latest[i].ValidatedAt) {
+				latest[i] = v
+			}
+			continue
+		}
+		index[v.Validator] = len(latest)
+		latest = append(latest, v)
+	}
+	return latest
+}
+
+// ValidationState derives the aggregate state from a validation log.
+func ValidationState(vals []Validation) string {
+	latest := LatestValidations(vals)
+	if len(latest) == 0 {
+		return ValidationStateNone
+	}
+	state := ValidationStatePassed
+	for _, v := range latest {
+		switch v.Outcome {
+		case ValidationFail:
+			return ValidationStateFailed
+		case ValidationNeedsWork:
+			state = ValidationStateNeedsWork
+		}
+	}
+	return state
+}
+
+// MissingValidations returns the required roles that lack a passing latest
+// validation. The role "*" is satisfied by a pass from any validator. Any
+// failing or needs-work latest validation is reported as well, since a close
+// should not proceed over an outstanding objection.
+func MissingValidations(vals []Validation, requiredRoles []string) []string {
+	latest := LatestValidations(vals)
+	var missing []string
+	for _, role := range requiredRoles {
+		satisfied := false
+		for _, v := range latest {
+			if v.Outcome == ValidationPass && (role == "*" || v.Role() == role) {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			if role == "*" {
+				missing = append(missing, "a passing validation")
+			} else {
+				missing = append(missing, "a passing "+role+" validation")
+			}
+		}
+	}
+	if len(requiredRoles) > 0 {
+		for _, v := range latest {
+			if v.Outcome != ValidationPass {
+				missing = append(missing, fmt.Sprintf("%s (%s) to be resolved", v.Validator, v.Outcome))
+			}
+		}
+	}
+	return missing
+}
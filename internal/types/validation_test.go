@@ -0,0 +1,71 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestValidationStateUsesLatestPerValidator(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	vals := []Validation{
+		{Validator: "reviewer:alice", Outcome: ValidationNeedsWork, ValidatedAt: t0},
+		{Validator: "qa:bob", Outcome: ValidationPass, ValidatedAt: t0.Add(time.Minute)},
+	}
+	if got := ValidationState(vals); got != ValidationStateNeedsWork {
+		t.Errorf("state = %q, want needs-work", got)
+	}
+	vals = append(vals, Validation{Validator: "reviewer:alice", Outcome: ValidationPass, ValidatedAt: t0.Add(time.Hour)})
+	if got := ValidationState(vals); got != ValidationStatePassed {
+		t.Errorf("state after re-review = %q, want passed", got)
+	}
+	if latest := LatestValidations(vals); len(latest) != 2 || latest[0].Outcome != ValidationPass {
+		t.Errorf("LatestValidations = %+v", latest)
+	}
+	vals = append(vals, Validation{Validator: "sec:carol", Outcome: ValidationFail, ValidatedAt: t0.Add(2 * time.Hour)})
+	if got := ValidationState(vals); got != ValidationStateFailed {
+		t.Errorf("state = %q, want failed", got)
+	}
+	if got := ValidationState(nil); got != ValidationStateNone {
+		t.Errorf("empty state = %q, want none", got)
+	}
+}
+
+func TestMissingValidations(t *testing.T) {
+	vals := []Validation{{Validator: "reviewer:alice", Outcome: ValidationPass}}
+	if missing := MissingValidations(vals, []string{"reviewer"}); len(missing) != 0 {
+		t.Errorf("reviewer pass should satisfy policy, missing = %v", missing)
+	}
+	if missing := MissingValidations(vals, []string{"*"}); len(missing) != 0 {
+		t.Errorf("* should accept any pass, missing = %v", missing)
+	}
+	if missing := MissingValidations(vals, []string{"reviewer", "security"}); len(missing) != 1 {
+		t.Errorf("missing = %v, want security only", missing)
+	}
+	vals = append(vals, Validation{Validator: "qa:bob", Outcome: ValidationFail})
+	if missing := MissingValidations(vals, []string{"reviewer"}); len(missing) != 1 {
+		t.Errorf("outstanding fail should block, missing = %v", missing)
+	}
+	if missing := MissingValidations(vals, nil); len(missing) != 0 {
+		t.Errorf("no policy should never report missing, got %v", missing)
+	}
+}
+
+func TestValidationsFromMetadata(t *testing.T) {
+	meta, _ := json.Marshal(map[string]interface{}{
+		ValidationsMetadataKey: []Validation{{Validator: "alice", Outcome: ValidationPass}},
+	})
+	vals, err := ValidationsFromMetadata(meta)
+	if err != nil || len(vals) != 1 || vals[0].Role() != "" {
+		t.Fatalf("ValidationsFromMetadata = %+v, %v", vals, err)
+	}
+	if (Validation{Validator: "reviewer:alice"}).Role() != "reviewer" {
+		t.Error("Role should be the part before the colon")
+	}
+	if vals, err := ValidationsFromMetadata(json.RawMessage(`{}`)); err != nil || vals != nil {
+		t.Errorf("empty metadata = %+v, %v", vals, err)
+	}
+	if !ValidationNeedsWork.IsValid() || ValidationOutcome("maybe").IsValid() {
+		t.Error("IsValid mismatch")
+	}
+}
@@ -2,7 +2,9 @@
 package ui
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -16,6 +18,18 @@ type PagerOptions struct {
 	NoPager bool
 }
 
+// pagedStdout is the real stdout while PageOutput has os.Stdout captured.
+var pagedStdout *os.File
+
+// stdoutFile returns the file output ends up on: the real stdout, even
+// while PageOutput is capturing it.
+func stdoutFile() *os.File {
+	if pagedStdout != nil {
+		return pagedStdout
+	}
+	return os.Stdout
+}
+
 // shouldUsePager determines if output should be piped to a pager.
 // Returns false if:
 // - NoPager option is set
@@ -31,7 +45,7 @@ func shouldUsePager(opts PagerOptions) bool {
 	}
 
 	// Check if stdout is a terminal
-	if !term.IsTerminal(int(os.Stdout.Fd())) {
+	if !term.IsTerminal(int(stdoutFile().Fd())) {
 		return false
 	}
 
@@ -53,7 +67,7 @@ func getPagerCommand() string {
 // getTerminalHeight returns the height of the terminal in lines.
 // Returns 0 if unable to determine (not a TTY).
 func getTerminalHeight() int {
-	fd := int(os.Stdout.Fd())
+	fd := int(stdoutFile().Fd())
 	if !term.IsTerminal(fd) {
 		return 0
 	}
@@ -117,3 +131,40 @@ func ToPager(content string, opts PagerOptions) error {
 
 	return cmd.Run()
 }
+
+// PageOutput runs render with os.Stdout captured and hands what it printed
+// to ToPager, for commands that print as they go rather than building a
+// string. When no pager would be used, render writes straight to stdout.
+func PageOutput(opts PagerOptions, render func() error) error {
+	if !shouldUsePager(opts) {
+		return render()
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return render()
+	}
+
+	var buf bytes.Buffer
+	copied := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(&buf, r)
+		close(copied)
+	}()
+
+	stdout := os.Stdout
+	pagedStdout, os.Stdout = stdout, w
+	renderErr := func() error {
+		defer func() {
+			os.Stdout, pagedStdout = stdout, nil
+			_ = w.Close()
+		}()
+		return render()
+	}()
+	<-copied
+	_ = r.Close()
+
+	if err := ToPager(buf.String(), opts); err != nil {
+		fmt.Print(buf.String())
+	}
+	return renderErr
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"testing"
@@ -172,3 +173,21 @@ func TestToPagerWithBdNoPagerEnv(t *testing.T) {
 		t.Errorf("ToPager() returned error: %v", err)
 	}
 }
+
+func TestPageOutputWithoutPagerRunsRenderDirectly(t *testing.T) {
+	ran := false
+	wantErr := errors.New("render failed")
+	err := PageOutput(PagerOptions{NoPager: true}, func() error {
+		ran = true
+		if pagedStdout != nil {
+			t.Error("stdout captured although no pager is used")
+		}
+		return wantErr
+	})
+	if !ran {
+		t.Fatal("render was not called")
+	}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("PageOutput() error = %v, want %v", err, wantErr)
+	}
+}
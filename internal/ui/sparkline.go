@@ -0,0 +1,25 @@
+package ui
+
+// sparkBlocks are the block heights of a sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as one block character each, scaled so the
+// largest value is a full block. Zeros get the lowest block so quiet
+// stretches still show as a baseline; any nonzero value is at least one
+// step above it.
+func Sparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	out := make([]rune, len(values))
+	for i, v := range values {
+		level := 0
+		if v > 0 {
+			steps := len(sparkBlocks) - 1
+			level = (v*steps + peak - 1) / peak // ceil(v/peak * steps)
+		}
+		out[i] = sparkBlocks[level]
+	}
+	return string(out)
+}
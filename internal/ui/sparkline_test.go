@@ -0,0 +1,24 @@
+package ui
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		want   string
+	}{
+		{"empty", nil, ""},
+		{"all zero", []int{0, 0, 0}, "▁▁▁"},
+		{"ramp", []int{0, 1, 2, 3, 4, 5, 6, 7}, "▁▂▃▄▅▆▇█"},
+		{"small values stay above baseline", []int{1, 0, 100}, "▂▁█"},
+		{"flat nonzero", []int{3, 3}, "██"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sparkline(tt.values); got != tt.want {
+				t.Errorf("Sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+			}
+		})
+	}
+}
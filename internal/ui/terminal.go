@@ -10,8 +10,20 @@ import (
 )
 
 // IsTerminal returns true if stdout is connected to a terminal (TTY).
+// While PageOutput captures output this reports on the real stdout, so
+// output rendered for the pager keeps its colors.
 func IsTerminal() bool {
-	return term.IsTerminal(int(os.Stdout.Fd()))
+	return term.IsTerminal(int(stdoutFile().Fd()))
+}
+
+// TerminalWidth returns the width of the terminal stdout is connected to,
+// or 0 if it is not a terminal.
+func TerminalWidth() int {
+	w, _, err := term.GetSize(int(stdoutFile().Fd()))
+	if err != nil {
+		return 0
+	}
+	return w
 }
 
 // IsStderrTerminal returns true if stderr is connected to a terminal (TTY).
@@ -6,14 +6,12 @@
 package uimd
 
 import (
-	"os"
 	"strings"
 
 	"charm.land/glamour/v2"
 	"charm.land/glamour/v2/styles"
 	xansi "github.com/charmbracelet/x/ansi"
 	"github.com/steveyegge/beads/internal/ui"
-	"golang.org/x/term"
 )
 
 // RenderMarkdown renders markdown text using glamour's terminal style.
@@ -27,7 +25,7 @@ func RenderMarkdown(markdown string) string {
 	// Cap at 100 chars for readability; wider lines are harder to scan.
 	const maxReadableWidth = 100
 	wrapWidth := 80
-	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+	if w := ui.TerminalWidth(); w > 0 {
 		wrapWidth = w
 	}
 	if wrapWidth > maxReadableWidth {
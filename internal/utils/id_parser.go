@@ -33,10 +33,15 @@ func parseIssueID(input string, prefix string) string {
 // - Without hyphen: "bda3f8e9" or "wya3f8e9" → "bd-a3f8e9"
 // - Partial IDs: "a3f8" → "bd-a3f8e9" (if unique match)
 // - Hierarchical: "a3f8e9.1" → "bd-a3f8e9.1"
+// - Slugs, current or old: "fix-login-timeout" → "bd-a3f8e9"
+//
+// An exact ID always wins, so "bd-12" resolves to bd-12 even when bd-123
+// exists. Otherwise IDs that start with the input are preferred over IDs
+// that merely contain it.
 //
 // Returns an error if:
 // - No issue found matching the ID
-// - Multiple issues match (ambiguous prefix)
+// - Multiple issues match (ambiguous prefix); the error is an *AmbiguousIDError
 func ResolvePartialID(ctx context.Context, store storage.Storage, input string) (string, error) {
 	if store == nil {
 		return "", fmt.Errorf("cannot resolve issue ID %q: storage is nil", input)
@@ -76,6 +81,12 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 			}
 		}
 	}
+	for _, key := range []string{types.ConfigKeyPrefixByType, types.ConfigKeyPrefixByLabel} {
+		if value, err := store.GetConfig(ctx, key); err == nil {
+			rules, _ := types.ParseIDPrefixRules(value)
+			knownPrefixes = append(knownPrefixes, types.RulePrefixes(rules)...)
+		}
+	}
 
 	// Normalize input:
 	// 1. If it has the full prefix with hyphen (bd-a3f8e9), use as-is
@@ -106,6 +117,12 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 		return issues[0].ID, nil
 	}
 
+	// A slug (bd slug) names one issue exactly, so it is tried before any
+	// partial matching, but after the exact ID so a slug never hides one.
+	if id, ok := resolveSlug(ctx, store, input); ok {
+		return id, nil
+	}
+
 	// If exact match failed, try substring search.
 	// Use the hash part as a search query to leverage SQL-level filtering
 	// (id LIKE %hash%) instead of loading ALL issues into memory.
@@ -127,7 +144,7 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 		return "", fmt.Errorf("failed to search issues: %w", err)
 	}
 
-	var matches []string
+	var matches, prefixMatches []string
 	var exactMatch string
 
 	for _, id := range ids {
@@ -140,12 +157,7 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 		// Extract hash from each issue using config-aware prefix extraction.
 		// This correctly handles multi-hyphen prefixes (e.g., "hacker-news-ko4"
 		// yields hash "ko4", not "news-ko4" from naive first-hyphen split).
-		var issueHash string
-		if p := ExtractIssuePrefixKnown(id, knownPrefixes); p != "" && strings.HasPrefix(id, p+"-") {
-			issueHash = id[len(p)+1:]
-		} else {
-			issueHash = id
-		}
+		issueHash := issueIDHash(id, knownPrefixes)
 
 		// Check for exact hash match (excluding hierarchical children)
 		if issueHash == hashPart {
@@ -156,6 +168,9 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 		// Check if the issue hash contains the input hash as substring
 		if strings.Contains(issueHash, hashPart) {
 			matches = append(matches, id)
+			if hashHasPrefix(issueHash, hashPart) {
+				prefixMatches = append(prefixMatches, id)
+			}
 		}
 	}
 
@@ -176,17 +191,15 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 				if wID == input {
 					return wID, nil
 				}
-				var wHash string
-				if p := ExtractIssuePrefixKnown(wID, knownPrefixes); p != "" && strings.HasPrefix(wID, p+"-") {
-					wHash = wID[len(p)+1:]
-				} else {
-					wHash = wID
-				}
+				wHash := issueIDHash(wID, knownPrefixes)
 				if wHash == hashPart {
 					exactMatch = wID
 				}
 				if strings.Contains(wHash, hashPart) {
 					matches = append(matches, wID)
+					if hashHasPrefix(wHash, hashPart) {
+						prefixMatches = append(prefixMatches, wID)
+					}
 				}
 			}
 			if exactMatch != "" {
@@ -199,18 +212,59 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 		return "", fmt.Errorf("no issue found matching %q", input)
 	}
 
-	// Sort so the ambiguity error lists IDs deterministically. SearchIssues return
-	// order is not a contract for ambiguous matches, so sorting by ID pins the same
-	// message for every storage implementation.
-	sort.Strings(matches)
+	// Like an abbreviated git SHA, input that starts an ID wins over input
+	// found in the middle of one: "12" means bd-12a or bd-123, not bd-a12.
+	// Substring matches are only considered when no ID starts with the input.
+	if len(prefixMatches) > 0 {
+		matches = prefixMatches
+	}
 
 	if len(matches) > 1 {
-		return "", fmt.Errorf("ambiguous ID %q matches %d issues: %v\nUse more characters to disambiguate", input, len(matches), matches)
+		return "", newAmbiguousIDError(ctx, store, input, matches)
 	}
 
 	return matches[0], nil
 }
 
+// resolveSlug looks input up as a current or old issue slug. Stores
+// without slug support never match.
+func resolveSlug(ctx context.Context, store storage.Storage, input string) (string, bool) {
+	if types.ValidateSlug(input) != nil {
+		return "", false
+	}
+	if ds, ok := store.(storage.DoltStorage); ok {
+		store = storage.UnwrapStore(ds)
+	}
+	ss, ok := store.(storage.SlugStore)
+	if !ok {
+		return "", false
+	}
+	id, err := ss.ResolveSlug(ctx, input)
+	return id, err == nil
+}
+
+// issueIDHash returns the part of id after its prefix, using the known
+// prefixes to split multi-hyphen prefixes correctly. IDs without a
+// recognizable prefix are returned unchanged.
+func issueIDHash(id string, knownPrefixes []string) string {
+	if p := ExtractIssuePrefixKnown(id, knownPrefixes); p != "" && strings.HasPrefix(id, p+"-") {
+		return id[len(p)+1:]
+	}
+	return id
+}
+
+// hashHasPrefix reports whether part abbreviates hash: hash starts with it,
+// or, for hashes with an infix such as "wisp-t3st", the last segment does.
+func hashHasPrefix(hash, part string) bool {
+	if strings.HasPrefix(hash, part) {
+		return true
+	}
+	if idx := strings.LastIndex(hash, "-"); idx >= 0 {
+		return strings.HasPrefix(hash[idx+1:], part)
+	}
+	return false
+}
+
 func partialIDSearchPart(hashPart string) (string, bool) {
 	if !looksLikePartialIDHash(hashPart) {
 		return "", false
@@ -251,6 +305,65 @@ func ResolvePartialIDs(ctx context.Context, store storage.Storage, inputs []stri
 	return resolved, nil
 }
 
+// maxAmbiguousCandidates caps how many matches an AmbiguousIDError lists,
+// so a one-character prefix in a large database stays readable.
+const maxAmbiguousCandidates = 10
+
+// AmbiguousIDError is returned by ResolvePartialID when more than one issue
+// matches a partial ID.
+type AmbiguousIDError struct {
+	Input string
+	// Matches holds every matching ID, sorted.
+	Matches []string
+	// Titles maps matching IDs to their titles, when they could be loaded.
+	Titles map[string]string
+}
+
+func (e *AmbiguousIDError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "ambiguous ID %q matches %d issues:", e.Input, len(e.Matches))
+	shown := e.Matches
+	if len(shown) > maxAmbiguousCandidates {
+		shown = shown[:maxAmbiguousCandidates]
+	}
+	width := 0
+	for _, id := range shown {
+		width = max(width, len(id))
+	}
+	for _, id := range shown {
+		if title := e.Titles[id]; title != "" {
+			fmt.Fprintf(&b, "\n  %-*s  %s", width, id, title)
+		} else {
+			fmt.Fprintf(&b, "\n  %s", id)
+		}
+	}
+	if more := len(e.Matches) - len(shown); more > 0 {
+		fmt.Fprintf(&b, "\n  ... and %d more", more)
+	}
+	b.WriteString("\nUse more characters to disambiguate")
+	return b.String()
+}
+
+// newAmbiguousIDError builds an AmbiguousIDError for matches, looking up the
+// titles of the candidates it will list. A failed lookup only drops titles.
+func newAmbiguousIDError(ctx context.Context, store storage.Storage, input string, matches []string) *AmbiguousIDError {
+	// Sort so the ambiguity error lists IDs deterministically. SearchIssues return
+	// order is not a contract for ambiguous matches, so sorting by ID pins the same
+	// message for every storage implementation.
+	sort.Strings(matches)
+	e := &AmbiguousIDError{Input: input, Matches: matches, Titles: make(map[string]string)}
+	shown := matches
+	if len(shown) > maxAmbiguousCandidates {
+		shown = shown[:maxAmbiguousCandidates]
+	}
+	if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IDs: shown}); err == nil {
+		for _, issue := range issues {
+			e.Titles[issue.ID] = issue.Title
+		}
+	}
+	return e
+}
+
 // looksLikePrefixedID checks if input appears to already have a prefix.
 // A prefixed ID has the format "prefix-hash" where prefix is 1-8 lowercase
 // letters/numbers and hash is alphanumeric (potentially with dots for hierarchical IDs).
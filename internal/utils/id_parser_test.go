@@ -6,7 +6,10 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/steveyegge/beads/internal/storage/dolt"
@@ -967,6 +970,53 @@ func TestResolvePartialID_TitleFalsePositive(t *testing.T) {
 	}
 }
 
+// TestResolvePartialID_PrefixAmbiguity verifies git-SHA-style resolution:
+// an exact ID beats longer IDs it prefixes, IDs starting with the input beat
+// IDs merely containing it, and a shared prefix yields an AmbiguousIDError
+// listing the candidates with their titles.
+func TestResolvePartialID_PrefixAmbiguity(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	for _, issue := range []*types.Issue{
+		{ID: "bd-12", Title: "Short one"},
+		{ID: "bd-123", Title: "Long one"},
+		{ID: "bd-124", Title: "Sibling"},
+		{ID: "bd-a12", Title: "Contains twelve"},
+		{ID: "bd-7e1", Title: "Lone prefix"},
+	} {
+		issue.Status = types.StatusOpen
+		issue.Priority = 1
+		issue.IssueType = types.TypeTask
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for input, want := range map[string]string{
+		"bd-12": "bd-12",
+		"12":    "bd-12",
+		"123":   "bd-123",
+		"7e":    "bd-7e1",
+	} {
+		if got, err := ResolvePartialID(ctx, store, input); err != nil || got != want {
+			t.Errorf("ResolvePartialID(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	_, err := ResolvePartialID(ctx, store, "bd-1")
+	var ambiguous *AmbiguousIDError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("ResolvePartialID(%q) error = %v; want *AmbiguousIDError", "bd-1", err)
+	}
+	if want := []string{"bd-12", "bd-123", "bd-124"}; !slices.Equal(ambiguous.Matches, want) {
+		t.Errorf("matches = %v; want %v (bd-a12 only contains the input)", ambiguous.Matches, want)
+	}
+	if !strings.Contains(err.Error(), "bd-123  Long one") {
+		t.Errorf("error does not list titles:\n%v", err)
+	}
+}
+
 // TestLooksLikePrefixedID tests the helper function for detecting prefixed IDs
 func TestLooksLikePrefixedID(t *testing.T) {
 	tests := []struct {
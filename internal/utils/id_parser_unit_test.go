@@ -1,6 +1,10 @@
 package utils
 
-import "testing"
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
 
 func TestPartialIDSearchPartUsesLastHyphenSuffix(t *testing.T) {
 	got, ok := partialIDSearchPart("hacker-news-ko4")
@@ -36,3 +40,50 @@ func TestPartialIDSearchPartRejectsInvalidSearchText(t *testing.T) {
 		}
 	}
 }
+
+func TestHashHasPrefix(t *testing.T) {
+	tests := []struct {
+		hash, part string
+		want       bool
+	}{
+		{"123", "12", true},
+		{"a12", "12", false},
+		{"wisp-t3st", "t3", true},
+		{"wisp-t3st", "wi", true},
+		{"a3f8e9.1", "a3f8", true},
+	}
+	for _, tt := range tests {
+		if got := hashHasPrefix(tt.hash, tt.part); got != tt.want {
+			t.Errorf("hashHasPrefix(%q, %q) = %v, want %v", tt.hash, tt.part, got, tt.want)
+		}
+	}
+}
+
+func TestAmbiguousIDErrorListsCandidates(t *testing.T) {
+	err := &AmbiguousIDError{
+		Input:   "bd-12",
+		Matches: []string{"bd-120", "bd-1234"},
+		Titles:  map[string]string{"bd-120": "Fix login", "bd-1234": "Add logout"},
+	}
+	want := "ambiguous ID \"bd-12\" matches 2 issues:\n" +
+		"  bd-120   Fix login\n" +
+		"  bd-1234  Add logout\n" +
+		"Use more characters to disambiguate"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAmbiguousIDErrorTruncatesLongLists(t *testing.T) {
+	var matches []string
+	for i := 0; i < maxAmbiguousCandidates+3; i++ {
+		matches = append(matches, fmt.Sprintf("bd-1%02d", i))
+	}
+	msg := (&AmbiguousIDError{Input: "1", Matches: matches}).Error()
+	if !strings.Contains(msg, "matches 13 issues") || !strings.Contains(msg, "... and 3 more") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+	if strings.Contains(msg, matches[maxAmbiguousCandidates]) {
+		t.Errorf("message lists more than %d candidates:\n%s", maxAmbiguousCandidates, msg)
+	}
+}
@@ -0,0 +1,314 @@
+// Package wasmhooks runs in-process hooks compiled to WebAssembly.
+//
+// Modules are *.wasm files in .beads/plugins/. Each is instantiated once per
+// process in a sandbox with no filesystem, network, environment, or
+// arguments, and called through a narrow JSON-in, scalar-out ABI:
+//
+//	exports (module → bd)
+//	  memory                          linear memory
+//	  bd_alloc(size i32) i32          return a buffer bd can write input into
+//	  bd_score(ptr, len i32) i64      optional: score an issue for bd ready --sort score
+//	  bd_close_policy(ptr, len i32) i32
+//	                                  optional: 0 allows the close, anything else refuses it
+//
+//	imports (bd → module, import module "bd")
+//	  set_result(ptr, len i32)        set the message returned with a policy refusal
+//	  log(ptr, len i32)               write a line to bd's stderr
+//
+// Inputs are JSON: bd_score receives the issue; bd_close_policy receives a
+// ClosePolicyInput. WASI preview 1 is provided (with nothing mounted) so
+// modules built by TinyGo, Rust, or GOOS=wasip1 load unchanged.
+package wasmhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Export and import names making up the module ABI.
+const (
+	HostModule         = "bd"
+	ExportAlloc        = "bd_alloc"
+	ExportScore        = "bd_score"
+	ExportClosePolicy  = "bd_close_policy"
+	DefaultCallTimeout = 2 * time.Second
+
+	// maxMemoryPages caps each module at 64 MiB of linear memory.
+	maxMemoryPages = 1024
+)
+
+// Hook names reported by Module.Hooks.
+const (
+	HookScore       = "score"
+	HookClosePolicy = "close_policy"
+)
+
+// ClosePolicyInput is the JSON document passed to bd_close_policy.
+type ClosePolicyInput struct {
+	Issue  *types.Issue `json:"issue"`
+	Actor  string       `json:"actor"`
+	Reason string       `json:"reason"`
+}
+
+// PolicyError is returned when a module's policy refuses an operation.
+type PolicyError struct {
+	Module string
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("refused by policy module %s", e.Module)
+	}
+	return fmt.Sprintf("refused by policy module %s: %s", e.Module, e.Reason)
+}
+
+// Module describes a loaded hook module.
+type Module struct {
+	Name  string   `json:"name"`
+	Path  string   `json:"path"`
+	Hooks []string `json:"hooks"`
+}
+
+type module struct {
+	Module
+	inst        api.Module
+	alloc       api.Function
+	score       api.Function
+	closePolicy api.Function
+}
+
+// Host owns the wasm runtime and the modules loaded from one directory.
+// Calls are serialized; a Host is safe for concurrent use.
+type Host struct {
+	mu      sync.Mutex
+	runtime wazero.Runtime
+	modules []*module
+	timeout time.Duration
+	logOut  io.Writer
+	result  []byte // set_result from the call in progress
+	calling string // module name of the call in progress, for log
+}
+
+// Load compiles and instantiates every *.wasm module in dir. A missing
+// directory is not an error; it yields a Host with no modules.
+func Load(ctx context.Context, dir string) (*Host, error) {
+	h := &Host{timeout: DefaultCallTimeout, logOut: os.Stderr}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.wasm"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	if len(paths) == 0 {
+		return h, nil
+	}
+
+	cfg := wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(maxMemoryPages)
+	// Compiling a module dominates startup; keep compiled code across runs.
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		if cache, err := wazero.NewCompilationCacheWithDir(filepath.Join(cacheDir, "beads", "wasm")); err == nil {
+			cfg = cfg.WithCompilationCache(cache)
+		}
+	}
+	h.runtime = wazero.NewRuntimeWithConfig(ctx, cfg)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, h.runtime); err != nil {
+		_ = h.Close(ctx)
+		return nil, fmt.Errorf("wasm: instantiating WASI: %w", err)
+	}
+	if _, err := h.runtime.NewHostModuleBuilder(HostModule).
+		NewFunctionBuilder().WithFunc(h.hostSetResult).Export("set_result").
+		NewFunctionBuilder().WithFunc(h.hostLog).Export("log").
+		Instantiate(ctx); err != nil {
+		_ = h.Close(ctx)
+		return nil, fmt.Errorf("wasm: instantiating host module: %w", err)
+	}
+
+	for _, path := range paths {
+		m, err := h.load(ctx, path)
+		if err != nil {
+			_ = h.Close(ctx)
+			return nil, err
+		}
+		h.modules = append(h.modules, m)
+	}
+	return h, nil
+}
+
+func (h *Host) load(ctx context.Context, path string) (*module, error) {
+	name := strings.TrimSuffix(filepath.Base(path), ".wasm")
+	code, err := os.ReadFile(path) // #nosec G304 -- module from the workspace's plugins directory
+	if err != nil {
+		return nil, fmt.Errorf("wasm: reading %s: %w", path, err)
+	}
+	compiled, err := h.runtime.CompileModule(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("wasm: compiling %s: %w", name, err)
+	}
+	// No FS, env, args, or stdio are configured: the module can only see
+	// what bd passes it.
+	modCfg := wazero.NewModuleConfig().WithName(name).WithStartFunctions("_initialize")
+	inst, err := h.runtime.InstantiateModule(ctx, compiled, modCfg)
+	if err != nil {
+		return nil, fmt.Errorf("wasm: instantiating %s: %w", name, err)
+	}
+	m := &module{
+		Module:      Module{Name: name, Path: path, Hooks: []string{}},
+		inst:        inst,
+		alloc:       inst.ExportedFunction(ExportAlloc),
+		score:       inst.ExportedFunction(ExportScore),
+		closePolicy: inst.ExportedFunction(ExportClosePolicy),
+	}
+	if m.score != nil {
+		m.Hooks = append(m.Hooks, HookScore)
+	}
+	if m.closePolicy != nil {
+		m.Hooks = append(m.Hooks, HookClosePolicy)
+	}
+	if len(m.Hooks) > 0 && (m.alloc == nil || inst.Memory() == nil) {
+		return nil, fmt.Errorf("wasm: module %s exports hooks but not %s and memory", name, ExportAlloc)
+	}
+	return m, nil
+}
+
+// Close releases the runtime and every module.
+func (h *Host) Close(ctx context.Context) error {
+	if h == nil || h.runtime == nil {
+		return nil
+	}
+	return h.runtime.Close(ctx)
+}
+
+// Modules lists the loaded modules in file name order.
+func (h *Host) Modules() []Module {
+	if h == nil {
+		return nil
+	}
+	out := make([]Module, 0, len(h.modules))
+	for _, m := range h.modules {
+		out = append(out, m.Module)
+	}
+	return out
+}
+
+// HasScorer reports whether any module exports bd_score.
+func (h *Host) HasScorer() bool {
+	if h == nil {
+		return false
+	}
+	for _, m := range h.modules {
+		if m.score != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Score returns the sum of every scoring module's score for issue.
+func (h *Host) Score(ctx context.Context, issue *types.Issue) (int64, error) {
+	if h == nil {
+		return 0, nil
+	}
+	input, err := json.Marshal(issue)
+	if err != nil {
+		return 0, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var total int64
+	for _, m := range h.modules {
+		if m.score == nil {
+			continue
+		}
+		res, err := h.call(ctx, m, m.score, input)
+		if err != nil {
+			return 0, err
+		}
+		total += int64(res) // #nosec G115 -- bd_score returns a signed i64
+	}
+	return total, nil
+}
+
+// CheckClose runs every close policy and returns a *PolicyError for the
+// first module that refuses.
+func (h *Host) CheckClose(ctx context.Context, in ClosePolicyInput) error {
+	if h == nil {
+		return nil
+	}
+	input, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, m := range h.modules {
+		if m.closePolicy == nil {
+			continue
+		}
+		res, err := h.call(ctx, m, m.closePolicy, input)
+		if err != nil {
+			return err
+		}
+		if uint32(res) != 0 {
+			return &PolicyError{Module: m.Name, Reason: string(h.result)}
+		}
+	}
+	return nil
+}
+
+// call copies input into the module and invokes fn(ptr, len). h.mu is held.
+func (h *Host) call(ctx context.Context, m *module, fn api.Function, input []byte) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	h.result = nil
+	h.calling = m.Name
+
+	size := uint64(len(input))
+	res, err := m.alloc.Call(ctx, size)
+	if err != nil {
+		return 0, fmt.Errorf("wasm: %s.%s: %w", m.Name, ExportAlloc, err)
+	}
+	ptr := res[0]
+	if !m.inst.Memory().Write(uint32(ptr), input) { // #nosec G115 -- wasm32 pointer
+		return 0, fmt.Errorf("wasm: %s.%s returned an out-of-range buffer", m.Name, ExportAlloc)
+	}
+	res, err = fn.Call(ctx, ptr, size)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return 0, fmt.Errorf("wasm: %s timed out after %s", m.Name, h.timeout)
+		}
+		return 0, fmt.Errorf("wasm: %s: %w", m.Name, err)
+	}
+	if len(res) == 0 {
+		return 0, nil
+	}
+	return res[0], nil
+}
+
+func (h *Host) hostSetResult(_ context.Context, mod api.Module, ptr, size uint32) {
+	if b, ok := mod.Memory().Read(ptr, size); ok {
+		h.result = append([]byte(nil), b...)
+	}
+}
+
+func (h *Host) hostLog(_ context.Context, mod api.Module, ptr, size uint32) {
+	if b, ok := mod.Memory().Read(ptr, size); ok {
+		fmt.Fprintf(h.logOut, "[%s] %s\n", h.calling, strings.TrimRight(string(b), "\n"))
+	}
+}
@@ -0,0 +1,124 @@
+package wasmhooks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// testModule is a hand-assembled module implementing the ABI:
+//
+//	bd_alloc(size)        → 1024
+//	bd_score(ptr, len)    → len (the size of the issue JSON)
+//	bd_close_policy(p, l) → set_result(p, l); return 1 (always refuse,
+//	                        echoing the input as the reason)
+func testModule() []byte {
+	name := func(s string) []byte { return append([]byte{byte(len(s))}, s...) }
+	section := func(id byte, body ...[]byte) []byte {
+		var b []byte
+		for _, p := range body {
+			b = append(b, p...)
+		}
+		return append([]byte{id, byte(len(b))}, b...)
+	}
+	export := func(n string, kind, idx byte) []byte { return append(name(n), kind, idx) }
+	code := func(body ...byte) []byte { return append([]byte{byte(len(body) + 1), 0x00}, body...) }
+
+	var m []byte
+	m = append(m, 0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00)
+	m = append(m, section(1, []byte{0x04},
+		[]byte{0x60, 0x01, 0x7f, 0x01, 0x7f},       // (i32) → i32
+		[]byte{0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e}, // (i32, i32) → i64
+		[]byte{0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f}, // (i32, i32) → i32
+		[]byte{0x60, 0x02, 0x7f, 0x7f, 0x00},       // (i32, i32) → ()
+	)...)
+	m = append(m, section(2, []byte{0x01}, name(HostModule), name("set_result"), []byte{0x00, 0x03})...)
+	m = append(m, section(3, []byte{0x03, 0x00, 0x01, 0x02})...)
+	m = append(m, section(5, []byte{0x01, 0x00, 0x01})...)
+	m = append(m, section(7, []byte{0x04},
+		export("memory", 0x02, 0),
+		export(ExportAlloc, 0x00, 1),
+		export(ExportScore, 0x00, 2),
+		export(ExportClosePolicy, 0x00, 3),
+	)...)
+	m = append(m, section(10, []byte{0x03},
+		code(0x41, 0x80, 0x08, 0x0b),                               // i32.const 1024
+		code(0x20, 0x01, 0xad, 0x0b),                               // local.get 1; i64.extend_i32_u
+		code(0x20, 0x00, 0x20, 0x01, 0x10, 0x00, 0x41, 0x01, 0x0b), // call set_result; i32.const 1
+	)...)
+	return m
+}
+
+func TestHost(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "policy.wasm"), testModule(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	h, err := Load(ctx, dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	defer func() { _ = h.Close(ctx) }()
+
+	mods := h.Modules()
+	if len(mods) != 1 || mods[0].Name != "policy" || strings.Join(mods[0].Hooks, ",") != "score,close_policy" {
+		t.Fatalf("Modules() = %+v", mods)
+	}
+	if !h.HasScorer() {
+		t.Fatal("HasScorer() = false")
+	}
+
+	short, err := h.Score(ctx, &types.Issue{ID: "bd-1", Title: "a"})
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	long, err := h.Score(ctx, &types.Issue{ID: "bd-2", Title: "a much longer title"})
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	if short <= 0 || long <= short {
+		t.Errorf("Score: short=%d long=%d, want 0 < short < long", short, long)
+	}
+
+	err = h.CheckClose(ctx, ClosePolicyInput{Issue: &types.Issue{ID: "bd-7"}, Actor: "alice"})
+	var perr *PolicyError
+	if !errors.As(err, &perr) {
+		t.Fatalf("CheckClose err = %v, want *PolicyError", err)
+	}
+	if perr.Module != "policy" || !strings.Contains(perr.Reason, `"bd-7"`) || !strings.Contains(perr.Reason, `"alice"`) {
+		t.Errorf("PolicyError = %+v", perr)
+	}
+}
+
+func TestLoadEmpty(t *testing.T) {
+	ctx := context.Background()
+	h, err := Load(ctx, filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if h.HasScorer() || len(h.Modules()) != 0 {
+		t.Errorf("empty host reports modules: %+v", h.Modules())
+	}
+	if err := h.CheckClose(ctx, ClosePolicyInput{}); err != nil {
+		t.Errorf("CheckClose on empty host: %v", err)
+	}
+	if err := h.Close(ctx); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
+
+func TestLoadRejectsInvalidModule(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "bad.wasm"), []byte("not wasm"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(context.Background(), dir); err == nil {
+		t.Fatal("Load accepted an invalid module")
+	}
+}
@@ -0,0 +1,84 @@
+package testsupport
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/steveyegge/beads"
+)
+
+// AssertStatus fails the test unless issue id has the given status.
+func AssertStatus(t testing.TB, store beads.Storage, id string, want beads.Status) {
+	t.Helper()
+	issue, err := store.GetIssue(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetIssue(%s): %v", id, err)
+	}
+	if issue.Status != want {
+		t.Errorf("%s: status = %q, want %q", id, issue.Status, want)
+	}
+}
+
+// AssertLabels fails the test unless issue id carries exactly the given
+// labels (order-insensitive).
+func AssertLabels(t testing.TB, store beads.Storage, id string, want ...string) {
+	t.Helper()
+	got, err := store.GetLabels(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetLabels(%s): %v", id, err)
+	}
+	got = slices.Clone(got)
+	want = slices.Clone(want)
+	sort.Strings(got)
+	sort.Strings(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("%s: labels = %v, want %v", id, got, want)
+	}
+}
+
+// AssertDependsOn fails the test unless issue id depends on dependsOnID.
+func AssertDependsOn(t testing.TB, store beads.Storage, id, dependsOnID string) {
+	t.Helper()
+	deps, err := store.GetDependencies(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetDependencies(%s): %v", id, err)
+	}
+	for _, d := range deps {
+		if d.ID == dependsOnID {
+			return
+		}
+	}
+	t.Errorf("%s: expected dependency on %s", id, dependsOnID)
+}
+
+// AssertReady fails the test unless issue id appears in ready work.
+func AssertReady(t testing.TB, store beads.Storage, id string) {
+	t.Helper()
+	if !isReady(t, store, id) {
+		t.Errorf("%s: expected issue to be ready", id)
+	}
+}
+
+// AssertNotReady fails the test if issue id appears in ready work.
+func AssertNotReady(t testing.TB, store beads.Storage, id string) {
+	t.Helper()
+	if isReady(t, store, id) {
+		t.Errorf("%s: expected issue not to be ready", id)
+	}
+}
+
+func isReady(t testing.TB, store beads.Storage, id string) bool {
+	t.Helper()
+	ready, err := store.GetReadyWork(context.Background(), beads.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork: %v", err)
+	}
+	for _, issue := range ready {
+		if issue.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/steveyegge/beads"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Fixture is a declarative set of issues to seed into a test store.
+//
+//	issues:
+//	  - key: epic
+//	    title: Ship the thing
+//	    type: epic
+//	  - key: api
+//	    title: Build the API
+//	    parent: epic
+//	    labels: [backend]
+//	  - key: ui
+//	    title: Build the UI
+//	    depends_on: [api]
+type Fixture struct {
+	Issues []FixtureIssue `yaml:"issues"`
+}
+
+// FixtureIssue describes one seeded issue. Key is a fixture-local name used
+// by depends_on, parent, and Store.ID; the real issue ID is assigned by the
+// store. Unset fields take the same defaults as bd create.
+type FixtureIssue struct {
+	Key         string   `yaml:"key"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description,omitempty"`
+	Type        string   `yaml:"type,omitempty"`
+	Priority    *int     `yaml:"priority,omitempty"`
+	Status      string   `yaml:"status,omitempty"`
+	Assignee    string   `yaml:"assignee,omitempty"`
+	Labels      []string `yaml:"labels,omitempty"`
+	DependsOn   []string `yaml:"depends_on,omitempty"`
+	Parent      string   `yaml:"parent,omitempty"`
+}
+
+// LoadFixture reads and parses a YAML fixture file.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - caller-supplied test fixture
+	if err != nil {
+		return nil, fmt.Errorf("reading fixture: %w", err)
+	}
+	fx, err := ParseFixture(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return fx, nil
+}
+
+// ParseFixture parses and validates YAML fixture data. Keys must be unique
+// and every depends_on or parent reference must name a key in the fixture.
+func ParseFixture(data []byte) (*Fixture, error) {
+	var fx Fixture
+	if err := yaml.Unmarshal(data, &fx); err != nil {
+		return nil, fmt.Errorf("parsing fixture: %w", err)
+	}
+	keys := make(map[string]bool, len(fx.Issues))
+	for i, fi := range fx.Issues {
+		if fi.Key == "" {
+			return nil, fmt.Errorf("fixture issue %d: key is required", i)
+		}
+		if fi.Title == "" {
+			return nil, fmt.Errorf("fixture issue %q: title is required", fi.Key)
+		}
+		if keys[fi.Key] {
+			return nil, fmt.Errorf("fixture issue %q: duplicate key", fi.Key)
+		}
+		keys[fi.Key] = true
+	}
+	for _, fi := range fx.Issues {
+		refs := fi.DependsOn
+		if fi.Parent != "" {
+			refs = append(append([]string(nil), refs...), fi.Parent)
+		}
+		for _, ref := range refs {
+			if !keys[ref] {
+				return nil, fmt.Errorf("fixture issue %q: unknown reference %q", fi.Key, ref)
+			}
+		}
+	}
+	return &fx, nil
+}
+
+// Seed creates the fixture's issues in order, then wires up labels,
+// dependencies, and parent links, and finally closes issues whose status is
+// closed (so blocked/ready state reflects the whole graph). It returns a map
+// from fixture key to assigned issue ID.
+func Seed(ctx context.Context, store beads.Storage, fx *Fixture) (map[string]string, error) {
+	keys := make(map[string]string, len(fx.Issues))
+	for _, fi := range fx.Issues {
+		issue := &types.Issue{
+			Title:       fi.Title,
+			Description: fi.Description,
+			IssueType:   types.TypeTask,
+			Priority:    2,
+			Status:      types.StatusOpen,
+			Assignee:    fi.Assignee,
+		}
+		if fi.Type != "" {
+			issue.IssueType = types.IssueType(fi.Type)
+		}
+		if fi.Priority != nil {
+			issue.Priority = *fi.Priority
+		}
+		if fi.Status != "" && types.Status(fi.Status) != types.StatusClosed {
+			issue.Status = types.Status(fi.Status)
+		}
+		if err := store.CreateIssue(ctx, issue, DefaultActor); err != nil {
+			return nil, fmt.Errorf("seeding %q: %w", fi.Key, err)
+		}
+		keys[fi.Key] = issue.ID
+	}
+
+	for _, fi := range fx.Issues {
+		id := keys[fi.Key]
+		for _, label := range fi.Labels {
+			if err := store.AddLabel(ctx, id, label, DefaultActor); err != nil {
+				return nil, fmt.Errorf("labeling %q: %w", fi.Key, err)
+			}
+		}
+		if fi.Parent != "" {
+			dep := &types.Dependency{IssueID: id, DependsOnID: keys[fi.Parent], Type: types.DepParentChild}
+			if err := store.AddDependency(ctx, dep, DefaultActor); err != nil {
+				return nil, fmt.Errorf("linking %q to parent %q: %w", fi.Key, fi.Parent, err)
+			}
+		}
+		for _, ref := range fi.DependsOn {
+			dep := &types.Dependency{IssueID: id, DependsOnID: keys[ref], Type: types.DepBlocks}
+			if err := store.AddDependency(ctx, dep, DefaultActor); err != nil {
+				return nil, fmt.Errorf("adding dependency %q -> %q: %w", fi.Key, ref, err)
+			}
+		}
+	}
+
+	for _, fi := range fx.Issues {
+		if types.Status(fi.Status) != types.StatusClosed {
+			continue
+		}
+		if err := store.CloseIssue(ctx, keys[fi.Key], "fixture", DefaultActor, ""); err != nil {
+			return nil, fmt.Errorf("closing %q: %w", fi.Key, err)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,29 @@
+//go:build cgo
+
+package testsupport
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+)
+
+// openEmbedded opens an embedded Dolt store in a per-test temp directory.
+func openEmbedded(t testing.TB, ctx context.Context, opts Options) (storage.Storage, storage.VersionControl) {
+	t.Helper()
+	var storeOpts []embeddeddolt.Option
+	if opts.Clock != nil {
+		storeOpts = append(storeOpts, embeddeddolt.WithClock(opts.Clock))
+	}
+	if opts.IDGenerator != nil {
+		storeOpts = append(storeOpts, embeddeddolt.WithIDGenerator(opts.IDGenerator))
+	}
+	s, err := embeddeddolt.Open(ctx, filepath.Join(t.TempDir(), ".beads"), opts.Prefix, "main", storeOpts...)
+	if err != nil {
+		t.Fatalf("testsupport: open embedded store: %v", err)
+	}
+	return s, s
+}
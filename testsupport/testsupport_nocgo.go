@@ -0,0 +1,17 @@
+//go:build !cgo
+
+package testsupport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// openEmbedded skips the test: embedded Dolt requires CGO.
+func openEmbedded(t testing.TB, _ context.Context, _ Options) (storage.Storage, storage.VersionControl) {
+	t.Helper()
+	t.Skip("testsupport: embedded backend requires CGO; use BackendServer")
+	return nil, nil
+}
@@ -0,0 +1,121 @@
+package testsupport
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads"
+)
+
+func TestParseFixture(t *testing.T) {
+	fx, err := LoadFixture("testdata/basic.yaml")
+	if err != nil {
+		t.Fatalf("LoadFixture: %v", err)
+	}
+	if len(fx.Issues) != 4 {
+		t.Fatalf("got %d issues, want 4", len(fx.Issues))
+	}
+	if ui := fx.Issues[2]; ui.Parent != "epic" || len(ui.DependsOn) != 1 || ui.DependsOn[0] != "api" {
+		t.Errorf("ui = %+v", ui)
+	}
+	if p := fx.Issues[0].Priority; p == nil || *p != 1 {
+		t.Errorf("epic priority = %v, want 1", p)
+	}
+}
+
+func TestParseFixtureErrors(t *testing.T) {
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"missing key", "issues:\n  - title: x\n", "key is required"},
+		{"missing title", "issues:\n  - key: a\n", "title is required"},
+		{"duplicate key", "issues:\n  - {key: a, title: x}\n  - {key: a, title: y}\n", "duplicate key"},
+		{"unknown dep", "issues:\n  - {key: a, title: x, depends_on: [b]}\n", `unknown reference "b"`},
+		{"unknown parent", "issues:\n  - {key: a, title: x, parent: b}\n", `unknown reference "b"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFixture([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStoreSeedsFixture(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt tests")
+	}
+	store := NewStore(t, Options{Prefix: "fx", Fixture: "testdata/basic.yaml"})
+
+	api, ui, spike := store.ID(t, "api"), store.ID(t, "ui"), store.ID(t, "spike")
+	if !strings.HasPrefix(api, "fx-") {
+		t.Errorf("api ID = %q, want fx- prefix", api)
+	}
+	AssertStatus(t, store, api, beads.StatusOpen)
+	AssertStatus(t, store, spike, beads.StatusClosed)
+	AssertLabels(t, store, ui, "ux", "frontend")
+	AssertDependsOn(t, store, ui, api)
+	AssertDependsOn(t, store, ui, store.ID(t, "epic"))
+	AssertReady(t, store, api)
+	AssertNotReady(t, store, ui)
+	AssertNotReady(t, store, spike)
+}
+
+func TestNewStoreDeterministicClockAndIDs(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt tests")
+	}
+	start := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := beads.NewFakeClock(start)
+	store := NewStore(t, Options{
+		Prefix:      "det",
+		Fixture:     "testdata/basic.yaml",
+		Clock:       clock,
+		IDGenerator: beads.NewSequentialIDGenerator(),
+	})
+	ctx := t.Context()
+
+	if got := store.ID(t, "epic"); got != "det-1" {
+		t.Errorf("epic ID = %q, want det-1", got)
+	}
+	api, err := store.GetIssue(ctx, store.ID(t, "api"))
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if !api.CreatedAt.Equal(start) {
+		t.Errorf("CreatedAt = %v, want %v", api.CreatedAt, start)
+	}
+
+	// Deferral is judged against the injected clock.
+	deferUntil := start.Add(24 * time.Hour)
+	if err := store.UpdateIssue(ctx, api.ID, map[string]interface{}{"defer_until": deferUntil}, DefaultActor); err != nil {
+		t.Fatalf("UpdateIssue(defer_until): %v", err)
+	}
+	AssertNotReady(t, store, api.ID)
+	clock.Advance(25 * time.Hour)
+	AssertReady(t, store, api.ID)
+
+	// So is overdue.
+	dueAt := start.Add(48 * time.Hour)
+	if err := store.UpdateIssue(ctx, api.ID, map[string]interface{}{"due_at": dueAt}, DefaultActor); err != nil {
+		t.Fatalf("UpdateIssue(due_at): %v", err)
+	}
+	overdue := func() bool {
+		issues, err := store.SearchIssues(ctx, "", beads.IssueFilter{Overdue: true})
+		if err != nil {
+			t.Fatalf("SearchIssues(overdue): %v", err)
+		}
+		return len(issues) == 1 && issues[0].ID == api.ID
+	}
+	if overdue() {
+		t.Errorf("issue overdue before due_at")
+	}
+	clock.Advance(24 * time.Hour)
+	if !overdue() {
+		t.Errorf("issue not overdue after due_at")
+	}
+}